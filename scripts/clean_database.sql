@@ -35,16 +35,56 @@ END $$;
 -- Tables are truncated in reverse dependency order for clarity
 
 -- Step 1: Delete dependent data first (child tables)
+TRUNCATE TABLE invoices CASCADE;
+
+TRUNCATE TABLE invoice_sequences CASCADE;
+
+TRUNCATE TABLE purchase_queue_entries CASCADE;
+
+TRUNCATE TABLE archived_orders CASCADE;
+
+TRUNCATE TABLE customer_notes CASCADE;
+
+TRUNCATE TABLE customer_risk_events CASCADE;
+
+TRUNCATE TABLE stock_movements CASCADE;
+
+TRUNCATE TABLE admin_alerts CASCADE;
+
+TRUNCATE TABLE order_remediations CASCADE;
+
+TRUNCATE TABLE email_templates CASCADE;
+
+TRUNCATE TABLE catalog_reports CASCADE;
+
+TRUNCATE TABLE recall_notices CASCADE;
+
+TRUNCATE TABLE recalls CASCADE;
+
+TRUNCATE TABLE search_ranking_rules CASCADE;
+
 TRUNCATE TABLE webhook_logs CASCADE;
 
+TRUNCATE TABLE order_item_components CASCADE;
+
 TRUNCATE TABLE order_items CASCADE;
 
 TRUNCATE TABLE orders CASCADE;
 
+TRUNCATE TABLE product_attributes CASCADE;
+
+TRUNCATE TABLE attribute_definitions CASCADE;
+
 TRUNCATE TABLE product_categories CASCADE;
 
+TRUNCATE TABLE variant_options CASCADE;
+
 TRUNCATE TABLE product_variants CASCADE;
 
+TRUNCATE TABLE product_option_values CASCADE;
+
+TRUNCATE TABLE product_options CASCADE;
+
 -- Step 2: Delete main entity tables
 TRUNCATE TABLE products CASCADE;
 
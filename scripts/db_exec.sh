@@ -0,0 +1,15 @@
+#!/bin/bash
+# Runs one SQL statement against the development database, used by the
+# integration test scripts to promote their users to admin.
+#
+# Usage: scripts/db_exec.sh "UPDATE users SET role = 'admin' WHERE email = 'a@example.com';"
+#
+# Targets the ecommerce_postgres container by default. With DB_DRIVER=sqlite it
+# runs on the SQLite file the API uses (DB_SQLITE_PATH, default ecommerce.db)
+# through the sqlite3 command line tool.
+
+if [ "$DB_DRIVER" = "sqlite" ]; then
+  sqlite3 "${DB_SQLITE_PATH:-ecommerce.db}" "$1"
+else
+  docker exec ecommerce_postgres psql -U postgres -d ecommerce -c "$1"
+fi
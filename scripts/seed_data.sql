@@ -95,42 +95,117 @@ VALUES (
     ) ON CONFLICT (id) DO NOTHING;
 
 -- ============================================
--- 4. PRODUCT VARIANTS (3 records)
+-- 4. PRODUCT OPTIONS AND VARIANTS (3 variants)
 -- ============================================
+INSERT INTO
+    product_options (
+        id,
+        product_id,
+        name,
+        position
+    )
+VALUES (
+        'c0c0c0c0-0000-0000-0000-000000000001',
+        'd4444444-4444-4444-4444-444444444444',
+        'Color',
+        0
+    ),
+    (
+        'c0c0c0c0-0000-0000-0000-000000000002',
+        'e5555555-5555-5555-5555-555555555555',
+        'Color',
+        0
+    ) ON CONFLICT (id) DO NOTHING;
+
+INSERT INTO
+    product_option_values (id, option_id, value, position)
+VALUES (
+        'c1c1c1c1-0000-0000-0000-000000000001',
+        'c0c0c0c0-0000-0000-0000-000000000001',
+        'Space Black',
+        0
+    ),
+    (
+        'c1c1c1c1-0000-0000-0000-000000000002',
+        'c0c0c0c0-0000-0000-0000-000000000001',
+        'Silver',
+        1
+    ),
+    (
+        'c1c1c1c1-0000-0000-0000-000000000003',
+        'c0c0c0c0-0000-0000-0000-000000000002',
+        'Graphite',
+        0
+    ) ON CONFLICT (id) DO NOTHING;
+
 INSERT INTO
     product_variants (
         id,
         product_id,
-        variant_name,
-        variant_value,
+        sku,
+        combination_key,
         quantity,
         price_override
     )
 VALUES (
         '77777777-7777-7777-7777-777777777777',
         'd4444444-4444-4444-4444-444444444444',
-        'Color',
-        'Space Black',
+        'D4444444-SPACE-BLACK',
+        'color=space black',
         15,
         NULL
     ),
     (
         '88888888-8888-8888-8888-888888888888',
         'd4444444-4444-4444-4444-444444444444',
-        'Color',
-        'Silver',
+        'D4444444-SILVER',
+        'color=silver',
         10,
         NULL
     ),
     (
         '99999999-9999-9999-9999-999999999999',
         'e5555555-5555-5555-5555-555555555555',
-        'Color',
-        'Graphite',
+        'E5555555-GRAPHITE',
+        'color=graphite',
         80,
         NULL
     ) ON CONFLICT (id) DO NOTHING;
 
+INSERT INTO
+    variant_options (
+        id,
+        variant_id,
+        option_id,
+        name,
+        value,
+        position
+    )
+VALUES (
+        'c2c2c2c2-0000-0000-0000-000000000001',
+        '77777777-7777-7777-7777-777777777777',
+        'c0c0c0c0-0000-0000-0000-000000000001',
+        'Color',
+        'Space Black',
+        0
+    ),
+    (
+        'c2c2c2c2-0000-0000-0000-000000000002',
+        '88888888-8888-8888-8888-888888888888',
+        'c0c0c0c0-0000-0000-0000-000000000001',
+        'Color',
+        'Silver',
+        0
+    ),
+    (
+        'c2c2c2c2-0000-0000-0000-000000000003',
+        '99999999-9999-9999-9999-999999999999',
+        'c0c0c0c0-0000-0000-0000-000000000002',
+        'Color',
+        'Graphite',
+        0
+    ) ON CONFLICT (id) DO NOTHING;
+
 -- ============================================
 -- 5. PRODUCT_CATEGORIES (Junction - 6 records)
 -- ============================================
@@ -312,7 +387,7 @@ BEGIN
   SELECT COUNT(*) INTO user_count FROM users WHERE email LIKE '%@ecommerce.com' OR email LIKE '%@example.com';
   SELECT COUNT(*) INTO category_count FROM categories WHERE name IN ('Electronics', 'Computers', 'Gaming');
   SELECT COUNT(*) INTO product_count FROM products WHERE name LIKE '%MacBook%' OR name LIKE '%Logitech%' OR name LIKE '%PlayStation%';
-  SELECT COUNT(*) INTO variant_count FROM product_variants WHERE variant_name = 'Color';
+  SELECT COUNT(*) INTO variant_count FROM variant_options WHERE name = 'Color';
   SELECT COUNT(*) INTO pc_count FROM product_categories;
   SELECT COUNT(*) INTO order_count FROM orders;
   SELECT COUNT(*) INTO item_count FROM order_items;
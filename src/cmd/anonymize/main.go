@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"flag"
+	"log"
+	"path/filepath"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	anonymizeUseCase "github.com/marcofilho/go-ecommerce/src/usecase/anonymize"
+	invoiceUseCase "github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+)
+
+// Scrubs the personal data of a copy of the production database, so staging
+// can run on production-like data. It rewrites the database it connects to in
+// place, so it asks for the name of that database to be confirmed.
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	confirm := flag.String("confirm", "", "Name of the database to anonymize, the database name or SQLite file name, to confirm it is a copy")
+	key := flag.String("key", "", "Key the pseudonyms are derived from, the same key gives the same pseudonyms; a random one when empty")
+	password := flag.String("password", "staging1234", "Password every account gets")
+	batchSize := flag.Int("batch-size", cfg.Archive.BatchSize, "Rows rewritten per transaction")
+	flag.Parse()
+
+	name := cfg.Database.DBName
+	if cfg.Database.Driver == config.DriverSQLite {
+		name = filepath.Base(cfg.Database.SQLitePath)
+	}
+	if *confirm != name {
+		log.Fatalf("Refusing to anonymize: this rewrites the database %q in place, run with -confirm=%s if it is a copy", name, name)
+	}
+
+	secret := []byte(*key)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal("Failed to generate a key: ", err)
+		}
+	}
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	invoices := invoiceUseCase.NewUseCase(
+		infraRepo.NewInvoiceRepository(db),
+		infraRepo.NewReadThroughOrderRepository(infraRepo.NewOrderRepositoryPostgres(db), infraRepo.NewOrderArchiveRepository(db)),
+		infraRepo.NewProductRepositoryPostgres(db),
+		infraRepo.NewUserRepository(db),
+		invoice.NewPDFRenderer(cfg.Invoice.StoreName),
+	)
+	uc := anonymizeUseCase.NewUseCase(infraRepo.NewAnonymizationRepository(db), invoices, *batchSize)
+
+	log.Printf("Anonymizing database %s...", name)
+
+	summary, err := uc.Anonymize(context.Background(), anonymizeUseCase.Options{Key: secret, Password: *password})
+	if err != nil {
+		log.Fatal("Anonymizing stopped: ", err)
+	}
+
+	log.Printf("Anonymized %d users, %d customers with %d addresses, %d blocklist entries and %d invoices, redacted %d notes and audit logs",
+		summary.Users, summary.Customers, summary.Addresses, summary.BlocklistEntries, summary.Invoices, summary.Redacted)
+	for _, user := range summary.Staff {
+		log.Printf("Staff account: %s (%s)", user.Email, user.Role)
+	}
+	log.Printf("Every account signs in with password %q", *password)
+}
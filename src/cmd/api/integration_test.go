@@ -0,0 +1,193 @@
+//go:build integration
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/app"
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// The integration tests run the whole API, middleware included, on a real
+// database migrated to the latest version. They take the database settings
+// of the API from the environment: `make test-integration` starts a
+// throwaway Postgres container for them, and DB_DRIVER=sqlite runs them on a
+// temporary SQLite file instead. The database must start out empty.
+
+const integrationWebhookSecret = "integration-webhook-secret"
+
+type integrationServer struct {
+	url string
+	db  *gorm.DB
+}
+
+func newIntegrationServer(t *testing.T) *integrationServer {
+	t.Helper()
+	t.Setenv("JWT_SECRET", "integration-jwt-secret-of-at-least-32-bytes")
+	t.Setenv("WEBHOOK_SECRET", integrationWebhookSecret)
+	t.Setenv("JOBS_ENABLED", "false")
+	if os.Getenv("DB_DRIVER") == config.DriverSQLite {
+		t.Setenv("DB_SQLITE_PATH", filepath.Join(t.TempDir(), "integration.db"))
+	}
+
+	cfg, err := config.Load()
+	require.NoError(t, err)
+	db, err := database.Connect(&cfg.Database)
+	require.NoError(t, err)
+	migrator, err := database.NewMigrator(db)
+	require.NoError(t, err)
+	_, err = migrator.Up(context.Background())
+	require.NoError(t, err)
+
+	server := httptest.NewServer(newHandler(app.NewContainer(db, cfg), cfg))
+	t.Cleanup(server.Close)
+	return &integrationServer{url: server.URL, db: db}
+}
+
+// call sends body as JSON with the token, checks the response has the status
+// and decodes its data into out when given
+func (s *integrationServer) call(t *testing.T, method, path, token string, body, out interface{}, status int) {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	s.send(t, method, path, payload, map[string]string{"Authorization": "Bearer " + token}, out, status)
+}
+
+func (s *integrationServer) send(t *testing.T, method, path string, payload []byte, headers map[string]string, out interface{}, status int) {
+	t.Helper()
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	var body dto.Response[json.RawMessage]
+	raw, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.Equal(t, status, res.StatusCode, "%s %s: %s", method, path, raw)
+	if out != nil {
+		require.NoError(t, json.Unmarshal(raw, &body))
+		require.NoError(t, json.Unmarshal(body.Data, out))
+	}
+}
+
+// signIn registers an account and returns its token. Admins are promoted in
+// the database, as the first admin of a deployment is.
+func (s *integrationServer) signIn(t *testing.T, email string, role entity.Role) string {
+	t.Helper()
+	s.call(t, http.MethodPost, "/api/auth/register", "", dto.RegisterRequest{Email: email, Password: "secret123", Name: "Integration"}, nil, http.StatusCreated)
+	if role != entity.RoleCustomer {
+		require.NoError(t, s.db.Model(&entity.User{}).Where("email = ?", email).Update("role", role).Error)
+	}
+
+	var auth dto.AuthResponse
+	s.call(t, http.MethodPost, "/api/auth/login", "", dto.LoginRequest{Email: email, Password: "secret123"}, &auth, http.StatusOK)
+	require.Equal(t, string(role), auth.Role)
+	return auth.Token
+}
+
+// payOrder sends a signed payment webhook for the order
+func (s *integrationServer) payOrder(t *testing.T, orderID, transactionID string, status int) {
+	t.Helper()
+	payload, err := json.Marshal(entity.PaymentWebhookRequest{
+		OrderID: orderID, TransactionID: transactionID, PaymentStatus: entity.Paid,
+	})
+	require.NoError(t, err)
+	timestamp, nonce := strconv.FormatInt(time.Now().Unix(), 10), uuid.NewString()
+	mac := hmac.New(sha256.New, []byte(integrationWebhookSecret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(payload)
+
+	headers := map[string]string{
+		"X-Payment-Signature": hex.EncodeToString(mac.Sum(nil)),
+		"X-Payment-Timestamp": timestamp,
+		"X-Payment-Nonce":     nonce,
+	}
+	s.send(t, http.MethodPost, "/api/payment-webhook", payload, headers, nil, status)
+}
+
+func TestIntegration_Checkout(t *testing.T) {
+	s := newIntegrationServer(t)
+	admin := s.signIn(t, "admin@integration.test", entity.RoleAdmin)
+	customer := s.signIn(t, "customer@integration.test", entity.RoleCustomer)
+
+	// Only admins manage the catalog
+	laptop := dto.ProductRequest{Name: "Laptop", Description: "14 inch", Price: 1000, Quantity: 5}
+	s.call(t, http.MethodPost, "/api/products", customer, laptop, nil, http.StatusForbidden)
+
+	var product dto.ProductResponse
+	s.call(t, http.MethodPost, "/api/products", admin, laptop, &product, http.StatusCreated)
+	laptop.Price = 900
+	s.call(t, http.MethodPut, "/api/products/"+product.ID, admin, laptop, &product, http.StatusOK)
+	assert.Equal(t, 900.0, product.Price)
+
+	// The preview quotes what checkout charges, without taking stock
+	var totals dto.TotalsResponse
+	preview := dto.CheckoutPreviewRequest{Products: []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}}}
+	s.call(t, http.MethodPost, "/api/checkout/preview", customer, preview, &totals, http.StatusOK)
+	assert.Equal(t, 1800.0, totals.Total)
+
+	// Checkout takes the stock and prices the lines from the catalog
+	var order dto.OrderResponse
+	request := dto.CreateOrderRequest{CustomerID: 1, Products: []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 2}}}
+	s.call(t, http.MethodPost, "/api/orders", customer, request, &order, http.StatusCreated)
+	assert.Equal(t, "pending", order.Status)
+	assert.Equal(t, totals.Total, order.TotalPrice)
+
+	s.call(t, http.MethodGet, "/api/products/"+product.ID, "", nil, &product, http.StatusOK)
+	assert.Equal(t, 3, product.Quantity)
+
+	request.Products[0].Quantity = 4
+	s.call(t, http.MethodPost, "/api/orders", customer, request, nil, http.StatusConflict)
+
+	// The payment webhook settles the order once, however often it is delivered
+	s.payOrder(t, order.ID, "txn_integration_1", http.StatusOK)
+	s.payOrder(t, order.ID, "txn_integration_1", http.StatusOK)
+
+	s.call(t, http.MethodGet, "/api/orders/"+order.ID, customer, nil, &order, http.StatusOK)
+	assert.Equal(t, "paid", order.PaymentStatus)
+	assert.Equal(t, 1800.0, order.AmountPaid)
+
+	var events []dto.PaymentEventResponse
+	s.call(t, http.MethodGet, fmt.Sprintf("/api/orders/%s/payment-history", order.ID), customer, nil, &events, http.StatusOK)
+	assert.Len(t, events, 1)
+
+	// Ordered products can't be deleted, archived ones are gone from the
+	// catalog but not from their orders
+	s.call(t, http.MethodDelete, "/api/products/"+product.ID, admin, nil, nil, http.StatusConflict)
+	s.call(t, http.MethodPost, "/api/products/"+product.ID+"/archive", admin, nil, nil, http.StatusOK)
+	s.call(t, http.MethodGet, "/api/products/"+product.ID, "", nil, nil, http.StatusNotFound)
+	s.call(t, http.MethodGet, "/api/orders/"+order.ID, customer, nil, &order, http.StatusOK)
+	assert.Len(t, order.Products, 1)
+}
@@ -0,0 +1,80 @@
+//go:build loadtest
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/app"
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/marcofilho/go-ecommerce/src/internal/loadtest"
+	"github.com/stretchr/testify/require"
+)
+
+// The load tests run the scenarios of the loadtest package against the whole
+// API, middleware included, on the in-memory repositories. Without a database
+// the latency is that of the handlers and use cases, so the budgets are tight
+// and a regression there fails the run. `make test-load` runs them.
+
+const (
+	loadRequests    = 2000
+	loadConcurrency = 16
+)
+
+var loadBudgets = map[string]time.Duration{
+	"browse":         25 * time.Millisecond,
+	"search":         50 * time.Millisecond,
+	"checkout-burst": 50 * time.Millisecond,
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("JWT_SECRET", "loadtest-jwt-secret-of-at-least-32-bytes")
+	t.Setenv("WEBHOOK_SECRET", "loadtest-webhook-secret")
+	t.Setenv("JOBS_ENABLED", "false")
+	cfg, err := config.Load()
+	require.NoError(t, err)
+
+	container := app.NewMemoryContainer(memory.NewStore(), cfg)
+	server := httptest.NewServer(newHandler(container, cfg))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := server.Client()
+	client.Transport.(*http.Transport).MaxIdleConnsPerHost = loadConcurrency
+	adminToken := loadAdmin(t, ctx, client, server.URL, container)
+
+	fixture, err := loadtest.Prepare(ctx, client, server.URL, adminToken, 100, loadConcurrency)
+	require.NoError(t, err)
+
+	scenarios := []loadtest.Scenario{
+		loadtest.Browse(fixture, loadRequests, loadConcurrency, loadBudgets["browse"]),
+		loadtest.Search(fixture, loadRequests, loadConcurrency, loadBudgets["search"]),
+		loadtest.CheckoutBurst(fixture, loadRequests, loadConcurrency, loadBudgets["checkout-burst"]),
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			result := loadtest.Run(ctx, client, scenario)
+			t.Log(result)
+			require.NoError(t, result.Err())
+		})
+	}
+}
+
+// loadAdmin creates an admin account in the store, as the first admin of a
+// deployment is, and returns its token
+func loadAdmin(t *testing.T, ctx context.Context, client *http.Client, url string, container *app.Container) string {
+	t.Helper()
+	admin := &entity.User{Email: "admin@loadtest.example.com", Name: "Load Test Admin", Role: entity.RoleAdmin}
+	require.NoError(t, admin.SetPassword("loadtest-admin"))
+	require.NoError(t, container.UserRepo.Create(ctx, admin))
+
+	token, err := loadtest.Login(ctx, client, url, admin.Email, "loadtest-admin")
+	require.NoError(t, err)
+	return token
+}
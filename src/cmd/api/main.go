@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"time"
+	// Reports take IANA time zones, which the Alpine image has no database of
+	_ "time/tzdata"
 
-	_ "github.com/marcofilho/go-ecommerce/docs"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/app"
 	"github.com/marcofilho/go-ecommerce/src/internal/config"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
 )
@@ -21,7 +26,7 @@ import (
 // @license.url https://opensource.org/licenses/MIT
 
 // @host localhost:8080
-// @BasePath /api
+// @BasePath /api/v1
 // @schemes http
 
 // @securityDefinitions.apikey BearerAuth
@@ -30,24 +35,45 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
-	cfg := config.Load()
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	db, err := database.Connect(&cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	if err := database.Migrate(db); err != nil {
-		log.Fatal("Failed to run migrations:", err)
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
 	}
 
-	container := NewContainer(db, cfg)
+	container := app.NewContainer(db, cfg)
+	app.WatchSecrets(context.Background(), container)
 
-	mux := SetupRoutes(container)
+	// Background jobs can run here or in cmd/worker
+	if cfg.Jobs.Enabled {
+		if err := app.RegisterJobs(container); err != nil {
+			log.Fatal("Failed to schedule background jobs:", err)
+		}
+		container.Scheduler.Start(context.Background())
+		log.Printf("Background jobs started with %d workers", cfg.Jobs.Workers)
+	}
 
 	serverAddr := ":" + cfg.Server.Port
 	log.Printf("Server starting on %s", serverAddr)
-	if err := http.ListenAndServe(serverAddr, mux); err != nil {
+	if err := http.ListenAndServe(serverAddr, newHandler(container, cfg)); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// newHandler wraps the routes in the middleware every request goes through
+func newHandler(container *app.Container, cfg *config.Config) http.Handler {
+	routes := SetupRoutes(container)
+	return container.RateLimitMiddleware.Limit(
+		middleware.Timeout(time.Duration(cfg.Server.RequestTimeoutSeconds) * time.Second)(
+			middleware.LimitBody(int64(cfg.Server.MaxBodyBytes))(middleware.ReplicaReads(routes)),
+		),
+	)
+}
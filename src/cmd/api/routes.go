@@ -2,27 +2,66 @@ package main
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/openapi"
+	"github.com/marcofilho/go-ecommerce/src/internal/app"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
-// SetupRoutes configures all application routes
-func SetupRoutes(c *Container) *http.ServeMux {
+// emailTemplateMaxBodyBytes lets template bodies carry full HTML emails, every
+// other route keeps the server-wide limit
+const emailTemplateMaxBodyBytes = 512 << 10
+
+// inventorySyncMaxBodyBytes fits a bulk stock update of 1000 SKUs
+const inventorySyncMaxBodyBytes = 256 << 10
+
+// Routes that work on many rows at once run longer than the server-wide
+// REQUEST_TIMEOUT_SECONDS allows
+const (
+	bulkUpdateTimeout  = 2 * time.Minute  // Inventory sync and bulk order status updates
+	salesExportTimeout = 10 * time.Minute // A year of order lines, streamed as CSV
+)
+
+// SetupRoutes configures all application routes. The router is wrapped in the
+// maintenance middleware, then the API version middleware, which serves the
+// routes under /api/v1/... too, and then the CORS middleware, which answers
+// preflight requests for every route and sets its headers on maintenance and
+// version responses too.
+func SetupRoutes(c *app.Container) http.Handler {
 	mux := http.NewServeMux()
 
-	// Swagger documentation
-	mux.Handle("/swagger/", httpSwagger.WrapHandler)
+	// API documentation, generated from the handler annotations
+	mux.Handle("GET /api/openapi.json", openapi.Handler())
+	mux.Handle("/swagger/", httpSwagger.Handler(httpSwagger.URL("/api/openapi.json")))
+
+	// Public: Health check for load balancers, answered in maintenance mode too
+	mux.HandleFunc("GET /api/health", c.MaintenanceHandler.Health)
 
 	mux.Handle("POST /api/auth/register", c.AuthMiddleware.OptionalAuth(
 		http.HandlerFunc(c.AuthHandler.Register),
 	))
 	mux.HandleFunc("POST /api/auth/login", c.AuthHandler.Login)
+	mux.Handle("POST /api/auth/logout", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.AuthHandler.Logout),
+	))
+
+	// Rate limit routes
+	// Authenticated users: Inspect their own request budget
+	mux.Handle("GET /api/users/me/quota", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.QuotaHandler.GetMyQuota),
+	))
 
 	// Product routes
-	// Public: Anyone can view products
-	mux.HandleFunc("GET /api/products", c.ProductHandler.ListProducts)
-	mux.HandleFunc("GET /api/products/{id}", c.ProductHandler.GetProduct)
+	// Public: Anyone can view published products, admins see drafts and archived ones too.
+	// Catalog routes take an access code from visitors while the store is in private beta.
+	mux.Handle("GET /api/products", c.AccessCodeMiddleware.Browse(c.AuthMiddleware.OptionalAuth(
+		http.HandlerFunc(c.ProductHandler.ListProducts),
+	)))
+	mux.Handle("GET /api/products/{id}", c.AccessCodeMiddleware.Browse(c.AuthMiddleware.OptionalAuth(
+		http.HandlerFunc(c.ProductHandler.GetProduct),
+	)))
 
 	// Admin only: Create, update, delete products
 	mux.Handle("POST /api/products", c.AuthMiddleware.Authenticate(
@@ -41,9 +80,121 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Admin only: Publish, unpublish and archive products
+	mux.Handle("POST /api/products/{id}/publish", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionPublishProduct)(
+			http.HandlerFunc(c.ProductHandler.PublishProduct),
+		),
+	))
+	mux.Handle("POST /api/products/{id}/unpublish", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionPublishProduct)(
+			http.HandlerFunc(c.ProductHandler.UnpublishProduct),
+		),
+	))
+	mux.Handle("POST /api/products/{id}/archive", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionPublishProduct)(
+			http.HandlerFunc(c.ProductHandler.ArchiveProduct),
+		),
+	))
+
+	// Admin only: Record the unit cost search ranking computes margins from
+	mux.Handle("PUT /api/products/{id}/cost", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.ProductHandler.SetProductCost),
+		),
+	))
+
+	// Admin only: Schedule when a product can be bought
+	mux.Handle("PUT /api/products/{id}/availability", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.ProductHandler.SetProductAvailability),
+		),
+	))
+
+	// Admin only: Cap the units of a product per order and per customer
+	mux.Handle("PUT /api/products/{id}/purchase-limits", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.ProductHandler.SetProductPurchaseLimits),
+		),
+	))
+
+	// Admin only: Toggle high-demand (queue) mode
+	mux.Handle("PUT /api/products/{id}/queue-mode", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.ProductHandler.SetHighDemandMode),
+		),
+	))
+
+	// Purchase queue routes
+	// Authenticated users: Join a high-demand product's queue and poll their position
+	mux.Handle("POST /api/products/{id}/queue", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateOrder)(
+			http.HandlerFunc(c.QueueHandler.JoinQueue),
+		),
+	))
+	mux.Handle("GET /api/products/{id}/queue", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateOrder)(
+			http.HandlerFunc(c.QueueHandler.GetQueueStatus),
+		),
+	))
+
+	// Stock movement routes
+	// Admin only: Stock ledger of a product and its variants
+	mux.Handle("GET /api/products/{id}/stock-movements", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewStockMovements)(
+			http.HandlerFunc(c.StockHandler.ListMovements),
+		),
+	))
+
+	// Review routes
+	// Public: Reviews of published products
+	mux.Handle("GET /api/products/{id}/reviews", c.AccessCodeMiddleware.Browse(c.AuthMiddleware.OptionalAuth(
+		http.HandlerFunc(c.ReviewHandler.ListReviews),
+	)))
+
+	// Protected: Customers who received a product review it
+	mux.Handle("PUT /api/products/{id}/reviews/mine", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.ReviewHandler.ReviewProduct),
+	))
+	mux.Handle("DELETE /api/products/{id}/reviews/mine", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.ReviewHandler.DeleteMyReview),
+	))
+
+	// Price history routes
+	// Admin only: Price history of a product and its variants
+	mux.Handle("GET /api/products/{id}/price-history", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewPriceHistory)(
+			http.HandlerFunc(c.PriceHistoryHandler.ListHistory),
+		),
+	))
+
+	// Admin only: Schedule price changes and cancel them before they start
+	mux.Handle("POST /api/products/{id}/price-schedule", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionSchedulePrices)(
+			http.HandlerFunc(c.PriceHistoryHandler.SchedulePrice),
+		),
+	))
+	mux.Handle("DELETE /api/products/{id}/price-schedule/{change_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionSchedulePrices)(
+			http.HandlerFunc(c.PriceHistoryHandler.CancelScheduledPrice),
+		),
+	))
+
+	// Admin only: Quantity breaks and customer group prices
+	mux.Handle("GET /api/products/{id}/price-list", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManagePriceLists)(
+			http.HandlerFunc(c.PriceListHandler.GetPriceList),
+		),
+	))
+	mux.Handle("PUT /api/products/{id}/price-list", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManagePriceLists)(
+			http.HandlerFunc(c.PriceListHandler.ReplacePriceList),
+		),
+	))
+
 	// Product Variant routes
 	// Public: View product variants for a product
-	mux.HandleFunc("GET /api/products/{id}/variants", c.ProductVariantHandler.ListProductVariants)
+	mux.Handle("GET /api/products/{id}/variants", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.ProductVariantHandler.ListProductVariants)))
 
 	// Admin only: Create product variant for a product
 	mux.Handle("POST /api/products/{id}/variants", c.AuthMiddleware.Authenticate(
@@ -52,6 +203,9 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Public: View a single product variant
+	mux.Handle("GET /api/variants/{variant_id}", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.ProductVariantHandler.GetProductVariant)))
+
 	// Admin only: Update and delete product variants
 	mux.Handle("PUT /api/variants/{variant_id}", c.AuthMiddleware.Authenticate(
 		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
@@ -64,9 +218,97 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Admin only: Move stock between a product's variants, recorded in the stock ledger
+	mux.Handle("POST /api/variants/{variant_id}/transfer", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionTransferStock)(
+			http.HandlerFunc(c.ProductVariantHandler.TransferStock),
+		),
+	))
+
+	// Inventory routes
+	// Admin only: Bulk stock updates by SKU for ERP synchronization
+	mux.Handle("PUT /api/inventory/bulk", middleware.Timeout(bulkUpdateTimeout)(middleware.LimitBody(inventorySyncMaxBodyBytes)(
+		c.AuthMiddleware.Authenticate(
+			c.AuthMiddleware.RequirePermission(middleware.PermissionSyncStock)(
+				http.HandlerFunc(c.InventoryHandler.SyncStock),
+			),
+		),
+	)))
+
+	// Product Option routes
+	// Public: View the options variants are built from
+	mux.Handle("GET /api/products/{id}/options", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.ProductVariantHandler.ListOptions)))
+
+	// Admin only: Manage product options and their values
+	mux.Handle("POST /api/products/{id}/options", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateProduct)(
+			http.HandlerFunc(c.ProductVariantHandler.CreateOption),
+		),
+	))
+	mux.Handle("POST /api/products/{id}/options/{option_id}/values", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.ProductVariantHandler.AddOptionValue),
+		),
+	))
+	mux.Handle("DELETE /api/products/{id}/options/{option_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionDeleteProduct)(
+			http.HandlerFunc(c.ProductVariantHandler.DeleteOption),
+		),
+	))
+
+	// Attribute routes
+	// Public: List attribute definitions
+	mux.Handle("GET /api/attributes", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.AttributeHandler.ListAttributes)))
+
+	// Admin only: Define attributes
+	mux.Handle("POST /api/attributes", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateProduct)(
+			http.HandlerFunc(c.AttributeHandler.CreateAttribute),
+		),
+	))
+
+	// Admin only: Set and remove product attribute values
+	mux.Handle("PUT /api/products/{id}/attributes/{attribute_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.AttributeHandler.SetProductAttribute),
+		),
+	))
+	mux.Handle("DELETE /api/products/{id}/attributes/{attribute_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.AttributeHandler.RemoveProductAttribute),
+		),
+	))
+
+	// Product translation routes
+	// Admin only: Manage the translated names and descriptions of a product
+	mux.Handle("GET /api/products/{id}/translations", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.TranslationHandler.ListTranslations),
+		),
+	))
+	mux.Handle("PUT /api/products/{id}/translations/{locale}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.TranslationHandler.SaveTranslation),
+		),
+	))
+	mux.Handle("DELETE /api/products/{id}/translations/{locale}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.TranslationHandler.DeleteTranslation),
+		),
+	))
+
 	// Category routes
 	// Public: List categories
-	mux.HandleFunc("GET /api/categories", c.CategoryHandler.ListCategories)
+	mux.Handle("GET /api/categories", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CategoryHandler.ListCategories)))
+
+	// Public: Nested category tree for navigation menus
+	mux.Handle("GET /api/categories/tree", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CategoryHandler.GetCategoryTree)))
+
+	// Public: Products of a category by slug, for SEO-friendly category pages
+	mux.Handle("GET /api/categories/{slug}/products", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CategoryHandler.ListCategoryProducts)))
+
+	// Public: Get a category
+	mux.Handle("GET /api/categories/{id}", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CategoryHandler.GetCategory)))
 
 	// Admin only: Create categories
 	mux.Handle("POST /api/categories", c.AuthMiddleware.Authenticate(
@@ -75,9 +317,30 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Admin only: Rename or move categories
+	mux.Handle("PUT /api/categories/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateProduct)(
+			http.HandlerFunc(c.CategoryHandler.UpdateCategory),
+		),
+	))
+
+	// Admin only: Delete categories (force=true unassigns their products first)
+	mux.Handle("DELETE /api/categories/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionDeleteProduct)(
+			http.HandlerFunc(c.CategoryHandler.DeleteCategory),
+		),
+	))
+
+	// Admin only: Merge a category into another, moving its products and subcategories
+	mux.Handle("POST /api/admin/categories/{id}/merge-into/{target_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionDeleteProduct)(
+			http.HandlerFunc(c.CategoryHandler.MergeCategory),
+		),
+	))
+
 	// Product-Category relationship routes
 	// Public: Get product categories
-	mux.HandleFunc("GET /api/products/{id}/categories", c.CategoryHandler.GetProductCategories)
+	mux.Handle("GET /api/products/{id}/categories", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CategoryHandler.GetProductCategories)))
 
 	// Admin only: Assign category to product
 	mux.Handle("POST /api/products/{id}/categories", c.AuthMiddleware.Authenticate(
@@ -93,6 +356,20 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Checkout routes
+	// Authenticated users: Preview the totals checkout would charge for a cart
+	mux.Handle("POST /api/checkout/preview", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateOrder)(
+			http.HandlerFunc(c.CheckoutHandler.PreviewTotals),
+		),
+	))
+	// Authenticated users: Preview where a cart would ship from, without reserving stock
+	mux.Handle("POST /api/checkout/preview-allocation", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCreateOrder)(
+			http.HandlerFunc(c.CheckoutHandler.PreviewAllocation),
+		),
+	))
+
 	// Order routes
 	// Authenticated users: Create and view orders
 	mux.Handle("POST /api/orders", c.AuthMiddleware.Authenticate(
@@ -105,12 +382,24 @@ func SetupRoutes(c *Container) *http.ServeMux {
 			http.HandlerFunc(c.OrderHandler.ListOrders),
 		),
 	))
+	mux.Handle("POST /api/orders/status-batch", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewOrder)(
+			http.HandlerFunc(c.OrderHandler.GetOrderStatuses),
+		),
+	))
 	mux.Handle("GET /api/orders/{id}", c.AuthMiddleware.Authenticate(
 		c.AuthMiddleware.RequirePermission(middleware.PermissionViewOrder)(
 			http.HandlerFunc(c.OrderHandler.GetOrder),
 		),
 	))
 
+	// Authenticated users: Download invoice (owner or admin)
+	mux.Handle("GET /api/orders/{id}/invoice", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewOrder)(
+			http.HandlerFunc(c.InvoiceHandler.GetInvoice),
+		),
+	))
+
 	// Admin only: Update order status
 	mux.Handle("PUT /api/orders/{id}/status", c.AuthMiddleware.Authenticate(
 		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateOrderStatus)(
@@ -118,15 +407,537 @@ func SetupRoutes(c *Container) *http.ServeMux {
 		),
 	))
 
+	// Admin and support: Search orders by customer, date, total, SKU and status
+	mux.Handle("GET /api/admin/orders/search", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAnyOrder)(
+			http.HandlerFunc(c.OrderHandler.SearchOrders),
+		),
+	))
+
+	// Admin only: Move a batch of orders to the same status
+	mux.Handle("PUT /api/admin/orders/status-bulk", middleware.Timeout(bulkUpdateTimeout)(c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionUpdateOrderStatus)(
+			http.HandlerFunc(c.OrderHandler.UpdateOrderStatuses),
+		),
+	)))
+
+	// Draft order routes
+	// Admin only: Compose orders for customers, send payment links and convert them through checkout
+	mux.Handle("POST /api/admin/draft-orders", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.CreateDraftOrder),
+		),
+	))
+	mux.Handle("GET /api/admin/draft-orders", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.ListDraftOrders),
+		),
+	))
+	mux.Handle("GET /api/admin/draft-orders/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.GetDraftOrder),
+		),
+	))
+	mux.Handle("PUT /api/admin/draft-orders/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.UpdateDraftOrder),
+		),
+	))
+	mux.Handle("POST /api/admin/draft-orders/{id}/send", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.SendDraftOrderLink),
+		),
+	))
+	mux.Handle("POST /api/admin/draft-orders/{id}/convert", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.ConvertDraftOrder),
+		),
+	))
+	mux.Handle("POST /api/admin/draft-orders/{id}/cancel", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageDraftOrders)(
+			http.HandlerFunc(c.DraftOrderHandler.CancelDraftOrder),
+		),
+	))
+
+	// Public: View and accept a draft order through its payment link, the token is the credential
+	mux.HandleFunc("GET /api/draft-orders/{token}", c.DraftOrderHandler.GetDraftOrderLink)
+	mux.HandleFunc("POST /api/draft-orders/{token}/accept", c.DraftOrderHandler.AcceptDraftOrderLink)
+
 	// Payment webhook routes
-	mux.HandleFunc("POST /api/payment-webhook", c.PaymentHandler.PaymentWebhookHandler) // Public - external integration
+	mux.HandleFunc("POST /api/payment-webhook", c.PaymentHandler.PaymentWebhookHandler)             // Public - external integration
+	mux.HandleFunc("POST /api/payment-webhook/{provider}", c.PaymentHandler.ProviderWebhookHandler) // Public - Stripe, PayPal and MercadoPago
 
-	// Admin only: View webhook history
+	// Authenticated users: View payment history
+	// Admins see full webhook logs, customers see sanitized events for their own orders
 	mux.Handle("GET /api/orders/{id}/payment-history", c.AuthMiddleware.Authenticate(
-		c.AuthMiddleware.RequirePermission(middleware.PermissionViewWebhookHistory)(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewOrder)(
 			http.HandlerFunc(c.PaymentHandler.GetWebhookHistoryHandler),
 		),
 	))
 
-	return mux
+	// Admin only: Capture an authorized payment through the payment provider
+	mux.Handle("POST /api/orders/{id}/capture", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionCapturePayments)(
+			http.HandlerFunc(c.PaymentHandler.CapturePaymentHandler),
+		),
+	))
+
+	// Admin only: Payment webhooks of every order, replayed or resolved when they fail or get stuck
+	mux.Handle("GET /api/admin/payment-webhooks", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewWebhookHistory)(
+			http.HandlerFunc(c.PaymentHandler.ListWebhooksHandler),
+		),
+	))
+	mux.Handle("POST /api/admin/payment-webhooks/{id}/replay", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionReplayWebhooks)(
+			http.HandlerFunc(c.PaymentHandler.ReplayWebhookHandler),
+		),
+	))
+	mux.Handle("POST /api/admin/payment-webhooks/{id}/resolve", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionReplayWebhooks)(
+			http.HandlerFunc(c.PaymentHandler.ResolveWebhookHandler),
+		),
+	))
+
+	// Admin only: Signed payment webhooks that were rejected, reprocessed once fixed or discarded
+	mux.Handle("GET /api/admin/payment-webhooks/dead-letters", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewWebhookHistory)(
+			http.HandlerFunc(c.PaymentHandler.ListDeadLettersHandler),
+		),
+	))
+	mux.Handle("POST /api/admin/payment-webhooks/dead-letters/{id}/reprocess", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionReplayWebhooks)(
+			http.HandlerFunc(c.PaymentHandler.ReprocessDeadLetterHandler),
+		),
+	))
+	mux.Handle("POST /api/admin/payment-webhooks/dead-letters/{id}/discard", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionReplayWebhooks)(
+			http.HandlerFunc(c.PaymentHandler.DiscardDeadLetterHandler),
+		),
+	))
+
+	// Customer profile routes
+	// Admin only: Internal notes and risk score on customer accounts
+	mux.Handle("GET /api/admin/customers/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageCustomers)(
+			http.HandlerFunc(c.CustomerHandler.GetProfile),
+		),
+	))
+	mux.Handle("POST /api/admin/customers/{id}/notes", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageCustomers)(
+			http.HandlerFunc(c.CustomerHandler.AddNote),
+		),
+	))
+	mux.Handle("POST /api/admin/customers/{id}/risk-events", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageCustomers)(
+			http.HandlerFunc(c.CustomerHandler.RecordRiskEvent),
+		),
+	))
+	mux.Handle("PUT /api/admin/customers/{id}/group", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageCustomers)(
+			http.HandlerFunc(c.CustomerHandler.SetCustomerGroup),
+		),
+	))
+	// Admin and support: Purchase summary of customer accounts
+	mux.Handle("GET /api/admin/customers/{id}/summary", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewCustomerSummary)(
+			http.HandlerFunc(c.CustomerHandler.GetSummary),
+		),
+	))
+	// Authenticated users: Manage their own customer data, verify their phone, export it, or delete their account.
+	// Exporting and deleting are refused to admins impersonating the user.
+	mux.Handle("GET /api/users/me/profile", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.CustomerHandler.GetMyCustomer),
+	))
+	mux.Handle("PUT /api/users/me/profile", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.CustomerHandler.UpdateMyCustomer),
+	))
+	mux.Handle("POST /api/users/me/phone/verification", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.CustomerHandler.SendMyPhoneVerification),
+	))
+	mux.Handle("POST /api/users/me/phone/verify", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.CustomerHandler.VerifyMyPhone),
+	))
+	mux.Handle("GET /api/users/me/export", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RejectImpersonation(http.HandlerFunc(c.CustomerHandler.ExportMyData)),
+	))
+	mux.Handle("DELETE /api/users/me", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RejectImpersonation(http.HandlerFunc(c.CustomerHandler.DeleteMyAccount)),
+	))
+
+	// Account routes
+	// Admin only: Revoke compromised tokens, sign users out everywhere and deactivate accounts
+	mux.Handle("POST /api/admin/tokens/revoke", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageUsers)(
+			http.HandlerFunc(c.AuthHandler.RevokeToken),
+		),
+	))
+	mux.Handle("POST /api/admin/users/{id}/revoke-tokens", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageUsers)(
+			http.HandlerFunc(c.AuthHandler.RevokeUserTokens),
+		),
+	))
+	mux.Handle("PUT /api/admin/users/{id}/status", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageUsers)(
+			http.HandlerFunc(c.AuthHandler.SetUserStatus),
+		),
+	))
+	// Admin only: Act as a customer with a short-lived token, audited as impersonated
+	mux.Handle("POST /api/admin/impersonate/{user_id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionImpersonateUsers)(
+			http.HandlerFunc(c.AuthHandler.Impersonate),
+		),
+	))
+
+	// Order remediation routes
+	// Support and admin: Refunds without return, goodwill credits and resends within the role's budget
+	mux.Handle("POST /api/admin/orders/{id}/remediations", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionRemediateOrders)(
+			http.HandlerFunc(c.RemediationHandler.Remediate),
+		),
+	))
+	mux.Handle("GET /api/admin/orders/{id}/remediations", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionRemediateOrders)(
+			http.HandlerFunc(c.RemediationHandler.ListRemediations),
+		),
+	))
+
+	// Return (RMA) routes
+	// Authenticated users: Request returns of their delivered orders and follow them
+	mux.Handle("POST /api/orders/{id}/returns", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionRequestReturns)(
+			http.HandlerFunc(c.ReturnHandler.RequestReturn),
+		),
+	))
+	mux.Handle("GET /api/users/me/returns", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.ReturnHandler.ListMyReturns),
+	))
+	mux.Handle("GET /api/users/me/returns/{id}", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.ReturnHandler.GetMyReturn),
+	))
+
+	// Admin only: Review returns, receive the items back and refund them
+	mux.Handle("GET /api/admin/returns", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.ListReturns),
+		),
+	))
+	mux.Handle("GET /api/admin/returns/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.GetReturn),
+		),
+	))
+	mux.Handle("POST /api/admin/returns/{id}/approve", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.ApproveReturn),
+		),
+	))
+	mux.Handle("POST /api/admin/returns/{id}/reject", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.RejectReturn),
+		),
+	))
+	mux.Handle("POST /api/admin/returns/{id}/receive", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.ReceiveReturn),
+		),
+	))
+	mux.Handle("POST /api/admin/returns/{id}/refund", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageReturns)(
+			http.HandlerFunc(c.ReturnHandler.RefundReturn),
+		),
+	))
+
+	// Admin activity routes
+	// Admin only: Audit trail of changes and alerts raised by monitoring rules
+	mux.Handle("GET /api/admin/activity", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAdminActivity)(
+			http.HandlerFunc(c.AdminActivityHandler.ListActivity),
+		),
+	))
+	mux.Handle("GET /api/admin/audit-logs", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAdminActivity)(
+			http.HandlerFunc(c.AdminActivityHandler.ListAuditLogs),
+		),
+	))
+	mux.Handle("GET /api/admin/alerts", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAdminActivity)(
+			http.HandlerFunc(c.AdminActivityHandler.ListAlerts),
+		),
+	))
+
+	// Email template routes
+	// Admin only: Edit notification email copy, preview it with sample data and send test emails
+	mux.Handle("GET /api/admin/email-templates", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+			http.HandlerFunc(c.EmailTemplateHandler.ListTemplates),
+		),
+	))
+	mux.Handle("GET /api/admin/email-templates/{key}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+			http.HandlerFunc(c.EmailTemplateHandler.GetTemplate),
+		),
+	))
+	mux.Handle("PUT /api/admin/email-templates/{key}", middleware.LimitBody(emailTemplateMaxBodyBytes)(
+		c.AuthMiddleware.Authenticate(
+			c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+				http.HandlerFunc(c.EmailTemplateHandler.SaveTemplate),
+			),
+		),
+	))
+	mux.Handle("GET /api/admin/email-templates/{key}/versions", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+			http.HandlerFunc(c.EmailTemplateHandler.ListVersions),
+		),
+	))
+	mux.Handle("POST /api/admin/email-templates/{key}/preview", middleware.LimitBody(emailTemplateMaxBodyBytes)(
+		c.AuthMiddleware.Authenticate(
+			c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+				http.HandlerFunc(c.EmailTemplateHandler.PreviewTemplate),
+			),
+		),
+	))
+	mux.Handle("POST /api/admin/email-templates/{key}/test-send", middleware.LimitBody(emailTemplateMaxBodyBytes)(
+		c.AuthMiddleware.Authenticate(
+			c.AuthMiddleware.RequirePermission(middleware.PermissionManageEmailTemplates)(
+				http.HandlerFunc(c.EmailTemplateHandler.TestSendTemplate),
+			),
+		),
+	))
+
+	// Store settings routes
+	// Public: The store name, currency, logo and checkout options front-ends render with
+	mux.HandleFunc("GET /api/settings", c.StoreSettingsHandler.GetSettings)
+	// Admin only: Edit the store settings or reset them to their defaults
+	mux.Handle("GET /api/admin/settings", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSettings)(
+			http.HandlerFunc(c.StoreSettingsHandler.ListSettings),
+		),
+	))
+	mux.Handle("PUT /api/admin/settings", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSettings)(
+			http.HandlerFunc(c.StoreSettingsHandler.UpdateSettings),
+		),
+	))
+
+	// Maintenance routes
+	// Admin only: Answer public traffic with 503 while the store is being worked on
+	mux.Handle("GET /api/admin/maintenance", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageMaintenance)(
+			http.HandlerFunc(c.MaintenanceHandler.GetMaintenance),
+		),
+	))
+	mux.Handle("PUT /api/admin/maintenance", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageMaintenance)(
+			http.HandlerFunc(c.MaintenanceHandler.UpdateMaintenance),
+		),
+	))
+
+	// Catalog report routes
+	// Admin only: Run the catalog health scan on demand and read the latest report
+	mux.Handle("POST /api/admin/catalog-report", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewCatalogReport)(
+			http.HandlerFunc(c.CatalogReportHandler.RunCatalogReport),
+		),
+	))
+	mux.Handle("GET /api/admin/catalog-report", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewCatalogReport)(
+			http.HandlerFunc(c.CatalogReportHandler.GetLatestCatalogReport),
+		),
+	))
+
+	// Recall routes
+	// Admin only: Find the orders affected by a recall, notify their customers and track acknowledgments
+	mux.Handle("POST /api/admin/recalls/affected", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageRecalls)(
+			http.HandlerFunc(c.RecallHandler.FindAffectedOrders),
+		),
+	))
+	mux.Handle("POST /api/admin/recalls", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageRecalls)(
+			http.HandlerFunc(c.RecallHandler.CreateRecall),
+		),
+	))
+	mux.Handle("GET /api/admin/recalls", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageRecalls)(
+			http.HandlerFunc(c.RecallHandler.ListRecalls),
+		),
+	))
+	mux.Handle("GET /api/admin/recalls/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageRecalls)(
+			http.HandlerFunc(c.RecallHandler.GetRecall),
+		),
+	))
+	mux.Handle("POST /api/admin/recalls/{id}/resend", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageRecalls)(
+			http.HandlerFunc(c.RecallHandler.ResendNotices),
+		),
+	))
+	// Authenticated users: Read and acknowledge the recall notices of their own orders
+	mux.Handle("GET /api/users/me/recalls", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.RecallHandler.ListMyRecallNotices),
+	))
+	mux.Handle("POST /api/users/me/recalls/{notice_id}/acknowledge", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.RecallHandler.AcknowledgeRecallNotice),
+	))
+
+	// Loyalty routes
+	// Authenticated users: Points balance and history of their own account
+	mux.Handle("GET /api/users/me/loyalty", c.AuthMiddleware.Authenticate(
+		http.HandlerFunc(c.LoyaltyHandler.GetMyLoyalty),
+	))
+
+	// Outgoing webhook routes
+	// Admin only: Subscribe URLs to store events and inspect or retry their deliveries
+	mux.Handle("POST /api/admin/webhooks", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.CreateWebhook),
+		),
+	))
+	mux.Handle("GET /api/admin/webhooks", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.ListWebhooks),
+		),
+	))
+	mux.Handle("GET /api/admin/webhooks/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.GetWebhook),
+		),
+	))
+	mux.Handle("PUT /api/admin/webhooks/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.UpdateWebhook),
+		),
+	))
+	mux.Handle("DELETE /api/admin/webhooks/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.DeleteWebhook),
+		),
+	))
+	mux.Handle("GET /api/admin/webhooks/{id}/deliveries", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.ListWebhookDeliveries),
+		),
+	))
+	mux.Handle("POST /api/admin/webhooks/{id}/deliveries/{delivery_id}/retry", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageWebhooks)(
+			http.HandlerFunc(c.WebhookHandler.RetryWebhookDelivery),
+		),
+	))
+
+	// Access code routes
+	// Admin only: Hand out the codes people register and browse with during a private beta
+	mux.Handle("POST /api/admin/access-codes", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageAccessCodes)(
+			http.HandlerFunc(c.AccessCodeHandler.CreateAccessCode),
+		),
+	))
+	mux.Handle("GET /api/admin/access-codes", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageAccessCodes)(
+			http.HandlerFunc(c.AccessCodeHandler.ListAccessCodes),
+		),
+	))
+	mux.Handle("PUT /api/admin/access-codes/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageAccessCodes)(
+			http.HandlerFunc(c.AccessCodeHandler.UpdateAccessCode),
+		),
+	))
+	mux.Handle("DELETE /api/admin/access-codes/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageAccessCodes)(
+			http.HandlerFunc(c.AccessCodeHandler.DeleteAccessCode),
+		),
+	))
+
+	// Blocklist routes
+	// Admin only: Block emails, domains and IP ranges from registering, signing in and ordering
+	mux.Handle("POST /api/admin/blocklist", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageBlocklist)(
+			http.HandlerFunc(c.BlocklistHandler.CreateBlocklistEntry),
+		),
+	))
+	mux.Handle("GET /api/admin/blocklist", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageBlocklist)(
+			http.HandlerFunc(c.BlocklistHandler.ListBlocklistEntries),
+		),
+	))
+	mux.Handle("DELETE /api/admin/blocklist/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageBlocklist)(
+			http.HandlerFunc(c.BlocklistHandler.DeleteBlocklistEntry),
+		),
+	))
+
+	// Catalog feed routes
+	// Public: Download the scheduled product feed for marketplaces
+	mux.Handle("GET /api/catalog/feed", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.CatalogFeedHandler.GetCatalogFeed)))
+
+	// Search routes
+	// Public: Search products, ranked by the active rules
+	mux.Handle("GET /api/search", c.AccessCodeMiddleware.Browse(http.HandlerFunc(c.SearchHandler.SearchProducts)))
+	// Admin only: Configure boost and pin rules and see why results ranked as they did
+	mux.Handle("GET /api/admin/search/explain", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSearch)(
+			http.HandlerFunc(c.SearchHandler.ExplainSearch),
+		),
+	))
+	mux.Handle("GET /api/admin/search/rules", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSearch)(
+			http.HandlerFunc(c.SearchHandler.ListRankingRules),
+		),
+	))
+	mux.Handle("POST /api/admin/search/rules", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSearch)(
+			http.HandlerFunc(c.SearchHandler.CreateRankingRule),
+		),
+	))
+	mux.Handle("PUT /api/admin/search/rules/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSearch)(
+			http.HandlerFunc(c.SearchHandler.UpdateRankingRule),
+		),
+	))
+	mux.Handle("DELETE /api/admin/search/rules/{id}", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionManageSearch)(
+			http.HandlerFunc(c.SearchHandler.DeleteRankingRule),
+		),
+	))
+
+	// Analytics routes
+	// Admin only: Sales figures aggregated in the database, without exporting orders
+	mux.Handle("GET /api/admin/analytics/revenue", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAnalytics)(
+			http.HandlerFunc(c.AnalyticsHandler.GetRevenue),
+		),
+	))
+	mux.Handle("GET /api/admin/analytics/top-products", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAnalytics)(
+			http.HandlerFunc(c.AnalyticsHandler.GetTopProducts),
+		),
+	))
+	mux.Handle("GET /api/admin/analytics/orders-by-status", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAnalytics)(
+			http.HandlerFunc(c.AnalyticsHandler.GetOrdersByStatus),
+		),
+	))
+	mux.Handle("GET /api/admin/analytics/summary", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewAnalytics)(
+			http.HandlerFunc(c.AnalyticsHandler.GetSummary),
+		),
+	))
+
+	// Report routes
+	// Admin only: Itemized sales export for accounting, streamed as CSV
+	mux.Handle("GET /api/admin/reports/sales", middleware.Timeout(salesExportTimeout)(c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionExportSales)(
+			http.HandlerFunc(c.SalesReportHandler.ExportSales),
+		),
+	)))
+
+	// Background job routes
+	// Admin only: Schedules and run metrics of the jobs run by this instance
+	mux.Handle("GET /api/admin/jobs", c.AuthMiddleware.Authenticate(
+		c.AuthMiddleware.RequirePermission(middleware.PermissionViewJobs)(
+			http.HandlerFunc(c.JobHandler.ListJobs),
+		),
+	))
+
+	return c.CORSMiddleware.Handle(c.APIVersionMiddleware.Negotiate(c.MaintenanceMiddleware.Block(mux)))
 }
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	logArchiveUseCase "github.com/marcofilho/go-ecommerce/src/usecase/logarchive"
+)
+
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	auditDays := flag.Int("audit-days", cfg.Archive.AuditLogsAfterDays, "Archive audit logs older than this many days")
+	webhookDays := flag.Int("webhook-days", cfg.Archive.WebhookLogsAfterDays, "Archive processed webhook logs older than this many days")
+	flag.Parse()
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	uc := logArchiveUseCase.NewUseCase(infraRepo.NewLogArchiveRepository(db), cfg.Archive.BatchSize)
+	ctx := context.Background()
+
+	log.Printf("Archiving audit logs older than %d days...", *auditDays)
+	archived, err := uc.ArchiveAuditLogs(ctx, *auditDays)
+	if err != nil {
+		log.Fatalf("Archiving stopped after %d audit logs: %v", archived, err)
+	}
+	log.Printf("Archived %d audit logs", archived)
+
+	log.Printf("Archiving webhook logs older than %d days...", *webhookDays)
+	archived, err = uc.ArchiveWebhookLogs(ctx, *webhookDays)
+	if err != nil {
+		log.Fatalf("Archiving stopped after %d webhook logs: %v", archived, err)
+	}
+	log.Printf("Archived %d webhook logs", archived)
+
+	log.Println("Log archiving completed successfully!")
+}
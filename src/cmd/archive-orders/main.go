@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	archiveUseCase "github.com/marcofilho/go-ecommerce/src/usecase/archive"
+)
+
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	years := flag.Int("years", cfg.Archive.OrdersAfterYears, "Archive finalized orders older than this many years")
+	flag.Parse()
+
+	log.Printf("Archiving orders older than %d years...", *years)
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	uc := archiveUseCase.NewUseCase(infraRepo.NewOrderArchiveRepository(db), cfg.Archive.BatchSize)
+
+	archived, err := uc.ArchiveOrders(context.Background(), *years)
+	if err != nil {
+		log.Fatalf("Archiving stopped after %d orders: %v", archived, err)
+	}
+
+	log.Printf("Archived %d orders successfully!", archived)
+}
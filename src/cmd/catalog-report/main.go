@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	catalogReportUseCase "github.com/marcofilho/go-ecommerce/src/usecase/catalogreport"
+)
+
+// Runs the catalog health scan, meant to be scheduled with cron.
+// Admins read the stored report at GET /api/admin/catalog-report.
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("Scanning the catalog...")
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	uc := catalogReportUseCase.NewUseCase(infraRepo.NewCatalogReportRepository(db), infraRepo.NewCategoryRepository(db))
+
+	report, err := uc.RunReport(context.Background(), entity.ReportScheduled, nil)
+	if err != nil {
+		log.Fatal("Failed to run catalog report:", err)
+	}
+
+	log.Printf("Catalog report %s: %d critical, %d warnings, %d info", report.ID, report.Critical, report.Warnings, report.Info)
+}
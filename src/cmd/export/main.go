@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	backupUseCase "github.com/marcofilho/go-ecommerce/src/usecase/backup"
+	invoiceUseCase "github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+)
+
+// Dumps the data of the store into a versioned archive that cmd/import
+// restores, to move a store between environments.
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	out := flag.String("out", "", "Archive to write, backup-<time>.zip when empty")
+	users := flag.String("users", string(backupUseCase.UsersExcluded), "Accounts and their customer data: excluded, or hashed into pseudonyms")
+	key := flag.String("key", "", "Key the pseudonyms of hashed users are derived from; a random one when empty")
+	flag.Parse()
+
+	if *out == "" {
+		*out = "backup-" + time.Now().UTC().Format("20060102-150405") + ".zip"
+	}
+
+	secret := []byte(*key)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			log.Fatal("Failed to generate a key: ", err)
+		}
+	}
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	file, err := os.OpenFile(*out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		log.Fatal("Failed to create the archive: ", err)
+	}
+
+	invoices := invoiceUseCase.NewUseCase(
+		infraRepo.NewInvoiceRepository(db),
+		infraRepo.NewReadThroughOrderRepository(infraRepo.NewOrderRepositoryPostgres(db), infraRepo.NewOrderArchiveRepository(db)),
+		infraRepo.NewProductRepositoryPostgres(db),
+		infraRepo.NewUserRepository(db),
+		invoice.NewPDFRenderer(cfg.Invoice.StoreName),
+	)
+	uc := backupUseCase.NewUseCase(infraRepo.NewBackupRepository(db), invoices, cfg.Archive.BatchSize)
+
+	log.Printf("Exporting the store to %s...", *out)
+
+	manifest, err := uc.Export(context.Background(), file, backupUseCase.ExportOptions{Users: backupUseCase.Users(*users), Key: secret})
+	if err == nil {
+		err = file.Close()
+	}
+	if err != nil {
+		file.Close()
+		os.Remove(*out)
+		log.Fatal("Export failed: ", err)
+	}
+
+	var rows int64
+	for _, table := range manifest.Tables {
+		rows += table.Rows
+	}
+	log.Printf("Exported %d rows of %d tables at schema version %d, users %s", rows, len(manifest.Tables), manifest.SchemaVersion, manifest.Users)
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	backupUseCase "github.com/marcofilho/go-ecommerce/src/usecase/backup"
+)
+
+// Restores an archive of cmd/export into an empty database migrated to the
+// schema version of the archive.
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	in := flag.String("in", "", "Archive to restore")
+	batchSize := flag.Int("batch-size", cfg.Archive.BatchSize, "Rows inserted per statement")
+	flag.Parse()
+
+	if *in == "" {
+		log.Fatal("Usage: import -in <archive>")
+	}
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatal("Failed to open the archive: ", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		log.Fatal("Failed to open the archive: ", err)
+	}
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	uc := backupUseCase.NewUseCase(infraRepo.NewBackupRepository(db), nil, *batchSize)
+
+	log.Printf("Restoring %s...", *in)
+
+	manifest, err := uc.Import(context.Background(), file, info.Size())
+	if err != nil {
+		log.Fatal("Nothing was restored: ", err)
+	}
+
+	var rows int64
+	for _, table := range manifest.Tables {
+		rows += table.Rows
+	}
+	log.Printf("Restored %d rows of %d tables exported at %s, users %s", rows, len(manifest.Tables), manifest.CreatedAt.Format("2006-01-02 15:04:05 MST"), manifest.Users)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/loadtest"
+)
+
+// Runs the browse, search and checkout burst scenarios against a live
+// deployment and checks their p95 latency against the budgets. Prints one
+// line per scenario and exits non-zero when any failed or went over budget.
+// The load test products are deleted afterwards; its customers and their
+// orders stay.
+func main() {
+	url := flag.String("url", "http://localhost:8080", "Base URL of the deployment")
+	adminEmail := flag.String("admin-email", os.Getenv("LOADTEST_ADMIN_EMAIL"), "Email of an existing admin account")
+	scenarios := flag.String("scenarios", "browse,search,checkout", "Comma-separated scenarios to run")
+	requests := flag.Int("requests", 1000, "Requests of each scenario")
+	concurrency := flag.Int("concurrency", 20, "Concurrent requests")
+	products := flag.Int("products", 50, "Products to create for the scenarios")
+	customers := flag.Int("customers", 10, "Customers to sign up for the checkout burst")
+	browseBudget := flag.Duration("browse-p95", 200*time.Millisecond, "p95 latency budget of browsing")
+	searchBudget := flag.Duration("search-p95", 300*time.Millisecond, "p95 latency budget of search")
+	checkoutBudget := flag.Duration("checkout-p95", 500*time.Millisecond, "p95 latency budget of the checkout burst")
+	flag.Parse()
+
+	// The password comes from the environment only, to keep it out of the process list
+	adminPassword := os.Getenv("LOADTEST_ADMIN_PASSWORD")
+	if *adminEmail == "" || adminPassword == "" {
+		log.Fatal("LOADTEST_ADMIN_EMAIL (or -admin-email) and LOADTEST_ADMIN_PASSWORD are required")
+	}
+	if *products < 1 || *customers < 1 {
+		log.Fatal("-products and -customers must be at least 1")
+	}
+
+	client := &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{MaxIdleConnsPerHost: *concurrency},
+	}
+	budgets := map[string]time.Duration{"browse": *browseBudget, "search": *searchBudget, "checkout": *checkoutBudget}
+
+	if !run(client, *url, *adminEmail, adminPassword, strings.Split(*scenarios, ","), *requests, *concurrency, *products, *customers, budgets) {
+		log.Fatal("Load test failed")
+	}
+	log.Print("Load test passed")
+}
+
+// run prepares the fixture, runs the scenarios and deletes the fixture
+// products, reporting whether every scenario passed
+func run(client *http.Client, url, adminEmail, adminPassword string, scenarios []string, requests, concurrency, products, customers int, budgets map[string]time.Duration) bool {
+	ctx := context.Background()
+
+	adminToken, err := loadtest.Login(ctx, client, url, adminEmail, adminPassword)
+	if err != nil {
+		log.Print("Failed to log in as admin: ", err)
+		return false
+	}
+
+	log.Printf("Preparing %d products and %d customers on %s...", products, customers, url)
+
+	fixture, err := loadtest.Prepare(ctx, client, url, adminToken, products, customers)
+	defer func() {
+		if err := fixture.Cleanup(ctx, client, adminToken); err != nil {
+			log.Print("Failed to delete the load test products: ", err)
+		}
+	}()
+	if err != nil {
+		log.Print("Failed to prepare the load test: ", err)
+		return false
+	}
+
+	passed := true
+	for _, name := range scenarios {
+		name = strings.TrimSpace(name)
+		var scenario loadtest.Scenario
+		switch name {
+		case "browse":
+			scenario = loadtest.Browse(fixture, requests, concurrency, budgets[name])
+		case "search":
+			scenario = loadtest.Search(fixture, requests, concurrency, budgets[name])
+		case "checkout":
+			scenario = loadtest.CheckoutBurst(fixture, requests, concurrency, budgets[name])
+		default:
+			log.Printf("FAIL unknown scenario %q", name)
+			passed = false
+			continue
+		}
+
+		result := loadtest.Run(ctx, client, scenario)
+		log.Print(result)
+		if err := result.Err(); err != nil {
+			log.Print("FAIL ", err)
+			passed = false
+		}
+	}
+	return passed
+}
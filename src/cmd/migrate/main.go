@@ -1,25 +1,145 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
 
 	"github.com/marcofilho/go-ecommerce/src/internal/config"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
 )
 
+const usage = `Usage: migrate <command> [flags]
+
+Commands:
+  up              Apply every pending migration (default)
+  down [-steps N] Revert the last N migrations (default 1)
+  status          List the migrations and whether they are applied
+  create <name>   Create empty up and down SQL files for a new migration
+`
+
 func main() {
-	log.Println("Running database migrations...")
+	command := "up"
+	args := os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
 
-	cfg := config.Load()
+	switch command {
+	case "up":
+		up()
+	case "down":
+		flags := flag.NewFlagSet("down", flag.ExitOnError)
+		steps := flags.Int("steps", 1, "Number of migrations to revert")
+		flags.Parse(args)
+		down(*steps)
+	case "status":
+		status()
+	case "create":
+		flags := flag.NewFlagSet("create", flag.ExitOnError)
+		dir := flags.String("dir", database.MigrationsDir, "Directory of the migration files")
+		flags.Parse(args)
+		if flags.NArg() != 1 {
+			log.Fatal("Usage: migrate create [-dir path] <name>")
+		}
+		create(*dir, flags.Arg(0))
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+func newMigrator() *database.Migrator {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+	// Backfills may take longer than any request should
+	cfg.Database.QueryTimeoutSeconds = 0
 
 	db, err := database.Connect(&cfg.Database)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
 
-	if err := database.Migrate(db); err != nil {
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	return migrator
+}
+
+func up() {
+	log.Println("Running database migrations...")
+
+	applied, err := newMigrator().Up(context.Background())
+	for _, migration := range applied {
+		log.Printf("Applied %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
 
+	if len(applied) == 0 {
+		log.Println("Database is up to date")
+		return
+	}
 	log.Println("Migrations completed successfully!")
 }
+
+func down(steps int) {
+	if steps < 1 {
+		log.Fatal("steps must be at least 1")
+	}
+
+	reverted, err := newMigrator().Down(context.Background(), steps)
+	for _, migration := range reverted {
+		log.Printf("Reverted %04d_%s", migration.Version, migration.Name)
+	}
+	if err != nil {
+		log.Fatal("Failed to revert migrations:", err)
+	}
+
+	if len(reverted) == 0 {
+		log.Println("No migration to revert")
+	}
+}
+
+func status() {
+	migrator := newMigrator()
+	ctx := context.Background()
+
+	version, dirty, err := migrator.Version(ctx)
+	if err != nil {
+		log.Fatal("Failed to read the schema version:", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		log.Fatal("Failed to read the schema version:", err)
+	}
+
+	for _, s := range statuses {
+		state := "pending"
+		if s.Applied {
+			state = "applied"
+		}
+		fmt.Printf("%04d_%-40s %s\n", s.Version, s.Name, state)
+	}
+
+	fmt.Printf("\nDatabase version: %d, latest: %d", version, migrator.Latest())
+	if dirty {
+		fmt.Print(" (dirty)")
+	}
+	fmt.Println()
+}
+
+func create(dir, name string) {
+	up, down, err := database.CreateMigration(dir, name)
+	if err != nil {
+		log.Fatal("Failed to create migration:", err)
+	}
+	log.Printf("Created %s", up)
+	log.Printf("Created %s", down)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/openapi"
+)
+
+// Regenerates the OpenAPI 3 document from the handler annotations and DTOs.
+// Run through go generate, the API embeds the result and serves it at GET /api/openapi.json.
+func main() {
+	root := flag.String("root", "src", "Path to the src directory of the module")
+	out := flag.String("out", "src/internal/adapter/http/openapi/openapi.json", "Output file")
+	flag.Parse()
+
+	document, err := openapi.Generate(openapi.DefaultConfig(*root))
+	if err != nil {
+		log.Fatal("Failed to generate OpenAPI document:", err)
+	}
+
+	if err := os.WriteFile(*out, document, 0o644); err != nil {
+		log.Fatal("Failed to write OpenAPI document:", err)
+	}
+
+	log.Printf("OpenAPI document written to %s", *out)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	seedUseCase "github.com/marcofilho/go-ecommerce/src/usecase/seed"
+)
+
+// Fills an empty database with demo users, categories, products with variants
+// and a history of orders. The same -seed produces the same data on every run,
+// with the history ending today.
+func main() {
+	cfg, err := config.LoadWithoutSecrets()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	seed := flag.Int64("seed", 42, "Seed of the generated data, the same seed produces the same data")
+	customers := flag.Int("customers", 25, "Number of demo customers")
+	orders := flag.Int("orders", 300, "Number of historical orders")
+	days := flag.Int("days", 180, "Days of order history")
+	password := flag.String("password", "demo1234", "Password of every demo account")
+	flag.Parse()
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	uc := seedUseCase.NewUseCase(
+		infraRepo.NewUserRepository(db),
+		infraRepo.NewCategoryRepository(db),
+		infraRepo.NewProductRepositoryPostgres(db),
+		infraRepo.NewProductOptionRepository(db),
+		infraRepo.NewProductVariantRepositoryPostgres(db),
+		infraRepo.NewOrderRepositoryPostgres(db),
+	)
+
+	log.Printf("Seeding demo data with seed %d...", *seed)
+
+	summary, err := uc.Seed(context.Background(), seedUseCase.Options{
+		Seed:      *seed,
+		Customers: *customers,
+		Orders:    *orders,
+		Days:      *days,
+		Now:       time.Now().UTC().Truncate(24 * time.Hour),
+		Password:  *password,
+		TaxRate:   cfg.Pricing.TaxRate,
+	})
+	if err != nil {
+		log.Fatal("Failed to seed demo data: ", err)
+	}
+
+	log.Printf("Seeded %d users, %d categories, %d products, %d variants and %d orders",
+		summary.Users, summary.Categories, summary.Products, summary.Variants, summary.Orders)
+	log.Printf("Sign in as %s with password %q", seedUseCase.AdminEmail, *password)
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Runs the main flow of the store against a live deployment: a new customer
+// signs up and logs in, an admin creates a product, the customer orders it
+// and a signed payment webhook pays the order. Prints one line per step and
+// exits non-zero on the first failure, so it can gate a deploy. The smoke
+// product is deleted afterwards; the customer account and its order stay.
+func main() {
+	url := flag.String("url", "http://localhost:8080", "Base URL of the deployment")
+	adminEmail := flag.String("admin-email", os.Getenv("SMOKE_ADMIN_EMAIL"), "Email of an existing admin account")
+	timeout := flag.Duration("timeout", 10*time.Second, "Timeout of each request")
+	flag.Parse()
+
+	// Secrets come from the environment only, to keep them out of the process list
+	adminPassword := os.Getenv("SMOKE_ADMIN_PASSWORD")
+	webhookSecret := os.Getenv("WEBHOOK_SECRET")
+	if *adminEmail == "" || adminPassword == "" || webhookSecret == "" {
+		log.Fatal("SMOKE_ADMIN_EMAIL (or -admin-email), SMOKE_ADMIN_PASSWORD and WEBHOOK_SECRET are required")
+	}
+
+	s := &smokeTest{
+		client:        &http.Client{Timeout: *timeout},
+		url:           strings.TrimRight(*url, "/"),
+		webhookSecret: webhookSecret,
+	}
+
+	log.Printf("Smoke testing %s...", s.url)
+
+	if err := s.run(*adminEmail, adminPassword); err != nil {
+		log.Fatal("Smoke test failed")
+	}
+
+	log.Println("Smoke test passed")
+}
+
+type smokeTest struct {
+	client        *http.Client
+	url           string
+	webhookSecret string
+}
+
+// run goes through the flow, stopping at the first step that fails
+func (s *smokeTest) run(adminEmail, adminPassword string) (err error) {
+	suffix := time.Now().UTC().Format("20060102150405.000000000")
+	customerEmail := fmt.Sprintf("smoke+%s@example.com", strings.ReplaceAll(suffix, ".", ""))
+	customerPassword := "smoke-" + suffix
+
+	var customer, admin dto.AuthResponse
+	var product dto.ProductResponse
+	var order dto.OrderResponse
+
+	err = s.step("register customer", func() error {
+		return s.call(http.MethodPost, "/api/auth/register", "",
+			dto.RegisterRequest{Email: customerEmail, Password: customerPassword, Name: "Smoke Test"}, nil, http.StatusCreated)
+	})
+	if err == nil {
+		err = s.step("log in as customer", func() error {
+			return s.call(http.MethodPost, "/api/auth/login", "",
+				dto.LoginRequest{Email: customerEmail, Password: customerPassword}, &customer, http.StatusOK)
+		})
+	}
+	if err == nil {
+		err = s.step("log in as admin", func() error {
+			if err := s.call(http.MethodPost, "/api/auth/login", "",
+				dto.LoginRequest{Email: adminEmail, Password: adminPassword}, &admin, http.StatusOK); err != nil {
+				return err
+			}
+			if admin.Role != string(entity.RoleAdmin) {
+				return fmt.Errorf("%s has role %q, not admin", adminEmail, admin.Role)
+			}
+			return nil
+		})
+	}
+	if err == nil {
+		err = s.step("create product as admin", func() error {
+			return s.call(http.MethodPost, "/api/products", admin.Token, dto.ProductRequest{
+				Name: "Smoke test product " + suffix, Description: "Created by the smoke test, archived when it ends", Price: 1, Quantity: 1,
+			}, &product, http.StatusCreated)
+		})
+	}
+	if product.ID != "" {
+		defer func() {
+			// Ordered products can't be deleted, archiving takes it off the catalog
+			cleanupErr := s.step("archive product", func() error {
+				return s.call(http.MethodPost, "/api/products/"+product.ID+"/archive", admin.Token, nil, nil, http.StatusOK)
+			})
+			if err == nil {
+				err = cleanupErr
+			}
+		}()
+	}
+	if err == nil {
+		err = s.step("order as customer", func() error {
+			return s.call(http.MethodPost, "/api/orders", customer.Token, dto.CreateOrderRequest{
+				CustomerID: 1, Products: []dto.OrderItemRequest{{ProductID: product.ID, Quantity: 1}},
+			}, &order, http.StatusCreated)
+		})
+	}
+	if err == nil {
+		err = s.step("pay with the payment webhook", func() error {
+			return s.payOrder(order.ID, "smoke_"+strings.ReplaceAll(suffix, ".", ""))
+		})
+	}
+	if err == nil {
+		err = s.step("order is paid", func() error {
+			if err := s.call(http.MethodGet, "/api/orders/"+order.ID, customer.Token, nil, &order, http.StatusOK); err != nil {
+				return err
+			}
+			if order.PaymentStatus != string(entity.Paid) {
+				return fmt.Errorf("payment status is %q, not paid", order.PaymentStatus)
+			}
+			return nil
+		})
+	}
+	return err
+}
+
+// step runs fn and reports whether it passed and how long it took
+func (s *smokeTest) step(name string, fn func() error) error {
+	start := time.Now()
+	err := fn()
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		log.Printf("FAIL %s (%s): %v", name, elapsed, err)
+		return err
+	}
+	log.Printf("PASS %s (%s)", name, elapsed)
+	return nil
+}
+
+// call sends body as JSON with the token, expects the status and decodes the
+// data of the response into out when given
+func (s *smokeTest) call(method, path, token string, body, out interface{}, status int) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	headers := map[string]string{}
+	if token != "" {
+		headers["Authorization"] = "Bearer " + token
+	}
+	return s.send(method, path, payload, headers, out, status)
+}
+
+// payOrder sends a paid webhook for the whole balance, signed like the
+// payment provider signs it
+func (s *smokeTest) payOrder(orderID, transactionID string) error {
+	payload, err := json.Marshal(entity.PaymentWebhookRequest{
+		OrderID: orderID, TransactionID: transactionID, PaymentStatus: entity.Paid,
+	})
+	if err != nil {
+		return err
+	}
+	timestamp, nonce := strconv.FormatInt(time.Now().Unix(), 10), uuid.NewString()
+	mac := hmac.New(sha256.New, []byte(s.webhookSecret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(payload)
+
+	headers := map[string]string{
+		"X-Payment-Signature": hex.EncodeToString(mac.Sum(nil)),
+		"X-Payment-Timestamp": timestamp,
+		"X-Payment-Nonce":     nonce,
+	}
+	return s.send(http.MethodPost, "/api/payment-webhook", payload, headers, nil, http.StatusOK)
+}
+
+func (s *smokeTest) send(method, path string, payload []byte, headers map[string]string, out interface{}, status int) error {
+	req, err := http.NewRequest(method, s.url+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != status {
+		var apiErr dto.ErrorResponse
+		if json.Unmarshal(raw, &apiErr) == nil && apiErr.Error != "" {
+			return fmt.Errorf("%s %s: got %d, want %d: %s", method, path, res.StatusCode, status, apiErr.Error)
+		}
+		return fmt.Errorf("%s %s: got %d, want %d", method, path, res.StatusCode, status)
+	}
+	if out == nil {
+		return nil
+	}
+
+	var body dto.Response[json.RawMessage]
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	if len(body.Data) == 0 {
+		return errors.New(method + " " + path + ": response has no data")
+	}
+	return json.Unmarshal(body.Data, out)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os/signal"
+	"syscall"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/app"
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+)
+
+// Runs the background jobs without the HTTP server, so workers scale apart
+// from the API. Set JOBS_ENABLED=false on the API instances to keep the jobs
+// off them; instances that do run jobs share them through database locks.
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := database.Connect(&cfg.Database)
+	if err != nil {
+		log.Fatal("Failed to connect to database:", err)
+	}
+
+	if err := database.CheckSchema(context.Background(), db); err != nil {
+		log.Fatal("Refusing to start: ", err)
+	}
+
+	container := app.NewContainer(db, cfg)
+	if err := app.RegisterJobs(container); err != nil {
+		log.Fatal("Failed to schedule background jobs:", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	container.Scheduler.Start(ctx)
+	log.Printf("Worker started with %d workers", cfg.Jobs.Workers)
+
+	<-ctx.Done()
+	log.Println("Shutting down, waiting for running jobs...")
+	container.Scheduler.Wait()
+
+	for _, job := range container.Scheduler.Metrics() {
+		log.Printf("%s: %d runs, %d failures, %d skipped", job.Name, job.Runs, job.Failures, job.Skipped)
+	}
+}
@@ -1,107 +1,555 @@
 package dto
 
+import "encoding/json"
+
+// Pagination describes the page a list response holds. Every list endpoint
+// fills it with NewPagination, so clients page through all of them alike.
 type Pagination struct {
-	Page       int `json:"page"`
-	PageSize   int `json:"page_size"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page       int  `json:"page"`
+	PageSize   int  `json:"page_size"`
+	Total      int  `json:"total"`
+	TotalPages int  `json:"total_pages"`
+	HasNext    bool `json:"has_next"`
+	HasPrev    bool `json:"has_prev"`
+}
+
+// NewPagination describes the given page of total items, pageSize per page
+func NewPagination(page, pageSize, total int) *Pagination {
+	totalPages := 0
+	if pageSize > 0 {
+		totalPages = (total + pageSize - 1) / pageSize
+	}
+	return &Pagination{
+		Page:       page,
+		PageSize:   pageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasNext:    page < totalPages,
+		HasPrev:    page > 1,
+	}
 }
 
-type PaginatedResponse[T any] struct {
-	Data       []T        `json:"data"`
-	Pagination Pagination `json:"pagination"`
+// Paginated is implemented by list responses, so handlers can link their
+// pages in the Link header
+type Paginated interface {
+	PageInfo() *Pagination
 }
 
+// Response is the envelope of every successful JSON response: the payload in
+// Data, plus Pagination for lists. Failures respond with ErrorResponse.
+type Response[T any] struct {
+	Data       T           `json:"data"`
+	Pagination *Pagination `json:"pagination,omitempty"`
+}
+
+func (Response[T]) enveloped() {}
+
+func (r Response[T]) PageInfo() *Pagination { return r.Pagination }
+
+// Enveloped is implemented by Response only, so handlers can tell a payload
+// that still needs wrapping from one already in the envelope
+type Enveloped interface {
+	enveloped()
+}
+
+// PaginatedResponse is the envelope of list endpoints
+type PaginatedResponse[T any] = Response[[]T]
+
 // Product DTOs
 type ProductRequest struct {
-	Name        string  `json:"name" example:"Laptop"`
-	Description string  `json:"description" example:"High-performance laptop"`
-	Price       float64 `json:"price" example:"999.99"`
-	Quantity    int     `json:"quantity" example:"50"`
+	Name            string  `json:"name" validate:"required,max=255" example:"Laptop"`
+	Description     string  `json:"description" example:"High-performance laptop"`
+	Price           float64 `json:"price" validate:"gte=0" example:"999.99"`
+	Quantity        int     `json:"quantity" validate:"gte=0" example:"50"`
+	MeasurementUnit string  `json:"measurement_unit,omitempty" validate:"omitempty,oneof=kg l m" example:"kg"` // Base unit for unit pricing: kg, l or m
+	UnitContent     float64 `json:"unit_content,omitempty" validate:"gte=0" example:"0.5"`                     // Content of one item in the base unit
+	Status          string  `json:"status,omitempty" validate:"omitempty,oneof=draft active" example:"draft"`  // Status of a new product, active by default; ignored on update, publish and unpublish change it
 }
 
 type ProductResponse struct {
-	ID          string                   `json:"id"`
-	Name        string                   `json:"name"`
-	Description string                   `json:"description"`
-	Price       float64                  `json:"price"`
-	Quantity    int                      `json:"quantity"`
-	Categories  []CategoryResponse       `json:"categories,omitempty"`
-	Variants    []ProductVariantResponse `json:"variants,omitempty"`
-	CreatedAt   string                   `json:"created_at"`
-	UpdatedAt   string                   `json:"updated_at"`
+	ID             string                     `json:"id"`
+	Name           string                     `json:"name"`
+	Description    string                     `json:"description"`
+	Locale         string                     `json:"locale,omitempty"` // Language the name and description were translated to, unset when they are the original
+	Price          float64                    `json:"price"`
+	EffectivePrice float64                    `json:"effective_price"` // Price it sells at now, a scheduled price while one is in effect
+	Quantity       int                        `json:"quantity"`
+	HighDemandMode bool                       `json:"high_demand_mode"`
+	Status         string                     `json:"status"`                     // draft, active or archived; only admins see drafts and archived products
+	AvailableFrom  *string                    `json:"available_from,omitempty"`   // Can't be bought before, unset when it has no start
+	AvailableUntil *string                    `json:"available_until,omitempty"`  // Can't be bought from then on, unset when it has no end
+	MaxPerOrder    int                        `json:"max_per_order,omitempty"`    // Units one order can have, unset without a limit
+	MaxPerCustomer int                        `json:"max_per_customer,omitempty"` // Units one customer can buy over all their orders, unset without a limit
+	RatingAverage  float64                    `json:"rating_average"`             // Mean stars of the reviews, 0 without any
+	RatingCount    int                        `json:"rating_count"`               // Reviews the average is over
+	ContentHash    string                     `json:"content_hash"`               // Send back in If-Match for conditional updates
+	UnitPricing    *UnitPricingResponse       `json:"unit_pricing,omitempty"`
+	Categories     []CategoryResponse         `json:"categories,omitempty"`
+	Options        []ProductOptionResponse    `json:"options,omitempty"`
+	Variants       []ProductVariantResponse   `json:"variants,omitempty"`
+	Attributes     []ProductAttributeResponse `json:"attributes,omitempty"`
+	CreatedAt      string                     `json:"created_at"`
+	UpdatedAt      string                     `json:"updated_at"`
+}
+
+// ProductSummaryResponse is a product in the public listing: what a listing
+// shows of it, without the relations and content of ProductResponse
+type ProductSummaryResponse struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	Locale         string  `json:"locale,omitempty"` // Language the name was translated to, unset when it is the original
+	Price          float64 `json:"price"`
+	EffectivePrice float64 `json:"effective_price"` // Price it sells at now, a scheduled price while one is in effect
+	Stock          int     `json:"stock"`
+	Status         string  `json:"status"`
+	RatingAverage  float64 `json:"rating_average"` // Mean stars of the reviews, 0 without any
+	RatingCount    int     `json:"rating_count"`
+}
+
+// UnitPricingResponse is the legally required price per base unit, e.g. 3.98 per kg
+type UnitPricingResponse struct {
+	MeasurementUnit string  `json:"measurement_unit"`
+	UnitContent     float64 `json:"unit_content"`
+	UnitPrice       float64 `json:"unit_price"`
+}
+
+// Attribute DTOs
+type AttributeRequest struct {
+	Name string `json:"name" validate:"required,max=100" example:"Material"`
+	Type string `json:"type" validate:"required,oneof=text number boolean" example:"text"` // text, number or boolean
+}
+
+type AttributeResponse struct {
+	ID        string `json:"id"`
+	Code      string `json:"code"` // Filter products with ?attr.<code>=<value>
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	CreatedAt string `json:"created_at"`
+}
+
+type ProductAttributeRequest struct {
+	Value interface{} `json:"value" validate:"required" swaggertype:"string" example:"Cotton"` // String, number or boolean matching the attribute type
+}
+
+type ProductAttributeResponse struct {
+	AttributeID string      `json:"attribute_id"`
+	Code        string      `json:"code"`
+	Name        string      `json:"name"`
+	Type        string      `json:"type"`
+	Value       interface{} `json:"value" swaggertype:"string"`
+}
+
+type HighDemandModeRequest struct {
+	Enabled bool `json:"enabled" example:"true"`
+}
+
+type ProductCostRequest struct {
+	Cost *float64 `json:"cost" validate:"omitempty,gte=0" example:"42.5"` // Unit cost, null clears it
+}
+
+// ProductAvailabilityRequest replaces the availability window, null leaves that end open
+type ProductAvailabilityRequest struct {
+	AvailableFrom  *string `json:"available_from" example:"2026-11-27T00:00:00Z"`  // RFC 3339
+	AvailableUntil *string `json:"available_until" example:"2026-11-30T23:59:59Z"` // RFC 3339, exclusive
+}
+
+// ProductPurchaseLimitsRequest replaces the purchase limits, 0 lifts one
+type ProductPurchaseLimitsRequest struct {
+	MaxPerOrder    int `json:"max_per_order" validate:"gte=0" example:"2"`
+	MaxPerCustomer int `json:"max_per_customer" validate:"gte=0" example:"4"`
+}
+
+// ProductCostResponse is only shown to admins, the public product response has no cost
+type ProductCostResponse struct {
+	ProductID string   `json:"product_id"`
+	Price     float64  `json:"price"`
+	Cost      *float64 `json:"cost"`
+	Margin    *float64 `json:"margin"` // Share of the price left after the cost, null without a cost
+}
+
+// Purchase queue DTOs
+type QueueStatusResponse struct {
+	ProductID       string  `json:"product_id"`
+	Status          string  `json:"status"`
+	Position        int     `json:"position"`                    // 1-based place in line while waiting, 0 once admitted
+	WindowExpiresAt *string `json:"window_expires_at,omitempty"` // Deadline to place the order once admitted
+}
+
+// Stock movement DTOs
+type StockMovementResponse struct {
+	ID             string  `json:"id"`
+	ProductID      string  `json:"product_id"`
+	VariantID      *string `json:"variant_id,omitempty"`
+	Reason         string  `json:"reason"`
+	Delta          int     `json:"delta"`
+	QuantityBefore int     `json:"quantity_before"`
+	QuantityAfter  int     `json:"quantity_after"`
+	Reference      string  `json:"reference,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+type StockTransferRequest struct {
+	ToVariantID *string `json:"to_variant_id,omitempty" validate:"omitempty,uuid"` // Receiving variant of the same product, the base product when empty
+	FromProduct bool    `json:"from_product,omitempty"`                            // Move units from the base product into the variant instead
+	Quantity    int     `json:"quantity" validate:"gt=0" example:"5"`
+}
+
+// StockTransferResponse holds the two ledger entries a transfer writes
+type StockTransferResponse struct {
+	From StockMovementResponse `json:"from"`
+	To   StockMovementResponse `json:"to"`
+}
+
+// Inventory sync DTOs
+type StockLevelRequest struct {
+	SKU              string `json:"sku" validate:"required,max=64" example:"TS-RED-L"`
+	Quantity         int    `json:"quantity" validate:"gte=0" example:"25"`
+	ExpectedQuantity *int   `json:"expected_quantity,omitempty" validate:"omitempty,gte=0" example:"30"` // Only apply while the variant still has this many units
+}
+
+type InventorySyncRequest struct {
+	Mode      string              `json:"mode,omitempty" validate:"omitempty,oneof=all_or_nothing best_effort" example:"all_or_nothing"` // all_or_nothing (default) or best_effort
+	Reference string              `json:"reference,omitempty" validate:"max=100" example:"erp-2026-10-17"`                               // Recorded on every stock movement, generated when empty
+	Items     []StockLevelRequest `json:"items" validate:"required,min=1,max=1000,dive"`
+}
+
+type StockLevelResultResponse struct {
+	SKU            string  `json:"sku"`
+	Status         string  `json:"status" example:"updated"` // updated, unchanged, not_found, conflict or skipped
+	ProductID      *string `json:"product_id,omitempty"`
+	VariantID      *string `json:"variant_id,omitempty"`
+	QuantityBefore *int    `json:"quantity_before,omitempty"`
+	QuantityAfter  *int    `json:"quantity_after,omitempty"`
+	Message        string  `json:"message,omitempty"`
+}
+
+// InventorySyncResponse reports what became of every item of a bulk stock update
+type InventorySyncResponse struct {
+	Mode      string                     `json:"mode"`
+	Reference string                     `json:"reference"`
+	Applied   bool                       `json:"applied"` // False when an all-or-nothing update was rejected and nothing was written
+	Updated   int                        `json:"updated"`
+	Unchanged int                        `json:"unchanged"`
+	Failed    int                        `json:"failed"` // Items not found or in conflict
+	Items     []StockLevelResultResponse `json:"items"`
+}
+
+// Product translation DTOs
+type ProductTranslationRequest struct {
+	Name        string `json:"name" validate:"required,max=255" example:"Camiseta Clássica"`
+	Description string `json:"description" example:"Camiseta de algodão"`
+}
+
+type ProductTranslationResponse struct {
+	ProductID   string `json:"product_id"`
+	Locale      string `json:"locale" example:"pt-BR"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+// Review DTOs
+type ProductReviewRequest struct {
+	Rating int    `json:"rating" validate:"required,min=1,max=5" example:"4"`
+	Body   string `json:"body" validate:"max=2000" example:"Keeps coffee hot for hours"`
+}
+
+type ProductReviewResponse struct {
+	ID        string `json:"id"`
+	ProductID string `json:"product_id"`
+	Rating    int    `json:"rating"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Price history DTOs
+type PriceChangeResponse struct {
+	ID            string   `json:"id"`
+	ProductID     string   `json:"product_id"`
+	VariantID     *string  `json:"variant_id,omitempty"`
+	Price         *float64 `json:"price"`                    // Null when a variant price override was removed
+	PreviousPrice *float64 `json:"previous_price,omitempty"` // Price before an edit
+	Scheduled     bool     `json:"scheduled"`
+	Status        string   `json:"status"` // applied, pending, active, expired or cancelled
+	EffectiveFrom string   `json:"effective_from"`
+	EffectiveTo   *string  `json:"effective_to,omitempty"`
+	CancelledAt   *string  `json:"cancelled_at,omitempty"`
+	ChangedBy     *string  `json:"changed_by,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+}
+
+type SchedulePriceRequest struct {
+	VariantID     *string `json:"variant_id,omitempty" validate:"omitempty,uuid"` // Schedule the price of this variant instead of the product
+	Price         float64 `json:"price" validate:"gte=0" example:"19.99"`
+	EffectiveFrom string  `json:"effective_from" validate:"required" example:"2026-11-27T00:00:00Z"` // RFC 3339, in the future
+	EffectiveTo   *string `json:"effective_to,omitempty" example:"2026-11-30T23:59:59Z"`             // RFC 3339, the price stays for good when omitted
+}
+
+// Price list DTOs
+type PriceTierRequest struct {
+	VariantID     *string `json:"variant_id,omitempty" validate:"omitempty,uuid"`                 // Price this variant only, every variant of the product when omitted
+	CustomerGroup string  `json:"customer_group,omitempty" validate:"max=50" example:"wholesale"` // retail when omitted, retail tiers apply to every group
+	MinQuantity   int     `json:"min_quantity" validate:"gte=1" example:"10"`
+	Price         float64 `json:"price" validate:"gte=0" example:"17.99"`
+}
+
+type PriceListRequest struct {
+	Tiers []PriceTierRequest `json:"tiers" validate:"max=100,dive"` // Replaces the whole price list, empty removes it
+}
+
+type PriceTierResponse struct {
+	ID            string  `json:"id"`
+	VariantID     *string `json:"variant_id,omitempty"`
+	CustomerGroup string  `json:"customer_group"`
+	MinQuantity   int     `json:"min_quantity"`
+	Price         float64 `json:"price"`
+}
+
+type PriceListResponse struct {
+	ProductID string              `json:"product_id"`
+	Tiers     []PriceTierResponse `json:"tiers"`
+}
+
+// Admin activity DTOs
+type AuditLogResponse struct {
+	ID             string                `json:"id"`
+	UserID         *string               `json:"user_id,omitempty"`
+	ImpersonatorID *string               `json:"impersonator_id,omitempty"` // Admin who made the change while impersonating the user
+	Action         string                `json:"action"`
+	ResourceType   string                `json:"resource_type"`
+	ResourceID     string                `json:"resource_id"`
+	PayloadBefore  json.RawMessage       `json:"payload_before,omitempty" swaggertype:"object"`
+	PayloadAfter   json.RawMessage       `json:"payload_after,omitempty" swaggertype:"object"`
+	Changes        []AuditChangeResponse `json:"changes,omitempty"` // Top-level fields that differ between the payloads
+	Timestamp      string                `json:"timestamp"`
+}
+
+type AuditChangeResponse struct {
+	Field  string          `json:"field" example:"Price"`
+	Before json.RawMessage `json:"before,omitempty" swaggertype:"object"`
+	After  json.RawMessage `json:"after,omitempty" swaggertype:"object"`
+}
+
+type AdminAlertResponse struct {
+	ID         string  `json:"id"`
+	Rule       string  `json:"rule"`
+	ActorID    *string `json:"actor_id,omitempty"`
+	AuditLogID string  `json:"audit_log_id"`
+	Message    string  `json:"message"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// Background job DTOs
+type JobResponse struct {
+	Name           string  `json:"name" example:"payment.retry_webhooks"`
+	Schedule       string  `json:"schedule" example:"@every 1m"`
+	Runs           int     `json:"runs"`     // Completed runs since the API started, failed ones included
+	Failures       int     `json:"failures"` // Runs that returned an error or panicked
+	Panics         int     `json:"panics"`
+	Skipped        int     `json:"skipped"` // Runs left out because the previous one was still going
+	Running        bool    `json:"running"`
+	LastStartedAt  *string `json:"last_started_at,omitempty"`
+	LastFinishedAt *string `json:"last_finished_at,omitempty"`
+	LastDurationMs int64   `json:"last_duration_ms"`
+	LastError      string  `json:"last_error,omitempty"`
+	NextRunAt      *string `json:"next_run_at,omitempty"` // Unset when the schedule never fires again
+}
+
+type JobListResponse struct {
+	Enabled bool          `json:"enabled"` // Whether this instance runs the background jobs
+	Jobs    []JobResponse `json:"jobs"`
 }
 
 // Order DTOs
 type CreateOrderRequest struct {
-	CustomerID int                `json:"customer_id" example:"123"`
-	Products   []OrderItemRequest `json:"products"`
+	CustomerID   int                `json:"customer_id" validate:"gt=0" example:"123"`
+	Products     []OrderItemRequest `json:"products" validate:"required,min=1,dive"`
+	RedeemPoints int                `json:"redeem_points,omitempty" validate:"gte=0" example:"500"` // Optional: loyalty points to take off the subtotal, signed in only
 }
 
 type OrderItemRequest struct {
-	ProductID string  `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	VariantID *string `json:"variant_id,omitempty" example:"660e8400-e29b-41d4-a716-446655440000"` // Optional: order specific variant
-	Quantity  int     `json:"quantity" example:"2"`
+	ProductID string  `json:"product_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	VariantID *string `json:"variant_id,omitempty" validate:"omitempty,uuid" example:"660e8400-e29b-41d4-a716-446655440000"` // Optional: order specific variant
+	Quantity  int     `json:"quantity" validate:"gt=0" example:"2"`
 }
 
 type UpdateOrderStatusRequest struct {
-	Status string `json:"status" example:"completed"`
+	Status string `json:"status" validate:"required,oneof=pending processing shipped delivered completed cancelled refunded" example:"shipped"` // Which moves are allowed depends on ORDER_WORKFLOW
 }
 
 type OrderItemResponse struct {
-	ProductID string  `json:"product_id"`
-	Quantity  int     `json:"quantity"`
-	Subtotal  float64 `json:"subtotal"`
+	ID                 string                       `json:"id"`
+	ProductID          string                       `json:"product_id"`
+	VariantID          *string                      `json:"variant_id,omitempty"`
+	ProductName        string                       `json:"product_name" example:"T-Shirt"`                              // As it was named when ordered
+	SKU                string                       `json:"sku,omitempty" example:"TSHIRT-L-RED"`                        // Of the variant, when ordered
+	VariantDescription string                       `json:"variant_description,omitempty" example:"Size: L, Color: Red"` // Options of the variant, when ordered
+	TaxRate            float64                      `json:"tax_rate" example:"0.2"`                                      // Fraction the line was taxed at
+	Quantity           int                          `json:"quantity"`
+	Subtotal           float64                      `json:"subtotal"`   // Line total, the sum of the components
+	Components         []OrderItemComponentResponse `json:"components"` // Base price, discounts, tax and surcharges of the line
+}
+
+type OrderItemComponentResponse struct {
+	Type   string  `json:"type" example:"tax"`
+	Label  string  `json:"label,omitempty" example:"Tax 20%"`
+	Amount float64 `json:"amount" example:"4.5"` // Negative for discounts
 }
 
 type OrderResponse struct {
-	ID            string              `json:"id"`
-	CustomerID    int                 `json:"customer_id"`
-	Products      []OrderItemResponse `json:"products"`
-	TotalPrice    float64             `json:"total_price"`
-	Status        string              `json:"status"`
-	PaymentStatus string              `json:"payment_status"`
-	CreatedAt     string              `json:"created_at"`
-	UpdatedAt     string              `json:"updated_at"`
+	ID             string              `json:"id"`
+	CustomerID     int                 `json:"customer_id"`
+	Products       []OrderItemResponse `json:"products"`
+	TotalPrice     float64             `json:"total_price"`
+	Status         string              `json:"status"`
+	PaymentStatus  string              `json:"payment_status"`
+	AmountPaid     float64             `json:"amount_paid"`
+	AmountRefunded float64             `json:"amount_refunded"`
+	AmountCredited float64             `json:"amount_credited"`
+	Balance        float64             `json:"balance"` // Left to pay
+	CreatedAt      string              `json:"created_at"`
+	UpdatedAt      string              `json:"updated_at"`
+}
+
+type OrderStatusBatchRequest struct {
+	OrderIDs []string `json:"order_ids" validate:"required,min=1,max=100" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"` // Up to 100 order IDs, duplicates are looked up once
+}
+
+// OrderStatusResponse is the status of an order without its lines
+type OrderStatusResponse struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status" example:"processing"`
+	PaymentStatus string  `json:"payment_status" example:"paid"`
+	TotalPrice    float64 `json:"total_price"`
+	Balance       float64 `json:"balance"` // Left to pay
+	UpdatedAt     string  `json:"updated_at"`
+}
+
+type OrderStatusBatchResponse struct {
+	Orders   []OrderStatusResponse `json:"orders"`    // In the order the IDs were sent
+	NotFound []string              `json:"not_found"` // IDs of orders that don't exist or belong to another account
+}
+
+type BulkOrderStatusRequest struct {
+	OrderIDs []string `json:"order_ids" validate:"required,min=1,max=500" example:"7c9e6679-7425-40de-944b-e07fc1f90ae7"` // Up to 500 order IDs, each listed once
+	Status   string   `json:"status" validate:"required,oneof=pending processing shipped delivered completed cancelled refunded" example:"shipped"`
+}
+
+type OrderStatusResultResponse struct {
+	OrderID      string  `json:"order_id"`
+	Result       string  `json:"result" example:"updated"` // updated, unchanged, not_found or rejected
+	StatusBefore *string `json:"status_before,omitempty" example:"processing"`
+	StatusAfter  *string `json:"status_after,omitempty" example:"shipped"`
+	Message      string  `json:"message,omitempty"` // Why the order couldn't move
+}
+
+// BulkOrderStatusResponse reports what became of every order of a bulk status update
+type BulkOrderStatusResponse struct {
+	Status    string                      `json:"status"`
+	Updated   int                         `json:"updated"`
+	Unchanged int                         `json:"unchanged"`
+	Failed    int                         `json:"failed"` // Orders not found or rejected by the workflow
+	Orders    []OrderStatusResultResponse `json:"orders"` // In the order the IDs were sent
 }
 
 // ProductVariant DTOs
 type ProductVariantRequest struct {
-	ProductID     string   `json:"product_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	VariantName   string   `json:"variant_name" example:"Color"`
-	VariantValue  string   `json:"variant_value" example:"Red"`
-	PriceOverride *float64 `json:"price_override,omitempty" example:"99.99"` // Optional price override
-	Quantity      int      `json:"quantity" example:"10"`
+	SKU           string            `json:"sku,omitempty" validate:"max=64" example:"TSHIRT-L-RED"`                            // Generated from the option values when empty
+	Options       map[string]string `json:"options" validate:"required,min=1" swaggertype:"object" example:"Size:L,Color:Red"` // Option name to value, one per product option
+	PriceOverride *float64          `json:"price_override,omitempty" validate:"omitempty,gte=0" example:"99.99"`               // Optional price override
+	Quantity      int               `json:"quantity" validate:"gte=0" example:"10"`
 }
 
 type ProductVariantResponse struct {
-	ID            string   `json:"id"`
-	ProductID     string   `json:"product_id"`
-	VariantName   string   `json:"variant_name"`
-	VariantValue  string   `json:"variant_value"`
-	Price         float64  `json:"price"`                    // Effective price (override or base product price)
-	PriceOverride *float64 `json:"price_override,omitempty"` // The override value if set
-	HasOverride   bool     `json:"has_override"`             // Indicates if price is overridden
-	Quantity      int      `json:"quantity"`
-	CreatedAt     string   `json:"created_at"`
-	UpdatedAt     string   `json:"updated_at"`
+	ID            string                  `json:"id"`
+	ProductID     string                  `json:"product_id"`
+	SKU           string                  `json:"sku"`
+	Title         string                  `json:"title"` // Option values in option order, e.g. "L / Red"
+	Options       []VariantOptionResponse `json:"options"`
+	Price         float64                 `json:"price"`                    // Effective price (scheduled price, override or base product price)
+	PriceOverride *float64                `json:"price_override,omitempty"` // The override value if set
+	HasOverride   bool                    `json:"has_override"`             // Indicates if price is overridden
+	Quantity      int                     `json:"quantity"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+}
+
+type VariantOptionResponse struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Product option DTOs
+type ProductOptionRequest struct {
+	Name   string   `json:"name" validate:"required,max=100" example:"Size"`
+	Values []string `json:"values" validate:"required,min=1,dive,required,max=100" example:"S,M,L"`
+}
+
+type ProductOptionValueRequest struct {
+	Value string `json:"value" validate:"required,max=100" example:"XL"`
+}
+
+type ProductOptionResponse struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Position int      `json:"position"`
+	Values   []string `json:"values"`
 }
 
 // Category DTOs
 type CategoryRequest struct {
-	Name string `json:"name" example:"Electronics"`
+	Name     string  `json:"name" validate:"required,max=255" example:"Electronics"`
+	ParentID *string `json:"parent_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
 type CategoryResponse struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Slug     string  `json:"slug"`
+	ParentID *string `json:"parent_id,omitempty"`
+}
+
+// CategoryTreeResponse is a category with its subcategories nested, for navigation menus
+type CategoryTreeResponse struct {
+	ID       string                 `json:"id"`
+	Name     string                 `json:"name"`
+	Slug     string                 `json:"slug"`
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+// CategoryProducts is a page of a category's products along with the category itself
+type CategoryProducts struct {
+	Category CategoryResponse  `json:"category"`
+	Products []ProductResponse `json:"products"`
+}
+
+// CategoryMergeResponse is the category another one was merged into
+type CategoryMergeResponse struct {
+	Category      CategoryResponse `json:"category"`
+	ProductsMoved int              `json:"products_moved"` // Products of the merged category, now in this one
 }
 
 type AssignCategoryRequest struct {
-	CategoryID string `json:"category_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	CategoryID string `json:"category_id" validate:"required,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
 }
 
 // Auth DTOs
+type RegisterRequest struct {
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,min=6"`
+	Name     string `json:"name" validate:"required,min=2"`
+	Role     string `json:"role,omitempty" validate:"omitempty,oneof=customer support admin" example:"customer"`
+	// Required while the store is in private beta, unless an admin creates the account
+	AccessCode string `json:"access_code,omitempty" validate:"max=64" example:"BETA-FRIENDS"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email" validate:"required"`
+	Password string `json:"password" validate:"required"`
+}
+
 type AuthResponse struct {
 	Token     string `json:"token"`
 	UserID    string `json:"user_id"`
@@ -109,14 +557,840 @@ type AuthResponse struct {
 	Name      string `json:"name"`
 	Role      string `json:"role"`
 	ExpiresAt string `json:"expires_at"`
+	// ImpersonatorID is the admin the token was issued to, on impersonation tokens only
+	ImpersonatorID *string `json:"impersonator_id,omitempty"`
+}
+
+type RevokeTokenRequest struct {
+	Token  string `json:"token" validate:"required"`                                                           // The token to revoke, as sent in the Authorization header
+	Reason string `json:"reason,omitempty" validate:"omitempty,oneof=compromised admin" example:"compromised"` // compromised or admin (default)
+}
+
+type RevokeUserTokensRequest struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,oneof=compromised admin" example:"compromised"` // compromised or admin (default)
+}
+
+type UserStatusRequest struct {
+	Active bool `json:"active" example:"false"`
+}
+
+type UserStatusResponse struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Active    bool   `json:"active"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// Order remediation DTOs (support and admin only)
+type RemediationRequest struct {
+	Action      string  `json:"action" validate:"required,oneof=refund_without_return goodwill_credit resend_item" example:"goodwill_credit"`                    // refund_without_return, goodwill_credit or resend_item
+	ReasonCode  string  `json:"reason_code" validate:"required,oneof=damaged not_received wrong_item late_delivery quality_issue other" example:"late_delivery"` // damaged, not_received, wrong_item, late_delivery, quality_issue or other
+	Amount      float64 `json:"amount,omitempty" validate:"gte=0" example:"15.00"`                                                                               // Required for refunds and credits
+	OrderItemID *string `json:"order_item_id,omitempty" validate:"omitempty,uuid"`                                                                               // Required for resends
+	Quantity    int     `json:"quantity,omitempty" validate:"gte=0" example:"1"`                                                                                 // Required for resends
+	Note        string  `json:"note,omitempty" validate:"max=2000" example:"Parcel arrived a week late"`
+}
+
+type RemediationResponse struct {
+	ID              string  `json:"id"`
+	OrderID         string  `json:"order_id"`
+	Action          string  `json:"action"`
+	ReasonCode      string  `json:"reason_code"`
+	Amount          float64 `json:"amount"`
+	OrderItemID     *string `json:"order_item_id,omitempty"`
+	Quantity        int     `json:"quantity,omitempty"`
+	Note            string  `json:"note,omitempty"`
+	PerformedBy     string  `json:"performed_by"`
+	PerformedByRole string  `json:"performed_by_role"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// Return (RMA) DTOs
+type ReturnItemRequest struct {
+	OrderItemID string `json:"order_item_id" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0" example:"1"`
+}
+
+type ReturnRequest struct {
+	ReasonCode string              `json:"reason_code" validate:"required,oneof=damaged wrong_item not_as_described no_longer_needed other" example:"damaged"` // damaged, wrong_item, not_as_described, no_longer_needed or other
+	Note       string              `json:"note,omitempty" validate:"max=2000" example:"The handle was broken"`                                                 // Required when the reason is other
+	Items      []ReturnItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type ReturnReviewRequest struct {
+	Note string `json:"note,omitempty" validate:"max=2000" example:"Outside the 30 day return window"`
+}
+
+type ReturnItemResponse struct {
+	ID           string  `json:"id"`
+	OrderItemID  string  `json:"order_item_id"`
+	ProductID    string  `json:"product_id"`
+	VariantID    *string `json:"variant_id,omitempty"`
+	Quantity     int     `json:"quantity"`
+	RefundAmount float64 `json:"refund_amount"`
+}
+
+type ReturnResponse struct {
+	ID              string               `json:"id"`
+	OrderID         string               `json:"order_id"`
+	Status          string               `json:"status"` // requested, approved, rejected, received or refunded
+	ReasonCode      string               `json:"reason_code"`
+	Note            string               `json:"note,omitempty"`
+	ResolutionNote  string               `json:"resolution_note,omitempty"`
+	Items           []ReturnItemResponse `json:"items"`
+	RefundAmount    float64              `json:"refund_amount"`
+	RefundReference string               `json:"refund_reference,omitempty"`
+	RefundError     string               `json:"refund_error,omitempty"` // Why the last refund attempt failed
+	ReviewedBy      *string              `json:"reviewed_by,omitempty"`
+	ReviewedAt      *string              `json:"reviewed_at,omitempty"`
+	ReceivedAt      *string              `json:"received_at,omitempty"`
+	RefundedAt      *string              `json:"refunded_at,omitempty"`
+	CreatedAt       string               `json:"created_at"`
+	UpdatedAt       string               `json:"updated_at"`
+}
+
+// Customer profile DTOs (admin only)
+type CustomerNoteRequest struct {
+	Body string `json:"body" validate:"required,max=2000" example:"Called about a delayed delivery, offered a refund"`
+}
+
+type CustomerRiskEventRequest struct {
+	Type      string `json:"type" validate:"required,oneof=chargeback failed_payment abuse_report" example:"chargeback"`
+	Reference string `json:"reference,omitempty" validate:"max=255" example:"CB-2024-0042"`
+}
+
+type CustomerNoteResponse struct {
+	ID        string `json:"id"`
+	AuthorID  string `json:"author_id"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CustomerRiskEventResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Reference string `json:"reference,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CustomerProfileResponse struct {
+	UserID     string                      `json:"user_id"`
+	Email      string                      `json:"email"`
+	Name       string                      `json:"name"`
+	Role       string                      `json:"role"`
+	Active     bool                        `json:"active"`
+	RiskScore  int                         `json:"risk_score"` // 0-100, computed from risk events
+	RiskLevel  string                      `json:"risk_level"`
+	Notes      []CustomerNoteResponse      `json:"notes"`
+	RiskEvents []CustomerRiskEventResponse `json:"risk_events"`
+	CreatedAt  string                      `json:"created_at"`
+}
+
+// CustomerSummaryResponse is the purchase history of a customer account, for
+// support and marketing. Archived orders count too.
+type CustomerSummaryResponse struct {
+	UserID             string                  `json:"user_id"`
+	Orders             int                     `json:"orders"`      // Every order placed
+	PaidOrders         int                     `json:"paid_orders"` // Paid and not cancelled, the orders the spend counts
+	LifetimeSpend      float64                 `json:"lifetime_spend"`
+	AverageOrderValue  float64                 `json:"average_order_value"`
+	LastOrderAt        *string                 `json:"last_order_at"` // Null when the account never ordered
+	FavoriteCategories []CategorySalesResponse `json:"favorite_categories"`
+}
+
+// CategorySalesResponse is what a customer bought in a category, on paid orders
+type CategorySalesResponse struct {
+	CategoryID string  `json:"category_id"`
+	Name       string  `json:"name"`
+	Units      int     `json:"units"`
+	Spend      float64 `json:"spend"`
+}
+
+type CustomerGroupRequest struct {
+	CustomerGroup string `json:"customer_group" validate:"required,max=50" example:"wholesale"`
+}
+
+// Customer data DTOs (the customer's own)
+type CustomerAddressRequest struct {
+	Label      string `json:"label,omitempty" validate:"max=50" example:"Home"`
+	Recipient  string `json:"recipient" validate:"required,max=100" example:"Jane Doe"`
+	Line1      string `json:"line1" validate:"required,max=200" example:"221B Baker Street"`
+	Line2      string `json:"line2,omitempty" validate:"max=200"`
+	City       string `json:"city" validate:"required,max=100" example:"London"`
+	Region     string `json:"region,omitempty" validate:"max=100"`
+	PostalCode string `json:"postal_code" validate:"required,max=20" example:"NW1 6XE"`
+	Country    string `json:"country" validate:"required,len=2" example:"GB"` // ISO 3166-1 alpha-2
+	IsDefault  bool   `json:"is_default"`
+}
+
+type CustomerRequest struct {
+	Phone          string                   `json:"phone,omitempty" validate:"max=32" example:"+44 20 7946 0958"`
+	MarketingEmail bool                     `json:"marketing_email"`
+	MarketingSMS   bool                     `json:"marketing_sms"`
+	Addresses      []CustomerAddressRequest `json:"addresses" validate:"max=10,dive"` // Replaces every saved address
+}
+
+type CustomerAddressResponse struct {
+	ID         string `json:"id"`
+	Label      string `json:"label,omitempty"`
+	Recipient  string `json:"recipient"`
+	Line1      string `json:"line1"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city"`
+	Region     string `json:"region,omitempty"`
+	PostalCode string `json:"postal_code"`
+	Country    string `json:"country"`
+	IsDefault  bool   `json:"is_default"`
+}
+
+type CustomerResponse struct {
+	UserID         string                    `json:"user_id"`
+	Phone          string                    `json:"phone,omitempty"`
+	PhoneVerified  bool                      `json:"phone_verified"` // Only a verified phone is sent text messages
+	MarketingEmail bool                      `json:"marketing_email"`
+	MarketingSMS   bool                      `json:"marketing_sms"`
+	CustomerGroup  string                    `json:"customer_group"` // Price list the customer buys at, set by an admin
+	Addresses      []CustomerAddressResponse `json:"addresses"`
+	UpdatedAt      *string                   `json:"updated_at,omitempty"` // Absent until the data is first saved
+}
+
+type AccountExport struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	Active    bool   `json:"active"`
+	CreatedAt string `json:"created_at"`
+}
+
+// DataExportResponse is the personal data kept about the customer
+type DataExportResponse struct {
+	Account    AccountExport    `json:"account"`
+	Customer   CustomerResponse `json:"customer"`
+	Orders     []OrderResponse  `json:"orders"`
+	ExportedAt string           `json:"exported_at"`
+}
+
+type VerifyPhoneRequest struct {
+	Code string `json:"code" validate:"required,len=6,numeric" example:"123456"` // Code texted to the phone
+}
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"` // Confirms the deletion
+}
+
+type DeleteAccountResponse struct {
+	Message          string `json:"message" example:"Account deleted"`
+	OrdersAnonymized int    `json:"orders_anonymized"`
+}
+
+// WebhookAckResponse confirms a processed payment webhook
+type WebhookAckResponse struct {
+	Status  string `json:"status" example:"success"`
+	Message string `json:"message"`
+}
+
+// Payment history DTOs
+// WebhookLogResponse is the full webhook log, only exposed to admins
+type WebhookLogResponse struct {
+	ID            string  `json:"id"`
+	OrderID       string  `json:"order_id"`
+	TransactionID string  `json:"transaction_id"`
+	PaymentStatus string  `json:"payment_status"`
+	Status        string  `json:"status"`
+	RetryCount    int     `json:"retry_count"`
+	NextRetryAt   *string `json:"next_retry_at,omitempty"`
+	RawPayload    string  `json:"raw_payload"`
+	ProcessedAt   *string `json:"processed_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type WebhookResolveRequest struct {
+	Note string `json:"note,omitempty" validate:"max=2000" example:"Captured by hand in the provider dashboard"`
+}
+
+// DeadLetterWebhookResponse is a payment webhook that was rejected, with the
+// body as its source sent it
+type DeadLetterWebhookResponse struct {
+	ID           string  `json:"id"`
+	Source       string  `json:"source" example:"stripe"`
+	PayloadHash  string  `json:"payload_hash"`
+	RawPayload   string  `json:"raw_payload"`
+	Reason       string  `json:"reason" example:"order not found"`
+	Attempts     int     `json:"attempts"`
+	Status       string  `json:"status" example:"pending"`
+	ResolvedBy   *string `json:"resolved_by,omitempty"`
+	ResolvedAt   *string `json:"resolved_at,omitempty"`
+	CreatedAt    string  `json:"created_at"`
+	LastFailedAt string  `json:"last_failed_at"`
+}
+
+type DeadLetterDiscardRequest struct {
+	Note string `json:"note,omitempty" validate:"max=2000" example:"Test event sent from the provider dashboard"`
+}
+
+// PaymentEventResponse is the sanitized view of a webhook log shown to customers
+type PaymentEventResponse struct {
+	TransactionID string  `json:"transaction_id"`
+	PaymentStatus string  `json:"payment_status"`
+	ProcessedAt   *string `json:"processed_at,omitempty"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+// Allocation preview DTOs
+type AllocationPreviewRequest struct {
+	Products []OrderItemRequest `json:"products" validate:"required,min=1,dive"`
+}
+
+type AllocationResponse struct {
+	WarehouseCode string `json:"warehouse_code" example:"main"`
+	WarehouseName string `json:"warehouse_name" example:"Main warehouse"`
+	Quantity      int    `json:"quantity" example:"2"`
+	ShipDate      string `json:"ship_date" example:"2026-03-05"`
+}
+
+type ItemAllocationResponse struct {
+	ProductID   string               `json:"product_id"`
+	VariantID   *string              `json:"variant_id,omitempty"`
+	Quantity    int                  `json:"quantity"`
+	Allocations []AllocationResponse `json:"allocations"`
+	Unallocated int                  `json:"unallocated"` // Units no warehouse has in stock
+	Split       bool                 `json:"split"`       // Item would not arrive as a single complete shipment
+}
+
+type AllocationPreviewResponse struct {
+	Items          []ItemAllocationResponse `json:"items"`
+	FullyAllocated bool                     `json:"fully_allocated"`
+	ShipDate       *string                  `json:"ship_date,omitempty"` // When the last allocated unit ships
+}
+
+type CheckoutPreviewRequest struct {
+	Products []OrderItemRequest `json:"products" validate:"required,min=1,dive"`
+}
+
+// TotalsResponse is the price breakdown of a cart, worked out as checkout
+// would charge it
+type TotalsResponse struct {
+	Subtotal   float64              `json:"subtotal" example:"212.35"`
+	Discount   float64              `json:"discount" example:"0"`
+	Tax        float64              `json:"tax" example:"42.47"`
+	Surcharges float64              `json:"surcharges" example:"0"`
+	Shipping   float64              `json:"shipping" example:"0"`
+	Credits    float64              `json:"credits" example:"0"`
+	Total      float64              `json:"total" example:"254.82"`
+	Lines      []LineTotalsResponse `json:"lines"` // One per product, in the order asked
+}
+
+type LineTotalsResponse struct {
+	Base      float64 `json:"base" example:"200"` // Unit price times quantity
+	Discount  float64 `json:"discount" example:"0"`
+	Tax       float64 `json:"tax" example:"40"`
+	Surcharge float64 `json:"surcharge" example:"0"`
+	Total     float64 `json:"total" example:"240"`
+}
+
+// Rate limit DTOs
+type QuotaResponse struct {
+	Limit     int    `json:"limit"`
+	Remaining int    `json:"remaining"`
+	ResetAt   string `json:"reset_at"`
+	ResetIn   int    `json:"reset_in"` // Seconds until the budget is restored
+}
+
+// Email template DTOs
+type EmailTemplateRequest struct {
+	Subject    string                 `json:"subject" validate:"required,max=255"`
+	Body       string                 `json:"body" validate:"required"`
+	SampleData map[string]interface{} `json:"sample_data,omitempty"` // Values used to preview the template
+}
+
+type EmailTemplateResponse struct {
+	ID         string          `json:"id"`
+	Key        string          `json:"key"`
+	Version    int             `json:"version"`
+	Subject    string          `json:"subject"`
+	Body       string          `json:"body"`
+	SampleData json.RawMessage `json:"sample_data,omitempty" swaggertype:"object"`
+	CreatedBy  *string         `json:"created_by,omitempty"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+type EmailPreviewRequest struct {
+	Version int                    `json:"version,omitempty" validate:"gte=0"` // Defaults to the latest version
+	Data    map[string]interface{} `json:"data,omitempty"`                     // Defaults to the template's sample data
+}
+
+type EmailTestSendRequest struct {
+	Version   int                    `json:"version,omitempty" validate:"gte=0"`
+	Recipient string                 `json:"recipient,omitempty" validate:"omitempty,email"` // Defaults to the caller's email
+	Data      map[string]interface{} `json:"data,omitempty"`
+}
+
+type RenderedEmailResponse struct {
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Catalog report DTOs
+type CatalogReportResponse struct {
+	ID          string                 `json:"id"`
+	Trigger     string                 `json:"trigger"` // manual or scheduled
+	TriggeredBy *string                `json:"triggered_by,omitempty"`
+	Summary     CatalogReportSummary   `json:"summary"`
+	Issues      []CatalogIssueResponse `json:"issues"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+// CatalogReportSummary counts all of the report's issues, regardless of any severity filter
+type CatalogReportSummary struct {
+	Critical int `json:"critical"`
+	Warnings int `json:"warnings"`
+	Info     int `json:"info"`
+}
+
+type CatalogIssueResponse struct {
+	Code         string `json:"code" example:"zero_price"`
+	Severity     string `json:"severity" example:"critical"`
+	ResourceType string `json:"resource_type" example:"product"` // product, variant or category
+	ResourceID   string `json:"resource_id"`
+	Message      string `json:"message"`
+	Link         string `json:"link" example:"/api/products/550e8400-e29b-41d4-a716-446655440000"` // Where to fix the issue
+}
+
+// Recall DTOs
+type RecallTargetRequest struct {
+	SKU       string  `json:"sku,omitempty" example:"HEAT-1500"`                                 // Recalls one variant
+	ProductID *string `json:"product_id,omitempty" validate:"omitempty,uuid"`                    // Recalls the product and all its variants when no SKU is given
+	Lot       string  `json:"lot,omitempty" validate:"max=100" example:"L-2024-07"`              // Quoted in notices, orders do not record lots
+	From      string  `json:"from" validate:"required,datetime=2006-01-02" example:"2024-01-01"` // First order date, inclusive
+	To        string  `json:"to" validate:"required,datetime=2006-01-02" example:"2024-03-31"`   // Last order date, inclusive
+}
+
+type RecallRequest struct {
+	RecallTargetRequest
+	Title       string `json:"title" validate:"required,max=200" example:"Overheating risk"`
+	Description string `json:"description" validate:"required" example:"Stop using the heater and return it for a full refund."`
+}
+
+type AffectedOrdersResponse struct {
+	ProductID string                  `json:"product_id"`
+	VariantID *string                 `json:"variant_id,omitempty"`
+	SKU       string                  `json:"sku,omitempty"`
+	Customers int                     `json:"customers"`
+	Units     int                     `json:"units"`
+	Orders    []AffectedOrderResponse `json:"orders"`
+}
+
+type AffectedOrderResponse struct {
+	OrderID    string  `json:"order_id"`
+	UserID     *string `json:"user_id,omitempty"` // Missing for orders placed without an account, which can't be notified
+	CustomerID int     `json:"customer_id"`
+	Quantity   int     `json:"quantity"`
+	OrderedAt  string  `json:"ordered_at"`
+}
+
+type RecallResponse struct {
+	ID          string                 `json:"id"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	ProductID   string                 `json:"product_id"`
+	VariantID   *string                `json:"variant_id,omitempty"`
+	SKU         string                 `json:"sku,omitempty"`
+	Lot         string                 `json:"lot,omitempty"`
+	From        string                 `json:"from"`
+	To          string                 `json:"to"`
+	Summary     RecallSummary          `json:"summary"`
+	Notices     []RecallNoticeResponse `json:"notices,omitempty"` // Only returned for a single recall
+	CreatedBy   string                 `json:"created_by"`
+	CreatedAt   string                 `json:"created_at"`
+}
+
+type RecallSummary struct {
+	Orders       int `json:"orders"`
+	Customers    int `json:"customers"`
+	Units        int `json:"units"`
+	Sent         int `json:"sent"`
+	Failed       int `json:"failed"`
+	Unreachable  int `json:"unreachable"`
+	Acknowledged int `json:"acknowledged"`
+}
+
+type RecallNoticeResponse struct {
+	ID             string  `json:"id"`
+	OrderID        string  `json:"order_id"`
+	UserID         *string `json:"user_id,omitempty"`
+	CustomerID     int     `json:"customer_id"`
+	Quantity       int     `json:"quantity"`
+	Status         string  `json:"status" example:"sent"` // pending, sent, failed, unreachable or acknowledged
+	Error          string  `json:"error,omitempty"`
+	SentAt         *string `json:"sent_at,omitempty"`
+	AcknowledgedAt *string `json:"acknowledged_at,omitempty"`
+}
+
+// MyRecallNoticeResponse is a recall notice as shown to the customer
+type MyRecallNoticeResponse struct {
+	ID             string  `json:"id"`
+	OrderID        string  `json:"order_id"`
+	Quantity       int     `json:"quantity"`
+	Title          string  `json:"title"`
+	Description    string  `json:"description"`
+	SKU            string  `json:"sku,omitempty"`
+	Lot            string  `json:"lot,omitempty"`
+	Acknowledged   bool    `json:"acknowledged"`
+	AcknowledgedAt *string `json:"acknowledged_at,omitempty"`
+	CreatedAt      string  `json:"created_at"`
+}
+
+// Search ranking DTOs
+type RankingRuleRequest struct {
+	Type      string  `json:"type" validate:"required,oneof=boost_in_stock boost_margin pin" example:"pin"` // boost_in_stock, boost_margin or pin
+	Weight    float64 `json:"weight,omitempty" validate:"gte=0" example:"5"`                                // Boosts only
+	Query     string  `json:"query,omitempty" example:"laptop"`                                             // Pins only
+	ProductID *string `json:"product_id,omitempty" validate:"omitempty,uuid"`                               // Pins only
+	Position  int     `json:"position,omitempty" validate:"gte=0" example:"1"`                              // Pins only, 1-based
+	Active    *bool   `json:"active,omitempty" example:"true"`                                              // Defaults to true
+}
+
+type RankingRuleResponse struct {
+	ID        string  `json:"id"`
+	Type      string  `json:"type"`
+	Weight    float64 `json:"weight,omitempty"`
+	Query     string  `json:"query,omitempty"`
+	ProductID *string `json:"product_id,omitempty"`
+	Position  int     `json:"position,omitempty"`
+	Active    bool    `json:"active"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// SearchExplanationResponse shows why a page of search results ranked as it did
+type SearchExplanationResponse struct {
+	Query      string                  `json:"query"`
+	Results    []RankedProductResponse `json:"results"`
+	Rules      []RankingRuleResponse   `json:"rules"` // Active rules applied to the query
+	Pagination *Pagination             `json:"pagination"`
+}
+
+func (r SearchExplanationResponse) PageInfo() *Pagination { return r.Pagination }
+
+type RankedProductResponse struct {
+	Rank          int                           `json:"rank"`
+	ProductID     string                        `json:"product_id"`
+	Name          string                        `json:"name"`
+	Score         float64                       `json:"score"`
+	Pinned        bool                          `json:"pinned"` // Placed by a pin rule, whatever its score
+	Contributions []RankingContributionResponse `json:"contributions"`
+}
+
+type RankingContributionResponse struct {
+	Source string  `json:"source" example:"boost_margin"` // text, boost_in_stock, boost_margin or pin
+	RuleID *string `json:"rule_id,omitempty"`
+	Score  float64 `json:"score"`
+	Detail string  `json:"detail" example:"Margin 35%"`
+}
+
+// Analytics responses report whole UTC days: from and to are inclusive dates
+type RevenueReportResponse struct {
+	Period   string                 `json:"period" example:"day"`
+	From     string                 `json:"from" example:"2024-05-01"`
+	To       string                 `json:"to" example:"2024-05-31"`
+	TimeZone string                 `json:"time_zone" example:"UTC"` // Zone the days and periods are in
+	Total    float64                `json:"total"`
+	Points   []RevenuePointResponse `json:"points"`
+}
+
+type RevenuePointResponse struct {
+	PeriodStart string  `json:"period_start" example:"2024-05-13"`
+	Orders      int     `json:"orders"`
+	Revenue     float64 `json:"revenue"`
+}
+
+type TopProductsResponse struct {
+	From     string                 `json:"from"`
+	To       string                 `json:"to"`
+	TimeZone string                 `json:"time_zone"`
+	Products []ProductSalesResponse `json:"products"`
+}
+
+type ProductSalesResponse struct {
+	ProductID string  `json:"product_id"`
+	Name      string  `json:"name"` // Empty when the product no longer exists
+	Units     int     `json:"units"`
+	Revenue   float64 `json:"revenue"`
+}
+
+type OrderStatusCountsResponse struct {
+	From     string                     `json:"from"`
+	To       string                     `json:"to"`
+	TimeZone string                     `json:"time_zone"`
+	Counts   []OrderStatusCountResponse `json:"counts"`
+}
+
+type OrderStatusCountResponse struct {
+	Status string `json:"status" example:"completed"`
+	Count  int    `json:"count"`
+}
+
+type SalesSummaryResponse struct {
+	From              string  `json:"from"`
+	To                string  `json:"to"`
+	TimeZone          string  `json:"time_zone"`
+	Orders            int     `json:"orders"`      // Every order placed
+	PaidOrders        int     `json:"paid_orders"` // Paid and not cancelled, the orders revenue counts
+	Revenue           float64 `json:"revenue"`
+	AverageOrderValue float64 `json:"average_order_value"`
+	NewCustomers      int     `json:"new_customers"`
+}
+
+// Webhook subscription DTOs
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2048" example:"https://erp.example.com/hooks/store"`
+	Events      []string `json:"events" validate:"required,min=1" example:"order.*,stock.changed"` // Event types, "order.*" for every event of a resource or "*" for all
+	Secret      string   `json:"secret,omitempty" validate:"omitempty,min=16,max=255"`             // Generated when left out
+	Description string   `json:"description,omitempty" validate:"max=255" example:"ERP order sync"`
+}
+
+// WebhookSubscriptionUpdateRequest changes the fields that are set
+type WebhookSubscriptionUpdateRequest struct {
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url,max=2048"`
+	Events      []string `json:"events,omitempty" validate:"omitempty,min=1"`
+	Secret      *string  `json:"secret,omitempty" validate:"omitempty,min=16,max=255"` // Rotates the signing secret
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	Active      *bool    `json:"active,omitempty" example:"false"` // Paused subscriptions get no new deliveries
+}
+
+type WebhookSubscriptionResponse struct {
+	ID          string   `json:"id"`
+	URL         string   `json:"url"`
+	Events      []string `json:"events"`
+	Secret      string   `json:"secret,omitempty"` // Only returned when the subscription is created
+	Description string   `json:"description,omitempty"`
+	Active      bool     `json:"active"`
+	CreatedBy   *string  `json:"created_by,omitempty"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID             string  `json:"id"`
+	EventID        string  `json:"event_id"` // Sent in X-Event-ID, the same for every subscription of the event
+	EventType      string  `json:"event_type" example:"order.created"`
+	Status         string  `json:"status" example:"pending"` // pending, succeeded or failed
+	Attempts       int     `json:"attempts"`
+	ResponseStatus int     `json:"response_status,omitempty"` // HTTP status of the last attempt
+	LastError      string  `json:"last_error,omitempty"`
+	NextAttemptAt  *string `json:"next_attempt_at,omitempty"`
+	DeliveredAt    *string `json:"delivered_at,omitempty"`
+	Payload        string  `json:"payload"` // The signed body, as sent
+	CreatedAt      string  `json:"created_at"`
+}
+
+// Blocklist DTOs
+
+type BlocklistEntryRequest struct {
+	Kind   string `json:"kind" validate:"required,oneof=email domain ip_range" example:"domain"`                   // email, domain or ip_range
+	Value  string `json:"value" validate:"required,max=255" example:"mailinator.com"`                              // An address, a domain, or an IP address or CIDR range
+	Reason string `json:"reason,omitempty" validate:"max=255" example:"Disposable addresses used for chargebacks"` // Why it is blocked, for the admins
+}
+
+type BlocklistEntryResponse struct {
+	ID        string  `json:"id"`
+	Kind      string  `json:"kind" example:"domain"`
+	Value     string  `json:"value" example:"mailinator.com"` // Lower case; an IP address is stored as a /32 or /128 range
+	Reason    string  `json:"reason,omitempty"`
+	CreatedBy *string `json:"created_by,omitempty"`
+	CreatedAt string  `json:"created_at"`
+}
+
+// Access code DTOs
+
+type CreateAccessCodeRequest struct {
+	Code      string  `json:"code,omitempty" validate:"omitempty,min=4,max=64" example:"BETA-FRIENDS"` // Generated when empty; letters, digits, dashes and underscores, matched case-insensitively
+	Note      string  `json:"note,omitempty" validate:"max=255" example:"Newsletter subscribers"`      // Who the code is for, for the admins
+	MaxUses   int     `json:"max_uses,omitempty" validate:"gte=0" example:"100"`                       // Registrations the code allows, 0 for no limit
+	ExpiresAt *string `json:"expires_at,omitempty" example:"2026-12-31T23:59:59Z"`                     // RFC 3339, never when empty
+}
+
+// UpdateAccessCodeRequest replaces the note, limits and status of a code
+type UpdateAccessCodeRequest struct {
+	Note      string  `json:"note" validate:"max=255" example:"Newsletter subscribers"`
+	MaxUses   int     `json:"max_uses" validate:"gte=0" example:"100"`
+	ExpiresAt *string `json:"expires_at" example:"2026-12-31T23:59:59Z"` // RFC 3339, null for never
+	Active    bool    `json:"active" example:"true"`
+}
+
+type AccessCodeResponse struct {
+	ID        string  `json:"id"`
+	Code      string  `json:"code" example:"BETA-FRIENDS"`
+	Note      string  `json:"note,omitempty"`
+	MaxUses   int     `json:"max_uses" example:"100"` // 0 for no limit
+	Uses      int     `json:"uses" example:"12"`      // Registrations made with the code
+	ExpiresAt *string `json:"expires_at,omitempty"`
+	Active    bool    `json:"active"`
+	CreatedBy *string `json:"created_by,omitempty"`
+	CreatedAt string  `json:"created_at"`
+	UpdatedAt string  `json:"updated_at"`
+}
+
+// Draft order DTOs
+
+type DraftOrderRequest struct {
+	CustomerID int                `json:"customer_id" validate:"gt=0" example:"123"`
+	UserID     *string            `json:"user_id,omitempty" validate:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`   // Account the order is placed for; required to send a payment link
+	Note       string             `json:"note,omitempty" validate:"max=2000" example:"As agreed on the phone, delivery after the 15th"` // Shown to the customer with the payment link
+	Products   []OrderItemRequest `json:"products" validate:"required,min=1,max=100,dive"`
+}
+
+type DraftOrderItemResponse struct {
+	ProductID string  `json:"product_id"`
+	VariantID *string `json:"variant_id,omitempty"`
+	Quantity  int     `json:"quantity"`
+}
+
+type DraftOrderResponse struct {
+	ID            string                   `json:"id"`
+	CustomerID    int                      `json:"customer_id"`
+	UserID        *string                  `json:"user_id,omitempty"`
+	Status        string                   `json:"status"` // open, sent, converted or cancelled
+	Note          string                   `json:"note,omitempty"`
+	Products      []DraftOrderItemResponse `json:"products"`
+	PaymentLink   string                   `json:"payment_link,omitempty"` // While the draft is sent
+	LinkExpiresAt *string                  `json:"link_expires_at,omitempty"`
+	SentAt        *string                  `json:"sent_at,omitempty"`
+	OrderID       *string                  `json:"order_id,omitempty"` // The order it was converted to
+	ConvertedAt   *string                  `json:"converted_at,omitempty"`
+	CreatedBy     *string                  `json:"created_by,omitempty"`
+	Totals        *TotalsResponse          `json:"totals,omitempty"` // At today's prices; left out when the products can't be ordered as they stand
+	CreatedAt     string                   `json:"created_at"`
+	UpdatedAt     string                   `json:"updated_at"`
+}
+
+// DraftOrderLinkResponse is what the customer sees through a payment link.
+// Its totals are a quote: checkout prices the order again when the draft is
+// accepted.
+type DraftOrderLinkResponse struct {
+	Note          string                   `json:"note,omitempty"`
+	Products      []DraftOrderItemResponse `json:"products"`
+	LinkExpiresAt *string                  `json:"link_expires_at,omitempty"`
+	Totals        *TotalsResponse          `json:"totals,omitempty"` // What accepting the link would charge today; left out when the products can't be ordered as they stand
+}
+
+// Loyalty DTOs
+
+// Loyalty is the points balance of the authenticated customer with a page of
+// its history
+type Loyalty struct {
+	Balance      int                          `json:"balance" example:"1250"`
+	BalanceValue float64                      `json:"balance_value" example:"12.5"` // Discount the balance gives at checkout
+	EarnRate     float64                      `json:"earn_rate" example:"1"`        // Points per 1.00 paid for a completed order
+	PointValue   float64                      `json:"point_value" example:"0.01"`   // Discount a redeemed point gives
+	Transactions []LoyaltyTransactionResponse `json:"transactions"`
+}
+
+type LoyaltyTransactionResponse struct {
+	ID        string  `json:"id"`
+	OrderID   *string `json:"order_id,omitempty"`
+	Type      string  `json:"type" example:"earned"` // earned, redeemed, restored or reversed
+	Points    int     `json:"points" example:"120"`  // Negative when points were spent or taken back
+	CreatedAt string  `json:"created_at"`
+}
+
+// Store settings DTOs
+
+// StoreSettingsResponse is the store configuration front-ends render with
+type StoreSettingsResponse struct {
+	Store    StoreInfoResponse        `json:"store"`
+	Checkout CheckoutSettingsResponse `json:"checkout"`
+}
+
+type StoreInfoResponse struct {
+	Name         string `json:"name" example:"Go E-Commerce"`
+	ContactEmail string `json:"contact_email,omitempty" example:"help@example.com"`
+	Currency     string `json:"currency" example:"USD"` // ISO 4217 code prices are shown in
+	LogoURL      string `json:"logo_url,omitempty" example:"https://cdn.example.com/logo.png"`
+}
+
+type CheckoutSettingsResponse struct {
+	TermsURL       string `json:"terms_url,omitempty" example:"https://example.com/terms"`
+	RequireTerms   bool   `json:"require_terms" example:"true"` // Customers must accept the terms to check out
+	SuccessMessage string `json:"success_message,omitempty" example:"Thanks for your order!"`
+}
+
+// UpdateStoreSettingsRequest sets settings by key. Values are strings or
+// booleans; null resets a setting to its default. Keys left out are unchanged.
+type UpdateStoreSettingsRequest struct {
+	Settings map[string]interface{} `json:"settings" validate:"required,min=1" swaggertype:"object" example:"store.name:My Shop"`
+}
+
+// StoreSettingResponse is a store setting as admins edit it
+type StoreSettingResponse struct {
+	Key         string  `json:"key" example:"store.currency"`
+	Type        string  `json:"type" example:"currency"` // text, email, url, currency or bool
+	Value       string  `json:"value" example:"EUR"`
+	Default     string  `json:"default" example:"USD"`
+	Set         bool    `json:"set"` // False while the setting takes its default
+	Description string  `json:"description"`
+	UpdatedBy   *string `json:"updated_by,omitempty"`
+	UpdatedAt   *string `json:"updated_at,omitempty"`
+}
+
+// MaintenanceRequest switches maintenance mode on or off. Fields left out
+// keep their current value; an empty message restores the default one.
+type MaintenanceRequest struct {
+	Enabled           bool    `json:"enabled" example:"true"`
+	Message           *string `json:"message,omitempty" validate:"omitempty,max=500" example:"Back in 10 minutes"`
+	RetryAfterSeconds *int    `json:"retry_after_seconds,omitempty" validate:"omitempty,gte=1,lte=86400" example:"600"`
+}
+
+// MaintenanceResponse is the maintenance mode of this API instance
+type MaintenanceResponse struct {
+	Enabled           bool    `json:"enabled"`
+	Message           string  `json:"message" example:"Back in 10 minutes"` // Sent to blocked clients
+	RetryAfterSeconds int     `json:"retry_after_seconds" example:"600"`
+	Since             *string `json:"since,omitempty"` // When the mode was last switched
+	UpdatedBy         *string `json:"updated_by,omitempty"`
+}
+
+// HealthResponse tells load balancers the API is up, in maintenance or not
+type HealthResponse struct {
+	Status      string `json:"status" example:"ok"`
+	Maintenance bool   `json:"maintenance"`
+}
+
+// MessageResponse confirms an action that has no resource to return
+type MessageResponse struct {
+	Message string `json:"message"`
 }
 
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// ValidationErrorResponse reports problems with single fields of a request
+// body, one message per field: 422 when values break the validation tags of
+// the DTO, 400 when a field is unknown or has the wrong JSON type
+type ValidationErrorResponse struct {
+	Error  string            `json:"error" example:"Validation failed"`
+	Errors map[string]string `json:"errors" swaggertype:"object" example:"price:must be >= 0"`
+}
+
 // Type aliases for backward compatibility and cleaner Swagger docs
 type ProductListResponse = PaginatedResponse[ProductResponse]
+type ProductSummaryListResponse = PaginatedResponse[ProductSummaryResponse]
 type OrderListResponse = PaginatedResponse[OrderResponse]
 type ProductVariantListResponse = PaginatedResponse[ProductVariantResponse]
 type CategoryListResponse = PaginatedResponse[CategoryResponse]
+type WebhookLogListResponse = PaginatedResponse[WebhookLogResponse]
+type DeadLetterWebhookListResponse = PaginatedResponse[DeadLetterWebhookResponse]
+type PaymentEventListResponse = PaginatedResponse[PaymentEventResponse]
+type StockMovementListResponse = PaginatedResponse[StockMovementResponse]
+type PriceChangeListResponse = PaginatedResponse[PriceChangeResponse]
+type ProductReviewListResponse = PaginatedResponse[ProductReviewResponse]
+type AuditLogListResponse = PaginatedResponse[AuditLogResponse]
+type AdminAlertListResponse = PaginatedResponse[AdminAlertResponse]
+type RecallListResponse = PaginatedResponse[RecallResponse]
+type ReturnListResponse = PaginatedResponse[ReturnResponse]
+type DraftOrderListResponse = PaginatedResponse[DraftOrderResponse]
+type WebhookDeliveryListResponse = PaginatedResponse[WebhookDeliveryResponse]
+type CategoryProductsResponse = Response[CategoryProducts]
+type LoyaltyResponse = Response[Loyalty]
@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Fields is a sparse fieldset: the attributes of a resource a client asked
+// for with ?fields=id,name,price. Nil selects every attribute.
+type Fields []string
+
+// ParseFields reads a comma separated list of attributes of the response T,
+// by their JSON names. An empty value selects every attribute, an attribute T
+// doesn't have is a ValidationError. The attributes keep T's order.
+func ParseFields[T any](raw string) (Fields, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	available := jsonNames(reflect.TypeFor[T]())
+	wanted := make(map[string]bool)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !slices.Contains(available, name) {
+			return nil, entity.ValidationError(fmt.Sprintf("Unknown field %q, use any of %s", name, strings.Join(available, ", ")))
+		}
+		wanted[name] = true
+	}
+	if len(wanted) == 0 {
+		return nil, nil
+	}
+
+	fields := make(Fields, 0, len(wanted))
+	for _, name := range available {
+		if wanted[name] {
+			fields = append(fields, name)
+		}
+	}
+	return fields, nil
+}
+
+// SelectFields returns the response with only the selected attributes, or
+// the response itself when every attribute is selected
+func SelectFields[T any](v T, fields Fields) interface{} {
+	if fields == nil {
+		return v
+	}
+	return selection{fields: fields, value: v}
+}
+
+// SelectPageFields is SelectFields for every item of a page, keeping its
+// pagination
+func SelectPageFields[T any](page Response[[]T], fields Fields) Paginated {
+	if fields == nil {
+		return page
+	}
+
+	items := make([]interface{}, len(page.Data))
+	for i, item := range page.Data {
+		items[i] = selection{fields: fields, value: item}
+	}
+	return Response[[]interface{}]{Data: items, Pagination: page.Pagination}
+}
+
+// selection encodes the selected attributes of a response, in the order of
+// the fieldset. Attributes left out by omitempty stay out.
+type selection struct {
+	fields Fields
+	value  interface{}
+}
+
+func (s selection) MarshalJSON() ([]byte, error) {
+	encoded, err := json.Marshal(s.value)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &attributes); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, name := range s.fields {
+		value, ok := attributes[name]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonNames lists the JSON attribute names of a struct type in field order
+func jsonNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+func TestParseFields(t *testing.T) {
+	fields, err := ParseFields[OrderResponse](" status, id,,total_price ")
+	if err != nil {
+		t.Fatalf("ParseFields() error = %v", err)
+	}
+	// Attributes keep the order of the response
+	if want := (Fields{"id", "total_price", "status"}); !reflect.DeepEqual(fields, want) {
+		t.Errorf("ParseFields() = %v, want %v", fields, want)
+	}
+
+	if fields, err := ParseFields[OrderResponse](""); fields != nil || err != nil {
+		t.Errorf("ParseFields(\"\") = %v, %v, want every attribute", fields, err)
+	}
+
+	if _, err := ParseFields[OrderResponse]("id,name"); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("ParseFields() error = %v, want a validation error", err)
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	parentID := "parent"
+	category := CategoryResponse{ID: "1", Name: "Shoes", Slug: "shoes", ParentID: &parentID}
+
+	fields, _ := ParseFields[CategoryResponse]("slug,parent_id")
+	encoded, err := json.Marshal(SelectFields(category, fields))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if got, want := string(encoded), `{"slug":"shoes","parent_id":"parent"}`; got != want {
+		t.Errorf("SelectFields() = %s, want %s", got, want)
+	}
+
+	// Attributes omitted when empty stay out
+	category.ParentID = nil
+	encoded, _ = json.Marshal(SelectFields(category, fields))
+	if got, want := string(encoded), `{"slug":"shoes"}`; got != want {
+		t.Errorf("SelectFields() = %s, want %s", got, want)
+	}
+
+	if got := SelectFields(category, nil); !reflect.DeepEqual(got, category) {
+		t.Errorf("SelectFields(nil) = %v, want the response itself", got)
+	}
+}
+
+func TestSelectPageFields(t *testing.T) {
+	page := Response[[]CategoryResponse]{
+		Data:       []CategoryResponse{{ID: "1", Name: "Shoes"}, {ID: "2", Name: "Hats"}},
+		Pagination: NewPagination(1, 10, 2),
+	}
+
+	fields, _ := ParseFields[CategoryResponse]("name")
+	selected := SelectPageFields(page, fields)
+	if selected.PageInfo() != page.Pagination {
+		t.Errorf("SelectPageFields() lost the pagination")
+	}
+
+	encoded, _ := json.Marshal(selected)
+	var decoded struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	json.Unmarshal(encoded, &decoded)
+	if len(decoded.Data) != 2 || len(decoded.Data[0]) != 1 || decoded.Data[1]["name"] != "Hats" {
+		t.Errorf("SelectPageFields() = %s", encoded)
+	}
+}
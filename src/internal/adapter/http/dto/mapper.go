@@ -1,35 +1,156 @@
 package dto
 
 import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/jobs"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
 )
 
 // Product Mappers
 func ToProductResponse(product *entity.Product) ProductResponse {
 	categories := make([]CategoryResponse, 0, len(product.Categories))
-	for _, cat := range product.Categories {
-		categories = append(categories, CategoryResponse{
-			ID:   cat.ID.String(),
-			Name: cat.Name,
-		})
+	for i := range product.Categories {
+		categories = append(categories, ToCategoryResponse(&product.Categories[i]))
+	}
+
+	options := make([]ProductOptionResponse, 0, len(product.Options))
+	for i := range product.Options {
+		options = append(options, ToProductOptionResponse(&product.Options[i]))
 	}
 
-	// Map variants
 	variants := make([]ProductVariantResponse, 0, len(product.Variants))
-	for _, variant := range product.Variants {
+	for i := range product.Variants {
+		// The variants of a product are loaded without it, their price falls back on it
+		variant := product.Variants[i]
+		if variant.Product == nil {
+			variant.Product = product
+		}
 		variants = append(variants, ToProductVariantResponse(&variant))
 	}
 
-	return ProductResponse{
-		ID:          product.ID.String(),
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Quantity:    product.Quantity,
-		Categories:  categories,
-		Variants:    variants,
-		CreatedAt:   product.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:   product.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	attributes := make([]ProductAttributeResponse, 0, len(product.Attributes))
+	for i := range product.Attributes {
+		attributes = append(attributes, ToProductAttributeResponse(&product.Attributes[i]))
+	}
+
+	response := ProductResponse{
+		ID:             product.ID.String(),
+		Name:           product.Name,
+		Description:    product.Description,
+		Price:          product.Price,
+		EffectivePrice: product.EffectivePrice(),
+		Quantity:       product.Quantity,
+		HighDemandMode: product.HighDemandMode,
+		Status:         string(product.Status),
+		AvailableFrom:  formatOptionalTime(product.AvailableFrom),
+		AvailableUntil: formatOptionalTime(product.AvailableUntil),
+		MaxPerOrder:    product.MaxPerOrder,
+		MaxPerCustomer: product.MaxPerCustomer,
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+		ContentHash:    product.ContentHash(),
+		UnitPricing:    toUnitPricingResponse(product),
+		Categories:     categories,
+		Options:        options,
+		Variants:       variants,
+		Attributes:     attributes,
+		CreatedAt:      FormatTime(product.CreatedAt),
+		UpdatedAt:      FormatTime(product.UpdatedAt),
+	}
+
+	// A translation without a description keeps the original one
+	if translation := product.Translation; translation != nil {
+		response.Name, response.Locale = translation.Name, translation.Locale
+		if translation.Description != "" {
+			response.Description = translation.Description
+		}
+	}
+	return response
+}
+
+func ToProductTranslationResponse(translation *entity.ProductTranslation) ProductTranslationResponse {
+	return ProductTranslationResponse{
+		ProductID:   translation.ProductID.String(),
+		Locale:      translation.Locale,
+		Name:        translation.Name,
+		Description: translation.Description,
+		CreatedAt:   FormatTime(translation.CreatedAt),
+		UpdatedAt:   FormatTime(translation.UpdatedAt),
+	}
+}
+
+// Attribute Mappers
+func ToAttributeResponse(definition *entity.AttributeDefinition) AttributeResponse {
+	return AttributeResponse{
+		ID:        definition.ID.String(),
+		Code:      definition.Code,
+		Name:      definition.Name,
+		Type:      string(definition.Type),
+		CreatedAt: FormatTime(definition.CreatedAt),
+	}
+}
+
+func ToProductAttributeResponse(attribute *entity.ProductAttribute) ProductAttributeResponse {
+	response := ProductAttributeResponse{
+		AttributeID: attribute.AttributeID.String(),
+		Value:       attribute.Value(),
+	}
+	if attribute.Attribute != nil {
+		response.Code = attribute.Attribute.Code
+		response.Name = attribute.Attribute.Name
+		response.Type = string(attribute.Attribute.Type)
+	}
+	return response
+}
+
+func ToProductSummaryResponse(product *entity.Product) ProductSummaryResponse {
+	response := ProductSummaryResponse{
+		ID:             product.ID.String(),
+		Name:           product.Name,
+		Price:          product.Price,
+		EffectivePrice: product.EffectivePrice(),
+		Stock:          product.Quantity,
+		Status:         string(product.Status),
+		RatingAverage:  product.RatingAverage,
+		RatingCount:    product.RatingCount,
+	}
+	if translation := product.Translation; translation != nil {
+		response.Name, response.Locale = translation.Name, translation.Locale
+	}
+	return response
+}
+
+func ToProductSummaryListResponse(products []*entity.Product, total, page, pageSize int) PaginatedResponse[ProductSummaryResponse] {
+	summaries := make([]ProductSummaryResponse, 0, len(products))
+	for _, product := range products {
+		summaries = append(summaries, ToProductSummaryResponse(product))
+	}
+
+	return PaginatedResponse[ProductSummaryResponse]{
+		Data:       summaries,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+func ToUnitMeasure(req ProductRequest) entity.UnitMeasure {
+	return entity.UnitMeasure{Unit: entity.MeasurementUnit(req.MeasurementUnit), Content: req.UnitContent}
+}
+
+func toUnitPricingResponse(product *entity.Product) *UnitPricingResponse {
+	if !product.Measure.IsSet() {
+		return nil
+	}
+	return &UnitPricingResponse{
+		MeasurementUnit: string(product.Measure.Unit),
+		UnitContent:     product.Measure.Content,
+		UnitPrice:       product.UnitPrice(),
 	}
 }
 
@@ -39,64 +160,158 @@ func ToProductListResponse(products []*entity.Product, total, page, pageSize int
 		productResponses = append(productResponses, ToProductResponse(product))
 	}
 
-	totalPages := (total + pageSize - 1) / pageSize
-	if total == 0 {
-		totalPages = 0
-	}
-
 	return PaginatedResponse[ProductResponse]{
-		Data: productResponses,
-		Pagination: Pagination{
-			Page:       page,
-			PageSize:   pageSize,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Data:       productResponses,
+		Pagination: NewPagination(page, pageSize, total),
 	}
 }
 
 // Order Mappers
 func ToOrderResponse(order *entity.Order) OrderResponse {
-	products := make([]OrderItemResponse, 0, len(order.Products))
-	for _, product := range order.Products {
-		products = append(products, OrderItemResponse{
-			ProductID: product.ProductID.String(),
-			Quantity:  product.Quantity,
-			Subtotal:  product.Subtotal(),
+	return newOrderLines(order).toOrderResponse(order)
+}
+
+func ToOrderStatusBatchResponse(orders []*entity.Order, notFound []uuid.UUID) OrderStatusBatchResponse {
+	response := OrderStatusBatchResponse{
+		Orders:   make([]OrderStatusResponse, 0, len(orders)),
+		NotFound: make([]string, 0, len(notFound)),
+	}
+	for _, order := range orders {
+		response.Orders = append(response.Orders, OrderStatusResponse{
+			ID:            order.ID.String(),
+			Status:        string(order.Status),
+			PaymentStatus: string(order.PaymentStatus),
+			TotalPrice:    order.TotalPrice,
+			Balance:       order.Balance(),
+			UpdatedAt:     FormatTime(order.UpdatedAt),
+		})
+	}
+	for _, id := range notFound {
+		response.NotFound = append(response.NotFound, id.String())
+	}
+	return response
+}
+
+func ToBulkOrderStatusResponse(report *entity.BulkOrderStatusReport) BulkOrderStatusResponse {
+	response := BulkOrderStatusResponse{
+		Status:    string(report.Status),
+		Updated:   report.Count(entity.OrderStatusUpdated),
+		Unchanged: report.Count(entity.OrderStatusUnchanged),
+		Failed:    report.Count(entity.OrderStatusNotFound) + report.Count(entity.OrderStatusRejected),
+		Orders:    make([]OrderStatusResultResponse, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		item := OrderStatusResultResponse{
+			OrderID: result.OrderID.String(),
+			Result:  string(result.Outcome),
+			Message: result.Message,
+		}
+		if result.StatusBefore != nil {
+			before, after := string(*result.StatusBefore), string(*result.StatusAfter)
+			item.StatusBefore, item.StatusAfter = &before, &after
+		}
+		response.Orders = append(response.Orders, item)
+	}
+	return response
+}
+
+// ToOrderItemComponentResponses lists the line's components. Lines stored
+// before components existed are shown with their base amount only.
+func ToOrderItemComponentResponses(item *entity.OrderItem) []OrderItemComponentResponse {
+	return appendOrderItemComponents(make([]OrderItemComponentResponse, 0, componentCount(item)), item)
+}
+
+func appendOrderItemComponents(components []OrderItemComponentResponse, item *entity.OrderItem) []OrderItemComponentResponse {
+	if len(item.Components) == 0 {
+		return append(components, OrderItemComponentResponse{Type: string(entity.ComponentBase), Amount: item.ComponentTotal(entity.ComponentBase)})
+	}
+
+	for _, component := range item.Components {
+		components = append(components, OrderItemComponentResponse{
+			Type:   string(component.Type),
+			Label:  component.Label,
+			Amount: component.Amount,
+		})
+	}
+	return components
+}
+
+func componentCount(item *entity.OrderItem) int {
+	return max(1, len(item.Components))
+}
+
+// orderLines hands out the line and component slices of order responses
+// from backing arrays sized for all the orders up front, so mapping a page
+// of orders allocates them once rather than once per order and per line
+type orderLines struct {
+	items      []OrderItemResponse
+	components []OrderItemComponentResponse
+}
+
+func newOrderLines(orders ...*entity.Order) *orderLines {
+	var items, components int
+	for _, order := range orders {
+		items += len(order.Products)
+		for i := range order.Products {
+			components += componentCount(&order.Products[i])
+		}
+	}
+	return &orderLines{
+		items:      make([]OrderItemResponse, 0, items),
+		components: make([]OrderItemComponentResponse, 0, components),
+	}
+}
+
+func (l *orderLines) toOrderResponse(order *entity.Order) OrderResponse {
+	start := len(l.items)
+	for i := range order.Products {
+		item := &order.Products[i]
+		l.items = append(l.items, OrderItemResponse{
+			ID:                 item.ID.String(),
+			ProductID:          item.ProductID.String(),
+			VariantID:          formatOptionalID(item.VariantID),
+			ProductName:        item.ProductName,
+			SKU:                item.SKU,
+			VariantDescription: item.VariantDescription,
+			TaxRate:            item.TaxRate,
+			Quantity:           item.Quantity,
+			Subtotal:           item.Subtotal(),
+			Components:         l.componentsOf(item),
 		})
 	}
 
 	return OrderResponse{
-		ID:            order.ID.String(),
-		CustomerID:    order.CustomerID,
-		Products:      products,
-		TotalPrice:    order.TotalPrice,
-		Status:        string(order.Status),
-		PaymentStatus: string(order.PaymentStatus),
-		CreatedAt:     order.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:     order.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		ID:             order.ID.String(),
+		CustomerID:     order.CustomerID,
+		Products:       l.items[start:len(l.items):len(l.items)],
+		TotalPrice:     order.TotalPrice,
+		Status:         string(order.Status),
+		PaymentStatus:  string(order.PaymentStatus),
+		AmountPaid:     order.AmountPaid,
+		AmountRefunded: order.AmountRefunded,
+		AmountCredited: order.AmountCredited,
+		Balance:        order.Balance(),
+		CreatedAt:      FormatTime(order.CreatedAt),
+		UpdatedAt:      FormatTime(order.UpdatedAt),
 	}
 }
 
+func (l *orderLines) componentsOf(item *entity.OrderItem) []OrderItemComponentResponse {
+	start := len(l.components)
+	l.components = appendOrderItemComponents(l.components, item)
+	return l.components[start:len(l.components):len(l.components)]
+}
+
 func ToOrderListResponse(orders []*entity.Order, total, page, pageSize int) PaginatedResponse[OrderResponse] {
+	lines := newOrderLines(orders...)
 	orderResponses := make([]OrderResponse, 0, len(orders))
 	for _, order := range orders {
-		orderResponses = append(orderResponses, ToOrderResponse(order))
-	}
-
-	totalPages := (total + pageSize - 1) / pageSize
-	if total == 0 {
-		totalPages = 0
+		orderResponses = append(orderResponses, lines.toOrderResponse(order))
 	}
 
 	return PaginatedResponse[OrderResponse]{
-		Data: orderResponses,
-		Pagination: Pagination{
-			Page:       page,
-			PageSize:   pageSize,
-			Total:      total,
-			TotalPages: totalPages,
-		},
+		Data:       orderResponses,
+		Pagination: NewPagination(page, pageSize, total),
 	}
 }
 
@@ -104,18 +319,47 @@ func ToOrderListResponse(orders []*entity.Order, total, page, pageSize int) Pagi
 func ToProductVariantResponse(variant *entity.ProductVariant) ProductVariantResponse {
 	price, _ := variant.GetPrice() // Ignoring error for response mapping
 
+	options := make([]VariantOptionResponse, 0, len(variant.Options))
+	for _, option := range variant.Options {
+		options = append(options, VariantOptionResponse{Name: option.Name, Value: option.Value})
+	}
+
 	return ProductVariantResponse{
 		ID:            variant.ID.String(),
 		ProductID:     variant.ProductID.String(),
-		VariantName:   variant.VariantName,
-		VariantValue:  variant.VariantValue,
+		SKU:           variant.SKU,
+		Title:         variant.Title(),
+		Options:       options,
 		Price:         price,
 		PriceOverride: variant.Price_Override,
 		HasOverride:   variant.HasPriceOverride(),
 		Quantity:      variant.Quantity,
-		CreatedAt:     variant.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt:     variant.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt:     FormatTime(variant.CreatedAt),
+		UpdatedAt:     FormatTime(variant.UpdatedAt),
+	}
+}
+
+// Product option Mappers
+func ToProductOptionResponse(option *entity.ProductOption) ProductOptionResponse {
+	values := make([]string, 0, len(option.Values))
+	for _, value := range option.Values {
+		values = append(values, value.Value)
+	}
+
+	return ProductOptionResponse{
+		ID:       option.ID.String(),
+		Name:     option.Name,
+		Position: option.Position,
+		Values:   values,
+	}
+}
+
+func ToProductOptionResponses(options []*entity.ProductOption) []ProductOptionResponse {
+	responses := make([]ProductOptionResponse, 0, len(options))
+	for _, option := range options {
+		responses = append(responses, ToProductOptionResponse(option))
 	}
+	return responses
 }
 
 func ToProductVariantListResponse(variants []*entity.ProductVariant, total, page, pageSize int) PaginatedResponse[ProductVariantResponse] {
@@ -124,18 +368,1188 @@ func ToProductVariantListResponse(variants []*entity.ProductVariant, total, page
 		variantResponses = append(variantResponses, ToProductVariantResponse(variant))
 	}
 
-	totalPages := (total + pageSize - 1) / pageSize
-	if total == 0 {
-		totalPages = 0
+	return PaginatedResponse[ProductVariantResponse]{
+		Data:       variantResponses,
+		Pagination: NewPagination(page, pageSize, total),
 	}
+}
 
-	return PaginatedResponse[ProductVariantResponse]{
-		Data: variantResponses,
-		Pagination: Pagination{
-			Page:       page,
-			PageSize:   pageSize,
-			Total:      total,
-			TotalPages: totalPages,
+// Payment history Mappers
+func ToWebhookLogResponse(log *entity.WebhookLog) WebhookLogResponse {
+	return WebhookLogResponse{
+		ID:            log.ID.String(),
+		OrderID:       log.OrderID.String(),
+		TransactionID: log.TransactionID,
+		PaymentStatus: string(log.PaymentStatus),
+		Status:        string(log.Status),
+		RetryCount:    log.RetryCount,
+		NextRetryAt:   formatOptionalTime(log.NextRetryAt),
+		RawPayload:    log.RawPayload,
+		ProcessedAt:   formatOptionalTime(log.ProcessedAt),
+		CreatedAt:     FormatTime(log.CreatedAt),
+	}
+}
+
+func ToWebhookLogListResponse(logs []entity.WebhookLog, total, page, pageSize int) PaginatedResponse[WebhookLogResponse] {
+	logResponses := make([]WebhookLogResponse, 0, len(logs))
+	for i := range logs {
+		logResponses = append(logResponses, ToWebhookLogResponse(&logs[i]))
+	}
+
+	return PaginatedResponse[WebhookLogResponse]{
+		Data:       logResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+func ToDeadLetterWebhookResponse(webhook *entity.DeadLetterWebhook) DeadLetterWebhookResponse {
+	return DeadLetterWebhookResponse{
+		ID:           webhook.ID.String(),
+		Source:       webhook.Source,
+		PayloadHash:  webhook.PayloadHash,
+		RawPayload:   webhook.RawPayload,
+		Reason:       webhook.Reason,
+		Attempts:     webhook.Attempts,
+		Status:       string(webhook.Status),
+		ResolvedBy:   formatOptionalID(webhook.ResolvedBy),
+		ResolvedAt:   formatOptionalTime(webhook.ResolvedAt),
+		CreatedAt:    FormatTime(webhook.CreatedAt),
+		LastFailedAt: FormatTime(webhook.LastFailedAt),
+	}
+}
+
+func ToDeadLetterWebhookListResponse(webhooks []entity.DeadLetterWebhook, total, page, pageSize int) PaginatedResponse[DeadLetterWebhookResponse] {
+	responses := make([]DeadLetterWebhookResponse, 0, len(webhooks))
+	for i := range webhooks {
+		responses = append(responses, ToDeadLetterWebhookResponse(&webhooks[i]))
+	}
+
+	return PaginatedResponse[DeadLetterWebhookResponse]{
+		Data:       responses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+func ToPaymentEventResponse(log *entity.WebhookLog) PaymentEventResponse {
+	return PaymentEventResponse{
+		TransactionID: log.TransactionID,
+		PaymentStatus: string(log.PaymentStatus),
+		ProcessedAt:   formatOptionalTime(log.ProcessedAt),
+		CreatedAt:     FormatTime(log.CreatedAt),
+	}
+}
+
+func ToPaymentEventListResponse(logs []entity.WebhookLog, total, page, pageSize int) PaginatedResponse[PaymentEventResponse] {
+	eventResponses := make([]PaymentEventResponse, 0, len(logs))
+	for i := range logs {
+		eventResponses = append(eventResponses, ToPaymentEventResponse(&logs[i]))
+	}
+
+	return PaginatedResponse[PaymentEventResponse]{
+		Data:       eventResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Background job Mappers
+func ToJobListResponse(enabled bool, metrics []jobs.JobMetrics) JobListResponse {
+	response := JobListResponse{Enabled: enabled, Jobs: make([]JobResponse, 0, len(metrics))}
+	for _, m := range metrics {
+		job := JobResponse{
+			Name:           m.Name,
+			Schedule:       m.Schedule,
+			Runs:           m.Runs,
+			Failures:       m.Failures,
+			Panics:         m.Panics,
+			Skipped:        m.Skipped,
+			Running:        m.Running,
+			LastStartedAt:  formatOptionalTime(m.LastStartedAt),
+			LastFinishedAt: formatOptionalTime(m.LastFinishedAt),
+			LastDurationMs: m.LastDuration.Milliseconds(),
+			LastError:      m.LastError,
+		}
+		if !m.NextRunAt.IsZero() {
+			job.NextRunAt = formatOptionalTime(&m.NextRunAt)
+		}
+		response.Jobs = append(response.Jobs, job)
+	}
+	return response
+}
+
+// FormatTime writes the timestamps of responses: RFC 3339 with fractional
+// seconds and the actual zone of t, UTC for what was read from storage and
+// the client's zone for reports asked for in one
+func FormatTime(t time.Time) string {
+	return t.Format(time.RFC3339Nano)
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := FormatTime(*t)
+	return &formatted
+}
+
+func formatOptionalID(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	formatted := id.String()
+	return &formatted
+}
+
+// Purchase queue Mappers
+func ToQueueStatusResponse(entry *entity.PurchaseQueueEntry, position int) QueueStatusResponse {
+	return QueueStatusResponse{
+		ProductID:       entry.ProductID.String(),
+		Status:          string(entry.Status),
+		Position:        position,
+		WindowExpiresAt: formatOptionalTime(entry.WindowExpiresAt),
+	}
+}
+
+// Customer profile Mappers
+func ToCustomerNoteResponse(note *entity.CustomerNote) CustomerNoteResponse {
+	return CustomerNoteResponse{
+		ID:        note.ID.String(),
+		AuthorID:  note.AuthorID.String(),
+		Body:      note.Body,
+		CreatedAt: FormatTime(note.CreatedAt),
+	}
+}
+
+func ToCustomerRiskEventResponse(event *entity.CustomerRiskEvent) CustomerRiskEventResponse {
+	return CustomerRiskEventResponse{
+		ID:        event.ID.String(),
+		Type:      string(event.Type),
+		Reference: event.Reference,
+		CreatedAt: FormatTime(event.CreatedAt),
+	}
+}
+
+func ToCustomerProfileResponse(user *entity.User, notes []*entity.CustomerNote, events []*entity.CustomerRiskEvent, score int, level entity.RiskLevel) CustomerProfileResponse {
+	noteResponses := make([]CustomerNoteResponse, 0, len(notes))
+	for _, note := range notes {
+		noteResponses = append(noteResponses, ToCustomerNoteResponse(note))
+	}
+
+	eventResponses := make([]CustomerRiskEventResponse, 0, len(events))
+	for _, event := range events {
+		eventResponses = append(eventResponses, ToCustomerRiskEventResponse(event))
+	}
+
+	return CustomerProfileResponse{
+		UserID:     user.ID.String(),
+		Email:      user.Email,
+		Name:       user.Name,
+		Role:       string(user.Role),
+		Active:     user.Active,
+		RiskScore:  score,
+		RiskLevel:  string(level),
+		Notes:      noteResponses,
+		RiskEvents: eventResponses,
+		CreatedAt:  FormatTime(user.CreatedAt),
+	}
+}
+
+func ToCustomerSummaryResponse(userID uuid.UUID, summary *entity.CustomerSummary) CustomerSummaryResponse {
+	categories := make([]CategorySalesResponse, 0, len(summary.FavoriteCategories))
+	for _, category := range summary.FavoriteCategories {
+		categories = append(categories, CategorySalesResponse{
+			CategoryID: category.CategoryID.String(),
+			Name:       category.Name,
+			Units:      category.Units,
+			Spend:      category.Spend,
+		})
+	}
+
+	return CustomerSummaryResponse{
+		UserID:             userID.String(),
+		Orders:             summary.Orders,
+		PaidOrders:         summary.PaidOrders,
+		LifetimeSpend:      summary.LifetimeSpend,
+		AverageOrderValue:  summary.AverageOrderValue,
+		LastOrderAt:        formatOptionalTime(summary.LastOrderAt),
+		FavoriteCategories: categories,
+	}
+}
+
+// Customer data Mappers
+func ToCustomerAddresses(requests []CustomerAddressRequest) []entity.CustomerAddress {
+	addresses := make([]entity.CustomerAddress, 0, len(requests))
+	for _, req := range requests {
+		addresses = append(addresses, entity.CustomerAddress{
+			Label:      req.Label,
+			Recipient:  req.Recipient,
+			Line1:      req.Line1,
+			Line2:      req.Line2,
+			City:       req.City,
+			Region:     req.Region,
+			PostalCode: req.PostalCode,
+			Country:    strings.ToUpper(req.Country),
+			IsDefault:  req.IsDefault,
+		})
+	}
+	return addresses
+}
+
+func ToCustomerResponse(customer *entity.Customer) CustomerResponse {
+	addresses := make([]CustomerAddressResponse, 0, len(customer.Addresses))
+	for _, address := range customer.Addresses {
+		addresses = append(addresses, CustomerAddressResponse{
+			ID:         address.ID.String(),
+			Label:      address.Label,
+			Recipient:  address.Recipient,
+			Line1:      address.Line1,
+			Line2:      address.Line2,
+			City:       address.City,
+			Region:     address.Region,
+			PostalCode: address.PostalCode,
+			Country:    address.Country,
+			IsDefault:  address.IsDefault,
+		})
+	}
+
+	response := CustomerResponse{
+		UserID:         customer.UserID.String(),
+		Phone:          customer.Phone,
+		PhoneVerified:  customer.PhoneVerified(),
+		MarketingEmail: customer.MarketingEmail,
+		MarketingSMS:   customer.MarketingSMS,
+		CustomerGroup:  customer.Group(),
+		Addresses:      addresses,
+	}
+	if !customer.UpdatedAt.IsZero() {
+		response.UpdatedAt = formatOptionalTime(&customer.UpdatedAt)
+	}
+	return response
+}
+
+func ToDataExportResponse(user *entity.User, customer *entity.Customer, orders []*entity.Order, exportedAt time.Time) DataExportResponse {
+	lines := newOrderLines(orders...)
+	orderResponses := make([]OrderResponse, 0, len(orders))
+	for _, order := range orders {
+		orderResponses = append(orderResponses, lines.toOrderResponse(order))
+	}
+
+	return DataExportResponse{
+		Account: AccountExport{
+			UserID:    user.ID.String(),
+			Email:     user.Email,
+			Name:      user.Name,
+			Role:      string(user.Role),
+			Active:    user.Active,
+			CreatedAt: FormatTime(user.CreatedAt),
 		},
+		Customer:   ToCustomerResponse(customer),
+		Orders:     orderResponses,
+		ExportedAt: FormatTime(exportedAt),
+	}
+}
+
+// Stock movement Mappers
+func ToStockMovementResponse(movement *entity.StockMovement) StockMovementResponse {
+	return StockMovementResponse{
+		ID:             movement.ID.String(),
+		ProductID:      movement.ProductID.String(),
+		VariantID:      formatOptionalID(movement.VariantID),
+		Reason:         string(movement.Reason),
+		Delta:          movement.Delta,
+		QuantityBefore: movement.QuantityBefore,
+		QuantityAfter:  movement.QuantityAfter,
+		Reference:      movement.Reference,
+		CreatedAt:      FormatTime(movement.CreatedAt),
+	}
+}
+
+func ToStockTransferResponse(out, in *entity.StockMovement) StockTransferResponse {
+	return StockTransferResponse{
+		From: ToStockMovementResponse(out),
+		To:   ToStockMovementResponse(in),
+	}
+}
+
+func ToInventorySync(req InventorySyncRequest) *entity.InventorySync {
+	sync := &entity.InventorySync{
+		Mode:      entity.SyncAllOrNothing,
+		Reference: req.Reference,
+		Levels:    make([]entity.StockLevel, len(req.Items)),
+	}
+	if req.Mode != "" {
+		sync.Mode = entity.InventorySyncMode(req.Mode)
+	}
+	for i, item := range req.Items {
+		sync.Levels[i] = entity.StockLevel{SKU: item.SKU, Quantity: item.Quantity, ExpectedQuantity: item.ExpectedQuantity}
+	}
+	return sync
+}
+
+func ToInventorySyncResponse(report *entity.InventorySyncReport) InventorySyncResponse {
+	response := InventorySyncResponse{
+		Mode:      string(report.Mode),
+		Reference: report.Reference,
+		Applied:   !report.Rejected,
+		Updated:   report.Count(entity.StockLevelUpdated),
+		Unchanged: report.Count(entity.StockLevelUnchanged),
+		Failed:    report.Count(entity.StockLevelNotFound) + report.Count(entity.StockLevelConflict),
+		Items:     make([]StockLevelResultResponse, 0, len(report.Results)),
+	}
+	for _, result := range report.Results {
+		response.Items = append(response.Items, StockLevelResultResponse{
+			SKU:            result.SKU,
+			Status:         string(result.Status),
+			ProductID:      formatOptionalID(result.ProductID),
+			VariantID:      formatOptionalID(result.VariantID),
+			QuantityBefore: result.QuantityBefore,
+			QuantityAfter:  result.QuantityAfter,
+			Message:        result.Message,
+		})
+	}
+	return response
+}
+
+func ToStockMovementListResponse(movements []*entity.StockMovement, total, page, pageSize int) PaginatedResponse[StockMovementResponse] {
+	movementResponses := make([]StockMovementResponse, 0, len(movements))
+	for _, movement := range movements {
+		movementResponses = append(movementResponses, ToStockMovementResponse(movement))
+	}
+
+	return PaginatedResponse[StockMovementResponse]{
+		Data:       movementResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Price history Mappers
+func ToPriceChangeResponse(change *entity.PriceChange) PriceChangeResponse {
+	return PriceChangeResponse{
+		ID:            change.ID.String(),
+		ProductID:     change.ProductID.String(),
+		VariantID:     formatOptionalID(change.VariantID),
+		Price:         change.Price,
+		PreviousPrice: change.PreviousPrice,
+		Scheduled:     change.Scheduled,
+		Status:        string(change.Status(time.Now())),
+		EffectiveFrom: FormatTime(change.EffectiveFrom),
+		EffectiveTo:   formatOptionalTime(change.EffectiveTo),
+		CancelledAt:   formatOptionalTime(change.CancelledAt),
+		ChangedBy:     formatOptionalID(change.ChangedBy),
+		CreatedAt:     FormatTime(change.CreatedAt),
+	}
+}
+
+func ToPriceChangeListResponse(changes []*entity.PriceChange, total, page, pageSize int) PaginatedResponse[PriceChangeResponse] {
+	changeResponses := make([]PriceChangeResponse, 0, len(changes))
+	for _, change := range changes {
+		changeResponses = append(changeResponses, ToPriceChangeResponse(change))
+	}
+
+	return PaginatedResponse[PriceChangeResponse]{
+		Data:       changeResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Review Mappers
+func ToProductReviewResponse(review *entity.ProductReview) ProductReviewResponse {
+	return ProductReviewResponse{
+		ID:        review.ID.String(),
+		ProductID: review.ProductID.String(),
+		Rating:    review.Rating,
+		Body:      review.Body,
+		CreatedAt: FormatTime(review.CreatedAt),
+		UpdatedAt: FormatTime(review.UpdatedAt),
+	}
+}
+
+func ToProductReviewListResponse(reviews []*entity.ProductReview, total, page, pageSize int) PaginatedResponse[ProductReviewResponse] {
+	reviewResponses := make([]ProductReviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		reviewResponses = append(reviewResponses, ToProductReviewResponse(review))
+	}
+
+	return PaginatedResponse[ProductReviewResponse]{
+		Data:       reviewResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Price list Mappers
+func ToPriceListResponse(productID uuid.UUID, tiers []*entity.PriceTier) PriceListResponse {
+	tierResponses := make([]PriceTierResponse, 0, len(tiers))
+	for _, tier := range tiers {
+		tierResponses = append(tierResponses, PriceTierResponse{
+			ID:            tier.ID.String(),
+			VariantID:     formatOptionalID(tier.VariantID),
+			CustomerGroup: tier.CustomerGroup,
+			MinQuantity:   tier.MinQuantity,
+			Price:         tier.Price,
+		})
+	}
+	return PriceListResponse{ProductID: productID.String(), Tiers: tierResponses}
+}
+
+// Admin activity Mappers
+func ToAuditLogResponse(log *entity.AuditLog) AuditLogResponse {
+	response := AuditLogResponse{
+		ID:             log.ID.String(),
+		UserID:         formatOptionalID(log.UserID),
+		ImpersonatorID: formatOptionalID(log.ImpersonatorID),
+		Action:         log.Action,
+		ResourceType:   log.ResourceType,
+		ResourceID:     log.ResourceID.String(),
+		PayloadBefore:  json.RawMessage(log.PayloadBefore),
+		PayloadAfter:   json.RawMessage(log.PayloadAfter),
+		Timestamp:      FormatTime(log.Timestamp),
+	}
+	for _, change := range log.Changes() {
+		response.Changes = append(response.Changes, AuditChangeResponse{
+			Field:  change.Field,
+			Before: change.Before,
+			After:  change.After,
+		})
+	}
+	return response
+}
+
+func ToAuditLogListResponse(logs []*entity.AuditLog, total, page, pageSize int) PaginatedResponse[AuditLogResponse] {
+	logResponses := make([]AuditLogResponse, 0, len(logs))
+	for _, log := range logs {
+		logResponses = append(logResponses, ToAuditLogResponse(log))
+	}
+
+	return PaginatedResponse[AuditLogResponse]{
+		Data:       logResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+func ToAdminAlertResponse(alert *entity.AdminAlert) AdminAlertResponse {
+	return AdminAlertResponse{
+		ID:         alert.ID.String(),
+		Rule:       string(alert.Rule),
+		ActorID:    formatOptionalID(alert.ActorID),
+		AuditLogID: alert.AuditLogID.String(),
+		Message:    alert.Message,
+		CreatedAt:  FormatTime(alert.CreatedAt),
+	}
+}
+
+func ToAdminAlertListResponse(alerts []*entity.AdminAlert, total, page, pageSize int) PaginatedResponse[AdminAlertResponse] {
+	alertResponses := make([]AdminAlertResponse, 0, len(alerts))
+	for _, alert := range alerts {
+		alertResponses = append(alertResponses, ToAdminAlertResponse(alert))
+	}
+
+	return PaginatedResponse[AdminAlertResponse]{
+		Data:       alertResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Category Mappers
+func ToCategoryResponse(category *entity.Category) CategoryResponse {
+	return CategoryResponse{
+		ID:       category.ID.String(),
+		Name:     category.Name,
+		Slug:     category.Slug,
+		ParentID: formatOptionalID(category.ParentID),
+	}
+}
+
+func ToCategoryProductsResponse(category *entity.Category, products []*entity.Product, total, page, pageSize int) CategoryProductsResponse {
+	list := ToProductListResponse(products, total, page, pageSize)
+	return CategoryProductsResponse{
+		Data: CategoryProducts{
+			Category: ToCategoryResponse(category),
+			Products: list.Data,
+		},
+		Pagination: list.Pagination,
+	}
+}
+
+func ToCategoryTreeResponse(categories []*entity.Category) []CategoryTreeResponse {
+	nodes := make([]CategoryTreeResponse, 0, len(categories))
+	for _, category := range categories {
+		nodes = append(nodes, CategoryTreeResponse{
+			ID:       category.ID.String(),
+			Name:     category.Name,
+			Slug:     category.Slug,
+			Children: ToCategoryTreeResponse(category.Children),
+		})
+	}
+	return nodes
+}
+
+// Remediation Mappers
+func ToRemediationResponse(remediation *entity.OrderRemediation) RemediationResponse {
+	return RemediationResponse{
+		ID:              remediation.ID.String(),
+		OrderID:         remediation.OrderID.String(),
+		Action:          string(remediation.Action),
+		ReasonCode:      string(remediation.Reason),
+		Amount:          remediation.Amount,
+		OrderItemID:     formatOptionalID(remediation.OrderItemID),
+		Quantity:        remediation.Quantity,
+		Note:            remediation.Note,
+		PerformedBy:     remediation.PerformedBy.String(),
+		PerformedByRole: string(remediation.PerformedByRole),
+		CreatedAt:       FormatTime(remediation.CreatedAt),
+	}
+}
+
+// Return Mappers
+func ToReturnResponse(ret *entity.Return) ReturnResponse {
+	items := make([]ReturnItemResponse, 0, len(ret.Items))
+	for _, item := range ret.Items {
+		items = append(items, ReturnItemResponse{
+			ID:           item.ID.String(),
+			OrderItemID:  item.OrderItemID.String(),
+			ProductID:    item.ProductID.String(),
+			VariantID:    formatOptionalID(item.VariantID),
+			Quantity:     item.Quantity,
+			RefundAmount: item.RefundAmount,
+		})
+	}
+
+	return ReturnResponse{
+		ID:              ret.ID.String(),
+		OrderID:         ret.OrderID.String(),
+		Status:          string(ret.Status),
+		ReasonCode:      string(ret.Reason),
+		Note:            ret.Note,
+		ResolutionNote:  ret.ResolutionNote,
+		Items:           items,
+		RefundAmount:    ret.RefundAmount,
+		RefundReference: ret.RefundReference,
+		RefundError:     ret.RefundError,
+		ReviewedBy:      formatOptionalID(ret.ReviewedBy),
+		ReviewedAt:      formatOptionalTime(ret.ReviewedAt),
+		ReceivedAt:      formatOptionalTime(ret.ReceivedAt),
+		RefundedAt:      formatOptionalTime(ret.RefundedAt),
+		CreatedAt:       FormatTime(ret.CreatedAt),
+		UpdatedAt:       FormatTime(ret.UpdatedAt),
+	}
+}
+
+func ToReturnListResponse(returns []*entity.Return, total, page, pageSize int) PaginatedResponse[ReturnResponse] {
+	returnResponses := make([]ReturnResponse, 0, len(returns))
+	for _, ret := range returns {
+		returnResponses = append(returnResponses, ToReturnResponse(ret))
+	}
+
+	return PaginatedResponse[ReturnResponse]{
+		Data:       returnResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Webhook Subscription Mappers
+
+// ToWebhookSubscriptionResponse maps a subscription, with its secret when
+// withSecret is set
+func ToWebhookSubscriptionResponse(s *entity.WebhookSubscription, withSecret bool) WebhookSubscriptionResponse {
+	response := WebhookSubscriptionResponse{
+		ID:          s.ID.String(),
+		URL:         s.URL,
+		Events:      s.Events,
+		Description: s.Description,
+		Active:      s.Active,
+		CreatedBy:   formatOptionalID(s.CreatedBy),
+		CreatedAt:   FormatTime(s.CreatedAt),
+		UpdatedAt:   FormatTime(s.UpdatedAt),
+	}
+	if withSecret {
+		response.Secret = s.Secret
+	}
+	return response
+}
+
+func ToWebhookSubscriptionResponses(subscriptions []*entity.WebhookSubscription) []WebhookSubscriptionResponse {
+	responses := make([]WebhookSubscriptionResponse, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		responses = append(responses, ToWebhookSubscriptionResponse(subscription, false))
+	}
+	return responses
+}
+
+func ToWebhookDeliveryResponse(d *entity.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             d.ID.String(),
+		EventID:        d.EventID.String(),
+		EventType:      d.EventType,
+		Status:         string(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		LastError:      d.LastError,
+		NextAttemptAt:  formatOptionalTime(d.NextAttemptAt),
+		DeliveredAt:    formatOptionalTime(d.DeliveredAt),
+		Payload:        d.Payload,
+		CreatedAt:      FormatTime(d.CreatedAt),
+	}
+}
+
+func ToWebhookDeliveryListResponse(deliveries []*entity.WebhookDelivery, total, page, pageSize int) PaginatedResponse[WebhookDeliveryResponse] {
+	deliveryResponses := make([]WebhookDeliveryResponse, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryResponses = append(deliveryResponses, ToWebhookDeliveryResponse(delivery))
+	}
+
+	return PaginatedResponse[WebhookDeliveryResponse]{
+		Data:       deliveryResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// Blocklist Mappers
+func ToBlocklistEntryResponse(e *entity.BlocklistEntry) BlocklistEntryResponse {
+	return BlocklistEntryResponse{
+		ID:        e.ID.String(),
+		Kind:      string(e.Kind),
+		Value:     e.Value,
+		Reason:    e.Reason,
+		CreatedBy: formatOptionalID(e.CreatedBy),
+		CreatedAt: FormatTime(e.CreatedAt),
+	}
+}
+
+func ToBlocklistEntryResponses(entries []*entity.BlocklistEntry) []BlocklistEntryResponse {
+	responses := make([]BlocklistEntryResponse, 0, len(entries))
+	for _, entry := range entries {
+		responses = append(responses, ToBlocklistEntryResponse(entry))
+	}
+	return responses
+}
+
+// Access code Mappers
+
+func ToAccessCodeResponse(c *entity.AccessCode) AccessCodeResponse {
+	return AccessCodeResponse{
+		ID:        c.ID.String(),
+		Code:      c.Code,
+		Note:      c.Note,
+		MaxUses:   c.MaxUses,
+		Uses:      c.Uses,
+		ExpiresAt: formatOptionalTime(c.ExpiresAt),
+		Active:    c.Active,
+		CreatedBy: formatOptionalID(c.CreatedBy),
+		CreatedAt: FormatTime(c.CreatedAt),
+		UpdatedAt: FormatTime(c.UpdatedAt),
+	}
+}
+
+func ToAccessCodeResponses(codes []*entity.AccessCode) []AccessCodeResponse {
+	responses := make([]AccessCodeResponse, 0, len(codes))
+	for _, code := range codes {
+		responses = append(responses, ToAccessCodeResponse(code))
+	}
+	return responses
+}
+
+// Draft order Mappers
+
+// ToDraftOrderResponse maps a draft with its payment link, which is only
+// given while the draft is sent
+func ToDraftOrderResponse(draft *entity.DraftOrder, paymentLink string) DraftOrderResponse {
+	response := DraftOrderResponse{
+		ID:            draft.ID.String(),
+		CustomerID:    draft.CustomerID,
+		UserID:        formatOptionalID(draft.UserID),
+		Status:        string(draft.Status),
+		Note:          draft.Note,
+		Products:      toDraftOrderItemResponses(draft.Items),
+		LinkExpiresAt: formatOptionalTime(draft.LinkExpiresAt),
+		SentAt:        formatOptionalTime(draft.SentAt),
+		OrderID:       formatOptionalID(draft.OrderID),
+		ConvertedAt:   formatOptionalTime(draft.ConvertedAt),
+		CreatedBy:     formatOptionalID(draft.CreatedBy),
+		CreatedAt:     FormatTime(draft.CreatedAt),
+		UpdatedAt:     FormatTime(draft.UpdatedAt),
+	}
+	if draft.Status == entity.DraftOrderSent {
+		response.PaymentLink = paymentLink
+	}
+	return response
+}
+
+func ToDraftOrderListResponse(drafts []*entity.DraftOrder, paymentLink func(*entity.DraftOrder) string, total, page, pageSize int) PaginatedResponse[DraftOrderResponse] {
+	draftResponses := make([]DraftOrderResponse, 0, len(drafts))
+	for _, draft := range drafts {
+		draftResponses = append(draftResponses, ToDraftOrderResponse(draft, paymentLink(draft)))
+	}
+
+	return PaginatedResponse[DraftOrderResponse]{
+		Data:       draftResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+func ToDraftOrderLinkResponse(draft *entity.DraftOrder) DraftOrderLinkResponse {
+	return DraftOrderLinkResponse{
+		Note:          draft.Note,
+		Products:      toDraftOrderItemResponses(draft.Items),
+		LinkExpiresAt: formatOptionalTime(draft.LinkExpiresAt),
+	}
+}
+
+func toDraftOrderItemResponses(items []entity.DraftOrderItem) []DraftOrderItemResponse {
+	responses := make([]DraftOrderItemResponse, 0, len(items))
+	for _, item := range items {
+		responses = append(responses, DraftOrderItemResponse{
+			ProductID: item.ProductID.String(),
+			VariantID: formatOptionalID(item.VariantID),
+			Quantity:  item.Quantity,
+		})
+	}
+	return responses
+}
+
+// Loyalty Mappers
+func ToLoyaltyTransactionResponse(t *entity.LoyaltyTransaction) LoyaltyTransactionResponse {
+	return LoyaltyTransactionResponse{
+		ID:        t.ID.String(),
+		OrderID:   formatOptionalID(t.OrderID),
+		Type:      string(t.Type),
+		Points:    t.Points,
+		CreatedAt: FormatTime(t.CreatedAt),
+	}
+}
+
+func ToLoyaltyResponse(balance int, program entity.LoyaltyProgram, transactions []*entity.LoyaltyTransaction, total, page, pageSize int) LoyaltyResponse {
+	transactionResponses := make([]LoyaltyTransactionResponse, 0, len(transactions))
+	for _, transaction := range transactions {
+		transactionResponses = append(transactionResponses, ToLoyaltyTransactionResponse(transaction))
+	}
+
+	return LoyaltyResponse{
+		Data: Loyalty{
+			Balance:      balance,
+			BalanceValue: program.Discount(balance),
+			EarnRate:     program.EarnRate,
+			PointValue:   program.PointValue,
+			Transactions: transactionResponses,
+		},
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// ToTotalsResponse maps the totals of a cart, line by line
+func ToTotalsResponse(totals pricing.Totals) TotalsResponse {
+	lines := make([]LineTotalsResponse, 0, len(totals.Lines))
+	for _, line := range totals.Lines {
+		lines = append(lines, LineTotalsResponse{
+			Base:      line.Base,
+			Discount:  line.Discount,
+			Tax:       line.Tax,
+			Surcharge: line.Surcharge,
+			Total:     line.Total,
+		})
+	}
+
+	return TotalsResponse{
+		Subtotal:   totals.Subtotal,
+		Discount:   totals.Discount,
+		Tax:        totals.Tax,
+		Surcharges: totals.Surcharges,
+		Shipping:   totals.Shipping,
+		Credits:    totals.Credits,
+		Total:      totals.Total,
+		Lines:      lines,
+	}
+}
+
+// Allocation Mappers
+func ToAllocationPreviewResponse(plan *entity.AllocationPlan) AllocationPreviewResponse {
+	items := make([]ItemAllocationResponse, 0, len(plan.Items))
+	for _, item := range plan.Items {
+		allocations := make([]AllocationResponse, 0, len(item.Allocations))
+		for _, allocation := range item.Allocations {
+			allocations = append(allocations, AllocationResponse{
+				WarehouseCode: allocation.Warehouse.Code,
+				WarehouseName: allocation.Warehouse.Name,
+				Quantity:      allocation.Quantity,
+				ShipDate:      allocation.ShipDate.Format("2006-01-02"),
+			})
+		}
+
+		items = append(items, ItemAllocationResponse{
+			ProductID:   item.ProductID.String(),
+			VariantID:   formatOptionalID(item.VariantID),
+			Quantity:    item.Quantity,
+			Allocations: allocations,
+			Unallocated: item.Unallocated,
+			Split:       item.IsSplit(),
+		})
+	}
+
+	response := AllocationPreviewResponse{
+		Items:          items,
+		FullyAllocated: plan.IsFullyAllocated(),
+	}
+	if shipDate := plan.ShipDate(); shipDate != nil {
+		formatted := shipDate.Format("2006-01-02")
+		response.ShipDate = &formatted
+	}
+	return response
+}
+
+// Rate limit Mappers
+func ToQuotaResponse(quota entity.RateLimitQuota, now time.Time) QuotaResponse {
+	return QuotaResponse{
+		Limit:     quota.Limit,
+		Remaining: quota.Remaining(),
+		ResetAt:   FormatTime(quota.ResetAt),
+		ResetIn:   max(int(quota.ResetAt.Sub(now).Seconds()), 0),
+	}
+}
+
+// Email template Mappers
+func ToEmailTemplateResponse(template *entity.EmailTemplate) EmailTemplateResponse {
+	response := EmailTemplateResponse{
+		ID:        template.ID.String(),
+		Key:       template.Key,
+		Version:   template.Version,
+		Subject:   template.Subject,
+		Body:      template.Body,
+		CreatedBy: formatOptionalID(template.CreatedBy),
+		CreatedAt: FormatTime(template.CreatedAt),
+	}
+	if template.SampleData != "" {
+		response.SampleData = json.RawMessage(template.SampleData)
+	}
+	return response
+}
+
+func ToRenderedEmailResponse(email *entity.RenderedEmail) RenderedEmailResponse {
+	return RenderedEmailResponse{
+		Subject: email.Subject,
+		Body:    email.Body,
+	}
+}
+
+// Catalog report Mappers
+func ToCatalogReportResponse(report *entity.CatalogReport, issues []entity.CatalogIssue) CatalogReportResponse {
+	issueResponses := make([]CatalogIssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		issueResponses = append(issueResponses, CatalogIssueResponse{
+			Code:         string(issue.Code),
+			Severity:     string(issue.Severity),
+			ResourceType: issue.ResourceType,
+			ResourceID:   issue.ResourceID.String(),
+			Message:      issue.Message,
+			Link:         issue.Link,
+		})
+	}
+
+	return CatalogReportResponse{
+		ID:          report.ID.String(),
+		Trigger:     string(report.Trigger),
+		TriggeredBy: formatOptionalID(report.TriggeredBy),
+		Summary: CatalogReportSummary{
+			Critical: report.Critical,
+			Warnings: report.Warnings,
+			Info:     report.Info,
+		},
+		Issues:    issueResponses,
+		CreatedAt: FormatTime(report.CreatedAt),
+	}
+}
+
+// Recall Mappers
+func ToAffectedOrdersResponse(impact *entity.RecallImpact) AffectedOrdersResponse {
+	orders := make([]AffectedOrderResponse, 0, len(impact.Orders))
+	for _, order := range impact.Orders {
+		orders = append(orders, AffectedOrderResponse{
+			OrderID:    order.OrderID.String(),
+			UserID:     formatOptionalID(order.UserID),
+			CustomerID: order.CustomerID,
+			Quantity:   order.Quantity,
+			OrderedAt:  FormatTime(order.OrderedAt),
+		})
+	}
+
+	return AffectedOrdersResponse{
+		ProductID: impact.ProductID.String(),
+		VariantID: formatOptionalID(impact.VariantID),
+		SKU:       impact.SKU,
+		Customers: impact.Customers,
+		Units:     impact.Units,
+		Orders:    orders,
+	}
+}
+
+// ToRecallResponse maps a recall, with its notices when withNotices is set
+func ToRecallResponse(r *entity.Recall, withNotices bool) RecallResponse {
+	summary := r.Summary()
+	response := RecallResponse{
+		ID:          r.ID.String(),
+		Title:       r.Title,
+		Description: r.Description,
+		ProductID:   r.ProductID.String(),
+		VariantID:   formatOptionalID(r.VariantID),
+		SKU:         r.SKU,
+		Lot:         r.Lot,
+		From:        r.OrderedFrom.Format("2006-01-02"),
+		To:          r.OrderedTo.Format("2006-01-02"),
+		Summary: RecallSummary{
+			Orders:       summary.Orders,
+			Customers:    summary.Customers,
+			Units:        summary.Units,
+			Sent:         summary.Sent,
+			Failed:       summary.Failed,
+			Unreachable:  summary.Unreachable,
+			Acknowledged: summary.Acknowledged,
+		},
+		CreatedBy: r.CreatedBy.String(),
+		CreatedAt: FormatTime(r.CreatedAt),
+	}
+
+	if withNotices {
+		response.Notices = make([]RecallNoticeResponse, 0, len(r.Notices))
+		for _, notice := range r.Notices {
+			response.Notices = append(response.Notices, RecallNoticeResponse{
+				ID:             notice.ID.String(),
+				OrderID:        notice.OrderID.String(),
+				UserID:         formatOptionalID(notice.UserID),
+				CustomerID:     notice.CustomerID,
+				Quantity:       notice.Quantity,
+				Status:         string(notice.Status),
+				Error:          notice.Error,
+				SentAt:         formatOptionalTime(notice.SentAt),
+				AcknowledgedAt: formatOptionalTime(notice.AcknowledgedAt),
+			})
+		}
+	}
+
+	return response
+}
+
+func ToRecallListResponse(recalls []*entity.Recall, total, page, pageSize int) PaginatedResponse[RecallResponse] {
+	recallResponses := make([]RecallResponse, 0, len(recalls))
+	for _, r := range recalls {
+		recallResponses = append(recallResponses, ToRecallResponse(r, false))
+	}
+
+	return PaginatedResponse[RecallResponse]{
+		Data:       recallResponses,
+		Pagination: NewPagination(page, pageSize, total),
+	}
+}
+
+// ToMyRecallNoticeResponse maps a notice with its recall loaded
+func ToMyRecallNoticeResponse(notice *entity.RecallNotice) MyRecallNoticeResponse {
+	response := MyRecallNoticeResponse{
+		ID:             notice.ID.String(),
+		OrderID:        notice.OrderID.String(),
+		Quantity:       notice.Quantity,
+		Acknowledged:   notice.Status == entity.NoticeAcknowledged,
+		AcknowledgedAt: formatOptionalTime(notice.AcknowledgedAt),
+		CreatedAt:      FormatTime(notice.CreatedAt),
+	}
+	if notice.Recall != nil {
+		response.Title = notice.Recall.Title
+		response.Description = notice.Recall.Description
+		response.SKU = notice.Recall.SKU
+		response.Lot = notice.Recall.Lot
+	}
+	return response
+}
+
+func ToProductCostResponse(product *entity.Product) ProductCostResponse {
+	response := ProductCostResponse{
+		ProductID: product.ID.String(),
+		Price:     product.Price,
+		Cost:      product.Cost,
+	}
+	if margin, ok := product.Margin(); ok {
+		response.Margin = &margin
+	}
+	return response
+}
+
+func ToRankingRuleResponse(rule *entity.RankingRule) RankingRuleResponse {
+	return RankingRuleResponse{
+		ID:        rule.ID.String(),
+		Type:      string(rule.Type),
+		Weight:    rule.Weight,
+		Query:     rule.Query,
+		ProductID: formatOptionalID(rule.ProductID),
+		Position:  rule.Position,
+		Active:    rule.Active,
+		CreatedAt: FormatTime(rule.CreatedAt),
+		UpdatedAt: FormatTime(rule.UpdatedAt),
+	}
+}
+
+func ToSearchExplanationResponse(explanation *entity.SearchExplanation, total, page, pageSize int) SearchExplanationResponse {
+	response := SearchExplanationResponse{
+		Query:      explanation.Query,
+		Results:    make([]RankedProductResponse, 0, len(explanation.Results)),
+		Rules:      make([]RankingRuleResponse, 0, len(explanation.Rules)),
+		Pagination: NewPagination(page, pageSize, total),
+	}
+
+	offset := (page - 1) * pageSize
+	for i, result := range explanation.Results {
+		contributions := make([]RankingContributionResponse, 0, len(result.Contributions))
+		for _, contribution := range result.Contributions {
+			contributions = append(contributions, RankingContributionResponse{
+				Source: contribution.Source,
+				RuleID: formatOptionalID(contribution.RuleID),
+				Score:  contribution.Score,
+				Detail: contribution.Detail,
+			})
+		}
+
+		response.Results = append(response.Results, RankedProductResponse{
+			Rank:          offset + i + 1,
+			ProductID:     result.Product.ID.String(),
+			Name:          result.Product.Name,
+			Score:         result.Score,
+			Pinned:        result.Pinned,
+			Contributions: contributions,
+		})
+	}
+
+	for _, rule := range explanation.Rules {
+		response.Rules = append(response.Rules, ToRankingRuleResponse(rule))
+	}
+	return response
+}
+
+func ToRevenueReportResponse(period entity.AnalyticsPeriod, from, to time.Time, points []entity.RevenuePoint) RevenueReportResponse {
+	response := RevenueReportResponse{
+		Period:   string(period),
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		TimeZone: from.Location().String(),
+		Points:   make([]RevenuePointResponse, 0, len(points)),
+	}
+	for _, point := range points {
+		response.Total += point.Revenue
+		response.Points = append(response.Points, RevenuePointResponse{
+			PeriodStart: point.PeriodStart.Format("2006-01-02"),
+			Orders:      point.Orders,
+			Revenue:     point.Revenue,
+		})
+	}
+	response.Total = math.Round(response.Total*100) / 100
+	return response
+}
+
+func ToTopProductsResponse(from, to time.Time, products []entity.ProductSales) TopProductsResponse {
+	response := TopProductsResponse{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		TimeZone: from.Location().String(),
+		Products: make([]ProductSalesResponse, 0, len(products)),
+	}
+	for _, product := range products {
+		response.Products = append(response.Products, ProductSalesResponse{
+			ProductID: product.ProductID.String(),
+			Name:      product.Name,
+			Units:     product.Units,
+			Revenue:   product.Revenue,
+		})
+	}
+	return response
+}
+
+func ToOrderStatusCountsResponse(from, to time.Time, counts []entity.OrderStatusCount) OrderStatusCountsResponse {
+	response := OrderStatusCountsResponse{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		TimeZone: from.Location().String(),
+		Counts:   make([]OrderStatusCountResponse, 0, len(counts)),
+	}
+	for _, count := range counts {
+		response.Counts = append(response.Counts, OrderStatusCountResponse{
+			Status: string(count.Status),
+			Count:  count.Count,
+		})
+	}
+	return response
+}
+
+func ToSalesSummaryResponse(from, to time.Time, summary *entity.SalesSummary) SalesSummaryResponse {
+	return SalesSummaryResponse{
+		From:              from.Format("2006-01-02"),
+		To:                to.Format("2006-01-02"),
+		TimeZone:          from.Location().String(),
+		Orders:            summary.Orders,
+		PaidOrders:        summary.PaidOrders,
+		Revenue:           summary.Revenue,
+		AverageOrderValue: summary.AverageOrderValue,
+		NewCustomers:      summary.NewCustomers,
+	}
+}
+
+// SalesReportCSVHeader names the columns of ToSalesReportCSVRecord
+var SalesReportCSVHeader = []string{
+	"order_id", "ordered_at", "customer_id", "status", "payment_status",
+	"item_id", "product_id", "variant_id", "quantity", "unit_price",
+	"base", "discount", "tax", "surcharge", "total",
+}
+
+// ToSalesReportCSVRecord formats a report line for the CSV export. Amounts have
+// two decimals and no currency symbol, so spreadsheets read them as numbers.
+func ToSalesReportCSVRecord(line entity.SalesLine) []string {
+	variantID := ""
+	if line.VariantID != nil {
+		variantID = line.VariantID.String()
+	}
+	return []string{
+		line.OrderID.String(),
+		FormatTime(line.OrderedAt),
+		strconv.Itoa(line.CustomerID),
+		string(line.Status),
+		string(line.PaymentStatus),
+		line.ItemID.String(),
+		line.ProductID.String(),
+		variantID,
+		strconv.Itoa(line.Quantity),
+		formatAmount(line.UnitPrice),
+		formatAmount(line.Base),
+		formatAmount(line.Discount),
+		formatAmount(line.Tax),
+		formatAmount(line.Surcharge),
+		formatAmount(line.Total),
+	}
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// Store settings Mappers
+func ToStoreSettingsResponse(settings entity.StoreSettings) StoreSettingsResponse {
+	return StoreSettingsResponse{
+		Store: StoreInfoResponse{
+			Name:         settings.StoreName(),
+			ContactEmail: settings.ContactEmail(),
+			Currency:     settings.Currency(),
+			LogoURL:      settings.LogoURL(),
+		},
+		Checkout: CheckoutSettingsResponse{
+			TermsURL:       settings.TermsURL(),
+			RequireTerms:   settings.RequireTerms(),
+			SuccessMessage: settings.SuccessMessage(),
+		},
+	}
+}
+
+// Maintenance Mappers
+func ToMaintenanceResponse(status entity.MaintenanceStatus) MaintenanceResponse {
+	response := MaintenanceResponse{
+		Enabled:           status.Enabled,
+		Message:           status.Message,
+		RetryAfterSeconds: status.RetryAfterSeconds(),
+	}
+	response.Since = formatOptionalTime(status.Since)
+	if status.UpdatedBy != nil {
+		updatedBy := status.UpdatedBy.String()
+		response.UpdatedBy = &updatedBy
 	}
+	return response
 }
@@ -71,6 +71,37 @@ func TestToProductListResponse(t *testing.T) {
 	}
 }
 
+func TestNewPagination(t *testing.T) {
+	tests := []struct {
+		name                  string
+		page, pageSize, total int
+		wantTotalPages        int
+		wantNext, wantPrev    bool
+	}{
+		{"empty", 1, 10, 0, 0, false, false},
+		{"first of several", 1, 10, 25, 3, true, false},
+		{"middle", 2, 10, 25, 3, true, true},
+		{"last", 3, 10, 25, 3, false, true},
+		{"exact fit", 2, 10, 20, 2, false, true},
+		{"past the last", 5, 10, 25, 3, false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPagination(tt.page, tt.pageSize, tt.total)
+			if p.TotalPages != tt.wantTotalPages {
+				t.Errorf("TotalPages = %v, want %v", p.TotalPages, tt.wantTotalPages)
+			}
+			if p.HasNext != tt.wantNext {
+				t.Errorf("HasNext = %v, want %v", p.HasNext, tt.wantNext)
+			}
+			if p.HasPrev != tt.wantPrev {
+				t.Errorf("HasPrev = %v, want %v", p.HasPrev, tt.wantPrev)
+			}
+		})
+	}
+}
+
 func TestToOrderResponse(t *testing.T) {
 	orderID := uuid.New()
 	productID := uuid.New()
@@ -153,3 +184,46 @@ func TestToOrderListResponse(t *testing.T) {
 		t.Errorf("ToOrderListResponse() Data[0].CustomerID = %v, want 1", response.Data[0].CustomerID)
 	}
 }
+
+func TestToOrderListResponse_LinesStayWithTheirOrder(t *testing.T) {
+	first := &entity.Order{
+		ID: uuid.New(),
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: 10, TotalPrice: 10},
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, Price: 5, TotalPrice: 12, Components: []entity.OrderItemComponent{
+				{Type: entity.ComponentBase, Amount: 10},
+				{Type: entity.ComponentTax, Label: "VAT", Amount: 2},
+			}},
+		},
+	}
+	empty := &entity.Order{ID: uuid.New()}
+	last := &entity.Order{
+		ID:       uuid.New(),
+		Products: []entity.OrderItem{{ID: uuid.New(), ProductID: uuid.New(), Quantity: 3, Price: 1, TotalPrice: 3}},
+	}
+
+	response := ToOrderListResponse([]*entity.Order{first, empty, last}, 3, 1, 10)
+
+	if got := len(response.Data[0].Products); got != 2 {
+		t.Fatalf("first order has %d lines, want 2", got)
+	}
+	if got := len(response.Data[0].Products[1].Components); got != 2 {
+		t.Errorf("second line of the first order has %d components, want 2", got)
+	}
+	if response.Data[1].Products == nil || len(response.Data[1].Products) != 0 {
+		t.Errorf("order without lines has %v, want an empty list", response.Data[1].Products)
+	}
+	if got := response.Data[2].Products[0].ProductID; got != last.Products[0].ProductID.String() {
+		t.Errorf("last order line ProductID = %v, want %v", got, last.Products[0].ProductID)
+	}
+
+	// Growing one order's lines must not overwrite the next order's
+	_ = append(response.Data[0].Products, OrderItemResponse{ID: "appended"})
+	_ = append(response.Data[0].Products[0].Components, OrderItemComponentResponse{Type: "appended"})
+	if got := response.Data[2].Products[0].ID; got != last.Products[0].ID.String() {
+		t.Errorf("last order line ID = %v after appending to the first order, want %v", got, last.Products[0].ID)
+	}
+	if got := response.Data[0].Products[1].Components[0].Type; got != string(entity.ComponentBase) {
+		t.Errorf("second line component type = %v after appending to the first line, want base", got)
+	}
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+)
+
+type AccessCodeHandler struct {
+	accessCodeService accesscode.AccessCodeService
+}
+
+func NewAccessCodeHandler(accessCodeService accesscode.AccessCodeService) *AccessCodeHandler {
+	return &AccessCodeHandler{
+		accessCodeService: accessCodeService,
+	}
+}
+
+// CreateAccessCode godoc
+// @Summary Create an access code
+// @Description Create a code that lets people register and browse while the store is in private beta (Admin only). A code is generated when none is given.
+// @Tags access-codes
+// @Accept json
+// @Produce json
+// @Param code body dto.CreateAccessCodeRequest true "Access code"
+// @Success 201 {object} dto.AccessCodeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Code already exists"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/access-codes [post]
+func (h *AccessCodeHandler) CreateAccessCode(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.CreateAccessCodeRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	expiresAt, ok := parseAccessCodeExpiry(w, req.ExpiresAt)
+	if !ok {
+		return
+	}
+
+	code, err := h.accessCodeService.CreateCode(r.Context(), &entity.AccessCode{
+		Code:      req.Code,
+		Note:      req.Note,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: expiresAt,
+	}, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToAccessCodeResponse(code))
+}
+
+// ListAccessCodes godoc
+// @Summary List the access codes
+// @Description Every access code with its uses, newest first (Admin only)
+// @Tags access-codes
+// @Produce json
+// @Success 200 {array} dto.AccessCodeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/access-codes [get]
+func (h *AccessCodeHandler) ListAccessCodes(w http.ResponseWriter, r *http.Request) {
+	codes, err := h.accessCodeService.ListCodes(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToAccessCodeResponses(codes))
+}
+
+// UpdateAccessCode godoc
+// @Summary Update an access code
+// @Description Replace the note, max uses, expiry and status of a code; deactivate it to stop its use (Admin only)
+// @Tags access-codes
+// @Accept json
+// @Produce json
+// @Param id path string true "Access code ID"
+// @Param code body dto.UpdateAccessCodeRequest true "Access code"
+// @Success 200 {object} dto.AccessCodeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/access-codes/{id} [put]
+func (h *AccessCodeHandler) UpdateAccessCode(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid access code ID")
+		return
+	}
+
+	var req dto.UpdateAccessCodeRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	expiresAt, ok := parseAccessCodeExpiry(w, req.ExpiresAt)
+	if !ok {
+		return
+	}
+
+	code, err := h.accessCodeService.UpdateCode(r.Context(), id, accesscode.Changes{
+		Note:      req.Note,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: expiresAt,
+		Active:    req.Active,
+	}, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToAccessCodeResponse(code))
+}
+
+// DeleteAccessCode godoc
+// @Summary Delete an access code
+// @Description Delete a code; accounts registered with it are kept (Admin only)
+// @Tags access-codes
+// @Produce json
+// @Param id path string true "Access code ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/access-codes/{id} [delete]
+func (h *AccessCodeHandler) DeleteAccessCode(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid access code ID")
+		return
+	}
+
+	if err := h.accessCodeService.DeleteCode(r.Context(), id, claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAccessCodeExpiry reads an optional RFC 3339 expiry, responding 400 when it is invalid
+func parseAccessCodeExpiry(w http.ResponseWriter, value *string) (*time.Time, bool) {
+	if value == nil || *value == "" {
+		return nil, true
+	}
+	parsed, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid expires_at. Use RFC 3339, e.g. 2026-12-31T23:59:59Z")
+		return nil, false
+	}
+	return &parsed, true
+}
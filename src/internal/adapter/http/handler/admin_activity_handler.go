@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/monitoring"
+)
+
+type AdminActivityHandler struct {
+	monitoringService monitoring.MonitoringService
+}
+
+func NewAdminActivityHandler(monitoringService monitoring.MonitoringService) *AdminActivityHandler {
+	return &AdminActivityHandler{
+		monitoringService: monitoringService,
+	}
+}
+
+// ListActivity godoc
+// @Summary List admin activity
+// @Description Paginated audit trail of changes, newest first, for reviewing what admins did
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param user_id query string false "Only actions by this user"
+// @Param action query string false "Filter by action (CREATE, UPDATE, DELETE, ...)"
+// @Param resource_type query string false "Filter by resource type (Product, Order, User, ...)"
+// @Param start_date query string false "Only actions at or after this RFC3339 time"
+// @Param end_date query string false "Only actions at or before this RFC3339 time"
+// @Success 200 {object} dto.AuditLogListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/activity [get]
+func (h *AdminActivityHandler) ListActivity(w http.ResponseWriter, r *http.Request) {
+	h.listAuditLogs(w, r)
+}
+
+// ListAuditLogs godoc
+// @Summary List audit logs
+// @Description Paginated audit logs, newest first, with who made each change, what it touched and the fields it changed
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param user_id query string false "Only changes made by this user"
+// @Param action query string false "Filter by action (CREATE, UPDATE, DELETE, ...)"
+// @Param resource_type query string false "Filter by resource type (Product, Order, Category, ...)"
+// @Param resource_id query string false "Only changes to this resource"
+// @Param start_date query string false "Only changes at or after this RFC3339 time"
+// @Param end_date query string false "Only changes at or before this RFC3339 time"
+// @Success 200 {object} dto.AuditLogListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/audit-logs [get]
+func (h *AdminActivityHandler) ListAuditLogs(w http.ResponseWriter, r *http.Request) {
+	h.listAuditLogs(w, r)
+}
+
+func (h *AdminActivityHandler) listAuditLogs(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
+	filters, err := parseAuditLogFilters(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logs, total, err := h.monitoringService.ListActivity(r.Context(), filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToAuditLogListResponse(logs, total, page, pageSize))
+}
+
+// ListAlerts godoc
+// @Summary List admin activity alerts
+// @Description Alerts raised by monitoring rules (mass deletes, large price changes, role escalations), newest first
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param rule query string false "Filter by rule (mass_delete, large_price_change, role_escalation)"
+// @Success 200 {object} dto.AdminAlertListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/alerts [get]
+func (h *AdminActivityHandler) ListAlerts(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
+
+	var rule *entity.AlertRule
+	if ruleStr := r.URL.Query().Get("rule"); ruleStr != "" {
+		ar := entity.AlertRule(ruleStr)
+		rule = &ar
+	}
+
+	alerts, total, err := h.monitoringService.ListAlerts(r.Context(), rule, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToAdminAlertListResponse(alerts, total, page, pageSize))
+}
+
+// parseAuditLogFilters reads the audit log filters from the query string.
+// IDs must be UUIDs and dates RFC3339 times.
+func parseAuditLogFilters(r *http.Request) (repository.AuditLogFilters, error) {
+	query := r.URL.Query()
+
+	var filters repository.AuditLogFilters
+	if userIDStr := query.Get("user_id"); userIDStr != "" {
+		userID, err := uuid.Parse(userIDStr)
+		if err != nil {
+			return filters, errors.New("Invalid user ID")
+		}
+		filters.UserID = &userID
+	}
+	if action := query.Get("action"); action != "" {
+		filters.Action = &action
+	}
+	if resourceType := query.Get("resource_type"); resourceType != "" {
+		filters.ResourceType = &resourceType
+	}
+	if resourceIDStr := query.Get("resource_id"); resourceIDStr != "" {
+		resourceID, err := uuid.Parse(resourceIDStr)
+		if err != nil {
+			return filters, errors.New("Invalid resource ID")
+		}
+		filters.ResourceID = &resourceID
+	}
+	if startDate := query.Get("start_date"); startDate != "" {
+		if _, err := time.Parse(time.RFC3339, startDate); err != nil {
+			return filters, errors.New("Invalid start_date. Must be an RFC3339 time")
+		}
+		filters.StartDate = &startDate
+	}
+	if endDate := query.Get("end_date"); endDate != "" {
+		if _, err := time.Parse(time.RFC3339, endDate); err != nil {
+			return filters, errors.New("Invalid end_date. Must be an RFC3339 time")
+		}
+		filters.EndDate = &endDate
+	}
+	return filters, nil
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/analytics"
+)
+
+type AnalyticsHandler struct {
+	analyticsService analytics.AnalyticsService
+}
+
+func NewAnalyticsHandler(analyticsService analytics.AnalyticsService) *AnalyticsHandler {
+	return &AnalyticsHandler{
+		analyticsService: analyticsService,
+	}
+}
+
+// GetRevenue godoc
+// @Summary Revenue over time
+// @Description Revenue and number of paid, not cancelled orders per day, week (starting Monday) or month, with empty periods included (Admin only). Dates are days in tz, UTC by default, and default to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Param period query string false "Bucket size (day, week, month)" default(day)
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD, defaults to today"
+// @Param tz query string false "IANA time zone the days are in, e.g. America/Sao_Paulo" default(UTC)
+// @Success 200 {object} dto.RevenueReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/analytics/revenue [get]
+func (h *AnalyticsHandler) GetRevenue(w http.ResponseWriter, r *http.Request) {
+	dates, err := parseDateRange(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	period := entity.AnalyticsPeriod(r.URL.Query().Get("period"))
+
+	report, err := h.analyticsService.Revenue(r.Context(), period, dates)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRevenueReportResponse(report.Period, report.Range.From, report.Range.To, report.Points))
+}
+
+// GetTopProducts godoc
+// @Summary Top-selling products
+// @Description Products that sold the most units in paid, not cancelled orders, with the revenue they brought (Admin only). Dates are days in tz, UTC by default, and default to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD, defaults to today"
+// @Param tz query string false "IANA time zone the days are in, e.g. America/Sao_Paulo" default(UTC)
+// @Param limit query int false "Number of products, at most 100" default(10)
+// @Success 200 {object} dto.TopProductsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/analytics/top-products [get]
+func (h *AnalyticsHandler) GetTopProducts(w http.ResponseWriter, r *http.Request) {
+	dates, err := parseDateRange(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var limit int
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid limit")
+			return
+		}
+	}
+
+	report, err := h.analyticsService.TopProducts(r.Context(), dates, limit)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToTopProductsResponse(report.Range.From, report.Range.To, report.Products))
+}
+
+// GetOrdersByStatus godoc
+// @Summary Order counts by status
+// @Description Number of orders placed in the range for every status, including those with none (Admin only). Dates are days in tz, UTC by default, and default to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD, defaults to today"
+// @Param tz query string false "IANA time zone the days are in, e.g. America/Sao_Paulo" default(UTC)
+// @Success 200 {object} dto.OrderStatusCountsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/analytics/orders-by-status [get]
+func (h *AnalyticsHandler) GetOrdersByStatus(w http.ResponseWriter, r *http.Request) {
+	dates, err := parseDateRange(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.analyticsService.OrdersByStatus(r.Context(), dates)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToOrderStatusCountsResponse(report.Range.From, report.Range.To, report.Counts))
+}
+
+// GetSummary godoc
+// @Summary Sales summary
+// @Description Orders placed, paid orders, revenue, average order value and new customer accounts in the range (Admin only). Dates are days in tz, UTC by default, and default to the last 30 days.
+// @Tags analytics
+// @Produce json
+// @Param from query string false "First day, YYYY-MM-DD"
+// @Param to query string false "Last day, YYYY-MM-DD, defaults to today"
+// @Param tz query string false "IANA time zone the days are in, e.g. America/Sao_Paulo" default(UTC)
+// @Success 200 {object} dto.SalesSummaryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/analytics/summary [get]
+func (h *AnalyticsHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	dates, err := parseDateRange(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.analyticsService.Summary(r.Context(), dates)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToSalesSummaryResponse(report.Range.From, report.Range.To, report.Summary))
+}
+
+// parseDateRange reads the optional from and to query parameters, days in
+// the time zone named by tz, UTC by default. Missing dates are left zero for
+// the use case to default.
+func parseDateRange(r *http.Request) (analytics.DateRange, error) {
+	var dates analytics.DateRange
+	query := r.URL.Query()
+
+	loc, err := parseTimeZone(query.Get("tz"))
+	if err != nil {
+		return dates, err
+	}
+	dates.Location = loc
+
+	if from := query.Get("from"); from != "" {
+		if dates.From, err = time.ParseInLocation("2006-01-02", from, loc); err != nil {
+			return dates, errors.New("Invalid from date. Use YYYY-MM-DD")
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		if dates.To, err = time.ParseInLocation("2006-01-02", to, loc); err != nil {
+			return dates, errors.New("Invalid to date. Use YYYY-MM-DD")
+		}
+	}
+
+	return dates, nil
+}
+
+// parseTimeZone loads the IANA time zone a client asked for a report in,
+// UTC when it named none
+func parseTimeZone(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	// LoadLocation also takes "Local", the zone of the server
+	if err != nil || name == "Local" {
+		return nil, errors.New("Invalid tz. Use an IANA time zone, e.g. America/Sao_Paulo")
+	}
+	return loc, nil
+}
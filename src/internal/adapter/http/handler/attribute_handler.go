@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/attribute"
+)
+
+type AttributeHandler struct {
+	attributeService attribute.AttributeService
+}
+
+func NewAttributeHandler(attributeService attribute.AttributeService) *AttributeHandler {
+	return &AttributeHandler{
+		attributeService: attributeService,
+	}
+}
+
+// CreateAttribute godoc
+// @Summary Define a product attribute
+// @Description Define a descriptive product attribute such as Material or Brand (Admin only). Its code is derived from the name and used to filter products.
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Param attribute body dto.AttributeRequest true "Attribute definition"
+// @Success 201 {object} dto.AttributeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Attribute already exists"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /attributes [post]
+func (h *AttributeHandler) CreateAttribute(w http.ResponseWriter, r *http.Request) {
+	var req dto.AttributeRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	definition, err := h.attributeService.CreateAttribute(r.Context(), req.Name, entity.AttributeType(req.Type))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToAttributeResponse(definition))
+}
+
+// ListAttributes godoc
+// @Summary List product attributes
+// @Description Get all attribute definitions, for building product search filters
+// @Tags attributes
+// @Produce json
+// @Success 200 {array} dto.AttributeResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /attributes [get]
+func (h *AttributeHandler) ListAttributes(w http.ResponseWriter, r *http.Request) {
+	definitions, err := h.attributeService.ListAttributes(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	responses := make([]dto.AttributeResponse, len(definitions))
+	for i, definition := range definitions {
+		responses[i] = dto.ToAttributeResponse(definition)
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// SetProductAttribute godoc
+// @Summary Set a product attribute value
+// @Description Set or replace a product's value for an attribute (Admin only). The value must match the attribute type.
+// @Tags attributes
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param attribute_id path string true "Attribute ID"
+// @Param value body dto.ProductAttributeRequest true "Attribute value"
+// @Success 200 {object} dto.ProductAttributeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Value does not match the attribute type"
+// @Security BearerAuth
+// @Router /products/{id}/attributes/{attribute_id} [put]
+func (h *AttributeHandler) SetProductAttribute(w http.ResponseWriter, r *http.Request) {
+	productID, attributeID, ok := parseProductAttributeIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ProductAttributeRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	value, err := h.attributeService.SetProductAttribute(r.Context(), productID, attributeID, req.Value)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductAttributeResponse(value))
+}
+
+// RemoveProductAttribute godoc
+// @Summary Remove a product attribute value
+// @Description Remove a product's value for an attribute (Admin only)
+// @Tags attributes
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param attribute_id path string true "Attribute ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/attributes/{attribute_id} [delete]
+func (h *AttributeHandler) RemoveProductAttribute(w http.ResponseWriter, r *http.Request) {
+	productID, attributeID, ok := parseProductAttributeIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.attributeService.RemoveProductAttribute(r.Context(), productID, attributeID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseProductAttributeIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	attributeID, err := uuid.Parse(r.PathValue("attribute_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid attribute ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return productID, attributeID, true
+}
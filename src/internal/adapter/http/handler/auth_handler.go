@@ -1,9 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	authUseCase "github.com/marcofilho/go-ecommerce/src/usecase/auth"
@@ -19,36 +22,26 @@ func NewAuthHandler(uc authUseCase.AuthService) *AuthHandler {
 	}
 }
 
-type RegisterRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	Name     string `json:"name"`
-	Role     string `json:"role,omitempty" example:"customer"`
-}
-
-type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
-
 // Register godoc
 // @Summary Register a new user
-// @Description Create a new user account. Public registration creates customer accounts. Creating admin accounts requires admin authentication.
+// @Description Create a new user account. Public registration creates customer accounts, and takes an access_code while the store is in private beta. Creating admin or support accounts requires admin authentication.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body RegisterRequest true "Registration data"
+// @Param request body dto.RegisterRequest true "Registration data"
 // @Success 201 {object} dto.AuthResponse
 // @Failure 400 {object} dto.ErrorResponse
-// @Failure 401 {object} dto.ErrorResponse "Unauthorized - Admin authentication required for admin role"
-// @Failure 403 {object} dto.ErrorResponse "Forbidden - Only admins can create admin accounts"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - Admin authentication required for admin and support roles"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - Only admins can create admin and support accounts, the email or client IP is blocked, or the access code is missing or invalid"
+// @Failure 409 {object} dto.ErrorResponse "Email already registered, or the access code has been used up"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Failure 500 {object} dto.ErrorResponse
 // @Security BearerAuth
 // @Router /auth/register [post]
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
-	var req RegisterRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	var req dto.RegisterRequest
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -67,28 +60,33 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if req.Role == "admin" || req.Role == string(entity.RoleAdmin) {
+	if req.Role == string(entity.RoleAdmin) || req.Role == string(entity.RoleSupport) {
 		claims, err := middleware.GetUserFromContext(r)
 		if err != nil {
-			respondError(w, http.StatusUnauthorized, "Only authenticated admin users can create admin accounts")
+			respondError(w, http.StatusUnauthorized, "Only authenticated admin users can create "+req.Role+" accounts")
 			return
 		}
 		if claims.Role != entity.RoleAdmin {
-			respondError(w, http.StatusForbidden, "Only admin users can create admin accounts")
+			respondError(w, http.StatusForbidden, "Only admin users can create "+req.Role+" accounts")
 			return
 		}
 	}
 
 	authReq := authUseCase.RegisterRequest{
-		Email:    req.Email,
-		Password: req.Password,
-		Name:     req.Name,
-		Role:     req.Role,
+		Email:      req.Email,
+		Password:   req.Password,
+		Name:       req.Name,
+		Role:       req.Role,
+		IP:         middleware.ClientIP(r),
+		AccessCode: req.AccessCode,
+	}
+	if claims, err := middleware.GetUserFromContext(r); err == nil && claims.Role == entity.RoleAdmin {
+		authReq.ByAdmin = true
 	}
 
 	response, err := h.authUseCase.Register(r.Context(), authReq)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
@@ -97,19 +95,22 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 
 // Login godoc
 // @Summary User login
-// @Description Authenticate user and return JWT token
+// @Description Authenticate user and return JWT token. After repeated failures the account, or the client IP, is locked out for a while, doubling on each lockout.
 // @Tags auth
 // @Accept json
 // @Produce json
-// @Param request body LoginRequest true "Login credentials"
+// @Param request body dto.LoginRequest true "Login credentials"
 // @Success 200 {object} dto.AuthResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Email or client IP is blocked"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts, retry after the Retry-After header"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
-	var req LoginRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	var req dto.LoginRequest
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -126,13 +127,219 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	authReq := authUseCase.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		IP:       middleware.ClientIP(r),
 	}
 
 	response, err := h.authUseCase.Login(r.Context(), authReq)
 	if err != nil {
+		var locked *authUseCase.LockedError
+		if errors.As(err, &locked) {
+			w.Header().Set("Retry-After", strconv.Itoa(max(int(locked.RetryAfter.Seconds()), 1)))
+			respondError(w, http.StatusTooManyRequests, err.Error())
+			return
+		}
+		if errors.Is(err, entity.ErrForbidden) {
+			respondDomainError(w, err)
+			return
+		}
 		respondError(w, http.StatusUnauthorized, err.Error())
 		return
 	}
 
 	respondJSON(w, http.StatusOK, response)
 }
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke the token the request is authenticated with. It is rejected from now on, even before it expires; other sessions of the user are not affected.
+// @Tags auth
+// @Produce json
+// @Success 204 "No Content"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Token issued without an ID"
+// @Security BearerAuth
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.authUseCase.Logout(r.Context(), claims); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeToken godoc
+// @Summary Revoke a token
+// @Description Revoke a compromised token until it expires. Only the token is revoked; use the user's revoke-tokens endpoint to sign them out everywhere.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RevokeTokenRequest true "Token to revoke"
+// @Success 204 "No Content"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid, expired or legacy token"
+// @Security BearerAuth
+// @Router /admin/tokens/revoke [post]
+func (h *AuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.RevokeTokenRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.authUseCase.RevokeToken(r.Context(), req.Token, revocationReason(req.Reason), claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeUserTokens godoc
+// @Summary Revoke every token of a user
+// @Description Sign a user out everywhere: every token issued to them so far is rejected. They can log in again, unless their account is deactivated.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.RevokeUserTokensRequest false "Revocation reason"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/users/{id}/revoke-tokens [post]
+func (h *AuthHandler) RevokeUserTokens(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.RevokeUserTokensRequest
+	if r.ContentLength != 0 && !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	if err := h.authUseCase.RevokeUserTokens(r.Context(), userID, revocationReason(req.Reason), claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetUserStatus godoc
+// @Summary Activate or deactivate a user
+// @Description Deactivated users cannot log in, and the tokens they hold are revoked at once instead of staying valid until they expire. Admins cannot deactivate themselves.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param request body dto.UserStatusRequest true "Account status"
+// @Success 200 {object} dto.UserStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/users/{id}/status [put]
+func (h *AuthHandler) SetUserStatus(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.UserStatusRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	user, err := h.authUseCase.SetUserActive(r.Context(), userID, req.Active, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.UserStatusResponse{
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Active:    user.Active,
+		UpdatedAt: dto.FormatTime(user.UpdatedAt),
+	})
+}
+
+// Impersonate godoc
+// @Summary Impersonate a customer
+// @Description Issue a token of a customer to the admin, to reproduce an issue the way the customer sees it. The token expires after 15 minutes and carries the admin as impersonator_id; the impersonation and every change made with the token are audited with the admin as impersonator. Staff accounts cannot be impersonated.
+// @Tags auth
+// @Produce json
+// @Param user_id path string true "User ID of the customer"
+// @Success 200 {object} dto.AuthResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Not an admin, or the user is not a customer"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Account is inactive"
+// @Security BearerAuth
+// @Router /admin/impersonate/{user_id} [post]
+func (h *AuthHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("user_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	response, err := h.authUseCase.Impersonate(r.Context(), userID, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
+
+// revocationReason defaults the reason of an admin revocation
+func revocationReason(reason string) entity.RevocationReason {
+	if reason == "" {
+		return entity.RevocationAdmin
+	}
+	return entity.RevocationReason(reason)
+}
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
@@ -22,6 +23,9 @@ type mockAuthService struct {
 	registerFunc      func(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error)
 	loginFunc         func(ctx context.Context, req authUseCase.LoginRequest) (*authUseCase.AuthResponse, error)
 	validateTokenFunc func(tokenString string) (*auth.Claims, error)
+	logoutFunc        func(ctx context.Context, claims *auth.Claims) error
+	setUserActiveFunc func(ctx context.Context, userID uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error)
+	impersonateFunc   func(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*authUseCase.AuthResponse, error)
 }
 
 func (m *mockAuthService) Register(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error) {
@@ -45,6 +49,35 @@ func (m *mockAuthService) ValidateToken(tokenString string) (*auth.Claims, error
 	return nil, errors.New("Not implemented")
 }
 
+func (m *mockAuthService) Logout(ctx context.Context, claims *auth.Claims) error {
+	if m.logoutFunc != nil {
+		return m.logoutFunc(ctx, claims)
+	}
+	return errors.New("Not implemented")
+}
+
+func (m *mockAuthService) RevokeToken(ctx context.Context, tokenString string, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	return errors.New("Not implemented")
+}
+
+func (m *mockAuthService) RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	return errors.New("Not implemented")
+}
+
+func (m *mockAuthService) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error) {
+	if m.setUserActiveFunc != nil {
+		return m.setUserActiveFunc(ctx, userID, active, changedBy)
+	}
+	return nil, errors.New("Not implemented")
+}
+
+func (m *mockAuthService) Impersonate(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*authUseCase.AuthResponse, error) {
+	if m.impersonateFunc != nil {
+		return m.impersonateFunc(ctx, userID, adminID)
+	}
+	return nil, errors.New("Not implemented")
+}
+
 func TestAuthHandler_Register_Success(t *testing.T) {
 	mockService := &mockAuthService{
 		registerFunc: func(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error) {
@@ -61,7 +94,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "test@example.com",
 		Password: "password123",
 		Name:     "Test User",
@@ -79,7 +112,7 @@ func TestAuthHandler_Register_Success(t *testing.T) {
 	}
 
 	var response authUseCase.AuthResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+	if err := decodeData(w.Body, &response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
@@ -110,13 +143,13 @@ func TestAuthHandler_Register_InvalidJSON(t *testing.T) {
 func TestAuthHandler_Register_UseCaseError(t *testing.T) {
 	mockService := &mockAuthService{
 		registerFunc: func(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error) {
-			return nil, errors.New("Email already registered")
+			return nil, entity.ConflictError("Email already registered")
 		},
 	}
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "existing@example.com",
 		Password: "password123",
 		Name:     "Test User",
@@ -129,8 +162,8 @@ func TestAuthHandler_Register_UseCaseError(t *testing.T) {
 
 	handler.Register(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("Register() status = %d, want %d", w.Code, http.StatusBadRequest)
+	if w.Code != http.StatusConflict {
+		t.Errorf("Register() status = %d, want %d", w.Code, http.StatusConflict)
 	}
 }
 
@@ -150,7 +183,7 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := LoginRequest{
+	reqBody := dto.LoginRequest{
 		Email:    "test@example.com",
 		Password: "password123",
 	}
@@ -167,7 +200,7 @@ func TestAuthHandler_Login_Success(t *testing.T) {
 	}
 
 	var response authUseCase.AuthResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+	if err := decodeData(w.Body, &response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
@@ -204,7 +237,7 @@ func TestAuthHandler_Login_InvalidCredentials(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := LoginRequest{
+	reqBody := dto.LoginRequest{
 		Email:    "test@example.com",
 		Password: "wrongpassword",
 	}
@@ -230,7 +263,7 @@ func TestAuthHandler_Login_InactiveAccount(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := LoginRequest{
+	reqBody := dto.LoginRequest{
 		Email:    "inactive@example.com",
 		Password: "password123",
 	}
@@ -247,11 +280,40 @@ func TestAuthHandler_Login_InactiveAccount(t *testing.T) {
 	}
 }
 
+func TestAuthHandler_Login_LockedOut(t *testing.T) {
+	var clientIP string
+	mockService := &mockAuthService{
+		loginFunc: func(ctx context.Context, req authUseCase.LoginRequest) (*authUseCase.AuthResponse, error) {
+			clientIP = req.IP
+			return nil, &authUseCase.LockedError{RetryAfter: 90 * time.Second}
+		},
+	}
+
+	handler := NewAuthHandler(mockService)
+
+	body, _ := json.Marshal(dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(body))
+	req.RemoteAddr = "203.0.113.1:52100"
+	w := httptest.NewRecorder()
+
+	handler.Login(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Login() status = %d, want %d", w.Code, http.StatusTooManyRequests)
+	}
+	if got := w.Header().Get("Retry-After"); got != "90" {
+		t.Errorf("Login() Retry-After = %q, want 90", got)
+	}
+	if clientIP != "203.0.113.1" {
+		t.Errorf("Login() passed IP %q, want 203.0.113.1", clientIP)
+	}
+}
+
 func TestAuthHandler_Register_AdminWithoutAuth(t *testing.T) {
 	mockService := &mockAuthService{}
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "admin@example.com",
 		Password: "password123",
 		Name:     "Admin User",
@@ -274,7 +336,7 @@ func TestAuthHandler_Register_AdminWithCustomerAuth(t *testing.T) {
 	mockService := &mockAuthService{}
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "admin@example.com",
 		Password: "password123",
 		Name:     "Admin User",
@@ -318,7 +380,7 @@ func TestAuthHandler_Register_AdminWithAdminAuth(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "newadmin@example.com",
 		Password: "password123",
 		Name:     "New Admin",
@@ -346,7 +408,7 @@ func TestAuthHandler_Register_AdminWithAdminAuth(t *testing.T) {
 	}
 
 	var response authUseCase.AuthResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+	if err := decodeData(w.Body, &response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
@@ -371,7 +433,7 @@ func TestAuthHandler_Register_CustomerWithoutRole(t *testing.T) {
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "customer@example.com",
 		Password: "password123",
 		Name:     "Customer User",
@@ -389,7 +451,7 @@ func TestAuthHandler_Register_CustomerWithoutRole(t *testing.T) {
 	}
 
 	var response authUseCase.AuthResponse
-	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+	if err := decodeData(w.Body, &response); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 
@@ -401,13 +463,13 @@ func TestAuthHandler_Register_CustomerWithoutRole(t *testing.T) {
 func TestAuthHandler_Register_InvalidRole(t *testing.T) {
 	mockService := &mockAuthService{
 		registerFunc: func(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error) {
-			return nil, errors.New("Invalid role. Must be 'customer' or 'admin'")
+			return nil, entity.ValidationError("Invalid role. Must be 'customer' or 'admin'")
 		},
 	}
 
 	handler := NewAuthHandler(mockService)
 
-	reqBody := RegisterRequest{
+	reqBody := dto.RegisterRequest{
 		Email:    "test@example.com",
 		Password: "password123",
 		Name:     "Test User",
@@ -421,7 +483,107 @@ func TestAuthHandler_Register_InvalidRole(t *testing.T) {
 
 	handler.Register(w, req)
 
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("Register() status = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	claims := &auth.Claims{UserID: uuid.New(), Role: entity.RoleCustomer}
+	var revoked *auth.Claims
+	handler := NewAuthHandler(&mockAuthService{
+		logoutFunc: func(ctx context.Context, c *auth.Claims) error {
+			revoked = c
+			return nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, claims))
+	w := httptest.NewRecorder()
+
+	handler.Logout(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("Logout() status = %d, want %d", w.Code, http.StatusNoContent)
+	}
+	if revoked != claims {
+		t.Error("Logout() did not revoke the token of the request")
+	}
+}
+
+func TestAuthHandler_SetUserStatus_Deactivate(t *testing.T) {
+	adminClaims := &auth.Claims{UserID: uuid.New(), Role: entity.RoleAdmin}
+	userID := uuid.New()
+	handler := NewAuthHandler(&mockAuthService{
+		setUserActiveFunc: func(ctx context.Context, id uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error) {
+			if id != userID || active || changedBy != adminClaims.UserID {
+				t.Errorf("SetUserActive(%s, %v, %s) called with unexpected arguments", id, active, changedBy)
+			}
+			return &entity.User{ID: id, Email: "test@example.com", Active: active}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPut, "/api/admin/users/"+userID.String()+"/status", bytes.NewReader([]byte(`{"active":false}`)))
+	req.SetPathValue("id", userID.String())
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, adminClaims))
+	w := httptest.NewRecorder()
+
+	handler.SetUserStatus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("SetUserStatus() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var response dto.UserStatusResponse
+	if err := decodeData(w.Body, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Active {
+		t.Error("SetUserStatus() response active = true, want false")
+	}
+}
+
+func TestAuthHandler_Impersonate(t *testing.T) {
+	adminClaims := &auth.Claims{UserID: uuid.New(), Role: entity.RoleAdmin}
+	customerID := uuid.New()
+	handler := NewAuthHandler(&mockAuthService{
+		impersonateFunc: func(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*authUseCase.AuthResponse, error) {
+			if userID != customerID || adminID != adminClaims.UserID {
+				t.Errorf("Impersonate(%s, %s) called with unexpected arguments", userID, adminID)
+			}
+			return &authUseCase.AuthResponse{Token: "impersonation-token", UserID: userID, Role: entity.RoleCustomer, ImpersonatorID: &adminID}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/impersonate/"+customerID.String(), nil)
+	req.SetPathValue("user_id", customerID.String())
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, adminClaims))
+	w := httptest.NewRecorder()
+
+	handler.Impersonate(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Impersonate() status = %d, want %d", w.Code, http.StatusOK)
+	}
+	var response dto.AuthResponse
+	if err := decodeData(w.Body, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.Token != "impersonation-token" || response.ImpersonatorID == nil || *response.ImpersonatorID != adminClaims.UserID.String() {
+		t.Errorf("Impersonate() response = %+v, want the token impersonated by %s", response, adminClaims.UserID)
+	}
+}
+
+func TestAuthHandler_Impersonate_InvalidUserID(t *testing.T) {
+	handler := NewAuthHandler(&mockAuthService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/admin/impersonate/not-a-uuid", nil)
+	req.SetPathValue("user_id", "not-a-uuid")
+	w := httptest.NewRecorder()
+
+	handler.Impersonate(w, req)
+
 	if w.Code != http.StatusBadRequest {
-		t.Errorf("Register() status = %d, want %d", w.Code, http.StatusBadRequest)
+		t.Errorf("Impersonate() status = %d, want %d", w.Code, http.StatusBadRequest)
 	}
 }
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+)
+
+type BlocklistHandler struct {
+	blocklistService blocklist.BlocklistService
+}
+
+func NewBlocklistHandler(blocklistService blocklist.BlocklistService) *BlocklistHandler {
+	return &BlocklistHandler{
+		blocklistService: blocklistService,
+	}
+}
+
+// CreateBlocklistEntry godoc
+// @Summary Block an email, domain or IP range
+// @Description Block an email address, every address at a domain and its subdomains, or an IP address or CIDR range (Admin only). Blocked emails and addresses can't register, sign in or place orders. Other instances pick the change up within BLOCKLIST_CACHE_SECONDS.
+// @Tags blocklist
+// @Accept json
+// @Produce json
+// @Param entry body dto.BlocklistEntryRequest true "What to block"
+// @Success 201 {object} dto.BlocklistEntryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Already blocked"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/blocklist [post]
+func (h *BlocklistHandler) CreateBlocklistEntry(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.BlocklistEntryRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	entry, err := h.blocklistService.AddEntry(r.Context(), &entity.BlocklistEntry{
+		Kind:   entity.BlocklistKind(req.Kind),
+		Value:  req.Value,
+		Reason: req.Reason,
+	}, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToBlocklistEntryResponse(entry))
+}
+
+// ListBlocklistEntries godoc
+// @Summary List the blocklist
+// @Description Every blocked email, domain and IP range, newest first (Admin only)
+// @Tags blocklist
+// @Produce json
+// @Param kind query string false "Filter by kind (email, domain, ip_range)"
+// @Success 200 {array} dto.BlocklistEntryResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/blocklist [get]
+func (h *BlocklistHandler) ListBlocklistEntries(w http.ResponseWriter, r *http.Request) {
+	entries, err := h.blocklistService.ListEntries(r.Context(), entity.BlocklistKind(r.URL.Query().Get("kind")))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToBlocklistEntryResponses(entries))
+}
+
+// DeleteBlocklistEntry godoc
+// @Summary Unblock an email, domain or IP range
+// @Description Remove an entry from the blocklist (Admin only)
+// @Tags blocklist
+// @Produce json
+// @Param id path string true "Entry ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/blocklist/{id} [delete]
+func (h *BlocklistHandler) DeleteBlocklistEntry(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid entry ID")
+		return
+	}
+
+	if err := h.blocklistService.RemoveEntry(r.Context(), id, claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/catalogfeed"
+)
+
+type CatalogFeedHandler struct {
+	feedService catalogfeed.CatalogFeedService
+}
+
+func NewCatalogFeedHandler(feedService catalogfeed.CatalogFeedService) *CatalogFeedHandler {
+	return &CatalogFeedHandler{
+		feedService: feedService,
+	}
+}
+
+// GetCatalogFeed godoc
+// @Summary Download the product feed
+// @Description Returns the whole catalog as a Google Shopping / Facebook catalog compatible feed, one item per product or per variant. The feed is regenerated on a schedule and served from cache; it carries Last-Modified so marketplaces can poll with If-Modified-Since.
+// @Tags catalog-feed
+// @Produce application/xml
+// @Produce text/csv
+// @Param format query string false "Feed format (xml, csv)" default(xml)
+// @Success 200 {file} binary
+// @Success 304 "Not Modified"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /catalog/feed [get]
+func (h *CatalogFeedHandler) GetCatalogFeed(w http.ResponseWriter, r *http.Request) {
+	format := entity.FeedXML
+	if value := r.URL.Query().Get("format"); value != "" {
+		format = entity.FeedFormat(value)
+	}
+	if !format.IsValid() {
+		respondError(w, http.StatusBadRequest, "Invalid format. Must be 'xml' or 'csv'")
+		return
+	}
+
+	feed, err := h.feedService.GetFeed(r.Context(), format)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", h.feedService.ContentType(format))
+	http.ServeContent(w, r, "catalog."+string(format), feed.GeneratedAt, strings.NewReader(feed.Content))
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/catalogreport"
+)
+
+type CatalogReportHandler struct {
+	reportService catalogreport.CatalogReportService
+}
+
+func NewCatalogReportHandler(reportService catalogreport.CatalogReportService) *CatalogReportHandler {
+	return &CatalogReportHandler{
+		reportService: reportService,
+	}
+}
+
+// RunCatalogReport godoc
+// @Summary Run a catalog health report
+// @Description Scan the catalog for products without a description or price, orphan or incomplete variants, empty categories and broken slugs (Admin only). Issues are ordered by severity and link to the resource to fix.
+// @Tags catalog-report
+// @Produce json
+// @Success 201 {object} dto.CatalogReportResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/catalog-report [post]
+func (h *CatalogReportHandler) RunCatalogReport(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	report, err := h.reportService.RunReport(r.Context(), entity.ReportManual, &claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToCatalogReportResponse(report, report.Issues))
+}
+
+// GetLatestCatalogReport godoc
+// @Summary Get the latest catalog health report
+// @Description Get the most recent report, whether run on demand or by the scheduled job (Admin only)
+// @Tags catalog-report
+// @Produce json
+// @Param severity query string false "Only return issues of this severity (critical, warning, info)"
+// @Success 200 {object} dto.CatalogReportResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/catalog-report [get]
+func (h *CatalogReportHandler) GetLatestCatalogReport(w http.ResponseWriter, r *http.Request) {
+	severity := entity.CatalogIssueSeverity(r.URL.Query().Get("severity"))
+	if severity != "" && !severity.IsValid() {
+		respondError(w, http.StatusBadRequest, "Invalid severity. Must be 'critical', 'warning' or 'info'")
+		return
+	}
+
+	report, err := h.reportService.GetLatestReport(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	issues := report.Issues
+	if severity != "" {
+		issues = report.Filter(severity)
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCatalogReportResponse(report, issues))
+}
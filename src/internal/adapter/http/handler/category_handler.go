@@ -1,12 +1,16 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/usecase/category"
 )
 
@@ -31,27 +35,269 @@ func NewCategoryHandler(categoryService category.CategoryService) *CategoryHandl
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Parent category not found"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Security BearerAuth
 // @Router /categories [post]
 func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request) {
 	var req dto.CategoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request payload")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
-	category, err := h.categoryService.CreateCategory(r.Context(), req.Name)
+	parentID, err := parseParentID(req.ParentID)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, err.Error())
 		return
 	}
 
-	response := dto.CategoryResponse{
-		ID:   category.ID.String(),
-		Name: category.Name,
+	category, err := h.categoryService.CreateCategory(r.Context(), req.Name, parentID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToCategoryResponse(category))
+}
+
+// GetCategory godoc
+// @Summary Get a category
+// @Description Get a category by ID
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param fields query string false "Comma-separated attributes to return, e.g. id,name; all of them when empty"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Unknown field"
+// @Router /categories/{id} [get]
+func (h *CategoryHandler) GetCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	fields, err := dto.ParseFields[dto.CategoryResponse](r.URL.Query().Get("fields"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	cat, err := h.categoryService.GetCategory(r.Context(), id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.SelectFields(dto.ToCategoryResponse(cat), fields))
+}
+
+// UpdateCategory godoc
+// @Summary Update a category
+// @Description Rename a category or move it under another parent (Admin only). Omitting parent_id makes it a root category.
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param category body dto.CategoryRequest true "Category details"
+// @Success 200 {object} dto.CategoryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /categories/{id} [put]
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	var req dto.CategoryRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	parentID, err := parseParentID(req.ParentID)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	updated, err := h.categoryService.UpdateCategory(r.Context(), id, req.Name, parentID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCategoryResponse(updated))
+}
+
+// DeleteCategory godoc
+// @Summary Delete a category
+// @Description Delete a category (Admin only). Fails with 409 while products are assigned, unless force=true, which unassigns them first. Subcategories move up to the deleted category's parent.
+// @Tags categories
+// @Produce json
+// @Param id path string true "Category ID"
+// @Param force query bool false "Unassign products and delete anyway" default(false)
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /categories/{id} [delete]
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+
+	force := false
+	if raw := r.URL.Query().Get("force"); raw != "" {
+		force, err = strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid force value, use true or false")
+			return
+		}
+	}
+
+	if err := h.categoryService.DeleteCategory(r.Context(), id, force); err != nil {
+		respondDomainError(w, err)
+		return
 	}
 
-	respondJSON(w, http.StatusCreated, response)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetCategoryTree godoc
+// @Summary Get the category tree
+// @Description Get all categories nested under their parents, for storefront navigation menus
+// @Tags categories
+// @Produce json
+// @Success 200 {array} dto.CategoryTreeResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /categories/tree [get]
+func (h *CategoryHandler) GetCategoryTree(w http.ResponseWriter, r *http.Request) {
+	roots, err := h.categoryService.GetCategoryTree(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCategoryTreeResponse(roots))
+}
+
+// ListCategoryProducts godoc
+// @Summary List products of a category
+// @Description Get the products of a category by its URL slug, with pagination and sorting. The slug of a category merged into another redirects to the other's.
+// @Tags categories
+// @Produce json
+// @Param slug path string true "Category slug"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Page size" default(10)
+// @Param sort_by query string false "Sort by field (name, price, created_at)" default("name")
+// @Param sort_order query string false "Sort order (asc, desc)" default("asc")
+// @Success 200 {object} dto.CategoryProductsResponse
+// @Success 301 "Moved Permanently, to the category the slug's category was merged into"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Invalid sort field"
+// @Router /categories/{slug}/products [get]
+func (h *CategoryHandler) ListCategoryProducts(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	page, pageSize := parsePagination(r)
+
+	sort := repository.ProductSort{Field: repository.ProductSortName}
+	if sortBy := query.Get("sort_by"); sortBy != "" {
+		sort.Field = repository.ProductSortField(sortBy)
+	}
+	switch query.Get("sort_order") {
+	case "", "asc":
+	case "desc":
+		sort.Descending = true
+	default:
+		respondError(w, http.StatusBadRequest, "Invalid sort order, use asc or desc")
+		return
+	}
+
+	slug := r.PathValue("slug")
+	cat, products, total, err := h.categoryService.ListProductsBySlug(r.Context(), slug, sort, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	// The category was merged into another, whose page replaces its own
+	if cat.Slug != slug {
+		path := strings.Replace(middleware.RequestPath(r), "/"+slug+"/", "/"+cat.Slug+"/", 1)
+		target := url.URL{Path: path, RawQuery: r.URL.RawQuery}
+		w.Header().Set("Location", target.String())
+		w.WriteHeader(http.StatusMovedPermanently)
+		return
+	}
+
+	respondPage(w, r, dto.ToCategoryProductsResponse(cat, products, total, page, pageSize))
+}
+
+// MergeCategory godoc
+// @Summary Merge a category into another
+// @Description Move the products and subcategories of a category into the target category and delete it, all or nothing, for catalog cleanup (Admin only). The merged category's slug redirects to the target's.
+// @Tags categories
+// @Produce json
+// @Param id path string true "ID of the category to merge and delete"
+// @Param target_id path string true "ID of the category to merge into"
+// @Success 200 {object} dto.CategoryMergeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Target is the category itself or one of its descendants"
+// @Security BearerAuth
+// @Router /admin/categories/{id}/merge-into/{target_id} [post]
+func (h *CategoryHandler) MergeCategory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid category ID")
+		return
+	}
+	targetID, err := uuid.Parse(r.PathValue("target_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid target category ID")
+		return
+	}
+
+	target, moved, err := h.categoryService.MergeCategory(r.Context(), id, targetID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.CategoryMergeResponse{
+		Category:      dto.ToCategoryResponse(target),
+		ProductsMoved: moved,
+	})
+}
+
+func parseParentID(raw *string) (*uuid.UUID, error) {
+	if raw == nil || *raw == "" {
+		return nil, nil
+	}
+
+	parentID, err := uuid.Parse(*raw)
+	if err != nil {
+		return nil, errors.New("Invalid parent category ID")
+	}
+	return &parentID, nil
 }
 
 // ListCategories godoc
@@ -64,50 +310,35 @@ func (h *CategoryHandler) CreateCategory(w http.ResponseWriter, r *http.Request)
 // @Param page_size query int false "Page size" default(10)
 // @Param sort_by query string false "Sort by field (name, created_at)" default("name")
 // @Param sort_order query string false "Sort order (asc, desc)" default("asc")
+// @Param fields query string false "Comma-separated attributes to return of each category, e.g. id,name; all of them when empty"
 // @Success 200 {object} dto.CategoryListResponse
+// @Failure 422 {object} dto.ErrorResponse "Unknown field"
 // @Failure 500 {object} dto.ErrorResponse
 // @Router /categories [get]
 func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	page, pageSize := parsePagination(r)
 
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
+	fields, err := dto.ParseFields[dto.CategoryResponse](r.URL.Query().Get("fields"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
 	}
 
 	categories, total, err := h.categoryService.ListCategories(r.Context(), page, pageSize)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
 	categoryResponses := make([]dto.CategoryResponse, len(categories))
 	for i, cat := range categories {
-		categoryResponses[i] = dto.CategoryResponse{
-			ID:   cat.ID.String(),
-			Name: cat.Name,
-		}
+		categoryResponses[i] = dto.ToCategoryResponse(cat)
 	}
 
-	totalPages := (total + pageSize - 1) / pageSize
-	if total == 0 {
-		totalPages = 0
-	}
-
-	response := dto.CategoryListResponse{
-		Data: categoryResponses,
-		Pagination: dto.Pagination{
-			Page:       page,
-			PageSize:   pageSize,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}
-
-	respondJSON(w, http.StatusOK, response)
+	respondPage(w, r, dto.SelectPageFields(dto.CategoryListResponse{
+		Data:       categoryResponses,
+		Pagination: dto.NewPagination(page, pageSize, total),
+	}, fields))
 }
 
 // AssignCategoryToProduct godoc
@@ -118,11 +349,13 @@ func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request)
 // @Produce json
 // @Param id path string true "Product ID"
 // @Param request body dto.AssignCategoryRequest true "Category assignment"
-// @Success 200 {object} handler.MessageResponse
+// @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Security BearerAuth
 // @Router /products/{id}/categories [post]
 func (h *CategoryHandler) AssignCategoryToProduct(w http.ResponseWriter, r *http.Request) {
@@ -134,8 +367,7 @@ func (h *CategoryHandler) AssignCategoryToProduct(w http.ResponseWriter, r *http
 	}
 
 	var req dto.AssignCategoryRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request payload")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -146,11 +378,11 @@ func (h *CategoryHandler) AssignCategoryToProduct(w http.ResponseWriter, r *http
 	}
 
 	if err := h.categoryService.AssignCategoryToProduct(r.Context(), productID, categoryID); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, MessageResponse{Message: "Category assigned successfully"})
+	respondJSON(w, http.StatusOK, dto.MessageResponse{Message: "Category assigned successfully"})
 }
 
 // RemoveCategoryFromProduct godoc
@@ -161,7 +393,7 @@ func (h *CategoryHandler) AssignCategoryToProduct(w http.ResponseWriter, r *http
 // @Produce json
 // @Param id path string true "Product ID"
 // @Param category_id path string true "Category ID"
-// @Success 200 {object} handler.MessageResponse
+// @Success 200 {object} dto.MessageResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse
 // @Failure 403 {object} dto.ErrorResponse
@@ -184,11 +416,11 @@ func (h *CategoryHandler) RemoveCategoryFromProduct(w http.ResponseWriter, r *ht
 	}
 
 	if err := h.categoryService.RemoveCategoryFromProduct(r.Context(), productID, categoryID); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, MessageResponse{Message: "Category removed successfully"})
+	respondJSON(w, http.StatusOK, dto.MessageResponse{Message: "Category removed successfully"})
 }
 
 // GetProductCategories godoc
@@ -212,21 +444,14 @@ func (h *CategoryHandler) GetProductCategories(w http.ResponseWriter, r *http.Re
 
 	categories, err := h.categoryService.GetProductCategories(r.Context(), productID)
 	if err != nil {
-		respondError(w, http.StatusNotFound, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
 	categoryResponses := make([]dto.CategoryResponse, len(categories))
 	for i, cat := range categories {
-		categoryResponses[i] = dto.CategoryResponse{
-			ID:   cat.ID.String(),
-			Name: cat.Name,
-		}
+		categoryResponses[i] = dto.ToCategoryResponse(cat)
 	}
 
 	respondJSON(w, http.StatusOK, categoryResponses)
 }
-
-type MessageResponse struct {
-	Message string `json:"message"`
-}
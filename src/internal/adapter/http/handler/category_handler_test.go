@@ -2,7 +2,6 @@ package handler
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -15,65 +14,14 @@ import (
 
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/servicemocks"
+	"github.com/marcofilho/go-ecommerce/src/usecase/category"
 )
 
-// MockCategoryService is a mock implementation of category.CategoryService
-type MockCategoryService struct {
-	mock.Mock
-}
-
-func (m *MockCategoryService) CreateCategory(ctx context.Context, name string) (*entity.Category, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Category), args.Error(1)
-}
-
-func (m *MockCategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Category), args.Error(1)
-}
-
-func (m *MockCategoryService) ListCategories(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error) {
-	args := m.Called(ctx, page, pageSize)
-	return args.Get(0).([]*entity.Category), args.Get(1).(int), args.Error(2)
-}
-
-func (m *MockCategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, name string) (*entity.Category, error) {
-	args := m.Called(ctx, id, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Category), args.Error(1)
-}
-
-func (m *MockCategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockCategoryService) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	args := m.Called(ctx, productID, categoryID)
-	return args.Error(0)
-}
-
-func (m *MockCategoryService) RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	args := m.Called(ctx, productID, categoryID)
-	return args.Error(0)
-}
-
-func (m *MockCategoryService) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
-	args := m.Called(ctx, productID)
-	return args.Get(0).([]*entity.Category), args.Error(1)
-}
-
 func TestCategoryHandler_CreateCategory(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		categoryID := uuid.New()
@@ -87,7 +35,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockService.On("CreateCategory", mock.Anything, "Electronics").Return(expectedCategory, nil)
+		mockService.On("CreateCategory", mock.Anything, "Electronics", (*uuid.UUID)(nil)).Return(expectedCategory, nil)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/categories", bytes.NewReader(body))
 		w := httptest.NewRecorder()
@@ -97,7 +45,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 		assert.Equal(t, http.StatusCreated, w.Code)
 
 		var response dto.CategoryResponse
-		json.Unmarshal(w.Body.Bytes(), &response)
+		decodeData(w.Body, &response)
 		assert.Equal(t, categoryID.String(), response.ID)
 		assert.Equal(t, "Electronics", response.Name)
 
@@ -105,7 +53,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 	})
 
 	t.Run("Invalid JSON", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		req := httptest.NewRequest(http.MethodPost, "/api/categories", bytes.NewReader([]byte("invalid json")))
@@ -118,7 +66,7 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 	})
 
 	t.Run("Service Error", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		reqBody := dto.CategoryRequest{
@@ -126,21 +74,21 @@ func TestCategoryHandler_CreateCategory(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockService.On("CreateCategory", mock.Anything, "Electronics").Return(nil, errors.New("database error"))
+		mockService.On("CreateCategory", mock.Anything, "Electronics", (*uuid.UUID)(nil)).Return(nil, errors.New("database error"))
 
 		req := httptest.NewRequest(http.MethodPost, "/api/categories", bytes.NewReader(body))
 		w := httptest.NewRecorder()
 
 		handler.CreateCategory(w, req)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
 
 func TestCategoryHandler_ListCategories(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		categories := []*entity.Category{
@@ -171,8 +119,44 @@ func TestCategoryHandler_ListCategories(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("Selected fields", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		categories := []*entity.Category{{ID: categoryID, Name: "Electronics", Slug: "electronics"}}
+		mockService.On("ListCategories", mock.Anything, 1, 10).Return(categories, 1, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories?fields=name,id", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListCategories(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data       []json.RawMessage `json:"data"`
+			Pagination dto.Pagination    `json:"pagination"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.JSONEq(t, `{"id":"`+categoryID.String()+`","name":"Electronics"}`, string(response.Data[0]))
+		assert.Equal(t, 1, response.Pagination.Total)
+	})
+
+	t.Run("Unknown field", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories?fields=name,price", nil)
+		w := httptest.NewRecorder()
+
+		handler.ListCategories(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		mockService.AssertNotCalled(t, "ListCategories")
+	})
+
 	t.Run("Service Error", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		mockService.On("ListCategories", mock.Anything, 1, 10).Return([]*entity.Category{}, 0, errors.New("database error"))
@@ -187,9 +171,346 @@ func TestCategoryHandler_ListCategories(t *testing.T) {
 	})
 }
 
+func TestCategoryHandler_GetCategoryTree(t *testing.T) {
+	mockService := new(servicemocks.CategoryService)
+	handler := NewCategoryHandler(mockService)
+
+	laptops := &entity.Category{ID: uuid.New(), Name: "Laptops"}
+	electronics := &entity.Category{ID: uuid.New(), Name: "Electronics", Children: []*entity.Category{laptops}}
+
+	mockService.On("GetCategoryTree", mock.Anything).Return([]*entity.Category{electronics}, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/categories/tree", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetCategoryTree(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []dto.CategoryTreeResponse
+	decodeData(w.Body, &response)
+	assert.Len(t, response, 1)
+	assert.Equal(t, "Electronics", response[0].Name)
+	assert.Len(t, response[0].Children, 1)
+	assert.Equal(t, laptops.ID.String(), response[0].Children[0].ID)
+
+	mockService.AssertExpectations(t)
+}
+
+func TestCategoryHandler_UpdateCategory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		parentID := uuid.New()
+		parent := parentID.String()
+		body, _ := json.Marshal(dto.CategoryRequest{Name: "Laptops", ParentID: &parent})
+
+		mockService.On("UpdateCategory", mock.Anything, categoryID, "Laptops", &parentID).
+			Return(&entity.Category{ID: categoryID, Name: "Laptops", ParentID: &parentID}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/categories/"+categoryID.String(), bytes.NewReader(body))
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.UpdateCategory(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.CategoryResponse
+		decodeData(w.Body, &response)
+		assert.Equal(t, parent, *response.ParentID)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Cycle", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		parentID := uuid.New()
+		parent := parentID.String()
+		body, _ := json.Marshal(dto.CategoryRequest{Name: "Electronics", ParentID: &parent})
+
+		mockService.On("UpdateCategory", mock.Anything, categoryID, "Electronics", &parentID).Return(nil, entity.ErrCategoryCycle)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/categories/"+categoryID.String(), bytes.NewReader(body))
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.UpdateCategory(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Parent ID", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		body := []byte(`{"name":"Electronics","parent_id":"not-a-uuid"}`)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/categories/"+categoryID.String(), bytes.NewReader(body))
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.UpdateCategory(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"parent_id":"must be a valid UUID"`)
+		mockService.AssertNotCalled(t, "UpdateCategory")
+	})
+}
+
+func TestCategoryHandler_GetCategory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		mockService.On("GetCategory", mock.Anything, categoryID).
+			Return(&entity.Category{ID: categoryID, Name: "Electronics", Slug: "electronics"}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/"+categoryID.String(), nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.GetCategory(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.CategoryResponse
+		decodeData(w.Body, &response)
+		assert.Equal(t, "electronics", response.Slug)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		mockService.On("GetCategory", mock.Anything, categoryID).Return(nil, category.ErrCategoryNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/"+categoryID.String(), nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.GetCategory(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
+func TestCategoryHandler_DeleteCategory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		mockService.On("DeleteCategory", mock.Anything, categoryID, false).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/categories/"+categoryID.String(), nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.DeleteCategory(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Products Assigned", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		mockService.On("DeleteCategory", mock.Anything, categoryID, false).Return(category.ErrCategoryInUse)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/categories/"+categoryID.String(), nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.DeleteCategory(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Force", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+		mockService.On("DeleteCategory", mock.Anything, categoryID, true).Return(nil)
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/categories/"+categoryID.String()+"?force=true", nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.DeleteCategory(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Force", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		categoryID := uuid.New()
+
+		req := httptest.NewRequest(http.MethodDelete, "/api/categories/"+categoryID.String()+"?force=maybe", nil)
+		req.SetPathValue("id", categoryID.String())
+		w := httptest.NewRecorder()
+
+		handler.DeleteCategory(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "DeleteCategory")
+	})
+}
+
+func TestCategoryHandler_ListCategoryProducts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		cat := &entity.Category{ID: uuid.New(), Name: "Laptops", Slug: "laptops"}
+		products := []*entity.Product{{ID: uuid.New(), Name: "Laptop Pro", Price: 2000}}
+		sort := repository.ProductSort{Field: repository.ProductSortPrice, Descending: true}
+
+		mockService.On("ListProductsBySlug", mock.Anything, "laptops", sort, 2, 5).Return(cat, products, 6, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/laptops/products?page=2&page_size=5&sort_by=price&sort_order=desc", nil)
+		req.SetPathValue("slug", "laptops")
+		w := httptest.NewRecorder()
+
+		handler.ListCategoryProducts(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.CategoryProductsResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "laptops", response.Data.Category.Slug)
+		assert.Len(t, response.Data.Products, 1)
+		assert.Equal(t, 2, response.Pagination.TotalPages)
+
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Category", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		mockService.On("ListProductsBySlug", mock.Anything, "missing", mock.Anything, 1, 10).Return(nil, nil, 0, category.ErrCategoryNotFound)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/missing/products", nil)
+		req.SetPathValue("slug", "missing")
+		w := httptest.NewRecorder()
+
+		handler.ListCategoryProducts(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Merged Category", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		merged := &entity.Category{ID: uuid.New(), Name: "Laptops", Slug: "laptops"}
+		mockService.On("ListProductsBySlug", mock.Anything, "notebooks", mock.Anything, 2, 10).Return(merged, nil, 0, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/notebooks/products?page=2", nil)
+		req.SetPathValue("slug", "notebooks")
+		w := httptest.NewRecorder()
+
+		handler.ListCategoryProducts(w, req)
+
+		assert.Equal(t, http.StatusMovedPermanently, w.Code)
+		assert.Equal(t, "/api/categories/laptops/products?page=2", w.Header().Get("Location"))
+	})
+
+	t.Run("Invalid Sort Order", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/categories/laptops/products?sort_order=up", nil)
+		req.SetPathValue("slug", "laptops")
+		w := httptest.NewRecorder()
+
+		handler.ListCategoryProducts(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListProductsBySlug")
+	})
+}
+
+func TestCategoryHandler_MergeCategory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		sourceID := uuid.New()
+		target := &entity.Category{ID: uuid.New(), Name: "Phones", Slug: "phones"}
+		mockService.On("MergeCategory", mock.Anything, sourceID, target.ID).Return(target, 4, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/"+sourceID.String()+"/merge-into/"+target.ID.String(), nil)
+		req.SetPathValue("id", sourceID.String())
+		req.SetPathValue("target_id", target.ID.String())
+		w := httptest.NewRecorder()
+
+		handler.MergeCategory(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dto.Response[dto.CategoryMergeResponse]
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "phones", response.Data.Category.Slug)
+		assert.Equal(t, 4, response.Data.ProductsMoved)
+	})
+
+	t.Run("Into A Descendant", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		sourceID, targetID := uuid.New(), uuid.New()
+		mockService.On("MergeCategory", mock.Anything, sourceID, targetID).Return(nil, 0, entity.ErrCategoryMergeCycle)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/"+sourceID.String()+"/merge-into/"+targetID.String(), nil)
+		req.SetPathValue("id", sourceID.String())
+		req.SetPathValue("target_id", targetID.String())
+		w := httptest.NewRecorder()
+
+		handler.MergeCategory(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Invalid Target ID", func(t *testing.T) {
+		mockService := new(servicemocks.CategoryService)
+		handler := NewCategoryHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/admin/categories/"+uuid.NewString()+"/merge-into/phones", nil)
+		req.SetPathValue("id", uuid.NewString())
+		req.SetPathValue("target_id", "phones")
+		w := httptest.NewRecorder()
+
+		handler.MergeCategory(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "MergeCategory")
+	})
+}
+
 func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -213,7 +534,7 @@ func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 	})
 
 	t.Run("Invalid Product ID", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		reqBody := dto.AssignCategoryRequest{
@@ -232,7 +553,7 @@ func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 	})
 
 	t.Run("Invalid Category ID", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -248,12 +569,13 @@ func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 
 		handler.AssignCategoryToProduct(w, req)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), `"category_id":"must be a valid UUID"`)
 		mockService.AssertNotCalled(t, "AssignCategoryToProduct")
 	})
 
 	t.Run("Service Error", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -264,7 +586,7 @@ func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 		}
 		body, _ := json.Marshal(reqBody)
 
-		mockService.On("AssignCategoryToProduct", mock.Anything, productID, categoryID).Return(errors.New("already assigned"))
+		mockService.On("AssignCategoryToProduct", mock.Anything, productID, categoryID).Return(entity.NotFoundError("Category not found"))
 
 		req := httptest.NewRequest(http.MethodPost, "/api/products/"+productID.String()+"/categories", bytes.NewReader(body))
 		req.SetPathValue("id", productID.String())
@@ -272,14 +594,14 @@ func TestCategoryHandler_AssignCategoryToProduct(t *testing.T) {
 
 		handler.AssignCategoryToProduct(w, req)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
 
 func TestCategoryHandler_RemoveCategoryFromProduct(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -299,7 +621,7 @@ func TestCategoryHandler_RemoveCategoryFromProduct(t *testing.T) {
 	})
 
 	t.Run("Invalid Product ID", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		categoryID := uuid.New()
@@ -316,7 +638,7 @@ func TestCategoryHandler_RemoveCategoryFromProduct(t *testing.T) {
 	})
 
 	t.Run("Invalid Category ID", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -333,13 +655,13 @@ func TestCategoryHandler_RemoveCategoryFromProduct(t *testing.T) {
 	})
 
 	t.Run("Service Error", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
 		categoryID := uuid.New()
 
-		mockService.On("RemoveCategoryFromProduct", mock.Anything, productID, categoryID).Return(errors.New("not found"))
+		mockService.On("RemoveCategoryFromProduct", mock.Anything, productID, categoryID).Return(entity.NotFoundError("Category not found"))
 
 		req := httptest.NewRequest(http.MethodDelete, "/api/products/"+productID.String()+"/categories/"+categoryID.String(), nil)
 		req.SetPathValue("id", productID.String())
@@ -348,14 +670,14 @@ func TestCategoryHandler_RemoveCategoryFromProduct(t *testing.T) {
 
 		handler.RemoveCategoryFromProduct(w, req)
 
-		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, http.StatusNotFound, w.Code)
 		mockService.AssertExpectations(t)
 	})
 }
 
 func TestCategoryHandler_GetProductCategories(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
@@ -375,14 +697,14 @@ func TestCategoryHandler_GetProductCategories(t *testing.T) {
 		assert.Equal(t, http.StatusOK, w.Code)
 
 		var response []dto.CategoryResponse
-		json.Unmarshal(w.Body.Bytes(), &response)
+		decodeData(w.Body, &response)
 		assert.Len(t, response, 2)
 
 		mockService.AssertExpectations(t)
 	})
 
 	t.Run("Invalid Product ID", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		req := httptest.NewRequest(http.MethodGet, "/api/products/invalid/categories", nil)
@@ -396,12 +718,12 @@ func TestCategoryHandler_GetProductCategories(t *testing.T) {
 	})
 
 	t.Run("Service Error", func(t *testing.T) {
-		mockService := new(MockCategoryService)
+		mockService := new(servicemocks.CategoryService)
 		handler := NewCategoryHandler(mockService)
 
 		productID := uuid.New()
 
-		mockService.On("GetProductCategories", mock.Anything, productID).Return([]*entity.Category{}, errors.New("product not found"))
+		mockService.On("GetProductCategories", mock.Anything, productID).Return([]*entity.Category{}, entity.NotFoundError("Product not found"))
 
 		req := httptest.NewRequest(http.MethodGet, "/api/products/"+productID.String()+"/categories", nil)
 		req.SetPathValue("id", productID.String())
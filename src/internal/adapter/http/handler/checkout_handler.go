@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/allocation"
+	"github.com/marcofilho/go-ecommerce/src/usecase/order"
+)
+
+type CheckoutHandler struct {
+	allocationService allocation.AllocationService
+	orderService      order.OrderService
+}
+
+func NewCheckoutHandler(allocationService allocation.AllocationService, orderService order.OrderService) *CheckoutHandler {
+	return &CheckoutHandler{
+		allocationService: allocationService,
+		orderService:      orderService,
+	}
+}
+
+// PreviewTotals godoc
+// @Summary Preview the totals of a cart
+// @Description Price a prospective cart as checkout would: the customer's prices, discounts and tax, line by line. The same checks as placing the order apply, but no stock is reserved and nothing is saved.
+// @Tags checkout
+// @Accept json
+// @Produce json
+// @Param cart body dto.CheckoutPreviewRequest true "Prospective cart"
+// @Success 200 {object} dto.TotalsResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Purchase window required"
+// @Failure 404 {object} dto.ErrorResponse "Product or variant not found"
+// @Failure 409 {object} dto.ErrorResponse "Insufficient stock"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /checkout/preview [post]
+func (h *CheckoutHandler) PreviewTotals(w http.ResponseWriter, r *http.Request) {
+	var req dto.CheckoutPreviewRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	items := make([]order.CreateOrderItem, 0, len(req.Products))
+	for _, product := range req.Products {
+		productID, err := uuid.Parse(product.ProductID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+
+		item := order.CreateOrderItem{
+			ProductID: productID,
+			Quantity:  product.Quantity,
+		}
+
+		if product.VariantID != nil && *product.VariantID != "" {
+			variantID, err := uuid.Parse(*product.VariantID)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid variant ID")
+				return
+			}
+			item.VariantID = &variantID
+		}
+
+		items = append(items, item)
+	}
+
+	var userID *uuid.UUID
+	if claims, err := middleware.GetUserFromContext(r); err == nil {
+		userID = &claims.UserID
+	}
+
+	totals, err := h.orderService.QuoteOrder(r.Context(), userID, items)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToTotalsResponse(totals))
+}
+
+// PreviewAllocation godoc
+// @Summary Preview inventory allocation
+// @Description Show which warehouse each item of a prospective cart would ship from, its expected ship date and any splits. No stock is reserved.
+// @Tags checkout
+// @Accept json
+// @Produce json
+// @Param cart body dto.AllocationPreviewRequest true "Prospective cart"
+// @Success 200 {object} dto.AllocationPreviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /checkout/preview-allocation [post]
+func (h *CheckoutHandler) PreviewAllocation(w http.ResponseWriter, r *http.Request) {
+	var req dto.AllocationPreviewRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	items := make([]allocation.CartItem, 0, len(req.Products))
+	for _, product := range req.Products {
+		productID, err := uuid.Parse(product.ProductID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid product ID")
+			return
+		}
+
+		item := allocation.CartItem{
+			ProductID: productID,
+			Quantity:  product.Quantity,
+		}
+
+		if product.VariantID != nil && *product.VariantID != "" {
+			variantID, err := uuid.Parse(*product.VariantID)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid variant ID")
+				return
+			}
+			item.VariantID = &variantID
+		}
+
+		items = append(items, item)
+	}
+
+	plan, err := h.allocationService.PreviewAllocation(r.Context(), items)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToAllocationPreviewResponse(plan))
+}
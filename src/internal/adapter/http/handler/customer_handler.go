@@ -0,0 +1,398 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/customer"
+)
+
+type CustomerHandler struct {
+	customerService customer.CustomerService
+}
+
+func NewCustomerHandler(customerService customer.CustomerService) *CustomerHandler {
+	return &CustomerHandler{
+		customerService: customerService,
+	}
+}
+
+// GetProfile godoc
+// @Summary Get customer profile
+// @Description Admin view of a customer account with internal notes, risk events and the computed risk score
+// @Tags customers
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.CustomerProfileResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/customers/{id} [get]
+func (h *CustomerHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	profile, err := h.customerService.GetProfile(r.Context(), userID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerProfileResponse(profile.User, profile.Notes, profile.RiskEvents, profile.RiskScore, profile.RiskLevel))
+}
+
+// GetSummary godoc
+// @Summary Get customer purchase summary
+// @Description Order count, lifetime spend, average order value, last order date and the 3 categories bought most, over live and archived orders. Spend counts the paid orders that were not cancelled.
+// @Tags customers
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} dto.CustomerSummaryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/customers/{id}/summary [get]
+func (h *CustomerHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	summary, err := h.customerService.GetSummary(r.Context(), userID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerSummaryResponse(userID, summary))
+}
+
+// AddNote godoc
+// @Summary Add internal note to a customer
+// @Description Attach an internal note to a customer account. Notes are never shown to the customer.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param note body dto.CustomerNoteRequest true "Note"
+// @Success 201 {object} dto.CustomerNoteResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/customers/{id}/notes [post]
+func (h *CustomerHandler) AddNote(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.CustomerNoteRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	note, err := h.customerService.AddNote(r.Context(), userID, claims.UserID, req.Body)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToCustomerNoteResponse(note))
+}
+
+// RecordRiskEvent godoc
+// @Summary Record a customer risk event
+// @Description Record a chargeback, failed payment or abuse report against a customer. Risk events feed the customer's risk score used by order fraud screening.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param event body dto.CustomerRiskEventRequest true "Risk event"
+// @Success 201 {object} dto.CustomerRiskEventResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/customers/{id}/risk-events [post]
+func (h *CustomerHandler) RecordRiskEvent(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.CustomerRiskEventRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	event, err := h.customerService.RecordRiskEvent(r.Context(), userID, entity.RiskEventType(req.Type), req.Reference)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToCustomerRiskEventResponse(event))
+}
+
+// SetCustomerGroup godoc
+// @Summary Set the customer group of a customer
+// @Description Move a customer to a customer group, e.g. wholesale, so their orders are priced from the group's price list tiers. Customers start in retail.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param id path string true "User ID"
+// @Param group body dto.CustomerGroupRequest true "Customer group"
+// @Success 200 {object} dto.CustomerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/customers/{id}/group [put]
+func (h *CustomerHandler) SetCustomerGroup(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	var req dto.CustomerGroupRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	data, err := h.customerService.SetCustomerGroup(r.Context(), userID, req.CustomerGroup)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerResponse(data))
+}
+
+// GetMyCustomer godoc
+// @Summary Get my customer data
+// @Description Contact details, addresses and marketing consent of the authenticated user. Empty until first saved.
+// @Tags customers
+// @Produce json
+// @Success 200 {object} dto.CustomerResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/profile [get]
+func (h *CustomerHandler) GetMyCustomer(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	data, err := h.customerService.GetCustomer(r.Context(), claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerResponse(data))
+}
+
+// UpdateMyCustomer godoc
+// @Summary Update my customer data
+// @Description Replace the contact details, addresses and marketing consent of the authenticated user. Addresses left out of the request are removed.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customer body dto.CustomerRequest true "Customer data"
+// @Success 200 {object} dto.CustomerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /users/me/profile [put]
+func (h *CustomerHandler) UpdateMyCustomer(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.CustomerRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	updated, err := h.customerService.UpdateCustomer(r.Context(), claims.UserID, customer.CustomerUpdate{
+		Phone:          req.Phone,
+		MarketingEmail: req.MarketingEmail,
+		MarketingSMS:   req.MarketingSMS,
+		Addresses:      dto.ToCustomerAddresses(req.Addresses),
+	})
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerResponse(updated))
+}
+
+// SendMyPhoneVerification godoc
+// @Summary Send a phone verification code
+// @Description Text a six digit code to the phone of the authenticated user, to be entered at /users/me/phone/verify within 10 minutes. Only a verified phone is sent text messages such as shipping updates. A new code can be asked for once a minute.
+// @Tags customers
+// @Produce json
+// @Success 202 {object} dto.MessageResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Phone already verified, code just sent, or text messages not enabled"
+// @Failure 422 {object} dto.ErrorResponse "No phone saved"
+// @Security BearerAuth
+// @Router /users/me/phone/verification [post]
+func (h *CustomerHandler) SendMyPhoneVerification(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.customerService.SendPhoneVerification(r.Context(), claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusAccepted, dto.MessageResponse{Message: "Verification code sent"})
+}
+
+// VerifyMyPhone godoc
+// @Summary Verify my phone
+// @Description Confirm the phone of the authenticated user with the code texted to it. Five wrong codes void the code and a new one has to be sent. Changing the phone later makes it unverified again.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param verification body dto.VerifyPhoneRequest true "Verification code"
+// @Success 200 {object} dto.CustomerResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "No code sent, code expired or too many wrong codes"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid code"
+// @Security BearerAuth
+// @Router /users/me/phone/verify [post]
+func (h *CustomerHandler) VerifyMyPhone(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.VerifyPhoneRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	verified, err := h.customerService.VerifyPhone(r.Context(), claims.UserID, req.Code)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToCustomerResponse(verified))
+}
+
+// ExportMyData godoc
+// @Summary Export my data
+// @Description Download the personal data kept about the authenticated user: account, customer data and orders. Refused to admins impersonating the user.
+// @Tags customers
+// @Produce json
+// @Success 200 {object} dto.DataExportResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Impersonation token"
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/export [get]
+func (h *CustomerHandler) ExportMyData(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	export, err := h.customerService.ExportData(r.Context(), claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", `attachment; filename="my-data-`+export.ExportedAt.Format("2006-01-02")+`.json"`)
+	respondJSON(w, http.StatusOK, dto.ToDataExportResponse(export.User, export.Customer, export.Orders, export.ExportedAt))
+}
+
+// DeleteMyAccount godoc
+// @Summary Delete my account
+// @Description Erase the authenticated customer's account, confirmed with their password. Orders are kept for accounting but anonymized; customer data, notes and the login are removed and every token is revoked. Staff accounts cannot be deleted this way, nor can admins impersonating the user delete it.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param confirmation body dto.DeleteAccountRequest true "Password confirmation"
+// @Success 200 {object} dto.DeleteAccountResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Incorrect password, or impersonation token"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /users/me [delete]
+func (h *CustomerHandler) DeleteMyAccount(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.DeleteAccountRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	anonymized, err := h.customerService.DeleteAccount(r.Context(), claims.UserID, req.Password)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.DeleteAccountResponse{
+		Message:          "Account deleted",
+		OrdersAnonymized: anonymized,
+	})
+}
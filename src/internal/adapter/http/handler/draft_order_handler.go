@@ -0,0 +1,386 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/draftorder"
+)
+
+type DraftOrderHandler struct {
+	draftOrderService draftorder.DraftOrderService
+}
+
+func NewDraftOrderHandler(draftOrderService draftorder.DraftOrderService) *DraftOrderHandler {
+	return &DraftOrderHandler{
+		draftOrderService: draftOrderService,
+	}
+}
+
+// CreateDraftOrder godoc
+// @Summary Create a draft order
+// @Description Compose an order for a customer, e.g. taken over the phone (Admin only). Drafts hold no stock and no prices: checkout prices the items and reserves the stock when the draft is converted.
+// @Tags draft-orders
+// @Accept json
+// @Produce json
+// @Param draft body dto.DraftOrderRequest true "Customer and items"
+// @Success 201 {object} dto.DraftOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 404 {object} dto.ErrorResponse "Product, variant or account not found"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/draft-orders [post]
+func (h *DraftOrderHandler) CreateDraftOrder(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	input, ok := draftOrderInput(w, r)
+	if !ok {
+		return
+	}
+
+	draft, err := h.draftOrderService.CreateDraft(r.Context(), claims.UserID, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToDraftOrderResponse(draft, h.draftOrderService.PaymentLink(draft)))
+}
+
+// ListDraftOrders godoc
+// @Summary List draft orders
+// @Description Paginated draft orders, newest first (Admin only)
+// @Tags draft-orders
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (open, sent, converted, cancelled)"
+// @Param customer_id query int false "Only drafts of this customer"
+// @Success 200 {object} dto.DraftOrderListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/draft-orders [get]
+func (h *DraftOrderHandler) ListDraftOrders(w http.ResponseWriter, r *http.Request) {
+	var filters repository.DraftOrderFilters
+	if status := r.URL.Query().Get("status"); status != "" {
+		draftStatus := entity.DraftOrderStatus(status)
+		filters.Status = &draftStatus
+	}
+	if customerIDStr := r.URL.Query().Get("customer_id"); customerIDStr != "" {
+		customerID, err := strconv.Atoi(customerIDStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid customer ID")
+			return
+		}
+		filters.CustomerID = &customerID
+	}
+	page, pageSize := parsePagination(r)
+
+	drafts, total, err := h.draftOrderService.ListDrafts(r.Context(), filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToDraftOrderListResponse(drafts, h.draftOrderService.PaymentLink, total, page, pageSize))
+}
+
+// GetDraftOrder godoc
+// @Summary Get a draft order
+// @Description With the totals checkout would charge for it today, while it is open or sent and its products can be ordered
+// @Tags draft-orders
+// @Produce json
+// @Param id path string true "Draft order ID"
+// @Success 200 {object} dto.DraftOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/draft-orders/{id} [get]
+func (h *DraftOrderHandler) GetDraftOrder(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid draft order ID")
+		return
+	}
+
+	draft, err := h.draftOrderService.GetDraft(r.Context(), id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	response := dto.ToDraftOrderResponse(draft, h.draftOrderService.PaymentLink(draft))
+	if response.Totals, err = h.quote(r, draft); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// UpdateDraftOrder godoc
+// @Summary Update a draft order
+// @Description Replace the customer, note and items of an open or sent draft (Admin only). A payment link already sent keeps working and places the updated items.
+// @Tags draft-orders
+// @Accept json
+// @Produce json
+// @Param id path string true "Draft order ID"
+// @Param draft body dto.DraftOrderRequest true "Customer and items"
+// @Success 200 {object} dto.DraftOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 404 {object} dto.ErrorResponse "Draft order, product, variant or account not found"
+// @Failure 409 {object} dto.ErrorResponse "Draft order converted or cancelled"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/draft-orders/{id} [put]
+func (h *DraftOrderHandler) UpdateDraftOrder(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid draft order ID")
+		return
+	}
+
+	input, ok := draftOrderInput(w, r)
+	if !ok {
+		return
+	}
+
+	draft, err := h.draftOrderService.UpdateDraft(r.Context(), claims.UserID, id, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToDraftOrderResponse(draft, h.draftOrderService.PaymentLink(draft)))
+}
+
+// SendDraftOrderLink godoc
+// @Summary Email the payment link of a draft order
+// @Description Email the customer's account a link to accept the draft and place it as an order (Admin only). The link is valid for DRAFT_ORDER_LINK_DAYS; sending it again extends it. Uses the draft_order.payment_link email template when there is one.
+// @Tags draft-orders
+// @Produce json
+// @Param id path string true "Draft order ID"
+// @Success 200 {object} dto.DraftOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Draft order converted or cancelled"
+// @Failure 422 {object} dto.ErrorResponse "Draft order has no customer account"
+// @Security BearerAuth
+// @Router /admin/draft-orders/{id}/send [post]
+func (h *DraftOrderHandler) SendDraftOrderLink(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid draft order ID")
+		return
+	}
+
+	draft, err := h.draftOrderService.SendLink(r.Context(), claims.UserID, id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToDraftOrderResponse(draft, h.draftOrderService.PaymentLink(draft)))
+}
+
+// ConvertDraftOrder godoc
+// @Summary Convert a draft order to an order
+// @Description Place the draft as an order through checkout, e.g. once the customer agreed over the phone (Admin only). Prices, stock, purchase limits and fraud screening apply as for any order; when checkout fails the draft stays open.
+// @Tags draft-orders
+// @Produce json
+// @Param id path string true "Draft order ID"
+// @Success 201 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission, or rejected by fraud screening"
+// @Failure 404 {object} dto.ErrorResponse "Draft order, product or variant not found"
+// @Failure 409 {object} dto.ErrorResponse "Draft order converted or cancelled, or insufficient stock"
+// @Security BearerAuth
+// @Router /admin/draft-orders/{id}/convert [post]
+func (h *DraftOrderHandler) ConvertDraftOrder(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid draft order ID")
+		return
+	}
+
+	order, err := h.draftOrderService.ConvertDraft(r.Context(), claims.UserID, id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToOrderResponse(order))
+}
+
+// CancelDraftOrder godoc
+// @Summary Cancel a draft order
+// @Description Close an open or sent draft; its payment link stops working (Admin only)
+// @Tags draft-orders
+// @Produce json
+// @Param id path string true "Draft order ID"
+// @Success 200 {object} dto.DraftOrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires draft_order:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Draft order converted or cancelled"
+// @Security BearerAuth
+// @Router /admin/draft-orders/{id}/cancel [post]
+func (h *DraftOrderHandler) CancelDraftOrder(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid draft order ID")
+		return
+	}
+
+	draft, err := h.draftOrderService.CancelDraft(r.Context(), claims.UserID, id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToDraftOrderResponse(draft, h.draftOrderService.PaymentLink(draft)))
+}
+
+// GetDraftOrderLink godoc
+// @Summary View a draft order through its payment link
+// @Description What the payment link emailed to the customer offers, with the totals accepting it would charge today. The totals are left out while its products can't be ordered as they stand. The token in the link is the only credential.
+// @Tags draft-orders
+// @Produce json
+// @Param token path string true "Token of the payment link"
+// @Success 200 {object} dto.DraftOrderLinkResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Link expired or order already placed"
+// @Router /draft-orders/{token} [get]
+func (h *DraftOrderHandler) GetDraftOrderLink(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.draftOrderService.GetByLink(r.Context(), r.PathValue("token"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	response := dto.ToDraftOrderLinkResponse(draft)
+	if response.Totals, err = h.quote(r, draft); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// quote prices the draft as checkout would today, nil when it can't be
+func (h *DraftOrderHandler) quote(r *http.Request, draft *entity.DraftOrder) (*dto.TotalsResponse, error) {
+	totals, err := h.draftOrderService.Quote(r.Context(), draft)
+	if err != nil || totals == nil {
+		return nil, err
+	}
+	response := dto.ToTotalsResponse(*totals)
+	return &response, nil
+}
+
+// AcceptDraftOrderLink godoc
+// @Summary Accept a draft order through its payment link
+// @Description Place the draft as an order for the customer's account through checkout, to be paid like any order. The token in the link is the only credential.
+// @Tags draft-orders
+// @Produce json
+// @Param token path string true "Token of the payment link"
+// @Success 201 {object} dto.OrderResponse
+// @Failure 403 {object} dto.ErrorResponse "Rejected by fraud screening or the blocklist"
+// @Failure 404 {object} dto.ErrorResponse "Link, product or variant not found"
+// @Failure 409 {object} dto.ErrorResponse "Link expired, order already placed or insufficient stock"
+// @Router /draft-orders/{token}/accept [post]
+func (h *DraftOrderHandler) AcceptDraftOrderLink(w http.ResponseWriter, r *http.Request) {
+	order, err := h.draftOrderService.AcceptLink(r.Context(), r.PathValue("token"), middleware.ClientIP(r))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToOrderResponse(order))
+}
+
+// draftOrderInput decodes a DraftOrderRequest. It writes the error response
+// itself and reports whether the handler can go on.
+func draftOrderInput(w http.ResponseWriter, r *http.Request) (draftorder.Input, bool) {
+	var req dto.DraftOrderRequest
+	if !decodeAndValidate(w, r, &req) {
+		return draftorder.Input{}, false
+	}
+
+	input := draftorder.Input{
+		CustomerID: req.CustomerID,
+		Note:       req.Note,
+		Items:      make([]draftorder.Item, 0, len(req.Products)),
+	}
+	if req.UserID != nil {
+		userID, err := uuid.Parse(*req.UserID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid user ID")
+			return draftorder.Input{}, false
+		}
+		input.UserID = &userID
+	}
+	for _, product := range req.Products {
+		productID, err := uuid.Parse(product.ProductID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid product ID")
+			return draftorder.Input{}, false
+		}
+		item := draftorder.Item{ProductID: productID, Quantity: product.Quantity}
+		if product.VariantID != nil && *product.VariantID != "" {
+			variantID, err := uuid.Parse(*product.VariantID)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid variant ID")
+				return draftorder.Input{}, false
+			}
+			item.VariantID = &variantID
+		}
+		input.Items = append(input.Items, item)
+	}
+	return input, true
+}
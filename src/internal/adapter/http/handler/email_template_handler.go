@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+)
+
+type EmailTemplateHandler struct {
+	templateService emailtemplate.EmailTemplateService
+}
+
+func NewEmailTemplateHandler(templateService emailtemplate.EmailTemplateService) *EmailTemplateHandler {
+	return &EmailTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// SaveTemplate godoc
+// @Summary Save an email template
+// @Description Store a new version of a notification email template (Admin only). Subject and body use Go template syntax such as {{.customer_name}}; earlier versions are kept.
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Template key, e.g. order.confirmation"
+// @Param template body dto.EmailTemplateRequest true "Template content"
+// @Success 201 {object} dto.EmailTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid template"
+// @Security BearerAuth
+// @Router /admin/email-templates/{key} [put]
+func (h *EmailTemplateHandler) SaveTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailTemplateRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	template := &entity.EmailTemplate{
+		Key:       r.PathValue("key"),
+		Subject:   req.Subject,
+		Body:      req.Body,
+		CreatedBy: &claims.UserID,
+	}
+	if req.SampleData != nil {
+		sample, err := json.Marshal(req.SampleData)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid sample data")
+			return
+		}
+		template.SampleData = string(sample)
+	}
+
+	saved, err := h.templateService.SaveTemplate(r.Context(), template)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToEmailTemplateResponse(saved))
+}
+
+// ListTemplates godoc
+// @Summary List email templates
+// @Description Get the latest version of every email template (Admin only)
+// @Tags email-templates
+// @Produce json
+// @Success 200 {array} dto.EmailTemplateResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/email-templates [get]
+func (h *EmailTemplateHandler) ListTemplates(w http.ResponseWriter, r *http.Request) {
+	templates, err := h.templateService.ListTemplates(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toEmailTemplateResponses(templates))
+}
+
+// GetTemplate godoc
+// @Summary Get an email template
+// @Description Get the latest version of an email template, or a specific one (Admin only)
+// @Tags email-templates
+// @Produce json
+// @Param key path string true "Template key"
+// @Param version query int false "Version, defaults to the latest"
+// @Success 200 {object} dto.EmailTemplateResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/email-templates/{key} [get]
+func (h *EmailTemplateHandler) GetTemplate(w http.ResponseWriter, r *http.Request) {
+	version := 0
+	if raw := r.URL.Query().Get("version"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			respondError(w, http.StatusBadRequest, "Invalid version")
+			return
+		}
+		version = parsed
+	}
+
+	template, err := h.templateService.GetTemplate(r.Context(), r.PathValue("key"), version)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToEmailTemplateResponse(template))
+}
+
+// ListVersions godoc
+// @Summary List email template versions
+// @Description Get every version of an email template, newest first (Admin only)
+// @Tags email-templates
+// @Produce json
+// @Param key path string true "Template key"
+// @Success 200 {array} dto.EmailTemplateResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/email-templates/{key}/versions [get]
+func (h *EmailTemplateHandler) ListVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.templateService.ListVersions(r.Context(), r.PathValue("key"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toEmailTemplateResponses(versions))
+}
+
+// PreviewTemplate godoc
+// @Summary Preview an email template
+// @Description Render an email template with the given data, or with its sample data (Admin only). Nothing is sent.
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Template key"
+// @Param preview body dto.EmailPreviewRequest false "Version and data to render"
+// @Success 200 {object} dto.RenderedEmailResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/email-templates/{key}/preview [post]
+func (h *EmailTemplateHandler) PreviewTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailPreviewRequest
+	// The body is optional, an empty one renders the latest version with sample data
+	if err := decodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if !validateRequest(w, &req) {
+		return
+	}
+
+	email, err := h.templateService.Preview(r.Context(), r.PathValue("key"), req.Version, req.Data)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRenderedEmailResponse(email))
+}
+
+// TestSendTemplate godoc
+// @Summary Send a test email
+// @Description Render an email template and send it to a single recipient, the caller by default (Admin only). The subject is prefixed with [Test].
+// @Tags email-templates
+// @Accept json
+// @Produce json
+// @Param key path string true "Template key"
+// @Param send body dto.EmailTestSendRequest false "Recipient, version and data to render"
+// @Success 200 {object} dto.RenderedEmailResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/email-templates/{key}/test-send [post]
+func (h *EmailTemplateHandler) TestSendTemplate(w http.ResponseWriter, r *http.Request) {
+	var req dto.EmailTestSendRequest
+	// The body is optional, an empty one sends the latest version with sample data to the caller
+	if err := decodeJSON(r.Body, &req); err != nil && !errors.Is(err, io.EOF) {
+		respondDecodeError(w, err)
+		return
+	}
+	if !validateRequest(w, &req) {
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	recipient := req.Recipient
+	if recipient == "" {
+		recipient = claims.Email
+	}
+
+	email, err := h.templateService.TestSend(r.Context(), r.PathValue("key"), req.Version, recipient, req.Data)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRenderedEmailResponse(email))
+}
+
+func toEmailTemplateResponses(templates []*entity.EmailTemplate) []dto.EmailTemplateResponse {
+	responses := make([]dto.EmailTemplateResponse, len(templates))
+	for i, template := range templates {
+		responses[i] = dto.ToEmailTemplateResponse(template)
+	}
+	return responses
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// The fuzz targets run as regular tests on their seeds. Fuzz one with e.g.
+// go test ./src/internal/adapter/http/handler -run '^$' -fuzz FuzzPaymentWebhookHandler -fuzztime 1m
+
+const fuzzWebhookSecret = "fuzz-secret"
+
+// FuzzPaymentWebhookHandler sends any body, signed now with a new nonce or
+// not signed at all, to the webhook endpoint. The handler must answer with a client error or accept the
+// webhook, and only ever hand a valid request to the payment use case.
+func FuzzPaymentWebhookHandler(f *testing.F) {
+	orderID := uuid.New().String()
+	f.Add([]byte(`{"order_id":"`+orderID+`","transaction_id":"txn_1","payment_status":"paid","timestamp":1733876543}`), true)
+	f.Add([]byte(`{"order_id":"`+orderID+`","transaction_id":"txn_1","payment_status":"authorized","amount":12.5,"timestamp":1733876543}`), true)
+	f.Add([]byte(`{"order_id":"`+orderID+`","transaction_id":"txn_1","payment_status":"paid","timestamp":1733876543}`), false)
+	f.Add([]byte(`{"order_id":"not-a-uuid","transaction_id":"","payment_status":"refunded","timestamp":1733876543}`), true)
+	f.Add([]byte(`{"order_id":"`+orderID+`","payment_status":"paid","amount":-1,"timestamp":1}`), true)
+	f.Add([]byte(`{"order_id":"`+orderID+`","unknown":true,"timestamp":1733876543}`), true)
+	f.Add([]byte(`{"timestamp":"soon"}`), true)
+	f.Add([]byte(`{} {}`), true)
+	f.Add([]byte(`[`), true)
+	f.Add([]byte(``), true)
+
+	f.Fuzz(func(t *testing.T, body []byte, signed bool) {
+		service := &stubPaymentService{}
+		h := NewPaymentHandler(service, fuzzWebhookSecret, nil)
+		req := httptest.NewRequest(http.MethodPost, "/api/payment-webhook", bytes.NewReader(body))
+		if signed {
+			timestamp, nonce := strconv.FormatInt(time.Now().Unix(), 10), uuid.NewString()
+			req.Header.Set("X-Payment-Signature", signWebhook(fuzzWebhookSecret, timestamp, nonce, body))
+			req.Header.Set("X-Payment-Timestamp", timestamp)
+			req.Header.Set("X-Payment-Nonce", nonce)
+		}
+		w := httptest.NewRecorder()
+		h.PaymentWebhookHandler(w, req)
+
+		switch w.Code {
+		case http.StatusOK:
+			if len(service.processed) != 1 {
+				t.Fatalf("expected an accepted webhook to be processed once, got %d", len(service.processed))
+			}
+			processed := service.processed[0]
+			if _, err := uuid.Parse(processed.OrderID); err != nil || processed.TransactionID == "" || processed.Amount < 0 {
+				t.Fatalf("processed an invalid webhook %+v", processed)
+			}
+			if processed.PaymentStatus != entity.Authorized && processed.PaymentStatus != entity.Paid && processed.PaymentStatus != entity.Failed {
+				t.Fatalf("processed an unknown payment status %q", processed.PaymentStatus)
+			}
+		case http.StatusBadRequest, http.StatusUnauthorized, http.StatusUnprocessableEntity:
+			if len(service.processed) != 0 {
+				t.Fatalf("expected a rejected webhook not to be processed, got %+v", service.processed)
+			}
+		default:
+			t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+		}
+		if !signed && w.Code != http.StatusUnauthorized {
+			t.Fatalf("expected an unsigned webhook to be unauthorized, got %d", w.Code)
+		}
+		if !json.Valid(w.Body.Bytes()) {
+			t.Fatalf("expected a JSON response, got %q", w.Body.String())
+		}
+	})
+}
+
+// FuzzVerifySignature checks that only the HMAC of the exact timestamp, nonce
+// and payload verifies, whatever the payload and the signature sent
+func FuzzVerifySignature(f *testing.F) {
+	const timestamp = "1733876543"
+	payload := []byte(`{"order_id":"123e4567-e89b-12d3-a456-426614174000"}`)
+	f.Add(payload, "nonce-0123456789abcdef", signWebhook(fuzzWebhookSecret, timestamp, "nonce-0123456789abcdef", payload))
+	f.Add(payload, "nonce-0123456789abcdef", signWebhook("other-secret", timestamp, "nonce-0123456789abcdef", payload))
+	f.Add(payload, "nonce-0123456789abcdef", signWebhook(fuzzWebhookSecret, timestamp, "nonce-fedcba9876543210", payload))
+	f.Add(payload, "", "")
+	f.Add([]byte{}, "nonce-0123456789abcdef", signWebhook(fuzzWebhookSecret, timestamp, "nonce-0123456789abcdef", nil))
+	f.Add([]byte("\x00\xff"), "n", "zz")
+
+	h := NewPaymentHandler(&stubPaymentService{}, fuzzWebhookSecret, nil)
+	f.Fuzz(func(t *testing.T, payload []byte, nonce, signature string) {
+		expected := signWebhook(fuzzWebhookSecret, timestamp, nonce, payload)
+		if !h.verifySignature(timestamp, nonce, payload, expected) {
+			t.Fatalf("expected the signature of %q with nonce %q to verify", payload, nonce)
+		}
+		if got := h.verifySignature(timestamp, nonce, payload, signature); got != (signature == expected) {
+			t.Fatalf("verifySignature(%q, %q, %q) = %v, expected signature %q", nonce, payload, signature, got, expected)
+		}
+	})
+}
+
+// FuzzDecodeCreateOrderRequest decodes any body as an order request. Bodies
+// must be rejected with a 400 or 422, or decode into a request the order
+// handler can rely on.
+func FuzzDecodeCreateOrderRequest(f *testing.F) {
+	productID := uuid.New().String()
+	f.Add([]byte(`{"customer_id":1,"products":[{"product_id":"` + productID + `","quantity":2}]}`))
+	f.Add([]byte(`{"customer_id":1,"products":[{"product_id":"` + productID + `","variant_id":"` + productID + `","quantity":1}],"redeem_points":500}`))
+	f.Add([]byte(`{"customer_id":0,"products":[]}`))
+	f.Add([]byte(`{"customer_id":1,"products":[{"product_id":"x","quantity":-1}]}`))
+	f.Add([]byte(`{"customer_id":"1","products":null}`))
+	f.Add([]byte(`{"customer_id":1e100}`))
+	f.Add([]byte(`{"customer_id":1,"extra":1}`))
+	f.Add([]byte(`{"customer_id":1}{"customer_id":2}`))
+	f.Add([]byte(`null`))
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		var req dto.CreateOrderRequest
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodPost, "/api/orders", bytes.NewReader(body))
+
+		if !decodeAndValidate(w, r, &req) {
+			if w.Code != http.StatusBadRequest && w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("unexpected status %d: %s", w.Code, w.Body.String())
+			}
+			if !json.Valid(w.Body.Bytes()) {
+				t.Fatalf("expected a JSON error, got %q", w.Body.String())
+			}
+			return
+		}
+
+		if req.CustomerID <= 0 || req.RedeemPoints < 0 || len(req.Products) == 0 {
+			t.Fatalf("accepted an invalid order request %+v", req)
+		}
+		for _, item := range req.Products {
+			if _, err := uuid.Parse(item.ProductID); err != nil || item.Quantity <= 0 {
+				t.Fatalf("accepted an invalid order item %+v", item)
+			}
+			if item.VariantID != nil {
+				if _, err := uuid.Parse(*item.VariantID); err != nil {
+					t.Fatalf("accepted an invalid variant ID %q", *item.VariantID)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/usecase/inventory"
+)
+
+type InventoryHandler struct {
+	inventoryService inventory.InventoryService
+}
+
+func NewInventoryHandler(inventoryService inventory.InventoryService) *InventoryHandler {
+	return &InventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
+
+// SyncStock godoc
+// @Summary Bulk update stock by SKU
+// @Description Set the stock of up to 1000 variants by SKU, as an ERP does nightly. In all_or_nothing mode (default) an unknown SKU or a conflict rejects the whole update with 409 and nothing is written; in best_effort mode the other items are applied. With expected_quantity an item only applies while the variant still has that many units, and is reported as a conflict otherwise. Every change is recorded in the stock ledger with reason "import" and the reference, and in the admin activity log (Admin only).
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param sync body dto.InventorySyncRequest true "Stock levels by SKU"
+// @Success 200 {object} dto.InventorySyncResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires stock:sync permission"
+// @Failure 409 {object} dto.InventorySyncResponse "All-or-nothing update rejected, see the items"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /inventory/bulk [put]
+func (h *InventoryHandler) SyncStock(w http.ResponseWriter, r *http.Request) {
+	var req dto.InventorySyncRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	report, err := h.inventoryService.SyncStock(r.Context(), dto.ToInventorySync(req))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	status := http.StatusOK
+	if report.Rejected {
+		status = http.StatusConflict
+	}
+	respondJSON(w, status, dto.ToInventorySyncResponse(report))
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+)
+
+type InvoiceHandler struct {
+	invoiceService invoice.InvoiceService
+}
+
+func NewInvoiceHandler(invoiceService invoice.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{
+		invoiceService: invoiceService,
+	}
+}
+
+// GetInvoice godoc
+// @Summary Download order invoice
+// @Description Returns the PDF invoice of an order, issuing it with the next sequential number of the year on first access. Available to the order owner and admins.
+// @Tags orders
+// @Produce application/pdf
+// @Param id path string true "Order ID"
+// @Success 200 {file} binary
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /orders/{id}/invoice [get]
+func (h *InvoiceHandler) GetInvoice(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	isAdmin := middleware.HasPermission(claims.Role, middleware.PermissionViewAnyInvoice)
+
+	inv, err := h.invoiceService.GetInvoice(r.Context(), orderID, claims.UserID, isAdmin)
+	if err != nil {
+		if errors.Is(err, invoice.ErrOrderNotFound) {
+			respondError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to generate invoice")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+inv.Number+`.pdf"`)
+	w.Header().Set("Content-Length", strconv.Itoa(len(inv.PDF)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(inv.PDF)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/jobs"
+)
+
+// JobMetricsSource reports how the background jobs have been doing
+type JobMetricsSource interface {
+	Metrics() []jobs.JobMetrics
+}
+
+type JobHandler struct {
+	scheduler JobMetricsSource
+	enabled   bool
+}
+
+func NewJobHandler(scheduler JobMetricsSource, enabled bool) *JobHandler {
+	return &JobHandler{
+		scheduler: scheduler,
+		enabled:   enabled,
+	}
+}
+
+// ListJobs godoc
+// @Summary List background jobs
+// @Description Schedule, next run and run metrics of every background job of the instance serving the request, since it started (Admin only)
+// @Tags admin
+// @Produce json
+// @Success 200 {object} dto.JobListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/jobs [get]
+func (h *JobHandler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, dto.ToJobListResponse(h.enabled, h.scheduler.Metrics()))
+}
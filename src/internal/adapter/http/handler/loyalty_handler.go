@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+)
+
+type LoyaltyHandler struct {
+	loyaltyService loyalty.LoyaltyService
+}
+
+func NewLoyaltyHandler(loyaltyService loyalty.LoyaltyService) *LoyaltyHandler {
+	return &LoyaltyHandler{
+		loyaltyService: loyaltyService,
+	}
+}
+
+// GetMyLoyalty godoc
+// @Summary Get my loyalty points
+// @Description Points balance of the authenticated user with a page of its history, newest first. Completed or delivered orders earn points on the amount paid; points are redeemed with redeem_points when placing an order, and given back if the order is cancelled or refunded.
+// @Tags loyalty
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} dto.LoyaltyResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/loyalty [get]
+func (h *LoyaltyHandler) GetMyLoyalty(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+	account, err := h.loyaltyService.GetAccount(r.Context(), claims.UserID, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToLoyaltyResponse(account.Balance, account.Program, account.Transactions, account.Total, page, pageSize))
+}
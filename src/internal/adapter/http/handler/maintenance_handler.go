@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/maintenance"
+)
+
+type MaintenanceHandler struct {
+	maintenanceService maintenance.MaintenanceService
+}
+
+func NewMaintenanceHandler(maintenanceService maintenance.MaintenanceService) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		maintenanceService: maintenanceService,
+	}
+}
+
+// Health godoc
+// @Summary Health check
+// @Description Tell load balancers the API is up. It answers 200 in maintenance mode too, which it reports.
+// @Tags health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse
+// @Router /health [get]
+func (h *MaintenanceHandler) Health(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, dto.HealthResponse{
+		Status:      "ok",
+		Maintenance: h.maintenanceService.Status().Enabled,
+	})
+}
+
+// GetMaintenance godoc
+// @Summary Get the maintenance mode
+// @Description Show whether this API instance is in maintenance mode (Admin only)
+// @Tags maintenance
+// @Produce json
+// @Success 200 {object} dto.MaintenanceResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/maintenance [get]
+func (h *MaintenanceHandler) GetMaintenance(w http.ResponseWriter, r *http.Request) {
+	respondJSON(w, http.StatusOK, dto.ToMaintenanceResponse(h.maintenanceService.Status()))
+}
+
+// UpdateMaintenance godoc
+// @Summary Switch maintenance mode
+// @Description Switch maintenance mode on or off for this API instance. While it is on, public requests get 503 with Retry-After; health checks, admin routes, logins and staff requests still go through (Admin only).
+// @Tags maintenance
+// @Accept json
+// @Produce json
+// @Param maintenance body dto.MaintenanceRequest true "Maintenance mode"
+// @Success 200 {object} dto.MaintenanceResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid message or retry_after_seconds"
+// @Security BearerAuth
+// @Router /admin/maintenance [put]
+func (h *MaintenanceHandler) UpdateMaintenance(w http.ResponseWriter, r *http.Request) {
+	var req dto.MaintenanceRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	update := maintenance.Update{Enabled: req.Enabled, Message: req.Message}
+	if req.RetryAfterSeconds != nil {
+		retryAfter := time.Duration(*req.RetryAfterSeconds) * time.Second
+		update.RetryAfter = &retryAfter
+	}
+
+	status, err := h.maintenanceService.Update(r.Context(), update, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToMaintenanceResponse(status))
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/servicemocks"
+	"github.com/marcofilho/go-ecommerce/src/usecase/maintenance"
+)
+
+func TestMaintenanceHandler_UpdateMaintenance(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockService := new(servicemocks.MaintenanceService)
+		handler := NewMaintenanceHandler(mockService)
+		adminID := uuid.New()
+
+		retryAfter := 10 * time.Minute
+		message := "Migrating the catalog"
+		mockService.On("Update", mock.Anything, maintenance.Update{Enabled: true, Message: &message, RetryAfter: &retryAfter}, adminID).
+			Return(entity.MaintenanceStatus{Enabled: true, Message: message, RetryAfter: retryAfter, UpdatedBy: &adminID}, nil)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/maintenance",
+			bytes.NewReader([]byte(`{"enabled": true, "message": "Migrating the catalog", "retry_after_seconds": 600}`)))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &auth.Claims{UserID: adminID, Role: entity.RoleAdmin}))
+		w := httptest.NewRecorder()
+
+		handler.UpdateMaintenance(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dto.MaintenanceResponse
+		decodeData(w.Body, &response)
+		assert.True(t, response.Enabled)
+		assert.Equal(t, 600, response.RetryAfterSeconds)
+		assert.Equal(t, adminID.String(), *response.UpdatedBy)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Retry After", func(t *testing.T) {
+		mockService := new(servicemocks.MaintenanceService)
+		handler := NewMaintenanceHandler(mockService)
+
+		req := httptest.NewRequest(http.MethodPut, "/api/admin/maintenance",
+			bytes.NewReader([]byte(`{"enabled": true, "retry_after_seconds": 0}`)))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &auth.Claims{UserID: uuid.New(), Role: entity.RoleAdmin}))
+		w := httptest.NewRecorder()
+
+		handler.UpdateMaintenance(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		mockService.AssertNotCalled(t, "Update")
+	})
+}
+
+type fakeTokenValidator map[string]*auth.Claims
+
+func (v fakeTokenValidator) ValidateToken(token string) (*auth.Claims, error) {
+	if claims, ok := v[token]; ok {
+		return claims, nil
+	}
+	return nil, errors.New("invalid token")
+}
+
+func TestMaintenanceMiddleware_Block(t *testing.T) {
+	adminID := uuid.New()
+	maintenanceService := maintenance.NewUseCase(&mockServices.MockServices{}, true, "Back soon", 2*time.Minute)
+	validator := fakeTokenValidator{
+		"admin":         {UserID: adminID, Role: entity.RoleAdmin},
+		"support":       {UserID: uuid.New(), Role: entity.RoleSupport},
+		"customer":      {UserID: uuid.New(), Role: entity.RoleCustomer},
+		"impersonation": {UserID: uuid.New(), Role: entity.RoleCustomer, ImpersonatorID: &adminID},
+	}
+	routes := NewMaintenanceHandler(maintenanceService)
+	handler := middleware.NewMaintenanceMiddleware(maintenanceService, validator).Block(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/health" {
+				routes.Health(w, r)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		want   int
+	}{
+		{"public catalog", http.MethodGet, "/api/products", "", http.StatusServiceUnavailable},
+		{"checkout of a customer", http.MethodPost, "/api/orders", "customer", http.StatusServiceUnavailable},
+		{"impersonated customer", http.MethodGet, "/api/users/me", "impersonation", http.StatusServiceUnavailable},
+		{"invalid token", http.MethodGet, "/api/products", "forged", http.StatusServiceUnavailable},
+		{"registration", http.MethodPost, "/api/auth/register", "", http.StatusServiceUnavailable},
+		{"health check", http.MethodGet, "/api/health", "", http.StatusOK},
+		{"login", http.MethodPost, "/api/auth/login", "", http.StatusOK},
+		{"admin route", http.MethodPut, "/api/admin/maintenance", "admin", http.StatusOK},
+		{"admin on a public route", http.MethodPut, "/api/products/1", "admin", http.StatusOK},
+		{"support agent", http.MethodGet, "/api/orders", "support", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			require.Equal(t, tt.want, w.Code)
+			if tt.want == http.StatusServiceUnavailable {
+				assert.Equal(t, "120", w.Header().Get("Retry-After"))
+				assert.JSONEq(t, `{"error": "Back soon"}`, w.Body.String())
+			}
+		})
+	}
+
+	t.Run("health reports maintenance", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/health", nil))
+
+		var response dto.HealthResponse
+		decodeData(w.Body, &response)
+		assert.Equal(t, "ok", response.Status)
+		assert.True(t, response.Maintenance)
+	})
+
+	t.Run("everything goes through once disabled", func(t *testing.T) {
+		_, err := maintenanceService.Update(context.Background(), maintenance.Update{Enabled: false}, adminID)
+		require.NoError(t, err)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/products", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get("Retry-After"))
+	})
+}
@@ -1,13 +1,18 @@
 package handler
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/usecase/order"
 )
 
@@ -23,18 +28,22 @@ func NewOrderHandler(useCase order.OrderService) *OrderHandler {
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with the provided products
+// @Description Create a new order with the provided products. A signed-in customer can redeem loyalty points with redeem_points for a discount on the subtotal. Fraud screening rejects orders from blocked or high-risk customers, and places suspicious ones in the review status: they keep their stock but can't be paid until an admin approves them.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param order body dto.CreateOrderRequest true "Order information"
 // @Success 201 {object} dto.OrderResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Rejected by fraud screening or the blocklist, or purchase window required"
+// @Failure 404 {object} dto.ErrorResponse "Product or variant not found"
+// @Failure 409 {object} dto.ErrorResponse "Insufficient stock or loyalty points"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /orders [post]
 func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req dto.CreateOrderRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
@@ -64,9 +73,14 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		products = append(products, orderItem)
 	}
 
-	createdOrder, err := h.useCase.CreateOrder(r.Context(), req.CustomerID, products)
+	var userID *uuid.UUID
+	if claims, err := middleware.GetUserFromContext(r); err == nil {
+		userID = &claims.UserID
+	}
+
+	createdOrder, err := h.useCase.CreateOrder(r.Context(), req.CustomerID, userID, middleware.ClientIP(r), products, req.RedeemPoints)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
@@ -81,9 +95,11 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
+// @Param fields query string false "Comma-separated attributes to return, e.g. id,status,total_price; all of them when empty"
 // @Success 200 {object} dto.OrderResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Unknown field"
 // @Router /orders/{id} [get]
 func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -93,15 +109,70 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	fields, err := dto.ParseFields[dto.OrderResponse](r.URL.Query().Get("fields"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
 	order, err := h.useCase.GetOrder(r.Context(), id)
 	if err != nil {
-		respondError(w, http.StatusNotFound, "Order not found")
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToOrderResponse(order)
+	respondJSON(w, http.StatusOK, dto.SelectFields(dto.ToOrderResponse(order), fields))
+}
 
-	respondJSON(w, http.StatusOK, response)
+// GetOrderStatuses godoc
+// @Summary Get the status of many orders
+// @Description Look up the status and payment status of up to 100 orders in one request, for dashboards polling their orders. Customers only get their own orders; the IDs of orders that don't exist or belong to another account are listed in not_found.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param request body dto.OrderStatusBatchRequest true "Order IDs"
+// @Success 200 {object} dto.OrderStatusBatchResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /orders/status-batch [post]
+func (h *OrderHandler) GetOrderStatuses(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.OrderStatusBatchRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.OrderIDs))
+	for _, value := range req.OrderIDs {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid order ID: "+value)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	var ownerID *uuid.UUID
+	if !middleware.HasPermission(claims.Role, middleware.PermissionViewAnyOrder) {
+		ownerID = &claims.UserID
+	}
+
+	orders, notFound, err := h.useCase.GetOrderStatuses(r.Context(), ids, ownerID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToOrderStatusBatchResponse(orders, notFound))
 }
 
 // ListOrders godoc
@@ -114,22 +185,22 @@ func (h *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) {
 // @Param page_size query int false "Items per page" default(10)
 // @Param sort_by query string false "Sort by field (created_at, total_price)" default("created_at")
 // @Param sort_order query string false "Sort order (asc, desc)" default("desc")
-// @Param status query string false "Filter by status (pending, cancelled, completed)"
-// @Param payment_status query string false "Filter by payment status (unpaid, paid, failed)"
+// @Param status query string false "Filter by status (review, pending, processing, shipped, delivered, completed, cancelled, refunded)"
+// @Param payment_status query string false "Filter by payment status (unpaid, authorized, partially_paid, paid, partially_refunded, refunded, failed)"
+// @Param fields query string false "Comma-separated attributes to return of each order, e.g. id,status,total_price; all of them when empty"
 // @Success 200 {object} dto.OrderListResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Unknown field"
+// @Failure 500 {object} dto.ErrorResponse
 // @Router /orders [get]
 func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	page, pageSize := parsePagination(r)
 	statusStr := r.URL.Query().Get("status")
 	paymentStatusStr := r.URL.Query().Get("payment_status")
 
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
+	fields, err := dto.ParseFields[dto.OrderResponse](r.URL.Query().Get("fields"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
 	}
 
 	var status *entity.OrderStatus
@@ -146,18 +217,125 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 
 	orders, total, err := h.useCase.ListOrders(r.Context(), page, pageSize, status, paymentStatus)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToOrderListResponse(orders, total, page, pageSize)
+	respondPage(w, r, dto.SelectPageFields(dto.ToOrderListResponse(orders, total, page, pageSize), fields))
+}
+
+// SearchOrders godoc
+// @Summary Search orders
+// @Description Look up orders by the email of the customer's account, the time they were placed, their total, a SKU they contain and their status, with pagination and sorting (Admin and support). Every filter given must match. Archived orders are left out.
+// @Tags orders
+// @Produce json
+// @Param email query string false "Email of the account that placed the order"
+// @Param from query string false "Placed at or after, RFC 3339, e.g. 2026-01-01T00:00:00Z"
+// @Param to query string false "Placed before, RFC 3339"
+// @Param min_total query float64 false "Lowest order total"
+// @Param max_total query float64 false "Highest order total"
+// @Param sku query string false "SKU of a variant ordered, as it was when ordered"
+// @Param status query string false "Filter by status (review, pending, processing, shipped, delivered, completed, cancelled, refunded)"
+// @Param payment_status query string false "Filter by payment status (unpaid, authorized, partially_paid, paid, partially_refunded, refunded, failed)"
+// @Param sort_by query string false "Sort by field (created_at, total_price)" default("created_at")
+// @Param sort_order query string false "Sort order (asc, desc)" default("desc")
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param fields query string false "Comma-separated attributes to return of each order, e.g. id,status,total_price; all of them when empty"
+// @Success 200 {object} dto.OrderListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Invalid filter, sort field or unknown field"
+// @Security BearerAuth
+// @Router /admin/orders/search [get]
+func (h *OrderHandler) SearchOrders(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
 
-	respondJSON(w, http.StatusOK, response)
+	fields, err := dto.ParseFields[dto.OrderResponse](r.URL.Query().Get("fields"))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	search, err := parseOrderSearch(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	orders, total, err := h.useCase.SearchOrders(r.Context(), search, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.SelectPageFields(dto.ToOrderListResponse(orders, total, page, pageSize), fields))
+}
+
+// parseOrderSearch reads the filters and sort of an order search, newest
+// orders first by default
+func parseOrderSearch(r *http.Request) (repository.OrderSearch, error) {
+	query := r.URL.Query()
+	search := repository.OrderSearch{
+		CustomerEmail: query.Get("email"),
+		SKU:           query.Get("sku"),
+		Sort:          repository.OrderSort{Field: repository.OrderSortCreatedAt, Descending: true},
+	}
+
+	if status := query.Get("status"); status != "" {
+		s := entity.OrderStatus(status)
+		search.Status = &s
+	}
+	if paymentStatus := query.Get("payment_status"); paymentStatus != "" {
+		ps := entity.PaymentStatus(paymentStatus)
+		search.PaymentStatus = &ps
+	}
+
+	times := []struct {
+		name  string
+		bound **time.Time
+	}{{"from", &search.From}, {"to", &search.To}}
+	for _, t := range times {
+		if value := query.Get(t.name); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return search, fmt.Errorf("Invalid %s. Use RFC 3339, e.g. 2026-01-01T00:00:00Z", t.name)
+			}
+			*t.bound = &parsed
+		}
+	}
+	totals := []struct {
+		name  string
+		bound **float64
+	}{{"min_total", &search.MinTotal}, {"max_total", &search.MaxTotal}}
+	for _, t := range totals {
+		if value := query.Get(t.name); value != "" {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil || math.IsNaN(parsed) || math.IsInf(parsed, 0) {
+				return search, fmt.Errorf("Invalid %s, use a number", t.name)
+			}
+			*t.bound = &parsed
+		}
+	}
+
+	if sortBy := query.Get("sort_by"); sortBy != "" {
+		search.Sort.Field = repository.OrderSortField(sortBy)
+	}
+	switch query.Get("sort_order") {
+	case "", "desc":
+	case "asc":
+		search.Sort.Descending = false
+	default:
+		return search, errors.New("Invalid sort order, use asc or desc")
+	}
+
+	return search, nil
 }
 
 // UpdateOrderStatus godoc
 // @Summary Update order status
-// @Description Update the status of an existing order
+// @Description Move an order to another status. The transitions allowed depend on the order workflow: with `simple` a pending order is completed or cancelled and a completed, paid order refunded; with `fulfillment` a paid order goes pending → processing → shipped → delivered → completed, may be refunded once paid, and only pending orders can be cancelled. Under both, an order held for fraud review is approved by moving it to pending, or cancelled. Cancelling, or refunding before the order shipped, puts the items back in stock.
 // @Tags orders
 // @Accept json
 // @Produce json
@@ -166,6 +344,9 @@ func (h *OrderHandler) ListOrders(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} dto.OrderResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Invalid status transition, or the order is not paid"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /orders/{id}/status [put]
 func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -176,15 +357,14 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 	}
 
 	var req dto.UpdateOrderStatusRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
 	newStatus := entity.OrderStatus(req.Status)
 	order, err := h.useCase.UpdateOrderStatus(r.Context(), id, newStatus)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
@@ -192,3 +372,46 @@ func (h *OrderHandler) UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
 
 	respondJSON(w, http.StatusOK, response)
 }
+
+// UpdateOrderStatuses godoc
+// @Summary Update the status of many orders
+// @Description Move up to 500 orders to the same status, as a warehouse does with a picking batch. The orders are locked and saved in one transaction, but each goes through the order workflow on its own: the ones that can move do, the others are reported as rejected with the reason, and IDs no order has as not_found. Orders already in the status are left unchanged. Cancelling, or refunding before the order shipped, puts the items back in stock, and every order moved is logged and notified as with PUT /orders/{id}/status.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param request body dto.BulkOrderStatusRequest true "Order IDs and the new status"
+// @Success 200 {object} dto.BulkOrderStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires order:update_status permission"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/orders/status-bulk [put]
+func (h *OrderHandler) UpdateOrderStatuses(w http.ResponseWriter, r *http.Request) {
+	var req dto.BulkOrderStatusRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	update := &entity.BulkOrderStatusUpdate{
+		OrderIDs: make([]uuid.UUID, 0, len(req.OrderIDs)),
+		Status:   entity.OrderStatus(req.Status),
+	}
+	for _, value := range req.OrderIDs {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid order ID: "+value)
+			return
+		}
+		update.OrderIDs = append(update.OrderIDs, id)
+	}
+
+	report, err := h.useCase.UpdateOrderStatuses(r.Context(), update)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToBulkOrderStatusResponse(report))
+}
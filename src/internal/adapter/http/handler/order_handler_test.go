@@ -23,6 +23,7 @@ type mockOrderRepo struct {
 	getByIDFunc func(ctx context.Context, id uuid.UUID) (*entity.Order, error)
 	getAllFunc  func(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error)
 	updateFunc  func(ctx context.Context, order *entity.Order) error
+	searchFunc  func(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error)
 }
 
 func (m *mockOrderRepo) Create(ctx context.Context, order *entity.Order) error {
@@ -36,7 +37,11 @@ func (m *mockOrderRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Orde
 	if m.getByIDFunc != nil {
 		return m.getByIDFunc(ctx, id)
 	}
-	return nil, errors.New("not found")
+	return nil, entity.NotFoundError("Order not found")
+}
+
+func (m *mockOrderRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error) {
+	return nil, nil
 }
 
 func (m *mockOrderRepo) GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
@@ -46,6 +51,13 @@ func (m *mockOrderRepo) GetAll(ctx context.Context, page, pageSize int, status *
 	return nil, 0, nil
 }
 
+func (m *mockOrderRepo) Search(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, search, page, pageSize)
+	}
+	return nil, 0, nil
+}
+
 func (m *mockOrderRepo) Update(ctx context.Context, order *entity.Order) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(ctx, order)
@@ -53,6 +65,30 @@ func (m *mockOrderRepo) Update(ctx context.Context, order *entity.Order) error {
 	return nil
 }
 
+func (m *mockOrderRepo) UpdateStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate, workflow *entity.OrderWorkflow) ([]entity.OrderStatusResult, []*entity.Order, error) {
+	return nil, nil, nil
+}
+
+func (m *mockOrderRepo) ScanByCreatedAt(ctx context.Context, from, until time.Time, batchSize int, fn func(orders []*entity.Order) error) error {
+	return nil
+}
+
+func (m *mockOrderRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	return nil, nil
+}
+
+func (m *mockOrderRepo) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockOrderRepo) CountPurchased(ctx context.Context, userID uuid.UUID, productID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (m *mockOrderRepo) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
 var _ repository.OrderRepository = (*mockOrderRepo)(nil)
 
 func TestOrderHandler_CreateOrder_Success(t *testing.T) {
@@ -61,7 +97,7 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 	mockProductRepo := &mockProductRepo{
 		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
 			return &entity.Product{
-				ID: id, Name: "Laptop", Price: 999.99, Quantity: 10,
+				ID: id, Name: "Laptop", Price: 999.99, Quantity: 10, Status: entity.ProductActive,
 				CreatedAt: time.Now(), UpdatedAt: time.Now(),
 			}, nil
 		},
@@ -119,8 +155,14 @@ func TestOrderHandler_CreateOrder_InvalidProductID(t *testing.T) {
 
 	handler.CreateOrder(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", w.Code)
+	}
+
+	var response dto.ValidationErrorResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	if got := response.Errors["products[0].product_id"]; got != "must be a valid UUID" {
+		t.Errorf("expected a field error for products[0].product_id, got %v", response.Errors)
 	}
 }
 
@@ -148,8 +190,8 @@ func TestOrderHandler_CreateOrder_UseCaseError(t *testing.T) {
 
 	handler.CreateOrder(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
 
@@ -198,7 +240,7 @@ func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
 func TestOrderHandler_GetOrder_NotFound(t *testing.T) {
 	mockOrderRepo := &mockOrderRepo{
 		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
-			return nil, errors.New("not found")
+			return nil, entity.NotFoundError("Order not found")
 		},
 	}
 
@@ -269,6 +311,55 @@ func TestOrderHandler_ListOrders_WithFilters(t *testing.T) {
 	}
 }
 
+func TestOrderHandler_SearchOrders(t *testing.T) {
+	var searched repository.OrderSearch
+	mockOrderRepo := &mockOrderRepo{
+		searchFunc: func(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+			searched = search
+			return []*entity.Order{{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, TotalPrice: 90}}, 1, nil
+		},
+	}
+	handler := NewOrderHandler(newOrderUseCase(mockOrderRepo, &mockProductRepo{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/orders/search?email=ana@example.com&sku=TSHIRT-L&from=2026-01-01T00:00:00Z&min_total=50&status=pending&sort_by=total_price&sort_order=asc", nil)
+	w := httptest.NewRecorder()
+	handler.SearchOrders(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if searched.CustomerEmail != "ana@example.com" || searched.SKU != "TSHIRT-L" || *searched.Status != entity.Pending {
+		t.Errorf("unexpected search %+v", searched)
+	}
+	if !searched.From.Equal(time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC)) || *searched.MinTotal != 50 || searched.To != nil || searched.MaxTotal != nil {
+		t.Errorf("unexpected ranges %+v", searched)
+	}
+	if searched.Sort != (repository.OrderSort{Field: repository.OrderSortTotalPrice}) {
+		t.Errorf("expected total_price ascending, got %+v", searched.Sort)
+	}
+
+	var response dto.OrderListResponse
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Data) != 1 {
+		t.Errorf("expected 1 order, got %d", len(response.Data))
+	}
+
+	for query, want := range map[string]int{
+		"from=yesterday":          http.StatusBadRequest,
+		"max_total=lots":          http.StatusBadRequest,
+		"sort_order=up":           http.StatusBadRequest,
+		"sort_by=customer_id":     http.StatusUnprocessableEntity,
+		"status=lost":             http.StatusUnprocessableEntity,
+		"min_total=9&max_total=1": http.StatusUnprocessableEntity,
+	} {
+		w := httptest.NewRecorder()
+		handler.SearchOrders(w, httptest.NewRequest(http.MethodGet, "/admin/orders/search?"+query, nil))
+		if w.Code != want {
+			t.Errorf("%s: expected status %d, got %d", query, want, w.Code)
+		}
+	}
+}
+
 func TestOrderHandler_ListOrders_UseCaseError(t *testing.T) {
 	mockOrderRepo := &mockOrderRepo{
 		getAllFunc: func(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
@@ -378,15 +469,15 @@ func TestOrderHandler_UpdateOrderStatus_UseCaseError(t *testing.T) {
 
 	handler.UpdateOrderStatus(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
 	}
 }
 
 func newOrderUseCase(orderRepo repository.OrderRepository, productRepo repository.ProductRepository) *order.UseCase {
 	// Create a mock variant repo for testing
 	variantRepo := &mockVariantRepo{}
-	return order.NewUseCase(orderRepo, productRepo, variantRepo, &mockServices.MockServices{})
+	return order.NewUseCase(orderRepo, productRepo, variantRepo, &mockQueueRepo{}, &mockServices.MockServices{}, 0)
 }
 
 // Mock variant repository for testing
@@ -400,6 +491,16 @@ func (m *mockVariantRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Pr
 	return nil, errors.New("variant not found")
 }
 
+func (m *mockVariantRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error) {
+	var found []*entity.ProductVariant
+	for _, id := range ids {
+		if item, err := m.GetByID(ctx, id); err == nil {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
 func (m *mockVariantRepo) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
 	return nil, 0, nil
 }
@@ -408,6 +509,14 @@ func (m *mockVariantRepo) GetAllByProductID(ctx context.Context, productID uuid.
 	return nil, 0, nil
 }
 
+func (m *mockVariantRepo) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockVariantRepo) GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error) {
+	return nil, errors.New("not found")
+}
+
 func (m *mockVariantRepo) Update(ctx context.Context, variant *entity.ProductVariant) error {
 	return nil
 }
@@ -415,3 +524,64 @@ func (m *mockVariantRepo) Update(ctx context.Context, variant *entity.ProductVar
 func (m *mockVariantRepo) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
+
+func (m *mockVariantRepo) TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error) {
+	return nil, nil, nil
+}
+
+// Mock purchase queue repository for testing
+type mockQueueRepo struct{}
+
+func (m *mockQueueRepo) Create(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	return nil
+}
+
+func (m *mockQueueRepo) Update(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	return nil
+}
+
+func (m *mockQueueRepo) GetActiveEntry(ctx context.Context, productID, userID uuid.UUID) (*entity.PurchaseQueueEntry, error) {
+	return nil, errors.New("queue entry not found")
+}
+
+func (m *mockQueueRepo) CountAhead(ctx context.Context, productID uuid.UUID, joinedAt time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockQueueRepo) Advance(ctx context.Context, productID uuid.UUID, slots int, window time.Duration, now time.Time) error {
+	return nil
+}
+
+func (m *mockQueueRepo) ListActiveProductIDs(ctx context.Context) ([]uuid.UUID, error) {
+	return nil, nil
+}
+
+func TestOrderHandler_UpdateOrderStatuses(t *testing.T) {
+	handler := NewOrderHandler(newOrderUseCase(&mockOrderRepo{}, &mockProductRepo{}))
+
+	tests := []struct {
+		name     string
+		orderIDs []string
+		status   string
+		want     int
+	}{
+		{"valid", []string{uuid.New().String(), uuid.New().String()}, string(entity.Shipped), http.StatusOK},
+		{"invalid order ID", []string{"invalid-id"}, string(entity.Shipped), http.StatusBadRequest},
+		{"unknown status", []string{uuid.New().String()}, "lost", http.StatusUnprocessableEntity},
+		{"no orders", []string{}, string(entity.Shipped), http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(dto.BulkOrderStatusRequest{OrderIDs: tt.orderIDs, Status: tt.status})
+			req := httptest.NewRequest(http.MethodPut, "/admin/orders/status-bulk", bytes.NewBuffer(body))
+			w := httptest.NewRecorder()
+
+			handler.UpdateOrderStatuses(w, req)
+
+			if w.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, w.Code)
+			}
+		})
+	}
+}
@@ -1,50 +1,76 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/paymentprovider"
 	"github.com/marcofilho/go-ecommerce/src/usecase/payment"
 )
 
 type PaymentHandler struct {
 	paymentUC     payment.PaymentService
+	mu            sync.RWMutex
 	webhookSecret string
+	providers     *paymentprovider.Registry
 }
 
-func NewPaymentHandler(paymentUC payment.PaymentService, webhookSecret string) *PaymentHandler {
+func NewPaymentHandler(paymentUC payment.PaymentService, webhookSecret string, providers *paymentprovider.Registry) *PaymentHandler {
 	return &PaymentHandler{
 		paymentUC:     paymentUC,
 		webhookSecret: webhookSecret,
+		providers:     providers,
 	}
 }
 
+// SetWebhookSecret replaces the secret webhooks are verified with when it is rotated
+func (h *PaymentHandler) SetWebhookSecret(secret string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.webhookSecret = secret
+}
+
 // PaymentWebhookHandler handles incoming payment webhooks
 // @Summary Process payment webhook
-// @Description Receives payment status updates from payment processor with HMAC signature verification and replay attack prevention
+// @Description Receives payment status updates from payment processor with HMAC signature verification and replay attack prevention. The signature covers the timestamp, the nonce and the body, joined as `<timestamp>.<nonce>.<body>`; the timestamp must be within 5 minutes of the server's clock and a nonce is accepted once. A paid event captures its amount, or the whole balance without one; the order moves on once paid in full
 // @Tags payments
 // @Accept json
 // @Produce json
-// @Param X-Payment-Signature header string true "HMAC-SHA256 signature of the request body"
-// @Param webhook body entity.PaymentWebhookRequest true "Payment webhook data with timestamp"
-// @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string "Unauthorized - Invalid signature or timestamp"
+// @Param X-Payment-Signature header string true "Hex HMAC-SHA256 of <timestamp>.<nonce>.<body>"
+// @Param X-Payment-Timestamp header int true "Unix time the webhook was signed at, in seconds"
+// @Param X-Payment-Nonce header string true "Unique value of 16 to 128 letters, digits, - or _"
+// @Param webhook body entity.PaymentWebhookRequest true "Payment webhook data"
+// @Success 200 {object} dto.WebhookAckResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - Invalid signature, stale timestamp or reused nonce"
+// @Failure 404 {object} dto.ErrorResponse "Order not found"
+// @Failure 409 {object} dto.ErrorResponse "Order is not pending, or its payment can't take the event"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid payload, or an amount over the balance"
 // @Router /payment-webhook [post]
 func (h *PaymentHandler) PaymentWebhookHandler(w http.ResponseWriter, r *http.Request) {
-	body, err := io.ReadAll(r.Body)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Failed to read request body")
+	body, ok := readWebhookBody(w, r)
+	if !ok {
 		return
 	}
-	defer r.Body.Close()
 
 	signature := r.Header.Get("X-Payment-Signature")
 	if signature == "" {
@@ -52,83 +78,620 @@ func (h *PaymentHandler) PaymentWebhookHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if !h.verifySignature(body, signature) {
+	timestamp := r.Header.Get("X-Payment-Timestamp")
+	signedAt, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Missing or invalid payment timestamp")
+		return
+	}
+
+	nonce := r.Header.Get("X-Payment-Nonce")
+	if !validNonce(nonce) {
+		respondError(w, http.StatusUnauthorized, "Missing or invalid payment nonce")
+		return
+	}
+
+	if !h.verifySignature(timestamp, nonce, body, signature) {
 		respondError(w, http.StatusUnauthorized, "Invalid payment signature")
 		return
 	}
 
+	if !verifyTimestamp(signedAt) {
+		respondError(w, http.StatusUnauthorized, "Request timestamp is too old or invalid")
+		return
+	}
+
+	// The nonce is claimed before the body is looked at, so a signature can't
+	// be used twice whatever becomes of its webhook
+	if err := h.paymentUC.UseWebhookNonce(r.Context(), nonce, time.Unix(signedAt, 0)); err != nil {
+		if errors.Is(err, entity.ErrConflict) {
+			respondError(w, http.StatusUnauthorized, "Nonce was already used")
+			return
+		}
+		respondDomainError(w, err)
+		return
+	}
+
 	var req entity.PaymentWebhookRequest
-	if err := json.Unmarshal(body, &req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if err := decodeJSON(bytes.NewReader(body), &req); err != nil {
+		h.deadLetter(r, entity.DirectWebhookSource, body, invalidBody(err))
+		respondDecodeError(w, err)
 		return
 	}
 
-	if !h.verifyTimestamp(req.Timestamp) {
-		respondError(w, http.StatusUnauthorized, "Request timestamp is too old or invalid")
+	if !validateRequest(w, &req) {
+		h.deadLetter(r, entity.DirectWebhookSource, body, invalidWebhook(&req))
 		return
 	}
 
 	if err := h.paymentUC.ProcessWebhook(r.Context(), &req); err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		h.deadLetter(r, entity.DirectWebhookSource, body, err)
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WebhookAckResponse{
+		Status:  "success",
+		Message: "Payment webhook processed successfully",
+	})
+}
+
+// ProviderWebhookHandler handles the webhooks of a payment provider
+// @Summary Process payment provider webhook
+// @Description Receives the webhooks of a payment provider (stripe, paypal or mercadopago), verified with the provider's own signature scheme and normalized into a payment webhook. Events that aren't about the payment of an order are acknowledged as ignored. Only providers configured on the server are accepted
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param provider path string true "Payment provider: stripe, paypal or mercadopago"
+// @Success 200 {object} dto.WebhookAckResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Invalid provider signature, or delivery already received"
+// @Failure 404 {object} dto.ErrorResponse "Unknown payment provider, or order not found"
+// @Failure 409 {object} dto.ErrorResponse "Order is not pending, or its payment can't take the event"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Invalid event"
+// @Router /payment-webhook/{provider} [post]
+func (h *PaymentHandler) ProviderWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.providers.Get(r.PathValue("provider"))
+	if !ok {
+		respondError(w, http.StatusNotFound, "Unknown payment provider")
+		return
+	}
+
+	body, ok := readWebhookBody(w, r)
+	if !ok {
+		return
+	}
+
+	if err := provider.Verify(r, body); err != nil {
+		respondError(w, http.StatusUnauthorized, "Invalid payment signature")
 		return
 	}
 
-	respondJSON(w, http.StatusOK, map[string]string{
-		"status":  "success",
-		"message": "Payment webhook processed successfully",
+	// As with direct webhooks, a delivery is claimed before its body is looked
+	// at. Its nonce is namespaced by provider, so IDs can't collide.
+	if transmitter, ok := provider.(paymentprovider.Transmitter); ok {
+		id, sentAt := transmitter.Transmission(r)
+		if err := h.paymentUC.UseWebhookNonce(r.Context(), provider.Name()+":"+id, sentAt); err != nil {
+			if errors.Is(err, entity.ErrConflict) {
+				respondError(w, http.StatusUnauthorized, "Delivery was already received")
+				return
+			}
+			respondDomainError(w, err)
+			return
+		}
+	}
+
+	req, err := provider.Parse(r, body)
+	if err != nil {
+		h.deadLetter(r, provider.Name(), body, err)
+		respondDomainError(w, err)
+		return
+	}
+	if req == nil {
+		respondJSON(w, http.StatusOK, dto.WebhookAckResponse{
+			Status:  "ignored",
+			Message: "Event is not about the payment of an order",
+		})
+		return
+	}
+
+	// Providers retry events long after they were created, so the signature
+	// check and the transmission nonces guard against replays, along with the
+	// transaction IDs processed once
+	if !validateRequest(w, req) {
+		h.deadLetter(r, provider.Name(), body, invalidWebhook(req))
+		return
+	}
+
+	if err := h.paymentUC.ProcessWebhook(r.Context(), req); err != nil {
+		h.deadLetter(r, provider.Name(), body, err)
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.WebhookAckResponse{
+		Status:  "success",
+		Message: "Payment webhook processed successfully",
 	})
 }
 
+// readWebhookBody reads the raw body signatures are computed over
+func readWebhookBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	defer r.Body.Close()
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			respondDecodeError(w, err)
+			return nil, false
+		}
+		respondError(w, http.StatusBadRequest, "Failed to read request body")
+		return nil, false
+	}
+	return body, true
+}
+
+// deadLetter keeps a signed webhook that was rejected, for an admin to
+// reprocess once whatever rejected it is fixed. Failures that aren't about
+// the webhook, like the database being down, are left to the retries of its
+// sender.
+func (h *PaymentHandler) deadLetter(r *http.Request, source string, body []byte, err error) {
+	if errors.Is(err, entity.ErrValidation) || errors.Is(err, payment.ErrWebhookRejected) {
+		h.paymentUC.DeadLetterWebhook(r.Context(), source, body, err)
+	}
+}
+
+// invalidBody describes a webhook body decodeJSON rejected
+func invalidBody(err error) error {
+	return entity.ValidationError("Invalid request body: " + err.Error())
+}
+
+// invalidWebhook describes the fields of a webhook that break its validation
+// tags, or returns nil when it is valid
+func invalidWebhook(req *entity.PaymentWebhookRequest) error {
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(validate.Struct(req), &fieldErrors) {
+		return nil
+	}
+
+	fields := make([]string, 0, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		fields = append(fields, fieldPath(fe)+" "+fieldMessage(fe))
+	}
+	sort.Strings(fields)
+	return entity.ValidationError("Validation failed: " + strings.Join(fields, ", "))
+}
+
+// parseWebhook turns a dead-lettered body back into a payment webhook the way
+// the endpoint of its source does, except for the signed timestamp and nonce
+// of direct webhooks: reprocessing comes long after they were sent. Bodies of a
+// provider that isn't configured anymore are a conflict, not another attempt.
+func (h *PaymentHandler) parseWebhook(ctx context.Context, source string, payload []byte) (*entity.PaymentWebhookRequest, error) {
+	if source == entity.DirectWebhookSource {
+		var req entity.PaymentWebhookRequest
+		if err := decodeJSON(bytes.NewReader(payload), &req); err != nil {
+			return nil, invalidBody(err)
+		}
+		if err := invalidWebhook(&req); err != nil {
+			return nil, err
+		}
+		return &req, nil
+	}
+
+	provider, ok := h.providers.Get(source)
+	if !ok {
+		return nil, entity.ConflictError(fmt.Sprintf("Payment provider %s is not configured", source))
+	}
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/api/payment-webhook/"+source, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req, err := provider.Parse(r, payload)
+	if err != nil || req == nil {
+		return nil, err
+	}
+	if err := invalidWebhook(req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // GetWebhookHistoryHandler retrieves webhook history for an order
 // @Summary Get payment webhook history
-// @Description Retrieves all payment webhook events for a specific order
+// @Description Admins get the full webhook logs of any order. Customers only get sanitized payment events (no raw payloads) for their own orders.
 // @Tags payments
 // @Accept json
 // @Produce json
 // @Param id path string true "Order ID"
-// @Success 200 {array} entity.WebhookLog
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param payment_status query string false "Filter by payment status (authorized, paid, failed)"
+// @Param status query string false "Filter by processing status (pending, processing, completed, failed) - admin only"
+// @Success 200 {object} dto.WebhookLogListResponse "Admin view; customers receive dto.PaymentEventListResponse"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
 // @Router /orders/{id}/payment-history [get]
 func (h *PaymentHandler) GetWebhookHistoryHandler(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
-	if _, err := uuid.Parse(idStr); err != nil {
+	orderID, err := uuid.Parse(idStr)
+	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid order ID")
 		return
 	}
 
-	logs, err := h.paymentUC.GetWebhookHistory(r.Context(), idStr)
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+
+	var filters repository.WebhookLogFilters
+	if paymentStatusStr := r.URL.Query().Get("payment_status"); paymentStatusStr != "" {
+		ps := entity.PaymentStatus(paymentStatusStr)
+		filters.PaymentStatus = &ps
+	}
+
+	if middleware.HasPermission(claims.Role, middleware.PermissionViewWebhookHistory) {
+		if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+			s := entity.WebhookStatus(statusStr)
+			filters.Status = &s
+		}
+
+		logs, total, err := h.paymentUC.GetWebhookHistory(r.Context(), idStr, filters, page, pageSize)
+		if err != nil {
+			respondDomainError(w, err)
+			return
+		}
+
+		respondPage(w, r, dto.ToWebhookLogListResponse(logs, total, page, pageSize))
+		return
+	}
+
+	// Customers only see events that reached a final outcome
+	completed := entity.WebhookStatusCompleted
+	filters.Status = &completed
+
+	logs, total, err := h.paymentUC.GetCustomerPaymentHistory(r.Context(), orderID, claims.UserID, filters, page, pageSize)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, logs)
+	respondPage(w, r, dto.ToPaymentEventListResponse(logs, total, page, pageSize))
 }
 
-// verifySignature validates the HMAC signature of the webhook payload
-func (h *PaymentHandler) verifySignature(payload []byte, signature string) bool {
-	mac := hmac.New(sha256.New, []byte(h.webhookSecret))
-	mac.Write(payload)
-	expectedSignature := hex.EncodeToString(mac.Sum(nil))
+// ListWebhooksHandler lists the payment webhooks of every order
+// @Summary List payment webhooks
+// @Description Payment webhook logs of every order, newest first, to find the ones that failed or got stuck (Admin only)
+// @Tags payments
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by processing status (pending, processing, completed, failed, resolved)"
+// @Param payment_status query string false "Filter by payment status (authorized, paid, failed)"
+// @Param transaction_id query string false "Filter by transaction ID"
+// @Success 200 {object} dto.WebhookLogListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/payment-webhooks [get]
+func (h *PaymentHandler) ListWebhooksHandler(w http.ResponseWriter, r *http.Request) {
+	var filters repository.WebhookLogFilters
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		s := entity.WebhookStatus(statusStr)
+		filters.Status = &s
+	}
+	if paymentStatusStr := r.URL.Query().Get("payment_status"); paymentStatusStr != "" {
+		ps := entity.PaymentStatus(paymentStatusStr)
+		filters.PaymentStatus = &ps
+	}
+	if transactionID := r.URL.Query().Get("transaction_id"); transactionID != "" {
+		filters.TransactionID = &transactionID
+	}
+	page, pageSize := parsePagination(r)
+
+	logs, total, err := h.paymentUC.ListWebhooks(r.Context(), filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToWebhookLogListResponse(logs, total, page, pageSize))
+}
+
+// CapturePaymentHandler captures the authorized payment of an order
+// @Summary Capture an authorized payment
+// @Description Capture the balance of a pending order whose payment was authorized, through the payment provider at CAPTURE_PROVIDER_URL, or logged to be captured by hand without one (Admin only). The capture is recorded in the order's payment history under the provider's reference and the order moves on as once paid, completed or processing. Capturing again after a provider error is safe, the provider captures an order once.
+// @Tags payments
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {object} dto.OrderResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires order:capture permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "The order is not pending or its payment is not authorized"
+// @Failure 500 {object} dto.ErrorResponse "The payment provider refused or failed the capture"
+// @Security BearerAuth
+// @Router /orders/{id}/capture [post]
+func (h *PaymentHandler) CapturePaymentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	order, err := h.paymentUC.CapturePayment(r.Context(), id, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToOrderResponse(order))
+}
+
+// ReplayWebhookHandler applies a failed or stuck payment webhook again
+// @Summary Replay a payment webhook
+// @Description Apply a failed, pending or stuck processing webhook again from its stored payload, without waiting for its next retry (Admin only). A webhook processing for less than 5 minutes may still be applying and is refused. When it fails again the error is returned and the log keeps the outcome.
+// @Tags payments
+// @Produce json
+// @Param id path string true "Webhook log ID"
+// @Success 200 {object} dto.WebhookLogResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires webhook:replay permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Webhook already completed or resolved, still processing, or the order is not pending anymore"
+// @Failure 422 {object} dto.ValidationErrorResponse "The order's payment can't take the webhook"
+// @Security BearerAuth
+// @Router /admin/payment-webhooks/{id}/replay [post]
+func (h *PaymentHandler) ReplayWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	log, err := h.paymentUC.ReplayWebhook(r.Context(), id, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookLogResponse(log))
+}
+
+// ResolveWebhookHandler marks a payment webhook handled by hand
+// @Summary Resolve a payment webhook
+// @Description Mark a failed, pending or stuck processing webhook as resolved, handled outside the API, so it is not retried anymore (Admin only). The order is left as it is; the note is kept in the audit log.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path string true "Webhook log ID"
+// @Param resolution body dto.WebhookResolveRequest false "How the webhook was handled"
+// @Success 200 {object} dto.WebhookLogResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires webhook:replay permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Webhook already completed or resolved, or still processing"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/payment-webhooks/{id}/resolve [post]
+func (h *PaymentHandler) ResolveWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid webhook ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.WebhookResolveRequest
+	if r.ContentLength != 0 && !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	log, err := h.paymentUC.ResolveWebhook(r.Context(), id, claims.UserID, req.Note)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookLogResponse(log))
+}
+
+// ListDeadLettersHandler lists the payment webhooks that were rejected
+// @Summary List dead-lettered payment webhooks
+// @Description Signed payment webhooks that were rejected, e.g. for a payload that doesn't validate or an order that can't be found, with the body as their source sent it and why the last attempt failed, most recently failed first (Admin only). The same body sent again by its source counts as another attempt.
+// @Tags payments
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (pending, reprocessed, discarded)"
+// @Param source query string false "Filter by source (direct, stripe, paypal or mercadopago)"
+// @Success 200 {object} dto.DeadLetterWebhookListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/payment-webhooks/dead-letters [get]
+func (h *PaymentHandler) ListDeadLettersHandler(w http.ResponseWriter, r *http.Request) {
+	var filters repository.DeadLetterFilters
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		s := entity.DeadLetterStatus(statusStr)
+		filters.Status = &s
+	}
+	if source := r.URL.Query().Get("source"); source != "" {
+		filters.Source = &source
+	}
+	page, pageSize := parsePagination(r)
+
+	webhooks, total, err := h.paymentUC.ListDeadLetters(r.Context(), filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToDeadLetterWebhookListResponse(webhooks, total, page, pageSize))
+}
+
+// ReprocessDeadLetterHandler processes a dead-lettered payment webhook again
+// @Summary Reprocess a dead-lettered payment webhook
+// @Description Parse and process a pending dead-lettered webhook again from its stored body, once whatever rejected it is fixed (Admin only). The timestamp of direct webhooks isn't checked again. When it is rejected again the error is returned and the webhook stays pending with another attempt counted.
+// @Tags payments
+// @Produce json
+// @Param id path string true "Dead-lettered webhook ID"
+// @Success 200 {object} dto.DeadLetterWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires webhook:replay permission"
+// @Failure 404 {object} dto.ErrorResponse "Dead-lettered webhook, or its order, not found"
+// @Failure 409 {object} dto.ErrorResponse "Already reprocessed or discarded, its provider isn't configured, or the order is not pending"
+// @Failure 422 {object} dto.ValidationErrorResponse "The webhook still doesn't validate"
+// @Security BearerAuth
+// @Router /admin/payment-webhooks/dead-letters/{id}/reprocess [post]
+func (h *PaymentHandler) ReprocessDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dead-lettered webhook ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	webhook, err := h.paymentUC.ReprocessDeadLetter(r.Context(), id, claims.UserID, h.parseWebhook)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToDeadLetterWebhookResponse(webhook))
+}
+
+// DiscardDeadLetterHandler gives up on a dead-lettered payment webhook
+// @Summary Discard a dead-lettered payment webhook
+// @Description Give up on a pending dead-lettered webhook without processing it, e.g. a test event (Admin only). The note is kept in the audit log. Its source sending it again still counts attempts, it isn't reopened.
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param id path string true "Dead-lettered webhook ID"
+// @Param discard body dto.DeadLetterDiscardRequest false "Why the webhook is discarded"
+// @Success 200 {object} dto.DeadLetterWebhookResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires webhook:replay permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Already reprocessed or discarded"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/payment-webhooks/dead-letters/{id}/discard [post]
+func (h *PaymentHandler) DiscardDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid dead-lettered webhook ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.DeadLetterDiscardRequest
+	if r.ContentLength != 0 && !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	webhook, err := h.paymentUC.DiscardDeadLetter(r.Context(), id, claims.UserID, req.Note)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToDeadLetterWebhookResponse(webhook))
+}
+
+// verifySignature validates the HMAC signature of the timestamp, nonce and
+// payload of a webhook
+func (h *PaymentHandler) verifySignature(timestamp, nonce string, payload []byte, signature string) bool {
+	h.mu.RLock()
+	secret := h.webhookSecret
+	h.mu.RUnlock()
+
+	expectedSignature := signWebhook(secret, timestamp, nonce, payload)
 	return hmac.Equal([]byte(signature), []byte(expectedSignature))
 }
 
-func (h *PaymentHandler) verifyTimestamp(timestamp int64) bool {
-	if timestamp == 0 {
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<nonce>.<payload>".
+// The timestamp is signed as sent, so it can't be reformatted.
+func signWebhook(secret, timestamp, nonce string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "." + nonce + "."))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyTimestamp checks that a webhook was signed within the tolerance of now
+func verifyTimestamp(timestamp int64) bool {
+	if timestamp <= 0 {
 		return false
 	}
 
 	webhookTime := time.Unix(timestamp, 0)
 	now := time.Now()
 
-	if webhookTime.After(now.Add(5 * time.Minute)) {
+	if webhookTime.After(now.Add(payment.WebhookTolerance)) {
 		return false
 	}
 
-	if webhookTime.Before(now.Add(-5 * time.Minute)) {
+	if webhookTime.Before(now.Add(-payment.WebhookTolerance)) {
 		return false
 	}
 
 	return true
 }
+
+// validNonce accepts 16 to 128 letters, digits, dashes and underscores, which
+// fit a UUID or a random token in hex or URL-safe base64
+func validNonce(nonce string) bool {
+	if len(nonce) < 16 || len(nonce) > 128 {
+		return false
+	}
+	for _, c := range nonce {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,384 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/paymentprovider"
+	"github.com/marcofilho/go-ecommerce/src/usecase/payment"
+)
+
+type stubPaymentService struct {
+	processed   []*entity.PaymentWebhookRequest
+	processErr  error
+	deadLetters []string
+	nonces      map[string]bool
+	captured    []uuid.UUID
+	captureErr  error
+}
+
+func (s *stubPaymentService) ProcessWebhook(ctx context.Context, req *entity.PaymentWebhookRequest) error {
+	s.processed = append(s.processed, req)
+	return s.processErr
+}
+
+func (s *stubPaymentService) CapturePayment(ctx context.Context, orderID, userID uuid.UUID) (*entity.Order, error) {
+	s.captured = append(s.captured, orderID)
+	if s.captureErr != nil {
+		return nil, s.captureErr
+	}
+	return &entity.Order{ID: orderID, TotalPrice: 20, AmountPaid: 20, Status: entity.Completed, PaymentStatus: entity.Paid}, nil
+}
+
+func (s *stubPaymentService) GetWebhookHistory(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubPaymentService) GetCustomerPaymentHistory(ctx context.Context, orderID, userID uuid.UUID, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubPaymentService) RetryFailedWebhooks(ctx context.Context, limit int) (int, error) {
+	return 0, nil
+}
+
+func (s *stubPaymentService) ListWebhooks(ctx context.Context, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubPaymentService) ReplayWebhook(ctx context.Context, id, userID uuid.UUID) (*entity.WebhookLog, error) {
+	return nil, entity.NotFoundError("Webhook not found")
+}
+
+func (s *stubPaymentService) ResolveWebhook(ctx context.Context, id, userID uuid.UUID, note string) (*entity.WebhookLog, error) {
+	return nil, entity.NotFoundError("Webhook not found")
+}
+
+func (s *stubPaymentService) DeadLetterWebhook(ctx context.Context, source string, payload []byte, reason error) {
+	s.deadLetters = append(s.deadLetters, source+": "+reason.Error())
+}
+
+func (s *stubPaymentService) ListDeadLetters(ctx context.Context, filters repository.DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	return nil, 0, nil
+}
+
+func (s *stubPaymentService) ReprocessDeadLetter(ctx context.Context, id, userID uuid.UUID, parse payment.WebhookParser) (*entity.DeadLetterWebhook, error) {
+	return nil, entity.NotFoundError("Dead-lettered webhook not found")
+}
+
+func (s *stubPaymentService) DiscardDeadLetter(ctx context.Context, id, userID uuid.UUID, note string) (*entity.DeadLetterWebhook, error) {
+	return nil, entity.NotFoundError("Dead-lettered webhook not found")
+}
+
+func (s *stubPaymentService) UseWebhookNonce(ctx context.Context, nonce string, signedAt time.Time) error {
+	if s.nonces[nonce] {
+		return entity.ConflictError("nonce was already used")
+	}
+	if s.nonces == nil {
+		s.nonces = make(map[string]bool)
+	}
+	s.nonces[nonce] = true
+	return nil
+}
+
+func (s *stubPaymentService) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// stubProvider trusts requests with the X-Test-Signature header and pays the
+// order named in the body, ignoring empty bodies
+type stubProvider struct{}
+
+func (stubProvider) Name() string { return "test" }
+
+func (stubProvider) Verify(r *http.Request, body []byte) error {
+	if r.Header.Get("X-Test-Signature") == "" {
+		return paymentprovider.ErrInvalidSignature
+	}
+	return nil
+}
+
+func (stubProvider) Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error) {
+	if len(body) == 0 {
+		return nil, nil
+	}
+	return &entity.PaymentWebhookRequest{OrderID: string(body), TransactionID: "evt_1", PaymentStatus: entity.Paid}, nil
+}
+
+// transmittingProvider is a stubProvider that signs the X-Test-Transmission
+// ID into its deliveries
+type transmittingProvider struct{ stubProvider }
+
+func (transmittingProvider) Transmission(r *http.Request) (string, time.Time) {
+	return r.Header.Get("X-Test-Transmission"), time.Now()
+}
+
+func TestProviderWebhookHandler(t *testing.T) {
+	orderID := uuid.New().String()
+
+	serve := func(service *stubPaymentService, provider, body string, signed bool) *httptest.ResponseRecorder {
+		h := NewPaymentHandler(service, "secret", paymentprovider.NewRegistry(stubProvider{}))
+		mux := http.NewServeMux()
+		mux.HandleFunc("POST /api/payment-webhook/{provider}", h.ProviderWebhookHandler)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/"+provider, strings.NewReader(body))
+		if signed {
+			req.Header.Set("X-Test-Signature", "ok")
+		}
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Processes the normalized payment", func(t *testing.T) {
+		service := &stubPaymentService{}
+		w := serve(service, "test", orderID, true)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if len(service.processed) != 1 || service.processed[0].OrderID != orderID {
+			t.Errorf("expected the payment of %s to be processed, got %+v", orderID, service.processed)
+		}
+	})
+
+	t.Run("Unknown provider", func(t *testing.T) {
+		if w := serve(&stubPaymentService{}, "stripe", orderID, true); w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+	})
+
+	t.Run("Invalid signature", func(t *testing.T) {
+		service := &stubPaymentService{}
+		if w := serve(service, "test", orderID, false); w.Code != http.StatusUnauthorized {
+			t.Errorf("expected status 401, got %d", w.Code)
+		}
+		if len(service.processed) != 0 {
+			t.Error("expected nothing to be processed")
+		}
+	})
+
+	t.Run("Ignores events that aren't payments", func(t *testing.T) {
+		service := &stubPaymentService{}
+		w := serve(service, "test", "", true)
+
+		var ack dto.Response[dto.WebhookAckResponse]
+		json.NewDecoder(w.Body).Decode(&ack)
+		if w.Code != http.StatusOK || ack.Data.Status != "ignored" || len(service.processed) != 0 {
+			t.Errorf("expected the event to be acknowledged as ignored, got %d %+v", w.Code, ack.Data)
+		}
+	})
+
+	t.Run("Rejects events without an order", func(t *testing.T) {
+		service := &stubPaymentService{}
+		if w := serve(service, "test", "not-a-uuid", true); w.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", w.Code)
+		}
+		if len(service.deadLetters) != 1 || service.deadLetters[0] != "test: Validation failed: order_id must be a valid UUID" {
+			t.Errorf("expected the event to be dead-lettered, got %v", service.deadLetters)
+		}
+	})
+
+	t.Run("Dead-letters events the payments reject", func(t *testing.T) {
+		service := &stubPaymentService{processErr: fmt.Errorf("rejected: %w %w", entity.NotFoundError("order not found"), payment.ErrWebhookRejected)}
+		if w := serve(service, "test", orderID, true); w.Code != http.StatusNotFound {
+			t.Errorf("expected status 404, got %d", w.Code)
+		}
+		if len(service.deadLetters) != 1 {
+			t.Errorf("expected the event to be dead-lettered, got %v", service.deadLetters)
+		}
+	})
+
+	t.Run("Leaves failures of the store to the retries of the provider", func(t *testing.T) {
+		service := &stubPaymentService{processErr: errors.New("database is down")}
+		if w := serve(service, "test", orderID, true); w.Code != http.StatusInternalServerError {
+			t.Errorf("expected status 500, got %d", w.Code)
+		}
+		if len(service.deadLetters) != 0 {
+			t.Errorf("expected nothing to be dead-lettered, got %v", service.deadLetters)
+		}
+	})
+
+	t.Run("Doesn't dead-letter unsigned events", func(t *testing.T) {
+		service := &stubPaymentService{}
+		serve(service, "test", "not-a-uuid", false)
+		if len(service.deadLetters) != 0 {
+			t.Errorf("expected nothing to be dead-lettered, got %v", service.deadLetters)
+		}
+	})
+
+	t.Run("Rejects replayed transmissions", func(t *testing.T) {
+		service := &stubPaymentService{}
+		h := NewPaymentHandler(service, "secret", paymentprovider.NewRegistry(transmittingProvider{}))
+		deliver := func(transmission string) int {
+			req := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/test", strings.NewReader(orderID))
+			req.SetPathValue("provider", "test")
+			req.Header.Set("X-Test-Signature", "ok")
+			req.Header.Set("X-Test-Transmission", transmission)
+			w := httptest.NewRecorder()
+			h.ProviderWebhookHandler(w, req)
+			return w.Code
+		}
+
+		if code := deliver("tx-1"); code != http.StatusOK {
+			t.Fatalf("expected the delivery to be accepted, got %d", code)
+		}
+		if code := deliver("tx-1"); code != http.StatusUnauthorized {
+			t.Errorf("expected a replayed delivery to be rejected, got %d", code)
+		}
+		if code := deliver("tx-2"); code != http.StatusOK {
+			t.Errorf("expected another delivery to be accepted, got %d", code)
+		}
+		if len(service.processed) != 2 || !service.nonces["test:tx-1"] {
+			t.Errorf("expected the transmissions to be claimed as nonces, got %v", service.nonces)
+		}
+	})
+}
+
+func TestPaymentWebhookHandler_Signature(t *testing.T) {
+	service := &stubPaymentService{}
+	h := NewPaymentHandler(service, "secret", nil)
+	body := []byte(`{"order_id":"` + uuid.New().String() + `","transaction_id":"txn-1","payment_status":"paid"}`)
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	send := func(timestamp, nonce, signature string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/payment-webhook", bytes.NewReader(body))
+		req.Header.Set("X-Payment-Signature", signature)
+		req.Header.Set("X-Payment-Timestamp", timestamp)
+		req.Header.Set("X-Payment-Nonce", nonce)
+		w := httptest.NewRecorder()
+		h.PaymentWebhookHandler(w, req)
+		return w
+	}
+
+	nonce := "nonce-0123456789abcdef"
+	if w := send(now, nonce, signWebhook("secret", now, nonce, body)); w.Code != http.StatusOK {
+		t.Fatalf("expected a signed webhook to be accepted, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := send(now, nonce, signWebhook("secret", now, nonce, body)); w.Code != http.StatusUnauthorized || !strings.Contains(w.Body.String(), "Nonce was already used") {
+		t.Errorf("expected a replayed signature to be unauthorized, got %d: %s", w.Code, w.Body.String())
+	}
+
+	// The scheme before nonces signed the body alone
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	bodyOnly := hex.EncodeToString(mac.Sum(nil))
+
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	other := "nonce-fedcba9876543210"
+	tests := []struct {
+		name                        string
+		timestamp, nonce, signature string
+	}{
+		{"body only", now, other, bodyOnly},
+		{"another nonce", now, other, signWebhook("secret", now, nonce, body)},
+		{"another timestamp", stale, other, signWebhook("secret", now, other, body)},
+		{"stale", stale, other, signWebhook("secret", stale, other, body)},
+		{"short nonce", now, "n-1", signWebhook("secret", now, "n-1", body)},
+		{"no timestamp", "", other, signWebhook("secret", "", other, body)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := send(tt.timestamp, tt.nonce, tt.signature); w.Code != http.StatusUnauthorized {
+				t.Errorf("expected status 401, got %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+	if len(service.processed) != 1 {
+		t.Errorf("expected only the first webhook to be processed, got %d", len(service.processed))
+	}
+}
+
+func TestPaymentHandler_ParseWebhook(t *testing.T) {
+	h := NewPaymentHandler(&stubPaymentService{}, "secret", paymentprovider.NewRegistry(stubProvider{}))
+	orderID := uuid.New().String()
+
+	// Direct webhooks are parsed without checking when they were signed,
+	// reprocessing comes long after they were sent
+	req, err := h.parseWebhook(context.Background(), entity.DirectWebhookSource,
+		[]byte(`{"order_id":"`+orderID+`","transaction_id":"txn-1","payment_status":"paid","timestamp":1}`))
+	if err != nil || req.OrderID != orderID {
+		t.Fatalf("expected the webhook to be parsed, got %+v, %v", req, err)
+	}
+
+	if _, err := h.parseWebhook(context.Background(), entity.DirectWebhookSource, []byte(`{"order_id":"`+orderID+`","extra":1}`)); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected an unknown field to be invalid, got %v", err)
+	}
+	if _, err := h.parseWebhook(context.Background(), entity.DirectWebhookSource, []byte(`{"order_id":"x"}`)); err == nil ||
+		err.Error() != "Validation failed: order_id must be a valid UUID, payment_status is required, transaction_id is required" {
+		t.Errorf("expected every invalid field to be described, got %v", err)
+	}
+
+	if req, err := h.parseWebhook(context.Background(), "test", []byte(orderID)); err != nil || req.OrderID != orderID {
+		t.Errorf("expected the provider to parse the event, got %+v, %v", req, err)
+	}
+	if req, err := h.parseWebhook(context.Background(), "test", nil); err != nil || req != nil {
+		t.Errorf("expected an ignored event, got %+v, %v", req, err)
+	}
+	if _, err := h.parseWebhook(context.Background(), "stripe", []byte(orderID)); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a provider that isn't configured to be a conflict, got %v", err)
+	}
+}
+
+func TestCapturePaymentHandler(t *testing.T) {
+	capture := func(service *stubPaymentService, id string) *httptest.ResponseRecorder {
+		h := NewPaymentHandler(service, "secret", paymentprovider.NewRegistry())
+		req := httptest.NewRequest(http.MethodPost, "/api/orders/"+id+"/capture", nil)
+		req.SetPathValue("id", id)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, &auth.Claims{UserID: uuid.New(), Role: entity.RoleAdmin}))
+		w := httptest.NewRecorder()
+		h.CapturePaymentHandler(w, req)
+		return w
+	}
+
+	t.Run("captured", func(t *testing.T) {
+		service := &stubPaymentService{}
+		orderID := uuid.New()
+		w := capture(service, orderID.String())
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want 200: %s", w.Code, w.Body.String())
+		}
+		var response dto.Response[dto.OrderResponse]
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		if response.Data.PaymentStatus != string(entity.Paid) || len(service.captured) != 1 || service.captured[0] != orderID {
+			t.Errorf("unexpected capture: %+v, %v", response.Data, service.captured)
+		}
+	})
+
+	t.Run("not authorized", func(t *testing.T) {
+		service := &stubPaymentService{captureErr: entity.ConflictError("only authorized payments of pending orders can be captured")}
+		if w := capture(service, uuid.New().String()); w.Code != http.StatusConflict {
+			t.Errorf("status = %d, want 409", w.Code)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		service := &stubPaymentService{}
+		if w := capture(service, "not-a-uuid"); w.Code != http.StatusBadRequest {
+			t.Errorf("status = %d, want 400", w.Code)
+		}
+		if len(service.captured) != 0 {
+			t.Error("expected no capture")
+		}
+	})
+}
@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+)
+
+type PriceHistoryHandler struct {
+	priceService pricehistory.PriceHistoryService
+}
+
+func NewPriceHistoryHandler(priceService pricehistory.PriceHistoryService) *PriceHistoryHandler {
+	return &PriceHistoryHandler{
+		priceService: priceService,
+	}
+}
+
+// ListHistory godoc
+// @Summary List the price history of a product
+// @Description Paginated price history of a product and its variants, latest effective first. Edits of the price and of variant price overrides are recorded as they are made; scheduled changes are listed with their window and status (pending, active, expired or cancelled).
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param variant_id query string false "Only changes of this variant"
+// @Success 200 {object} dto.PriceChangeListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires price:view_history permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/price-history [get]
+func (h *PriceHistoryHandler) ListHistory(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+
+	var filters repository.PriceChangeFilters
+	if variantIDStr := r.URL.Query().Get("variant_id"); variantIDStr != "" {
+		variantID, err := uuid.Parse(variantIDStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid variant ID")
+			return
+		}
+		filters.VariantID = &variantID
+	}
+
+	changes, total, err := h.priceService.ListHistory(r.Context(), productID, filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToPriceChangeListResponse(changes, total, page, pageSize))
+}
+
+// SchedulePrice godoc
+// @Summary Schedule a price change
+// @Description Schedule a price for a product, or for one of its variants, from effective_from until effective_to, or for good when effective_to is omitted. The stored price is left alone: the scheduled price overrides it while in effect, and when windows overlap the one that started last wins. Requires admin privileges.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param schedule body dto.SchedulePriceRequest true "Price and window"
+// @Success 201 {object} dto.PriceChangeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires price:schedule permission"
+// @Failure 404 {object} dto.ErrorResponse "Product or variant not found"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /products/{id}/price-schedule [post]
+func (h *PriceHistoryHandler) SchedulePrice(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	input := pricehistory.ScheduleInput{Price: req.Price}
+	if req.VariantID != nil && *req.VariantID != "" {
+		variantID, err := uuid.Parse(*req.VariantID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid variant ID")
+			return
+		}
+		input.VariantID = &variantID
+	}
+	if input.EffectiveFrom, err = time.Parse(time.RFC3339, req.EffectiveFrom); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid effective_from. Use RFC 3339, e.g. 2026-11-27T00:00:00Z")
+		return
+	}
+	if req.EffectiveTo != nil && *req.EffectiveTo != "" {
+		effectiveTo, err := time.Parse(time.RFC3339, *req.EffectiveTo)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid effective_to. Use RFC 3339, e.g. 2026-11-30T23:59:59Z")
+			return
+		}
+		input.EffectiveTo = &effectiveTo
+	}
+
+	change, err := h.priceService.SchedulePrice(r.Context(), productID, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToPriceChangeResponse(change))
+}
+
+// CancelScheduledPrice godoc
+// @Summary Cancel a scheduled price change
+// @Description Cancel a scheduled price change that has not started yet. It stays in the price history as cancelled. Requires admin privileges.
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param change_id path string true "Price change ID"
+// @Success 200 {object} dto.PriceChangeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires price:schedule permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "The change already started or is not scheduled"
+// @Router /products/{id}/price-schedule/{change_id} [delete]
+func (h *PriceHistoryHandler) CancelScheduledPrice(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+	changeID, err := uuid.Parse(r.PathValue("change_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid price change ID")
+		return
+	}
+
+	change, err := h.priceService.CancelScheduledPrice(r.Context(), productID, changeID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToPriceChangeResponse(change))
+}
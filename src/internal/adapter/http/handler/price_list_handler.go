@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+)
+
+type PriceListHandler struct {
+	priceListService pricing.PriceListService
+}
+
+func NewPriceListHandler(priceListService pricing.PriceListService) *PriceListHandler {
+	return &PriceListHandler{
+		priceListService: priceListService,
+	}
+}
+
+// GetPriceList godoc
+// @Summary Get the price list of a product
+// @Description Quantity breaks and customer group prices of a product and its variants. Requires admin privileges.
+// @Tags products
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.PriceListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires price:manage_lists permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /products/{id}/price-list [get]
+func (h *PriceListHandler) GetPriceList(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	tiers, err := h.priceListService.GetPriceList(r.Context(), productID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToPriceListResponse(productID, tiers))
+}
+
+// ReplacePriceList godoc
+// @Summary Replace the price list of a product
+// @Description Replace the quantity breaks and customer group prices of a product. A tier prices lines of at least min_quantity units bought by customers of its group; retail tiers apply to every group and tiers without a variant to every variant. Orders pay the lowest of the effective price and the tiers that apply. An empty list removes the price list. Requires admin privileges.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param price_list body dto.PriceListRequest true "Tiers"
+// @Success 200 {object} dto.PriceListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires price:manage_lists permission"
+// @Failure 404 {object} dto.ErrorResponse "Product or variant not found"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /products/{id}/price-list [put]
+func (h *PriceListHandler) ReplacePriceList(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.PriceListRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	tiers := make([]entity.PriceTier, 0, len(req.Tiers))
+	for _, tierReq := range req.Tiers {
+		tier := entity.PriceTier{
+			CustomerGroup: tierReq.CustomerGroup,
+			MinQuantity:   tierReq.MinQuantity,
+			Price:         tierReq.Price,
+		}
+		if tierReq.VariantID != nil && *tierReq.VariantID != "" {
+			variantID, err := uuid.Parse(*tierReq.VariantID)
+			if err != nil {
+				respondError(w, http.StatusBadRequest, "Invalid variant ID")
+				return
+			}
+			tier.VariantID = &variantID
+		}
+		tiers = append(tiers, tier)
+	}
+
+	saved, err := h.priceListService.ReplacePriceList(r.Context(), productID, tiers)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToPriceListResponse(productID, saved))
+}
@@ -1,12 +1,17 @@
 package handler
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"net/http"
-	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/usecase/product"
 )
 
@@ -22,41 +27,46 @@ func NewProductHandler(useCase product.ProductService) *ProductHandler {
 
 // CreateProduct godoc
 // @Summary Create a new product
-// @Description Create a new product with the provided information
+// @Description Create a new product with the provided information. Send status draft to prepare it out of public view and publish it later; it is active, on sale, by default.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param product body dto.ProductRequest true "Product information"
 // @Success 201 {object} dto.ProductResponse
 // @Failure 400 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /products [post]
 func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
 	var req dto.ProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
-	product, err := h.useCase.CreateProduct(r.Context(), req.Name, req.Description, req.Price, req.Quantity)
+	product, err := h.useCase.CreateProduct(r.Context(), req.Name, req.Description, req.Price, req.Quantity, dto.ToUnitMeasure(req), entity.ProductStatus(req.Status))
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
+	setETag(w, product.ContentHash())
 	response := dto.ToProductResponse(product)
 	respondJSON(w, http.StatusCreated, response)
 }
 
 // GetProduct godoc
 // @Summary Get a product by ID
-// @Description Get detailed information about a specific product
+// @Description Get detailed information about a specific product. The name and description are translated to the first language of Accept-Language the product has a translation for, falling back from a regional language such as pt-BR to pt; Content-Language and the locale field name the translation used. The ETag stays the content hash of the untranslated product. Drafts and archived products are only found by admins.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param Accept-Language header string false "Preferred languages, e.g. pt-BR, en;q=0.8"
+// @Param fields query string false "Comma-separated attributes to return, e.g. id,name,price; all of them when empty"
 // @Success 200 {object} dto.ProductResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse "Unknown field"
 // @Router /products/{id} [get]
 func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -66,33 +76,58 @@ func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	product, err := h.useCase.GetProduct(r.Context(), id)
+	fields, err := dto.ParseFields[dto.ProductResponse](r.URL.Query().Get("fields"))
 	if err != nil {
-		respondError(w, http.StatusNotFound, "Product not found")
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToProductResponse(product)
-	respondJSON(w, http.StatusOK, response)
+	product, err := h.useCase.GetProduct(r.Context(), id, acceptedLocales(r), !canSeeUnpublished(r))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	setETag(w, product.ContentHash())
+	setContentLanguage(w, product)
+	respondJSON(w, http.StatusOK, dto.SelectFields(dto.ToProductResponse(product), fields))
 }
 
 // ListProducts godoc
 // @Summary List all products
-// @Description Get a paginated list of products with optional filtering and sorting
+// @Description Get a paginated list of products with optional filtering and sorting. Products are listed as summaries, with their ID, name, prices, stock and status; pass include to list full products with the relations named instead, like a single product. Names and descriptions are translated by Accept-Language like a single product. Only published products are listed, except for admins, who see every status unless they filter by one.
 // @Tags products
 // @Accept json
 // @Produce json
+// @Param Accept-Language header string false "Preferred languages, e.g. pt-BR, en;q=0.8"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Items per page" default(10)
-// @Param sort_by query string false "Sort by field (name, price, created_at)" default("created_at")
+// @Param sort_by query string false "Sort by field (name, price, created_at, rating); rating sorts by average rating, then number of reviews" default("created_at")
 // @Param sort_order query string false "Sort order (asc, desc)" default("desc")
 // @Param in_stock_only query bool false "Filter products in stock only" default(true)
-// @Success 200 {object} dto.ProductListResponse
-// @Failure 400 {object} dto.ErrorResponse
+// @Param attr.{code} query string false "Filter by attribute value, e.g. attr.material=cotton (repeat for several attributes)"
+// @Param status query string false "Filter by status (draft, active, archived), admins only"
+// @Param include query string false "Comma-separated relations to load with each product (categories, variants, options, attributes), listing full products; an empty value lists full products without relations"
+// @Param fields query string false "Comma-separated attributes to return of each product, e.g. id,name,price; all of them when empty. Summaries and full products have different attributes"
+// @Success 200 {object} dto.ProductSummaryListResponse "Without include; with it, dto.ProductListResponse"
+// @Failure 400 {object} dto.ErrorResponse "Invalid sort order"
+// @Failure 422 {object} dto.ErrorResponse "Unknown attribute, invalid attribute value, invalid sort field, invalid include or unknown field"
 // @Router /products [get]
 func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	page, pageSize := parsePagination(r)
+
+	sort := repository.ProductSort{Field: repository.ProductSortCreatedAt, Descending: true}
+	if sortBy := r.URL.Query().Get("sort_by"); sortBy != "" {
+		sort.Field = repository.ProductSortField(sortBy)
+	}
+	switch r.URL.Query().Get("sort_order") {
+	case "", "desc":
+	case "asc":
+		sort.Descending = false
+	default:
+		respondError(w, http.StatusBadRequest, "Invalid sort order, use asc or desc")
+		return
+	}
 
 	inStockOnlyParam := r.URL.Query().Get("in_stock_only")
 	inStockOnly := true
@@ -100,34 +135,88 @@ func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 		inStockOnly = false
 	}
 
-	if page < 1 {
-		page = 1
+	attributes := make(map[string]string)
+	for key, values := range r.URL.Query() {
+		if code, ok := strings.CutPrefix(key, "attr."); ok && code != "" {
+			attributes[code] = values[0]
+		}
 	}
-	if pageSize < 1 {
-		pageSize = 10
+
+	status := entity.ProductActive
+	if canSeeUnpublished(r) {
+		status = entity.ProductStatus(r.URL.Query().Get("status"))
+	}
+
+	// Admins also see what is scheduled for later or no longer on sale
+	availableOnly := !canSeeUnpublished(r)
+
+	include := productIncludes(r)
+	if include == nil {
+		fields, err := dto.ParseFields[dto.ProductSummaryResponse](r.URL.Query().Get("fields"))
+		if err != nil {
+			respondDomainError(w, err)
+			return
+		}
+
+		products, total, err := h.useCase.ListProductSummaries(r.Context(), page, pageSize, inStockOnly, status, availableOnly, attributes, sort, acceptedLocales(r))
+		if err != nil {
+			respondDomainError(w, err)
+			return
+		}
+
+		w.Header().Add("Vary", "Accept-Language")
+		respondPage(w, r, dto.SelectPageFields(dto.ToProductSummaryListResponse(products, total, page, pageSize), fields))
+		return
 	}
 
-	products, total, err := h.useCase.ListProducts(r.Context(), page, pageSize, inStockOnly)
+	fields, err := dto.ParseFields[dto.ProductResponse](r.URL.Query().Get("fields"))
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToProductListResponse(products, total, page, pageSize)
-	respondJSON(w, http.StatusOK, response)
+	products, total, err := h.useCase.ListProducts(r.Context(), page, pageSize, inStockOnly, status, availableOnly, attributes, sort, include, acceptedLocales(r))
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.Header().Add("Vary", "Accept-Language")
+
+	respondPage(w, r, dto.SelectPageFields(dto.ToProductListResponse(products, total, page, pageSize), fields))
+}
+
+// productIncludes returns the relations listed in the include query
+// parameter, nil when it is absent
+func productIncludes(r *http.Request) []repository.ProductRelation {
+	if !r.URL.Query().Has("include") {
+		return nil
+	}
+
+	include := []repository.ProductRelation{}
+	for _, name := range strings.Split(r.URL.Query().Get("include"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			include = append(include, repository.ProductRelation(name))
+		}
+	}
+	return include
 }
 
 // UpdateProduct godoc
 // @Summary Update a product
-// @Description Update an existing product's information
+// @Description Update an existing product's information. Send the content hash from a previous read or event in If-Match to reject the update if the product changed in the meantime.
 // @Tags products
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
+// @Param If-Match header string false "Expected content hash"
 // @Param product body dto.ProductRequest true "Product information"
 // @Success 200 {object} dto.ProductResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 412 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /products/{id} [put]
 func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -138,24 +227,30 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var req dto.ProductRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
-	product, err := h.useCase.UpdateProduct(r.Context(), id, req.Name, req.Description, req.Price, req.Quantity)
+	expectedHash := parseIfMatch(r.Header.Get("If-Match"))
+
+	updated, err := h.useCase.UpdateProduct(r.Context(), id, req.Name, req.Description, req.Price, req.Quantity, dto.ToUnitMeasure(req), expectedHash)
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		if errors.Is(err, product.ErrContentHashMismatch) {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+			return
+		}
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToProductResponse(product)
+	setETag(w, updated.ContentHash())
+	response := dto.ToProductResponse(updated)
 	respondJSON(w, http.StatusOK, response)
 }
 
 // DeleteProduct godoc
 // @Summary Delete a product
-// @Description Delete a product by ID
+// @Description Delete a product by ID. Products that orders were placed for can't be deleted, as their invoices and history need them: archive them instead.
 // @Tags products
 // @Accept json
 // @Produce json
@@ -163,6 +258,7 @@ func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
 // @Success 204 "No Content"
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Product has orders"
 // @Router /products/{id} [delete]
 func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -173,9 +269,269 @@ func (h *ProductHandler) DeleteProduct(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := h.useCase.DeleteProduct(r.Context(), id); err != nil {
-		respondError(w, http.StatusNotFound, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// SetHighDemandMode godoc
+// @Summary Toggle high-demand (queue) mode
+// @Description Enable or disable the fair purchase queue for a product. While enabled, customers must join the queue and wait for a purchase window before ordering.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.HighDemandModeRequest true "Queue mode"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/queue-mode [put]
+func (h *ProductHandler) SetHighDemandMode(w http.ResponseWriter, r *http.Request) {
+	idStr := r.PathValue("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.HighDemandModeRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	product, err := h.useCase.SetHighDemandMode(r.Context(), id, req.Enabled)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	response := dto.ToProductResponse(product)
+	respondJSON(w, http.StatusOK, response)
+}
+
+// SetProductCost godoc
+// @Summary Set product cost
+// @Description Record the unit cost of a product, or clear it with null. The cost is never shown in public product responses; search ranking uses it to boost high-margin products.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.ProductCostRequest true "Unit cost"
+// @Success 200 {object} dto.ProductCostResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/cost [put]
+func (h *ProductHandler) SetProductCost(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductCostRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	updated, err := h.useCase.SetCost(r.Context(), id, req.Cost)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductCostResponse(updated))
+}
+
+// SetProductAvailability godoc
+// @Summary Schedule product availability
+// @Description Set the window a product can be bought in, e.g. for a flash sale or a seasonal item. Outside of it the product is left out of public listings, search, category pages and the product feed, and orders for it are rejected; it can still be looked up by ID. Null leaves that end of the window open, so sending both as null makes the product available for good.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.ProductAvailabilityRequest true "Availability window"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/availability [put]
+func (h *ProductHandler) SetProductAvailability(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductAvailabilityRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	var from, until *time.Time
+	if req.AvailableFrom != nil && *req.AvailableFrom != "" {
+		parsed, err := time.Parse(time.RFC3339, *req.AvailableFrom)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid available_from. Use RFC 3339, e.g. 2026-11-27T00:00:00Z")
+			return
+		}
+		from = &parsed
+	}
+	if req.AvailableUntil != nil && *req.AvailableUntil != "" {
+		parsed, err := time.Parse(time.RFC3339, *req.AvailableUntil)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid available_until. Use RFC 3339, e.g. 2026-11-30T23:59:59Z")
+			return
+		}
+		until = &parsed
+	}
+
+	updated, err := h.useCase.SetAvailability(r.Context(), id, from, until)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductResponse(updated))
+}
+
+// SetProductPurchaseLimits godoc
+// @Summary Set product purchase limits
+// @Description Cap the units of a product one order can have and one customer can buy over all their orders, e.g. for a limited-edition drop. Cancelled and refunded orders don't count towards the customer limit. 0 lifts a limit.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param request body dto.ProductPurchaseLimitsRequest true "Purchase limits"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/purchase-limits [put]
+func (h *ProductHandler) SetProductPurchaseLimits(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductPurchaseLimitsRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	updated, err := h.useCase.SetPurchaseLimits(r.Context(), id, req.MaxPerOrder, req.MaxPerCustomer)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductResponse(updated))
+}
+
+// PublishProduct godoc
+// @Summary Publish a product
+// @Description Put a draft or archived product on sale: it shows in public listings, search, category pages and the product feed, and can be ordered.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Product is already published"
+// @Security BearerAuth
+// @Router /products/{id}/publish [post]
+func (h *ProductHandler) PublishProduct(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.PublishProduct)
+}
+
+// UnpublishProduct godoc
+// @Summary Unpublish a product
+// @Description Take a published product off sale and back to draft. Only admins see it until it is published again.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Product is not published"
+// @Security BearerAuth
+// @Router /products/{id}/unpublish [post]
+func (h *ProductHandler) UnpublishProduct(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.UnpublishProduct)
+}
+
+// ArchiveProduct godoc
+// @Summary Archive a product
+// @Description Retire a product that is no longer sold without deleting it: it is hidden from the public and can't be ordered, but admins still see it with its history. Publishing puts it back on sale.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.ProductResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Product is already archived"
+// @Security BearerAuth
+// @Router /products/{id}/archive [post]
+func (h *ProductHandler) ArchiveProduct(w http.ResponseWriter, r *http.Request) {
+	h.changeStatus(w, r, h.useCase.ArchiveProduct)
+}
+
+func (h *ProductHandler) changeStatus(w http.ResponseWriter, r *http.Request, change func(ctx context.Context, id uuid.UUID) (*entity.Product, error)) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	updated, err := change(r.Context(), id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductResponse(updated))
+}
+
+// canSeeUnpublished tells whether the caller, if signed in, may see drafts
+// and archived products
+func canSeeUnpublished(r *http.Request) bool {
+	claims, err := middleware.GetUserFromContext(r)
+	return err == nil && middleware.HasPermission(claims.Role, middleware.PermissionUpdateProduct)
+}
+
+// setETag exposes the product content hash so clients can send it back in If-Match
+func setETag(w http.ResponseWriter, hash string) {
+	w.Header().Set("ETag", `"`+hash+`"`)
+}
+
+// setContentLanguage tells caches the response depends on Accept-Language
+// and names the language of a translated product
+func setContentLanguage(w http.ResponseWriter, product *entity.Product) {
+	w.Header().Add("Vary", "Accept-Language")
+	if product.Translation != nil {
+		w.Header().Set("Content-Language", product.Translation.Locale)
+	}
+}
+
+// parseIfMatch extracts the content hash from an If-Match header, accepting
+// both quoted ETags and the bare hash
+func parseIfMatch(header string) string {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return ""
+	}
+	header = strings.TrimPrefix(header, "W/")
+	return strings.Trim(header, `"`)
+}
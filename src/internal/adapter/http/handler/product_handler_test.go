@@ -12,8 +12,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
 	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
 	"github.com/marcofilho/go-ecommerce/src/usecase/product"
 )
@@ -21,7 +23,7 @@ import (
 type mockProductRepo struct {
 	createFunc  func(ctx context.Context, product *entity.Product) error
 	getByIDFunc func(ctx context.Context, id uuid.UUID) (*entity.Product, error)
-	getAllFunc  func(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error)
+	getAllFunc  func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error)
 	updateFunc  func(ctx context.Context, product *entity.Product) error
 	deleteFunc  func(ctx context.Context, id uuid.UUID) error
 }
@@ -37,16 +39,31 @@ func (m *mockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Pr
 	if m.getByIDFunc != nil {
 		return m.getByIDFunc(ctx, id)
 	}
-	return nil, errors.New("not found")
+	return nil, entity.NotFoundError("Product not found")
 }
 
-func (m *mockProductRepo) GetAll(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+func (m *mockProductRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	var found []*entity.Product
+	for _, id := range ids {
+		if item, err := m.GetByID(ctx, id); err == nil {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockProductRepo) GetAll(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
 	if m.getAllFunc != nil {
-		return m.getAllFunc(ctx, page, pageSize, inStockOnly)
+		return m.getAllFunc(ctx, page, pageSize, filters)
 	}
 	return nil, 0, nil
 }
 
+// ListProductsSummary serves summaries from the same function as GetAll
+func (m *mockProductRepo) ListProductsSummary(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return m.GetAll(ctx, page, pageSize, filters)
+}
+
 func (m *mockProductRepo) Update(ctx context.Context, prod *entity.Product) error {
 	if m.updateFunc != nil {
 		return m.updateFunc(ctx, prod)
@@ -70,7 +87,7 @@ func TestProductHandler_CreateProduct_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	uc := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	reqBody := dto.ProductRequest{
 		Name:        "Laptop",
@@ -90,7 +107,7 @@ func TestProductHandler_CreateProduct_Success(t *testing.T) {
 	}
 
 	var response dto.ProductResponse
-	json.NewDecoder(w.Body).Decode(&response)
+	decodeData(w.Body, &response)
 	if response.Name != "Laptop" {
 		t.Errorf("expected name Laptop, got %s", response.Name)
 	}
@@ -98,7 +115,7 @@ func TestProductHandler_CreateProduct_Success(t *testing.T) {
 
 func TestProductHandler_CreateProduct_InvalidJSON(t *testing.T) {
 	mockRepo := &mockProductRepo{}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodPost, "/products", bytes.NewBuffer([]byte("invalid json")))
 	w := httptest.NewRecorder()
@@ -116,7 +133,7 @@ func TestProductHandler_CreateProduct_UseCaseError(t *testing.T) {
 			return errors.New("validation error")
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	reqBody := dto.ProductRequest{Name: "", Price: -10, Quantity: 0}
 	body, _ := json.Marshal(reqBody)
@@ -126,8 +143,8 @@ func TestProductHandler_CreateProduct_UseCaseError(t *testing.T) {
 
 	handler.CreateProduct(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected status 422, got %d", w.Code)
 	}
 }
 
@@ -140,12 +157,13 @@ func TestProductHandler_GetProduct_Success(t *testing.T) {
 				Name:      "Laptop",
 				Price:     999.99,
 				Quantity:  10,
+				Status:    entity.ProductActive,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
 			}, nil
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String(), nil)
 	req.SetPathValue("id", productID.String())
@@ -158,7 +176,7 @@ func TestProductHandler_GetProduct_Success(t *testing.T) {
 	}
 
 	var response dto.ProductResponse
-	json.NewDecoder(w.Body).Decode(&response)
+	decodeData(w.Body, &response)
 	if response.ID != productID.String() {
 		t.Errorf("expected ID %s, got %s", productID.String(), response.ID)
 	}
@@ -166,7 +184,7 @@ func TestProductHandler_GetProduct_Success(t *testing.T) {
 
 func TestProductHandler_GetProduct_InvalidID(t *testing.T) {
 	mockRepo := &mockProductRepo{}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/products/invalid-id", nil)
 	req.SetPathValue("id", "invalid-id")
@@ -182,10 +200,10 @@ func TestProductHandler_GetProduct_InvalidID(t *testing.T) {
 func TestProductHandler_GetProduct_NotFound(t *testing.T) {
 	mockRepo := &mockProductRepo{
 		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
-			return nil, errors.New("not found")
+			return nil, entity.NotFoundError("Product not found")
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	productID := uuid.New()
 	req := httptest.NewRequest(http.MethodGet, "/products/"+productID.String(), nil)
@@ -201,14 +219,14 @@ func TestProductHandler_GetProduct_NotFound(t *testing.T) {
 
 func TestProductHandler_ListProducts_Success(t *testing.T) {
 	mockRepo := &mockProductRepo{
-		getAllFunc: func(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
 			return []*entity.Product{
 				{ID: uuid.New(), Name: "P1", Price: 100, Quantity: 5, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 				{ID: uuid.New(), Name: "P2", Price: 200, Quantity: 10, CreatedAt: time.Now(), UpdatedAt: time.Now()},
 			}, 2, nil
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/products?page=1&page_size=10&in_stock_only=true", nil)
 	w := httptest.NewRecorder()
@@ -226,16 +244,53 @@ func TestProductHandler_ListProducts_Success(t *testing.T) {
 	}
 }
 
+func TestProductHandler_ListProducts_Summaries(t *testing.T) {
+	mockRepo := &mockProductRepo{
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+			return []*entity.Product{
+				{ID: uuid.New(), Name: "P1", Description: "Long description", Price: 100, Quantity: 5, Status: entity.ProductActive,
+					Categories: []entity.Category{{ID: uuid.New(), Name: "Clothing"}}},
+			}, 1, nil
+		},
+	}
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
+
+	w := httptest.NewRecorder()
+	handler.ListProducts(w, httptest.NewRequest(http.MethodGet, "/products", nil))
+
+	var response struct {
+		Data []map[string]any `json:"data"`
+	}
+	json.NewDecoder(w.Body).Decode(&response)
+	if len(response.Data) != 1 || response.Data[0]["stock"] != float64(5) {
+		t.Fatalf("expected a summary with the stock, got %+v", response.Data)
+	}
+	if _, ok := response.Data[0]["description"]; ok {
+		t.Error("expected the description left to the detail endpoint")
+	}
+	if _, ok := response.Data[0]["categories"]; ok {
+		t.Error("expected the categories left to the detail endpoint")
+	}
+
+	w = httptest.NewRecorder()
+	handler.ListProducts(w, httptest.NewRequest(http.MethodGet, "/products?include=categories", nil))
+	var full dto.ProductListResponse
+	json.NewDecoder(w.Body).Decode(&full)
+	if len(full.Data) != 1 || len(full.Data[0].Categories) != 1 {
+		t.Errorf("expected include to list full products, got %+v", full.Data)
+	}
+}
+
 func TestProductHandler_ListProducts_InStockOnlyFalse(t *testing.T) {
 	mockRepo := &mockProductRepo{
-		getAllFunc: func(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
-			if inStockOnly {
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+			if filters.InStockOnly {
 				t.Error("expected inStockOnly to be false")
 			}
 			return []*entity.Product{}, 0, nil
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/products?in_stock_only=false", nil)
 	w := httptest.NewRecorder()
@@ -247,13 +302,75 @@ func TestProductHandler_ListProducts_InStockOnlyFalse(t *testing.T) {
 	}
 }
 
+func TestProductHandler_ListProducts_StatusVisibility(t *testing.T) {
+	var lastStatus entity.ProductStatus
+	mockRepo := &mockProductRepo{
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+			lastStatus = filters.Status
+			return []*entity.Product{}, 0, nil
+		},
+	}
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
+
+	req := httptest.NewRequest(http.MethodGet, "/products?status=draft", nil)
+	handler.ListProducts(httptest.NewRecorder(), req)
+	if lastStatus != entity.ProductActive {
+		t.Errorf("expected anonymous callers to only see active products, got %q", lastStatus)
+	}
+
+	adminClaims := &auth.Claims{UserID: uuid.New(), Email: "admin@example.com", Role: entity.RoleAdmin}
+	req = httptest.NewRequest(http.MethodGet, "/products?status=draft", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserContextKey, adminClaims))
+	handler.ListProducts(httptest.NewRecorder(), req)
+	if lastStatus != entity.ProductDraft {
+		t.Errorf("expected admins to filter by status, got %q", lastStatus)
+	}
+}
+
+func TestProductHandler_ListProducts_Include(t *testing.T) {
+	var lastInclude []repository.ProductRelation
+	mockRepo := &mockProductRepo{
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+			lastInclude = filters.Include
+			return []*entity.Product{}, 0, nil
+		},
+	}
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
+
+	tests := []struct {
+		query string
+		want  []repository.ProductRelation
+	}{
+		{"/products?include=", []repository.ProductRelation{}},
+		{"/products?include=variants,%20categories", []repository.ProductRelation{repository.ProductVariants, repository.ProductCategories}},
+	}
+	for _, tt := range tests {
+		handler.ListProducts(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.query, nil))
+		if (lastInclude == nil) != (tt.want == nil) || len(lastInclude) != len(tt.want) {
+			t.Errorf("%s: expected include %v, got %v", tt.query, tt.want, lastInclude)
+			continue
+		}
+		for i := range tt.want {
+			if lastInclude[i] != tt.want[i] {
+				t.Errorf("%s: expected include %v, got %v", tt.query, tt.want, lastInclude)
+			}
+		}
+	}
+
+	w := httptest.NewRecorder()
+	handler.ListProducts(w, httptest.NewRequest(http.MethodGet, "/products?include=reviews", nil))
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected an unknown relation rejected with 422, got %d", w.Code)
+	}
+}
+
 func TestProductHandler_ListProducts_UseCaseError(t *testing.T) {
 	mockRepo := &mockProductRepo{
-		getAllFunc: func(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+		getAllFunc: func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
 			return nil, 0, errors.New("database error")
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodGet, "/products", nil)
 	w := httptest.NewRecorder()
@@ -282,7 +399,7 @@ func TestProductHandler_UpdateProduct_Success(t *testing.T) {
 			return nil
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	reqBody := dto.ProductRequest{
 		Name:        "Updated Laptop",
@@ -303,15 +420,38 @@ func TestProductHandler_UpdateProduct_Success(t *testing.T) {
 	}
 
 	var response dto.ProductResponse
-	json.NewDecoder(w.Body).Decode(&response)
+	decodeData(w.Body, &response)
 	if response.Name != "Updated Laptop" {
 		t.Errorf("expected name 'Updated Laptop', got %s", response.Name)
 	}
 }
 
+func TestProductHandler_UpdateProduct_StaleIfMatch(t *testing.T) {
+	productID := uuid.New()
+	mockRepo := &mockProductRepo{
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+			return &entity.Product{ID: productID, Name: "Laptop", Price: 100, Quantity: 5}, nil
+		},
+	}
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
+
+	body, _ := json.Marshal(dto.ProductRequest{Name: "Updated Laptop", Price: 120, Quantity: 5})
+
+	req := httptest.NewRequest(http.MethodPut, "/products/"+productID.String(), bytes.NewBuffer(body))
+	req.SetPathValue("id", productID.String())
+	req.Header.Set("If-Match", `"outdated-hash"`)
+	w := httptest.NewRecorder()
+
+	handler.UpdateProduct(w, req)
+
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected status 412, got %d", w.Code)
+	}
+}
+
 func TestProductHandler_UpdateProduct_InvalidID(t *testing.T) {
 	mockRepo := &mockProductRepo{}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	reqBody := dto.ProductRequest{Name: "Updated"}
 	body, _ := json.Marshal(reqBody)
@@ -330,7 +470,7 @@ func TestProductHandler_UpdateProduct_InvalidID(t *testing.T) {
 func TestProductHandler_UpdateProduct_InvalidJSON(t *testing.T) {
 	productID := uuid.New()
 	mockRepo := &mockProductRepo{}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodPut, "/products/"+productID.String(), bytes.NewBuffer([]byte("invalid")))
 	req.SetPathValue("id", productID.String())
@@ -347,10 +487,10 @@ func TestProductHandler_UpdateProduct_UseCaseError(t *testing.T) {
 	productID := uuid.New()
 	mockRepo := &mockProductRepo{
 		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
-			return nil, errors.New("not found")
+			return nil, entity.NotFoundError("Product not found")
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	reqBody := dto.ProductRequest{Name: "Test"}
 	body, _ := json.Marshal(reqBody)
@@ -361,8 +501,8 @@ func TestProductHandler_UpdateProduct_UseCaseError(t *testing.T) {
 
 	handler.UpdateProduct(w, req)
 
-	if w.Code != http.StatusBadRequest {
-		t.Errorf("expected status 400, got %d", w.Code)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404, got %d", w.Code)
 	}
 }
 
@@ -376,7 +516,7 @@ func TestProductHandler_DeleteProduct_Success(t *testing.T) {
 			return nil
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
 	req.SetPathValue("id", productID.String())
@@ -391,7 +531,7 @@ func TestProductHandler_DeleteProduct_Success(t *testing.T) {
 
 func TestProductHandler_DeleteProduct_InvalidID(t *testing.T) {
 	mockRepo := &mockProductRepo{}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/products/invalid-id", nil)
 	req.SetPathValue("id", "invalid-id")
@@ -404,14 +544,37 @@ func TestProductHandler_DeleteProduct_InvalidID(t *testing.T) {
 	}
 }
 
+func TestProductHandler_DeleteProduct_HasOrders(t *testing.T) {
+	productID := uuid.New()
+	mockRepo := &mockProductRepo{
+		getByIDFunc: func(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+			return &entity.Product{ID: productID, Name: "Test Product", Price: 100}, nil
+		},
+		deleteFunc: func(ctx context.Context, id uuid.UUID) error {
+			return entity.ErrProductHasOrders
+		},
+	}
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
+
+	req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
+	req.SetPathValue("id", productID.String())
+	w := httptest.NewRecorder()
+
+	handler.DeleteProduct(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected status 409, got %d", w.Code)
+	}
+}
+
 func TestProductHandler_DeleteProduct_NotFound(t *testing.T) {
 	productID := uuid.New()
 	mockRepo := &mockProductRepo{
 		deleteFunc: func(ctx context.Context, id uuid.UUID) error {
-			return errors.New("not found")
+			return entity.NotFoundError("Product not found")
 		},
 	}
-	handler := NewProductHandler(product.NewUseCase(mockRepo, &mockServices.MockServices{}))
+	handler := NewProductHandler(product.NewUseCase(mockRepo, nil, &mockServices.MockServices{}))
 
 	req := httptest.NewRequest(http.MethodDelete, "/products/"+productID.String(), nil)
 	req.SetPathValue("id", productID.String())
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/usecase/translation"
+)
+
+type ProductTranslationHandler struct {
+	translationService translation.TranslationService
+}
+
+func NewProductTranslationHandler(translationService translation.TranslationService) *ProductTranslationHandler {
+	return &ProductTranslationHandler{
+		translationService: translationService,
+	}
+}
+
+// ListTranslations godoc
+// @Summary List the translations of a product
+// @Description List the name and description of a product in every language it was translated to, ordered by locale. Requires admin privileges.
+// @Tags product_translations
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Success 200 {array} dto.ProductTranslationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:update permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Router /products/{id}/translations [get]
+func (h *ProductTranslationHandler) ListTranslations(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	translations, err := h.translationService.ListTranslations(r.Context(), productID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	response := make([]dto.ProductTranslationResponse, 0, len(translations))
+	for _, t := range translations {
+		response = append(response, dto.ToProductTranslationResponse(t))
+	}
+	respondJSON(w, http.StatusOK, response)
+}
+
+// SaveTranslation godoc
+// @Summary Translate a product
+// @Description Create or replace the name and description of a product in a language, e.g. pt-BR. Product reads with that language in Accept-Language return them; an empty description keeps the original one. The default locale can't be translated, it is the product itself. Requires admin privileges.
+// @Tags product_translations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param locale path string true "Language tag, e.g. es or pt-BR"
+// @Param translation body dto.ProductTranslationRequest true "Translated name and description"
+// @Success 200 {object} dto.ProductTranslationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:update permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /products/{id}/translations/{locale} [put]
+func (h *ProductTranslationHandler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductTranslationRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	translation, err := h.translationService.SaveTranslation(r.Context(), productID, r.PathValue("locale"), req.Name, req.Description)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductTranslationResponse(translation))
+}
+
+// DeleteTranslation godoc
+// @Summary Delete a translation of a product
+// @Description Remove the translation of a product in a language, reads in that language fall back to the next one. Requires admin privileges.
+// @Tags product_translations
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param locale path string true "Language tag, e.g. es or pt-BR"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:update permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 422 {object} dto.ErrorResponse
+// @Router /products/{id}/translations/{locale} [delete]
+func (h *ProductTranslationHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.translationService.DeleteTranslation(r.Context(), productID, r.PathValue("locale")); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
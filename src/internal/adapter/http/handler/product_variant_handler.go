@@ -1,9 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
 	"net/http"
-	"strconv"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
@@ -22,7 +20,7 @@ func NewProductVariantHandler(useCase productvariant.ProductVariantService) *Pro
 
 // CreateProductVariant godoc
 // @Summary Create a new product variant
-// @Description Create a new product variant with the provided information. Requires admin privileges.
+// @Description Create a variant for a combination of the product's option values, e.g. {"Size": "L", "Color": "Red"}. The SKU is generated when omitted. Requires admin privileges.
 // @Tags product_variants
 // @Accept json
 // @Produce json
@@ -33,6 +31,9 @@ func NewProductVariantHandler(useCase productvariant.ProductVariantService) *Pro
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:create permission"
+// @Failure 409 {object} dto.ErrorResponse "SKU or option combination already used"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /products/{id}/variants [post]
 func (h *ProductVariantHandler) CreateProductVariant(w http.ResponseWriter, r *http.Request) {
 	// Get product ID from path parameter
@@ -44,14 +45,13 @@ func (h *ProductVariantHandler) CreateProductVariant(w http.ResponseWriter, r *h
 	}
 
 	var req dto.ProductVariantRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
-	productVariant, err := h.useCase.CreateProductVariant(r.Context(), productID, req.VariantName, req.VariantValue, req.PriceOverride, req.Quantity)
+	productVariant, err := h.useCase.CreateProductVariant(r.Context(), productID, toVariantInput(req))
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
@@ -65,13 +65,13 @@ func (h *ProductVariantHandler) CreateProductVariant(w http.ResponseWriter, r *h
 // @Tags product_variants
 // @Accept json
 // @Produce json
-// @Param id path string true "Product Variant ID"
+// @Param variant_id path string true "Product Variant ID"
 // @Success 200 {object} dto.ProductVariantResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Failure 404 {object} dto.ErrorResponse
-// @Router /product_variants/{id} [get]
+// @Router /variants/{variant_id} [get]
 func (h *ProductVariantHandler) GetProductVariant(w http.ResponseWriter, r *http.Request) {
-	idStr := r.PathValue("id")
+	idStr := r.PathValue("variant_id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid product variant ID")
@@ -90,15 +90,13 @@ func (h *ProductVariantHandler) GetProductVariant(w http.ResponseWriter, r *http
 
 // ListProductVariants godoc
 // @Summary List all product variants for a product
-// @Description Get a paginated list of product variants for a specific product with sorting
+// @Description Get a paginated list of product variants for a specific product
 // @Tags product_variants
 // @Accept json
 // @Produce json
 // @Param id path string true "Product ID"
 // @Param page query int false "Page number" default(1)
 // @Param page_size query int false "Items per page" default(10)
-// @Param sort_by query string false "Sort by field (variant_name, created_at)" default("created_at")
-// @Param sort_order query string false "Sort order (asc, desc)" default("asc")
 // @Success 200 {object} dto.ProductVariantListResponse
 // @Failure 400 {object} dto.ErrorResponse
 // @Router /products/{id}/variants [get]
@@ -110,24 +108,15 @@ func (h *ProductVariantHandler) ListProductVariants(w http.ResponseWriter, r *ht
 		return
 	}
 
-	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
-	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
-
-	if page < 1 {
-		page = 1
-	}
-	if pageSize < 1 {
-		pageSize = 10
-	}
+	page, pageSize := parsePagination(r)
 
 	variants, total, err := h.useCase.ListProductVariants(r.Context(), productID, page, pageSize)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
-	response := dto.ToProductVariantListResponse(variants, total, page, pageSize)
-	respondJSON(w, http.StatusOK, response)
+	respondPage(w, r, dto.ToProductVariantListResponse(variants, total, page, pageSize))
 }
 
 // UpdateProductVariant godoc
@@ -144,6 +133,9 @@ func (h *ProductVariantHandler) ListProductVariants(w http.ResponseWriter, r *ht
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized"
 // @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:update permission"
 // @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "SKU or option combination already used"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
 // @Router /variants/{variant_id} [put]
 func (h *ProductVariantHandler) UpdateProductVariant(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("variant_id")
@@ -154,14 +146,13 @@ func (h *ProductVariantHandler) UpdateProductVariant(w http.ResponseWriter, r *h
 	}
 
 	var req dto.ProductVariantRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid request body")
+	if !decodeAndValidate(w, r, &req) {
 		return
 	}
 
-	productVariant, err := h.useCase.UpdateProductVariant(r.Context(), id, req.VariantName, req.VariantValue, req.PriceOverride, req.Quantity)
+	productVariant, err := h.useCase.UpdateProductVariant(r.Context(), id, toVariantInput(req))
 	if err != nil {
-		respondError(w, http.StatusBadRequest, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
@@ -192,9 +183,215 @@ func (h *ProductVariantHandler) DeleteProductVariant(w http.ResponseWriter, r *h
 	}
 
 	if err := h.useCase.DeleteProductVariant(r.Context(), id); err != nil {
-		respondError(w, http.StatusNotFound, err.Error())
+		respondDomainError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// TransferStock godoc
+// @Summary Transfer stock of a variant
+// @Description Atomically move units out of a variant into another variant of the same product, or into the base product when to_variant_id is omitted. With from_product set, units move from the base product into the variant. Both sides are recorded in the stock ledger with reason "transfer" and a shared reference. Requires admin privileges.
+// @Tags product_variants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param variant_id path string true "Product Variant ID"
+// @Param transfer body dto.StockTransferRequest true "Destination and quantity"
+// @Success 200 {object} dto.StockTransferResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires stock:transfer permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Insufficient stock at the source"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /variants/{variant_id}/transfer [post]
+func (h *ProductVariantHandler) TransferStock(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("variant_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product variant ID")
+		return
+	}
+
+	var req dto.StockTransferRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	input := productvariant.TransferInput{FromProduct: req.FromProduct, Quantity: req.Quantity}
+	if req.ToVariantID != nil && *req.ToVariantID != "" {
+		toVariantID, err := uuid.Parse(*req.ToVariantID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid destination variant ID")
+			return
+		}
+		input.ToVariantID = &toVariantID
+	}
+
+	out, in, err := h.useCase.TransferStock(r.Context(), id, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToStockTransferResponse(out, in))
+}
+
+// CreateOption godoc
+// @Summary Add an option to a product
+// @Description Add an axis the product varies on, such as Size, with its values. Options can only be added before the product has variants. Requires admin privileges.
+// @Tags product_variants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param option body dto.ProductOptionRequest true "Option name and values"
+// @Success 201 {object} dto.ProductOptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:create permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Option exists or product already has variants"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /products/{id}/options [post]
+func (h *ProductVariantHandler) CreateOption(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductOptionRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	option, err := h.useCase.CreateOption(r.Context(), productID, req.Name, req.Values)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToProductOptionResponse(option))
+}
+
+// ListOptions godoc
+// @Summary List a product's options
+// @Description Get the options a product varies on and their values, in display order
+// @Tags product_variants
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {array} dto.ProductOptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Router /products/{id}/options [get]
+func (h *ProductVariantHandler) ListOptions(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	options, err := h.useCase.ListOptions(r.Context(), productID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductOptionResponses(options))
+}
+
+// AddOptionValue godoc
+// @Summary Add a value to a product option
+// @Description Add a value, such as XL, to an existing option. Requires admin privileges.
+// @Tags product_variants
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param option_id path string true "Option ID"
+// @Param value body dto.ProductOptionValueRequest true "Option value"
+// @Success 201 {object} dto.ProductOptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:update permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Router /products/{id}/options/{option_id}/values [post]
+func (h *ProductVariantHandler) AddOptionValue(w http.ResponseWriter, r *http.Request) {
+	productID, optionID, ok := parseProductOptionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ProductOptionValueRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	option, err := h.useCase.AddOptionValue(r.Context(), productID, optionID, req.Value)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToProductOptionResponse(option))
+}
+
+// DeleteOption godoc
+// @Summary Delete a product option
+// @Description Delete an option and its values. Fails while any variant still uses it. Requires admin privileges.
+// @Tags product_variants
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Product ID"
+// @Param option_id path string true "Option ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires product:delete permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Option is used by variants"
+// @Router /products/{id}/options/{option_id} [delete]
+func (h *ProductVariantHandler) DeleteOption(w http.ResponseWriter, r *http.Request) {
+	productID, optionID, ok := parseProductOptionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.useCase.DeleteOption(r.Context(), productID, optionID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toVariantInput(req dto.ProductVariantRequest) productvariant.VariantInput {
+	return productvariant.VariantInput{
+		SKU:           req.SKU,
+		Options:       req.Options,
+		PriceOverride: req.PriceOverride,
+		Quantity:      req.Quantity,
+	}
+}
+
+func parseProductOptionIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	optionID, err := uuid.Parse(r.PathValue("option_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid option ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return productID, optionID, true
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/queue"
+)
+
+type QueueHandler struct {
+	queueService queue.QueueService
+}
+
+func NewQueueHandler(queueService queue.QueueService) *QueueHandler {
+	return &QueueHandler{
+		queueService: queueService,
+	}
+}
+
+// JoinQueue godoc
+// @Summary Join a product's purchase queue
+// @Description Enter the fair queue of a high-demand product. Joining again while still in line returns the existing place.
+// @Tags queue
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.QueueStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Product is not in high-demand mode"
+// @Security BearerAuth
+// @Router /products/{id}/queue [post]
+func (h *QueueHandler) JoinQueue(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	status, err := h.queueService.JoinQueue(r.Context(), productID, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToQueueStatusResponse(status.Entry, status.Position))
+}
+
+// GetQueueStatus godoc
+// @Summary Get purchase queue position
+// @Description Poll the caller's position in a product's queue. Once admitted, the response includes the purchase window deadline.
+// @Tags queue
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} dto.QueueStatusResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/queue [get]
+func (h *QueueHandler) GetQueueStatus(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	status, err := h.queueService.GetQueueStatus(r.Context(), productID, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToQueueStatusResponse(status.Entry, status.Position))
+}
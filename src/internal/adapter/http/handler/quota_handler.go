@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/ratelimit"
+)
+
+type QuotaHandler struct {
+	rateLimitService ratelimit.RateLimitService
+}
+
+func NewQuotaHandler(rateLimitService ratelimit.RateLimitService) *QuotaHandler {
+	return &QuotaHandler{
+		rateLimitService: rateLimitService,
+	}
+}
+
+// GetMyQuota godoc
+// @Summary Get my rate limit quota
+// @Description Show the caller's request budget for the current window so clients can pace themselves. This request itself has already been counted.
+// @Tags users
+// @Produce json
+// @Success 200 {object} dto.QuotaResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/quota [get]
+func (h *QuotaHandler) GetMyQuota(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	quota := h.rateLimitService.Quota(ratelimit.UserKey(claims.UserID))
+	middleware.SetRateLimitHeaders(w, quota)
+
+	respondJSON(w, http.StatusOK, dto.ToQuotaResponse(quota, time.Now()))
+}
@@ -0,0 +1,283 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/recall"
+)
+
+type RecallHandler struct {
+	recallService recall.RecallService
+}
+
+func NewRecallHandler(recallService recall.RecallService) *RecallHandler {
+	return &RecallHandler{
+		recallService: recallService,
+	}
+}
+
+// FindAffectedOrders godoc
+// @Summary Find orders affected by a recall
+// @Description Look up the orders, live and archived, that contain a SKU or product and were placed in the date range, without notifying anyone (Admin only). Cancelled orders are skipped.
+// @Tags recalls
+// @Accept json
+// @Produce json
+// @Param target body dto.RecallTargetRequest true "Recalled SKU or product and order dates"
+// @Success 200 {object} dto.AffectedOrdersResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/recalls/affected [post]
+func (h *RecallHandler) FindAffectedOrders(w http.ResponseWriter, r *http.Request) {
+	var req dto.RecallTargetRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	target, err := parseRecallTarget(req)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	impact, err := h.recallService.FindAffected(r.Context(), target)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToAffectedOrdersResponse(impact))
+}
+
+// CreateRecall godoc
+// @Summary Create a recall
+// @Description Create a recall for a SKU or product and order dates, and notify the customer of every affected order (Admin only). Orders placed without an account are listed as unreachable. Set up the "recall.notice" email template to customize the notice.
+// @Tags recalls
+// @Accept json
+// @Produce json
+// @Param recall body dto.RecallRequest true "Recall"
+// @Success 201 {object} dto.RecallResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "No affected orders"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/recalls [post]
+func (h *RecallHandler) CreateRecall(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.RecallRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	target, err := parseRecallTarget(req.RecallTargetRequest)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	created, err := h.recallService.CreateRecall(r.Context(), claims.UserID, recall.CreateInput{
+		Target:      target,
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToRecallResponse(created, true))
+}
+
+// ListRecalls godoc
+// @Summary List recalls
+// @Description Paginated recalls with their notification and acknowledgment counts, newest first (Admin only)
+// @Tags recalls
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} dto.RecallListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/recalls [get]
+func (h *RecallHandler) ListRecalls(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
+
+	recalls, total, err := h.recallService.ListRecalls(r.Context(), page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToRecallListResponse(recalls, total, page, pageSize))
+}
+
+// GetRecall godoc
+// @Summary Get a recall
+// @Description Get a recall with the delivery and acknowledgment status of every notice (Admin only)
+// @Tags recalls
+// @Produce json
+// @Param id path string true "Recall ID"
+// @Success 200 {object} dto.RecallResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/recalls/{id} [get]
+func (h *RecallHandler) GetRecall(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+
+	found, err := h.recallService.GetRecall(r.Context(), id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRecallResponse(found, true))
+}
+
+// ResendNotices godoc
+// @Summary Resend recall notices
+// @Description Retry the notices of a recall that are pending or failed to send (Admin only)
+// @Tags recalls
+// @Produce json
+// @Param id path string true "Recall ID"
+// @Success 200 {object} dto.RecallResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/recalls/{id}/resend [post]
+func (h *RecallHandler) ResendNotices(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recall ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	updated, err := h.recallService.ResendNotices(r.Context(), claims.UserID, id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRecallResponse(updated, true))
+}
+
+// ListMyRecallNotices godoc
+// @Summary List my recall notices
+// @Description Recalls affecting the authenticated user's orders, newest first
+// @Tags recalls
+// @Produce json
+// @Success 200 {array} dto.MyRecallNoticeResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/recalls [get]
+func (h *RecallHandler) ListMyRecallNotices(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	notices, err := h.recallService.ListMyNotices(r.Context(), claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	responses := make([]dto.MyRecallNoticeResponse, 0, len(notices))
+	for _, notice := range notices {
+		responses = append(responses, dto.ToMyRecallNoticeResponse(notice))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// AcknowledgeRecallNotice godoc
+// @Summary Acknowledge a recall notice
+// @Description Confirm that the authenticated user received a recall notice. Acknowledging again keeps the first acknowledgment.
+// @Tags recalls
+// @Produce json
+// @Param notice_id path string true "Recall notice ID"
+// @Success 200 {object} dto.MyRecallNoticeResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/recalls/{notice_id}/acknowledge [post]
+func (h *RecallHandler) AcknowledgeRecallNotice(w http.ResponseWriter, r *http.Request) {
+	noticeID, err := uuid.Parse(r.PathValue("notice_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid recall notice ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	notice, err := h.recallService.Acknowledge(r.Context(), claims.UserID, noticeID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToMyRecallNoticeResponse(notice))
+}
+
+func parseRecallTarget(req dto.RecallTargetRequest) (recall.Target, error) {
+	target := recall.Target{SKU: req.SKU, Lot: req.Lot}
+
+	if req.ProductID != nil {
+		productID, err := uuid.Parse(*req.ProductID)
+		if err != nil {
+			return target, errors.New("Invalid product ID")
+		}
+		target.ProductID = &productID
+	}
+
+	var err error
+	if target.From, err = time.Parse("2006-01-02", req.From); err != nil {
+		return target, errors.New("Invalid from date. Use YYYY-MM-DD")
+	}
+	if target.To, err = time.Parse("2006-01-02", req.To); err != nil {
+		return target, errors.New("Invalid to date. Use YYYY-MM-DD")
+	}
+
+	return target, nil
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/remediation"
+)
+
+type RemediationHandler struct {
+	remediationService remediation.RemediationService
+}
+
+func NewRemediationHandler(remediationService remediation.RemediationService) *RemediationHandler {
+	return &RemediationHandler{
+		remediationService: remediationService,
+	}
+}
+
+// Remediate godoc
+// @Summary Remediate an order
+// @Description Refund without return, issue a partial goodwill credit or resend an item. Every action needs a reason code and counts against the agent's daily budget for their role.
+// @Tags remediations
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param remediation body dto.RemediationRequest true "Remediation"
+// @Success 201 {object} dto.RemediationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Budget exceeded"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Order not eligible, order total exceeded or out of stock"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/orders/{id}/remediations [post]
+func (h *RemediationHandler) Remediate(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.RemediationRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	var itemID *uuid.UUID
+	if req.OrderItemID != nil {
+		parsed, err := uuid.Parse(*req.OrderItemID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid order item ID")
+			return
+		}
+		itemID = &parsed
+	}
+
+	result, err := h.remediationService.Remediate(r.Context(), orderID, remediation.Actor{ID: claims.UserID, Role: claims.Role}, remediation.Request{
+		Action:      entity.RemediationAction(req.Action),
+		Reason:      entity.RemediationReason(req.ReasonCode),
+		Amount:      req.Amount,
+		OrderItemID: itemID,
+		Quantity:    req.Quantity,
+		Note:        req.Note,
+	})
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToRemediationResponse(result))
+}
+
+// ListRemediations godoc
+// @Summary List order remediations
+// @Description Refunds without return, goodwill credits and resends issued on an order, newest first
+// @Tags remediations
+// @Produce json
+// @Param id path string true "Order ID"
+// @Success 200 {array} dto.RemediationResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/orders/{id}/remediations [get]
+func (h *RemediationHandler) ListRemediations(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	remediations, err := h.remediationService.ListRemediations(r.Context(), orderID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	response := make([]dto.RemediationResponse, 0, len(remediations))
+	for _, item := range remediations {
+		response = append(response, dto.ToRemediationResponse(item))
+	}
+
+	respondJSON(w, http.StatusOK, response)
+}
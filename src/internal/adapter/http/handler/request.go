@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// validate checks the `validate` tags of request DTOs. Fields are reported by
+// their JSON name, so clients see the keys they sent.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+	return v
+}
+
+// errTrailingData rejects a body with more than the one JSON value expected
+var errTrailingData = errors.New("Request body must contain a single JSON value")
+
+// decodeJSON decodes a single JSON value strictly: fields the target does not
+// declare and anything after the value are errors rather than ignored
+func decodeJSON(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errTrailingData
+	}
+	return nil
+}
+
+// decodeAndValidate decodes the JSON body into req and checks its validation
+// tags. It writes the error response itself and reports whether the handler
+// can go on: a body that cannot be decoded is a 400, or a 413 when it is over
+// the route's size limit, and a body that breaks a tag is a 422 listing every
+// offending field.
+func decodeAndValidate(w http.ResponseWriter, r *http.Request, req interface{}) bool {
+	if err := decodeJSON(r.Body, req); err != nil {
+		respondDecodeError(w, err)
+		return false
+	}
+	return validateRequest(w, req)
+}
+
+// respondDecodeError writes the response for a body decodeJSON rejected.
+// Unknown fields and values of the wrong type are reported per field.
+func respondDecodeError(w http.ResponseWriter, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body too large, the limit is %d bytes", tooLarge.Limit))
+		return
+	}
+	if errors.Is(err, errTrailingData) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		respondFieldErrors(w, http.StatusBadRequest, "Invalid request body", map[string]string{
+			jsonPath(typeErr.Field): typeMessage(typeErr.Type),
+		})
+		return
+	}
+	if name, ok := unknownField(err); ok {
+		respondFieldErrors(w, http.StatusBadRequest, "Invalid request body", map[string]string{
+			name: "is not a known field",
+		})
+		return
+	}
+
+	respondError(w, http.StatusBadRequest, "Invalid request body")
+}
+
+func respondFieldErrors(w http.ResponseWriter, status int, message string, errs map[string]string) {
+	writeJSON(w, status, dto.ValidationErrorResponse{Error: message, Errors: errs})
+}
+
+// unknownField extracts the field name from the error DisallowUnknownFields
+// causes. encoding/json has no error type for it, only the message.
+func unknownField(err error) (string, bool) {
+	rest, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	name, err := strconv.Unquote(rest)
+	if err != nil {
+		return rest, true
+	}
+	return name, true
+}
+
+// jsonPath turns the dotted path encoding/json reports, e.g.
+// products.0.quantity, into the one validation errors use: products[0].quantity
+func jsonPath(field string) string {
+	parts := strings.Split(field, ".")
+	var b strings.Builder
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil && i > 0 {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+// typeMessage names the JSON type a Go field expects
+func typeMessage(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "must be a string"
+	case reflect.Bool:
+		return "must be a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "must be an integer"
+	case reflect.Float32, reflect.Float64:
+		return "must be a number"
+	case reflect.Slice, reflect.Array:
+		return "must be an array"
+	default:
+		return "must be an object"
+	}
+}
+
+// validateRequest checks the validation tags of an already decoded request,
+// for handlers that need the raw body, e.g. to verify a signature, or accept
+// an empty one
+func validateRequest(w http.ResponseWriter, req interface{}) bool {
+	err := validate.Struct(req)
+	if err == nil {
+		return true
+	}
+
+	var fieldErrors validator.ValidationErrors
+	if !errors.As(err, &fieldErrors) {
+		respondError(w, http.StatusInternalServerError, "Failed to validate request")
+		return false
+	}
+
+	errs := make(map[string]string, len(fieldErrors))
+	for _, fe := range fieldErrors {
+		errs[fieldPath(fe)] = fieldMessage(fe)
+	}
+	respondFieldErrors(w, http.StatusUnprocessableEntity, "Validation failed", errs)
+	return false
+}
+
+// fieldPath is the JSON path of the field without the request type, e.g.
+// products[0].quantity. Embedded structs have no JSON name and are skipped.
+func fieldPath(fe validator.FieldError) string {
+	_, path, _ := strings.Cut(fe.Namespace(), ".")
+	parts := strings.Split(path, ".")
+	kept := parts[:0]
+	for _, part := range parts {
+		if part != "" && !isEmbeddedName(part) {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, ".")
+}
+
+// isEmbeddedName reports whether a namespace part is a Go type name, which the
+// validator uses for embedded structs. JSON names in this API are snake_case.
+func isEmbeddedName(part string) bool {
+	return part[0] >= 'A' && part[0] <= 'Z'
+}
+
+// fieldMessage describes a broken validation tag the way the API words its
+// other errors, short and lowercase after the field name
+func fieldMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "uuid":
+		return "must be a valid UUID"
+	case "datetime":
+		return "must be a date in YYYY-MM-DD format"
+	case "oneof":
+		return "must be one of: " + strings.ReplaceAll(param, " ", ", ")
+	case "gt":
+		return "must be > " + param
+	case "gte":
+		return "must be >= " + param
+	case "min", "max":
+		return sizeMessage(fe)
+	default:
+		return fmt.Sprintf("failed the %s check", fe.Tag())
+	}
+}
+
+// sizeMessage words min and max for the kind of field they limit
+func sizeMessage(fe validator.FieldError) string {
+	bound := "at least"
+	if fe.Tag() == "max" {
+		bound = "at most"
+	}
+	plural := "s"
+	if fe.Param() == "1" {
+		plural = ""
+	}
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("must be %s %s character%s", bound, fe.Param(), plural)
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("must have %s %s item%s", bound, fe.Param(), plural)
+	default:
+		if fe.Tag() == "max" {
+			return "must be <= " + fe.Param()
+		}
+		return "must be >= " + fe.Param()
+	}
+}
+
+// maxAcceptedLocales caps how many languages of Accept-Language are looked up
+const maxAcceptedLocales = 10
+
+// acceptedLocales returns the languages of the Accept-Language header, most
+// preferred first. Tags that aren't valid locales, the wildcard and languages
+// with q=0 are left out.
+func acceptedLocales(r *http.Request) []string {
+	type weighted struct {
+		locale string
+		q      float64
+	}
+
+	var candidates []weighted
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		locale, err := entity.NormalizeLocale(tag)
+		if err != nil || q <= 0 {
+			continue
+		}
+		candidates = append(candidates, weighted{locale, q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].q > candidates[j].q })
+	if len(candidates) > maxAcceptedLocales {
+		candidates = candidates[:maxAcceptedLocales]
+	}
+
+	locales := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		locales[i] = candidate.locale
+	}
+	return locales
+}
+
+// Page sizes of list endpoints: the default, and the largest a client may ask for
+const (
+	defaultPageSize = 10
+	maxPageSize     = 100
+)
+
+// parsePagination reads the page and page_size query parameters of a list
+// endpoint, falling back to the first page and the default size
+func parsePagination(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	pageSize, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > maxPageSize {
+		pageSize = defaultPageSize
+	}
+	return page, pageSize
+}
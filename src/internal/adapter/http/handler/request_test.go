@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+)
+
+func TestDecodeAndValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		req        interface{}
+		wantOK     bool
+		wantStatus int
+		wantErrors map[string]string
+	}{
+		{
+			name:   "valid product",
+			body:   `{"name":"Laptop","price":999.99,"quantity":5}`,
+			req:    &dto.ProductRequest{},
+			wantOK: true,
+		},
+		{
+			name:       "malformed JSON",
+			body:       `{"name":`,
+			req:        &dto.ProductRequest{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "every broken field is reported",
+			body:       `{"name":"","price":-1,"quantity":-2,"measurement_unit":"lb"}`,
+			req:        &dto.ProductRequest{},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: map[string]string{
+				"name":             "is required",
+				"price":            "must be >= 0",
+				"quantity":         "must be >= 0",
+				"measurement_unit": "must be one of: kg, l, m",
+			},
+		},
+		{
+			name:       "nested items use their JSON path",
+			body:       `{"customer_id":1,"products":[{"product_id":"550e8400-e29b-41d4-a716-446655440000","quantity":1},{"product_id":"x","quantity":0}]}`,
+			req:        &dto.CreateOrderRequest{},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: map[string]string{
+				"products[1].product_id": "must be a valid UUID",
+				"products[1].quantity":   "must be > 0",
+			},
+		},
+		{
+			name:       "embedded fields are reported at the top level",
+			body:       `{"title":"Overheating risk","description":"Return it","from":"01/02/2024","lot":"` + strings.Repeat("L", 101) + `"}`,
+			req:        &dto.RecallRequest{},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: map[string]string{
+				"from": "must be a date in YYYY-MM-DD format",
+				"to":   "is required",
+				"lot":  "must be at most 100 characters",
+			},
+		},
+		{
+			name:       "unknown fields are rejected",
+			body:       `{"name":"Laptop","price":10,"stock":5}`,
+			req:        &dto.ProductRequest{},
+			wantStatus: http.StatusBadRequest,
+			wantErrors: map[string]string{"stock": "is not a known field"},
+		},
+		{
+			name:       "values of the wrong type",
+			body:       `{"customer_id":1,"products":[{"product_id":"550e8400-e29b-41d4-a716-446655440000","quantity":"2"}]}`,
+			req:        &dto.CreateOrderRequest{},
+			wantStatus: http.StatusBadRequest,
+			wantErrors: map[string]string{"products[0].quantity": "must be an integer"},
+		},
+		{
+			name:       "trailing data",
+			body:       `{"name":"Laptop"} {"name":"Phone"}`,
+			req:        &dto.ProductRequest{},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "slice sizes",
+			body:       `{"name":"Size","values":[]}`,
+			req:        &dto.ProductOptionRequest{},
+			wantStatus: http.StatusUnprocessableEntity,
+			wantErrors: map[string]string{"values": "must have at least 1 item"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+
+			ok := decodeAndValidate(w, r, tt.req)
+
+			if ok != tt.wantOK {
+				t.Fatalf("decodeAndValidate() = %v, want %v (body %s)", ok, tt.wantOK, w.Body.String())
+			}
+			if ok {
+				return
+			}
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantErrors == nil {
+				return
+			}
+
+			var response dto.ValidationErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !reflect.DeepEqual(response.Errors, tt.wantErrors) {
+				t.Errorf("errors = %v, want %v", response.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestDecodeAndValidate_BodyLimit(t *testing.T) {
+	decode := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req dto.EmailTemplateRequest
+		if decodeAndValidate(w, r, &req) {
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+	body := `{"subject":"Welcome","body":"` + strings.Repeat("x", 2000) + `"}`
+
+	tests := []struct {
+		name          string
+		handler       http.Handler
+		contentLength bool
+		want          int
+	}{
+		{"within the limit", middleware.LimitBody(4096)(decode), true, http.StatusNoContent},
+		{"announced length over the limit", middleware.LimitBody(1024)(decode), true, http.StatusRequestEntityTooLarge},
+		{"streamed body over the limit", middleware.LimitBody(1024)(decode), false, http.StatusRequestEntityTooLarge},
+		{"route limit replaces the default", middleware.LimitBody(1024)(middleware.LimitBody(4096)(decode)), true, http.StatusNoContent},
+		{"route limit also lowers the default", middleware.LimitBody(4096)(middleware.LimitBody(1024)(decode)), true, http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPut, "/", strings.NewReader(body))
+			if !tt.contentLength {
+				r.ContentLength = -1
+			}
+			w := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Errorf("status = %d, want %d (body %s)", w.Code, tt.want, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestAcceptedLocales(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"", []string{}},
+		{"pt-br", []string{"pt-BR"}},
+		{"en;q=0.5, pt-BR, fr;q=0.8", []string{"pt-BR", "fr", "en"}},
+		{"de;q=0, *, es_MX;q=0.9, x;q=1", []string{"es-MX"}},
+		{"it;q=abc, nl", []string{"nl"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/products", nil)
+			req.Header.Set("Accept-Language", tt.header)
+			if got := acceptedLocales(req); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("acceptedLocales(%q) = %v, want %v", tt.header, got, tt.want)
+			}
+		})
+	}
+}
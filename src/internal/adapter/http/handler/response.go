@@ -1,18 +1,139 @@
 package handler
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+// maxPooledBuffer keeps the buffer of an unusually large response, e.g. a
+// full catalog export, from being held by the pool afterwards
+const maxPooledBuffer = 1 << 20
+
+// responseEncoder is a JSON encoder bound to the buffer it writes into. Both
+// keep their grown capacity between responses.
+type responseEncoder struct {
+	buf *bytes.Buffer
+	enc *json.Encoder
+}
+
+// encoderPool recycles response encoders. Product and order listings dominate
+// traffic, and a fresh encoder grows its buffers again for every response.
+var encoderPool = sync.Pool{
+	New: func() interface{} {
+		buf := new(bytes.Buffer)
+		return &responseEncoder{buf: buf, enc: json.NewEncoder(buf)}
+	},
+}
+
+// respondJSON writes a successful response. Payloads are wrapped in the
+// dto.Response envelope unless they already are one, e.g. a paginated list.
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-	json.NewEncoder(w).Encode(data)
+	if _, ok := data.(dto.Enveloped); !ok {
+		data = dto.Response[interface{}]{Data: data}
+	}
+	writeJSON(w, status, data)
+}
+
+// respondPage writes a page of a list, linking the first, previous, next and
+// last pages in the Link header (RFC 8288, formerly RFC 5988), after any link
+// the API version middleware set
+func respondPage(w http.ResponseWriter, r *http.Request, page dto.Paginated) {
+	if links := pageLinks(r, page.PageInfo()); links != "" {
+		w.Header().Add("Link", links)
+	}
+	respondJSON(w, http.StatusOK, page)
+}
+
+// pageLinks returns the Link header value for the page. The links keep the
+// path and query the client called, changing only page and page_size.
+func pageLinks(r *http.Request, p *dto.Pagination) string {
+	if p == nil {
+		return ""
+	}
+
+	link := func(page int, rel string) string {
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page))
+		query.Set("page_size", strconv.Itoa(p.PageSize))
+		target := url.URL{Path: middleware.RequestPath(r), RawQuery: query.Encode()}
+		return fmt.Sprintf(`<%s>; rel="%s"`, target.String(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if p.HasPrev {
+		links = append(links, link(min(p.Page-1, max(p.TotalPages, 1)), "prev"))
+	}
+	if p.HasNext {
+		links = append(links, link(p.Page+1, "next"))
+	}
+	links = append(links, link(max(p.TotalPages, 1), "last"))
+	return strings.Join(links, ", ")
 }
 
 func respondError(w http.ResponseWriter, status int, message string) {
-	respondJSON(w, status, dto.ErrorResponse{Error: message})
+	writeJSON(w, status, dto.ErrorResponse{Error: message})
+}
+
+// respondDomainError writes an error returned by a use case with the status
+// of its kind. Requests the handler itself rejects, e.g. a malformed ID or
+// body, are answered with respondError and 400 instead.
+func respondDomainError(w http.ResponseWriter, err error) {
+	respondError(w, errorStatus(err), err.Error())
+}
+
+// errorStatus maps the kind of a domain error to its HTTP status. Errors of
+// no known kind are unexpected failures. Running out of the request's time is
+// a 503, whether the repository reported it as ErrTimeout or not.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, entity.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, entity.ErrConflict), errors.Is(err, entity.ErrInsufficientStock):
+		return http.StatusConflict
+	case errors.Is(err, entity.ErrValidation):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, entity.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, entity.ErrTimeout), errors.Is(err, context.DeadlineExceeded):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeJSON encodes the payload fully before writing anything, so a payload
+// that fails to encode becomes a 500 instead of a truncated body, and the
+// response carries its Content-Length.
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	e := encoderPool.Get().(*responseEncoder)
+	buf := e.buf
+	buf.Reset()
+	defer func() {
+		if buf.Cap() <= maxPooledBuffer {
+			encoderPool.Put(e)
+		}
+	}()
+
+	if err := e.enc.Encode(data); err != nil {
+		buf.Reset()
+		status = http.StatusInternalServerError
+		e.enc.Encode(dto.ErrorResponse{Error: "Failed to encode response"})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
 }
@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+func TestRespondJSON_SetsContentLength(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondJSON(w, http.StatusCreated, map[string]string{"name": "Laptop"})
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusCreated)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", got)
+	}
+	if got, want := w.Header().Get("Content-Length"), fmt.Sprint(w.Body.Len()); got != want {
+		t.Errorf("Content-Length = %q, want %q", got, want)
+	}
+	if got := w.Body.String(); got != `{"data":{"name":"Laptop"}}`+"\n" {
+		t.Errorf("body = %q", got)
+	}
+}
+
+func TestRespondJSON_EncodeFailure(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	respondJSON(w, http.StatusOK, map[string]interface{}{"bad": make(chan int)})
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	var response dto.ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil || response.Error == "" {
+		t.Errorf("body = %q, want an error response", w.Body.String())
+	}
+}
+
+func TestRespondPage_LinkHeader(t *testing.T) {
+	tests := []struct {
+		name  string
+		url   string
+		page  int
+		total int
+		want  string
+	}{
+		{
+			name: "middle page", url: "/api/orders?status=pending&page=2&page_size=10", page: 2, total: 35,
+			want: `</api/orders?page=1&page_size=10&status=pending>; rel="first", ` +
+				`</api/orders?page=1&page_size=10&status=pending>; rel="prev", ` +
+				`</api/orders?page=3&page_size=10&status=pending>; rel="next", ` +
+				`</api/orders?page=4&page_size=10&status=pending>; rel="last"`,
+		},
+		{
+			name: "only page", url: "/api/orders", page: 1, total: 3,
+			want: `</api/orders?page=1&page_size=10>; rel="first", </api/orders?page=1&page_size=10>; rel="last"`,
+		},
+		{
+			name: "past the last page", url: "/api/orders?page=9", page: 9, total: 15,
+			want: `</api/orders?page=1&page_size=10>; rel="first", ` +
+				`</api/orders?page=2&page_size=10>; rel="prev", ` +
+				`</api/orders?page=2&page_size=10>; rel="last"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+
+			respondPage(w, r, dto.ToOrderListResponse(nil, tt.total, tt.page, 10))
+
+			if w.Code != http.StatusOK {
+				t.Errorf("status = %d, want %d", w.Code, http.StatusOK)
+			}
+			if got := w.Header().Get("Link"); got != tt.want {
+				t.Errorf("Link = %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRespondPage_KeepsAPIVersion(t *testing.T) {
+	versions := middleware.NewAPIVersionMiddleware([]string{"v1", "v2"}, config.APIVersionConfig{Default: "v1", Deprecated: []string{"v1"}})
+	handler := versions.Negotiate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		respondPage(w, r, dto.ToOrderListResponse(nil, 15, 1, 10))
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil))
+
+	want := []string{
+		`</api/v2/orders>; rel="successor-version"`,
+		`</api/v1/orders?page=1&page_size=10>; rel="first", ` +
+			`</api/v1/orders?page=2&page_size=10>; rel="next", ` +
+			`</api/v1/orders?page=2&page_size=10>; rel="last"`,
+	}
+	if got := w.Header().Values("Link"); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Link = %q\nwant %q", got, want)
+	}
+}
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", entity.NotFoundError("Order not found"), http.StatusNotFound},
+		{"conflict", entity.ConflictError("Invalid status transition"), http.StatusConflict},
+		{"insufficient stock", entity.ErrInsufficientStock, http.StatusConflict},
+		{"validation", entity.ValidationError("Name is required"), http.StatusUnprocessableEntity},
+		{"forbidden", entity.ForbiddenError("Remediation budget exceeded"), http.StatusForbidden},
+		{"timeout", entity.TimeoutError("The request took too long, try again", context.DeadlineExceeded), http.StatusServiceUnavailable},
+		{"wrapped", fmt.Errorf("%w: 10.00 remaining", entity.ConflictError("Refunds cannot exceed the order total")), http.StatusConflict},
+		{"unknown", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errorStatus(tt.err); got != tt.want {
+				t.Errorf("errorStatus() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// benchmarkProducts builds a page of products shaped like a typical catalog
+// listing: a couple of categories and a few variants each.
+func benchmarkProducts(n int) []*entity.Product {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	products := make([]*entity.Product, 0, n)
+	for i := 0; i < n; i++ {
+		productID := uuid.New()
+		product := &entity.Product{
+			ID:          productID,
+			Name:        fmt.Sprintf("Product %d", i),
+			Description: "A reasonably long product description used to size the benchmark payload",
+			Price:       99.90,
+			Quantity:    10,
+			Categories: []entity.Category{
+				{ID: uuid.New(), Name: "Electronics", CreatedAt: now, UpdatedAt: now},
+				{ID: uuid.New(), Name: "Accessories", CreatedAt: now, UpdatedAt: now},
+			},
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		for v := 0; v < 3; v++ {
+			product.Variants = append(product.Variants, entity.ProductVariant{
+				ID:        uuid.New(),
+				ProductID: productID,
+				SKU:       fmt.Sprintf("SKU-%d-%d", i, v),
+				Quantity:  5,
+				CreatedAt: now,
+				UpdatedAt: now,
+			})
+		}
+		products = append(products, product)
+	}
+	return products
+}
+
+func benchmarkOrders(n int) []*entity.Order {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	orders := make([]*entity.Order, 0, n)
+	for i := 0; i < n; i++ {
+		order := &entity.Order{
+			ID:            uuid.New(),
+			CustomerID:    i,
+			TotalPrice:    299.70,
+			Status:        entity.Pending,
+			PaymentStatus: entity.Unpaid,
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		}
+		for item := 0; item < 3; item++ {
+			order.Products = append(order.Products, entity.OrderItem{
+				ID:        uuid.New(),
+				ProductID: uuid.New(),
+				Quantity:  1,
+				Price:     99.90,
+			})
+		}
+		orders = append(orders, order)
+	}
+	return orders
+}
+
+// discardWriter stands in for the server's response writer, which streams
+// the body out instead of growing a buffer like httptest.ResponseRecorder
+type discardWriter struct {
+	header http.Header
+}
+
+func (w *discardWriter) Header() http.Header         { return w.header }
+func (w *discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w *discardWriter) WriteHeader(int)             {}
+
+func BenchmarkListProductsResponse(b *testing.B) {
+	products := benchmarkProducts(50)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := &discardWriter{header: http.Header{}}
+		respondJSON(w, http.StatusOK, dto.ToProductListResponse(products, 500, 1, 50))
+	}
+}
+
+func BenchmarkListProductSummariesResponse(b *testing.B) {
+	products := benchmarkProducts(50)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := &discardWriter{header: http.Header{}}
+		respondJSON(w, http.StatusOK, dto.ToProductSummaryListResponse(products, 500, 1, 50))
+	}
+}
+
+func BenchmarkListOrdersResponse(b *testing.B) {
+	orders := benchmarkOrders(50)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		w := &discardWriter{header: http.Header{}}
+		respondJSON(w, http.StatusOK, dto.ToOrderListResponse(orders, 500, 1, 50))
+	}
+}
@@ -0,0 +1,352 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/returns"
+)
+
+type ReturnHandler struct {
+	returnService returns.ReturnService
+}
+
+func NewReturnHandler(returnService returns.ReturnService) *ReturnHandler {
+	return &ReturnHandler{
+		returnService: returnService,
+	}
+}
+
+// RequestReturn godoc
+// @Summary Request a return
+// @Description Ask to send items of one of your completed, paid orders back. Each item is refunded what was paid for it once an admin approved the return and the items were received. Items can't be returned more times than they were ordered.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Order ID"
+// @Param return body dto.ReturnRequest true "Reason and items"
+// @Success 201 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:request permission"
+// @Failure 404 {object} dto.ErrorResponse "Order or order item not found"
+// @Failure 409 {object} dto.ErrorResponse "Order not completed and paid"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /orders/{id}/returns [post]
+func (h *ReturnHandler) RequestReturn(w http.ResponseWriter, r *http.Request) {
+	orderID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid order ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.ReturnRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	input := returns.Request{
+		Reason: entity.ReturnReason(req.ReasonCode),
+		Note:   req.Note,
+		Items:  make([]returns.ItemRequest, 0, len(req.Items)),
+	}
+	for _, item := range req.Items {
+		itemID, err := uuid.Parse(item.OrderItemID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid order item ID")
+			return
+		}
+		input.Items = append(input.Items, returns.ItemRequest{OrderItemID: itemID, Quantity: item.Quantity})
+	}
+
+	ret, err := h.returnService.RequestReturn(r.Context(), claims.UserID, orderID, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToReturnResponse(ret))
+}
+
+// ListMyReturns godoc
+// @Summary List your returns
+// @Description Paginated returns of your orders, newest first
+// @Tags returns
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (requested, approved, rejected, received, refunded)"
+// @Success 200 {object} dto.ReturnListResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/returns [get]
+func (h *ReturnHandler) ListMyReturns(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	filters := repository.ReturnFilters{UserID: &claims.UserID}
+	if status := r.URL.Query().Get("status"); status != "" {
+		returnStatus := entity.ReturnStatus(status)
+		filters.Status = &returnStatus
+	}
+
+	h.list(w, r, filters)
+}
+
+// GetMyReturn godoc
+// @Summary Get one of your returns
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return ID"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /users/me/returns/{id} [get]
+func (h *ReturnHandler) GetMyReturn(w http.ResponseWriter, r *http.Request) {
+	returnID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid return ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	ret, err := h.returnService.GetCustomerReturn(r.Context(), claims.UserID, returnID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToReturnResponse(ret))
+}
+
+// ListReturns godoc
+// @Summary List returns
+// @Description Paginated returns of every customer, newest first. Requires admin privileges.
+// @Tags returns
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (requested, approved, rejected, received, refunded)"
+// @Param order_id query string false "Only returns of this order"
+// @Success 200 {object} dto.ReturnListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/returns [get]
+func (h *ReturnHandler) ListReturns(w http.ResponseWriter, r *http.Request) {
+	var filters repository.ReturnFilters
+	if status := r.URL.Query().Get("status"); status != "" {
+		returnStatus := entity.ReturnStatus(status)
+		filters.Status = &returnStatus
+	}
+	if orderIDStr := r.URL.Query().Get("order_id"); orderIDStr != "" {
+		orderID, err := uuid.Parse(orderIDStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid order ID")
+			return
+		}
+		filters.OrderID = &orderID
+	}
+
+	h.list(w, r, filters)
+}
+
+func (h *ReturnHandler) list(w http.ResponseWriter, r *http.Request, filters repository.ReturnFilters) {
+	page, pageSize := parsePagination(r)
+
+	list, total, err := h.returnService.ListReturns(r.Context(), filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToReturnListResponse(list, total, page, pageSize))
+}
+
+// GetReturn godoc
+// @Summary Get a return
+// @Description Requires admin privileges.
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return ID"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/returns/{id} [get]
+func (h *ReturnHandler) GetReturn(w http.ResponseWriter, r *http.Request) {
+	returnID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid return ID")
+		return
+	}
+
+	ret, err := h.returnService.GetReturn(r.Context(), returnID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToReturnResponse(ret))
+}
+
+// ApproveReturn godoc
+// @Summary Approve a return
+// @Description Approve a requested return so the customer can send the items back. Requires admin privileges.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return ID"
+// @Param review body dto.ReturnReviewRequest false "Note to the customer"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Return already reviewed"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/returns/{id}/approve [post]
+func (h *ReturnHandler) ApproveReturn(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.returnService.ApproveReturn)
+}
+
+// RejectReturn godoc
+// @Summary Reject a return
+// @Description Reject a requested return. The items can be requested for return again. Requires admin privileges.
+// @Tags returns
+// @Accept json
+// @Produce json
+// @Param id path string true "Return ID"
+// @Param review body dto.ReturnReviewRequest false "Why the return was rejected"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Return already reviewed"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/returns/{id}/reject [post]
+func (h *ReturnHandler) RejectReturn(w http.ResponseWriter, r *http.Request) {
+	h.review(w, r, h.returnService.RejectReturn)
+}
+
+type reviewFunc func(ctx context.Context, returnID, reviewerID uuid.UUID, note string) (*entity.Return, error)
+
+func (h *ReturnHandler) review(w http.ResponseWriter, r *http.Request, review reviewFunc) {
+	returnID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid return ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.ReturnReviewRequest
+	if r.ContentLength != 0 && !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	ret, err := review(r.Context(), returnID, claims.UserID, req.Note)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToReturnResponse(ret))
+}
+
+// ReceiveReturn godoc
+// @Summary Receive a return
+// @Description Record that the items of an approved return arrived. They go back in stock and the refund is issued through the payment provider, never more than is left of the order after remediations and other returns. When the provider fails the return stays received with refund_error set; retry with the refund endpoint. Requires admin privileges.
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return ID"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Return not approved"
+// @Security BearerAuth
+// @Router /admin/returns/{id}/receive [post]
+func (h *ReturnHandler) ReceiveReturn(w http.ResponseWriter, r *http.Request) {
+	returnID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid return ID")
+		return
+	}
+
+	ret, err := h.returnService.ReceiveReturn(r.Context(), returnID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToReturnResponse(ret))
+}
+
+// RefundReturn godoc
+// @Summary Retry the refund of a return
+// @Description Retry the refund of a received return whose refund failed. Requires admin privileges.
+// @Tags returns
+// @Produce json
+// @Param id path string true "Return ID"
+// @Success 200 {object} dto.ReturnResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - requires return:manage permission"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Return not received or already refunded"
+// @Security BearerAuth
+// @Router /admin/returns/{id}/refund [post]
+func (h *ReturnHandler) RefundReturn(w http.ResponseWriter, r *http.Request) {
+	returnID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid return ID")
+		return
+	}
+
+	ret, err := h.returnService.RefundReturn(r.Context(), returnID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToReturnResponse(ret))
+}
@@ -0,0 +1,127 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/review"
+)
+
+type ReviewHandler struct {
+	reviewService review.ReviewService
+}
+
+func NewReviewHandler(reviewService review.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		reviewService: reviewService,
+	}
+}
+
+// ListReviews godoc
+// @Summary List the reviews of a product
+// @Description Paginated reviews of a published product, newest first. The average and count of the ratings are on the product as rating_average and rating_count.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} dto.ProductReviewListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /products/{id}/reviews [get]
+func (h *ReviewHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+
+	reviews, total, err := h.reviewService.ListReviews(r.Context(), productID, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToProductReviewListResponse(reviews, total, page, pageSize))
+}
+
+// ReviewProduct godoc
+// @Summary Review a product
+// @Description Rate a product from 1 to 5 stars, with an optional text, as the authenticated user, replacing the review they left before. Only customers with a delivered order of the product can review it. The rating of the product is updated with the review.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param review body dto.ProductReviewRequest true "Rating and text"
+// @Success 200 {object} dto.ProductReviewResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse "No delivered order of the product"
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/reviews/mine [put]
+func (h *ReviewHandler) ReviewProduct(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	var req dto.ProductReviewRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	saved, err := h.reviewService.ReviewProduct(r.Context(), productID, claims.UserID, req.Rating, req.Body)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToProductReviewResponse(saved))
+}
+
+// DeleteMyReview godoc
+// @Summary Delete my review of a product
+// @Description Remove the review the authenticated user left on a product, taking it out of the rating of the product.
+// @Tags products
+// @Param id path string true "Product ID"
+// @Success 204
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/reviews/mine [delete]
+func (h *ReviewHandler) DeleteMyReview(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	if err := h.reviewService.DeleteReview(r.Context(), productID, claims.UserID); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
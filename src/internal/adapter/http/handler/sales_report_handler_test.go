@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/salesreport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSalesReportService struct {
+	batches  [][]entity.SalesLine
+	err      error
+	from, to time.Time
+}
+
+func (m *mockSalesReportService) ExportSales(ctx context.Context, from, to time.Time, fn func(lines []entity.SalesLine) error) error {
+	m.from, m.to = from, to
+	for _, batch := range m.batches {
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	return m.err
+}
+
+func TestSalesReportHandler_ExportSales(t *testing.T) {
+	line := entity.SalesLine{
+		OrderID: uuid.New(), ItemID: uuid.New(), ProductID: uuid.New(), CustomerID: 3,
+		Status: entity.Completed, PaymentStatus: entity.Paid,
+		Quantity: 2, UnitPrice: 50, Base: 100, Discount: -10, Tax: 7.2, Total: 97.2,
+	}
+
+	t.Run("Streams a CSV with a header row", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{batches: [][]entity.SalesLine{{line}, {line}}})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31&format=csv", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="sales-2024-05-01-to-2024-05-31.csv"`, w.Header().Get("Content-Disposition"))
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, dto.SalesReportCSVHeader, records[0])
+		assert.Equal(t, []string{"100.00", "-10.00", "7.20", "0.00", "97.20"}, records[1][10:])
+	})
+
+	t.Run("Days in the time zone asked for", func(t *testing.T) {
+		service := &mockSalesReportService{}
+		h := NewSalesReportHandler(service)
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31&tz=America/Sao_Paulo", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "America/Sao_Paulo", service.from.Location().String())
+		assert.Equal(t, time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC), service.from.UTC())
+	})
+
+	t.Run("Unknown time zone", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31&tz=Mars/Olympus", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("An empty range still gets the header row", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		records, err := csv.NewReader(w.Body).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 1)
+	})
+
+	t.Run("Errors before the first row are reported", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{err: salesreport.ErrDateRangeRequired})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
+	t.Run("Errors after the first row abort the response", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{batches: [][]entity.SalesLine{{line}}, err: errors.New("connection reset")})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31", nil)
+		w := httptest.NewRecorder()
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() { h.ExportSales(w, r) })
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		h := NewSalesReportHandler(&mockSalesReportService{})
+		r := httptest.NewRequest(http.MethodGet, "/api/admin/reports/sales?from=2024-05-01&to=2024-05-31&format=xlsx", nil)
+		w := httptest.NewRecorder()
+
+		h.ExportSales(w, r)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
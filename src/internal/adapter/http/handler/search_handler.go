@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/search"
+)
+
+type SearchHandler struct {
+	searchService search.SearchService
+}
+
+func NewSearchHandler(searchService search.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchProducts godoc
+// @Summary Search products
+// @Description Products whose name or description contains every word of the query, ranked by text relevance and the active ranking rules. Pinned products come first at their positions. Sorting by rating lists the best rated first instead, relevance breaking ties.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param sort query string false "relevance or rating" default(relevance)
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} dto.ProductListResponse
+// @Failure 422 {object} dto.ErrorResponse "Missing query or invalid sort"
+// @Router /search [get]
+func (h *SearchHandler) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
+
+	products, total, err := h.searchService.Search(r.Context(), r.URL.Query().Get("q"), r.URL.Query().Get("sort"), page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToProductListResponse(products, total, page, pageSize))
+}
+
+// ExplainSearch godoc
+// @Summary Explain search ranking
+// @Description Rank a query exactly like the public search and show how every result on the page scored: text match, each boost rule and pins (Admin only)
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Success 200 {object} dto.SearchExplanationResponse
+// @Failure 422 {object} dto.ErrorResponse "Missing query"
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/search/explain [get]
+func (h *SearchHandler) ExplainSearch(w http.ResponseWriter, r *http.Request) {
+	page, pageSize := parsePagination(r)
+
+	explanation, total, err := h.searchService.Explain(r.Context(), r.URL.Query().Get("q"), page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToSearchExplanationResponse(explanation, total, page, pageSize))
+}
+
+// ListRankingRules godoc
+// @Summary List search ranking rules
+// @Description Every boost and pin rule, active or not, oldest first (Admin only)
+// @Tags search
+// @Produce json
+// @Success 200 {array} dto.RankingRuleResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/search/rules [get]
+func (h *SearchHandler) ListRankingRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.searchService.ListRules(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	responses := make([]dto.RankingRuleResponse, 0, len(rules))
+	for _, rule := range rules {
+		responses = append(responses, dto.ToRankingRuleResponse(rule))
+	}
+
+	respondJSON(w, http.StatusOK, responses)
+}
+
+// CreateRankingRule godoc
+// @Summary Create a search ranking rule
+// @Description Boost in-stock products or products with a high margin by a weight, or pin a product at a position for a query (Admin only). Margins need the product cost, set with PUT /products/{id}/cost.
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param rule body dto.RankingRuleRequest true "Ranking rule"
+// @Success 201 {object} dto.RankingRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse "Pinned product not found"
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/search/rules [post]
+func (h *SearchHandler) CreateRankingRule(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	input, ok := decodeRankingRule(w, r)
+	if !ok {
+		return
+	}
+
+	rule, err := h.searchService.CreateRule(r.Context(), claims.UserID, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToRankingRuleResponse(rule))
+}
+
+// UpdateRankingRule godoc
+// @Summary Update a search ranking rule
+// @Description Replace a boost or pin rule, e.g. to change its weight or deactivate it (Admin only)
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param id path string true "Ranking rule ID"
+// @Param rule body dto.RankingRuleRequest true "Ranking rule"
+// @Success 200 {object} dto.RankingRuleResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/search/rules/{id} [put]
+func (h *SearchHandler) UpdateRankingRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ranking rule ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	input, ok := decodeRankingRule(w, r)
+	if !ok {
+		return
+	}
+
+	rule, err := h.searchService.UpdateRule(r.Context(), claims.UserID, id, input)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToRankingRuleResponse(rule))
+}
+
+// DeleteRankingRule godoc
+// @Summary Delete a search ranking rule
+// @Description Remove a boost or pin rule (Admin only)
+// @Tags search
+// @Param id path string true "Ranking rule ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/search/rules/{id} [delete]
+func (h *SearchHandler) DeleteRankingRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid ranking rule ID")
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	if err := h.searchService.DeleteRule(r.Context(), claims.UserID, id); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeRankingRule decodes and validates a ranking rule request, writing the
+// error response itself when the handler cannot go on
+func decodeRankingRule(w http.ResponseWriter, r *http.Request) (search.RuleInput, bool) {
+	var req dto.RankingRuleRequest
+	if !decodeAndValidate(w, r, &req) {
+		return search.RuleInput{}, false
+	}
+
+	input := search.RuleInput{
+		Type:     entity.RankingRuleType(req.Type),
+		Weight:   req.Weight,
+		Query:    req.Query,
+		Position: req.Position,
+		Active:   req.Active == nil || *req.Active,
+	}
+	if req.ProductID != nil {
+		productID, err := uuid.Parse(*req.ProductID)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid product ID")
+			return input, false
+		}
+		input.ProductID = &productID
+	}
+	return input, true
+}
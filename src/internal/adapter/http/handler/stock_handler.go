@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+)
+
+type StockHandler struct {
+	stockService stock.StockService
+}
+
+func NewStockHandler(stockService stock.StockService) *StockHandler {
+	return &StockHandler{
+		stockService: stockService,
+	}
+}
+
+// ListMovements godoc
+// @Summary List stock movements of a product
+// @Description Paginated stock ledger of a product and its variants, newest first. Every entry records the reason and the quantities before and after the change.
+// @Tags products
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param variant_id query string false "Only movements of this variant"
+// @Param reason query string false "Filter by reason (order, cancellation, adjustment, import, resend, transfer, return)"
+// @Success 200 {object} dto.StockMovementListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /products/{id}/stock-movements [get]
+func (h *StockHandler) ListMovements(w http.ResponseWriter, r *http.Request) {
+	productID, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid product ID")
+		return
+	}
+
+	page, pageSize := parsePagination(r)
+
+	var filters repository.StockMovementFilters
+	if variantIDStr := r.URL.Query().Get("variant_id"); variantIDStr != "" {
+		variantID, err := uuid.Parse(variantIDStr)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid variant ID")
+			return
+		}
+		filters.VariantID = &variantID
+	}
+	if reasonStr := r.URL.Query().Get("reason"); reasonStr != "" {
+		reason := entity.StockMovementReason(reasonStr)
+		filters.Reason = &reason
+	}
+
+	movements, total, err := h.stockService.ListMovements(r.Context(), productID, filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToStockMovementListResponse(movements, total, page, pageSize))
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/usecase/storesettings"
+)
+
+type StoreSettingsHandler struct {
+	settingsService storesettings.StoreSettingsService
+}
+
+func NewStoreSettingsHandler(settingsService storesettings.StoreSettingsService) *StoreSettingsHandler {
+	return &StoreSettingsHandler{
+		settingsService: settingsService,
+	}
+}
+
+// GetSettings godoc
+// @Summary Get the store settings
+// @Description Get the store name, contact email, currency, logo and checkout options front-ends render the storefront with
+// @Tags settings
+// @Produce json
+// @Success 200 {object} dto.StoreSettingsResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Router /settings [get]
+func (h *StoreSettingsHandler) GetSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.settingsService.GetSettings(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToStoreSettingsResponse(settings))
+}
+
+// ListSettings godoc
+// @Summary List the store settings
+// @Description Get every store setting with its type, value and default (Admin only)
+// @Tags settings
+// @Produce json
+// @Success 200 {array} dto.StoreSettingResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/settings [get]
+func (h *StoreSettingsHandler) ListSettings(w http.ResponseWriter, r *http.Request) {
+	settings, err := h.settingsService.ListSettings(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toStoreSettingResponses(settings))
+}
+
+// UpdateSettings godoc
+// @Summary Update the store settings
+// @Description Set store settings by key, e.g. {"settings": {"store.currency": "EUR", "checkout.require_terms": true}}. A null value resets a setting to its default; keys left out are unchanged. Nothing is saved when any value is invalid (Admin only).
+// @Tags settings
+// @Accept json
+// @Produce json
+// @Param settings body dto.UpdateStoreSettingsRequest true "Settings to change"
+// @Success 200 {array} dto.StoreSettingResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse "Unknown setting or invalid value"
+// @Security BearerAuth
+// @Router /admin/settings [put]
+func (h *StoreSettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateStoreSettingsRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	values := make(map[string]*string, len(req.Settings))
+	for key, raw := range req.Settings {
+		var value string
+		switch typed := raw.(type) {
+		case nil:
+			values[key] = nil
+			continue
+		case string:
+			value = typed
+		case bool:
+			value = strconv.FormatBool(typed)
+		default:
+			respondFieldErrors(w, http.StatusBadRequest, "Invalid request body", map[string]string{
+				"settings." + key: "must be a string, a boolean or null",
+			})
+			return
+		}
+		values[key] = &value
+	}
+
+	settings, err := h.settingsService.UpdateSettings(r.Context(), values, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, toStoreSettingResponses(settings))
+}
+
+func toStoreSettingResponses(settings []*storesettings.Setting) []dto.StoreSettingResponse {
+	responses := make([]dto.StoreSettingResponse, 0, len(settings))
+	for _, setting := range settings {
+		var updatedAt *string
+		if setting.UpdatedAt != nil {
+			formatted := dto.FormatTime(*setting.UpdatedAt)
+			updatedAt = &formatted
+		}
+		var updatedBy *string
+		if setting.UpdatedBy != nil {
+			formatted := setting.UpdatedBy.String()
+			updatedBy = &formatted
+		}
+
+		responses = append(responses, dto.StoreSettingResponse{
+			Key:         setting.Key,
+			Type:        string(setting.Type),
+			Value:       setting.Value,
+			Default:     setting.Default,
+			Set:         setting.Set,
+			Description: setting.Description,
+			UpdatedBy:   updatedBy,
+			UpdatedAt:   updatedAt,
+		})
+	}
+	return responses
+}
@@ -2,8 +2,11 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"io"
 
 	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
 )
 
 // mockAuditService is a mock implementation of audit.AuditService for testing
@@ -12,3 +15,12 @@ type mockAuditService struct{}
 func (m *mockAuditService) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
 	return nil
 }
+
+// decodeData decodes the payload of a dto.Response envelope into v
+func decodeData(body io.Reader, v interface{}) error {
+	var envelope dto.Response[json.RawMessage]
+	if err := json.NewDecoder(body).Decode(&envelope); err != nil {
+		return err
+	}
+	return json.Unmarshal(envelope.Data, v)
+}
@@ -0,0 +1,264 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/webhook"
+)
+
+type WebhookHandler struct {
+	webhookService webhook.WebhookService
+}
+
+func NewWebhookHandler(webhookService webhook.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// CreateWebhook godoc
+// @Summary Subscribe to store events
+// @Description Register a URL that receives the order, product and stock events it subscribes to as signed JSON POST requests (Admin only). Without a secret one is generated; the secret is only returned here. Event types: order.created, order.status_changed, order.paid, product.created, product.updated, product.archived, stock.changed.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param subscription body dto.WebhookSubscriptionRequest true "Subscriber URL and events"
+// @Success 201 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks [post]
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	claims, err := middleware.GetUserFromContext(r)
+	if err != nil {
+		respondError(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+
+	var req dto.WebhookSubscriptionRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	created, err := h.webhookService.CreateSubscription(r.Context(), &entity.WebhookSubscription{
+		URL:         req.URL,
+		Events:      req.Events,
+		Secret:      req.Secret,
+		Description: req.Description,
+	}, claims.UserID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dto.ToWebhookSubscriptionResponse(created, true))
+}
+
+// ListWebhooks godoc
+// @Summary List webhook subscriptions
+// @Description Every webhook subscription, oldest first, without their secrets (Admin only)
+// @Tags webhooks
+// @Produce json
+// @Success 200 {array} dto.WebhookSubscriptionResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 500 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks [get]
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookSubscriptionResponses(subscriptions))
+}
+
+// GetWebhook godoc
+// @Summary Get a webhook subscription
+// @Description Get a webhook subscription without its secret (Admin only)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks/{id} [get]
+func (h *WebhookHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(r.Context(), id)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookSubscriptionResponse(subscription, false))
+}
+
+// UpdateWebhook godoc
+// @Summary Update a webhook subscription
+// @Description Change the URL, events, secret or description of a subscription, or pause it with active=false (Admin only). Only the fields sent change. Deliveries already queued are still sent.
+// @Tags webhooks
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param subscription body dto.WebhookSubscriptionUpdateRequest true "Fields to change"
+// @Success 200 {object} dto.WebhookSubscriptionResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 413 {object} dto.ErrorResponse "Request body too large"
+// @Failure 422 {object} dto.ValidationErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks/{id} [put]
+func (h *WebhookHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var req dto.WebhookSubscriptionUpdateRequest
+	if !decodeAndValidate(w, r, &req) {
+		return
+	}
+
+	updated, err := h.webhookService.UpdateSubscription(r.Context(), id, webhook.Update{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      req.Events,
+		Description: req.Description,
+		Active:      req.Active,
+	})
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookSubscriptionResponse(updated, false))
+}
+
+// DeleteWebhook godoc
+// @Summary Delete a webhook subscription
+// @Description Delete a subscription with its delivery log; deliveries not sent yet are dropped (Admin only)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 204 "No Content"
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks/{id} [delete]
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(r.Context(), id); err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries godoc
+// @Summary List the deliveries of a webhook subscription
+// @Description Paginated delivery log of a subscription, newest first, with the payload sent and the outcome of the last attempt (Admin only). Failed attempts are retried with a backoff, up to 8 times.
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param page query int false "Page number" default(1)
+// @Param page_size query int false "Items per page" default(10)
+// @Param status query string false "Filter by status (pending, succeeded, failed)"
+// @Param event_type query string false "Filter by event type"
+// @Success 200 {object} dto.WebhookDeliveryListResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Security BearerAuth
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+
+	var filters repository.WebhookDeliveryFilters
+	if status := r.URL.Query().Get("status"); status != "" {
+		deliveryStatus := entity.WebhookDeliveryStatus(status)
+		filters.Status = &deliveryStatus
+	}
+	if eventType := r.URL.Query().Get("event_type"); eventType != "" {
+		filters.EventType = &eventType
+	}
+	page, pageSize := parsePagination(r)
+
+	deliveries, total, err := h.webhookService.ListDeliveries(r.Context(), id, filters, page, pageSize)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondPage(w, r, dto.ToWebhookDeliveryListResponse(deliveries, total, page, pageSize))
+}
+
+// RetryWebhookDelivery godoc
+// @Summary Retry a failed webhook delivery
+// @Description Send a delivery that ran out of attempts again, with a fresh set of attempts, on the next run of the delivery job (Admin only)
+// @Tags webhooks
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param delivery_id path string true "Delivery ID"
+// @Success 200 {object} dto.WebhookDeliveryResponse
+// @Failure 400 {object} dto.ErrorResponse
+// @Failure 401 {object} dto.ErrorResponse
+// @Failure 403 {object} dto.ErrorResponse
+// @Failure 404 {object} dto.ErrorResponse
+// @Failure 409 {object} dto.ErrorResponse "Delivery hasn't failed"
+// @Security BearerAuth
+// @Router /admin/webhooks/{id}/deliveries/{delivery_id}/retry [post]
+func (h *WebhookHandler) RetryWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(r.PathValue("id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid subscription ID")
+		return
+	}
+	deliveryID, err := uuid.Parse(r.PathValue("delivery_id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid delivery ID")
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(r.Context(), id, deliveryID)
+	if err != nil {
+		respondDomainError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dto.ToWebhookDeliveryResponse(delivery))
+}
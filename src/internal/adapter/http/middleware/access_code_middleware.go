@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+)
+
+// AccessCodeHeader carries the access code of visitors who aren't signed in
+const AccessCodeHeader = "X-Access-Code"
+
+// AccessCodeMiddleware keeps the catalog to signed-in users and visitors with
+// an access code while the store requires one to browse
+type AccessCodeMiddleware struct {
+	gate      accesscode.Gate
+	validator TokenValidator
+}
+
+func NewAccessCodeMiddleware(gate accesscode.Gate, validator TokenValidator) *AccessCodeMiddleware {
+	return &AccessCodeMiddleware{
+		gate:      gate,
+		validator: validator,
+	}
+}
+
+// Browse wraps the catalog routes. Signed-in users already got past the gate
+// when they registered, everyone else sends a code in X-Access-Code.
+func (m *AccessCodeMiddleware) Browse(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.gate.RequiredForBrowsing() || m.signedIn(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := m.gate.Check(r.Context(), r.Header.Get(AccessCodeHeader)); err != nil {
+			status := http.StatusInternalServerError
+			message := "Internal server error"
+			if errors.Is(err, entity.ErrForbidden) {
+				status, message = http.StatusForbidden, err.Error()
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			json.NewEncoder(w).Encode(map[string]string{"error": message})
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *AccessCodeMiddleware) signedIn(r *http.Request) bool {
+	parts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		return false
+	}
+	_, err := m.validator.ValidateToken(parts[1])
+	return err == nil
+}
@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+)
+
+// APIVersionHeader names the version a request asks for when its path names
+// none, and the version that served a response
+const APIVersionHeader = "API-Version"
+
+// apiVersionContextKey holds the API version serving the request and the path
+// the client called, before the version was taken out of it
+type apiVersionContextKey struct{}
+
+type apiVersion struct {
+	version string
+	path    string
+}
+
+// APIVersionMiddleware serves every version of the API from the one router.
+// Clients call /api/v2/products, or /api/products with an API-Version header,
+// and the routes see /api/products with the version in the request context,
+// so handlers only branch where a version changed the shape of a response.
+// Requests naming no version get the default one, the shapes clients used
+// before the API was versioned.
+type APIVersionMiddleware struct {
+	versions   []string
+	defaultVer string
+	deprecated map[string]bool
+	sunset     string
+}
+
+// NewAPIVersionMiddleware creates the middleware for the versions, oldest
+// first. Deprecated versions keep being served, with Deprecation headers
+// and, once a date is set, a Sunset header.
+func NewAPIVersionMiddleware(versions []string, cfg config.APIVersionConfig) *APIVersionMiddleware {
+	m := &APIVersionMiddleware{
+		versions:   versions,
+		defaultVer: cfg.Default,
+		deprecated: make(map[string]bool, len(cfg.Deprecated)),
+	}
+	for _, version := range cfg.Deprecated {
+		m.deprecated[version] = true
+	}
+	if sunset, err := time.Parse(time.DateOnly, cfg.SunsetDate); err == nil {
+		m.sunset = sunset.Format(http.TimeFormat)
+	}
+	return m
+}
+
+// Negotiate wraps the router and picks the version of each API request: the
+// one in its path, else the one in its API-Version header, else the default
+func (m *APIVersionMiddleware) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rest, ok := strings.CutPrefix(r.URL.Path, "/api/")
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		version := m.defaultVer
+		if named, path, ok := strings.Cut(rest, "/"); ok && isVersion(named) {
+			if !slices.Contains(m.versions, named) {
+				m.reject(w, http.StatusNotFound, named)
+				return
+			}
+			version, rest = named, path
+		} else if header := r.Header.Get(APIVersionHeader); header != "" {
+			version = strings.ToLower(header)
+			if !strings.HasPrefix(version, "v") {
+				version = "v" + version
+			}
+			if !slices.Contains(m.versions, version) {
+				m.reject(w, http.StatusBadRequest, header)
+				return
+			}
+		}
+
+		m.setHeaders(w, version, rest)
+
+		ctx := context.WithValue(r.Context(), apiVersionContextKey{}, apiVersion{version: version, path: r.URL.Path})
+		r = r.WithContext(ctx)
+		r.URL.Path = "/api/" + rest
+		r.URL.RawPath = ""
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *APIVersionMiddleware) setHeaders(w http.ResponseWriter, version, rest string) {
+	w.Header().Set(APIVersionHeader, version)
+	w.Header().Add("Vary", APIVersionHeader)
+	if !m.deprecated[version] {
+		return
+	}
+
+	w.Header().Set("Deprecation", "true")
+	if m.sunset != "" {
+		w.Header().Set("Sunset", m.sunset)
+	}
+	if latest := m.versions[len(m.versions)-1]; latest != version {
+		w.Header().Add("Link", fmt.Sprintf(`</api/%s/%s>; rel="successor-version"`, latest, rest))
+	}
+}
+
+func (m *APIVersionMiddleware) reject(w http.ResponseWriter, status int, version string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": fmt.Sprintf("Unknown API version %q, use one of %s", version, strings.Join(m.versions, ", ")),
+	})
+}
+
+// isVersion reports whether a path segment names a version, e.g. v2
+func isVersion(segment string) bool {
+	digits, ok := strings.CutPrefix(segment, "v")
+	if !ok || digits == "" {
+		return false
+	}
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// APIVersion returns the API version serving the request, empty outside the API
+func APIVersion(ctx context.Context) string {
+	v, _ := ctx.Value(apiVersionContextKey{}).(apiVersion)
+	return v.version
+}
+
+// RequestPath returns the path the client called, with the version the
+// router doesn't see, so links in responses point where the client started
+func RequestPath(r *http.Request) string {
+	if v, ok := r.Context().Value(apiVersionContextKey{}).(apiVersion); ok {
+		return v.path
+	}
+	return r.URL.Path
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+)
+
+func TestAPIVersionMiddleware_Negotiate(t *testing.T) {
+	m := NewAPIVersionMiddleware([]string{"v1", "v2"}, config.APIVersionConfig{
+		Default:    "v1",
+		Deprecated: []string{"v1"},
+		SunsetDate: "2027-06-30",
+	})
+
+	var routed, version, requestPath string
+	handler := m.Negotiate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		routed, version, requestPath = r.URL.Path, APIVersion(r.Context()), RequestPath(r)
+	}))
+
+	tests := []struct {
+		name        string
+		path        string
+		header      string
+		status      int
+		wantRouted  string
+		wantVersion string
+		deprecated  bool
+	}{
+		{name: "Version in the path", path: "/api/v2/products", status: http.StatusOK, wantRouted: "/api/products", wantVersion: "v2"},
+		{name: "Deprecated version", path: "/api/v1/products", status: http.StatusOK, wantRouted: "/api/products", wantVersion: "v1", deprecated: true},
+		{name: "No version", path: "/api/products", status: http.StatusOK, wantRouted: "/api/products", wantVersion: "v1", deprecated: true},
+		{name: "Version in the header", path: "/api/products", header: "2", status: http.StatusOK, wantRouted: "/api/products", wantVersion: "v2"},
+		{name: "The path wins over the header", path: "/api/v1/products", header: "v2", status: http.StatusOK, wantRouted: "/api/products", wantVersion: "v1", deprecated: true},
+		{name: "Unknown version in the path", path: "/api/v9/products", status: http.StatusNotFound},
+		{name: "Unknown version in the header", path: "/api/products", header: "v9", status: http.StatusBadRequest},
+		{name: "Outside the API", path: "/swagger/index.html", status: http.StatusOK, wantRouted: "/swagger/index.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			routed, version, requestPath = "", "", ""
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set(APIVersionHeader, tt.header)
+			}
+
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d", w.Code, tt.status)
+			}
+			if routed != tt.wantRouted || version != tt.wantVersion {
+				t.Errorf("routed %q as %q, want %q as %q", routed, version, tt.wantRouted, tt.wantVersion)
+			}
+			if tt.wantRouted != "" && requestPath != tt.path {
+				t.Errorf("RequestPath = %q, want %q", requestPath, tt.path)
+			}
+			if got := w.Header().Get(APIVersionHeader); got != tt.wantVersion {
+				t.Errorf("API-Version = %q, want %q", got, tt.wantVersion)
+			}
+
+			if !tt.deprecated {
+				if w.Header().Get("Deprecation") != "" {
+					t.Errorf("unexpected Deprecation header on %s", tt.wantVersion)
+				}
+				return
+			}
+			if got := w.Header().Get("Deprecation"); got != "true" {
+				t.Errorf("Deprecation = %q, want true", got)
+			}
+			if got := w.Header().Get("Sunset"); got != "Wed, 30 Jun 2027 00:00:00 GMT" {
+				t.Errorf("Sunset = %q", got)
+			}
+			if got := w.Header().Get("Link"); got != `</api/v2/products>; rel="successor-version"` {
+				t.Errorf("Link = %q", got)
+			}
+		})
+	}
+}
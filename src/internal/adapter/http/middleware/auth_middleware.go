@@ -2,6 +2,8 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"log"
 	"net/http"
 	"strings"
 
@@ -56,6 +58,17 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Reject tokens revoked before they expired
+		if err := m.authUseCase.CheckRevoked(r.Context(), claims); err != nil {
+			if errors.Is(err, authUseCase.ErrTokenRevoked) {
+				m.writeError(w, "Token has been revoked", http.StatusUnauthorized)
+				return
+			}
+			log.Printf("Failed to check token revocation: %v", err)
+			m.writeError(w, "Unable to verify token", http.StatusServiceUnavailable)
+			return
+		}
+
 		// Inject user data into context
 		ctx := context.WithValue(r.Context(), UserContextKey, claims)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -106,6 +119,25 @@ func (m *AuthMiddleware) RequirePermission(permission Permission) func(http.Hand
 	}
 }
 
+// RejectImpersonation refuses requests made with an impersonation token, for
+// actions only the user themselves may take
+func (m *AuthMiddleware) RejectImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := r.Context().Value(UserContextKey).(*auth.Claims)
+		if !ok {
+			m.writeError(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		if claims.Impersonated() {
+			m.writeError(w, "Not allowed while impersonating a user", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 // OptionalAuth validates token if present but doesn't require it
 func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -118,6 +150,9 @@ func (m *AuthMiddleware) OptionalAuth(next http.Handler) http.Handler {
 		parts := strings.Split(authHeader, " ")
 		if len(parts) == 2 && parts[0] == "Bearer" {
 			claims, err := m.authUseCase.ValidateToken(parts[1])
+			if err == nil {
+				err = m.authUseCase.CheckRevoked(r.Context(), claims)
+			}
 			if err == nil {
 				ctx := context.WithValue(r.Context(), UserContextKey, claims)
 				next.ServeHTTP(w, r.WithContext(ctx))
@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+)
+
+// limitedBody is a request body behind http.MaxBytesReader. It keeps the body
+// it wraps, so a route can replace the server-wide limit instead of only
+// lowering it.
+type limitedBody struct {
+	io.ReadCloser
+	original io.ReadCloser
+}
+
+// LimitBody caps the request body at maxBytes. Wrap the whole router with it
+// for a default and single routes for their own limit, which replaces the
+// default. Reading past the limit fails with *http.MaxBytesError, which the
+// handlers answer with 413. A body is never rejected by its Content-Length
+// alone, the route it is meant for may allow more than the default.
+func LimitBody(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body := r.Body
+			if limited, ok := body.(*limitedBody); ok {
+				body = limited.original
+			}
+			if body != nil && body != http.NoBody {
+				r.Body = &limitedBody{
+					ReadCloser: http.MaxBytesReader(w, body, maxBytes),
+					original:   body,
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
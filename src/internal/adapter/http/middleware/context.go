@@ -1,9 +1,11 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"net/http"
 
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
 )
 
@@ -15,3 +17,13 @@ func GetUserFromContext(r *http.Request) (*auth.Claims, error) {
 	}
 	return claims, nil
 }
+
+// ActorFromContext returns the authenticated user stored in ctx and the admin
+// impersonating them, for the audit log
+func ActorFromContext(ctx context.Context) audit.Actor {
+	claims, ok := ctx.Value(UserContextKey).(*auth.Claims)
+	if !ok {
+		return audit.Actor{}
+	}
+	return audit.Actor{UserID: &claims.UserID, ImpersonatorID: claims.ImpersonatorID}
+}
@@ -0,0 +1,107 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+)
+
+// exposedHeaders are the response headers browser clients may read besides
+// the CORS-safelisted ones
+var exposedHeaders = strings.Join([]string{
+	"API-Version",
+	"Deprecation",
+	"Sunset",
+	"ETag",
+	"Content-Disposition",
+	"Link",
+	"Retry-After",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}, ", ")
+
+// CORSMiddleware lets browser apps on the configured origins call the API.
+// Requests from other origins are served without CORS headers, so browsers
+// keep blocking them.
+type CORSMiddleware struct {
+	origins     map[string]bool
+	anyOrigin   bool
+	methods     string
+	headers     string
+	credentials bool
+	maxAge      string
+}
+
+// NewCORSMiddleware creates the CORS middleware. An empty origin list
+// disables CORS, "*" allows every origin but without credentials.
+func NewCORSMiddleware(cfg config.CORSConfig) *CORSMiddleware {
+	m := &CORSMiddleware{
+		origins:     make(map[string]bool, len(cfg.AllowedOrigins)),
+		methods:     strings.Join(cfg.AllowedMethods, ", "),
+		headers:     strings.Join(cfg.AllowedHeaders, ", "),
+		credentials: cfg.AllowCredentials,
+		maxAge:      strconv.Itoa(cfg.MaxAgeSeconds),
+	}
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			m.anyOrigin = true
+		}
+		m.origins[strings.TrimRight(origin, "/")] = true
+	}
+	return m
+}
+
+// Handle wraps the whole router. Preflight requests are answered here, the
+// router has no OPTIONS routes.
+func (m *CORSMiddleware) Handle(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Add("Vary", "Origin")
+		if !m.allows(origin) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		m.setAllowOrigin(w, origin)
+
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.Header().Add("Vary", "Access-Control-Request-Method")
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+			w.Header().Set("Access-Control-Allow-Methods", m.methods)
+			w.Header().Set("Access-Control-Allow-Headers", m.headers)
+			w.Header().Set("Access-Control-Max-Age", m.maxAge)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Access-Control-Expose-Headers", exposedHeaders)
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m *CORSMiddleware) allows(origin string) bool {
+	return m.anyOrigin || m.origins[origin]
+}
+
+// setAllowOrigin echoes a listed origin. With "*" any site may call the API,
+// so credentials are never allowed along with it: browsers reject them on a
+// wildcard, and echoing the origin instead would hand every site the
+// caller's session.
+func (m *CORSMiddleware) setAllowOrigin(w http.ResponseWriter, origin string) {
+	if m.anyOrigin {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	if m.credentials {
+		w.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
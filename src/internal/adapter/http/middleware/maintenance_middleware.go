@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/maintenance"
+)
+
+// MaintenanceMiddleware answers public traffic with 503 while maintenance
+// mode is on. Health checks, admin routes, logins and requests of staff
+// keep going through, so admins can still work and switch it off.
+type MaintenanceMiddleware struct {
+	maintenance maintenance.MaintenanceService
+	validator   TokenValidator
+}
+
+func NewMaintenanceMiddleware(maintenance maintenance.MaintenanceService, validator TokenValidator) *MaintenanceMiddleware {
+	return &MaintenanceMiddleware{
+		maintenance: maintenance,
+		validator:   validator,
+	}
+}
+
+// Block wraps the router, so it runs before route level authentication
+func (m *MaintenanceMiddleware) Block(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := m.maintenance.Status()
+		if !status.Enabled || m.allowed(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Retry-After", strconv.Itoa(status.RetryAfterSeconds()))
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		json.NewEncoder(w).Encode(map[string]string{"error": status.Message})
+	})
+}
+
+func (m *MaintenanceMiddleware) allowed(r *http.Request) bool {
+	switch {
+	case r.URL.Path == "/api/health":
+		return true
+	case strings.HasPrefix(r.URL.Path, "/api/admin/"):
+		return true
+	case r.Method == http.MethodPost && r.URL.Path == "/api/auth/login":
+		return true
+	}
+
+	// Impersonation tokens carry the customer's role, so they are blocked too
+	parts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		if claims, err := m.validator.ValidateToken(parts[1]); err == nil {
+			return claims.Role != entity.RoleCustomer
+		}
+	}
+	return false
+}
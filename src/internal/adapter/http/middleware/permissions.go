@@ -6,20 +6,82 @@ type Permission string
 
 const (
 	// Product permissions
-	PermissionCreateProduct Permission = "product:create"
-	PermissionUpdateProduct Permission = "product:update"
-	PermissionDeleteProduct Permission = "product:delete"
-	PermissionViewProduct   Permission = "product:view"
-	PermissionListProducts  Permission = "product:list"
+	PermissionCreateProduct  Permission = "product:create"
+	PermissionUpdateProduct  Permission = "product:update"
+	PermissionDeleteProduct  Permission = "product:delete"
+	PermissionViewProduct    Permission = "product:view"
+	PermissionListProducts   Permission = "product:list"
+	PermissionPublishProduct Permission = "product:publish" // Publish, unpublish and archive products
 
 	// Order permissions
 	PermissionCreateOrder       Permission = "order:create"
 	PermissionViewOrder         Permission = "order:view"
+	PermissionViewAnyOrder      Permission = "order:view_any" // Orders of every account in status lookups, not only the caller's
 	PermissionListOrders        Permission = "order:list"
 	PermissionUpdateOrderStatus Permission = "order:update_status"
+	PermissionCapturePayments   Permission = "order:capture"      // Capture authorized payments through the payment provider
+	PermissionRemediateOrders   Permission = "order:remediate"    // Refunds without return, goodwill credits and resends
+	PermissionManageDraftOrders Permission = "draft_order:manage" // Orders composed for a customer, e.g. phone sales
+
+	// Return permissions
+	PermissionRequestReturns Permission = "return:request"
+	PermissionManageReturns  Permission = "return:manage" // Review, receive and refund returns
 
 	// Webhook permissions
 	PermissionViewWebhookHistory Permission = "webhook:view_history"
+	PermissionManageWebhooks     Permission = "webhook:manage" // Subscriber URLs for store events and their delivery log
+	PermissionReplayWebhooks     Permission = "webhook:replay" // Replay failed payment webhooks or mark them resolved
+
+	// Invoice permissions
+	PermissionViewAnyInvoice Permission = "invoice:view_any"
+
+	// Customer permissions
+	PermissionManageCustomers     Permission = "customer:manage"
+	PermissionViewCustomerSummary Permission = "customer:view_summary" // Order count, lifetime spend and favorite categories of an account
+
+	// Account permissions
+	PermissionManageUsers       Permission = "user:manage"        // Deactivate accounts and revoke their tokens
+	PermissionImpersonateUsers  Permission = "user:impersonate"   // Act as a customer with a short-lived token, to reproduce their issues
+	PermissionManageBlocklist   Permission = "blocklist:manage"   // Emails, domains and IP ranges kept from registering, signing in and ordering
+	PermissionManageAccessCodes Permission = "access_code:manage" // Codes people register and browse with during a private beta
+
+	// Inventory permissions
+	PermissionViewStockMovements Permission = "stock:view_movements"
+	PermissionTransferStock      Permission = "stock:transfer"
+	PermissionSyncStock          Permission = "stock:sync" // Bulk stock updates by SKU, as an ERP sends them
+
+	// Pricing permissions
+	PermissionViewPriceHistory Permission = "price:view_history"
+	PermissionSchedulePrices   Permission = "price:schedule"
+	PermissionManagePriceLists Permission = "price:manage_lists" // Quantity breaks and customer group prices
+
+	// Admin monitoring permissions
+	PermissionViewAdminActivity Permission = "admin:view_activity"
+
+	// Email template permissions
+	PermissionManageEmailTemplates Permission = "email_template:manage"
+
+	// Store settings permissions
+	PermissionManageSettings Permission = "settings:manage" // Store name, contact email, currency, logo and checkout options
+
+	// Maintenance permissions
+	PermissionManageMaintenance Permission = "maintenance:manage"
+
+	// Catalog quality permissions
+	PermissionViewCatalogReport Permission = "catalog:view_report"
+
+	// Product safety permissions
+	PermissionManageRecalls Permission = "recall:manage"
+
+	// Search permissions
+	PermissionManageSearch Permission = "search:manage"
+
+	// Reporting permissions
+	PermissionViewAnalytics Permission = "analytics:view"
+	PermissionExportSales   Permission = "report:export_sales"
+
+	// Operations permissions
+	PermissionViewJobs Permission = "job:view"
 )
 
 var RolePermissions = map[entity.Role][]Permission{
@@ -30,11 +92,54 @@ var RolePermissions = map[entity.Role][]Permission{
 		PermissionDeleteProduct,
 		PermissionViewProduct,
 		PermissionListProducts,
+		PermissionPublishProduct,
 		PermissionCreateOrder,
 		PermissionViewOrder,
+		PermissionViewAnyOrder,
 		PermissionListOrders,
 		PermissionUpdateOrderStatus,
+		PermissionCapturePayments,
+		PermissionManageDraftOrders,
 		PermissionViewWebhookHistory,
+		PermissionManageWebhooks,
+		PermissionReplayWebhooks,
+		PermissionViewAnyInvoice,
+		PermissionManageCustomers,
+		PermissionViewCustomerSummary,
+		PermissionManageUsers,
+		PermissionImpersonateUsers,
+		PermissionManageBlocklist,
+		PermissionManageAccessCodes,
+		PermissionViewStockMovements,
+		PermissionTransferStock,
+		PermissionSyncStock,
+		PermissionViewPriceHistory,
+		PermissionSchedulePrices,
+		PermissionManagePriceLists,
+		PermissionViewAdminActivity,
+		PermissionRemediateOrders,
+		PermissionRequestReturns,
+		PermissionManageReturns,
+		PermissionManageEmailTemplates,
+		PermissionManageSettings,
+		PermissionManageMaintenance,
+		PermissionViewCatalogReport,
+		PermissionManageRecalls,
+		PermissionManageSearch,
+		PermissionViewAnalytics,
+		PermissionExportSales,
+		PermissionViewJobs,
+	},
+	entity.RoleSupport: {
+		// Support agents can look up orders and remediate them within their budget
+		PermissionViewProduct,
+		PermissionListProducts,
+		PermissionViewOrder,
+		PermissionViewAnyOrder,
+		PermissionListOrders,
+		PermissionViewAnyInvoice,
+		PermissionViewCustomerSummary,
+		PermissionRemediateOrders,
 	},
 	entity.RoleCustomer: {
 		// Customers can only view products and manage their own orders
@@ -43,6 +148,7 @@ var RolePermissions = map[entity.Role][]Permission{
 		PermissionCreateOrder,
 		PermissionViewOrder,
 		PermissionListOrders,
+		PermissionRequestReturns,
 	},
 }
 
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/marcofilho/go-ecommerce/src/usecase/ratelimit"
+)
+
+// TokenValidator resolves a bearer token to the user it was issued to
+type TokenValidator interface {
+	ValidateToken(tokenString string) (*auth.Claims, error)
+}
+
+// RateLimitMiddleware counts every request against the caller's budget and
+// reports it through X-RateLimit-* headers. Callers are identified by user
+// when a valid token is sent and by client IP otherwise.
+type RateLimitMiddleware struct {
+	limiter   ratelimit.RateLimitService
+	validator TokenValidator
+	enforce   bool
+}
+
+// NewRateLimitMiddleware creates the rate limit middleware. When enforce is false
+// the limit is soft: headers are set but requests over the limit still go through.
+func NewRateLimitMiddleware(limiter ratelimit.RateLimitService, validator TokenValidator, enforce bool) *RateLimitMiddleware {
+	return &RateLimitMiddleware{
+		limiter:   limiter,
+		validator: validator,
+		enforce:   enforce,
+	}
+}
+
+// Limit wraps the whole router, so it runs before route level authentication
+func (m *RateLimitMiddleware) Limit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		quota := m.limiter.Consume(m.clientKey(r))
+		SetRateLimitHeaders(w, quota)
+
+		if m.enforce && quota.Exceeded() {
+			retryAfter := max(int(time.Until(quota.ResetAt).Seconds()), 1)
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":"Rate limit exceeded"}`))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// SetRateLimitHeaders writes quota as X-RateLimit-* headers. The reset is a Unix timestamp.
+func SetRateLimitHeaders(w http.ResponseWriter, quota entity.RateLimitQuota) {
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(quota.Limit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(quota.Remaining()))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(quota.ResetAt.Unix(), 10))
+}
+
+func (m *RateLimitMiddleware) clientKey(r *http.Request) string {
+	parts := strings.Split(r.Header.Get("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		if claims, err := m.validator.ValidateToken(parts[1]); err == nil {
+			return ratelimit.UserKey(claims.UserID)
+		}
+	}
+
+	return ratelimit.IPKey(ClientIP(r))
+}
+
+// ClientIP returns the address of the client the request came from
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+// ReplicaReads lets GET and HEAD requests read the catalog from the read
+// replica. Other methods may read in order to write, so they stay on the
+// primary.
+func ReplicaReads(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			r = r.WithContext(repository.WithStaleReads(r.Context()))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
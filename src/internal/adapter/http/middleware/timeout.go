@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// untimedContextKey holds the request context as it was before Timeout gave
+// it a deadline
+type untimedContextKey struct{}
+
+// retimedContext has the values of the request context and the deadline and
+// cancellation of the one before Timeout, so a route's timeout replaces the
+// default without losing what the middleware in between stored
+type retimedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c retimedContext) Value(key any) any {
+	return c.values.Value(key)
+}
+
+// Timeout gives the request context a deadline of timeout, 0 for none. Wrap
+// the whole router with it for a default and single routes for their own
+// timeout, which replaces the default, so e.g. an export can run longer than
+// the other routes allow. Handlers aren't stopped at the deadline: the
+// repositories see it through the context and fail with entity.ErrTimeout,
+// which the handlers answer with 503.
+func Timeout(timeout time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			untimed, ok := ctx.Value(untimedContextKey{}).(context.Context)
+			if ok {
+				ctx = retimedContext{Context: untimed, values: ctx}
+			} else {
+				ctx = context.WithValue(ctx, untimedContextKey{}, ctx)
+			}
+
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
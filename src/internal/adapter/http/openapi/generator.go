@@ -0,0 +1,381 @@
+// Package openapi generates the OpenAPI 3 document of the API from the godoc
+// annotations of the handlers and the DTO types they reference, and serves it.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Config locates the annotated sources, relative to Root
+type Config struct {
+	Root       string            // The src directory of the module
+	MainFile   string            // General API info annotations
+	HandlerDir string            // Operation annotations
+	Packages   map[string]string // Package name used in annotations to its directory
+}
+
+// DefaultConfig is the layout of this repository under the src directory root
+func DefaultConfig(root string) Config {
+	return Config{
+		Root:       root,
+		MainFile:   "cmd/api/main.go",
+		HandlerDir: "internal/adapter/http/handler",
+		Packages: map[string]string{
+			"dto":    "internal/adapter/http/dto",
+			"entity": "internal/domain/entity",
+		},
+	}
+}
+
+var (
+	paramPattern    = regexp.MustCompile(`^(\S+)\s+(path|query|header|body)\s+(\S+)\s+(true|false)\s+"([^"]*)"(.*)$`)
+	responsePattern = regexp.MustCompile(`^(\d{3})(?:\s+\{(object|array|file)\}\s+(\S+))?(?:\s+"([^"]*)")?$`)
+	routerPattern   = regexp.MustCompile(`^(\S+)\s+\[(get|post|put|patch|delete)\]$`)
+	defaultPattern  = regexp.MustCompile(`default\(([^)]*)\)`)
+)
+
+// Generate builds the OpenAPI 3 document as indented JSON
+func Generate(cfg Config) ([]byte, error) {
+	s := newSchemas()
+	for _, pkg := range sortedKeys(cfg.Packages) {
+		if err := s.load(pkg, filepath.Join(cfg.Root, cfg.Packages[pkg])); err != nil {
+			return nil, err
+		}
+	}
+
+	// Every envelope references the pagination schema
+	if _, err := s.named(envelopePackage, "Pagination"); err != nil {
+		return nil, err
+	}
+
+	doc, err := generalInfo(filepath.Join(cfg.Root, cfg.MainFile))
+	if err != nil {
+		return nil, err
+	}
+
+	paths, err := operations(s, filepath.Join(cfg.Root, cfg.HandlerDir))
+	if err != nil {
+		return nil, err
+	}
+	doc["paths"] = paths
+	doc["components"].(map[string]any)["schemas"] = s.components
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// generalInfo reads the API annotations above func main
+func generalInfo(path string) (map[string]any, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	info := map[string]any{}
+	contact := map[string]any{}
+	license := map[string]any{}
+	servers := []map[string]any{}
+	securitySchemes := map[string]any{}
+	var scheme map[string]any
+
+	for _, group := range file.Comments {
+		for _, line := range strings.Split(group.Text(), "\n") {
+			key, value := annotation(line)
+			switch key {
+			case "@title":
+				info["title"] = value
+			case "@version":
+				info["version"] = value
+			case "@description":
+				if scheme != nil {
+					scheme["description"] = value
+				} else {
+					info["description"] = value
+				}
+			case "@termsOfService":
+				info["termsOfService"] = value
+			case "@contact.name":
+				contact["name"] = value
+			case "@contact.email":
+				contact["email"] = value
+			case "@license.name":
+				license["name"] = value
+			case "@license.url":
+				license["url"] = value
+			case "@BasePath":
+				// Relative to wherever the document is served from
+				servers = append(servers, map[string]any{"url": value})
+			case "@securityDefinitions.apikey":
+				scheme = map[string]any{"type": "apiKey"}
+				securitySchemes[value] = scheme
+			case "@in":
+				if scheme != nil {
+					scheme["in"] = value
+				}
+			case "@name":
+				if scheme != nil {
+					scheme["name"] = value
+				}
+			}
+		}
+	}
+
+	if info["title"] == nil || info["version"] == nil {
+		return nil, fmt.Errorf("%s: missing @title or @version", path)
+	}
+	if len(contact) > 0 {
+		info["contact"] = contact
+	}
+	if len(license) > 0 {
+		info["license"] = license
+	}
+
+	return map[string]any{
+		"openapi":    "3.0.3",
+		"info":       info,
+		"servers":    servers,
+		"components": map[string]any{"securitySchemes": securitySchemes},
+	}, nil
+}
+
+// operations reads the annotations of every handler method into path items
+func operations(s *schemas, dir string) (map[string]any, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string]any)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil {
+				continue
+			}
+
+			path, method, op, err := operation(s, fn)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", name, fn.Name.Name, err)
+			}
+			if op == nil {
+				continue
+			}
+
+			item, ok := paths[path].(map[string]any)
+			if !ok {
+				item = make(map[string]any)
+				paths[path] = item
+			}
+			if _, exists := item[method]; exists {
+				return nil, fmt.Errorf("%s: %s: %s %s is documented twice", name, fn.Name.Name, strings.ToUpper(method), path)
+			}
+			item[method] = op
+		}
+	}
+	return paths, nil
+}
+
+// operation returns a nil operation for functions without @Router
+func operation(s *schemas, fn *ast.FuncDecl) (string, string, map[string]any, error) {
+	op := map[string]any{"operationId": fn.Name.Name}
+	consumes, produces := "application/json", "application/json"
+	var path, method string
+	var params []map[string]any
+	var body map[string]any
+	responses := make(map[string]any)
+
+	for _, line := range strings.Split(fn.Doc.Text(), "\n") {
+		key, value := annotation(line)
+		switch key {
+		case "@Summary":
+			op["summary"] = value
+		case "@Description":
+			op["description"] = value
+		case "@Tags":
+			op["tags"] = strings.Split(value, ",")
+		case "@Accept":
+			consumes = mimeType(value)
+		case "@Produce":
+			produces = mimeType(value)
+		case "@Security":
+			op["security"] = []map[string]any{{value: []string{}}}
+		case "@Router":
+			match := routerPattern.FindStringSubmatch(value)
+			if match == nil {
+				return "", "", nil, fmt.Errorf("invalid @Router %q", value)
+			}
+			path, method = match[1], match[2]
+		case "@Param":
+			match := paramPattern.FindStringSubmatch(value)
+			if match == nil {
+				return "", "", nil, fmt.Errorf("invalid @Param %q", value)
+			}
+			param, err := parameter(s, match)
+			if err != nil {
+				return "", "", nil, err
+			}
+			if match[2] == "body" {
+				body = param
+			} else {
+				params = append(params, param)
+			}
+		case "@Success", "@Failure":
+			match := responsePattern.FindStringSubmatch(value)
+			if match == nil {
+				return "", "", nil, fmt.Errorf("invalid %s %q", key, value)
+			}
+			if _, exists := responses[match[1]]; exists {
+				return "", "", nil, fmt.Errorf("response %s is documented twice", match[1])
+			}
+			resp, err := response(s, match, key == "@Success", produces)
+			if err != nil {
+				return "", "", nil, err
+			}
+			responses[match[1]] = resp
+		}
+	}
+
+	if path == "" {
+		return "", "", nil, nil
+	}
+	if len(responses) == 0 {
+		return "", "", nil, fmt.Errorf("no responses documented")
+	}
+
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+	if body != nil {
+		op["requestBody"] = map[string]any{
+			"required":    body["required"],
+			"description": body["description"],
+			"content":     map[string]any{consumes: map[string]any{"schema": body["schema"]}},
+		}
+	}
+	op["responses"] = responses
+	return path, method, op, nil
+}
+
+func parameter(s *schemas, match []string) (map[string]any, error) {
+	name, in, typeName, required, description, options := match[1], match[2], match[3], match[4] == "true", match[5], match[6]
+
+	schema, err := typeSchema(s, typeName)
+	if err != nil {
+		return nil, fmt.Errorf("@Param %s: %w", name, err)
+	}
+	if value := defaultPattern.FindStringSubmatch(options); value != nil {
+		schema["default"] = typedValue(schema["type"], value[1])
+	}
+
+	return map[string]any{
+		"name":        name,
+		"in":          in,
+		"required":    required || in == "path",
+		"description": description,
+		"schema":      schema,
+	}, nil
+}
+
+func response(s *schemas, match []string, success bool, produces string) (map[string]any, error) {
+	code, kind, typeName, description := match[1], match[2], match[3], match[4]
+	if description == "" {
+		status, _ := strconv.Atoi(code)
+		description = http.StatusText(status)
+	}
+	resp := map[string]any{"description": description}
+
+	var schema map[string]any
+	switch kind {
+	case "":
+		return resp, nil
+	case "file":
+		schema = map[string]any{"type": "string", "format": "binary"}
+	default:
+		var err error
+		if schema, err = typeSchema(s, typeName); err != nil {
+			return nil, fmt.Errorf("response %s: %w", code, err)
+		}
+		if kind == "array" {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+		// Successful payloads are wrapped by respondJSON, failures are not
+		if success && (kind == "array" || !isEnvelopeName(s, typeName)) {
+			schema = envelope(schema, false)
+		}
+	}
+
+	resp["content"] = map[string]any{produces: map[string]any{"schema": schema}}
+	return resp, nil
+}
+
+// typeSchema resolves the type of a @Param or response, either a basic type
+// or a package-qualified one like dto.ProductResponse
+func typeSchema(s *schemas, typeName string) (map[string]any, error) {
+	pkg, name, qualified := strings.Cut(typeName, ".")
+	if !qualified {
+		if schema, ok := basicSchema(typeName); ok {
+			return schema, nil
+		}
+		return nil, fmt.Errorf("unknown type %s", typeName)
+	}
+	return s.named(pkg, name)
+}
+
+func isEnvelopeName(s *schemas, typeName string) bool {
+	pkg, name, _ := strings.Cut(typeName, ".")
+	return s.isEnvelope(pkg, name)
+}
+
+func annotation(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "@") {
+		return "", ""
+	}
+	key, value, _ := strings.Cut(line, " ")
+	return key, strings.TrimSpace(value)
+}
+
+func mimeType(value string) string {
+	if value == "json" {
+		return "application/json"
+	}
+	return value
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,25 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:generate go run ../../../../cmd/openapi -root ../../../.. -out openapi.json
+
+//go:embed openapi.json
+var document []byte
+
+// Document returns the generated OpenAPI 3 document
+func Document() []byte {
+	return document
+}
+
+// Handler serves the generated OpenAPI 3 document
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write(document)
+	})
+}
@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const srcRoot = "../../../.."
+
+func TestDocumentIsUpToDate(t *testing.T) {
+	generated, err := Generate(DefaultConfig(srcRoot))
+	if err != nil {
+		t.Fatalf("failed to generate: %v", err)
+	}
+
+	if !bytes.Equal(generated, Document()) {
+		t.Error("openapi.json is stale, run go generate ./src/internal/adapter/http/openapi")
+	}
+}
+
+func TestDocumentCoversRoutes(t *testing.T) {
+	routes, err := os.ReadFile(srcRoot + "/cmd/api/routes.go")
+	if err != nil {
+		t.Fatalf("failed to read routes: %v", err)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(Document(), &doc); err != nil {
+		t.Fatalf("invalid document: %v", err)
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range doc.Paths {
+		for method := range item {
+			documented[strings.ToUpper(method)+" /api"+path] = true
+		}
+	}
+
+	pattern := regexp.MustCompile(`"((?:GET|POST|PUT|PATCH|DELETE) /api/[^"]*)"`)
+	for _, match := range pattern.FindAllStringSubmatch(string(routes), -1) {
+		route := match[1]
+		if route == "GET /api/openapi.json" {
+			continue
+		}
+		if !documented[route] {
+			t.Errorf("route %s has no @Router annotation", route)
+		}
+		delete(documented, route)
+	}
+
+	for route := range documented {
+		t.Errorf("documented operation %s is not routed", route)
+	}
+}
@@ -0,0 +1,401 @@
+package openapi
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// The generic envelope types of the dto package, see dto.Response
+const (
+	envelopePackage  = "dto"
+	envelopeType     = "Response"
+	pageEnvelopeType = "PaginatedResponse"
+)
+
+type typeDecl struct {
+	pkg  string
+	spec *ast.TypeSpec
+	doc  string
+}
+
+// schemas turns Go type declarations into OpenAPI component schemas. Named
+// structs become components referenced by $ref, everything else is inlined.
+type schemas struct {
+	types      map[string]*typeDecl // Keyed by "pkg.Name"
+	enums      map[string][]string  // String constants of named types, keyed by "pkg.Name"
+	components map[string]any
+	owners     map[string]string // Component name to the type it was generated from
+}
+
+func newSchemas() *schemas {
+	return &schemas{
+		types:      make(map[string]*typeDecl),
+		enums:      make(map[string][]string),
+		components: make(map[string]any),
+		owners:     make(map[string]string),
+	}
+}
+
+// load parses the type and constant declarations of a package directory,
+// skipping tests. pkg is the name annotations use for the package.
+func (s *schemas) load(pkg, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			switch gen.Tok {
+			case token.TYPE:
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					s.types[pkg+"."+typeSpec.Name.Name] = &typeDecl{pkg: pkg, spec: typeSpec, doc: commentText(doc)}
+				}
+			case token.CONST:
+				s.loadEnums(pkg, gen)
+			}
+		}
+	}
+	return nil
+}
+
+func (s *schemas) loadEnums(pkg string, gen *ast.GenDecl) {
+	for _, spec := range gen.Specs {
+		valueSpec := spec.(*ast.ValueSpec)
+		typeName, ok := valueSpec.Type.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		for _, value := range valueSpec.Values {
+			lit, ok := value.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				continue
+			}
+			if unquoted, err := strconv.Unquote(lit.Value); err == nil {
+				key := pkg + "." + typeName.Name
+				s.enums[key] = append(s.enums[key], unquoted)
+			}
+		}
+	}
+}
+
+// named returns the schema of a type referenced as pkg.Name in an annotation
+func (s *schemas) named(pkg, name string) (map[string]any, error) {
+	decl, ok := s.types[pkg+"."+name]
+	if !ok {
+		return nil, fmt.Errorf("unknown type %s.%s", pkg, name)
+	}
+
+	switch t := decl.spec.Type.(type) {
+	case *ast.StructType:
+		return s.component(decl, func() (map[string]any, error) { return s.structSchema(pkg, t) })
+	case *ast.IndexExpr:
+		// Aliases of the envelope, e.g. ProductListResponse = PaginatedResponse[ProductResponse]
+		return s.component(decl, func() (map[string]any, error) { return s.expr(pkg, t) })
+	}
+
+	schema, err := s.expr(pkg, decl.spec.Type)
+	if err != nil {
+		return nil, fmt.Errorf("%s.%s: %w", pkg, name, err)
+	}
+	if values := s.enums[pkg+"."+name]; len(values) > 0 {
+		schema["enum"] = values
+	}
+	return schema, nil
+}
+
+// component generates the schema of decl once and returns a reference to it
+func (s *schemas) component(decl *typeDecl, build func() (map[string]any, error)) (map[string]any, error) {
+	name := decl.spec.Name.Name
+	key := decl.pkg + "." + name
+	ref := map[string]any{"$ref": "#/components/schemas/" + name}
+
+	if owner, ok := s.owners[name]; ok {
+		if owner != key {
+			return nil, fmt.Errorf("schema name %s is used by both %s and %s", name, owner, key)
+		}
+		return ref, nil
+	}
+	s.owners[name] = key
+
+	schema, err := build()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", key, err)
+	}
+	if decl.doc != "" {
+		schema["description"] = decl.doc
+	}
+	s.components[name] = schema
+	return ref, nil
+}
+
+// isEnvelope reports whether a named type already is the response envelope
+func (s *schemas) isEnvelope(pkg, name string) bool {
+	if name == envelopeType || name == pageEnvelopeType {
+		return true
+	}
+	decl, ok := s.types[pkg+"."+name]
+	if !ok {
+		return false
+	}
+	index, ok := decl.spec.Type.(*ast.IndexExpr)
+	if !ok {
+		return false
+	}
+	base, ok := index.X.(*ast.Ident)
+	return ok && (base.Name == envelopeType || base.Name == pageEnvelopeType)
+}
+
+// envelope wraps a payload schema the way respondJSON does
+func envelope(data map[string]any, paginated bool) map[string]any {
+	required := []string{"data"}
+	if paginated {
+		required = append(required, "pagination")
+	}
+	return map[string]any{
+		"type":     "object",
+		"required": required,
+		"properties": map[string]any{
+			"data":       data,
+			"pagination": map[string]any{"$ref": "#/components/schemas/Pagination"},
+		},
+	}
+}
+
+func (s *schemas) expr(pkg string, expr ast.Expr) (map[string]any, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if schema, ok := basicSchema(t.Name); ok {
+			return schema, nil
+		}
+		return s.named(pkg, t.Name)
+
+	case *ast.StarExpr:
+		return s.expr(pkg, t.X)
+
+	case *ast.ArrayType:
+		items, err := s.expr(pkg, t.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+
+	case *ast.MapType:
+		values, err := s.expr(pkg, t.Value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+
+	case *ast.InterfaceType:
+		return map[string]any{}, nil
+
+	case *ast.StructType:
+		return s.structSchema(pkg, t)
+
+	case *ast.SelectorExpr:
+		qualifier, ok := t.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		switch qualifier.Name + "." + t.Sel.Name {
+		case "json.RawMessage":
+			return map[string]any{}, nil
+		case "time.Time":
+			return map[string]any{"type": "string", "format": "date-time"}, nil
+		case "uuid.UUID":
+			return map[string]any{"type": "string", "format": "uuid"}, nil
+		}
+		return s.named(qualifier.Name, t.Sel.Name)
+
+	case *ast.IndexExpr:
+		base, ok := t.X.(*ast.Ident)
+		if !ok || (base.Name != envelopeType && base.Name != pageEnvelopeType) {
+			break
+		}
+		data, err := s.expr(pkg, t.Index)
+		if err != nil {
+			return nil, err
+		}
+		if base.Name == pageEnvelopeType {
+			return envelope(map[string]any{"type": "array", "items": data}, true), nil
+		}
+		return envelope(data, false), nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %T", expr)
+}
+
+func basicSchema(name string) (map[string]any, bool) {
+	switch name {
+	case "string":
+		return map[string]any{"type": "string"}, true
+	case "int", "int32", "uint", "uint32":
+		return map[string]any{"type": "integer"}, true
+	case "int64", "uint64":
+		return map[string]any{"type": "integer", "format": "int64"}, true
+	case "float32", "float64":
+		return map[string]any{"type": "number"}, true
+	case "bool":
+		return map[string]any{"type": "boolean"}, true
+	case "any":
+		return map[string]any{}, true
+	}
+	return nil, false
+}
+
+func (s *schemas) structSchema(pkg string, st *ast.StructType) (map[string]any, error) {
+	properties := make(map[string]any)
+	var required []string
+
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			unquoted, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				return nil, err
+			}
+			tag = reflect.StructTag(unquoted)
+		}
+
+		jsonName, options, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" {
+			continue
+		}
+		omitEmpty := strings.Contains(","+options+",", ",omitempty,")
+
+		// Embedded structs without a JSON name are flattened, like encoding/json does
+		if len(field.Names) == 0 && jsonName == "" {
+			embedded, err := s.embedded(pkg, field.Type)
+			if err != nil {
+				return nil, err
+			}
+			for name, property := range embedded["properties"].(map[string]any) {
+				properties[name] = property
+			}
+			if fields, ok := embedded["required"].([]string); ok {
+				required = append(required, fields...)
+			}
+			continue
+		}
+
+		for _, name := range fieldNames(field, jsonName) {
+			property, err := s.expr(pkg, field.Type)
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+
+			// Siblings of $ref are ignored, so references carry no description
+			if _, isRef := property["$ref"]; !isRef {
+				if _, isPointer := field.Type.(*ast.StarExpr); isPointer && !omitEmpty {
+					property["nullable"] = true
+				}
+				if description := commentText(field.Comment); description != "" {
+					property["description"] = description
+				} else if description := commentText(field.Doc); description != "" {
+					property["description"] = description
+				}
+				if example, ok := tag.Lookup("example"); ok {
+					property["example"] = typedValue(property["type"], example)
+				}
+			}
+
+			properties[name] = property
+			if !omitEmpty {
+				required = append(required, name)
+			}
+		}
+	}
+
+	schema := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+// embedded returns the inline schema of an embedded struct type
+func (s *schemas) embedded(pkg string, expr ast.Expr) (map[string]any, error) {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	name, ok := expr.(*ast.Ident)
+	if !ok {
+		return nil, fmt.Errorf("unsupported embedded type %T", expr)
+	}
+	decl, ok := s.types[pkg+"."+name.Name]
+	if !ok {
+		return nil, fmt.Errorf("unknown embedded type %s.%s", pkg, name.Name)
+	}
+	st, ok := decl.spec.Type.(*ast.StructType)
+	if !ok {
+		return nil, fmt.Errorf("embedded type %s.%s is not a struct", pkg, name.Name)
+	}
+	return s.structSchema(pkg, st)
+}
+
+func fieldNames(field *ast.Field, jsonName string) []string {
+	if jsonName != "" {
+		return []string{jsonName}
+	}
+	var names []string
+	for _, name := range field.Names {
+		if name.IsExported() {
+			names = append(names, name.Name)
+		}
+	}
+	return names
+}
+
+// typedValue converts an annotation value to the JSON type of its schema
+func typedValue(schemaType any, value string) any {
+	switch schemaType {
+	case "integer":
+		if parsed, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return parsed
+		}
+	case "number":
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	case "boolean":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	}
+	return strings.Trim(value, `"`)
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
@@ -0,0 +1,587 @@
+package app
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/handler"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/middleware"
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/capture"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/jobs"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/paymentprovider"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/sms"
+	accessCodeUseCase "github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	allocationUseCase "github.com/marcofilho/go-ecommerce/src/usecase/allocation"
+	analyticsUseCase "github.com/marcofilho/go-ecommerce/src/usecase/analytics"
+	attributeUseCase "github.com/marcofilho/go-ecommerce/src/usecase/attribute"
+	authUseCase "github.com/marcofilho/go-ecommerce/src/usecase/auth"
+	blocklistUseCase "github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	catalogFeedUseCase "github.com/marcofilho/go-ecommerce/src/usecase/catalogfeed"
+	catalogReportUseCase "github.com/marcofilho/go-ecommerce/src/usecase/catalogreport"
+	categoryUseCase "github.com/marcofilho/go-ecommerce/src/usecase/category"
+	customerUseCase "github.com/marcofilho/go-ecommerce/src/usecase/customer"
+	draftOrderUseCase "github.com/marcofilho/go-ecommerce/src/usecase/draftorder"
+	emailTemplateUseCase "github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	inventoryUseCase "github.com/marcofilho/go-ecommerce/src/usecase/inventory"
+	invoiceUseCase "github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+	lowStockUseCase "github.com/marcofilho/go-ecommerce/src/usecase/lowstock"
+	loyaltyUseCase "github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+	maintenanceUseCase "github.com/marcofilho/go-ecommerce/src/usecase/maintenance"
+	monitoringUseCase "github.com/marcofilho/go-ecommerce/src/usecase/monitoring"
+	orderUseCase "github.com/marcofilho/go-ecommerce/src/usecase/order"
+	paymentUseCase "github.com/marcofilho/go-ecommerce/src/usecase/payment"
+	priceHistoryUseCase "github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	pricingUseCase "github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	productUseCase "github.com/marcofilho/go-ecommerce/src/usecase/product"
+	productVariantUseCase "github.com/marcofilho/go-ecommerce/src/usecase/product_variant"
+	queueUseCase "github.com/marcofilho/go-ecommerce/src/usecase/queue"
+	rateLimitUseCase "github.com/marcofilho/go-ecommerce/src/usecase/ratelimit"
+	recallUseCase "github.com/marcofilho/go-ecommerce/src/usecase/recall"
+	remediationUseCase "github.com/marcofilho/go-ecommerce/src/usecase/remediation"
+	returnsUseCase "github.com/marcofilho/go-ecommerce/src/usecase/returns"
+	reviewUseCase "github.com/marcofilho/go-ecommerce/src/usecase/review"
+	salesReportUseCase "github.com/marcofilho/go-ecommerce/src/usecase/salesreport"
+	searchUseCase "github.com/marcofilho/go-ecommerce/src/usecase/search"
+	stockUseCase "github.com/marcofilho/go-ecommerce/src/usecase/stock"
+	storeSettingsUseCase "github.com/marcofilho/go-ecommerce/src/usecase/storesettings"
+	textMessageUseCase "github.com/marcofilho/go-ecommerce/src/usecase/textmessage"
+	translationUseCase "github.com/marcofilho/go-ecommerce/src/usecase/translation"
+	webhookUseCase "github.com/marcofilho/go-ecommerce/src/usecase/webhook"
+)
+
+// Services holds common infrastructure services
+type Services struct {
+	audit     audit.AuditService
+	blocklist blocklistUseCase.Checker
+	access    accessCodeUseCase.Gate
+	fraud     fraud.Checker
+	events    events.Publisher
+	webhooks  events.Dispatcher
+	stock     stockUseCase.Recorder
+	prices    priceHistoryUseCase.Book
+	resolver  pricingUseCase.Resolver
+	workflow  *entity.OrderWorkflow
+	loyalty   loyaltyUseCase.Program
+	localizer translationUseCase.Localizer
+	texts     textMessageUseCase.Messenger
+}
+
+func (s *Services) GetAuditService() audit.AuditService {
+	return s.audit
+}
+
+func (s *Services) GetBlocklist() blocklistUseCase.Checker {
+	return s.blocklist
+}
+
+func (s *Services) GetAccessGate() accessCodeUseCase.Gate {
+	return s.access
+}
+
+func (s *Services) GetFraudChecker() fraud.Checker {
+	return s.fraud
+}
+
+func (s *Services) GetEventPublisher() events.Publisher {
+	return s.events
+}
+
+func (s *Services) GetWebhookDispatcher() events.Dispatcher {
+	return s.webhooks
+}
+
+func (s *Services) GetStockRecorder() stockUseCase.Recorder {
+	return s.stock
+}
+
+func (s *Services) GetPriceBook() priceHistoryUseCase.Book {
+	return s.prices
+}
+
+func (s *Services) GetPriceResolver() pricingUseCase.Resolver {
+	return s.resolver
+}
+
+func (s *Services) GetOrderWorkflow() *entity.OrderWorkflow {
+	return s.workflow
+}
+
+func (s *Services) GetLoyaltyProgram() loyaltyUseCase.Program {
+	return s.loyalty
+}
+
+func (s *Services) GetLocalizer() translationUseCase.Localizer {
+	return s.localizer
+}
+
+func (s *Services) GetTextMessenger() textMessageUseCase.Messenger {
+	return s.texts
+}
+
+// Container holds all application dependencies
+type Container struct {
+	DB     *gorm.DB
+	Config *config.Config
+
+	// Repositories
+	ProductRepo        repository.ProductRepository
+	ProductVariantRepo repository.ProductVariantRepository
+	ProductOptionRepo  repository.ProductOptionRepository
+	CategoryRepo       repository.CategoryRepository
+	OrderRepo          repository.OrderRepository
+	WebhookRepo        repository.WebhookRepository
+	DeadLetterRepo     repository.DeadLetterRepository
+	WebhookNonceRepo   repository.WebhookNonceRepository
+	UserRepo           repository.UserRepository
+	AuditLogRepo       repository.AuditLogRepository
+	InvoiceRepo        repository.InvoiceRepository
+	PurchaseQueueRepo  repository.PurchaseQueueRepository
+	CustomerRepo       repository.CustomerProfileRepository
+	CustomerDataRepo   repository.CustomerRepository
+	StockMovementRepo  repository.StockMovementRepository
+	PriceChangeRepo    repository.PriceChangeRepository
+	PriceTierRepo      repository.PriceTierRepository
+	AdminAlertRepo     repository.AdminAlertRepository
+	RemediationRepo    repository.OrderRemediationRepository
+	ReturnRepo         repository.ReturnRepository
+	ReviewRepo         repository.ProductReviewRepository
+	AttributeRepo      repository.AttributeRepository
+	EmailTemplateRepo  repository.EmailTemplateRepository
+	CatalogReportRepo  repository.CatalogReportRepository
+	RecallRepo         repository.RecallRepository
+	SearchRepo         repository.SearchRepository
+	AnalyticsRepo      repository.AnalyticsRepository
+	LowStockRepo       repository.LowStockRepository
+	RevocationRepo     repository.TokenRevocationRepository
+	SubscriptionRepo   repository.WebhookSubscriptionRepository
+	LoyaltyRepo        repository.LoyaltyRepository
+	CatalogFeedRepo    repository.CatalogFeedRepository
+	InventoryRepo      repository.InventoryRepository
+	TranslationRepo    repository.ProductTranslationRepository
+	BlocklistRepo      repository.BlocklistRepository
+	AccessCodeRepo     repository.AccessCodeRepository
+	DraftOrderRepo     repository.DraftOrderRepository
+	StoreSettingRepo   repository.StoreSettingRepository
+
+	// Infrastructure
+	JWTProvider      *auth.JWTProvider
+	Notifier         notification.Notifier
+	Refunds          refund.Gateway
+	Captures         capture.Gateway
+	PaymentProviders *paymentprovider.Registry
+	Services         *Services
+	Scheduler        *jobs.Scheduler
+
+	// Use Cases
+	ProductUseCase        *productUseCase.UseCase
+	ProductVariantUseCase *productVariantUseCase.UseCase
+	CategoryUseCase       *categoryUseCase.UseCase
+	OrderUseCase          *orderUseCase.UseCase
+	PaymentUseCase        *paymentUseCase.PaymentUseCase
+	AuthUseCase           *authUseCase.UseCase
+	InvoiceUseCase        *invoiceUseCase.UseCase
+	QueueUseCase          *queueUseCase.UseCase
+	CustomerUseCase       *customerUseCase.UseCase
+	StockUseCase          *stockUseCase.UseCase
+	PriceHistoryUseCase   *priceHistoryUseCase.UseCase
+	PricingUseCase        *pricingUseCase.UseCase
+	MonitoringUseCase     *monitoringUseCase.UseCase
+	RemediationUseCase    *remediationUseCase.UseCase
+	ReturnsUseCase        *returnsUseCase.UseCase
+	ReviewUseCase         *reviewUseCase.UseCase
+	AllocationUseCase     *allocationUseCase.UseCase
+	AttributeUseCase      *attributeUseCase.UseCase
+	RateLimitUseCase      *rateLimitUseCase.UseCase
+	MaintenanceUseCase    *maintenanceUseCase.UseCase
+	EmailTemplateUseCase  *emailTemplateUseCase.UseCase
+	CatalogReportUseCase  *catalogReportUseCase.UseCase
+	RecallUseCase         *recallUseCase.UseCase
+	SearchUseCase         *searchUseCase.UseCase
+	AnalyticsUseCase      *analyticsUseCase.UseCase
+	SalesReportUseCase    *salesReportUseCase.UseCase
+	LowStockUseCase       *lowStockUseCase.UseCase
+	WebhookUseCase        *webhookUseCase.UseCase
+	LoyaltyUseCase        *loyaltyUseCase.UseCase
+	CatalogFeedUseCase    *catalogFeedUseCase.UseCase
+	InventoryUseCase      *inventoryUseCase.UseCase
+	TranslationUseCase    *translationUseCase.UseCase
+	BlocklistUseCase      *blocklistUseCase.UseCase
+	AccessCodeUseCase     *accessCodeUseCase.UseCase
+	DraftOrderUseCase     *draftOrderUseCase.UseCase
+	StoreSettingsUseCase  *storeSettingsUseCase.UseCase
+
+	// Handlers
+	ProductHandler        *handler.ProductHandler
+	ProductVariantHandler *handler.ProductVariantHandler
+	CategoryHandler       *handler.CategoryHandler
+	OrderHandler          *handler.OrderHandler
+	PaymentHandler        *handler.PaymentHandler
+	AuthHandler           *handler.AuthHandler
+	InvoiceHandler        *handler.InvoiceHandler
+	QueueHandler          *handler.QueueHandler
+	CustomerHandler       *handler.CustomerHandler
+	StockHandler          *handler.StockHandler
+	PriceHistoryHandler   *handler.PriceHistoryHandler
+	PriceListHandler      *handler.PriceListHandler
+	AdminActivityHandler  *handler.AdminActivityHandler
+	RemediationHandler    *handler.RemediationHandler
+	ReturnHandler         *handler.ReturnHandler
+	ReviewHandler         *handler.ReviewHandler
+	CheckoutHandler       *handler.CheckoutHandler
+	AttributeHandler      *handler.AttributeHandler
+	QuotaHandler          *handler.QuotaHandler
+	EmailTemplateHandler  *handler.EmailTemplateHandler
+	CatalogReportHandler  *handler.CatalogReportHandler
+	RecallHandler         *handler.RecallHandler
+	SearchHandler         *handler.SearchHandler
+	AnalyticsHandler      *handler.AnalyticsHandler
+	SalesReportHandler    *handler.SalesReportHandler
+	JobHandler            *handler.JobHandler
+	WebhookHandler        *handler.WebhookHandler
+	LoyaltyHandler        *handler.LoyaltyHandler
+	CatalogFeedHandler    *handler.CatalogFeedHandler
+	InventoryHandler      *handler.InventoryHandler
+	TranslationHandler    *handler.ProductTranslationHandler
+	BlocklistHandler      *handler.BlocklistHandler
+	AccessCodeHandler     *handler.AccessCodeHandler
+	DraftOrderHandler     *handler.DraftOrderHandler
+	StoreSettingsHandler  *handler.StoreSettingsHandler
+	MaintenanceHandler    *handler.MaintenanceHandler
+
+	// Middleware
+	AuthMiddleware        *middleware.AuthMiddleware
+	RateLimitMiddleware   *middleware.RateLimitMiddleware
+	CORSMiddleware        *middleware.CORSMiddleware
+	APIVersionMiddleware  *middleware.APIVersionMiddleware
+	MaintenanceMiddleware *middleware.MaintenanceMiddleware
+	AccessCodeMiddleware  *middleware.AccessCodeMiddleware
+}
+
+// NewContainer creates and wires up all dependencies
+func NewContainer(db *gorm.DB, cfg *config.Config) *Container {
+	c := &Container{
+		DB:     db,
+		Config: cfg,
+	}
+
+	c.ProductRepo = infraRepo.NewProductRepositoryPostgres(db)
+	c.ProductVariantRepo = infraRepo.NewProductVariantRepositoryPostgres(db)
+	c.ProductOptionRepo = infraRepo.NewProductOptionRepository(db)
+	c.CategoryRepo = infraRepo.NewCategoryRepository(db)
+	c.OrderRepo = infraRepo.NewReadThroughOrderRepository(
+		infraRepo.NewOrderRepositoryPostgres(db),
+		infraRepo.NewOrderArchiveRepository(db),
+	)
+	c.WebhookRepo = infraRepo.NewWebhookRepository(db)
+	c.DeadLetterRepo = infraRepo.NewDeadLetterRepository(db)
+	c.WebhookNonceRepo = infraRepo.NewWebhookNonceRepository(db)
+	c.UserRepo = infraRepo.NewUserRepository(db)
+	c.AuditLogRepo = infraRepo.NewAuditLogRepository(db)
+	c.InvoiceRepo = infraRepo.NewInvoiceRepository(db)
+	c.PurchaseQueueRepo = infraRepo.NewPurchaseQueueRepository(db)
+	c.CustomerRepo = infraRepo.NewCustomerProfileRepository(db)
+	c.CustomerDataRepo = infraRepo.NewCustomerRepository(db)
+	c.StockMovementRepo = infraRepo.NewStockMovementRepository(db)
+	c.PriceChangeRepo = infraRepo.NewPriceChangeRepository(db)
+	c.PriceTierRepo = infraRepo.NewPriceTierRepository(db)
+	c.AdminAlertRepo = infraRepo.NewAdminAlertRepository(db)
+	c.RemediationRepo = infraRepo.NewOrderRemediationRepository(db)
+	c.ReturnRepo = infraRepo.NewReturnRepository(db)
+	c.ReviewRepo = infraRepo.NewProductReviewRepositoryPostgres(db)
+	c.AttributeRepo = infraRepo.NewAttributeRepository(db)
+	c.EmailTemplateRepo = infraRepo.NewEmailTemplateRepository(db)
+	c.CatalogReportRepo = infraRepo.NewCatalogReportRepository(db)
+	c.RecallRepo = infraRepo.NewRecallRepository(db)
+	c.SearchRepo = infraRepo.NewSearchRepository(db)
+	c.AnalyticsRepo = infraRepo.NewAnalyticsRepository(db)
+	c.LowStockRepo = infraRepo.NewLowStockRepository(db)
+	c.RevocationRepo = infraRepo.NewTokenRevocationRepository(db)
+	c.SubscriptionRepo = infraRepo.NewWebhookSubscriptionRepository(db)
+	c.LoyaltyRepo = infraRepo.NewLoyaltyRepository(db)
+	c.CatalogFeedRepo = infraRepo.NewCatalogFeedRepository(db)
+	c.InventoryRepo = infraRepo.NewInventoryRepository(db)
+	c.TranslationRepo = infraRepo.NewProductTranslationRepository(db)
+	c.BlocklistRepo = infraRepo.NewBlocklistRepository(db)
+	c.AccessCodeRepo = infraRepo.NewAccessCodeRepository(db)
+	c.DraftOrderRepo = infraRepo.NewDraftOrderRepository(db)
+	c.StoreSettingRepo = infraRepo.NewStoreSettingRepository(db)
+
+	// SQLite stands in for Postgres in local development. These repositories
+	// have queries of their own for it, the others are portable.
+	sqlite := cfg.Database.Driver == config.DriverSQLite
+	if sqlite {
+		c.EmailTemplateRepo = infraRepo.NewEmailTemplateRepositorySQLite(db)
+		c.RecallRepo = infraRepo.NewRecallRepositorySQLite(db)
+		c.SearchRepo = infraRepo.NewSearchRepositorySQLite(db)
+		c.AnalyticsRepo = infraRepo.NewAnalyticsRepositorySQLite(db)
+		c.LowStockRepo = infraRepo.NewLowStockRepositorySQLite(db)
+	}
+
+	// A SQLite database has a single process using it, nothing to lock against
+	var locker jobs.Locker
+	if !sqlite {
+		locker = jobs.NewPostgresLocker(db)
+	}
+
+	c.wire(locker)
+	return c
+}
+
+// NewMemoryContainer wires up all dependencies on in-memory repositories over
+// the store, for tests and load tests that should not need a database. DB is
+// nil and the background jobs lock nothing, the store has one process using it.
+func NewMemoryContainer(store *memory.Store, cfg *config.Config) *Container {
+	c := &Container{
+		Config: cfg,
+	}
+
+	c.ProductRepo = memory.NewProductRepository(store)
+	c.ProductVariantRepo = memory.NewProductVariantRepository(store)
+	c.ProductOptionRepo = memory.NewProductOptionRepository(store)
+	c.CategoryRepo = memory.NewCategoryRepository(store)
+	c.OrderRepo = infraRepo.NewReadThroughOrderRepository(
+		memory.NewOrderRepository(store),
+		memory.NewOrderArchiveRepository(store),
+	)
+	c.WebhookRepo = memory.NewWebhookRepository(store)
+	c.DeadLetterRepo = memory.NewDeadLetterRepository(store)
+	c.WebhookNonceRepo = memory.NewWebhookNonceRepository(store)
+	c.UserRepo = memory.NewUserRepository(store)
+	c.AuditLogRepo = memory.NewAuditLogRepository(store)
+	c.InvoiceRepo = memory.NewInvoiceRepository(store)
+	c.PurchaseQueueRepo = memory.NewPurchaseQueueRepository(store)
+	c.CustomerRepo = memory.NewCustomerProfileRepository(store)
+	c.CustomerDataRepo = memory.NewCustomerRepository(store)
+	c.StockMovementRepo = memory.NewStockMovementRepository(store)
+	c.PriceChangeRepo = memory.NewPriceChangeRepository(store)
+	c.PriceTierRepo = memory.NewPriceTierRepository(store)
+	c.AdminAlertRepo = memory.NewAdminAlertRepository(store)
+	c.RemediationRepo = memory.NewOrderRemediationRepository(store)
+	c.ReturnRepo = memory.NewReturnRepository(store)
+	c.ReviewRepo = memory.NewProductReviewRepository(store)
+	c.AttributeRepo = memory.NewAttributeRepository(store)
+	c.EmailTemplateRepo = memory.NewEmailTemplateRepository(store)
+	c.CatalogReportRepo = memory.NewCatalogReportRepository(store)
+	c.RecallRepo = memory.NewRecallRepository(store)
+	c.SearchRepo = memory.NewSearchRepository(store)
+	c.AnalyticsRepo = memory.NewAnalyticsRepository(store)
+	c.LowStockRepo = memory.NewLowStockRepository(store)
+	c.RevocationRepo = memory.NewTokenRevocationRepository(store)
+	c.SubscriptionRepo = memory.NewWebhookSubscriptionRepository(store)
+	c.LoyaltyRepo = memory.NewLoyaltyRepository(store)
+	c.CatalogFeedRepo = memory.NewCatalogFeedRepository(store)
+	c.InventoryRepo = memory.NewInventoryRepository(store)
+	c.TranslationRepo = memory.NewProductTranslationRepository(store)
+	c.BlocklistRepo = memory.NewBlocklistRepository(store)
+	c.AccessCodeRepo = memory.NewAccessCodeRepository(store)
+	c.DraftOrderRepo = memory.NewDraftOrderRepository(store)
+	c.StoreSettingRepo = memory.NewStoreSettingRepository(store)
+
+	c.wire(nil)
+	return c
+}
+
+// wire builds the services, use cases, handlers and middleware on the
+// repositories of the container
+func (c *Container) wire(locker jobs.Locker) {
+	cfg := c.Config
+
+	// Infrastructure Services
+	c.JWTProvider = auth.NewJWTProvider(cfg.JWT.Secret, cfg.JWT.ExpirationHours, cfg.JWT.PreviousSecrets...)
+	c.Notifier = notification.NewLogNotifier(nil)
+	// Without a payment provider refunds are logged to be issued by hand
+	c.Refunds = refund.NewLogGateway(nil)
+	if cfg.Refunds.ProviderURL != "" {
+		c.Refunds = refund.NewHTTPGateway(cfg.Refunds.ProviderURL, cfg.Refunds.ProviderSecret)
+	}
+	// and so are captures of authorized payments, to be made by hand
+	c.Captures = capture.NewLogGateway(nil)
+	if cfg.Captures.ProviderURL != "" {
+		c.Captures = capture.NewHTTPGateway(cfg.Captures.ProviderURL, cfg.Captures.ProviderSecret)
+	}
+	// Webhooks are only accepted from the payment providers configured
+	c.PaymentProviders = paymentprovider.NewRegistry()
+	if cfg.Webhook.StripeSecret != "" {
+		c.PaymentProviders.Register(paymentprovider.NewStripe(cfg.Webhook.StripeSecret))
+	}
+	if cfg.Webhook.PayPalWebhookID != "" {
+		c.PaymentProviders.Register(paymentprovider.NewPayPal(cfg.Webhook.PayPalWebhookID))
+	}
+	if cfg.Webhook.MercadoPagoSecret != "" {
+		c.PaymentProviders.Register(paymentprovider.NewMercadoPago(cfg.Webhook.MercadoPagoSecret, cfg.Webhook.MercadoPagoAccessToken))
+	}
+	c.Scheduler = jobs.NewScheduler(cfg.Jobs.Workers, locker, nil)
+	c.MonitoringUseCase = monitoringUseCase.NewUseCase(
+		c.AuditLogRepo,
+		c.AdminAlertRepo,
+		c.UserRepo,
+		c.Notifier,
+		monitoringUseCase.NewRules(monitoringUseCase.RulesConfig{
+			MassDeleteThreshold: cfg.Alerts.MassDeleteThreshold,
+			MassDeleteWindow:    time.Duration(cfg.Alerts.MassDeleteWindowMinutes) * time.Minute,
+			PriceChangePercent:  cfg.Alerts.PriceChangePercent,
+			RoleEscalation:      true,
+		}, c.AuditLogRepo),
+	)
+	c.Services = &Services{
+		audit:  audit.NewAuditService(c.AuditLogRepo, middleware.ActorFromContext, c.MonitoringUseCase),
+		events: events.NewNoopPublisher(),
+	}
+	c.Services.workflow = entity.NewSimpleOrderWorkflow()
+	if cfg.Orders.Workflow == config.WorkflowFulfillment {
+		c.Services.workflow = entity.NewFulfillmentOrderWorkflow()
+	}
+	// Without an SMS provider text messages are logged
+	var smsSender sms.Sender = sms.NewLogSender(nil)
+	if cfg.SMS.Provider == config.SMSTwilio {
+		smsSender = sms.NewTwilioSender(cfg.SMS.APIURL, cfg.SMS.AccountSID, cfg.SMS.AuthToken, cfg.SMS.From)
+	}
+	c.Services.texts = textMessageUseCase.NewUseCase(c.CustomerDataRepo, sms.NewChannel(smsSender, cfg.SMS.Events))
+	if cfg.Webhook.ProductEventsURL != "" {
+		c.Services.events = events.NewWebhookPublisher(cfg.Webhook.ProductEventsURL, cfg.Webhook.ProductEventsSecret)
+	}
+
+	// Use Cases
+	c.WebhookUseCase = webhookUseCase.NewUseCase(c.SubscriptionRepo, events.NewHTTPSender(), c.Services)
+	c.Services.webhooks = c.WebhookUseCase
+	c.StockUseCase = stockUseCase.NewUseCase(c.StockMovementRepo)
+	// Every recorded stock movement is also an event for webhook subscribers
+	c.Services.stock = webhookUseCase.NewStockRecorder(c.StockUseCase, c.WebhookUseCase)
+	c.PriceHistoryUseCase = priceHistoryUseCase.NewUseCase(c.PriceChangeRepo, c.ProductRepo, c.ProductVariantRepo, middleware.ActorFromContext, c.Services)
+	c.Services.prices = c.PriceHistoryUseCase
+	c.PricingUseCase = pricingUseCase.NewUseCase(c.PriceTierRepo, c.ProductRepo, c.ProductVariantRepo, c.Services)
+	c.Services.resolver = pricingUseCase.NewResolver(c.PriceTierRepo, c.CustomerDataRepo)
+	c.BlocklistUseCase = blocklistUseCase.NewUseCase(c.BlocklistRepo, c.UserRepo, c.Services, time.Duration(cfg.Blocklist.CacheSeconds)*time.Second)
+	c.Services.blocklist = c.BlocklistUseCase
+	c.AccessCodeUseCase = accessCodeUseCase.NewUseCase(c.AccessCodeRepo, c.Services, accessCodeUseCase.Requirements{
+		Registration: cfg.Access.RequireForRegistration,
+		Browsing:     cfg.Access.RequireForBrowsing,
+	})
+	c.Services.access = c.AccessCodeUseCase
+	c.AuthUseCase = authUseCase.NewUseCase(c.UserRepo, c.RevocationRepo, c.JWTProvider, authUseCase.NewLoginThrottle(authUseCase.LockoutPolicy{
+		MaxFailures:   cfg.Login.MaxFailures,
+		IPMaxFailures: cfg.Login.IPMaxFailures,
+		Window:        time.Duration(cfg.Login.WindowMinutes) * time.Minute,
+		Lockout:       time.Duration(cfg.Login.LockoutSeconds) * time.Second,
+		MaxLockout:    time.Duration(cfg.Login.MaxLockoutMinutes) * time.Minute,
+	}), c.Services)
+	c.InvoiceUseCase = invoiceUseCase.NewUseCase(c.InvoiceRepo, c.OrderRepo, c.ProductRepo, c.UserRepo, invoice.NewPDFRenderer(cfg.Invoice.StoreName))
+	c.CustomerUseCase = customerUseCase.NewUseCase(c.UserRepo, c.CustomerRepo, c.CustomerDataRepo, c.OrderRepo, c.AnalyticsRepo, c.ReviewRepo, c.BlocklistRepo, c.AuditLogRepo, c.InvoiceUseCase, c.AuthUseCase, c.Services)
+	fraudRules := []fraud.Checker{
+		fraud.NewBlocklistChecker(cfg.Fraud.Blocklist),
+		fraud.NewRiskChecker(c.CustomerUseCase, cfg.Fraud.RiskBlockThreshold),
+		fraud.NewVelocityChecker(c.OrderRepo, cfg.Fraud.VelocityMaxOrders, time.Duration(cfg.Fraud.VelocityWindowMinutes)*time.Minute),
+	}
+	if cfg.Fraud.ReviewAddressMismatch {
+		fraudRules = append(fraudRules, fraud.NewAddressChecker(c.CustomerUseCase))
+	}
+	c.Services.fraud = fraud.NewRulesChecker(fraudRules...)
+	c.TranslationUseCase = translationUseCase.NewUseCase(c.TranslationRepo, c.ProductRepo, c.Services, cfg.I18n.DefaultLocale)
+	c.Services.localizer = c.TranslationUseCase
+	c.ProductUseCase = productUseCase.NewUseCase(c.ProductRepo, c.AttributeRepo, c.Services)
+	c.ProductVariantUseCase = productVariantUseCase.NewUseCase(c.ProductVariantRepo, c.ProductOptionRepo, c.ProductRepo, c.Services)
+	c.CategoryUseCase = categoryUseCase.NewUseCase(c.CategoryRepo, c.Services)
+	c.InventoryUseCase = inventoryUseCase.NewUseCase(c.InventoryRepo, c.Services)
+	c.LoyaltyUseCase = loyaltyUseCase.NewUseCase(c.LoyaltyRepo, entity.LoyaltyProgram{
+		EarnRate:   cfg.Loyalty.EarnRate,
+		PointValue: cfg.Loyalty.PointValue,
+	})
+	c.Services.loyalty = c.LoyaltyUseCase
+	c.OrderUseCase = orderUseCase.NewUseCase(c.OrderRepo, c.ProductRepo, c.ProductVariantRepo, c.PurchaseQueueRepo, c.Services, cfg.Pricing.TaxRate)
+	c.PaymentUseCase = paymentUseCase.NewPaymentUseCase(c.OrderRepo, c.WebhookRepo, c.DeadLetterRepo, c.WebhookNonceRepo, c.CustomerRepo, c.Captures, c.Services)
+	c.QueueUseCase = queueUseCase.NewUseCase(c.PurchaseQueueRepo, c.ProductRepo, time.Duration(cfg.Queue.WindowSeconds)*time.Second, cfg.Queue.MaxWindows)
+	c.RemediationUseCase = remediationUseCase.NewUseCase(c.RemediationRepo, c.OrderRepo, c.ProductRepo, c.ProductVariantRepo, c.WebhookRepo, c.Refunds, remediationUseCase.Budgets{
+		entity.RoleSupport: float64(cfg.Support.SupportDailyBudget),
+		entity.RoleAdmin:   float64(cfg.Support.AdminDailyBudget),
+	}, c.Services)
+	c.ReturnsUseCase = returnsUseCase.NewUseCase(c.ReturnRepo, c.OrderRepo, c.ProductRepo, c.ProductVariantRepo, c.WebhookRepo, c.Refunds, c.Services)
+	c.ReviewUseCase = reviewUseCase.NewUseCase(c.ReviewRepo, c.ProductRepo, c.OrderRepo, c.Services)
+	c.AttributeUseCase = attributeUseCase.NewUseCase(c.AttributeRepo, c.ProductRepo, c.Services)
+	c.AllocationUseCase = allocationUseCase.NewUseCase(c.ProductRepo, c.ProductVariantRepo, entity.Warehouse{
+		Code:         cfg.Shipping.WarehouseCode,
+		Name:         cfg.Shipping.WarehouseName,
+		HandlingDays: cfg.Shipping.HandlingDays,
+		CutoffHour:   cfg.Shipping.CutoffHour,
+	})
+	c.EmailTemplateUseCase = emailTemplateUseCase.NewUseCase(c.EmailTemplateRepo, c.Notifier, c.Services)
+	c.CatalogReportUseCase = catalogReportUseCase.NewUseCase(c.CatalogReportRepo, c.CategoryRepo)
+	c.CatalogFeedUseCase = catalogFeedUseCase.NewUseCase(c.CatalogFeedRepo, c.Services, catalogFeedUseCase.Store{
+		Name:     cfg.Invoice.StoreName,
+		URL:      cfg.Feed.StoreURL,
+		Currency: cfg.Feed.Currency,
+	})
+	c.DraftOrderUseCase = draftOrderUseCase.NewUseCase(c.DraftOrderRepo, c.ProductRepo, c.ProductVariantRepo, c.UserRepo, c.OrderUseCase,
+		c.EmailTemplateUseCase, c.Notifier, c.Services, cfg.Orders.DraftLinkURL, time.Duration(cfg.Orders.DraftLinkDays)*24*time.Hour)
+	// The configured store name and currency are the defaults until admins change them
+	c.StoreSettingsUseCase = storeSettingsUseCase.NewUseCase(c.StoreSettingRepo, c.Services, map[string]string{
+		entity.SettingStoreName:     cfg.Invoice.StoreName,
+		entity.SettingStoreCurrency: cfg.Feed.Currency,
+	})
+	c.RecallUseCase = recallUseCase.NewUseCase(c.RecallRepo, c.ProductRepo, c.ProductVariantRepo, c.UserRepo, c.EmailTemplateUseCase, c.Notifier, c.Services)
+	c.SearchUseCase = searchUseCase.NewUseCase(c.SearchRepo, c.ProductRepo, c.Services)
+	c.AnalyticsUseCase = analyticsUseCase.NewUseCase(c.AnalyticsRepo)
+	c.SalesReportUseCase = salesReportUseCase.NewUseCase(c.OrderRepo)
+	c.LowStockUseCase = lowStockUseCase.NewUseCase(c.LowStockRepo, c.UserRepo, c.Notifier, cfg.Jobs.LowStockThreshold)
+	c.RateLimitUseCase = rateLimitUseCase.NewUseCase(cfg.RateLimit.Requests, time.Duration(cfg.RateLimit.WindowSeconds)*time.Second)
+	c.MaintenanceUseCase = maintenanceUseCase.NewUseCase(c.Services, cfg.Maintenance.Enabled, cfg.Maintenance.Message,
+		time.Duration(cfg.Maintenance.RetryAfterSeconds)*time.Second)
+
+	// Handlers
+	c.ProductHandler = handler.NewProductHandler(c.ProductUseCase)
+	c.ProductVariantHandler = handler.NewProductVariantHandler(c.ProductVariantUseCase)
+	c.CategoryHandler = handler.NewCategoryHandler(c.CategoryUseCase)
+	c.OrderHandler = handler.NewOrderHandler(c.OrderUseCase)
+	c.PaymentHandler = handler.NewPaymentHandler(c.PaymentUseCase, cfg.Webhook.Secret, c.PaymentProviders)
+	c.AuthHandler = handler.NewAuthHandler(c.AuthUseCase)
+	c.InvoiceHandler = handler.NewInvoiceHandler(c.InvoiceUseCase)
+	c.QueueHandler = handler.NewQueueHandler(c.QueueUseCase)
+	c.CustomerHandler = handler.NewCustomerHandler(c.CustomerUseCase)
+	c.StockHandler = handler.NewStockHandler(c.StockUseCase)
+	c.PriceHistoryHandler = handler.NewPriceHistoryHandler(c.PriceHistoryUseCase)
+	c.PriceListHandler = handler.NewPriceListHandler(c.PricingUseCase)
+	c.AdminActivityHandler = handler.NewAdminActivityHandler(c.MonitoringUseCase)
+	c.RemediationHandler = handler.NewRemediationHandler(c.RemediationUseCase)
+	c.ReturnHandler = handler.NewReturnHandler(c.ReturnsUseCase)
+	c.ReviewHandler = handler.NewReviewHandler(c.ReviewUseCase)
+	c.CheckoutHandler = handler.NewCheckoutHandler(c.AllocationUseCase, c.OrderUseCase)
+	c.AttributeHandler = handler.NewAttributeHandler(c.AttributeUseCase)
+	c.QuotaHandler = handler.NewQuotaHandler(c.RateLimitUseCase)
+	c.EmailTemplateHandler = handler.NewEmailTemplateHandler(c.EmailTemplateUseCase)
+	c.CatalogReportHandler = handler.NewCatalogReportHandler(c.CatalogReportUseCase)
+	c.RecallHandler = handler.NewRecallHandler(c.RecallUseCase)
+	c.SearchHandler = handler.NewSearchHandler(c.SearchUseCase)
+	c.AnalyticsHandler = handler.NewAnalyticsHandler(c.AnalyticsUseCase)
+	c.SalesReportHandler = handler.NewSalesReportHandler(c.SalesReportUseCase)
+	c.JobHandler = handler.NewJobHandler(c.Scheduler, cfg.Jobs.Enabled)
+	c.WebhookHandler = handler.NewWebhookHandler(c.WebhookUseCase)
+	c.LoyaltyHandler = handler.NewLoyaltyHandler(c.LoyaltyUseCase)
+	c.CatalogFeedHandler = handler.NewCatalogFeedHandler(c.CatalogFeedUseCase)
+	c.InventoryHandler = handler.NewInventoryHandler(c.InventoryUseCase)
+	c.TranslationHandler = handler.NewProductTranslationHandler(c.TranslationUseCase)
+	c.BlocklistHandler = handler.NewBlocklistHandler(c.BlocklistUseCase)
+	c.AccessCodeHandler = handler.NewAccessCodeHandler(c.AccessCodeUseCase)
+	c.DraftOrderHandler = handler.NewDraftOrderHandler(c.DraftOrderUseCase)
+	c.StoreSettingsHandler = handler.NewStoreSettingsHandler(c.StoreSettingsUseCase)
+	c.MaintenanceHandler = handler.NewMaintenanceHandler(c.MaintenanceUseCase)
+
+	// Middleware
+	c.AuthMiddleware = middleware.NewAuthMiddleware(c.AuthUseCase)
+	c.RateLimitMiddleware = middleware.NewRateLimitMiddleware(c.RateLimitUseCase, c.AuthUseCase, cfg.RateLimit.Enforce)
+	c.CORSMiddleware = middleware.NewCORSMiddleware(cfg.CORS)
+	c.APIVersionMiddleware = middleware.NewAPIVersionMiddleware(config.APIVersions, cfg.APIVersions)
+	c.MaintenanceMiddleware = middleware.NewMaintenanceMiddleware(c.MaintenanceUseCase, c.AuthUseCase)
+	c.AccessCodeMiddleware = middleware.NewAccessCodeMiddleware(c.AccessCodeUseCase, c.AuthUseCase)
+}
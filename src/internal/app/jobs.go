@@ -0,0 +1,96 @@
+package app
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/jobs"
+)
+
+// webhookRetryBatchSize is how many failed webhooks one retry run picks up
+const webhookRetryBatchSize = 100
+
+// webhookDeliveryBatchSize is how many outgoing webhooks one delivery run sends
+const webhookDeliveryBatchSize = 100
+
+// RegisterJobs schedules the background jobs. Jobs scheduled "off" are left out.
+func RegisterJobs(c *Container) error {
+	cfg := c.Config.Jobs
+
+	backgroundJobs := []jobs.Job{
+		{
+			Name:     "queue.advance",
+			Schedule: cfg.QueueSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.QueueUseCase.AdvanceQueues(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "payment.retry_webhooks",
+			Schedule: cfg.WebhookRetrySchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.PaymentUseCase.RetryFailedWebhooks(ctx, webhookRetryBatchSize)
+				return err
+			},
+		},
+		{
+			Name:     "payment.purge_nonces",
+			Schedule: cfg.NoncePurgeSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.PaymentUseCase.PurgeExpiredNonces(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "stock.low_stock",
+			Schedule: cfg.LowStockSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.LowStockUseCase.CheckLowStock(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "catalog.report",
+			Schedule: cfg.CatalogReportSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.CatalogReportUseCase.RunReport(ctx, entity.ReportScheduled, nil)
+				return err
+			},
+		},
+		{
+			Name:     "auth.purge_revocations",
+			Schedule: cfg.TokenPurgeSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.AuthUseCase.PurgeExpiredRevocations(ctx)
+				return err
+			},
+		},
+		{
+			Name:     "webhook.deliver",
+			Schedule: cfg.WebhookDeliverySchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.WebhookUseCase.DeliverDue(ctx, webhookDeliveryBatchSize)
+				return err
+			},
+		},
+		{
+			Name:     "catalog.feed",
+			Schedule: cfg.CatalogFeedSchedule,
+			Run: func(ctx context.Context) error {
+				_, err := c.CatalogFeedUseCase.GenerateFeeds(ctx)
+				return err
+			},
+		},
+	}
+
+	for _, job := range backgroundJobs {
+		if job.Schedule == "off" {
+			continue
+		}
+		if err := c.Scheduler.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+)
+
+// WatchSecrets picks up secrets rotated in the secrets manager every
+// SECRETS_REFRESH_MINUTES until ctx is done. A rotated JWT secret becomes the
+// signing key while the previous one keeps verifying the tokens it signed
+// until they expire; the webhook secret is swapped in place. The database
+// credentials and the other settings are only read at startup, changing them
+// takes a restart.
+func WatchSecrets(ctx context.Context, c *Container) {
+	cfg := c.Config.Secrets
+	if cfg.Store == nil || cfg.RefreshMinutes <= 0 {
+		return
+	}
+
+	go cfg.Store.Watch(ctx, time.Duration(cfg.RefreshMinutes)*time.Minute, func(changed map[string]string) {
+		for key, value := range changed {
+			switch key {
+			case "JWT_SECRET":
+				if len(value) < config.MinJWTSecretLength {
+					log.Printf("Ignoring rotated JWT_SECRET shorter than %d characters", config.MinJWTSecretLength)
+					continue
+				}
+				c.JWTProvider.Rotate(value)
+			case "WEBHOOK_SECRET":
+				if value == "" {
+					continue
+				}
+				c.PaymentHandler.SetWebhookSecret(value)
+			default:
+				log.Printf("Secret %s changed in %s, restart to apply it", key, cfg.Store.Name())
+				continue
+			}
+			log.Printf("Rotated %s from %s", key, cfg.Store.Name())
+		}
+	})
+}
@@ -1,84 +1,561 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/secrets"
 )
 
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Webhook  WebhookConfig
-	JWT      JWTConfig
+	Database    DatabaseConfig
+	Server      ServerConfig
+	Webhook     WebhookConfig
+	JWT         JWTConfig
+	Invoice     InvoiceConfig
+	Queue       QueueConfig
+	Archive     ArchiveConfig
+	Fraud       FraudConfig
+	Blocklist   BlocklistConfig
+	Alerts      AdminAlertConfig
+	Support     SupportConfig
+	Refunds     RefundConfig
+	Captures    CaptureConfig
+	SMS         SMSConfig
+	Shipping    ShippingConfig
+	RateLimit   RateLimitConfig
+	Maintenance MaintenanceConfig
+	Access      AccessConfig
+	Login       LoginConfig
+	Orders      OrderConfig
+	Pricing     PricingConfig
+	Loyalty     LoyaltyConfig
+	Feed        FeedConfig
+	I18n        I18nConfig
+	CORS        CORSConfig
+	APIVersions APIVersionConfig
+	Jobs        JobsConfig
+	Secrets     SecretsConfig
 }
 
+// Database drivers
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite" // Local development and tests, without a Postgres instance
+)
+
 type DatabaseConfig struct {
+	Driver     string
+	SQLitePath string // Database file of the sqlite driver
+
 	Host     string
 	Port     string
 	User     string
 	Password string
 	DBName   string
 	SSLMode  string
+
+	ReplicaDSN string // Read replica serving catalog reads, none when empty
+
+	MaxOpenConns           int // Connections open at once per pool, 0 for no limit
+	MaxIdleConns           int
+	ConnMaxLifetimeMinutes int // Connections are recycled after this long, 0 keeps them
+	ConnMaxIdleMinutes     int // Idle connections are closed after this long, 0 keeps them
+	QueryTimeoutSeconds    int // Limit on every statement, waiting for a connection included, 0 for none
+
+	IDStrategy string // How the IDs of new rows are made: uuidv7, ulid or uuidv4
 }
 
 type ServerConfig struct {
-	Port string
+	Port         string
+	MaxBodyBytes int // Request body limit of routes that set none of their own
+	// Deadline of the requests of routes that set none of their own, 0 for none
+	RequestTimeoutSeconds int
 }
 
 type WebhookConfig struct {
-	Secret string
+	Secret              string
+	ProductEventsURL    string // Subscriber for product lifecycle events, disabled when empty
+	ProductEventsSecret string
+
+	// Payment providers, each accepted at /api/payment-webhook/{provider} once configured
+	StripeSecret           string // Signing secret of the Stripe webhook endpoint
+	PayPalWebhookID        string
+	MercadoPagoSecret      string
+	MercadoPagoAccessToken string // Looks up the payments MercadoPago notifies
+}
+
+type InvoiceConfig struct {
+	StoreName string
+}
+
+type QueueConfig struct {
+	WindowSeconds int // How long an admitted user has to place the order
+	MaxWindows    int // Maximum purchase windows open at once per product
+}
+
+type ArchiveConfig struct {
+	OrdersAfterYears     int
+	AuditLogsAfterDays   int // Retention of audit logs in the hot table
+	WebhookLogsAfterDays int // Retention of processed webhook logs in the hot table
+	BatchSize            int
+}
+
+type AdminAlertConfig struct {
+	MassDeleteThreshold     int // Deletes by one admin within the window that raise an alert, 0 disables
+	MassDeleteWindowMinutes int
+	PriceChangePercent      int // Relative product price change that raises an alert, 0 disables
+}
+
+type SupportConfig struct {
+	SupportDailyBudget int // Refunds, credits and resends a support agent can issue per 24 hours
+	AdminDailyBudget   int
+}
+
+type RefundConfig struct {
+	ProviderURL    string // Refund endpoint of the payment provider, refunds are logged to be issued by hand when empty
+	ProviderSecret string
+}
+
+type CaptureConfig struct {
+	ProviderURL    string // Capture endpoint of the payment provider, captures are logged to be made by hand when empty
+	ProviderSecret string
+}
+
+// SMS providers
+const (
+	SMSLog    = "log"    // Text messages are written to the application log
+	SMSTwilio = "twilio" // Twilio, or a provider with a Twilio compatible API at SMS_API_URL
+
+	SMSEventsNone = "none" // SMS_EVENTS sending no event by text message
+)
+
+type SMSConfig struct {
+	Provider   string
+	APIURL     string // Base URL of a Twilio compatible API, Twilio's when empty
+	AccountSID string
+	AuthToken  string
+	From       string   // Number or sender ID messages are sent from
+	Events     []string // Events sent by text message, see sms.Events
+}
+
+type ShippingConfig struct {
+	WarehouseCode string
+	WarehouseName string
+	HandlingDays  int // Business days between allocation and handing over to the carrier
+	CutoffHour    int // Orders from this hour on start handling the next business day
+}
+
+type RateLimitConfig struct {
+	Requests      int // Requests a client may make per window
+	WindowSeconds int
+	Enforce       bool // Reject requests over the limit with 429, otherwise only report it in headers
+}
+
+// MaintenanceConfig starts the API in maintenance mode, which admins can then
+// switch off at runtime
+type MaintenanceConfig struct {
+	Enabled           bool // Answer public traffic with 503
+	RetryAfterSeconds int  // Sent to clients in Retry-After
+	Message           string
+}
+
+// AccessConfig puts the store in private beta, behind access codes admins
+// hand out
+type AccessConfig struct {
+	RequireForRegistration bool // Customers need a code to register
+	RequireForBrowsing     bool // Visitors who aren't signed in need a code to see the catalog
+}
+
+// LoginConfig locks accounts and client IPs out after repeated failed logins
+type LoginConfig struct {
+	MaxFailures       int // Failed logins of an account before it is locked
+	IPMaxFailures     int // Failed logins from one IP, over any accounts, before it is locked
+	WindowMinutes     int // Failures older than this are forgotten
+	LockoutSeconds    int // First lockout, doubled on each lockout in a row
+	MaxLockoutMinutes int
+}
+
+type CORSConfig struct {
+	AllowedOrigins   []string // Browser origins allowed to call the API, "*" for any, none disables CORS
+	AllowedMethods   []string
+	AllowedHeaders   []string // Request headers browsers may send
+	AllowCredentials bool     // Let browsers send cookies and HTTP auth along, ignored for "*"
+	MaxAgeSeconds    int      // How long browsers may cache a preflight response
+}
+
+// APIVersions are the versions of the API, oldest first. A version is added
+// when a response changes shape in a way that breaks clients, which keep
+// calling the version they were built against.
+var APIVersions = []string{"v1"}
+
+// APIVersionConfig picks the version of requests that name none and
+// announces the retirement of old versions to their clients
+type APIVersionConfig struct {
+	Default    string   // Version of requests to /api/... without one in the path or API-Version header
+	Deprecated []string // Versions answered with Deprecation headers
+	SunsetDate string   // Day deprecated versions stop being served, YYYY-MM-DD, sent in Sunset; none when empty
+}
+
+type JobsConfig struct {
+	Enabled                 bool // Run the background jobs in this instance; enable it on one instance only
+	Workers                 int
+	QueueSchedule           string // Expire purchase windows and admit waiting users, "off" disables the job
+	WebhookRetrySchedule    string // Reapply failed payment webhooks
+	NoncePurgeSchedule      string // Remove the nonces of payment webhooks too old to be accepted
+	LowStockSchedule        string // Notify admins of products and variants running out
+	CatalogReportSchedule   string // Precompute the catalog health report
+	TokenPurgeSchedule      string // Remove the revocations of tokens that have expired
+	WebhookDeliverySchedule string // Send the outgoing webhook deliveries that are due
+	CatalogFeedSchedule     string // Regenerate the product feed read by marketplaces
+	LowStockThreshold       int    // Stock at or below which an item counts as low
+}
+
+// Order workflows
+const (
+	WorkflowSimple      = "simple"      // Paying an order completes it
+	WorkflowFulfillment = "fulfillment" // Paid orders are processed, shipped and delivered
+)
+
+type OrderConfig struct {
+	Workflow      string // Order status state machine
+	DraftLinkURL  string // Storefront page of draft order payment links, a link is {DraftLinkURL}/{token}
+	DraftLinkDays int    // How long a payment link sent for a draft order can be used
+}
+
+type PricingConfig struct {
+	TaxRate float64 // Fraction charged on every order line, e.g. 0.2 for 20%
+}
+
+type LoyaltyConfig struct {
+	EarnRate   float64 // Points earned per 1.00 paid for a completed order, 0 turns earning off
+	PointValue float64 // Discount a redeemed point gives at checkout, 0 turns redeeming off
+}
+
+type FeedConfig struct {
+	StoreURL string // Storefront the items of the catalog feed link to, product pages are at {StoreURL}/products/{id}
+	Currency string // ISO 4217 code of the prices in the feed
+}
+
+type I18nConfig struct {
+	DefaultLocale string // Language products are written in, other languages are translations
+}
+
+type FraudConfig struct {
+	RiskBlockThreshold    int      // Orders from customers at or above this risk score are rejected
+	Blocklist             []string // Customer IDs and account IDs whose orders are rejected
+	VelocityMaxOrders     int      // Orders an account can place within the window before the next ones are held for review, 0 turns the rule off
+	VelocityWindowMinutes int
+	ReviewAddressMismatch bool // Hold the orders of accounts with addresses in different countries for review
+}
+
+type BlocklistConfig struct {
+	CacheSeconds int // How long the blocked emails, domains and IP ranges are cached before they are read again
+}
+
+// Secrets providers
+const (
+	SecretsEnv   = "env" // Secrets come from the environment or the config file like any setting
+	SecretsVault = "vault"
+	SecretsAWS   = "aws" // AWS Secrets Manager
+)
+
+// fetchTimeout bounds fetching the secrets at startup
+const fetchTimeout = 30 * time.Second
+
+type SecretsConfig struct {
+	Provider       string
+	RefreshMinutes int // How often rotated secrets are picked up, 0 reads them at startup only
+	Vault          secrets.VaultConfig
+	AWS            secrets.AWSConfig
+
+	// Store holds the settings fetched from the secrets manager, nil with the env provider
+	Store *secrets.Store
 }
 
 type JWTConfig struct {
-	Secret          string
+	Secret string // Signs new tokens
+	// PreviousSecrets still verify tokens, so sessions signed before a rotation
+	// stay valid. Drop a secret once ExpirationHours have passed since it was replaced.
+	PreviousSecrets []string
 	ExpirationHours int
 }
 
-func Load() *Config {
+// Load reads the configuration and validates it. Settings come from the
+// environment, then from the config file (see CONFIG_FILE), then from the
+// defaults. The error lists every problem found rather than the first, so a
+// broken deployment is fixed in one go instead of failing at startup one
+// setting at a time.
+func Load() (*Config, error) {
+	return load(true)
+}
+
+// LoadWithoutSecrets is Load for the commands that only work on the database,
+// such as migrations, archiving and seeding, which don't need the JWT and
+// webhook secrets
+func LoadWithoutSecrets() (*Config, error) {
+	return load(false)
+}
+
+func load(requireSecrets bool) (*Config, error) {
+	src, err := newSource(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	// The secrets manager is set up from the environment and the file alone,
+	// its settings then take precedence over both
+	secretsCfg := src.secretsConfig()
+	if problems := append(src.problems, secretsCfg.validate()...); len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	if store := secretsCfg.newStore(); store != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), fetchTimeout)
+		defer cancel()
+		if _, err := store.Refresh(ctx); err != nil {
+			return nil, fmt.Errorf("Failed to fetch secrets from %s: %w", store.Name(), err)
+		}
+		src.secrets = store
+		secretsCfg.Store = store
+	}
+
+	cfg := src.config()
+	cfg.Secrets = secretsCfg
+	problems := append(src.problems, cfg.validate(requireSecrets)...)
+	if len(problems) > 0 {
+		return nil, &Error{Problems: problems}
+	}
+	return cfg, nil
+}
+
+func (s *source) secretsConfig() SecretsConfig {
+	return SecretsConfig{
+		Provider:       s.get("SECRETS_PROVIDER", SecretsEnv),
+		RefreshMinutes: s.getInt("SECRETS_REFRESH_MINUTES", 0),
+		Vault: secrets.VaultConfig{
+			Addr:      s.get("VAULT_ADDR", ""),
+			Token:     s.get("VAULT_TOKEN", ""),
+			Namespace: s.get("VAULT_NAMESPACE", ""),
+			Path:      s.get("VAULT_SECRET_PATH", ""),
+		},
+		AWS: secrets.AWSConfig{
+			Region:          s.get("AWS_REGION", ""),
+			SecretID:        s.get("AWS_SECRET_ID", ""),
+			AccessKeyID:     s.get("AWS_ACCESS_KEY_ID", ""),
+			SecretAccessKey: s.get("AWS_SECRET_ACCESS_KEY", ""),
+			SessionToken:    s.get("AWS_SESSION_TOKEN", ""),
+			Endpoint:        s.get("AWS_SECRETS_ENDPOINT", ""),
+		},
+	}
+}
+
+// newStore returns the store of the configured secrets manager, nil for the env provider
+func (c *SecretsConfig) newStore() *secrets.Store {
+	switch c.Provider {
+	case SecretsVault:
+		return secrets.NewStore(secrets.NewVaultProvider(c.Vault, nil))
+	case SecretsAWS:
+		return secrets.NewStore(secrets.NewAWSProvider(c.AWS, nil))
+	}
+	return nil
+}
+
+// config builds the configuration from the source, recording the values that don't parse
+func (s *source) config() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "localhost"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", "postgres"),
-			DBName:   getEnv("DB_NAME", "ecommerce"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Driver:     s.get("DB_DRIVER", DriverPostgres),
+			SQLitePath: s.get("DB_SQLITE_PATH", "ecommerce.db"),
+
+			Host:     s.get("DB_HOST", "localhost"),
+			Port:     s.get("DB_PORT", "5432"),
+			User:     s.get("DB_USER", "postgres"),
+			Password: s.get("DB_PASSWORD", "postgres"),
+			DBName:   s.get("DB_NAME", "ecommerce"),
+			SSLMode:  s.get("DB_SSLMODE", "disable"),
+
+			ReplicaDSN: s.get("DB_REPLICA_DSN", ""),
+
+			MaxOpenConns:           s.getInt("DB_MAX_OPEN_CONNS", 25),
+			MaxIdleConns:           s.getInt("DB_MAX_IDLE_CONNS", 10),
+			ConnMaxLifetimeMinutes: s.getInt("DB_CONN_MAX_LIFETIME_MINUTES", 30),
+			ConnMaxIdleMinutes:     s.getInt("DB_CONN_MAX_IDLE_MINUTES", 5),
+			QueryTimeoutSeconds:    s.getInt("DB_QUERY_TIMEOUT_SECONDS", 10),
+
+			IDStrategy: s.get("DB_ID_STRATEGY", string(entity.IDStrategyUUIDv7)),
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8080"),
+			Port:         s.get("SERVER_PORT", "8080"),
+			MaxBodyBytes: s.getInt("MAX_BODY_BYTES", 64<<10),
+
+			RequestTimeoutSeconds: s.getInt("REQUEST_TIMEOUT_SECONDS", 30),
 		},
 		Webhook: WebhookConfig{
-			Secret: getEnv("WEBHOOK_SECRET", "your-webhook-secret-key"),
+			Secret:              s.get("WEBHOOK_SECRET", ""),
+			ProductEventsURL:    s.get("PRODUCT_EVENTS_URL", ""),
+			ProductEventsSecret: s.get("PRODUCT_EVENTS_SECRET", "your-product-events-secret"),
+
+			StripeSecret:           s.get("STRIPE_WEBHOOK_SECRET", ""),
+			PayPalWebhookID:        s.get("PAYPAL_WEBHOOK_ID", ""),
+			MercadoPagoSecret:      s.get("MERCADOPAGO_WEBHOOK_SECRET", ""),
+			MercadoPagoAccessToken: s.get("MERCADOPAGO_ACCESS_TOKEN", ""),
 		},
 		JWT: JWTConfig{
-			Secret:          getEnv("JWT_SECRET", "your-jwt-secret-key-change-in-production"),
-			ExpirationHours: getEnvAsInt("JWT_EXPIRATION_HOURS", 24),
+			Secret:          s.get("JWT_SECRET", ""),
+			PreviousSecrets: s.getList("JWT_PREVIOUS_SECRETS", ""),
+			ExpirationHours: s.getInt("JWT_EXPIRATION_HOURS", 24),
+		},
+		Invoice: InvoiceConfig{
+			StoreName: s.get("INVOICE_STORE_NAME", "Go E-Commerce"),
+		},
+		Queue: QueueConfig{
+			WindowSeconds: s.getInt("QUEUE_WINDOW_SECONDS", 120),
+			MaxWindows:    s.getInt("QUEUE_MAX_WINDOWS", 50),
+		},
+		Archive: ArchiveConfig{
+			OrdersAfterYears:     s.getInt("ARCHIVE_ORDERS_AFTER_YEARS", 3),
+			AuditLogsAfterDays:   s.getInt("ARCHIVE_AUDIT_LOGS_AFTER_DAYS", 365),
+			WebhookLogsAfterDays: s.getInt("ARCHIVE_WEBHOOK_LOGS_AFTER_DAYS", 90),
+			BatchSize:            s.getInt("ARCHIVE_BATCH_SIZE", 500),
+		},
+		Fraud: FraudConfig{
+			RiskBlockThreshold:    s.getInt("FRAUD_RISK_BLOCK_THRESHOLD", 80),
+			Blocklist:             s.getList("FRAUD_BLOCKLIST", ""),
+			VelocityMaxOrders:     s.getInt("FRAUD_VELOCITY_MAX_ORDERS", 5),
+			VelocityWindowMinutes: s.getInt("FRAUD_VELOCITY_WINDOW_MINUTES", 60),
+			ReviewAddressMismatch: s.getBool("FRAUD_REVIEW_ADDRESS_MISMATCH", true),
+		},
+		Blocklist: BlocklistConfig{
+			CacheSeconds: s.getInt("BLOCKLIST_CACHE_SECONDS", 60),
+		},
+		Alerts: AdminAlertConfig{
+			MassDeleteThreshold:     s.getInt("ALERT_MASS_DELETE_THRESHOLD", 10),
+			MassDeleteWindowMinutes: s.getInt("ALERT_MASS_DELETE_WINDOW_MINUTES", 10),
+			PriceChangePercent:      s.getInt("ALERT_PRICE_CHANGE_PERCENT", 50),
+		},
+		Support: SupportConfig{
+			SupportDailyBudget: s.getInt("SUPPORT_DAILY_BUDGET", 200),
+			AdminDailyBudget:   s.getInt("SUPPORT_ADMIN_DAILY_BUDGET", 2000),
+		},
+		Refunds: RefundConfig{
+			ProviderURL:    s.get("REFUND_PROVIDER_URL", ""),
+			ProviderSecret: s.get("REFUND_PROVIDER_SECRET", ""),
+		},
+		Captures: CaptureConfig{
+			ProviderURL:    s.get("CAPTURE_PROVIDER_URL", ""),
+			ProviderSecret: s.get("CAPTURE_PROVIDER_SECRET", ""),
+		},
+		SMS: SMSConfig{
+			Provider:   s.get("SMS_PROVIDER", SMSLog),
+			APIURL:     s.get("SMS_API_URL", ""),
+			AccountSID: s.get("SMS_ACCOUNT_SID", ""),
+			AuthToken:  s.get("SMS_AUTH_TOKEN", ""),
+			From:       s.get("SMS_FROM", ""),
+			Events:     s.getList("SMS_EVENTS", "order.shipped,phone.verification"),
+		},
+		Shipping: ShippingConfig{
+			WarehouseCode: s.get("SHIPPING_WAREHOUSE_CODE", "main"),
+			WarehouseName: s.get("SHIPPING_WAREHOUSE_NAME", "Main warehouse"),
+			HandlingDays:  s.getInt("SHIPPING_HANDLING_DAYS", 1),
+			CutoffHour:    s.getInt("SHIPPING_CUTOFF_HOUR", 14),
+		},
+		RateLimit: RateLimitConfig{
+			Requests:      s.getInt("RATE_LIMIT_REQUESTS", 300),
+			WindowSeconds: s.getInt("RATE_LIMIT_WINDOW_SECONDS", 60),
+			Enforce:       s.getBool("RATE_LIMIT_ENFORCE", false),
+		},
+		Maintenance: MaintenanceConfig{
+			Enabled:           s.getBool("MAINTENANCE_MODE", false),
+			RetryAfterSeconds: s.getInt("MAINTENANCE_RETRY_AFTER_SECONDS", 300),
+			Message:           s.get("MAINTENANCE_MESSAGE", ""),
+		},
+		Access: AccessConfig{
+			RequireForRegistration: s.getBool("ACCESS_CODE_REGISTRATION", false),
+			RequireForBrowsing:     s.getBool("ACCESS_CODE_BROWSING", false),
+		},
+		Login: LoginConfig{
+			MaxFailures:       s.getInt("LOGIN_MAX_FAILURES", 5),
+			IPMaxFailures:     s.getInt("LOGIN_IP_MAX_FAILURES", 20),
+			WindowMinutes:     s.getInt("LOGIN_FAILURE_WINDOW_MINUTES", 15),
+			LockoutSeconds:    s.getInt("LOGIN_LOCKOUT_SECONDS", 60),
+			MaxLockoutMinutes: s.getInt("LOGIN_MAX_LOCKOUT_MINUTES", 60),
+		},
+		Orders: OrderConfig{
+			Workflow:      s.get("ORDER_WORKFLOW", WorkflowSimple),
+			DraftLinkURL:  s.get("DRAFT_ORDER_LINK_URL", "http://localhost:3000/pay"),
+			DraftLinkDays: s.getInt("DRAFT_ORDER_LINK_DAYS", 7),
+		},
+		Pricing: PricingConfig{
+			TaxRate: s.getFloat("TAX_RATE", 0),
+		},
+		Loyalty: LoyaltyConfig{
+			EarnRate:   s.getFloat("LOYALTY_EARN_RATE", 1),
+			PointValue: s.getFloat("LOYALTY_POINT_VALUE", 0.01),
+		},
+		I18n: I18nConfig{
+			DefaultLocale: s.get("DEFAULT_LOCALE", "en"),
+		},
+		Feed: FeedConfig{
+			StoreURL: s.get("FEED_STORE_URL", "http://localhost:3000"),
+			Currency: s.get("FEED_CURRENCY", "USD"),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   s.getList("CORS_ALLOWED_ORIGINS", ""),
+			AllowedMethods:   s.getList("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE"),
+			AllowedHeaders:   s.getList("CORS_ALLOWED_HEADERS", "API-Version,Authorization,Content-Type,If-Match,X-Access-Code"),
+			AllowCredentials: s.getBool("CORS_ALLOW_CREDENTIALS", false),
+			MaxAgeSeconds:    s.getInt("CORS_MAX_AGE_SECONDS", 600),
+		},
+		APIVersions: APIVersionConfig{
+			Default:    s.get("API_DEFAULT_VERSION", "v1"),
+			Deprecated: s.getList("API_DEPRECATED_VERSIONS", ""),
+			SunsetDate: s.get("API_SUNSET_DATE", ""),
+		},
+		Jobs: JobsConfig{
+			Enabled:                 s.getBool("JOBS_ENABLED", true),
+			Workers:                 s.getInt("JOBS_WORKERS", 2),
+			QueueSchedule:           s.get("JOB_QUEUE_SCHEDULE", "@every 30s"),
+			WebhookRetrySchedule:    s.get("JOB_WEBHOOK_RETRY_SCHEDULE", "@every 1m"),
+			NoncePurgeSchedule:      s.get("JOB_NONCE_PURGE_SCHEDULE", "@hourly"),
+			LowStockSchedule:        s.get("JOB_LOW_STOCK_SCHEDULE", "0 8 * * *"),
+			CatalogReportSchedule:   s.get("JOB_CATALOG_REPORT_SCHEDULE", "0 3 * * *"),
+			TokenPurgeSchedule:      s.get("JOB_TOKEN_PURGE_SCHEDULE", "@hourly"),
+			WebhookDeliverySchedule: s.get("JOB_WEBHOOK_DELIVERY_SCHEDULE", "@every 15s"),
+			CatalogFeedSchedule:     s.get("JOB_CATALOG_FEED_SCHEDULE", "@hourly"),
+			LowStockThreshold:       s.getInt("LOW_STOCK_THRESHOLD", 5),
 		},
 	}
 }
 
 func (c *DatabaseConfig) DSN() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
-		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode)
+		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode) + c.statementTimeout()
 }
 
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// ReplicaConnectionString returns the replica DSN with the same statement timeout
+// as the primary
+func (c *DatabaseConfig) ReplicaConnectionString() string {
+	timeout := c.statementTimeout()
+	if timeout == "" || !strings.Contains(c.ReplicaDSN, "://") {
+		return c.ReplicaDSN + timeout
 	}
-	return defaultValue
-}
-
-func getEnvAsInt(key string, defaultValue int) int {
-	valueStr := os.Getenv(key)
-	if valueStr == "" {
-		return defaultValue
+	// URL form, the setting goes into the query string
+	separator := "?"
+	if strings.Contains(c.ReplicaDSN, "?") {
+		separator = "&"
 	}
+	return c.ReplicaDSN + separator + strings.TrimSpace(timeout)
+}
 
-	var value int
-	_, err := fmt.Sscanf(valueStr, "%d", &value)
-	if err != nil {
-		return defaultValue
+// statementTimeout makes Postgres cancel statements running past the query
+// timeout, so the server frees them even when the client is gone
+func (c *DatabaseConfig) statementTimeout() string {
+	if c.QueryTimeoutSeconds <= 0 {
+		return ""
 	}
-	return value
+	return fmt.Sprintf(" statement_timeout=%d", c.QueryTimeoutSeconds*1000)
 }
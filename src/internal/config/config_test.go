@@ -0,0 +1,206 @@
+package config
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// fakeEnv builds an environment lookup from key=value pairs
+func fakeEnv(pairs ...string) func(string) (string, bool) {
+	env := make(map[string]string)
+	for _, pair := range pairs {
+		key, value, _ := strings.Cut(pair, "=")
+		env[key] = value
+	}
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSource_DotEnvFileWithEnvironmentOverrides(t *testing.T) {
+	path := writeFile(t, "app.env", `
+# Comment
+export DB_HOST=db.internal
+DB_PORT="6543"
+SERVER_PORT=9000
+CORS_ALLOWED_ORIGINS=https://shop.example.com, https://admin.example.com
+`)
+
+	s, err := newSource(fakeEnv("CONFIG_FILE="+path, "SERVER_PORT=7000"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cfg := s.config()
+
+	if cfg.Database.Host != "db.internal" || cfg.Database.Port != "6543" {
+		t.Errorf("expected the database from the file, got %s:%s", cfg.Database.Host, cfg.Database.Port)
+	}
+	if cfg.Server.Port != "7000" {
+		t.Errorf("expected the environment to win, got port %s", cfg.Server.Port)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 2 || cfg.CORS.AllowedOrigins[1] != "https://admin.example.com" {
+		t.Errorf("unexpected origins %v", cfg.CORS.AllowedOrigins)
+	}
+	if cfg.Database.User != "postgres" {
+		t.Errorf("expected the default for unset settings, got %s", cfg.Database.User)
+	}
+}
+
+func TestSource_YAMLFile(t *testing.T) {
+	path := writeFile(t, "config.yaml", `
+DB_DRIVER: sqlite
+JOBS_WORKERS: 4
+RATE_LIMIT_ENFORCE: true
+CORS_ALLOWED_METHODS: [GET, POST]
+`)
+
+	s, err := newSource(fakeEnv("CONFIG_FILE=" + path))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	cfg := s.config()
+
+	if cfg.Database.Driver != DriverSQLite || cfg.Jobs.Workers != 4 || !cfg.RateLimit.Enforce {
+		t.Errorf("unexpected config %+v %+v %+v", cfg.Database, cfg.Jobs, cfg.RateLimit)
+	}
+	if strings.Join(cfg.CORS.AllowedMethods, ",") != "GET,POST" {
+		t.Errorf("unexpected methods %v", cfg.CORS.AllowedMethods)
+	}
+}
+
+func TestSource_YAMLRejectsNestedSettings(t *testing.T) {
+	path := writeFile(t, "config.yml", "database:\n  host: db\n")
+
+	if _, err := newSource(fakeEnv("CONFIG_FILE=" + path)); err == nil {
+		t.Error("expected an error for a nested setting")
+	}
+}
+
+func TestSource_MissingExplicitFile(t *testing.T) {
+	if _, err := newSource(fakeEnv("CONFIG_FILE=/nonexistent/app.env")); err == nil {
+		t.Error("expected an error for a missing config file")
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	s, _ := newSource(fakeEnv(
+		"CONFIG_FILE="+writeFile(t, "empty.env", ""),
+		"JWT_SECRET=short",
+		"DB_PORT=http",
+		"JOBS_WORKERS=two",
+		"TAX_RATE=20",
+		"ORDER_WORKFLOW=express",
+		"MERCADOPAGO_WEBHOOK_SECRET=mp-secret",
+		"FEED_STORE_URL=shop.example.com",
+		"DB_ID_STRATEGY=serial",
+		"API_DEPRECATED_VERSIONS=v0",
+		"API_SUNSET_DATE=next year",
+		"SMS_PROVIDER=twilio",
+		"SMS_EVENTS=order.shipped,order.lost",
+	))
+	cfg := s.config()
+	problems := append(s.problems, cfg.validate(true)...)
+
+	report := (&Error{Problems: problems}).Error()
+	for _, want := range []string{"JWT_SECRET: must be at least 32", "WEBHOOK_SECRET", "DB_PORT", "JOBS_WORKERS", "TAX_RATE", "ORDER_WORKFLOW", "MERCADOPAGO_ACCESS_TOKEN", "FEED_STORE_URL", "DB_ID_STRATEGY", "API_DEPRECATED_VERSIONS", "API_SUNSET_DATE", "SMS_ACCOUNT_SID", "SMS_AUTH_TOKEN", "SMS_EVENTS"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("expected the report to mention %s, got:\n%s", want, report)
+		}
+	}
+}
+
+func TestValidate_SecretsOnlyWhenNeeded(t *testing.T) {
+	s, _ := newSource(fakeEnv("CONFIG_FILE=" + writeFile(t, "empty.env", "")))
+	cfg := s.config()
+
+	if problems := cfg.validate(false); len(problems) != 0 {
+		t.Errorf("expected the defaults to be valid without secrets, got %v", problems)
+	}
+	if problems := cfg.validate(true); len(problems) != 2 {
+		t.Errorf("expected the JWT and webhook secrets to be required, got %v", problems)
+	}
+
+	cfg.JWT.Secret, cfg.Webhook.Secret = testSecret, "webhook-secret"
+	if problems := cfg.validate(true); len(problems) != 0 {
+		t.Errorf("expected a valid config, got %v", problems)
+	}
+}
+
+func TestValidate_SQLiteDriver(t *testing.T) {
+	s, _ := newSource(fakeEnv("CONFIG_FILE="+writeFile(t, "empty.env", ""), "DB_DRIVER=sqlite", "DB_HOST=", "DB_REPLICA_DSN=host=replica"))
+	problems := s.config().validate(false)
+
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "DB_REPLICA_DSN") {
+		t.Errorf("expected only the replica to be rejected, got %v", problems)
+	}
+}
+
+func TestLoad_ReturnsAggregatedError(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeFile(t, "app.env", "JWT_SECRET=short\n"))
+	t.Setenv("WEBHOOK_SECRET", "")
+
+	_, err := Load()
+	var cfgErr *Error
+	if !errors.As(err, &cfgErr) || len(cfgErr.Problems) != 2 {
+		t.Fatalf("expected a config error with 2 problems, got %v", err)
+	}
+
+	t.Setenv("JWT_SECRET", testSecret)
+	t.Setenv("WEBHOOK_SECRET", "webhook-secret")
+	if _, err := Load(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestLoad_SecretsFromVault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"data":{"JWT_SECRET":"` + testSecret + `","WEBHOOK_SECRET":"from-vault","DB_PASSWORD":"rotated"}}}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("CONFIG_FILE", writeFile(t, "app.env", "WEBHOOK_SECRET=from-file\n"))
+	t.Setenv("SECRETS_PROVIDER", SecretsVault)
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "token")
+	t.Setenv("VAULT_SECRET_PATH", "secret/data/go-ecommerce")
+	t.Setenv("DB_PASSWORD", "from-env")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cfg.JWT.Secret != testSecret || cfg.Webhook.Secret != "from-vault" || cfg.Database.Password != "rotated" {
+		t.Errorf("expected the vault secrets to win, got %q %q %q", cfg.JWT.Secret, cfg.Webhook.Secret, cfg.Database.Password)
+	}
+	if cfg.Secrets.Store == nil {
+		t.Error("expected the store to be kept for rotation")
+	}
+}
+
+func TestLoad_SecretsProviderNeedsItsSettings(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeFile(t, "app.env", ""))
+	t.Setenv("SECRETS_PROVIDER", SecretsAWS)
+
+	_, err := Load()
+	var cfgErr *Error
+	if !errors.As(err, &cfgErr) || len(cfgErr.Problems) != 3 {
+		t.Errorf("expected the region, secret ID and credentials to be reported, got %v", err)
+	}
+}
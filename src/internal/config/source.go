@@ -0,0 +1,179 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/secrets"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultFile is read when CONFIG_FILE is not set, if it exists
+const defaultFile = ".env"
+
+// source resolves settings by their environment variable name. The secrets
+// manager wins over the environment, which wins over the config file. Empty
+// values count as unset everywhere, so an empty variable doesn't hide the file
+// or the default.
+type source struct {
+	lookupEnv func(key string) (string, bool)
+	file      map[string]string
+	secrets   *secrets.Store // nil without a secrets manager
+	// problems collects the values that don't parse, reported with the validation errors
+	problems []string
+}
+
+// newSource reads the config file named by CONFIG_FILE, or .env in the working
+// directory when that exists. A .yaml or .yml file holds the same keys as the
+// environment; any other file is read as KEY=VALUE lines.
+func newSource(lookupEnv func(key string) (string, bool)) (*source, error) {
+	s := &source{lookupEnv: lookupEnv, file: make(map[string]string)}
+
+	path, explicit := lookupEnv("CONFIG_FILE")
+	if !explicit || path == "" {
+		path = defaultFile
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !explicit {
+			return s, nil
+		}
+		return nil, fmt.Errorf("Failed to read config file: %w", err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = s.parseYAML(path, data)
+	default:
+		err = s.parseDotEnv(path, data)
+	}
+	return s, err
+}
+
+// parseDotEnv reads KEY=VALUE lines. Blank lines and lines starting with #
+// are skipped, an "export " prefix and quotes around the value are dropped.
+func (s *source) parseDotEnv(path string, data []byte) error {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return fmt.Errorf("%s:%d: expected KEY=VALUE", path, n)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		s.file[key] = value
+	}
+	return scanner.Err()
+}
+
+// parseYAML reads a mapping of setting names to scalars. Lists are joined
+// with commas, the way the list settings are written in the environment.
+func (s *source) parseYAML(path string, data []byte) error {
+	var settings map[string]interface{}
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	for key, raw := range settings {
+		switch value := raw.(type) {
+		case nil:
+			s.file[key] = ""
+		case []interface{}:
+			items := make([]string, len(value))
+			for i, item := range value {
+				items[i] = fmt.Sprint(item)
+			}
+			s.file[key] = strings.Join(items, ",")
+		case map[string]interface{}:
+			return fmt.Errorf("%s: %s must be a single value, settings use their environment variable names", path, key)
+		default:
+			s.file[key] = fmt.Sprint(value)
+		}
+	}
+	return nil
+}
+
+func (s *source) get(key, defaultValue string) string {
+	if s.secrets != nil {
+		if value := s.secrets.Get(key); value != "" {
+			return value
+		}
+	}
+	if value, ok := s.lookupEnv(key); ok && value != "" {
+		return value
+	}
+	if value := s.file[key]; value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func (s *source) getInt(key string, defaultValue int) int {
+	raw := s.get(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not a whole number", key, raw))
+		return defaultValue
+	}
+	return value
+}
+
+func (s *source) getFloat(key string, defaultValue float64) float64 {
+	raw := s.get(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not a number", key, raw))
+		return defaultValue
+	}
+	return value
+}
+
+func (s *source) getBool(key string, defaultValue bool) bool {
+	raw := s.get(key, "")
+	if raw == "" {
+		return defaultValue
+	}
+
+	value, err := strconv.ParseBool(strings.TrimSpace(raw))
+	if err != nil {
+		s.problems = append(s.problems, fmt.Sprintf("%s: %q is not true or false", key, raw))
+		return defaultValue
+	}
+	return value
+}
+
+// getList splits a comma-separated value, skipping empty entries
+func (s *source) getList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(s.get(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/sms"
+)
+
+// MinJWTSecretLength is the shortest JWT secret accepted, 256 bits for HS256
+const MinJWTSecretLength = 32
+
+// Error lists every problem found in the configuration
+type Error struct {
+	Problems []string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("Invalid configuration (%d problems):\n  - %s", len(e.Problems), strings.Join(e.Problems, "\n  - "))
+}
+
+// validate checks the settings and returns a problem per invalid setting,
+// named by its environment variable. The secrets are only checked when
+// secrets is set.
+func (c *Config) validate(secrets bool) []string {
+	var problems []string
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	db := c.Database
+	switch db.Driver {
+	case DriverPostgres:
+		for _, required := range []struct{ key, value string }{
+			{"DB_HOST", db.Host}, {"DB_USER", db.User}, {"DB_NAME", db.DBName},
+		} {
+			if strings.TrimSpace(required.value) == "" {
+				report("%s: is required with the postgres driver", required.key)
+			}
+		}
+		if !validPort(db.Port) {
+			report("DB_PORT: %q is not a port number", db.Port)
+		}
+	case DriverSQLite:
+		if strings.TrimSpace(db.SQLitePath) == "" {
+			report("DB_SQLITE_PATH: is required with the sqlite driver")
+		}
+		if db.ReplicaDSN != "" {
+			report("DB_REPLICA_DSN: read replicas need the postgres driver")
+		}
+	default:
+		report("DB_DRIVER: must be %s or %s, got %q", DriverPostgres, DriverSQLite, db.Driver)
+	}
+	for _, setting := range []struct {
+		key   string
+		value int
+	}{
+		{"DB_MAX_OPEN_CONNS", db.MaxOpenConns},
+		{"DB_MAX_IDLE_CONNS", db.MaxIdleConns},
+		{"DB_CONN_MAX_LIFETIME_MINUTES", db.ConnMaxLifetimeMinutes},
+		{"DB_CONN_MAX_IDLE_MINUTES", db.ConnMaxIdleMinutes},
+		{"DB_QUERY_TIMEOUT_SECONDS", db.QueryTimeoutSeconds},
+	} {
+		if setting.value < 0 {
+			report("%s: cannot be negative", setting.key)
+		}
+	}
+	if _, err := entity.NewIDGenerator(entity.IDStrategy(db.IDStrategy)); err != nil {
+		report("DB_ID_STRATEGY: must be %s, %s or %s, got %q", entity.IDStrategyUUIDv7, entity.IDStrategyULID, entity.IDStrategyUUIDv4, db.IDStrategy)
+	}
+
+	if !validPort(c.Server.Port) {
+		report("SERVER_PORT: %q is not a port number", c.Server.Port)
+	}
+	if c.Server.MaxBodyBytes <= 0 {
+		report("MAX_BODY_BYTES: must be greater than 0")
+	}
+	if c.Server.RequestTimeoutSeconds < 0 {
+		report("REQUEST_TIMEOUT_SECONDS: cannot be negative")
+	}
+
+	if secrets {
+		switch {
+		case c.JWT.Secret == "":
+			report("JWT_SECRET: is required, generate one with openssl rand -base64 32")
+		case len(c.JWT.Secret) < MinJWTSecretLength:
+			report("JWT_SECRET: must be at least %d characters, got %d", MinJWTSecretLength, len(c.JWT.Secret))
+		}
+		for i, previous := range c.JWT.PreviousSecrets {
+			if len(previous) < MinJWTSecretLength {
+				report("JWT_PREVIOUS_SECRETS: secret %d must be at least %d characters, got %d", i+1, MinJWTSecretLength, len(previous))
+			}
+		}
+		if c.JWT.ExpirationHours <= 0 {
+			report("JWT_EXPIRATION_HOURS: must be greater than 0")
+		}
+		if c.Webhook.Secret == "" {
+			report("WEBHOOK_SECRET: is required to verify payment webhooks")
+		}
+		if c.Webhook.ProductEventsURL != "" && c.Webhook.ProductEventsSecret == "" {
+			report("PRODUCT_EVENTS_SECRET: is required when PRODUCT_EVENTS_URL is set")
+		}
+		if c.Webhook.MercadoPagoSecret != "" && c.Webhook.MercadoPagoAccessToken == "" {
+			report("MERCADOPAGO_ACCESS_TOKEN: is required when MERCADOPAGO_WEBHOOK_SECRET is set")
+		}
+		if c.Refunds.ProviderURL != "" && c.Refunds.ProviderSecret == "" {
+			report("REFUND_PROVIDER_SECRET: is required when REFUND_PROVIDER_URL is set")
+		}
+		if c.Captures.ProviderURL != "" && c.Captures.ProviderSecret == "" {
+			report("CAPTURE_PROVIDER_SECRET: is required when CAPTURE_PROVIDER_URL is set")
+		}
+		if c.SMS.Provider == SMSTwilio && c.SMS.AuthToken == "" {
+			report("SMS_AUTH_TOKEN: is required with the twilio SMS provider")
+		}
+	}
+
+	switch c.SMS.Provider {
+	case SMSLog:
+	case SMSTwilio:
+		if c.SMS.AccountSID == "" || c.SMS.From == "" {
+			report("SMS_ACCOUNT_SID and SMS_FROM: are required with the twilio SMS provider")
+		}
+		if c.SMS.APIURL != "" {
+			if u, err := url.Parse(c.SMS.APIURL); err != nil || u.Scheme != "https" || u.Host == "" {
+				report("SMS_API_URL: must be an absolute https URL, got %q", c.SMS.APIURL)
+			}
+		}
+	default:
+		report("SMS_PROVIDER: must be %s or %s, got %q", SMSLog, SMSTwilio, c.SMS.Provider)
+	}
+	for _, event := range c.SMS.Events {
+		if event != SMSEventsNone && !slices.Contains(sms.Events, event) {
+			report("SMS_EVENTS: must list events among %s, got %q", strings.Join(sms.Events, ", "), event)
+		}
+	}
+	if c.Orders.Workflow != WorkflowSimple && c.Orders.Workflow != WorkflowFulfillment {
+		report("ORDER_WORKFLOW: must be %s or %s, got %q", WorkflowSimple, WorkflowFulfillment, c.Orders.Workflow)
+	}
+	if u, err := url.Parse(c.Orders.DraftLinkURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		report("DRAFT_ORDER_LINK_URL: must be an absolute http(s) URL, got %q", c.Orders.DraftLinkURL)
+	}
+	if c.Orders.DraftLinkDays <= 0 {
+		report("DRAFT_ORDER_LINK_DAYS: must be positive")
+	}
+	if c.Pricing.TaxRate < 0 || c.Pricing.TaxRate >= 1 {
+		report("TAX_RATE: must be a fraction between 0 and 1, e.g. 0.2 for 20%%")
+	}
+	if c.Loyalty.EarnRate < 0 || c.Loyalty.PointValue < 0 {
+		report("LOYALTY_EARN_RATE and LOYALTY_POINT_VALUE: can't be negative")
+	}
+	if locale, err := entity.NormalizeLocale(c.I18n.DefaultLocale); err != nil || locale != c.I18n.DefaultLocale {
+		report("DEFAULT_LOCALE: must be a language tag like en or pt-BR, got %q", c.I18n.DefaultLocale)
+	}
+	if u, err := url.Parse(c.Feed.StoreURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		report("FEED_STORE_URL: must be an absolute http(s) URL, got %q", c.Feed.StoreURL)
+	}
+	if len(c.Feed.Currency) != 3 || strings.ToUpper(c.Feed.Currency) != c.Feed.Currency {
+		report("FEED_CURRENCY: must be an ISO 4217 code like USD, got %q", c.Feed.Currency)
+	}
+	if c.Shipping.CutoffHour < 0 || c.Shipping.CutoffHour > 23 {
+		report("SHIPPING_CUTOFF_HOUR: must be between 0 and 23")
+	}
+	if c.RateLimit.Requests <= 0 || c.RateLimit.WindowSeconds <= 0 {
+		report("RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW_SECONDS: must be greater than 0")
+	}
+	versions := strings.Join(APIVersions, ", ")
+	if !slices.Contains(APIVersions, c.APIVersions.Default) {
+		report("API_DEFAULT_VERSION: must be one of %s, got %q", versions, c.APIVersions.Default)
+	}
+	for _, version := range c.APIVersions.Deprecated {
+		if !slices.Contains(APIVersions, version) {
+			report("API_DEPRECATED_VERSIONS: must list versions among %s, got %q", versions, version)
+		}
+	}
+	if c.APIVersions.SunsetDate != "" {
+		if _, err := time.Parse(time.DateOnly, c.APIVersions.SunsetDate); err != nil {
+			report("API_SUNSET_DATE: must be a date like 2027-06-30, got %q", c.APIVersions.SunsetDate)
+		}
+	}
+	if c.Maintenance.RetryAfterSeconds <= 0 {
+		report("MAINTENANCE_RETRY_AFTER_SECONDS: must be greater than 0")
+	}
+	for _, setting := range []struct {
+		key   string
+		value int
+	}{
+		{"LOGIN_MAX_FAILURES", c.Login.MaxFailures},
+		{"LOGIN_IP_MAX_FAILURES", c.Login.IPMaxFailures},
+		{"LOGIN_FAILURE_WINDOW_MINUTES", c.Login.WindowMinutes},
+		{"LOGIN_LOCKOUT_SECONDS", c.Login.LockoutSeconds},
+		{"LOGIN_MAX_LOCKOUT_MINUTES", c.Login.MaxLockoutMinutes},
+	} {
+		if setting.value <= 0 {
+			report("%s: must be greater than 0", setting.key)
+		}
+	}
+	if c.Archive.BatchSize <= 0 {
+		report("ARCHIVE_BATCH_SIZE: must be greater than 0")
+	}
+	if c.Jobs.Enabled && c.Jobs.Workers <= 0 {
+		report("JOBS_WORKERS: must be greater than 0 when JOBS_ENABLED is true")
+	}
+
+	return problems
+}
+
+// validate checks the settings needed to reach the secrets manager
+func (c *SecretsConfig) validate() []string {
+	var problems []string
+	report := func(key, provider string) {
+		problems = append(problems, fmt.Sprintf("%s: is required with SECRETS_PROVIDER=%s", key, provider))
+	}
+
+	switch c.Provider {
+	case SecretsEnv:
+	case SecretsVault:
+		if c.Vault.Addr == "" {
+			report("VAULT_ADDR", c.Provider)
+		}
+		if c.Vault.Token == "" {
+			report("VAULT_TOKEN", c.Provider)
+		}
+		if c.Vault.Path == "" {
+			report("VAULT_SECRET_PATH", c.Provider)
+		}
+	case SecretsAWS:
+		if c.AWS.Region == "" {
+			report("AWS_REGION", c.Provider)
+		}
+		if c.AWS.SecretID == "" {
+			report("AWS_SECRET_ID", c.Provider)
+		}
+		if c.AWS.AccessKeyID == "" || c.AWS.SecretAccessKey == "" {
+			report("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", c.Provider)
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("SECRETS_PROVIDER: must be %s, %s or %s, got %q", SecretsEnv, SecretsVault, SecretsAWS, c.Provider))
+	}
+	if c.RefreshMinutes < 0 {
+		problems = append(problems, "SECRETS_REFRESH_MINUTES: cannot be negative")
+	}
+	return problems
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
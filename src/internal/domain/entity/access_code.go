@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AccessCode lets people into a store that is in private beta: registering
+// takes a code when the store requires one, and so does browsing the catalog
+// without signing in
+type AccessCode struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Code      string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Note      string     `gorm:"type:varchar(255)"` // Who the code was handed out to, for the admins
+	MaxUses   int        `gorm:"not null"`          // Registrations the code allows, 0 for no limit
+	Uses      int        `gorm:"not null"`
+	ExpiresAt *time.Time // Never when nil
+	Active    bool       `gorm:"not null"`
+	CreatedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (c *AccessCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = NewID()
+	}
+	return nil
+}
+
+// Access code lengths, generated codes are accessCodeGeneratedLength long
+const (
+	minAccessCodeLength       = 4
+	maxAccessCodeLength       = 64
+	accessCodeGeneratedLength = 10
+)
+
+// accessCodeAlphabet leaves out 0, 1, I and O, which are easy to mistake for each other
+const accessCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// NormalizeAccessCode puts a code in the form it is stored and matched in:
+// trimmed and upper case, so codes typed by hand match
+func NormalizeAccessCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// GenerateAccessCode returns a random code that is easy to read out and type
+func GenerateAccessCode() string {
+	random := make([]byte, accessCodeGeneratedLength)
+	rand.Read(random)
+
+	code := make([]byte, accessCodeGeneratedLength)
+	for i, b := range random {
+		code[i] = accessCodeAlphabet[int(b)%len(accessCodeAlphabet)]
+	}
+	return string(code)
+}
+
+// Validate normalizes the code and checks the code and its limits
+func (c *AccessCode) Validate() error {
+	c.Code = NormalizeAccessCode(c.Code)
+	if len(c.Code) < minAccessCodeLength || len(c.Code) > maxAccessCodeLength {
+		return ValidationError("Access code must be between 4 and 64 characters")
+	}
+	if strings.Trim(c.Code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+		return ValidationError("Access code may only contain letters, digits, dashes and underscores")
+	}
+	if c.MaxUses < 0 {
+		return ValidationError("Max uses cannot be negative")
+	}
+	return nil
+}
+
+// Valid tells whether the code lets people browse the catalog: it is active
+// and hasn't expired. Codes that ran out of uses still do.
+func (c *AccessCode) Valid(now time.Time) bool {
+	return c.Active && (c.ExpiresAt == nil || now.Before(*c.ExpiresAt))
+}
+
+// Exhausted tells whether the code allows no more registrations
+func (c *AccessCode) Exhausted() bool {
+	return c.MaxUses > 0 && c.Uses >= c.MaxUses
+}
@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AlertRule identifies the rule that raised an admin alert
+type AlertRule string
+
+const (
+	AlertMassDelete       AlertRule = "mass_delete"
+	AlertLargePriceChange AlertRule = "large_price_change"
+	AlertRoleEscalation   AlertRule = "role_escalation"
+)
+
+// AdminAlert flags a suspicious admin action for review by other admins
+type AdminAlert struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Rule       AlertRule  `gorm:"type:varchar(50);not null;index"`
+	ActorID    *uuid.UUID `gorm:"type:uuid;index"` // Nullable when the action was not authenticated
+	AuditLogID uuid.UUID  `gorm:"type:uuid;not null"`
+	Message    string     `gorm:"type:text;not null"`
+	CreatedAt  time.Time  `gorm:"index"`
+}
+
+func (a *AdminAlert) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = NewID()
+	}
+	return nil
+}
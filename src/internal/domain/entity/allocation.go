@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Warehouse is a location orders are shipped from
+type Warehouse struct {
+	Code         string
+	Name         string
+	HandlingDays int // Business days between allocation and handing over to the carrier
+	CutoffHour   int // Allocations from this hour on (local time) start handling the next business day
+}
+
+// ShipDate returns the day an allocation made at now would leave the warehouse.
+// Weekends are not working days.
+func (w Warehouse) ShipDate(now time.Time) time.Time {
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if now.Hour() >= w.CutoffHour || !isBusinessDay(day) {
+		day = nextBusinessDay(day)
+	}
+	for i := 0; i < w.HandlingDays; i++ {
+		day = nextBusinessDay(day)
+	}
+	return day
+}
+
+// Allocation is the part of a cart item that ships from one warehouse
+type Allocation struct {
+	Warehouse Warehouse
+	Quantity  int
+	ShipDate  time.Time
+}
+
+// ItemAllocation describes how a cart item would be fulfilled
+type ItemAllocation struct {
+	ProductID   uuid.UUID
+	VariantID   *uuid.UUID
+	Quantity    int
+	Allocations []Allocation
+	Unallocated int // Quantity no warehouse has in stock
+}
+
+// IsSplit reports whether the item would not arrive as a single complete shipment
+func (i ItemAllocation) IsSplit() bool {
+	return len(i.Allocations) > 1 || (len(i.Allocations) == 1 && i.Unallocated > 0)
+}
+
+// AllocationPlan is the allocation preview of a whole cart. It is never persisted.
+type AllocationPlan struct {
+	Items []ItemAllocation
+}
+
+// IsFullyAllocated reports whether every item can be shipped in full
+func (p *AllocationPlan) IsFullyAllocated() bool {
+	for _, item := range p.Items {
+		if item.Unallocated > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ShipDate returns the day the last allocated unit leaves its warehouse, nil when nothing can be allocated
+func (p *AllocationPlan) ShipDate() *time.Time {
+	var latest *time.Time
+	for _, item := range p.Items {
+		for i := range item.Allocations {
+			if latest == nil || item.Allocations[i].ShipDate.After(*latest) {
+				latest = &item.Allocations[i].ShipDate
+			}
+		}
+	}
+	return latest
+}
+
+func isBusinessDay(day time.Time) bool {
+	return day.Weekday() != time.Saturday && day.Weekday() != time.Sunday
+}
+
+func nextBusinessDay(day time.Time) time.Time {
+	day = day.AddDate(0, 0, 1)
+	for !isBusinessDay(day) {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day
+}
@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWarehouse_ShipDate(t *testing.T) {
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{"before cutoff", time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC), time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)},
+		{"after cutoff", time.Date(2026, time.March, 4, 15, 0, 0, 0, time.UTC), time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"friday after cutoff", time.Date(2026, time.March, 6, 15, 0, 0, 0, time.UTC), time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)},
+		{"saturday", time.Date(2026, time.March, 7, 9, 0, 0, 0, time.UTC), time.Date(2026, time.March, 10, 0, 0, 0, 0, time.UTC)},
+	}
+
+	warehouse := Warehouse{Code: "main", HandlingDays: 1, CutoffHour: 14}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := warehouse.ShipDate(tt.now); !got.Equal(tt.want) {
+				t.Errorf("ShipDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocationPlan(t *testing.T) {
+	shipDate := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.UTC)
+	complete := ItemAllocation{ProductID: uuid.New(), Quantity: 2, Allocations: []Allocation{{Quantity: 2, ShipDate: shipDate}}}
+	partial := ItemAllocation{ProductID: uuid.New(), Quantity: 3, Allocations: []Allocation{{Quantity: 1, ShipDate: shipDate}}, Unallocated: 2}
+
+	if complete.IsSplit() {
+		t.Error("expected a complete item not to be split")
+	}
+	if !partial.IsSplit() {
+		t.Error("expected a partially allocated item to be split")
+	}
+
+	if plan := (&AllocationPlan{Items: []ItemAllocation{complete}}); !plan.IsFullyAllocated() || !plan.ShipDate().Equal(shipDate) {
+		t.Error("expected a fully allocated plan shipping on the allocation date")
+	}
+	if plan := (&AllocationPlan{Items: []ItemAllocation{complete, partial}}); plan.IsFullyAllocated() {
+		t.Error("expected a partially allocated plan")
+	}
+	if plan := (&AllocationPlan{Items: []ItemAllocation{{Quantity: 1, Unallocated: 1}}}); plan.ShipDate() != nil {
+		t.Error("expected no ship date when nothing is allocated")
+	}
+}
@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsPeriod is the bucket size of a revenue series
+type AnalyticsPeriod string
+
+const (
+	PeriodDay   AnalyticsPeriod = "day"
+	PeriodWeek  AnalyticsPeriod = "week" // Weeks start on Monday, as in Postgres date_trunc
+	PeriodMonth AnalyticsPeriod = "month"
+)
+
+func (p AnalyticsPeriod) IsValid() bool {
+	switch p {
+	case PeriodDay, PeriodWeek, PeriodMonth:
+		return true
+	}
+	return false
+}
+
+// Start truncates t to the start of its period in the location of t, so
+// periods start at midnight in the zone a report is asked for
+func (p AnalyticsPeriod) Start(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case PeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case PeriodMonth:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	default:
+		return day
+	}
+}
+
+// Next returns the start of the period after the one starting at start
+func (p AnalyticsPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// RevenuePoint is the revenue of the paid orders placed in one period
+type RevenuePoint struct {
+	PeriodStart time.Time
+	Orders      int
+	Revenue     float64
+}
+
+// FillRevenueSeries returns one point per period between from and until,
+// taking the points found and adding empty ones for periods without sales, so
+// charts get an evenly spaced series. Periods start in the location of from.
+func FillRevenueSeries(points []RevenuePoint, period AnalyticsPeriod, from, until time.Time) []RevenuePoint {
+	found := make(map[int64]RevenuePoint, len(points))
+	for _, point := range points {
+		found[period.Start(point.PeriodStart.In(from.Location())).Unix()] = point
+	}
+
+	var series []RevenuePoint
+	for start := period.Start(from); start.Before(until); start = period.Next(start) {
+		point, ok := found[start.Unix()]
+		if !ok {
+			point = RevenuePoint{}
+		}
+		point.PeriodStart = start
+		series = append(series, point)
+	}
+	return series
+}
+
+// ProductSales is what one product sold over a date range
+type ProductSales struct {
+	ProductID uuid.UUID
+	Name      string // Empty when the product no longer exists
+	Units     int
+	Revenue   float64
+}
+
+// OrderStatusCount is the number of orders placed with a status
+type OrderStatusCount struct {
+	Status OrderStatus
+	Count  int
+}
+
+// SalesSummary gives the headline figures of a date range. Orders counts every
+// order placed, revenue and the average only the paid ones that were not
+// cancelled.
+type SalesSummary struct {
+	Orders            int
+	PaidOrders        int
+	Revenue           float64
+	AverageOrderValue float64
+	NewCustomers      int
+}
+
+func NewSalesSummary(orders, paidOrders int, revenue float64, newCustomers int) *SalesSummary {
+	summary := &SalesSummary{
+		Orders:       orders,
+		PaidOrders:   paidOrders,
+		Revenue:      revenue,
+		NewCustomers: newCustomers,
+	}
+	summary.AverageOrderValue = averageOrderValue(revenue, paidOrders)
+	return summary
+}
+
+func averageOrderValue(revenue float64, paidOrders int) float64 {
+	if paidOrders == 0 {
+		return 0
+	}
+	return math.Round(revenue/float64(paidOrders)*100) / 100
+}
+
+// CustomerSummary is the purchase history of a customer account over all
+// time, archived orders included. Like SalesSummary, Orders counts every
+// order placed, the spend and average only the paid ones that were not
+// cancelled.
+type CustomerSummary struct {
+	Orders             int
+	PaidOrders         int
+	LifetimeSpend      float64
+	AverageOrderValue  float64
+	LastOrderAt        *time.Time // Nil when the account never ordered
+	FavoriteCategories []CategorySales
+}
+
+func NewCustomerSummary(orders, paidOrders int, spend float64, lastOrderAt *time.Time) *CustomerSummary {
+	return &CustomerSummary{
+		Orders:            orders,
+		PaidOrders:        paidOrders,
+		LifetimeSpend:     math.Round(spend*100) / 100,
+		AverageOrderValue: averageOrderValue(spend, paidOrders),
+		LastOrderAt:       lastOrderAt,
+	}
+}
+
+// CategorySales is what a customer bought of the products of one category
+type CategorySales struct {
+	CategoryID uuid.UUID
+	Name       string
+	Units      int
+	Spend      float64
+}
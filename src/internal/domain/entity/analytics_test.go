@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestAnalyticsPeriod_Start(t *testing.T) {
+	at := time.Date(2024, 5, 15, 17, 30, 0, 0, time.UTC) // A Wednesday
+
+	assert.Equal(t, date("2024-05-15"), PeriodDay.Start(at))
+	assert.Equal(t, date("2024-05-13"), PeriodWeek.Start(at))
+	assert.Equal(t, date("2024-05-01"), PeriodMonth.Start(at))
+	assert.Equal(t, date("2024-05-13"), PeriodWeek.Start(date("2024-05-19")), "Sunday belongs to the week before")
+}
+
+func TestFillRevenueSeries(t *testing.T) {
+	t.Run("Days without sales are added", func(t *testing.T) {
+		points := []RevenuePoint{
+			{PeriodStart: date("2024-05-02"), Orders: 2, Revenue: 150},
+		}
+
+		series := FillRevenueSeries(points, PeriodDay, date("2024-05-01"), date("2024-05-04"))
+
+		assert.Equal(t, []RevenuePoint{
+			{PeriodStart: date("2024-05-01")},
+			{PeriodStart: date("2024-05-02"), Orders: 2, Revenue: 150},
+			{PeriodStart: date("2024-05-03")},
+		}, series)
+	})
+
+	t.Run("Months cover a partial first month", func(t *testing.T) {
+		points := []RevenuePoint{
+			{PeriodStart: date("2024-02-01"), Orders: 1, Revenue: 40},
+		}
+
+		series := FillRevenueSeries(points, PeriodMonth, date("2024-01-20"), date("2024-03-11"))
+
+		assert.Len(t, series, 3)
+		assert.Equal(t, date("2024-01-01"), series[0].PeriodStart)
+		assert.Equal(t, 40.0, series[1].Revenue)
+		assert.Equal(t, date("2024-03-01"), series[2].PeriodStart)
+	})
+}
+
+func TestNewSalesSummary(t *testing.T) {
+	summary := NewSalesSummary(5, 3, 100, 2)
+
+	assert.Equal(t, 33.33, summary.AverageOrderValue)
+	assert.Equal(t, 5, summary.Orders)
+	assert.Equal(t, 2, summary.NewCustomers)
+
+	assert.Zero(t, NewSalesSummary(2, 0, 0, 0).AverageOrderValue)
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ArchivedAuditLog is an audit log moved out of the hot audit_logs table once
+// it is past the retention window. The columns are kept as they were.
+type ArchivedAuditLog struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	UserID         *uuid.UUID     `gorm:"type:uuid;index"`
+	ImpersonatorID *uuid.UUID     `gorm:"type:uuid"`
+	Action         string         `gorm:"size:100;not null"`
+	ResourceType   string         `gorm:"size:100;not null"`
+	ResourceID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PayloadBefore  datatypes.JSON `gorm:"type:jsonb"`
+	PayloadAfter   datatypes.JSON `gorm:"type:jsonb"`
+	Timestamp      time.Time      `gorm:"not null;index"`
+	ArchivedAt     time.Time      `gorm:"not null"`
+}
+
+func NewArchivedAuditLog(log *AuditLog, archivedAt time.Time) *ArchivedAuditLog {
+	return &ArchivedAuditLog{
+		ID:             log.ID,
+		UserID:         log.UserID,
+		ImpersonatorID: log.ImpersonatorID,
+		Action:         log.Action,
+		ResourceType:   log.ResourceType,
+		ResourceID:     log.ResourceID,
+		PayloadBefore:  log.PayloadBefore,
+		PayloadAfter:   log.PayloadAfter,
+		Timestamp:      log.Timestamp,
+		ArchivedAt:     archivedAt,
+	}
+}
+
+// ArchivedWebhookLog is a processed webhook log moved out of the hot
+// webhook_logs table once it is past the retention window
+type ArchivedWebhookLog struct {
+	ID            uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	OrderID       uuid.UUID     `gorm:"type:uuid;not null;index"` // No foreign key, the order may be archived too
+	TransactionID string        `gorm:"type:varchar(255);not null;index"`
+	PaymentStatus PaymentStatus `gorm:"type:varchar(20);not null"`
+	Status        WebhookStatus `gorm:"type:varchar(20);not null"`
+	RetryCount    int
+	RawPayload    string `gorm:"type:text"`
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time `gorm:"not null;index"`
+	ArchivedAt    time.Time `gorm:"not null"`
+}
+
+func NewArchivedWebhookLog(log *WebhookLog, archivedAt time.Time) *ArchivedWebhookLog {
+	return &ArchivedWebhookLog{
+		ID:            log.ID,
+		OrderID:       log.OrderID,
+		TransactionID: log.TransactionID,
+		PaymentStatus: log.PaymentStatus,
+		Status:        log.Status,
+		RetryCount:    log.RetryCount,
+		RawPayload:    log.RawPayload,
+		ProcessedAt:   log.ProcessedAt,
+		CreatedAt:     log.CreatedAt,
+		ArchivedAt:    archivedAt,
+	}
+}
+
+// IsArchivable reports whether a webhook finished processing and can be moved to cold storage
+func (w *WebhookLog) IsArchivable() bool {
+	return w.Status == WebhookStatusCompleted || w.Status == WebhookStatusFailed
+}
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestNewArchivedAuditLog(t *testing.T) {
+	userID := uuid.New()
+	log := &AuditLog{
+		ID:            uuid.New(),
+		UserID:        &userID,
+		Action:        "UPDATE",
+		ResourceType:  "Product",
+		ResourceID:    uuid.New(),
+		PayloadBefore: datatypes.JSON(`{"Price":10}`),
+		PayloadAfter:  datatypes.JSON(`{"Price":12}`),
+		Timestamp:     time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	archivedAt := time.Now()
+
+	archived := NewArchivedAuditLog(log, archivedAt)
+
+	assert.Equal(t, log.ID, archived.ID)
+	assert.Equal(t, &userID, archived.UserID)
+	assert.Equal(t, log.ResourceID, archived.ResourceID)
+	assert.Equal(t, log.PayloadAfter, archived.PayloadAfter)
+	assert.Equal(t, log.Timestamp, archived.Timestamp)
+	assert.Equal(t, archivedAt, archived.ArchivedAt)
+}
+
+func TestNewArchivedWebhookLog(t *testing.T) {
+	processedAt := time.Date(2022, 3, 1, 9, 0, 5, 0, time.UTC)
+	log := &WebhookLog{
+		ID:            uuid.New(),
+		OrderID:       uuid.New(),
+		TransactionID: "txn_123",
+		PaymentStatus: Paid,
+		Status:        WebhookStatusCompleted,
+		RawPayload:    `{"order_id":"..."}`,
+		ProcessedAt:   &processedAt,
+		CreatedAt:     time.Date(2022, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+
+	archived := NewArchivedWebhookLog(log, time.Now())
+
+	assert.Equal(t, log.ID, archived.ID)
+	assert.Equal(t, log.TransactionID, archived.TransactionID)
+	assert.Equal(t, log.Status, archived.Status)
+	assert.Equal(t, &processedAt, archived.ProcessedAt)
+	assert.Equal(t, log.CreatedAt, archived.CreatedAt)
+}
+
+func TestWebhookLog_IsArchivable(t *testing.T) {
+	cases := map[WebhookStatus]bool{
+		WebhookStatusPending:    false,
+		WebhookStatusProcessing: false,
+		WebhookStatusCompleted:  true,
+		WebhookStatusFailed:     true,
+	}
+	for status, want := range cases {
+		assert.Equal(t, want, (&WebhookLog{Status: status}).IsArchivable(), status)
+	}
+}
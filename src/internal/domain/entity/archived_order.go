@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// ArchivedOrder is a finalized order moved out of the hot orders tables.
+// The full order (with items) is kept as a JSON snapshot; only the columns
+// needed for lookups are stored separately.
+type ArchivedOrder struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	CustomerID     int            `gorm:"not null;index"`
+	UserID         *uuid.UUID     `gorm:"type:uuid;index"`
+	Status         OrderStatus    `gorm:"type:varchar(20);not null"`
+	PaymentStatus  PaymentStatus  `gorm:"type:varchar(20);not null"`
+	TotalPrice     float64        `gorm:"type:decimal(10,2);not null"`
+	Snapshot       datatypes.JSON `gorm:"type:jsonb;not null"`
+	OrderCreatedAt time.Time      `gorm:"not null;index"`
+	ArchivedAt     time.Time      `gorm:"not null"`
+}
+
+func NewArchivedOrder(order *Order, archivedAt time.Time) (*ArchivedOrder, error) {
+	snapshot, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ArchivedOrder{
+		ID:             order.ID,
+		CustomerID:     order.CustomerID,
+		UserID:         order.UserID,
+		Status:         order.Status,
+		PaymentStatus:  order.PaymentStatus,
+		TotalPrice:     order.TotalPrice,
+		Snapshot:       datatypes.JSON(snapshot),
+		OrderCreatedAt: order.CreatedAt,
+		ArchivedAt:     archivedAt,
+	}, nil
+}
+
+// ToOrder restores the order from its snapshot
+func (a *ArchivedOrder) ToOrder() (*Order, error) {
+	var order Order
+	if err := json.Unmarshal(a.Snapshot, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Anonymize unlinks the archived order from the account that placed it, in
+// the snapshot as well
+func (a *ArchivedOrder) Anonymize() error {
+	order, err := a.ToOrder()
+	if err != nil {
+		return err
+	}
+	order.UserID = nil
+
+	snapshot, err := json.Marshal(order)
+	if err != nil {
+		return err
+	}
+	a.UserID = nil
+	a.Snapshot = datatypes.JSON(snapshot)
+	return nil
+}
+
+// IsArchivable reports whether an order reached a final state and can be moved to cold storage
+func (o *Order) IsArchivable() bool {
+	return o.Status == Completed || o.IsVoid()
+}
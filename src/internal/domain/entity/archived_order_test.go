@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArchivedOrder_RoundTrip(t *testing.T) {
+	userID := uuid.New()
+	order := &Order{
+		ID:            uuid.New(),
+		CustomerID:    42,
+		UserID:        &userID,
+		Status:        Completed,
+		PaymentStatus: Paid,
+		TotalPrice:    150,
+		Products: []OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 3, Price: 50, TotalPrice: 150},
+		},
+		CreatedAt: time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	archived, err := NewArchivedOrder(order, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, order.ID, archived.ID)
+	assert.Equal(t, order.CreatedAt, archived.OrderCreatedAt)
+
+	restored, err := archived.ToOrder()
+	assert.NoError(t, err)
+	assert.Equal(t, order.ID, restored.ID)
+	assert.Equal(t, order.CustomerID, restored.CustomerID)
+	assert.Equal(t, *order.UserID, *restored.UserID)
+	assert.Len(t, restored.Products, 1)
+	assert.Equal(t, order.Products[0].TotalPrice, restored.Products[0].TotalPrice)
+}
+
+func TestArchivedOrder_Anonymize(t *testing.T) {
+	userID := uuid.New()
+	order := &Order{ID: uuid.New(), CustomerID: 42, UserID: &userID, Status: Completed, TotalPrice: 150}
+	archived, err := NewArchivedOrder(order, time.Now())
+	assert.NoError(t, err)
+
+	assert.NoError(t, archived.Anonymize())
+
+	assert.Nil(t, archived.UserID)
+	restored, err := archived.ToOrder()
+	assert.NoError(t, err)
+	assert.Nil(t, restored.UserID, "the snapshot is anonymized too")
+	assert.Equal(t, order.TotalPrice, restored.TotalPrice)
+}
+
+func TestOrder_IsArchivable(t *testing.T) {
+	assert.True(t, (&Order{Status: Completed}).IsArchivable())
+	assert.True(t, (&Order{Status: Cancelled}).IsArchivable())
+	assert.True(t, (&Order{Status: Refunded}).IsArchivable())
+	assert.False(t, (&Order{Status: Pending}).IsArchivable())
+	assert.False(t, (&Order{Status: Shipped}).IsArchivable())
+}
@@ -0,0 +1,142 @@
+package entity
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var ErrInvalidAttributeValue = ValidationError("Invalid attribute value")
+
+// AttributeType is the kind of value an attribute holds
+type AttributeType string
+
+const (
+	AttributeText    AttributeType = "text"
+	AttributeNumber  AttributeType = "number"
+	AttributeBoolean AttributeType = "boolean"
+)
+
+// AttributeDefinition describes a product property such as "Material" or "Brand".
+// Unlike variants, attributes are descriptive and not purchasable on their own.
+type AttributeDefinition struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	Code      string        `gorm:"type:varchar(120);uniqueIndex;not null"` // Used as the filter key in product search
+	Name      string        `gorm:"type:varchar(100);not null"`
+	Type      AttributeType `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (d *AttributeDefinition) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = NewID()
+	}
+	return nil
+}
+
+func (d *AttributeDefinition) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return ValidationError("Attribute name is required")
+	}
+	if len(d.Name) > 100 {
+		return ValidationError("Attribute name must be at most 100 characters")
+	}
+	if d.Type != AttributeText && d.Type != AttributeNumber && d.Type != AttributeBoolean {
+		return ValidationError("Invalid attribute type. Must be 'text', 'number' or 'boolean'")
+	}
+	return nil
+}
+
+// ParseValue converts a query string value to the attribute's type
+func (d *AttributeDefinition) ParseValue(raw string) (interface{}, error) {
+	switch d.Type {
+	case AttributeNumber:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, ErrInvalidAttributeValue
+		}
+		return value, nil
+	case AttributeBoolean:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, ErrInvalidAttributeValue
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
+// ProductAttribute is the value of an attribute for one product.
+// Exactly one of the typed value columns is set, matching the definition's type.
+type ProductAttribute struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_attribute"`
+	AttributeID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_attribute;index"`
+	TextValue    *string   `gorm:"type:varchar(255)"`
+	NumberValue  *float64  `gorm:"type:decimal(12,3)"`
+	BooleanValue *bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	// Relations
+	Attribute *AttributeDefinition `gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+}
+
+func (a *ProductAttribute) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = NewID()
+	}
+	return nil
+}
+
+// SetValue stores value in the column matching the definition's type.
+// Values decoded from JSON arrive as string, float64 or bool.
+func (a *ProductAttribute) SetValue(definition *AttributeDefinition, value interface{}) error {
+	a.AttributeID = definition.ID
+	a.Attribute = definition
+	a.TextValue, a.NumberValue, a.BooleanValue = nil, nil, nil
+
+	switch definition.Type {
+	case AttributeText:
+		text, ok := value.(string)
+		text = strings.TrimSpace(text)
+		if !ok || text == "" || len(text) > 255 {
+			return ErrInvalidAttributeValue
+		}
+		a.TextValue = &text
+	case AttributeNumber:
+		number, ok := value.(float64)
+		if !ok {
+			return ErrInvalidAttributeValue
+		}
+		a.NumberValue = &number
+	case AttributeBoolean:
+		boolean, ok := value.(bool)
+		if !ok {
+			return ErrInvalidAttributeValue
+		}
+		a.BooleanValue = &boolean
+	default:
+		return ErrInvalidAttributeValue
+	}
+
+	return nil
+}
+
+// Value returns whichever typed value is set
+func (a *ProductAttribute) Value() interface{} {
+	switch {
+	case a.TextValue != nil:
+		return *a.TextValue
+	case a.NumberValue != nil:
+		return *a.NumberValue
+	case a.BooleanValue != nil:
+		return *a.BooleanValue
+	}
+	return nil
+}
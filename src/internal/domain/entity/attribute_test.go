@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAttributeDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     AttributeDefinition
+		wantErr bool
+	}{
+		{"text", AttributeDefinition{Name: "Material", Type: AttributeText}, false},
+		{"number", AttributeDefinition{Name: "Weight (kg)", Type: AttributeNumber}, false},
+		{"boolean", AttributeDefinition{Name: "Waterproof", Type: AttributeBoolean}, false},
+		{"missing name", AttributeDefinition{Name: " ", Type: AttributeText}, true},
+		{"unknown type", AttributeDefinition{Name: "Material", Type: "list"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.def.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAttributeDefinition_ParseValue(t *testing.T) {
+	number := &AttributeDefinition{Type: AttributeNumber}
+	if value, err := number.ParseValue("2.5"); err != nil || value != 2.5 {
+		t.Errorf("ParseValue(2.5) = %v, %v", value, err)
+	}
+	if _, err := number.ParseValue("heavy"); !errors.Is(err, ErrInvalidAttributeValue) {
+		t.Errorf("expected ErrInvalidAttributeValue, got %v", err)
+	}
+
+	boolean := &AttributeDefinition{Type: AttributeBoolean}
+	if value, err := boolean.ParseValue("true"); err != nil || value != true {
+		t.Errorf("ParseValue(true) = %v, %v", value, err)
+	}
+
+	text := &AttributeDefinition{Type: AttributeText}
+	if value, err := text.ParseValue("Cotton"); err != nil || value != "Cotton" {
+		t.Errorf("ParseValue(Cotton) = %v, %v", value, err)
+	}
+}
+
+func TestProductAttribute_SetValue(t *testing.T) {
+	material := &AttributeDefinition{ID: uuid.New(), Name: "Material", Type: AttributeText}
+	weight := &AttributeDefinition{ID: uuid.New(), Name: "Weight", Type: AttributeNumber}
+
+	var attr ProductAttribute
+	if err := attr.SetValue(material, " Cotton "); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attr.AttributeID != material.ID || attr.Value() != "Cotton" {
+		t.Errorf("expected trimmed text value for material, got %v", attr.Value())
+	}
+
+	if err := attr.SetValue(weight, 1.5); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if attr.TextValue != nil || attr.Value() != 1.5 {
+		t.Errorf("expected only the number value to be set, got %v", attr.Value())
+	}
+
+	if err := attr.SetValue(weight, "1.5"); !errors.Is(err, ErrInvalidAttributeValue) {
+		t.Errorf("expected ErrInvalidAttributeValue for a string number, got %v", err)
+	}
+	if err := attr.SetValue(material, ""); !errors.Is(err, ErrInvalidAttributeValue) {
+		t.Errorf("expected ErrInvalidAttributeValue for an empty text, got %v", err)
+	}
+}
@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"encoding/json"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,19 +12,21 @@ import (
 )
 
 type AuditLog struct {
-	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
-	UserID        *uuid.UUID     `gorm:"type:uuid;index"` // Nullable for system actions
-	Action        string         `gorm:"size:100;not null;index"`
-	ResourceType  string         `gorm:"size:100;not null;index"`
-	ResourceID    uuid.UUID      `gorm:"type:uuid;not null;index"`
-	PayloadBefore datatypes.JSON `gorm:"type:jsonb"`
-	PayloadAfter  datatypes.JSON `gorm:"type:jsonb"`
-	Timestamp     time.Time      `gorm:"not null;index"`
+	ID     uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	UserID *uuid.UUID `gorm:"type:uuid;index"` // Nullable for system actions
+	// ImpersonatorID is the admin who made the change while impersonating the user
+	ImpersonatorID *uuid.UUID     `gorm:"type:uuid;index"`
+	Action         string         `gorm:"size:100;not null;index"`
+	ResourceType   string         `gorm:"size:100;not null;index"`
+	ResourceID     uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PayloadBefore  datatypes.JSON `gorm:"type:jsonb"`
+	PayloadAfter   datatypes.JSON `gorm:"type:jsonb"`
+	Timestamp      time.Time      `gorm:"not null;index"`
 }
 
 func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
 	if a.ID == uuid.Nil {
-		a.ID = uuid.New()
+		a.ID = NewID()
 	}
 	if a.Timestamp.IsZero() {
 		a.Timestamp = time.Now()
@@ -32,3 +37,67 @@ func (a *AuditLog) BeforeCreate(tx *gorm.DB) error {
 func (a *AuditLog) TableName() string {
 	return "audit_logs"
 }
+
+// AuditChange is a top-level field whose value differs between the payloads
+// of an audit log. A side without the field has a nil value.
+type AuditChange struct {
+	Field  string
+	Before json.RawMessage
+	After  json.RawMessage
+}
+
+// Changes diffs the before and after payloads field by field, sorted by field
+// name. Creations list every field with no before value, deletions every field
+// with no after value. Payloads that are not JSON objects yield no changes.
+func (a *AuditLog) Changes() []AuditChange {
+	before, ok := payloadFields(a.PayloadBefore)
+	if !ok {
+		return nil
+	}
+	after, ok := payloadFields(a.PayloadAfter)
+	if !ok {
+		return nil
+	}
+
+	fields := make(map[string]bool, len(before)+len(after))
+	for field := range before {
+		fields[field] = true
+	}
+	for field := range after {
+		fields[field] = true
+	}
+
+	var changes []AuditChange
+	for field := range fields {
+		if sameJSON(before[field], after[field]) {
+			continue
+		}
+		changes = append(changes, AuditChange{Field: field, Before: before[field], After: after[field]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// payloadFields splits a payload into its fields. An empty payload has none.
+func payloadFields(payload datatypes.JSON) (map[string]json.RawMessage, bool) {
+	fields := make(map[string]json.RawMessage)
+	if len(payload) == 0 || string(payload) == "null" {
+		return fields, true
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return nil, false
+	}
+	return fields, true
+}
+
+// sameJSON compares two values by meaning, ignoring key order and spacing
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/datatypes"
+)
+
+func TestAuditLog_Changes(t *testing.T) {
+	t.Run("Update lists changed fields only", func(t *testing.T) {
+		log := &AuditLog{
+			PayloadBefore: datatypes.JSON(`{"Name":"Laptop","Price":999,"Tags":["a","b"]}`),
+			PayloadAfter:  datatypes.JSON(`{"Price": 899, "Name":"Laptop","Tags":["a","b"],"Cost":500}`),
+		}
+
+		changes := log.Changes()
+
+		assert.Equal(t, []AuditChange{
+			{Field: "Cost", After: json.RawMessage(`500`)},
+			{Field: "Price", Before: json.RawMessage(`999`), After: json.RawMessage(`899`)},
+		}, changes)
+	})
+
+	t.Run("Creation lists every field", func(t *testing.T) {
+		log := &AuditLog{PayloadAfter: datatypes.JSON(`{"Name":"Shoes","Quantity":3}`)}
+
+		changes := log.Changes()
+
+		assert.Len(t, changes, 2)
+		assert.Nil(t, changes[0].Before)
+	})
+
+	t.Run("Payloads that are not objects", func(t *testing.T) {
+		log := &AuditLog{PayloadBefore: datatypes.JSON(`"2024-05-01"`), PayloadAfter: datatypes.JSON(`"2024-05-02"`)}
+
+		assert.Empty(t, log.Changes())
+	})
+}
@@ -0,0 +1,12 @@
+package entity
+
+// BackupRow is one row of a table in a backup, by column name. Values are
+// nil, strings, numbers, booleans, times or, for binary columns, bytes.
+type BackupRow map[string]interface{}
+
+// BackupTable is a table a backup copies. Personal tables hold the accounts
+// and the personal data of customers.
+type BackupTable struct {
+	Name     string
+	Personal bool
+}
@@ -0,0 +1,193 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// BlocklistKind is what a blocklist entry matches
+type BlocklistKind string
+
+const (
+	BlockEmail   BlocklistKind = "email"    // One address, e.g. spam@example.com
+	BlockDomain  BlocklistKind = "domain"   // Every address at the domain and its subdomains, e.g. mailinator.com
+	BlockIPRange BlocklistKind = "ip_range" // A CIDR range, a single address is stored as a /32 or /128
+)
+
+func (k BlocklistKind) IsValid() bool {
+	return k == BlockEmail || k == BlockDomain || k == BlockIPRange
+}
+
+// BlocklistEntry keeps an email, email domain or IP range from registering,
+// signing in and placing orders
+type BlocklistEntry struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	Kind      BlocklistKind `gorm:"type:varchar(20);not null;uniqueIndex:idx_blocklist_entries_kind_value"`
+	Value     string        `gorm:"type:varchar(255);not null;uniqueIndex:idx_blocklist_entries_kind_value"`
+	Reason    string        `gorm:"type:varchar(255)"` // Why it was blocked, for the admins
+	CreatedBy *uuid.UUID    `gorm:"type:uuid"`
+	CreatedAt time.Time
+}
+
+func (e *BlocklistEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = NewID()
+	}
+	return nil
+}
+
+// Normalize validates the entry and puts its value in the form it is matched
+// in: emails and domains in lower case, IP ranges in CIDR notation
+func (e *BlocklistEntry) Normalize() error {
+	value := strings.ToLower(strings.TrimSpace(e.Value))
+	switch e.Kind {
+	case BlockEmail:
+		at := strings.LastIndex(value, "@")
+		if at <= 0 || at == len(value)-1 {
+			return ValidationError("Invalid email. Must be an address like spam@example.com")
+		}
+	case BlockDomain:
+		value = strings.TrimPrefix(value, "@")
+		if value == "" || strings.ContainsAny(value, "@/ ") || !strings.Contains(value, ".") {
+			return ValidationError("Invalid domain. Must be a domain like example.com")
+		}
+	case BlockIPRange:
+		if !strings.Contains(value, "/") {
+			ip := net.ParseIP(value)
+			if ip == nil {
+				return ValidationError("Invalid IP range. Must be an address or a CIDR range like 203.0.113.0/24")
+			}
+			if ip.To4() != nil {
+				value += "/32"
+			} else {
+				value += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return ValidationError("Invalid IP range. Must be an address or a CIDR range like 203.0.113.0/24")
+		}
+		value = network.String()
+	default:
+		return ValidationError("Invalid kind. Must be 'email', 'domain' or 'ip_range'")
+	}
+
+	if len(value) > 255 {
+		return ValidationError("Blocked value must be at most 255 characters")
+	}
+	if len(e.Reason) > 255 {
+		return ValidationError("Reason must be at most 255 characters")
+	}
+	e.Value = value
+	return nil
+}
+
+// HashedEmail is the value an email entry keeps once the account of the
+// address was deleted: the address is gone, and it is still blocked
+func HashedEmail(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Blocklist is the ruleset built from every blocklist entry, ready to match
+// emails and IP addresses against
+type Blocklist struct {
+	emails   map[string]*BlocklistEntry
+	domains  map[string]*BlocklistEntry
+	networks []blockedNetwork
+}
+
+type blockedNetwork struct {
+	network *net.IPNet
+	entry   *BlocklistEntry
+}
+
+// NewBlocklist builds the ruleset of entries. Entries whose value can't be
+// matched, e.g. an IP range edited by hand into nonsense, are left out.
+func NewBlocklist(entries []*BlocklistEntry) *Blocklist {
+	b := &Blocklist{emails: make(map[string]*BlocklistEntry), domains: make(map[string]*BlocklistEntry)}
+	for _, entry := range entries {
+		switch entry.Kind {
+		case BlockEmail:
+			b.emails[entry.Value] = entry
+		case BlockDomain:
+			b.domains[entry.Value] = entry
+		case BlockIPRange:
+			if _, network, err := net.ParseCIDR(entry.Value); err == nil {
+				b.networks = append(b.networks, blockedNetwork{network: network, entry: entry})
+			}
+		}
+	}
+	return b
+}
+
+// Len returns how many entries the ruleset matches with
+func (b *Blocklist) Len() int {
+	return len(b.emails) + len(b.domains) + len(b.networks)
+}
+
+// Contains tells whether the ruleset already has an entry of the kind with the
+// normalized value
+func (b *Blocklist) Contains(kind BlocklistKind, value string) bool {
+	switch kind {
+	case BlockEmail:
+		return b.emails[value] != nil || b.emails[HashedEmail(value)] != nil
+	case BlockDomain:
+		return b.domains[value] != nil
+	case BlockIPRange:
+		for _, blocked := range b.networks {
+			if blocked.entry.Value == value {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// MatchEmail returns the entry blocking the address, by the address itself or
+// its hash or its domain or a parent domain, or nil
+func (b *Blocklist) MatchEmail(email string) *BlocklistEntry {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if entry := b.emails[email]; entry != nil {
+		return entry
+	}
+	if entry := b.emails[HashedEmail(email)]; entry != nil {
+		return entry
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return nil
+	}
+	for domain := email[at+1:]; domain != ""; {
+		if entry := b.domains[domain]; entry != nil {
+			return entry
+		}
+		dot := strings.Index(domain, ".")
+		if dot < 0 {
+			break
+		}
+		domain = domain[dot+1:]
+	}
+	return nil
+}
+
+// MatchIP returns the entry whose range holds the address, or nil
+func (b *Blocklist) MatchIP(address string) *BlocklistEntry {
+	ip := net.ParseIP(strings.TrimSpace(address))
+	if ip == nil {
+		return nil
+	}
+	for _, blocked := range b.networks {
+		if blocked.network.Contains(ip) {
+			return blocked.entry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package entity
+
+import "testing"
+
+func TestBlocklistEntry_Normalize(t *testing.T) {
+	tests := []struct {
+		kind    BlocklistKind
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{BlockEmail, " Spam@Example.com ", "spam@example.com", false},
+		{BlockEmail, "example.com", "", true},
+		{BlockEmail, "spam@", "", true},
+		{BlockDomain, "@Mailinator.com", "mailinator.com", false},
+		{BlockDomain, "localhost", "", true},
+		{BlockDomain, "spam@example.com", "", true},
+		{BlockIPRange, "203.0.113.7", "203.0.113.7/32", false},
+		{BlockIPRange, "203.0.113.7/24", "203.0.113.0/24", false},
+		{BlockIPRange, "2001:DB8::1", "2001:db8::1/128", false},
+		{BlockIPRange, "203.0.113.0/33", "", true},
+		{BlockIPRange, "not-an-ip", "", true},
+		{"phone", "555-0100", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.kind)+" "+tt.value, func(t *testing.T) {
+			entry := BlocklistEntry{Kind: tt.kind, Value: tt.value}
+			err := entry.Normalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if !tt.wantErr && entry.Value != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, entry.Value)
+			}
+		})
+	}
+}
+
+func TestBlocklist_Match(t *testing.T) {
+	blocklist := NewBlocklist([]*BlocklistEntry{
+		{Kind: BlockEmail, Value: "spam@example.com"},
+		{Kind: BlockEmail, Value: HashedEmail("deleted@example.com")},
+		{Kind: BlockDomain, Value: "mailinator.com"},
+		{Kind: BlockIPRange, Value: "203.0.113.0/24"},
+		{Kind: BlockIPRange, Value: "2001:db8::/32"},
+		{Kind: BlockIPRange, Value: "nonsense"},
+	})
+
+	if blocklist.Len() != 5 {
+		t.Errorf("expected the unparsable range to be left out, got %d entries", blocklist.Len())
+	}
+
+	emails := map[string]bool{
+		"spam@example.com":         true,
+		"SPAM@Example.com":         true,
+		"ham@example.com":          false,
+		" Deleted@example.com":     true,
+		"anyone@mailinator.com":    true,
+		"anyone@eu.mailinator.com": true,
+		"anyone@notmailinator.com": false,
+		"not-an-email":             false,
+	}
+	for email, blocked := range emails {
+		if got := blocklist.MatchEmail(email) != nil; got != blocked {
+			t.Errorf("MatchEmail(%q) blocked = %v, want %v", email, got, blocked)
+		}
+	}
+
+	if !blocklist.Contains(BlockEmail, "deleted@example.com") {
+		t.Error("expected the hashed address to be contained")
+	}
+
+	addresses := map[string]bool{
+		"203.0.113.99":  true,
+		"203.0.114.1":   false,
+		"2001:db8:1::5": true,
+		"2001:db9::1":   false,
+		"":              false,
+	}
+	for address, blocked := range addresses {
+		if got := blocklist.MatchIP(address) != nil; got != blocked {
+			t.Errorf("MatchIP(%q) blocked = %v, want %v", address, got, blocked)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"strconv"
+	"time"
+)
+
+// FeedFormat is a file format marketplaces read product feeds in
+type FeedFormat string
+
+const (
+	FeedXML FeedFormat = "xml" // RSS 2.0 with the Google Shopping namespace, also read by Facebook catalogs
+	FeedCSV FeedFormat = "csv" // One row per item with the same attribute names as the XML feed
+)
+
+// FeedFormats lists every format a feed is rendered in
+var FeedFormats = []FeedFormat{FeedXML, FeedCSV}
+
+func (f FeedFormat) IsValid() bool {
+	return f == FeedXML || f == FeedCSV
+}
+
+// CatalogFeed is the product feed rendered in one format. There is one per
+// format, replaced by every run of the feed job.
+type CatalogFeed struct {
+	Format      FeedFormat `gorm:"type:varchar(10);primaryKey"`
+	Content     string     `gorm:"type:text;not null"`
+	Items       int        `gorm:"not null"`
+	GeneratedAt time.Time  `gorm:"not null"`
+}
+
+// Feed item availabilities
+const (
+	FeedInStock    = "in stock"
+	FeedOutOfStock = "out of stock"
+)
+
+// FeedItem is one sellable item of a product feed: a product, or a variant
+// of it grouped with its siblings by ItemGroupID
+type FeedItem struct {
+	ID                 string
+	ItemGroupID        string // Product ID of a variant, empty for products without variants
+	Title              string
+	Description        string
+	Link               string
+	Availability       string
+	Condition          string
+	Price              float64
+	ProductType        string // Category of the product
+	UnitPricingMeasure string // e.g. 0.5kg, for products sold by measure
+}
+
+// FeedItems returns the feed items of a product at the given time: the
+// product itself, or one item per variant when it has variants. link is the
+// storefront page of the product.
+func FeedItems(product *Product, link string, at time.Time) []FeedItem {
+	base := FeedItem{
+		ID:           product.ID.String(),
+		Title:        product.Name,
+		Description:  product.Description,
+		Link:         link,
+		Availability: availability(product.Quantity),
+		Condition:    "new",
+		Price:        product.PriceAt(at),
+	}
+	// Marketplaces reject items without a description
+	if base.Description == "" {
+		base.Description = product.Name
+	}
+	if len(product.Categories) > 0 {
+		base.ProductType = product.Categories[0].Name
+	}
+	if product.Measure.IsSet() {
+		base.UnitPricingMeasure = strconv.FormatFloat(product.Measure.Content, 'f', -1, 64) + string(product.Measure.Unit)
+	}
+
+	if !product.HasVariants() {
+		return []FeedItem{base}
+	}
+
+	items := make([]FeedItem, 0, len(product.Variants))
+	for _, variant := range product.Variants {
+		variant.Product = product
+		price, err := variant.PriceAt(at)
+		if err != nil {
+			continue
+		}
+
+		item := base
+		item.ID = variant.SKU
+		if item.ID == "" {
+			item.ID = variant.ID.String()
+		}
+		item.ItemGroupID = product.ID.String()
+		if title := variant.Title(); title != "" {
+			item.Title = product.Name + " - " + title
+		}
+		item.Availability = availability(variant.Quantity)
+		item.Price = price
+		items = append(items, item)
+	}
+	return items
+}
+
+func availability(quantity int) string {
+	if quantity > 0 {
+		return FeedInStock
+	}
+	return FeedOutOfStock
+}
@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedItems(t *testing.T) {
+	now := time.Date(2026, time.May, 1, 12, 0, 0, 0, time.UTC)
+	coffee := &Product{
+		ID: uuid.New(), Name: "Coffee Beans", Price: 12.5, Quantity: 3,
+		Measure:    UnitMeasure{Unit: UnitKilogram, Content: 0.5},
+		Categories: []Category{{Name: "Groceries"}},
+	}
+
+	items := FeedItems(coffee, "https://shop.example.com/products/coffee", now)
+
+	assert.Len(t, items, 1)
+	assert.Equal(t, coffee.ID.String(), items[0].ID)
+	assert.Empty(t, items[0].ItemGroupID)
+	assert.Equal(t, "Coffee Beans", items[0].Description, "A missing description falls back to the name")
+	assert.Equal(t, FeedInStock, items[0].Availability)
+	assert.Equal(t, "Groceries", items[0].ProductType)
+	assert.Equal(t, "0.5kg", items[0].UnitPricingMeasure)
+
+	override, sale := 25.0, 15.0
+	shirt := &Product{
+		ID: uuid.New(), Name: "T-Shirt", Description: "Cotton", Price: 20,
+		Variants: []ProductVariant{
+			{ID: uuid.New(), SKU: "TS-L-RED", Quantity: 0, Options: []VariantOption{{Name: "Size", Value: "L"}, {Name: "Color", Value: "Red"}}},
+			{ID: uuid.New(), Quantity: 4, Price_Override: &override},
+		},
+	}
+	shirt.PriceSchedule = []PriceChange{{ProductID: shirt.ID, Price: &sale, Scheduled: true, EffectiveFrom: now.Add(-time.Hour)}}
+
+	items = FeedItems(shirt, "https://shop.example.com/products/t-shirt", now)
+
+	assert.Len(t, items, 2)
+	assert.Equal(t, "TS-L-RED", items[0].ID)
+	assert.Equal(t, shirt.ID.String(), items[0].ItemGroupID)
+	assert.Equal(t, "T-Shirt - L / Red", items[0].Title)
+	assert.Equal(t, FeedOutOfStock, items[0].Availability)
+	assert.Equal(t, 15.0, items[0].Price, "The scheduled price in effect is the price")
+	assert.Equal(t, shirt.Variants[1].ID.String(), items[1].ID, "Variants without a SKU use their ID")
+	assert.Equal(t, 25.0, items[1].Price)
+}
@@ -0,0 +1,215 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CatalogIssueSeverity ranks how urgently a catalog issue needs fixing
+type CatalogIssueSeverity string
+
+const (
+	SeverityCritical CatalogIssueSeverity = "critical" // Blocks selling, e.g. a free product
+	SeverityWarning  CatalogIssueSeverity = "warning"  // Hurts the listing, e.g. a missing description
+	SeverityInfo     CatalogIssueSeverity = "info"     // Worth a look, e.g. an empty category
+)
+
+func (s CatalogIssueSeverity) IsValid() bool {
+	return s == SeverityCritical || s == SeverityWarning || s == SeverityInfo
+}
+
+func (s CatalogIssueSeverity) rank() int {
+	switch s {
+	case SeverityCritical:
+		return 0
+	case SeverityWarning:
+		return 1
+	}
+	return 2
+}
+
+// CatalogIssueCode identifies the check that found an issue
+type CatalogIssueCode string
+
+const (
+	IssueMissingDescription CatalogIssueCode = "missing_description"
+	IssueZeroPrice          CatalogIssueCode = "zero_price"
+	IssueOrphanVariant      CatalogIssueCode = "orphan_variant"
+	IssueIncompleteVariant  CatalogIssueCode = "incomplete_variant"
+	IssueEmptyCategory      CatalogIssueCode = "empty_category"
+	IssueBrokenSlug         CatalogIssueCode = "broken_slug"
+)
+
+// CatalogIssue is a single problem found in the catalog. Link is the API path
+// of the resource to fix.
+type CatalogIssue struct {
+	Code         CatalogIssueCode     `json:"code"`
+	Severity     CatalogIssueSeverity `json:"severity"`
+	ResourceType string               `json:"resource_type"`
+	ResourceID   uuid.UUID            `json:"resource_id"`
+	Message      string               `json:"message"`
+	Link         string               `json:"link"`
+}
+
+// CatalogReportTrigger records how a report was started
+type CatalogReportTrigger string
+
+const (
+	ReportManual    CatalogReportTrigger = "manual"
+	ReportScheduled CatalogReportTrigger = "scheduled"
+)
+
+// CatalogReport is the result of one catalog health scan
+type CatalogReport struct {
+	ID          uuid.UUID            `gorm:"type:uuid;primaryKey"`
+	Trigger     CatalogReportTrigger `gorm:"type:varchar(20);not null"`
+	TriggeredBy *uuid.UUID           `gorm:"type:uuid"` // Nil for scheduled runs
+	Critical    int                  `gorm:"not null;default:0"`
+	Warnings    int                  `gorm:"not null;default:0"`
+	Info        int                  `gorm:"not null;default:0"`
+	Issues      []CatalogIssue       `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time            `gorm:"index"`
+}
+
+func (r *CatalogReport) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+// NewCatalogReport orders issues from most to least severe and counts them per severity
+func NewCatalogReport(trigger CatalogReportTrigger, triggeredBy *uuid.UUID, issues []CatalogIssue) *CatalogReport {
+	sort.SliceStable(issues, func(i, j int) bool {
+		return issues[i].Severity.rank() < issues[j].Severity.rank()
+	})
+
+	report := &CatalogReport{Trigger: trigger, TriggeredBy: triggeredBy, Issues: issues}
+	for _, issue := range issues {
+		switch issue.Severity {
+		case SeverityCritical:
+			report.Critical++
+		case SeverityWarning:
+			report.Warnings++
+		default:
+			report.Info++
+		}
+	}
+	return report
+}
+
+// Filter returns the report's issues with the given severity
+func (r *CatalogReport) Filter(severity CatalogIssueSeverity) []CatalogIssue {
+	issues := make([]CatalogIssue, 0)
+	for _, issue := range r.Issues {
+		if issue.Severity == severity {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// CheckProduct returns the issues of a product and its variants. Variants and
+// Options must be loaded.
+func CheckProduct(product *Product) []CatalogIssue {
+	var issues []CatalogIssue
+	link := "/api/products/" + product.ID.String()
+
+	if strings.TrimSpace(product.Description) == "" {
+		issues = append(issues, CatalogIssue{
+			Code:         IssueMissingDescription,
+			Severity:     SeverityWarning,
+			ResourceType: "product",
+			ResourceID:   product.ID,
+			Message:      fmt.Sprintf("Product %q has no description", product.Name),
+			Link:         link,
+		})
+	}
+
+	if product.Price <= 0 {
+		issues = append(issues, CatalogIssue{
+			Code:         IssueZeroPrice,
+			Severity:     SeverityCritical,
+			ResourceType: "product",
+			ResourceID:   product.ID,
+			Message:      fmt.Sprintf("Product %q has a price of zero", product.Name),
+			Link:         link,
+		})
+	}
+
+	for _, variant := range product.Variants {
+		variantLink := "/api/variants/" + variant.ID.String()
+
+		if variant.Price_Override != nil && *variant.Price_Override <= 0 {
+			issues = append(issues, CatalogIssue{
+				Code:         IssueZeroPrice,
+				Severity:     SeverityCritical,
+				ResourceType: "variant",
+				ResourceID:   variant.ID,
+				Message:      fmt.Sprintf("Variant %s of %q overrides the price with zero", variant.SKU, product.Name),
+				Link:         variantLink,
+			})
+		}
+
+		if len(variant.Options) != len(product.Options) {
+			issues = append(issues, CatalogIssue{
+				Code:         IssueIncompleteVariant,
+				Severity:     SeverityWarning,
+				ResourceType: "variant",
+				ResourceID:   variant.ID,
+				Message:      fmt.Sprintf("Variant %s of %q has %d of the product's %d option values", variant.SKU, product.Name, len(variant.Options), len(product.Options)),
+				Link:         variantLink,
+			})
+		}
+	}
+
+	return issues
+}
+
+// CheckOrphanVariant reports a variant whose product no longer exists
+func CheckOrphanVariant(variant *ProductVariant) CatalogIssue {
+	return CatalogIssue{
+		Code:         IssueOrphanVariant,
+		Severity:     SeverityCritical,
+		ResourceType: "variant",
+		ResourceID:   variant.ID,
+		Message:      fmt.Sprintf("Variant %s belongs to product %s, which no longer exists", variant.SKU, variant.ProductID),
+		Link:         "/api/variants/" + variant.ID.String(),
+	}
+}
+
+// CheckCategory returns the issues of a category. productCount includes the
+// products of its subcategories, so a parent used only for grouping is not empty.
+func CheckCategory(category *Category, productCount int) []CatalogIssue {
+	var issues []CatalogIssue
+	link := "/api/categories/" + category.ID.String()
+
+	if category.Slug == "" || Slugify(category.Slug) != category.Slug {
+		issues = append(issues, CatalogIssue{
+			Code:         IssueBrokenSlug,
+			Severity:     SeverityWarning,
+			ResourceType: "category",
+			ResourceID:   category.ID,
+			Message:      fmt.Sprintf("Category %q has an invalid slug %q", category.Name, category.Slug),
+			Link:         link,
+		})
+	}
+
+	if productCount == 0 {
+		issues = append(issues, CatalogIssue{
+			Code:         IssueEmptyCategory,
+			Severity:     SeverityInfo,
+			ResourceType: "category",
+			ResourceID:   category.ID,
+			Message:      fmt.Sprintf("Category %q has no products", category.Name),
+			Link:         link,
+		})
+	}
+
+	return issues
+}
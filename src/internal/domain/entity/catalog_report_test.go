@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func issueCodes(issues []CatalogIssue) []CatalogIssueCode {
+	codes := make([]CatalogIssueCode, len(issues))
+	for i, issue := range issues {
+		codes[i] = issue.Code
+	}
+	return codes
+}
+
+func TestCheckProduct(t *testing.T) {
+	t.Run("Healthy product", func(t *testing.T) {
+		product := &Product{ID: uuid.New(), Name: "Laptop", Description: "Fast", Price: 999}
+
+		assert.Empty(t, CheckProduct(product))
+	})
+
+	t.Run("Missing description and zero price", func(t *testing.T) {
+		product := &Product{ID: uuid.New(), Name: "Laptop", Description: "  "}
+
+		issues := CheckProduct(product)
+
+		assert.Equal(t, []CatalogIssueCode{IssueMissingDescription, IssueZeroPrice}, issueCodes(issues))
+		assert.Equal(t, SeverityCritical, issues[1].Severity)
+		assert.Equal(t, "/api/products/"+product.ID.String(), issues[0].Link)
+	})
+
+	t.Run("Variant issues", func(t *testing.T) {
+		zero := 0.0
+		product := &Product{
+			ID: uuid.New(), Name: "T-Shirt", Description: "Cotton", Price: 20,
+			Options: []ProductOption{{Name: "Size"}, {Name: "Color"}},
+			Variants: []ProductVariant{
+				{ID: uuid.New(), SKU: "TS-L-RED", Options: []VariantOption{{Name: "Size"}, {Name: "Color"}}},
+				{ID: uuid.New(), SKU: "TS-FREE", Price_Override: &zero, Options: []VariantOption{{Name: "Size"}}},
+			},
+		}
+
+		issues := CheckProduct(product)
+
+		assert.Equal(t, []CatalogIssueCode{IssueZeroPrice, IssueIncompleteVariant}, issueCodes(issues))
+		assert.Equal(t, "variant", issues[0].ResourceType)
+		assert.Equal(t, "/api/variants/"+product.Variants[1].ID.String(), issues[1].Link)
+	})
+}
+
+func TestCheckCategory(t *testing.T) {
+	t.Run("Healthy category", func(t *testing.T) {
+		assert.Empty(t, CheckCategory(&Category{ID: uuid.New(), Name: "Home & Garden", Slug: "home-garden"}, 3))
+	})
+
+	t.Run("Broken slug and no products", func(t *testing.T) {
+		issues := CheckCategory(&Category{ID: uuid.New(), Name: "Gaming", Slug: "Gaming Stuff"}, 0)
+
+		assert.Equal(t, []CatalogIssueCode{IssueBrokenSlug, IssueEmptyCategory}, issueCodes(issues))
+		assert.Equal(t, SeverityInfo, issues[1].Severity)
+	})
+
+	t.Run("Missing slug", func(t *testing.T) {
+		issues := CheckCategory(&Category{ID: uuid.New(), Name: "Gaming"}, 1)
+
+		assert.Equal(t, []CatalogIssueCode{IssueBrokenSlug}, issueCodes(issues))
+	})
+}
+
+func TestNewCatalogReport(t *testing.T) {
+	issues := []CatalogIssue{
+		{Code: IssueEmptyCategory, Severity: SeverityInfo},
+		{Code: IssueMissingDescription, Severity: SeverityWarning},
+		{Code: IssueZeroPrice, Severity: SeverityCritical},
+		{Code: IssueOrphanVariant, Severity: SeverityCritical},
+	}
+
+	report := NewCatalogReport(ReportScheduled, nil, issues)
+
+	assert.Equal(t, 2, report.Critical)
+	assert.Equal(t, 1, report.Warnings)
+	assert.Equal(t, 1, report.Info)
+	assert.Equal(t, []CatalogIssueCode{IssueZeroPrice, IssueOrphanVariant, IssueMissingDescription, IssueEmptyCategory}, issueCodes(report.Issues))
+	assert.Len(t, report.Filter(SeverityCritical), 2)
+	assert.Empty(t, NewCatalogReport(ReportManual, nil, nil).Filter(SeverityWarning))
+}
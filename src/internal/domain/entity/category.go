@@ -1,34 +1,118 @@
 package entity
 
 import (
-	"errors"
+	"fmt"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
 	"gorm.io/gorm"
 )
 
+var ErrCategoryCycle = ConflictError("Category cannot be moved under itself or one of its descendants")
+
+var ErrCategoryMergeCycle = ConflictError("Category cannot be merged into itself or one of its descendants")
+
 type Category struct {
-	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name      string    `gorm:"type:varchar(100);unique;not null"`
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Name      string     `gorm:"type:varchar(100);unique;not null"`
+	Slug      string     `gorm:"type:varchar(120);uniqueIndex"`
+	ParentID  *uuid.UUID `gorm:"type:uuid;index"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
+	// MergedIntoID is set on a category deleted by merging it into another,
+	// whose slug then leads to the category it was merged into
+	MergedIntoID *uuid.UUID `gorm:"type:uuid;index"`
+
 	// Many-to-many relationship with products
 	Products []Product `gorm:"many2many:product_categories;"`
+
+	// Children is populated when the category is loaded as part of a tree
+	Children []*Category `gorm:"-"`
 }
 
 func (c *Category) BeforeCreate(tx *gorm.DB) error {
 	if c.ID == uuid.Nil {
-		c.ID = uuid.New()
+		c.ID = NewID()
 	}
 	return nil
 }
 
 func (c *Category) Validate() error {
 	if c.Name == "" {
-		return errors.New("Category name is required")
+		return ValidationError("Category name is required")
+	}
+	if c.ParentID != nil && *c.ParentID == c.ID {
+		return ErrCategoryCycle
 	}
 	return nil
 }
+
+// BuildCategoryTree links a flat list of categories into trees and returns
+// the roots. Categories whose parent is not in the list are treated as roots,
+// and the order of the input is kept among siblings.
+func BuildCategoryTree(categories []*Category) []*Category {
+	byID := make(map[uuid.UUID]*Category, len(categories))
+	for _, c := range categories {
+		c.Children = nil
+		byID[c.ID] = c
+	}
+
+	var roots []*Category
+	for _, c := range categories {
+		if c.ParentID != nil {
+			if parent, ok := byID[*c.ParentID]; ok {
+				parent.Children = append(parent.Children, c)
+				continue
+			}
+		}
+		roots = append(roots, c)
+	}
+	return roots
+}
+
+// Slugify turns a category name into a lowercase, URL-safe slug, e.g.
+// "Café & Bakery" becomes "cafe-bakery". Accents are stripped and any run of
+// other characters becomes a single hyphen.
+func Slugify(name string) string {
+	var b strings.Builder
+	hyphen := false
+	for _, r := range norm.NFD.String(strings.ToLower(name)) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining mark left over from decomposing an accented letter
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if hyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			hyphen = false
+			b.WriteRune(r)
+		default:
+			hyphen = true
+		}
+	}
+	if b.Len() == 0 {
+		return "category"
+	}
+	return b.String()
+}
+
+// UniqueSlug returns base if it is free, otherwise the first of base-2,
+// base-3, ... for which taken reports false
+func UniqueSlug(base string, taken func(slug string) (bool, error)) (string, error) {
+	slug := base
+	for n := 2; ; n++ {
+		exists, err := taken(slug)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return slug, nil
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+}
@@ -26,6 +26,18 @@ func TestCategory_Validate(t *testing.T) {
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "Category name is required")
 	})
+
+	t.Run("Invalid - own parent", func(t *testing.T) {
+		id := uuid.New()
+		category := &Category{
+			ID:       id,
+			Name:     "Electronics",
+			ParentID: &id,
+		}
+
+		err := category.Validate()
+		assert.ErrorIs(t, err, ErrCategoryCycle)
+	})
 }
 
 func TestCategory_BeforeCreate(t *testing.T) {
@@ -52,3 +64,47 @@ func TestCategory_BeforeCreate(t *testing.T) {
 		assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", category.ID.String())
 	})
 }
+
+func TestBuildCategoryTree(t *testing.T) {
+	electronics := &Category{ID: uuid.New(), Name: "Electronics"}
+	computers := &Category{ID: uuid.New(), Name: "Computers", ParentID: &electronics.ID}
+	laptops := &Category{ID: uuid.New(), Name: "Laptops", ParentID: &computers.ID}
+	phones := &Category{ID: uuid.New(), Name: "Phones", ParentID: &electronics.ID}
+	missingParent := uuid.New()
+	orphan := &Category{ID: uuid.New(), Name: "Orphan", ParentID: &missingParent}
+
+	roots := BuildCategoryTree([]*Category{computers, electronics, laptops, orphan, phones})
+
+	assert.Equal(t, []*Category{electronics, orphan}, roots)
+	assert.Equal(t, []*Category{computers, phones}, electronics.Children)
+	assert.Equal(t, []*Category{laptops}, computers.Children)
+	assert.Empty(t, laptops.Children)
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Electronics", "electronics"},
+		{"Home & Garden", "home-garden"},
+		{"  Café  Crème ", "cafe-creme"},
+		{"Kids' Toys (0-3)", "kids-toys-0-3"},
+		{"***", "category"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Slugify(tt.name), tt.name)
+	}
+}
+
+func TestUniqueSlug(t *testing.T) {
+	taken := map[string]bool{"books": true, "books-2": true}
+
+	slug, err := UniqueSlug("books", func(slug string) (bool, error) {
+		return taken[slug], nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "books-3", slug)
+}
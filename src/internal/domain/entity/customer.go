@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxCustomerAddresses is how many addresses a customer can keep
+const MaxCustomerAddresses = 10
+
+var (
+	phonePattern   = regexp.MustCompile(`^\+?[0-9][0-9 ()-]{5,19}$`)
+	countryPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// Customer is the personal data of a customer account kept apart from the
+// login credentials on User: contact details, addresses, marketing consent
+// and the customer group. It is optional, accounts start without one.
+type Customer struct {
+	UserID         uuid.UUID         `gorm:"type:uuid;primaryKey"`
+	Phone          string            `gorm:"type:varchar(32)"`
+	MarketingEmail bool              `gorm:"not null;default:false"`                     // Consented to marketing email
+	MarketingSMS   bool              `gorm:"not null;default:false"`                     // Consented to marketing text messages
+	CustomerGroup  string            `gorm:"type:varchar(50);not null;default:'retail'"` // Price tiers the customer buys at, set by an admin
+	Addresses      []CustomerAddress `gorm:"foreignKey:UserID;references:UserID;constraint:OnDelete:CASCADE"`
+	// The phone takes text messages once verified with a code sent to it,
+	// see customer_phone.go
+	PhoneVerifiedAt   *time.Time
+	PhoneCodeHash     string `gorm:"type:varchar(64)"` // SHA-256 of the last code sent, empty when none is pending
+	PhoneCodeSentAt   *time.Time
+	PhoneCodeAttempts int `gorm:"not null;default:0"` // Wrong codes entered since the last one was sent
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// CustomerAddress is a shipping or billing address of a customer
+type CustomerAddress struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID     uuid.UUID `gorm:"type:uuid;not null;index"`
+	Label      string    `gorm:"type:varchar(50)"` // e.g. Home, Work
+	Recipient  string    `gorm:"type:varchar(100);not null"`
+	Line1      string    `gorm:"type:varchar(200);not null"`
+	Line2      string    `gorm:"type:varchar(200)"`
+	City       string    `gorm:"type:varchar(100);not null"`
+	Region     string    `gorm:"type:varchar(100)"`
+	PostalCode string    `gorm:"type:varchar(20);not null"`
+	Country    string    `gorm:"type:varchar(2);not null"` // ISO 3166-1 alpha-2
+	IsDefault  bool      `gorm:"not null;default:false"`
+	CreatedAt  time.Time
+}
+
+func (a *CustomerAddress) BeforeCreate(tx *gorm.DB) error {
+	if a.ID == uuid.Nil {
+		a.ID = NewID()
+	}
+	return nil
+}
+
+// Group returns the customer group, retail when none was set
+func (c *Customer) Group() string {
+	if c.CustomerGroup == "" {
+		return RetailGroup
+	}
+	return c.CustomerGroup
+}
+
+func (c *Customer) Validate() error {
+	if c.UserID == uuid.Nil {
+		return ValidationError("Customer ID is required")
+	}
+	if c.CustomerGroup != "" {
+		if err := ValidateCustomerGroup(c.CustomerGroup); err != nil {
+			return err
+		}
+	}
+	if c.Phone != "" && !phonePattern.MatchString(c.Phone) {
+		return ValidationError("Phone must be 6 to 20 digits, optionally starting with +")
+	}
+	if len(c.Addresses) > MaxCustomerAddresses {
+		return ValidationError("A customer can keep at most 10 addresses")
+	}
+
+	defaults := 0
+	for i := range c.Addresses {
+		if err := c.Addresses[i].Validate(); err != nil {
+			return err
+		}
+		if c.Addresses[i].IsDefault {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return ValidationError("Only one address can be the default")
+	}
+	return nil
+}
+
+func (a *CustomerAddress) Validate() error {
+	for _, required := range []struct{ name, value string }{
+		{"Recipient", a.Recipient}, {"Address line 1", a.Line1}, {"City", a.City}, {"Postal code", a.PostalCode},
+	} {
+		if strings.TrimSpace(required.value) == "" {
+			return ValidationError(required.name + " is required")
+		}
+	}
+	if !countryPattern.MatchString(a.Country) {
+		return ValidationError("Country must be a two-letter ISO code, e.g. US")
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	// PhoneCodeTTL is how long a verification code can be entered
+	PhoneCodeTTL = 10 * time.Minute
+	// PhoneCodeResendAfter is how long to wait before another code is sent
+	PhoneCodeResendAfter = time.Minute
+	// MaxPhoneCodeAttempts is how many wrong codes void the one sent
+	MaxPhoneCodeAttempts = 5
+)
+
+// NewPhoneCode returns a random six digit verification code
+func NewPhoneCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
+
+// PhoneVerified tells whether the phone can be sent text messages
+func (c *Customer) PhoneVerified() bool {
+	return c.Phone != "" && c.PhoneVerifiedAt != nil
+}
+
+// StartPhoneVerification records the code about to be sent to the phone,
+// replacing any code sent before
+func (c *Customer) StartPhoneVerification(code string, now time.Time) error {
+	if c.Phone == "" {
+		return ValidationError("Add a phone number before verifying it")
+	}
+	if c.PhoneVerified() {
+		return ConflictError("Phone is already verified")
+	}
+	if c.PhoneCodeSentAt != nil && now.Sub(*c.PhoneCodeSentAt) < PhoneCodeResendAfter {
+		return ConflictError("A code was just sent, wait a minute before asking for another")
+	}
+
+	c.PhoneCodeHash = c.phoneCodeHash(code)
+	c.PhoneCodeSentAt = &now
+	c.PhoneCodeAttempts = 0
+	return nil
+}
+
+// VerifyPhone checks the code the customer entered against the one sent. A
+// wrong code counts as an attempt, and the customer's to save either way.
+func (c *Customer) VerifyPhone(code string, now time.Time) error {
+	if c.PhoneVerified() {
+		return ConflictError("Phone is already verified")
+	}
+	if c.PhoneCodeHash == "" || c.PhoneCodeSentAt == nil {
+		return ConflictError("No verification code was sent, ask for one first")
+	}
+	if now.Sub(*c.PhoneCodeSentAt) > PhoneCodeTTL {
+		return ConflictError("Verification code expired, ask for a new one")
+	}
+	if c.PhoneCodeAttempts >= MaxPhoneCodeAttempts {
+		return ConflictError("Too many wrong codes, ask for a new one")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(c.phoneCodeHash(code)), []byte(c.PhoneCodeHash)) != 1 {
+		c.PhoneCodeAttempts++
+		return ValidationError("Invalid verification code")
+	}
+
+	c.PhoneVerifiedAt = &now
+	c.clearPhoneCode()
+	return nil
+}
+
+// KeepPhoneVerification carries the verification of the previous customer
+// data over when the phone didn't change; a new phone has to be verified
+func (c *Customer) KeepPhoneVerification(previous *Customer) {
+	if c.Phone != previous.Phone {
+		return
+	}
+	c.PhoneVerifiedAt = previous.PhoneVerifiedAt
+	c.PhoneCodeHash = previous.PhoneCodeHash
+	c.PhoneCodeSentAt = previous.PhoneCodeSentAt
+	c.PhoneCodeAttempts = previous.PhoneCodeAttempts
+}
+
+func (c *Customer) clearPhoneCode() {
+	c.PhoneCodeHash = ""
+	c.PhoneCodeSentAt = nil
+	c.PhoneCodeAttempts = 0
+}
+
+// phoneCodeHash ties the code to the account and the number it was sent to
+func (c *Customer) phoneCodeHash(code string) string {
+	sum := sha256.Sum256([]byte(c.UserID.String() + ":" + c.Phone + ":" + code))
+	return hex.EncodeToString(sum[:])
+}
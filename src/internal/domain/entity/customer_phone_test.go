@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewPhoneCode(t *testing.T) {
+	code, err := NewPhoneCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !regexp.MustCompile(`^\d{6}$`).MatchString(code) {
+		t.Errorf("code = %q, want six digits", code)
+	}
+}
+
+func TestCustomer_VerifyPhone(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	customer := &Customer{UserID: uuid.New(), Phone: "+55 11 98765-4321"}
+
+	if err := customer.StartPhoneVerification("123456", now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := customer.StartPhoneVerification("654321", now.Add(30*time.Second)); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a resend too soon to be a conflict, got %v", err)
+	}
+
+	if err := customer.VerifyPhone("000000", now.Add(time.Minute)); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected a wrong code to be invalid, got %v", err)
+	}
+	if customer.PhoneCodeAttempts != 1 || customer.PhoneVerified() {
+		t.Fatalf("expected one failed attempt, got %d", customer.PhoneCodeAttempts)
+	}
+
+	if err := customer.VerifyPhone("123456", now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !customer.PhoneVerified() || customer.PhoneCodeHash != "" || customer.PhoneCodeAttempts != 0 {
+		t.Errorf("expected the phone verified and the code cleared, got %+v", customer)
+	}
+	if err := customer.StartPhoneVerification("123456", now.Add(time.Hour)); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a verified phone to be a conflict, got %v", err)
+	}
+}
+
+func TestCustomer_VerifyPhoneRejects(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	started := func() *Customer {
+		customer := &Customer{UserID: uuid.New(), Phone: "+5511987654321"}
+		if err := customer.StartPhoneVerification("123456", now); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return customer
+	}
+
+	t.Run("expired code", func(t *testing.T) {
+		if err := started().VerifyPhone("123456", now.Add(PhoneCodeTTL+time.Second)); !errors.Is(err, ErrConflict) {
+			t.Errorf("expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		customer := started()
+		for i := 0; i < MaxPhoneCodeAttempts; i++ {
+			customer.VerifyPhone("000000", now)
+		}
+		if err := customer.VerifyPhone("123456", now); !errors.Is(err, ErrConflict) {
+			t.Errorf("expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("no code sent", func(t *testing.T) {
+		customer := &Customer{UserID: uuid.New(), Phone: "+5511987654321"}
+		if err := customer.VerifyPhone("123456", now); !errors.Is(err, ErrConflict) {
+			t.Errorf("expected a conflict, got %v", err)
+		}
+	})
+
+	t.Run("no phone", func(t *testing.T) {
+		customer := &Customer{UserID: uuid.New()}
+		if err := customer.StartPhoneVerification("123456", now); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected a validation error, got %v", err)
+		}
+	})
+
+	t.Run("code of another number", func(t *testing.T) {
+		customer := started()
+		customer.Phone = "+5511900000000"
+		if err := customer.VerifyPhone("123456", now); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected the code to be invalid for another number, got %v", err)
+		}
+	})
+}
+
+func TestCustomer_KeepPhoneVerification(t *testing.T) {
+	verifiedAt := time.Now()
+	previous := &Customer{Phone: "+5511987654321", PhoneVerifiedAt: &verifiedAt}
+
+	same := &Customer{Phone: previous.Phone}
+	same.KeepPhoneVerification(previous)
+	if !same.PhoneVerified() {
+		t.Error("expected the same phone to stay verified")
+	}
+
+	changed := &Customer{Phone: "+5511900000000"}
+	changed.KeepPhoneVerification(previous)
+	if changed.PhoneVerified() {
+		t.Error("expected a new phone to need verifying")
+	}
+}
@@ -0,0 +1,112 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// CustomerNote is an internal note left by an admin on a customer account.
+// Notes are never shown to the customer.
+type CustomerNote struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	AuthorID  uuid.UUID `gorm:"type:uuid;not null"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time
+}
+
+func (n *CustomerNote) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = NewID()
+	}
+	return nil
+}
+
+func (n *CustomerNote) Validate() error {
+	if n.UserID == uuid.Nil {
+		return ValidationError("Customer ID is required")
+	}
+	if n.Body == "" {
+		return ValidationError("Note body is required")
+	}
+	if len(n.Body) > 2000 {
+		return ValidationError("Note body cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+// RiskEventType is a signal that contributes to a customer's risk score
+type RiskEventType string
+
+const (
+	RiskChargeback    RiskEventType = "chargeback"
+	RiskFailedPayment RiskEventType = "failed_payment"
+	RiskAbuseReport   RiskEventType = "abuse_report"
+)
+
+// riskWeights is how many points each event adds to the risk score
+var riskWeights = map[RiskEventType]int{
+	RiskChargeback:    40,
+	RiskAbuseReport:   25,
+	RiskFailedPayment: 10,
+}
+
+// CustomerRiskEvent records a single risk signal for a customer
+type CustomerRiskEvent struct {
+	ID        uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID     `gorm:"type:uuid;not null;index"`
+	Type      RiskEventType `gorm:"type:varchar(30);not null"`
+	Reference string        `gorm:"size:255"` // Order ID, transaction ID or report reference
+	CreatedAt time.Time
+}
+
+func (e *CustomerRiskEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == uuid.Nil {
+		e.ID = NewID()
+	}
+	return nil
+}
+
+func (e *CustomerRiskEvent) Validate() error {
+	if e.UserID == uuid.Nil {
+		return ValidationError("Customer ID is required")
+	}
+	if _, ok := riskWeights[e.Type]; !ok {
+		return ValidationError("Invalid risk event type. Must be 'chargeback', 'failed_payment' or 'abuse_report'")
+	}
+	return nil
+}
+
+// RiskLevel buckets a risk score for display
+type RiskLevel string
+
+const (
+	RiskLow    RiskLevel = "low"
+	RiskMedium RiskLevel = "medium"
+	RiskHigh   RiskLevel = "high"
+)
+
+// CalculateRiskScore sums the weight of every event, capped at 100
+func CalculateRiskScore(events []CustomerRiskEvent) int {
+	score := 0
+	for _, event := range events {
+		score += riskWeights[event.Type]
+	}
+	if score > 100 {
+		return 100
+	}
+	return score
+}
+
+func RiskLevelFor(score int) RiskLevel {
+	switch {
+	case score >= 60:
+		return RiskHigh
+	case score >= 30:
+		return RiskMedium
+	default:
+		return RiskLow
+	}
+}
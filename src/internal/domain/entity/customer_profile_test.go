@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCalculateRiskScore(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []CustomerRiskEvent
+		score  int
+		level  RiskLevel
+	}{
+		{"no events", nil, 0, RiskLow},
+		{"single failed payment", []CustomerRiskEvent{{Type: RiskFailedPayment}}, 10, RiskLow},
+		{"chargeback", []CustomerRiskEvent{{Type: RiskChargeback}}, 40, RiskMedium},
+		{"chargeback and abuse report", []CustomerRiskEvent{{Type: RiskChargeback}, {Type: RiskAbuseReport}}, 65, RiskHigh},
+		{"capped at 100", []CustomerRiskEvent{{Type: RiskChargeback}, {Type: RiskChargeback}, {Type: RiskChargeback}}, 100, RiskHigh},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := CalculateRiskScore(tt.events)
+			if score != tt.score {
+				t.Errorf("CalculateRiskScore() = %d, want %d", score, tt.score)
+			}
+			if level := RiskLevelFor(score); level != tt.level {
+				t.Errorf("RiskLevelFor(%d) = %s, want %s", score, level, tt.level)
+			}
+		})
+	}
+}
+
+func TestCustomerRiskEvent_Validate(t *testing.T) {
+	event := CustomerRiskEvent{UserID: uuid.New(), Type: "refund"}
+	if err := event.Validate(); err == nil {
+		t.Error("expected error for unknown risk event type")
+	}
+
+	event.Type = RiskAbuseReport
+	if err := event.Validate(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestCustomerNote_Validate(t *testing.T) {
+	note := CustomerNote{UserID: uuid.New()}
+	if err := note.Validate(); err == nil {
+		t.Error("expected error for empty body")
+	}
+
+	note.Body = strings.Repeat("a", 2001)
+	if err := note.Validate(); err == nil {
+		t.Error("expected error for body over 2000 characters")
+	}
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DeadLetterStatus is where a dead-lettered webhook stands
+type DeadLetterStatus string
+
+const (
+	DeadLetterPending     DeadLetterStatus = "pending"     // Waiting for a fix and an admin to reprocess it
+	DeadLetterReprocessed DeadLetterStatus = "reprocessed" // Processed after all
+	DeadLetterDiscarded   DeadLetterStatus = "discarded"   // Given up on by an admin
+)
+
+// DirectWebhookSource is the source of the webhooks of /api/payment-webhook,
+// provider webhooks have the provider as their source
+const DirectWebhookSource = "direct"
+
+// DeadLetterWebhook is a payment webhook that was signed but couldn't be
+// processed, e.g. a payload that doesn't validate or names an unknown order.
+// It keeps the body as received, so it can be reprocessed once the cause is
+// fixed. A source sending the same body again counts as another attempt of
+// the same dead letter.
+type DeadLetterWebhook struct {
+	ID           uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	Source       string           `gorm:"type:varchar(50);not null;uniqueIndex:idx_dead_letter_webhooks_payload,priority:1"`
+	PayloadHash  string           `gorm:"type:varchar(64);not null;uniqueIndex:idx_dead_letter_webhooks_payload,priority:2"` // SHA-256 of the body
+	RawPayload   string           `gorm:"type:text;not null"`
+	Reason       string           `gorm:"type:text;not null"` // Why the last attempt failed
+	Attempts     int              `gorm:"not null;default:1"`
+	Status       DeadLetterStatus `gorm:"type:varchar(20);not null;default:'pending';index"`
+	ResolvedBy   *uuid.UUID       `gorm:"type:uuid"` // Admin who reprocessed or discarded it
+	ResolvedAt   *time.Time
+	CreatedAt    time.Time
+	LastFailedAt time.Time `gorm:"not null;index"`
+}
+
+// NewDeadLetterWebhook dead-letters a body the source sent. The body is
+// stored as text: bytes that aren't UTF-8 are replaced, they could not be
+// processed anyway.
+func NewDeadLetterWebhook(source string, payload []byte, reason string, now time.Time) *DeadLetterWebhook {
+	sum := sha256.Sum256(payload)
+	return &DeadLetterWebhook{
+		ID:           NewID(),
+		Source:       source,
+		PayloadHash:  hex.EncodeToString(sum[:]),
+		RawPayload:   strings.ToValidUTF8(strings.ReplaceAll(string(payload), "\x00", ""), "�"),
+		Reason:       reason,
+		Attempts:     1,
+		Status:       DeadLetterPending,
+		CreatedAt:    now,
+		LastFailedAt: now,
+	}
+}
+
+func (d *DeadLetterWebhook) pending() error {
+	if d.Status != DeadLetterPending {
+		return ConflictError(fmt.Sprintf("dead-lettered webhook is already %s", d.Status))
+	}
+	return nil
+}
+
+// Fail records another failed attempt
+func (d *DeadLetterWebhook) Fail(reason string, now time.Time) {
+	d.Attempts++
+	d.Reason = reason
+	d.LastFailedAt = now
+}
+
+// CanReprocess returns a conflict unless the webhook is still pending
+func (d *DeadLetterWebhook) CanReprocess() error {
+	return d.pending()
+}
+
+// Reprocessed marks the webhook processed after all
+func (d *DeadLetterWebhook) Reprocessed(by uuid.UUID, now time.Time) error {
+	return d.resolve(DeadLetterReprocessed, by, now)
+}
+
+// Discard gives up on the webhook
+func (d *DeadLetterWebhook) Discard(by uuid.UUID, now time.Time) error {
+	return d.resolve(DeadLetterDiscarded, by, now)
+}
+
+func (d *DeadLetterWebhook) resolve(status DeadLetterStatus, by uuid.UUID, now time.Time) error {
+	if err := d.pending(); err != nil {
+		return err
+	}
+	d.Status = status
+	d.ResolvedBy = &by
+	d.ResolvedAt = &now
+	return nil
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewDeadLetterWebhook(t *testing.T) {
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	d := NewDeadLetterWebhook(DirectWebhookSource, []byte("{\"id\":\"a\x00\xff\"}"), "order not found", now)
+
+	if d.RawPayload != "{\"id\":\"a�\"}" {
+		t.Errorf("expected NUL bytes dropped and invalid UTF-8 replaced, got %q", d.RawPayload)
+	}
+	if len(d.PayloadHash) != 64 || d.Attempts != 1 || d.Status != DeadLetterPending || !d.LastFailedAt.Equal(now) {
+		t.Errorf("expected a pending dead letter with one attempt, got %+v", d)
+	}
+	if other := NewDeadLetterWebhook(DirectWebhookSource, []byte(`{"id":"b"}`), "", now); other.PayloadHash == d.PayloadHash {
+		t.Error("expected other bodies to hash differently")
+	}
+}
+
+func TestDeadLetterWebhook_Resolve(t *testing.T) {
+	now := time.Now()
+	admin := uuid.New()
+
+	d := NewDeadLetterWebhook("stripe", []byte("{}"), "first", now)
+	d.Fail("second", now.Add(time.Minute))
+	if d.Attempts != 2 || d.Reason != "second" || !d.LastFailedAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected a second attempt, got %+v", d)
+	}
+
+	if err := d.Reprocessed(admin, now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if d.Status != DeadLetterReprocessed || d.ResolvedBy == nil || *d.ResolvedBy != admin || d.ResolvedAt == nil {
+		t.Errorf("expected the dead letter to be reprocessed by the admin, got %+v", d)
+	}
+	if err := d.CanReprocess(); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+	if err := d.Discard(admin, now); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+}
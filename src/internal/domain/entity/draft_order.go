@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DraftOrderStatus is where a draft order stands
+type DraftOrderStatus string
+
+const (
+	DraftOrderOpen      DraftOrderStatus = "open"      // Being composed by an admin
+	DraftOrderSent      DraftOrderStatus = "sent"      // The customer was sent a payment link
+	DraftOrderConverted DraftOrderStatus = "converted" // Placed as a real order
+	DraftOrderCancelled DraftOrderStatus = "cancelled"
+)
+
+// MaxDraftOrderItems caps the lines of a draft order
+const MaxDraftOrderItems = 100
+
+// MaxDraftOrderNoteLength is how long the note of a draft order can be
+const MaxDraftOrderNoteLength = 2000
+
+var (
+	ErrDraftOrderClosed = ConflictError("Only open or sent draft orders can be changed")
+	ErrDraftLinkExpired = ConflictError("This payment link has expired, ask the store for a new one")
+)
+
+// DraftOrder is an order an admin composes for a customer, e.g. over the
+// phone, before it is placed. It holds no prices nor stock: converting it goes
+// through checkout like any order, at the prices and stock of that moment.
+// The customer can be sent a payment link to accept it themselves.
+type DraftOrder struct {
+	ID            uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	CustomerID    int              `gorm:"not null"`
+	UserID        *uuid.UUID       `gorm:"type:uuid;index"` // Account the order is placed for, needed to send a payment link
+	Status        DraftOrderStatus `gorm:"type:varchar(20);not null;default:'open';index"`
+	Note          string           `gorm:"type:text"` // Shown to the customer with the payment link
+	Items         []DraftOrderItem `gorm:"foreignKey:DraftOrderID;constraint:OnDelete:CASCADE"`
+	Token         string           `gorm:"type:varchar(64);not null;uniqueIndex"` // Secret of the payment link
+	LinkExpiresAt *time.Time
+	SentAt        *time.Time
+	OrderID       *uuid.UUID `gorm:"type:uuid"` // Order the draft was converted to
+	ConvertedAt   *time.Time
+	CreatedBy     *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// DraftOrderItem is a quantity of a product or variant on a draft order
+type DraftOrderItem struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	DraftOrderID uuid.UUID  `gorm:"type:uuid;not null;index"`
+	ProductID    uuid.UUID  `gorm:"type:uuid;not null"`
+	VariantID    *uuid.UUID `gorm:"type:uuid"`
+	Quantity     int        `gorm:"not null"`
+}
+
+func (d *DraftOrder) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = NewID()
+	}
+	return nil
+}
+
+func (i *DraftOrderItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = NewID()
+	}
+	return nil
+}
+
+func (d *DraftOrder) Validate() error {
+	if d.CustomerID <= 0 {
+		return ValidationError("Customer ID is required")
+	}
+	if len(d.Note) > MaxDraftOrderNoteLength {
+		return ValidationError("Note cannot exceed 2000 characters")
+	}
+	if len(d.Items) == 0 {
+		return ValidationError("A draft order must have at least one item")
+	}
+	if len(d.Items) > MaxDraftOrderItems {
+		return ValidationError("A draft order can have at most 100 items")
+	}
+
+	type line struct{ product, variant uuid.UUID }
+	seen := make(map[line]bool, len(d.Items))
+	for _, item := range d.Items {
+		if item.ProductID == uuid.Nil {
+			return ValidationError("Product ID is required")
+		}
+		if item.Quantity <= 0 {
+			return ValidationError("Quantity must be greater than 0")
+		}
+		key := line{product: item.ProductID}
+		if item.VariantID != nil {
+			key.variant = *item.VariantID
+		}
+		if seen[key] {
+			return ValidationError("Each product or variant can only be listed once")
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// CanChange tells whether the draft can still be edited, sent, converted or cancelled
+func (d *DraftOrder) CanChange() error {
+	if d.Status != DraftOrderOpen && d.Status != DraftOrderSent {
+		return ErrDraftOrderClosed
+	}
+	return nil
+}
+
+// MarkSent records that the payment link was sent, valid until expiresAt.
+// Sending it again extends it.
+func (d *DraftOrder) MarkSent(at, expiresAt time.Time) error {
+	if err := d.CanChange(); err != nil {
+		return err
+	}
+	if d.UserID == nil {
+		return ValidationError("The draft order needs a customer account to send a payment link to")
+	}
+	d.Status = DraftOrderSent
+	d.SentAt = &at
+	d.LinkExpiresAt = &expiresAt
+	return nil
+}
+
+// CheckLink tells whether the customer can still accept the draft through its payment link
+func (d *DraftOrder) CheckLink(now time.Time) error {
+	switch d.Status {
+	case DraftOrderSent:
+	case DraftOrderConverted:
+		return ConflictError("This draft order was already placed")
+	default:
+		// Links of drafts never sent or cancelled don't exist as far as customers know
+		return NotFoundError("Draft order not found")
+	}
+	if d.LinkExpiresAt != nil && !now.Before(*d.LinkExpiresAt) {
+		return ErrDraftLinkExpired
+	}
+	return nil
+}
+
+// Cancel closes a draft that won't be placed
+func (d *DraftOrder) Cancel() error {
+	if err := d.CanChange(); err != nil {
+		return err
+	}
+	d.Status = DraftOrderCancelled
+	return nil
+}
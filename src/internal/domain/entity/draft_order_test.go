@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestDraftOrder_Validate(t *testing.T) {
+	productID, variantID := uuid.New(), uuid.New()
+	valid := func() DraftOrder {
+		return DraftOrder{CustomerID: 7, Items: []DraftOrderItem{
+			{ProductID: productID, Quantity: 2},
+			{ProductID: productID, VariantID: &variantID, Quantity: 1},
+		}}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(d *DraftOrder)
+		wantErr bool
+	}{
+		{"valid", func(d *DraftOrder) {}, false},
+		{"no customer", func(d *DraftOrder) { d.CustomerID = 0 }, true},
+		{"no items", func(d *DraftOrder) { d.Items = nil }, true},
+		{"too many items", func(d *DraftOrder) { d.Items = make([]DraftOrderItem, MaxDraftOrderItems+1) }, true},
+		{"no product", func(d *DraftOrder) { d.Items[0].ProductID = uuid.Nil }, true},
+		{"zero quantity", func(d *DraftOrder) { d.Items[0].Quantity = 0 }, true},
+		{"same variant twice", func(d *DraftOrder) { d.Items = append(d.Items, d.Items[1]) }, true},
+		{"note too long", func(d *DraftOrder) { d.Note = strings.Repeat("a", MaxDraftOrderNoteLength+1) }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := valid()
+			tt.modify(&d)
+			err := d.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestDraftOrder_PaymentLink(t *testing.T) {
+	now := time.Now()
+	d := DraftOrder{CustomerID: 7, Status: DraftOrderOpen}
+
+	if err := d.MarkSent(now, now.Add(time.Hour)); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected a validation error sending without an account, got %v", err)
+	}
+	if err := d.CheckLink(now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the link of an unsent draft not found, got %v", err)
+	}
+
+	userID := uuid.New()
+	d.UserID = &userID
+	if err := d.MarkSent(now, now.Add(time.Hour)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := d.CheckLink(now); err != nil {
+		t.Errorf("expected the link valid, got %v", err)
+	}
+	if err := d.CheckLink(now.Add(time.Hour)); !errors.Is(err, ErrDraftLinkExpired) {
+		t.Errorf("expected the link expired, got %v", err)
+	}
+
+	if err := d.Cancel(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := d.CanChange(); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a cancelled draft closed, got %v", err)
+	}
+	if err := d.CheckLink(now); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the link of a cancelled draft not found, got %v", err)
+	}
+}
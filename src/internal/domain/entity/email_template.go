@@ -0,0 +1,114 @@
+package entity
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	htmlTemplate "html/template"
+	"regexp"
+	"strings"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidTemplate = ValidationError("Invalid email template")
+	ErrTemplateRender  = ValidationError("Failed to render email template")
+)
+
+var templateKeyPattern = regexp.MustCompile(`^[a-z0-9]+([._][a-z0-9]+)*$`)
+
+// EmailTemplate is one version of a notification email. Editing a template
+// stores a new version, so earlier copy stays available for review.
+// Subject and body use Go template syntax, e.g. "Hi {{.customer_name}}".
+type EmailTemplate struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Key        string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_email_template_version"` // e.g. "order.confirmation"
+	Version    int        `gorm:"not null;uniqueIndex:idx_email_template_version"`
+	Subject    string     `gorm:"type:varchar(255);not null"`
+	Body       string     `gorm:"type:text;not null"` // HTML, values are escaped when rendered
+	SampleData string     `gorm:"type:text"`          // JSON object used to preview the template
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+}
+
+func (t *EmailTemplate) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = NewID()
+	}
+	return nil
+}
+
+func (t *EmailTemplate) Validate() error {
+	if !templateKeyPattern.MatchString(t.Key) || len(t.Key) > 100 {
+		return ValidationError("Template key must be lowercase letters and digits separated by dots or underscores")
+	}
+	if strings.TrimSpace(t.Subject) == "" {
+		return ValidationError("Template subject is required")
+	}
+	if len(t.Subject) > 255 {
+		return ValidationError("Template subject must be at most 255 characters")
+	}
+	if strings.TrimSpace(t.Body) == "" {
+		return ValidationError("Template body is required")
+	}
+	if _, _, err := t.parse(); err != nil {
+		return err
+	}
+	if _, err := t.Sample(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Sample returns the template's sample data, empty when none is stored
+func (t *EmailTemplate) Sample() (map[string]interface{}, error) {
+	data := make(map[string]interface{})
+	if t.SampleData == "" {
+		return data, nil
+	}
+	if err := json.Unmarshal([]byte(t.SampleData), &data); err != nil {
+		return nil, fmt.Errorf("%w: sample data must be a JSON object", ErrInvalidTemplate)
+	}
+	return data, nil
+}
+
+// Render fills the subject and body with data. Referencing a value that is
+// not in data is an error, so typos surface when previewing.
+func (t *EmailTemplate) Render(data map[string]interface{}) (*RenderedEmail, error) {
+	subject, body, err := t.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var subjectBuf, bodyBuf bytes.Buffer
+	if err := subject.Execute(&subjectBuf, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplateRender, err)
+	}
+	if err := body.Execute(&bodyBuf, data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrTemplateRender, err)
+	}
+
+	return &RenderedEmail{Subject: subjectBuf.String(), Body: bodyBuf.String()}, nil
+}
+
+func (t *EmailTemplate) parse() (*textTemplate.Template, *htmlTemplate.Template, error) {
+	subject, err := textTemplate.New("subject").Option("missingkey=error").Parse(t.Subject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: subject: %v", ErrInvalidTemplate, err)
+	}
+	body, err := htmlTemplate.New("body").Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: body: %v", ErrInvalidTemplate, err)
+	}
+	return subject, body, nil
+}
+
+// RenderedEmail is a template filled with data, ready to send
+type RenderedEmail struct {
+	Subject string
+	Body    string
+}
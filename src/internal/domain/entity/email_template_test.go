@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEmailTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template EmailTemplate
+		wantErr  bool
+	}{
+		{"valid", EmailTemplate{Key: "order.confirmation", Subject: "Order {{.order_number}}", Body: "<p>Thanks</p>"}, false},
+		{"with sample data", EmailTemplate{Key: "welcome", Subject: "Hi", Body: "Hi {{.name}}", SampleData: `{"name":"Jane"}`}, false},
+		{"invalid key", EmailTemplate{Key: "Order Confirmation", Subject: "Hi", Body: "Hi"}, true},
+		{"missing subject", EmailTemplate{Key: "welcome", Body: "Hi"}, true},
+		{"missing body", EmailTemplate{Key: "welcome", Subject: "Hi"}, true},
+		{"broken syntax", EmailTemplate{Key: "welcome", Subject: "Hi", Body: "Hi {{.name"}, true},
+		{"sample data not an object", EmailTemplate{Key: "welcome", Subject: "Hi", Body: "Hi", SampleData: `["Jane"]`}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.template.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEmailTemplate_Render(t *testing.T) {
+	template := &EmailTemplate{
+		Key:     "order.confirmation",
+		Subject: "Order {{.order_number}} confirmed",
+		Body:    "<p>Hi {{.name}}</p>",
+	}
+
+	email, err := template.Render(map[string]interface{}{"order_number": "A-1", "name": "<Jane>"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if email.Subject != "Order A-1 confirmed" {
+		t.Errorf("unexpected subject %q", email.Subject)
+	}
+	if email.Body != "<p>Hi &lt;Jane&gt;</p>" {
+		t.Errorf("expected values to be escaped in the body, got %q", email.Body)
+	}
+}
+
+func TestEmailTemplate_RenderMissingValue(t *testing.T) {
+	template := &EmailTemplate{Key: "welcome", Subject: "Hi {{.name}}", Body: "Hi"}
+
+	if _, err := template.Render(map[string]interface{}{}); !errors.Is(err, ErrTemplateRender) {
+		t.Errorf("expected ErrTemplateRender, got %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package entity
+
+import "errors"
+
+// Error kinds. Domain errors wrap one of them, so callers such as the HTTP
+// layer can tell what went wrong without knowing every specific error.
+var (
+	ErrNotFound   = errors.New("Not found")
+	ErrConflict   = errors.New("Conflict with the current state")
+	ErrValidation = errors.New("Validation failed")
+	ErrForbidden  = errors.New("Forbidden")
+	ErrTimeout    = errors.New("Timed out")
+
+	ErrInsufficientStock = errors.New("Insufficient stock")
+)
+
+// Error is a domain error of a known kind. Its message is meant for clients
+// and is shown as is; errors.Is matches both the error itself and its kind.
+type Error struct {
+	kind    error
+	message string
+	cause   error // What the error was made from, if anything
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.cause}
+}
+
+// NotFoundError reports a missing resource
+func NotFoundError(message string) error {
+	return &Error{kind: ErrNotFound, message: message}
+}
+
+// ConflictError reports a request that the current state of a resource rules
+// out, e.g. a duplicate or an invalid status transition
+func ConflictError(message string) error {
+	return &Error{kind: ErrConflict, message: message}
+}
+
+// ValidationError reports input that breaks a domain rule
+func ValidationError(message string) error {
+	return &Error{kind: ErrValidation, message: message}
+}
+
+// ForbiddenError reports an action the caller is not allowed to take
+func ForbiddenError(message string) error {
+	return &Error{kind: ErrForbidden, message: message}
+}
+
+// TimeoutError reports an operation that ran out of time, e.g. a query past
+// the deadline of the request. It matches ErrTimeout and cause, such as
+// context.DeadlineExceeded.
+func TimeoutError(message string, cause error) error {
+	return &Error{kind: ErrTimeout, message: message, cause: cause}
+}
+
+// InsufficientStockError reports a product or variant without enough stock.
+// It matches ErrInsufficientStock.
+func InsufficientStockError(message string) error {
+	return &Error{kind: ErrInsufficientStock, message: message}
+}
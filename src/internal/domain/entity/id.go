@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IDStrategy is how the IDs of new entities are made. Every strategy makes
+// 128-bit IDs kept in the same uuid columns and written the same way, so IDs
+// of different strategies live side by side: switching needs no migration and
+// the random IDs already stored stay valid.
+type IDStrategy string
+
+const (
+	IDStrategyUUIDv4 IDStrategy = "uuidv4" // Random, as every ID was made before strategies
+	IDStrategyUUIDv7 IDStrategy = "uuidv7" // Time-ordered, new rows land at the end of the indexes instead of all over them
+	IDStrategyULID   IDStrategy = "ulid"   // Time-ordered like uuidv7 with 80 random bits, in UUID form
+)
+
+// IDGenerator makes the ID of a new entity. Like uuid.New it panics when the
+// system's random source fails.
+type IDGenerator func() uuid.UUID
+
+// NewIDGenerator returns the generator of strategy, uuidv7 when it is empty
+func NewIDGenerator(strategy IDStrategy) (IDGenerator, error) {
+	switch strategy {
+	case IDStrategyUUIDv7, "":
+		return newUUIDv7, nil
+	case IDStrategyULID:
+		return newULID, nil
+	case IDStrategyUUIDv4:
+		return uuid.New, nil
+	}
+	return nil, fmt.Errorf("Unknown ID strategy %q, must be %s, %s or %s", strategy, IDStrategyUUIDv7, IDStrategyULID, IDStrategyUUIDv4)
+}
+
+var idGenerator IDGenerator = newUUIDv7
+
+// UseIDGenerator sets the generator NewID uses. It is meant to be called once
+// at startup, before any entity is created.
+func UseIDGenerator(generator IDGenerator) {
+	idGenerator = generator
+}
+
+// NewID returns an ID for a new entity, made by the generator in use
+func NewID() uuid.UUID {
+	return idGenerator()
+}
+
+func newUUIDv7() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// newULID lays a ULID out as a UUID: the Unix time in milliseconds in the
+// first 48 bits, big endian, and 80 random bits
+func newULID() uuid.UUID {
+	var id uuid.UUID
+	ms := uint64(time.Now().UnixMilli())
+	for i := 0; i < 6; i++ {
+		id[i] = byte(ms >> (40 - 8*i))
+	}
+	if _, err := rand.Read(id[6:]); err != nil {
+		panic(err)
+	}
+	return id
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewIDGenerator(t *testing.T) {
+	tests := []struct {
+		strategy IDStrategy
+		version  uuid.Version
+		ordered  bool
+	}{
+		{"", 7, true},
+		{IDStrategyUUIDv7, 7, true},
+		{IDStrategyUUIDv4, 4, false},
+		{IDStrategyULID, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			generate, err := NewIDGenerator(tt.strategy)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			first := generate()
+			time.Sleep(2 * time.Millisecond)
+			second := generate()
+
+			if first == second || first == uuid.Nil {
+				t.Fatalf("expected distinct IDs, got %s and %s", first, second)
+			}
+			if tt.version != 0 && first.Version() != tt.version {
+				t.Errorf("expected version %d, got %d", tt.version, first.Version())
+			}
+			if tt.ordered && bytes.Compare(first[:], second[:]) >= 0 {
+				t.Errorf("expected %s to sort before %s", first, second)
+			}
+			if _, err := uuid.Parse(first.String()); err != nil {
+				t.Errorf("expected the ID to be written as a UUID, got %v", err)
+			}
+		})
+	}
+
+	if _, err := NewIDGenerator("serial"); err == nil {
+		t.Error("expected an unknown strategy to be rejected")
+	}
+}
+
+func TestNewULID_Timestamp(t *testing.T) {
+	before := time.Now().UnixMilli()
+	id := newULID()
+
+	var ms int64
+	for _, b := range id[:6] {
+		ms = ms<<8 | int64(b)
+	}
+	if ms < before || ms > time.Now().UnixMilli() {
+		t.Errorf("expected the first 48 bits to be the creation time, got %d", ms)
+	}
+}
+
+func TestUseIDGenerator(t *testing.T) {
+	fixed := uuid.MustParse("018f3c1e-7b2a-7cc0-9d41-2f1e8a6b5c3d")
+	UseIDGenerator(func() uuid.UUID { return fixed })
+	defer UseIDGenerator(newUUIDv7)
+
+	if id := NewID(); id != fixed {
+		t.Errorf("expected the generator in use, got %s", id)
+	}
+	order := Order{}
+	if err := order.BeforeCreate(nil); err != nil || order.ID != fixed {
+		t.Errorf("expected new entities to take their ID from it, got %s, %v", order.ID, err)
+	}
+}
@@ -0,0 +1,171 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// MaxInventorySyncLevels caps the stock levels of one bulk update
+const MaxInventorySyncLevels = 1000
+
+// InventorySyncMode decides what happens to a bulk stock update when some of its levels can't be applied
+type InventorySyncMode string
+
+const (
+	SyncAllOrNothing InventorySyncMode = "all_or_nothing" // One failing level rejects the whole update
+	SyncBestEffort   InventorySyncMode = "best_effort"    // The levels that can be applied are, the others are reported
+)
+
+func (m InventorySyncMode) IsValid() bool {
+	return m == SyncAllOrNothing || m == SyncBestEffort
+}
+
+// StockLevelStatus is the outcome of one level of a bulk stock update
+type StockLevelStatus string
+
+const (
+	StockLevelUpdated   StockLevelStatus = "updated"
+	StockLevelUnchanged StockLevelStatus = "unchanged" // The variant already had the quantity
+	StockLevelNotFound  StockLevelStatus = "not_found" // No variant has the SKU
+	StockLevelConflict  StockLevelStatus = "conflict"  // The stock moved since the sender read it
+	StockLevelSkipped   StockLevelStatus = "skipped"   // Could be applied, but the all-or-nothing update was rejected
+)
+
+// StockLevel sets the stock of the variant with SKU to Quantity. With
+// ExpectedQuantity set, it only applies while the variant still has that many
+// units, so sales made since the sender read the stock aren't overwritten.
+type StockLevel struct {
+	SKU              string
+	Quantity         int
+	ExpectedQuantity *int
+}
+
+// StockLevelResult reports what became of a stock level
+type StockLevelResult struct {
+	SKU            string
+	Status         StockLevelStatus
+	ProductID      *uuid.UUID
+	VariantID      *uuid.UUID
+	QuantityBefore *int // Nil when no variant has the SKU
+	QuantityAfter  *int
+	Message        string
+}
+
+func (r *StockLevelResult) Failed() bool {
+	return r.Status == StockLevelNotFound || r.Status == StockLevelConflict
+}
+
+// InventorySyncReport tells what became of every level of a bulk stock update
+type InventorySyncReport struct {
+	Mode      InventorySyncMode
+	Reference string
+	Rejected  bool // An all-or-nothing update had a failing level, nothing was written
+	Results   []StockLevelResult
+}
+
+// Count returns how many levels ended with status
+func (r *InventorySyncReport) Count(status StockLevelStatus) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// InventorySync is a bulk stock update, as an ERP sends every night
+type InventorySync struct {
+	Mode      InventorySyncMode
+	Levels    []StockLevel
+	Reference string // Recorded on every stock movement of the update
+}
+
+func (s *InventorySync) Validate() error {
+	if !s.Mode.IsValid() {
+		return ValidationError("Invalid sync mode. Must be 'all_or_nothing' or 'best_effort'")
+	}
+	if len(s.Levels) == 0 {
+		return ValidationError("At least one stock level is required")
+	}
+	if len(s.Levels) > MaxInventorySyncLevels {
+		return ValidationError(fmt.Sprintf("At most %d stock levels can be updated at once", MaxInventorySyncLevels))
+	}
+
+	seen := make(map[string]bool, len(s.Levels))
+	for _, level := range s.Levels {
+		if strings.TrimSpace(level.SKU) == "" {
+			return ValidationError("Every stock level needs a SKU")
+		}
+		if seen[level.SKU] {
+			return ValidationError(fmt.Sprintf("SKU %s is listed more than once", level.SKU))
+		}
+		seen[level.SKU] = true
+		if level.Quantity < 0 || (level.ExpectedQuantity != nil && *level.ExpectedQuantity < 0) {
+			return ValidationError(fmt.Sprintf("Quantities of SKU %s can't be negative", level.SKU))
+		}
+	}
+	return nil
+}
+
+// SKUs lists the SKUs of the update in order
+func (s *InventorySync) SKUs() []string {
+	skus := make([]string, len(s.Levels))
+	for i, level := range s.Levels {
+		skus[i] = level.SKU
+	}
+	return skus
+}
+
+// Apply works out every level against the current variants, keyed by SKU, and
+// returns their results with the stock movements to write. When the update is
+// all or nothing and a level failed, there are no movements and the levels that
+// could have been applied are skipped.
+func (s *InventorySync) Apply(variants map[string]*ProductVariant) ([]StockLevelResult, []*StockMovement) {
+	results := make([]StockLevelResult, len(s.Levels))
+	var movements []*StockMovement
+	failed := false
+
+	for i, level := range s.Levels {
+		result := StockLevelResult{SKU: level.SKU}
+		variant, ok := variants[level.SKU]
+		if !ok {
+			result.Status = StockLevelNotFound
+			result.Message = "No variant has this SKU"
+			results[i] = result
+			failed = true
+			continue
+		}
+
+		before, after := variant.Quantity, level.Quantity
+		result.ProductID, result.VariantID = &variant.ProductID, &variant.ID
+		result.QuantityBefore, result.QuantityAfter = &before, &after
+
+		switch {
+		case level.ExpectedQuantity != nil && *level.ExpectedQuantity != before:
+			result.Status = StockLevelConflict
+			result.QuantityAfter = &before
+			result.Message = fmt.Sprintf("Expected %d units, the variant has %d", *level.ExpectedQuantity, before)
+			failed = true
+		case before == after:
+			result.Status = StockLevelUnchanged
+		default:
+			result.Status = StockLevelUpdated
+			movements = append(movements, NewStockMovement(variant.ProductID, &variant.ID, StockImport, before, after, s.Reference))
+		}
+		results[i] = result
+	}
+
+	if failed && s.Mode == SyncAllOrNothing {
+		for i := range results {
+			if results[i].Status == StockLevelUpdated {
+				results[i].Status = StockLevelSkipped
+				results[i].QuantityAfter = results[i].QuantityBefore
+			}
+		}
+		return results, nil
+	}
+	return results, movements
+}
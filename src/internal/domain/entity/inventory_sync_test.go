@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestInventorySync_Validate(t *testing.T) {
+	negative := -1
+	tests := []struct {
+		name    string
+		sync    InventorySync
+		wantErr bool
+	}{
+		{"valid", InventorySync{Mode: SyncBestEffort, Levels: []StockLevel{{SKU: "A", Quantity: 0}}}, false},
+		{"unknown mode", InventorySync{Mode: "partial", Levels: []StockLevel{{SKU: "A"}}}, true},
+		{"no levels", InventorySync{Mode: SyncAllOrNothing}, true},
+		{"blank SKU", InventorySync{Mode: SyncAllOrNothing, Levels: []StockLevel{{SKU: " "}}}, true},
+		{"duplicate SKU", InventorySync{Mode: SyncAllOrNothing, Levels: []StockLevel{{SKU: "A"}, {SKU: "A"}}}, true},
+		{"negative quantity", InventorySync{Mode: SyncAllOrNothing, Levels: []StockLevel{{SKU: "A", Quantity: -2}}}, true},
+		{"negative expected quantity", InventorySync{Mode: SyncAllOrNothing, Levels: []StockLevel{{SKU: "A", ExpectedQuantity: &negative}}}, true},
+		{"too many levels", InventorySync{Mode: SyncAllOrNothing, Levels: make([]StockLevel, MaxInventorySyncLevels+1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.sync.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestInventorySync_Apply(t *testing.T) {
+	productID := uuid.New()
+	variants := map[string]*ProductVariant{
+		"TS-S": {ID: uuid.New(), ProductID: productID, SKU: "TS-S", Quantity: 4},
+		"TS-M": {ID: uuid.New(), ProductID: productID, SKU: "TS-M", Quantity: 7},
+		"TS-L": {ID: uuid.New(), ProductID: productID, SKU: "TS-L", Quantity: 2},
+	}
+	expected := 5
+	levels := []StockLevel{
+		{SKU: "TS-S", Quantity: 10},
+		{SKU: "TS-M", Quantity: 7},
+		{SKU: "TS-L", Quantity: 9, ExpectedQuantity: &expected},
+		{SKU: "TS-XL", Quantity: 1},
+	}
+
+	sync := InventorySync{Mode: SyncBestEffort, Levels: levels, Reference: "erp-1"}
+	results, movements := sync.Apply(variants)
+
+	want := []StockLevelStatus{StockLevelUpdated, StockLevelUnchanged, StockLevelConflict, StockLevelNotFound}
+	for i, status := range want {
+		if results[i].Status != status {
+			t.Errorf("expected %s to be %s, got %s", levels[i].SKU, status, results[i].Status)
+		}
+	}
+	if *results[2].QuantityAfter != 2 {
+		t.Errorf("expected a conflict to leave the stock alone, got %d", *results[2].QuantityAfter)
+	}
+	if len(movements) != 1 || movements[0].Delta != 6 || movements[0].Reason != StockImport || movements[0].Reference != "erp-1" {
+		t.Errorf("expected one import movement of +6, got %+v", movements)
+	}
+
+	sync.Mode = SyncAllOrNothing
+	results, movements = sync.Apply(variants)
+	if len(movements) != 0 || results[0].Status != StockLevelSkipped || *results[0].QuantityAfter != 4 {
+		t.Errorf("expected nothing applied, got %+v and %d movements", results[0], len(movements))
+	}
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Invoice is the stored, numbered invoice document of an order.
+// Numbers are sequential per calendar year: INV-2024-000001, INV-2024-000002, ...
+type Invoice struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrderID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Number    string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	Year      int       `gorm:"not null;uniqueIndex:idx_invoice_year_sequence"`
+	Sequence  int       `gorm:"not null;uniqueIndex:idx_invoice_year_sequence"`
+	PDF       []byte    `gorm:"type:bytea;not null"`
+	IssuedAt  time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+func (i *Invoice) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = NewID()
+	}
+	return nil
+}
+
+// AssignNumber sets the year, sequence and formatted invoice number
+func (i *Invoice) AssignNumber(year, sequence int) {
+	i.Year = year
+	i.Sequence = sequence
+	i.Number = FormatInvoiceNumber(year, sequence)
+}
+
+func FormatInvoiceNumber(year, sequence int) string {
+	return fmt.Sprintf("INV-%d-%06d", year, sequence)
+}
+
+// InvoiceSequence keeps the last issued invoice number for each year
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null;default:0"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// LowStockItem is a product without variants, or a variant, whose stock
+// dropped to the low-stock threshold or below
+type LowStockItem struct {
+	ProductID   uuid.UUID
+	VariantID   *uuid.UUID // Nil for a product without variants
+	ProductName string
+	SKU         string // Variant SKU, empty for products
+	Quantity    int
+}
+
+func (i LowStockItem) String() string {
+	if i.SKU != "" {
+		return fmt.Sprintf("%s (%s): %d left", i.ProductName, i.SKU, i.Quantity)
+	}
+	return fmt.Sprintf("%s: %d left", i.ProductName, i.Quantity)
+}
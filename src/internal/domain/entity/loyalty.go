@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LoyaltyTransactionType is why the points balance of a customer changed
+type LoyaltyTransactionType string
+
+const (
+	LoyaltyEarned   LoyaltyTransactionType = "earned"   // Accrued on a completed or delivered order
+	LoyaltyRedeemed LoyaltyTransactionType = "redeemed" // Spent as a discount at checkout
+	LoyaltyRestored LoyaltyTransactionType = "restored" // Redeemed points given back when the order was cancelled or refunded
+	LoyaltyReversed LoyaltyTransactionType = "reversed" // Earned points taken back when the order was refunded
+)
+
+// LoyaltyTransaction is an entry of the points ledger of a customer. The
+// balance is the sum of the points of every entry. An order has at most one
+// entry of each type.
+type LoyaltyTransaction struct {
+	ID        uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID              `gorm:"type:uuid;not null;index"`
+	OrderID   *uuid.UUID             `gorm:"type:uuid;uniqueIndex:idx_loyalty_transactions_order_type"`
+	Type      LoyaltyTransactionType `gorm:"type:varchar(20);not null;uniqueIndex:idx_loyalty_transactions_order_type"`
+	Points    int                    `gorm:"not null"` // Negative when points are spent or taken back
+	CreatedAt time.Time
+}
+
+func NewLoyaltyTransaction(userID, orderID uuid.UUID, transactionType LoyaltyTransactionType, points int) *LoyaltyTransaction {
+	return &LoyaltyTransaction{
+		ID:        NewID(),
+		UserID:    userID,
+		OrderID:   &orderID,
+		Type:      transactionType,
+		Points:    points,
+		CreatedAt: time.Now(),
+	}
+}
+
+// Spend checks that a balance covers the points a redemption spends
+func (t *LoyaltyTransaction) Spend(balance int) error {
+	if balance+t.Points < 0 {
+		return ConflictError(fmt.Sprintf("Not enough loyalty points: %d requested, the balance is %d", -t.Points, balance))
+	}
+	return nil
+}
+
+// LoyaltyProgram sets how points are earned and what they are worth at
+// checkout. A zero rate turns earning off, a zero value turns redeeming off.
+type LoyaltyProgram struct {
+	EarnRate   float64 // Points per 1.00 paid for an order
+	PointValue float64 // Discount a redeemed point gives
+}
+
+// PointsFor returns the points an amount paid earns, rounded down
+func (p LoyaltyProgram) PointsFor(amount float64) int {
+	if amount <= 0 || p.EarnRate <= 0 {
+		return 0
+	}
+	// The epsilon keeps e.g. 19.99 * 100 from flooring to 1998
+	return int(math.Floor(amount*p.EarnRate + 1e-9))
+}
+
+// Discount returns what redeeming points takes off an order, to the cent
+func (p LoyaltyProgram) Discount(points int) float64 {
+	return math.Round(float64(points)*p.PointValue*100) / 100
+}
+
+// ValidateRedemption checks that points can be redeemed on an order of the
+// given subtotal: the discount they give can't be more than the subtotal
+func (p LoyaltyProgram) ValidateRedemption(points int, subtotal float64) error {
+	if points <= 0 {
+		return ValidationError("Points to redeem must be greater than 0")
+	}
+	if p.PointValue <= 0 {
+		return ValidationError("Loyalty points can't be redeemed")
+	}
+	if p.Discount(points) > subtotal {
+		most := int(math.Floor(subtotal/p.PointValue + 1e-9))
+		return ValidationError(fmt.Sprintf("At most %d points can be redeemed on this order", most))
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestLoyaltyProgram_PointsAndDiscount(t *testing.T) {
+	program := LoyaltyProgram{EarnRate: 1, PointValue: 0.01}
+
+	if points := program.PointsFor(19.99); points != 19 {
+		t.Errorf("expected 19 points, got %d", points)
+	}
+	if points := (LoyaltyProgram{EarnRate: 100}).PointsFor(19.99); points != 1999 {
+		t.Errorf("expected 1999 points, got %d", points)
+	}
+	if points := (LoyaltyProgram{}).PointsFor(100); points != 0 {
+		t.Errorf("expected no points without an earn rate, got %d", points)
+	}
+	if discount := program.Discount(250); discount != 2.5 {
+		t.Errorf("expected a 2.50 discount, got %v", discount)
+	}
+}
+
+func TestLoyaltyProgram_ValidateRedemption(t *testing.T) {
+	tests := []struct {
+		name     string
+		program  LoyaltyProgram
+		points   int
+		subtotal float64
+		wantErr  bool
+	}{
+		{"covered by the subtotal", LoyaltyProgram{PointValue: 0.01}, 500, 5, false},
+		{"more than the subtotal", LoyaltyProgram{PointValue: 0.01}, 501, 5, true},
+		{"no points", LoyaltyProgram{PointValue: 0.01}, 0, 5, true},
+		{"redeeming turned off", LoyaltyProgram{EarnRate: 1}, 100, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.program.ValidateRedemption(tt.points, tt.subtotal)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLoyaltyTransaction_Spend(t *testing.T) {
+	redemption := NewLoyaltyTransaction(uuid.New(), uuid.New(), LoyaltyRedeemed, -300)
+
+	if err := redemption.Spend(300); err != nil {
+		t.Errorf("expected the balance to cover the redemption, got %v", err)
+	}
+	if err := redemption.Spend(299); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+}
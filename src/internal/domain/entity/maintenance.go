@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultMaintenanceMessage is shown to clients when no message was given
+const DefaultMaintenanceMessage = "The store is down for maintenance, please try again later"
+
+// MaintenanceStatus tells whether the API is in maintenance mode. While it is
+// enabled, public traffic is answered with 503.
+type MaintenanceStatus struct {
+	Enabled    bool
+	Message    string
+	RetryAfter time.Duration // How long clients are told to wait before retrying
+	Since      *time.Time    // When maintenance mode was last switched on or off
+	UpdatedBy  *uuid.UUID    // Nil while the mode is the configured one
+}
+
+// RetryAfterSeconds returns RetryAfter as the Retry-After header expects it,
+// at least one second
+func (s MaintenanceStatus) RetryAfterSeconds() int {
+	return max(int(s.RetryAfter.Seconds()), 1)
+}
@@ -1,7 +1,6 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -11,43 +10,63 @@ import (
 type OrderStatus string
 
 const (
-	Pending   OrderStatus = "pending"
-	Cancelled OrderStatus = "cancelled"
-	Completed OrderStatus = "completed"
+	Pending    OrderStatus = "pending"
+	Review     OrderStatus = "review" // Held by fraud screening until an admin approves or cancels it
+	Cancelled  OrderStatus = "cancelled"
+	Completed  OrderStatus = "completed"
+	Processing OrderStatus = "processing" // Paid, being picked and packed
+	Shipped    OrderStatus = "shipped"
+	Delivered  OrderStatus = "delivered"
+	Refunded   OrderStatus = "refunded" // Paid in full back to the customer
 )
 
+// OrderStatuses lists every order status, roughly in the order an order goes
+// through them. Which transitions are allowed is up to the OrderWorkflow.
+var OrderStatuses = []OrderStatus{Review, Pending, Processing, Shipped, Delivered, Completed, Cancelled, Refunded}
+
 type PaymentStatus string
 
 const (
-	Unpaid PaymentStatus = "unpaid"
-	Paid   PaymentStatus = "paid"
-	Failed PaymentStatus = "failed"
+	Unpaid            PaymentStatus = "unpaid"
+	Authorized        PaymentStatus = "authorized"     // Held on the customer's card, nothing captured yet
+	PartiallyPaid     PaymentStatus = "partially_paid" // Captured in part, a balance is left
+	Paid              PaymentStatus = "paid"           // Captured in full
+	PartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentRefunded   PaymentStatus = "refunded"
+	Failed            PaymentStatus = "failed"
 )
 
+// PaymentStatuses lists every payment status
+var PaymentStatuses = []PaymentStatus{Unpaid, Authorized, PartiallyPaid, Paid, PartiallyRefunded, PaymentRefunded, Failed}
+
 type Order struct {
-	ID            uuid.UUID     `gorm:"type:uuid;primaryKey"`
-	CustomerID    int           `gorm:"not null"`
-	Products      []OrderItem   `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
-	TotalPrice    float64       `gorm:"type:decimal(10,2);not null"`
-	Status        OrderStatus   `gorm:"type:varchar(20);not null;default:'pending'"`
-	PaymentStatus PaymentStatus `gorm:"type:varchar(20);not null;default:'unpaid'"`
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	CustomerID     int           `gorm:"not null"`
+	UserID         *uuid.UUID    `gorm:"type:uuid;index"` // Account that placed the order, nil for legacy orders
+	Products       []OrderItem   `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	TotalPrice     float64       `gorm:"type:decimal(10,2);not null"`
+	Status         OrderStatus   `gorm:"type:varchar(20);not null;default:'pending'"`
+	PaymentStatus  PaymentStatus `gorm:"type:varchar(20);not null;default:'unpaid'"`
+	AmountPaid     float64       `gorm:"type:decimal(10,2);not null;default:0"` // Captured so far
+	AmountRefunded float64       `gorm:"type:decimal(10,2);not null;default:0"` // Refunded of what was captured
+	AmountCredited float64       `gorm:"type:decimal(10,2);not null;default:0"` // Given back as goodwill credit
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 func (o *Order) BeforeCreate(tx *gorm.DB) error {
 	if o.ID == uuid.Nil {
-		o.ID = uuid.New()
+		o.ID = NewID()
 	}
 	return nil
 }
 
 func (o *Order) Validate() error {
 	if o.CustomerID <= 0 {
-		return errors.New("customer ID is required")
+		return ValidationError("customer ID is required")
 	}
 	if len(o.Products) == 0 {
-		return errors.New("Order must have at least one product")
+		return ValidationError("Order must have at least one product")
 	}
 	for _, product := range o.Products {
 		if err := product.Validate(); err != nil {
@@ -57,27 +76,40 @@ func (o *Order) Validate() error {
 	return nil
 }
 
+// IsDelivered reports whether the customer has the items: delivered, or
+// completed under a workflow that doesn't track delivery
+func (o *Order) IsDelivered() bool {
+	return o.Status == Delivered || o.Status == Completed
+}
+
+// IsVoid reports whether the order no longer counts as a sale
+func (o *Order) IsVoid() bool {
+	return o.Status == Cancelled || o.Status == Refunded
+}
+
+// IsOwnedBy reports whether the order was placed by the given user account
+func (o *Order) IsOwnedBy(userID uuid.UUID) bool {
+	return o.UserID != nil && *o.UserID == userID
+}
+
+// CalculateTotal sets the total price to the sum of the line totals, which
+// hold the discount, tax and surcharges priced on each line
 func (o *Order) CalculateTotal() {
 	total := 0.0
 	for _, item := range o.Products {
 		total += item.Subtotal()
 	}
 
-	o.TotalPrice = total
+	o.TotalPrice = roundCents(total)
 }
 
-func (o *Order) CanTransitionTo(newStatus OrderStatus) error {
-	if o.Status == Pending {
-		if newStatus == Completed || newStatus == Cancelled {
-			return nil
-		}
-	}
-
-	return errors.New("Invalid status transition")
+// CanTransitionTo tells whether the workflow lets the order move to newStatus
+func (o *Order) CanTransitionTo(workflow *OrderWorkflow, newStatus OrderStatus) error {
+	return workflow.CanTransition(o, newStatus)
 }
 
-func (o *Order) UpdateStatus(newStatus OrderStatus) error {
-	if err := o.CanTransitionTo(newStatus); err != nil {
+func (o *Order) UpdateStatus(workflow *OrderWorkflow, newStatus OrderStatus) error {
+	if err := o.CanTransitionTo(workflow, newStatus); err != nil {
 		return err
 	}
 
@@ -1,44 +1,172 @@
 package entity
 
 import (
-	"errors"
+	"math"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type OrderItem struct {
-	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
-	OrderID    uuid.UUID  `gorm:"type:uuid;not null"`
-	ProductID  uuid.UUID  `gorm:"type:uuid;not null"`
-	VariantID  *uuid.UUID `gorm:"type:uuid"`
-	Quantity   int        `gorm:"not null"`
-	Price      float64    `gorm:"type:decimal(10,2);not null"`
-	TotalPrice float64    `gorm:"type:decimal(10,2);not null"`
+	ID         uuid.UUID            `gorm:"type:uuid;primaryKey"`
+	OrderID    uuid.UUID            `gorm:"type:uuid;not null"`
+	ProductID  uuid.UUID            `gorm:"type:uuid;not null"`
+	VariantID  *uuid.UUID           `gorm:"type:uuid"`
+	Quantity   int                  `gorm:"not null"`
+	Price      float64              `gorm:"type:decimal(10,2);not null"`
+	TotalPrice float64              `gorm:"type:decimal(10,2);not null"` // Sum of the components, what the customer pays for the line
+	Components []OrderItemComponent `gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE"`
+
+	// What was ordered, as it was at order time, so orders and invoices keep
+	// showing it after the product is edited or deleted. Lines older than
+	// the snapshots have their name and SKU filled in from the catalog.
+	ProductName        string  `gorm:"size:255;not null;default:''"`
+	SKU                string  `gorm:"type:varchar(64);not null;default:''"` // Variant lines only
+	VariantDescription string  `gorm:"size:500;not null;default:''"`         // e.g. "Size: L, Color: Red"
+	TaxRate            float64 `gorm:"type:decimal(6,4);not null;default:0"` // Fraction the line was taxed at, e.g. 0.2
+}
+
+// OrderItemComponentType is the kind of amount that makes up a line total
+type OrderItemComponentType string
+
+const (
+	ComponentBase      OrderItemComponentType = "base"      // Unit price times quantity
+	ComponentDiscount  OrderItemComponentType = "discount"  // Negative, the line's share of a discount
+	ComponentTax       OrderItemComponentType = "tax"       // Tax on the discounted line amount
+	ComponentSurcharge OrderItemComponentType = "surcharge" // Line fee, neither discounted nor taxed
+)
+
+// OrderItemComponent is one amount of a line total, persisted so refunds,
+// reports and invoices use exactly what was charged for the line
+type OrderItemComponent struct {
+	ID          uuid.UUID              `gorm:"type:uuid;primaryKey"`
+	OrderItemID uuid.UUID              `gorm:"type:uuid;not null;index"`
+	Type        OrderItemComponentType `gorm:"type:varchar(20);not null"`
+	Label       string                 `gorm:"size:100"` // e.g. "10% off" or "Tax 8.25%"
+	Amount      float64                `gorm:"type:decimal(10,2);not null"`
+}
+
+func (c *OrderItemComponent) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = NewID()
+	}
+	return nil
+}
+
+// SnapshotProduct records the name of the product the line orders and, for
+// a variant, its SKU and options. variant is nil for lines of the product itself.
+func (oi *OrderItem) SnapshotProduct(product *Product, variant *ProductVariant) {
+	if product != nil {
+		oi.ProductName = product.Name
+	}
+	if variant != nil {
+		oi.SKU = variant.SKU
+		oi.VariantDescription = variant.Describe()
+	}
+}
+
+// Description names what the line orders, e.g. "T-Shirt (Size: L)", from its
+// snapshot. Empty for lines without one.
+func (oi *OrderItem) Description() string {
+	if oi.ProductName == "" || oi.VariantDescription == "" {
+		return oi.ProductName
+	}
+	return oi.ProductName + " (" + oi.VariantDescription + ")"
 }
 
 func (oi *OrderItem) Validate() error {
 	if oi.ID == uuid.Nil {
-		return errors.New("Order item ID is required")
+		return ValidationError("Order item ID is required")
 	}
 	if oi.ProductID == uuid.Nil {
-		return errors.New("Product ID is required")
+		return ValidationError("Product ID is required")
 	}
 	if oi.Quantity <= 0 {
-		return errors.New("Quantity must be greater than 0")
+		return ValidationError("Quantity must be greater than 0")
 	}
 	if oi.Price < 0 {
-		return errors.New("Price cannot be negative")
+		return ValidationError("Price cannot be negative")
 	}
 	if oi.TotalPrice < 0 {
-		return errors.New("Total price cannot be negative")
+		return ValidationError("Total price cannot be negative")
+	}
+	for _, component := range oi.Components {
+		switch component.Type {
+		case ComponentBase, ComponentTax, ComponentSurcharge:
+			if component.Amount < 0 {
+				return ValidationError("Only discount components can be negative")
+			}
+		case ComponentDiscount:
+			if component.Amount > 0 {
+				return ValidationError("Discount components cannot be positive")
+			}
+		default:
+			return ValidationError("Invalid order item component type. Must be 'base', 'discount', 'tax' or 'surcharge'")
+		}
 	}
 	return nil
 }
 
+// AddComponent appends an amount to the line. Zero amounts other than the base are skipped.
+func (oi *OrderItem) AddComponent(componentType OrderItemComponentType, label string, amount float64) {
+	if amount == 0 && componentType != ComponentBase {
+		return
+	}
+	oi.Components = append(oi.Components, OrderItemComponent{
+		OrderItemID: oi.ID,
+		Type:        componentType,
+		Label:       label,
+		Amount:      roundCents(amount),
+	})
+}
+
+// ComponentTotal sums the line's components of the given type. Lines without
+// components predate them, their base is derived from the unit price.
+func (oi *OrderItem) ComponentTotal(componentType OrderItemComponentType) float64 {
+	if len(oi.Components) == 0 {
+		if componentType == ComponentBase {
+			return roundCents(oi.Price * float64(oi.Quantity))
+		}
+		return 0
+	}
+
+	total := 0.0
+	for _, component := range oi.Components {
+		if component.Type == componentType {
+			total += component.Amount
+		}
+	}
+	return roundCents(total)
+}
+
+// CalculateTotal sets the line total from its components, or from the unit
+// price when it has none
 func (oi *OrderItem) CalculateTotal() {
-	oi.TotalPrice = oi.Price * float64(oi.Quantity)
+	if len(oi.Components) == 0 {
+		oi.TotalPrice = oi.Price * float64(oi.Quantity)
+		return
+	}
+
+	total := 0.0
+	for _, component := range oi.Components {
+		total += component.Amount
+	}
+	oi.TotalPrice = roundCents(total)
 }
 
 func (oi *OrderItem) Subtotal() float64 {
 	return oi.TotalPrice
 }
+
+// AmountFor returns what the customer paid for quantity units of the line,
+// including its discount, tax and surcharges
+func (oi *OrderItem) AmountFor(quantity int) float64 {
+	if oi.Quantity <= 0 || quantity >= oi.Quantity {
+		return oi.TotalPrice
+	}
+	return roundCents(oi.TotalPrice * float64(quantity) / float64(oi.Quantity))
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,125 @@
+package entity
+
+// paymentTransitions lists the payment statuses an order can move to from
+// each status. A failed payment can be tried again, and an order can be paid
+// in several captures before it is refunded in one or more refunds. Only
+// orders paid in full are refunded, so a refunded status always means the
+// whole total was captured.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	Unpaid:            {Authorized, PartiallyPaid, Paid, Failed},
+	Failed:            {Authorized, PartiallyPaid, Paid, Failed},
+	Authorized:        {PartiallyPaid, Paid, Failed},
+	PartiallyPaid:     {PartiallyPaid, Paid},
+	Paid:              {PartiallyRefunded, PaymentRefunded},
+	PartiallyRefunded: {PartiallyRefunded, PaymentRefunded},
+}
+
+// IsPaid reports whether the order was paid in full and not refunded in full
+// since
+func (o *Order) IsPaid() bool {
+	return o.PaymentStatus == Paid || o.PaymentStatus == PartiallyRefunded
+}
+
+// Balance is what is left to pay of the order
+func (o *Order) Balance() float64 {
+	if balance := roundCents(o.TotalPrice - o.AmountPaid); balance > 0 {
+		return balance
+	}
+	return 0
+}
+
+// Refundable is what can still be given back of what was captured, once
+// refunds and goodwill credits of the order are taken off
+func (o *Order) Refundable() float64 {
+	return roundCents(o.AmountPaid - o.AmountRefunded - o.AmountCredited)
+}
+
+// CanTransitionPaymentTo tells whether the payment of the order can move to
+// status
+func (o *Order) CanTransitionPaymentTo(status PaymentStatus) error {
+	for _, next := range paymentTransitions[o.PaymentStatus] {
+		if next == status {
+			return nil
+		}
+	}
+	return ConflictError("Cannot move a payment from " + string(o.PaymentStatus) + " to " + string(status))
+}
+
+// AuthorizePayment records that the payment provider holds the order total
+// on the customer's card
+func (o *Order) AuthorizePayment() error {
+	return o.setPaymentStatus(Authorized)
+}
+
+// FailPayment records that the payment provider declined the payment
+func (o *Order) FailPayment() error {
+	return o.setPaymentStatus(Failed)
+}
+
+// CapturePayment records amount paid towards the balance of the order. The
+// order is paid once nothing is left of its balance, capturing nothing for a
+// free order.
+func (o *Order) CapturePayment(amount float64) error {
+	amount = roundCents(amount)
+	if amount < 0 || (amount == 0 && o.Balance() > 0) {
+		return ValidationError("Amount must be greater than zero")
+	}
+	if amount > o.Balance() {
+		return ValidationError("Amount exceeds the balance of the order")
+	}
+
+	status := PartiallyPaid
+	if amount == o.Balance() {
+		status = Paid
+	}
+	if err := o.setPaymentStatus(status); err != nil {
+		return err
+	}
+	o.AmountPaid = roundCents(o.AmountPaid + amount)
+	return nil
+}
+
+// RefundPayment records amount refunded of what was captured. The payment is
+// refunded once nothing is left to give back.
+func (o *Order) RefundPayment(amount float64) error {
+	amount = roundCents(amount)
+	if amount <= 0 {
+		return ValidationError("Amount must be greater than zero")
+	}
+	if amount > o.Refundable() {
+		return ValidationError("Amount exceeds what was paid for the order")
+	}
+
+	status := PartiallyRefunded
+	if amount == o.Refundable() {
+		status = PaymentRefunded
+	}
+	if err := o.setPaymentStatus(status); err != nil {
+		return err
+	}
+	o.AmountRefunded = roundCents(o.AmountRefunded + amount)
+	return nil
+}
+
+// CreditPayment records amount given back of what was captured as goodwill
+// credit. It leaves the payment status alone, as no money went back to the
+// customer's card, but it can't be refunded again.
+func (o *Order) CreditPayment(amount float64) error {
+	amount = roundCents(amount)
+	if amount <= 0 {
+		return ValidationError("Amount must be greater than zero")
+	}
+	if amount > o.Refundable() {
+		return ValidationError("Amount exceeds what was paid for the order")
+	}
+	o.AmountCredited = roundCents(o.AmountCredited + amount)
+	return nil
+}
+
+func (o *Order) setPaymentStatus(status PaymentStatus) error {
+	if err := o.CanTransitionPaymentTo(status); err != nil {
+		return err
+	}
+	o.PaymentStatus = status
+	return nil
+}
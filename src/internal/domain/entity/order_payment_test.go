@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrder_CapturePayment(t *testing.T) {
+	order := &Order{TotalPrice: 100, PaymentStatus: Unpaid}
+
+	if err := order.AuthorizePayment(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := order.CapturePayment(40); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != PartiallyPaid || order.Balance() != 60 {
+		t.Fatalf("expected partially_paid with 60 left, got %s with %.2f", order.PaymentStatus, order.Balance())
+	}
+	if order.IsPaid() {
+		t.Error("expected a partially paid order not to be paid")
+	}
+
+	if err := order.CapturePayment(60.01); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected capturing more than the balance to be rejected, got %v", err)
+	}
+	if err := order.CapturePayment(60); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != Paid || order.Balance() != 0 || order.AmountPaid != 100 {
+		t.Errorf("expected the order paid in full, got %s with %.2f paid", order.PaymentStatus, order.AmountPaid)
+	}
+}
+
+func TestOrder_RefundPayment(t *testing.T) {
+	order := &Order{TotalPrice: 100, PaymentStatus: Paid, AmountPaid: 100}
+
+	if err := order.RefundPayment(30); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != PartiallyRefunded || order.Refundable() != 70 || !order.IsPaid() {
+		t.Fatalf("expected partially_refunded with 70 refundable, got %s with %.2f", order.PaymentStatus, order.Refundable())
+	}
+	if err := order.RefundPayment(80); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected refunding more than was paid to be rejected, got %v", err)
+	}
+	if err := order.RefundPayment(70); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != PaymentRefunded || order.IsPaid() {
+		t.Errorf("expected the payment refunded, got %s", order.PaymentStatus)
+	}
+}
+
+func TestOrder_CreditPayment(t *testing.T) {
+	order := &Order{TotalPrice: 100, PaymentStatus: Paid, AmountPaid: 100}
+
+	if err := order.CreditPayment(40); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != Paid || order.Refundable() != 60 {
+		t.Fatalf("expected paid with 60 refundable, got %s with %.2f", order.PaymentStatus, order.Refundable())
+	}
+	if err := order.RefundPayment(70); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected refunding what was credited to be rejected, got %v", err)
+	}
+	if err := order.RefundPayment(60); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if order.PaymentStatus != PaymentRefunded || order.AmountRefunded != 60 {
+		t.Errorf("expected the payment refunded with 60 refunded, got %s with %.2f", order.PaymentStatus, order.AmountRefunded)
+	}
+	if err := order.CreditPayment(1); !errors.Is(err, ErrValidation) {
+		t.Errorf("expected crediting past what was paid to be rejected, got %v", err)
+	}
+}
+
+func TestOrder_CanTransitionPaymentTo(t *testing.T) {
+	tests := []struct {
+		from    PaymentStatus
+		to      PaymentStatus
+		wantErr bool
+	}{
+		{Unpaid, Authorized, false},
+		{Unpaid, Paid, false},
+		{Failed, Paid, false},
+		{Authorized, Failed, false},
+		{Paid, Failed, true},
+		{Paid, Authorized, true},
+		{Unpaid, PaymentRefunded, true},
+		{PaymentRefunded, Paid, true},
+		{PartiallyPaid, Paid, false},
+		{PartiallyPaid, PartiallyRefunded, true},
+		{PartiallyPaid, PaymentRefunded, true},
+		{Paid, PartiallyRefunded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			order := &Order{PaymentStatus: tt.from}
+			err := order.CanTransitionPaymentTo(tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanTransitionPaymentTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrConflict) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOrder_IsPaid(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		want  bool
+	}{
+		{"unpaid", Order{TotalPrice: 100, PaymentStatus: Unpaid}, false},
+		{"authorized", Order{TotalPrice: 100, PaymentStatus: Authorized}, false},
+		{"partially paid", Order{TotalPrice: 100, PaymentStatus: PartiallyPaid, AmountPaid: 40}, false},
+		{"paid", Order{TotalPrice: 100, PaymentStatus: Paid, AmountPaid: 100}, true},
+		{"partially refunded", Order{TotalPrice: 100, PaymentStatus: PartiallyRefunded, AmountPaid: 100, AmountRefunded: 30}, true},
+		{"refunded", Order{TotalPrice: 100, PaymentStatus: PaymentRefunded, AmountPaid: 100, AmountRefunded: 100}, false},
+		{"failed", Order{TotalPrice: 100, PaymentStatus: Failed}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.IsPaid(); got != tt.want {
+				t.Errorf("IsPaid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrder_RefundPartiallyPaid(t *testing.T) {
+	order := &Order{TotalPrice: 100, PaymentStatus: PartiallyPaid, AmountPaid: 40}
+
+	if err := order.RefundPayment(10); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected refunding a partially paid order to conflict, got %v", err)
+	}
+	if order.PaymentStatus != PartiallyPaid || order.AmountRefunded != 0 || order.IsPaid() {
+		t.Errorf("expected the order to stay partially paid and unpaid, got %s with %.2f refunded", order.PaymentStatus, order.AmountRefunded)
+	}
+}
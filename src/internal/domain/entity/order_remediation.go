@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RemediationAction is a one-click support action taken on an order
+type RemediationAction string
+
+const (
+	RemediationRefund         RemediationAction = "refund_without_return"
+	RemediationGoodwillCredit RemediationAction = "goodwill_credit"
+	RemediationResend         RemediationAction = "resend_item"
+)
+
+// RemediationReason is the reason code a support agent picks for an action
+type RemediationReason string
+
+const (
+	ReasonDamaged      RemediationReason = "damaged"
+	ReasonNotReceived  RemediationReason = "not_received"
+	ReasonWrongItem    RemediationReason = "wrong_item"
+	ReasonLateDelivery RemediationReason = "late_delivery"
+	ReasonQualityIssue RemediationReason = "quality_issue"
+	ReasonOther        RemediationReason = "other"
+)
+
+// OrderRemediation records a refund without return, goodwill credit or item
+// resend issued by support. Amount is the money refunded or credited, or the
+// value of the resent items, and counts against the agent's budget.
+type OrderRemediation struct {
+	ID              uuid.UUID         `gorm:"type:uuid;primaryKey"`
+	OrderID         uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Action          RemediationAction `gorm:"type:varchar(30);not null"`
+	Reason          RemediationReason `gorm:"type:varchar(30);not null"`
+	Amount          float64           `gorm:"type:decimal(10,2);not null"`
+	OrderItemID     *uuid.UUID        `gorm:"type:uuid"` // Resent item
+	Quantity        int               `gorm:"not null;default:0"`
+	Note            string            `gorm:"type:text"`
+	PerformedBy     uuid.UUID         `gorm:"type:uuid;not null;index:idx_order_remediations_actor_created,priority:1"`
+	PerformedByRole Role              `gorm:"type:varchar(50);not null"`
+	CreatedAt       time.Time         `gorm:"index:idx_order_remediations_actor_created,priority:2"`
+}
+
+func (r *OrderRemediation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+// IsPayout reports whether the action returns money to the customer
+func (r *OrderRemediation) IsPayout() bool {
+	return r.Action == RemediationRefund || r.Action == RemediationGoodwillCredit
+}
+
+func (r *OrderRemediation) Validate() error {
+	if r.OrderID == uuid.Nil {
+		return ValidationError("Order ID is required")
+	}
+
+	switch r.Action {
+	case RemediationRefund, RemediationGoodwillCredit:
+		if r.Amount <= 0 {
+			return ValidationError("Amount must be greater than zero")
+		}
+	case RemediationResend:
+		if r.OrderItemID == nil {
+			return ValidationError("Order item ID is required to resend an item")
+		}
+		if r.Quantity <= 0 {
+			return ValidationError("Quantity must be greater than zero")
+		}
+	default:
+		return ValidationError("Invalid action. Must be 'refund_without_return', 'goodwill_credit' or 'resend_item'")
+	}
+
+	switch r.Reason {
+	case ReasonDamaged, ReasonNotReceived, ReasonWrongItem, ReasonLateDelivery, ReasonQualityIssue:
+	case ReasonOther:
+		if r.Note == "" {
+			return ValidationError("A note is required when the reason is 'other'")
+		}
+	default:
+		return ValidationError("Invalid reason code. Must be 'damaged', 'not_received', 'wrong_item', 'late_delivery', 'quality_issue' or 'other'")
+	}
+
+	if len(r.Note) > 2000 {
+		return ValidationError("Note cannot exceed 2000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkStatusOrders caps the orders of one bulk status update
+const MaxBulkStatusOrders = 500
+
+// OrderStatusOutcome is what became of one order of a bulk status update
+type OrderStatusOutcome string
+
+const (
+	OrderStatusUpdated   OrderStatusOutcome = "updated"
+	OrderStatusUnchanged OrderStatusOutcome = "unchanged" // The order already had the status
+	OrderStatusNotFound  OrderStatusOutcome = "not_found"
+	OrderStatusRejected  OrderStatusOutcome = "rejected" // The workflow doesn't allow the transition, or a guard failed
+)
+
+// OrderStatusResult reports what became of an order of a bulk status update
+type OrderStatusResult struct {
+	OrderID      uuid.UUID
+	Outcome      OrderStatusOutcome
+	StatusBefore *OrderStatus // Nil when the order wasn't found
+	StatusAfter  *OrderStatus
+	Message      string
+}
+
+func (r *OrderStatusResult) Failed() bool {
+	return r.Outcome == OrderStatusNotFound || r.Outcome == OrderStatusRejected
+}
+
+// BulkOrderStatusReport tells what became of every order of a bulk status update
+type BulkOrderStatusReport struct {
+	Status  OrderStatus
+	Results []OrderStatusResult
+}
+
+// Count returns how many orders ended with outcome
+func (r *BulkOrderStatusReport) Count(outcome OrderStatusOutcome) int {
+	n := 0
+	for _, result := range r.Results {
+		if result.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// BulkOrderStatusUpdate moves many orders to the same status, as a warehouse
+// does with the orders of a picking batch. Every order goes through the
+// workflow on its own: the ones that can move do, the others are reported.
+type BulkOrderStatusUpdate struct {
+	OrderIDs []uuid.UUID
+	Status   OrderStatus
+}
+
+func (u *BulkOrderStatusUpdate) Validate() error {
+	if len(u.OrderIDs) == 0 {
+		return ValidationError("At least one order ID is required")
+	}
+	if len(u.OrderIDs) > MaxBulkStatusOrders {
+		return ValidationError(fmt.Sprintf("At most %d orders can be updated at once", MaxBulkStatusOrders))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(u.OrderIDs))
+	for _, id := range u.OrderIDs {
+		if seen[id] {
+			return ValidationError(fmt.Sprintf("Order %s is listed more than once", id))
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// Apply moves every order found, keyed by ID, through the workflow and returns
+// the result of each ID in order with the orders that changed status
+func (u *BulkOrderStatusUpdate) Apply(orders map[uuid.UUID]*Order, workflow *OrderWorkflow) ([]OrderStatusResult, []*Order) {
+	results := make([]OrderStatusResult, len(u.OrderIDs))
+	var updated []*Order
+
+	for i, id := range u.OrderIDs {
+		result := OrderStatusResult{OrderID: id}
+		order, ok := orders[id]
+		if !ok {
+			result.Outcome = OrderStatusNotFound
+			result.Message = "Order not found"
+			results[i] = result
+			continue
+		}
+
+		before, after := order.Status, u.Status
+		result.StatusBefore, result.StatusAfter = &before, &after
+
+		if before == after {
+			result.Outcome = OrderStatusUnchanged
+		} else if err := order.UpdateStatus(workflow, u.Status); err != nil {
+			result.Outcome = OrderStatusRejected
+			result.StatusAfter = &before
+			result.Message = err.Error()
+		} else {
+			result.Outcome = OrderStatusUpdated
+			updated = append(updated, order)
+		}
+		results[i] = result
+	}
+	return results, updated
+}
@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestBulkOrderStatusUpdate_Validate(t *testing.T) {
+	id := uuid.New()
+	tests := []struct {
+		name    string
+		update  BulkOrderStatusUpdate
+		wantErr bool
+	}{
+		{"valid", BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{id, uuid.New()}, Status: Shipped}, false},
+		{"no orders", BulkOrderStatusUpdate{Status: Shipped}, true},
+		{"duplicate order", BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{id, id}, Status: Shipped}, true},
+		{"too many orders", BulkOrderStatusUpdate{OrderIDs: make([]uuid.UUID, MaxBulkStatusOrders+1), Status: Shipped}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.update.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestBulkOrderStatusUpdate_Apply(t *testing.T) {
+	processing := &Order{ID: uuid.New(), Status: Processing}
+	shipped := &Order{ID: uuid.New(), Status: Shipped}
+	pending := &Order{ID: uuid.New(), Status: Pending}
+	orders := map[uuid.UUID]*Order{processing.ID: processing, shipped.ID: shipped, pending.ID: pending}
+	missing := uuid.New()
+
+	update := BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{processing.ID, shipped.ID, pending.ID, missing}, Status: Shipped}
+	results, updated := update.Apply(orders, NewFulfillmentOrderWorkflow())
+
+	want := []OrderStatusOutcome{OrderStatusUpdated, OrderStatusUnchanged, OrderStatusRejected, OrderStatusNotFound}
+	for i, outcome := range want {
+		if results[i].Outcome != outcome {
+			t.Errorf("expected order %d to be %s, got %s", i, outcome, results[i].Outcome)
+		}
+	}
+	if len(updated) != 1 || updated[0] != processing || processing.Status != Shipped {
+		t.Errorf("expected only the processing order to ship, got %+v", updated)
+	}
+	if pending.Status != Pending || *results[2].StatusAfter != Pending || results[2].Message == "" {
+		t.Errorf("expected a rejected transition to leave the order alone with a reason, got %+v", results[2])
+	}
+	if results[3].StatusBefore != nil {
+		t.Errorf("expected no status for a missing order, got %s", *results[3].StatusBefore)
+	}
+
+	report := BulkOrderStatusReport{Status: Shipped, Results: results}
+	if report.Count(OrderStatusUpdated) != 1 || report.Count(OrderStatusNotFound) != 1 {
+		t.Errorf("expected 1 updated and 1 not found, got %d and %d", report.Count(OrderStatusUpdated), report.Count(OrderStatusNotFound))
+	}
+}
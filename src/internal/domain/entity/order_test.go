@@ -31,6 +31,16 @@ func TestOrder_CalculateTotal(t *testing.T) {
 			},
 			want: 350.00,
 		},
+		{
+			name: "rounds to cents",
+			order: Order{
+				Products: []OrderItem{
+					{ID: uuid.New(), Price: 0.1, Quantity: 1, TotalPrice: 0.1},
+					{ID: uuid.New(), Price: 0.2, Quantity: 1, TotalPrice: 0.2},
+				},
+			},
+			want: 0.3,
+		},
 		{
 			name: "empty order",
 			order: Order{
@@ -92,7 +102,7 @@ func TestOrder_CanTransitionTo(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			order := Order{Status: tt.current}
-			err := order.CanTransitionTo(tt.newStatus)
+			err := order.CanTransitionTo(NewSimpleOrderWorkflow(), tt.newStatus)
 			got := err == nil
 			if got != tt.want {
 				t.Errorf("CanTransitionTo() = %v, want %v", got, tt.want)
@@ -125,7 +135,7 @@ func TestOrder_UpdateStatus(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			order := &Order{Status: tt.current}
-			err := order.UpdateStatus(tt.newStatus)
+			err := order.UpdateStatus(NewSimpleOrderWorkflow(), tt.newStatus)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("UpdateStatus() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -238,6 +248,75 @@ func TestOrderItem_Subtotal(t *testing.T) {
 	}
 }
 
+func TestOrderItem_Components(t *testing.T) {
+	item := OrderItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 3, Price: 20}
+	item.AddComponent(ComponentBase, "", 60)
+	item.AddComponent(ComponentDiscount, "10% off", -6)
+	item.AddComponent(ComponentTax, "Tax 20%", 10.8)
+	item.AddComponent(ComponentSurcharge, "Surcharge", 0)
+	item.CalculateTotal()
+
+	if len(item.Components) != 3 {
+		t.Errorf("expected the zero surcharge to be skipped, got %d components", len(item.Components))
+	}
+	if item.TotalPrice != 64.8 {
+		t.Errorf("TotalPrice = %v, want 64.8", item.TotalPrice)
+	}
+	if got := item.ComponentTotal(ComponentDiscount); got != -6 {
+		t.Errorf("ComponentTotal(discount) = %v, want -6", got)
+	}
+	if got := item.AmountFor(1); got != 21.6 {
+		t.Errorf("AmountFor(1) = %v, want 21.6", got)
+	}
+	if err := item.Validate(); err != nil {
+		t.Errorf("expected a valid item, got %v", err)
+	}
+
+	item.AddComponent(ComponentTax, "Refund", -1)
+	if err := item.Validate(); err == nil {
+		t.Error("expected a negative tax component to be rejected")
+	}
+}
+
+func TestOrderItem_ComponentTotalWithoutComponents(t *testing.T) {
+	item := OrderItem{Quantity: 2, Price: 10.5, TotalPrice: 21}
+
+	if got := item.ComponentTotal(ComponentBase); got != 21 {
+		t.Errorf("ComponentTotal(base) = %v, want 21", got)
+	}
+	if got := item.ComponentTotal(ComponentTax); got != 0 {
+		t.Errorf("ComponentTotal(tax) = %v, want 0", got)
+	}
+}
+
+func TestOrderItem_SnapshotProduct(t *testing.T) {
+	product := &Product{Name: "T-Shirt"}
+	variant := &ProductVariant{SKU: "TSHIRT-L-RED"}
+	variant.SetOptions([]VariantOption{
+		{Name: "Size", Value: "L", Position: 0},
+		{Name: "Color", Value: "Red", Position: 1},
+	})
+
+	item := OrderItem{}
+	item.SnapshotProduct(product, variant)
+	// Later catalog edits don't reach the snapshot
+	product.Name = "Renamed"
+	variant.SKU = "CHANGED"
+
+	if item.ProductName != "T-Shirt" || item.SKU != "TSHIRT-L-RED" {
+		t.Errorf("unexpected snapshot %q %q", item.ProductName, item.SKU)
+	}
+	if got := item.Description(); got != "T-Shirt (Size: L, Color: Red)" {
+		t.Errorf("Description() = %q, want %q", got, "T-Shirt (Size: L, Color: Red)")
+	}
+
+	plain := OrderItem{}
+	plain.SnapshotProduct(product, nil)
+	if got := plain.Description(); got != "Renamed" || plain.SKU != "" {
+		t.Errorf("Description() = %q, SKU = %q", got, plain.SKU)
+	}
+}
+
 func TestOrder_BeforeCreate(t *testing.T) {
 	t.Run("generates UUID if not set", func(t *testing.T) {
 		order := &Order{}
@@ -0,0 +1,107 @@
+package entity
+
+// OrderGuard checks that an order meets a condition of a status transition,
+// returning why it doesn't
+type OrderGuard func(o *Order) error
+
+// OrderWorkflow is the state machine of order statuses: the transitions an
+// order can take, each with the guards it must pass, and the status the
+// payment of a pending order moves it to
+type OrderWorkflow struct {
+	paid        OrderStatus
+	transitions map[OrderStatus]map[OrderStatus][]OrderGuard
+}
+
+// NewOrderWorkflow returns a workflow without transitions, paid orders move
+// to the paid status
+func NewOrderWorkflow(paid OrderStatus) *OrderWorkflow {
+	return &OrderWorkflow{
+		paid:        paid,
+		transitions: make(map[OrderStatus]map[OrderStatus][]OrderGuard),
+	}
+}
+
+// Allow adds the transition from one status to another, taken when every
+// guard passes
+func (w *OrderWorkflow) Allow(from, to OrderStatus, guards ...OrderGuard) *OrderWorkflow {
+	if w.transitions[from] == nil {
+		w.transitions[from] = make(map[OrderStatus][]OrderGuard)
+	}
+	w.transitions[from][to] = guards
+	return w
+}
+
+// PaidStatus is the status a pending order moves to once paid
+func (w *OrderWorkflow) PaidStatus() OrderStatus {
+	return w.paid
+}
+
+// CanTransition tells whether the order can move to status, a ConflictError
+// when the workflow has no such transition or a guard fails
+func (w *OrderWorkflow) CanTransition(o *Order, to OrderStatus) error {
+	guards, ok := w.transitions[o.Status][to]
+	if !ok {
+		return ConflictError("Invalid status transition from " + string(o.Status) + " to " + string(to))
+	}
+	for _, guard := range guards {
+		if err := guard(o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RequirePaid lets only orders paid in full through
+func RequirePaid(o *Order) error {
+	if !o.IsPaid() {
+		return ConflictError("Order must be paid first")
+	}
+	return nil
+}
+
+// RequireCaptured lets through orders that were paid in full, whether the
+// payment was refunded since or not
+func RequireCaptured(o *Order) error {
+	if !o.IsPaid() && o.PaymentStatus != PaymentRefunded {
+		return ConflictError("Order must be paid first")
+	}
+	return nil
+}
+
+// RequireNoAuthorization keeps back orders whose payment was authorized but
+// not captured yet
+func RequireNoAuthorization(o *Order) error {
+	if o.PaymentStatus == Authorized {
+		return ConflictError("Authorized payment must be captured first")
+	}
+	return nil
+}
+
+// NewSimpleOrderWorkflow is the workflow of stores that don't track
+// fulfillment: paying an order completes it. An order whose payment is only
+// authorized is completed by capturing it. Under both workflows, an order
+// held for review is approved by moving it to pending.
+func NewSimpleOrderWorkflow() *OrderWorkflow {
+	return NewOrderWorkflow(Completed).
+		Allow(Review, Pending).
+		Allow(Review, Cancelled).
+		Allow(Pending, Completed, RequireNoAuthorization).
+		Allow(Pending, Cancelled).
+		Allow(Completed, Refunded, RequireCaptured)
+}
+
+// NewFulfillmentOrderWorkflow tracks paid orders until they are delivered.
+// Orders completed under the simple workflow can still be refunded.
+func NewFulfillmentOrderWorkflow() *OrderWorkflow {
+	return NewOrderWorkflow(Processing).
+		Allow(Review, Pending).
+		Allow(Review, Cancelled).
+		Allow(Pending, Processing, RequirePaid).
+		Allow(Pending, Cancelled).
+		Allow(Processing, Shipped).
+		Allow(Processing, Refunded, RequireCaptured).
+		Allow(Shipped, Delivered).
+		Allow(Delivered, Completed).
+		Allow(Delivered, Refunded, RequireCaptured).
+		Allow(Completed, Refunded, RequireCaptured)
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFulfillmentOrderWorkflow(t *testing.T) {
+	workflow := NewFulfillmentOrderWorkflow()
+
+	tests := []struct {
+		name    string
+		order   Order
+		to      OrderStatus
+		wantErr bool
+	}{
+		{"paid pending to processing", Order{Status: Pending, PaymentStatus: Paid}, Processing, false},
+		{"unpaid pending to processing", Order{Status: Pending, PaymentStatus: Unpaid}, Processing, true},
+		{"pending to cancelled", Order{Status: Pending}, Cancelled, false},
+		{"pending skips to shipped", Order{Status: Pending, PaymentStatus: Paid}, Shipped, true},
+		{"processing to shipped", Order{Status: Processing, PaymentStatus: Paid}, Shipped, false},
+		{"processing to refunded", Order{Status: Processing, PaymentStatus: Paid}, Refunded, false},
+		{"shipped to delivered", Order{Status: Shipped, PaymentStatus: Paid}, Delivered, false},
+		{"shipped to cancelled", Order{Status: Shipped, PaymentStatus: Paid}, Cancelled, true},
+		{"delivered to completed", Order{Status: Delivered, PaymentStatus: Paid}, Completed, false},
+		{"delivered to refunded", Order{Status: Delivered, PaymentStatus: Paid}, Refunded, false},
+		{"completed to refunded", Order{Status: Completed, PaymentStatus: Paid}, Refunded, false},
+		{"partially paid pending to processing", Order{Status: Pending, PaymentStatus: PartiallyPaid}, Processing, true},
+		{"payment refunded to refunded", Order{Status: Delivered, PaymentStatus: PaymentRefunded}, Refunded, false},
+		{"refunded is final", Order{Status: Refunded, PaymentStatus: Paid}, Completed, true},
+		{"review approved to pending", Order{Status: Review}, Pending, false},
+		{"review to cancelled", Order{Status: Review}, Cancelled, false},
+		{"review skips to processing", Order{Status: Review, PaymentStatus: Paid}, Processing, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflow.CanTransition(&tt.order, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrConflict) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+		})
+	}
+
+	if got := workflow.PaidStatus(); got != Processing {
+		t.Errorf("PaidStatus() = %v, want processing", got)
+	}
+}
+
+func TestSimpleOrderWorkflow(t *testing.T) {
+	workflow := NewSimpleOrderWorkflow()
+
+	tests := []struct {
+		name    string
+		order   Order
+		to      OrderStatus
+		wantErr bool
+	}{
+		{"paid pending to completed", Order{Status: Pending, PaymentStatus: Paid}, Completed, false},
+		{"unpaid pending to completed", Order{Status: Pending, PaymentStatus: Unpaid}, Completed, false},
+		{"authorized pending to completed", Order{Status: Pending, PaymentStatus: Authorized}, Completed, true},
+		{"authorized pending to cancelled", Order{Status: Pending, PaymentStatus: Authorized}, Cancelled, false},
+		{"completed to refunded", Order{Status: Completed, PaymentStatus: Paid}, Refunded, false},
+		{"pending to processing", Order{Status: Pending, PaymentStatus: Paid}, Processing, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := workflow.CanTransition(&tt.order, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CanTransition() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrConflict) {
+				t.Errorf("expected a conflict, got %v", err)
+			}
+		})
+	}
+}
+
+func TestOrderWorkflow_Allow(t *testing.T) {
+	onHold := OrderStatus("on_hold")
+	workflow := NewOrderWorkflow(Completed).
+		Allow(Pending, onHold).
+		Allow(onHold, Completed, RequirePaid)
+
+	order := &Order{Status: Pending}
+	if err := order.UpdateStatus(workflow, onHold); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := order.UpdateStatus(workflow, Completed); err == nil {
+		t.Fatal("expected the guard to stop an unpaid order")
+	}
+	order.PaymentStatus = Paid
+	if err := order.UpdateStatus(workflow, Completed); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := order.UpdateStatus(workflow, Cancelled); err == nil {
+		t.Error("expected no transition out of completed")
+	}
+}
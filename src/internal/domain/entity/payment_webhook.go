@@ -6,12 +6,17 @@ import (
 	"github.com/google/uuid"
 )
 
-// PaymentWebhookRequest represents a simplified payment webhook payload
+// PaymentWebhookRequest represents a simplified payment webhook payload.
+// Amount is what a paid event captured, the whole balance of the order when
+// left out. Timestamp is when the event happened as providers report it;
+// direct webhooks are checked against their signed X-Payment-Timestamp
+// header instead and may leave it out.
 type PaymentWebhookRequest struct {
-	OrderID       string        `json:"order_id"`
-	TransactionID string        `json:"transaction_id"`
-	PaymentStatus PaymentStatus `json:"payment_status"`
-	Timestamp     int64         `json:"timestamp"`
+	OrderID       string        `json:"order_id" validate:"required,uuid"`
+	TransactionID string        `json:"transaction_id" validate:"required"`
+	PaymentStatus PaymentStatus `json:"payment_status" validate:"required,oneof=authorized paid failed"`
+	Amount        float64       `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Timestamp     int64         `json:"timestamp,omitempty"`
 }
 
 // WebhookStatus represents the processing status of a webhook
@@ -22,6 +27,9 @@ const (
 	WebhookStatusProcessing WebhookStatus = "processing"
 	WebhookStatusCompleted  WebhookStatus = "completed"
 	WebhookStatusFailed     WebhookStatus = "failed"
+	// WebhookStatusResolved marks a webhook an admin handled by hand, it is
+	// not applied nor retried
+	WebhookStatusResolved WebhookStatus = "resolved"
 )
 
 // WebhookLog stores webhook events for audit
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PriceChangeStatus tells where a price change stands at a given time
+type PriceChangeStatus string
+
+const (
+	PriceChangeApplied   PriceChangeStatus = "applied"   // An edit, in effect since it was made
+	PriceChangePending   PriceChangeStatus = "pending"   // Scheduled, not started yet
+	PriceChangeActive    PriceChangeStatus = "active"    // Scheduled, in effect now
+	PriceChangeExpired   PriceChangeStatus = "expired"   // Scheduled, ended
+	PriceChangeCancelled PriceChangeStatus = "cancelled" // Scheduled, cancelled before it started
+)
+
+// PriceChange is an entry of the price history of a product, or of one of its
+// variants when VariantID is set. Edits of the price are recorded as they are
+// made. Scheduled changes are entered ahead of time and only override the
+// price between EffectiveFrom and EffectiveTo, the stored price is left alone.
+type PriceChange struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index:idx_price_changes_product_effective,priority:1"`
+	VariantID     *uuid.UUID `gorm:"type:uuid;index"`
+	Price         *float64   `gorm:"type:decimal(10,2)"` // Unset when a variant price override was removed
+	PreviousPrice *float64   `gorm:"type:decimal(10,2)"` // Unset for the first price and for scheduled changes
+	Scheduled     bool       `gorm:"not null;default:false"`
+	EffectiveFrom time.Time  `gorm:"not null;index:idx_price_changes_product_effective,priority:2"`
+	EffectiveTo   *time.Time // Scheduled changes only, open ended when unset
+	CancelledAt   *time.Time
+	ChangedBy     *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt     time.Time
+}
+
+func (c *PriceChange) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = NewID()
+	}
+	return nil
+}
+
+// NewPriceChange records an edit of the price from previous to price, in effect immediately
+func NewPriceChange(productID uuid.UUID, variantID *uuid.UUID, previous, price *float64, changedBy *uuid.UUID) *PriceChange {
+	now := time.Now()
+	return &PriceChange{
+		ID:            NewID(),
+		ProductID:     productID,
+		VariantID:     variantID,
+		Price:         price,
+		PreviousPrice: previous,
+		EffectiveFrom: now,
+		ChangedBy:     changedBy,
+		CreatedAt:     now,
+	}
+}
+
+func (c *PriceChange) Validate() error {
+	if c.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	if c.Price != nil && *c.Price < 0 {
+		return ValidationError("Price cannot be negative")
+	}
+	if c.EffectiveFrom.IsZero() {
+		return ValidationError("Effective from is required")
+	}
+	if !c.Scheduled {
+		return nil
+	}
+	if c.Price == nil {
+		return ValidationError("Price is required for a scheduled change")
+	}
+	if c.EffectiveTo != nil && !c.EffectiveTo.After(c.EffectiveFrom) {
+		return ValidationError("Effective to must be after effective from")
+	}
+	return nil
+}
+
+// Status returns where the change stands at the given time
+func (c *PriceChange) Status(at time.Time) PriceChangeStatus {
+	switch {
+	case !c.Scheduled:
+		return PriceChangeApplied
+	case c.CancelledAt != nil:
+		return PriceChangeCancelled
+	case at.Before(c.EffectiveFrom):
+		return PriceChangePending
+	case c.EffectiveTo != nil && !at.Before(*c.EffectiveTo):
+		return PriceChangeExpired
+	default:
+		return PriceChangeActive
+	}
+}
+
+// Cancel withdraws a scheduled change that has not started yet
+func (c *PriceChange) Cancel(at time.Time) error {
+	if c.Status(at) != PriceChangePending {
+		return ValidationError("Only scheduled price changes that have not started can be cancelled")
+	}
+	c.CancelledAt = &at
+	return nil
+}
+
+// scheduledPrice returns the price scheduled for the product, or for one of
+// its variants, at the given time. When windows overlap the change that
+// started last wins.
+func scheduledPrice(schedule []PriceChange, variantID *uuid.UUID, at time.Time) (float64, bool) {
+	var current *PriceChange
+	for i := range schedule {
+		change := &schedule[i]
+		if !sameVariant(change.VariantID, variantID) || change.Status(at) != PriceChangeActive || change.Price == nil {
+			continue
+		}
+		if current == nil || change.EffectiveFrom.After(current.EffectiveFrom) {
+			current = change
+		}
+	}
+	if current == nil {
+		return 0, false
+	}
+	return *current.Price, true
+}
+
+func sameVariant(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
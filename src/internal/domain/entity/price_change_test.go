@@ -0,0 +1,147 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func scheduled(productID uuid.UUID, variantID *uuid.UUID, price float64, from time.Time, to *time.Time) PriceChange {
+	return PriceChange{ID: uuid.New(), ProductID: productID, VariantID: variantID, Price: &price, Scheduled: true, EffectiveFrom: from, EffectiveTo: to}
+}
+
+func TestPriceChange_Status(t *testing.T) {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	edit := NewPriceChange(uuid.New(), nil, nil, nil, nil)
+	sale := scheduled(uuid.New(), nil, 10, now, &later)
+	cancelled := scheduled(uuid.New(), nil, 10, later, nil)
+	cancelled.CancelledAt = &now
+
+	tests := []struct {
+		name   string
+		change PriceChange
+		at     time.Time
+		want   PriceChangeStatus
+	}{
+		{"edit", *edit, now.Add(-time.Hour), PriceChangeApplied},
+		{"before the window", sale, now.Add(-time.Minute), PriceChangePending},
+		{"at the start", sale, now, PriceChangeActive},
+		{"at the end", sale, later, PriceChangeExpired},
+		{"cancelled", cancelled, later, PriceChangeCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.change.Status(tt.at); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPriceChange_Validate(t *testing.T) {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+	negative := -1.0
+
+	tests := []struct {
+		name    string
+		change  PriceChange
+		wantErr bool
+	}{
+		{"scheduled", scheduled(uuid.New(), nil, 10, now, nil), false},
+		{"removed override", *NewPriceChange(uuid.New(), nil, &negative, nil, nil), false},
+		{"negative price", *NewPriceChange(uuid.New(), nil, nil, &negative, nil), true},
+		{"scheduled without price", PriceChange{ProductID: uuid.New(), Scheduled: true, EffectiveFrom: now}, true},
+		{"ends before it starts", scheduled(uuid.New(), nil, 10, now, &before), true},
+		{"missing product", scheduled(uuid.Nil, nil, 10, now, nil), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.change.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPriceChange_Cancel(t *testing.T) {
+	now := time.Now()
+	pending := scheduled(uuid.New(), nil, 10, now.Add(time.Hour), nil)
+	if err := pending.Cancel(now); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if pending.Status(now) != PriceChangeCancelled {
+		t.Errorf("expected cancelled, got %s", pending.Status(now))
+	}
+
+	active := scheduled(uuid.New(), nil, 10, now.Add(-time.Hour), nil)
+	if err := active.Cancel(now); err == nil {
+		t.Error("expected error cancelling a change in effect")
+	}
+}
+
+func TestProduct_PriceAt(t *testing.T) {
+	now := time.Now()
+	productID, variantID := uuid.New(), uuid.New()
+	saleEnd := now.Add(48 * time.Hour)
+	product := &Product{ID: productID, Price: 100, PriceSchedule: []PriceChange{
+		scheduled(productID, nil, 80, now, &saleEnd),
+		scheduled(productID, nil, 70, now.Add(24*time.Hour), nil),
+		scheduled(productID, &variantID, 50, now, nil),
+	}}
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want float64
+	}{
+		{"before the schedule", now.Add(-time.Minute), 100},
+		{"during the sale", now.Add(time.Hour), 80},
+		{"overlap, the latest start wins", now.Add(25 * time.Hour), 70},
+		{"after the sale", now.Add(72 * time.Hour), 70},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := product.PriceAt(tt.at); got != tt.want {
+				t.Errorf("expected %.2f, got %.2f", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestProductVariant_PriceAt(t *testing.T) {
+	now := time.Now()
+	productID, variantID, otherID := uuid.New(), uuid.New(), uuid.New()
+	override := 120.0
+	product := &Product{ID: productID, Price: 100, PriceSchedule: []PriceChange{
+		scheduled(productID, nil, 80, now, nil),
+		scheduled(productID, &variantID, 90, now, nil),
+	}}
+
+	tests := []struct {
+		name    string
+		variant ProductVariant
+		want    float64
+	}{
+		{"scheduled for the variant", ProductVariant{ID: variantID, ProductID: productID, Price_Override: &override, Product: product}, 90},
+		{"override over the product schedule", ProductVariant{ID: otherID, ProductID: productID, Price_Override: &override, Product: product}, 120},
+		{"follows the product schedule", ProductVariant{ID: otherID, ProductID: productID, Product: product}, 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.variant.PriceAt(now.Add(time.Minute))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %.2f, got %.2f", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RetailGroup is the customer group every account is in until an admin moves
+// it. Its price tiers apply to the customers of every group.
+const RetailGroup = "retail"
+
+// MaxPriceTiers is how many tiers the price list of a product can hold
+const MaxPriceTiers = 100
+
+var customerGroupPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// ValidateCustomerGroup checks a customer group name, e.g. wholesale
+func ValidateCustomerGroup(group string) error {
+	if !customerGroupPattern.MatchString(group) {
+		return ValidationError("Customer group must be 1 to 50 lowercase letters, digits, - or _, e.g. wholesale")
+	}
+	return nil
+}
+
+// PriceTier is an entry of the price list of a product: the unit price of the
+// product, or of one of its variants when VariantID is set, for lines of at
+// least MinQuantity units bought by customers of CustomerGroup. A tier with a
+// MinQuantity of 1 is a plain group price, the retail group's tiers are
+// quantity breaks for everyone.
+type PriceTier struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ProductID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID     *uuid.UUID `gorm:"type:uuid"`
+	CustomerGroup string     `gorm:"type:varchar(50);not null;default:'retail'"`
+	MinQuantity   int        `gorm:"not null;default:1"`
+	Price         float64    `gorm:"type:decimal(10,2);not null"`
+	CreatedAt     time.Time
+}
+
+func (t *PriceTier) BeforeCreate(tx *gorm.DB) error {
+	if t.ID == uuid.Nil {
+		t.ID = NewID()
+	}
+	return nil
+}
+
+func (t *PriceTier) Validate() error {
+	if t.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	if err := ValidateCustomerGroup(t.CustomerGroup); err != nil {
+		return err
+	}
+	if t.MinQuantity < 1 {
+		return ValidationError("Minimum quantity must be at least 1")
+	}
+	if t.Price < 0 {
+		return ValidationError("Tier price cannot be negative")
+	}
+	return nil
+}
+
+// AppliesTo tells whether the tier prices a line of quantity units of the
+// variant, nil for the product itself, bought by a customer of group. Tiers
+// without a variant apply to every variant of the product.
+func (t *PriceTier) AppliesTo(variantID *uuid.UUID, group string, quantity int) bool {
+	if t.VariantID != nil && (variantID == nil || *t.VariantID != *variantID) {
+		return false
+	}
+	if t.CustomerGroup != group && t.CustomerGroup != RetailGroup {
+		return false
+	}
+	return quantity >= t.MinQuantity
+}
+
+// ValidatePriceList checks the tiers of a product's price list together: each
+// tier is valid and no two tiers share a variant, group and minimum quantity
+func ValidatePriceList(tiers []PriceTier) error {
+	if len(tiers) > MaxPriceTiers {
+		return ValidationError(fmt.Sprintf("A price list can hold at most %d tiers", MaxPriceTiers))
+	}
+
+	type tierKey struct {
+		variant     uuid.UUID
+		group       string
+		minQuantity int
+	}
+	seen := make(map[tierKey]bool, len(tiers))
+	for i := range tiers {
+		if err := tiers[i].Validate(); err != nil {
+			return err
+		}
+		key := tierKey{group: tiers[i].CustomerGroup, minQuantity: tiers[i].MinQuantity}
+		if tiers[i].VariantID != nil {
+			key.variant = *tiers[i].VariantID
+		}
+		if seen[key] {
+			return ValidationError(fmt.Sprintf("Duplicate tier for %s customers from %d units", key.group, key.minQuantity))
+		}
+		seen[key] = true
+	}
+	return nil
+}
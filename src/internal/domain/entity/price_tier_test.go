@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestPriceTier_AppliesTo(t *testing.T) {
+	variantID, otherVariant := uuid.New(), uuid.New()
+	retailBreak := PriceTier{CustomerGroup: RetailGroup, MinQuantity: 10}
+	wholesale := PriceTier{CustomerGroup: "wholesale", MinQuantity: 1}
+	variantTier := PriceTier{VariantID: &variantID, CustomerGroup: RetailGroup, MinQuantity: 1}
+
+	tests := []struct {
+		name     string
+		tier     PriceTier
+		variant  *uuid.UUID
+		group    string
+		quantity int
+		want     bool
+	}{
+		{"below the break", retailBreak, nil, RetailGroup, 9, false},
+		{"at the break", retailBreak, nil, RetailGroup, 10, true},
+		{"retail breaks apply to every group", retailBreak, nil, "wholesale", 10, true},
+		{"product tiers apply to variants", retailBreak, &variantID, RetailGroup, 10, true},
+		{"group price for the group", wholesale, nil, "wholesale", 1, true},
+		{"group price for other groups", wholesale, nil, RetailGroup, 1, false},
+		{"variant tier for the variant", variantTier, &variantID, RetailGroup, 1, true},
+		{"variant tier for another variant", variantTier, &otherVariant, RetailGroup, 1, false},
+		{"variant tier for the product", variantTier, nil, RetailGroup, 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tier.AppliesTo(tt.variant, tt.group, tt.quantity); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestValidatePriceList(t *testing.T) {
+	productID, variantID := uuid.New(), uuid.New()
+	tier := func(variant *uuid.UUID, group string, minQuantity int, price float64) PriceTier {
+		return PriceTier{ProductID: productID, VariantID: variant, CustomerGroup: group, MinQuantity: minQuantity, Price: price}
+	}
+
+	valid := []PriceTier{
+		tier(nil, RetailGroup, 10, 9),
+		tier(nil, "wholesale", 1, 8),
+		tier(&variantID, RetailGroup, 10, 11),
+	}
+	if err := ValidatePriceList(valid); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	tests := map[string][]PriceTier{
+		"duplicate":       {tier(nil, RetailGroup, 10, 9), tier(nil, RetailGroup, 10, 8)},
+		"zero quantity":   {tier(nil, RetailGroup, 0, 9)},
+		"negative price":  {tier(nil, RetailGroup, 1, -1)},
+		"group name":      {tier(nil, "Wholesale", 1, 9)},
+		"too many tiers":  make([]PriceTier, MaxPriceTiers+1),
+		"missing group":   {tier(nil, "", 1, 9)},
+		"missing product": {{CustomerGroup: RetailGroup, MinQuantity: 1}},
+	}
+	for name, tiers := range tests {
+		if err := ValidatePriceList(tiers); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+}
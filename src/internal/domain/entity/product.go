@@ -1,44 +1,141 @@
 package entity
 
 import (
-	"errors"
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// MeasurementUnit is the base unit a unit price is quoted in
+type MeasurementUnit string
+
+const (
+	UnitKilogram MeasurementUnit = "kg"
+	UnitLiter    MeasurementUnit = "l"
+	UnitMeter    MeasurementUnit = "m"
+)
+
+// UnitMeasure is the content of one sellable item expressed in a base unit,
+// e.g. {kg, 0.5} for a 500 g pack
+type UnitMeasure struct {
+	Unit    MeasurementUnit `gorm:"column:measurement_unit;type:varchar(10)"`
+	Content float64         `gorm:"column:unit_content;type:decimal(10,3)"`
+}
+
+// IsSet reports whether unit pricing applies
+func (m UnitMeasure) IsSet() bool {
+	return m.Unit != "" || m.Content != 0
+}
+
+func (m UnitMeasure) Validate() error {
+	if !m.IsSet() {
+		return nil
+	}
+	if m.Unit != UnitKilogram && m.Unit != UnitLiter && m.Unit != UnitMeter {
+		return ValidationError("Invalid measurement unit. Must be 'kg', 'l' or 'm'")
+	}
+	if m.Content <= 0 {
+		return ValidationError("Unit content must be greater than 0")
+	}
+	return nil
+}
+
+// ProductStatus is where a product stands in its publishing workflow
+type ProductStatus string
+
+const (
+	ProductDraft    ProductStatus = "draft"    // Being prepared, only admins see it
+	ProductActive   ProductStatus = "active"   // Listed and for sale
+	ProductArchived ProductStatus = "archived" // No longer sold, kept for its orders and history
+)
+
+// ErrProductHasOrders is returned when deleting a product that orders were
+// placed for. Invoices and order history need it, so it is archived instead.
+var ErrProductHasOrders = ConflictError("Product has orders, archive it instead to keep it in their history")
+
+func (s ProductStatus) IsValid() bool {
+	return s == ProductDraft || s == ProductActive || s == ProductArchived
+}
+
 type Product struct {
-	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
-	Name        string    `gorm:"size:255;not null"`
-	Description string    `gorm:"type:text"`
-	Price       float64   `gorm:"type:decimal(10,2);not null"`
-	Quantity    int       `gorm:"not null"`
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   gorm.DeletedAt `gorm:"index"`
+	ID             uuid.UUID     `gorm:"type:uuid;primaryKey"`
+	Name           string        `gorm:"size:255;not null"`
+	Description    string        `gorm:"type:text"`
+	Price          float64       `gorm:"type:decimal(10,2);not null"`
+	Quantity       int           `gorm:"not null"`
+	HighDemandMode bool          `gorm:"not null;default:false"` // Purchases go through a fair queue with short windows
+	Cost           *float64      `gorm:"type:decimal(10,2)"`     // Unit cost, admin only, unset when unknown
+	Measure        UnitMeasure   `gorm:"embedded"`
+	Status         ProductStatus `gorm:"type:varchar(20);not null;default:'active';index"`
+	AvailableFrom  *time.Time    `gorm:"index"`                                      // Not sold before, e.g. the start of a flash sale; unset sells right away
+	AvailableUntil *time.Time    `gorm:"index"`                                      // Not sold from then on, e.g. the end of a season; unset sells for good
+	MaxPerOrder    int           `gorm:"not null;default:0"`                         // Units one order can have, 0 for no limit
+	MaxPerCustomer int           `gorm:"not null;default:0"`                         // Units one customer can buy over all their orders, 0 for no limit
+	RatingAverage  float64       `gorm:"type:decimal(3,2);not null;default:0;index"` // Mean stars of the reviews, 0 without any; set with the reviews, see product_review.go
+	RatingCount    int           `gorm:"not null;default:0"`                         // Reviews the average is over
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
 
 	// Relations (not stored in DB, loaded via GORM preload)
-	Variants   []ProductVariant `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
-	Categories []Category       `gorm:"many2many:product_categories;"`
+	Variants   []ProductVariant   `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Options    []ProductOption    `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Categories []Category         `gorm:"many2many:product_categories;"`
+	Attributes []ProductAttribute `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+
+	// PriceSchedule holds the scheduled price changes of the product and its
+	// variants that are or will be in effect, attached by the use cases that
+	// sell or show the product. Price is the price outside of them.
+	PriceSchedule []PriceChange `gorm:"-" json:"-"`
+
+	// Translation is the name and description in the language the caller
+	// asked for, set by Localize. Name and Description stay in the language
+	// the product was written in.
+	Translation *ProductTranslation `gorm:"-" json:"-"`
 }
 
 func (p *Product) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
-		p.ID = uuid.New()
+		p.ID = NewID()
+	}
+	if p.Status == "" {
+		p.Status = ProductActive
 	}
 	return nil
 }
 
 func (p *Product) Validate() error {
 	if p.Name == "" {
-		return errors.New("Product name is required")
+		return ValidationError("Product name is required")
 	}
 	if p.Price < 0 {
-		return errors.New("Product price cannot be negative")
+		return ValidationError("Product price cannot be negative")
 	}
 	if p.Quantity < 0 {
-		return errors.New("Product quantity cannot be negative")
+		return ValidationError("Product quantity cannot be negative")
+	}
+	if p.Cost != nil && *p.Cost < 0 {
+		return ValidationError("Product cost cannot be negative")
+	}
+	if err := p.Measure.Validate(); err != nil {
+		return err
+	}
+	if p.Status != "" && !p.Status.IsValid() {
+		return ValidationError("Invalid product status. Must be 'draft', 'active' or 'archived'")
+	}
+	if p.AvailableFrom != nil && p.AvailableUntil != nil && !p.AvailableUntil.After(*p.AvailableFrom) {
+		return ValidationError("Product availability must end after it starts")
+	}
+	if p.MaxPerOrder < 0 || p.MaxPerCustomer < 0 {
+		return ValidationError("Product purchase limits cannot be negative")
+	}
+	if p.MaxPerOrder > 0 && p.MaxPerCustomer > 0 && p.MaxPerOrder > p.MaxPerCustomer {
+		return ValidationError("Product limit per order cannot exceed the limit per customer")
 	}
 
 	return nil
@@ -49,18 +146,104 @@ func (p *Product) ValidateForCreation() error {
 		return err
 	}
 	if p.Quantity == 0 {
-		return errors.New("Product quantity must be greater than 0 for new products")
+		return ValidationError("Product quantity must be greater than 0 for new products")
+	}
+	return nil
+}
+
+// IsPublished reports whether the public can see and order the product
+func (p *Product) IsPublished() bool {
+	return p.Status == ProductActive
+}
+
+// IsAvailableAt reports whether at falls in the availability window of the
+// product, from AvailableFrom included to AvailableUntil excluded
+func (p *Product) IsAvailableAt(at time.Time) bool {
+	if p.AvailableFrom != nil && at.Before(*p.AvailableFrom) {
+		return false
+	}
+	return p.AvailableUntil == nil || at.Before(*p.AvailableUntil)
+}
+
+// IsOnSaleAt reports whether the product is published and available at the
+// given time, the products public listings and search show
+func (p *Product) IsOnSaleAt(at time.Time) bool {
+	return p.IsPublished() && p.IsAvailableAt(at)
+}
+
+// CheckPurchasable returns an error unless customers can order the product now
+func (p *Product) CheckPurchasable() error {
+	if !p.IsPublished() {
+		return ValidationError("Product is not available for sale: " + p.Name)
+	}
+	now := time.Now()
+	if p.AvailableFrom != nil && now.Before(*p.AvailableFrom) {
+		return ValidationError("Product is not available until " + p.AvailableFrom.UTC().Format(time.RFC3339) + ": " + p.Name)
+	}
+	if !p.IsAvailableAt(now) {
+		return ValidationError("Product is no longer available: " + p.Name)
+	}
+	return nil
+}
+
+// HasPurchaseLimits tells whether orders of the product are capped, e.g. for
+// a limited-edition drop
+func (p *Product) HasPurchaseLimits() bool {
+	return p.MaxPerOrder > 0 || p.MaxPerCustomer > 0
+}
+
+// CheckPurchaseLimits returns an error unless a customer who already bought
+// purchased units of the product can order quantity more in one order
+func (p *Product) CheckPurchaseLimits(quantity, purchased int) error {
+	if p.MaxPerOrder > 0 && quantity > p.MaxPerOrder {
+		return ValidationError("At most " + strconv.Itoa(p.MaxPerOrder) + " units per order of product: " + p.Name)
+	}
+	if p.MaxPerCustomer > 0 && purchased+quantity > p.MaxPerCustomer {
+		left := max(p.MaxPerCustomer-purchased, 0)
+		return ValidationError("At most " + strconv.Itoa(p.MaxPerCustomer) + " units per customer of product, " + strconv.Itoa(left) + " left: " + p.Name)
+	}
+	return nil
+}
+
+// Publish puts a draft or archived product on sale
+func (p *Product) Publish() error {
+	if p.Status == ProductActive {
+		return ConflictError("Product is already published")
+	}
+	p.setStatus(ProductActive)
+	return nil
+}
+
+// Unpublish takes a product off sale and back to draft
+func (p *Product) Unpublish() error {
+	if p.Status != ProductActive {
+		return ConflictError("Only published products can be unpublished")
 	}
+	p.setStatus(ProductDraft)
 	return nil
 }
 
+// Archive retires a product, it stays hidden until published again
+func (p *Product) Archive() error {
+	if p.Status == ProductArchived {
+		return ConflictError("Product is already archived")
+	}
+	p.setStatus(ProductArchived)
+	return nil
+}
+
+func (p *Product) setStatus(status ProductStatus) {
+	p.Status = status
+	p.UpdatedAt = time.Now()
+}
+
 func (p *Product) IsAvailable(quantity int) bool {
 	return p.Quantity >= quantity
 }
 
 func (p *Product) DecreaseStock(quantity int) error {
 	if !p.IsAvailable(quantity) {
-		return errors.New("Insufficient stock")
+		return ErrInsufficientStock
 	}
 
 	p.Quantity -= quantity
@@ -71,7 +254,7 @@ func (p *Product) DecreaseStock(quantity int) error {
 
 func (p *Product) IncreaseStock(quantity int) error {
 	if quantity < 0 {
-		return errors.New("Quantity must be positive")
+		return ValidationError("Quantity must be positive")
 	}
 
 	p.Quantity += quantity
@@ -80,6 +263,77 @@ func (p *Product) IncreaseStock(quantity int) error {
 	return nil
 }
 
+// EffectivePrice returns the price the product sells at now
+func (p *Product) EffectivePrice() float64 {
+	return p.PriceAt(time.Now())
+}
+
+// PriceAt returns the price the product sells at at the given time: the
+// scheduled price in effect then, or the stored price
+func (p *Product) PriceAt(at time.Time) float64 {
+	if price, ok := scheduledPrice(p.PriceSchedule, nil, at); ok {
+		return price
+	}
+	return p.Price
+}
+
+// UnitPrice returns the effective price per base unit rounded to cents, or 0
+// when the product is not sold by measure
+func (p *Product) UnitPrice() float64 {
+	if !p.Measure.IsSet() || p.Measure.Content <= 0 {
+		return 0
+	}
+	return math.Round(p.EffectivePrice()/p.Measure.Content*100) / 100
+}
+
+// ContentHash fingerprints the editable content of the product. External
+// systems send it back in If-Match to update only the version they have seen.
+// The fingerprinted document is appended by hand rather than marshaled: it is
+// computed for every product of every listing, and it must stay byte for byte
+// what json.Marshal produced so hashes already handed out keep matching.
+func (p *Product) ContentHash() string {
+	content := make([]byte, 0, 128+len(p.Name)+len(p.Description))
+	content = append(content, `{"name":`...)
+	content = appendJSONString(content, p.Name)
+	content = append(content, `,"description":`...)
+	content = appendJSONString(content, p.Description)
+	content = append(content, `,"price":`...)
+	content, ok := appendJSONFloat(content, p.Price)
+	content = append(content, `,"quantity":`...)
+	content = strconv.AppendInt(content, int64(p.Quantity), 10)
+	if p.Measure.Unit != "" {
+		content = append(content, `,"measurement_unit":`...)
+		content = appendJSONString(content, string(p.Measure.Unit))
+	}
+	if p.Measure.Content != 0 {
+		content = append(content, `,"unit_content":`...)
+		var contentOK bool
+		content, contentOK = appendJSONFloat(content, p.Measure.Content)
+		ok = ok && contentOK
+	}
+	content = append(content, '}')
+	if !ok {
+		content = nil // json.Marshal fails on NaN and infinities
+	}
+
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Margin returns the share of the price left after the unit cost, between 0
+// and 1. It reports false when the cost is unknown or the product is free.
+func (p *Product) Margin() (float64, bool) {
+	if p.Cost == nil || p.Price <= 0 {
+		return 0, false
+	}
+	return math.Max(0, math.Min(1, (p.Price-*p.Cost)/p.Price)), true
+}
+
+// InStock reports whether the product or any of its variants can be bought
+func (p *Product) InStock() bool {
+	return p.Quantity > 0 || p.GetTotalVariantStock() > 0
+}
+
 // HasVariants returns true if the product has any variants
 func (p *Product) HasVariants() bool {
 	return len(p.Variants) > 0
@@ -98,10 +352,17 @@ func (p *Product) GetTotalVariantStock() int {
 	return total
 }
 
-// GetVariantByNameValue finds a variant by name and value
-func (p *Product) GetVariantByNameValue(name, value string) *ProductVariant {
+// GetVariantByOptions finds the variant with exactly the given option values,
+// matched regardless of case
+func (p *Product) GetVariantByOptions(selection map[string]string) *ProductVariant {
+	options := make([]VariantOption, 0, len(selection))
+	for name, value := range selection {
+		options = append(options, VariantOption{Name: name, Value: value})
+	}
+	key := CombinationKey(options)
+
 	for i := range p.Variants {
-		if p.Variants[i].VariantName == name && p.Variants[i].VariantValue == value {
+		if p.Variants[i].CombinationKey == key {
 			return &p.Variants[i]
 		}
 	}
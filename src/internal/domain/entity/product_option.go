@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ProductOption is an axis a product varies on, such as Size or Color.
+// Every variant of the product picks exactly one value per option.
+type ProductOption struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_option_name"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_product_option_name"`
+	Position  int       `gorm:"not null;default:0"` // Order the option is shown in variant titles
+	CreatedAt time.Time
+
+	Values []ProductOptionValue `gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE"`
+}
+
+func (o *ProductOption) BeforeCreate(tx *gorm.DB) error {
+	if o.ID == uuid.Nil {
+		o.ID = NewID()
+	}
+	return nil
+}
+
+func (o *ProductOption) Validate() error {
+	if strings.TrimSpace(o.Name) == "" {
+		return ValidationError("Option name is required")
+	}
+	if len(o.Name) > 100 {
+		return ValidationError("Option name must be at most 100 characters")
+	}
+	if len(o.Values) == 0 {
+		return ValidationError("Option must have at least one value")
+	}
+
+	seen := make(map[string]bool)
+	for _, value := range o.Values {
+		if err := value.Validate(); err != nil {
+			return err
+		}
+		key := strings.ToLower(value.Value)
+		if seen[key] {
+			return fmt.Errorf("Duplicate option value %q", value.Value)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// FindValue returns the option's value matching value case-insensitively
+func (o *ProductOption) FindValue(value string) *ProductOptionValue {
+	for i := range o.Values {
+		if strings.EqualFold(o.Values[i].Value, strings.TrimSpace(value)) {
+			return &o.Values[i]
+		}
+	}
+	return nil
+}
+
+// ProductOptionValue is one of the values an option can take, such as "L" for Size
+type ProductOptionValue struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OptionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_option_value"`
+	Value    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_option_value"`
+	Position int       `gorm:"not null;default:0"`
+}
+
+func (v *ProductOptionValue) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = NewID()
+	}
+	return nil
+}
+
+func (v *ProductOptionValue) Validate() error {
+	if strings.TrimSpace(v.Value) == "" {
+		return ValidationError("Option value is required")
+	}
+	if len(v.Value) > 100 {
+		return ValidationError("Option value must be at most 100 characters")
+	}
+	return nil
+}
+
+// VariantOption is the value a variant takes for one of its product's options.
+// Name and Value are copied from the option so a variant reads "Size: L"
+// without joins; options can't be renamed, so the copies don't drift.
+type VariantOption struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	VariantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_variant_option"`
+	OptionID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_variant_option;index"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Value     string    `gorm:"type:varchar(100);not null"`
+	Position  int       `gorm:"not null;default:0"`
+}
+
+func (v *VariantOption) BeforeCreate(tx *gorm.DB) error {
+	if v.ID == uuid.Nil {
+		v.ID = NewID()
+	}
+	return nil
+}
+
+// BuildVariantOptions resolves a selection of option name to value against the
+// product's options. The selection must name every option exactly once.
+func BuildVariantOptions(options []*ProductOption, selection map[string]string) ([]VariantOption, error) {
+	if len(options) == 0 {
+		return nil, ConflictError("Product has no options, define them before adding variants")
+	}
+
+	remaining := make(map[string]string, len(selection))
+	for name, value := range selection {
+		remaining[strings.ToLower(strings.TrimSpace(name))] = value
+	}
+
+	variantOptions := make([]VariantOption, 0, len(options))
+	for _, option := range options {
+		key := strings.ToLower(option.Name)
+		raw, ok := remaining[key]
+		if !ok {
+			return nil, fmt.Errorf("Missing value for option %q", option.Name)
+		}
+		delete(remaining, key)
+
+		value := option.FindValue(raw)
+		if value == nil {
+			return nil, fmt.Errorf("Invalid value %q for option %q", raw, option.Name)
+		}
+
+		variantOptions = append(variantOptions, VariantOption{
+			OptionID: option.ID,
+			Name:     option.Name,
+			Value:    value.Value,
+			Position: option.Position,
+		})
+	}
+
+	for name := range remaining {
+		return nil, fmt.Errorf("Unknown option %q", name)
+	}
+
+	sort.SliceStable(variantOptions, func(i, j int) bool {
+		return variantOptions[i].Position < variantOptions[j].Position
+	})
+	return variantOptions, nil
+}
+
+// CombinationKey identifies a combination of option values regardless of
+// order or case, e.g. "color=red;size=l"
+func CombinationKey(options []VariantOption) string {
+	pairs := make([]string, len(options))
+	for i, option := range options {
+		pairs[i] = strings.ToLower(option.Name) + "=" + strings.ToLower(option.Value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ";")
+}
+
+// GenerateSKU builds a default SKU from the product ID and the variant's option
+// values, e.g. "D4444444-L-RED"
+func GenerateSKU(productID uuid.UUID, options []VariantOption) string {
+	parts := []string{strings.ToUpper(productID.String()[:8])}
+	for _, option := range options {
+		parts = append(parts, strings.ToUpper(Slugify(option.Value)))
+	}
+	sku := strings.Join(parts, "-")
+	if len(sku) > 64 {
+		sku = sku[:64]
+	}
+	return sku
+}
@@ -0,0 +1,82 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func newTestOptions() []*ProductOption {
+	return []*ProductOption{
+		{ID: uuid.New(), Name: "Size", Position: 0, Values: []ProductOptionValue{{Value: "S"}, {Value: "L"}}},
+		{ID: uuid.New(), Name: "Color", Position: 1, Values: []ProductOptionValue{{Value: "Red"}, {Value: "Blue"}}},
+	}
+}
+
+func TestProductOption_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		option  ProductOption
+		wantErr bool
+	}{
+		{"valid", ProductOption{Name: "Size", Values: []ProductOptionValue{{Value: "S"}, {Value: "L"}}}, false},
+		{"missing name", ProductOption{Name: " ", Values: []ProductOptionValue{{Value: "S"}}}, true},
+		{"no values", ProductOption{Name: "Size"}, true},
+		{"empty value", ProductOption{Name: "Size", Values: []ProductOptionValue{{Value: ""}}}, true},
+		{"duplicate values", ProductOption{Name: "Size", Values: []ProductOptionValue{{Value: "L"}, {Value: "l"}}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.option.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildVariantOptions(t *testing.T) {
+	options := newTestOptions()
+
+	variantOptions, err := BuildVariantOptions(options, map[string]string{"color": "red", "Size": "l"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(variantOptions) != 2 || variantOptions[0].Name != "Size" || variantOptions[0].Value != "L" {
+		t.Errorf("expected canonical values in option order, got %+v", variantOptions)
+	}
+	if variantOptions[1].OptionID != options[1].ID || variantOptions[1].Value != "Red" {
+		t.Errorf("expected Color=Red, got %+v", variantOptions[1])
+	}
+}
+
+func TestBuildVariantOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   []*ProductOption
+		selection map[string]string
+	}{
+		{"product without options", nil, map[string]string{"Size": "L"}},
+		{"missing option", newTestOptions(), map[string]string{"Size": "L"}},
+		{"unknown value", newTestOptions(), map[string]string{"Size": "XL", "Color": "Red"}},
+		{"unknown option", newTestOptions(), map[string]string{"Size": "L", "Color": "Red", "Fit": "Slim"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := BuildVariantOptions(tt.options, tt.selection); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateSKU(t *testing.T) {
+	productID := uuid.MustParse("d4444444-4444-4444-4444-444444444444")
+	sku := GenerateSKU(productID, []VariantOption{{Value: "L"}, {Value: "Space Black"}})
+
+	if sku != "D4444444-L-SPACE-BLACK" {
+		t.Errorf("GenerateSKU() = %q", sku)
+	}
+}
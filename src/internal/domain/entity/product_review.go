@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// ProductReview is the rating a customer gives a product they received, with
+// an optional comment. A customer has one review per product, which they can
+// change or remove; the product keeps the average and count of its reviews.
+type ProductReview struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_reviews_product_user"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_reviews_product_user;index"`
+	Rating    int       `gorm:"not null"` // From MinRating to MaxRating stars
+	Body      string    `gorm:"type:text"`
+	CreatedAt time.Time `gorm:"index"`
+	UpdatedAt time.Time
+}
+
+func (r *ProductReview) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+func (r *ProductReview) Validate() error {
+	if r.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	if r.UserID == uuid.Nil {
+		return ValidationError("Customer ID is required")
+	}
+	if r.Rating < MinRating || r.Rating > MaxRating {
+		return ValidationError("Rating must be from 1 to 5")
+	}
+	if len(r.Body) > 2000 {
+		return ValidationError("Review body cannot exceed 2000 characters")
+	}
+	return nil
+}
+
+// AverageRating is the rating a product shows for count reviews whose ratings
+// add up to sum, rounded to two decimals like the rating_average column
+func AverageRating(sum, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(sum)/float64(count)*100) / 100
+}
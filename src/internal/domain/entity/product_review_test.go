@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestProductReviewValidate(t *testing.T) {
+	valid := ProductReview{ProductID: uuid.New(), UserID: uuid.New(), Rating: 4}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid review rejected: %v", err)
+	}
+
+	for name, change := range map[string]func(r *ProductReview){
+		"no product":  func(r *ProductReview) { r.ProductID = uuid.Nil },
+		"no customer": func(r *ProductReview) { r.UserID = uuid.Nil },
+		"no stars":    func(r *ProductReview) { r.Rating = 0 },
+		"too many":    func(r *ProductReview) { r.Rating = 6 },
+		"long body":   func(r *ProductReview) { r.Body = strings.Repeat("a", 2001) },
+	} {
+		review := valid
+		change(&review)
+		if err := review.Validate(); !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+}
+
+func TestAverageRating(t *testing.T) {
+	cases := []struct {
+		sum, count int
+		want       float64
+	}{
+		{0, 0, 0},
+		{5, 1, 5},
+		{9, 2, 4.5},
+		{13, 3, 4.33},
+		{11, 3, 3.67},
+	}
+	for _, c := range cases {
+		if got := AverageRating(c.sum, c.count); got != c.want {
+			t.Errorf("AverageRating(%d, %d) = %v, want %v", c.sum, c.count, got, c.want)
+		}
+	}
+}
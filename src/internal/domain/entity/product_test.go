@@ -1,12 +1,19 @@
 package entity
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 func TestProduct_Validate(t *testing.T) {
+	availableFrom := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
 	tests := []struct {
 		name    string
 		product Product
@@ -61,6 +68,61 @@ func TestProduct_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "Product quantity cannot be negative",
 		},
+		{
+			name: "valid unit measure",
+			product: Product{
+				Name:     "Coffee beans",
+				Price:    7.99,
+				Quantity: 10,
+				Measure:  UnitMeasure{Unit: UnitKilogram, Content: 0.5},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown measurement unit",
+			product: Product{
+				Name:     "Coffee beans",
+				Price:    7.99,
+				Quantity: 10,
+				Measure:  UnitMeasure{Unit: "lb", Content: 1},
+			},
+			wantErr: true,
+			errMsg:  "Invalid measurement unit. Must be 'kg', 'l' or 'm'",
+		},
+		{
+			name: "unit without content",
+			product: Product{
+				Name:     "Olive oil",
+				Price:    9.49,
+				Quantity: 10,
+				Measure:  UnitMeasure{Unit: UnitLiter},
+			},
+			wantErr: true,
+			errMsg:  "Unit content must be greater than 0",
+		},
+		{
+			name: "unknown status",
+			product: Product{
+				Name:     "Laptop",
+				Price:    999.99,
+				Quantity: 10,
+				Status:   "hidden",
+			},
+			wantErr: true,
+			errMsg:  "Invalid product status. Must be 'draft', 'active' or 'archived'",
+		},
+		{
+			name: "availability ending before it starts",
+			product: Product{
+				Name:           "Laptop",
+				Price:          999.99,
+				Quantity:       10,
+				AvailableFrom:  &availableFrom,
+				AvailableUntil: &availableFrom,
+			},
+			wantErr: true,
+			errMsg:  "Product availability must end after it starts",
+		},
 	}
 
 	for _, tt := range tests {
@@ -77,6 +139,26 @@ func TestProduct_Validate(t *testing.T) {
 	}
 }
 
+func TestProduct_UnitPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		product Product
+		want    float64
+	}{
+		{"500 g pack", Product{Price: 1.99, Measure: UnitMeasure{Unit: UnitKilogram, Content: 0.5}}, 3.98},
+		{"750 ml bottle", Product{Price: 4.49, Measure: UnitMeasure{Unit: UnitLiter, Content: 0.75}}, 5.99},
+		{"not sold by measure", Product{Price: 999.99}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.product.UnitPrice(); got != tt.want {
+				t.Errorf("UnitPrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestProduct_ValidateForCreation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -292,6 +374,128 @@ func TestProduct_BeforeCreate(t *testing.T) {
 			t.Error("BeforeCreate() changed existing UUID")
 		}
 	})
+
+	t.Run("defaults status to active", func(t *testing.T) {
+		draft := &Product{Status: ProductDraft}
+		product := &Product{}
+		draft.BeforeCreate(nil)
+		product.BeforeCreate(nil)
+		if product.Status != ProductActive || draft.Status != ProductDraft {
+			t.Errorf("BeforeCreate() statuses = %s, %s", product.Status, draft.Status)
+		}
+	})
+}
+
+func TestProduct_IsAvailableAt(t *testing.T) {
+	from := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	until := from.Add(72 * time.Hour)
+
+	tests := []struct {
+		name    string
+		product Product
+		at      time.Time
+		want    bool
+	}{
+		{"no window", Product{}, from, true},
+		{"before start", Product{AvailableFrom: &from}, from.Add(-time.Second), false},
+		{"at start", Product{AvailableFrom: &from, AvailableUntil: &until}, from, true},
+		{"before end", Product{AvailableUntil: &until}, until.Add(-time.Second), true},
+		{"at end", Product{AvailableFrom: &from, AvailableUntil: &until}, until, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.product.IsAvailableAt(tt.at); got != tt.want {
+				t.Errorf("IsAvailableAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProduct_CheckPurchasable_Window(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+
+	upcoming := &Product{Name: "Sneaker Drop", Status: ProductActive, AvailableFrom: &future}
+	if err := upcoming.CheckPurchasable(); !errors.Is(err, ErrValidation) {
+		t.Errorf("CheckPurchasable() before the window error = %v", err)
+	}
+	if upcoming.IsOnSaleAt(time.Now()) || !upcoming.IsOnSaleAt(future) {
+		t.Error("expected the product to go on sale when its window starts")
+	}
+
+	ended := &Product{Name: "Winter Coat", Status: ProductActive, AvailableUntil: &past}
+	if err := ended.CheckPurchasable(); !errors.Is(err, ErrValidation) {
+		t.Errorf("CheckPurchasable() after the window error = %v", err)
+	}
+
+	current := &Product{Name: "Laptop", Status: ProductActive, AvailableFrom: &past, AvailableUntil: &future}
+	if err := current.CheckPurchasable(); err != nil {
+		t.Errorf("CheckPurchasable() in the window error = %v", err)
+	}
+}
+
+func TestProduct_CheckPurchaseLimits(t *testing.T) {
+	product := &Product{Name: "Sneaker Drop", MaxPerOrder: 2, MaxPerCustomer: 3}
+
+	tests := []struct {
+		name      string
+		quantity  int
+		purchased int
+		wantErr   bool
+	}{
+		{"within both limits", 2, 1, false},
+		{"over the order limit", 3, 0, true},
+		{"over the customer limit", 2, 2, true},
+		{"customer limit reached", 1, 3, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := product.CheckPurchaseLimits(tt.quantity, tt.purchased)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrValidation)) {
+				t.Errorf("CheckPurchaseLimits(%d, %d) error = %v, wantErr %v", tt.quantity, tt.purchased, err, tt.wantErr)
+			}
+		})
+	}
+
+	unlimited := &Product{Name: "Laptop"}
+	if unlimited.HasPurchaseLimits() || unlimited.CheckPurchaseLimits(100, 100) != nil {
+		t.Error("expected a product without limits to be unlimited")
+	}
+
+	if err := (&Product{Name: "Laptop", MaxPerOrder: 5, MaxPerCustomer: 2}).Validate(); !errors.Is(err, ErrValidation) {
+		t.Errorf("Validate() with an order limit over the customer limit error = %v", err)
+	}
+}
+
+func TestProduct_StatusTransitions(t *testing.T) {
+	product := &Product{Name: "Laptop", Status: ProductDraft}
+	if product.IsPublished() || product.CheckPurchasable() == nil {
+		t.Error("expected a draft to be hidden and not for sale")
+	}
+	if err := product.Unpublish(); !errors.Is(err, ErrConflict) {
+		t.Errorf("Unpublish() of a draft error = %v, want a conflict", err)
+	}
+
+	if err := product.Publish(); err != nil || !product.IsPublished() || product.CheckPurchasable() != nil {
+		t.Errorf("Publish() error = %v, status %s", err, product.Status)
+	}
+	if err := product.Publish(); !errors.Is(err, ErrConflict) {
+		t.Errorf("Publish() twice error = %v, want a conflict", err)
+	}
+
+	if err := product.Unpublish(); err != nil || product.Status != ProductDraft {
+		t.Errorf("Unpublish() error = %v, status %s", err, product.Status)
+	}
+
+	if err := product.Archive(); err != nil || product.Status != ProductArchived || product.CheckPurchasable() == nil {
+		t.Errorf("Archive() error = %v, status %s", err, product.Status)
+	}
+	if err := product.Archive(); !errors.Is(err, ErrConflict) {
+		t.Errorf("Archive() twice error = %v, want a conflict", err)
+	}
+	if err := product.Publish(); err != nil || product.Status != ProductActive {
+		t.Errorf("Publish() of an archived product error = %v, status %s", err, product.Status)
+	}
 }
 
 func TestProduct_HasVariants(t *testing.T) {
@@ -314,7 +518,7 @@ func TestProduct_HasVariants(t *testing.T) {
 			name: "has variants",
 			product: Product{
 				Variants: []ProductVariant{
-					{VariantName: "Size", VariantValue: "Large"},
+					{SKU: "TEST-SKU-LARGE"},
 				},
 			},
 			expected: true,
@@ -323,9 +527,9 @@ func TestProduct_HasVariants(t *testing.T) {
 			name: "multiple variants",
 			product: Product{
 				Variants: []ProductVariant{
-					{VariantName: "Size", VariantValue: "Small"},
-					{VariantName: "Size", VariantValue: "Large"},
-					{VariantName: "Color", VariantValue: "Red"},
+					{SKU: "TEST-SKU-SMALL"},
+					{SKU: "TEST-SKU-LARGE"},
+					{SKU: "TEST-SKU-RED"},
 				},
 			},
 			expected: true,
@@ -357,7 +561,7 @@ func TestProduct_GetTotalVariantStock(t *testing.T) {
 			name: "single variant",
 			product: Product{
 				Variants: []ProductVariant{
-					{VariantName: "Size", VariantValue: "Large", Quantity: 10},
+					{SKU: "TEST-SKU-LARGE", Quantity: 10},
 				},
 			},
 			expected: 10,
@@ -366,9 +570,9 @@ func TestProduct_GetTotalVariantStock(t *testing.T) {
 			name: "multiple variants",
 			product: Product{
 				Variants: []ProductVariant{
-					{VariantName: "Size", VariantValue: "Small", Quantity: 5},
-					{VariantName: "Size", VariantValue: "Medium", Quantity: 8},
-					{VariantName: "Size", VariantValue: "Large", Quantity: 12},
+					{SKU: "TEST-SKU-SMALL", Quantity: 5},
+					{SKU: "TEST-SKU-MEDIUM", Quantity: 8},
+					{SKU: "TEST-SKU-LARGE", Quantity: 12},
 				},
 			},
 			expected: 25,
@@ -377,8 +581,8 @@ func TestProduct_GetTotalVariantStock(t *testing.T) {
 			name: "variants with zero quantity",
 			product: Product{
 				Variants: []ProductVariant{
-					{VariantName: "Color", VariantValue: "Red", Quantity: 0},
-					{VariantName: "Color", VariantValue: "Blue", Quantity: 15},
+					{SKU: "TEST-SKU-RED", Quantity: 0},
+					{SKU: "TEST-SKU-BLUE", Quantity: 15},
 				},
 			},
 			expected: 15,
@@ -395,83 +599,153 @@ func TestProduct_GetTotalVariantStock(t *testing.T) {
 	}
 }
 
-func TestProduct_GetVariantByNameValue(t *testing.T) {
+func TestProduct_GetVariantByOptions(t *testing.T) {
+	newVariant := func(quantity int, options ...VariantOption) ProductVariant {
+		variant := ProductVariant{ID: uuid.New(), Quantity: quantity}
+		variant.SetOptions(options)
+		return variant
+	}
 	product := Product{
 		Variants: []ProductVariant{
-			{ID: uuid.New(), VariantName: "Size", VariantValue: "Small", Quantity: 5},
-			{ID: uuid.New(), VariantName: "Size", VariantValue: "Large", Quantity: 10},
-			{ID: uuid.New(), VariantName: "Color", VariantValue: "Red", Quantity: 8},
+			newVariant(5, VariantOption{Name: "Size", Value: "S"}, VariantOption{Name: "Color", Value: "Red"}),
+			newVariant(10, VariantOption{Name: "Size", Value: "L"}, VariantOption{Name: "Color", Value: "Red"}),
+			newVariant(8, VariantOption{Name: "Size", Value: "L"}, VariantOption{Name: "Color", Value: "Blue"}),
 		},
 	}
 
 	tests := []struct {
-		name         string
-		variantName  string
-		variantValue string
-		expectFound  bool
-		expectedQty  int
+		name        string
+		selection   map[string]string
+		expectFound bool
+		expectedQty int
 	}{
 		{
-			name:         "find existing variant",
-			variantName:  "Size",
-			variantValue: "Large",
-			expectFound:  true,
-			expectedQty:  10,
+			name:        "find existing combination",
+			selection:   map[string]string{"Size": "L", "Color": "Red"},
+			expectFound: true,
+			expectedQty: 10,
 		},
 		{
-			name:         "find another variant",
-			variantName:  "Color",
-			variantValue: "Red",
-			expectFound:  true,
-			expectedQty:  8,
+			name:        "match ignores case",
+			selection:   map[string]string{"size": "l", "color": "blue"},
+			expectFound: true,
+			expectedQty: 8,
 		},
 		{
-			name:         "variant name not found",
-			variantName:  "Weight",
-			variantValue: "Heavy",
-			expectFound:  false,
+			name:        "combination not offered",
+			selection:   map[string]string{"Size": "S", "Color": "Blue"},
+			expectFound: false,
 		},
 		{
-			name:         "variant value not found",
-			variantName:  "Size",
-			variantValue: "XL",
-			expectFound:  false,
-		},
-		{
-			name:         "case sensitive - wrong case",
-			variantName:  "size",
-			variantValue: "large",
-			expectFound:  false,
+			name:        "partial selection",
+			selection:   map[string]string{"Size": "L"},
+			expectFound: false,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := product.GetVariantByNameValue(tt.variantName, tt.variantValue)
+			result := product.GetVariantByOptions(tt.selection)
 
 			if tt.expectFound {
 				if result == nil {
-					t.Errorf("GetVariantByNameValue() = nil, expected variant to be found")
+					t.Errorf("GetVariantByOptions() = nil, expected variant to be found")
 					return
 				}
 				if result.Quantity != tt.expectedQty {
-					t.Errorf("GetVariantByNameValue() quantity = %v, want %v", result.Quantity, tt.expectedQty)
+					t.Errorf("GetVariantByOptions() quantity = %v, want %v", result.Quantity, tt.expectedQty)
 				}
 			} else {
 				if result != nil {
-					t.Errorf("GetVariantByNameValue() = %v, expected nil", result)
+					t.Errorf("GetVariantByOptions() = %v, expected nil", result)
 				}
 			}
 		})
 	}
 }
 
-func TestProduct_GetVariantByNameValue_EmptyProduct(t *testing.T) {
+func TestProduct_GetVariantByOptions_EmptyProduct(t *testing.T) {
 	product := Product{Variants: []ProductVariant{}}
 
-	result := product.GetVariantByNameValue("Size", "Large")
+	result := product.GetVariantByOptions(map[string]string{"Size": "L"})
 
 	if result != nil {
-		t.Errorf("GetVariantByNameValue() on empty variants = %v, want nil", result)
+		t.Errorf("GetVariantByOptions() on empty variants = %v, want nil", result)
+	}
+}
+
+func TestProduct_Margin(t *testing.T) {
+	cost := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name     string
+		product  Product
+		expected float64
+		known    bool
+	}{
+		{"no cost", Product{Price: 100}, 0, false},
+		{"free product", Product{Price: 0, Cost: cost(5)}, 0, false},
+		{"regular margin", Product{Price: 80, Cost: cost(60)}, 0.25, true},
+		{"sold at a loss", Product{Price: 50, Cost: cost(70)}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			margin, known := tt.product.Margin()
+			if margin != tt.expected || known != tt.known {
+				t.Errorf("Margin() = %v, %v, want %v, %v", margin, known, tt.expected, tt.known)
+			}
+		})
+	}
+}
+
+func TestProduct_ContentHashMatchesMarshaledContent(t *testing.T) {
+	// Hashes handed out before ContentHash stopped using json.Marshal must
+	// still match, so the hashed document has to be byte for byte the same
+	marshaledHash := func(p *Product) string {
+		content, _ := json.Marshal(struct {
+			Name        string  `json:"name"`
+			Description string  `json:"description"`
+			Price       float64 `json:"price"`
+			Quantity    int     `json:"quantity"`
+			Unit        string  `json:"measurement_unit,omitempty"`
+			UnitContent float64 `json:"unit_content,omitempty"`
+		}{p.Name, p.Description, p.Price, p.Quantity, string(p.Measure.Unit), p.Measure.Content})
+		sum := sha256.Sum256(content)
+		return hex.EncodeToString(sum[:])
+	}
+
+	tests := []struct {
+		name    string
+		product Product
+	}{
+		{"plain", Product{Name: "Laptop", Description: "High-end gaming laptop", Price: 1299.99, Quantity: 5}},
+		{"empty", Product{}},
+		{"escaped characters", Product{Name: `Tom & Jerry <"DVD">`, Description: "Line\nbreak\ttab\r\b\f\x01\x1f back\\slash", Price: 10}},
+		{"unicode", Product{Name: "Café crème ☕", Description: "sep\u2028ara\u2029tor \x7f", Price: 3.5}},
+		{"invalid utf-8", Product{Name: "bad \xff\xfe byte", Description: "cut \xe2\x82", Price: 1}},
+		{"large and small floats", Product{Name: "Float", Price: 1e21, Measure: UnitMeasure{Unit: UnitKilogram, Content: 1e-7}}},
+		{"float boundaries", Product{Name: "Float", Price: 999999999999999999999, Measure: UnitMeasure{Unit: UnitLiter, Content: 0.000001}}},
+		{"negative values", Product{Name: "Negative", Price: -12.5, Quantity: -3, Measure: UnitMeasure{Unit: UnitMeter, Content: -2.25e-9}}},
+		{"negative zero", Product{Name: "Zero", Price: math.Copysign(0, -1)}},
+		{"unit without content", Product{Name: "Unit", Price: 2, Measure: UnitMeasure{Unit: UnitKilogram}}},
+		{"not a number", Product{Name: "NaN", Price: math.NaN()}},
+		{"infinite content", Product{Name: "Inf", Price: 1, Measure: UnitMeasure{Unit: UnitLiter, Content: math.Inf(1)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, want := tt.product.ContentHash(), marshaledHash(&tt.product); got != want {
+				t.Errorf("ContentHash() = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func BenchmarkProduct_ContentHash(b *testing.B) {
+	product := &Product{Name: "Laptop", Description: "High-end gaming laptop", Price: 1299.99, Quantity: 5}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		product.ContentHash()
 	}
 }
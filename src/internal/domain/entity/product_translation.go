@@ -0,0 +1,105 @@
+package entity
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// localePattern accepts BCP 47 tags made of a language and optional subtags,
+// e.g. "pt", "pt-BR" or "zh-Hant-TW"
+var localePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+// ProductTranslation is the name and description of a product in another
+// language than the one it was written in
+type ProductTranslation struct {
+	ProductID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Locale      string    `gorm:"type:varchar(35);primaryKey"`
+	Name        string    `gorm:"size:255;not null"`
+	Description string    `gorm:"type:text"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (t *ProductTranslation) Validate() error {
+	if strings.TrimSpace(t.Name) == "" {
+		return ValidationError("Translated name is required")
+	}
+	if len(t.Name) > 255 {
+		return ValidationError("Translated name must be at most 255 characters")
+	}
+	return nil
+}
+
+// NormalizeLocale returns tag in its canonical form, the language in lower
+// case and a two letter region in upper case, e.g. "pt_br" becomes "pt-BR"
+func NormalizeLocale(tag string) (string, error) {
+	tag = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"))
+	if len(tag) > 35 || !localePattern.MatchString(tag) {
+		return "", ValidationError("Invalid locale. Must be a language tag like 'es' or 'pt-BR'")
+	}
+
+	subtags := strings.Split(tag, "-")
+	for i := 1; i < len(subtags); i++ {
+		switch len(subtags[i]) {
+		case 2:
+			subtags[i] = strings.ToUpper(subtags[i])
+		case 4:
+			subtags[i] = strings.ToUpper(subtags[i][:1]) + subtags[i][1:]
+		}
+	}
+	return strings.Join(subtags, "-"), nil
+}
+
+// LocaleFallbacks lists the locales to look for in order of preference, each
+// followed by its parent tags unless those are preferred later on, e.g.
+// [pt-BR, en] becomes [pt-BR, pt, en]
+func LocaleFallbacks(preferred []string) []string {
+	seen := make(map[string]bool)
+	var fallbacks []string
+	for i, locale := range preferred {
+		for tag := locale; tag != ""; {
+			if !seen[tag] && (tag == locale || !containsLocale(preferred[i+1:], tag)) {
+				seen[tag] = true
+				fallbacks = append(fallbacks, tag)
+			}
+			cut := strings.LastIndex(tag, "-")
+			if cut < 0 {
+				break
+			}
+			tag = tag[:cut]
+		}
+	}
+	return fallbacks
+}
+
+// Localize puts the translation of the first locale of fallbacks the product
+// has one for on it, and returns that locale. Reaching defaultLocale, the
+// language the product is written in, or running out of locales leaves the
+// product as it is.
+func (p *Product) Localize(translations []ProductTranslation, fallbacks []string, defaultLocale string) string {
+	p.Translation = nil
+	for _, locale := range fallbacks {
+		if locale == defaultLocale {
+			return defaultLocale
+		}
+		for i := range translations {
+			if translations[i].ProductID == p.ID && translations[i].Locale == locale {
+				p.Translation = &translations[i]
+				return locale
+			}
+		}
+	}
+	return defaultLocale
+}
+
+func containsLocale(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	tests := []struct {
+		tag     string
+		want    string
+		wantErr bool
+	}{
+		{"es", "es", false},
+		{"pt_br", "pt-BR", false},
+		{" PT-br ", "pt-BR", false},
+		{"zh-hant-tw", "zh-Hant-TW", false},
+		{"", "", true},
+		{"english", "", true},
+		{"en--US", "", true},
+		{"*", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got, err := NormalizeLocale(tt.tag)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLocaleFallbacks(t *testing.T) {
+	tests := []struct {
+		preferred []string
+		want      []string
+	}{
+		{nil, nil},
+		{[]string{"pt-BR", "en"}, []string{"pt-BR", "pt", "en"}},
+		{[]string{"pt-BR", "es", "pt"}, []string{"pt-BR", "es", "pt"}},
+		{[]string{"zh-Hant-TW"}, []string{"zh-Hant-TW", "zh-Hant", "zh"}},
+		{[]string{"es", "es-MX"}, []string{"es", "es-MX"}},
+	}
+
+	for _, tt := range tests {
+		if got := LocaleFallbacks(tt.preferred); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LocaleFallbacks(%v) = %v, want %v", tt.preferred, got, tt.want)
+		}
+	}
+}
+
+func TestProduct_Localize(t *testing.T) {
+	product := &Product{ID: uuid.New(), Name: "Shirt"}
+	translations := []ProductTranslation{
+		{ProductID: uuid.New(), Locale: "es", Name: "Falda"},
+		{ProductID: product.ID, Locale: "es", Name: "Camisa"},
+		{ProductID: product.ID, Locale: "pt", Name: "Camiseta"},
+	}
+
+	if locale := product.Localize(translations, []string{"pt-BR", "pt", "es"}, "en"); locale != "pt" || product.Translation.Name != "Camiseta" {
+		t.Errorf("expected the pt translation, got %s %+v", locale, product.Translation)
+	}
+	if locale := product.Localize(translations, []string{"fr", "es"}, "en"); locale != "es" || product.Translation.Name != "Camisa" {
+		t.Errorf("expected the translation of the product, got %s %+v", locale, product.Translation)
+	}
+	if locale := product.Localize(translations, []string{"en", "es"}, "en"); locale != "en" || product.Translation != nil {
+		t.Errorf("expected the default locale to win, got %s %+v", locale, product.Translation)
+	}
+	if locale := product.Localize(translations, []string{"fr"}, "en"); locale != "en" || product.Translation != nil {
+		t.Errorf("expected no translation, got %s %+v", locale, product.Translation)
+	}
+}
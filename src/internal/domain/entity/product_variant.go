@@ -2,37 +2,53 @@ package entity
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+// ProductVariant is a sellable combination of option values, such as
+// Size=L + Color=Red, with its own SKU, price and stock
 type ProductVariant struct {
 	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
-	ProductID      uuid.UUID `gorm:"type:uuid;not null;index"`
-	VariantName    string    `gorm:"size:255;not null"`
-	VariantValue   string    `gorm:"size:255;not null"`
-	Price_Override *float64  `gorm:"type:decimal(10,2)"` // Pointer to distinguish between 0 and unset
+	ProductID      uuid.UUID `gorm:"type:uuid;not null;index:idx_variant_combination"`
+	SKU            string    `gorm:"type:varchar(64);uniqueIndex:idx_variant_sku,where:deleted_at IS NULL"`
+	CombinationKey string    `gorm:"type:varchar(500);not null;default:'';index:idx_variant_combination"` // See CombinationKey
+	Price_Override *float64  `gorm:"type:decimal(10,2)"`                                                  // Pointer to distinguish between 0 and unset
 	Quantity       int       `gorm:"not null"`
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	DeletedAt      gorm.DeletedAt `gorm:"index"`
 
-	Product *Product `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Product *Product        `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Options []VariantOption `gorm:"foreignKey:VariantID;constraint:OnDelete:CASCADE"`
 }
 
 func (p *ProductVariant) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == uuid.Nil {
-		p.ID = uuid.New()
+		p.ID = NewID()
 	}
 	return nil
 }
 
-// GetPrice returns the effective price for this variant.
-// If price_override is set, returns the override value.
-// Otherwise, returns the base product price.
+// GetPrice returns the effective price for this variant now.
+// A price scheduled for the variant comes first, then price_override,
+// then the effective price of the product.
 func (pv *ProductVariant) GetPrice() (float64, error) {
+	return pv.PriceAt(time.Now())
+}
+
+// PriceAt returns the effective price for this variant at the given time.
+// Scheduled prices are read from the product's PriceSchedule.
+func (pv *ProductVariant) PriceAt(at time.Time) (float64, error) {
+	if pv.Product != nil {
+		if price, ok := scheduledPrice(pv.Product.PriceSchedule, &pv.ID, at); ok {
+			return price, nil
+		}
+	}
+
 	if pv.Price_Override != nil {
 		return *pv.Price_Override, nil
 	}
@@ -41,7 +57,7 @@ func (pv *ProductVariant) GetPrice() (float64, error) {
 		return 0, errors.New("Product not loaded: cannot determine variant price")
 	}
 
-	return pv.Product.Price, nil
+	return pv.Product.PriceAt(at), nil
 }
 
 // HasPriceOverride returns true if this variant has a custom price
@@ -49,21 +65,48 @@ func (pv *ProductVariant) HasPriceOverride() bool {
 	return pv.Price_Override != nil
 }
 
+// SetOptions replaces the variant's option values and updates its combination key
+func (pv *ProductVariant) SetOptions(options []VariantOption) {
+	pv.Options = options
+	pv.CombinationKey = CombinationKey(options)
+}
+
+// Title joins the variant's option values in option order, e.g. "L / Red"
+func (pv *ProductVariant) Title() string {
+	values := make([]string, len(pv.Options))
+	for i, option := range pv.Options {
+		values[i] = option.Value
+	}
+	return strings.Join(values, " / ")
+}
+
+// Describe lists the variant's options with their names, e.g. "Size: L, Color: Red"
+func (pv *ProductVariant) Describe() string {
+	pairs := make([]string, len(pv.Options))
+	for i, option := range pv.Options {
+		pairs[i] = option.Name + ": " + option.Value
+	}
+	return strings.Join(pairs, ", ")
+}
+
 func (p *ProductVariant) ValidateForCreation() error {
-	if p.VariantName == "" {
-		return errors.New("Variant name is required")
+	if len(p.Options) == 0 {
+		return ValidationError("Variant must have a value for each product option")
+	}
+	if strings.TrimSpace(p.SKU) == "" {
+		return ValidationError("Variant SKU is required")
 	}
-	if p.VariantValue == "" {
-		return errors.New("Variant value is required")
+	if len(p.SKU) > 64 {
+		return ValidationError("Variant SKU must be at most 64 characters")
 	}
 	if p.Price_Override != nil && *p.Price_Override < 0 {
-		return errors.New("Variant price override cannot be negative")
+		return ValidationError("Variant price override cannot be negative")
 	}
 	if p.Quantity < 0 {
-		return errors.New("Variant quantity cannot be negative")
+		return ValidationError("Variant quantity cannot be negative")
 	}
 	if p.Quantity == 0 {
-		return errors.New("Variant quantity must be greater than 0 for new variants")
+		return ValidationError("Variant quantity must be greater than 0 for new variants")
 	}
 	return nil
 }
@@ -76,10 +119,10 @@ func (pv *ProductVariant) IsAvailable(quantity int) bool {
 // DecreaseStock reduces the variant's quantity
 func (pv *ProductVariant) DecreaseStock(quantity int) error {
 	if quantity <= 0 {
-		return errors.New("Quantity to decrease must be positive")
+		return ValidationError("Quantity to decrease must be positive")
 	}
 	if !pv.IsAvailable(quantity) {
-		return errors.New("Insufficient variant stock")
+		return InsufficientStockError("Insufficient variant stock")
 	}
 	pv.Quantity -= quantity
 	return nil
@@ -88,7 +131,7 @@ func (pv *ProductVariant) DecreaseStock(quantity int) error {
 // IncreaseStock adds to the variant's quantity
 func (pv *ProductVariant) IncreaseStock(quantity int) error {
 	if quantity <= 0 {
-		return errors.New("Quantity to increase must be positive")
+		return ValidationError("Quantity to increase must be positive")
 	}
 	pv.Quantity += quantity
 	return nil
@@ -11,8 +11,8 @@ func TestProductVariant_GetPrice_WithOverride(t *testing.T) {
 	variant := &ProductVariant{
 		ID:             uuid.New(),
 		ProductID:      uuid.New(),
-		VariantName:    "Size",
-		VariantValue:   "Large",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Size", Value: "Large"}},
 		Price_Override: &overridePrice,
 		Quantity:       10,
 	}
@@ -39,8 +39,8 @@ func TestProductVariant_GetPrice_WithoutOverride(t *testing.T) {
 	variant := &ProductVariant{
 		ID:             uuid.New(),
 		ProductID:      product.ID,
-		VariantName:    "Color",
-		VariantValue:   "Blue",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Color", Value: "Blue"}},
 		Price_Override: nil,
 		Quantity:       10,
 		Product:        product,
@@ -61,8 +61,8 @@ func TestProductVariant_GetPrice_WithoutOverride_NoProduct(t *testing.T) {
 	variant := &ProductVariant{
 		ID:             uuid.New(),
 		ProductID:      uuid.New(),
-		VariantName:    "Color",
-		VariantValue:   "Red",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Color", Value: "Red"}},
 		Price_Override: nil,
 		Quantity:       10,
 		Product:        nil,
@@ -85,8 +85,8 @@ func TestProductVariant_GetPrice_ZeroOverride(t *testing.T) {
 	variant := &ProductVariant{
 		ID:             uuid.New(),
 		ProductID:      uuid.New(),
-		VariantName:    "Sample",
-		VariantValue:   "Free",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Sample", Value: "Free"}},
 		Price_Override: &zeroPrice,
 		Quantity:       5,
 	}
@@ -130,8 +130,8 @@ func TestProductVariant_HasPriceOverride(t *testing.T) {
 			variant := &ProductVariant{
 				ID:             uuid.New(),
 				ProductID:      uuid.New(),
-				VariantName:    "Test",
-				VariantValue:   "Value",
+				SKU:            "TEST-SKU",
+				Options:        []VariantOption{{Name: "Test", Value: "Value"}},
 				Price_Override: tt.priceOverride,
 				Quantity:       10,
 			}
@@ -148,8 +148,8 @@ func TestProductVariant_HasPriceOverride(t *testing.T) {
 func TestProductVariant_ValidateForCreation_Success(t *testing.T) {
 	overridePrice := 49.99
 	variant := &ProductVariant{
-		VariantName:    "Color",
-		VariantValue:   "Red",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Color", Value: "Red"}},
 		Price_Override: &overridePrice,
 		Quantity:       5,
 	}
@@ -161,39 +161,37 @@ func TestProductVariant_ValidateForCreation_Success(t *testing.T) {
 	}
 }
 
-func TestProductVariant_ValidateForCreation_MissingVariantName(t *testing.T) {
+func TestProductVariant_ValidateForCreation_MissingOptions(t *testing.T) {
 	variant := &ProductVariant{
-		VariantName:  "",
-		VariantValue: "Red",
-		Quantity:     5,
+		SKU:      "TSHIRT-RED",
+		Quantity: 5,
 	}
 
 	err := variant.ValidateForCreation()
 
 	if err == nil {
-		t.Error("ValidateForCreation() should return error for missing variant name")
+		t.Error("ValidateForCreation() should return error for missing options")
 	}
 
-	expectedError := "Variant name is required"
+	expectedError := "Variant must have a value for each product option"
 	if err.Error() != expectedError {
 		t.Errorf("ValidateForCreation() error = %v, want %v", err.Error(), expectedError)
 	}
 }
 
-func TestProductVariant_ValidateForCreation_MissingVariantValue(t *testing.T) {
+func TestProductVariant_ValidateForCreation_MissingSKU(t *testing.T) {
 	variant := &ProductVariant{
-		VariantName:  "Color",
-		VariantValue: "",
-		Quantity:     5,
+		Options:  []VariantOption{{Name: "Color", Value: "Red"}},
+		Quantity: 5,
 	}
 
 	err := variant.ValidateForCreation()
 
 	if err == nil {
-		t.Error("ValidateForCreation() should return error for missing variant value")
+		t.Error("ValidateForCreation() should return error for missing SKU")
 	}
 
-	expectedError := "Variant value is required"
+	expectedError := "Variant SKU is required"
 	if err.Error() != expectedError {
 		t.Errorf("ValidateForCreation() error = %v, want %v", err.Error(), expectedError)
 	}
@@ -202,8 +200,8 @@ func TestProductVariant_ValidateForCreation_MissingVariantValue(t *testing.T) {
 func TestProductVariant_ValidateForCreation_NegativePriceOverride(t *testing.T) {
 	negativePrice := -10.0
 	variant := &ProductVariant{
-		VariantName:    "Size",
-		VariantValue:   "Large",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Size", Value: "Large"}},
 		Price_Override: &negativePrice,
 		Quantity:       5,
 	}
@@ -222,9 +220,9 @@ func TestProductVariant_ValidateForCreation_NegativePriceOverride(t *testing.T)
 
 func TestProductVariant_ValidateForCreation_NegativeQuantity(t *testing.T) {
 	variant := &ProductVariant{
-		VariantName:  "Color",
-		VariantValue: "Blue",
-		Quantity:     -5,
+		SKU:      "TEST-SKU",
+		Options:  []VariantOption{{Name: "Color", Value: "Blue"}},
+		Quantity: -5,
 	}
 
 	err := variant.ValidateForCreation()
@@ -241,9 +239,9 @@ func TestProductVariant_ValidateForCreation_NegativeQuantity(t *testing.T) {
 
 func TestProductVariant_ValidateForCreation_ZeroQuantity(t *testing.T) {
 	variant := &ProductVariant{
-		VariantName:  "Color",
-		VariantValue: "Green",
-		Quantity:     0,
+		SKU:      "TEST-SKU",
+		Options:  []VariantOption{{Name: "Color", Value: "Green"}},
+		Quantity: 0,
 	}
 
 	err := variant.ValidateForCreation()
@@ -261,8 +259,8 @@ func TestProductVariant_ValidateForCreation_ZeroQuantity(t *testing.T) {
 func TestProductVariant_ValidateForCreation_ZeroPriceOverrideIsValid(t *testing.T) {
 	zeroPrice := 0.0
 	variant := &ProductVariant{
-		VariantName:    "Sample",
-		VariantValue:   "Free",
+		SKU:            "TEST-SKU",
+		Options:        []VariantOption{{Name: "Sample", Value: "Free"}},
 		Price_Override: &zeroPrice,
 		Quantity:       5,
 	}
@@ -276,9 +274,9 @@ func TestProductVariant_ValidateForCreation_ZeroPriceOverrideIsValid(t *testing.
 
 func TestProductVariant_BeforeCreate(t *testing.T) {
 	variant := &ProductVariant{
-		VariantName:  "Color",
-		VariantValue: "Black",
-		Quantity:     10,
+		SKU:      "TEST-SKU",
+		Options:  []VariantOption{{Name: "Color", Value: "Black"}},
+		Quantity: 10,
 	}
 
 	err := variant.BeforeCreate(nil)
@@ -295,10 +293,10 @@ func TestProductVariant_BeforeCreate(t *testing.T) {
 func TestProductVariant_BeforeCreate_PreservesExistingID(t *testing.T) {
 	existingID := uuid.New()
 	variant := &ProductVariant{
-		ID:           existingID,
-		VariantName:  "Size",
-		VariantValue: "Medium",
-		Quantity:     10,
+		ID:       existingID,
+		SKU:      "TEST-SKU",
+		Options:  []VariantOption{{Name: "Size", Value: "Medium"}},
+		Quantity: 10,
 	}
 
 	err := variant.BeforeCreate(nil)
@@ -315,3 +313,21 @@ func TestProductVariant_BeforeCreate_PreservesExistingID(t *testing.T) {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func TestProductVariant_TitleAndDescribe(t *testing.T) {
+	variant := &ProductVariant{}
+	variant.SetOptions([]VariantOption{
+		{Name: "Size", Value: "L", Position: 0},
+		{Name: "Color", Value: "Red", Position: 1},
+	})
+
+	if variant.Title() != "L / Red" {
+		t.Errorf("Title() = %q, want %q", variant.Title(), "L / Red")
+	}
+	if variant.Describe() != "Size: L, Color: Red" {
+		t.Errorf("Describe() = %q, want %q", variant.Describe(), "Size: L, Color: Red")
+	}
+	if variant.CombinationKey != "color=red;size=l" {
+		t.Errorf("CombinationKey = %q, want %q", variant.CombinationKey, "color=red;size=l")
+	}
+}
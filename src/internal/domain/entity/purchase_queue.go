@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// QueueEntryStatus represents the state of a purchase attempt in a high-demand queue
+type QueueEntryStatus string
+
+const (
+	QueueWaiting   QueueEntryStatus = "waiting"
+	QueueAdmitted  QueueEntryStatus = "admitted"
+	QueueExpired   QueueEntryStatus = "expired"
+	QueueCompleted QueueEntryStatus = "completed"
+)
+
+// PurchaseQueueEntry is a user's place in the fair queue of a high-demand product.
+// Entries are admitted in arrival order and get a short purchase window to place the order.
+type PurchaseQueueEntry struct {
+	ID              uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID        `gorm:"type:uuid;not null;index:idx_queue_product_status"`
+	UserID          uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Status          QueueEntryStatus `gorm:"type:varchar(20);not null;default:'waiting';index:idx_queue_product_status"`
+	WindowExpiresAt *time.Time
+	CreatedAt       time.Time `gorm:"not null;index"`
+	UpdatedAt       time.Time
+}
+
+func (q *PurchaseQueueEntry) BeforeCreate(tx *gorm.DB) error {
+	if q.ID == uuid.Nil {
+		q.ID = NewID()
+	}
+	return nil
+}
+
+// HasOpenWindow reports whether the entry is admitted and its purchase window has not elapsed
+func (q *PurchaseQueueEntry) HasOpenWindow(now time.Time) bool {
+	return q.Status == QueueAdmitted && q.WindowExpiresAt != nil && now.Before(*q.WindowExpiresAt)
+}
+
+// IsActive reports whether the entry still holds a place in the queue
+func (q *PurchaseQueueEntry) IsActive(now time.Time) bool {
+	return q.Status == QueueWaiting || q.HasOpenWindow(now)
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPurchaseQueueEntry_HasOpenWindow(t *testing.T) {
+	now := time.Now()
+	future := now.Add(time.Minute)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name  string
+		entry PurchaseQueueEntry
+		open  bool
+	}{
+		{"waiting", PurchaseQueueEntry{Status: QueueWaiting}, false},
+		{"admitted with future deadline", PurchaseQueueEntry{Status: QueueAdmitted, WindowExpiresAt: &future}, true},
+		{"admitted with elapsed deadline", PurchaseQueueEntry{Status: QueueAdmitted, WindowExpiresAt: &past}, false},
+		{"admitted without deadline", PurchaseQueueEntry{Status: QueueAdmitted}, false},
+		{"completed", PurchaseQueueEntry{Status: QueueCompleted, WindowExpiresAt: &future}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.entry.HasOpenWindow(now); got != tt.open {
+				t.Errorf("HasOpenWindow() = %v, want %v", got, tt.open)
+			}
+		})
+	}
+}
+
+func TestPurchaseQueueEntry_IsActive(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Minute)
+
+	if !(&PurchaseQueueEntry{Status: QueueWaiting}).IsActive(now) {
+		t.Error("waiting entry should be active")
+	}
+	if (&PurchaseQueueEntry{Status: QueueAdmitted, WindowExpiresAt: &past}).IsActive(now) {
+		t.Error("entry with elapsed window should not be active")
+	}
+	if (&PurchaseQueueEntry{Status: QueueExpired}).IsActive(now) {
+		t.Error("expired entry should not be active")
+	}
+}
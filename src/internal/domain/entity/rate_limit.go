@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// RateLimitQuota is a client's request budget for the current rate limit window
+type RateLimitQuota struct {
+	Limit   int
+	Used    int
+	ResetAt time.Time // When the window ends and the budget is restored
+}
+
+// Remaining returns how many requests are left in the window, never negative
+func (q RateLimitQuota) Remaining() int {
+	return max(q.Limit-q.Used, 0)
+}
+
+// Exceeded reports whether more requests were made than the window allows
+func (q RateLimitQuota) Exceeded() bool {
+	return q.Used > q.Limit
+}
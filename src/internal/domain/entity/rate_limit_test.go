@@ -0,0 +1,28 @@
+package entity
+
+import "testing"
+
+func TestRateLimitQuota_Remaining(t *testing.T) {
+	tests := []struct {
+		name          string
+		quota         RateLimitQuota
+		wantRemaining int
+		wantExceeded  bool
+	}{
+		{"unused", RateLimitQuota{Limit: 10}, 10, false},
+		{"partially used", RateLimitQuota{Limit: 10, Used: 4}, 6, false},
+		{"exhausted", RateLimitQuota{Limit: 10, Used: 10}, 0, false},
+		{"over the limit", RateLimitQuota{Limit: 10, Used: 12}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.quota.Remaining(); got != tt.wantRemaining {
+				t.Errorf("expected %d remaining, got %d", tt.wantRemaining, got)
+			}
+			if got := tt.quota.Exceeded(); got != tt.wantExceeded {
+				t.Errorf("expected exceeded %v, got %v", tt.wantExceeded, got)
+			}
+		})
+	}
+}
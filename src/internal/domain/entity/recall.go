@@ -0,0 +1,214 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecallNoticeStatus tracks a recall notice from delivery to acknowledgment
+type RecallNoticeStatus string
+
+const (
+	NoticePending      RecallNoticeStatus = "pending"
+	NoticeSent         RecallNoticeStatus = "sent"
+	NoticeFailed       RecallNoticeStatus = "failed"
+	NoticeUnreachable  RecallNoticeStatus = "unreachable" // Order placed without a customer account
+	NoticeAcknowledged RecallNoticeStatus = "acknowledged"
+)
+
+// Recall is a safety recall of a product, or of a single variant when VariantID
+// is set, covering the orders placed between OrderedFrom and OrderedTo.
+type Recall struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Title       string     `gorm:"type:varchar(200);not null"`
+	Description string     `gorm:"type:text;not null"` // The hazard and what customers should do
+	ProductID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID   *uuid.UUID `gorm:"type:uuid"`
+	SKU         string     `gorm:"type:varchar(64)"`
+	Lot         string     `gorm:"type:varchar(100)"` // Quoted in notices only, order items do not record lots
+	OrderedFrom time.Time  `gorm:"not null"`
+	OrderedTo   time.Time  `gorm:"not null"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time  `gorm:"index"`
+
+	// Relations
+	Notices []RecallNotice `gorm:"foreignKey:RecallID;constraint:OnDelete:CASCADE"`
+}
+
+func (r *Recall) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+func (r *Recall) Validate() error {
+	if strings.TrimSpace(r.Title) == "" {
+		return ValidationError("Recall title is required")
+	}
+	if len(r.Title) > 200 {
+		return ValidationError("Recall title must be at most 200 characters")
+	}
+	if strings.TrimSpace(r.Description) == "" {
+		return ValidationError("Recall description is required")
+	}
+	if len(r.Lot) > 100 {
+		return ValidationError("Lot must be at most 100 characters")
+	}
+	if r.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	if r.OrderedFrom.IsZero() || r.OrderedTo.IsZero() {
+		return ValidationError("Recall date range is required")
+	}
+	if r.OrderedTo.Before(r.OrderedFrom) {
+		return ValidationError("Recall date range ends before it starts")
+	}
+	return nil
+}
+
+// RecallSummary counts the notices of a recall by outcome
+type RecallSummary struct {
+	Orders       int
+	Customers    int
+	Units        int
+	Sent         int
+	Failed       int
+	Unreachable  int
+	Acknowledged int
+}
+
+// Summary counts the recall's notices. Notices must be loaded.
+func (r *Recall) Summary() RecallSummary {
+	var summary RecallSummary
+	customers := make(map[uuid.UUID]bool)
+
+	for _, notice := range r.Notices {
+		summary.Orders++
+		summary.Units += notice.Quantity
+		if notice.UserID != nil {
+			customers[*notice.UserID] = true
+		}
+
+		switch notice.Status {
+		case NoticeSent:
+			summary.Sent++
+		case NoticeFailed:
+			summary.Failed++
+		case NoticeUnreachable:
+			summary.Unreachable++
+		case NoticeAcknowledged:
+			summary.Sent++
+			summary.Acknowledged++
+		}
+	}
+
+	summary.Customers = len(customers)
+	return summary
+}
+
+// RecallNotice is the notification of one affected order, and whether its
+// customer has acknowledged it
+type RecallNotice struct {
+	ID             uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	RecallID       uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_recall_notice_order"`
+	OrderID        uuid.UUID          `gorm:"type:uuid;not null;uniqueIndex:idx_recall_notice_order"`
+	UserID         *uuid.UUID         `gorm:"type:uuid;index"`
+	CustomerID     int                `gorm:"not null"`
+	Quantity       int                `gorm:"not null"` // Recalled units in the order
+	Status         RecallNoticeStatus `gorm:"type:varchar(20);not null"`
+	Error          string             `gorm:"size:255"` // Why delivery failed
+	SentAt         *time.Time
+	AcknowledgedAt *time.Time
+	CreatedAt      time.Time
+
+	// Relations
+	Recall *Recall `gorm:"foreignKey:RecallID"`
+}
+
+func (n *RecallNotice) BeforeCreate(tx *gorm.DB) error {
+	if n.ID == uuid.Nil {
+		n.ID = NewID()
+	}
+	return nil
+}
+
+// NewRecallNotice creates the notice of an affected order. Orders without a
+// customer account can't be notified and are marked unreachable.
+func NewRecallNotice(recallID uuid.UUID, affected AffectedOrder) RecallNotice {
+	status := NoticePending
+	if affected.UserID == nil {
+		status = NoticeUnreachable
+	}
+
+	return RecallNotice{
+		ID:         NewID(),
+		RecallID:   recallID,
+		OrderID:    affected.OrderID,
+		UserID:     affected.UserID,
+		CustomerID: affected.CustomerID,
+		Quantity:   affected.Quantity,
+		Status:     status,
+	}
+}
+
+func (n *RecallNotice) MarkSent(at time.Time) {
+	n.Status = NoticeSent
+	n.Error = ""
+	n.SentAt = &at
+}
+
+func (n *RecallNotice) MarkFailed(err error) {
+	n.Status = NoticeFailed
+	n.Error = err.Error()
+	if len(n.Error) > 255 {
+		n.Error = n.Error[:255]
+	}
+}
+
+// Acknowledge records that the customer read the notice. Acknowledging
+// again keeps the first acknowledgment.
+func (n *RecallNotice) Acknowledge(at time.Time) {
+	if n.Status == NoticeAcknowledged {
+		return
+	}
+	n.Status = NoticeAcknowledged
+	n.AcknowledgedAt = &at
+}
+
+// AffectedOrder is an order containing recalled units
+type AffectedOrder struct {
+	OrderID    uuid.UUID
+	UserID     *uuid.UUID
+	CustomerID int
+	Quantity   int
+	OrderedAt  time.Time
+}
+
+// RecallImpact answers "am I affected" for a product or variant, before any
+// recall is created
+type RecallImpact struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	SKU       string
+	Orders    []AffectedOrder
+	Customers int
+	Units     int
+}
+
+// NewRecallImpact counts the customers and units of the affected orders
+func NewRecallImpact(productID uuid.UUID, variantID *uuid.UUID, sku string, orders []AffectedOrder) *RecallImpact {
+	impact := &RecallImpact{ProductID: productID, VariantID: variantID, SKU: sku, Orders: orders}
+
+	customers := make(map[int]bool)
+	for _, order := range orders {
+		customers[order.CustomerID] = true
+		impact.Units += order.Quantity
+	}
+	impact.Customers = len(customers)
+
+	return impact
+}
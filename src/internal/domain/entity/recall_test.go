@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRecall_Validate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+	valid := Recall{Title: "Overheating risk", Description: "Stop using it.", ProductID: uuid.New(), OrderedFrom: from, OrderedTo: to}
+
+	tests := []struct {
+		name    string
+		mutate  func(r *Recall)
+		wantErr bool
+	}{
+		{"valid", func(r *Recall) {}, false},
+		{"single day", func(r *Recall) { r.OrderedTo = from }, false},
+		{"missing title", func(r *Recall) { r.Title = " " }, true},
+		{"missing description", func(r *Recall) { r.Description = "" }, true},
+		{"missing product", func(r *Recall) { r.ProductID = uuid.Nil }, true},
+		{"missing dates", func(r *Recall) { r.OrderedFrom = time.Time{} }, true},
+		{"reversed dates", func(r *Recall) { r.OrderedFrom, r.OrderedTo = to, from }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recall := valid
+			tt.mutate(&recall)
+			if err := recall.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRecall_Summary(t *testing.T) {
+	recallID := uuid.New()
+	userA, userB := uuid.New(), uuid.New()
+	now := time.Now()
+
+	sent := NewRecallNotice(recallID, AffectedOrder{OrderID: uuid.New(), UserID: &userA, Quantity: 2})
+	sent.MarkSent(now)
+	acknowledged := NewRecallNotice(recallID, AffectedOrder{OrderID: uuid.New(), UserID: &userA, Quantity: 1})
+	acknowledged.MarkSent(now)
+	acknowledged.Acknowledge(now)
+	failed := NewRecallNotice(recallID, AffectedOrder{OrderID: uuid.New(), UserID: &userB, Quantity: 1})
+	failed.MarkFailed(errors.New("mailbox full"))
+	unreachable := NewRecallNotice(recallID, AffectedOrder{OrderID: uuid.New(), Quantity: 4})
+
+	if unreachable.Status != NoticeUnreachable {
+		t.Errorf("expected an order without an account to be unreachable, got %s", unreachable.Status)
+	}
+
+	recall := Recall{Notices: []RecallNotice{sent, acknowledged, failed, unreachable}}
+	want := RecallSummary{Orders: 4, Customers: 2, Units: 8, Sent: 2, Failed: 1, Unreachable: 1, Acknowledged: 1}
+	if got := recall.Summary(); got != want {
+		t.Errorf("expected %+v, got %+v", want, got)
+	}
+}
+
+func TestRecallNotice_AcknowledgeKeepsFirstTime(t *testing.T) {
+	notice := NewRecallNotice(uuid.New(), AffectedOrder{OrderID: uuid.New(), UserID: new(uuid.UUID), Quantity: 1})
+	first := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	notice.Acknowledge(first)
+	notice.Acknowledge(first.Add(time.Hour))
+
+	if !notice.AcknowledgedAt.Equal(first) {
+		t.Errorf("expected the first acknowledgment time, got %v", notice.AcknowledgedAt)
+	}
+}
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReturnStatus is where a return merchandise authorization (RMA) stands
+type ReturnStatus string
+
+const (
+	ReturnRequested ReturnStatus = "requested" // Waiting for an admin to review it
+	ReturnApproved  ReturnStatus = "approved"  // The customer can send the items back
+	ReturnRejected  ReturnStatus = "rejected"
+	ReturnReceived  ReturnStatus = "received" // Items back in stock, refund pending
+	ReturnRefunded  ReturnStatus = "refunded"
+)
+
+// returnTransitions lists the statuses a return can move to from each status
+var returnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested: {ReturnApproved, ReturnRejected},
+	ReturnApproved:  {ReturnReceived},
+	ReturnReceived:  {ReturnRefunded},
+}
+
+// ReturnReason is the reason code a customer picks when requesting a return
+type ReturnReason string
+
+const (
+	ReturnDamaged        ReturnReason = "damaged"
+	ReturnWrongItem      ReturnReason = "wrong_item"
+	ReturnNotAsDescribed ReturnReason = "not_as_described"
+	ReturnNoLongerNeeded ReturnReason = "no_longer_needed"
+	ReturnReasonOther    ReturnReason = "other"
+)
+
+// MaxReturnNoteLength is how long the notes of a return can be
+const MaxReturnNoteLength = 2000
+
+// Return is a return merchandise authorization (RMA): a customer asks to send
+// items of a delivered order back, an admin approves or rejects it, and once
+// the items are received they go back in stock and RefundAmount is refunded
+// through the payment provider.
+type Return struct {
+	ID              uuid.UUID    `gorm:"type:uuid;primaryKey"`
+	OrderID         uuid.UUID    `gorm:"type:uuid;not null;index"`
+	Status          ReturnStatus `gorm:"type:varchar(20);not null;default:'requested';index"`
+	Reason          ReturnReason `gorm:"type:varchar(30);not null"`
+	Note            string       `gorm:"type:text"` // From the customer
+	ResolutionNote  string       `gorm:"type:text"` // From the admin, e.g. why it was rejected
+	Items           []ReturnItem `gorm:"foreignKey:ReturnID;constraint:OnDelete:CASCADE"`
+	RefundAmount    float64      `gorm:"type:decimal(10,2);not null;default:0"`
+	RefundReference string       `gorm:"type:varchar(255)"` // Refund ID given by the payment provider
+	RefundError     string       `gorm:"type:text"`         // Why the last refund attempt failed
+	ReviewedBy      *uuid.UUID   `gorm:"type:uuid"`
+	ReviewedAt      *time.Time
+	ReceivedAt      *time.Time
+	RefundedAt      *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// ReturnItem is a quantity of an order line sent back
+type ReturnItem struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ReturnID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	OrderItemID  uuid.UUID  `gorm:"type:uuid;not null"`
+	ProductID    uuid.UUID  `gorm:"type:uuid;not null"`
+	VariantID    *uuid.UUID `gorm:"type:uuid"`
+	Quantity     int        `gorm:"not null"`
+	RefundAmount float64    `gorm:"type:decimal(10,2);not null"` // What the customer paid for these units
+}
+
+func (r *Return) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+func (i *ReturnItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = NewID()
+	}
+	return nil
+}
+
+func (r *Return) Validate() error {
+	if r.OrderID == uuid.Nil {
+		return ValidationError("Order ID is required")
+	}
+	switch r.Reason {
+	case ReturnDamaged, ReturnWrongItem, ReturnNotAsDescribed, ReturnNoLongerNeeded:
+	case ReturnReasonOther:
+		if r.Note == "" {
+			return ValidationError("A note is required when the reason is 'other'")
+		}
+	default:
+		return ValidationError("Invalid reason code. Must be 'damaged', 'wrong_item', 'not_as_described', 'no_longer_needed' or 'other'")
+	}
+	if len(r.Note) > MaxReturnNoteLength || len(r.ResolutionNote) > MaxReturnNoteLength {
+		return ValidationError("Notes cannot exceed 2000 characters")
+	}
+	if len(r.Items) == 0 {
+		return ValidationError("A return must have at least one item")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(r.Items))
+	for _, item := range r.Items {
+		if item.OrderItemID == uuid.Nil {
+			return ValidationError("Order item ID is required")
+		}
+		if seen[item.OrderItemID] {
+			return ValidationError("Each order item can only be listed once")
+		}
+		seen[item.OrderItemID] = true
+		if item.Quantity <= 0 {
+			return ValidationError("Quantity must be greater than 0")
+		}
+	}
+	return nil
+}
+
+// CanTransitionTo tells whether the return can move to status
+func (r *Return) CanTransitionTo(status ReturnStatus) error {
+	for _, next := range returnTransitions[r.Status] {
+		if next == status {
+			return nil
+		}
+	}
+	return ConflictError("Cannot move a " + string(r.Status) + " return to " + string(status))
+}
+
+// Review approves or rejects a requested return
+func (r *Return) Review(status ReturnStatus, reviewer uuid.UUID, note string, at time.Time) error {
+	if status != ReturnApproved && status != ReturnRejected {
+		return ValidationError("A return can only be approved or rejected")
+	}
+	if err := r.CanTransitionTo(status); err != nil {
+		return err
+	}
+	if len(note) > MaxReturnNoteLength {
+		return ValidationError("Notes cannot exceed 2000 characters")
+	}
+
+	r.Status = status
+	r.ResolutionNote = note
+	r.ReviewedBy = &reviewer
+	r.ReviewedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+// Receive records that the items of an approved return arrived
+func (r *Return) Receive(at time.Time) error {
+	if err := r.CanTransitionTo(ReturnReceived); err != nil {
+		return err
+	}
+	r.Status = ReturnReceived
+	r.ReceivedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+// MarkRefunded records the refund of a received return
+func (r *Return) MarkRefunded(reference string, at time.Time) error {
+	if err := r.CanTransitionTo(ReturnRefunded); err != nil {
+		return err
+	}
+	r.Status = ReturnRefunded
+	r.RefundReference = reference
+	r.RefundError = ""
+	r.RefundedAt = &at
+	r.UpdatedAt = at
+	return nil
+}
+
+// Counts tells whether the items of the return are, or may still be, taken
+// back. Only rejected returns give the quantities back to the order.
+func (r *Return) Counts() bool {
+	return r.Status != ReturnRejected
+}
@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestReturn_Validate(t *testing.T) {
+	item := ReturnItem{OrderItemID: uuid.New(), ProductID: uuid.New(), Quantity: 1}
+	valid := func() Return {
+		return Return{OrderID: uuid.New(), Reason: ReturnDamaged, Items: []ReturnItem{item}}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *Return)
+		wantErr bool
+	}{
+		{"valid", func(r *Return) {}, false},
+		{"unknown reason", func(r *Return) { r.Reason = "changed_mind" }, true},
+		{"other without a note", func(r *Return) { r.Reason = ReturnReasonOther }, true},
+		{"other with a note", func(r *Return) { r.Reason = ReturnReasonOther; r.Note = "Arrived late" }, false},
+		{"note too long", func(r *Return) { r.Note = strings.Repeat("a", MaxReturnNoteLength+1) }, true},
+		{"no items", func(r *Return) { r.Items = nil }, true},
+		{"same order item twice", func(r *Return) { r.Items = append(r.Items, item) }, true},
+		{"zero quantity", func(r *Return) { r.Items[0].Quantity = 0 }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := valid()
+			r.Items = append([]ReturnItem(nil), r.Items...)
+			tt.modify(&r)
+			err := r.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if err != nil && !errors.Is(err, ErrValidation) {
+				t.Errorf("expected a validation error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestReturn_StatusMachine(t *testing.T) {
+	now := time.Now()
+	reviewer := uuid.New()
+
+	r := Return{Status: ReturnRequested}
+	if err := r.Receive(now); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected a conflict receiving a requested return, got %v", err)
+	}
+	if err := r.Review(ReturnReceived, reviewer, "", now); !errors.Is(err, ErrValidation) {
+		t.Fatalf("expected a validation error reviewing to received, got %v", err)
+	}
+	if err := r.Review(ReturnApproved, reviewer, "Send it back", now); err != nil {
+		t.Fatalf("unexpected error approving: %v", err)
+	}
+	if r.ReviewedBy == nil || *r.ReviewedBy != reviewer || r.ResolutionNote != "Send it back" {
+		t.Errorf("expected the review to be recorded, got %+v", r)
+	}
+	if err := r.Review(ReturnRejected, reviewer, "", now); !errors.Is(err, ErrConflict) {
+		t.Fatalf("expected a conflict reviewing twice, got %v", err)
+	}
+	if err := r.Receive(now); err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+
+	r.RefundError = "provider unavailable"
+	if err := r.MarkRefunded("re_1", now); err != nil {
+		t.Fatalf("unexpected error refunding: %v", err)
+	}
+	if r.Status != ReturnRefunded || r.RefundError != "" || r.RefundedAt == nil {
+		t.Errorf("expected a refunded return without error, got %+v", r)
+	}
+	if err := r.MarkRefunded("re_2", now); !errors.Is(err, ErrConflict) {
+		t.Errorf("expected a conflict refunding twice, got %v", err)
+	}
+}
+
+func TestReturn_Counts(t *testing.T) {
+	for status, want := range map[ReturnStatus]bool{
+		ReturnRequested: true,
+		ReturnApproved:  true,
+		ReturnRejected:  false,
+		ReturnReceived:  true,
+		ReturnRefunded:  true,
+	} {
+		r := Return{Status: status}
+		if got := r.Counts(); got != want {
+			t.Errorf("%s: expected %v, got %v", status, want, got)
+		}
+	}
+}
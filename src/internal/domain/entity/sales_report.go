@@ -0,0 +1,53 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SalesLine is one order line of the itemized sales report, with the amounts
+// that make up what the customer was charged for it
+type SalesLine struct {
+	OrderID       uuid.UUID
+	OrderedAt     time.Time
+	CustomerID    int
+	Status        OrderStatus
+	PaymentStatus PaymentStatus
+	ItemID        uuid.UUID
+	ProductID     uuid.UUID
+	VariantID     *uuid.UUID
+	Quantity      int
+	UnitPrice     float64
+	Base          float64
+	Discount      float64 // Negative
+	Tax           float64
+	Surcharge     float64
+	Total         float64
+}
+
+// SalesLines breaks an order down into its report lines, in item order
+func SalesLines(order *Order) []SalesLine {
+	lines := make([]SalesLine, 0, len(order.Products))
+	for i := range order.Products {
+		item := &order.Products[i]
+		lines = append(lines, SalesLine{
+			OrderID:       order.ID,
+			OrderedAt:     order.CreatedAt,
+			CustomerID:    order.CustomerID,
+			Status:        order.Status,
+			PaymentStatus: order.PaymentStatus,
+			ItemID:        item.ID,
+			ProductID:     item.ProductID,
+			VariantID:     item.VariantID,
+			Quantity:      item.Quantity,
+			UnitPrice:     item.Price,
+			Base:          item.ComponentTotal(ComponentBase),
+			Discount:      item.ComponentTotal(ComponentDiscount),
+			Tax:           item.ComponentTotal(ComponentTax),
+			Surcharge:     item.ComponentTotal(ComponentSurcharge),
+			Total:         item.TotalPrice,
+		})
+	}
+	return lines
+}
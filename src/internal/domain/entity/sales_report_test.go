@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSalesLines(t *testing.T) {
+	item := OrderItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, Price: 50}
+	item.AddComponent(ComponentBase, "", 100)
+	item.AddComponent(ComponentDiscount, "10% off", -10)
+	item.AddComponent(ComponentTax, "Tax 8%", 7.2)
+	item.CalculateTotal()
+	legacy := OrderItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 3, Price: 5, TotalPrice: 15}
+	order := &Order{ID: uuid.New(), CustomerID: 7, Status: Completed, PaymentStatus: Paid, Products: []OrderItem{item, legacy}}
+
+	lines := SalesLines(order)
+
+	assert.Len(t, lines, 2)
+	assert.Equal(t, order.ID, lines[0].OrderID)
+	assert.Equal(t, 100.0, lines[0].Base)
+	assert.Equal(t, -10.0, lines[0].Discount)
+	assert.Equal(t, 7.2, lines[0].Tax)
+	assert.Equal(t, 97.2, lines[0].Total)
+	assert.Equal(t, 15.0, lines[1].Base, "Lines without components derive the base from the unit price")
+	assert.Zero(t, lines[1].Tax)
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RankingRuleType selects how a rule changes the order of search results
+type RankingRuleType string
+
+const (
+	RuleBoostInStock RankingRuleType = "boost_in_stock" // Adds Weight to products that can be bought
+	RuleBoostMargin  RankingRuleType = "boost_margin"   // Adds Weight times the margin of products with a cost
+	RulePin          RankingRuleType = "pin"            // Shows ProductID at Position when searching for Query
+)
+
+// RankingRule is an admin-configured adjustment of product search ranking
+type RankingRule struct {
+	ID        uuid.UUID       `gorm:"type:uuid;primaryKey"`
+	Type      RankingRuleType `gorm:"size:20;not null;index"`
+	Weight    float64         `gorm:"type:decimal(10,2);not null"` // Boosts only
+	Query     string          `gorm:"size:255;index"`              // Normalized search text, pins only
+	ProductID *uuid.UUID      `gorm:"type:uuid"`                   // Pins only
+	Position  int             `gorm:"not null"`                    // 1-based result slot, pins only
+	Active    bool            `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (RankingRule) TableName() string {
+	return "search_ranking_rules"
+}
+
+func (r *RankingRule) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+func (r *RankingRule) Validate() error {
+	switch r.Type {
+	case RuleBoostInStock, RuleBoostMargin:
+		if r.Weight <= 0 {
+			return ValidationError("Boost weight must be greater than 0")
+		}
+		if r.Query != "" || r.ProductID != nil || r.Position != 0 {
+			return ValidationError("Boost rules apply to every search and take no query, product or position")
+		}
+	case RulePin:
+		if NormalizeSearchQuery(r.Query) == "" {
+			return ValidationError("Pin query is required")
+		}
+		if r.ProductID == nil || *r.ProductID == uuid.Nil {
+			return ValidationError("Pinned product is required")
+		}
+		if r.Position < 1 {
+			return ValidationError("Pin position must be at least 1")
+		}
+		if r.Weight != 0 {
+			return ValidationError("Pin rules take no weight")
+		}
+	default:
+		return ValidationError("Invalid rule type. Must be 'boost_in_stock', 'boost_margin' or 'pin'")
+	}
+	return nil
+}
+
+// Pins reports whether the rule pins a product for the normalized query
+func (r *RankingRule) Pins(query string) bool {
+	return r.Active && r.Type == RulePin && r.Query == query
+}
+
+// NormalizeSearchQuery lowercases the query and collapses its whitespace, so
+// pins match however the customer typed the query
+func NormalizeSearchQuery(query string) string {
+	return strings.Join(strings.Fields(strings.ToLower(query)), " ")
+}
+
+// RankingContribution is one reason a search result scored as it did. Source
+// is "text" for the text match, or the type of the rule.
+type RankingContribution struct {
+	Source string
+	RuleID *uuid.UUID
+	Score  float64
+	Detail string
+}
+
+// RankedProduct is a search result with the breakdown of its score. Pinned
+// results are placed by their rule whatever their score.
+type RankedProduct struct {
+	Product       *Product
+	Score         float64
+	Pinned        bool
+	PinRuleID     *uuid.UUID
+	Contributions []RankingContribution
+}
+
+// SearchExplanation is a page of ranked results and the active rules they were ranked with
+type SearchExplanation struct {
+	Query   string
+	Results []RankedProduct
+	Rules   []*RankingRule
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestRankingRule_Validate(t *testing.T) {
+	productID := uuid.New()
+
+	tests := []struct {
+		name    string
+		rule    RankingRule
+		wantErr bool
+	}{
+		{"in stock boost", RankingRule{Type: RuleBoostInStock, Weight: 5}, false},
+		{"margin boost", RankingRule{Type: RuleBoostMargin, Weight: 10}, false},
+		{"boost without weight", RankingRule{Type: RuleBoostInStock}, true},
+		{"boost with query", RankingRule{Type: RuleBoostMargin, Weight: 1, Query: "laptop"}, true},
+		{"pin", RankingRule{Type: RulePin, Query: "laptop", ProductID: &productID, Position: 1}, false},
+		{"pin without query", RankingRule{Type: RulePin, Query: "  ", ProductID: &productID, Position: 1}, true},
+		{"pin without product", RankingRule{Type: RulePin, Query: "laptop", Position: 1}, true},
+		{"pin without position", RankingRule{Type: RulePin, Query: "laptop", ProductID: &productID}, true},
+		{"pin with weight", RankingRule{Type: RulePin, Query: "laptop", ProductID: &productID, Position: 2, Weight: 3}, true},
+		{"unknown type", RankingRule{Type: "bury", Weight: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestNormalizeSearchQuery(t *testing.T) {
+	if got := NormalizeSearchQuery("  Gaming   LAPTOP "); got != "gaming laptop" {
+		t.Errorf("expected normalized query, got %q", got)
+	}
+}
@@ -0,0 +1,107 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// StockMovementReason explains why a stock level changed
+type StockMovementReason string
+
+const (
+	StockOrder        StockMovementReason = "order"
+	StockCancellation StockMovementReason = "cancellation"
+	StockAdjustment   StockMovementReason = "adjustment"
+	StockImport       StockMovementReason = "import"
+	StockResend       StockMovementReason = "resend"   // Replacement sent by support
+	StockTransfer     StockMovementReason = "transfer" // Moved between a product and its variants
+	StockReturn       StockMovementReason = "return"   // Items of a return received back
+)
+
+// StockMovement is an immutable ledger entry for a single stock change of a
+// product, or of one of its variants when VariantID is set
+type StockMovement struct {
+	ID             uuid.UUID           `gorm:"type:uuid;primaryKey"`
+	ProductID      uuid.UUID           `gorm:"type:uuid;not null;index:idx_stock_movements_product_created,priority:1"`
+	VariantID      *uuid.UUID          `gorm:"type:uuid;index"`
+	Reason         StockMovementReason `gorm:"type:varchar(20);not null"`
+	Delta          int                 `gorm:"not null"`
+	QuantityBefore int                 `gorm:"not null"`
+	QuantityAfter  int                 `gorm:"not null"`
+	Reference      string              `gorm:"size:255"` // Order ID, import batch, etc.
+	CreatedAt      time.Time           `gorm:"index:idx_stock_movements_product_created,priority:2"`
+}
+
+func (m *StockMovement) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == uuid.Nil {
+		m.ID = NewID()
+	}
+	return nil
+}
+
+// NewStockMovement records a change from before to after
+func NewStockMovement(productID uuid.UUID, variantID *uuid.UUID, reason StockMovementReason, before, after int, reference string) *StockMovement {
+	return &StockMovement{
+		ID:             NewID(),
+		ProductID:      productID,
+		VariantID:      variantID,
+		Reason:         reason,
+		Delta:          after - before,
+		QuantityBefore: before,
+		QuantityAfter:  after,
+		Reference:      reference,
+		CreatedAt:      time.Now(),
+	}
+}
+
+func (m *StockMovement) Validate() error {
+	if m.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	switch m.Reason {
+	case StockOrder, StockCancellation, StockAdjustment, StockImport, StockResend, StockTransfer, StockReturn:
+	default:
+		return ValidationError("Invalid stock movement reason. Must be 'order', 'cancellation', 'adjustment', 'import', 'resend', 'transfer' or 'return'")
+	}
+	if m.Delta != m.QuantityAfter-m.QuantityBefore {
+		return ValidationError("Stock movement delta does not match quantities")
+	}
+	return nil
+}
+
+// VariantStockTransfer moves units between variants of the same product. A nil
+// variant ID stands for the stock of the base product itself.
+type VariantStockTransfer struct {
+	ProductID     uuid.UUID
+	FromVariantID *uuid.UUID
+	ToVariantID   *uuid.UUID
+	Quantity      int
+	Reference     string
+}
+
+func (t *VariantStockTransfer) Validate() error {
+	if t.ProductID == uuid.Nil {
+		return ValidationError("Product ID is required")
+	}
+	if t.Quantity <= 0 {
+		return ValidationError("Transfer quantity must be greater than 0")
+	}
+	if sameVariant(t.FromVariantID, t.ToVariantID) {
+		return ValidationError("Cannot transfer stock to where it already is")
+	}
+	return nil
+}
+
+// Apply returns the outgoing and incoming ledger entries of the transfer, given
+// the current quantities at the source and the destination
+func (t *VariantStockTransfer) Apply(fromQuantity, toQuantity int) (*StockMovement, *StockMovement, error) {
+	if fromQuantity < t.Quantity {
+		return nil, nil, ErrInsufficientStock
+	}
+
+	out := NewStockMovement(t.ProductID, t.FromVariantID, StockTransfer, fromQuantity, fromQuantity-t.Quantity, t.Reference)
+	in := NewStockMovement(t.ProductID, t.ToVariantID, StockTransfer, toQuantity, toQuantity+t.Quantity, t.Reference)
+	return out, in, nil
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestVariantStockTransfer_Validate(t *testing.T) {
+	productID := uuid.New()
+	variantA, variantB := uuid.New(), uuid.New()
+	sameAsA := variantA
+
+	tests := []struct {
+		name     string
+		transfer VariantStockTransfer
+		wantErr  bool
+	}{
+		{"between variants", VariantStockTransfer{ProductID: productID, FromVariantID: &variantA, ToVariantID: &variantB, Quantity: 1}, false},
+		{"to the base product", VariantStockTransfer{ProductID: productID, FromVariantID: &variantA, Quantity: 1}, false},
+		{"zero quantity", VariantStockTransfer{ProductID: productID, FromVariantID: &variantA, ToVariantID: &variantB}, true},
+		{"same variant", VariantStockTransfer{ProductID: productID, FromVariantID: &variantA, ToVariantID: &sameAsA, Quantity: 1}, true},
+		{"base product to itself", VariantStockTransfer{ProductID: productID, Quantity: 1}, true},
+		{"missing product", VariantStockTransfer{FromVariantID: &variantA, Quantity: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.transfer.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestVariantStockTransfer_Apply(t *testing.T) {
+	productID, variantID := uuid.New(), uuid.New()
+	transfer := VariantStockTransfer{ProductID: productID, FromVariantID: &variantID, Quantity: 4, Reference: "transfer 1"}
+
+	out, in, err := transfer.Apply(10, 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if out.QuantityAfter != 6 || out.Delta != -4 || *out.VariantID != variantID {
+		t.Errorf("unexpected outgoing entry %+v", out)
+	}
+	if in.QuantityAfter != 7 || in.Delta != 4 || in.VariantID != nil {
+		t.Errorf("unexpected incoming entry %+v", in)
+	}
+	if out.Reason != StockTransfer || out.Reference != in.Reference {
+		t.Error("expected both entries to be linked transfer movements")
+	}
+	if err := out.Validate(); err != nil {
+		t.Errorf("expected a valid movement, got %v", err)
+	}
+}
+
+func TestVariantStockTransfer_ApplyInsufficientStock(t *testing.T) {
+	variantID := uuid.New()
+	transfer := VariantStockTransfer{ProductID: uuid.New(), FromVariantID: &variantID, Quantity: 4}
+
+	if _, _, err := transfer.Apply(3, 0); !errors.Is(err, ErrInsufficientStock) {
+		t.Errorf("expected ErrInsufficientStock, got %v", err)
+	}
+}
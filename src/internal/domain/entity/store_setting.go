@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"net/mail"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StoreSetting is the value of one store setting, stored as text under its
+// key. Settings that were never set have no row and take their default.
+type StoreSetting struct {
+	Key       string     `gorm:"type:varchar(100);primaryKey"`
+	Value     string     `gorm:"type:text;not null"`
+	UpdatedBy *uuid.UUID `gorm:"type:uuid"`
+	UpdatedAt time.Time
+}
+
+func (s *StoreSetting) TableName() string {
+	return "store_settings"
+}
+
+// SettingType tells how the text value of a setting is read and validated
+type SettingType string
+
+const (
+	SettingText     SettingType = "text"
+	SettingEmail    SettingType = "email"
+	SettingURL      SettingType = "url"
+	SettingCurrency SettingType = "currency" // ISO 4217 code
+	SettingBool     SettingType = "bool"
+)
+
+// Store setting keys
+const (
+	SettingStoreName              = "store.name"
+	SettingStoreContactEmail      = "store.contact_email"
+	SettingStoreCurrency          = "store.currency"
+	SettingStoreLogoURL           = "store.logo_url"
+	SettingCheckoutTermsURL       = "checkout.terms_url"
+	SettingCheckoutRequireTerms   = "checkout.require_terms"
+	SettingCheckoutSuccessMessage = "checkout.success_message"
+)
+
+// SettingDefinition describes a store setting. Default is used until the
+// setting is set; the container may override it from the configuration.
+type SettingDefinition struct {
+	Key         string
+	Type        SettingType
+	Default     string
+	Description string
+}
+
+// SettingDefinitions are the store settings front-ends can read, in the
+// order they are listed
+var SettingDefinitions = []SettingDefinition{
+	{Key: SettingStoreName, Type: SettingText, Default: "Go E-Commerce", Description: "Name of the store shown in the storefront"},
+	{Key: SettingStoreContactEmail, Type: SettingEmail, Description: "Address customers can write to"},
+	{Key: SettingStoreCurrency, Type: SettingCurrency, Default: "USD", Description: "Currency prices are shown in"},
+	{Key: SettingStoreLogoURL, Type: SettingURL, Description: "Image shown as the store logo"},
+	{Key: SettingCheckoutTermsURL, Type: SettingURL, Description: "Terms and conditions linked from the checkout"},
+	{Key: SettingCheckoutRequireTerms, Type: SettingBool, Default: "false", Description: "Whether customers must accept the terms to check out"},
+	{Key: SettingCheckoutSuccessMessage, Type: SettingText, Description: "Message shown once an order is placed"},
+}
+
+// maxSettingLength caps text values, which are shown as they are
+const maxSettingLength = 2000
+
+// Normalize validates a value for the setting and returns it the way it is
+// stored: trimmed, currency codes upper case and booleans as true or false.
+// Only settings without a default can be emptied; the others are reset.
+func (d SettingDefinition) Normalize(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if d.Default != "" {
+			return "", ValidationError(d.Key + " cannot be empty, reset it to its default instead")
+		}
+		return "", nil
+	}
+
+	switch d.Type {
+	case SettingEmail:
+		if _, err := mail.ParseAddress(value); err != nil {
+			return "", ValidationError(d.Key + " must be an email address")
+		}
+	case SettingURL:
+		parsed, err := url.Parse(value)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return "", ValidationError(d.Key + " must be an http or https URL")
+		}
+	case SettingCurrency:
+		value = strings.ToUpper(value)
+		if len(value) != 3 || strings.Trim(value, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+			return "", ValidationError(d.Key + " must be an ISO 4217 code like USD")
+		}
+	case SettingBool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", ValidationError(d.Key + " must be true or false")
+		}
+		value = strconv.FormatBool(parsed)
+	}
+
+	if len(value) > maxSettingLength {
+		return "", ValidationError(d.Key + " must be at most 2000 characters")
+	}
+	return value, nil
+}
+
+// StoreSettings holds the value of every store setting by key, defaults
+// filled in, with typed accessors
+type StoreSettings map[string]string
+
+func (s StoreSettings) StoreName() string      { return s[SettingStoreName] }
+func (s StoreSettings) ContactEmail() string   { return s[SettingStoreContactEmail] }
+func (s StoreSettings) Currency() string       { return s[SettingStoreCurrency] }
+func (s StoreSettings) LogoURL() string        { return s[SettingStoreLogoURL] }
+func (s StoreSettings) TermsURL() string       { return s[SettingCheckoutTermsURL] }
+func (s StoreSettings) SuccessMessage() string { return s[SettingCheckoutSuccessMessage] }
+
+// RequireTerms tells whether customers must accept the terms to check out
+func (s StoreSettings) RequireTerms() bool {
+	return s.Bool(SettingCheckoutRequireTerms)
+}
+
+// Bool reads a boolean setting, false when it isn't one
+func (s StoreSettings) Bool(key string) bool {
+	value, _ := strconv.ParseBool(s[key])
+	return value
+}
@@ -0,0 +1,53 @@
+package entity
+
+import "testing"
+
+func TestSettingDefinition_Normalize(t *testing.T) {
+	tests := []struct {
+		definition SettingDefinition
+		value      string
+		want       string
+		wantErr    bool
+	}{
+		{SettingDefinition{Key: "name", Type: SettingText, Default: "Shop"}, "  My Shop ", "My Shop", false},
+		{SettingDefinition{Key: "name", Type: SettingText, Default: "Shop"}, " ", "", true},
+		{SettingDefinition{Key: "message", Type: SettingText}, "", "", false},
+		{SettingDefinition{Key: "email", Type: SettingEmail}, "help@example.com", "help@example.com", false},
+		{SettingDefinition{Key: "email", Type: SettingEmail}, "example.com", "", true},
+		{SettingDefinition{Key: "url", Type: SettingURL}, "https://cdn.example.com/logo.png", "https://cdn.example.com/logo.png", false},
+		{SettingDefinition{Key: "url", Type: SettingURL}, "javascript:alert(1)", "", true},
+		{SettingDefinition{Key: "url", Type: SettingURL}, "/logo.png", "", true},
+		{SettingDefinition{Key: "currency", Type: SettingCurrency, Default: "USD"}, "eur", "EUR", false},
+		{SettingDefinition{Key: "currency", Type: SettingCurrency, Default: "USD"}, "EURO", "", true},
+		{SettingDefinition{Key: "currency", Type: SettingCurrency, Default: "USD"}, "E1R", "", true},
+		{SettingDefinition{Key: "bool", Type: SettingBool, Default: "false"}, "1", "true", false},
+		{SettingDefinition{Key: "bool", Type: SettingBool, Default: "false"}, "yes", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.definition.Key+" "+tt.value, func(t *testing.T) {
+			got, err := tt.definition.Normalize(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestStoreSettings_Accessors(t *testing.T) {
+	settings := StoreSettings{
+		SettingStoreName:            "My Shop",
+		SettingStoreCurrency:        "EUR",
+		SettingCheckoutRequireTerms: "true",
+	}
+
+	if settings.StoreName() != "My Shop" || settings.Currency() != "EUR" || !settings.RequireTerms() {
+		t.Errorf("unexpected accessor values for %v", settings)
+	}
+	if settings.LogoURL() != "" || settings.Bool(SettingStoreName) {
+		t.Error("expected unset and non-boolean settings to read as zero values")
+	}
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RevocationReason tells why access tokens were revoked
+type RevocationReason string
+
+const (
+	RevocationLogout      RevocationReason = "logout"
+	RevocationCompromised RevocationReason = "compromised"
+	RevocationDeactivated RevocationReason = "deactivated"
+	RevocationAdmin       RevocationReason = "admin"
+	RevocationDeleted     RevocationReason = "deleted" // The account was deleted
+)
+
+func (r RevocationReason) IsValid() bool {
+	switch r {
+	case RevocationLogout, RevocationCompromised, RevocationDeactivated, RevocationAdmin, RevocationDeleted:
+		return true
+	}
+	return false
+}
+
+// TokenRevocation revokes access tokens before they expire: a single token by
+// its ID (the jti claim) or, without a token ID, every token of the user
+// issued before RevokedAt.
+type TokenRevocation struct {
+	ID        uuid.UUID        `gorm:"type:uuid;primaryKey"`
+	TokenID   *string          `gorm:"type:varchar(64);uniqueIndex"` // nil to revoke every token of the user
+	UserID    uuid.UUID        `gorm:"type:uuid;not null;index"`
+	Reason    RevocationReason `gorm:"type:varchar(20);not null"`
+	RevokedBy *uuid.UUID       `gorm:"type:uuid"` // nil when the user logged out
+	RevokedAt time.Time        `gorm:"not null"`
+	// ExpiresAt is when the revoked tokens expire anyway; past it the row is
+	// no longer needed
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+func (r *TokenRevocation) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == uuid.Nil {
+		r.ID = NewID()
+	}
+	return nil
+}
+
+// Covers tells whether the revocation applies to the token with the given ID,
+// issued to userID at issuedAt
+func (r *TokenRevocation) Covers(tokenID string, userID uuid.UUID, issuedAt time.Time) bool {
+	if r.TokenID != nil {
+		return tokenID != "" && *r.TokenID == tokenID
+	}
+	return r.UserID == userID && !issuedAt.After(r.RevokedAt)
+}
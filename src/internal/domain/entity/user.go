@@ -1,7 +1,6 @@
 package entity
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,6 +11,7 @@ type Role string
 
 const (
 	RoleAdmin    Role = "admin"
+	RoleSupport  Role = "support" // Customer support agent
 	RoleCustomer Role = "customer"
 )
 
@@ -28,15 +28,15 @@ type User struct {
 
 func (u *User) Validate() error {
 	if u.Email == "" {
-		return errors.New("Email is required")
+		return ValidationError("Email is required")
 	}
 
 	if len(u.Name) < 2 {
-		return errors.New("Name must be at least 2 characters")
+		return ValidationError("Name must be at least 2 characters")
 	}
 
-	if u.Role != RoleAdmin && u.Role != RoleCustomer {
-		return errors.New("Invalid role")
+	if u.Role != RoleAdmin && u.Role != RoleSupport && u.Role != RoleCustomer {
+		return ValidationError("Invalid role")
 	}
 
 	return nil
@@ -45,7 +45,7 @@ func (u *User) Validate() error {
 // SetPassword hashes and sets the user password
 func (u *User) SetPassword(password string) error {
 	if len(password) < 6 {
-		return errors.New("Password must be at least 6 characters")
+		return ValidationError("Password must be at least 6 characters")
 	}
 
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
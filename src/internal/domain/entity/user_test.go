@@ -156,6 +156,7 @@ func TestUser_IsAdmin(t *testing.T) {
 		want bool
 	}{
 		{"Admin role", RoleAdmin, true},
+		{"Support role", RoleSupport, false},
 		{"Customer role", RoleCustomer, false},
 	}
 
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// WebhookNonce is a nonce a signed payment webhook used. It is kept until the
+// timestamp signed with it is out of the tolerance window, so the signature
+// can't be replayed meanwhile; after that the timestamp check rejects it.
+type WebhookNonce struct {
+	Nonce     string    `gorm:"type:varchar(128);primaryKey"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Store event types delivered to webhook subscribers
+const (
+	EventOrderCreated       = "order.created"
+	EventOrderStatusChanged = "order.status_changed"
+	EventOrderPaid          = "order.paid"
+	EventProductCreated     = "product.created"
+	EventProductUpdated     = "product.updated"
+	EventProductArchived    = "product.archived"
+	EventStockChanged       = "stock.changed"
+)
+
+// WebhookEventTypes lists every event type a subscription can filter on
+var WebhookEventTypes = []string{
+	EventOrderCreated,
+	EventOrderStatusChanged,
+	EventOrderPaid,
+	EventProductCreated,
+	EventProductUpdated,
+	EventProductArchived,
+	EventStockChanged,
+}
+
+// MinWebhookSecretLength is how long a subscription secret must be
+const MinWebhookSecretLength = 16
+
+// WebhookSubscription is an endpoint outside the store that receives the
+// events it subscribed to as signed HTTP POST requests. Events hold event
+// types, "order.*" for every event of a resource or "*" for all of them.
+type WebhookSubscription struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	URL         string     `gorm:"type:varchar(2048);not null"`
+	Secret      string     `gorm:"type:varchar(255);not null"` // Signs the deliveries, only shown when the subscription is created
+	Events      []string   `gorm:"type:text;not null;serializer:json"`
+	Description string     `gorm:"type:varchar(255)"`
+	Active      bool       `gorm:"not null;index"` // Paused subscriptions get no new deliveries
+	CreatedBy   *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (s *WebhookSubscription) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == uuid.Nil {
+		s.ID = NewID()
+	}
+	return nil
+}
+
+func (s *WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ValidationError("Webhook URL must be an absolute http or https URL")
+	}
+	if len(s.URL) > 2048 {
+		return ValidationError("Webhook URL must be at most 2048 characters")
+	}
+	if len(s.Secret) < MinWebhookSecretLength {
+		return ValidationError("Webhook secret must be at least 16 characters")
+	}
+	if len(s.Description) > 255 {
+		return ValidationError("Webhook description must be at most 255 characters")
+	}
+	if len(s.Events) == 0 {
+		return ValidationError("Webhook must subscribe to at least one event")
+	}
+	for _, pattern := range s.Events {
+		if !validEventPattern(pattern) {
+			return ValidationError("Unknown webhook event: " + pattern)
+		}
+	}
+	return nil
+}
+
+// Matches tells whether the subscription receives events of eventType
+func (s *WebhookSubscription) Matches(eventType string) bool {
+	for _, pattern := range s.Events {
+		if pattern == "*" || pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, ".*"); ok && strings.HasPrefix(eventType, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func validEventPattern(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	for _, eventType := range WebhookEventTypes {
+		if pattern == eventType {
+			return true
+		}
+		if prefix, ok := strings.CutSuffix(pattern, ".*"); ok && strings.HasPrefix(eventType, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookDeliveryStatus is where the delivery of an event to a subscriber stands
+type WebhookDeliveryStatus string
+
+const (
+	DeliveryPending   WebhookDeliveryStatus = "pending" // Waiting for its next attempt
+	DeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	DeliveryFailed    WebhookDeliveryStatus = "failed" // Gave up after MaxWebhookDeliveryAttempts
+)
+
+// MaxWebhookDeliveryAttempts is how many times an event is sent before its
+// delivery is failed. Attempts back off exponentially from
+// webhookRetryBase, so the last one is about an hour after the event.
+const MaxWebhookDeliveryAttempts = 8
+
+const webhookRetryBase = 30 * time.Second
+
+// WebhookDelivery is one event sent to one subscription, with the outcome of
+// its last attempt. Payload is the exact body that is signed and sent.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `gorm:"type:uuid;primaryKey"`
+	SubscriptionID uuid.UUID             `gorm:"type:uuid;not null;index"`
+	EventID        uuid.UUID             `gorm:"type:uuid;not null"`
+	EventType      string                `gorm:"type:varchar(50);not null"`
+	Payload        string                `gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `gorm:"type:varchar(20);not null;default:'pending';index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	ResponseStatus int                   // HTTP status of the last attempt, 0 when it got no response
+	LastError      string                `gorm:"type:text"`
+	NextAttemptAt  *time.Time            `gorm:"index:idx_webhook_deliveries_due,priority:2"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time `gorm:"index"`
+	UpdatedAt      time.Time
+}
+
+func (d *WebhookDelivery) BeforeCreate(tx *gorm.DB) error {
+	if d.ID == uuid.Nil {
+		d.ID = NewID()
+	}
+	return nil
+}
+
+// Succeed records an attempt the subscriber accepted
+func (d *WebhookDelivery) Succeed(responseStatus int, now time.Time) {
+	d.Attempts++
+	d.Status = DeliverySucceeded
+	d.ResponseStatus = responseStatus
+	d.LastError = ""
+	d.NextAttemptAt = nil
+	d.DeliveredAt = &now
+}
+
+// Fail records an attempt that failed and schedules the next one, or fails
+// the delivery for good once it ran out of attempts
+func (d *WebhookDelivery) Fail(responseStatus int, reason string, now time.Time) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+	d.LastError = reason
+	if d.Attempts >= MaxWebhookDeliveryAttempts {
+		d.Status = DeliveryFailed
+		d.NextAttemptAt = nil
+		return
+	}
+	next := now.Add(webhookRetryBase << (d.Attempts - 1))
+	d.Status = DeliveryPending
+	d.NextAttemptAt = &next
+}
+
+// Retry schedules a failed delivery again with a fresh set of attempts
+func (d *WebhookDelivery) Retry(now time.Time) error {
+	if d.Status != DeliveryFailed {
+		return ConflictError("Only failed deliveries can be retried")
+	}
+	d.Status = DeliveryPending
+	d.Attempts = 0
+	d.NextAttemptAt = &now
+	return nil
+}
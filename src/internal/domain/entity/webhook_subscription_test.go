@@ -0,0 +1,80 @@
+package entity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWebhookSubscription_Validate(t *testing.T) {
+	valid := WebhookSubscription{URL: "https://erp.example.com/hooks", Secret: "0123456789abcdef", Events: []string{EventOrderCreated}}
+
+	tests := []struct {
+		name    string
+		mutate  func(s *WebhookSubscription)
+		wantErr bool
+	}{
+		{"valid", func(s *WebhookSubscription) {}, false},
+		{"resource wildcard", func(s *WebhookSubscription) { s.Events = []string{"order.*", "stock.*"} }, false},
+		{"every event", func(s *WebhookSubscription) { s.Events = []string{"*"} }, false},
+		{"relative URL", func(s *WebhookSubscription) { s.URL = "/hooks" }, true},
+		{"other scheme", func(s *WebhookSubscription) { s.URL = "ftp://erp.example.com/hooks" }, true},
+		{"short secret", func(s *WebhookSubscription) { s.Secret = "secret" }, true},
+		{"no events", func(s *WebhookSubscription) { s.Events = nil }, true},
+		{"unknown event", func(s *WebhookSubscription) { s.Events = []string{"order.shipped"} }, true},
+		{"unknown resource", func(s *WebhookSubscription) { s.Events = []string{"user.*"} }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subscription := valid
+			tt.mutate(&subscription)
+			if err := subscription.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWebhookSubscription_Matches(t *testing.T) {
+	subscription := WebhookSubscription{Events: []string{"order.*", EventStockChanged}}
+
+	for eventType, want := range map[string]bool{
+		EventOrderCreated:   true,
+		EventOrderPaid:      true,
+		EventStockChanged:   true,
+		EventProductCreated: false,
+	} {
+		if got := subscription.Matches(eventType); got != want {
+			t.Errorf("Matches(%q) = %v, want %v", eventType, got, want)
+		}
+	}
+}
+
+func TestWebhookDelivery_FailBacksOffThenGivesUp(t *testing.T) {
+	now := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	delivery := WebhookDelivery{Status: DeliveryPending}
+
+	delivery.Fail(500, "subscriber responded with status 500", now)
+	if delivery.Status != DeliveryPending || !delivery.NextAttemptAt.Equal(now.Add(30*time.Second)) {
+		t.Fatalf("expected a retry in 30s, got %s at %v", delivery.Status, delivery.NextAttemptAt)
+	}
+	delivery.Fail(500, "subscriber responded with status 500", now)
+	if !delivery.NextAttemptAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected the next retry in 1m, got %v", delivery.NextAttemptAt)
+	}
+
+	for delivery.Status == DeliveryPending {
+		delivery.Fail(0, "connection refused", now)
+	}
+	if delivery.Status != DeliveryFailed || delivery.Attempts != MaxWebhookDeliveryAttempts || delivery.NextAttemptAt != nil {
+		t.Errorf("expected the delivery to fail after %d attempts, got %s after %d", MaxWebhookDeliveryAttempts, delivery.Status, delivery.Attempts)
+	}
+
+	if err := delivery.Retry(now); err != nil || delivery.Status != DeliveryPending || delivery.Attempts != 0 {
+		t.Errorf("expected a failed delivery to be retried, got %s, %v", delivery.Status, err)
+	}
+	delivery.Succeed(204, now)
+	if err := delivery.Retry(now); err == nil {
+		t.Error("expected a succeeded delivery not to be retried")
+	}
+}
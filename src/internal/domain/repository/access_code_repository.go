@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface AccessCodeRepository -out ../../testing/mocks
+
+type AccessCodeRepository interface {
+	Create(ctx context.Context, code *entity.AccessCode) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.AccessCode, error)
+	// GetByCode finds a code by its normalized value
+	GetByCode(ctx context.Context, code string) (*entity.AccessCode, error)
+	// Update saves the note, limits and status of the code, leaving its uses alone
+	Update(ctx context.Context, code *entity.AccessCode) error
+	// Redeem counts a use of the code unless it ran out meanwhile, a
+	// ConflictError then. Two registrations can't both take the last use.
+	Redeem(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns every code, newest first
+	List(ctx context.Context) ([]*entity.AccessCode, error)
+}
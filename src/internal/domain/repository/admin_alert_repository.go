@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface AdminAlertRepository -out ../../testing/mocks
+
+type AdminAlertRepository interface {
+	Create(ctx context.Context, alert *entity.AdminAlert) error
+
+	// List returns alerts newest first, optionally filtered by rule
+	List(ctx context.Context, rule *entity.AlertRule, page, pageSize int) ([]*entity.AdminAlert, int, error)
+}
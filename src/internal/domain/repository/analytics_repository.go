@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface AnalyticsRepository -out ../../testing/mocks
+
+// AnalyticsRepository aggregates sales in the database, over live and archived
+// orders alike. The store-wide queries cover the orders placed at or after
+// from and before until, those of a customer all of their orders. Revenue
+// counts the orders that are paid and not cancelled.
+type AnalyticsRepository interface {
+	// RevenueByPeriod returns the periods with sales, oldest first. Periods
+	// start at midnight in loc and so do the points.
+	RevenueByPeriod(ctx context.Context, period entity.AnalyticsPeriod, from, until time.Time, loc *time.Location) ([]entity.RevenuePoint, error)
+	// TopProducts returns the products that sold the most units, best first
+	TopProducts(ctx context.Context, from, until time.Time, limit int) ([]entity.ProductSales, error)
+	// CountOrdersByStatus returns the statuses that have orders
+	CountOrdersByStatus(ctx context.Context, from, until time.Time) ([]entity.OrderStatusCount, error)
+	// SumRevenue returns the number of paid orders and their revenue
+	SumRevenue(ctx context.Context, from, until time.Time) (int, float64, error)
+	// CountNewCustomers returns the customer accounts registered in the range
+	CountNewCustomers(ctx context.Context, from, until time.Time) (int, error)
+
+	// SummarizeCustomer returns the order count, spend and last order of the
+	// account, without its favorite categories
+	SummarizeCustomer(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error)
+	// FavoriteCategories returns the categories the account bought the most
+	// units of, best first. A product in many categories counts in each.
+	FavoriteCategories(ctx context.Context, userID uuid.UUID, limit int) ([]entity.CategorySales, error)
+}
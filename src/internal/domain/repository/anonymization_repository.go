@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface AnonymizationRepository -out ../../testing/mocks
+
+// AnonymizationRepository rewrites the personal data of a copy of the
+// database in place. Rows keep their IDs, so everything referencing them
+// still does. The lists return up to limit rows in ID order, those after the
+// given ID, uuid.Nil for the first batch.
+type AnonymizationRepository interface {
+	ListUsers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.User, error)
+	// UpdateUsers saves the email, name and password hash of the users
+	UpdateUsers(ctx context.Context, users []*entity.User) error
+
+	// ListCustomers returns customers with their addresses, in user ID order
+	ListCustomers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Customer, error)
+	// UpdateCustomers saves the phone of the customers and the recipient,
+	// street lines and postal code of their addresses
+	UpdateCustomers(ctx context.Context, customers []*entity.Customer) error
+
+	// ListBlockedEmails returns the email and domain entries of the blocklist
+	ListBlockedEmails(ctx context.Context, after uuid.UUID, limit int) ([]*entity.BlocklistEntry, error)
+	// UpdateBlocklistEntries saves the values of the entries
+	UpdateBlocklistEntries(ctx context.Context, entries []*entity.BlocklistEntry) error
+
+	// ListInvoices returns the invoices without their documents
+	ListInvoices(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Invoice, error)
+	// UpdateInvoices saves the documents of the invoices
+	UpdateInvoices(ctx context.Context, invoices []*entity.Invoice) error
+
+	// RedactText replaces the free text staff and customers typed in, notes
+	// and reasons, and the bodies of payment and outgoing webhooks with
+	// placeholder and clears the payloads of audit logs, live and archived. It
+	// returns how many rows were changed.
+	RedactText(ctx context.Context, placeholder string) (int64, error)
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface AttributeRepository -out ../../testing/mocks
+
+type AttributeRepository interface {
+	CreateDefinition(ctx context.Context, definition *entity.AttributeDefinition) error
+	GetDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error)
+	GetDefinitionByCode(ctx context.Context, code string) (*entity.AttributeDefinition, error)
+	ListDefinitions(ctx context.Context) ([]*entity.AttributeDefinition, error)
+
+	// SetProductValue creates or replaces the product's value for the attribute
+	SetProductValue(ctx context.Context, value *entity.ProductAttribute) error
+	DeleteProductValue(ctx context.Context, productID, attributeID uuid.UUID) error
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface AuditLogRepository -out ../../testing/mocks
+
 type AuditLogRepository interface {
 	// Create creates a new audit log entry
 	Create(ctx context.Context, log *entity.AuditLog) error
@@ -16,6 +18,11 @@ type AuditLogRepository interface {
 
 	// GetByResourceID returns all audit logs for a specific resource
 	GetByResourceID(ctx context.Context, resourceType string, resourceID uuid.UUID) ([]*entity.AuditLog, error)
+
+	// ClearPayloads clears the payloads of the audit logs, live and archived,
+	// about the resource or whose payloads contain text. It returns how many
+	// logs were changed.
+	ClearPayloads(ctx context.Context, resourceID uuid.UUID, text string) (int64, error)
 }
 
 type AuditLogFilters struct {
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface BackupRepository -out ../../testing/mocks
+
+// BackupRepository reads and writes whole tables, to copy a store from one
+// database to another
+type BackupRepository interface {
+	// SchemaVersion returns the migration the database is at
+	SchemaVersion(ctx context.Context) (int64, error)
+	// Tables lists the tables holding store data, every table after the
+	// tables it references
+	Tables() []entity.BackupTable
+	// ExportTable calls fn with every row of the table
+	ExportTable(ctx context.Context, table string, fn func(row entity.BackupRow) error) error
+	CountRows(ctx context.Context, table string) (int64, error)
+	// Restore runs restore in one transaction, inserting the rows it hands
+	// to insert. Strings are converted to the times and bytes the columns
+	// hold. Nothing is kept when restore fails.
+	Restore(ctx context.Context, restore func(insert func(table string, rows []entity.BackupRow) error) error) error
+}
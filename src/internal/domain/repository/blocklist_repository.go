@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface BlocklistRepository -out ../../testing/mocks
+
+type BlocklistRepository interface {
+	Create(ctx context.Context, entry *entity.BlocklistEntry) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns every entry, newest first
+	List(ctx context.Context) ([]*entity.BlocklistEntry, error)
+	// HashEmail replaces the value of the email entry of the address, if
+	// any, with entity.HashedEmail
+	HashEmail(ctx context.Context, email string) error
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface CatalogFeedRepository -out ../../testing/mocks
+
+type CatalogFeedRepository interface {
+	// ScanProducts calls fn with batches of the products on sale at the given
+	// time, with their variants, variant options and categories loaded
+	ScanProducts(ctx context.Context, at time.Time, batchSize int, fn func(products []*entity.Product) error) error
+	// Save stores a feed in place of the previous one of its format
+	Save(ctx context.Context, feed *entity.CatalogFeed) error
+	Get(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error)
+}
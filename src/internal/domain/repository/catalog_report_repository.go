@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface CatalogReportRepository -out ../../testing/mocks
+
+type CatalogReportRepository interface {
+	// ScanProducts calls fn with batches of products, with their variants and options loaded
+	ScanProducts(ctx context.Context, batchSize int, fn func(products []*entity.Product) error) error
+	// ListOrphanVariants returns variants whose product was deleted
+	ListOrphanVariants(ctx context.Context) ([]*entity.ProductVariant, error)
+	// CountProductsByCategory returns the number of products assigned directly to each category
+	CountProductsByCategory(ctx context.Context) (map[uuid.UUID]int, error)
+
+	Create(ctx context.Context, report *entity.CatalogReport) error
+	GetLatest(ctx context.Context) (*entity.CatalogReport, error)
+}
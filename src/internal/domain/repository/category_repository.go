@@ -2,21 +2,66 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface CategoryRepository -out ../../testing/mocks
+
 type CategoryRepository interface {
 	Create(ctx context.Context, category *entity.Category) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error)
 	GetAll(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error)
 	Update(ctx context.Context, category *entity.Category) error
+	// Delete removes the category together with its product assignments.
+	// Its subcategories are moved up to the deleted category's parent.
 	Delete(ctx context.Context, id uuid.UUID) error
 	GetByName(ctx context.Context, name string) (*entity.Category, error)
+	GetBySlug(ctx context.Context, slug string) (*entity.Category, error)
+	// SlugExists reports whether any category, including soft-deleted ones, uses the slug
+	SlugExists(ctx context.Context, slug string) (bool, error)
+	// GetByMergedSlug returns the category that a deleted category with the slug was merged into
+	GetByMergedSlug(ctx context.Context, slug string) (*entity.Category, error)
+	// Merge moves the product assignments and subcategories of the source
+	// category to the target and deletes the source, all or nothing. The slug
+	// of the source, and of the categories merged into it before, then lead to
+	// the target. Returns the number of products the source had.
+	Merge(ctx context.Context, sourceID, targetID uuid.UUID) (int, error)
+
+	// Hierarchy methods
+	// GetTree returns the root categories with their Children populated recursively
+	GetTree(ctx context.Context) ([]*entity.Category, error)
+	// GetDescendants returns every category below the given one, at any depth
+	GetDescendants(ctx context.Context, id uuid.UUID) ([]*entity.Category, error)
 
 	// Product-Category relationship methods
 	AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error
 	RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error
 	GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error)
+	// GetProducts returns the products assigned to a category that are on sale at the given time
+	GetProducts(ctx context.Context, categoryID uuid.UUID, at time.Time, sort ProductSort, page, pageSize int) ([]*entity.Product, int, error)
+}
+
+type ProductSortField string
+
+const (
+	ProductSortName      ProductSortField = "name"
+	ProductSortPrice     ProductSortField = "price"
+	ProductSortCreatedAt ProductSortField = "created_at"
+	ProductSortRating    ProductSortField = "rating" // Average rating, then number of reviews
+)
+
+type ProductSort struct {
+	Field      ProductSortField
+	Descending bool
+}
+
+func (s ProductSort) IsValid() bool {
+	switch s.Field {
+	case ProductSortName, ProductSortPrice, ProductSortCreatedAt, ProductSortRating:
+		return true
+	}
+	return false
 }
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface CustomerProfileRepository -out ../../testing/mocks
+
+type CustomerProfileRepository interface {
+	AddNote(ctx context.Context, note *entity.CustomerNote) error
+	ListNotes(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerNote, error)
+
+	AddRiskEvent(ctx context.Context, event *entity.CustomerRiskEvent) error
+	ListRiskEvents(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerRiskEvent, error)
+
+	// DeleteByUser removes the notes and risk events of the customer
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
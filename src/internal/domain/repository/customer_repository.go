@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface CustomerRepository -out ../../testing/mocks
+
+type CustomerRepository interface {
+	// Get returns the customer data of the account with its addresses
+	Get(ctx context.Context, userID uuid.UUID) (*entity.Customer, error)
+	// Save creates or replaces the customer data, addresses included
+	Save(ctx context.Context, customer *entity.Customer) error
+	// Delete removes the customer data and addresses; it is a no-op when there are none
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
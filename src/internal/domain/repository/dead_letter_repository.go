@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface DeadLetterRepository -out ../../testing/mocks
+
+type DeadLetterRepository interface {
+	// Record stores a dead-lettered webhook. When its source already sent the
+	// same body, the existing one counts another attempt with the new reason
+	// instead, and keeps its status.
+	Record(ctx context.Context, webhook *entity.DeadLetterWebhook) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.DeadLetterWebhook, error)
+	Update(ctx context.Context, webhook *entity.DeadLetterWebhook) error
+
+	// List returns dead-lettered webhooks with optional filters, most
+	// recently failed first
+	List(ctx context.Context, filters DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error)
+}
+
+type DeadLetterFilters struct {
+	Status *entity.DeadLetterStatus
+	Source *string
+}
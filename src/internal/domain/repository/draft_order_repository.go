@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface DraftOrderRepository -out ../../testing/mocks
+
+type DraftOrderRepository interface {
+	// Create stores the draft with its items
+	Create(ctx context.Context, draft *entity.DraftOrder) error
+	// GetByID returns the draft with its items
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error)
+	// GetByToken returns the draft whose payment link has the token, with its items
+	GetByToken(ctx context.Context, token string) (*entity.DraftOrder, error)
+	// Update saves the draft and replaces its items with the ones it has
+	Update(ctx context.Context, draft *entity.DraftOrder) error
+	// UpdateStatus saves the status, order and conversion time of the draft
+	// only while it still has status from, a ConflictError otherwise. Two
+	// conversions of the same draft can't both go through.
+	UpdateStatus(ctx context.Context, draft *entity.DraftOrder, from entity.DraftOrderStatus) error
+	// List returns the drafts matching the filters with their items, newest first
+	List(ctx context.Context, filters DraftOrderFilters, page, pageSize int) ([]*entity.DraftOrder, int, error)
+}
+
+type DraftOrderFilters struct {
+	Status     *entity.DraftOrderStatus
+	CustomerID *int
+}
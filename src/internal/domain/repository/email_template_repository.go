@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface EmailTemplateRepository -out ../../testing/mocks
+
+type EmailTemplateRepository interface {
+	// CreateVersion stores template as the next version of its key
+	CreateVersion(ctx context.Context, template *entity.EmailTemplate) error
+	GetLatest(ctx context.Context, key string) (*entity.EmailTemplate, error)
+	GetVersion(ctx context.Context, key string, version int) (*entity.EmailTemplate, error)
+
+	// ListLatest returns the current version of every template, ordered by key
+	ListLatest(ctx context.Context) ([]*entity.EmailTemplate, error)
+	// ListVersions returns every version of a template, newest first
+	ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error)
+}
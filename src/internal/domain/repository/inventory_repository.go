@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface InventoryRepository -out ../../testing/mocks
+
+type InventoryRepository interface {
+	// SyncStock locks the variants with the SKUs of the update, applies it and
+	// writes the quantities and stock movements in one transaction. It returns
+	// the result of every level and the movements written.
+	SyncStock(ctx context.Context, sync *entity.InventorySync) ([]entity.StockLevelResult, []*entity.StockMovement, error)
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface InvoiceRepository -out ../../testing/mocks
+
+type InvoiceRepository interface {
+	// CreateWithNextNumber atomically reserves the next sequence number for the
+	// invoice's issue year, assigns it, calls render so the document can include
+	// the number and stores the invoice. Nothing is stored if render fails.
+	CreateWithNextNumber(ctx context.Context, invoice *entity.Invoice, render func(invoice *entity.Invoice) error) error
+	GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entity.Invoice, error)
+	// UpdatePDF saves the document of the invoice
+	UpdatePDF(ctx context.Context, invoice *entity.Invoice) error
+}
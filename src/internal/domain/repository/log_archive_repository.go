@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate go run ../../tools/mockgen -interface LogArchiveRepository -out ../../testing/mocks
+
+type LogArchiveRepository interface {
+	// ArchiveAuditLogs moves up to batchSize audit logs recorded before cutoff
+	// into the archive and returns how many were moved
+	ArchiveAuditLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error)
+
+	// ArchiveWebhookLogs moves up to batchSize processed webhook logs received
+	// before cutoff into the archive and returns how many were moved
+	ArchiveWebhookLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface LowStockRepository -out ../../testing/mocks
+
+type LowStockRepository interface {
+	// ListLowStock returns the products without variants and the variants with
+	// at most threshold units in stock, lowest stock first
+	ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error)
+}
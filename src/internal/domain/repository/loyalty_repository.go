@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface LoyaltyRepository -out ../../testing/mocks
+
+type LoyaltyRepository interface {
+	// Create records a transaction; an order takes one transaction of each
+	// type, a second one fails as a duplicate
+	Create(ctx context.Context, transaction *entity.LoyaltyTransaction) error
+
+	// Redeem records points spent by a user, checking under a lock of the
+	// user that the balance covers them
+	Redeem(ctx context.Context, transaction *entity.LoyaltyTransaction) error
+
+	// Balance returns the points of a user
+	Balance(ctx context.Context, userID uuid.UUID) (int, error)
+
+	ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.LoyaltyTransaction, error)
+
+	// ListByUser returns the transactions of a user, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*entity.LoyaltyTransaction, int, error)
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface OrderArchiveRepository -out ../../testing/mocks
+
+type OrderArchiveRepository interface {
+	// ArchiveBatch moves up to batchSize finalized orders created before cutoff
+	// (with their items) into the archive and returns how many were moved
+	ArchiveBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error)
+
+	// GetByID returns an archived order restored from its snapshot
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error)
+
+	// ListByUser returns the archived orders placed by the account, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error)
+
+	// AnonymizeUser unlinks the archived orders of the account from it, in
+	// their snapshots too, and returns how many it changed
+	AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error)
+}
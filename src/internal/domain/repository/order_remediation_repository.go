@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface OrderRemediationRepository -out ../../testing/mocks
+
+type OrderRemediationRepository interface {
+	Create(ctx context.Context, remediation *entity.OrderRemediation) error
+
+	// ListByOrder returns the remediations of an order, newest first
+	ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error)
+
+	// SumByActorSince returns the total amount of every action taken by an agent since the given time
+	SumByActorSince(ctx context.Context, actorID uuid.UUID, since time.Time) (float64, error)
+}
@@ -2,14 +2,73 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface OrderRepository -out ../../testing/mocks
+
 type OrderRepository interface {
 	Create(ctx context.Context, order *entity.Order) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error)
+	// GetByIDs returns the orders with the IDs and their items, as GetByID
+	// does. IDs no order has are left out.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error)
 	GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error)
+	// Search returns a page of the orders matching every filter of the
+	// search, sorted as it asks, with the total of matching orders
+	Search(ctx context.Context, search OrderSearch, page, pageSize int) ([]*entity.Order, int, error)
 	Update(ctx context.Context, order *entity.Order) error
+	// UpdateStatuses locks the orders of the update, moves them through the
+	// workflow and saves the ones that changed status in one transaction. It
+	// returns the result of every order and the orders updated, with their items.
+	UpdateStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate, workflow *entity.OrderWorkflow) ([]entity.OrderStatusResult, []*entity.Order, error)
+	// ScanByCreatedAt calls fn with batches of the orders placed at or after from
+	// and before until, oldest first, with their items and components loaded
+	ScanByCreatedAt(ctx context.Context, from, until time.Time, batchSize int, fn func(orders []*entity.Order) error) error
+	// ListByUser returns the orders placed by the account, newest first
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error)
+	// CountByUserSince returns how many orders the account placed at or after since
+	CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+	// CountPurchased returns how many units of the product the account ordered,
+	// variants included, leaving out cancelled and refunded orders
+	CountPurchased(ctx context.Context, userID uuid.UUID, productID uuid.UUID) (int, error)
+	// AnonymizeUser unlinks the orders of the account from it and returns how many it changed
+	AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// OrderSearch filters and sorts the orders admins look up. Empty filters
+// don't filter.
+type OrderSearch struct {
+	CustomerEmail string     // Of the account that placed the order
+	From          *time.Time // Placed at or after
+	To            *time.Time // Placed before
+	MinTotal      *float64
+	MaxTotal      *float64
+	SKU           string // Orders with a line of the variant, as it was ordered
+	Status        *entity.OrderStatus
+	PaymentStatus *entity.PaymentStatus
+	Sort          OrderSort
+}
+
+type OrderSortField string
+
+const (
+	OrderSortCreatedAt  OrderSortField = "created_at"
+	OrderSortTotalPrice OrderSortField = "total_price"
+)
+
+type OrderSort struct {
+	Field      OrderSortField
+	Descending bool
+}
+
+func (s OrderSort) IsValid() bool {
+	switch s.Field {
+	case OrderSortCreatedAt, OrderSortTotalPrice:
+		return true
+	}
+	return false
 }
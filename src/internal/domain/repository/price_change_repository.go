@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface PriceChangeRepository -out ../../testing/mocks
+
+type PriceChangeRepository interface {
+	Create(ctx context.Context, change *entity.PriceChange) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceChange, error)
+	Update(ctx context.Context, change *entity.PriceChange) error
+
+	// GetByProductID returns the price history of a product and its variants,
+	// latest effective first, scheduled changes included
+	GetByProductID(ctx context.Context, productID uuid.UUID, filters PriceChangeFilters, page, pageSize int) ([]*entity.PriceChange, int, error)
+
+	// GetSchedules returns the scheduled changes of the products that are not
+	// cancelled and have not ended at the given time
+	GetSchedules(ctx context.Context, productIDs []uuid.UUID, at time.Time) ([]*entity.PriceChange, error)
+}
+
+type PriceChangeFilters struct {
+	VariantID *uuid.UUID
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface PriceTierRepository -out ../../testing/mocks
+
+type PriceTierRepository interface {
+	// GetByProductID returns the price list of a product, ordered by variant,
+	// customer group and minimum quantity
+	GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error)
+	// ReplaceForProduct replaces the whole price list of a product
+	ReplaceForProduct(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) error
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface ProductOptionRepository -out ../../testing/mocks
+
+type ProductOptionRepository interface {
+	// Create stores the option together with its values
+	Create(ctx context.Context, option *entity.ProductOption) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductOption, error)
+	// ListByProductID returns the product's options and their values in position order
+	ListByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error)
+	AddValue(ctx context.Context, value *entity.ProductOptionValue) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// InUse reports whether any variant that is not deleted uses the option
+	InUse(ctx context.Context, id uuid.UUID) (bool, error)
+}
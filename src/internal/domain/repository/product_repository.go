@@ -2,15 +2,69 @@ package repository
 
 import (
 	"context"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface ProductRepository -out ../../testing/mocks
+
 type ProductRepository interface {
 	Create(ctx context.Context, product *entity.Product) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error)
-	GetAll(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error)
+	// GetByIDs returns the products with the IDs, loaded like GetByID, in one
+	// round trip per relation. IDs no product has are left out.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error)
+	GetAll(ctx context.Context, page, pageSize int, filters ProductFilters) ([]*entity.Product, int, error)
+	// ListProductsSummary lists products like GetAll with only the columns a
+	// listing shows: ID, name, price, quantity, status and rating. No relation is
+	// loaded, whatever filters include.
+	ListProductsSummary(ctx context.Context, page, pageSize int, filters ProductFilters) ([]*entity.Product, int, error)
 	Update(ctx context.Context, product *entity.Product) error
+	// Delete removes the product. Returns entity.ErrProductHasOrders when
+	// order items reference it, which keep needing it for their history.
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// ProductFilters narrows a product listing
+type ProductFilters struct {
+	InStockOnly bool
+	// Status only keeps products in the status, all of them when empty
+	Status entity.ProductStatus
+	// AvailableAt only keeps products whose availability window contains the time
+	AvailableAt *time.Time
+	// Attributes only keeps products having every given value. Each entry has
+	// its AttributeID and the typed value matching the definition set.
+	Attributes []entity.ProductAttribute
+	// Include lists the relations loaded along with the products, all of them
+	// when nil and none when empty
+	Include []ProductRelation
+	// Sort orders the listing, unsorted when its Field is empty
+	Sort ProductSort
+}
+
+// Includes tells whether the listing loads relation
+func (f ProductFilters) Includes(relation ProductRelation) bool {
+	return f.Include == nil || slices.Contains(f.Include, relation)
+}
+
+// ProductRelation is something a product listing can load along with the
+// products, each one costing a query per page
+type ProductRelation string
+
+const (
+	ProductCategories ProductRelation = "categories"
+	ProductVariants   ProductRelation = "variants" // With the option values of each variant
+	ProductOptions    ProductRelation = "options"  // With their values
+	ProductAttributes ProductRelation = "attributes"
+)
+
+func (r ProductRelation) IsValid() bool {
+	switch r {
+	case ProductCategories, ProductVariants, ProductOptions, ProductAttributes:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface ProductReviewRepository -out ../../testing/mocks
+
+// ProductReviewRepository keeps the reviews of products. Every change to the
+// reviews of a product updates its RatingAverage and RatingCount in the same
+// transaction, so the rating never drifts from the reviews.
+type ProductReviewRepository interface {
+	// Save creates the review of the customer for the product, or replaces
+	// the one they left before, keeping its ID and creation time
+	Save(ctx context.Context, review *entity.ProductReview) error
+	// Get returns the review the customer left on the product
+	Get(ctx context.Context, productID, userID uuid.UUID) (*entity.ProductReview, error)
+	// ListByProduct returns a page of the reviews of the product, newest first
+	ListByProduct(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductReview, int, error)
+	// Delete removes the review the customer left on the product
+	Delete(ctx context.Context, productID, userID uuid.UUID) error
+	// DeleteByUser removes the reviews of the customer from every product
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
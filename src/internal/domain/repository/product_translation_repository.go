@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface ProductTranslationRepository -out ../../testing/mocks
+
+type ProductTranslationRepository interface {
+	// Save creates the translation of a product in its locale, or replaces the
+	// name and description of the existing one
+	Save(ctx context.Context, translation *entity.ProductTranslation) error
+	Get(ctx context.Context, productID uuid.UUID, locale string) (*entity.ProductTranslation, error)
+
+	// ListByProduct returns the translations of a product ordered by locale
+	ListByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error)
+
+	// ListForProducts returns the translations of the products in any of the locales
+	ListForProducts(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]entity.ProductTranslation, error)
+
+	Delete(ctx context.Context, productID uuid.UUID, locale string) error
+}
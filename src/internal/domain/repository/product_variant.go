@@ -7,11 +7,24 @@ import (
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface ProductVariantRepository -out ../../testing/mocks
+
 type ProductVariantRepository interface {
 	Create(ctx context.Context, productVariant *entity.ProductVariant) error
 	GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error)
+	// GetByIDs returns the variants with the IDs, loaded like GetByID. IDs no
+	// variant has are left out.
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error)
 	GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error)
 	GetAllByProductID(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error)
+	GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error)
+	// GetByCombination finds the product's variant with the given combination key, see entity.CombinationKey
+	GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error)
+	// Update saves the variant and replaces its option values
 	Update(ctx context.Context, productVariant *entity.ProductVariant) error
 	Delete(ctx context.Context, id uuid.UUID) error
+
+	// TransferStock moves stock between the product and its variants and writes both
+	// ledger entries, all in one transaction. It returns the outgoing and incoming entries.
+	TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error)
 }
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface PurchaseQueueRepository -out ../../testing/mocks
+
+type PurchaseQueueRepository interface {
+	Create(ctx context.Context, entry *entity.PurchaseQueueEntry) error
+	Update(ctx context.Context, entry *entity.PurchaseQueueEntry) error
+
+	// GetActiveEntry returns the user's waiting or admitted entry for a product
+	GetActiveEntry(ctx context.Context, productID, userID uuid.UUID) (*entity.PurchaseQueueEntry, error)
+
+	// CountAhead returns how many waiting entries joined the product queue before the given time
+	CountAhead(ctx context.Context, productID uuid.UUID, joinedAt time.Time) (int, error)
+
+	// Advance expires elapsed purchase windows and admits waiting entries in arrival
+	// order until the number of open windows reaches slots. It runs atomically per product.
+	Advance(ctx context.Context, productID uuid.UUID, slots int, window time.Duration, now time.Time) error
+
+	// ListActiveProductIDs returns the products with waiting or admitted entries
+	ListActiveProductIDs(ctx context.Context) ([]uuid.UUID, error)
+}
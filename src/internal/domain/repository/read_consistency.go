@@ -0,0 +1,18 @@
+package repository
+
+import "context"
+
+type staleReadsKey struct{}
+
+// WithStaleReads marks ctx as belonging to work that only reads, such as a GET
+// request. Catalog reads made with it may be answered by a read replica that
+// lags a little behind the primary.
+func WithStaleReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, staleReadsKey{}, true)
+}
+
+// StaleReadsAllowed tells whether ctx was marked with WithStaleReads
+func StaleReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(staleReadsKey{}).(bool)
+	return allowed
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface RecallRepository -out ../../testing/mocks
+
+type RecallRepository interface {
+	// FindAffectedOrders returns the orders, live and archived, that contain
+	// the recalled product or variant, oldest first. Cancelled orders are skipped.
+	FindAffectedOrders(ctx context.Context, criteria RecallCriteria) ([]entity.AffectedOrder, error)
+
+	// Create stores the recall with its notices
+	Create(ctx context.Context, recall *entity.Recall) error
+	// GetByID returns the recall with its notices
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error)
+	// List returns recalls with their notices, newest first
+	List(ctx context.Context, page, pageSize int) ([]*entity.Recall, int, error)
+
+	GetNotice(ctx context.Context, id uuid.UUID) (*entity.RecallNotice, error)
+	UpdateNotice(ctx context.Context, notice *entity.RecallNotice) error
+	// ListNoticesByUser returns the user's notices with their recall, newest first
+	ListNoticesByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error)
+}
+
+// RecallCriteria selects the order items of a recall. Orders are matched when
+// placed at or after From and before Until.
+type RecallCriteria struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID // Only this variant, all of the product when nil
+	From      time.Time
+	Until     time.Time
+}
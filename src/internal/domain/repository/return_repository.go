@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface ReturnRepository -out ../../testing/mocks
+
+type ReturnRepository interface {
+	// Create stores the return with its items
+	Create(ctx context.Context, ret *entity.Return) error
+	// GetByID returns the return with its items
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error)
+	// Update saves the status and refund of the return, its items don't change
+	Update(ctx context.Context, ret *entity.Return) error
+
+	// List returns the returns matching the filters with their items, newest first
+	List(ctx context.Context, filters ReturnFilters, page, pageSize int) ([]*entity.Return, int, error)
+	// ListByOrder returns every return of an order with its items, oldest first
+	ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Return, error)
+}
+
+type ReturnFilters struct {
+	Status  *entity.ReturnStatus
+	OrderID *uuid.UUID
+	UserID  *uuid.UUID // Returns of the orders the account placed
+}
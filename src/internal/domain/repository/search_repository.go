@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface SearchRepository -out ../../testing/mocks
+
+type SearchRepository interface {
+	// FindCandidates returns up to limit products on sale at the given time
+	// whose name or description contains every term, case-insensitively, with
+	// their relations loaded
+	FindCandidates(ctx context.Context, terms []string, at time.Time, limit int) ([]*entity.Product, error)
+
+	CreateRule(ctx context.Context, rule *entity.RankingRule) error
+	GetRule(ctx context.Context, id uuid.UUID) (*entity.RankingRule, error)
+	UpdateRule(ctx context.Context, rule *entity.RankingRule) error
+	DeleteRule(ctx context.Context, id uuid.UUID) error
+	// ListRules returns every rule, oldest first
+	ListRules(ctx context.Context) ([]*entity.RankingRule, error)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface StockMovementRepository -out ../../testing/mocks
+
+type StockMovementRepository interface {
+	Create(ctx context.Context, movement *entity.StockMovement) error
+
+	// GetByProductID returns the movements of a product and its variants, newest first
+	GetByProductID(ctx context.Context, productID uuid.UUID, filters StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error)
+}
+
+type StockMovementFilters struct {
+	VariantID *uuid.UUID
+	Reason    *entity.StockMovementReason
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface StoreSettingRepository -out ../../testing/mocks
+
+type StoreSettingRepository interface {
+	// List returns the settings that were set, ordered by key
+	List(ctx context.Context) ([]*entity.StoreSetting, error)
+	// Save sets the settings at once, replacing the values of keys already set
+	Save(ctx context.Context, settings []*entity.StoreSetting) error
+	// Delete unsets the keys, so they take their default again
+	Delete(ctx context.Context, keys []string) error
+}
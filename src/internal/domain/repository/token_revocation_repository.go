@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface TokenRevocationRepository -out ../../testing/mocks
+
+type TokenRevocationRepository interface {
+	// Create records a revocation. Revoking a token already revoked is a no-op.
+	Create(ctx context.Context, revocation *entity.TokenRevocation) error
+
+	// IsRevoked tells whether the token with the given ID, issued to userID at
+	// issuedAt, is covered by a revocation that hasn't expired
+	IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error)
+
+	// DeleteExpired removes the revocations of tokens expired before cutoff
+	// and returns how many were removed
+	DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error)
+}
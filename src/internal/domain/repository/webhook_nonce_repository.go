@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface WebhookNonceRepository -out ../../testing/mocks
+
+type WebhookNonceRepository interface {
+	// Claim records a nonce and reports whether it was free: never seen, or
+	// only by a webhook whose nonce expired before now
+	Claim(ctx context.Context, nonce *entity.WebhookNonce, now time.Time) (bool, error)
+
+	// DeleteExpired removes the nonces expired before cutoff and returns how
+	// many were removed
+	DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error)
+}
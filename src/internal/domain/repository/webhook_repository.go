@@ -2,12 +2,32 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface WebhookRepository -out ../../testing/mocks
+
 type WebhookRepository interface {
 	Create(ctx context.Context, log *entity.WebhookLog) error
 	Update(ctx context.Context, log *entity.WebhookLog) error
-	GetByOrderID(ctx context.Context, orderID string) ([]entity.WebhookLog, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookLog, error)
+
+	// List returns the webhook logs of every order with optional filters, newest first
+	List(ctx context.Context, filters WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error)
+
+	// GetByOrderID returns webhook logs for an order with optional filters, newest first
+	GetByOrderID(ctx context.Context, orderID string, filters WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error)
+
+	// ListDueRetries returns up to limit failed webhook logs whose next retry
+	// is at or before now, oldest retry first
+	ListDueRetries(ctx context.Context, now time.Time, limit int) ([]entity.WebhookLog, error)
+}
+
+type WebhookLogFilters struct {
+	TransactionID *string
+	PaymentStatus *entity.PaymentStatus
+	Status        *entity.WebhookStatus
 }
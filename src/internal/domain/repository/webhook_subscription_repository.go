@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../tools/mockgen -interface WebhookSubscriptionRepository -out ../../testing/mocks
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription) error
+	GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	Update(ctx context.Context, subscription *entity.WebhookSubscription) error
+	// Delete removes the subscription with its deliveries
+	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns every subscription, oldest first
+	List(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	// ListActive returns the subscriptions events are delivered to
+	ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error)
+
+	CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+	// ListDeliveries returns the deliveries of a subscription, newest first
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters WebhookDeliveryFilters, page, pageSize int) ([]*entity.WebhookDelivery, int, error)
+	// ListDueDeliveries returns up to limit pending deliveries whose next
+	// attempt is at or before now, the earliest attempt first
+	ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+}
+
+type WebhookDeliveryFilters struct {
+	Status    *entity.WebhookDeliveryStatus
+	EventType *string
+}
@@ -10,17 +10,41 @@ import (
 	"gorm.io/datatypes"
 )
 
+//go:generate go run ../../tools/mockgen -interface AuditService -out ../../testing/mocks
+
 // AuditService handles audit logging for entity changes
 type AuditService interface {
 	LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error
 }
 
+// Actor is who performs the current request: the user, and the admin
+// impersonating them if any
+type Actor struct {
+	UserID         *uuid.UUID
+	ImpersonatorID *uuid.UUID
+}
+
+// ActorResolver returns the actor of the current request, empty outside of one
+type ActorResolver func(ctx context.Context) Actor
+
+//go:generate go run ../../tools/mockgen -interface Observer -out ../../testing/mocks -name AuditObserver
+
+// Observer is notified of every audit log entry after it is stored
+type Observer interface {
+	Observe(ctx context.Context, log *entity.AuditLog)
+}
+
 type auditService struct {
-	repo repository.AuditLogRepository
+	repo         repository.AuditLogRepository
+	resolveActor ActorResolver
+	observers    []Observer
 }
 
-func NewAuditService(repo repository.AuditLogRepository) AuditService {
-	return &auditService{repo: repo}
+// NewAuditService creates the audit service. When a change is logged without
+// a user, resolveActor is used to attribute it to the caller. Changes made
+// while impersonating are flagged with the impersonator either way.
+func NewAuditService(repo repository.AuditLogRepository, resolveActor ActorResolver, observers ...Observer) AuditService {
+	return &auditService{repo: repo, resolveActor: resolveActor, observers: observers}
 }
 
 func (s *auditService) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
@@ -44,15 +68,32 @@ func (s *auditService) LogChange(ctx context.Context, userID *uuid.UUID, action,
 		payloadAfter = datatypes.JSON(afterBytes)
 	}
 
+	var actor Actor
+	if s.resolveActor != nil {
+		actor = s.resolveActor(ctx)
+	}
+	if userID == nil {
+		userID = actor.UserID
+	}
+
 	// Create audit log entry
 	log := &entity.AuditLog{
-		UserID:        userID,
-		Action:        action,
-		ResourceType:  resourceType,
-		ResourceID:    resourceID,
-		PayloadBefore: payloadBefore,
-		PayloadAfter:  payloadAfter,
+		UserID:         userID,
+		ImpersonatorID: actor.ImpersonatorID,
+		Action:         action,
+		ResourceType:   resourceType,
+		ResourceID:     resourceID,
+		PayloadBefore:  payloadBefore,
+		PayloadAfter:   payloadAfter,
+	}
+
+	if err := s.repo.Create(ctx, log); err != nil {
+		return err
+	}
+
+	for _, observer := range s.observers {
+		observer.Observe(ctx, log)
 	}
 
-	return s.repo.Create(ctx, log)
+	return nil
 }
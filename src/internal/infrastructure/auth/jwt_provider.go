@@ -1,7 +1,10 @@
 package auth
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,48 +12,178 @@ import (
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
 
+//go:generate go run ../../tools/mockgen -interface TokenProvider -out ../../testing/mocks
+
 // TokenProvider defines the interface for JWT token operations
 type TokenProvider interface {
 	GenerateToken(user *entity.User) (string, error)
+	// GenerateImpersonationToken issues a token of user to the admin impersonating them
+	GenerateImpersonationToken(user *entity.User, impersonatorID uuid.UUID, lifetime time.Duration) (string, error)
 	ValidateToken(tokenString string) (*Claims, error)
+	// Lifetime is how long a token stays valid after it is issued
+	Lifetime() time.Duration
 }
 
 type Claims struct {
 	UserID uuid.UUID   `json:"user_id"`
 	Email  string      `json:"email"`
 	Role   entity.Role `json:"role"`
+	// ImpersonatorID is the admin acting as the user, set on impersonation tokens only
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Impersonated tells whether the token was issued to an admin impersonating the user
+func (c *Claims) Impersonated() bool {
+	return c.ImpersonatorID != nil
+}
+
+// signingKey is a secret of the keyring, identified in the kid header of the
+// tokens it signs
+type signingKey struct {
+	id     string
+	secret []byte
+	// retiresAt is when a rotated-out key stops verifying, once every token it
+	// signed has expired. Zero for the configured keys, which verify until removed.
+	retiresAt time.Time
+}
+
+// JWTProvider signs tokens with one key and verifies them with any key of its
+// keyring, so the secret can be rotated without logging everyone out: tokens
+// signed with a previous key keep working until they expire.
 type JWTProvider struct {
-	secretKey       string
+	mu              sync.RWMutex
+	signing         signingKey
+	verification    []signingKey // Previous keys, still accepted
 	expirationHours int
+	now             func() time.Time
 }
 
-func NewJWTProvider(secretKey string, expirationHours int) *JWTProvider {
-	return &JWTProvider{
-		secretKey:       secretKey,
+// NewJWTProvider signs with secretKey and also accepts tokens signed with the
+// previous secrets
+func NewJWTProvider(secretKey string, expirationHours int, previousSecrets ...string) *JWTProvider {
+	p := &JWTProvider{
+		signing:         newSigningKey(secretKey),
 		expirationHours: expirationHours,
+		now:             time.Now,
+	}
+	for _, secret := range previousSecrets {
+		if key := newSigningKey(secret); key.id != p.signing.id {
+			p.verification = append(p.verification, key)
+		}
+	}
+	return p
+}
+
+// KeyID identifies a secret in the kid header without revealing it: the first
+// 8 bytes of its SHA-256 in hex
+func KeyID(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:8])
+}
+
+func newSigningKey(secret string) signingKey {
+	return signingKey{id: KeyID(secret), secret: []byte(secret)}
+}
+
+// Rotate makes secretKey the signing key. The previous signing key keeps
+// verifying until the tokens it signed have expired.
+func (p *JWTProvider) Rotate(secretKey string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	next := newSigningKey(secretKey)
+	if next.id == p.signing.id {
+		return
+	}
+
+	previous := p.signing
+	previous.retiresAt = p.now().Add(time.Duration(p.expirationHours) * time.Hour)
+	keys := []signingKey{previous}
+	for _, key := range p.verification {
+		if key.id != next.id && key.id != previous.id {
+			keys = append(keys, key)
+		}
+	}
+	p.signing, p.verification = next, keys
+}
+
+// activeVerificationKeys returns the previous keys that have not retired; the caller holds mu
+func (p *JWTProvider) activeVerificationKeys() []signingKey {
+	now := p.now()
+	var keys []signingKey
+	for _, key := range p.verification {
+		if key.retiresAt.IsZero() || now.Before(key.retiresAt) {
+			keys = append(keys, key)
+		}
 	}
+	return keys
+}
+
+// verificationKey returns the secret of the key that signed the token. Tokens
+// from before the keyring carry no kid, they are tried with every key.
+func (p *JWTProvider) verificationKey(token *jwt.Token) (interface{}, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	keys := append([]signingKey{p.signing}, p.activeVerificationKeys()...)
+
+	kid, ok := token.Header["kid"].(string)
+	if !ok {
+		set := jwt.VerificationKeySet{}
+		for _, key := range keys {
+			set.Keys = append(set.Keys, key.secret)
+		}
+		return set, nil
+	}
+
+	for _, key := range keys {
+		if key.id == kid {
+			return key.secret, nil
+		}
+	}
+	return nil, errors.New("Unknown signing key")
+}
+
+func (p *JWTProvider) Lifetime() time.Duration {
+	return time.Duration(p.expirationHours) * time.Hour
 }
 
 // GenerateToken generates a new JWT token for a user
 func (p *JWTProvider) GenerateToken(user *entity.User) (string, error) {
-	expirationTime := time.Now().Add(time.Duration(p.expirationHours) * time.Hour)
+	return p.generateToken(user, nil, p.Lifetime())
+}
+
+// GenerateImpersonationToken generates a token of the user for the admin
+// impersonating them. It carries the impersonator and expires after lifetime,
+// whatever the lifetime of regular tokens.
+func (p *JWTProvider) GenerateImpersonationToken(user *entity.User, impersonatorID uuid.UUID, lifetime time.Duration) (string, error) {
+	return p.generateToken(user, &impersonatorID, lifetime)
+}
+
+func (p *JWTProvider) generateToken(user *entity.User, impersonatorID *uuid.UUID, lifetime time.Duration) (string, error) {
+	expirationTime := time.Now().Add(lifetime)
 
 	claims := &Claims{
-		UserID: user.ID,
-		Email:  user.Email,
-		Role:   user.Role,
+		UserID:         user.ID,
+		Email:          user.Email,
+		Role:           user.Role,
+		ImpersonatorID: impersonatorID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expirationTime),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			Issuer:    "go-ecommerce",
+			ID:        uuid.NewString(), // Lets a single token be revoked
 		},
 	}
 
+	p.mu.RLock()
+	key := p.signing
+	p.mu.RUnlock()
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(p.secretKey))
+	token.Header["kid"] = key.id
+	return token.SignedString(key.secret)
 }
 
 // ValidateToken validates a JWT token and returns the claims
@@ -60,7 +193,7 @@ func (p *JWTProvider) ValidateToken(tokenString string) (*Claims, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errors.New("Invalid signing method")
 		}
-		return []byte(p.secretKey), nil
+		return p.verificationKey(token)
 	})
 
 	if err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 )
@@ -75,6 +76,43 @@ func TestJWTProvider_ValidateToken_Success(t *testing.T) {
 	}
 }
 
+func TestJWTProvider_GenerateImpersonationToken(t *testing.T) {
+	provider := NewJWTProvider("test-secret-key-for-jwt", 24)
+
+	user := &entity.User{
+		ID:    uuid.New(),
+		Email: "test@example.com",
+		Name:  "Test User",
+		Role:  entity.RoleCustomer,
+	}
+	adminID := uuid.New()
+
+	token, err := provider.GenerateImpersonationToken(user, adminID, 15*time.Minute)
+	if err != nil {
+		t.Fatalf("GenerateImpersonationToken() error = %v", err)
+	}
+
+	claims, err := provider.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v, want nil", err)
+	}
+
+	if claims.UserID != user.ID || claims.Role != entity.RoleCustomer {
+		t.Errorf("ValidateToken() user = %s (%s), want %s (customer)", claims.UserID, claims.Role, user.ID)
+	}
+	if !claims.Impersonated() || *claims.ImpersonatorID != adminID {
+		t.Errorf("ValidateToken() ImpersonatorID = %v, want %s", claims.ImpersonatorID, adminID)
+	}
+	if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != 15*time.Minute {
+		t.Errorf("token lifetime = %v, want 15m", lifetime)
+	}
+
+	regular, _ := provider.GenerateToken(user)
+	if claims, _ := provider.ValidateToken(regular); claims.Impersonated() {
+		t.Error("regular token is impersonated")
+	}
+}
+
 func TestJWTProvider_ValidateToken_InvalidToken(t *testing.T) {
 	provider := NewJWTProvider("test-secret-key-for-jwt", 24)
 
@@ -190,3 +228,69 @@ func TestJWTProvider_ValidateToken_EmptyToken(t *testing.T) {
 		t.Error("ValidateToken() should return error for empty token")
 	}
 }
+
+func TestJWTProvider_Rotate(t *testing.T) {
+	provider := NewJWTProvider("test-secret-key-before-rotation", 24)
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com", Role: entity.RoleCustomer}
+
+	oldToken, _ := provider.GenerateToken(user)
+	provider.Rotate("test-secret-key-after-rotation")
+
+	if _, err := provider.ValidateToken(oldToken); err != nil {
+		t.Errorf("ValidateToken() error = %v, want the previous key to still verify", err)
+	}
+
+	newToken, _ := provider.GenerateToken(user)
+	if _, err := NewJWTProvider("test-secret-key-before-rotation", 24).ValidateToken(newToken); err == nil {
+		t.Error("ValidateToken() accepted a token signed with the new key on a provider without it")
+	}
+	if _, err := provider.ValidateToken(newToken); err != nil {
+		t.Errorf("ValidateToken() error = %v, want nil", err)
+	}
+
+	// Once every token signed with it has expired, the previous key retires
+	provider.now = func() time.Time { return time.Now().Add(25 * time.Hour) }
+	if _, err := provider.ValidateToken(oldToken); err == nil {
+		t.Error("ValidateToken() accepted a token signed with a retired key")
+	}
+}
+
+func TestJWTProvider_PreviousSecrets(t *testing.T) {
+	user := &entity.User{ID: uuid.New(), Email: "test@example.com", Role: entity.RoleCustomer}
+	oldToken, _ := NewJWTProvider("test-secret-key-before-rotation", 24).GenerateToken(user)
+
+	provider := NewJWTProvider("test-secret-key-after-rotation", 24, "test-secret-key-before-rotation")
+	if _, err := provider.ValidateToken(oldToken); err != nil {
+		t.Errorf("ValidateToken() error = %v, want tokens of a previous secret to verify", err)
+	}
+}
+
+func TestJWTProvider_ValidateToken_KeyID(t *testing.T) {
+	provider := NewJWTProvider("test-secret-key-for-jwt", 24)
+	claims := &Claims{
+		UserID:           uuid.New(),
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+	}
+
+	token, _ := provider.GenerateToken(&entity.User{ID: claims.UserID})
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+	if parsed.Header["kid"] != KeyID("test-secret-key-for-jwt") {
+		t.Errorf("kid = %v, want %s", parsed.Header["kid"], KeyID("test-secret-key-for-jwt"))
+	}
+
+	// Tokens issued before the keyring have no kid
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("test-secret-key-for-jwt"))
+	if _, err := provider.ValidateToken(legacy); err != nil {
+		t.Errorf("ValidateToken() error = %v, want a token without kid to verify", err)
+	}
+
+	unknown := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	unknown.Header["kid"] = "0000000000000000"
+	signed, _ := unknown.SignedString([]byte("test-secret-key-for-jwt"))
+	if _, err := provider.ValidateToken(signed); err == nil {
+		t.Error("ValidateToken() accepted a token with an unknown kid")
+	}
+}
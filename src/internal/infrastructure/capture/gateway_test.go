@@ -0,0 +1,102 @@
+package capture
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHTTPGateway_Capture(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Signature")
+		gotKey = r.Header.Get("Idempotency-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"capture_id": "cap_123"}`))
+	}))
+	defer server.Close()
+
+	orderID := uuid.New()
+	reference, err := NewHTTPGateway(server.URL, "secret").Capture(context.Background(), Request{
+		OrderID: orderID, TransactionID: "auth_1", Amount: 49.9, IdempotencyKey: "capture-1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if reference != "cap_123" {
+		t.Errorf("reference = %q, want cap_123", reference)
+	}
+	if gotKey != "capture-1" {
+		t.Errorf("Idempotency-Key = %q, want capture-1", gotKey)
+	}
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(gotBody)
+	if gotSignature != hex.EncodeToString(mac.Sum(nil)) {
+		t.Error("signature does not match the body")
+	}
+
+	var payload capturePayload
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.OrderID != orderID.String() || payload.TransactionID != "auth_1" || payload.Amount != 49.9 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
+
+func TestHTTPGateway_RejectedCapture(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+	}{
+		{"declined", http.StatusPaymentRequired, ""},
+		{"no capture id", http.StatusOK, `{}`},
+		{"invalid body", http.StatusOK, `not json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			_, err := NewHTTPGateway(server.URL, "secret").Capture(context.Background(), Request{OrderID: uuid.New(), Amount: 5, IdempotencyKey: "k"})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
+
+func TestLogGateway_Capture(t *testing.T) {
+	var buf bytes.Buffer
+	reference, err := NewLogGateway(log.New(&buf, "", 0)).Capture(context.Background(), Request{
+		OrderID: uuid.New(), TransactionID: "auth_1", Amount: 10, IdempotencyKey: "capture-1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reference != "manual-capture-1" {
+		t.Errorf("reference = %q, want manual-capture-1", reference)
+	}
+	if !strings.Contains(buf.String(), "transaction=auth_1") {
+		t.Errorf("expected the capture to be logged, got %q", buf.String())
+	}
+}
@@ -0,0 +1,37 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// accessCodesUp creates the table of the access codes admins hand out while
+// the store is in private beta. Codes are stored upper case, so the unique
+// index matches them regardless of how they were typed.
+func accessCodesUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS access_codes (
+    id UUID PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    note VARCHAR(255),
+    max_uses INTEGER NOT NULL DEFAULT 0,
+    uses INTEGER NOT NULL DEFAULT 0,
+    expires_at {timestamp},
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID,
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_access_codes_code ON access_codes (code);
+`, "{timestamp}", timestamp)).Error
+}
+
+func accessCodesDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS access_codes;`).Error
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// auditImpersonatorModels record the admin who impersonated the user of a change
+var auditImpersonatorModels = []interface{}{&entity.AuditLog{}, &entity.ArchivedAuditLog{}}
+
+// auditImpersonatorsUp adds the impersonator to audit logs, archived ones
+// included. Existing logs were made without impersonation and keep it null.
+func auditImpersonatorsUp(tx *gorm.DB) error {
+	for _, model := range auditImpersonatorModels {
+		if err := tx.Migrator().AddColumn(model, "ImpersonatorID"); err != nil {
+			return err
+		}
+	}
+	return tx.Migrator().CreateIndex(&entity.AuditLog{}, "ImpersonatorID")
+}
+
+// auditImpersonatorsDown drops the index only when it is there: SQLite loses
+// the indexes of a table when a later migration drops one of its columns
+func auditImpersonatorsDown(tx *gorm.DB) error {
+	if tx.Migrator().HasIndex(&entity.AuditLog{}, "ImpersonatorID") {
+		if err := tx.Migrator().DropIndex(&entity.AuditLog{}, "ImpersonatorID"); err != nil {
+			return err
+		}
+	}
+	for _, model := range auditImpersonatorModels {
+		if err := tx.Migrator().DropColumn(model, "ImpersonatorID"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
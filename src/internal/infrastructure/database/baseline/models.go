@@ -0,0 +1,411 @@
+// Package baseline holds the models of the baseline migration as they were
+// when versioned migrations started. The schema of version 1 is built from
+// them, not from the entities, which keep changing with later migrations.
+// They keep the names of the entities they were copied from, which GORM
+// names the constraints and join columns after, and must not be edited.
+package baseline
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// Models are the tables of the baseline migration.
+// Order matters: tables with foreign keys must come after their references
+var Models = []interface{}{
+	&User{},                // No dependencies
+	&Category{},            // No dependencies
+	&Product{},             // No dependencies
+	&ProductOption{},       // Foreign key to Product
+	&ProductOptionValue{},  // Foreign key to ProductOption
+	&ProductVariant{},      // Foreign key to Product
+	&VariantOption{},       // Foreign key to ProductVariant and ProductOption
+	&ProductCategory{},     // Foreign key to Product and Category (junction table)
+	&Order{},               // Foreign key to User (CustomerID)
+	&OrderItem{},           // Foreign key to Order and Product
+	&OrderItemComponent{},  // Foreign key to OrderItem
+	&WebhookLog{},          // Foreign key to Order
+	&AuditLog{},            // Audit logging for all entities
+	&InvoiceSequence{},     // No dependencies
+	&Invoice{},             // Foreign key to Order
+	&PurchaseQueueEntry{},  // Foreign key to Product and User
+	&ArchivedOrder{},       // Cold storage for old orders, no foreign keys
+	&ArchivedAuditLog{},    // Cold storage for old audit logs, no foreign keys
+	&ArchivedWebhookLog{},  // Cold storage for old webhook logs, no foreign keys
+	&CustomerNote{},        // Foreign key to User
+	&CustomerRiskEvent{},   // Foreign key to User
+	&StockMovement{},       // Foreign key to Product and ProductVariant
+	&AdminAlert{},          // References AuditLog and User
+	&OrderRemediation{},    // Foreign key to Order and User
+	&AttributeDefinition{}, // No dependencies
+	&ProductAttribute{},    // Foreign key to Product and AttributeDefinition
+	&EmailTemplate{},       // No dependencies
+	&CatalogReport{},       // No dependencies
+	&Recall{},              // References Product and User
+	&RecallNotice{},        // Foreign key to Recall, references Order and User
+	&RankingRule{},         // References Product
+}
+
+type User struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Email        string    `gorm:"uniqueIndex;not null"`
+	PasswordHash string    `gorm:"not null"`
+	Name         string    `gorm:"not null"`
+	Role         string    `gorm:"type:varchar(50);not null;default:customer"`
+	Active       bool      `gorm:"not null;default:true"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+type Category struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Name      string     `gorm:"type:varchar(100);unique;not null"`
+	Slug      string     `gorm:"type:varchar(120);uniqueIndex"`
+	ParentID  *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+
+	Products []Product `gorm:"many2many:product_categories;"`
+}
+
+type UnitMeasure struct {
+	Unit    string  `gorm:"column:measurement_unit;type:varchar(10)"`
+	Content float64 `gorm:"column:unit_content;type:decimal(10,3)"`
+}
+
+type Product struct {
+	ID             uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	Name           string      `gorm:"size:255;not null"`
+	Description    string      `gorm:"type:text"`
+	Price          float64     `gorm:"type:decimal(10,2);not null"`
+	Quantity       int         `gorm:"not null"`
+	HighDemandMode bool        `gorm:"not null;default:false"`
+	Cost           *float64    `gorm:"type:decimal(10,2)"`
+	Measure        UnitMeasure `gorm:"embedded"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	Variants   []ProductVariant   `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Options    []ProductOption    `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Categories []Category         `gorm:"many2many:product_categories;"`
+	Attributes []ProductAttribute `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductCategory struct {
+	ProductID  uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_product_category"`
+	CategoryID uuid.UUID `gorm:"type:uuid;primaryKey;index:idx_product_category"`
+
+	Product  Product  `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Category Category `gorm:"foreignKey:CategoryID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductOption struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_option_name"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_product_option_name"`
+	Position  int       `gorm:"not null;default:0"`
+	CreatedAt time.Time
+
+	Values []ProductOptionValue `gorm:"foreignKey:OptionID;constraint:OnDelete:CASCADE"`
+}
+
+type ProductOptionValue struct {
+	ID       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OptionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_option_value"`
+	Value    string    `gorm:"type:varchar(100);not null;uniqueIndex:idx_option_value"`
+	Position int       `gorm:"not null;default:0"`
+}
+
+type VariantOption struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	VariantID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_variant_option"`
+	OptionID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_variant_option;index"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Value     string    `gorm:"type:varchar(100);not null"`
+	Position  int       `gorm:"not null;default:0"`
+}
+
+type ProductVariant struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID      uuid.UUID `gorm:"type:uuid;not null;index:idx_variant_combination"`
+	SKU            string    `gorm:"type:varchar(64);uniqueIndex:idx_variant_sku,where:deleted_at IS NULL"`
+	CombinationKey string    `gorm:"type:varchar(500);not null;default:'';index:idx_variant_combination"`
+	Price_Override *float64  `gorm:"type:decimal(10,2)"`
+	Quantity       int       `gorm:"not null"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"`
+
+	Product *Product        `gorm:"foreignKey:ProductID;constraint:OnDelete:CASCADE"`
+	Options []VariantOption `gorm:"foreignKey:VariantID;constraint:OnDelete:CASCADE"`
+}
+
+type Order struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primaryKey"`
+	CustomerID    int         `gorm:"not null"`
+	UserID        *uuid.UUID  `gorm:"type:uuid;index"`
+	Products      []OrderItem `gorm:"foreignKey:OrderID;constraint:OnDelete:CASCADE"`
+	TotalPrice    float64     `gorm:"type:decimal(10,2);not null"`
+	Status        string      `gorm:"type:varchar(20);not null;default:'pending'"`
+	PaymentStatus string      `gorm:"type:varchar(20);not null;default:'unpaid'"`
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type OrderItem struct {
+	ID         uuid.UUID            `gorm:"type:uuid;primaryKey"`
+	OrderID    uuid.UUID            `gorm:"type:uuid;not null"`
+	ProductID  uuid.UUID            `gorm:"type:uuid;not null"`
+	VariantID  *uuid.UUID           `gorm:"type:uuid"`
+	Quantity   int                  `gorm:"not null"`
+	Price      float64              `gorm:"type:decimal(10,2);not null"`
+	TotalPrice float64              `gorm:"type:decimal(10,2);not null"`
+	Components []OrderItemComponent `gorm:"foreignKey:OrderItemID;constraint:OnDelete:CASCADE"`
+}
+
+type OrderItemComponent struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrderItemID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type        string    `gorm:"type:varchar(20);not null"`
+	Label       string    `gorm:"size:100"`
+	Amount      float64   `gorm:"type:decimal(10,2);not null"`
+}
+
+type WebhookLog struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrderID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	TransactionID string    `gorm:"type:varchar(255);not null;uniqueIndex"`
+	PaymentStatus string    `gorm:"type:varchar(20);not null"`
+	Status        string    `gorm:"type:varchar(20);not null;default:'pending'"`
+	RetryCount    int       `gorm:"default:0"`
+	NextRetryAt   *time.Time
+	RawPayload    string `gorm:"type:text"`
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+type AuditLog struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	UserID        *uuid.UUID     `gorm:"type:uuid;index"`
+	Action        string         `gorm:"size:100;not null;index"`
+	ResourceType  string         `gorm:"size:100;not null;index"`
+	ResourceID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PayloadBefore datatypes.JSON `gorm:"type:jsonb"`
+	PayloadAfter  datatypes.JSON `gorm:"type:jsonb"`
+	Timestamp     time.Time      `gorm:"not null;index"`
+}
+
+func (a *AuditLog) TableName() string {
+	return "audit_logs"
+}
+
+type InvoiceSequence struct {
+	Year       int `gorm:"primaryKey;autoIncrement:false"`
+	LastNumber int `gorm:"not null;default:0"`
+}
+
+type Invoice struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrderID   uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	Number    string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	Year      int       `gorm:"not null;uniqueIndex:idx_invoice_year_sequence"`
+	Sequence  int       `gorm:"not null;uniqueIndex:idx_invoice_year_sequence"`
+	PDF       []byte    `gorm:"type:bytea;not null"`
+	IssuedAt  time.Time `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+type PurchaseQueueEntry struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID       uuid.UUID `gorm:"type:uuid;not null;index:idx_queue_product_status"`
+	UserID          uuid.UUID `gorm:"type:uuid;not null;index"`
+	Status          string    `gorm:"type:varchar(20);not null;default:'waiting';index:idx_queue_product_status"`
+	WindowExpiresAt *time.Time
+	CreatedAt       time.Time `gorm:"not null;index"`
+	UpdatedAt       time.Time
+}
+
+type ArchivedOrder struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	CustomerID     int            `gorm:"not null;index"`
+	UserID         *uuid.UUID     `gorm:"type:uuid;index"`
+	Status         string         `gorm:"type:varchar(20);not null"`
+	PaymentStatus  string         `gorm:"type:varchar(20);not null"`
+	TotalPrice     float64        `gorm:"type:decimal(10,2);not null"`
+	Snapshot       datatypes.JSON `gorm:"type:jsonb;not null"`
+	OrderCreatedAt time.Time      `gorm:"not null;index"`
+	ArchivedAt     time.Time      `gorm:"not null"`
+}
+
+type ArchivedAuditLog struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	UserID        *uuid.UUID     `gorm:"type:uuid;index"`
+	Action        string         `gorm:"size:100;not null"`
+	ResourceType  string         `gorm:"size:100;not null"`
+	ResourceID    uuid.UUID      `gorm:"type:uuid;not null;index"`
+	PayloadBefore datatypes.JSON `gorm:"type:jsonb"`
+	PayloadAfter  datatypes.JSON `gorm:"type:jsonb"`
+	Timestamp     time.Time      `gorm:"not null;index"`
+	ArchivedAt    time.Time      `gorm:"not null"`
+}
+
+type ArchivedWebhookLog struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey"`
+	OrderID       uuid.UUID `gorm:"type:uuid;not null;index"`
+	TransactionID string    `gorm:"type:varchar(255);not null;index"`
+	PaymentStatus string    `gorm:"type:varchar(20);not null"`
+	Status        string    `gorm:"type:varchar(20);not null"`
+	RetryCount    int
+	RawPayload    string `gorm:"type:text"`
+	ProcessedAt   *time.Time
+	CreatedAt     time.Time `gorm:"not null;index"`
+	ArchivedAt    time.Time `gorm:"not null"`
+}
+
+type CustomerNote struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	AuthorID  uuid.UUID `gorm:"type:uuid;not null"`
+	Body      string    `gorm:"type:text;not null"`
+	CreatedAt time.Time
+}
+
+type CustomerRiskEvent struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:uuid;not null;index"`
+	Type      string    `gorm:"type:varchar(30);not null"`
+	Reference string    `gorm:"size:255"`
+	CreatedAt time.Time
+}
+
+type StockMovement struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	ProductID      uuid.UUID  `gorm:"type:uuid;not null;index:idx_stock_movements_product_created,priority:1"`
+	VariantID      *uuid.UUID `gorm:"type:uuid;index"`
+	Reason         string     `gorm:"type:varchar(20);not null"`
+	Delta          int        `gorm:"not null"`
+	QuantityBefore int        `gorm:"not null"`
+	QuantityAfter  int        `gorm:"not null"`
+	Reference      string     `gorm:"size:255"`
+	CreatedAt      time.Time  `gorm:"index:idx_stock_movements_product_created,priority:2"`
+}
+
+type AdminAlert struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Rule       string     `gorm:"type:varchar(50);not null;index"`
+	ActorID    *uuid.UUID `gorm:"type:uuid;index"`
+	AuditLogID uuid.UUID  `gorm:"type:uuid;not null"`
+	Message    string     `gorm:"type:text;not null"`
+	CreatedAt  time.Time  `gorm:"index"`
+}
+
+type OrderRemediation struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	OrderID         uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Action          string     `gorm:"type:varchar(30);not null"`
+	Reason          string     `gorm:"type:varchar(30);not null"`
+	Amount          float64    `gorm:"type:decimal(10,2);not null"`
+	OrderItemID     *uuid.UUID `gorm:"type:uuid"`
+	Quantity        int        `gorm:"not null;default:0"`
+	Note            string     `gorm:"type:text"`
+	PerformedBy     uuid.UUID  `gorm:"type:uuid;not null;index:idx_order_remediations_actor_created,priority:1"`
+	PerformedByRole string     `gorm:"type:varchar(50);not null"`
+	CreatedAt       time.Time  `gorm:"index:idx_order_remediations_actor_created,priority:2"`
+}
+
+type AttributeDefinition struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Code      string    `gorm:"type:varchar(120);uniqueIndex;not null"`
+	Name      string    `gorm:"type:varchar(100);not null"`
+	Type      string    `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type ProductAttribute struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProductID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_attribute"`
+	AttributeID  uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_product_attribute;index"`
+	TextValue    *string   `gorm:"type:varchar(255)"`
+	NumberValue  *float64  `gorm:"type:decimal(12,3)"`
+	BooleanValue *bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+
+	Attribute *AttributeDefinition `gorm:"foreignKey:AttributeID;constraint:OnDelete:CASCADE"`
+}
+
+type EmailTemplate struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Key        string     `gorm:"type:varchar(100);not null;uniqueIndex:idx_email_template_version"`
+	Version    int        `gorm:"not null;uniqueIndex:idx_email_template_version"`
+	Subject    string     `gorm:"type:varchar(255);not null"`
+	Body       string     `gorm:"type:text;not null"`
+	SampleData string     `gorm:"type:text"`
+	CreatedBy  *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt  time.Time
+}
+
+type CatalogReport struct {
+	ID          uuid.UUID                `gorm:"type:uuid;primaryKey"`
+	Trigger     string                   `gorm:"type:varchar(20);not null"`
+	TriggeredBy *uuid.UUID               `gorm:"type:uuid"`
+	Critical    int                      `gorm:"not null;default:0"`
+	Warnings    int                      `gorm:"not null;default:0"`
+	Info        int                      `gorm:"not null;default:0"`
+	Issues      []map[string]interface{} `gorm:"type:jsonb;serializer:json"`
+	CreatedAt   time.Time                `gorm:"index"`
+}
+
+type Recall struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Title       string     `gorm:"type:varchar(200);not null"`
+	Description string     `gorm:"type:text;not null"`
+	ProductID   uuid.UUID  `gorm:"type:uuid;not null;index"`
+	VariantID   *uuid.UUID `gorm:"type:uuid"`
+	SKU         string     `gorm:"type:varchar(64)"`
+	Lot         string     `gorm:"type:varchar(100)"`
+	OrderedFrom time.Time  `gorm:"not null"`
+	OrderedTo   time.Time  `gorm:"not null"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null"`
+	CreatedAt   time.Time  `gorm:"index"`
+
+	Notices []RecallNotice `gorm:"foreignKey:RecallID;constraint:OnDelete:CASCADE"`
+}
+
+type RecallNotice struct {
+	ID             uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	RecallID       uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_recall_notice_order"`
+	OrderID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_recall_notice_order"`
+	UserID         *uuid.UUID `gorm:"type:uuid;index"`
+	CustomerID     int        `gorm:"not null"`
+	Quantity       int        `gorm:"not null"`
+	Status         string     `gorm:"type:varchar(20);not null"`
+	Error          string     `gorm:"size:255"`
+	SentAt         *time.Time
+	AcknowledgedAt *time.Time
+	CreatedAt      time.Time
+
+	Recall *Recall `gorm:"foreignKey:RecallID"`
+}
+
+type RankingRule struct {
+	ID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Type      string     `gorm:"size:20;not null;index"`
+	Weight    float64    `gorm:"type:decimal(10,2);not null"`
+	Query     string     `gorm:"size:255;index"`
+	ProductID *uuid.UUID `gorm:"type:uuid"`
+	Position  int        `gorm:"not null"`
+	Active    bool       `gorm:"not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (RankingRule) TableName() string {
+	return "search_ranking_rules"
+}
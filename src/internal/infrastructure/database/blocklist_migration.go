@@ -0,0 +1,33 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// blocklistUp creates the table of blocked emails, email domains and IP
+// ranges, one row per kind and value. Like returnsUp it is written in Go for
+// its timestamp column.
+func blocklistUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS blocklist_entries (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    value VARCHAR(255) NOT NULL,
+    reason VARCHAR(255),
+    created_by UUID,
+    created_at {timestamp}
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_blocklist_entries_kind_value ON blocklist_entries (kind, value);
+`, "{timestamp}", timestamp)).Error
+}
+
+func blocklistDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS blocklist_entries;`).Error
+}
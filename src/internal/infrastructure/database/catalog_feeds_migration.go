@@ -0,0 +1,30 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// catalogFeedsUp creates the table the rendered product feeds are kept in,
+// one row per format. Like returnsUp it is written in Go for its timestamp
+// column.
+func catalogFeedsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS catalog_feeds (
+    format VARCHAR(10) PRIMARY KEY,
+    content TEXT NOT NULL,
+    items INTEGER NOT NULL,
+    generated_at {timestamp} NOT NULL
+);
+`, "{timestamp}", timestamp)).Error
+}
+
+func catalogFeedsDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS catalog_feeds;`).Error
+}
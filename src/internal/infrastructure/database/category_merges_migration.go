@@ -0,0 +1,19 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// categoryMergesUp records which category a deleted one was merged into, so
+// the slugs of merged categories redirect to the category that took them in
+func categoryMergesUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&entity.Category{}, "MergedIntoID"); err != nil {
+		return err
+	}
+	return tx.Migrator().CreateIndex(&entity.Category{}, "MergedIntoID")
+}
+
+func categoryMergesDown(tx *gorm.DB) error {
+	return tx.Migrator().DropColumn(&entity.Category{}, "MergedIntoID")
+}
@@ -0,0 +1,30 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// customerPhoneVerificationFields track whether a customer's phone takes text messages
+var customerPhoneVerificationFields = []string{"PhoneVerifiedAt", "PhoneCodeHash", "PhoneCodeSentAt", "PhoneCodeAttempts"}
+
+// customerPhoneVerificationUp adds phone verification to customers. The
+// phones saved before are unverified, so nobody is texted until they
+// confirm a code.
+func customerPhoneVerificationUp(tx *gorm.DB) error {
+	for _, field := range customerPhoneVerificationFields {
+		if err := tx.Migrator().AddColumn(&entity.Customer{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func customerPhoneVerificationDown(tx *gorm.DB) error {
+	for _, field := range customerPhoneVerificationFields {
+		if err := tx.Migrator().DropColumn(&entity.Customer{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
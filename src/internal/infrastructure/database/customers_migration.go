@@ -0,0 +1,53 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// customersUp creates the customer data split from the login on users:
+// contact details, marketing consent and addresses. It is written in Go for
+// its timestamp columns, which need a type per dialect: the SQLite driver only
+// reads back as times the columns declared DATETIME, TIMESTAMP or DATE, and
+// TIMESTAMP alone loses the time zone on Postgres.
+func customersUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS customers (
+    user_id UUID PRIMARY KEY,
+    phone VARCHAR(32),
+    marketing_email BOOLEAN NOT NULL DEFAULT FALSE,
+    marketing_sms BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE TABLE IF NOT EXISTS customer_addresses (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    label VARCHAR(50),
+    recipient VARCHAR(100) NOT NULL,
+    line1 VARCHAR(200) NOT NULL,
+    line2 VARCHAR(200),
+    city VARCHAR(100) NOT NULL,
+    region VARCHAR(100),
+    postal_code VARCHAR(20) NOT NULL,
+    country VARCHAR(2) NOT NULL,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at {timestamp},
+    CONSTRAINT fk_customers_addresses FOREIGN KEY (user_id) REFERENCES customers (user_id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_customer_addresses_user_id ON customer_addresses (user_id);
+`, "{timestamp}", timestamp)).Error
+}
+
+func customersDown(tx *gorm.DB) error {
+	return tx.Exec(`
+DROP TABLE IF EXISTS customer_addresses;
+DROP TABLE IF EXISTS customers;
+`).Error
+}
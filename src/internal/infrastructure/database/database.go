@@ -2,33 +2,274 @@ package database
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 
 	"github.com/marcofilho/go-ecommerce/src/internal/config"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database/baseline"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
+// Connect opens the database of cfg. Every command connects before creating
+// anything, so it also sets up how the IDs of new rows are made.
 func Connect(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	generator, err := entity.NewIDGenerator(entity.IDStrategy(cfg.IDStrategy))
+	if err != nil {
+		return nil, err
+	}
+	entity.UseIDGenerator(generator)
+
+	switch cfg.Driver {
+	case config.DriverSQLite:
+		return connectSQLite(cfg)
+	case config.DriverPostgres, "":
+	default:
+		return nil, fmt.Errorf("Unknown database driver %q", cfg.Driver)
+	}
+
 	db, err := gorm.Open(postgres.Open(cfg.DSN()), &gorm.Config{})
 	if err != nil {
 		return nil, fmt.Errorf("Failed to connect to database: %w", err)
 	}
+	if err := configurePool(db, cfg); err != nil {
+		return nil, err
+	}
+
+	if cfg.ReplicaDSN != "" {
+		replica, err := gorm.Open(postgres.Open(cfg.ReplicaConnectionString()), &gorm.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to connect to read replica: %w", err)
+		}
+		if err := configurePool(replica, cfg); err != nil {
+			return nil, err
+		}
+		if err := UseReplica(db, replica.ConnPool); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.QueryTimeoutSeconds > 0 {
+		if err := UseQueryTimeout(db, time.Duration(cfg.QueryTimeoutSeconds)*time.Second); err != nil {
+			return nil, err
+		}
+	}
+	if err := UseTimeoutErrors(db); err != nil {
+		return nil, err
+	}
+	if err := UseUTC(db); err != nil {
+		return nil, err
+	}
 	return db, nil
 }
 
-func Migrate(db *gorm.DB) error {
-	// AutoMigrate creates tables and indexes
-	// Order matters: tables with foreign keys must come after their references
-	return db.AutoMigrate(
-		&entity.User{},            // No dependencies
-		&entity.Category{},        // No dependencies
-		&entity.Product{},         // No dependencies
-		&entity.ProductVariant{},  // Foreign key to Product
-		&entity.ProductCategory{}, // Foreign key to Product and Category (junction table)
-		&entity.Order{},           // Foreign key to User (CustomerID)
-		&entity.OrderItem{},       // Foreign key to Order and Product
-		&entity.WebhookLog{},      // Foreign key to Order
-		&entity.AuditLog{},        // Audit logging for all entities
-	)
+// connectSQLite opens the database file of cfg, creating it when missing.
+// Foreign keys are enforced as on Postgres, and transactions take the write
+// lock when they begin, so concurrent requests wait for each other instead of
+// failing halfway with "database is locked". The pool and the read replica
+// settings don't apply.
+func connectSQLite(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	dsn := "file:" + cfg.SQLitePath + "?_foreign_keys=on&_busy_timeout=5000&_journal_mode=WAL&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open SQLite database: %w", err)
+	}
+
+	if cfg.QueryTimeoutSeconds > 0 {
+		if err := UseQueryTimeout(db, time.Duration(cfg.QueryTimeoutSeconds)*time.Second); err != nil {
+			return nil, err
+		}
+	}
+	if err := UseTimeoutErrors(db); err != nil {
+		return nil, err
+	}
+	if err := UseUTC(db); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// IsSQLite tells whether db runs on SQLite rather than Postgres
+func IsSQLite(db *gorm.DB) bool {
+	return db.Dialector.Name() == "sqlite"
+}
+
+// configurePool applies the connection pool settings to the pool of db
+func configurePool(db *gorm.DB, cfg *config.DatabaseConfig) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(time.Duration(cfg.ConnMaxLifetimeMinutes) * time.Minute)
+	sqlDB.SetConnMaxIdleTime(time.Duration(cfg.ConnMaxIdleMinutes) * time.Minute)
+	return nil
+}
+
+// baselineUp builds the schema that existed before versioned migrations, the
+// way AutoMigrate always did, so databases created by earlier releases adopt
+// version 1 without changes. It builds it from the frozen models of the
+// baseline package, which must stay as they are: schema changes go into new
+// migrations.
+func baselineUp(db *gorm.DB) error {
+	if err := db.AutoMigrate(baseline.Models...); err != nil {
+		return err
+	}
+
+	if err := backfillCategorySlugs(db); err != nil {
+		return err
+	}
+
+	if err := migrateLegacyVariants(db); err != nil {
+		return err
+	}
+
+	return backfillOrderItemComponents(db)
+}
+
+// baselineDown drops every table of the baseline, dependents first
+func baselineDown(db *gorm.DB) error {
+	for i := len(baseline.Models) - 1; i >= 0; i-- {
+		if err := db.Migrator().DropTable(baseline.Models[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillCategorySlugs gives categories created before slugs existed a slug
+func backfillCategorySlugs(db *gorm.DB) error {
+	var categories []*baseline.Category
+	if err := db.Where("slug IS NULL OR slug = ''").Find(&categories).Error; err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		slug, err := entity.UniqueSlug(entity.Slugify(category.Name), func(slug string) (bool, error) {
+			var count int64
+			err := db.Unscoped().Model(&baseline.Category{}).Where("slug = ?", slug).Count(&count).Error
+			return count > 0, err
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := db.Model(category).Update("slug", slug).Error; err != nil {
+			return fmt.Errorf("Failed to backfill slug for category %s: %w", category.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// backfillOrderItemComponents gives order items created before lines were broken
+// down into components a base component holding their total
+func backfillOrderItemComponents(db *gorm.DB) error {
+	var items []baseline.OrderItem
+	return db.Where("NOT EXISTS (SELECT 1 FROM order_item_components c WHERE c.order_item_id = order_items.id)").
+		FindInBatches(&items, 500, func(_ *gorm.DB, _ int) error {
+			components := make([]baseline.OrderItemComponent, 0, len(items))
+			for _, item := range items {
+				components = append(components, baseline.OrderItemComponent{
+					ID:          entity.NewID(),
+					OrderItemID: item.ID,
+					Type:        string(entity.ComponentBase),
+					Amount:      item.TotalPrice,
+				})
+			}
+			return db.Create(&components).Error
+		}).Error
+}
+
+// legacyVariant is a row of product_variants from before variants were
+// combinations of options, when each variant had a single name and value
+type legacyVariant struct {
+	ID           uuid.UUID
+	ProductID    uuid.UUID
+	VariantName  string
+	VariantValue string
+}
+
+// migrateLegacyVariants turns the single variant_name/variant_value pair of
+// existing variants into product options, gives them a SKU and drops the old columns
+func migrateLegacyVariants(db *gorm.DB) error {
+	if !db.Migrator().HasColumn(&baseline.ProductVariant{}, "variant_name") {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var variants []legacyVariant
+		if err := tx.Table("product_variants").Select("id, product_id, variant_name, variant_value").Scan(&variants).Error; err != nil {
+			return err
+		}
+
+		options := make(map[string]*baseline.ProductOption)
+		for _, variant := range variants {
+			key := variant.ProductID.String() + "/" + strings.ToLower(variant.VariantName)
+			option, ok := options[key]
+			if !ok {
+				option = &baseline.ProductOption{ProductID: variant.ProductID, Name: variant.VariantName}
+				err := tx.Where("product_id = ? AND name = ?", option.ProductID, option.Name).
+					Attrs(baseline.ProductOption{ID: entity.NewID()}).FirstOrCreate(option).Error
+				if err != nil {
+					return fmt.Errorf("Failed to create option %q for product %s: %w", variant.VariantName, variant.ProductID, err)
+				}
+				options[key] = option
+			}
+
+			value := findLegacyValue(option, variant.VariantValue)
+			if value == nil {
+				option.Values = append(option.Values, baseline.ProductOptionValue{ID: entity.NewID(), OptionID: option.ID, Value: variant.VariantValue, Position: len(option.Values)})
+				value = &option.Values[len(option.Values)-1]
+				if err := tx.Create(value).Error; err != nil {
+					return err
+				}
+			}
+
+			variantOption := baseline.VariantOption{ID: entity.NewID(), VariantID: variant.ID, OptionID: option.ID, Name: option.Name, Value: value.Value}
+			if err := tx.Create(&variantOption).Error; err != nil {
+				return err
+			}
+
+			variantOptions := []entity.VariantOption{{Name: variantOption.Name, Value: variantOption.Value}}
+			sku := entity.GenerateSKU(variant.ProductID, variantOptions)
+			var taken int64
+			if err := tx.Unscoped().Model(&baseline.ProductVariant{}).Where("sku = ?", sku).Count(&taken).Error; err != nil {
+				return err
+			}
+			if taken > 0 {
+				// Legacy data may hold duplicate name/value pairs
+				sku = entity.GenerateSKU(variant.ID, variantOptions)
+			}
+
+			err := tx.Table("product_variants").Where("id = ?", variant.ID).Updates(map[string]interface{}{
+				"sku":             sku,
+				"combination_key": entity.CombinationKey(variantOptions),
+			}).Error
+			if err != nil {
+				return fmt.Errorf("Failed to migrate variant %s: %w", variant.ID, err)
+			}
+		}
+
+		if err := tx.Migrator().DropColumn(&baseline.ProductVariant{}, "variant_name"); err != nil {
+			return err
+		}
+		return tx.Migrator().DropColumn(&baseline.ProductVariant{}, "variant_value")
+	})
+}
+
+// findLegacyValue returns the value of the option matching value regardless
+// of case, as ProductOption.FindValue does, or nil
+func findLegacyValue(option *baseline.ProductOption, value string) *baseline.ProductOptionValue {
+	for i := range option.Values {
+		if strings.EqualFold(option.Values[i].Value, strings.TrimSpace(value)) {
+			return &option.Values[i]
+		}
+	}
+	return nil
 }
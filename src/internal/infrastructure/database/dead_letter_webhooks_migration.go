@@ -0,0 +1,40 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// deadLetterWebhooksUp creates the table of payment webhooks that were
+// signed but couldn't be processed, one row per source and body. Like
+// blocklistUp it is written in Go for its timestamp columns.
+func deadLetterWebhooksUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS dead_letter_webhooks (
+    id UUID PRIMARY KEY,
+    source VARCHAR(50) NOT NULL,
+    payload_hash VARCHAR(64) NOT NULL,
+    raw_payload TEXT NOT NULL,
+    reason TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    resolved_by UUID,
+    resolved_at {timestamp},
+    created_at {timestamp},
+    last_failed_at {timestamp} NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dead_letter_webhooks_payload ON dead_letter_webhooks (source, payload_hash);
+CREATE INDEX IF NOT EXISTS idx_dead_letter_webhooks_status ON dead_letter_webhooks (status);
+CREATE INDEX IF NOT EXISTS idx_dead_letter_webhooks_last_failed_at ON dead_letter_webhooks (last_failed_at);
+`, "{timestamp}", timestamp)).Error
+}
+
+func deadLetterWebhooksDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS dead_letter_webhooks;`).Error
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// draftOrdersUp creates the draft orders admins compose for customers and
+// their items. Like returnsUp it is written in Go for its timestamp columns.
+func draftOrdersUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS draft_orders (
+    id UUID PRIMARY KEY,
+    customer_id INTEGER NOT NULL,
+    user_id UUID,
+    status VARCHAR(20) NOT NULL DEFAULT 'open',
+    note TEXT,
+    token VARCHAR(64) NOT NULL,
+    link_expires_at {timestamp},
+    sent_at {timestamp},
+    order_id UUID,
+    converted_at {timestamp},
+    created_by UUID,
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_draft_orders_token ON draft_orders (token);
+CREATE INDEX IF NOT EXISTS idx_draft_orders_user_id ON draft_orders (user_id);
+CREATE INDEX IF NOT EXISTS idx_draft_orders_status ON draft_orders (status);
+CREATE TABLE IF NOT EXISTS draft_order_items (
+    id UUID PRIMARY KEY,
+    draft_order_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    variant_id UUID,
+    quantity INTEGER NOT NULL,
+    CONSTRAINT fk_draft_orders_items FOREIGN KEY (draft_order_id) REFERENCES draft_orders (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_draft_order_items_draft_order_id ON draft_order_items (draft_order_id);
+`, "{timestamp}", timestamp)).Error
+}
+
+func draftOrdersDown(tx *gorm.DB) error {
+	return tx.Exec(`
+DROP TABLE IF EXISTS draft_order_items;
+DROP TABLE IF EXISTS draft_orders;
+`).Error
+}
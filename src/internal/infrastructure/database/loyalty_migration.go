@@ -0,0 +1,35 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// loyaltyUp creates the loyalty points ledger. Like returnsUp it is written
+// in Go for its timestamp column. Entries go with their user, and reference
+// orders without a foreign key, archiving moves the orders to another table.
+func loyaltyUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS loyalty_transactions (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    order_id UUID,
+    type VARCHAR(20) NOT NULL,
+    points INTEGER NOT NULL,
+    created_at {timestamp},
+    CONSTRAINT fk_users_loyalty_transactions FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_loyalty_transactions_user_id ON loyalty_transactions (user_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_loyalty_transactions_order_type ON loyalty_transactions (order_id, type);
+`, "{timestamp}", timestamp)).Error
+}
+
+func loyaltyDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS loyalty_transactions;`).Error
+}
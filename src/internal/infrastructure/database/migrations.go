@@ -0,0 +1,388 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// MigrationsDir is where new migration files are created, relative to the
+// repository root
+const MigrationsDir = "src/internal/infrastructure/database/migrations"
+
+// ErrSchemaBehind is returned when the database misses migrations this build
+// ships with
+var ErrSchemaBehind = errors.New("database schema is behind, run the pending migrations first")
+
+// ErrSchemaDirty is returned when a migration failed halfway and the schema
+// needs fixing by hand
+var ErrSchemaDirty = errors.New("database schema is dirty, fix the failed migration and force its version")
+
+// Migration is one versioned step of the schema. Migrations are either SQL
+// files named <version>_<name>.up.sql and <version>_<name>.down.sql, the layout
+// golang-migrate uses, or Go functions for changes SQL can't express.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+// goMigrations are the migrations written in Go
+var goMigrations = []Migration{
+	{Version: 1, Name: "baseline", Up: baselineUp, Down: baselineDown},
+	{Version: 4, Name: "customers", Up: customersUp, Down: customersDown},
+	{Version: 5, Name: "price_changes", Up: priceChangesUp, Down: priceChangesDown},
+	{Version: 6, Name: "price_tiers", Up: priceTiersUp, Down: priceTiersDown},
+	{Version: 7, Name: "returns", Up: returnsUp, Down: returnsDown},
+	{Version: 8, Name: "order_payments", Up: orderPaymentsUp, Down: orderPaymentsDown},
+	{Version: 9, Name: "webhook_subscriptions", Up: webhookSubscriptionsUp, Down: webhookSubscriptionsDown},
+	{Version: 10, Name: "loyalty", Up: loyaltyUp, Down: loyaltyDown},
+	{Version: 11, Name: "catalog_feeds", Up: catalogFeedsUp, Down: catalogFeedsDown},
+	{Version: 12, Name: "product_translations", Up: productTranslationsUp, Down: productTranslationsDown},
+	{Version: 13, Name: "product_status", Up: productStatusUp, Down: productStatusDown},
+	{Version: 14, Name: "product_availability", Up: productAvailabilityUp, Down: productAvailabilityDown},
+	{Version: 15, Name: "product_purchase_limits", Up: productPurchaseLimitsUp, Down: productPurchaseLimitsDown},
+	{Version: 16, Name: "blocklist", Up: blocklistUp, Down: blocklistDown},
+	{Version: 17, Name: "draft_orders", Up: draftOrdersUp, Down: draftOrdersDown},
+	{Version: 19, Name: "audit_impersonators", Up: auditImpersonatorsUp, Down: auditImpersonatorsDown},
+	{Version: 20, Name: "store_settings", Up: storeSettingsUp, Down: storeSettingsDown},
+	{Version: 21, Name: "access_codes", Up: accessCodesUp, Down: accessCodesDown},
+	{Version: 22, Name: "category_merges", Up: categoryMergesUp, Down: categoryMergesDown},
+	{Version: 23, Name: "order_item_snapshots", Up: orderItemSnapshotsUp, Down: orderItemSnapshotsDown},
+	{Version: 25, Name: "dead_letter_webhooks", Up: deadLetterWebhooksUp, Down: deadLetterWebhooksDown},
+	{Version: 26, Name: "webhook_nonces", Up: webhookNoncesUp, Down: webhookNoncesDown},
+	{Version: 27, Name: "customer_phone_verification", Up: customerPhoneVerificationUp, Down: customerPhoneVerificationDown},
+	{Version: 28, Name: "product_reviews", Up: productReviewsUp, Down: productReviewsDown},
+	{Version: 29, Name: "order_credits", Up: orderCreditsUp, Down: orderCreditsDown},
+}
+
+// MigrationStatus tells whether a migration has been applied
+type MigrationStatus struct {
+	Version int64
+	Name    string
+	Applied bool
+}
+
+// Migrator applies migrations in order and records the schema version in the
+// schema_migrations table, which keeps the single version and dirty row of
+// golang-migrate. Every migration runs in its own transaction together with
+// the version update, under an advisory lock, so concurrent runs apply each
+// migration once.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator creates a migrator for the migrations this build ships with
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations", goMigrations)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+var (
+	migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+	nonSlugChars      = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// loadMigrations reads the SQL migrations of dir and merges them with the Go
+// ones, sorted by version. Every version must be unique and have both an up
+// and a down step.
+func loadMigrations(fsys fs.FS, dir string, extra []Migration) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, m := range extra {
+		m := m
+		byVersion[m.Version] = &m
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %q, expected <version>_<name>.up.sql or .down.sql", entry.Name())
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid migration version in %q", entry.Name())
+		}
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %q and %q", version, m.Name, match[2])
+		}
+
+		step := sqlStep(string(content))
+		if match[3] == "up" {
+			if m.Up != nil {
+				return nil, fmt.Errorf("migration %d has more than one up step", version)
+			}
+			m.Up = step
+		} else {
+			if m.Down != nil {
+				return nil, fmt.Errorf("migration %d has more than one down step", version)
+			}
+			m.Down = step
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil || m.Down == nil {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down step", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// sqlStep runs a whole SQL file. Without arguments the statements go through
+// the simple protocol, so a file may hold several of them.
+func sqlStep(sql string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(sql).Error
+	}
+}
+
+// Latest returns the version of the newest migration
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version returns the version the database is at, 0 when no migration ran yet
+func (m *Migrator) Version(ctx context.Context) (version int64, dirty bool, err error) {
+	db := m.db.WithContext(ctx)
+	if !db.Migrator().HasTable("schema_migrations") {
+		return 0, false, nil
+	}
+	return readVersion(db)
+}
+
+func readVersion(db *gorm.DB) (version int64, dirty bool, err error) {
+	var row struct {
+		Version int64
+		Dirty   bool
+	}
+	result := db.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&row)
+	if result.Error != nil {
+		return 0, false, result.Error
+	}
+	return row.Version, row.Dirty, nil
+}
+
+// Status lists every migration and whether the database has it
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	current, _, err := m.Version(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		statuses = append(statuses, MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+			Applied: migration.Version <= current,
+		})
+	}
+	return statuses, nil
+}
+
+// Check returns ErrSchemaBehind when migrations are pending and ErrSchemaDirty
+// when the last one failed. A database ahead of this build passes, so an older
+// release keeps running while a newer one rolls out.
+func (m *Migrator) Check(ctx context.Context) error {
+	current, dirty, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w (version %d)", ErrSchemaDirty, current)
+	}
+	if current < m.Latest() {
+		return fmt.Errorf("%w: at version %d, expected %d", ErrSchemaBehind, current, m.Latest())
+	}
+	return nil
+}
+
+// CheckSchema fails unless the database has every migration of this build.
+// Binaries call it on startup instead of migrating themselves.
+func CheckSchema(ctx context.Context, db *gorm.DB) error {
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	return migrator.Check(ctx)
+}
+
+// Up applies every pending migration and returns the ones it applied
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var applied []Migration
+	for _, migration := range m.migrations {
+		ran := false
+		err := m.step(ctx, func(tx *gorm.DB, current int64) (int64, error) {
+			if migration.Version <= current {
+				return current, nil
+			}
+			if err := migration.Up(tx); err != nil {
+				return 0, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			ran = true
+			return migration.Version, nil
+		})
+		if err != nil {
+			return applied, err
+		}
+		if ran {
+			applied = append(applied, migration)
+		}
+	}
+	return applied, nil
+}
+
+// Down reverts the last steps migrations and returns the ones it reverted,
+// newest first
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	if err := m.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var reverted []Migration
+	for i := 0; i < steps; i++ {
+		var migration *Migration
+		err := m.step(ctx, func(tx *gorm.DB, current int64) (int64, error) {
+			if current == 0 {
+				return 0, nil
+			}
+			index := sort.Search(len(m.migrations), func(j int) bool { return m.migrations[j].Version >= current })
+			if index == len(m.migrations) || m.migrations[index].Version != current {
+				return 0, fmt.Errorf("database is at version %d, which this build doesn't know", current)
+			}
+
+			migration = &m.migrations[index]
+			if err := migration.Down(tx); err != nil {
+				return 0, fmt.Errorf("reverting migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if index == 0 {
+				return 0, nil
+			}
+			return m.migrations[index-1].Version, nil
+		})
+		if err != nil {
+			return reverted, err
+		}
+		if migration == nil {
+			break
+		}
+		reverted = append(reverted, *migration)
+	}
+	return reverted, nil
+}
+
+func (m *Migrator) ensureTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec("CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)").Error
+}
+
+// step runs fn in a transaction holding the migration lock and stores the
+// version it returns. A dirty schema is refused.
+func (m *Migrator) step(ctx context.Context, fn func(tx *gorm.DB, current int64) (int64, error)) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// SQLite transactions lock the whole database already
+		if !IsSQLite(tx) {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('schema_migrations'))").Error; err != nil {
+				return err
+			}
+		}
+
+		current, dirty, err := readVersion(tx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("%w (version %d)", ErrSchemaDirty, current)
+		}
+
+		next, err := fn(tx, current)
+		if err != nil || next == current {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+			return err
+		}
+		if next == 0 {
+			return nil
+		}
+		return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, false)", next).Error
+	})
+}
+
+// CreateMigration writes empty up and down files for a new migration in dir,
+// numbered after the newest one there, and returns their paths
+func CreateMigration(dir, name string) (up, down string, err error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = nonSlugChars.ReplaceAllString(name, "_")
+	name = strings.Trim(name, "_")
+	if name == "" {
+		return "", "", errors.New("migration name is required")
+	}
+
+	migrations, err := loadMigrations(os.DirFS(dir), ".", goMigrations)
+	if err != nil {
+		return "", "", err
+	}
+	version := int64(1)
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+	up, down = base+".up.sql", base+".down.sql"
+	if err := os.WriteFile(up, []byte("-- Write the schema change here\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	if err := os.WriteFile(down, []byte("-- Write the statements undoing the up migration here\n"), 0o644); err != nil {
+		return "", "", err
+	}
+	return up, down, nil
+}
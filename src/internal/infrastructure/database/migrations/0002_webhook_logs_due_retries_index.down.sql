@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_webhook_logs_due_retries;
@@ -0,0 +1,2 @@
+-- The webhook retry job looks up failed webhooks whose retry is due
+CREATE INDEX IF NOT EXISTS idx_webhook_logs_due_retries ON webhook_logs (next_retry_at) WHERE status = 'failed';
@@ -0,0 +1,13 @@
+-- Access tokens revoked before they expire, checked on every authenticated request
+CREATE TABLE IF NOT EXISTS token_revocations (
+    id UUID PRIMARY KEY,
+    token_id VARCHAR(64),
+    user_id UUID NOT NULL,
+    reason VARCHAR(20) NOT NULL,
+    revoked_by UUID,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_token_revocations_token_id ON token_revocations (token_id);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_user_id ON token_revocations (user_id);
+CREATE INDEX IF NOT EXISTS idx_token_revocations_expires_at ON token_revocations (expires_at);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_products_deleted_at_quantity;
+DROP INDEX IF EXISTS idx_product_variants_product_id_deleted_at;
+DROP INDEX IF EXISTS idx_order_items_product_id;
+DROP INDEX IF EXISTS idx_orders_status_payment_status_created_at;
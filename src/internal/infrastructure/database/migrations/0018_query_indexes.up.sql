@@ -0,0 +1,9 @@
+-- Order listings and sales reports filter by status and payment status, then by date
+CREATE INDEX IF NOT EXISTS idx_orders_status_payment_status_created_at ON orders (status, payment_status, created_at);
+-- Purchase limits sum what a customer bought of a product
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items (product_id);
+-- Variant listings of a product skip the deleted ones
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id_deleted_at ON product_variants (product_id, deleted_at);
+-- Listings of products in stock. Every product query skips the deleted ones,
+-- so the quantity follows deleted_at for both to narrow the search.
+CREATE INDEX IF NOT EXISTS idx_products_deleted_at_quantity ON products (deleted_at, quantity);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_order_items_sku;
+DROP INDEX IF EXISTS idx_orders_total_price;
+DROP INDEX IF EXISTS idx_orders_created_at;
@@ -0,0 +1,5 @@
+-- Order searches filter on any of these on their own, sorted by date or total
+CREATE INDEX IF NOT EXISTS idx_orders_created_at ON orders (created_at);
+CREATE INDEX IF NOT EXISTS idx_orders_total_price ON orders (total_price);
+-- Searches by SKU find the orders through their lines
+CREATE INDEX IF NOT EXISTS idx_order_items_sku ON order_items (sku);
@@ -0,0 +1,13 @@
+-- A user holds one active place in the queue of a product. Older duplicates
+-- left by concurrent joins are expired first, keeping the newest entry.
+UPDATE purchase_queue_entries SET status = 'expired'
+WHERE status IN ('waiting', 'admitted') AND EXISTS (
+    SELECT 1 FROM purchase_queue_entries newer
+    WHERE newer.product_id = purchase_queue_entries.product_id
+      AND newer.user_id = purchase_queue_entries.user_id
+      AND newer.status IN ('waiting', 'admitted')
+      AND (newer.created_at > purchase_queue_entries.created_at
+           OR (newer.created_at = purchase_queue_entries.created_at AND newer.id > purchase_queue_entries.id))
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_queue_active_entry ON purchase_queue_entries (product_id, user_id)
+WHERE status IN ('waiting', 'admitted');
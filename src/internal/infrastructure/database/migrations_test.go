@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func noop(tx *gorm.DB) error { return nil }
+
+func TestLoadMigrations(t *testing.T) {
+	baseline := []Migration{{Version: 1, Name: "baseline", Up: noop, Down: noop}}
+
+	t.Run("Merges SQL and Go migrations sorted by version", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/0010_add_index.up.sql":      {Data: []byte("CREATE INDEX a ON b (c);")},
+			"migrations/0010_add_index.down.sql":    {Data: []byte("DROP INDEX a;")},
+			"migrations/0002_create_table.up.sql":   {Data: []byte("CREATE TABLE b (c INT);")},
+			"migrations/0002_create_table.down.sql": {Data: []byte("DROP TABLE b;")},
+			"migrations/README.md":                  {Data: []byte("notes")},
+		}
+
+		migrations, err := loadMigrations(fsys, "migrations", baseline)
+
+		require.NoError(t, err)
+		require.Len(t, migrations, 3)
+		assert.Equal(t, int64(1), migrations[0].Version)
+		assert.Equal(t, int64(2), migrations[1].Version)
+		assert.Equal(t, "create_table", migrations[1].Name)
+		assert.Equal(t, int64(10), migrations[2].Version)
+		assert.NotNil(t, migrations[2].Up)
+		assert.NotNil(t, migrations[2].Down)
+	})
+
+	t.Run("Requires both steps", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/0002_create_table.up.sql": {Data: []byte("CREATE TABLE b (c INT);")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations", baseline)
+
+		assert.ErrorContains(t, err, "needs both an up and a down step")
+	})
+
+	t.Run("Rejects a version used twice", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/0001_other.up.sql":   {Data: []byte("SELECT 1;")},
+			"migrations/0001_other.down.sql": {Data: []byte("SELECT 1;")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations", baseline)
+
+		assert.ErrorContains(t, err, "is named both")
+	})
+
+	t.Run("Rejects badly named files", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"migrations/add_index.sql": {Data: []byte("SELECT 1;")},
+		}
+
+		_, err := loadMigrations(fsys, "migrations", baseline)
+
+		assert.ErrorContains(t, err, "invalid migration file name")
+	})
+
+	t.Run("Ships a valid set", func(t *testing.T) {
+		migrator, err := NewMigrator(nil)
+
+		require.NoError(t, err)
+		assert.Greater(t, migrator.Latest(), int64(1))
+	})
+}
+
+func TestCreateMigration(t *testing.T) {
+	var latest int64
+	for _, m := range goMigrations {
+		latest = max(latest, m.Version)
+	}
+	name := func(dir string, version int64, suffix string) string {
+		return filepath.Join(dir, fmt.Sprintf("%04d_%s", version, suffix))
+	}
+
+	t.Run("Numbers past the Go migrations", func(t *testing.T) {
+		dir := t.TempDir()
+
+		up, down, err := CreateMigration(dir, "Add Orders Index")
+
+		require.NoError(t, err)
+		assert.Equal(t, name(dir, latest+1, "add_orders_index.up.sql"), up)
+		assert.Equal(t, name(dir, latest+1, "add_orders_index.down.sql"), down)
+		assert.FileExists(t, up)
+		assert.FileExists(t, down)
+	})
+
+	t.Run("Numbers past the SQL migrations", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(name(dir, latest+1, "create_table.up.sql"), []byte("SELECT 1;"), 0o644))
+		require.NoError(t, os.WriteFile(name(dir, latest+1, "create_table.down.sql"), []byte("SELECT 1;"), 0o644))
+
+		up, _, err := CreateMigration(dir, "Add Orders Index")
+
+		require.NoError(t, err)
+		assert.Equal(t, name(dir, latest+2, "add_orders_index.up.sql"), up)
+	})
+
+	t.Run("Requires a name", func(t *testing.T) {
+		_, _, err := CreateMigration(t.TempDir(), "  ")
+		assert.Error(t, err)
+	})
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	db, err := Connect(&config.DatabaseConfig{Driver: config.DriverSQLite, SQLitePath: filepath.Join(t.TempDir(), "migrations.db")})
+	require.NoError(t, err)
+	migrator, err := NewMigrator(db)
+	require.NoError(t, err)
+
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	// The baseline is frozen, the migrations after it add what the entities
+	// gained since
+	for _, model := range []interface{}{
+		&entity.User{}, &entity.Category{}, &entity.Product{}, &entity.ProductVariant{}, &entity.Order{},
+		&entity.OrderItem{}, &entity.AuditLog{}, &entity.ArchivedAuditLog{}, &entity.Customer{},
+	} {
+		stmt := &gorm.Statement{DB: db}
+		require.NoError(t, stmt.Parse(model))
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName != "" {
+				assert.True(t, db.Migrator().HasColumn(model, field.DBName), "%s.%s", stmt.Schema.Table, field.DBName)
+			}
+		}
+	}
+
+	_, err = migrator.Down(ctx, int(migrator.Latest()))
+	require.NoError(t, err, "every migration can be reverted")
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err, "and applied again")
+}
@@ -0,0 +1,23 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// orderCreditsUp adds the goodwill credited to orders. Remediations paid out before weren't recorded on their order,
+// so they are counted as credited, and returns can't refund them again.
+func orderCreditsUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&entity.Order{}, "AmountCredited"); err != nil {
+		return err
+	}
+
+	return tx.Exec(`UPDATE orders SET amount_credited = (
+		SELECT COALESCE(SUM(amount), 0) FROM order_remediations
+		WHERE order_remediations.order_id = orders.id AND action IN (?, ?)
+	) WHERE amount_credited = 0`, entity.RemediationRefund, entity.RemediationGoodwillCredit).Error
+}
+
+func orderCreditsDown(tx *gorm.DB) error {
+	return tx.Migrator().DropColumn(&entity.Order{}, "AmountCredited")
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// orderItemSnapshotColumns record what an order line was for at order time
+var orderItemSnapshotColumns = []string{"ProductName", "SKU", "VariantDescription", "TaxRate"}
+
+// orderItemSnapshotsUp adds the product snapshots to order items. Lines
+// ordered before take the name and SKU their product and variant have now,
+// deleted ones included, and the rate their tax component was worked out at.
+// Their variant options weren't kept and stay empty.
+func orderItemSnapshotsUp(tx *gorm.DB) error {
+	for _, column := range orderItemSnapshotColumns {
+		if err := tx.Migrator().AddColumn(&entity.OrderItem{}, column); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Exec(`UPDATE order_items SET product_name = COALESCE(
+    (SELECT name FROM products WHERE products.id = order_items.product_id), '')
+WHERE product_name = ''`).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec(`UPDATE order_items SET sku = COALESCE(
+    (SELECT sku FROM product_variants WHERE product_variants.id = order_items.variant_id), '')
+WHERE sku = '' AND variant_id IS NOT NULL`).Error; err != nil {
+		return err
+	}
+
+	// Tax is charged on the discounted line amount
+	return tx.Exec(`UPDATE order_items SET tax_rate = ROUND(
+    (SELECT SUM(amount) * 1.0 FROM order_item_components c WHERE c.order_item_id = order_items.id AND c.type = 'tax') /
+    (SELECT SUM(amount) FROM order_item_components c WHERE c.order_item_id = order_items.id AND c.type IN ('base', 'discount')), 4)
+WHERE tax_rate = 0 AND EXISTS (
+    SELECT 1 FROM order_item_components c WHERE c.order_item_id = order_items.id AND c.type = 'tax')`).Error
+}
+
+func orderItemSnapshotsDown(tx *gorm.DB) error {
+	for _, column := range orderItemSnapshotColumns {
+		if err := tx.Migrator().DropColumn(&entity.OrderItem{}, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
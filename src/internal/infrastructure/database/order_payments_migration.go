@@ -0,0 +1,30 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// orderPaymentColumns record what was captured and refunded of every order
+var orderPaymentColumns = []string{"AmountPaid", "AmountRefunded"}
+
+// orderPaymentsUp adds the payment amounts to orders. Orders paid before
+// were paid in full.
+func orderPaymentsUp(tx *gorm.DB) error {
+	for _, column := range orderPaymentColumns {
+		if err := tx.Migrator().AddColumn(&entity.Order{}, column); err != nil {
+			return err
+		}
+	}
+
+	return tx.Exec("UPDATE orders SET amount_paid = total_price WHERE payment_status = ? AND amount_paid = 0", entity.Paid).Error
+}
+
+func orderPaymentsDown(tx *gorm.DB) error {
+	for _, column := range orderPaymentColumns {
+		if err := tx.Migrator().DropColumn(&entity.Order{}, column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// priceChangesUp creates the price history, scheduled changes included. Like
+// customersUp it is written in Go for its timestamp columns.
+func priceChangesUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS price_changes (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    variant_id UUID,
+    price DECIMAL(10,2),
+    previous_price DECIMAL(10,2),
+    scheduled BOOLEAN NOT NULL DEFAULT FALSE,
+    effective_from {timestamp} NOT NULL,
+    effective_to {timestamp},
+    cancelled_at {timestamp},
+    changed_by UUID,
+    created_at {timestamp}
+);
+CREATE INDEX IF NOT EXISTS idx_price_changes_product_effective ON price_changes (product_id, effective_from);
+CREATE INDEX IF NOT EXISTS idx_price_changes_variant_id ON price_changes (variant_id);
+`, "{timestamp}", timestamp)).Error
+}
+
+func priceChangesDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS price_changes;`).Error
+}
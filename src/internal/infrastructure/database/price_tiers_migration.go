@@ -0,0 +1,38 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// priceTiersUp creates the price lists of the products and puts every customer
+// in the retail group. Like customersUp it is written in Go for its timestamp
+// column.
+func priceTiersUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS price_tiers (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    variant_id UUID,
+    customer_group VARCHAR(50) NOT NULL DEFAULT 'retail',
+    min_quantity INTEGER NOT NULL DEFAULT 1,
+    price DECIMAL(10,2) NOT NULL,
+    created_at {timestamp}
+);
+CREATE INDEX IF NOT EXISTS idx_price_tiers_product_id ON price_tiers (product_id);
+ALTER TABLE customers ADD COLUMN customer_group VARCHAR(50) NOT NULL DEFAULT 'retail';
+`, "{timestamp}", timestamp)).Error
+}
+
+func priceTiersDown(tx *gorm.DB) error {
+	return tx.Exec(`
+ALTER TABLE customers DROP COLUMN customer_group;
+DROP TABLE IF EXISTS price_tiers;
+`).Error
+}
@@ -0,0 +1,39 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// productAvailabilityFields are the ends of the window a product can be bought in
+var productAvailabilityFields = []string{"AvailableFrom", "AvailableUntil"}
+
+// productAvailabilityUp adds the availability window to products. Both ends
+// start out empty, so the products that existed before stay available.
+func productAvailabilityUp(tx *gorm.DB) error {
+	for _, field := range productAvailabilityFields {
+		if err := tx.Migrator().AddColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+		if err := tx.Migrator().CreateIndex(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// productAvailabilityDown drops the indexes only when they are there: SQLite
+// loses the indexes of products when a later migration drops one of its columns
+func productAvailabilityDown(tx *gorm.DB) error {
+	for _, field := range productAvailabilityFields {
+		if tx.Migrator().HasIndex(&entity.Product{}, field) {
+			if err := tx.Migrator().DropIndex(&entity.Product{}, field); err != nil {
+				return err
+			}
+		}
+		if err := tx.Migrator().DropColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
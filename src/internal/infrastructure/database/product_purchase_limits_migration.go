@@ -0,0 +1,29 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// productPurchaseLimitFields cap the units of a product per order and per customer
+var productPurchaseLimitFields = []string{"MaxPerOrder", "MaxPerCustomer"}
+
+// productPurchaseLimitsUp adds the purchase limits to products. They default
+// to 0, so the products that existed before stay unlimited.
+func productPurchaseLimitsUp(tx *gorm.DB) error {
+	for _, field := range productPurchaseLimitFields {
+		if err := tx.Migrator().AddColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func productPurchaseLimitsDown(tx *gorm.DB) error {
+	for _, field := range productPurchaseLimitFields {
+		if err := tx.Migrator().DropColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// productRatingFields hold the rating of a product's reviews
+var productRatingFields = []string{"RatingAverage", "RatingCount"}
+
+// productReviewsUp creates the table of product reviews, written in Go for
+// its timestamp columns, and adds the rating to products. The products
+// already there have no reviews, so their rating starts at 0.
+func productReviewsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	err := tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS product_reviews (
+    id UUID PRIMARY KEY,
+    product_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    rating INTEGER NOT NULL,
+    body TEXT,
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_product_reviews_product_user ON product_reviews (product_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_user_id ON product_reviews (user_id);
+CREATE INDEX IF NOT EXISTS idx_product_reviews_created_at ON product_reviews (created_at);
+`, "{timestamp}", timestamp)).Error
+	if err != nil {
+		return err
+	}
+
+	for _, field := range productRatingFields {
+		if err := tx.Migrator().AddColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return tx.Exec(`CREATE INDEX IF NOT EXISTS idx_products_rating_average ON products (rating_average);`).Error
+}
+
+func productReviewsDown(tx *gorm.DB) error {
+	if err := tx.Exec(`DROP INDEX IF EXISTS idx_products_rating_average;`).Error; err != nil {
+		return err
+	}
+	for _, field := range productRatingFields {
+		if err := tx.Migrator().DropColumn(&entity.Product{}, field); err != nil {
+			return err
+		}
+	}
+	return tx.Exec(`DROP TABLE IF EXISTS product_reviews;`).Error
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// productStatusUp adds the publishing status to products. Products that
+// existed before were all on sale, so they become active.
+func productStatusUp(tx *gorm.DB) error {
+	if err := tx.Migrator().AddColumn(&entity.Product{}, "Status"); err != nil {
+		return err
+	}
+	return tx.Migrator().CreateIndex(&entity.Product{}, "Status")
+}
+
+// productStatusDown drops the index only when it is there, like
+// productAvailabilityDown
+func productStatusDown(tx *gorm.DB) error {
+	if tx.Migrator().HasIndex(&entity.Product{}, "Status") {
+		if err := tx.Migrator().DropIndex(&entity.Product{}, "Status"); err != nil {
+			return err
+		}
+	}
+	return tx.Migrator().DropColumn(&entity.Product{}, "Status")
+}
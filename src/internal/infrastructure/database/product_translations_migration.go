@@ -0,0 +1,34 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// productTranslationsUp creates the table of product names and descriptions
+// in other languages, one row per product and locale. Like returnsUp it is
+// written in Go for its timestamp columns.
+func productTranslationsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS product_translations (
+    product_id UUID NOT NULL,
+    locale VARCHAR(35) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    created_at {timestamp},
+    updated_at {timestamp},
+    PRIMARY KEY (product_id, locale),
+    CONSTRAINT fk_products_product_translations FOREIGN KEY (product_id) REFERENCES products (id) ON DELETE CASCADE
+);
+`, "{timestamp}", timestamp)).Error
+}
+
+func productTranslationsDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS product_translations;`).Error
+}
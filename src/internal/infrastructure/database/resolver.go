@@ -0,0 +1,44 @@
+package database
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+const replicaSetting = "database:replica"
+
+// ReadReplica is a scope marking a query as one the read replica may answer.
+// The replica is only used when the query's context allows stale reads (see
+// repository.WithStaleReads), so a write path reading the same rows keeps
+// reading the primary. Without a replica, or inside a transaction, the query
+// runs on the primary.
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Set(replicaSetting, true)
+}
+
+// UseReplica sends the queries of db marked with ReadReplica, made with a
+// context allowing stale reads, to replica. Everything else, writes included,
+// keeps using the primary connection.
+func UseReplica(db *gorm.DB, replica gorm.ConnPool) error {
+	route := func(tx *gorm.DB) {
+		if tx.Error != nil {
+			return
+		}
+		if marked, ok := tx.Get(replicaSetting); !ok || marked != true {
+			return
+		}
+		if !repository.StaleReadsAllowed(tx.Statement.Context) {
+			return
+		}
+		// Reads in a transaction must see its own writes
+		if _, inTransaction := tx.Statement.ConnPool.(gorm.TxCommitter); inTransaction {
+			return
+		}
+		tx.Statement.ConnPool = replica
+	}
+
+	if err := db.Callback().Query().Before("gorm:query").Register("database:replica_query", route); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("database:replica_row", route)
+}
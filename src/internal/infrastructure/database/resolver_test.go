@@ -0,0 +1,110 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// fakePool fails every statement with its own name, telling which connection
+// a query went to
+type fakePool struct {
+	name string
+}
+
+func (p *fakePool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New(p.name)
+}
+
+func (p *fakePool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, errors.New(p.name)
+}
+
+func (p *fakePool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, errors.New(p.name)
+}
+
+func (p *fakePool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return nil
+}
+
+func (p *fakePool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return &fakeTx{fakePool{name: p.name + " transaction"}}, nil
+}
+
+type fakeTx struct {
+	fakePool
+}
+
+func (t *fakeTx) Commit() error   { return nil }
+func (t *fakeTx) Rollback() error { return nil }
+
+type row struct {
+	ID int
+}
+
+func newResolvedDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &fakePool{name: "primary"}}), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, UseReplica(db, &fakePool{name: "replica"}))
+	return db
+}
+
+func TestUseReplica(t *testing.T) {
+	staleCtx := repository.WithStaleReads(context.Background())
+
+	t.Run("Marked reads allowing stale data go to the replica", func(t *testing.T) {
+		db := newResolvedDB(t)
+
+		err := db.WithContext(staleCtx).Scopes(ReadReplica).Find(&[]row{}).Error
+		assert.EqualError(t, err, "replica")
+
+		var count int64
+		err = db.WithContext(staleCtx).Scopes(ReadReplica).Model(&row{}).Count(&count).Error
+		assert.EqualError(t, err, "replica")
+
+		err = db.WithContext(staleCtx).Scopes(ReadReplica).Raw("SELECT id FROM rows").Scan(&[]row{}).Error
+		assert.EqualError(t, err, "replica")
+	})
+
+	t.Run("Unmarked reads stay on the primary", func(t *testing.T) {
+		db := newResolvedDB(t)
+
+		err := db.WithContext(staleCtx).Find(&[]row{}).Error
+
+		assert.EqualError(t, err, "primary")
+	})
+
+	t.Run("Reads without stale data allowed stay on the primary", func(t *testing.T) {
+		db := newResolvedDB(t)
+
+		err := db.WithContext(context.Background()).Scopes(ReadReplica).Find(&[]row{}).Error
+
+		assert.EqualError(t, err, "primary")
+	})
+
+	t.Run("Reads in a transaction stay on it", func(t *testing.T) {
+		db := newResolvedDB(t)
+
+		err := db.WithContext(staleCtx).Transaction(func(tx *gorm.DB) error {
+			return tx.Scopes(ReadReplica).Find(&[]row{}).Error
+		})
+
+		assert.EqualError(t, err, "primary transaction")
+	})
+
+	t.Run("Writes stay on the primary", func(t *testing.T) {
+		db := newResolvedDB(t)
+
+		err := db.WithContext(staleCtx).Scopes(ReadReplica).Exec("DELETE FROM rows").Error
+
+		assert.EqualError(t, err, "primary")
+	})
+}
@@ -0,0 +1,57 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// returnsUp creates the returns (RMAs) and their items. Like customersUp it is
+// written in Go for its timestamp columns. Returns don't reference orders with
+// a foreign key, archiving moves the orders to another table.
+func returnsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS returns (
+    id UUID PRIMARY KEY,
+    order_id UUID NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'requested',
+    reason VARCHAR(30) NOT NULL,
+    note TEXT,
+    resolution_note TEXT,
+    refund_amount DECIMAL(10,2) NOT NULL DEFAULT 0,
+    refund_reference VARCHAR(255),
+    refund_error TEXT,
+    reviewed_by UUID,
+    reviewed_at {timestamp},
+    received_at {timestamp},
+    refunded_at {timestamp},
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON returns (order_id);
+CREATE INDEX IF NOT EXISTS idx_returns_status ON returns (status);
+CREATE TABLE IF NOT EXISTS return_items (
+    id UUID PRIMARY KEY,
+    return_id UUID NOT NULL,
+    order_item_id UUID NOT NULL,
+    product_id UUID NOT NULL,
+    variant_id UUID,
+    quantity INTEGER NOT NULL,
+    refund_amount DECIMAL(10,2) NOT NULL,
+    CONSTRAINT fk_returns_items FOREIGN KEY (return_id) REFERENCES returns (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_return_items_return_id ON return_items (return_id);
+`, "{timestamp}", timestamp)).Error
+}
+
+func returnsDown(tx *gorm.DB) error {
+	return tx.Exec(`
+DROP TABLE IF EXISTS return_items;
+DROP TABLE IF EXISTS returns;
+`).Error
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// storeSettingsUp creates the key-value table of the store settings. Settings
+// without a row take their default, so the table starts empty.
+func storeSettingsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS store_settings (
+    key VARCHAR(100) PRIMARY KEY,
+    value TEXT NOT NULL,
+    updated_by UUID,
+    updated_at {timestamp}
+);
+`, "{timestamp}", timestamp)).Error
+}
+
+func storeSettingsDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS store_settings;`).Error
+}
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+const timeoutSetting = "database:timeout"
+
+type timeoutState struct {
+	parent context.Context
+	cancel context.CancelFunc
+}
+
+// UseQueryTimeout gives every statement run through db a deadline of timeout,
+// waiting for a pooled connection included, so slow queries release their
+// connection instead of piling up until the pool is exhausted. A shorter
+// deadline already on the context wins. Rows returned by db.Rows, which the
+// caller reads after the statement ends, are only bounded by the server-side
+// statement_timeout.
+func UseQueryTimeout(db *gorm.DB, timeout time.Duration) error {
+	start := func(tx *gorm.DB) {
+		ctx, cancel := context.WithTimeout(tx.Statement.Context, timeout)
+		tx.InstanceSet(timeoutSetting, timeoutState{parent: tx.Statement.Context, cancel: cancel})
+		tx.Statement.Context = ctx
+	}
+	// The statement may be reused by the caller, so it gets its own context back
+	end := func(tx *gorm.DB) {
+		if value, ok := tx.InstanceGet(timeoutSetting); ok {
+			state := value.(timeoutState)
+			state.cancel()
+			tx.Statement.Context = state.parent
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("*").Register("database:timeout_start", start),
+		callbacks.Create().After("*").Register("database:timeout_end", end),
+		callbacks.Query().Before("*").Register("database:timeout_start", start),
+		callbacks.Query().After("*").Register("database:timeout_end", end),
+		callbacks.Update().Before("*").Register("database:timeout_start", start),
+		callbacks.Update().After("*").Register("database:timeout_end", end),
+		callbacks.Delete().Before("*").Register("database:timeout_start", start),
+		callbacks.Delete().After("*").Register("database:timeout_end", end),
+		callbacks.Raw().Before("*").Register("database:timeout_start", start),
+		callbacks.Raw().After("*").Register("database:timeout_end", end),
+	)
+}
+
+// queryCanceled is the SQLSTATE of a statement Postgres canceled, e.g. at its
+// statement_timeout
+const queryCanceled = "57014"
+
+// timeoutMessage is what clients are told when a statement ran out of time
+const timeoutMessage = "The request took too long, try again"
+
+// UseTimeoutErrors turns the errors of statements run through db that ran out
+// of time, at the deadline of their context or at the server's
+// statement_timeout, into entity.TimeoutError, so every repository reports
+// them as ErrTimeout and the handlers answer 503 instead of 500. The errors
+// still match the original cause, e.g. context.DeadlineExceeded. Statements of
+// a request the client gave up on, context.Canceled, are left as they are.
+func UseTimeoutErrors(db *gorm.DB) error {
+	translate := func(tx *gorm.DB) {
+		if tx.Error != nil && timedOut(tx.Error) && !errors.Is(tx.Error, entity.ErrTimeout) {
+			tx.Error = entity.TimeoutError(timeoutMessage, tx.Error)
+		}
+	}
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().After("*").Register("database:timeout_error", translate),
+		callbacks.Query().After("*").Register("database:timeout_error", translate),
+		callbacks.Update().After("*").Register("database:timeout_error", translate),
+		callbacks.Delete().After("*").Register("database:timeout_error", translate),
+		callbacks.Row().After("*").Register("database:timeout_error", translate),
+		callbacks.Raw().After("*").Register("database:timeout_error", translate),
+	)
+}
+
+// timedOut tells whether err is a deadline or statement_timeout. Postgres
+// reports the statements the driver canceled for a canceled context with the
+// same SQLSTATE, those are the client's doing.
+func timedOut(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var pgErr interface{ SQLState() string }
+	return errors.As(err, &pgErr) && pgErr.SQLState() == queryCanceled
+}
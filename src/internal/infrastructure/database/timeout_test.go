@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// slowPool never answers, statements end when their context does
+type slowPool struct {
+	fakePool
+}
+
+func (p *slowPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (p *slowPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func newSlowDB(t *testing.T, timeout time.Duration) *gorm.DB {
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: &slowPool{}}), &gorm.Config{SkipDefaultTransaction: true})
+	require.NoError(t, err)
+	require.NoError(t, UseQueryTimeout(db, timeout))
+	return db
+}
+
+func TestUseQueryTimeout(t *testing.T) {
+	t.Run("Stops slow statements at the timeout", func(t *testing.T) {
+		db := newSlowDB(t, 20*time.Millisecond)
+
+		started := time.Now()
+		err := db.Find(&[]row{}).Error
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(started), time.Second)
+
+		err = db.Exec("UPDATE rows SET id = 1").Error
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Keeps a shorter deadline of the caller", func(t *testing.T) {
+		db := newSlowDB(t, time.Hour)
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		err := db.WithContext(ctx).Find(&[]row{}).Error
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Gives a reused statement its context back", func(t *testing.T) {
+		db := newSlowDB(t, 20*time.Millisecond)
+		ctx := context.WithValue(context.Background(), struct{}{}, "request")
+
+		query := db.WithContext(ctx).Model(&row{})
+		var count int64
+		query.Count(&count)
+
+		assert.Equal(t, ctx, query.Statement.Context)
+	})
+}
+
+// pgError stands in for the driver's error of a statement Postgres canceled
+type pgError struct{ code string }
+
+func (e *pgError) Error() string    { return "ERROR: canceling statement due to statement timeout" }
+func (e *pgError) SQLState() string { return e.code }
+
+// failingPool fails every statement with err
+type failingPool struct {
+	fakePool
+	err error
+}
+
+func (p *failingPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, p.err
+}
+
+func (p *failingPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return nil, p.err
+}
+
+func TestUseTimeoutErrors(t *testing.T) {
+	open := func(t *testing.T, pool gorm.ConnPool) *gorm.DB {
+		db, err := gorm.Open(postgres.New(postgres.Config{Conn: pool}), &gorm.Config{SkipDefaultTransaction: true})
+		require.NoError(t, err)
+		require.NoError(t, UseTimeoutErrors(db))
+		return db
+	}
+
+	t.Run("Reports a deadline as a timeout", func(t *testing.T) {
+		db := newSlowDB(t, 20*time.Millisecond)
+		require.NoError(t, UseTimeoutErrors(db))
+
+		err := db.Find(&[]row{}).Error
+		assert.ErrorIs(t, err, entity.ErrTimeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, timeoutMessage, err.Error())
+
+		err = db.Exec("UPDATE rows SET id = 1").Error
+		assert.ErrorIs(t, err, entity.ErrTimeout)
+	})
+
+	t.Run("Reports the statement_timeout as a timeout", func(t *testing.T) {
+		db := open(t, &failingPool{err: &pgError{code: queryCanceled}})
+
+		err := db.Create(&row{ID: 1}).Error
+		assert.ErrorIs(t, err, entity.ErrTimeout)
+	})
+
+	t.Run("Leaves a request the client canceled and other errors alone", func(t *testing.T) {
+		db := open(t, &failingPool{err: errors.Join(context.Canceled, &pgError{code: queryCanceled})})
+		err := db.Find(&[]row{}).Error
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.NotErrorIs(t, err, entity.ErrTimeout)
+
+		db = open(t, &failingPool{err: &pgError{code: "23505"}})
+		err = db.Find(&[]row{}).Error
+		assert.NotErrorIs(t, err, entity.ErrTimeout)
+	})
+}
@@ -0,0 +1,87 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// UseUTC stores every time written through db in UTC: the created and updated
+// times gorm fills in, and the times of the rows created or updated, whatever
+// zone the caller used. Postgres keeps instants whichever zone they come in,
+// but SQLite keeps times as text and compares them as text, so a time written
+// in another zone would sort out of place.
+func UseUTC(db *gorm.DB) error {
+	db.Config.NowFunc = func() time.Time { return time.Now().UTC() }
+
+	callbacks := db.Callback()
+	return errors.Join(
+		callbacks.Create().Before("gorm:create").Register("database:utc", toUTC),
+		callbacks.Update().Before("gorm:update").Register("database:utc", toUTC),
+	)
+}
+
+// toUTC converts the times of the statement's rows, or of its column values
+// when it updates a map of them
+func toUTC(tx *gorm.DB) {
+	if tx.Error != nil {
+		return
+	}
+	stmt := tx.Statement
+
+	if values, ok := stmt.Dest.(map[string]interface{}); ok {
+		for column, value := range values {
+			if utc, ok := utcValue(value); ok {
+				values[column] = utc
+			}
+		}
+		return
+	}
+	if stmt.Schema == nil {
+		return
+	}
+
+	switch rows := stmt.ReflectValue; rows.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rows.Len(); i++ {
+			rowToUTC(stmt, stmt.Schema, reflect.Indirect(rows.Index(i)))
+		}
+	case reflect.Struct:
+		rowToUTC(stmt, stmt.Schema, rows)
+	}
+}
+
+func rowToUTC(stmt *gorm.Statement, s *schema.Schema, row reflect.Value) {
+	if !row.CanAddr() {
+		return
+	}
+	for _, field := range s.Fields {
+		value, zero := field.ValueOf(stmt.Context, row)
+		if zero {
+			continue
+		}
+		if utc, ok := utcValue(value); ok {
+			field.Set(stmt.Context, row, utc)
+		}
+	}
+}
+
+// utcValue returns a time, or a pointer to one, in UTC, and false for times
+// already in UTC and values that aren't times
+func utcValue(value interface{}) (interface{}, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		if t.Location() != time.UTC {
+			return t.UTC(), true
+		}
+	case *time.Time:
+		if t != nil && t.Location() != time.UTC {
+			utc := t.UTC()
+			return &utc, true
+		}
+	}
+	return nil, false
+}
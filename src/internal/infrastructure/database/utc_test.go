@@ -0,0 +1,47 @@
+package database
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type utcRow struct {
+	ID        int
+	At        time.Time
+	Until     *time.Time
+	CreatedAt time.Time
+}
+
+func TestUseUTC(t *testing.T) {
+	db, err := Connect(&config.DatabaseConfig{Driver: config.DriverSQLite, SQLitePath: filepath.Join(t.TempDir(), "utc.db")})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&utcRow{}))
+
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	at := time.Date(2026, time.March, 1, 22, 30, 0, 0, saoPaulo)
+	until := at.Add(time.Hour)
+	row := utcRow{ID: 1, At: at, Until: &until}
+	require.NoError(t, db.Create(&row).Error)
+
+	assert.Equal(t, time.UTC, row.At.Location())
+	assert.True(t, row.At.Equal(at))
+	assert.Equal(t, time.UTC, row.Until.Location())
+	assert.Equal(t, time.UTC, row.CreatedAt.Location())
+	assert.Equal(t, saoPaulo, until.Location(), "the caller's time is left as it was")
+
+	var stored string
+	require.NoError(t, db.Raw("SELECT CAST(at AS TEXT) FROM utc_rows WHERE id = 1").Scan(&stored).Error)
+	assert.True(t, strings.HasPrefix(stored, "2026-03-02 01:30:00"), stored)
+	assert.True(t, strings.HasSuffix(stored, "+00:00"), stored)
+
+	later := at.Add(24 * time.Hour)
+	require.NoError(t, db.Model(&utcRow{}).Where("id = 1").Updates(map[string]interface{}{"at": later}).Error)
+	require.NoError(t, db.Raw("SELECT CAST(at AS TEXT) FROM utc_rows WHERE id = 1").Scan(&stored).Error)
+	assert.True(t, strings.HasPrefix(stored, "2026-03-03 01:30:00"), stored)
+}
@@ -0,0 +1,29 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// webhookNoncesUp creates the table of the nonces signed payment webhooks
+// used, kept until their timestamp is too old to pass. Like blocklistUp it
+// is written in Go for its timestamp column.
+func webhookNoncesUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS webhook_nonces (
+    nonce VARCHAR(128) PRIMARY KEY,
+    expires_at {timestamp} NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_nonces_expires_at ON webhook_nonces (expires_at);
+`, "{timestamp}", timestamp)).Error
+}
+
+func webhookNoncesDown(tx *gorm.DB) error {
+	return tx.Exec(`DROP TABLE IF EXISTS webhook_nonces;`).Error
+}
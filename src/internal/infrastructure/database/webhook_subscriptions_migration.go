@@ -0,0 +1,58 @@
+package database
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// webhookSubscriptionsUp creates the subscriptions of outgoing webhooks and
+// their delivery log. Like returnsUp it is written in Go for its timestamp
+// columns.
+func webhookSubscriptionsUp(tx *gorm.DB) error {
+	timestamp := "TIMESTAMP WITH TIME ZONE"
+	if IsSQLite(tx) {
+		timestamp = "DATETIME"
+	}
+
+	return tx.Exec(strings.ReplaceAll(`
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY,
+    url VARCHAR(2048) NOT NULL,
+    secret VARCHAR(255) NOT NULL,
+    events TEXT NOT NULL,
+    description VARCHAR(255),
+    active BOOLEAN NOT NULL,
+    created_by UUID,
+    created_at {timestamp},
+    updated_at {timestamp}
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active ON webhook_subscriptions (active);
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    event_id UUID NOT NULL,
+    event_type VARCHAR(50) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error TEXT,
+    next_attempt_at {timestamp},
+    delivered_at {timestamp},
+    created_at {timestamp},
+    updated_at {timestamp},
+    CONSTRAINT fk_webhook_subscriptions_deliveries FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id) ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries (status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
+`, "{timestamp}", timestamp)).Error
+}
+
+func webhookSubscriptionsDown(tx *gorm.DB) error {
+	return tx.Exec(`
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_subscriptions;
+`).Error
+}
@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Order and stock event types, the product ones are above
+const (
+	OrderCreated       = entity.EventOrderCreated
+	OrderStatusChanged = entity.EventOrderStatusChanged
+	OrderPaid          = entity.EventOrderPaid
+	StockChanged       = entity.EventStockChanged
+)
+
+//go:generate go run ../../tools/mockgen -interface Dispatcher -out ../../testing/mocks -name EventDispatcher
+
+// Dispatcher hands store events to the webhook subscriptions that want them.
+// Dispatching only queues the deliveries, so it doesn't wait on subscribers.
+type Dispatcher interface {
+	Dispatch(ctx context.Context, eventType string, data interface{}) error
+}
+
+type noopDispatcher struct{}
+
+// NewNoopDispatcher drops every event
+func NewNoopDispatcher() Dispatcher {
+	return &noopDispatcher{}
+}
+
+func (noopDispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	return nil
+}
+
+// Envelope is the body of every event delivered to a subscription
+type Envelope struct {
+	ID         string      `json:"id"`
+	Type       string      `json:"type"`
+	OccurredAt string      `json:"occurred_at"`
+	Data       interface{} `json:"data"`
+}
+
+// OrderData is the order state at the time of an order event
+type OrderData struct {
+	ID             string          `json:"id"`
+	UserID         string          `json:"user_id,omitempty"`
+	Status         string          `json:"status"`
+	PaymentStatus  string          `json:"payment_status"`
+	TotalPrice     float64         `json:"total_price"`
+	AmountPaid     float64         `json:"amount_paid"`
+	AmountRefunded float64         `json:"amount_refunded"`
+	Items          []OrderItemData `json:"items"`
+	CreatedAt      string          `json:"created_at"`
+}
+
+type OrderItemData struct {
+	ProductID   string  `json:"product_id"`
+	VariantID   string  `json:"variant_id,omitempty"`
+	ProductName string  `json:"product_name,omitempty"`
+	SKU         string  `json:"sku,omitempty"`
+	Quantity    int     `json:"quantity"`
+	Price       float64 `json:"price"`
+	TotalPrice  float64 `json:"total_price"`
+}
+
+// NewOrderData snapshots an order for an event
+func NewOrderData(order *entity.Order) OrderData {
+	data := OrderData{
+		ID:             order.ID.String(),
+		Status:         string(order.Status),
+		PaymentStatus:  string(order.PaymentStatus),
+		TotalPrice:     order.TotalPrice,
+		AmountPaid:     order.AmountPaid,
+		AmountRefunded: order.AmountRefunded,
+		Items:          make([]OrderItemData, 0, len(order.Products)),
+		CreatedAt:      order.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if order.UserID != nil {
+		data.UserID = order.UserID.String()
+	}
+	for _, item := range order.Products {
+		itemData := OrderItemData{
+			ProductID:   item.ProductID.String(),
+			ProductName: item.ProductName,
+			SKU:         item.SKU,
+			Quantity:    item.Quantity,
+			Price:       item.Price,
+			TotalPrice:  item.TotalPrice,
+		}
+		if item.VariantID != nil {
+			itemData.VariantID = item.VariantID.String()
+		}
+		data.Items = append(data.Items, itemData)
+	}
+	return data
+}
+
+// ProductData is the product state at the time of a product event
+type ProductData struct {
+	ProductID   string          `json:"product_id"`
+	ContentHash string          `json:"content_hash"`
+	Product     ProductSnapshot `json:"product"`
+}
+
+// NewProductData snapshots a product for an event, as NewProductEvent does
+func NewProductData(product *entity.Product) ProductData {
+	event := NewProductEvent("", product, time.Time{})
+	return ProductData{ProductID: event.ProductID, ContentHash: event.ContentHash, Product: event.Product}
+}
+
+// StockData is a stock movement of a product or variant
+type StockData struct {
+	ProductID      string `json:"product_id"`
+	VariantID      string `json:"variant_id,omitempty"`
+	Reason         string `json:"reason"`
+	Delta          int    `json:"delta"`
+	QuantityBefore int    `json:"quantity_before"`
+	QuantityAfter  int    `json:"quantity_after"`
+	Reference      string `json:"reference,omitempty"`
+}
+
+// NewStockData describes a stock movement for an event
+func NewStockData(movement *entity.StockMovement) StockData {
+	data := StockData{
+		ProductID:      movement.ProductID.String(),
+		Reason:         string(movement.Reason),
+		Delta:          movement.Delta,
+		QuantityBefore: movement.QuantityBefore,
+		QuantityAfter:  movement.QuantityAfter,
+		Reference:      movement.Reference,
+	}
+	if movement.VariantID != nil {
+		data.VariantID = movement.VariantID.String()
+	}
+	return data
+}
+
+//go:generate go run ../../tools/mockgen -interface Sender -out ../../testing/mocks -name EventSender
+
+// Sender posts the deliveries of webhook subscriptions
+type Sender interface {
+	// Send posts body signed with secret and returns the status the
+	// subscriber responded with, failing unless it is 2xx
+	Send(ctx context.Context, url, secret, eventID, eventType string, body []byte) (int, error)
+}
+
+type httpSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender signs deliveries like the product event publisher, with the
+// event ID in X-Event-ID so subscribers can drop the ones they already got
+func NewHTTPSender() Sender {
+	return &httpSender{client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (s *httpSender) Send(ctx context.Context, url, secret, eventID, eventType string, body []byte) (int, error) {
+	return post(ctx, s.client, url, secret, eventID, eventType, body)
+}
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Product lifecycle event types
+const (
+	ProductCreated  = entity.EventProductCreated
+	ProductUpdated  = entity.EventProductUpdated
+	ProductArchived = entity.EventProductArchived
+)
+
+// ProductEvent is the payload delivered to subscribers such as a PIM
+type ProductEvent struct {
+	ID          string          `json:"id"`
+	Type        string          `json:"type"`
+	OccurredAt  string          `json:"occurred_at"`
+	ProductID   string          `json:"product_id"`
+	ContentHash string          `json:"content_hash"`
+	Product     ProductSnapshot `json:"product"`
+}
+
+// ProductSnapshot is the product state at the time of the event
+type ProductSnapshot struct {
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	Price           float64 `json:"price"`
+	Quantity        int     `json:"quantity"`
+	MeasurementUnit string  `json:"measurement_unit,omitempty"`
+	UnitContent     float64 `json:"unit_content,omitempty"`
+	Status          string  `json:"status,omitempty"` // draft, active or archived
+}
+
+//go:generate go run ../../tools/mockgen -interface Publisher -out ../../testing/mocks -name EventPublisher
+
+// Publisher emits outbound product lifecycle events
+type Publisher interface {
+	PublishProductEvent(ctx context.Context, eventType string, product *entity.Product) error
+}
+
+// NewProductEvent builds the event payload for a product
+func NewProductEvent(eventType string, product *entity.Product, occurredAt time.Time) ProductEvent {
+	return ProductEvent{
+		ID:          uuid.New().String(),
+		Type:        eventType,
+		OccurredAt:  dto.FormatTime(occurredAt.UTC()),
+		ProductID:   product.ID.String(),
+		ContentHash: product.ContentHash(),
+		Product: ProductSnapshot{
+			Name:            product.Name,
+			Description:     product.Description,
+			Price:           product.Price,
+			Quantity:        product.Quantity,
+			MeasurementUnit: string(product.Measure.Unit),
+			UnitContent:     product.Measure.Content,
+			Status:          string(product.Status),
+		},
+	}
+}
+
+const maxDeliveryAttempts = 3
+
+type webhookPublisher struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookPublisher delivers events as signed HTTP POST requests to url.
+// The body is signed with HMAC-SHA256 in the X-Event-Signature header, the
+// same scheme used for incoming payment webhooks.
+func NewWebhookPublisher(url, secret string) Publisher {
+	return &webhookPublisher{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// PublishProductEvent delivers in the background so API requests are not
+// held up by a slow subscriber
+func (p *webhookPublisher) PublishProductEvent(ctx context.Context, eventType string, product *entity.Product) error {
+	body, err := json.Marshal(NewProductEvent(eventType, product, time.Now()))
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		for attempt := 1; attempt <= maxDeliveryAttempts; attempt++ {
+			err := p.deliver(context.Background(), eventType, body)
+			if err == nil {
+				return
+			}
+			log.Printf("Failed to deliver %s event (attempt %d/%d): %v", eventType, attempt, maxDeliveryAttempts, err)
+			time.Sleep(time.Duration(attempt) * time.Second)
+		}
+	}()
+
+	return nil
+}
+
+func (p *webhookPublisher) deliver(ctx context.Context, eventType string, body []byte) error {
+	_, err := post(ctx, p.client, p.url, p.secret, "", eventType, body)
+	return err
+}
+
+// post sends a signed event to url and returns the status the subscriber
+// responded with, failing unless it is 2xx
+func post(ctx context.Context, client *http.Client, url, secret, eventID, eventType string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if eventID != "" {
+		req.Header.Set("X-Event-ID", eventID)
+	}
+	req.Header.Set("X-Event-Type", eventType)
+	req.Header.Set("X-Event-Signature", Sign(body, secret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded with status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type noopPublisher struct{}
+
+// NewNoopPublisher drops every event, used when no subscriber is configured
+func NewNoopPublisher() Publisher {
+	return &noopPublisher{}
+}
+
+func (noopPublisher) PublishProductEvent(ctx context.Context, eventType string, product *entity.Product) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+func TestWebhookPublisher_DeliversSignedEvent(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Event-Signature")
+		gotType = r.Header.Get("X-Event-Type")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 999.99, Quantity: 5}
+	body, _ := json.Marshal(NewProductEvent(ProductUpdated, product, product.CreatedAt))
+
+	publisher := NewWebhookPublisher(server.URL, "secret").(*webhookPublisher)
+	if err := publisher.deliver(context.Background(), ProductUpdated, body); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotType != ProductUpdated {
+		t.Errorf("X-Event-Type = %q, want %q", gotType, ProductUpdated)
+	}
+	if gotSignature != Sign(gotBody, "secret") {
+		t.Error("signature does not match the delivered body")
+	}
+
+	var event ProductEvent
+	if err := json.Unmarshal(gotBody, &event); err != nil {
+		t.Fatalf("invalid event payload: %v", err)
+	}
+	if event.ContentHash != product.ContentHash() {
+		t.Error("expected event to carry the product content hash")
+	}
+}
+
+func TestWebhookPublisher_RejectedDelivery(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	publisher := NewWebhookPublisher(server.URL, "secret").(*webhookPublisher)
+	if err := publisher.deliver(context.Background(), ProductCreated, []byte("{}")); err == nil {
+		t.Error("expected error for non-2xx response")
+	}
+}
+
+func TestHTTPSender_SendsEventID(t *testing.T) {
+	var gotID, gotSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotID = r.Header.Get("X-Event-ID")
+		gotSignature = r.Header.Get("X-Event-Signature")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	status, err := NewHTTPSender().Send(context.Background(), server.URL, "secret", "evt-1", OrderCreated, []byte("{}"))
+	if err != nil || status != http.StatusAccepted {
+		t.Fatalf("expected status 202, got %d, %v", status, err)
+	}
+	if gotID != "evt-1" || gotSignature != Sign([]byte("{}"), "secret") {
+		t.Errorf("unexpected headers: X-Event-ID %q, X-Event-Signature %q", gotID, gotSignature)
+	}
+}
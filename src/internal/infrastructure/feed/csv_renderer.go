@@ -0,0 +1,48 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// csvHeader names the columns with the attribute names of the XML feed
+var csvHeader = []string{
+	"id", "title", "description", "link", "availability", "condition", "price",
+	"item_group_id", "product_type", "unit_pricing_measure",
+}
+
+type csvRenderer struct{}
+
+// NewCSVRenderer renders feeds as CSV with a header row
+func NewCSVRenderer() Renderer {
+	return &csvRenderer{}
+}
+
+func (r *csvRenderer) ContentType() string {
+	return "text/csv; charset=utf-8"
+}
+
+func (r *csvRenderer) Render(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	out := csv.NewWriter(&buf)
+	out.Write(csvHeader)
+	for _, item := range doc.Items {
+		out.Write([]string{
+			item.ID,
+			item.Title,
+			item.Description,
+			item.Link,
+			item.Availability,
+			item.Condition,
+			formatPrice(item.Price, doc.Currency),
+			item.ItemGroupID,
+			item.ProductType,
+			item.UnitPricingMeasure,
+		})
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,29 @@
+package feed
+
+import (
+	"strconv"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Document holds everything written to a product feed
+type Document struct {
+	Title       string // Store name
+	Link        string // Storefront URL
+	Description string
+	Currency    string // ISO 4217 code prices are quoted in
+	Items       []entity.FeedItem
+}
+
+//go:generate go run ../../tools/mockgen -interface Renderer -out ../../testing/mocks -name FeedRenderer
+
+// Renderer turns a feed document into a file marketplaces import
+type Renderer interface {
+	Render(doc *Document) ([]byte, error)
+	ContentType() string
+}
+
+// formatPrice writes a price the way Google Shopping and Facebook expect it, e.g. "12.50 USD"
+func formatPrice(price float64, currency string) string {
+	return strconv.FormatFloat(price, 'f', 2, 64) + " " + currency
+}
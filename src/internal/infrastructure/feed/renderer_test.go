@@ -0,0 +1,64 @@
+package feed
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+var doc = &Document{
+	Title:    "Go E-Commerce",
+	Link:     "https://shop.example.com",
+	Currency: "USD",
+	Items: []entity.FeedItem{
+		{ID: "TS-L", ItemGroupID: "group-1", Title: `T-Shirt "Classic", L`, Description: "Cotton & linen <50%>", Link: "https://shop.example.com/products/1",
+			Availability: entity.FeedInStock, Condition: "new", Price: 19.5},
+	},
+}
+
+func TestXMLRenderer(t *testing.T) {
+	content, err := NewXMLRenderer().Render(doc)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !strings.HasPrefix(string(content), xml.Header) || !strings.Contains(string(content), `xmlns:g="http://base.google.com/ns/1.0"`) {
+		t.Errorf("expected an RSS document in the Google namespace, got %s", content)
+	}
+	if !strings.Contains(string(content), "<g:price>19.50 USD</g:price>") || strings.Contains(string(content), "<g:product_type>") {
+		t.Errorf("expected the price and no empty attributes, got %s", content)
+	}
+
+	var parsed struct {
+		Items []struct {
+			Description string `xml:"description"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.Unmarshal(content, &parsed); err != nil {
+		t.Fatalf("expected well-formed XML, got %v", err)
+	}
+	if len(parsed.Items) != 1 || parsed.Items[0].Description != doc.Items[0].Description {
+		t.Errorf("expected the description to survive escaping, got %+v", parsed.Items)
+	}
+}
+
+func TestCSVRenderer(t *testing.T) {
+	content, err := NewCSVRenderer().Render(doc)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(content))).ReadAll()
+	if err != nil {
+		t.Fatalf("expected valid CSV, got %v", err)
+	}
+	if len(records) != 2 || records[0][0] != "id" {
+		t.Fatalf("expected a header and 1 row, got %v", records)
+	}
+	if records[1][1] != doc.Items[0].Title || records[1][6] != "19.50 USD" || records[1][7] != "group-1" {
+		t.Errorf("unexpected row %v", records[1])
+	}
+}
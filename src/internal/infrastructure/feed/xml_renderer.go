@@ -0,0 +1,87 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+)
+
+// googleNamespace is the namespace of the product attributes of an RSS feed
+const googleNamespace = "http://base.google.com/ns/1.0"
+
+type rss struct {
+	XMLName   xml.Name `xml:"rss"`
+	Version   string   `xml:"version,attr"`
+	Namespace string   `xml:"xmlns:g,attr"`
+	Channel   channel  `xml:"channel"`
+}
+
+type channel struct {
+	Title       string    `xml:"title"`
+	Link        string    `xml:"link"`
+	Description string    `xml:"description"`
+	Items       []xmlItem `xml:"item"`
+}
+
+// xmlItem is an item with its attributes in the g: namespace. encoding/xml
+// writes prefixed names as they are, which is what feed readers expect.
+type xmlItem struct {
+	ID                 string `xml:"g:id"`
+	Title              string `xml:"g:title"`
+	Description        string `xml:"g:description"`
+	Link               string `xml:"g:link"`
+	Availability       string `xml:"g:availability"`
+	Condition          string `xml:"g:condition"`
+	Price              string `xml:"g:price"`
+	ItemGroupID        string `xml:"g:item_group_id,omitempty"`
+	ProductType        string `xml:"g:product_type,omitempty"`
+	UnitPricingMeasure string `xml:"g:unit_pricing_measure,omitempty"`
+}
+
+type xmlRenderer struct{}
+
+// NewXMLRenderer renders RSS 2.0 feeds with the product attributes in the
+// Google Shopping namespace, the format Facebook catalogs read too
+func NewXMLRenderer() Renderer {
+	return &xmlRenderer{}
+}
+
+func (r *xmlRenderer) ContentType() string {
+	return "application/xml; charset=utf-8"
+}
+
+func (r *xmlRenderer) Render(doc *Document) ([]byte, error) {
+	feed := rss{
+		Version:   "2.0",
+		Namespace: googleNamespace,
+		Channel: channel{
+			Title:       doc.Title,
+			Link:        doc.Link,
+			Description: doc.Description,
+			Items:       make([]xmlItem, 0, len(doc.Items)),
+		},
+	}
+	for _, item := range doc.Items {
+		feed.Channel.Items = append(feed.Channel.Items, xmlItem{
+			ID:                 item.ID,
+			Title:              item.Title,
+			Description:        item.Description,
+			Link:               item.Link,
+			Availability:       item.Availability,
+			Condition:          item.Condition,
+			Price:              formatPrice(item.Price, doc.Currency),
+			ItemGroupID:        item.ItemGroupID,
+			ProductType:        item.ProductType,
+			UnitPricingMeasure: item.UnitPricingMeasure,
+		})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(feed); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,143 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// Line is a single order line printed on the invoice. Total includes the
+// line's discount, tax and surcharges.
+type Line struct {
+	Description string
+	Quantity    int
+	UnitPrice   float64
+	Discount    float64
+	Tax         float64
+	Total       float64
+}
+
+// Document holds everything printed on an invoice
+type Document struct {
+	Number        string
+	IssuedAt      time.Time
+	OrderID       string
+	CustomerID    int
+	CustomerName  string
+	CustomerEmail string
+	Lines         []Line
+	Subtotal      float64
+	Discount      float64
+	Tax           float64
+	Surcharges    float64
+	Shipping      float64
+	Credits       float64
+	Total         float64
+}
+
+//go:generate go run ../../tools/mockgen -interface Renderer -out ../../testing/mocks -name InvoiceRenderer
+
+// Renderer turns an invoice document into a printable file
+type Renderer interface {
+	Render(doc *Document) ([]byte, error)
+}
+
+type pdfRenderer struct {
+	storeName string
+}
+
+func NewPDFRenderer(storeName string) Renderer {
+	return &pdfRenderer{storeName: storeName}
+}
+
+func (r *pdfRenderer) Render(doc *Document) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetCreationDate(doc.IssuedAt)
+	pdf.SetTitle("Invoice "+doc.Number, true)
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, r.storeName, "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, "Invoice "+doc.Number, "", 1, "L", false, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, "Issued: "+doc.IssuedAt.Format("2006-01-02"), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, "Order: "+doc.OrderID, "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	// Customer details
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(0, 6, "Bill to", "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	if doc.CustomerName != "" {
+		pdf.CellFormat(0, 6, doc.CustomerName, "", 1, "L", false, 0, "")
+	}
+	if doc.CustomerEmail != "" {
+		pdf.CellFormat(0, 6, doc.CustomerEmail, "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, 6, fmt.Sprintf("Customer #%d", doc.CustomerID), "", 1, "L", false, 0, "")
+	pdf.Ln(6)
+
+	// Order lines
+	pdf.SetFont("Helvetica", "B", 10)
+	pdf.SetFillColor(230, 230, 230)
+	pdf.CellFormat(75, 7, "Item", "1", 0, "L", true, 0, "")
+	pdf.CellFormat(15, 7, "Qty", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(25, 7, "Unit price", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(25, 7, "Discount", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(20, 7, "Tax", "1", 0, "R", true, 0, "")
+	pdf.CellFormat(30, 7, "Total", "1", 1, "R", true, 0, "")
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range doc.Lines {
+		pdf.CellFormat(75, 7, line.Description, "1", 0, "L", false, 0, "")
+		pdf.CellFormat(15, 7, fmt.Sprintf("%d", line.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 7, formatMoney(line.UnitPrice), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(25, 7, formatMoney(-line.Discount), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(20, 7, formatMoney(line.Tax), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(30, 7, formatMoney(line.Total), "1", 1, "R", false, 0, "")
+	}
+	pdf.Ln(4)
+
+	// Totals
+	writeTotal(pdf, "Subtotal", doc.Subtotal, false)
+	if doc.Discount > 0 {
+		writeTotal(pdf, "Discount", -doc.Discount, false)
+	}
+	writeTotal(pdf, "Tax", doc.Tax, false)
+	if doc.Surcharges > 0 {
+		writeTotal(pdf, "Surcharges", doc.Surcharges, false)
+	}
+	if doc.Shipping > 0 {
+		writeTotal(pdf, "Shipping", doc.Shipping, false)
+	}
+	if doc.Credits > 0 {
+		writeTotal(pdf, "Credits", -doc.Credits, false)
+	}
+	writeTotal(pdf, "Total", doc.Total, true)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("Failed to render invoice PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeTotal(pdf *fpdf.Fpdf, label string, amount float64, bold bool) {
+	style := ""
+	if bold {
+		style = "B"
+	}
+	pdf.SetFont("Helvetica", style, 10)
+	pdf.CellFormat(150, 7, label, "", 0, "R", false, 0, "")
+	pdf.CellFormat(40, 7, formatMoney(amount), "", 1, "R", false, 0, "")
+}
+
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type postgresLocker struct {
+	db *gorm.DB
+}
+
+// NewPostgresLocker locks jobs with transaction-level advisory locks, so every
+// instance sharing the database runs a due job once between them. The lock is
+// released when the run ends, or when its connection is lost.
+func NewPostgresLocker(db *gorm.DB) Locker {
+	return &postgresLocker{db: db}
+}
+
+func (l *postgresLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	tx := l.db.WithContext(ctx).Begin()
+	if tx.Error != nil {
+		return nil, false, tx.Error
+	}
+
+	var acquired bool
+	if err := tx.Raw("SELECT pg_try_advisory_xact_lock(hashtext(?))", "job:"+name).Scan(&acquired).Error; err != nil {
+		tx.Rollback()
+		return nil, false, err
+	}
+	if !acquired {
+		tx.Rollback()
+		return nil, false, nil
+	}
+
+	return func() { tx.Rollback() }, true, nil
+}
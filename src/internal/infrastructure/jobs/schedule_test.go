@@ -0,0 +1,88 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+)
+
+func at(value string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestParseSchedule_Next(t *testing.T) {
+	cases := []struct {
+		expr  string
+		after string
+		want  string
+	}{
+		{"* * * * *", "2024-05-01 10:00", "2024-05-01 10:01"},
+		{"*/15 * * * *", "2024-05-01 10:07", "2024-05-01 10:15"},
+		{"0 3 * * *", "2024-05-01 03:00", "2024-05-02 03:00"},
+		{"30 8-18 * * 1-5", "2024-05-03 18:30", "2024-05-06 08:30"}, // Friday evening to Monday morning
+		{"0 0 1 * *", "2024-01-31 12:00", "2024-02-01 00:00"},
+		{"0 12 29 2 *", "2023-03-01 00:00", "2024-02-29 12:00"},
+		{"0 0 * * 7", "2024-05-01 00:00", "2024-05-05 00:00"},  // 7 is Sunday
+		{"0 0 13 * 5", "2024-05-01 00:00", "2024-05-03 00:00"}, // Either day field matches
+		{"5/20 * * * *", "2024-05-01 10:26", "2024-05-01 10:45"},
+		{"0,30 9 * * *", "2024-05-01 09:10", "2024-05-01 09:30"},
+		{"@daily", "2024-05-01 10:00", "2024-05-02 00:00"},
+		{"@hourly", "2024-05-01 10:00", "2024-05-01 11:00"},
+	}
+
+	for _, tc := range cases {
+		schedule, err := ParseSchedule(tc.expr)
+		if err != nil {
+			t.Fatalf("%q: unexpected error %v", tc.expr, err)
+		}
+		got := schedule.Next(at(tc.after))
+		if want := at(tc.want); !got.Equal(want) {
+			t.Errorf("%q after %s = %s, want %s", tc.expr, tc.after, got, want)
+		}
+	}
+}
+
+func TestParseSchedule_Every(t *testing.T) {
+	schedule, err := ParseSchedule("@every 90s")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if next := schedule.Next(at("2024-05-01 10:00")); !next.Equal(at("2024-05-01 10:00").Add(90 * time.Second)) {
+		t.Errorf("unexpected next run %s", next)
+	}
+}
+
+func TestParseSchedule_NeverFires(t *testing.T) {
+	schedule, err := ParseSchedule("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if next := schedule.Next(at("2024-01-01 00:00")); !next.IsZero() {
+		t.Errorf("expected no next run, got %s", next)
+	}
+}
+
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"@every 10",
+		"@every 500ms",
+		"@yearly",
+	} {
+		if _, err := ParseSchedule(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Job is a unit of background work run on a schedule
+type Job struct {
+	Name     string
+	Schedule string // Cron expression or descriptor, see ParseSchedule
+	Run      func(ctx context.Context) error
+}
+
+// JobMetrics describes how a job has been doing since the scheduler started
+type JobMetrics struct {
+	Name           string
+	Schedule       string
+	Runs           int // Completed runs, failed ones included
+	Failures       int // Runs that returned an error or panicked
+	Panics         int
+	Skipped        int // Runs left out because the previous one was still going or another instance ran it
+	Running        bool
+	LastStartedAt  *time.Time
+	LastFinishedAt *time.Time
+	LastDuration   time.Duration
+	LastError      string
+	NextRunAt      time.Time
+}
+
+//go:generate go run ../../tools/mockgen -interface Locker -out ../../testing/mocks -name JobLocker
+
+// Locker keeps a job from running on several instances at once
+type Locker interface {
+	// TryLock takes the lock of the named job without waiting. It returns
+	// false when another instance holds it, otherwise a function releasing it.
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
+
+type scheduledJob struct {
+	job      Job
+	schedule Schedule
+	metrics  JobMetrics
+}
+
+// Scheduler runs registered jobs when they are due on a fixed pool of workers.
+// A job never runs concurrently with itself, and a panicking job is recovered
+// and counted as a failure without stopping the scheduler.
+type Scheduler struct {
+	workers int
+	locker  Locker
+	tick    time.Duration
+	logger  *log.Logger
+	now     func() time.Time
+
+	mu      sync.Mutex
+	jobs    []*scheduledJob
+	queue   chan *scheduledJob
+	started bool
+	wg      sync.WaitGroup
+}
+
+// NewScheduler creates a scheduler with the given number of workers.
+// Schedules are evaluated in UTC. With a locker, a run due on several
+// instances happens on one of them only; a nil locker runs every due job.
+func NewScheduler(workers int, locker Locker, logger *log.Logger) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &Scheduler{
+		workers: workers,
+		locker:  locker,
+		tick:    time.Second,
+		logger:  logger,
+		now:     func() time.Time { return time.Now().UTC() },
+	}
+}
+
+// Register adds a job. Jobs must be registered before the scheduler starts.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return errors.New("job needs a name and a run function")
+	}
+
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", job.Name, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return fmt.Errorf("job %s: scheduler already started", job.Name)
+	}
+	for _, existing := range s.jobs {
+		if existing.job.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+
+	s.jobs = append(s.jobs, &scheduledJob{
+		job:      job,
+		schedule: schedule,
+		metrics: JobMetrics{
+			Name:      job.Name,
+			Schedule:  job.Schedule,
+			NextRunAt: schedule.Next(s.now()),
+		},
+	})
+	return nil
+}
+
+// Start runs the scheduler until ctx is canceled. It returns immediately.
+// Use Wait to block until running jobs finish after cancellation.
+func (s *Scheduler) Start(ctx context.Context) {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return
+	}
+	s.started = true
+	// Each job is queued at most once at a time, so the queue never blocks
+	s.queue = make(chan *scheduledJob, len(s.jobs))
+	s.mu.Unlock()
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work(ctx)
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		ticker := time.NewTicker(s.tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				s.dispatch(s.now())
+			}
+		}
+	}()
+}
+
+// Wait blocks until the scheduler and its workers stop
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+// dispatch queues every job that is due at now
+func (s *Scheduler) dispatch(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, job := range s.jobs {
+		next := job.metrics.NextRunAt
+		if next.IsZero() || now.Before(next) {
+			continue
+		}
+
+		job.metrics.NextRunAt = job.schedule.Next(now)
+		if job.metrics.Running {
+			job.metrics.Skipped++
+			s.logger.Printf("[jobs] %s skipped, the previous run is still going", job.job.Name)
+			continue
+		}
+
+		job.metrics.Running = true
+		s.queue <- job
+	}
+}
+
+func (s *Scheduler) work(ctx context.Context) {
+	defer s.wg.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			s.execute(ctx, job)
+		}
+	}
+}
+
+// execute runs one job and records the outcome
+func (s *Scheduler) execute(ctx context.Context, job *scheduledJob) {
+	if s.locker != nil {
+		unlock, acquired, err := s.locker.TryLock(ctx, job.job.Name)
+		if err != nil || !acquired {
+			s.mu.Lock()
+			job.metrics.Running = false
+			job.metrics.Skipped++
+			s.mu.Unlock()
+			if err != nil {
+				s.logger.Printf("[jobs] %s skipped, failed to take its lock: %v", job.job.Name, err)
+			}
+			return
+		}
+		defer unlock()
+	}
+
+	startedAt := s.now()
+	s.mu.Lock()
+	job.metrics.LastStartedAt = &startedAt
+	s.mu.Unlock()
+
+	panicked, err := s.run(ctx, job.job)
+
+	finishedAt := s.now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.metrics.Running = false
+	job.metrics.Runs++
+	job.metrics.LastFinishedAt = &finishedAt
+	job.metrics.LastDuration = finishedAt.Sub(startedAt)
+	job.metrics.LastError = ""
+	if panicked {
+		job.metrics.Panics++
+	}
+	if err != nil {
+		job.metrics.Failures++
+		job.metrics.LastError = err.Error()
+		s.logger.Printf("[jobs] %s failed after %s: %v", job.job.Name, job.metrics.LastDuration, err)
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, job Job) (panicked bool, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Printf("[jobs] %s panicked: %v\n%s", job.Name, r, debug.Stack())
+			panicked, err = true, fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return false, job.Run(ctx)
+}
+
+// Metrics returns a snapshot of every job's metrics, sorted by name
+func (s *Scheduler) Metrics() []JobMetrics {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	metrics := make([]JobMetrics, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		metrics = append(metrics, job.metrics)
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].Name < metrics[j].Name })
+	return metrics
+}
@@ -0,0 +1,163 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestScheduler(now time.Time) *Scheduler {
+	s := NewScheduler(2, nil, log.New(io.Discard, "", 0))
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestScheduler_Register(t *testing.T) {
+	s := newTestScheduler(at("2024-05-01 10:00"))
+	noop := func(ctx context.Context) error { return nil }
+
+	if err := s.Register(Job{Name: "report", Schedule: "0 3 * * *", Run: noop}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err := s.Register(Job{Name: "report", Schedule: "@hourly", Run: noop}); err == nil {
+		t.Error("expected an error for a duplicate name")
+	}
+	if err := s.Register(Job{Name: "broken", Schedule: "nope", Run: noop}); err == nil {
+		t.Error("expected an error for an invalid schedule")
+	}
+	if err := s.Register(Job{Name: "empty", Schedule: "@hourly"}); err == nil {
+		t.Error("expected an error without a run function")
+	}
+
+	metrics := s.Metrics()
+	if len(metrics) != 1 || !metrics[0].NextRunAt.Equal(at("2024-05-02 03:00")) {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+}
+
+func TestScheduler_DispatchesDueJobsOnce(t *testing.T) {
+	s := newTestScheduler(at("2024-05-01 10:00"))
+	noop := func(ctx context.Context) error { return nil }
+	s.Register(Job{Name: "due", Schedule: "* * * * *", Run: noop})
+	s.Register(Job{Name: "later", Schedule: "@daily", Run: noop})
+	s.queue = make(chan *scheduledJob, 2)
+
+	s.dispatch(at("2024-05-01 10:01"))
+	if len(s.queue) != 1 {
+		t.Fatalf("expected 1 queued job, got %d", len(s.queue))
+	}
+
+	// Still running when it is due again
+	s.dispatch(at("2024-05-01 10:02"))
+	if len(s.queue) != 1 {
+		t.Fatalf("expected the running job not to be queued again, got %d", len(s.queue))
+	}
+
+	metrics := s.Metrics()
+	if metrics[0].Name != "due" || !metrics[0].Running || metrics[0].Skipped != 1 {
+		t.Errorf("unexpected metrics %+v", metrics[0])
+	}
+	if !metrics[0].NextRunAt.Equal(at("2024-05-01 10:03")) {
+		t.Errorf("expected next run at 10:03, got %s", metrics[0].NextRunAt)
+	}
+}
+
+func TestScheduler_RecordsOutcomes(t *testing.T) {
+	s := newTestScheduler(at("2024-05-01 10:00"))
+	calls := 0
+	s.Register(Job{Name: "flaky", Schedule: "@hourly", Run: func(ctx context.Context) error {
+		calls++
+		switch calls {
+		case 1:
+			return errors.New("db down")
+		case 2:
+			panic("nil map")
+		}
+		return nil
+	}})
+	job := s.jobs[0]
+
+	s.execute(context.Background(), job)
+	s.execute(context.Background(), job)
+	metrics := s.Metrics()[0]
+	if metrics.Runs != 2 || metrics.Failures != 2 || metrics.Panics != 1 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+	if metrics.LastError != "panic: nil map" {
+		t.Errorf("unexpected last error %q", metrics.LastError)
+	}
+
+	s.execute(context.Background(), job)
+	metrics = s.Metrics()[0]
+	if metrics.Runs != 3 || metrics.Failures != 2 || metrics.LastError != "" || metrics.Running {
+		t.Errorf("unexpected metrics after a successful run %+v", metrics)
+	}
+}
+
+func TestScheduler_RunsJobsUntilCanceled(t *testing.T) {
+	s := NewScheduler(1, nil, log.New(io.Discard, "", 0))
+	s.tick = time.Millisecond
+	var runs atomic.Int32
+	s.Register(Job{Name: "tick", Schedule: "@every 1s", Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}})
+	// Make the job due right away
+	s.jobs[0].metrics.NextRunAt = time.Now().UTC()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx)
+
+	deadline := time.Now().Add(time.Second)
+	for runs.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	s.Wait()
+
+	if runs.Load() == 0 {
+		t.Error("expected the job to run")
+	}
+}
+
+type mockLocker struct {
+	held     bool
+	released int
+}
+
+func (m *mockLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if m.held {
+		return nil, false, nil
+	}
+	return func() { m.released++ }, true, nil
+}
+
+func TestScheduler_SkipsJobsLockedElsewhere(t *testing.T) {
+	locker := &mockLocker{held: true}
+	s := NewScheduler(1, locker, log.New(io.Discard, "", 0))
+	runs := 0
+	s.Register(Job{Name: "report", Schedule: "@hourly", Run: func(ctx context.Context) error {
+		runs++
+		return nil
+	}})
+	job := s.jobs[0]
+	job.metrics.Running = true
+
+	s.execute(context.Background(), job)
+	if runs != 0 {
+		t.Fatal("expected the job not to run while another instance holds its lock")
+	}
+	if metrics := s.Metrics()[0]; metrics.Skipped != 1 || metrics.Running || metrics.Runs != 0 {
+		t.Errorf("unexpected metrics %+v", metrics)
+	}
+
+	locker.held = false
+	s.execute(context.Background(), job)
+	if runs != 1 || locker.released != 1 {
+		t.Errorf("expected the job to run once and release its lock, got %d runs and %d releases", runs, locker.released)
+	}
+}
@@ -0,0 +1,40 @@
+package notification
+
+import (
+	"context"
+	"log"
+	"strings"
+)
+
+// Notification is a message addressed to one or more recipients
+type Notification struct {
+	Recipients []string
+	Subject    string
+	Body       string
+}
+
+//go:generate go run ../../tools/mockgen -interface Notifier -out ../../testing/mocks
+
+// Notifier delivers notifications to users
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+type logNotifier struct {
+	logger *log.Logger
+}
+
+// NewLogNotifier writes notifications to the application log. It is the
+// default transport until an email or chat integration is configured.
+func NewLogNotifier(logger *log.Logger) Notifier {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &logNotifier{logger: logger}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.logger.Printf("[notification] to=%s subject=%q body=%q",
+		strings.Join(notification.Recipients, ","), notification.Subject, notification.Body)
+	return nil
+}
@@ -0,0 +1,77 @@
+package paymentprovider
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// checkParsed fails when a provider turned an event into an unknown payment
+// status or a negative amount. Nil means the event was ignored.
+func checkParsed(t *testing.T, req *entity.PaymentWebhookRequest, err error) {
+	t.Helper()
+	if err != nil {
+		if req != nil {
+			t.Fatalf("expected no request with error %v, got %+v", err, req)
+		}
+		if !errors.Is(err, entity.ErrValidation) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+		return
+	}
+	if req == nil {
+		return
+	}
+	if req.PaymentStatus != entity.Authorized && req.PaymentStatus != entity.Paid && req.PaymentStatus != entity.Failed {
+		t.Fatalf("parsed an unknown payment status %q", req.PaymentStatus)
+	}
+}
+
+// FuzzStripe sends any body with any Stripe-Signature header. Verification
+// must only pass for a signature of the secret, and parsing must not panic.
+func FuzzStripe(f *testing.F) {
+	now := time.Unix(1733876543, 0)
+	body := `{"id":"evt_1","type":"payment_intent.succeeded","created":1733876500,"data":{"object":{"amount_received":1999,"metadata":{"order_id":"` + orderID + `"}}}}`
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	f.Add([]byte(body), "t="+timestamp+",v1="+signHex("whsec", timestamp+"."+body))
+	f.Add([]byte(body), "t="+timestamp+",v1=deadbeef")
+	f.Add([]byte(`{"type":"payment_intent.payment_failed","data":{"object":{"metadata":null}}}`), "t=,v1=")
+	f.Add([]byte(`{"type":"payment_intent.succeeded","data":{"object":{"amount_received":"lots"}}}`), "t=99999999999999999999")
+	f.Add([]byte(`{"type":"customer.created"}`), ",,=,t")
+	f.Add([]byte(`not json`), "")
+
+	provider := &stripe{secret: "whsec", now: func() time.Time { return now }}
+	f.Fuzz(func(t *testing.T, body []byte, header string) {
+		r := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/stripe", nil)
+		r.Header.Set("Stripe-Signature", header)
+
+		if err := provider.Verify(r, body); err != nil && !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("expected an invalid signature, got %v", err)
+		}
+		req, err := provider.Parse(r, body)
+		checkParsed(t, req, err)
+	})
+}
+
+// FuzzPayPalParse parses any body as a PayPal event. Verification needs
+// PayPal's certificates and is left out.
+func FuzzPayPalParse(f *testing.F) {
+	f.Add([]byte(`{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","create_time":"2024-12-11T00:00:00Z","resource":{"custom_id":"` + orderID + `","amount":{"value":"19.99"}}}`))
+	f.Add([]byte(`{"id":"WH-2","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"amount":{"value":"NaN"}}}`))
+	f.Add([]byte(`{"id":"WH-3","event_type":"PAYMENT.AUTHORIZATION.CREATED","create_time":"yesterday"}`))
+	f.Add([]byte(`{"event_type":"PAYMENT.CAPTURE.DENIED","resource":null}`))
+	f.Add([]byte(`{"event_type":"BILLING.PLAN.CREATED"}`))
+	f.Add([]byte(`[]`))
+
+	provider := NewPayPal("webhook-id")
+	f.Fuzz(func(t *testing.T, body []byte) {
+		r := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/paypal", nil)
+		req, err := provider.Parse(r, body)
+		checkParsed(t, req, err)
+	})
+}
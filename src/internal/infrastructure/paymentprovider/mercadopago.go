@@ -0,0 +1,150 @@
+package paymentprovider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// mercadoPagoAPI is where the payments MercadoPago notifies are looked up
+const mercadoPagoAPI = "https://api.mercadopago.com"
+
+type mercadoPago struct {
+	secret      string
+	accessToken string
+	baseURL     string
+	client      *http.Client
+}
+
+// NewMercadoPago accepts the notifications of a MercadoPago webhook signed
+// with its secret. Notifications only name the payment, which is then looked
+// up with the access token; payments carry the order in their
+// external_reference.
+func NewMercadoPago(secret, accessToken string) Provider {
+	return &mercadoPago{
+		secret:      secret,
+		accessToken: accessToken,
+		baseURL:     mercadoPagoAPI,
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *mercadoPago) Name() string {
+	return "mercadopago"
+}
+
+// Verify checks the x-signature header: a ts timestamp and a v1 signature of
+// "id:<data.id>;request-id:<x-request-id>;ts:<ts>;", where data.id is the
+// query parameter of the notification URL. Parts MercadoPago didn't send are
+// left out of the manifest.
+func (p *mercadoPago) Verify(r *http.Request, body []byte) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(r.Header.Get("X-Signature"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "ts":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	if timestamp == "" || signature == "" {
+		return ErrInvalidSignature
+	}
+
+	var manifest strings.Builder
+	if id := r.URL.Query().Get("data.id"); id != "" {
+		fmt.Fprintf(&manifest, "id:%s;", strings.ToLower(id))
+	}
+	if requestID := r.Header.Get("X-Request-Id"); requestID != "" {
+		fmt.Fprintf(&manifest, "request-id:%s;", requestID)
+	}
+	fmt.Fprintf(&manifest, "ts:%s;", timestamp)
+
+	if !equalSignatures(signature, signHex(p.secret, manifest.String())) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+type mercadoPagoNotification struct {
+	Type string `json:"type"`
+	Data struct {
+		ID string `json:"id"`
+	} `json:"data"`
+}
+
+type mercadoPagoPayment struct {
+	ID                int64   `json:"id"`
+	Status            string  `json:"status"`
+	TransactionAmount float64 `json:"transaction_amount"`
+	ExternalReference string  `json:"external_reference"`
+}
+
+// Parse looks up the notified payment and maps its status: authorized,
+// approved for a payment of transaction_amount, and rejected. A payment goes
+// through several statuses, each one is a transaction of its own.
+func (p *mercadoPago) Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error) {
+	var notification mercadoPagoNotification
+	if err := json.Unmarshal(body, &notification); err != nil {
+		return nil, entity.ValidationError("Invalid MercadoPago notification")
+	}
+	if notification.Type != "payment" {
+		return nil, nil
+	}
+	if notification.Data.ID == "" {
+		return nil, entity.ValidationError("MercadoPago notification names no payment")
+	}
+
+	payment, err := p.payment(r, notification.Data.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &entity.PaymentWebhookRequest{
+		OrderID:       payment.ExternalReference,
+		TransactionID: fmt.Sprintf("%d:%s", payment.ID, payment.Status),
+		Timestamp:     time.Now().Unix(),
+	}
+	switch payment.Status {
+	case "authorized":
+		req.PaymentStatus = entity.Authorized
+	case "approved":
+		req.PaymentStatus = entity.Paid
+		req.Amount = payment.TransactionAmount
+	case "rejected":
+		req.PaymentStatus = entity.Failed
+	default:
+		return nil, nil
+	}
+	return req, nil
+}
+
+func (p *mercadoPago) payment(r *http.Request, id string) (*mercadoPagoPayment, error) {
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, p.baseURL+"/v1/payments/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to look up MercadoPago payment: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("MercadoPago responded with status %d to the payment lookup", resp.StatusCode)
+	}
+
+	var payment mercadoPagoPayment
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 256<<10)).Decode(&payment); err != nil {
+		return nil, fmt.Errorf("Invalid MercadoPago payment: %w", err)
+	}
+	return &payment, nil
+}
@@ -0,0 +1,265 @@
+package paymentprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// certFetcher returns the certificate PayPal signed a webhook with
+type certFetcher func(ctx context.Context, certURL string) (*x509.Certificate, error)
+
+// paypalTolerance is how far the transmission time of a PayPal webhook can be
+// from now, the window of the direct webhooks, within which its transmission
+// ID is kept as a webhook nonce
+const paypalTolerance = 5 * time.Minute
+
+type paypal struct {
+	webhookID string
+	certs     certFetcher
+	now       func() time.Time
+}
+
+// NewPayPal accepts the events of the PayPal webhook of webhookID. Their
+// signatures are verified offline against PayPal's certificate, downloaded
+// from the certificate URL of the webhook once per URL and checked against
+// the system roots. Captures and
+// authorizations carry the order in their custom_id.
+func NewPayPal(webhookID string) Provider {
+	return &paypal{webhookID: webhookID, certs: newPayPalCertCache(&http.Client{Timeout: 10 * time.Second}).get, now: time.Now}
+}
+
+func (p *paypal) Name() string {
+	return "paypal"
+}
+
+// Verify checks the SHA256withRSA signature of
+// "<transmission id>|<transmission time>|<webhook id>|<crc32 of the body>",
+// transmitted within paypalTolerance of now
+func (p *paypal) Verify(r *http.Request, body []byte) error {
+	if r.Header.Get("Paypal-Auth-Algo") != "SHA256withRSA" || r.Header.Get("Paypal-Transmission-Id") == "" {
+		return ErrInvalidSignature
+	}
+	_, sentAt := p.Transmission(r)
+	if age := p.now().Sub(sentAt); age > paypalTolerance || age < -paypalTolerance {
+		return ErrInvalidSignature
+	}
+	signature, err := base64.StdEncoding.DecodeString(r.Header.Get("Paypal-Transmission-Sig"))
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	cert, err := p.certs(r.Context(), r.Header.Get("Paypal-Cert-Url"))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return ErrInvalidSignature
+	}
+
+	message := strings.Join([]string{
+		r.Header.Get("Paypal-Transmission-Id"),
+		r.Header.Get("Paypal-Transmission-Time"),
+		p.webhookID,
+		strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10),
+	}, "|")
+	digest := sha256.Sum256([]byte(message))
+	if rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) != nil {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Transmission returns the ID and time PayPal signed the delivery with. A
+// missing or invalid time is the zero time.
+func (p *paypal) Transmission(r *http.Request) (string, time.Time) {
+	sentAt, _ := time.Parse(time.RFC3339, r.Header.Get("Paypal-Transmission-Time"))
+	return r.Header.Get("Paypal-Transmission-Id"), sentAt
+}
+
+type paypalEvent struct {
+	ID         string    `json:"id"`
+	EventType  string    `json:"event_type"`
+	CreateTime time.Time `json:"create_time"`
+	Resource   struct {
+		CustomID string `json:"custom_id"`
+		Amount   struct {
+			Value string `json:"value"`
+		} `json:"amount"`
+	} `json:"resource"`
+}
+
+// Parse maps the authorization created, capture completed and capture denied
+// events
+func (p *paypal) Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error) {
+	var event paypalEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, entity.ValidationError("Invalid PayPal event")
+	}
+
+	req := &entity.PaymentWebhookRequest{
+		OrderID:       event.Resource.CustomID,
+		TransactionID: event.ID,
+		Timestamp:     event.CreateTime.Unix(),
+	}
+	switch event.EventType {
+	case "PAYMENT.AUTHORIZATION.CREATED":
+		req.PaymentStatus = entity.Authorized
+	case "PAYMENT.CAPTURE.COMPLETED":
+		amount, err := strconv.ParseFloat(event.Resource.Amount.Value, 64)
+		if err != nil {
+			return nil, entity.ValidationError("Invalid PayPal capture amount")
+		}
+		req.PaymentStatus = entity.Paid
+		req.Amount = amount
+	case "PAYMENT.CAPTURE.DENIED":
+		req.PaymentStatus = entity.Failed
+	default:
+		return nil, nil
+	}
+	return req, nil
+}
+
+// maxPayPalCerts bounds the certificates kept. PayPal signs with one
+// certificate at a time, so a handful covers a rotation.
+const maxPayPalCerts = 8
+
+// payPalCertCache downloads PayPal's signing certificates, only from
+// paypal.com over HTTPS, and keeps them until they expire. A certificate is
+// only trusted when it chains to roots, the system roots when nil, and is
+// issued to a paypal.com host.
+type payPalCertCache struct {
+	client *http.Client
+	roots  *x509.CertPool
+	mu     sync.Mutex
+	certs  map[string]*x509.Certificate
+}
+
+func newPayPalCertCache(client *http.Client) *payPalCertCache {
+	return &payPalCertCache{client: client, certs: make(map[string]*x509.Certificate)}
+}
+
+func (c *payPalCertCache) get(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	c.mu.Lock()
+	cert, ok := c.certs[certURL]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(u.Hostname(), ".paypal.com") {
+		return nil, fmt.Errorf("certificate URL %q is not PayPal's", certURL)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("PayPal certificate responded with status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err != nil {
+		return nil, err
+	}
+	cert, err = c.verify(data, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	c.put(certURL, cert, time.Now())
+	return cert, nil
+}
+
+// verify parses the PEM certificate chain PayPal serves, the signing
+// certificate first, and checks it chains to the roots and names a
+// paypal.com host
+func (c *payPalCertCache) verify(data []byte, now time.Time) (*x509.Certificate, error) {
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("PayPal certificate is not PEM")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	leaf := chain[0]
+	if _, err := leaf.Verify(x509.VerifyOptions{Roots: c.roots, Intermediates: intermediates, CurrentTime: now}); err != nil {
+		return nil, fmt.Errorf("PayPal certificate is not trusted: %w", err)
+	}
+	// VerifyOptions.DNSName only matches a "*.paypal.com" pattern literally,
+	// so the names are checked against the domain here
+	if !namesPayPalHost(leaf) {
+		return nil, fmt.Errorf("PayPal certificate is not issued to a paypal.com host")
+	}
+	return leaf, nil
+}
+
+// put caches cert for certURL, making room first by dropping the expired
+// certificates, then the one expiring first
+func (c *payPalCertCache) put(certURL string, cert *x509.Certificate, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.certs[certURL]; !ok && len(c.certs) >= maxPayPalCerts {
+		var first string
+		for cached, other := range c.certs {
+			if !now.Before(other.NotAfter) {
+				delete(c.certs, cached)
+				continue
+			}
+			if first == "" || other.NotAfter.Before(c.certs[first].NotAfter) {
+				first = cached
+			}
+		}
+		if len(c.certs) >= maxPayPalCerts {
+			delete(c.certs, first)
+		}
+	}
+	c.certs[certURL] = cert
+}
+
+func namesPayPalHost(cert *x509.Certificate) bool {
+	for _, name := range cert.DNSNames {
+		if strings.HasSuffix(strings.ToLower(name), ".paypal.com") {
+			return true
+		}
+	}
+	return false
+}
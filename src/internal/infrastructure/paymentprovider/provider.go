@@ -0,0 +1,85 @@
+package paymentprovider
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// ErrInvalidSignature is returned when a webhook wasn't signed by the provider
+var ErrInvalidSignature = errors.New("invalid payment provider signature")
+
+//go:generate go run ../../tools/mockgen -interface Provider -out ../../testing/mocks -name PaymentProvider
+
+// Provider verifies the webhooks of a payment provider and normalizes them
+// into payment webhooks
+type Provider interface {
+	// Name is the provider in the webhook URL, /api/payment-webhook/{name}
+	Name() string
+
+	// Verify checks that the provider sent the webhook, returning
+	// ErrInvalidSignature when it didn't
+	Verify(r *http.Request, body []byte) error
+
+	// Parse returns the payment webhook of the body, or nil for events that
+	// aren't about the payment of an order
+	Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error)
+}
+
+// Transmitter is implemented by the providers that sign a unique ID into each
+// delivery of a webhook. The ID is claimed as a webhook nonce, so a delivery
+// can't be replayed while its signature is recent enough to be accepted.
+type Transmitter interface {
+	// Transmission returns the ID and time of the delivery
+	Transmission(r *http.Request) (id string, sentAt time.Time)
+}
+
+// Registry holds the payment providers webhooks are accepted from
+type Registry struct {
+	mu        sync.RWMutex
+	providers map[string]Provider
+}
+
+func NewRegistry(providers ...Provider) *Registry {
+	r := &Registry{providers: make(map[string]Provider, len(providers))}
+	for _, provider := range providers {
+		r.Register(provider)
+	}
+	return r
+}
+
+// Register adds the provider, replacing the one of the same name
+func (r *Registry) Register(provider Provider) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers[provider.Name()] = provider
+}
+
+// Get returns the provider of the given name. A nil registry has none.
+func (r *Registry) Get(name string) (Provider, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	provider, ok := r.providers[name]
+	return provider, ok
+}
+
+// signHex is the hex encoded HMAC-SHA256 of message
+func signHex(secret, message string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// equalSignatures compares signatures in constant time
+func equalSignatures(a, b string) bool {
+	return hmac.Equal([]byte(a), []byte(b))
+}
@@ -0,0 +1,286 @@
+package paymentprovider
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"hash/crc32"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+const orderID = "123e4567-e89b-12d3-a456-426614174000"
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry(NewStripe("whsec"))
+
+	if _, ok := registry.Get("stripe"); !ok {
+		t.Error("expected stripe to be registered")
+	}
+	if _, ok := registry.Get("paypal"); ok {
+		t.Error("expected paypal not to be registered")
+	}
+	if _, ok := (*Registry)(nil).Get("stripe"); ok {
+		t.Error("expected a nil registry to have no providers")
+	}
+}
+
+func TestStripe(t *testing.T) {
+	now := time.Unix(1733876543, 0)
+	provider := &stripe{secret: "whsec", now: func() time.Time { return now }}
+	body := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","created":1733876500,"data":{"object":{"amount_received":1999,"metadata":{"order_id":"` + orderID + `"}}}}`)
+
+	signed := func(at time.Time, secret string) *http.Request {
+		timestamp := strconv.FormatInt(at.Unix(), 10)
+		r := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/stripe", nil)
+		r.Header.Set("Stripe-Signature", "t="+timestamp+",v1=deadbeef,v1="+signHex(secret, timestamp+"."+string(body)))
+		return r
+	}
+
+	t.Run("Accepts any v1 signature of the secret", func(t *testing.T) {
+		if err := provider.Verify(signed(now, "whsec"), body); err != nil {
+			t.Errorf("expected the signature to verify, got %v", err)
+		}
+	})
+
+	t.Run("Rejects other secrets and old signatures", func(t *testing.T) {
+		if err := provider.Verify(signed(now, "other"), body); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected an invalid signature, got %v", err)
+		}
+		if err := provider.Verify(signed(now.Add(-10*time.Minute), "whsec"), body); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected an old signature to be rejected, got %v", err)
+		}
+	})
+
+	t.Run("Parses payment intents", func(t *testing.T) {
+		req, err := provider.Parse(signed(now, "whsec"), body)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if req.OrderID != orderID || req.TransactionID != "evt_1" || req.PaymentStatus != entity.Paid || req.Amount != 19.99 {
+			t.Errorf("unexpected payment webhook %+v", req)
+		}
+
+		req, err = provider.Parse(signed(now, "whsec"), []byte(`{"id":"evt_2","type":"customer.created"}`))
+		if err != nil || req != nil {
+			t.Errorf("expected other events to be ignored, got %+v, %v", req, err)
+		}
+	})
+}
+
+func TestPayPal(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "messageverificationcerts.paypal.com"}, NotAfter: time.Now().Add(time.Hour)}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Date(2024, 12, 11, 0, 1, 0, 0, time.UTC)
+	provider := &paypal{webhookID: "WH-1", certs: func(ctx context.Context, certURL string) (*x509.Certificate, error) { return cert, nil }, now: func() time.Time { return now }}
+	body := []byte(`{"id":"WH-EVT-1","event_type":"PAYMENT.CAPTURE.COMPLETED","create_time":"2024-12-11T00:00:00Z","resource":{"custom_id":"` + orderID + `","amount":{"value":"25.50","currency_code":"USD"}}}`)
+
+	signedAt := func(webhookID, transmissionTime string) *http.Request {
+		message := "tx-1|" + transmissionTime + "|" + webhookID + "|" + strconv.FormatUint(uint64(crc32.ChecksumIEEE(body)), 10)
+		digest := sha256.Sum256([]byte(message))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/paypal", nil)
+		r.Header.Set("Paypal-Auth-Algo", "SHA256withRSA")
+		r.Header.Set("Paypal-Transmission-Id", "tx-1")
+		r.Header.Set("Paypal-Transmission-Time", transmissionTime)
+		r.Header.Set("Paypal-Transmission-Sig", base64.StdEncoding.EncodeToString(signature))
+		r.Header.Set("Paypal-Cert-Url", "https://api.paypal.com/v1/notifications/certs/CERT-1")
+		return r
+	}
+	signed := func(webhookID string) *http.Request {
+		return signedAt(webhookID, "2024-12-11T00:00:01Z")
+	}
+
+	if err := provider.Verify(signed("WH-1"), body); err != nil {
+		t.Errorf("expected the signature to verify, got %v", err)
+	}
+	if err := provider.Verify(signed("WH-2"), body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected a signature for another webhook to be rejected, got %v", err)
+	}
+	for _, stale := range []string{"2024-12-10T23:50:00Z", "2024-12-11T00:10:00Z", "yesterday"} {
+		if err := provider.Verify(signedAt("WH-1", stale), body); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("expected a transmission at %s to be rejected, got %v", stale, err)
+		}
+	}
+
+	id, sentAt := provider.Transmission(signed("WH-1"))
+	if id != "tx-1" || !sentAt.Equal(time.Date(2024, 12, 11, 0, 0, 1, 0, time.UTC)) {
+		t.Errorf("unexpected transmission %s at %v", id, sentAt)
+	}
+
+	req, err := provider.Parse(signed("WH-1"), body)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if req.OrderID != orderID || req.TransactionID != "WH-EVT-1" || req.PaymentStatus != entity.Paid || req.Amount != 25.5 {
+		t.Errorf("unexpected payment webhook %+v", req)
+	}
+}
+
+func TestPayPalCertCache_OnlyFromPayPal(t *testing.T) {
+	cache := newPayPalCertCache(http.DefaultClient)
+	for _, certURL := range []string{"https://evil.example.com/cert", "http://api.paypal.com/cert", "https://paypal.com.evil.example.com/cert"} {
+		if _, err := cache.get(context.Background(), certURL); err == nil {
+			t.Errorf("expected %s to be refused", certURL)
+		}
+	}
+}
+
+// issue creates a certificate for names signed by parent, self-signed when nil
+func issue(t *testing.T, parent *x509.Certificate, parentKey *rsa.PrivateKey, ca bool, names ...string) (*x509.Certificate, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "test"},
+		DNSNames:              names,
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  ca,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return cert, key
+}
+
+func TestPayPalCertCache_Verify(t *testing.T) {
+	root, rootKey := issue(t, nil, nil, true)
+	roots := x509.NewCertPool()
+	roots.AddCert(root)
+	cache := &payPalCertCache{roots: roots, certs: make(map[string]*x509.Certificate)}
+	encode := func(cert *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+
+	trusted, _ := issue(t, root, rootKey, false, "messageverificationcerts.paypal.com")
+	if _, err := cache.verify(encode(trusted), time.Now()); err != nil {
+		t.Errorf("expected a PayPal certificate from a trusted root to verify, got %v", err)
+	}
+
+	selfSigned, _ := issue(t, nil, nil, false, "messageverificationcerts.paypal.com")
+	if _, err := cache.verify(encode(selfSigned), time.Now()); err == nil {
+		t.Error("expected a self-signed certificate to be refused")
+	}
+
+	other, _ := issue(t, root, rootKey, false, "messageverificationcerts.example.com")
+	if _, err := cache.verify(encode(other), time.Now()); err == nil {
+		t.Error("expected a certificate of another domain to be refused")
+	}
+
+	intermediate, intermediateKey := issue(t, root, rootKey, true)
+	chained, _ := issue(t, intermediate, intermediateKey, false, "messageverificationcerts.paypal.com")
+	if _, err := cache.verify(append(encode(chained), encode(intermediate)...), time.Now()); err != nil {
+		t.Errorf("expected a certificate served with its intermediate to verify, got %v", err)
+	}
+
+	if _, err := cache.verify(encode(trusted), time.Now().Add(2*time.Hour)); err == nil {
+		t.Error("expected an expired certificate to be refused")
+	}
+}
+
+func TestPayPalCertCache_Bounded(t *testing.T) {
+	cache := newPayPalCertCache(http.DefaultClient)
+	now := time.Now()
+	for i := 0; i <= maxPayPalCerts; i++ {
+		cert := &x509.Certificate{NotAfter: now.Add(time.Duration(i+1) * time.Hour)}
+		cache.put("https://api.paypal.com/cert/"+strconv.Itoa(i), cert, now)
+	}
+
+	if len(cache.certs) != maxPayPalCerts {
+		t.Fatalf("expected %d certificates kept, got %d", maxPayPalCerts, len(cache.certs))
+	}
+	if _, ok := cache.certs["https://api.paypal.com/cert/0"]; ok {
+		t.Error("expected the certificate expiring first to be dropped")
+	}
+	if _, ok := cache.certs["https://api.paypal.com/cert/"+strconv.Itoa(maxPayPalCerts)]; !ok {
+		t.Error("expected the new certificate to be kept")
+	}
+}
+
+func TestMercadoPago(t *testing.T) {
+	var gotAuth string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		if r.URL.Path != "/v1/payments/42" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"id":42,"status":"approved","transaction_amount":150.25,"external_reference":"` + orderID + `"}`))
+	}))
+	defer api.Close()
+
+	provider := &mercadoPago{secret: "mp-secret", accessToken: "token", baseURL: api.URL, client: api.Client()}
+	body := []byte(`{"type":"payment","action":"payment.updated","data":{"id":"42"}}`)
+
+	signed := func(secret string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/api/payment-webhook/mercadopago?data.id=42&type=payment", nil)
+		r.Header.Set("X-Request-Id", "req-1")
+		r.Header.Set("X-Signature", "ts=1733876543,v1="+signHex(secret, "id:42;request-id:req-1;ts:1733876543;"))
+		return r
+	}
+
+	if err := provider.Verify(signed("mp-secret"), body); err != nil {
+		t.Errorf("expected the signature to verify, got %v", err)
+	}
+	if err := provider.Verify(signed("other"), body); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected an invalid signature, got %v", err)
+	}
+
+	req, err := provider.Parse(signed("mp-secret"), body)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if gotAuth != "Bearer token" {
+		t.Errorf("Authorization = %q, want the access token", gotAuth)
+	}
+	if req.OrderID != orderID || req.TransactionID != "42:approved" || req.PaymentStatus != entity.Paid || req.Amount != 150.25 {
+		t.Errorf("unexpected payment webhook %+v", req)
+	}
+
+	req, err = provider.Parse(signed("mp-secret"), []byte(`{"type":"plan","data":{"id":"7"}}`))
+	if err != nil || req != nil {
+		t.Errorf("expected other notifications to be ignored, got %+v, %v", req, err)
+	}
+}
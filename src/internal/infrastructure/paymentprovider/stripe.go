@@ -0,0 +1,104 @@
+package paymentprovider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// stripeTolerance is how old the signature of a Stripe webhook can be, the
+// default of Stripe's libraries
+const stripeTolerance = 5 * time.Minute
+
+type stripe struct {
+	secret string
+	now    func() time.Time
+}
+
+// NewStripe accepts the events of a Stripe webhook endpoint signed with its
+// signing secret. Payment intents carry the order in their order_id metadata.
+func NewStripe(secret string) Provider {
+	return &stripe{secret: secret, now: time.Now}
+}
+
+func (p *stripe) Name() string {
+	return "stripe"
+}
+
+// Verify checks the Stripe-Signature header: a t timestamp and v1 signatures
+// of "<t>.<body>". Stripe sends one v1 signature per active secret while a
+// secret is rolled.
+func (p *stripe) Verify(r *http.Request, body []byte) error {
+	var timestamp string
+	var signatures []string
+	for _, part := range strings.Split(r.Header.Get("Stripe-Signature"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signatures = append(signatures, value)
+		}
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrInvalidSignature
+	}
+	if age := p.now().Sub(time.Unix(seconds, 0)); age > stripeTolerance || age < -stripeTolerance {
+		return ErrInvalidSignature
+	}
+
+	expected := signHex(p.secret, timestamp+"."+string(body))
+	for _, signature := range signatures {
+		if equalSignatures(signature, expected) {
+			return nil
+		}
+	}
+	return ErrInvalidSignature
+}
+
+type stripeEvent struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Created int64  `json:"created"`
+	Data    struct {
+		Object struct {
+			AmountReceived int64             `json:"amount_received"` // In cents
+			Metadata       map[string]string `json:"metadata"`
+		} `json:"object"`
+	} `json:"data"`
+}
+
+// Parse maps the payment intent events: amount_capturable_updated is an
+// authorization, succeeded a payment of amount_received and payment_failed a
+// failure. Amounts are read in cents, so zero-decimal currencies aren't
+// supported.
+func (p *stripe) Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error) {
+	var event stripeEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, entity.ValidationError("Invalid Stripe event")
+	}
+
+	req := &entity.PaymentWebhookRequest{
+		OrderID:       event.Data.Object.Metadata["order_id"],
+		TransactionID: event.ID,
+		Timestamp:     event.Created,
+	}
+	switch event.Type {
+	case "payment_intent.amount_capturable_updated":
+		req.PaymentStatus = entity.Authorized
+	case "payment_intent.succeeded":
+		req.PaymentStatus = entity.Paid
+		req.Amount = float64(event.Data.Object.AmountReceived) / 100
+	case "payment_intent.payment_failed":
+		req.PaymentStatus = entity.Failed
+	default:
+		return nil, nil
+	}
+	return req, nil
+}
@@ -0,0 +1,68 @@
+package refund
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHTTPGateway_Refund(t *testing.T) {
+	var gotBody []byte
+	var gotSignature, gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotSignature = r.Header.Get("X-Signature")
+		gotKey = r.Header.Get("Idempotency-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"refund_id": "re_123"}`))
+	}))
+	defer server.Close()
+
+	orderID := uuid.New()
+	reference, err := NewHTTPGateway(server.URL, "secret").Refund(context.Background(), Request{
+		OrderID: orderID, TransactionID: "txn_1", Amount: 19.99, IdempotencyKey: "return-1", Reason: "damaged",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if reference != "re_123" {
+		t.Errorf("reference = %q, want re_123", reference)
+	}
+	if gotKey != "return-1" {
+		t.Errorf("Idempotency-Key = %q, want return-1", gotKey)
+	}
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(gotBody)
+	if gotSignature != hex.EncodeToString(mac.Sum(nil)) {
+		t.Error("signature does not match the body")
+	}
+
+	var payload refundPayload
+	if err := json.Unmarshal(gotBody, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.OrderID != orderID.String() || payload.TransactionID != "txn_1" || payload.Amount != 19.99 {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
+
+func TestHTTPGateway_RejectedRefund(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusPaymentRequired)
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPGateway(server.URL, "secret").Refund(context.Background(), Request{OrderID: uuid.New(), Amount: 5, IdempotencyKey: "k"})
+	if err == nil {
+		t.Fatal("expected an error for a rejected refund")
+	}
+}
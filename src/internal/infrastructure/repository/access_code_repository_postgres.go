@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AccessCodeRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAccessCodeRepository(db *gorm.DB) repository.AccessCodeRepository {
+	return &AccessCodeRepositoryPostgres{db: db}
+}
+
+func (r *AccessCodeRepositoryPostgres) Create(ctx context.Context, code *entity.AccessCode) error {
+	return r.db.WithContext(ctx).Create(code).Error
+}
+
+func (r *AccessCodeRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.AccessCode, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *AccessCodeRepositoryPostgres) GetByCode(ctx context.Context, code string) (*entity.AccessCode, error) {
+	return r.first(ctx, "code = ?", code)
+}
+
+func (r *AccessCodeRepositoryPostgres) first(ctx context.Context, query string, value interface{}) (*entity.AccessCode, error) {
+	var code entity.AccessCode
+	if err := r.db.WithContext(ctx).First(&code, query, value).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Access code not found")
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *AccessCodeRepositoryPostgres) Update(ctx context.Context, code *entity.AccessCode) error {
+	code.UpdatedAt = time.Now()
+	result := r.db.WithContext(ctx).Model(&entity.AccessCode{}).
+		Where("id = ?", code.ID).
+		Updates(map[string]interface{}{
+			"note":       code.Note,
+			"max_uses":   code.MaxUses,
+			"expires_at": code.ExpiresAt,
+			"active":     code.Active,
+			"updated_at": code.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Access code not found")
+	}
+	return nil
+}
+
+func (r *AccessCodeRepositoryPostgres) Redeem(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Model(&entity.AccessCode{}).
+		Where("id = ? AND (max_uses = 0 OR uses < max_uses)", id).
+		Updates(map[string]interface{}{
+			"uses":       gorm.Expr("uses + 1"),
+			"updated_at": time.Now(),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ConflictError("This access code has been used up")
+	}
+	return nil
+}
+
+func (r *AccessCodeRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.AccessCode{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Access code not found")
+	}
+	return nil
+}
+
+func (r *AccessCodeRepositoryPostgres) List(ctx context.Context) ([]*entity.AccessCode, error) {
+	var codes []*entity.AccessCode
+	err := r.db.WithContext(ctx).Order("created_at DESC, id").Find(&codes).Error
+	return codes, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AdminAlertRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAdminAlertRepository(db *gorm.DB) repository.AdminAlertRepository {
+	return &AdminAlertRepositoryPostgres{db: db}
+}
+
+func (r *AdminAlertRepositoryPostgres) Create(ctx context.Context, alert *entity.AdminAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+func (r *AdminAlertRepositoryPostgres) List(ctx context.Context, rule *entity.AlertRule, page, pageSize int) ([]*entity.AdminAlert, int, error) {
+	var alerts []*entity.AdminAlert
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.AdminAlert{})
+	if rule != nil {
+		query = query.Where("rule = ?", *rule)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&alerts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return alerts, int(total), nil
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// salesOrders are the live and archived orders, with the columns the reports use
+const salesOrders = `(
+	SELECT created_at, user_id, status, payment_status, total_price FROM orders
+	UNION ALL
+	SELECT order_created_at, user_id, status, payment_status, total_price FROM archived_orders
+) AS sales`
+
+// salesItems are the order lines of live and archived orders. Archived lines
+// are read from the order snapshot.
+const salesItems = `(
+	SELECT order_items.product_id, order_items.quantity, order_items.total_price,
+		orders.created_at, orders.user_id, orders.status, orders.payment_status
+	FROM order_items
+	JOIN orders ON orders.id = order_items.order_id
+	UNION ALL
+	SELECT (item->>'ProductID')::uuid, (item->>'Quantity')::int, (item->>'TotalPrice')::numeric,
+		archived_orders.order_created_at, archived_orders.user_id, archived_orders.status, archived_orders.payment_status
+	FROM archived_orders
+	CROSS JOIN jsonb_array_elements(archived_orders.snapshot->'Products') AS item
+) AS sold`
+
+// Orders count towards revenue with a paid status and none of the void ones
+var (
+	paidStatuses = []entity.PaymentStatus{entity.Paid, entity.PartiallyRefunded}
+	voidStatuses = []entity.OrderStatus{entity.Cancelled, entity.Refunded}
+)
+
+type AnalyticsRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAnalyticsRepository(db *gorm.DB) repository.AnalyticsRepository {
+	return &AnalyticsRepositoryPostgres{db: db}
+}
+
+// paidSales narrows a query over salesOrders or salesItems to the revenue
+func paidSales(query *gorm.DB, table string, from, until time.Time) *gorm.DB {
+	return paid(query.Where(table+".created_at >= ? AND "+table+".created_at < ?", from, until), table)
+}
+
+// paid narrows a query over salesOrders or salesItems to the orders that
+// count towards revenue
+func paid(query *gorm.DB, table string) *gorm.DB {
+	return query.
+		Where(table+".payment_status IN ?", paidStatuses).
+		Where(table+".status NOT IN ?", voidStatuses)
+}
+
+func (r *AnalyticsRepositoryPostgres) RevenueByPeriod(ctx context.Context, period entity.AnalyticsPeriod, from, until time.Time, loc *time.Location) ([]entity.RevenuePoint, error) {
+	var rows []struct {
+		PeriodStart time.Time
+		Orders      int
+		Revenue     float64
+	}
+	// The periods are truncated on the wall clock of loc, which Postgres
+	// knows by its IANA name
+	query := r.db.WithContext(ctx).
+		Table(salesOrders).
+		Select("date_trunc(?, sales.created_at AT TIME ZONE ?) AS period_start, COUNT(*) AS orders, COALESCE(SUM(sales.total_price), 0) AS revenue", string(period), loc.String())
+	err := paidSales(query, "sales", from, until).
+		Group("period_start").
+		Order("period_start").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]entity.RevenuePoint, 0, len(rows))
+	for _, row := range rows {
+		points = append(points, entity.RevenuePoint{
+			PeriodStart: time.Date(row.PeriodStart.Year(), row.PeriodStart.Month(), row.PeriodStart.Day(), 0, 0, 0, 0, loc),
+			Orders:      row.Orders,
+			Revenue:     row.Revenue,
+		})
+	}
+	return points, nil
+}
+
+func (r *AnalyticsRepositoryPostgres) TopProducts(ctx context.Context, from, until time.Time, limit int) ([]entity.ProductSales, error) {
+	return r.topProducts(ctx, salesItems, from, until, limit)
+}
+
+// topProducts ranks the products of items, a table of order lines like salesItems
+func (r *AnalyticsRepositoryPostgres) topProducts(ctx context.Context, items string, from, until time.Time, limit int) ([]entity.ProductSales, error) {
+	var sales []entity.ProductSales
+	query := r.db.WithContext(ctx).
+		Table(items).
+		Select("sold.product_id, COALESCE(MAX(products.name), '') AS name, SUM(sold.quantity) AS units, COALESCE(SUM(sold.total_price), 0) AS revenue").
+		Joins("LEFT JOIN products ON products.id = sold.product_id")
+	err := paidSales(query, "sold", from, until).
+		Group("sold.product_id").
+		Order("units DESC, revenue DESC").
+		Limit(limit).
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
+
+func (r *AnalyticsRepositoryPostgres) CountOrdersByStatus(ctx context.Context, from, until time.Time) ([]entity.OrderStatusCount, error) {
+	var counts []entity.OrderStatusCount
+	err := r.db.WithContext(ctx).
+		Table(salesOrders).
+		Select("sales.status, COUNT(*) AS count").
+		Where("sales.created_at >= ? AND sales.created_at < ?", from, until).
+		Group("sales.status").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+func (r *AnalyticsRepositoryPostgres) SumRevenue(ctx context.Context, from, until time.Time) (int, float64, error) {
+	var row struct {
+		Orders  int
+		Revenue float64
+	}
+	query := r.db.WithContext(ctx).
+		Table(salesOrders).
+		Select("COUNT(*) AS orders, COALESCE(SUM(sales.total_price), 0) AS revenue")
+	if err := paidSales(query, "sales", from, until).Scan(&row).Error; err != nil {
+		return 0, 0, err
+	}
+	return row.Orders, row.Revenue, nil
+}
+
+func (r *AnalyticsRepositoryPostgres) CountNewCustomers(ctx context.Context, from, until time.Time) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&entity.User{}).
+		Where("role = ?", entity.RoleCustomer).
+		Where("created_at >= ? AND created_at < ?", from, until).
+		Count(&count).Error
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (r *AnalyticsRepositoryPostgres) SummarizeCustomer(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	return r.summarizeCustomer(ctx, "EXTRACT(EPOCH FROM MAX(sales.created_at))", userID)
+}
+
+// summarizeCustomer sums up the account's orders, reading the time of the
+// last one as seconds since the epoch with lastOrder, as the databases keep
+// times differently
+func (r *AnalyticsRepositoryPostgres) summarizeCustomer(ctx context.Context, lastOrder string, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	var row struct {
+		Orders     int
+		PaidOrders int
+		Spend      float64
+		LastOrder  *float64
+	}
+	err := r.db.WithContext(ctx).
+		Table(salesOrders).
+		Select(`COUNT(*) AS orders,
+			COUNT(*) FILTER (WHERE sales.payment_status IN ? AND sales.status NOT IN ?) AS paid_orders,
+			COALESCE(SUM(sales.total_price) FILTER (WHERE sales.payment_status IN ? AND sales.status NOT IN ?), 0) AS spend,
+			`+lastOrder+` AS last_order`,
+			paidStatuses, voidStatuses, paidStatuses, voidStatuses).
+		Where("sales.user_id = ?", userID).
+		Scan(&row).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var lastOrderAt *time.Time
+	if row.LastOrder != nil {
+		at := time.Unix(int64(*row.LastOrder), 0).UTC()
+		lastOrderAt = &at
+	}
+	return entity.NewCustomerSummary(row.Orders, row.PaidOrders, row.Spend, lastOrderAt), nil
+}
+
+func (r *AnalyticsRepositoryPostgres) FavoriteCategories(ctx context.Context, userID uuid.UUID, limit int) ([]entity.CategorySales, error) {
+	return r.favoriteCategories(ctx, salesItems, userID, limit)
+}
+
+// favoriteCategories ranks the categories of the account's lines in items, a
+// table of order lines like salesItems
+func (r *AnalyticsRepositoryPostgres) favoriteCategories(ctx context.Context, items string, userID uuid.UUID, limit int) ([]entity.CategorySales, error) {
+	var sales []entity.CategorySales
+	query := r.db.WithContext(ctx).
+		Table(items).
+		Select("categories.id AS category_id, categories.name, SUM(sold.quantity) AS units, COALESCE(SUM(sold.total_price), 0) AS spend").
+		Joins("JOIN product_categories ON product_categories.product_id = sold.product_id").
+		Joins("JOIN categories ON categories.id = product_categories.category_id AND categories.deleted_at IS NULL").
+		Where("sold.user_id = ?", userID)
+	err := paid(query, "sold").
+		Group("categories.id, categories.name").
+		Order("units DESC, spend DESC").
+		Limit(limit).
+		Scan(&sales).Error
+	if err != nil {
+		return nil, err
+	}
+	return sales, nil
+}
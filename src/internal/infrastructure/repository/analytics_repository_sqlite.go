@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// salesItemsSQLite is salesItems reading the archived lines with json_each
+const salesItemsSQLite = `(
+	SELECT order_items.product_id, order_items.quantity, order_items.total_price,
+		orders.created_at, orders.user_id, orders.status, orders.payment_status
+	FROM order_items
+	JOIN orders ON orders.id = order_items.order_id
+	UNION ALL
+	SELECT json_extract(item.value, '$.ProductID'), json_extract(item.value, '$.Quantity'), json_extract(item.value, '$.TotalPrice'),
+		archived_orders.order_created_at, archived_orders.user_id, archived_orders.status, archived_orders.payment_status
+	FROM archived_orders, json_each(archived_orders.snapshot, '$.Products') AS item
+) AS sold`
+
+// slotSQLite is the length of the slots SQLite sums the orders in before
+// they are put into periods. SQLite knows no time zones, but every zone is a
+// whole number of quarter hours off UTC, so a slot never straddles midnight.
+const slotSQLite = 15 * time.Minute
+
+// AnalyticsRepositorySQLite runs the reports on SQLite, which has neither
+// date_trunc, time zones nor the jsonb operators
+type AnalyticsRepositorySQLite struct {
+	*AnalyticsRepositoryPostgres
+}
+
+func NewAnalyticsRepositorySQLite(db *gorm.DB) repository.AnalyticsRepository {
+	return &AnalyticsRepositorySQLite{&AnalyticsRepositoryPostgres{db: db}}
+}
+
+func (r *AnalyticsRepositorySQLite) RevenueByPeriod(ctx context.Context, period entity.AnalyticsPeriod, from, until time.Time, loc *time.Location) ([]entity.RevenuePoint, error) {
+	var rows []struct {
+		Slot    int64
+		Orders  int
+		Revenue float64
+	}
+	slot := int64(slotSQLite / time.Second)
+	query := r.db.WithContext(ctx).
+		Table(salesOrders).
+		Select("CAST(strftime('%s', sales.created_at) AS INTEGER) / ? AS slot, COUNT(*) AS orders, COALESCE(SUM(sales.total_price), 0) AS revenue", slot)
+	err := paidSales(query, "sales", from, until).
+		Group("slot").
+		Order("slot").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var points []entity.RevenuePoint
+	for _, row := range rows {
+		start := period.Start(time.Unix(row.Slot*slot, 0).In(loc))
+		if n := len(points); n > 0 && points[n-1].PeriodStart.Equal(start) {
+			points[n-1].Orders += row.Orders
+			points[n-1].Revenue = math.Round((points[n-1].Revenue+row.Revenue)*100) / 100
+			continue
+		}
+		points = append(points, entity.RevenuePoint{PeriodStart: start, Orders: row.Orders, Revenue: row.Revenue})
+	}
+	return points, nil
+}
+
+func (r *AnalyticsRepositorySQLite) TopProducts(ctx context.Context, from, until time.Time, limit int) ([]entity.ProductSales, error) {
+	return r.topProducts(ctx, salesItemsSQLite, from, until, limit)
+}
+
+func (r *AnalyticsRepositorySQLite) SummarizeCustomer(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	return r.summarizeCustomer(ctx, "MAX(CAST(strftime('%s', sales.created_at) AS INTEGER))", userID)
+}
+
+func (r *AnalyticsRepositorySQLite) FavoriteCategories(ctx context.Context, userID uuid.UUID, limit int) ([]entity.CategorySales, error) {
+	return r.favoriteCategories(ctx, salesItemsSQLite, userID, limit)
+}
@@ -0,0 +1,83 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyticsRepositorySQLite_Customer(t *testing.T) {
+	db := newSQLiteDB(t)
+	analytics := NewAnalyticsRepositorySQLite(db)
+	ctx := context.Background()
+
+	userID, otherID := uuid.New(), uuid.New()
+	for i, id := range []uuid.UUID{userID, otherID} {
+		user := entity.User{ID: id, Email: []string{"jane@example.com", "john@example.com"}[i], Role: entity.RoleCustomer}
+		require.NoError(t, db.Create(&user).Error)
+	}
+
+	books := entity.Category{Name: "Books", Slug: "books"}
+	games := entity.Category{Name: "Games", Slug: "games"}
+	music := entity.Category{Name: "Music", Slug: "music"}
+	require.NoError(t, db.Create([]*entity.Category{&books, &games, &music}).Error)
+	guide := &entity.Product{Name: "Board Game Guide", Price: 20, Categories: []entity.Category{books, games}}
+	chess := &entity.Product{Name: "Chess", Price: 10, Categories: []entity.Category{games}}
+	album := &entity.Product{Name: "Album", Price: 15, Categories: []entity.Category{music}}
+	require.NoError(t, db.Create([]*entity.Product{guide, chess, album}).Error)
+
+	orders := NewOrderRepositoryPostgres(db)
+	place := func(userID uuid.UUID, status entity.OrderStatus, createdAt time.Time, items ...entity.OrderItem) {
+		order := &entity.Order{CustomerID: 1, UserID: &userID, Status: status, PaymentStatus: entity.Paid, CreatedAt: createdAt, Products: items}
+		for _, item := range items {
+			order.TotalPrice += item.TotalPrice
+		}
+		require.NoError(t, orders.Create(ctx, order))
+	}
+	line := func(product *entity.Product, quantity int) entity.OrderItem {
+		return entity.OrderItem{ID: uuid.New(), ProductID: product.ID, Quantity: quantity, Price: product.Price, TotalPrice: product.Price * float64(quantity)}
+	}
+
+	// The oldest order is archived and its lines read from the snapshot
+	place(userID, entity.Completed, time.Now().AddDate(-2, 0, 0), line(album, 1), line(chess, 1))
+	_, err := NewOrderArchiveRepository(db).ArchiveBatch(ctx, time.Now().AddDate(-1, 0, 0), 10)
+	require.NoError(t, err)
+	lastOrderAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	place(userID, entity.Completed, lastOrderAt.Add(-time.Hour), line(guide, 2), line(chess, 1))
+	place(userID, entity.Cancelled, lastOrderAt.Add(500*time.Millisecond), line(album, 5))
+	place(otherID, entity.Completed, lastOrderAt, line(album, 9))
+
+	summary, err := analytics.SummarizeCustomer(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Orders)
+	assert.Equal(t, 2, summary.PaidOrders)
+	assert.Equal(t, 75.0, summary.LifetimeSpend)
+	assert.Equal(t, 37.5, summary.AverageOrderValue)
+	require.NotNil(t, summary.LastOrderAt)
+	assert.Equal(t, lastOrderAt, *summary.LastOrderAt)
+
+	// Deleted categories are left out
+	require.NoError(t, db.Delete(&music).Error)
+	favorites, err := analytics.FavoriteCategories(ctx, userID, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []entity.CategorySales{
+		{CategoryID: games.ID, Name: "Games", Units: 4, Spend: 60},
+		{CategoryID: books.ID, Name: "Books", Units: 2, Spend: 40},
+	}, favorites)
+
+	favorites, err = analytics.FavoriteCategories(ctx, userID, 1)
+	require.NoError(t, err)
+	assert.Len(t, favorites, 1)
+
+	summary, err = analytics.SummarizeCustomer(ctx, uuid.New())
+	require.NoError(t, err)
+	assert.Zero(t, summary.Orders)
+	assert.Nil(t, summary.LastOrderAt)
+}
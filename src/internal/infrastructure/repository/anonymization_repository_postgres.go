@@ -0,0 +1,158 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// freeText lists the columns staff and customers type free text into, which
+// may name anyone, and the bodies of webhooks, which copy whatever the payment
+// provider or the event carried
+var freeText = []struct{ table, column string }{
+	{"customer_notes", "body"},
+	{"access_codes", "note"},
+	{"blocklist_entries", "reason"},
+	{"draft_orders", "note"},
+	{"returns", "note"},
+	{"returns", "resolution_note"},
+	{"order_remediations", "note"},
+	{"recall_notices", "error"},
+	{"webhook_logs", "raw_payload"},
+	{"archived_webhook_logs", "raw_payload"},
+	{"dead_letter_webhooks", "raw_payload"},
+	{"webhook_deliveries", "payload"},
+}
+
+// auditTables hold payloads of audit logs, which copy whatever changed
+var auditTables = []string{"audit_logs", "archived_audit_logs"}
+
+type AnonymizationRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAnonymizationRepository(db *gorm.DB) repository.AnonymizationRepository {
+	return &AnonymizationRepositoryPostgres{db: db}
+}
+
+func (r *AnonymizationRepositoryPostgres) ListUsers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).Where("id > ?", after).Order("id").Limit(limit).Find(&users).Error
+	return users, err
+}
+
+func (r *AnonymizationRepositoryPostgres) UpdateUsers(ctx context.Context, users []*entity.User) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, user := range users {
+			err := tx.Model(&entity.User{}).Where("id = ?", user.ID).Updates(map[string]interface{}{
+				"email":         user.Email,
+				"name":          user.Name,
+				"password_hash": user.PasswordHash,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *AnonymizationRepositoryPostgres) ListCustomers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Customer, error) {
+	var customers []*entity.Customer
+	err := r.db.WithContext(ctx).Preload("Addresses").
+		Where("user_id > ?", after).Order("user_id").Limit(limit).Find(&customers).Error
+	return customers, err
+}
+
+func (r *AnonymizationRepositoryPostgres) UpdateCustomers(ctx context.Context, customers []*entity.Customer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, customer := range customers {
+			err := tx.Model(&entity.Customer{}).Where("user_id = ?", customer.UserID).Updates(map[string]interface{}{
+				"phone":              customer.Phone,
+				"phone_verified_at":  customer.PhoneVerifiedAt,
+				"phone_code_hash":    customer.PhoneCodeHash,
+				"phone_code_sent_at": customer.PhoneCodeSentAt,
+			}).Error
+			if err != nil {
+				return err
+			}
+			for _, address := range customer.Addresses {
+				err := tx.Model(&entity.CustomerAddress{}).Where("id = ?", address.ID).Updates(map[string]interface{}{
+					"recipient":   address.Recipient,
+					"line1":       address.Line1,
+					"line2":       address.Line2,
+					"postal_code": address.PostalCode,
+				}).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (r *AnonymizationRepositoryPostgres) ListBlockedEmails(ctx context.Context, after uuid.UUID, limit int) ([]*entity.BlocklistEntry, error) {
+	var entries []*entity.BlocklistEntry
+	err := r.db.WithContext(ctx).
+		Where("kind IN ?", []entity.BlocklistKind{entity.BlockEmail, entity.BlockDomain}).
+		Where("id > ?", after).Order("id").Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+func (r *AnonymizationRepositoryPostgres) UpdateBlocklistEntries(ctx context.Context, entries []*entity.BlocklistEntry) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, entry := range entries {
+			if err := tx.Model(&entity.BlocklistEntry{}).Where("id = ?", entry.ID).Update("value", entry.Value).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *AnonymizationRepositoryPostgres) ListInvoices(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Invoice, error) {
+	var invoices []*entity.Invoice
+	err := r.db.WithContext(ctx).Omit("pdf").Where("id > ?", after).Order("id").Limit(limit).Find(&invoices).Error
+	return invoices, err
+}
+
+func (r *AnonymizationRepositoryPostgres) UpdateInvoices(ctx context.Context, invoices []*entity.Invoice) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, invoice := range invoices {
+			if err := tx.Model(&entity.Invoice{}).Where("id = ?", invoice.ID).Update("pdf", invoice.PDF).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *AnonymizationRepositoryPostgres) RedactText(ctx context.Context, placeholder string) (int64, error) {
+	var redacted int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, text := range freeText {
+			result := tx.Table(text.table).
+				Where(text.column+" IS NOT NULL AND "+text.column+" <> '' AND "+text.column+" <> ?", placeholder).
+				Update(text.column, placeholder)
+			if result.Error != nil {
+				return result.Error
+			}
+			redacted += result.RowsAffected
+		}
+		for _, table := range auditTables {
+			result := tx.Table(table).
+				Where("payload_before IS NOT NULL OR payload_after IS NOT NULL").
+				Updates(map[string]interface{}{"payload_before": nil, "payload_after": nil})
+			if result.Error != nil {
+				return result.Error
+			}
+			redacted += result.RowsAffected
+		}
+		return nil
+	})
+	return redacted, err
+}
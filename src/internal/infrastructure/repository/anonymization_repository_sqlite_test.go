@@ -0,0 +1,89 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func TestAnonymizationRepository(t *testing.T) {
+	db := newSQLiteDB(t)
+	repo := NewAnonymizationRepository(db)
+	ctx := context.Background()
+
+	var ids []uuid.UUID
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		user := entity.User{ID: uuid.New(), Email: email, Name: "Jane Doe", Role: entity.RoleCustomer}
+		require.NoError(t, db.Create(&user).Error)
+		ids = append(ids, user.ID)
+	}
+
+	t.Run("Lists users in batches", func(t *testing.T) {
+		first, err := repo.ListUsers(ctx, uuid.Nil, 2)
+		require.NoError(t, err)
+		require.Len(t, first, 2)
+		rest, err := repo.ListUsers(ctx, first[1].ID, 2)
+		require.NoError(t, err)
+		require.Len(t, rest, 1)
+
+		assert.ElementsMatch(t, ids, []uuid.UUID{first[0].ID, first[1].ID, rest[0].ID})
+	})
+
+	t.Run("Updates customers and their addresses", func(t *testing.T) {
+		verifiedAt := time.Now()
+		customer := &entity.Customer{UserID: ids[0], Phone: "+1 555 0100", PhoneVerifiedAt: &verifiedAt, Addresses: []entity.CustomerAddress{
+			{Recipient: "Jane Doe", Line1: "1 Real Street", City: "Lisbon", PostalCode: "1000-001", Country: "PT"},
+		}}
+		require.NoError(t, db.Create(customer).Error)
+
+		customers, err := repo.ListCustomers(ctx, uuid.Nil, 10)
+		require.NoError(t, err)
+		require.Len(t, customers, 1)
+		require.Len(t, customers[0].Addresses, 1)
+
+		customers[0].Phone = "+1 555 0199"
+		customers[0].PhoneVerifiedAt = nil
+		customers[0].Addresses[0].Recipient = "Ada Kim"
+		customers[0].Addresses[0].Line1 = "42 Maple Avenue"
+		require.NoError(t, repo.UpdateCustomers(ctx, customers))
+
+		var saved entity.Customer
+		require.NoError(t, db.Preload("Addresses").First(&saved, "user_id = ?", ids[0]).Error)
+		assert.Equal(t, "+1 555 0199", saved.Phone)
+		assert.Nil(t, saved.PhoneVerifiedAt)
+		assert.Equal(t, "Ada Kim", saved.Addresses[0].Recipient)
+		assert.Equal(t, "42 Maple Avenue", saved.Addresses[0].Line1)
+		assert.Equal(t, "Lisbon", saved.Addresses[0].City)
+	})
+
+	t.Run("Redacts free text and audit payloads", func(t *testing.T) {
+		require.NoError(t, db.Create(&entity.CustomerNote{UserID: ids[0], AuthorID: ids[1], Body: "Called Jane at home"}).Error)
+		require.NoError(t, db.Create(&entity.AuditLog{
+			Action: "update", ResourceType: "user", ResourceID: ids[0], Timestamp: time.Now(),
+			PayloadBefore: datatypes.JSON(`{"email":"a@example.com"}`),
+		}).Error)
+
+		redacted, err := repo.RedactText(ctx, "[redacted]")
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), redacted)
+
+		var note entity.CustomerNote
+		require.NoError(t, db.First(&note).Error)
+		assert.Equal(t, "[redacted]", note.Body)
+		var log entity.AuditLog
+		require.NoError(t, db.First(&log).Error)
+		assert.Nil(t, log.PayloadBefore)
+
+		redacted, err = repo.RedactText(ctx, "[redacted]")
+		require.NoError(t, err)
+		assert.Zero(t, redacted, "text already redacted is left alone")
+	})
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AttributeRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewAttributeRepository(db *gorm.DB) repository.AttributeRepository {
+	return &AttributeRepositoryPostgres{db: db}
+}
+
+func (r *AttributeRepositoryPostgres) CreateDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	return r.db.WithContext(ctx).Create(definition).Error
+}
+
+func (r *AttributeRepositoryPostgres) GetDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	var definition entity.AttributeDefinition
+	if err := r.db.WithContext(ctx).First(&definition, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *AttributeRepositoryPostgres) GetDefinitionByCode(ctx context.Context, code string) (*entity.AttributeDefinition, error) {
+	var definition entity.AttributeDefinition
+	if err := r.db.WithContext(ctx).First(&definition, "code = ?", code).Error; err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+func (r *AttributeRepositoryPostgres) ListDefinitions(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	var definitions []*entity.AttributeDefinition
+	if err := r.db.WithContext(ctx).Order("name ASC").Find(&definitions).Error; err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func (r *AttributeRepositoryPostgres) SetProductValue(ctx context.Context, value *entity.ProductAttribute) error {
+	return r.db.WithContext(ctx).
+		Omit("Attribute").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "attribute_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"text_value", "number_value", "boolean_value", "updated_at"}),
+		}).
+		Create(value).Error
+}
+
+func (r *AttributeRepositoryPostgres) DeleteProductValue(ctx context.Context, productID, attributeID uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.ProductAttribute{}, "product_id = ? AND attribute_id = ?", productID, attributeID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
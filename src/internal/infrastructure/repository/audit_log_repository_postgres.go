@@ -76,3 +76,23 @@ func (r *AuditLogRepositoryPostgres) GetByResourceID(ctx context.Context, resour
 		Find(&logs).Error
 	return logs, err
 }
+
+// ClearPayloads matches text in the JSON of the payloads, as written
+func (r *AuditLogRepositoryPostgres) ClearPayloads(ctx context.Context, resourceID uuid.UUID, text string) (int64, error) {
+	pattern := "%" + likeEscaper.Replace(text) + "%"
+	var cleared int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, table := range []string{"audit_logs", "archived_audit_logs"} {
+			result := tx.Table(table).
+				Where("payload_before IS NOT NULL OR payload_after IS NOT NULL").
+				Where(`resource_id = ? OR CAST(payload_before AS TEXT) LIKE ? ESCAPE '\' OR CAST(payload_after AS TEXT) LIKE ? ESCAPE '\'`, resourceID, pattern, pattern).
+				Updates(map[string]interface{}{"payload_before": nil, "payload_after": nil})
+			if result.Error != nil {
+				return result.Error
+			}
+			cleared += result.RowsAffected
+		}
+		return nil
+	})
+	return cleared, err
+}
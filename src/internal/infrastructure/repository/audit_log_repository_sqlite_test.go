@@ -0,0 +1,49 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+func TestAuditLogRepository_ClearPayloads(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+	repo := NewAuditLogRepository(db)
+
+	userID := uuid.New()
+	about := &entity.AuditLog{Action: "update", ResourceType: "user", ResourceID: userID, PayloadBefore: datatypes.JSON(`{"name":"Jane Doe"}`)}
+	naming := &entity.AuditLog{Action: "create", ResourceType: "order", ResourceID: uuid.New(), PayloadAfter: datatypes.JSON(`{"email":"jane@example.com"}`)}
+	wildcard := &entity.AuditLog{Action: "create", ResourceType: "order", ResourceID: uuid.New(), PayloadAfter: datatypes.JSON(`{"email":"janeXexample.com"}`)}
+	for _, log := range []*entity.AuditLog{about, naming, wildcard} {
+		require.NoError(t, repo.Create(ctx, log))
+	}
+	archived := entity.NewArchivedAuditLog(&entity.AuditLog{ID: uuid.New(), Action: "update", ResourceType: "user", ResourceID: userID,
+		PayloadAfter: datatypes.JSON(`{"role":"customer"}`), Timestamp: time.Now()}, time.Now())
+	require.NoError(t, db.Create(archived).Error)
+
+	cleared, err := repo.ClearPayloads(ctx, userID, "jane_example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), cleared, "the underscore is no wildcard")
+
+	cleared, err = repo.ClearPayloads(ctx, userID, "jane@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), cleared, "cleared payloads aren't counted again")
+
+	logs, err := repo.GetByResourceID(ctx, "order", naming.ResourceID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Nil(t, logs[0].PayloadAfter)
+	logs, err = repo.GetByResourceID(ctx, "order", wildcard.ResourceID)
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.NotNil(t, logs[0].PayloadAfter)
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// backupTables are the tables of the store, parents before the tables with
+// foreign keys to them. schema_migrations is left out, restores go into a
+// database migrated to the same version, and so are token revocations,
+// which only concern tokens signed with the secret of the source, and
+// webhook nonces, which are only kept for a few minutes. Personal tables
+// hold or name the accounts and their customer data, and are left out or
+// hashed along with them.
+var backupTables = []entity.BackupTable{
+	{Name: "users", Personal: true},
+	{Name: "customers", Personal: true},
+	{Name: "customer_addresses", Personal: true},
+	{Name: "customer_notes", Personal: true},
+	{Name: "customer_risk_events", Personal: true},
+	{Name: "categories"},
+	{Name: "products"},
+	{Name: "product_categories"},
+	{Name: "product_translations"},
+	{Name: "attribute_definitions"},
+	{Name: "product_attributes"},
+	{Name: "product_options"},
+	{Name: "product_option_values"},
+	{Name: "product_variants"},
+	{Name: "variant_options"},
+	{Name: "price_changes"},
+	{Name: "price_tiers"},
+	{Name: "stock_movements"},
+	{Name: "product_reviews", Personal: true},
+	{Name: "search_ranking_rules"},
+	{Name: "orders"},
+	{Name: "order_items"},
+	{Name: "order_item_components"},
+	{Name: "order_remediations"},
+	{Name: "archived_orders"},
+	{Name: "invoice_sequences"},
+	{Name: "invoices", Personal: true},
+	{Name: "loyalty_transactions", Personal: true},
+	{Name: "returns"},
+	{Name: "return_items"},
+	{Name: "draft_orders", Personal: true},
+	{Name: "draft_order_items", Personal: true},
+	{Name: "purchase_queue_entries"},
+	{Name: "recalls"},
+	{Name: "recall_notices"},
+	{Name: "blocklist_entries", Personal: true},
+	{Name: "access_codes"},
+	{Name: "email_templates"},
+	{Name: "store_settings"},
+	{Name: "catalog_feeds"},
+	{Name: "catalog_reports"},
+	{Name: "webhook_subscriptions"},
+	{Name: "webhook_deliveries", Personal: true},
+	{Name: "webhook_logs", Personal: true},
+	{Name: "archived_webhook_logs", Personal: true},
+	{Name: "dead_letter_webhooks", Personal: true},
+	{Name: "audit_logs", Personal: true},
+	{Name: "archived_audit_logs", Personal: true},
+	{Name: "admin_alerts", Personal: true},
+}
+
+type BackupRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewBackupRepository(db *gorm.DB) repository.BackupRepository {
+	return &BackupRepositoryPostgres{db: db}
+}
+
+func (r *BackupRepositoryPostgres) SchemaVersion(ctx context.Context) (int64, error) {
+	migrator, err := database.NewMigrator(r.db)
+	if err != nil {
+		return 0, err
+	}
+	version, _, err := migrator.Version(ctx)
+	return version, err
+}
+
+func (r *BackupRepositoryPostgres) Tables() []entity.BackupTable {
+	return backupTables
+}
+
+func (r *BackupRepositoryPostgres) ExportTable(ctx context.Context, table string, fn func(row entity.BackupRow) error) error {
+	db := r.db.WithContext(ctx)
+	types, err := columnTypes(db, table)
+	if err != nil {
+		return err
+	}
+
+	rows, err := db.Table(table).Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(columns))
+	pointers := make([]interface{}, len(columns))
+	for i := range values {
+		pointers[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if err := rows.Scan(pointers...); err != nil {
+			return err
+		}
+		row := make(entity.BackupRow, len(columns))
+		for i, column := range columns {
+			row[column] = exportValue(values[i], types[column])
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (r *BackupRepositoryPostgres) CountRows(ctx context.Context, table string) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Table(table).Count(&count).Error
+	return count, err
+}
+
+func (r *BackupRepositoryPostgres) Restore(ctx context.Context, restore func(insert func(table string, rows []entity.BackupRow) error) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tables := make(map[string]map[string]string)
+		return restore(func(table string, rows []entity.BackupRow) error {
+			types, ok := tables[table]
+			if !ok {
+				var err error
+				if types, err = columnTypes(tx, table); err != nil {
+					return err
+				}
+				tables[table] = types
+			}
+
+			values := make([]map[string]interface{}, len(rows))
+			for i, row := range rows {
+				values[i] = make(map[string]interface{}, len(row))
+				for column, value := range row {
+					kind, ok := types[column]
+					if !ok {
+						return fmt.Errorf("Table %s has no column %s", table, column)
+					}
+					converted, err := importValue(value, kind)
+					if err != nil {
+						return fmt.Errorf("Column %s of table %s: %w", column, table, err)
+					}
+					values[i][column] = converted
+				}
+			}
+			return tx.Table(table).Create(&values).Error
+		})
+	})
+}
+
+// columnTypes returns the lowercase database type of every column of table
+func columnTypes(db *gorm.DB, table string) (map[string]string, error) {
+	columns, err := db.Migrator().ColumnTypes(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("Table %s does not exist", table)
+	}
+	types := make(map[string]string, len(columns))
+	for _, column := range columns {
+		types[column.Name()] = strings.ToLower(column.DatabaseTypeName())
+	}
+	return types, nil
+}
+
+func isBinary(kind string) bool {
+	return kind == "bytea" || kind == "blob"
+}
+
+func isTime(kind string) bool {
+	return strings.Contains(kind, "time") || kind == "date"
+}
+
+func isBool(kind string) bool {
+	return kind == "bool" || kind == "boolean"
+}
+
+// exportValue returns bytes only for binary columns, drivers return the text
+// of some columns, like JSON, as bytes too
+func exportValue(value interface{}, kind string) interface{} {
+	switch v := value.(type) {
+	case []byte:
+		if isBinary(kind) {
+			return v
+		}
+		return string(v)
+	case time.Time:
+		return v.UTC()
+	}
+	return value
+}
+
+// importValue converts the strings of binary and time columns, which a
+// backup holds as base64 and RFC 3339, and the numbers SQLite keeps booleans
+// as
+func importValue(value interface{}, kind string) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		switch {
+		case isBinary(kind):
+			return base64.StdEncoding.DecodeString(v)
+		case isTime(kind):
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	case int64:
+		if isBool(kind) {
+			return v != 0, nil
+		}
+	case float64:
+		if isBool(kind) {
+			return v != 0, nil
+		}
+	}
+	return value, nil
+}
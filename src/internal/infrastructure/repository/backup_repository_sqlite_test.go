@@ -0,0 +1,101 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRepository_CoversEveryTable(t *testing.T) {
+	db := newSQLiteDB(t)
+
+	tables, err := db.Migrator().GetTables()
+	require.NoError(t, err)
+
+	backedUp := make(map[string]bool)
+	for _, table := range NewBackupRepository(db).Tables() {
+		backedUp[table.Name] = true
+	}
+	for _, table := range tables {
+		if table == "schema_migrations" || table == "token_revocations" || table == "webhook_nonces" {
+			continue
+		}
+		assert.True(t, backedUp[table], "table %s is missing from the backup tables", table)
+	}
+}
+
+func TestBackupRepository_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	source := newSQLiteDB(t)
+	target := newSQLiteDB(t)
+
+	createdAt := time.Date(2026, 3, 4, 5, 6, 7, 800, time.UTC)
+	user := entity.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane", Role: entity.RoleCustomer, Active: true, CreatedAt: createdAt}
+	require.NoError(t, source.Create(&user).Error)
+	invoice := entity.Invoice{ID: uuid.New(), OrderID: uuid.New(), Number: "INV-2026-000001", Year: 2026, Sequence: 1, PDF: []byte{0x25, 0x50, 0x00, 0xff}, IssuedAt: createdAt}
+	require.NoError(t, source.Create(&invoice).Error)
+
+	exported := make(map[string][]entity.BackupRow)
+	repo := NewBackupRepository(source)
+	for _, table := range []string{"users", "invoices"} {
+		require.NoError(t, repo.ExportTable(ctx, table, func(row entity.BackupRow) error {
+			exported[table] = append(exported[table], row)
+			return nil
+		}))
+	}
+	require.Len(t, exported["invoices"], 1)
+	assert.Equal(t, invoice.PDF, exported["invoices"][0]["pdf"], "binary columns export as bytes")
+
+	// Rows come back from an archive with times and bytes as text
+	exported["users"][0]["created_at"] = createdAt.Format(time.RFC3339Nano)
+	exported["invoices"][0]["pdf"] = "JVAA/w=="
+
+	restore := NewBackupRepository(target)
+	version, err := restore.SchemaVersion(ctx)
+	require.NoError(t, err)
+	assert.Positive(t, version)
+
+	err = restore.Restore(ctx, func(insert func(table string, rows []entity.BackupRow) error) error {
+		for _, table := range []string{"users", "invoices"} {
+			if err := insert(table, exported[table]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var restoredUser entity.User
+	require.NoError(t, target.First(&restoredUser, "id = ?", user.ID).Error)
+	assert.Equal(t, "jane@example.com", restoredUser.Email)
+	assert.True(t, restoredUser.Active)
+	assert.True(t, createdAt.Equal(restoredUser.CreatedAt))
+	var count int64
+	require.NoError(t, target.Model(&entity.User{}).Where("created_at < ?", createdAt.Add(time.Second)).Count(&count).Error)
+	assert.Equal(t, int64(1), count, "restored times compare like the ones the application writes")
+
+	var restoredInvoice entity.Invoice
+	require.NoError(t, target.First(&restoredInvoice, "id = ?", invoice.ID).Error)
+	assert.Equal(t, invoice.PDF, restoredInvoice.PDF)
+
+	t.Run("A failed restore keeps nothing", func(t *testing.T) {
+		err := restore.Restore(ctx, func(insert func(table string, rows []entity.BackupRow) error) error {
+			if err := insert("categories", []entity.BackupRow{{"id": uuid.New().String(), "name": "Books", "slug": "books"}}); err != nil {
+				return err
+			}
+			return insert("categories", []entity.BackupRow{{"id": uuid.New().String(), "nickname": "x"}})
+		})
+		assert.ErrorContains(t, err, "Table categories has no column nickname")
+
+		restored, err := restore.CountRows(ctx, "categories")
+		require.NoError(t, err)
+		assert.Zero(t, restored)
+	})
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type BlocklistRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewBlocklistRepository(db *gorm.DB) repository.BlocklistRepository {
+	return &BlocklistRepositoryPostgres{db: db}
+}
+
+func (r *BlocklistRepositoryPostgres) Create(ctx context.Context, entry *entity.BlocklistEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *BlocklistRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.BlocklistEntry{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Blocklist entry not found")
+	}
+	return nil
+}
+
+func (r *BlocklistRepositoryPostgres) HashEmail(ctx context.Context, email string) error {
+	email = strings.ToLower(strings.TrimSpace(email))
+	return r.db.WithContext(ctx).Model(&entity.BlocklistEntry{}).
+		Where("kind = ? AND value = ?", entity.BlockEmail, email).
+		Update("value", entity.HashedEmail(email)).Error
+}
+
+func (r *BlocklistRepositoryPostgres) List(ctx context.Context) ([]*entity.BlocklistEntry, error) {
+	var entries []*entity.BlocklistEntry
+	err := r.db.WithContext(ctx).Order("created_at DESC, id").Find(&entries).Error
+	return entries, err
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CatalogFeedRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewCatalogFeedRepository(db *gorm.DB) repository.CatalogFeedRepository {
+	return &CatalogFeedRepositoryPostgres{db: db}
+}
+
+func (r *CatalogFeedRepositoryPostgres) ScanProducts(ctx context.Context, at time.Time, batchSize int, fn func(products []*entity.Product) error) error {
+	var products []*entity.Product
+	query := r.db.WithContext(ctx).
+		Preload("Variants.Options").
+		Preload("Categories").
+		Where("status = ?", entity.ProductActive).
+		Scopes(availableAt(at)).
+		Order("id")
+
+	return query.FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(products)
+	}).Error
+}
+
+func (r *CatalogFeedRepositoryPostgres) Save(ctx context.Context, feed *entity.CatalogFeed) error {
+	return r.db.WithContext(ctx).Save(feed).Error
+}
+
+func (r *CatalogFeedRepositoryPostgres) Get(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error) {
+	var feed entity.CatalogFeed
+	err := r.db.WithContext(ctx).Where("format = ?", format).First(&feed).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Catalog feed not found")
+		}
+		return nil, err
+	}
+
+	return &feed, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CatalogReportRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewCatalogReportRepository(db *gorm.DB) repository.CatalogReportRepository {
+	return &CatalogReportRepositoryPostgres{db: db}
+}
+
+func (r *CatalogReportRepositoryPostgres) ScanProducts(ctx context.Context, batchSize int, fn func(products []*entity.Product) error) error {
+	var products []*entity.Product
+	query := r.db.WithContext(ctx).
+		Preload("Variants.Options").
+		Preload("Options").
+		Order("id")
+
+	return query.FindInBatches(&products, batchSize, func(tx *gorm.DB, batch int) error {
+		return fn(products)
+	}).Error
+}
+
+func (r *CatalogReportRepositoryPostgres) ListOrphanVariants(ctx context.Context) ([]*entity.ProductVariant, error) {
+	var variants []*entity.ProductVariant
+	err := r.db.WithContext(ctx).
+		Joins("LEFT JOIN products ON products.id = product_variants.product_id").
+		Where("products.id IS NULL OR products.deleted_at IS NOT NULL").
+		Find(&variants).Error
+	if err != nil {
+		return nil, err
+	}
+	return variants, nil
+}
+
+func (r *CatalogReportRepositoryPostgres) CountProductsByCategory(ctx context.Context) (map[uuid.UUID]int, error) {
+	var rows []struct {
+		CategoryID uuid.UUID
+		Count      int
+	}
+	err := r.db.WithContext(ctx).
+		Table("product_categories").
+		Select("product_categories.category_id, COUNT(*) AS count").
+		Joins("JOIN products ON products.id = product_categories.product_id AND products.deleted_at IS NULL").
+		Group("product_categories.category_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		counts[row.CategoryID] = row.Count
+	}
+	return counts, nil
+}
+
+func (r *CatalogReportRepositoryPostgres) Create(ctx context.Context, report *entity.CatalogReport) error {
+	return r.db.WithContext(ctx).Create(report).Error
+}
+
+func (r *CatalogReportRepositoryPostgres) GetLatest(ctx context.Context) (*entity.CatalogReport, error) {
+	var report entity.CatalogReport
+	err := r.db.WithContext(ctx).Order("created_at DESC").First(&report).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Catalog report not found")
+		}
+		return nil, err
+	}
+
+	return &report, nil
+}
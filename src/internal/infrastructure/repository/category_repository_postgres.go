@@ -2,9 +2,13 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
 	"gorm.io/gorm"
 )
 
@@ -22,7 +26,7 @@ func (r *CategoryRepositoryPostgres) Create(ctx context.Context, category *entit
 
 func (r *CategoryRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
 	var category entity.Category
-	err := r.db.WithContext(ctx).Preload("Products").First(&category, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Preload("Products").First(&category, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -35,11 +39,11 @@ func (r *CategoryRepositoryPostgres) GetAll(ctx context.Context, page, pageSize
 
 	offset := (page - 1) * pageSize
 
-	if err := r.db.WithContext(ctx).Model(&entity.Category{}).Count(&total).Error; err != nil {
+	if err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.Category{}).Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	err := r.db.WithContext(ctx).
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).
 		Offset(offset).
 		Limit(pageSize).
 		Order("name ASC").
@@ -57,7 +61,23 @@ func (r *CategoryRepositoryPostgres) Update(ctx context.Context, category *entit
 }
 
 func (r *CategoryRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entity.Category{}, "id = ?", id).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var category entity.Category
+		if err := tx.First(&category, "id = ?", id).Error; err != nil {
+			return err
+		}
+
+		// Subcategories move up one level instead of becoming orphans
+		if err := tx.Model(&entity.Category{}).Where("parent_id = ?", id).Update("parent_id", category.ParentID).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec("DELETE FROM product_categories WHERE category_id = ?", id).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&category).Error
+	})
 }
 
 func (r *CategoryRepositoryPostgres) GetByName(ctx context.Context, name string) (*entity.Category, error) {
@@ -69,47 +89,191 @@ func (r *CategoryRepositoryPostgres) GetByName(ctx context.Context, name string)
 	return &category, nil
 }
 
-func (r *CategoryRepositoryPostgres) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	// Get product and category to ensure they exist
-	var product entity.Product
-	if err := r.db.WithContext(ctx).First(&product, "id = ?", productID).Error; err != nil {
-		return err
+func (r *CategoryRepositoryPostgres) GetBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	var category entity.Category
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Where("slug = ?", slug).First(&category).Error
+	if err != nil {
+		return nil, err
 	}
+	return &category, nil
+}
 
+func (r *CategoryRepositoryPostgres) SlugExists(ctx context.Context, slug string) (bool, error) {
+	var count int64
+	// Soft-deleted rows still hold their slug in the unique index
+	err := r.db.WithContext(ctx).Unscoped().Model(&entity.Category{}).Where("slug = ?", slug).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (r *CategoryRepositoryPostgres) GetByMergedSlug(ctx context.Context, slug string) (*entity.Category, error) {
 	var category entity.Category
-	if err := r.db.WithContext(ctx).First(&category, "id = ?", categoryID).Error; err != nil {
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).
+		Where("id = (?)", r.db.Unscoped().Model(&entity.Category{}).Select("merged_into_id").Where("slug = ? AND merged_into_id IS NOT NULL", slug)).
+		First(&category).Error
+	if err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepositoryPostgres) Merge(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	var moved int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var source, target entity.Category
+		if err := tx.First(&source, "id = ?", sourceID).Error; err != nil {
+			return err
+		}
+		if err := tx.First(&target, "id = ?", targetID).Error; err != nil {
+			return err
+		}
+
+		// Products already in the target keep their single assignment
+		if err := tx.Exec(`
+			INSERT INTO product_categories (product_id, category_id)
+			SELECT product_id, ? FROM product_categories
+			WHERE category_id = ? AND product_id NOT IN (SELECT product_id FROM product_categories WHERE category_id = ?)`,
+			targetID, sourceID, targetID).Error; err != nil {
+			return err
+		}
+		removed := tx.Exec("DELETE FROM product_categories WHERE category_id = ?", sourceID)
+		if removed.Error != nil {
+			return removed.Error
+		}
+		moved = int(removed.RowsAffected)
+
+		if err := tx.Model(&entity.Category{}).Where("parent_id = ?", sourceID).Update("parent_id", targetID).Error; err != nil {
+			return err
+		}
+
+		// Categories merged into the source before redirect to the target in one step
+		if err := tx.Unscoped().Model(&entity.Category{}).Where("merged_into_id = ?", sourceID).Update("merged_into_id", targetID).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&source).Update("merged_into_id", targetID).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&source).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return moved, nil
+}
+
+func (r *CategoryRepositoryPostgres) GetTree(ctx context.Context) ([]*entity.Category, error) {
+	var categories []*entity.Category
+	if err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Order("name ASC").Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	return entity.BuildCategoryTree(categories), nil
+}
+
+func (r *CategoryRepositoryPostgres) GetDescendants(ctx context.Context, id uuid.UUID) ([]*entity.Category, error) {
+	var categories []*entity.Category
+
+	// UNION (rather than UNION ALL) stops the recursion even if a cycle slipped into the data
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Raw(`
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM categories WHERE parent_id = ? AND deleted_at IS NULL
+			UNION
+			SELECT c.id FROM categories c
+			JOIN descendants d ON c.parent_id = d.id
+			WHERE c.deleted_at IS NULL
+		)
+		SELECT * FROM categories WHERE id IN (SELECT id FROM descendants) ORDER BY name ASC`, id).
+		Scan(&categories).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (r *CategoryRepositoryPostgres) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
+	product, category, err := r.findProductAndCategory(ctx, productID, categoryID)
+	if err != nil {
 		return err
 	}
 
 	// Add the association
-	return r.db.WithContext(ctx).Model(&product).Association("Categories").Append(&category)
+	return r.db.WithContext(ctx).Model(product).Association("Categories").Append(category)
 }
 
 func (r *CategoryRepositoryPostgres) RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
+	product, category, err := r.findProductAndCategory(ctx, productID, categoryID)
+	if err != nil {
+		return err
+	}
+
+	// Remove the association
+	return r.db.WithContext(ctx).Model(product).Association("Categories").Delete(category)
+}
+
+// findProductAndCategory loads both sides of a product category assignment,
+// ensuring they exist
+func (r *CategoryRepositoryPostgres) findProductAndCategory(ctx context.Context, productID, categoryID uuid.UUID) (*entity.Product, *entity.Category, error) {
 	var product entity.Product
 	if err := r.db.WithContext(ctx).First(&product, "id = ?", productID).Error; err != nil {
-		return err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, entity.NotFoundError("Product not found")
+		}
+		return nil, nil, err
 	}
 
 	var category entity.Category
 	if err := r.db.WithContext(ctx).First(&category, "id = ?", categoryID).Error; err != nil {
-		return err
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, entity.NotFoundError("Category not found")
+		}
+		return nil, nil, err
 	}
-
-	// Remove the association
-	return r.db.WithContext(ctx).Model(&product).Association("Categories").Delete(&category)
+	return &product, &category, nil
 }
 
 func (r *CategoryRepositoryPostgres) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
 	var product entity.Product
-	err := r.db.WithContext(ctx).Preload("Categories").First(&product, "id = ?", productID).Error
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Preload("Categories").First(&product, "id = ?", productID).Error
 	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Product not found")
+		}
 		return nil, err
 	}
 
 	return convertCategoriesToPointers(product.Categories), nil
 }
 
+func (r *CategoryRepositoryPostgres) GetProducts(ctx context.Context, categoryID uuid.UUID, at time.Time, sort repository.ProductSort, page, pageSize int) ([]*entity.Product, int, error) {
+	var products []*entity.Product
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.Product{}).
+		Joins("JOIN product_categories ON product_categories.product_id = products.id").
+		Where("product_categories.category_id = ? AND products.status = ?", categoryID, entity.ProductActive).
+		Scopes(availableAt(at))
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := preloadProductRelations(query).
+		Order(productOrder(sort)).
+		Offset(offset).
+		Limit(pageSize).
+		Find(&products).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return products, int(total), nil
+}
+
 func convertCategoriesToPointers(categories []entity.Category) []*entity.Category {
 	result := make([]*entity.Category, len(categories))
 	for i := range categories {
@@ -0,0 +1,76 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+func TestCategoryRepository_Merge(t *testing.T) {
+	db := newSQLiteDB(t)
+	categories := NewCategoryRepository(db)
+	ctx := context.Background()
+
+	phones := entity.Category{Name: "Phones", Slug: "phones"}
+	mobiles := entity.Category{Name: "Mobiles", Slug: "mobiles"}
+	cellulars := entity.Category{Name: "Cellulars", Slug: "cellulars"}
+	for _, category := range []*entity.Category{&phones, &mobiles, &cellulars} {
+		if err := db.Create(category).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := entity.Category{Name: "Cases", Slug: "cases", ParentID: &mobiles.ID}
+	if err := db.Create(&cases).Error; err != nil {
+		t.Fatal(err)
+	}
+	both := entity.Product{Name: "Phone", Price: 100, Status: entity.ProductActive, Categories: []entity.Category{phones, mobiles}}
+	only := entity.Product{Name: "Charger", Price: 10, Status: entity.ProductActive, Categories: []entity.Category{mobiles}}
+	for _, product := range []*entity.Product{&both, &only} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := categories.Merge(ctx, cellulars.ID, mobiles.ID); err != nil {
+		t.Fatal(err)
+	}
+	moved, err := categories.Merge(ctx, mobiles.ID, phones.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved != 2 {
+		t.Errorf("moved %d products, want 2", moved)
+	}
+
+	var assignments int64
+	db.Table("product_categories").Where("category_id = ?", phones.ID).Count(&assignments)
+	if assignments != 2 {
+		t.Errorf("phones has %d products, want 2", assignments)
+	}
+	db.Table("product_categories").Where("category_id = ?", mobiles.ID).Count(&assignments)
+	if assignments != 0 {
+		t.Errorf("mobiles kept %d products", assignments)
+	}
+
+	if _, err := categories.GetByID(ctx, mobiles.ID); err == nil {
+		t.Error("expected the merged category to be deleted")
+	}
+	child, err := categories.GetByID(ctx, cases.ID)
+	if err != nil || child.ParentID == nil || *child.ParentID != phones.ID {
+		t.Errorf("expected cases under phones, got %+v, %v", child, err)
+	}
+
+	// Categories merged before the last merge lead to its target too
+	for _, slug := range []string{"mobiles", "cellulars"} {
+		target, err := categories.GetByMergedSlug(ctx, slug)
+		if err != nil || target.ID != phones.ID {
+			t.Errorf("%s: expected phones, got %+v, %v", slug, target, err)
+		}
+	}
+	if _, err := categories.GetByMergedSlug(ctx, "phones"); err == nil {
+		t.Error("expected no redirect for a live category")
+	}
+}
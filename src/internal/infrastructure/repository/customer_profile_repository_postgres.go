@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CustomerProfileRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewCustomerProfileRepository(db *gorm.DB) repository.CustomerProfileRepository {
+	return &CustomerProfileRepositoryPostgres{db: db}
+}
+
+func (r *CustomerProfileRepositoryPostgres) AddNote(ctx context.Context, note *entity.CustomerNote) error {
+	return r.db.WithContext(ctx).Create(note).Error
+}
+
+func (r *CustomerProfileRepositoryPostgres) ListNotes(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerNote, error) {
+	var notes []*entity.CustomerNote
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&notes).Error
+	return notes, err
+}
+
+func (r *CustomerProfileRepositoryPostgres) AddRiskEvent(ctx context.Context, event *entity.CustomerRiskEvent) error {
+	return r.db.WithContext(ctx).Create(event).Error
+}
+
+func (r *CustomerProfileRepositoryPostgres) ListRiskEvents(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerRiskEvent, error) {
+	var events []*entity.CustomerRiskEvent
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&events).Error
+	return events, err
+}
+
+func (r *CustomerProfileRepositoryPostgres) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.CustomerNote{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.CustomerRiskEvent{}).Error
+	})
+}
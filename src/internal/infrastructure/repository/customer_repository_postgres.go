@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type CustomerRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewCustomerRepository(db *gorm.DB) repository.CustomerRepository {
+	return &CustomerRepositoryPostgres{db: db}
+}
+
+func (r *CustomerRepositoryPostgres) Get(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	var customer entity.Customer
+	err := r.db.WithContext(ctx).
+		Preload("Addresses", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		First(&customer, "user_id = ?", userID).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Customer not found")
+		}
+		return nil, err
+	}
+
+	return &customer, nil
+}
+
+// Save upserts the customer row and replaces its addresses in one transaction
+func (r *CustomerRepositoryPostgres) Save(ctx context.Context, customer *entity.Customer) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(customer).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", customer.UserID).Delete(&entity.CustomerAddress{}).Error; err != nil {
+			return err
+		}
+		if len(customer.Addresses) == 0 {
+			return nil
+		}
+		for i := range customer.Addresses {
+			customer.Addresses[i].UserID = customer.UserID
+		}
+		return tx.Create(&customer.Addresses).Error
+	})
+}
+
+func (r *CustomerRepositoryPostgres) Delete(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.CustomerAddress{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.Customer{}).Error
+	})
+}
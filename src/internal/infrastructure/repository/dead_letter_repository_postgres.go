@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DeadLetterRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewDeadLetterRepository(db *gorm.DB) repository.DeadLetterRepository {
+	return &DeadLetterRepositoryPostgres{db: db}
+}
+
+func (r *DeadLetterRepositoryPostgres) Record(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "source"}, {Name: "payload_hash"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"attempts":       gorm.Expr("dead_letter_webhooks.attempts + 1"),
+				"reason":         webhook.Reason,
+				"last_failed_at": webhook.LastFailedAt,
+			}),
+		}).
+		Create(webhook).Error
+}
+
+func (r *DeadLetterRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.DeadLetterWebhook, error) {
+	var webhook entity.DeadLetterWebhook
+	if err := r.db.WithContext(ctx).First(&webhook, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Dead-lettered webhook not found")
+		}
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+func (r *DeadLetterRepositoryPostgres) Update(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	return r.db.WithContext(ctx).Save(webhook).Error
+}
+
+func (r *DeadLetterRepositoryPostgres) List(ctx context.Context, filters repository.DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	var webhooks []entity.DeadLetterWebhook
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.DeadLetterWebhook{})
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.Source != nil {
+		query = query.Where("source = ?", *filters.Source)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("last_failed_at DESC, id").Offset(offset).Limit(pageSize).Find(&webhooks).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return webhooks, int(total), nil
+}
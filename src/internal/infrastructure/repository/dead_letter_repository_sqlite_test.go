@@ -0,0 +1,54 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeadLetterRepository_Record(t *testing.T) {
+	ctx := context.Background()
+	repo := NewDeadLetterRepository(newSQLiteDB(t))
+
+	first := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	webhook := entity.NewDeadLetterWebhook(entity.DirectWebhookSource, []byte(`{"order_id":"x"}`), "invalid order_id format", first)
+	require.NoError(t, repo.Record(ctx, webhook))
+
+	again := entity.NewDeadLetterWebhook(entity.DirectWebhookSource, []byte(`{"order_id":"x"}`), "order not found", first.Add(time.Hour))
+	require.NoError(t, repo.Record(ctx, again))
+	require.NoError(t, repo.Record(ctx, entity.NewDeadLetterWebhook("stripe", []byte(`{"order_id":"x"}`), "Invalid Stripe event", first)))
+
+	stored, err := repo.GetByID(ctx, webhook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stored.Attempts, "the same body from the same source is another attempt")
+	assert.Equal(t, "order not found", stored.Reason)
+	assert.True(t, stored.LastFailedAt.Equal(first.Add(time.Hour)))
+	assert.True(t, stored.CreatedAt.Equal(first))
+
+	require.NoError(t, stored.Discard(uuid.New(), time.Now()))
+	require.NoError(t, repo.Update(ctx, stored))
+	require.NoError(t, repo.Record(ctx, again))
+
+	pending := entity.DeadLetterPending
+	webhooks, total, err := repo.List(ctx, repository.DeadLetterFilters{Status: &pending}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, total, "a discarded dead letter stays discarded")
+	assert.Equal(t, "stripe", webhooks[0].Source)
+
+	webhooks, total, err = repo.List(ctx, repository.DeadLetterFilters{}, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, webhook.ID, webhooks[0].ID, "most recently failed first")
+	assert.Equal(t, 3, webhooks[0].Attempts)
+
+	_, err = repo.GetByID(ctx, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
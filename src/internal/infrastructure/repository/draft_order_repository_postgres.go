@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type DraftOrderRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewDraftOrderRepository(db *gorm.DB) repository.DraftOrderRepository {
+	return &DraftOrderRepositoryPostgres{db: db}
+}
+
+func (r *DraftOrderRepositoryPostgres) Create(ctx context.Context, draft *entity.DraftOrder) error {
+	return r.db.WithContext(ctx).Create(draft).Error
+}
+
+func (r *DraftOrderRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error) {
+	return r.first(ctx, "id = ?", id)
+}
+
+func (r *DraftOrderRepositoryPostgres) GetByToken(ctx context.Context, token string) (*entity.DraftOrder, error) {
+	return r.first(ctx, "token = ?", token)
+}
+
+func (r *DraftOrderRepositoryPostgres) first(ctx context.Context, query string, value interface{}) (*entity.DraftOrder, error) {
+	var draft entity.DraftOrder
+	if err := r.db.WithContext(ctx).Preload("Items").First(&draft, query, value).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Draft order not found")
+		}
+		return nil, err
+	}
+	return &draft, nil
+}
+
+func (r *DraftOrderRepositoryPostgres) Update(ctx context.Context, draft *entity.DraftOrder) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit(clause.Associations).Save(draft)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entity.NotFoundError("Draft order not found")
+		}
+
+		if err := tx.Where("draft_order_id = ?", draft.ID).Delete(&entity.DraftOrderItem{}).Error; err != nil {
+			return err
+		}
+		for i := range draft.Items {
+			draft.Items[i].ID = uuid.Nil
+			draft.Items[i].DraftOrderID = draft.ID
+		}
+		if len(draft.Items) == 0 {
+			return nil
+		}
+		return tx.Create(&draft.Items).Error
+	})
+}
+
+func (r *DraftOrderRepositoryPostgres) UpdateStatus(ctx context.Context, draft *entity.DraftOrder, from entity.DraftOrderStatus) error {
+	draft.UpdatedAt = time.Now()
+	result := r.db.WithContext(ctx).Model(&entity.DraftOrder{}).
+		Where("id = ? AND status = ?", draft.ID, from).
+		Updates(map[string]interface{}{
+			"status":       draft.Status,
+			"order_id":     draft.OrderID,
+			"converted_at": draft.ConvertedAt,
+			"updated_at":   draft.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.ConflictError("The draft order was changed meanwhile, reload it and try again")
+	}
+	return nil
+}
+
+func (r *DraftOrderRepositoryPostgres) List(ctx context.Context, filters repository.DraftOrderFilters, page, pageSize int) ([]*entity.DraftOrder, int, error) {
+	var drafts []*entity.DraftOrder
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.DraftOrder{})
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filters.CustomerID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Items").Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&drafts).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return drafts, int(total), nil
+}
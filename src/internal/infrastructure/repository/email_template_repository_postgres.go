@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type EmailTemplateRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewEmailTemplateRepository(db *gorm.DB) repository.EmailTemplateRepository {
+	return &EmailTemplateRepositoryPostgres{db: db}
+}
+
+func (r *EmailTemplateRepositoryPostgres) CreateVersion(ctx context.Context, template *entity.EmailTemplate) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var latest int
+		err := tx.Model(&entity.EmailTemplate{}).
+			Where("key = ?", template.Key).
+			Select("COALESCE(MAX(version), 0)").
+			Scan(&latest).Error
+		if err != nil {
+			return err
+		}
+
+		// Concurrent edits of the same key collide on the (key, version) unique index
+		template.Version = latest + 1
+		return tx.Create(template).Error
+	})
+}
+
+func (r *EmailTemplateRepositoryPostgres) GetLatest(ctx context.Context, key string) (*entity.EmailTemplate, error) {
+	var template entity.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("key = ?", key).
+		Order("version DESC").
+		First(&template).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Email template not found")
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *EmailTemplateRepositoryPostgres) GetVersion(ctx context.Context, key string, version int) (*entity.EmailTemplate, error) {
+	var template entity.EmailTemplate
+	err := r.db.WithContext(ctx).First(&template, "key = ? AND version = ?", key, version).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Email template version not found")
+		}
+		return nil, err
+	}
+
+	return &template, nil
+}
+
+func (r *EmailTemplateRepositoryPostgres) ListLatest(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	var templates []*entity.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Select("DISTINCT ON (key) *").
+		Order("key, version DESC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+func (r *EmailTemplateRepositoryPostgres) ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	var templates []*entity.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("key = ?", key).
+		Order("version DESC").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// EmailTemplateRepositorySQLite stores templates in SQLite, which has no
+// DISTINCT ON
+type EmailTemplateRepositorySQLite struct {
+	*EmailTemplateRepositoryPostgres
+}
+
+func NewEmailTemplateRepositorySQLite(db *gorm.DB) repository.EmailTemplateRepository {
+	return &EmailTemplateRepositorySQLite{&EmailTemplateRepositoryPostgres{db: db}}
+}
+
+func (r *EmailTemplateRepositorySQLite) ListLatest(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	var templates []*entity.EmailTemplate
+	err := r.db.WithContext(ctx).
+		Where("version = (SELECT MAX(latest.version) FROM email_templates latest WHERE latest.key = email_templates.key)").
+		Order("key").
+		Find(&templates).Error
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type InventoryRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewInventoryRepository(db *gorm.DB) repository.InventoryRepository {
+	return &InventoryRepositoryPostgres{db: db}
+}
+
+func (r *InventoryRepositoryPostgres) SyncStock(ctx context.Context, sync *entity.InventorySync) ([]entity.StockLevelResult, []*entity.StockMovement, error) {
+	var results []entity.StockLevelResult
+	var movements []*entity.StockMovement
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Rows are locked in id order so concurrent updates can't deadlock
+		var variants []entity.ProductVariant
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "product_id", "sku", "quantity").
+			Where("sku IN ?", sync.SKUs()).
+			Where("product_id IN (SELECT id FROM products WHERE deleted_at IS NULL)").
+			Order("id").
+			Find(&variants).Error
+		if err != nil {
+			return err
+		}
+
+		bySKU := make(map[string]*entity.ProductVariant, len(variants))
+		for i := range variants {
+			bySKU[variants[i].SKU] = &variants[i]
+		}
+
+		results, movements = sync.Apply(bySKU)
+		if len(movements) == 0 {
+			return nil
+		}
+		for _, movement := range movements {
+			if err := setStock(tx, movement); err != nil {
+				return err
+			}
+		}
+		return tx.Create(movements).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results, movements, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type InvoiceRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) repository.InvoiceRepository {
+	return &InvoiceRepositoryPostgres{db: db}
+}
+
+func (r *InvoiceRepositoryPostgres) CreateWithNextNumber(ctx context.Context, invoice *entity.Invoice, render func(invoice *entity.Invoice) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		year := invoice.IssuedAt.Year()
+
+		// Upsert the per-year counter; the row lock serializes concurrent invoices
+		var sequence int
+		err := tx.Raw(`
+			INSERT INTO invoice_sequences (year, last_number) VALUES (?, 1)
+			ON CONFLICT (year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+			RETURNING last_number`, year).Scan(&sequence).Error
+		if err != nil {
+			return err
+		}
+
+		invoice.AssignNumber(year, sequence)
+
+		if err := render(invoice); err != nil {
+			return err
+		}
+
+		return tx.Create(invoice).Error
+	})
+}
+
+func (r *InvoiceRepositoryPostgres) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entity.Invoice, error) {
+	var invoice entity.Invoice
+	err := r.db.WithContext(ctx).First(&invoice, "order_id = ?", orderID).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Invoice not found")
+		}
+		return nil, err
+	}
+
+	return &invoice, nil
+}
+
+func (r *InvoiceRepositoryPostgres) UpdatePDF(ctx context.Context, invoice *entity.Invoice) error {
+	return r.db.WithContext(ctx).Model(&entity.Invoice{}).Where("id = ?", invoice.ID).Update("pdf", invoice.PDF).Error
+}
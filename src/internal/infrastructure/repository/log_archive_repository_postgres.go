@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LogArchiveRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewLogArchiveRepository(db *gorm.DB) repository.LogArchiveRepository {
+	return &LogArchiveRepositoryPostgres{db: db}
+}
+
+func (r *LogArchiveRepositoryPostgres) ArchiveAuditLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	moved := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var logs []*entity.AuditLog
+		err := tx.Where("timestamp < ?", cutoff).
+			Order("timestamp ASC").
+			Limit(batchSize).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&logs).Error
+		if err != nil || len(logs) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*entity.ArchivedAuditLog, 0, len(logs))
+		ids := make([]uuid.UUID, 0, len(logs))
+		for _, log := range logs {
+			archived = append(archived, entity.NewArchivedAuditLog(log, now))
+			ids = append(ids, log.ID)
+		}
+
+		if err := tx.Create(&archived).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&entity.AuditLog{}).Error; err != nil {
+			return err
+		}
+
+		moved = len(logs)
+		return nil
+	})
+
+	return moved, err
+}
+
+func (r *LogArchiveRepositoryPostgres) ArchiveWebhookLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	moved := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var logs []*entity.WebhookLog
+		err := tx.Where("created_at < ? AND status IN ?", cutoff, []entity.WebhookStatus{entity.WebhookStatusCompleted, entity.WebhookStatusFailed, entity.WebhookStatusResolved}).
+			Order("created_at ASC").
+			Limit(batchSize).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&logs).Error
+		if err != nil || len(logs) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*entity.ArchivedWebhookLog, 0, len(logs))
+		ids := make([]uuid.UUID, 0, len(logs))
+		for _, log := range logs {
+			archived = append(archived, entity.NewArchivedWebhookLog(log, now))
+			ids = append(ids, log.ID)
+		}
+
+		if err := tx.Create(&archived).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&entity.WebhookLog{}).Error; err != nil {
+			return err
+		}
+
+		moved = len(logs)
+		return nil
+	})
+
+	return moved, err
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type LowStockRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewLowStockRepository(db *gorm.DB) repository.LowStockRepository {
+	return &LowStockRepositoryPostgres{db: db}
+}
+
+// Products with variants are represented by their variants, since their own
+// quantity is not what is sold
+const lowStockQuery = `
+SELECT p.id AS product_id, NULL::uuid AS variant_id, p.name AS product_name, '' AS sku, p.quantity
+FROM products p
+WHERE p.deleted_at IS NULL AND p.quantity <= @threshold
+  AND NOT EXISTS (SELECT 1 FROM product_variants v WHERE v.product_id = p.id AND v.deleted_at IS NULL)
+UNION ALL
+SELECT v.product_id, v.id, p.name, COALESCE(v.sku, ''), v.quantity
+FROM product_variants v
+JOIN products p ON p.id = v.product_id AND p.deleted_at IS NULL
+WHERE v.deleted_at IS NULL AND v.quantity <= @threshold
+ORDER BY quantity ASC, product_name ASC`
+
+func (r *LowStockRepositoryPostgres) ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error) {
+	var items []entity.LowStockItem
+	err := r.db.WithContext(ctx).Raw(lowStockQuery, map[string]interface{}{"threshold": threshold}).Scan(&items).Error
+	return items, err
+}
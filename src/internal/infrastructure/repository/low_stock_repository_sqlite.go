@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// lowStockQuerySQLite is lowStockQuery without the cast, SQLite has no uuid type
+var lowStockQuerySQLite = strings.Replace(lowStockQuery, "NULL::uuid", "NULL", 1)
+
+type LowStockRepositorySQLite struct {
+	*LowStockRepositoryPostgres
+}
+
+func NewLowStockRepositorySQLite(db *gorm.DB) repository.LowStockRepository {
+	return &LowStockRepositorySQLite{&LowStockRepositoryPostgres{db: db}}
+}
+
+func (r *LowStockRepositorySQLite) ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error) {
+	var items []entity.LowStockItem
+	err := r.db.WithContext(ctx).Raw(lowStockQuerySQLite, map[string]interface{}{"threshold": threshold}).Scan(&items).Error
+	return items, err
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoyaltyRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewLoyaltyRepository(db *gorm.DB) repository.LoyaltyRepository {
+	return &LoyaltyRepositoryPostgres{db: db}
+}
+
+func (r *LoyaltyRepositoryPostgres) Create(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	return r.db.WithContext(ctx).Create(transaction).Error
+}
+
+func (r *LoyaltyRepositoryPostgres) Redeem(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the user so concurrent redemptions can't both spend the same points
+		var user entity.User
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&user, "id = ?", transaction.UserID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entity.NotFoundError("User not found")
+			}
+			return err
+		}
+
+		balance, err := balance(tx, transaction.UserID)
+		if err != nil {
+			return err
+		}
+		if err := transaction.Spend(balance); err != nil {
+			return err
+		}
+
+		return tx.Create(transaction).Error
+	})
+}
+
+func (r *LoyaltyRepositoryPostgres) Balance(ctx context.Context, userID uuid.UUID) (int, error) {
+	return balance(r.db.WithContext(ctx), userID)
+}
+
+func balance(db *gorm.DB, userID uuid.UUID) (int, error) {
+	var points int
+	err := db.Model(&entity.LoyaltyTransaction{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(points), 0)").
+		Scan(&points).Error
+	return points, err
+}
+
+func (r *LoyaltyRepositoryPostgres) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.LoyaltyTransaction, error) {
+	var transactions []*entity.LoyaltyTransaction
+	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).Order("created_at ASC").Find(&transactions).Error
+	return transactions, err
+}
+
+func (r *LoyaltyRepositoryPostgres) ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*entity.LoyaltyTransaction, int, error) {
+	var transactions []*entity.LoyaltyTransaction
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.LoyaltyTransaction{}).Where("user_id = ?", userID)
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&transactions).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return transactions, int(total), nil
+}
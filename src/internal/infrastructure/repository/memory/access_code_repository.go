@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AccessCodeRepository struct {
+	store *Store
+}
+
+func NewAccessCodeRepository(store *Store) repository.AccessCodeRepository {
+	return &AccessCodeRepository{store: store}
+}
+
+// Create enforces the unique index on the code
+func (r *AccessCodeRepository) Create(ctx context.Context, code *entity.AccessCode) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(code); err != nil {
+		return err
+	}
+	if _, exists := r.store.accessCodes[code.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	for _, existing := range r.store.accessCodes {
+		if existing.Code == code.Code {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&code.CreatedAt, &code.UpdatedAt)
+
+	r.store.accessCodes[code.ID] = *code
+	r.store.track(code.ID)
+	return nil
+}
+
+func (r *AccessCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AccessCode, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	code, ok := r.store.accessCodes[id]
+	if !ok {
+		return nil, entity.NotFoundError("Access code not found")
+	}
+	return &code, nil
+}
+
+func (r *AccessCodeRepository) GetByCode(ctx context.Context, value string) (*entity.AccessCode, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, code := range r.store.accessCodes {
+		if code.Code == value {
+			return &code, nil
+		}
+	}
+	return nil, entity.NotFoundError("Access code not found")
+}
+
+func (r *AccessCodeRepository) Update(ctx context.Context, code *entity.AccessCode) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stored, ok := r.store.accessCodes[code.ID]
+	if !ok {
+		return entity.NotFoundError("Access code not found")
+	}
+	code.UpdatedAt = time.Now()
+	stored.Note = code.Note
+	stored.MaxUses = code.MaxUses
+	stored.ExpiresAt = code.ExpiresAt
+	stored.Active = code.Active
+	stored.UpdatedAt = code.UpdatedAt
+	r.store.accessCodes[code.ID] = stored
+	return nil
+}
+
+func (r *AccessCodeRepository) Redeem(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	code, ok := r.store.accessCodes[id]
+	if !ok || code.Exhausted() {
+		return entity.ConflictError("This access code has been used up")
+	}
+	code.Uses++
+	code.UpdatedAt = time.Now()
+	r.store.accessCodes[id] = code
+	return nil
+}
+
+func (r *AccessCodeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.accessCodes[id]; !ok {
+		return entity.NotFoundError("Access code not found")
+	}
+	delete(r.store.accessCodes, id)
+	return nil
+}
+
+func (r *AccessCodeRepository) List(ctx context.Context) ([]*entity.AccessCode, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.accessCodes))
+	for id := range r.store.accessCodes {
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.accessCodes[id].CreatedAt }, true)
+
+	codes := make([]*entity.AccessCode, 0, len(ids))
+	for _, id := range ids {
+		code := r.store.accessCodes[id]
+		codes = append(codes, &code)
+	}
+	return codes, nil
+}
@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AdminAlertRepository struct {
+	store *Store
+}
+
+func NewAdminAlertRepository(store *Store) repository.AdminAlertRepository {
+	return &AdminAlertRepository{store: store}
+}
+
+func (r *AdminAlertRepository) Create(ctx context.Context, alert *entity.AdminAlert) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(alert); err != nil {
+		return err
+	}
+	if _, exists := r.store.alerts[alert.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&alert.CreatedAt, nil)
+
+	r.store.alerts[alert.ID] = *alert
+	r.store.track(alert.ID)
+	return nil
+}
+
+func (r *AdminAlertRepository) List(ctx context.Context, rule *entity.AlertRule, page, pageSize int) ([]*entity.AdminAlert, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, alert := range r.store.alerts {
+		if rule == nil || alert.Rule == *rule {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.alerts[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	alerts := make([]*entity.AdminAlert, 0, end-start)
+	for _, id := range ids[start:end] {
+		alert := r.store.alerts[id]
+		alerts = append(alerts, &alert)
+	}
+	return alerts, len(ids), nil
+}
@@ -0,0 +1,268 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type AnalyticsRepository struct {
+	store *Store
+}
+
+func NewAnalyticsRepository(store *Store) repository.AnalyticsRepository {
+	return &AnalyticsRepository{store: store}
+}
+
+// sales returns the live and archived orders placed in the range, with their
+// items. Archived items are read from the order snapshot.
+func (r *AnalyticsRepository) sales(from, until time.Time) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	for id, order := range r.store.orders {
+		if !order.CreatedAt.Before(from) && order.CreatedAt.Before(until) {
+			orders = append(orders, r.store.order(id))
+		}
+	}
+	for _, a := range r.store.archivedOrders {
+		if a.OrderCreatedAt.Before(from) || !a.OrderCreatedAt.Before(until) {
+			continue
+		}
+		order, err := a.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+		order.CreatedAt = a.OrderCreatedAt
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// paid tells whether the order counts towards revenue
+func paid(order *entity.Order) bool {
+	return order.IsPaid() && !order.IsVoid()
+}
+
+func (r *AnalyticsRepository) RevenueByPeriod(ctx context.Context, period entity.AnalyticsPeriod, from, until time.Time, loc *time.Location) ([]entity.RevenuePoint, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.sales(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[time.Time]*entity.RevenuePoint)
+	for _, order := range orders {
+		if !paid(order) {
+			continue
+		}
+		start := period.Start(order.CreatedAt.In(loc))
+		point, ok := points[start]
+		if !ok {
+			point = &entity.RevenuePoint{PeriodStart: start}
+			points[start] = point
+		}
+		point.Orders++
+		point.Revenue += order.TotalPrice
+	}
+
+	series := make([]entity.RevenuePoint, 0, len(points))
+	for _, point := range points {
+		series = append(series, *point)
+	}
+	sort.Slice(series, func(i, j int) bool {
+		return series[i].PeriodStart.Before(series[j].PeriodStart)
+	})
+	return series, nil
+}
+
+func (r *AnalyticsRepository) TopProducts(ctx context.Context, from, until time.Time, limit int) ([]entity.ProductSales, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.sales(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[uuid.UUID]*entity.ProductSales)
+	for _, order := range orders {
+		if !paid(order) {
+			continue
+		}
+		for _, item := range order.Products {
+			sales, ok := products[item.ProductID]
+			if !ok {
+				// Deleted products keep their name, as the join ignores soft deletes
+				sales = &entity.ProductSales{ProductID: item.ProductID, Name: r.store.products[item.ProductID].Name}
+				products[item.ProductID] = sales
+			}
+			sales.Units += item.Quantity
+			sales.Revenue += item.TotalPrice
+		}
+	}
+
+	top := make([]entity.ProductSales, 0, len(products))
+	for _, sales := range products {
+		top = append(top, *sales)
+	}
+	sort.Slice(top, func(i, j int) bool {
+		if top[i].Units != top[j].Units {
+			return top[i].Units > top[j].Units
+		}
+		return top[i].Revenue > top[j].Revenue
+	})
+	return top[:limitRows(len(top), limit)], nil
+}
+
+func (r *AnalyticsRepository) CountOrdersByStatus(ctx context.Context, from, until time.Time) ([]entity.OrderStatusCount, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.sales(from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[entity.OrderStatus]int)
+	for _, order := range orders {
+		statuses[order.Status]++
+	}
+
+	counts := make([]entity.OrderStatusCount, 0, len(statuses))
+	for status, count := range statuses {
+		counts = append(counts, entity.OrderStatusCount{Status: status, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Status < counts[j].Status
+	})
+	return counts, nil
+}
+
+func (r *AnalyticsRepository) SumRevenue(ctx context.Context, from, until time.Time) (int, float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.sales(from, until)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	count, revenue := 0, 0.0
+	for _, order := range orders {
+		if paid(order) {
+			count++
+			revenue += order.TotalPrice
+		}
+	}
+	return count, revenue, nil
+}
+
+func (r *AnalyticsRepository) CountNewCustomers(ctx context.Context, from, until time.Time) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, user := range r.store.users {
+		if user.Role == entity.RoleCustomer && !user.CreatedAt.Before(from) && user.CreatedAt.Before(until) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// customerOrders returns the live and archived orders of the account, with
+// their items
+func (r *AnalyticsRepository) customerOrders(userID uuid.UUID) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	for id, order := range r.store.orders {
+		if order.UserID != nil && *order.UserID == userID {
+			orders = append(orders, r.store.order(id))
+		}
+	}
+	for _, a := range r.store.archivedOrders {
+		if a.UserID == nil || *a.UserID != userID {
+			continue
+		}
+		order, err := a.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+		order.CreatedAt = a.OrderCreatedAt
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *AnalyticsRepository) SummarizeCustomer(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.customerOrders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	paidOrders, spend := 0, 0.0
+	var lastOrderAt *time.Time
+	for _, order := range orders {
+		if paid(order) {
+			paidOrders++
+			spend += order.TotalPrice
+		}
+		if lastOrderAt == nil || order.CreatedAt.After(*lastOrderAt) {
+			// The databases keep the time to the second
+			at := order.CreatedAt.UTC().Truncate(time.Second)
+			lastOrderAt = &at
+		}
+	}
+	return entity.NewCustomerSummary(len(orders), paidOrders, spend, lastOrderAt), nil
+}
+
+func (r *AnalyticsRepository) FavoriteCategories(ctx context.Context, userID uuid.UUID, limit int) ([]entity.CategorySales, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders, err := r.customerOrders(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[uuid.UUID]*entity.CategorySales)
+	for _, order := range orders {
+		if !paid(order) {
+			continue
+		}
+		for _, item := range order.Products {
+			for assignment := range r.store.productCategories {
+				category, ok := r.store.categories[assignment.CategoryID]
+				if assignment.ProductID != item.ProductID || !ok || deleted(category.DeletedAt) {
+					continue
+				}
+				sales, ok := categories[category.ID]
+				if !ok {
+					sales = &entity.CategorySales{CategoryID: category.ID, Name: category.Name}
+					categories[category.ID] = sales
+				}
+				sales.Units += item.Quantity
+				sales.Spend += item.TotalPrice
+			}
+		}
+	}
+
+	favorites := make([]entity.CategorySales, 0, len(categories))
+	for _, sales := range categories {
+		favorites = append(favorites, *sales)
+	}
+	sort.Slice(favorites, func(i, j int) bool {
+		if favorites[i].Units != favorites[j].Units {
+			return favorites[i].Units > favorites[j].Units
+		}
+		return favorites[i].Spend > favorites[j].Spend
+	})
+	return favorites[:limitRows(len(favorites), limit)], nil
+}
@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type AttributeRepository struct {
+	store *Store
+}
+
+func NewAttributeRepository(store *Store) repository.AttributeRepository {
+	return &AttributeRepository{store: store}
+}
+
+func (r *AttributeRepository) CreateDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(definition); err != nil {
+		return err
+	}
+	for id, existing := range r.store.definitions {
+		if id == definition.ID || existing.Code == definition.Code {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&definition.CreatedAt, &definition.UpdatedAt)
+
+	r.store.definitions[definition.ID] = *definition
+	r.store.track(definition.ID)
+	return nil
+}
+
+func (r *AttributeRepository) GetDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	definition, ok := r.store.definitions[id]
+	if !ok {
+		return nil, entity.NotFoundError("Attribute not found")
+	}
+	return &definition, nil
+}
+
+func (r *AttributeRepository) GetDefinitionByCode(ctx context.Context, code string) (*entity.AttributeDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, definition := range r.store.definitions {
+		if definition.Code == code {
+			return &definition, nil
+		}
+	}
+	return nil, entity.NotFoundError("Attribute not found")
+}
+
+func (r *AttributeRepository) ListDefinitions(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	definitions := make([]*entity.AttributeDefinition, 0, len(r.store.definitions))
+	for _, definition := range r.store.definitions {
+		definition := definition
+		definitions = append(definitions, &definition)
+	}
+	sort.Slice(definitions, func(i, j int) bool {
+		return definitions[i].Name < definitions[j].Name
+	})
+	return definitions, nil
+}
+
+func (r *AttributeRepository) SetProductValue(ctx context.Context, value *entity.ProductAttribute) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.productAttributes {
+		if existing.ProductID != value.ProductID || existing.AttributeID != value.AttributeID {
+			continue
+		}
+		// The conflicting row keeps its ID and creation time
+		existing.TextValue, existing.NumberValue, existing.BooleanValue = value.TextValue, value.NumberValue, value.BooleanValue
+		existing.UpdatedAt = time.Now()
+		r.store.productAttributes[id] = existing
+		return nil
+	}
+
+	return r.store.insertProductAttribute(value)
+}
+
+func (r *AttributeRepository) DeleteProductValue(ctx context.Context, productID, attributeID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.productAttributes {
+		if existing.ProductID == productID && existing.AttributeID == attributeID {
+			delete(r.store.productAttributes, id)
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
@@ -0,0 +1,138 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	store *Store
+}
+
+func NewAuditLogRepository(store *Store) repository.AuditLogRepository {
+	return &AuditLogRepository{store: store}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(log); err != nil {
+		return err
+	}
+	if _, exists := r.store.auditLogs[log.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	r.store.auditLogs[log.ID] = *log
+	r.store.track(log.ID)
+	return nil
+}
+
+func (r *AuditLogRepository) List(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*entity.AuditLog, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// Dates that don't parse are ignored, as in PostgreSQL
+	var startTime, endTime *time.Time
+	if filters.StartDate != nil {
+		if t, err := time.Parse(time.RFC3339, *filters.StartDate); err == nil {
+			startTime = &t
+		}
+	}
+	if filters.EndDate != nil {
+		if t, err := time.Parse(time.RFC3339, *filters.EndDate); err == nil {
+			endTime = &t
+		}
+	}
+
+	var ids []uuid.UUID
+	for id, log := range r.store.auditLogs {
+		if filters.UserID != nil && (log.UserID == nil || *log.UserID != *filters.UserID) {
+			continue
+		}
+		if filters.Action != nil && log.Action != *filters.Action {
+			continue
+		}
+		if filters.ResourceType != nil && log.ResourceType != *filters.ResourceType {
+			continue
+		}
+		if filters.ResourceID != nil && log.ResourceID != *filters.ResourceID {
+			continue
+		}
+		if startTime != nil && log.Timestamp.Before(*startTime) {
+			continue
+		}
+		if endTime != nil && log.Timestamp.After(*endTime) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, r.timestamp, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	return r.logs(ids[start:end]), len(ids), nil
+}
+
+func (r *AuditLogRepository) GetByResourceID(ctx context.Context, resourceType string, resourceID uuid.UUID) ([]*entity.AuditLog, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, log := range r.store.auditLogs {
+		if log.ResourceType == resourceType && log.ResourceID == resourceID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, r.timestamp, true)
+	return r.logs(ids), nil
+}
+
+func (r *AuditLogRepository) ClearPayloads(ctx context.Context, resourceID uuid.UUID, text string) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	matches := func(id uuid.UUID, before, after datatypes.JSON) bool {
+		if before == nil && after == nil {
+			return false
+		}
+		return id == resourceID || strings.Contains(string(before), text) || strings.Contains(string(after), text)
+	}
+
+	var cleared int64
+	for id, log := range r.store.auditLogs {
+		if matches(log.ResourceID, log.PayloadBefore, log.PayloadAfter) {
+			log.PayloadBefore, log.PayloadAfter = nil, nil
+			r.store.auditLogs[id] = log
+			cleared++
+		}
+	}
+	for id, log := range r.store.archivedAudits {
+		if matches(log.ResourceID, log.PayloadBefore, log.PayloadAfter) {
+			log.PayloadBefore, log.PayloadAfter = nil, nil
+			r.store.archivedAudits[id] = log
+			cleared++
+		}
+	}
+	return cleared, nil
+}
+
+func (r *AuditLogRepository) timestamp(id uuid.UUID) time.Time {
+	return r.store.auditLogs[id].Timestamp
+}
+
+func (r *AuditLogRepository) logs(ids []uuid.UUID) []*entity.AuditLog {
+	logs := make([]*entity.AuditLog, 0, len(ids))
+	for _, id := range ids {
+		log := r.store.auditLogs[id]
+		logs = append(logs, &log)
+	}
+	return logs
+}
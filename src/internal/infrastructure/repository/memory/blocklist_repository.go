@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type BlocklistRepository struct {
+	store *Store
+}
+
+func NewBlocklistRepository(store *Store) repository.BlocklistRepository {
+	return &BlocklistRepository{store: store}
+}
+
+// Create enforces the unique index on kind and value
+func (r *BlocklistRepository) Create(ctx context.Context, entry *entity.BlocklistEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(entry); err != nil {
+		return err
+	}
+	if _, exists := r.store.blocklist[entry.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	for _, existing := range r.store.blocklist {
+		if existing.Kind == entry.Kind && existing.Value == entry.Value {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&entry.CreatedAt, nil)
+
+	r.store.blocklist[entry.ID] = *entry
+	r.store.track(entry.ID)
+	return nil
+}
+
+func (r *BlocklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.blocklist[id]; !ok {
+		return entity.NotFoundError("Blocklist entry not found")
+	}
+	delete(r.store.blocklist, id)
+	return nil
+}
+
+func (r *BlocklistRepository) HashEmail(ctx context.Context, email string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	for id, entry := range r.store.blocklist {
+		if entry.Kind == entity.BlockEmail && entry.Value == email {
+			entry.Value = entity.HashedEmail(email)
+			r.store.blocklist[id] = entry
+		}
+	}
+	return nil
+}
+
+func (r *BlocklistRepository) List(ctx context.Context) ([]*entity.BlocklistEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.blocklist))
+	for id := range r.store.blocklist {
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.blocklist[id].CreatedAt }, true)
+
+	entries := make([]*entity.BlocklistEntry, 0, len(ids))
+	for _, id := range ids {
+		entry := r.store.blocklist[id]
+		entries = append(entries, &entry)
+	}
+	return entries, nil
+}
@@ -0,0 +1,382 @@
+package memory
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// The helpers below expect the caller to hold the store lock
+
+// insertProduct stores the product with its variants, options, attributes and
+// category assignments, as GORM creates associations
+func (s *Store) insertProduct(product *entity.Product) error {
+	if err := beforeCreate(product); err != nil {
+		return err
+	}
+	if _, exists := s.products[product.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&product.CreatedAt, &product.UpdatedAt)
+
+	row := *product
+	row.Variants, row.Options, row.Categories, row.Attributes, row.PriceSchedule = nil, nil, nil, nil, nil
+	s.products[product.ID] = row
+	s.track(product.ID)
+
+	for i := range product.Options {
+		product.Options[i].ProductID = product.ID
+		if err := s.insertOption(&product.Options[i]); err != nil {
+			return err
+		}
+	}
+	for i := range product.Variants {
+		product.Variants[i].ProductID = product.ID
+		if err := s.insertVariant(&product.Variants[i]); err != nil {
+			return err
+		}
+	}
+	for i := range product.Attributes {
+		product.Attributes[i].ProductID = product.ID
+		if err := s.insertProductAttribute(&product.Attributes[i]); err != nil {
+			return err
+		}
+	}
+	for i := range product.Categories {
+		category := &product.Categories[i]
+		if _, exists := s.categories[category.ID]; !exists {
+			if err := s.insertCategory(category); err != nil {
+				return err
+			}
+		}
+		s.productCategories[productCategory{ProductID: product.ID, CategoryID: category.ID}] = true
+	}
+	return nil
+}
+
+func (s *Store) insertCategory(category *entity.Category) error {
+	if err := beforeCreate(category); err != nil {
+		return err
+	}
+	// Soft-deleted rows keep their name and slug in the unique indexes
+	for id, existing := range s.categories {
+		if id == category.ID || existing.Name == category.Name || existing.Slug == category.Slug {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&category.CreatedAt, &category.UpdatedAt)
+
+	row := *category
+	row.Products, row.Children = nil, nil
+	s.categories[category.ID] = row
+	s.track(category.ID)
+	return nil
+}
+
+// insertOption stores the option with its values
+func (s *Store) insertOption(option *entity.ProductOption) error {
+	if err := beforeCreate(option); err != nil {
+		return err
+	}
+	for id, existing := range s.options {
+		if id == option.ID || (existing.ProductID == option.ProductID && existing.Name == option.Name) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&option.CreatedAt, nil)
+
+	row := *option
+	row.Values = nil
+	s.options[option.ID] = row
+	s.track(option.ID)
+
+	for i := range option.Values {
+		option.Values[i].OptionID = option.ID
+		if err := s.insertOptionValue(&option.Values[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) insertOptionValue(value *entity.ProductOptionValue) error {
+	if err := beforeCreate(value); err != nil {
+		return err
+	}
+	for id, existing := range s.optionValues {
+		if id == value.ID || (existing.OptionID == value.OptionID && existing.Value == value.Value) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	s.optionValues[value.ID] = *value
+	s.track(value.ID)
+	return nil
+}
+
+// insertVariant stores the variant with its option values
+func (s *Store) insertVariant(variant *entity.ProductVariant) error {
+	if err := beforeCreate(variant); err != nil {
+		return err
+	}
+	if _, exists := s.variants[variant.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	if err := s.checkSKU(variant); err != nil {
+		return err
+	}
+	stamp(&variant.CreatedAt, &variant.UpdatedAt)
+
+	row := *variant
+	row.Product, row.Options = nil, nil
+	s.variants[variant.ID] = row
+	s.track(variant.ID)
+
+	return s.insertVariantOptions(variant)
+}
+
+// checkSKU enforces the unique index on the SKU of variants that aren't deleted
+func (s *Store) checkSKU(variant *entity.ProductVariant) error {
+	for id, existing := range s.variants {
+		if id != variant.ID && !deleted(existing.DeletedAt) && !deleted(variant.DeletedAt) && existing.SKU == variant.SKU {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	return nil
+}
+
+func (s *Store) insertVariantOptions(variant *entity.ProductVariant) error {
+	for i := range variant.Options {
+		option := &variant.Options[i]
+		option.VariantID = variant.ID
+		if err := beforeCreate(option); err != nil {
+			return err
+		}
+		for id, existing := range s.variantOptions {
+			if id == option.ID || (existing.VariantID == option.VariantID && existing.OptionID == option.OptionID) {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+		s.variantOptions[option.ID] = *option
+		s.track(option.ID)
+	}
+	return nil
+}
+
+func (s *Store) insertProductAttribute(value *entity.ProductAttribute) error {
+	if err := beforeCreate(value); err != nil {
+		return err
+	}
+	for id, existing := range s.productAttributes {
+		if id == value.ID || (existing.ProductID == value.ProductID && existing.AttributeID == value.AttributeID) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&value.CreatedAt, &value.UpdatedAt)
+
+	row := *value
+	row.Attribute = nil
+	s.productAttributes[value.ID] = row
+	s.track(value.ID)
+	return nil
+}
+
+// product returns a copy of the product with everything a product response
+// shows, like preloadProductRelations
+func (s *Store) product(id uuid.UUID) *entity.Product {
+	product := s.products[id]
+
+	for _, categoryID := range s.productCategoryIDs(id) {
+		product.Categories = append(product.Categories, s.categories[categoryID])
+	}
+	for _, variant := range s.productVariants(id) {
+		product.Variants = append(product.Variants, *variant)
+	}
+	for _, option := range s.productOptions(id) {
+		product.Options = append(product.Options, *option)
+	}
+
+	var ids []uuid.UUID
+	for attributeID, value := range s.productAttributes {
+		if value.ProductID == id {
+			ids = append(ids, attributeID)
+		}
+	}
+	s.sortInserted(ids)
+	for _, attributeID := range ids {
+		value := s.productAttributes[attributeID]
+		if definition, ok := s.definitions[value.AttributeID]; ok {
+			value.Attribute = &definition
+		}
+		product.Attributes = append(product.Attributes, value)
+	}
+
+	return &product
+}
+
+// productCategoryIDs returns the categories assigned to a product that aren't deleted
+func (s *Store) productCategoryIDs(productID uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for assignment := range s.productCategories {
+		category, ok := s.categories[assignment.CategoryID]
+		if assignment.ProductID == productID && ok && !deleted(category.DeletedAt) {
+			ids = append(ids, assignment.CategoryID)
+		}
+	}
+	s.sortInserted(ids)
+	return ids
+}
+
+// productVariants returns the product's variants that aren't deleted, with their options
+func (s *Store) productVariants(productID uuid.UUID) []*entity.ProductVariant {
+	var ids []uuid.UUID
+	for id, variant := range s.variants {
+		if variant.ProductID == productID && !deleted(variant.DeletedAt) {
+			ids = append(ids, id)
+		}
+	}
+	s.sortInserted(ids)
+
+	variants := make([]*entity.ProductVariant, 0, len(ids))
+	for _, id := range ids {
+		variants = append(variants, s.variant(id, false))
+	}
+	return variants
+}
+
+// variant returns a copy of the variant with its options in position order,
+// and its product when withProduct is set
+func (s *Store) variant(id uuid.UUID, withProduct bool) *entity.ProductVariant {
+	variant := s.variants[id]
+
+	var ids []uuid.UUID
+	for optionID, option := range s.variantOptions {
+		if option.VariantID == id {
+			ids = append(ids, optionID)
+		}
+	}
+	s.sortByPosition(ids, func(id uuid.UUID) int { return s.variantOptions[id].Position })
+	for _, optionID := range ids {
+		variant.Options = append(variant.Options, s.variantOptions[optionID])
+	}
+
+	if product, ok := s.products[variant.ProductID]; withProduct && ok && !deleted(product.DeletedAt) {
+		variant.Product = &product
+	}
+	return &variant
+}
+
+// productOptions returns the product's options and their values in position order
+func (s *Store) productOptions(productID uuid.UUID) []*entity.ProductOption {
+	var ids []uuid.UUID
+	for id, option := range s.options {
+		if option.ProductID == productID {
+			ids = append(ids, id)
+		}
+	}
+	s.sortByPosition(ids, func(id uuid.UUID) int { return s.options[id].Position })
+
+	options := make([]*entity.ProductOption, 0, len(ids))
+	for _, id := range ids {
+		options = append(options, s.option(id))
+	}
+	return options
+}
+
+// option returns a copy of the option with its values in position order
+func (s *Store) option(id uuid.UUID) *entity.ProductOption {
+	option := s.options[id]
+
+	var ids []uuid.UUID
+	for valueID, value := range s.optionValues {
+		if value.OptionID == id {
+			ids = append(ids, valueID)
+		}
+	}
+	s.sortByPosition(ids, func(id uuid.UUID) int { return s.optionValues[id].Position })
+	for _, valueID := range ids {
+		option.Values = append(option.Values, s.optionValues[valueID])
+	}
+	return &option
+}
+
+// sortByPosition sorts ids by position, then insertion order
+func (s *Store) sortByPosition(ids []uuid.UUID, position func(id uuid.UUID) int) {
+	sort.Slice(ids, func(i, j int) bool {
+		if pi, pj := position(ids[i]), position(ids[j]); pi != pj {
+			return pi < pj
+		}
+		return s.inserted[ids[i]] < s.inserted[ids[j]]
+	})
+}
+
+// liveProductIDs returns the products that aren't deleted, oldest first
+func (s *Store) liveProductIDs() []uuid.UUID {
+	var ids []uuid.UUID
+	for id, product := range s.products {
+		if !deleted(product.DeletedAt) {
+			ids = append(ids, id)
+		}
+	}
+	s.sortInserted(ids)
+	return ids
+}
+
+// hasAttributeValue tells whether the product has the attribute set to the
+// value of filter. Text values are compared case-insensitively.
+func (s *Store) hasAttributeValue(productID uuid.UUID, filter entity.ProductAttribute) bool {
+	for _, value := range s.productAttributes {
+		if value.ProductID != productID || value.AttributeID != filter.AttributeID {
+			continue
+		}
+		switch {
+		case filter.TextValue != nil:
+			if value.TextValue != nil && strings.EqualFold(*value.TextValue, *filter.TextValue) {
+				return true
+			}
+		case filter.NumberValue != nil:
+			if value.NumberValue != nil && *value.NumberValue == *filter.NumberValue {
+				return true
+			}
+		case filter.BooleanValue != nil:
+			if value.BooleanValue != nil && *value.BooleanValue == *filter.BooleanValue {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// sortProducts sorts ids by the sort field, ties in insertion order
+func (s *Store) sortProducts(ids []uuid.UUID, order repository.ProductSort) {
+	less := func(a, b entity.Product) int {
+		switch order.Field {
+		case repository.ProductSortName:
+			return strings.Compare(a.Name, b.Name)
+		case repository.ProductSortPrice:
+			return compareFloat(a.Price, b.Price)
+		case repository.ProductSortCreatedAt:
+			return a.CreatedAt.Compare(b.CreatedAt)
+		case repository.ProductSortRating:
+			if c := compareFloat(a.RatingAverage, b.RatingAverage); c != 0 {
+				return c
+			}
+			return a.RatingCount - b.RatingCount
+		}
+		return 0
+	}
+
+	sort.SliceStable(ids, func(i, j int) bool {
+		c := less(s.products[ids[i]], s.products[ids[j]])
+		if order.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
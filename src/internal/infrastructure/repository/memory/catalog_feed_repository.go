@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type CatalogFeedRepository struct {
+	store *Store
+}
+
+func NewCatalogFeedRepository(store *Store) repository.CatalogFeedRepository {
+	return &CatalogFeedRepository{store: store}
+}
+
+// ScanProducts reads every product at once, then calls fn without holding the
+// store, like CatalogReportRepository.ScanProducts
+func (r *CatalogFeedRepository) ScanProducts(ctx context.Context, at time.Time, batchSize int, fn func(products []*entity.Product) error) error {
+	r.store.mu.RLock()
+	ids := r.store.liveProductIDs()
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if product := r.store.products[id]; product.IsOnSaleAt(at) {
+			products = append(products, r.store.product(id))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID.String() < products[j].ID.String()
+	})
+
+	return inBatches(len(products), batchSize, func(start, end int) error {
+		return fn(products[start:end])
+	})
+}
+
+func (r *CatalogFeedRepository) Save(ctx context.Context, feed *entity.CatalogFeed) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	r.store.catalogFeeds[feed.Format] = *feed
+	return nil
+}
+
+func (r *CatalogFeedRepository) Get(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	feed, ok := r.store.catalogFeeds[format]
+	if !ok {
+		return nil, entity.NotFoundError("Catalog feed not found")
+	}
+	return &feed, nil
+}
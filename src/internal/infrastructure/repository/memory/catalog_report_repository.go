@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CatalogReportRepository struct {
+	store *Store
+}
+
+func NewCatalogReportRepository(store *Store) repository.CatalogReportRepository {
+	return &CatalogReportRepository{store: store}
+}
+
+// ScanProducts reads every product at once, then calls fn without holding the
+// store, so fn may use the repositories
+func (r *CatalogReportRepository) ScanProducts(ctx context.Context, batchSize int, fn func(products []*entity.Product) error) error {
+	r.store.mu.RLock()
+	ids := r.store.liveProductIDs()
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		products = append(products, r.store.product(id))
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID.String() < products[j].ID.String()
+	})
+
+	return inBatches(len(products), batchSize, func(start, end int) error {
+		return fn(products[start:end])
+	})
+}
+
+func (r *CatalogReportRepository) ListOrphanVariants(ctx context.Context) ([]*entity.ProductVariant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, variant := range r.store.variants {
+		if product, ok := r.store.products[variant.ProductID]; !ok || deleted(product.DeletedAt) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortInserted(ids)
+
+	variants := make([]*entity.ProductVariant, 0, len(ids))
+	for _, id := range ids {
+		variant := r.store.variants[id]
+		variants = append(variants, &variant)
+	}
+	return variants, nil
+}
+
+func (r *CatalogReportRepository) CountProductsByCategory(ctx context.Context) (map[uuid.UUID]int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	counts := make(map[uuid.UUID]int)
+	for assignment := range r.store.productCategories {
+		if product, ok := r.store.products[assignment.ProductID]; ok && !deleted(product.DeletedAt) {
+			counts[assignment.CategoryID]++
+		}
+	}
+	return counts, nil
+}
+
+func (r *CatalogReportRepository) Create(ctx context.Context, report *entity.CatalogReport) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(report); err != nil {
+		return err
+	}
+	if _, exists := r.store.catalogReports[report.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&report.CreatedAt, nil)
+
+	r.store.catalogReports[report.ID] = *report
+	r.store.track(report.ID)
+	return nil
+}
+
+func (r *CatalogReportRepository) GetLatest(ctx context.Context) (*entity.CatalogReport, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id := range r.store.catalogReports {
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return nil, entity.NotFoundError("Catalog report not found")
+	}
+
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.catalogReports[id].CreatedAt }, true)
+	report := r.store.catalogReports[ids[0]]
+	return &report, nil
+}
@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newProduct(t *testing.T, products repository.ProductRepository, name string, quantity int) *entity.Product {
+	t.Helper()
+	product := &entity.Product{Name: name, Price: 10, Quantity: quantity}
+	require.NoError(t, products.Create(context.Background(), product))
+	return product
+}
+
+func TestProductRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Loads categories assigned through the category repository", func(t *testing.T) {
+		store := NewStore()
+		products, categories := NewProductRepository(store), NewCategoryRepository(store)
+		product := newProduct(t, products, "Shirt", 5)
+		category := &entity.Category{Name: "Clothing", Slug: "clothing"}
+		require.NoError(t, categories.Create(ctx, category))
+
+		require.NoError(t, categories.AssignCategoryToProduct(ctx, product.ID, category.ID))
+
+		found, err := products.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		require.Len(t, found.Categories, 1)
+		assert.Equal(t, "Clothing", found.Categories[0].Name)
+	})
+
+	t.Run("Lists only the included relations", func(t *testing.T) {
+		store := NewStore()
+		products, categories := NewProductRepository(store), NewCategoryRepository(store)
+		product := newProduct(t, products, "Shirt", 5)
+		category := &entity.Category{Name: "Clothing", Slug: "clothing"}
+		require.NoError(t, categories.Create(ctx, category))
+		require.NoError(t, categories.AssignCategoryToProduct(ctx, product.ID, category.ID))
+
+		listed, _, err := products.GetAll(ctx, 1, 10, repository.ProductFilters{})
+		require.NoError(t, err)
+		assert.Len(t, listed[0].Categories, 1)
+
+		listed, _, err = products.GetAll(ctx, 1, 10, repository.ProductFilters{Include: []repository.ProductRelation{repository.ProductVariants}})
+		require.NoError(t, err)
+		assert.Empty(t, listed[0].Categories)
+
+		summaries, total, err := products.ListProductsSummary(ctx, 1, 10, repository.ProductFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, "Shirt", summaries[0].Name)
+		assert.Empty(t, summaries[0].Categories)
+	})
+
+	t.Run("Returns copies", func(t *testing.T) {
+		products := NewProductRepository(NewStore())
+		product := newProduct(t, products, "Shirt", 5)
+
+		product.Name = "Changed"
+		found, err := products.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Shirt", found.Name)
+
+		found.Quantity = 0
+		again, _ := products.GetByID(ctx, product.ID)
+		assert.Equal(t, 5, again.Quantity)
+	})
+
+	t.Run("Soft deletes", func(t *testing.T) {
+		store := NewStore()
+		products := NewProductRepository(store)
+		product := newProduct(t, products, "Shirt", 5)
+
+		require.NoError(t, products.Delete(ctx, product.ID))
+
+		_, err := products.GetByID(ctx, product.ID)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		_, total, err := products.GetAll(ctx, 1, 10, repository.ProductFilters{})
+		require.NoError(t, err)
+		assert.Zero(t, total)
+		assert.True(t, store.products[product.ID].DeletedAt.Valid)
+	})
+
+	t.Run("Paginates in insertion order and filters stock", func(t *testing.T) {
+		products := NewProductRepository(NewStore())
+		newProduct(t, products, "First", 1)
+		newProduct(t, products, "Second", 0)
+		newProduct(t, products, "Third", 3)
+
+		page, total, err := products.GetAll(ctx, 2, 2, repository.ProductFilters{})
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		require.Len(t, page, 1)
+		assert.Equal(t, "Third", page[0].Name)
+
+		inStock, total, err := products.GetAll(ctx, 1, 10, repository.ProductFilters{InStockOnly: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, "First", inStock[0].Name)
+		assert.Equal(t, "Third", inStock[1].Name)
+	})
+}
+
+func TestCategoryRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Rejects a duplicate name", func(t *testing.T) {
+		categories := NewCategoryRepository(NewStore())
+		require.NoError(t, categories.Create(ctx, &entity.Category{Name: "Clothing", Slug: "clothing"}))
+
+		err := categories.Create(ctx, &entity.Category{Name: "Clothing", Slug: "clothing-2"})
+
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	})
+
+	t.Run("Re-parents children on delete", func(t *testing.T) {
+		categories := NewCategoryRepository(NewStore())
+		root := &entity.Category{Name: "Clothing", Slug: "clothing"}
+		require.NoError(t, categories.Create(ctx, root))
+		middle := &entity.Category{Name: "Shirts", Slug: "shirts", ParentID: &root.ID}
+		require.NoError(t, categories.Create(ctx, middle))
+		leaf := &entity.Category{Name: "Polos", Slug: "polos", ParentID: &middle.ID}
+		require.NoError(t, categories.Create(ctx, leaf))
+
+		require.NoError(t, categories.Delete(ctx, middle.ID))
+
+		found, err := categories.GetByID(ctx, leaf.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found.ParentID)
+		assert.Equal(t, root.ID, *found.ParentID)
+
+		exists, err := categories.SlugExists(ctx, "shirts")
+		require.NoError(t, err)
+		assert.True(t, exists, "deleted categories keep their slug")
+	})
+}
+
+func TestProductVariantRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Frees the SKU of a deleted variant", func(t *testing.T) {
+		store := NewStore()
+		product := newProduct(t, NewProductRepository(store), "Shirt", 0)
+		variants := NewProductVariantRepository(store)
+		variant := &entity.ProductVariant{ProductID: product.ID, SKU: "SHIRT-L", Quantity: 1}
+		require.NoError(t, variants.Create(ctx, variant))
+
+		err := variants.Create(ctx, &entity.ProductVariant{ProductID: product.ID, SKU: "SHIRT-L", Quantity: 1})
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+		require.NoError(t, variants.Delete(ctx, variant.ID))
+		assert.NoError(t, variants.Create(ctx, &entity.ProductVariant{ProductID: product.ID, SKU: "SHIRT-L", Quantity: 1}))
+	})
+
+	t.Run("Transfers stock and writes the ledger", func(t *testing.T) {
+		store := NewStore()
+		product := newProduct(t, NewProductRepository(store), "Shirt", 10)
+		variants := NewProductVariantRepository(store)
+		variant := &entity.ProductVariant{ProductID: product.ID, SKU: "SHIRT-L", Quantity: 2}
+		require.NoError(t, variants.Create(ctx, variant))
+
+		transfer := &entity.VariantStockTransfer{ProductID: product.ID, ToVariantID: &variant.ID, Quantity: 4}
+		out, in, err := variants.TransferStock(ctx, transfer)
+
+		require.NoError(t, err)
+		assert.Equal(t, 6, out.QuantityAfter)
+		assert.Equal(t, 6, in.QuantityAfter)
+		found, _ := variants.GetByID(ctx, variant.ID)
+		assert.Equal(t, 6, found.Quantity)
+		assert.Equal(t, 6, store.products[product.ID].Quantity)
+
+		movements, total, err := NewStockMovementRepository(store).GetByProductID(ctx, product.ID, repository.StockMovementFilters{}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Len(t, movements, 2)
+	})
+
+	t.Run("Leaves stock alone when it is insufficient", func(t *testing.T) {
+		store := NewStore()
+		product := newProduct(t, NewProductRepository(store), "Shirt", 1)
+		variants := NewProductVariantRepository(store)
+		variant := &entity.ProductVariant{ProductID: product.ID, SKU: "SHIRT-L", Quantity: 0}
+		require.NoError(t, variants.Create(ctx, variant))
+
+		transfer := &entity.VariantStockTransfer{ProductID: product.ID, ToVariantID: &variant.ID, Quantity: 4}
+		_, _, err := variants.TransferStock(ctx, transfer)
+
+		assert.ErrorIs(t, err, entity.ErrInsufficientStock)
+		assert.Equal(t, 1, store.products[product.ID].Quantity)
+		assert.Empty(t, store.stockMovements)
+	})
+}
+
+func TestLowStockRepository(t *testing.T) {
+	store := NewStore()
+	products := NewProductRepository(store)
+	newProduct(t, products, "Mug", 2)
+	newProduct(t, products, "Poster", 50)
+	shirt := newProduct(t, products, "Shirt", 0)
+	variant := &entity.ProductVariant{ProductID: shirt.ID, SKU: "SHIRT-L", Quantity: 1}
+	require.NoError(t, NewProductVariantRepository(store).Create(context.Background(), variant))
+
+	items, err := NewLowStockRepository(store).ListLowStock(context.Background(), 5)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2, "products with variants are listed by their variants")
+	assert.Equal(t, "SHIRT-L", items[0].SKU)
+	assert.Equal(t, "Mug", items[1].ProductName)
+	assert.Nil(t, items[1].VariantID)
+	assert.NotEqual(t, uuid.Nil, items[0].ProductID)
+}
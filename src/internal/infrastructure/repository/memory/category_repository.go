@@ -0,0 +1,344 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CategoryRepository struct {
+	store *Store
+}
+
+func NewCategoryRepository(store *Store) repository.CategoryRepository {
+	return &CategoryRepository{store: store}
+}
+
+func (r *CategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertCategory(category)
+}
+
+func (r *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	category, ok := r.live(id)
+	if !ok {
+		return nil, entity.NotFoundError("Category not found")
+	}
+
+	for _, productID := range r.productIDs(id) {
+		category.Products = append(category.Products, r.store.products[productID])
+	}
+	return &category, nil
+}
+
+func (r *CategoryRepository) GetAll(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	categories := r.byName()
+	start, end := pageBounds(len(categories), page, pageSize)
+	return categories[start:end], len(categories), nil
+}
+
+func (r *CategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.categories {
+		if id != category.ID && (existing.Name == category.Name || existing.Slug == category.Slug) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.categories[category.ID]; !exists {
+		r.store.track(category.ID)
+	}
+	category.UpdatedAt = time.Now()
+	row := *category
+	row.Products, row.Children = nil, nil
+	r.store.categories[category.ID] = row
+	return nil
+}
+
+func (r *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	category, ok := r.live(id)
+	if !ok {
+		return entity.NotFoundError("Category not found")
+	}
+
+	// Subcategories move up one level instead of becoming orphans
+	for childID, child := range r.store.categories {
+		if child.ParentID != nil && *child.ParentID == id {
+			child.ParentID = category.ParentID
+			r.store.categories[childID] = child
+		}
+	}
+
+	for assignment := range r.store.productCategories {
+		if assignment.CategoryID == id {
+			delete(r.store.productCategories, assignment)
+		}
+	}
+
+	category.DeletedAt = softDelete()
+	r.store.categories[id] = category
+	return nil
+}
+
+func (r *CategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	return r.find(func(category entity.Category) bool { return category.Name == name })
+}
+
+func (r *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return r.find(func(category entity.Category) bool { return category.Slug == slug })
+}
+
+func (r *CategoryRepository) find(match func(category entity.Category) bool) (*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, category := range r.byName() {
+		if match(*category) {
+			return category, nil
+		}
+	}
+	return nil, entity.NotFoundError("Category not found")
+}
+
+func (r *CategoryRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// Soft-deleted rows still hold their slug in the unique index
+	for _, category := range r.store.categories {
+		if category.Slug == slug {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *CategoryRepository) GetByMergedSlug(ctx context.Context, slug string) (*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, merged := range r.store.categories {
+		if merged.Slug != slug || merged.MergedIntoID == nil {
+			continue
+		}
+		if category, ok := r.live(*merged.MergedIntoID); ok {
+			return &category, nil
+		}
+	}
+	return nil, entity.NotFoundError("Category not found")
+}
+
+func (r *CategoryRepository) Merge(ctx context.Context, sourceID, targetID uuid.UUID) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	source, ok := r.live(sourceID)
+	if !ok {
+		return 0, entity.NotFoundError("Category not found")
+	}
+	if _, ok := r.live(targetID); !ok {
+		return 0, entity.NotFoundError("Category not found")
+	}
+
+	moved := 0
+	for assignment := range r.store.productCategories {
+		if assignment.CategoryID == sourceID {
+			delete(r.store.productCategories, assignment)
+			r.store.productCategories[productCategory{ProductID: assignment.ProductID, CategoryID: targetID}] = true
+			moved++
+		}
+	}
+
+	for id, category := range r.store.categories {
+		switch {
+		case category.ParentID != nil && *category.ParentID == sourceID && !deleted(category.DeletedAt):
+			category.ParentID = &targetID
+		case category.MergedIntoID != nil && *category.MergedIntoID == sourceID:
+			category.MergedIntoID = &targetID
+		default:
+			continue
+		}
+		r.store.categories[id] = category
+	}
+
+	source.MergedIntoID = &targetID
+	source.DeletedAt = softDelete()
+	r.store.categories[sourceID] = source
+	return moved, nil
+}
+
+func (r *CategoryRepository) GetTree(ctx context.Context) ([]*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return entity.BuildCategoryTree(r.byName()), nil
+}
+
+func (r *CategoryRepository) GetDescendants(ctx context.Context, id uuid.UUID) ([]*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	// Visited categories aren't expanded again, so a cycle in the data ends the walk
+	found := make(map[uuid.UUID]bool)
+	parents := []uuid.UUID{id}
+	for len(parents) > 0 {
+		var next []uuid.UUID
+		for _, category := range r.store.categories {
+			if deleted(category.DeletedAt) || category.ParentID == nil || found[category.ID] {
+				continue
+			}
+			for _, parentID := range parents {
+				if *category.ParentID == parentID {
+					found[category.ID] = true
+					next = append(next, category.ID)
+					break
+				}
+			}
+		}
+		parents = next
+	}
+
+	var descendants []*entity.Category
+	for _, category := range r.byName() {
+		if found[category.ID] {
+			descendants = append(descendants, category)
+		}
+	}
+	return descendants, nil
+}
+
+func (r *CategoryRepository) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkProductAndCategory(productID, categoryID); err != nil {
+		return err
+	}
+
+	// Assigning twice is a no-op, as appending to the association is
+	r.store.productCategories[productCategory{ProductID: productID, CategoryID: categoryID}] = true
+	return nil
+}
+
+func (r *CategoryRepository) RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkProductAndCategory(productID, categoryID); err != nil {
+		return err
+	}
+
+	delete(r.store.productCategories, productCategory{ProductID: productID, CategoryID: categoryID})
+	return nil
+}
+
+// checkProductAndCategory ensures both sides of a product category assignment exist
+func (r *CategoryRepository) checkProductAndCategory(productID, categoryID uuid.UUID) error {
+	if product, ok := r.store.products[productID]; !ok || deleted(product.DeletedAt) {
+		return entity.NotFoundError("Product not found")
+	}
+	if _, ok := r.live(categoryID); !ok {
+		return entity.NotFoundError("Category not found")
+	}
+	return nil
+}
+
+func (r *CategoryRepository) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if product, ok := r.store.products[productID]; !ok || deleted(product.DeletedAt) {
+		return nil, entity.NotFoundError("Product not found")
+	}
+
+	ids := r.store.productCategoryIDs(productID)
+	categories := make([]*entity.Category, 0, len(ids))
+	for _, id := range ids {
+		category := r.store.categories[id]
+		categories = append(categories, &category)
+	}
+	return categories, nil
+}
+
+func (r *CategoryRepository) GetProducts(ctx context.Context, categoryID uuid.UUID, at time.Time, sort repository.ProductSort, page, pageSize int) ([]*entity.Product, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for _, id := range r.productIDs(categoryID) {
+		if product := r.store.products[id]; product.IsOnSaleAt(at) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortProducts(ids, sort)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	products := make([]*entity.Product, 0, end-start)
+	for _, id := range ids[start:end] {
+		products = append(products, r.store.product(id))
+	}
+	return products, len(ids), nil
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// productIDs returns the products assigned to a category that aren't deleted
+func (r *CategoryRepository) productIDs(categoryID uuid.UUID) []uuid.UUID {
+	var ids []uuid.UUID
+	for assignment := range r.store.productCategories {
+		product, ok := r.store.products[assignment.ProductID]
+		if assignment.CategoryID == categoryID && ok && !deleted(product.DeletedAt) {
+			ids = append(ids, assignment.ProductID)
+		}
+	}
+	r.store.sortInserted(ids)
+	return ids
+}
+
+// live returns the category unless it is missing or deleted
+func (r *CategoryRepository) live(id uuid.UUID) (entity.Category, bool) {
+	category, ok := r.store.categories[id]
+	return category, ok && !deleted(category.DeletedAt)
+}
+
+// byName returns copies of the categories that aren't deleted, by name
+func (r *CategoryRepository) byName() []*entity.Category {
+	var categories []*entity.Category
+	for _, category := range r.store.categories {
+		if !deleted(category.DeletedAt) {
+			category := category
+			categories = append(categories, &category)
+		}
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Name < categories[j].Name
+	})
+	return categories
+}
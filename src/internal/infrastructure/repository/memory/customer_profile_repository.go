@@ -0,0 +1,110 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type CustomerProfileRepository struct {
+	store *Store
+}
+
+func NewCustomerProfileRepository(store *Store) repository.CustomerProfileRepository {
+	return &CustomerProfileRepository{store: store}
+}
+
+func (r *CustomerProfileRepository) AddNote(ctx context.Context, note *entity.CustomerNote) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(note); err != nil {
+		return err
+	}
+	if _, exists := r.store.notes[note.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&note.CreatedAt, nil)
+
+	r.store.notes[note.ID] = *note
+	r.store.track(note.ID)
+	return nil
+}
+
+func (r *CustomerProfileRepository) ListNotes(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerNote, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, note := range r.store.notes {
+		if note.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.notes[id].CreatedAt }, true)
+
+	notes := make([]*entity.CustomerNote, 0, len(ids))
+	for _, id := range ids {
+		note := r.store.notes[id]
+		notes = append(notes, &note)
+	}
+	return notes, nil
+}
+
+func (r *CustomerProfileRepository) AddRiskEvent(ctx context.Context, event *entity.CustomerRiskEvent) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(event); err != nil {
+		return err
+	}
+	if _, exists := r.store.riskEvents[event.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&event.CreatedAt, nil)
+
+	r.store.riskEvents[event.ID] = *event
+	r.store.track(event.ID)
+	return nil
+}
+
+func (r *CustomerProfileRepository) ListRiskEvents(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerRiskEvent, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, event := range r.store.riskEvents {
+		if event.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.riskEvents[id].CreatedAt }, true)
+
+	events := make([]*entity.CustomerRiskEvent, 0, len(ids))
+	for _, id := range ids {
+		event := r.store.riskEvents[id]
+		events = append(events, &event)
+	}
+	return events, nil
+}
+
+func (r *CustomerProfileRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, note := range r.store.notes {
+		if note.UserID == userID {
+			delete(r.store.notes, id)
+		}
+	}
+	for id, event := range r.store.riskEvents {
+		if event.UserID == userID {
+			delete(r.store.riskEvents, id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type CustomerRepository struct {
+	store *Store
+}
+
+func NewCustomerRepository(store *Store) repository.CustomerRepository {
+	return &CustomerRepository{store: store}
+}
+
+func (r *CustomerRepository) Get(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	customer, ok := r.store.customers[userID]
+	if !ok {
+		return nil, entity.NotFoundError("Customer not found")
+	}
+	customer.Addresses = append([]entity.CustomerAddress(nil), customer.Addresses...)
+	return &customer, nil
+}
+
+// Save upserts the customer and replaces its addresses
+func (r *CustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	stamp(&customer.CreatedAt, &customer.UpdatedAt)
+	for i := range customer.Addresses {
+		address := &customer.Addresses[i]
+		address.UserID = customer.UserID
+		if err := beforeCreate(address); err != nil {
+			return err
+		}
+		stamp(&address.CreatedAt, nil)
+	}
+
+	row := *customer
+	row.Addresses = append([]entity.CustomerAddress(nil), customer.Addresses...)
+	if _, exists := r.store.customers[customer.UserID]; !exists {
+		if row.CustomerGroup == "" {
+			row.CustomerGroup = entity.RetailGroup // The column default
+		}
+		r.store.track(customer.UserID)
+	}
+	r.store.customers[customer.UserID] = row
+	return nil
+}
+
+func (r *CustomerRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.customers, userID)
+	return nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type DeadLetterRepository struct {
+	store *Store
+}
+
+func NewDeadLetterRepository(store *Store) repository.DeadLetterRepository {
+	return &DeadLetterRepository{store: store}
+}
+
+// Record upserts on the unique index on source and payload hash
+func (r *DeadLetterRepository) Record(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.deadLetters {
+		if existing.Source == webhook.Source && existing.PayloadHash == webhook.PayloadHash {
+			existing.Attempts++
+			existing.Reason = webhook.Reason
+			existing.LastFailedAt = webhook.LastFailedAt
+			r.store.deadLetters[id] = existing
+			return nil
+		}
+	}
+
+	newID(&webhook.ID)
+	stamp(&webhook.CreatedAt, nil)
+	r.store.deadLetters[webhook.ID] = *webhook
+	r.store.track(webhook.ID)
+	return nil
+}
+
+func (r *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DeadLetterWebhook, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	webhook, ok := r.store.deadLetters[id]
+	if !ok {
+		return nil, entity.NotFoundError("Dead-lettered webhook not found")
+	}
+	return &webhook, nil
+}
+
+func (r *DeadLetterRepository) Update(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.deadLetters[webhook.ID]; !exists {
+		r.store.track(webhook.ID)
+	}
+	r.store.deadLetters[webhook.ID] = *webhook
+	return nil
+}
+
+func (r *DeadLetterRepository) List(ctx context.Context, filters repository.DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, webhook := range r.store.deadLetters {
+		if filters.Status != nil && webhook.Status != *filters.Status {
+			continue
+		}
+		if filters.Source != nil && webhook.Source != *filters.Source {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.deadLetters[id].LastFailedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	webhooks := make([]entity.DeadLetterWebhook, 0, end-start)
+	for _, id := range ids[start:end] {
+		webhooks = append(webhooks, r.store.deadLetters[id])
+	}
+	return webhooks, len(ids), nil
+}
@@ -0,0 +1,142 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type DraftOrderRepository struct {
+	store *Store
+}
+
+func NewDraftOrderRepository(store *Store) repository.DraftOrderRepository {
+	return &DraftOrderRepository{store: store}
+}
+
+func (r *DraftOrderRepository) Create(ctx context.Context, draft *entity.DraftOrder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(draft); err != nil {
+		return err
+	}
+	if _, exists := r.store.draftOrders[draft.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	for _, existing := range r.store.draftOrders {
+		if existing.Token == draft.Token {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if draft.Status == "" {
+		draft.Status = entity.DraftOrderOpen
+	}
+	stamp(&draft.CreatedAt, &draft.UpdatedAt)
+	if err := r.prepareItems(draft); err != nil {
+		return err
+	}
+
+	r.store.draftOrders[draft.ID] = *copyDraftOrder(*draft)
+	r.store.track(draft.ID)
+	return nil
+}
+
+func (r *DraftOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	draft, ok := r.store.draftOrders[id]
+	if !ok {
+		return nil, entity.NotFoundError("Draft order not found")
+	}
+	return copyDraftOrder(draft), nil
+}
+
+func (r *DraftOrderRepository) GetByToken(ctx context.Context, token string) (*entity.DraftOrder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, draft := range r.store.draftOrders {
+		if draft.Token == token {
+			return copyDraftOrder(draft), nil
+		}
+	}
+	return nil, entity.NotFoundError("Draft order not found")
+}
+
+func (r *DraftOrderRepository) Update(ctx context.Context, draft *entity.DraftOrder) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.draftOrders[draft.ID]; !ok {
+		return entity.NotFoundError("Draft order not found")
+	}
+	draft.UpdatedAt = time.Now()
+	for i := range draft.Items {
+		draft.Items[i].ID = uuid.Nil
+	}
+	if err := r.prepareItems(draft); err != nil {
+		return err
+	}
+	r.store.draftOrders[draft.ID] = *copyDraftOrder(*draft)
+	return nil
+}
+
+func (r *DraftOrderRepository) UpdateStatus(ctx context.Context, draft *entity.DraftOrder, from entity.DraftOrderStatus) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.draftOrders[draft.ID]
+	if !ok || row.Status != from {
+		return entity.ConflictError("The draft order was changed meanwhile, reload it and try again")
+	}
+	draft.UpdatedAt = time.Now()
+	row.Status, row.OrderID, row.ConvertedAt, row.UpdatedAt = draft.Status, draft.OrderID, draft.ConvertedAt, draft.UpdatedAt
+	r.store.draftOrders[draft.ID] = row
+	return nil
+}
+
+func (r *DraftOrderRepository) List(ctx context.Context, filters repository.DraftOrderFilters, page, pageSize int) ([]*entity.DraftOrder, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, draft := range r.store.draftOrders {
+		if filters.Status != nil && draft.Status != *filters.Status {
+			continue
+		}
+		if filters.CustomerID != nil && draft.CustomerID != *filters.CustomerID {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.draftOrders[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	drafts := make([]*entity.DraftOrder, 0, end-start)
+	for _, id := range ids[start:end] {
+		drafts = append(drafts, copyDraftOrder(r.store.draftOrders[id]))
+	}
+	return drafts, len(ids), nil
+}
+
+// prepareItems links the items to the draft and gives the new ones an ID
+func (r *DraftOrderRepository) prepareItems(draft *entity.DraftOrder) error {
+	for i := range draft.Items {
+		draft.Items[i].DraftOrderID = draft.ID
+		if err := beforeCreate(&draft.Items[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyDraftOrder(row entity.DraftOrder) *entity.DraftOrder {
+	row.Items = append([]entity.DraftOrderItem(nil), row.Items...)
+	return &row
+}
@@ -0,0 +1,115 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type EmailTemplateRepository struct {
+	store *Store
+}
+
+func NewEmailTemplateRepository(store *Store) repository.EmailTemplateRepository {
+	return &EmailTemplateRepository{store: store}
+}
+
+func (r *EmailTemplateRepository) CreateVersion(ctx context.Context, template *entity.EmailTemplate) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	latest := 0
+	for _, existing := range r.store.emailTemplates {
+		if existing.Key == template.Key && existing.Version > latest {
+			latest = existing.Version
+		}
+	}
+
+	template.Version = latest + 1
+	if err := beforeCreate(template); err != nil {
+		return err
+	}
+	if _, exists := r.store.emailTemplates[template.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&template.CreatedAt, nil)
+
+	r.store.emailTemplates[template.ID] = *template
+	r.store.track(template.ID)
+	return nil
+}
+
+func (r *EmailTemplateRepository) GetLatest(ctx context.Context, key string) (*entity.EmailTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	versions := r.versions(key)
+	if len(versions) == 0 {
+		return nil, entity.NotFoundError("Email template not found")
+	}
+	return versions[0], nil
+}
+
+func (r *EmailTemplateRepository) GetVersion(ctx context.Context, key string, version int) (*entity.EmailTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, template := range r.store.emailTemplates {
+		if template.Key == key && template.Version == version {
+			return &template, nil
+		}
+	}
+	return nil, entity.NotFoundError("Email template version not found")
+}
+
+func (r *EmailTemplateRepository) ListLatest(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	latest := make(map[string]entity.EmailTemplate)
+	for _, template := range r.store.emailTemplates {
+		if current, ok := latest[template.Key]; !ok || template.Version > current.Version {
+			latest[template.Key] = template
+		}
+	}
+
+	templates := make([]*entity.EmailTemplate, 0, len(latest))
+	for _, template := range latest {
+		templates = append(templates, &template)
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Key < templates[j].Key
+	})
+	return templates, nil
+}
+
+func (r *EmailTemplateRepository) ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.versions(key), nil
+}
+
+// versions returns every version of the template, newest first
+func (r *EmailTemplateRepository) versions(key string) []*entity.EmailTemplate {
+	var ids []uuid.UUID
+	for id, template := range r.store.emailTemplates {
+		if template.Key == key {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return r.store.emailTemplates[ids[i]].Version > r.store.emailTemplates[ids[j]].Version
+	})
+
+	templates := make([]*entity.EmailTemplate, 0, len(ids))
+	for _, id := range ids {
+		template := r.store.emailTemplates[id]
+		templates = append(templates, &template)
+	}
+	return templates
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type InventoryRepository struct {
+	store *Store
+}
+
+func NewInventoryRepository(store *Store) repository.InventoryRepository {
+	return &InventoryRepository{store: store}
+}
+
+func (r *InventoryRepository) SyncStock(ctx context.Context, sync *entity.InventorySync) ([]entity.StockLevelResult, []*entity.StockMovement, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	skus := make(map[string]bool, len(sync.Levels))
+	for _, sku := range sync.SKUs() {
+		skus[sku] = true
+	}
+	bySKU := make(map[string]*entity.ProductVariant)
+	for _, variant := range r.store.variants {
+		if deleted(variant.DeletedAt) || !skus[variant.SKU] {
+			continue
+		}
+		if product, ok := r.store.products[variant.ProductID]; !ok || deleted(product.DeletedAt) {
+			continue
+		}
+		variant := variant
+		bySKU[variant.SKU] = &variant
+	}
+
+	results, movements := sync.Apply(bySKU)
+	variants := &ProductVariantRepository{store: r.store}
+	for _, movement := range movements {
+		variants.setStock(movement)
+		if err := r.store.insertStockMovement(movement); err != nil {
+			return nil, nil, err
+		}
+	}
+	return results, movements, nil
+}
@@ -0,0 +1,79 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type InvoiceRepository struct {
+	store *Store
+}
+
+func NewInvoiceRepository(store *Store) repository.InvoiceRepository {
+	return &InvoiceRepository{store: store}
+}
+
+// CreateWithNextNumber renders without holding the store, so render may use
+// the repositories. Invoices are still numbered one at a time.
+func (r *InvoiceRepository) CreateWithNextNumber(ctx context.Context, invoice *entity.Invoice, render func(invoice *entity.Invoice) error) error {
+	r.store.invoiceMu.Lock()
+	defer r.store.invoiceMu.Unlock()
+
+	year := invoice.IssuedAt.Year()
+	r.store.mu.RLock()
+	sequence := r.store.invoiceNumbers[year] + 1
+	r.store.mu.RUnlock()
+
+	invoice.AssignNumber(year, sequence)
+
+	if err := render(invoice); err != nil {
+		return err
+	}
+
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(invoice); err != nil {
+		return err
+	}
+	for id, existing := range r.store.invoices {
+		if id == invoice.ID || existing.OrderID == invoice.OrderID || existing.Number == invoice.Number {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	stamp(&invoice.CreatedAt, nil)
+
+	r.store.invoiceNumbers[year] = sequence
+	r.store.invoices[invoice.ID] = *invoice
+	r.store.track(invoice.ID)
+	return nil
+}
+
+func (r *InvoiceRepository) GetByOrderID(ctx context.Context, orderID uuid.UUID) (*entity.Invoice, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, invoice := range r.store.invoices {
+		if invoice.OrderID == orderID {
+			return &invoice, nil
+		}
+	}
+	return nil, entity.NotFoundError("Invoice not found")
+}
+
+func (r *InvoiceRepository) UpdatePDF(ctx context.Context, invoice *entity.Invoice) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.invoices[invoice.ID]
+	if !ok {
+		return nil
+	}
+	existing.PDF = invoice.PDF
+	r.store.invoices[invoice.ID] = existing
+	return nil
+}
@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type LogArchiveRepository struct {
+	store *Store
+}
+
+func NewLogArchiveRepository(store *Store) repository.LogArchiveRepository {
+	return &LogArchiveRepository{store: store}
+}
+
+func (r *LogArchiveRepository) ArchiveAuditLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []uuid.UUID
+	for id, log := range r.store.auditLogs {
+		if log.Timestamp.Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.auditLogs[id].Timestamp }, false)
+	ids = ids[:limitRows(len(ids), batchSize)]
+
+	now := time.Now()
+	for _, id := range ids {
+		log := r.store.auditLogs[id]
+		r.store.archivedAudits[id] = *entity.NewArchivedAuditLog(&log, now)
+		delete(r.store.auditLogs, id)
+	}
+	return len(ids), nil
+}
+
+func (r *LogArchiveRepository) ArchiveWebhookLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []uuid.UUID
+	for id, log := range r.store.webhookLogs {
+		processed := log.Status == entity.WebhookStatusCompleted || log.Status == entity.WebhookStatusFailed || log.Status == entity.WebhookStatusResolved
+		if log.CreatedAt.Before(cutoff) && processed {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.webhookLogs[id].CreatedAt }, false)
+	ids = ids[:limitRows(len(ids), batchSize)]
+
+	now := time.Now()
+	for _, id := range ids {
+		log := r.store.webhookLogs[id]
+		r.store.archivedHooks[id] = *entity.NewArchivedWebhookLog(&log, now)
+		delete(r.store.webhookLogs, id)
+	}
+	return len(ids), nil
+}
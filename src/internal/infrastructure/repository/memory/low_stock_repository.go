@@ -0,0 +1,57 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type LowStockRepository struct {
+	store *Store
+}
+
+func NewLowStockRepository(store *Store) repository.LowStockRepository {
+	return &LowStockRepository{store: store}
+}
+
+// ListLowStock represents products with variants by their variants, since
+// their own quantity is not what is sold
+func (r *LowStockRepository) ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var items []entity.LowStockItem
+	for _, id := range r.store.liveProductIDs() {
+		product := r.store.products[id]
+		variants := r.store.productVariants(id)
+
+		if len(variants) == 0 && product.Quantity <= threshold {
+			items = append(items, entity.LowStockItem{
+				ProductID:   product.ID,
+				ProductName: product.Name,
+				Quantity:    product.Quantity,
+			})
+		}
+		for _, variant := range variants {
+			if variant.Quantity <= threshold {
+				items = append(items, entity.LowStockItem{
+					ProductID:   product.ID,
+					VariantID:   &variant.ID,
+					ProductName: product.Name,
+					SKU:         variant.SKU,
+					Quantity:    variant.Quantity,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Quantity != items[j].Quantity {
+			return items[i].Quantity < items[j].Quantity
+		}
+		return items[i].ProductName < items[j].ProductName
+	})
+	return items, nil
+}
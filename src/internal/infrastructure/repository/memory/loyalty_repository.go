@@ -0,0 +1,119 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type LoyaltyRepository struct {
+	store *Store
+}
+
+func NewLoyaltyRepository(store *Store) repository.LoyaltyRepository {
+	return &LoyaltyRepository{store: store}
+}
+
+func (r *LoyaltyRepository) Create(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.insert(transaction)
+}
+
+// insert expects the caller to hold the store lock
+func (r *LoyaltyRepository) insert(transaction *entity.LoyaltyTransaction) error {
+	newID(&transaction.ID)
+	if _, exists := r.store.loyalty[transaction.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	// The unique index on the order and type
+	if transaction.OrderID != nil {
+		for _, existing := range r.store.loyalty {
+			if existing.OrderID != nil && *existing.OrderID == *transaction.OrderID && existing.Type == transaction.Type {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+	}
+	stamp(&transaction.CreatedAt, nil)
+
+	r.store.loyalty[transaction.ID] = *transaction
+	r.store.track(transaction.ID)
+	return nil
+}
+
+func (r *LoyaltyRepository) Redeem(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.users[transaction.UserID]; !ok {
+		return entity.NotFoundError("User not found")
+	}
+	if err := transaction.Spend(r.balance(transaction.UserID)); err != nil {
+		return err
+	}
+	return r.insert(transaction)
+}
+
+func (r *LoyaltyRepository) Balance(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.balance(userID), nil
+}
+
+// balance expects the caller to hold the store lock
+func (r *LoyaltyRepository) balance(userID uuid.UUID) int {
+	points := 0
+	for _, transaction := range r.store.loyalty {
+		if transaction.UserID == userID {
+			points += transaction.Points
+		}
+	}
+	return points
+}
+
+func (r *LoyaltyRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.LoyaltyTransaction, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, transaction := range r.store.loyalty {
+		if transaction.OrderID != nil && *transaction.OrderID == orderID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.loyalty[id].CreatedAt }, false)
+
+	transactions := make([]*entity.LoyaltyTransaction, 0, len(ids))
+	for _, id := range ids {
+		transaction := r.store.loyalty[id]
+		transactions = append(transactions, &transaction)
+	}
+	return transactions, nil
+}
+
+func (r *LoyaltyRepository) ListByUser(ctx context.Context, userID uuid.UUID, page, pageSize int) ([]*entity.LoyaltyTransaction, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, transaction := range r.store.loyalty {
+		if transaction.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.loyalty[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	transactions := make([]*entity.LoyaltyTransaction, 0, end-start)
+	for _, id := range ids[start:end] {
+		transaction := r.store.loyalty[id]
+		transactions = append(transactions, &transaction)
+	}
+	return transactions, len(ids), nil
+}
@@ -0,0 +1,107 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type OrderArchiveRepository struct {
+	store *Store
+}
+
+func NewOrderArchiveRepository(store *Store) repository.OrderArchiveRepository {
+	return &OrderArchiveRepository{store: store}
+}
+
+func (r *OrderArchiveRepository) ArchiveBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var ids []uuid.UUID
+	for id, order := range r.store.orders {
+		if order.CreatedAt.Before(cutoff) && order.IsArchivable() {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.orders[id].CreatedAt }, false)
+	ids = ids[:limitRows(len(ids), batchSize)]
+
+	// Snapshot every order before moving any, so a failure leaves them all in place
+	now := time.Now()
+	archived := make([]*entity.ArchivedOrder, 0, len(ids))
+	for _, id := range ids {
+		a, err := entity.NewArchivedOrder(r.store.order(id), now)
+		if err != nil {
+			return 0, err
+		}
+		archived = append(archived, a)
+	}
+
+	for _, a := range archived {
+		r.store.archivedOrders[a.ID] = *a
+		r.store.deleteOrder(a.ID)
+	}
+	return len(archived), nil
+}
+
+func (r *OrderArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	archived, ok := r.store.archivedOrders[id]
+	if !ok {
+		return nil, entity.NotFoundError("Order not found")
+	}
+	return archived.ToOrder()
+}
+
+func (r *OrderArchiveRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, archived := range r.store.archivedOrders {
+		if archived.UserID != nil && *archived.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.archivedOrders[id].OrderCreatedAt }, true)
+
+	orders := make([]*entity.Order, 0, len(ids))
+	for _, id := range ids {
+		archived := r.store.archivedOrders[id]
+		order, err := archived.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// AnonymizeUser rewrites every snapshot before storing any, so a failure
+// leaves them all as they were
+func (r *OrderArchiveRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var anonymized []entity.ArchivedOrder
+	for _, archived := range r.store.archivedOrders {
+		if archived.UserID == nil || *archived.UserID != userID {
+			continue
+		}
+		if err := archived.Anonymize(); err != nil {
+			return 0, err
+		}
+		anonymized = append(anonymized, archived)
+	}
+
+	for _, archived := range anonymized {
+		r.store.archivedOrders[archived.ID] = archived
+	}
+	return len(anonymized), nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type OrderRemediationRepository struct {
+	store *Store
+}
+
+func NewOrderRemediationRepository(store *Store) repository.OrderRemediationRepository {
+	return &OrderRemediationRepository{store: store}
+}
+
+func (r *OrderRemediationRepository) Create(ctx context.Context, remediation *entity.OrderRemediation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(remediation); err != nil {
+		return err
+	}
+	if _, exists := r.store.remediations[remediation.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&remediation.CreatedAt, nil)
+
+	r.store.remediations[remediation.ID] = *remediation
+	r.store.track(remediation.ID)
+	return nil
+}
+
+func (r *OrderRemediationRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, remediation := range r.store.remediations {
+		if remediation.OrderID == orderID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.remediations[id].CreatedAt }, true)
+
+	remediations := make([]*entity.OrderRemediation, 0, len(ids))
+	for _, id := range ids {
+		remediation := r.store.remediations[id]
+		remediations = append(remediations, &remediation)
+	}
+	return remediations, nil
+}
+
+func (r *OrderRemediationRepository) SumByActorSince(ctx context.Context, actorID uuid.UUID, since time.Time) (float64, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var total float64
+	for _, remediation := range r.store.remediations {
+		if remediation.PerformedBy == actorID && !remediation.CreatedAt.Before(since) {
+			total += remediation.Amount
+		}
+	}
+	return total, nil
+}
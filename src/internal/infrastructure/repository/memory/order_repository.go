@@ -0,0 +1,379 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type OrderRepository struct {
+	store *Store
+}
+
+func NewOrderRepository(store *Store) repository.OrderRepository {
+	return &OrderRepository{store: store}
+}
+
+// Create stores the order with its items and their components
+func (r *OrderRepository) Create(ctx context.Context, order *entity.Order) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(order); err != nil {
+		return err
+	}
+	if _, exists := r.store.orders[order.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	// Zero values take the column defaults, as with GORM
+	if order.Status == "" {
+		order.Status = entity.Pending
+	}
+	if order.PaymentStatus == "" {
+		order.PaymentStatus = entity.Unpaid
+	}
+	stamp(&order.CreatedAt, &order.UpdatedAt)
+
+	row := *order
+	row.Products = nil
+	r.store.orders[order.ID] = row
+	r.store.track(order.ID)
+
+	return r.insertItems(order)
+}
+
+// insertItems stores the items of the order it doesn't have yet, with their
+// components. Items already stored are left as they are, as when GORM saves
+// associations.
+func (r *OrderRepository) insertItems(order *entity.Order) error {
+	for i := range order.Products {
+		item := &order.Products[i]
+		item.OrderID = order.ID
+		if _, exists := r.store.orderItems[item.ID]; exists {
+			continue
+		}
+		newID(&item.ID)
+
+		row := *item
+		row.Components = nil
+		r.store.orderItems[item.ID] = row
+		r.store.track(item.ID)
+
+		for j := range item.Components {
+			component := &item.Components[j]
+			component.OrderItemID = item.ID
+			if err := beforeCreate(component); err != nil {
+				return err
+			}
+			if _, exists := r.store.components[component.ID]; exists {
+				continue
+			}
+			r.store.components[component.ID] = *component
+			r.store.track(component.ID)
+		}
+	}
+	return nil
+}
+
+func (r *OrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.store.orders[id]; !ok {
+		return nil, entity.NotFoundError("Order not found")
+	}
+	return r.store.order(id), nil
+}
+
+func (r *OrderRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	orders := make([]*entity.Order, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := r.store.orders[id]; ok {
+			orders = append(orders, r.store.order(id))
+		}
+	}
+	return orders, nil
+}
+
+func (r *OrderRepository) GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, order := range r.store.orders {
+		if status != nil && order.Status != *status {
+			continue
+		}
+		if paymentStatus != nil && order.PaymentStatus != *paymentStatus {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortInserted(ids)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	orders := make([]*entity.Order, 0, end-start)
+	for _, id := range ids[start:end] {
+		orders = append(orders, r.store.order(id))
+	}
+	return orders, len(ids), nil
+}
+
+func (r *OrderRepository) Search(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var userIDs map[uuid.UUID]bool
+	if search.CustomerEmail != "" {
+		userIDs = make(map[uuid.UUID]bool)
+		for id, user := range r.store.users {
+			if user.Email == search.CustomerEmail {
+				userIDs[id] = true
+			}
+		}
+	}
+	var skuOrders map[uuid.UUID]bool
+	if search.SKU != "" {
+		skuOrders = make(map[uuid.UUID]bool)
+		for _, item := range r.store.orderItems {
+			if item.SKU == search.SKU {
+				skuOrders[item.OrderID] = true
+			}
+		}
+	}
+
+	var ids []uuid.UUID
+	for id, order := range r.store.orders {
+		switch {
+		case userIDs != nil && (order.UserID == nil || !userIDs[*order.UserID]),
+			search.From != nil && order.CreatedAt.Before(*search.From),
+			search.To != nil && !order.CreatedAt.Before(*search.To),
+			search.MinTotal != nil && order.TotalPrice < *search.MinTotal,
+			search.MaxTotal != nil && order.TotalPrice > *search.MaxTotal,
+			skuOrders != nil && !skuOrders[id],
+			search.Status != nil && order.Status != *search.Status,
+			search.PaymentStatus != nil && order.PaymentStatus != *search.PaymentStatus:
+			continue
+		}
+		ids = append(ids, id)
+	}
+
+	descending := search.Sort.Descending
+	if search.Sort.Field == repository.OrderSortTotalPrice {
+		r.store.sortInserted(ids)
+		sort.SliceStable(ids, func(i, j int) bool {
+			a, b := r.store.orders[ids[i]].TotalPrice, r.store.orders[ids[j]].TotalPrice
+			return a != b && (a < b) != descending
+		})
+	} else {
+		r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.orders[id].CreatedAt }, descending)
+	}
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	orders := make([]*entity.Order, 0, end-start)
+	for _, id := range ids[start:end] {
+		orders = append(orders, r.store.order(id))
+	}
+	return orders, len(ids), nil
+}
+
+// Update saves the order's columns and stores the items it doesn't have yet
+func (r *OrderRepository) Update(ctx context.Context, order *entity.Order) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.orders[order.ID]; !ok {
+		return entity.NotFoundError("Order not found")
+	}
+
+	order.UpdatedAt = time.Now()
+	row := *order
+	row.Products = nil
+	r.store.orders[order.ID] = row
+
+	return r.insertItems(order)
+}
+
+func (r *OrderRepository) UpdateStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate, workflow *entity.OrderWorkflow) ([]entity.OrderStatusResult, []*entity.Order, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	byID := make(map[uuid.UUID]*entity.Order, len(update.OrderIDs))
+	for _, id := range update.OrderIDs {
+		if _, ok := r.store.orders[id]; ok {
+			byID[id] = r.store.order(id)
+		}
+	}
+
+	results, updated := update.Apply(byID, workflow)
+	for _, order := range updated {
+		row := r.store.orders[order.ID]
+		row.Status, row.UpdatedAt = order.Status, order.UpdatedAt
+		r.store.orders[order.ID] = row
+	}
+	return results, updated, nil
+}
+
+func (r *OrderRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, order := range r.store.orders {
+		if order.IsOwnedBy(userID) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.orders[id].CreatedAt }, true)
+
+	orders := make([]*entity.Order, 0, len(ids))
+	for _, id := range ids {
+		orders = append(orders, r.store.order(id))
+	}
+	return orders, nil
+}
+
+func (r *OrderRepository) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, order := range r.store.orders {
+		if order.IsOwnedBy(userID) && !order.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *OrderRepository) CountPurchased(ctx context.Context, userID uuid.UUID, productID uuid.UUID) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	purchased := 0
+	for _, item := range r.store.orderItems {
+		order, ok := r.store.orders[item.OrderID]
+		if !ok || !order.IsOwnedBy(userID) || item.ProductID != productID {
+			continue
+		}
+		if order.Status != entity.Cancelled && order.Status != entity.Refunded {
+			purchased += item.Quantity
+		}
+	}
+	return purchased, nil
+}
+
+func (r *OrderRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	changed := 0
+	for id, order := range r.store.orders {
+		if order.IsOwnedBy(userID) {
+			order.UserID = nil
+			order.UpdatedAt = time.Now()
+			r.store.orders[id] = order
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// ScanByCreatedAt reads the whole range at once, then calls fn without
+// holding the store, so fn may use the repositories
+func (r *OrderRepository) ScanByCreatedAt(ctx context.Context, from, until time.Time, batchSize int, fn func(orders []*entity.Order) error) error {
+	r.store.mu.RLock()
+	var orders []*entity.Order
+	for id, order := range r.store.orders {
+		if !order.CreatedAt.Before(from) && order.CreatedAt.Before(until) {
+			orders = append(orders, r.store.order(id))
+		}
+	}
+	r.store.mu.RUnlock()
+
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].CreatedAt.Equal(orders[j].CreatedAt) {
+			return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+		}
+		return orders[i].ID.String() < orders[j].ID.String()
+	})
+
+	return inBatches(len(orders), batchSize, func(start, end int) error {
+		return fn(orders[start:end])
+	})
+}
+
+// inBatches calls fn with the bounds of each batch of total rows
+func inBatches(total, batchSize int, fn func(start, end int) error) error {
+	if batchSize <= 0 {
+		batchSize = total
+	}
+	for start := 0; start < total; start += batchSize {
+		end := start + batchSize
+		if end > total {
+			end = total
+		}
+		if err := fn(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// order returns a copy of the order with its items and their components,
+// expecting the caller to hold the store lock
+func (s *Store) order(id uuid.UUID) *entity.Order {
+	order := s.orders[id]
+
+	var itemIDs []uuid.UUID
+	for itemID, item := range s.orderItems {
+		if item.OrderID == id {
+			itemIDs = append(itemIDs, itemID)
+		}
+	}
+	s.sortInserted(itemIDs)
+
+	for _, itemID := range itemIDs {
+		item := s.orderItems[itemID]
+
+		var componentIDs []uuid.UUID
+		for componentID, component := range s.components {
+			if component.OrderItemID == itemID {
+				componentIDs = append(componentIDs, componentID)
+			}
+		}
+		s.sortInserted(componentIDs)
+		for _, componentID := range componentIDs {
+			item.Components = append(item.Components, s.components[componentID])
+		}
+
+		order.Products = append(order.Products, item)
+	}
+	return &order
+}
+
+// deleteOrder removes the order with its items and their components,
+// expecting the caller to hold the store lock
+func (s *Store) deleteOrder(id uuid.UUID) {
+	for itemID, item := range s.orderItems {
+		if item.OrderID != id {
+			continue
+		}
+		for componentID, component := range s.components {
+			if component.OrderItemID == itemID {
+				delete(s.components, componentID)
+			}
+		}
+		delete(s.orderItems, itemID)
+	}
+	delete(s.orders, id)
+}
@@ -0,0 +1,278 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newOrder(t *testing.T, store *Store, status entity.OrderStatus, createdAt time.Time) *entity.Order {
+	t.Helper()
+	order := &entity.Order{
+		CustomerID: 1,
+		Status:     status,
+		TotalPrice: 20,
+		CreatedAt:  createdAt,
+		Products:   []entity.OrderItem{{ProductID: uuid.New(), Quantity: 2, Price: 10, TotalPrice: 20}},
+	}
+	require.NoError(t, NewOrderRepository(store).Create(context.Background(), order))
+	return order
+}
+
+func TestOrderRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Applies the column defaults and stores the items", func(t *testing.T) {
+		store := NewStore()
+		order := newOrder(t, store, "", time.Time{})
+
+		found, err := NewOrderRepository(store).GetByID(ctx, order.ID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.Pending, found.Status)
+		assert.Equal(t, entity.Unpaid, found.PaymentStatus)
+		require.Len(t, found.Products, 1)
+		assert.Equal(t, order.ID, found.Products[0].OrderID)
+		assert.False(t, found.CreatedAt.IsZero())
+	})
+
+	t.Run("Scans the range in batches, oldest first", func(t *testing.T) {
+		store := NewStore()
+		now := time.Now()
+		newOrder(t, store, entity.Pending, now.Add(-time.Hour))
+		newOrder(t, store, entity.Pending, now.Add(-3*time.Hour))
+		newOrder(t, store, entity.Pending, now.Add(-2*time.Hour))
+		newOrder(t, store, entity.Pending, now.Add(-48*time.Hour))
+
+		var batches [][]time.Time
+		err := NewOrderRepository(store).ScanByCreatedAt(ctx, now.Add(-24*time.Hour), now, 2, func(orders []*entity.Order) error {
+			var batch []time.Time
+			for _, order := range orders {
+				batch = append(batch, order.CreatedAt)
+			}
+			batches = append(batches, batch)
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.Len(t, batches, 2)
+		assert.Equal(t, []time.Time{now.Add(-3 * time.Hour), now.Add(-2 * time.Hour)}, batches[0])
+		assert.Equal(t, []time.Time{now.Add(-time.Hour)}, batches[1])
+	})
+
+	t.Run("Counts the units an account bought, leaving out cancelled orders", func(t *testing.T) {
+		store := NewStore()
+		userID := uuid.New()
+		productID := uuid.New()
+		for _, order := range []*entity.Order{
+			{CustomerID: 1, UserID: &userID, Status: entity.Pending},
+			{CustomerID: 2, UserID: &userID, Status: entity.Pending},
+			{CustomerID: 1, UserID: &userID, Status: entity.Cancelled},
+			{CustomerID: 1, Status: entity.Pending},
+		} {
+			order.TotalPrice = 20
+			order.Products = []entity.OrderItem{{ProductID: productID, Quantity: 2, Price: 10, TotalPrice: 20}}
+			require.NoError(t, NewOrderRepository(store).Create(ctx, order))
+		}
+
+		purchased, err := NewOrderRepository(store).CountPurchased(ctx, userID, productID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, purchased, "Every customer ID the account ordered under counts")
+
+		purchased, err = NewOrderRepository(store).CountPurchased(ctx, uuid.New(), productID)
+		require.NoError(t, err)
+		assert.Zero(t, purchased)
+	})
+
+	t.Run("Gets orders by ID with their items", func(t *testing.T) {
+		store := NewStore()
+		first := newOrder(t, store, entity.Pending, time.Now())
+		second := newOrder(t, store, entity.Completed, time.Now())
+
+		orders, err := NewOrderRepository(store).GetByIDs(ctx, []uuid.UUID{second.ID, uuid.New(), first.ID})
+
+		require.NoError(t, err)
+		require.Len(t, orders, 2)
+		assert.Equal(t, entity.Completed, orders[0].Status)
+		require.Len(t, orders[0].Products, 1)
+		found, _ := NewOrderRepository(store).GetByID(ctx, second.ID)
+		assert.Equal(t, found.Products, orders[0].Products, "the items come as GetByID loads them")
+	})
+
+	t.Run("Updates the statuses the workflow allows and keeps the items", func(t *testing.T) {
+		store := NewStore()
+		pending := newOrder(t, store, entity.Pending, time.Now())
+		completed := newOrder(t, store, entity.Completed, time.Now())
+		update := &entity.BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{pending.ID, completed.ID}, Status: entity.Cancelled}
+
+		results, updated, err := NewOrderRepository(store).UpdateStatuses(ctx, update, entity.NewSimpleOrderWorkflow())
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.OrderStatusUpdated, results[0].Outcome)
+		assert.Equal(t, entity.OrderStatusRejected, results[1].Outcome)
+		require.Len(t, updated, 1)
+		assert.Len(t, updated[0].Products, 1)
+		found, _ := NewOrderRepository(store).GetByID(ctx, pending.ID)
+		assert.Equal(t, entity.Cancelled, found.Status)
+		assert.Len(t, found.Products, 1)
+		found, _ = NewOrderRepository(store).GetByID(ctx, completed.ID)
+		assert.Equal(t, entity.Completed, found.Status)
+	})
+
+	t.Run("Searches by every filter given, sorted as asked", func(t *testing.T) {
+		store := NewStore()
+		user := &entity.User{Email: "ana@example.com", Name: "Ana"}
+		require.NoError(t, NewUserRepository(store).Create(ctx, user))
+		now := time.Now()
+		old := newOrder(t, store, entity.Completed, now.Add(-48*time.Hour))
+		recent := newOrder(t, store, entity.Pending, now.Add(-time.Hour))
+		theirs := &entity.Order{
+			CustomerID: 1, UserID: &user.ID, TotalPrice: 90, CreatedAt: now.Add(-2 * time.Hour),
+			Products: []entity.OrderItem{{ProductID: uuid.New(), Quantity: 1, Price: 90, TotalPrice: 90, SKU: "TSHIRT-L"}},
+		}
+		require.NoError(t, NewOrderRepository(store).Create(ctx, theirs))
+		orders := NewOrderRepository(store)
+		newestFirst := repository.OrderSort{Field: repository.OrderSortCreatedAt, Descending: true}
+
+		found, total, err := orders.Search(ctx, repository.OrderSearch{Sort: newestFirst}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []uuid.UUID{recent.ID, theirs.ID, old.ID}, orderIDs(found))
+
+		since, minTotal := now.Add(-24*time.Hour), 50.0
+		for name, search := range map[string]repository.OrderSearch{
+			"email": {CustomerEmail: "ana@example.com"},
+			"sku":   {SKU: "TSHIRT-L"},
+			"total": {MinTotal: &minTotal},
+			"all":   {CustomerEmail: "ana@example.com", From: &since, MinTotal: &minTotal, SKU: "TSHIRT-L", Status: &theirs.Status},
+		} {
+			search.Sort = newestFirst
+			found, total, err := orders.Search(ctx, search, 1, 10)
+			require.NoError(t, err)
+			assert.Equal(t, 1, total, name)
+			assert.Equal(t, []uuid.UUID{theirs.ID}, orderIDs(found), name)
+		}
+
+		found, _, err = orders.Search(ctx, repository.OrderSearch{To: &since, Sort: newestFirst}, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{old.ID}, orderIDs(found))
+
+		found, _, err = orders.Search(ctx, repository.OrderSearch{Sort: repository.OrderSort{Field: repository.OrderSortTotalPrice, Descending: true}}, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{theirs.ID, old.ID}, orderIDs(found))
+	})
+
+	t.Run("Is safe for concurrent use", func(t *testing.T) {
+		store := NewStore()
+		orders := NewOrderRepository(store)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				order := &entity.Order{CustomerID: 1, TotalPrice: 10}
+				assert.NoError(t, orders.Create(ctx, order))
+				_, _, err := orders.GetAll(ctx, 1, 10, nil, nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		_, total, err := orders.GetAll(ctx, 1, 10, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, 20, total)
+	})
+}
+
+func TestOrderArchiveRepository(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	old := newOrder(t, store, entity.Completed, time.Now().AddDate(-2, 0, 0))
+	open := newOrder(t, store, entity.Pending, time.Now().AddDate(-2, 0, 0))
+	recent := newOrder(t, store, entity.Completed, time.Now())
+	archive := NewOrderArchiveRepository(store)
+
+	archived, err := archive.ArchiveBatch(ctx, time.Now().AddDate(-1, 0, 0), 10)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, archived)
+
+	_, err = NewOrderRepository(store).GetByID(ctx, old.ID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+	restored, err := archive.GetByID(ctx, old.ID)
+	require.NoError(t, err)
+	require.Len(t, restored.Products, 1)
+	assert.Equal(t, 2, restored.Products[0].Quantity)
+	for _, item := range store.orderItems {
+		assert.NotEqual(t, old.ID, item.OrderID, "the items move with the order")
+	}
+
+	for _, id := range []uuid.UUID{open.ID, recent.ID} {
+		_, err := NewOrderRepository(store).GetByID(ctx, id)
+		assert.NoError(t, err)
+	}
+}
+
+func TestInvoiceRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Numbers invoices sequentially per year", func(t *testing.T) {
+		invoices := NewInvoiceRepository(NewStore())
+		issuedAt := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				invoice := &entity.Invoice{OrderID: uuid.New(), IssuedAt: issuedAt}
+				assert.NoError(t, invoices.CreateWithNextNumber(ctx, invoice, func(*entity.Invoice) error { return nil }))
+			}()
+		}
+		wg.Wait()
+
+		next := &entity.Invoice{OrderID: uuid.New(), IssuedAt: issuedAt}
+		require.NoError(t, invoices.CreateWithNextNumber(ctx, next, func(*entity.Invoice) error { return nil }))
+		assert.Equal(t, "INV-2026-000011", next.Number)
+
+		nextYear := &entity.Invoice{OrderID: uuid.New(), IssuedAt: issuedAt.AddDate(1, 0, 0)}
+		require.NoError(t, invoices.CreateWithNextNumber(ctx, nextYear, func(*entity.Invoice) error { return nil }))
+		assert.Equal(t, "INV-2027-000001", nextYear.Number)
+	})
+
+	t.Run("Doesn't use up a number when rendering or storing fails", func(t *testing.T) {
+		invoices := NewInvoiceRepository(NewStore())
+		orderID := uuid.New()
+		render := func(*entity.Invoice) error { return nil }
+		require.NoError(t, invoices.CreateWithNextNumber(ctx, &entity.Invoice{OrderID: orderID, IssuedAt: time.Now()}, render))
+
+		err := invoices.CreateWithNextNumber(ctx, &entity.Invoice{OrderID: uuid.New(), IssuedAt: time.Now()}, func(*entity.Invoice) error {
+			return errors.New("render failed")
+		})
+		assert.EqualError(t, err, "render failed")
+		err = invoices.CreateWithNextNumber(ctx, &entity.Invoice{OrderID: orderID, IssuedAt: time.Now()}, render)
+		assert.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+		next := &entity.Invoice{OrderID: uuid.New(), IssuedAt: time.Now()}
+		require.NoError(t, invoices.CreateWithNextNumber(ctx, next, render))
+		assert.Equal(t, 2, next.Sequence)
+	})
+}
+
+func orderIDs(orders []*entity.Order) []uuid.UUID {
+	ids := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		ids[i] = order.ID
+	}
+	return ids
+}
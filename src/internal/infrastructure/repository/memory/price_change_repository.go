@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type PriceChangeRepository struct {
+	store *Store
+}
+
+func NewPriceChangeRepository(store *Store) repository.PriceChangeRepository {
+	return &PriceChangeRepository{store: store}
+}
+
+func (r *PriceChangeRepository) Create(ctx context.Context, change *entity.PriceChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(change); err != nil {
+		return err
+	}
+	if _, exists := r.store.priceChanges[change.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&change.CreatedAt, nil)
+
+	r.store.priceChanges[change.ID] = *change
+	r.store.track(change.ID)
+	return nil
+}
+
+func (r *PriceChangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	change, ok := r.store.priceChanges[id]
+	if !ok {
+		return nil, entity.NotFoundError("Price change not found")
+	}
+	return &change, nil
+}
+
+func (r *PriceChangeRepository) Update(ctx context.Context, change *entity.PriceChange) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.priceChanges[change.ID]; !ok {
+		return entity.NotFoundError("Price change not found")
+	}
+	r.store.priceChanges[change.ID] = *change
+	return nil
+}
+
+func (r *PriceChangeRepository) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page, pageSize int) ([]*entity.PriceChange, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, change := range r.store.priceChanges {
+		if change.ProductID != productID {
+			continue
+		}
+		if filters.VariantID != nil && (change.VariantID == nil || *change.VariantID != *filters.VariantID) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.priceChanges[id].EffectiveFrom }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	changes := make([]*entity.PriceChange, 0, end-start)
+	for _, id := range ids[start:end] {
+		change := r.store.priceChanges[id]
+		changes = append(changes, &change)
+	}
+	return changes, len(ids), nil
+}
+
+func (r *PriceChangeRepository) GetSchedules(ctx context.Context, productIDs []uuid.UUID, at time.Time) ([]*entity.PriceChange, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	products := make(map[uuid.UUID]bool, len(productIDs))
+	for _, id := range productIDs {
+		products[id] = true
+	}
+
+	var ids []uuid.UUID
+	for id, change := range r.store.priceChanges {
+		if !products[change.ProductID] || !change.Scheduled || change.CancelledAt != nil {
+			continue
+		}
+		if change.EffectiveTo != nil && !change.EffectiveTo.After(at) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.priceChanges[id].EffectiveFrom }, false)
+
+	changes := make([]*entity.PriceChange, 0, len(ids))
+	for _, id := range ids {
+		change := r.store.priceChanges[id]
+		changes = append(changes, &change)
+	}
+	return changes, nil
+}
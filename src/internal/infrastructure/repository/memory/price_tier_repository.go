@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type PriceTierRepository struct {
+	store *Store
+}
+
+func NewPriceTierRepository(store *Store) repository.PriceTierRepository {
+	return &PriceTierRepository{store: store}
+}
+
+func (r *PriceTierRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var tiers []*entity.PriceTier
+	for _, tier := range r.store.priceTiers {
+		if tier.ProductID == productID {
+			tier := tier
+			tiers = append(tiers, &tier)
+		}
+	}
+	sort.Slice(tiers, func(i, j int) bool {
+		a, b := tiers[i], tiers[j]
+		if (a.VariantID == nil) != (b.VariantID == nil) {
+			return a.VariantID == nil
+		}
+		if a.VariantID != nil && *a.VariantID != *b.VariantID {
+			return a.VariantID.String() < b.VariantID.String()
+		}
+		if a.CustomerGroup != b.CustomerGroup {
+			return a.CustomerGroup < b.CustomerGroup
+		}
+		return a.MinQuantity < b.MinQuantity
+	})
+	return tiers, nil
+}
+
+func (r *PriceTierRepository) ReplaceForProduct(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, tier := range r.store.priceTiers {
+		if tier.ProductID == productID {
+			delete(r.store.priceTiers, id)
+		}
+	}
+	for i := range tiers {
+		tier := &tiers[i]
+		tier.ProductID = productID
+		if err := beforeCreate(tier); err != nil {
+			return err
+		}
+		stamp(&tier.CreatedAt, nil)
+		r.store.priceTiers[tier.ID] = *tier
+		r.store.track(tier.ID)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type ProductOptionRepository struct {
+	store *Store
+}
+
+func NewProductOptionRepository(store *Store) repository.ProductOptionRepository {
+	return &ProductOptionRepository{store: store}
+}
+
+func (r *ProductOptionRepository) Create(ctx context.Context, option *entity.ProductOption) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertOption(option)
+}
+
+func (r *ProductOptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductOption, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.store.options[id]; !ok {
+		return nil, entity.NotFoundError("Product option not found")
+	}
+	return r.store.option(id), nil
+}
+
+func (r *ProductOptionRepository) ListByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	return r.store.productOptions(productID), nil
+}
+
+func (r *ProductOptionRepository) AddValue(ctx context.Context, value *entity.ProductOptionValue) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertOptionValue(value)
+}
+
+// Delete removes the option with its values
+func (r *ProductOptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.options[id]; !ok {
+		return entity.NotFoundError("Product option not found")
+	}
+
+	for valueID, value := range r.store.optionValues {
+		if value.OptionID == id {
+			delete(r.store.optionValues, valueID)
+		}
+	}
+	delete(r.store.options, id)
+	return nil
+}
+
+func (r *ProductOptionRepository) InUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, option := range r.store.variantOptions {
+		if option.OptionID != id {
+			continue
+		}
+		if variant, ok := r.store.variants[option.VariantID]; ok && !deleted(variant.DeletedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
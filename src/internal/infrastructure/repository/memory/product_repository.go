@@ -0,0 +1,162 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type ProductRepository struct {
+	store *Store
+}
+
+func NewProductRepository(store *Store) repository.ProductRepository {
+	return &ProductRepository{store: store}
+}
+
+func (r *ProductRepository) Create(ctx context.Context, product *entity.Product) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertProduct(product)
+}
+
+func (r *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	product, ok := r.store.products[id]
+	if !ok || deleted(product.DeletedAt) {
+		return nil, entity.NotFoundError("Product not found")
+	}
+	return r.store.product(id), nil
+}
+
+func (r *ProductRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		if product, ok := r.store.products[id]; ok && !deleted(product.DeletedAt) {
+			products = append(products, r.store.product(id))
+		}
+	}
+	return products, nil
+}
+
+func (r *ProductRepository) GetAll(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids, total := r.matching(page, pageSize, filters)
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		product := r.store.product(id)
+		if !filters.Includes(repository.ProductCategories) {
+			product.Categories = nil
+		}
+		if !filters.Includes(repository.ProductVariants) {
+			product.Variants = nil
+		}
+		if !filters.Includes(repository.ProductOptions) {
+			product.Options = nil
+		}
+		if !filters.Includes(repository.ProductAttributes) {
+			product.Attributes = nil
+		}
+		products = append(products, product)
+	}
+	return products, total, nil
+}
+
+func (r *ProductRepository) ListProductsSummary(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids, total := r.matching(page, pageSize, filters)
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids {
+		row := r.store.products[id]
+		products = append(products, &entity.Product{ID: row.ID, Name: row.Name, Price: row.Price, Quantity: row.Quantity, Status: row.Status,
+			RatingAverage: row.RatingAverage, RatingCount: row.RatingCount})
+	}
+	return products, total, nil
+}
+
+// matching returns the IDs of the page of products matching filters, and how
+// many match in all
+func (r *ProductRepository) matching(page, pageSize int, filters repository.ProductFilters) ([]uuid.UUID, int) {
+	var ids []uuid.UUID
+	for _, id := range r.store.liveProductIDs() {
+		if filters.InStockOnly && r.store.products[id].Quantity <= 0 {
+			continue
+		}
+		if filters.Status != "" && r.store.products[id].Status != filters.Status {
+			continue
+		}
+		if product := r.store.products[id]; filters.AvailableAt != nil && !product.IsAvailableAt(*filters.AvailableAt) {
+			continue
+		}
+		if !r.hasAttributes(id, filters.Attributes) {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if filters.Sort.Field != "" {
+		r.store.sortProducts(ids, filters.Sort)
+	}
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	return ids[start:end], len(ids)
+}
+
+func (r *ProductRepository) hasAttributes(productID uuid.UUID, attributes []entity.ProductAttribute) bool {
+	for _, attribute := range attributes {
+		if !r.store.hasAttributeValue(productID, attribute) {
+			return false
+		}
+	}
+	return true
+}
+
+// Update saves the product's own columns
+func (r *ProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.products[product.ID]
+	if !ok || deleted(existing.DeletedAt) {
+		return entity.NotFoundError("Product not found")
+	}
+
+	product.UpdatedAt = time.Now()
+	row := *product
+	row.Variants, row.Options, row.Categories, row.Attributes, row.PriceSchedule = nil, nil, nil, nil, nil
+	// The rating belongs to the reviews
+	row.RatingAverage, row.RatingCount = existing.RatingAverage, existing.RatingCount
+	r.store.products[product.ID] = row
+	return nil
+}
+
+func (r *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	product, ok := r.store.products[id]
+	if !ok || deleted(product.DeletedAt) {
+		return entity.NotFoundError("Product not found")
+	}
+	for _, item := range r.store.orderItems {
+		if item.ProductID == id {
+			return entity.ErrProductHasOrders
+		}
+	}
+
+	product.DeletedAt = softDelete()
+	r.store.products[id] = product
+	return nil
+}
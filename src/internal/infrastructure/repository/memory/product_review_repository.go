@@ -0,0 +1,132 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type ProductReviewRepository struct {
+	store *Store
+}
+
+func NewProductReviewRepository(store *Store) repository.ProductReviewRepository {
+	return &ProductReviewRepository{store: store}
+}
+
+func (r *ProductReviewRepository) Save(ctx context.Context, review *entity.ProductReview) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if product, ok := r.store.products[review.ProductID]; !ok || deleted(product.DeletedAt) {
+		return entity.NotFoundError("Product not found")
+	}
+
+	if existing, ok := r.find(review.ProductID, review.UserID); ok {
+		review.ID, review.CreatedAt = existing.ID, existing.CreatedAt
+		review.UpdatedAt = time.Now()
+	} else {
+		if err := beforeCreate(review); err != nil {
+			return err
+		}
+		stamp(&review.CreatedAt, &review.UpdatedAt)
+		r.store.track(review.ID)
+	}
+
+	r.store.reviews[review.ID] = *review
+	r.refreshRating(review.ProductID)
+	return nil
+}
+
+func (r *ProductReviewRepository) Get(ctx context.Context, productID, userID uuid.UUID) (*entity.ProductReview, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	review, ok := r.find(productID, userID)
+	if !ok {
+		return nil, entity.NotFoundError("Review not found")
+	}
+	return &review, nil
+}
+
+func (r *ProductReviewRepository) ListByProduct(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductReview, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, review := range r.store.reviews {
+		if review.ProductID == productID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.reviews[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	reviews := make([]*entity.ProductReview, 0, end-start)
+	for _, id := range ids[start:end] {
+		review := r.store.reviews[id]
+		reviews = append(reviews, &review)
+	}
+	return reviews, len(ids), nil
+}
+
+func (r *ProductReviewRepository) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if product, ok := r.store.products[productID]; !ok || deleted(product.DeletedAt) {
+		return entity.NotFoundError("Product not found")
+	}
+	review, ok := r.find(productID, userID)
+	if !ok {
+		return entity.NotFoundError("Review not found")
+	}
+
+	delete(r.store.reviews, review.ID)
+	r.refreshRating(productID)
+	return nil
+}
+
+func (r *ProductReviewRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, review := range r.store.reviews {
+		if review.UserID == userID {
+			delete(r.store.reviews, id)
+			r.refreshRating(review.ProductID)
+		}
+	}
+	return nil
+}
+
+func (r *ProductReviewRepository) find(productID, userID uuid.UUID) (entity.ProductReview, bool) {
+	for _, review := range r.store.reviews {
+		if review.ProductID == productID && review.UserID == userID {
+			return review, true
+		}
+	}
+	return entity.ProductReview{}, false
+}
+
+// refreshRating recomputes the rating of the product from its reviews, like
+// the PostgreSQL repository in the same transaction
+func (r *ProductReviewRepository) refreshRating(productID uuid.UUID) {
+	product, ok := r.store.products[productID]
+	if !ok {
+		return
+	}
+
+	sum, count := 0, 0
+	for _, review := range r.store.reviews {
+		if review.ProductID == productID {
+			sum += review.Rating
+			count++
+		}
+	}
+	product.RatingAverage, product.RatingCount = entity.AverageRating(sum, count), count
+	r.store.products[productID] = product
+}
@@ -0,0 +1,96 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+// translationKey identifies a translation like the primary key of its table
+type translationKey struct {
+	productID uuid.UUID
+	locale    string
+}
+
+type ProductTranslationRepository struct {
+	store *Store
+}
+
+func NewProductTranslationRepository(store *Store) repository.ProductTranslationRepository {
+	return &ProductTranslationRepository{store: store}
+}
+
+func (r *ProductTranslationRepository) Save(ctx context.Context, translation *entity.ProductTranslation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := translationKey{translation.ProductID, translation.Locale}
+	if existing, ok := r.store.translations[key]; ok {
+		// The conflicting row keeps its creation time
+		existing.Name, existing.Description = translation.Name, translation.Description
+		existing.UpdatedAt = time.Now()
+		r.store.translations[key] = existing
+		return nil
+	}
+
+	stamp(&translation.CreatedAt, &translation.UpdatedAt)
+	r.store.translations[key] = *translation
+	return nil
+}
+
+func (r *ProductTranslationRepository) Get(ctx context.Context, productID uuid.UUID, locale string) (*entity.ProductTranslation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	translation, ok := r.store.translations[translationKey{productID, locale}]
+	if !ok {
+		return nil, entity.NotFoundError("Product translation not found")
+	}
+	return &translation, nil
+}
+
+func (r *ProductTranslationRepository) ListByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var translations []*entity.ProductTranslation
+	for key, translation := range r.store.translations {
+		if key.productID == productID {
+			translation := translation
+			translations = append(translations, &translation)
+		}
+	}
+	sort.Slice(translations, func(i, j int) bool { return translations[i].Locale < translations[j].Locale })
+	return translations, nil
+}
+
+func (r *ProductTranslationRepository) ListForProducts(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]entity.ProductTranslation, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var translations []entity.ProductTranslation
+	for _, productID := range productIDs {
+		for _, locale := range locales {
+			if translation, ok := r.store.translations[translationKey{productID, locale}]; ok {
+				translations = append(translations, translation)
+			}
+		}
+	}
+	return translations, nil
+}
+
+func (r *ProductTranslationRepository) Delete(ctx context.Context, productID uuid.UUID, locale string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	key := translationKey{productID, locale}
+	if _, ok := r.store.translations[key]; !ok {
+		return entity.NotFoundError("Product translation not found")
+	}
+	delete(r.store.translations, key)
+	return nil
+}
@@ -0,0 +1,212 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type ProductVariantRepository struct {
+	store *Store
+}
+
+func NewProductVariantRepository(store *Store) repository.ProductVariantRepository {
+	return &ProductVariantRepository{store: store}
+}
+
+func (r *ProductVariantRepository) Create(ctx context.Context, productVariant *entity.ProductVariant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertVariant(productVariant)
+}
+
+func (r *ProductVariantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.live(id); !ok {
+		return nil, entity.NotFoundError("Product variant not found")
+	}
+	return r.store.variant(id, true), nil
+}
+
+func (r *ProductVariantRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	variants := make([]*entity.ProductVariant, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := r.live(id); ok {
+			variants = append(variants, r.store.variant(id, true))
+		}
+	}
+	return variants, nil
+}
+
+func (r *ProductVariantRepository) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
+	return r.list(page, pageSize, func(variant entity.ProductVariant) bool { return true })
+}
+
+func (r *ProductVariantRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error) {
+	return r.list(page, pageSize, func(variant entity.ProductVariant) bool { return variant.ProductID == productID })
+}
+
+func (r *ProductVariantRepository) list(page, pageSize int, match func(variant entity.ProductVariant) bool) ([]*entity.ProductVariant, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, variant := range r.store.variants {
+		if !deleted(variant.DeletedAt) && match(variant) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortInserted(ids)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	variants := make([]*entity.ProductVariant, 0, end-start)
+	for _, id := range ids[start:end] {
+		variants = append(variants, r.store.variant(id, true))
+	}
+	return variants, len(ids), nil
+}
+
+func (r *ProductVariantRepository) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	return r.find(func(variant entity.ProductVariant) bool { return variant.SKU == sku })
+}
+
+func (r *ProductVariantRepository) GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error) {
+	return r.find(func(variant entity.ProductVariant) bool {
+		return variant.ProductID == productID && variant.CombinationKey == combinationKey
+	})
+}
+
+func (r *ProductVariantRepository) find(match func(variant entity.ProductVariant) bool) (*entity.ProductVariant, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, variant := range r.store.variants {
+		if !deleted(variant.DeletedAt) && match(variant) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, entity.NotFoundError("Product variant not found")
+	}
+	r.store.sortInserted(ids)
+	return r.store.variant(ids[0], false), nil
+}
+
+// Update saves the variant and replaces its option values
+func (r *ProductVariantRepository) Update(ctx context.Context, productVariant *entity.ProductVariant) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.live(productVariant.ID); !ok {
+		return entity.NotFoundError("Product variant not found")
+	}
+	if err := r.store.checkSKU(productVariant); err != nil {
+		return err
+	}
+
+	productVariant.UpdatedAt = time.Now()
+	row := *productVariant
+	row.Product, row.Options = nil, nil
+	r.store.variants[productVariant.ID] = row
+
+	for id, option := range r.store.variantOptions {
+		if option.VariantID == productVariant.ID {
+			delete(r.store.variantOptions, id)
+		}
+	}
+	for i := range productVariant.Options {
+		productVariant.Options[i].ID = uuid.Nil
+	}
+	return r.store.insertVariantOptions(productVariant)
+}
+
+func (r *ProductVariantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	variant, ok := r.live(id)
+	if !ok {
+		return entity.NotFoundError("Product variant not found")
+	}
+
+	variant.DeletedAt = softDelete()
+	r.store.variants[id] = variant
+	return nil
+}
+
+func (r *ProductVariantRepository) TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	product, ok := r.store.products[transfer.ProductID]
+	if !ok || deleted(product.DeletedAt) {
+		return nil, nil, entity.NotFoundError("Product not found")
+	}
+
+	fromQuantity, err := r.stock(product, transfer.FromVariantID)
+	if err != nil {
+		return nil, nil, err
+	}
+	toQuantity, err := r.stock(product, transfer.ToVariantID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out, in, err := transfer.Apply(fromQuantity, toQuantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, movement := range []*entity.StockMovement{out, in} {
+		r.setStock(movement)
+		if err := r.store.insertStockMovement(movement); err != nil {
+			return nil, nil, err
+		}
+	}
+	return out, in, nil
+}
+
+// stock returns the quantity of the product's variant, or of the product when variantID is nil
+func (r *ProductVariantRepository) stock(product entity.Product, variantID *uuid.UUID) (int, error) {
+	if variantID == nil {
+		return product.Quantity, nil
+	}
+
+	variant, ok := r.live(*variantID)
+	if !ok || variant.ProductID != product.ID {
+		return 0, entity.NotFoundError("Product variant not found")
+	}
+	return variant.Quantity, nil
+}
+
+// setStock writes the quantity a ledger entry leaves behind
+func (r *ProductVariantRepository) setStock(movement *entity.StockMovement) {
+	if movement.VariantID == nil {
+		product := r.store.products[movement.ProductID]
+		product.Quantity = movement.QuantityAfter
+		product.UpdatedAt = movement.CreatedAt
+		r.store.products[movement.ProductID] = product
+		return
+	}
+
+	variant := r.store.variants[*movement.VariantID]
+	variant.Quantity = movement.QuantityAfter
+	variant.UpdatedAt = movement.CreatedAt
+	r.store.variants[*movement.VariantID] = variant
+}
+
+// live returns the variant unless it is missing or deleted
+func (r *ProductVariantRepository) live(id uuid.UUID) (entity.ProductVariant, bool) {
+	variant, ok := r.store.variants[id]
+	return variant, ok && !deleted(variant.DeletedAt)
+}
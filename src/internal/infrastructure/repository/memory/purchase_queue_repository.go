@@ -0,0 +1,165 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type PurchaseQueueRepository struct {
+	store *Store
+}
+
+func NewPurchaseQueueRepository(store *Store) repository.PurchaseQueueRepository {
+	return &PurchaseQueueRepository{store: store}
+}
+
+// Create enforces the partial unique index on the active entry of a user for
+// a product
+func (r *PurchaseQueueRepository) Create(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(entry); err != nil {
+		return err
+	}
+	if _, exists := r.store.queueEntries[entry.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	// A zero status takes the column default, as with GORM
+	if entry.Status == "" {
+		entry.Status = entity.QueueWaiting
+	}
+	if active(*entry) {
+		for _, existing := range r.store.queueEntries {
+			if existing.ProductID == entry.ProductID && existing.UserID == entry.UserID && active(existing) {
+				return entity.ConflictError("Already in queue for this product")
+			}
+		}
+	}
+	stamp(&entry.CreatedAt, &entry.UpdatedAt)
+
+	r.store.queueEntries[entry.ID] = *entry
+	r.store.track(entry.ID)
+	return nil
+}
+
+func (r *PurchaseQueueRepository) Update(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.queueEntries[entry.ID]; !exists {
+		r.store.track(entry.ID)
+	}
+	entry.UpdatedAt = time.Now()
+	r.store.queueEntries[entry.ID] = *entry
+	return nil
+}
+
+func (r *PurchaseQueueRepository) GetActiveEntry(ctx context.Context, productID, userID uuid.UUID) (*entity.PurchaseQueueEntry, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, entry := range r.store.queueEntries {
+		if entry.ProductID == productID && entry.UserID == userID && active(entry) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, entity.NotFoundError("Queue entry not found")
+	}
+
+	r.store.sortByTime(ids, r.joinedAt, true)
+	entry := r.store.queueEntries[ids[0]]
+	return &entry, nil
+}
+
+func (r *PurchaseQueueRepository) CountAhead(ctx context.Context, productID uuid.UUID, joinedAt time.Time) (int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	count := 0
+	for _, entry := range r.store.queueEntries {
+		if entry.ProductID == productID && entry.Status == entity.QueueWaiting && entry.CreatedAt.Before(joinedAt) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *PurchaseQueueRepository) Advance(ctx context.Context, productID uuid.UUID, slots int, window time.Duration, now time.Time) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if product, ok := r.store.products[productID]; !ok || deleted(product.DeletedAt) {
+		return gorm.ErrRecordNotFound
+	}
+
+	open := 0
+	var waiting []uuid.UUID
+	for id, entry := range r.store.queueEntries {
+		if entry.ProductID != productID {
+			continue
+		}
+		switch entry.Status {
+		case entity.QueueAdmitted:
+			if entry.WindowExpiresAt != nil && !entry.WindowExpiresAt.After(now) {
+				entry.Status = entity.QueueExpired
+				entry.UpdatedAt = now
+				r.store.queueEntries[id] = entry
+				continue
+			}
+			open++
+		case entity.QueueWaiting:
+			waiting = append(waiting, id)
+		}
+	}
+
+	free := slots - open
+	if free <= 0 {
+		return nil
+	}
+
+	r.store.sortByTime(waiting, r.joinedAt, false)
+	expiresAt := now.Add(window)
+	for _, id := range waiting[:limitRows(len(waiting), free)] {
+		entry := r.store.queueEntries[id]
+		entry.Status = entity.QueueAdmitted
+		entry.WindowExpiresAt = &expiresAt
+		entry.UpdatedAt = now
+		r.store.queueEntries[id] = entry
+	}
+	return nil
+}
+
+func (r *PurchaseQueueRepository) ListActiveProductIDs(ctx context.Context) ([]uuid.UUID, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, entry := range r.store.queueEntries {
+		if active(entry) && !seen[entry.ProductID] {
+			seen[entry.ProductID] = true
+			ids = append(ids, entry.ProductID)
+		}
+	}
+	r.store.sortInserted(ids)
+	return ids, nil
+}
+
+func (r *PurchaseQueueRepository) joinedAt(id uuid.UUID) time.Time {
+	return r.store.queueEntries[id].CreatedAt
+}
+
+// active tells whether the entry is waiting or admitted
+func active(entry entity.PurchaseQueueEntry) bool {
+	return entry.Status == entity.QueueWaiting || entry.Status == entity.QueueAdmitted
+}
@@ -0,0 +1,73 @@
+package memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPurchaseQueueRepository_Advance(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	product := newProduct(t, NewProductRepository(store), "Console", 3)
+	queue := NewPurchaseQueueRepository(store)
+	now := time.Now()
+
+	join := func(offset time.Duration) *entity.PurchaseQueueEntry {
+		entry := &entity.PurchaseQueueEntry{ProductID: product.ID, UserID: uuid.New(), CreatedAt: now.Add(offset)}
+		require.NoError(t, queue.Create(ctx, entry))
+		return entry
+	}
+	expired := join(-time.Hour)
+	expired.Status = entity.QueueAdmitted
+	windowEnd := now.Add(-time.Minute)
+	expired.WindowExpiresAt = &windowEnd
+	require.NoError(t, queue.Update(ctx, expired))
+	second := join(-2 * time.Minute)
+	first := join(-3 * time.Minute)
+	third := join(-time.Minute)
+
+	require.NoError(t, queue.Advance(ctx, product.ID, 2, 5*time.Minute, now))
+
+	status := func(entry *entity.PurchaseQueueEntry) entity.QueueEntryStatus {
+		return store.queueEntries[entry.ID].Status
+	}
+	assert.Equal(t, entity.QueueExpired, status(expired))
+	assert.Equal(t, entity.QueueAdmitted, status(first))
+	assert.Equal(t, entity.QueueAdmitted, status(second))
+	assert.Equal(t, entity.QueueWaiting, status(third))
+	assert.Equal(t, now.Add(5*time.Minute), *store.queueEntries[first.ID].WindowExpiresAt)
+
+	ahead, err := queue.CountAhead(ctx, product.ID, third.CreatedAt)
+	require.NoError(t, err)
+	assert.Zero(t, ahead)
+
+	active, err := queue.GetActiveEntry(ctx, product.ID, third.UserID)
+	require.NoError(t, err)
+	assert.Equal(t, third.ID, active.ID)
+	_, err = queue.GetActiveEntry(ctx, product.ID, expired.UserID)
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestPurchaseQueueRepository_CreateOneActiveEntry(t *testing.T) {
+	ctx := context.Background()
+	store := NewStore()
+	product := newProduct(t, NewProductRepository(store), "Console", 3)
+	queue := NewPurchaseQueueRepository(store)
+	user := uuid.New()
+
+	first := &entity.PurchaseQueueEntry{ProductID: product.ID, UserID: user}
+	require.NoError(t, queue.Create(ctx, first))
+
+	err := queue.Create(ctx, &entity.PurchaseQueueEntry{ProductID: product.ID, UserID: user})
+	assert.ErrorIs(t, err, entity.ErrConflict)
+
+	first.Status = entity.QueueExpired
+	require.NoError(t, queue.Update(ctx, first))
+	assert.NoError(t, queue.Create(ctx, &entity.PurchaseQueueEntry{ProductID: product.ID, UserID: user}))
+}
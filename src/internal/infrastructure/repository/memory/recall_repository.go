@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type RecallRepository struct {
+	store *Store
+}
+
+func NewRecallRepository(store *Store) repository.RecallRepository {
+	return &RecallRepository{store: store}
+}
+
+func (r *RecallRepository) FindAffectedOrders(ctx context.Context, criteria repository.RecallCriteria) ([]entity.AffectedOrder, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	inRange := func(at time.Time) bool {
+		return !at.Before(criteria.From) && at.Before(criteria.Until)
+	}
+
+	var ids []uuid.UUID
+	for id, order := range r.store.orders {
+		if order.Status != entity.Cancelled && inRange(order.CreatedAt) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortInserted(ids)
+
+	var affected []entity.AffectedOrder
+	for _, id := range ids {
+		order := r.store.order(id)
+		if quantity := recalledUnits(order.Products, criteria); quantity > 0 {
+			affected = append(affected, entity.AffectedOrder{
+				OrderID:    order.ID,
+				UserID:     order.UserID,
+				CustomerID: order.CustomerID,
+				Quantity:   quantity,
+				OrderedAt:  order.CreatedAt,
+			})
+		}
+	}
+
+	for _, a := range r.store.archivedOrders {
+		if a.Status == entity.Cancelled || !inRange(a.OrderCreatedAt) {
+			continue
+		}
+		order, err := a.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+		if quantity := recalledUnits(order.Products, criteria); quantity > 0 {
+			affected = append(affected, entity.AffectedOrder{
+				OrderID:    a.ID,
+				UserID:     a.UserID,
+				CustomerID: a.CustomerID,
+				Quantity:   quantity,
+				OrderedAt:  a.OrderCreatedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(affected, func(i, j int) bool {
+		return affected[i].OrderedAt.Before(affected[j].OrderedAt)
+	})
+	return affected, nil
+}
+
+// recalledUnits sums the quantity of the items the criteria select
+func recalledUnits(items []entity.OrderItem, criteria repository.RecallCriteria) int {
+	quantity := 0
+	for _, item := range items {
+		if item.ProductID != criteria.ProductID {
+			continue
+		}
+		if criteria.VariantID != nil && (item.VariantID == nil || *item.VariantID != *criteria.VariantID) {
+			continue
+		}
+		quantity += item.Quantity
+	}
+	return quantity
+}
+
+func (r *RecallRepository) Create(ctx context.Context, recall *entity.Recall) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(recall); err != nil {
+		return err
+	}
+	if _, exists := r.store.recalls[recall.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	// Check every notice first, so a conflict stores nothing
+	orders := make(map[uuid.UUID]bool)
+	for i := range recall.Notices {
+		notice := &recall.Notices[i]
+		notice.RecallID = recall.ID
+		if err := beforeCreate(notice); err != nil {
+			return err
+		}
+		if _, exists := r.store.recallNotices[notice.ID]; exists || orders[notice.OrderID] {
+			return gorm.ErrDuplicatedKey
+		}
+		orders[notice.OrderID] = true
+	}
+
+	stamp(&recall.CreatedAt, nil)
+	row := *recall
+	row.Notices = nil
+	r.store.recalls[recall.ID] = row
+	r.store.track(recall.ID)
+
+	for i := range recall.Notices {
+		notice := &recall.Notices[i]
+		stamp(&notice.CreatedAt, nil)
+		row := *notice
+		row.Recall = nil
+		r.store.recallNotices[notice.ID] = row
+		r.store.track(notice.ID)
+	}
+	return nil
+}
+
+func (r *RecallRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	if _, ok := r.store.recalls[id]; !ok {
+		return nil, entity.NotFoundError("Recall not found")
+	}
+
+	recall := r.recall(id)
+	sort.SliceStable(recall.Notices, func(i, j int) bool {
+		a, b := recall.Notices[i], recall.Notices[j]
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID.String() < b.ID.String()
+	})
+	return recall, nil
+}
+
+func (r *RecallRepository) List(ctx context.Context, page, pageSize int) ([]*entity.Recall, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.recalls))
+	for id := range r.store.recalls {
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.recalls[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	recalls := make([]*entity.Recall, 0, end-start)
+	for _, id := range ids[start:end] {
+		recalls = append(recalls, r.recall(id))
+	}
+	return recalls, len(ids), nil
+}
+
+func (r *RecallRepository) GetNotice(ctx context.Context, id uuid.UUID) (*entity.RecallNotice, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	notice, ok := r.store.recallNotices[id]
+	if !ok {
+		return nil, entity.NotFoundError("Recall notice not found")
+	}
+	if recall, ok := r.store.recalls[notice.RecallID]; ok {
+		notice.Recall = &recall
+	}
+	return &notice, nil
+}
+
+// UpdateNotice saves the delivery and acknowledgment of the notice only
+func (r *RecallRepository) UpdateNotice(ctx context.Context, notice *entity.RecallNotice) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	row, ok := r.store.recallNotices[notice.ID]
+	if !ok {
+		return nil
+	}
+	row.Status = notice.Status
+	row.Error = notice.Error
+	row.SentAt = notice.SentAt
+	row.AcknowledgedAt = notice.AcknowledgedAt
+	r.store.recallNotices[notice.ID] = row
+	return nil
+}
+
+func (r *RecallRepository) ListNoticesByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, notice := range r.store.recallNotices {
+		if notice.UserID != nil && *notice.UserID == userID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, r.noticeCreatedAt, true)
+
+	notices := make([]*entity.RecallNotice, 0, len(ids))
+	for _, id := range ids {
+		notice := r.store.recallNotices[id]
+		if recall, ok := r.store.recalls[notice.RecallID]; ok {
+			notice.Recall = &recall
+		}
+		notices = append(notices, &notice)
+	}
+	return notices, nil
+}
+
+// recall returns a copy of the recall with its notices in insertion order
+func (r *RecallRepository) recall(id uuid.UUID) *entity.Recall {
+	recall := r.store.recalls[id]
+
+	var ids []uuid.UUID
+	for noticeID, notice := range r.store.recallNotices {
+		if notice.RecallID == id {
+			ids = append(ids, noticeID)
+		}
+	}
+	r.store.sortInserted(ids)
+	for _, noticeID := range ids {
+		recall.Notices = append(recall.Notices, r.store.recallNotices[noticeID])
+	}
+	return &recall
+}
+
+func (r *RecallRepository) noticeCreatedAt(id uuid.UUID) time.Time {
+	return r.store.recallNotices[id].CreatedAt
+}
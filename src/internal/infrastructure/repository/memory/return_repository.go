@@ -0,0 +1,127 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type ReturnRepository struct {
+	store *Store
+}
+
+func NewReturnRepository(store *Store) repository.ReturnRepository {
+	return &ReturnRepository{store: store}
+}
+
+func (r *ReturnRepository) Create(ctx context.Context, ret *entity.Return) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(ret); err != nil {
+		return err
+	}
+	if _, exists := r.store.returns[ret.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	if ret.Status == "" {
+		ret.Status = entity.ReturnRequested
+	}
+	stamp(&ret.CreatedAt, &ret.UpdatedAt)
+	for i := range ret.Items {
+		ret.Items[i].ReturnID = ret.ID
+		if err := beforeCreate(&ret.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	row := *ret
+	row.Items = append([]entity.ReturnItem(nil), ret.Items...)
+	r.store.returns[ret.ID] = row
+	r.store.track(ret.ID)
+	return nil
+}
+
+func (r *ReturnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ret, ok := r.store.returns[id]
+	if !ok {
+		return nil, entity.NotFoundError("Return not found")
+	}
+	return copyReturn(ret), nil
+}
+
+func (r *ReturnRepository) Update(ctx context.Context, ret *entity.Return) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	existing, ok := r.store.returns[ret.ID]
+	if !ok {
+		return entity.NotFoundError("Return not found")
+	}
+	ret.UpdatedAt = time.Now()
+	row := *ret
+	row.Items = existing.Items
+	r.store.returns[ret.ID] = row
+	return nil
+}
+
+func (r *ReturnRepository) List(ctx context.Context, filters repository.ReturnFilters, page, pageSize int) ([]*entity.Return, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, ret := range r.store.returns {
+		if filters.Status != nil && ret.Status != *filters.Status {
+			continue
+		}
+		if filters.OrderID != nil && ret.OrderID != *filters.OrderID {
+			continue
+		}
+		if filters.UserID != nil {
+			order, ok := r.store.orders[ret.OrderID]
+			if !ok || !order.IsOwnedBy(*filters.UserID) {
+				continue
+			}
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.returns[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	returns := make([]*entity.Return, 0, end-start)
+	for _, id := range ids[start:end] {
+		returns = append(returns, copyReturn(r.store.returns[id]))
+	}
+	return returns, len(ids), nil
+}
+
+func (r *ReturnRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Return, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, ret := range r.store.returns {
+		if ret.OrderID == orderID {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.returns[id].CreatedAt }, false)
+
+	returns := make([]*entity.Return, 0, len(ids))
+	for _, id := range ids {
+		returns = append(returns, copyReturn(r.store.returns[id]))
+	}
+	return returns, nil
+}
+
+func copyReturn(row entity.Return) *entity.Return {
+	row.Items = append([]entity.ReturnItem(nil), row.Items...)
+	return &row
+}
@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type SearchRepository struct {
+	store *Store
+}
+
+func NewSearchRepository(store *Store) repository.SearchRepository {
+	return &SearchRepository{store: store}
+}
+
+func (r *SearchRepository) FindCandidates(ctx context.Context, terms []string, at time.Time, limit int) ([]*entity.Product, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for _, id := range r.store.liveProductIDs() {
+		product := r.store.products[id]
+		if !product.IsOnSaleAt(at) {
+			continue
+		}
+		name, description := strings.ToLower(product.Name), strings.ToLower(product.Description)
+
+		matches := true
+		for _, term := range terms {
+			term = strings.ToLower(term)
+			if !strings.Contains(name, term) && !strings.Contains(description, term) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.products[id].CreatedAt }, true)
+
+	products := make([]*entity.Product, 0, len(ids))
+	for _, id := range ids[:limitRows(len(ids), limit)] {
+		products = append(products, r.store.product(id))
+	}
+	return products, nil
+}
+
+func (r *SearchRepository) CreateRule(ctx context.Context, rule *entity.RankingRule) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(rule); err != nil {
+		return err
+	}
+	if _, exists := r.store.rankingRules[rule.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&rule.CreatedAt, &rule.UpdatedAt)
+
+	r.store.rankingRules[rule.ID] = *rule
+	r.store.track(rule.ID)
+	return nil
+}
+
+func (r *SearchRepository) GetRule(ctx context.Context, id uuid.UUID) (*entity.RankingRule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	rule, ok := r.store.rankingRules[id]
+	if !ok {
+		return nil, entity.NotFoundError("Ranking rule not found")
+	}
+	return &rule, nil
+}
+
+func (r *SearchRepository) UpdateRule(ctx context.Context, rule *entity.RankingRule) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.rankingRules[rule.ID]; !exists {
+		r.store.track(rule.ID)
+	}
+	rule.UpdatedAt = time.Now()
+	r.store.rankingRules[rule.ID] = *rule
+	return nil
+}
+
+func (r *SearchRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.rankingRules[id]; !ok {
+		return entity.NotFoundError("Ranking rule not found")
+	}
+	delete(r.store.rankingRules, id)
+	return nil
+}
+
+func (r *SearchRepository) ListRules(ctx context.Context) ([]*entity.RankingRule, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	ids := make([]uuid.UUID, 0, len(r.store.rankingRules))
+	for id := range r.store.rankingRules {
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.rankingRules[id].CreatedAt }, false)
+
+	rules := make([]*entity.RankingRule, 0, len(ids))
+	for _, id := range ids {
+		rule := r.store.rankingRules[id]
+		rules = append(rules, &rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type StockMovementRepository struct {
+	store *Store
+}
+
+func NewStockMovementRepository(store *Store) repository.StockMovementRepository {
+	return &StockMovementRepository{store: store}
+}
+
+func (r *StockMovementRepository) Create(ctx context.Context, movement *entity.StockMovement) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	return r.store.insertStockMovement(movement)
+}
+
+// insertStockMovement expects the caller to hold the store lock
+func (s *Store) insertStockMovement(movement *entity.StockMovement) error {
+	if err := beforeCreate(movement); err != nil {
+		return err
+	}
+	if _, exists := s.stockMovements[movement.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&movement.CreatedAt, nil)
+
+	s.stockMovements[movement.ID] = *movement
+	s.track(movement.ID)
+	return nil
+}
+
+func (r *StockMovementRepository) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, movement := range r.store.stockMovements {
+		if movement.ProductID != productID {
+			continue
+		}
+		if filters.VariantID != nil && (movement.VariantID == nil || *movement.VariantID != *filters.VariantID) {
+			continue
+		}
+		if filters.Reason != nil && movement.Reason != *filters.Reason {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.stockMovements[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	movements := make([]*entity.StockMovement, 0, end-start)
+	for _, id := range ids[start:end] {
+		movement := r.store.stockMovements[id]
+		movements = append(movements, &movement)
+	}
+	return movements, len(ids), nil
+}
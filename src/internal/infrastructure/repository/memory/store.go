@@ -0,0 +1,237 @@
+// Package memory implements the repository interfaces with maps, for tests and
+// local demos that should not need a database.
+//
+// Every repository is a view over a Store. Repositories created from the same
+// store see each other's writes, as the tables of one database do, so a
+// product read through NewProductRepository has the categories assigned
+// through NewCategoryRepository. The repositories follow the PostgreSQL ones:
+// they enforce the same unique indexes (failing with gorm.ErrDuplicatedKey),
+// soft-delete what the entities soft-delete, sort listings the same way and
+// return the same not found errors. Rows are copied in and out, so changing a
+// returned entity doesn't change the store until it is saved.
+package memory
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// Store holds the rows of every in-memory repository. It is safe for
+// concurrent use; each repository method runs atomically.
+type Store struct {
+	mu sync.RWMutex
+	// invoiceMu serializes invoice numbering while the document renders,
+	// like the row lock on the yearly sequence
+	invoiceMu sync.Mutex
+
+	users             map[uuid.UUID]entity.User
+	customers         map[uuid.UUID]entity.Customer // By user ID, with their addresses
+	categories        map[uuid.UUID]entity.Category
+	products          map[uuid.UUID]entity.Product
+	productCategories map[productCategory]bool
+	options           map[uuid.UUID]entity.ProductOption
+	optionValues      map[uuid.UUID]entity.ProductOptionValue
+	variants          map[uuid.UUID]entity.ProductVariant
+	variantOptions    map[uuid.UUID]entity.VariantOption
+	definitions       map[uuid.UUID]entity.AttributeDefinition
+	productAttributes map[uuid.UUID]entity.ProductAttribute
+	translations      map[translationKey]entity.ProductTranslation
+	stockMovements    map[uuid.UUID]entity.StockMovement
+	priceChanges      map[uuid.UUID]entity.PriceChange
+	priceTiers        map[uuid.UUID]entity.PriceTier
+	queueEntries      map[uuid.UUID]entity.PurchaseQueueEntry
+	reviews           map[uuid.UUID]entity.ProductReview
+
+	orders         map[uuid.UUID]entity.Order
+	orderItems     map[uuid.UUID]entity.OrderItem
+	components     map[uuid.UUID]entity.OrderItemComponent
+	archivedOrders map[uuid.UUID]entity.ArchivedOrder
+	invoices       map[uuid.UUID]entity.Invoice
+	invoiceNumbers map[int]int
+	remediations   map[uuid.UUID]entity.OrderRemediation
+	returns        map[uuid.UUID]entity.Return // With their items
+	webhookLogs    map[uuid.UUID]entity.WebhookLog
+	archivedHooks  map[uuid.UUID]entity.ArchivedWebhookLog
+	deadLetters    map[uuid.UUID]entity.DeadLetterWebhook
+	webhookNonces  map[string]time.Time // Expiry by nonce
+	subscriptions  map[uuid.UUID]entity.WebhookSubscription
+	deliveries     map[uuid.UUID]entity.WebhookDelivery
+	loyalty        map[uuid.UUID]entity.LoyaltyTransaction
+
+	auditLogs      map[uuid.UUID]entity.AuditLog
+	archivedAudits map[uuid.UUID]entity.ArchivedAuditLog
+	alerts         map[uuid.UUID]entity.AdminAlert
+	notes          map[uuid.UUID]entity.CustomerNote
+	riskEvents     map[uuid.UUID]entity.CustomerRiskEvent
+	emailTemplates map[uuid.UUID]entity.EmailTemplate
+	catalogReports map[uuid.UUID]entity.CatalogReport
+	catalogFeeds   map[entity.FeedFormat]entity.CatalogFeed
+	recalls        map[uuid.UUID]entity.Recall
+	recallNotices  map[uuid.UUID]entity.RecallNotice
+	rankingRules   map[uuid.UUID]entity.RankingRule
+	revocations    map[uuid.UUID]entity.TokenRevocation
+	blocklist      map[uuid.UUID]entity.BlocklistEntry
+	draftOrders    map[uuid.UUID]entity.DraftOrder // With their items
+	storeSettings  map[string]entity.StoreSetting
+	accessCodes    map[uuid.UUID]entity.AccessCode
+
+	// sequence numbers rows in insertion order, the order listings without an
+	// explicit sort return them in
+	sequence int64
+	inserted map[uuid.UUID]int64
+}
+
+type productCategory struct {
+	ProductID  uuid.UUID
+	CategoryID uuid.UUID
+}
+
+// NewStore returns an empty store
+func NewStore() *Store {
+	return &Store{
+		users:             make(map[uuid.UUID]entity.User),
+		customers:         make(map[uuid.UUID]entity.Customer),
+		categories:        make(map[uuid.UUID]entity.Category),
+		products:          make(map[uuid.UUID]entity.Product),
+		productCategories: make(map[productCategory]bool),
+		options:           make(map[uuid.UUID]entity.ProductOption),
+		optionValues:      make(map[uuid.UUID]entity.ProductOptionValue),
+		variants:          make(map[uuid.UUID]entity.ProductVariant),
+		variantOptions:    make(map[uuid.UUID]entity.VariantOption),
+		definitions:       make(map[uuid.UUID]entity.AttributeDefinition),
+		productAttributes: make(map[uuid.UUID]entity.ProductAttribute),
+		translations:      make(map[translationKey]entity.ProductTranslation),
+		stockMovements:    make(map[uuid.UUID]entity.StockMovement),
+		priceChanges:      make(map[uuid.UUID]entity.PriceChange),
+		priceTiers:        make(map[uuid.UUID]entity.PriceTier),
+		queueEntries:      make(map[uuid.UUID]entity.PurchaseQueueEntry),
+		reviews:           make(map[uuid.UUID]entity.ProductReview),
+		orders:            make(map[uuid.UUID]entity.Order),
+		orderItems:        make(map[uuid.UUID]entity.OrderItem),
+		components:        make(map[uuid.UUID]entity.OrderItemComponent),
+		archivedOrders:    make(map[uuid.UUID]entity.ArchivedOrder),
+		invoices:          make(map[uuid.UUID]entity.Invoice),
+		invoiceNumbers:    make(map[int]int),
+		remediations:      make(map[uuid.UUID]entity.OrderRemediation),
+		returns:           make(map[uuid.UUID]entity.Return),
+		webhookLogs:       make(map[uuid.UUID]entity.WebhookLog),
+		archivedHooks:     make(map[uuid.UUID]entity.ArchivedWebhookLog),
+		deadLetters:       make(map[uuid.UUID]entity.DeadLetterWebhook),
+		webhookNonces:     make(map[string]time.Time),
+		subscriptions:     make(map[uuid.UUID]entity.WebhookSubscription),
+		deliveries:        make(map[uuid.UUID]entity.WebhookDelivery),
+		loyalty:           make(map[uuid.UUID]entity.LoyaltyTransaction),
+		auditLogs:         make(map[uuid.UUID]entity.AuditLog),
+		archivedAudits:    make(map[uuid.UUID]entity.ArchivedAuditLog),
+		alerts:            make(map[uuid.UUID]entity.AdminAlert),
+		notes:             make(map[uuid.UUID]entity.CustomerNote),
+		riskEvents:        make(map[uuid.UUID]entity.CustomerRiskEvent),
+		emailTemplates:    make(map[uuid.UUID]entity.EmailTemplate),
+		catalogReports:    make(map[uuid.UUID]entity.CatalogReport),
+		catalogFeeds:      make(map[entity.FeedFormat]entity.CatalogFeed),
+		recalls:           make(map[uuid.UUID]entity.Recall),
+		recallNotices:     make(map[uuid.UUID]entity.RecallNotice),
+		rankingRules:      make(map[uuid.UUID]entity.RankingRule),
+		revocations:       make(map[uuid.UUID]entity.TokenRevocation),
+		blocklist:         make(map[uuid.UUID]entity.BlocklistEntry),
+		draftOrders:       make(map[uuid.UUID]entity.DraftOrder),
+		storeSettings:     make(map[string]entity.StoreSetting),
+		accessCodes:       make(map[uuid.UUID]entity.AccessCode),
+		inserted:          make(map[uuid.UUID]int64),
+	}
+}
+
+// track records that the row id was just inserted
+func (s *Store) track(id uuid.UUID) {
+	s.sequence++
+	s.inserted[id] = s.sequence
+}
+
+// sortInserted sorts ids oldest insertion first
+func (s *Store) sortInserted(ids []uuid.UUID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return s.inserted[ids[i]] < s.inserted[ids[j]]
+	})
+}
+
+// creator is a model with a GORM create hook. The hooks of the entities only
+// fill in IDs and defaults, they don't use the transaction.
+type creator interface {
+	BeforeCreate(tx *gorm.DB) error
+}
+
+func beforeCreate(model creator) error {
+	return model.BeforeCreate(nil)
+}
+
+// newID gives a model without a create hook an ID, as the hooks do
+func newID(id *uuid.UUID) {
+	if *id == uuid.Nil {
+		*id = entity.NewID()
+	}
+}
+
+// stamp fills in the timestamps GORM sets on create
+func stamp(createdAt, updatedAt *time.Time) {
+	now := time.Now().UTC()
+	if createdAt != nil && createdAt.IsZero() {
+		*createdAt = now
+	}
+	if updatedAt != nil && updatedAt.IsZero() {
+		*updatedAt = now
+	}
+}
+
+// deleted tells whether a soft-deletable row was deleted
+func deleted(deletedAt gorm.DeletedAt) bool {
+	return deletedAt.Valid
+}
+
+func softDelete() gorm.DeletedAt {
+	return gorm.DeletedAt{Time: time.Now(), Valid: true}
+}
+
+// pageBounds returns the bounds of a page within total rows, the way GORM
+// applies Offset and Limit: no offset when negative, no limit when pageSize is
+// negative
+func pageBounds(total, page, pageSize int) (int, int) {
+	start := (page - 1) * pageSize
+	if start < 0 {
+		start = 0
+	}
+	if start > total {
+		start = total
+	}
+
+	end := total
+	if pageSize >= 0 && start+pageSize < total {
+		end = start + pageSize
+	}
+	return start, end
+}
+
+// limitRows keeps at most n of total rows, no limit when n is negative
+func limitRows(total, n int) int {
+	if n >= 0 && n < total {
+		return n
+	}
+	return total
+}
+
+// sortByTime sorts ids by the time at returns for each, oldest first, or
+// newest first when descending. Ties keep insertion order, reversed when
+// descending.
+func (s *Store) sortByTime(ids []uuid.UUID, at func(id uuid.UUID) time.Time, descending bool) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := at(ids[i]), at(ids[j])
+		if !a.Equal(b) {
+			return a.Before(b) != descending
+		}
+		return (s.inserted[ids[i]] < s.inserted[ids[j]]) != descending
+	})
+}
@@ -0,0 +1,52 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type StoreSettingRepository struct {
+	store *Store
+}
+
+func NewStoreSettingRepository(store *Store) repository.StoreSettingRepository {
+	return &StoreSettingRepository{store: store}
+}
+
+func (r *StoreSettingRepository) List(ctx context.Context) ([]*entity.StoreSetting, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	settings := make([]*entity.StoreSetting, 0, len(r.store.storeSettings))
+	for _, setting := range r.store.storeSettings {
+		settings = append(settings, &setting)
+	}
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Key < settings[j].Key
+	})
+	return settings, nil
+}
+
+func (r *StoreSettingRepository) Save(ctx context.Context, settings []*entity.StoreSetting) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, setting := range settings {
+		stamp(nil, &setting.UpdatedAt)
+		r.store.storeSettings[setting.Key] = *setting
+	}
+	return nil
+}
+
+func (r *StoreSettingRepository) Delete(ctx context.Context, keys []string) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for _, key := range keys {
+		delete(r.store.storeSettings, key)
+	}
+	return nil
+}
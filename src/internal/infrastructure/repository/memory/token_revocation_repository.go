@@ -0,0 +1,66 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type TokenRevocationRepository struct {
+	store *Store
+}
+
+func NewTokenRevocationRepository(store *Store) repository.TokenRevocationRepository {
+	return &TokenRevocationRepository{store: store}
+}
+
+func (r *TokenRevocationRepository) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	// The unique token ID turns a second revocation into a no-op
+	if revocation.TokenID != nil {
+		for _, existing := range r.store.revocations {
+			if existing.TokenID != nil && *existing.TokenID == *revocation.TokenID {
+				return nil
+			}
+		}
+	}
+
+	if err := beforeCreate(revocation); err != nil {
+		return err
+	}
+	r.store.revocations[revocation.ID] = *revocation
+	r.store.track(revocation.ID)
+	return nil
+}
+
+func (r *TokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	now := time.Now()
+	for _, revocation := range r.store.revocations {
+		if revocation.ExpiresAt.After(now) && revocation.Covers(tokenID, userID, issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *TokenRevocationRepository) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var removed int64
+	for id, revocation := range r.store.revocations {
+		if revocation.ExpiresAt.Before(cutoff) {
+			delete(r.store.revocations, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
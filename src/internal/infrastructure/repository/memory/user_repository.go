@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type UserRepository struct {
+	store *Store
+}
+
+func NewUserRepository(store *Store) repository.UserRepository {
+	return &UserRepository{store: store}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	newID(&user.ID)
+	if _, exists := r.store.users[user.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	for _, existing := range r.store.users {
+		if existing.Email == user.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	// Zero values take the column defaults, as with GORM
+	if user.Role == "" {
+		user.Role = entity.RoleCustomer
+	}
+	if !user.Active {
+		user.Active = true
+	}
+	stamp(&user.CreatedAt, &user.UpdatedAt)
+
+	r.store.users[user.ID] = *user
+	r.store.track(user.ID)
+	return nil
+}
+
+func (r *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	user, ok := r.store.users[id]
+	if !ok {
+		return nil, entity.NotFoundError("User not found")
+	}
+	return &user, nil
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	for _, user := range r.store.users {
+		if user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, entity.NotFoundError("User not found")
+}
+
+func (r *UserRepository) ListByRole(ctx context.Context, role entity.Role) ([]*entity.User, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, user := range r.store.users {
+		if user.Role == role && user.Active {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortInserted(ids)
+
+	users := make([]*entity.User, 0, len(ids))
+	for _, id := range ids {
+		user := r.store.users[id]
+		users = append(users, &user)
+	}
+	return users, nil
+}
+
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	for id, existing := range r.store.users {
+		if id != user.ID && existing.Email == user.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.users[user.ID]; !exists {
+		r.store.track(user.ID)
+	}
+	user.UpdatedAt = time.Now()
+	r.store.users[user.ID] = *user
+	return nil
+}
+
+func (r *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	delete(r.store.users, id)
+	// The loyalty ledger goes with the user, as its foreign key cascades
+	for transactionID, transaction := range r.store.loyalty {
+		if transaction.UserID == id {
+			delete(r.store.loyalty, transactionID)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type WebhookNonceRepository struct {
+	store *Store
+}
+
+func NewWebhookNonceRepository(store *Store) repository.WebhookNonceRepository {
+	return &WebhookNonceRepository{store: store}
+}
+
+func (r *WebhookNonceRepository) Claim(ctx context.Context, nonce *entity.WebhookNonce, now time.Time) (bool, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if expiresAt, ok := r.store.webhookNonces[nonce.Nonce]; ok && !expiresAt.Before(now) {
+		return false, nil
+	}
+	r.store.webhookNonces[nonce.Nonce] = nonce.ExpiresAt
+	return true, nil
+}
+
+func (r *WebhookNonceRepository) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	var removed int64
+	for nonce, expiresAt := range r.store.webhookNonces {
+		if expiresAt.Before(cutoff) {
+			delete(r.store.webhookNonces, nonce)
+			removed++
+		}
+	}
+	return removed, nil
+}
@@ -0,0 +1,136 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository struct {
+	store *Store
+}
+
+func NewWebhookRepository(store *Store) repository.WebhookRepository {
+	return &WebhookRepository{store: store}
+}
+
+func (r *WebhookRepository) Create(ctx context.Context, log *entity.WebhookLog) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	newID(&log.ID)
+	if err := r.checkTransaction(log); err != nil {
+		return err
+	}
+	if _, exists := r.store.webhookLogs[log.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+
+	// A zero status takes the column default, as with GORM
+	if log.Status == "" {
+		log.Status = entity.WebhookStatusPending
+	}
+	stamp(&log.CreatedAt, nil)
+
+	r.store.webhookLogs[log.ID] = *log
+	r.store.track(log.ID)
+	return nil
+}
+
+// checkTransaction enforces the unique index on the transaction ID
+func (r *WebhookRepository) checkTransaction(log *entity.WebhookLog) error {
+	for id, existing := range r.store.webhookLogs {
+		if id != log.ID && existing.TransactionID == log.TransactionID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	return nil
+}
+
+func (r *WebhookRepository) Update(ctx context.Context, log *entity.WebhookLog) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := r.checkTransaction(log); err != nil {
+		return err
+	}
+
+	// Save inserts rows it doesn't find
+	if _, exists := r.store.webhookLogs[log.ID]; !exists {
+		r.store.track(log.ID)
+	}
+	r.store.webhookLogs[log.ID] = *log
+	return nil
+}
+
+func (r *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookLog, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	log, ok := r.store.webhookLogs[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &log, nil
+}
+
+func (r *WebhookRepository) GetByOrderID(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return r.list(func(log entity.WebhookLog) bool { return log.OrderID.String() == orderID }, filters, page, pageSize)
+}
+
+func (r *WebhookRepository) List(ctx context.Context, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return r.list(func(entity.WebhookLog) bool { return true }, filters, page, pageSize)
+}
+
+func (r *WebhookRepository) list(keep func(log entity.WebhookLog) bool, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, log := range r.store.webhookLogs {
+		if !keep(log) {
+			continue
+		}
+		if filters.TransactionID != nil && log.TransactionID != *filters.TransactionID {
+			continue
+		}
+		if filters.PaymentStatus != nil && log.PaymentStatus != *filters.PaymentStatus {
+			continue
+		}
+		if filters.Status != nil && log.Status != *filters.Status {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.webhookLogs[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	logs := make([]entity.WebhookLog, 0, end-start)
+	for _, id := range ids[start:end] {
+		logs = append(logs, r.store.webhookLogs[id])
+	}
+	return logs, len(ids), nil
+}
+
+func (r *WebhookRepository) ListDueRetries(ctx context.Context, now time.Time, limit int) ([]entity.WebhookLog, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, log := range r.store.webhookLogs {
+		if log.Status == entity.WebhookStatusFailed && log.NextRetryAt != nil && !log.NextRetryAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return *r.store.webhookLogs[id].NextRetryAt }, false)
+
+	logs := make([]entity.WebhookLog, 0, len(ids))
+	for _, id := range ids[:limitRows(len(ids), limit)] {
+		logs = append(logs, r.store.webhookLogs[id])
+	}
+	return logs, nil
+}
@@ -0,0 +1,202 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepository struct {
+	store *Store
+}
+
+func NewWebhookSubscriptionRepository(store *Store) repository.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{store: store}
+}
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(subscription); err != nil {
+		return err
+	}
+	if _, exists := r.store.subscriptions[subscription.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	stamp(&subscription.CreatedAt, &subscription.UpdatedAt)
+
+	r.store.subscriptions[subscription.ID] = copySubscription(*subscription)
+	r.store.track(subscription.ID)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	subscription, ok := r.store.subscriptions[id]
+	if !ok {
+		return nil, entity.NotFoundError("Webhook subscription not found")
+	}
+	row := copySubscription(subscription)
+	return &row, nil
+}
+
+func (r *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[subscription.ID]; !ok {
+		return entity.NotFoundError("Webhook subscription not found")
+	}
+	subscription.UpdatedAt = time.Now()
+	r.store.subscriptions[subscription.ID] = copySubscription(*subscription)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.subscriptions[id]; !ok {
+		return entity.NotFoundError("Webhook subscription not found")
+	}
+	for deliveryID, delivery := range r.store.deliveries {
+		if delivery.SubscriptionID == id {
+			delete(r.store.deliveries, deliveryID)
+		}
+	}
+	delete(r.store.subscriptions, id)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return r.list(false), nil
+}
+
+func (r *WebhookSubscriptionRepository) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return r.list(true), nil
+}
+
+func (r *WebhookSubscriptionRepository) list(activeOnly bool) []*entity.WebhookSubscription {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, subscription := range r.store.subscriptions {
+		if activeOnly && !subscription.Active {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.subscriptions[id].CreatedAt }, false)
+
+	subscriptions := make([]*entity.WebhookSubscription, 0, len(ids))
+	for _, id := range ids {
+		row := copySubscription(r.store.subscriptions[id])
+		subscriptions = append(subscriptions, &row)
+	}
+	return subscriptions
+}
+
+func (r *WebhookSubscriptionRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if err := beforeCreate(delivery); err != nil {
+		return err
+	}
+	if _, exists := r.store.deliveries[delivery.ID]; exists {
+		return gorm.ErrDuplicatedKey
+	}
+	// A zero status takes the column default, as with GORM
+	if delivery.Status == "" {
+		delivery.Status = entity.DeliveryPending
+	}
+	stamp(&delivery.CreatedAt, &delivery.UpdatedAt)
+
+	r.store.deliveries[delivery.ID] = *delivery
+	r.store.track(delivery.ID)
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	delivery, ok := r.store.deliveries[id]
+	if !ok {
+		return nil, entity.NotFoundError("Webhook delivery not found")
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookSubscriptionRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+
+	if _, ok := r.store.deliveries[delivery.ID]; !ok {
+		return entity.NotFoundError("Webhook delivery not found")
+	}
+	delivery.UpdatedAt = time.Now()
+	r.store.deliveries[delivery.ID] = *delivery
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page, pageSize int) ([]*entity.WebhookDelivery, int, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, delivery := range r.store.deliveries {
+		if delivery.SubscriptionID != subscriptionID {
+			continue
+		}
+		if filters.Status != nil && delivery.Status != *filters.Status {
+			continue
+		}
+		if filters.EventType != nil && delivery.EventType != *filters.EventType {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return r.store.deliveries[id].CreatedAt }, true)
+
+	start, end := pageBounds(len(ids), page, pageSize)
+	deliveries := make([]*entity.WebhookDelivery, 0, end-start)
+	for _, id := range ids[start:end] {
+		delivery := r.store.deliveries[id]
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, len(ids), nil
+}
+
+func (r *WebhookSubscriptionRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	r.store.mu.RLock()
+	defer r.store.mu.RUnlock()
+
+	var ids []uuid.UUID
+	for id, delivery := range r.store.deliveries {
+		if delivery.Status == entity.DeliveryPending && delivery.NextAttemptAt != nil && !delivery.NextAttemptAt.After(now) {
+			ids = append(ids, id)
+		}
+	}
+	r.store.sortByTime(ids, func(id uuid.UUID) time.Time { return *r.store.deliveries[id].NextAttemptAt }, false)
+
+	deliveries := make([]*entity.WebhookDelivery, 0, len(ids))
+	for _, id := range ids[:limitRows(len(ids), limit)] {
+		delivery := r.store.deliveries[id]
+		deliveries = append(deliveries, &delivery)
+	}
+	return deliveries, nil
+}
+
+func copySubscription(row entity.WebhookSubscription) entity.WebhookSubscription {
+	row.Events = append([]string(nil), row.Events...)
+	return row
+}
@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type OrderArchiveRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewOrderArchiveRepository(db *gorm.DB) repository.OrderArchiveRepository {
+	return &OrderArchiveRepositoryPostgres{db: db}
+}
+
+func (r *OrderArchiveRepositoryPostgres) ArchiveBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	moved := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var orders []*entity.Order
+		err := tx.Preload("Products.Components").
+			Where("created_at < ? AND status IN ?", cutoff, []entity.OrderStatus{entity.Completed, entity.Cancelled, entity.Refunded}).
+			Order("created_at ASC").
+			Limit(batchSize).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&orders).Error
+		if err != nil || len(orders) == 0 {
+			return err
+		}
+
+		now := time.Now()
+		archived := make([]*entity.ArchivedOrder, 0, len(orders))
+		ids := make([]uuid.UUID, 0, len(orders))
+		for _, order := range orders {
+			a, err := entity.NewArchivedOrder(order, now)
+			if err != nil {
+				return err
+			}
+			archived = append(archived, a)
+			ids = append(ids, order.ID)
+		}
+
+		if err := tx.Create(&archived).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("order_id IN ?", ids).Delete(&entity.OrderItem{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("id IN ?", ids).Delete(&entity.Order{}).Error; err != nil {
+			return err
+		}
+
+		moved = len(orders)
+		return nil
+	})
+
+	return moved, err
+}
+
+func (r *OrderArchiveRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	var archived entity.ArchivedOrder
+	err := r.db.WithContext(ctx).First(&archived, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Order not found")
+		}
+		return nil, err
+	}
+
+	return archived.ToOrder()
+}
+
+func (r *OrderArchiveRepositoryPostgres) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	var archived []*entity.ArchivedOrder
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("order_created_at DESC").
+		Find(&archived).Error
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*entity.Order, 0, len(archived))
+	for _, a := range archived {
+		order, err := a.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+// AnonymizeUser rewrites the snapshots one by one, they hold the user ID too
+func (r *OrderArchiveRepositoryPostgres) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	changed := 0
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var archived []*entity.ArchivedOrder
+		if err := tx.Where("user_id = ?", userID).Find(&archived).Error; err != nil {
+			return err
+		}
+
+		for _, a := range archived {
+			if err := a.Anonymize(); err != nil {
+				return err
+			}
+			err := tx.Model(a).Select("user_id", "snapshot").Updates(map[string]interface{}{
+				"user_id":  nil,
+				"snapshot": a.Snapshot,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		changed = len(archived)
+		return nil
+	})
+
+	return changed, err
+}
+
+// ReadThroughOrderRepository serves orders from the hot tables and falls back
+// to the archive for lookups by ID, so archived orders stay reachable. Listing
+// and anonymizing the orders of an account cover both.
+type ReadThroughOrderRepository struct {
+	repository.OrderRepository
+	archive repository.OrderArchiveRepository
+}
+
+func NewReadThroughOrderRepository(hot repository.OrderRepository, archive repository.OrderArchiveRepository) repository.OrderRepository {
+	return &ReadThroughOrderRepository{
+		OrderRepository: hot,
+		archive:         archive,
+	}
+}
+
+func (r *ReadThroughOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	order, err := r.OrderRepository.GetByID(ctx, id)
+	if err == nil {
+		return order, nil
+	}
+
+	if archived, archiveErr := r.archive.GetByID(ctx, id); archiveErr == nil {
+		return archived, nil
+	}
+
+	return nil, err
+}
+
+// GetByIDs looks the IDs missing from the hot tables up in the archive one by
+// one, batches are small and rarely reach archived orders
+func (r *ReadThroughOrderRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error) {
+	orders, err := r.OrderRepository.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uuid.UUID]bool, len(orders))
+	for _, order := range orders {
+		found[order.ID] = true
+	}
+	for _, id := range ids {
+		if found[id] {
+			continue
+		}
+		if archived, err := r.archive.GetByID(ctx, id); err == nil {
+			orders = append(orders, archived)
+		}
+	}
+	return orders, nil
+}
+
+func (r *ReadThroughOrderRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	orders, err := r.OrderRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	archived, err := r.archive.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Orders left pending stay hot past the archive cutoff, so the two lists interleave
+	orders = append(orders, archived...)
+	sort.SliceStable(orders, func(i, j int) bool { return orders[i].CreatedAt.After(orders[j].CreatedAt) })
+	return orders, nil
+}
+
+func (r *ReadThroughOrderRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	changed, err := r.OrderRepository.AnonymizeUser(ctx, userID)
+	if err != nil {
+		return changed, err
+	}
+
+	archived, err := r.archive.AnonymizeUser(ctx, userID)
+	return changed + archived, err
+}
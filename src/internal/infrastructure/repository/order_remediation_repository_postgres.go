@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type OrderRemediationRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewOrderRemediationRepository(db *gorm.DB) repository.OrderRemediationRepository {
+	return &OrderRemediationRepositoryPostgres{db: db}
+}
+
+func (r *OrderRemediationRepositoryPostgres) Create(ctx context.Context, remediation *entity.OrderRemediation) error {
+	return r.db.WithContext(ctx).Create(remediation).Error
+}
+
+func (r *OrderRemediationRepositoryPostgres) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error) {
+	var remediations []*entity.OrderRemediation
+	err := r.db.WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("created_at DESC").
+		Find(&remediations).Error
+	if err != nil {
+		return nil, err
+	}
+	return remediations, nil
+}
+
+func (r *OrderRemediationRepositoryPostgres) SumByActorSince(ctx context.Context, actorID uuid.UUID, since time.Time) (float64, error) {
+	var total float64
+	err := r.db.WithContext(ctx).Model(&entity.OrderRemediation{}).
+		Where("performed_by = ? AND created_at >= ?", actorID, since).
+		Select("COALESCE(SUM(amount), 0)").
+		Scan(&total).Error
+	return total, err
+}
@@ -3,11 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type OrderRepositoryPostgres struct {
@@ -26,11 +28,11 @@ func (r *OrderRepositoryPostgres) Create(ctx context.Context, order *entity.Orde
 
 func (r *OrderRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
 	var order entity.Order
-	err := r.db.WithContext(ctx).Preload("Products").First(&order, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Products.Components").First(&order, "id = ?", id).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("Order not found")
+			return nil, entity.NotFoundError("Order not found")
 		}
 		return nil, err
 	}
@@ -38,6 +40,14 @@ func (r *OrderRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*e
 	return &order, nil
 }
 
+func (r *OrderRepositoryPostgres) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	if err := r.db.WithContext(ctx).Preload("Products.Components").Where("id IN ?", ids).Find(&orders).Error; err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
 func (r *OrderRepositoryPostgres) GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
 	var orders []*entity.Order
 	var total int64
@@ -56,8 +66,61 @@ func (r *OrderRepositoryPostgres) GetAll(ctx context.Context, page, pageSize int
 	}
 
 	offset := (page - 1) * pageSize
-	err := query.Preload("Products").Offset(offset).Limit(pageSize).Find(&orders).Error
+	err := query.Preload("Products.Components").Offset(offset).Limit(pageSize).Find(&orders).Error
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return orders, int(total), nil
+}
+
+// Search filters on indexed columns: the account's email through its unique
+// index, the SKU through the order items' and the dates and totals through
+// the orders'
+func (r *OrderRepositoryPostgres) Search(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+	var orders []*entity.Order
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Order{})
+
+	if search.CustomerEmail != "" {
+		query = query.Where("user_id IN (SELECT id FROM users WHERE email = ?)", search.CustomerEmail)
+	}
+	if search.From != nil {
+		query = query.Where("created_at >= ?", *search.From)
+	}
+	if search.To != nil {
+		query = query.Where("created_at < ?", *search.To)
+	}
+	if search.MinTotal != nil {
+		query = query.Where("total_price >= ?", *search.MinTotal)
+	}
+	if search.MaxTotal != nil {
+		query = query.Where("total_price <= ?", *search.MaxTotal)
+	}
+	if search.SKU != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items WHERE order_items.order_id = orders.id AND order_items.sku = ?)", search.SKU)
+	}
+	if search.Status != nil {
+		query = query.Where("status = ?", *search.Status)
+	}
+	if search.PaymentStatus != nil {
+		query = query.Where("payment_status = ?", *search.PaymentStatus)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
+	// Orders with the same date or total keep one order across pages
+	offset := (page - 1) * pageSize
+	err := query.Preload("Products.Components").
+		Order(clause.OrderByColumn{Column: clause.Column{Name: string(search.Sort.Field)}, Desc: search.Sort.Descending}).
+		Order("id").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&orders).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -73,8 +136,116 @@ func (r *OrderRepositoryPostgres) Update(ctx context.Context, order *entity.Orde
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("Order not found")
+		return entity.NotFoundError("Order not found")
 	}
 
 	return nil
 }
+
+func (r *OrderRepositoryPostgres) UpdateStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate, workflow *entity.OrderWorkflow) ([]entity.OrderStatusResult, []*entity.Order, error) {
+	var results []entity.OrderStatusResult
+	var updated []*entity.Order
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Rows are locked in id order so concurrent updates can't deadlock
+		var orders []*entity.Order
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Preload("Products.Components").
+			Where("id IN ?", update.OrderIDs).
+			Order("id").
+			Find(&orders).Error
+		if err != nil {
+			return err
+		}
+
+		byID := make(map[uuid.UUID]*entity.Order, len(orders))
+		for _, order := range orders {
+			byID[order.ID] = order
+		}
+
+		results, updated = update.Apply(byID, workflow)
+		if len(updated) == 0 {
+			return nil
+		}
+		ids := make([]uuid.UUID, len(updated))
+		for i, order := range updated {
+			ids[i] = order.ID
+		}
+		return tx.Model(&entity.Order{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": update.Status, "updated_at": time.Now()}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return results, updated, nil
+}
+
+func (r *OrderRepositoryPostgres) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	var orders []*entity.Order
+	err := r.db.WithContext(ctx).
+		Preload("Products.Components").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&orders).Error
+	return orders, err
+}
+
+func (r *OrderRepositoryPostgres) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.Order{}).
+		Where("user_id = ? AND created_at >= ?", userID, since).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (r *OrderRepositoryPostgres) CountPurchased(ctx context.Context, userID uuid.UUID, productID uuid.UUID) (int, error) {
+	var purchased int
+	err := r.db.WithContext(ctx).Model(&entity.OrderItem{}).
+		Select("COALESCE(SUM(order_items.quantity), 0)").
+		Joins("JOIN orders ON orders.id = order_items.order_id").
+		Where("orders.user_id = ? AND order_items.product_id = ?", userID, productID).
+		Where("orders.status NOT IN ?", []entity.OrderStatus{entity.Cancelled, entity.Refunded}).
+		Scan(&purchased).Error
+	return purchased, err
+}
+
+func (r *OrderRepositoryPostgres) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	result := r.db.WithContext(ctx).Model(&entity.Order{}).
+		Where("user_id = ?", userID).
+		Update("user_id", nil)
+	return int(result.RowsAffected), result.Error
+}
+
+// ScanByCreatedAt pages through the range with a keyset cursor on
+// (created_at, id), so each batch is an index range scan however far into the
+// range it is, and orders created meanwhile cannot shift the pages
+func (r *OrderRepositoryPostgres) ScanByCreatedAt(ctx context.Context, from, until time.Time, batchSize int, fn func(orders []*entity.Order) error) error {
+	var cursor *entity.Order
+	for {
+		query := r.db.WithContext(ctx).
+			Preload("Products.Components").
+			Where("created_at >= ? AND created_at < ?", from, until).
+			Order("created_at, id").
+			Limit(batchSize)
+		if cursor != nil {
+			query = query.Where("(created_at, id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+		}
+
+		var orders []*entity.Order
+		if err := query.Find(&orders).Error; err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return nil
+		}
+		if err := fn(orders); err != nil {
+			return err
+		}
+		if len(orders) < batchSize {
+			return nil
+		}
+		cursor = orders[len(orders)-1]
+	}
+}
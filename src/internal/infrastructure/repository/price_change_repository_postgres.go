@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type PriceChangeRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewPriceChangeRepository(db *gorm.DB) repository.PriceChangeRepository {
+	return &PriceChangeRepositoryPostgres{db: db}
+}
+
+func (r *PriceChangeRepositoryPostgres) Create(ctx context.Context, change *entity.PriceChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+func (r *PriceChangeRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceChange, error) {
+	var change entity.PriceChange
+	if err := r.db.WithContext(ctx).First(&change, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Price change not found")
+		}
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *PriceChangeRepositoryPostgres) Update(ctx context.Context, change *entity.PriceChange) error {
+	result := r.db.WithContext(ctx).Save(change)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Price change not found")
+	}
+	return nil
+}
+
+func (r *PriceChangeRepositoryPostgres) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page, pageSize int) ([]*entity.PriceChange, int, error) {
+	var changes []*entity.PriceChange
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.PriceChange{}).Where("product_id = ?", productID)
+
+	if filters.VariantID != nil {
+		query = query.Where("variant_id = ?", *filters.VariantID)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("effective_from DESC").Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&changes).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return changes, int(total), nil
+}
+
+func (r *PriceChangeRepositoryPostgres) GetSchedules(ctx context.Context, productIDs []uuid.UUID, at time.Time) ([]*entity.PriceChange, error) {
+	var changes []*entity.PriceChange
+	if len(productIDs) == 0 {
+		return changes, nil
+	}
+
+	err := r.db.WithContext(ctx).
+		Where("product_id IN ?", productIDs).
+		Where("scheduled = ? AND cancelled_at IS NULL", true).
+		Where("effective_to IS NULL OR effective_to > ?", at).
+		Order("effective_from").
+		Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type PriceTierRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewPriceTierRepository(db *gorm.DB) repository.PriceTierRepository {
+	return &PriceTierRepositoryPostgres{db: db}
+}
+
+func (r *PriceTierRepositoryPostgres) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error) {
+	var tiers []*entity.PriceTier
+	err := r.db.WithContext(ctx).
+		Where("product_id = ?", productID).
+		Order("variant_id NULLS FIRST, customer_group, min_quantity").
+		Find(&tiers).Error
+	return tiers, err
+}
+
+// ReplaceForProduct deletes the tiers of the product and creates the new ones in one transaction
+func (r *PriceTierRepositoryPostgres) ReplaceForProduct(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("product_id = ?", productID).Delete(&entity.PriceTier{}).Error; err != nil {
+			return err
+		}
+		if len(tiers) == 0 {
+			return nil
+		}
+		for i := range tiers {
+			tiers[i].ProductID = productID
+		}
+		return tx.Create(&tiers).Error
+	})
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type ProductOptionRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewProductOptionRepository(db *gorm.DB) repository.ProductOptionRepository {
+	return &ProductOptionRepositoryPostgres{db: db}
+}
+
+func (r *ProductOptionRepositoryPostgres) Create(ctx context.Context, option *entity.ProductOption) error {
+	return r.db.WithContext(ctx).Create(option).Error
+}
+
+func (r *ProductOptionRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductOption, error) {
+	var option entity.ProductOption
+	err := r.db.WithContext(ctx).Preload("Values", orderByPosition).First(&option, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Product option not found")
+		}
+		return nil, err
+	}
+
+	return &option, nil
+}
+
+func (r *ProductOptionRepositoryPostgres) ListByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error) {
+	var options []*entity.ProductOption
+	err := r.db.WithContext(ctx).
+		Preload("Values", orderByPosition).
+		Where("product_id = ?", productID).
+		Order("position").
+		Find(&options).Error
+	if err != nil {
+		return nil, err
+	}
+	return options, nil
+}
+
+func (r *ProductOptionRepositoryPostgres) AddValue(ctx context.Context, value *entity.ProductOptionValue) error {
+	return r.db.WithContext(ctx).Create(value).Error
+}
+
+func (r *ProductOptionRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.ProductOption{}, "id = ?", id)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Product option not found")
+	}
+
+	return nil
+}
+
+func (r *ProductOptionRepositoryPostgres) InUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.VariantOption{}).
+		Joins("JOIN product_variants ON product_variants.id = variant_options.variant_id").
+		Where("variant_options.option_id = ? AND product_variants.deleted_at IS NULL", id).
+		Count(&count).Error
+	return count > 0, err
+}
@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductRepositoryPostgres struct {
@@ -26,11 +29,11 @@ func (r *ProductRepositoryPostgres) Create(ctx context.Context, product *entity.
 
 func (r *ProductRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
 	var product entity.Product
-	err := r.db.WithContext(ctx).Preload("Categories").Preload("Variants").First(&product, "id = ?", id).Error
+	err := preloadProductRelations(r.db.WithContext(ctx).Scopes(database.ReadReplica)).First(&product, "id = ?", id).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("Product not found")
+			return nil, entity.NotFoundError("Product not found")
 		}
 		return nil, err
 	}
@@ -38,24 +41,65 @@ func (r *ProductRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (
 	return &product, nil
 }
 
-func (r *ProductRepositoryPostgres) GetAll(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+func (r *ProductRepositoryPostgres) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	var products []*entity.Product
+	if err := preloadProductRelations(r.db.WithContext(ctx).Scopes(database.ReadReplica)).Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+func (r *ProductRepositoryPostgres) GetAll(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return r.list(ctx, page, pageSize, filters, func(query *gorm.DB) *gorm.DB {
+		return preloadIncludedRelations(query, filters)
+	})
+}
+
+// productSummaryColumns are the columns ListProductsSummary reads
+var productSummaryColumns = []string{"id", "name", "price", "quantity", "status", "rating_average", "rating_count"}
+
+func (r *ProductRepositoryPostgres) ListProductsSummary(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return r.list(ctx, page, pageSize, filters, func(query *gorm.DB) *gorm.DB {
+		return query.Select(productSummaryColumns)
+	})
+}
+
+// list returns a page of the products matching filters, read by the query
+// load makes of the filtered one
+func (r *ProductRepositoryPostgres) list(ctx context.Context, page, pageSize int, filters repository.ProductFilters, load func(*gorm.DB) *gorm.DB) ([]*entity.Product, int, error) {
 	var products []*entity.Product
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.Product{})
+	query := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.Product{})
 
-	if inStockOnly {
+	if filters.InStockOnly {
 		query = query.Where("quantity > ?", 0)
 	}
 
+	if filters.Status != "" {
+		query = query.Where("status = ?", filters.Status)
+	}
+
+	if filters.AvailableAt != nil {
+		query = query.Scopes(availableAt(*filters.AvailableAt))
+	}
+
+	for _, attribute := range filters.Attributes {
+		query = query.Where("EXISTS (?)", attributeValueQuery(r.db, attribute))
+	}
+
 	// Get total count
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
+	if filters.Sort.Field != "" {
+		query = query.Order(productOrder(filters.Sort))
+	}
+
 	// Apply pagination
 	offset := (page - 1) * pageSize
-	err := query.Preload("Categories").Preload("Variants").Offset(offset).Limit(pageSize).Find(&products).Error
+	err := load(query).Offset(offset).Limit(pageSize).Find(&products).Error
 
 	if err != nil {
 		return nil, 0, err
@@ -64,30 +108,111 @@ func (r *ProductRepositoryPostgres) GetAll(ctx context.Context, page, pageSize i
 	return products, int(total), nil
 }
 
+// productRatingColumns belong to the reviews, which update them in their own
+// transactions; saving a product read before a review would undo the review
+var productRatingColumns = []string{"rating_average", "rating_count"}
+
 func (r *ProductRepositoryPostgres) Update(ctx context.Context, product *entity.Product) error {
-	result := r.db.WithContext(ctx).Save(product)
+	result := r.db.WithContext(ctx).Omit(productRatingColumns...).Save(product)
 
 	if result.Error != nil {
 		return result.Error
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("Product not found")
+		return entity.NotFoundError("Product not found")
 	}
 
 	return nil
 }
 
 func (r *ProductRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&entity.Product{}, "id = ?", id)
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the product waits for orders reserving its stock to commit
+		var product entity.Product
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, "id = ?", id).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entity.NotFoundError("Product not found")
+		}
+		if err != nil {
+			return err
+		}
 
-	if result.Error != nil {
-		return result.Error
+		var ordered int64
+		if err := tx.Model(&entity.OrderItem{}).Where("product_id = ?", id).Count(&ordered).Error; err != nil {
+			return err
+		}
+		if ordered > 0 {
+			return entity.ErrProductHasOrders
+		}
+
+		return tx.Delete(&product).Error
+	})
+}
+
+// productOrder sorts products as sort asks. Ratings tie often, the product
+// with more reviews behind its average comes first among them.
+func productOrder(sort repository.ProductSort) clause.OrderBy {
+	column := func(name string) clause.OrderByColumn {
+		return clause.OrderByColumn{Column: clause.Column{Table: "products", Name: name}, Desc: sort.Descending}
 	}
+	if sort.Field == repository.ProductSortRating {
+		return clause.OrderBy{Columns: []clause.OrderByColumn{column("rating_average"), column("rating_count")}}
+	}
+	return clause.OrderBy{Columns: []clause.OrderByColumn{column(string(sort.Field))}}
+}
 
-	if result.RowsAffected == 0 {
-		return errors.New("Product not found")
+// attributeValueQuery matches products having the attribute set to the given value.
+// Text values are compared case-insensitively.
+func attributeValueQuery(db *gorm.DB, attribute entity.ProductAttribute) *gorm.DB {
+	query := db.Table("product_attributes").
+		Select("1").
+		Where("product_attributes.product_id = products.id AND product_attributes.attribute_id = ?", attribute.AttributeID)
+
+	switch {
+	case attribute.TextValue != nil:
+		query = query.Where("LOWER(product_attributes.text_value) = LOWER(?)", *attribute.TextValue)
+	case attribute.NumberValue != nil:
+		query = query.Where("product_attributes.number_value = ?", *attribute.NumberValue)
+	case attribute.BooleanValue != nil:
+		query = query.Where("product_attributes.boolean_value = ?", *attribute.BooleanValue)
 	}
 
-	return nil
+	return query
+}
+
+// availableAt keeps the products whose availability window contains at
+func availableAt(at time.Time) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("(products.available_from IS NULL OR products.available_from <= ?) AND (products.available_until IS NULL OR products.available_until > ?)", at, at)
+	}
+}
+
+// preloadProductRelations loads everything a product response shows
+func preloadProductRelations(query *gorm.DB) *gorm.DB {
+	return preloadIncludedRelations(query, repository.ProductFilters{})
+}
+
+// preloadIncludedRelations loads the relations filters include. GORM batches
+// each association into one query over the IDs of the whole page, so a page
+// costs the same number of queries whatever its size; what is left out saves
+// those queries and the rows they return.
+func preloadIncludedRelations(query *gorm.DB, filters repository.ProductFilters) *gorm.DB {
+	if filters.Includes(repository.ProductCategories) {
+		query = query.Preload("Categories")
+	}
+	if filters.Includes(repository.ProductVariants) {
+		query = query.Preload("Variants.Options", orderByPosition)
+	}
+	if filters.Includes(repository.ProductOptions) {
+		query = query.Preload("Options", orderByPosition).Preload("Options.Values", orderByPosition)
+	}
+	if filters.Includes(repository.ProductAttributes) {
+		query = query.Preload("Attributes.Attribute")
+	}
+	return query
+}
+
+func orderByPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position")
 }
@@ -0,0 +1,129 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/config"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// newSQLiteDB opens a SQLite database migrated to the latest version
+func newSQLiteDB(tb testing.TB) *gorm.DB {
+	tb.Helper()
+	db, err := database.Connect(&config.DatabaseConfig{Driver: config.DriverSQLite, SQLitePath: filepath.Join(tb.TempDir(), "test.db")})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		tb.Fatal(err)
+	}
+	return db
+}
+
+// newCatalogDB opens a migrated SQLite database holding n products shaped like
+// a typical catalog listing: a couple of categories and a few variants each.
+// The returned counter is incremented by every query run on the database.
+func newCatalogDB(b *testing.B, n int) (*gorm.DB, *atomic.Int64) {
+	b.Helper()
+	db := newSQLiteDB(b)
+
+	categories := []entity.Category{{Name: "Electronics", Slug: "electronics"}, {Name: "Accessories", Slug: "accessories"}}
+	if err := db.Create(&categories).Error; err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < n; i++ {
+		product := entity.Product{Name: fmt.Sprintf("Product %d", i), Price: 99.90, Quantity: 10, Status: entity.ProductActive, Categories: categories}
+		for v := 0; v < 3; v++ {
+			product.Variants = append(product.Variants, entity.ProductVariant{SKU: fmt.Sprintf("SKU-%d-%d", i, v), Quantity: 5})
+		}
+		if err := db.Create(&product).Error; err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	var queries atomic.Int64
+	count := func(*gorm.DB) { queries.Add(1) }
+	if err := db.Callback().Query().After("gorm:query").Register("test:count_queries", count); err != nil {
+		b.Fatal(err)
+	}
+	return db, &queries
+}
+
+func BenchmarkProductRepository_GetAll(b *testing.B) {
+	db, queries := newCatalogDB(b, 50)
+	products := NewProductRepositoryPostgres(db)
+
+	benchmarks := []struct {
+		name    string
+		list    func(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error)
+		include []repository.ProductRelation
+	}{
+		{"everything", products.GetAll, nil},
+		{"categories", products.GetAll, []repository.ProductRelation{repository.ProductCategories}},
+		{"none", products.GetAll, []repository.ProductRelation{}},
+		{"summary", products.ListProductsSummary, nil},
+	}
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			queries.Store(0)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				page, _, err := bm.list(context.Background(), 1, 50, repository.ProductFilters{Include: bm.include})
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(page) != 50 {
+					b.Fatalf("expected a full page, got %d products", len(page))
+				}
+			}
+			b.ReportMetric(float64(queries.Load())/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func TestProductRepository_Delete_KeepsOrderedProducts(t *testing.T) {
+	db := newSQLiteDB(t)
+	products := NewProductRepositoryPostgres(db)
+	ctx := context.Background()
+
+	ordered := entity.Product{Name: "Laptop", Price: 999, Quantity: 5, Status: entity.ProductActive}
+	unsold := entity.Product{Name: "Mouse", Price: 20, Quantity: 5, Status: entity.ProductActive}
+	for _, product := range []*entity.Product{&ordered, &unsold} {
+		if err := db.Create(product).Error; err != nil {
+			t.Fatal(err)
+		}
+	}
+	order := entity.Order{CustomerID: 1, TotalPrice: 999, Products: []entity.OrderItem{{ProductID: ordered.ID, Quantity: 1, Price: 999, TotalPrice: 999}}}
+	if err := db.Create(&order).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := products.Delete(ctx, ordered.ID); !errors.Is(err, entity.ErrProductHasOrders) {
+		t.Errorf("expected ErrProductHasOrders, got %v", err)
+	}
+	if _, err := products.GetByID(ctx, ordered.ID); err != nil {
+		t.Errorf("expected the ordered product to be kept, got %v", err)
+	}
+
+	if err := products.Delete(ctx, unsold.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := products.Delete(ctx, unsold.ID); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected ErrNotFound deleting twice, got %v", err)
+	}
+}
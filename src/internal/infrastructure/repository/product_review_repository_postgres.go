@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductReviewRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewProductReviewRepositoryPostgres(db *gorm.DB) repository.ProductReviewRepository {
+	return &ProductReviewRepositoryPostgres{db: db}
+}
+
+func (r *ProductReviewRepositoryPostgres) Save(ctx context.Context, review *entity.ProductReview) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, review.ProductID); err != nil {
+			return err
+		}
+
+		var existing entity.ProductReview
+		err := tx.Where("product_id = ? AND user_id = ?", review.ProductID, review.UserID).First(&existing).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			err = tx.Create(review).Error
+		case err == nil:
+			review.ID, review.CreatedAt = existing.ID, existing.CreatedAt
+			err = tx.Save(review).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		return refreshRating(tx, review.ProductID)
+	})
+}
+
+func (r *ProductReviewRepositoryPostgres) Get(ctx context.Context, productID, userID uuid.UUID) (*entity.ProductReview, error) {
+	var review entity.ProductReview
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).
+		Where("product_id = ? AND user_id = ?", productID, userID).First(&review).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, entity.NotFoundError("Review not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &review, nil
+}
+
+func (r *ProductReviewRepositoryPostgres) ListByProduct(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductReview, int, error) {
+	var reviews []*entity.ProductReview
+	var total int64
+
+	query := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.ProductReview{}).Where("product_id = ?", productID)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	if err := query.Order("created_at DESC").Order("id").Offset(offset).Limit(pageSize).Find(&reviews).Error; err != nil {
+		return nil, 0, err
+	}
+	return reviews, int(total), nil
+}
+
+func (r *ProductReviewRepositoryPostgres) Delete(ctx context.Context, productID, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockProduct(tx, productID); err != nil {
+			return err
+		}
+
+		result := tx.Where("product_id = ? AND user_id = ?", productID, userID).Delete(&entity.ProductReview{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entity.NotFoundError("Review not found")
+		}
+
+		return refreshRating(tx, productID)
+	})
+}
+
+func (r *ProductReviewRepositoryPostgres) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var productIDs []uuid.UUID
+		if err := tx.Model(&entity.ProductReview{}).Where("user_id = ?", userID).Pluck("product_id", &productIDs).Error; err != nil {
+			return err
+		}
+		if len(productIDs) == 0 {
+			return nil
+		}
+
+		// Locked in ID order, so concurrent deletions don't deadlock. The
+		// products may have been deleted since, their rating is kept anyway.
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").
+			Where("id IN ?", productIDs).Order("id").Find(&[]entity.Product{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.ProductReview{}).Error; err != nil {
+			return err
+		}
+		for _, productID := range productIDs {
+			if err := refreshRating(tx, productID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// lockProduct locks the product whose reviews are about to change, so the
+// rating is refreshed by one transaction at a time and counts every review
+// committed before it
+func lockProduct(tx *gorm.DB, productID uuid.UUID) error {
+	var product entity.Product
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, "id = ?", productID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return entity.NotFoundError("Product not found")
+	}
+	return err
+}
+
+// refreshRating recomputes the rating of the product from its reviews. The
+// columns are updated without touching updated_at, which tracks the product's
+// own changes.
+func refreshRating(tx *gorm.DB, productID uuid.UUID) error {
+	reviews := func() *gorm.DB {
+		return tx.Session(&gorm.Session{NewDB: true}).Model(&entity.ProductReview{}).Where("product_id = ?", productID)
+	}
+	return tx.Unscoped().Model(&entity.Product{}).Where("id = ?", productID).UpdateColumns(map[string]interface{}{
+		"rating_average": reviews().Select("COALESCE(ROUND(AVG(rating), 2), 0)"),
+		"rating_count":   reviews().Select("COUNT(*)"),
+	}).Error
+}
@@ -0,0 +1,69 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProductReviewRepository_KeepsTheRating(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDB(t)
+	repo := NewProductReviewRepositoryPostgres(db)
+	products := NewProductRepositoryPostgres(db)
+
+	product := &entity.Product{Name: "Mug", Price: 10, Quantity: 5}
+	require.NoError(t, products.Create(ctx, product))
+	rating := func() (float64, int) {
+		t.Helper()
+		saved, err := products.GetByID(ctx, product.ID)
+		require.NoError(t, err)
+		return saved.RatingAverage, saved.RatingCount
+	}
+
+	jane, john, ada := uuid.New(), uuid.New(), uuid.New()
+	first := &entity.ProductReview{ProductID: product.ID, UserID: jane, Rating: 5}
+	require.NoError(t, repo.Save(ctx, first))
+	require.NoError(t, repo.Save(ctx, &entity.ProductReview{ProductID: product.ID, UserID: john, Rating: 4}))
+	require.NoError(t, repo.Save(ctx, &entity.ProductReview{ProductID: product.ID, UserID: ada, Rating: 4}))
+	average, count := rating()
+	assert.Equal(t, 4.33, average)
+	assert.Equal(t, 3, count)
+
+	// A product read before the reviews doesn't undo them when saved
+	product.Name = "Big mug"
+	require.NoError(t, products.Update(ctx, product))
+
+	changed := &entity.ProductReview{ProductID: product.ID, UserID: jane, Rating: 1, Body: "Chipped"}
+	require.NoError(t, repo.Save(ctx, changed))
+	assert.Equal(t, first.ID, changed.ID, "a customer has one review per product")
+	average, count = rating()
+	assert.Equal(t, 3.0, average)
+	assert.Equal(t, 3, count)
+
+	reviews, total, err := repo.ListByProduct(ctx, product.ID, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+	assert.Len(t, reviews, 2)
+
+	require.NoError(t, repo.Delete(ctx, product.ID, john))
+	assert.ErrorIs(t, repo.Delete(ctx, product.ID, john), entity.ErrNotFound)
+	average, count = rating()
+	assert.Equal(t, 2.5, average)
+	assert.Equal(t, 2, count)
+
+	require.NoError(t, repo.DeleteByUser(ctx, jane))
+	require.NoError(t, repo.DeleteByUser(ctx, ada))
+	average, count = rating()
+	assert.Equal(t, 0.0, average)
+	assert.Equal(t, 0, count)
+
+	err = repo.Save(ctx, &entity.ProductReview{ProductID: uuid.New(), UserID: jane, Rating: 5})
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ProductTranslationRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewProductTranslationRepository(db *gorm.DB) repository.ProductTranslationRepository {
+	return &ProductTranslationRepositoryPostgres{db: db}
+}
+
+func (r *ProductTranslationRepositoryPostgres) Save(ctx context.Context, translation *entity.ProductTranslation) error {
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "product_id"}, {Name: "locale"}},
+			DoUpdates: clause.AssignmentColumns([]string{"name", "description", "updated_at"}),
+		}).
+		Create(translation).Error
+}
+
+func (r *ProductTranslationRepositoryPostgres) Get(ctx context.Context, productID uuid.UUID, locale string) (*entity.ProductTranslation, error) {
+	var translation entity.ProductTranslation
+	err := r.db.WithContext(ctx).First(&translation, "product_id = ? AND locale = ?", productID, locale).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Product translation not found")
+		}
+		return nil, err
+	}
+	return &translation, nil
+}
+
+func (r *ProductTranslationRepositoryPostgres) ListByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error) {
+	var translations []*entity.ProductTranslation
+	if err := r.db.WithContext(ctx).Where("product_id = ?", productID).Order("locale ASC").Find(&translations).Error; err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *ProductTranslationRepositoryPostgres) ListForProducts(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]entity.ProductTranslation, error) {
+	if len(productIDs) == 0 || len(locales) == 0 {
+		return nil, nil
+	}
+
+	var translations []entity.ProductTranslation
+	err := r.db.WithContext(ctx).
+		Where("product_id IN ? AND locale IN ?", productIDs, locales).
+		Find(&translations).Error
+	if err != nil {
+		return nil, err
+	}
+	return translations, nil
+}
+
+func (r *ProductTranslationRepositoryPostgres) Delete(ctx context.Context, productID uuid.UUID, locale string) error {
+	result := r.db.WithContext(ctx).Delete(&entity.ProductTranslation{}, "product_id = ? AND locale = ?", productID, locale)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Product translation not found")
+	}
+	return nil
+}
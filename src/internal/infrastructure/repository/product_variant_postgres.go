@@ -8,6 +8,7 @@ import (
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type ProductVariantRepositoryPostgres struct {
@@ -26,11 +27,11 @@ func (r *ProductVariantRepositoryPostgres) Create(ctx context.Context, productVa
 
 func (r *ProductVariantRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
 	var productVariant entity.ProductVariant
-	err := r.db.WithContext(ctx).Preload("Product").First(&productVariant, "id = ?", id).Error
+	err := r.db.WithContext(ctx).Preload("Product").Preload("Options", orderByPosition).First(&productVariant, "id = ?", id).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("Product variant not found")
+			return nil, entity.NotFoundError("Product variant not found")
 		}
 		return nil, err
 	}
@@ -38,11 +39,19 @@ func (r *ProductVariantRepositoryPostgres) GetByID(ctx context.Context, id uuid.
 	return &productVariant, nil
 }
 
+func (r *ProductVariantRepositoryPostgres) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error) {
+	var productVariants []*entity.ProductVariant
+	if err := r.db.WithContext(ctx).Preload("Product").Preload("Options", orderByPosition).Where("id IN ?", ids).Find(&productVariants).Error; err != nil {
+		return nil, err
+	}
+	return productVariants, nil
+}
+
 func (r *ProductVariantRepositoryPostgres) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
 	var productVariants []*entity.ProductVariant
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.ProductVariant{}).Preload("Product")
+	query := r.db.WithContext(ctx).Model(&entity.ProductVariant{}).Preload("Product").Preload("Options", orderByPosition)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -62,7 +71,7 @@ func (r *ProductVariantRepositoryPostgres) GetAllByProductID(ctx context.Context
 	var productVariants []*entity.ProductVariant
 	var total int64
 
-	query := r.db.WithContext(ctx).Model(&entity.ProductVariant{}).Preload("Product").Where("product_id = ?", productID)
+	query := r.db.WithContext(ctx).Model(&entity.ProductVariant{}).Preload("Product").Preload("Options", orderByPosition).Where("product_id = ?", productID)
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
@@ -78,17 +87,59 @@ func (r *ProductVariantRepositoryPostgres) GetAllByProductID(ctx context.Context
 	return productVariants, int(total), nil
 }
 
-func (r *ProductVariantRepositoryPostgres) Update(ctx context.Context, productVariant *entity.ProductVariant) error {
-	result := r.db.WithContext(ctx).Save(productVariant)
-	if result.Error != nil {
-		return result.Error
+func (r *ProductVariantRepositoryPostgres) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	var productVariant entity.ProductVariant
+	err := r.db.WithContext(ctx).Preload("Options", orderByPosition).First(&productVariant, "sku = ?", sku).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Product variant not found")
+		}
+		return nil, err
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.New("Product variant not found")
+	return &productVariant, nil
+}
+
+func (r *ProductVariantRepositoryPostgres) GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error) {
+	var productVariant entity.ProductVariant
+	err := r.db.WithContext(ctx).
+		Preload("Options", orderByPosition).
+		First(&productVariant, "product_id = ? AND combination_key = ?", productID, combinationKey).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Product variant not found")
+		}
+		return nil, err
 	}
 
-	return nil
+	return &productVariant, nil
+}
+
+func (r *ProductVariantRepositoryPostgres) Update(ctx context.Context, productVariant *entity.ProductVariant) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Options").Save(productVariant)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return entity.NotFoundError("Product variant not found")
+		}
+
+		if err := tx.Where("variant_id = ?", productVariant.ID).Delete(&entity.VariantOption{}).Error; err != nil {
+			return err
+		}
+		if len(productVariant.Options) == 0 {
+			return nil
+		}
+		for i := range productVariant.Options {
+			productVariant.Options[i].ID = uuid.Nil
+			productVariant.Options[i].VariantID = productVariant.ID
+		}
+		return tx.Create(&productVariant.Options).Error
+	})
 }
 
 func (r *ProductVariantRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
@@ -99,8 +150,82 @@ func (r *ProductVariantRepositoryPostgres) Delete(ctx context.Context, id uuid.U
 	}
 
 	if result.RowsAffected == 0 {
-		return errors.New("Product variant not found")
+		return entity.NotFoundError("Product variant not found")
 	}
 
 	return nil
 }
+
+func (r *ProductVariantRepositoryPostgres) TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error) {
+	var out, in *entity.StockMovement
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the product row first so concurrent transfers of the same product
+		// are serialized and variant rows are always locked in the same order
+		var product entity.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "quantity").First(&product, "id = ?", transfer.ProductID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return entity.NotFoundError("Product not found")
+			}
+			return err
+		}
+
+		fromQuantity, err := lockedStock(tx, &product, transfer.FromVariantID)
+		if err != nil {
+			return err
+		}
+		toQuantity, err := lockedStock(tx, &product, transfer.ToVariantID)
+		if err != nil {
+			return err
+		}
+
+		out, in, err = transfer.Apply(fromQuantity, toQuantity)
+		if err != nil {
+			return err
+		}
+
+		if err := setStock(tx, out); err != nil {
+			return err
+		}
+		if err := setStock(tx, in); err != nil {
+			return err
+		}
+
+		return tx.Create([]*entity.StockMovement{out, in}).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return out, in, nil
+}
+
+// lockedStock returns the quantity of the variant, or of the locked product when variantID is nil
+func lockedStock(tx *gorm.DB, product *entity.Product, variantID *uuid.UUID) (int, error) {
+	if variantID == nil {
+		return product.Quantity, nil
+	}
+
+	var variant entity.ProductVariant
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "quantity").
+		First(&variant, "id = ? AND product_id = ?", *variantID, product.ID).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, entity.NotFoundError("Product variant not found")
+		}
+		return 0, err
+	}
+
+	return variant.Quantity, nil
+}
+
+// setStock writes the quantity a ledger entry leaves behind
+func setStock(tx *gorm.DB, movement *entity.StockMovement) error {
+	if movement.VariantID == nil {
+		return tx.Model(&entity.Product{}).Where("id = ?", movement.ProductID).
+			Updates(map[string]interface{}{"quantity": movement.QuantityAfter, "updated_at": movement.CreatedAt}).Error
+	}
+	return tx.Model(&entity.ProductVariant{}).Where("id = ?", *movement.VariantID).
+		Updates(map[string]interface{}{"quantity": movement.QuantityAfter, "updated_at": movement.CreatedAt}).Error
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PurchaseQueueRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewPurchaseQueueRepository(db *gorm.DB) repository.PurchaseQueueRepository {
+	return &PurchaseQueueRepositoryPostgres{db: db}
+}
+
+// Create reports a user already holding an active place in the queue of the
+// product, caught by the partial unique index, as a conflict
+func (r *PurchaseQueueRepositoryPostgres) Create(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	err := r.db.WithContext(ctx).Create(entry).Error
+	if translator, ok := r.db.Dialector.(gorm.ErrorTranslator); ok && err != nil {
+		err = translator.Translate(err)
+	}
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return entity.ConflictError("Already in queue for this product")
+	}
+	return err
+}
+
+func (r *PurchaseQueueRepositoryPostgres) Update(ctx context.Context, entry *entity.PurchaseQueueEntry) error {
+	return r.db.WithContext(ctx).Save(entry).Error
+}
+
+func (r *PurchaseQueueRepositoryPostgres) GetActiveEntry(ctx context.Context, productID, userID uuid.UUID) (*entity.PurchaseQueueEntry, error) {
+	var entry entity.PurchaseQueueEntry
+	err := r.db.WithContext(ctx).
+		Where("product_id = ? AND user_id = ?", productID, userID).
+		Where("status IN ?", []entity.QueueEntryStatus{entity.QueueWaiting, entity.QueueAdmitted}).
+		Order("created_at DESC").
+		First(&entry).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Queue entry not found")
+		}
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+func (r *PurchaseQueueRepositoryPostgres) CountAhead(ctx context.Context, productID uuid.UUID, joinedAt time.Time) (int, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&entity.PurchaseQueueEntry{}).
+		Where("product_id = ? AND status = ? AND created_at < ?", productID, entity.QueueWaiting, joinedAt).
+		Count(&count).Error
+	return int(count), err
+}
+
+func (r *PurchaseQueueRepositoryPostgres) ListActiveProductIDs(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.WithContext(ctx).Model(&entity.PurchaseQueueEntry{}).
+		Where("status IN ?", []entity.QueueEntryStatus{entity.QueueWaiting, entity.QueueAdmitted}).
+		Distinct().
+		Pluck("product_id", &ids).Error
+	return ids, err
+}
+
+func (r *PurchaseQueueRepositoryPostgres) Advance(ctx context.Context, productID uuid.UUID, slots int, window time.Duration, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Lock the product row so concurrent advances for the same product are serialized
+		var product entity.Product
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&product, "id = ?", productID).Error; err != nil {
+			return err
+		}
+
+		err := tx.Model(&entity.PurchaseQueueEntry{}).
+			Where("product_id = ? AND status = ? AND window_expires_at <= ?", productID, entity.QueueAdmitted, now).
+			Updates(map[string]interface{}{"status": entity.QueueExpired, "updated_at": now}).Error
+		if err != nil {
+			return err
+		}
+
+		var open int64
+		err = tx.Model(&entity.PurchaseQueueEntry{}).
+			Where("product_id = ? AND status = ?", productID, entity.QueueAdmitted).
+			Count(&open).Error
+		if err != nil {
+			return err
+		}
+
+		free := slots - int(open)
+		if free <= 0 {
+			return nil
+		}
+
+		var next []entity.PurchaseQueueEntry
+		err = tx.Where("product_id = ? AND status = ?", productID, entity.QueueWaiting).
+			Order("created_at ASC").
+			Limit(free).
+			Find(&next).Error
+		if err != nil || len(next) == 0 {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(next))
+		for i := range next {
+			ids[i] = next[i].ID
+		}
+
+		expiresAt := now.Add(window)
+		return tx.Model(&entity.PurchaseQueueEntry{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{"status": entity.QueueAdmitted, "window_expires_at": expiresAt, "updated_at": now}).Error
+	})
+}
@@ -0,0 +1,146 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// statement is a query a repository ran, with its arguments
+type statement struct {
+	sql  string
+	vars []interface{}
+}
+
+// recordStatements keeps every read run on db from then on
+func recordStatements(t *testing.T, db *gorm.DB) *[]statement {
+	t.Helper()
+	var statements []statement
+	record := func(tx *gorm.DB) {
+		statements = append(statements, statement{tx.Statement.SQL.String(), tx.Statement.Vars})
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Register("test:record_statements", record))
+	require.NoError(t, db.Callback().Row().After("gorm:row").Register("test:record_statements", record))
+	return &statements
+}
+
+// queryPlan returns the steps SQLite takes to run s, one per line
+func queryPlan(t *testing.T, db *gorm.DB, s statement) string {
+	t.Helper()
+	rows, err := db.Raw("EXPLAIN QUERY PLAN "+s.sql, s.vars...).Rows()
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var steps []string
+	for rows.Next() {
+		var id, parent, unused int
+		var detail string
+		require.NoError(t, rows.Scan(&id, &parent, &unused, &detail))
+		steps = append(steps, detail)
+	}
+	require.NoError(t, rows.Err())
+	return strings.Join(steps, "\n")
+}
+
+// TestQueryPlans runs repository queries on a migrated database and checks
+// the planner reads them through the indexes migrations created for them
+func TestQueryPlans(t *testing.T) {
+	ctx := context.Background()
+	status, paymentStatus := entity.Pending, entity.Unpaid
+	since, total := time.Now().Add(-24*time.Hour), 100.0
+	newestFirst := repository.OrderSort{Field: repository.OrderSortCreatedAt, Descending: true}
+
+	tests := []struct {
+		name  string
+		index string
+		run   func(db *gorm.DB) error
+	}{
+		{
+			name:  "Orders by status and payment status",
+			index: "idx_orders_status_payment_status_created_at",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewOrderRepositoryPostgres(db).GetAll(ctx, 1, 10, &status, &paymentStatus)
+				return err
+			},
+		},
+		{
+			name:  "Orders placed since a time",
+			index: "idx_orders_created_at",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewOrderRepositoryPostgres(db).Search(ctx, repository.OrderSearch{From: &since, Sort: newestFirst}, 1, 10)
+				return err
+			},
+		},
+		{
+			name:  "Orders above a total",
+			index: "idx_orders_total_price",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewOrderRepositoryPostgres(db).Search(ctx, repository.OrderSearch{MinTotal: &total, Sort: newestFirst}, 1, 10)
+				return err
+			},
+		},
+		{
+			name:  "Orders of a SKU",
+			index: "idx_order_items_sku",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewOrderRepositoryPostgres(db).Search(ctx, repository.OrderSearch{SKU: "TSHIRT-L", Sort: newestFirst}, 1, 10)
+				return err
+			},
+		},
+		{
+			name:  "Orders of a customer email",
+			index: "idx_users_email",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewOrderRepositoryPostgres(db).Search(ctx, repository.OrderSearch{CustomerEmail: "ana@example.com", Sort: newestFirst}, 1, 10)
+				return err
+			},
+		},
+		{
+			name:  "Units of a product a customer bought",
+			index: "idx_order_items_product_id",
+			run: func(db *gorm.DB) error {
+				_, err := NewOrderRepositoryPostgres(db).CountPurchased(ctx, uuid.New(), uuid.New())
+				return err
+			},
+		},
+		{
+			name:  "Variants of a product",
+			index: "idx_product_variants_product_id_deleted_at",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewProductVariantRepositoryPostgres(db).GetAllByProductID(ctx, uuid.New(), 1, 10)
+				return err
+			},
+		},
+		{
+			name:  "Products in stock",
+			index: "idx_products_deleted_at_quantity",
+			run: func(db *gorm.DB) error {
+				_, _, err := NewProductRepositoryPostgres(db).ListProductsSummary(ctx, 1, 10, repository.ProductFilters{InStockOnly: true})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newSQLiteDB(t)
+			statements := recordStatements(t, db)
+
+			require.NoError(t, tt.run(db))
+			require.NotEmpty(t, *statements)
+
+			plan := queryPlan(t, db, (*statements)[0])
+			assert.Contains(t, plan, tt.index)
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type RecallRepositoryPostgres struct {
+	db *gorm.DB
+	// matchSnapshot narrows archived orders to the ones whose snapshot may hold
+	// the recalled items, the units are counted from the restored order
+	matchSnapshot func(query *gorm.DB, criteria repository.RecallCriteria) (*gorm.DB, error)
+}
+
+func NewRecallRepository(db *gorm.DB) repository.RecallRepository {
+	return &RecallRepositoryPostgres{db: db, matchSnapshot: containsRecalledItem}
+}
+
+func (r *RecallRepositoryPostgres) FindAffectedOrders(ctx context.Context, criteria repository.RecallCriteria) ([]entity.AffectedOrder, error) {
+	var affected []entity.AffectedOrder
+	query := r.db.WithContext(ctx).
+		Table("orders").
+		Select("orders.id AS order_id, orders.user_id, orders.customer_id, SUM(order_items.quantity) AS quantity, orders.created_at AS ordered_at").
+		Joins("JOIN order_items ON order_items.order_id = orders.id").
+		Where("order_items.product_id = ?", criteria.ProductID).
+		Where("orders.status <> ?", entity.Cancelled).
+		Where("orders.created_at >= ? AND orders.created_at < ?", criteria.From, criteria.Until).
+		Group("orders.id")
+	if criteria.VariantID != nil {
+		query = query.Where("order_items.variant_id = ?", *criteria.VariantID)
+	}
+	if err := query.Scan(&affected).Error; err != nil {
+		return nil, err
+	}
+
+	archived, err := r.findAffectedArchivedOrders(ctx, criteria)
+	if err != nil {
+		return nil, err
+	}
+	affected = append(affected, archived...)
+
+	sort.SliceStable(affected, func(i, j int) bool {
+		return affected[i].OrderedAt.Before(affected[j].OrderedAt)
+	})
+	return affected, nil
+}
+
+// containsRecalledItem matches snapshots with JSON containment
+func containsRecalledItem(query *gorm.DB, criteria repository.RecallCriteria) (*gorm.DB, error) {
+	match := map[string]any{"ProductID": criteria.ProductID}
+	if criteria.VariantID != nil {
+		match["VariantID"] = *criteria.VariantID
+	}
+	contains, err := json.Marshal(map[string]any{"Products": []any{match}})
+	if err != nil {
+		return nil, err
+	}
+	return query.Where("snapshot @> ?", string(contains)), nil
+}
+
+// findAffectedArchivedOrders narrows the archive with a match on the snapshot,
+// then counts the recalled units from the restored items
+func (r *RecallRepositoryPostgres) findAffectedArchivedOrders(ctx context.Context, criteria repository.RecallCriteria) ([]entity.AffectedOrder, error) {
+	query := r.db.WithContext(ctx).
+		Where("status <> ?", entity.Cancelled).
+		Where("order_created_at >= ? AND order_created_at < ?", criteria.From, criteria.Until)
+	query, err := r.matchSnapshot(query, criteria)
+	if err != nil {
+		return nil, err
+	}
+
+	var archived []*entity.ArchivedOrder
+	if err := query.Find(&archived).Error; err != nil {
+		return nil, err
+	}
+
+	affected := make([]entity.AffectedOrder, 0, len(archived))
+	for _, a := range archived {
+		order, err := a.ToOrder()
+		if err != nil {
+			return nil, err
+		}
+
+		quantity := 0
+		for _, item := range order.Products {
+			if item.ProductID != criteria.ProductID {
+				continue
+			}
+			if criteria.VariantID != nil && (item.VariantID == nil || *item.VariantID != *criteria.VariantID) {
+				continue
+			}
+			quantity += item.Quantity
+		}
+		if quantity == 0 {
+			continue
+		}
+
+		affected = append(affected, entity.AffectedOrder{
+			OrderID:    a.ID,
+			UserID:     a.UserID,
+			CustomerID: a.CustomerID,
+			Quantity:   quantity,
+			OrderedAt:  a.OrderCreatedAt,
+		})
+	}
+	return affected, nil
+}
+
+func (r *RecallRepositoryPostgres) Create(ctx context.Context, recall *entity.Recall) error {
+	return r.db.WithContext(ctx).Create(recall).Error
+}
+
+func (r *RecallRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	var recall entity.Recall
+	err := r.db.WithContext(ctx).
+		Preload("Notices", func(db *gorm.DB) *gorm.DB { return db.Order("created_at, id") }).
+		First(&recall, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Recall not found")
+		}
+		return nil, err
+	}
+
+	return &recall, nil
+}
+
+func (r *RecallRepositoryPostgres) List(ctx context.Context, page, pageSize int) ([]*entity.Recall, int, error) {
+	var recalls []*entity.Recall
+	var total int64
+
+	if err := r.db.WithContext(ctx).Model(&entity.Recall{}).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := r.db.WithContext(ctx).
+		Preload("Notices").
+		Order("created_at DESC").
+		Offset(offset).
+		Limit(pageSize).
+		Find(&recalls).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return recalls, int(total), nil
+}
+
+func (r *RecallRepositoryPostgres) GetNotice(ctx context.Context, id uuid.UUID) (*entity.RecallNotice, error) {
+	var notice entity.RecallNotice
+	err := r.db.WithContext(ctx).Preload("Recall").First(&notice, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Recall notice not found")
+		}
+		return nil, err
+	}
+
+	return &notice, nil
+}
+
+func (r *RecallRepositoryPostgres) UpdateNotice(ctx context.Context, notice *entity.RecallNotice) error {
+	return r.db.WithContext(ctx).
+		Model(&entity.RecallNotice{}).
+		Where("id = ?", notice.ID).
+		Updates(map[string]any{
+			"status":          notice.Status,
+			"error":           notice.Error,
+			"sent_at":         notice.SentAt,
+			"acknowledged_at": notice.AcknowledgedAt,
+		}).Error
+}
+
+func (r *RecallRepositoryPostgres) ListNoticesByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error) {
+	var notices []*entity.RecallNotice
+	err := r.db.WithContext(ctx).
+		Preload("Recall").
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&notices).Error
+	if err != nil {
+		return nil, err
+	}
+	return notices, nil
+}
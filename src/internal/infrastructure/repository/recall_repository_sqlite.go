@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// NewRecallRepositorySQLite finds recalled items in archived snapshots with
+// json_each, SQLite has no JSON containment
+func NewRecallRepositorySQLite(db *gorm.DB) repository.RecallRepository {
+	return &RecallRepositoryPostgres{db: db, matchSnapshot: holdsRecalledItemSQLite}
+}
+
+func holdsRecalledItemSQLite(query *gorm.DB, criteria repository.RecallCriteria) (*gorm.DB, error) {
+	item := "SELECT 1 FROM json_each(archived_orders.snapshot, '$.Products') AS item WHERE json_extract(item.value, '$.ProductID') = ?"
+	args := []interface{}{criteria.ProductID.String()}
+	if criteria.VariantID != nil {
+		item += " AND json_extract(item.value, '$.VariantID') = ?"
+		args = append(args, criteria.VariantID.String())
+	}
+	return query.Where("EXISTS ("+item+")", args...), nil
+}
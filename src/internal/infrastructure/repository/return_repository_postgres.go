@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReturnRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) repository.ReturnRepository {
+	return &ReturnRepositoryPostgres{db: db}
+}
+
+func (r *ReturnRepositoryPostgres) Create(ctx context.Context, ret *entity.Return) error {
+	return r.db.WithContext(ctx).Create(ret).Error
+}
+
+func (r *ReturnRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
+	var ret entity.Return
+	if err := r.db.WithContext(ctx).Preload("Items").First(&ret, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Return not found")
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (r *ReturnRepositoryPostgres) Update(ctx context.Context, ret *entity.Return) error {
+	result := r.db.WithContext(ctx).Omit(clause.Associations).Save(ret)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Return not found")
+	}
+	return nil
+}
+
+func (r *ReturnRepositoryPostgres) List(ctx context.Context, filters repository.ReturnFilters, page, pageSize int) ([]*entity.Return, int, error) {
+	var returns []*entity.Return
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.Return{})
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.OrderID != nil {
+		query = query.Where("order_id = ?", *filters.OrderID)
+	}
+	if filters.UserID != nil {
+		query = query.Where("order_id IN (?)", r.db.Model(&entity.Order{}).Select("id").Where("user_id = ?", *filters.UserID))
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Preload("Items").Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&returns).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return returns, int(total), nil
+}
+
+func (r *ReturnRepositoryPostgres) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Return, error) {
+	var returns []*entity.Return
+	err := r.db.WithContext(ctx).Preload("Items").Where("order_id = ?", orderID).Order("created_at").Find(&returns).Error
+	return returns, err
+}
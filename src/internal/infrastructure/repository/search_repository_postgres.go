@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+type SearchRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewSearchRepository(db *gorm.DB) repository.SearchRepository {
+	return &SearchRepositoryPostgres{db: db}
+}
+
+// likeEscaper makes user input match literally in a LIKE pattern
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *SearchRepositoryPostgres) FindCandidates(ctx context.Context, terms []string, at time.Time, limit int) ([]*entity.Product, error) {
+	query := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.Product{}).
+		Where("status = ?", entity.ProductActive).
+		Scopes(availableAt(at))
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(term) + "%"
+		query = query.Where("(name ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+
+	// Ranking happens in memory, the order only decides which candidates make the cut
+	var products []*entity.Product
+	err := preloadProductRelations(query).Order("created_at DESC").Limit(limit).Find(&products).Error
+	return products, err
+}
+
+func (r *SearchRepositoryPostgres) CreateRule(ctx context.Context, rule *entity.RankingRule) error {
+	return r.db.WithContext(ctx).Create(rule).Error
+}
+
+func (r *SearchRepositoryPostgres) GetRule(ctx context.Context, id uuid.UUID) (*entity.RankingRule, error) {
+	var rule entity.RankingRule
+	err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Ranking rule not found")
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+func (r *SearchRepositoryPostgres) UpdateRule(ctx context.Context, rule *entity.RankingRule) error {
+	return r.db.WithContext(ctx).Save(rule).Error
+}
+
+func (r *SearchRepositoryPostgres) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	result := r.db.WithContext(ctx).Delete(&entity.RankingRule{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Ranking rule not found")
+	}
+	return nil
+}
+
+func (r *SearchRepositoryPostgres) ListRules(ctx context.Context) ([]*entity.RankingRule, error) {
+	var rules []*entity.RankingRule
+	err := r.db.WithContext(ctx).Scopes(database.ReadReplica).Order("created_at ASC").Find(&rules).Error
+	return rules, err
+}
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/database"
+	"gorm.io/gorm"
+)
+
+// SearchRepositorySQLite matches with LIKE, which SQLite compares without case
+// for ASCII letters, in place of ILIKE
+type SearchRepositorySQLite struct {
+	*SearchRepositoryPostgres
+}
+
+func NewSearchRepositorySQLite(db *gorm.DB) repository.SearchRepository {
+	return &SearchRepositorySQLite{&SearchRepositoryPostgres{db: db}}
+}
+
+func (r *SearchRepositorySQLite) FindCandidates(ctx context.Context, terms []string, at time.Time, limit int) ([]*entity.Product, error) {
+	query := r.db.WithContext(ctx).Scopes(database.ReadReplica).Model(&entity.Product{}).
+		Where("status = ?", entity.ProductActive).
+		Scopes(availableAt(at))
+	for _, term := range terms {
+		pattern := "%" + likeEscaper.Replace(term) + "%"
+		query = query.Where(`(name LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`, pattern, pattern)
+	}
+
+	var products []*entity.Product
+	err := preloadProductRelations(query).Order("created_at DESC").Limit(limit).Find(&products).Error
+	return products, err
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type StockMovementRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewStockMovementRepository(db *gorm.DB) repository.StockMovementRepository {
+	return &StockMovementRepositoryPostgres{db: db}
+}
+
+func (r *StockMovementRepositoryPostgres) Create(ctx context.Context, movement *entity.StockMovement) error {
+	return r.db.WithContext(ctx).Create(movement).Error
+}
+
+func (r *StockMovementRepositoryPostgres) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error) {
+	var movements []*entity.StockMovement
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.StockMovement{}).Where("product_id = ?", productID)
+
+	if filters.VariantID != nil {
+		query = query.Where("variant_id = ?", *filters.VariantID)
+	}
+	if filters.Reason != nil {
+		query = query.Where("reason = ?", *filters.Reason)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&movements).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return movements, int(total), nil
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// StoreSettingRepositoryPostgres stores settings in PostgreSQL or SQLite,
+// which both support the upsert
+type StoreSettingRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewStoreSettingRepository(db *gorm.DB) repository.StoreSettingRepository {
+	return &StoreSettingRepositoryPostgres{db: db}
+}
+
+func (r *StoreSettingRepositoryPostgres) List(ctx context.Context) ([]*entity.StoreSetting, error) {
+	var settings []*entity.StoreSetting
+	if err := r.db.WithContext(ctx).Order("key ASC").Find(&settings).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (r *StoreSettingRepositoryPostgres) Save(ctx context.Context, settings []*entity.StoreSetting) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key"}},
+			DoUpdates: clause.AssignmentColumns([]string{"value", "updated_by", "updated_at"}),
+		}).
+		Create(&settings).Error
+}
+
+func (r *StoreSettingRepositoryPostgres) Delete(ctx context.Context, keys []string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Where("key IN ?", keys).Delete(&entity.StoreSetting{}).Error
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TokenRevocationRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewTokenRevocationRepository(db *gorm.DB) repository.TokenRevocationRepository {
+	return &TokenRevocationRepositoryPostgres{db: db}
+}
+
+func (r *TokenRevocationRepositoryPostgres) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(revocation).Error
+}
+
+func (r *TokenRevocationRepositoryPostgres) IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	query := r.db.WithContext(ctx).Model(&entity.TokenRevocation{}).
+		Where("expires_at > ?", time.Now())
+
+	userWide := r.db.Where("token_id IS NULL AND user_id = ? AND revoked_at >= ?", userID, issuedAt)
+	if tokenID != "" {
+		query = query.Where(r.db.Where("token_id = ?", tokenID).Or(userWide))
+	} else {
+		query = query.Where(userWide)
+	}
+
+	var count int64
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+func (r *TokenRevocationRepositoryPostgres) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", cutoff).Delete(&entity.TokenRevocation{})
+	return result.RowsAffected, result.Error
+}
@@ -26,7 +26,7 @@ func (r *userRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*en
 	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("User not found")
+			return nil, entity.NotFoundError("User not found")
 		}
 		return nil, err
 	}
@@ -38,13 +38,19 @@ func (r *userRepositoryPostgres) GetByEmail(ctx context.Context, email string) (
 	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("User not found")
+			return nil, entity.NotFoundError("User not found")
 		}
 		return nil, err
 	}
 	return &user, nil
 }
 
+func (r *userRepositoryPostgres) ListByRole(ctx context.Context, role entity.Role) ([]*entity.User, error) {
+	var users []*entity.User
+	err := r.db.WithContext(ctx).Where("role = ? AND active = ?", role, true).Find(&users).Error
+	return users, err
+}
+
 func (r *userRepositoryPostgres) Update(ctx context.Context, user *entity.User) error {
 	return r.db.WithContext(ctx).Save(user).Error
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WebhookNonceRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewWebhookNonceRepository(db *gorm.DB) repository.WebhookNonceRepository {
+	return &WebhookNonceRepositoryPostgres{db: db}
+}
+
+// Claim inserts the nonce, or takes over an expired one the purge hasn't
+// removed yet. A row is only written when the nonce is free, so concurrent
+// claims of one nonce can't both succeed.
+func (r *WebhookNonceRepositoryPostgres) Claim(ctx context.Context, nonce *entity.WebhookNonce, now time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "nonce"}},
+			DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+			Where: clause.Where{Exprs: []clause.Expression{
+				clause.Expr{SQL: "webhook_nonces.expires_at < ?", Vars: []interface{}{now}},
+			}},
+		}).
+		Create(nonce)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *WebhookNonceRepositoryPostgres) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("expires_at < ?", cutoff).Delete(&entity.WebhookNonce{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,39 @@
+//go:build cgo
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookNonceRepository_Claim(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWebhookNonceRepository(newSQLiteDB(t))
+
+	now := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	claimed, err := repo.Claim(ctx, &entity.WebhookNonce{Nonce: "n-1", ExpiresAt: now.Add(5 * time.Minute)}, now)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = repo.Claim(ctx, &entity.WebhookNonce{Nonce: "n-1", ExpiresAt: now.Add(6 * time.Minute)}, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, claimed, "a nonce can't be used twice while it is live")
+
+	claimed, err = repo.Claim(ctx, &entity.WebhookNonce{Nonce: "n-1", ExpiresAt: now.Add(16 * time.Minute)}, now.Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, claimed, "an expired nonce the purge missed is free again")
+
+	claimed, err = repo.Claim(ctx, &entity.WebhookNonce{Nonce: "n-2", ExpiresAt: now.Add(5 * time.Minute)}, now)
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	removed, err := repo.DeleteExpired(ctx, now.Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), removed, "only n-2 is expired")
+}
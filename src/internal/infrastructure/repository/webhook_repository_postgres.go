@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"gorm.io/gorm"
 )
 
@@ -18,7 +20,7 @@ func NewWebhookRepository(db *gorm.DB) *WebhookRepositoryPostgres {
 
 func (r *WebhookRepositoryPostgres) Create(ctx context.Context, log *entity.WebhookLog) error {
 	if log.ID == uuid.Nil {
-		log.ID = uuid.New()
+		log.ID = entity.NewID()
 	}
 	return r.db.WithContext(ctx).Create(log).Error
 }
@@ -27,11 +29,55 @@ func (r *WebhookRepositoryPostgres) Update(ctx context.Context, log *entity.Webh
 	return r.db.WithContext(ctx).Save(log).Error
 }
 
-func (r *WebhookRepositoryPostgres) GetByOrderID(ctx context.Context, orderID string) ([]entity.WebhookLog, error) {
+func (r *WebhookRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookLog, error) {
+	var log entity.WebhookLog
+	if err := r.db.WithContext(ctx).First(&log, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+func (r *WebhookRepositoryPostgres) GetByOrderID(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&entity.WebhookLog{}).Where("order_id = ?", orderID), filters, page, pageSize)
+}
+
+func (r *WebhookRepositoryPostgres) List(ctx context.Context, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	return r.list(r.db.WithContext(ctx).Model(&entity.WebhookLog{}), filters, page, pageSize)
+}
+
+func (r *WebhookRepositoryPostgres) list(query *gorm.DB, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	var logs []entity.WebhookLog
+	var total int64
+
+	if filters.TransactionID != nil {
+		query = query.Where("transaction_id = ?", *filters.TransactionID)
+	}
+	if filters.PaymentStatus != nil {
+		query = query.Where("payment_status = ?", *filters.PaymentStatus)
+	}
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&logs).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return logs, int(total), nil
+}
+
+func (r *WebhookRepositoryPostgres) ListDueRetries(ctx context.Context, now time.Time, limit int) ([]entity.WebhookLog, error) {
 	var logs []entity.WebhookLog
 	err := r.db.WithContext(ctx).
-		Where("order_id = ?", orderID).
-		Order("created_at DESC").
+		Where("status = ? AND next_retry_at IS NOT NULL AND next_retry_at <= ?", entity.WebhookStatusFailed, now).
+		Order("next_retry_at ASC").
+		Limit(limit).
 		Find(&logs).Error
 	return logs, err
 }
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+type WebhookSubscriptionRepositoryPostgres struct {
+	db *gorm.DB
+}
+
+func NewWebhookSubscriptionRepository(db *gorm.DB) repository.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepositoryPostgres{db: db}
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	var subscription entity.WebhookSubscription
+	if err := r.db.WithContext(ctx).First(&subscription, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Webhook subscription not found")
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	result := r.db.WithContext(ctx).Save(subscription)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Webhook subscription not found")
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("subscription_id = ?", id).Delete(&entity.WebhookDelivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Delete(&entity.WebhookSubscription{}, "id = ?", id)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return entity.NotFoundError("Webhook subscription not found")
+		}
+		return nil
+	})
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	err := r.db.WithContext(ctx).Order("created_at").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	var subscriptions []*entity.WebhookSubscription
+	err := r.db.WithContext(ctx).Where("active = ?", true).Order("created_at").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	return r.db.WithContext(ctx).Create(delivery).Error
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+	if err := r.db.WithContext(ctx).First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, entity.NotFoundError("Webhook delivery not found")
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	result := r.db.WithContext(ctx).Save(delivery)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return entity.NotFoundError("Webhook delivery not found")
+	}
+	return nil
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page, pageSize int) ([]*entity.WebhookDelivery, int, error) {
+	var deliveries []*entity.WebhookDelivery
+	var total int64
+
+	query := r.db.WithContext(ctx).Model(&entity.WebhookDelivery{}).Where("subscription_id = ?", subscriptionID)
+
+	if filters.Status != nil {
+		query = query.Where("status = ?", *filters.Status)
+	}
+	if filters.EventType != nil {
+		query = query.Where("event_type = ?", *filters.EventType)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+	err := query.Order("created_at DESC").Offset(offset).Limit(pageSize).Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return deliveries, int(total), nil
+}
+
+func (r *WebhookSubscriptionRepositoryPostgres) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at IS NOT NULL AND next_attempt_at <= ?", entity.DeliveryPending, now).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&deliveries).Error
+	return deliveries, err
+}
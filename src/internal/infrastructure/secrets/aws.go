@@ -0,0 +1,186 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AWSConfig locates a secret of AWS Secrets Manager. Its SecretString must be
+// a JSON object of settings.
+type AWSConfig struct {
+	Region   string
+	SecretID string // Name or ARN of the secret
+	// Credentials, usually from AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Endpoint        string // Overrides the regional endpoint, e.g. for LocalStack
+}
+
+type awsProvider struct {
+	cfg    AWSConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewAWSProvider(cfg AWSConfig, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = "https://secretsmanager." + cfg.Region + ".amazonaws.com"
+	}
+	return &awsProvider{cfg: cfg, client: client, now: time.Now}
+}
+
+func (p *awsProvider) Name() string {
+	return "aws"
+}
+
+// Fetch calls GetSecretValue. The request is signed with Signature Version 4
+// by hand, which spares the AWS SDK for a single call.
+func (p *awsProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	body, err := json.Marshal(map[string]string{"SecretId": p.cfg.SecretID})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimRight(p.cfg.Endpoint, "/")+"/", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if p.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.cfg.SessionToken)
+	}
+	signV4(req, body, p.cfg.AccessKeyID, p.cfg.SecretAccessKey, p.cfg.Region, "secretsmanager", p.now())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("secrets manager returned %d: %s", resp.StatusCode, strings.TrimSpace(string(message)))
+	}
+
+	var secret struct {
+		SecretString *string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("decoding secret: %w", err)
+	}
+	if secret.SecretString == nil {
+		return nil, fmt.Errorf("secret %s has no SecretString, binary secrets are not supported", p.cfg.SecretID)
+	}
+
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(*secret.SecretString), &data); err != nil {
+		return nil, fmt.Errorf("secret %s must be a JSON object of settings: %w", p.cfg.SecretID, err)
+	}
+	return stringValues(data)
+}
+
+// signV4 adds the X-Amz-Date and Authorization headers of AWS Signature
+// Version 4 to the request. Every header already set is signed along with Host.
+func signV4(req *http.Request, body []byte, accessKeyID, secretAccessKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(payloadHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+secretAccessKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery sorts the query parameters by name, then value
+func canonicalQuery(req *http.Request) string {
+	query := req.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// awsEscape percent-encodes everything but the unreserved characters of RFC 3986
+func awsEscape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,92 @@
+// Package secrets fetches settings such as the JWT and webhook secrets and the
+// database credentials from a secrets manager, HashiCorp Vault or AWS Secrets
+// Manager, instead of the environment.
+//
+// A secret holds settings by their environment variable name, e.g.
+// {"JWT_SECRET": "...", "DB_PASSWORD": "..."}. The config package reads them
+// at startup ahead of the environment; Store.Watch picks up rotated values.
+package secrets
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+//go:generate go run ../../tools/mockgen -interface Provider -out ../../testing/mocks -name SecretsProvider
+
+// Provider fetches the current settings from a secrets manager
+type Provider interface {
+	// Name identifies the secrets manager in logs and errors
+	Name() string
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+// Store holds the settings last fetched from a provider. It is safe for concurrent use.
+type Store struct {
+	provider Provider
+
+	mu     sync.RWMutex
+	values map[string]string
+}
+
+func NewStore(provider Provider) *Store {
+	return &Store{provider: provider, values: make(map[string]string)}
+}
+
+func (s *Store) Name() string {
+	return s.provider.Name()
+}
+
+// Get returns the setting, or "" when the secret doesn't hold it
+func (s *Store) Get(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.values[key]
+}
+
+// Refresh fetches the settings again and returns the ones whose value changed.
+// On error the settings fetched before are kept.
+func (s *Store) Refresh(ctx context.Context) (map[string]string, error) {
+	values, err := s.provider.Fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	changed := make(map[string]string)
+	for key, value := range values {
+		if s.values[key] != value {
+			changed[key] = value
+		}
+	}
+	s.values = values
+	return changed, nil
+}
+
+// Watch refreshes the settings every interval until ctx is done, calling
+// onChange with the settings that were rotated. Failed refreshes are logged
+// and the previous values stay in use.
+func (s *Store) Watch(ctx context.Context, interval time.Duration, onChange func(changed map[string]string)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := s.Refresh(ctx)
+			if err != nil {
+				log.Printf("Failed to refresh secrets from %s: %v", s.Name(), err)
+				continue
+			}
+			if len(changed) > 0 {
+				onChange(changed)
+			}
+		}
+	}
+}
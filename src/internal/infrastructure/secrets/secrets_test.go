@@ -0,0 +1,128 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeProvider struct {
+	values map[string]string
+	err    error
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	return f.values, f.err
+}
+
+func TestStore_RefreshReportsRotatedValues(t *testing.T) {
+	provider := &fakeProvider{values: map[string]string{"JWT_SECRET": "one", "DB_PASSWORD": "pw"}}
+	store := NewStore(provider)
+
+	if _, err := store.Refresh(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	provider.values = map[string]string{"JWT_SECRET": "two", "DB_PASSWORD": "pw"}
+	changed, _ := store.Refresh(context.Background())
+	if len(changed) != 1 || changed["JWT_SECRET"] != "two" {
+		t.Errorf("expected only the JWT secret to change, got %v", changed)
+	}
+
+	provider.err = errors.New("vault sealed")
+	if _, err := store.Refresh(context.Background()); err == nil {
+		t.Error("expected the fetch error")
+	}
+	if store.Get("JWT_SECRET") != "two" {
+		t.Errorf("expected the previous values to stay, got %q", store.Get("JWT_SECRET"))
+	}
+}
+
+func TestVaultProvider_ReadsKVVersion2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/secret/data/go-ecommerce" || r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"JWT_SECRET":"jwt","DB_PORT":5433},"metadata":{"version":3}}}`))
+	}))
+	defer server.Close()
+
+	provider := NewVaultProvider(VaultConfig{Addr: server.URL, Token: "token", Path: "secret/data/go-ecommerce"}, server.Client())
+	values, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if values["JWT_SECRET"] != "jwt" || values["DB_PORT"] != "5433" {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+func TestVaultProvider_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	provider := NewVaultProvider(VaultConfig{Addr: server.URL, Token: "bad", Path: "secret/data/app"}, server.Client())
+	if _, err := provider.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("expected the vault error, got %v", err)
+	}
+}
+
+func TestAWSProvider_GetSecretValue(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		if r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" || body["SecretId"] != "prod/go-ecommerce" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/20260301/eu-west-1/secretsmanager/aws4_request") {
+			t.Errorf("unexpected authorization %s", r.Header.Get("Authorization"))
+		}
+		if r.Header.Get("X-Amz-Security-Token") != "session" {
+			t.Error("expected the session token")
+		}
+		json.NewEncoder(w).Encode(map[string]string{"SecretString": `{"WEBHOOK_SECRET":"hook"}`})
+	}))
+	defer server.Close()
+
+	provider := NewAWSProvider(AWSConfig{
+		Region:          "eu-west-1",
+		SecretID:        "prod/go-ecommerce",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		SessionToken:    "session",
+		Endpoint:        server.URL,
+	}, server.Client()).(*awsProvider)
+	provider.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	values, err := provider.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if values["WEBHOOK_SECRET"] != "hook" {
+		t.Errorf("unexpected values %v", values)
+	}
+}
+
+// The example request of the AWS Signature Version 4 documentation
+func TestSignV4_DocumentationExample(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	signV4(req, nil, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("unexpected authorization\n got: %s\nwant: %s", got, want)
+	}
+}
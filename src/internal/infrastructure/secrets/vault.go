@@ -0,0 +1,95 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultConfig locates a secret of a Vault KV secrets engine
+type VaultConfig struct {
+	Addr      string // e.g. https://vault.internal:8200
+	Token     string
+	Namespace string // Vault Enterprise namespace, none when empty
+	// Path is the API path of the secret below /v1, e.g. secret/data/go-ecommerce
+	// for a KV version 2 engine mounted at secret
+	Path string
+}
+
+type vaultProvider struct {
+	cfg    VaultConfig
+	client *http.Client
+}
+
+func NewVaultProvider(cfg VaultConfig, client *http.Client) Provider {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &vaultProvider{cfg: cfg, client: client}
+}
+
+func (p *vaultProvider) Name() string {
+	return "vault"
+}
+
+func (p *vaultProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	url := strings.TrimRight(p.cfg.Addr, "/") + "/v1/" + strings.TrimLeft(p.cfg.Path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", p.cfg.Token)
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("vault returned %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	// KV version 2 nests the values in data.data, version 1 has them in data
+	var secret struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return nil, fmt.Errorf("decoding vault secret: %w", err)
+	}
+	data := secret.Data
+	if nested, ok := secret.Data["data"]; ok {
+		data = nil
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return nil, fmt.Errorf("decoding vault secret: %w", err)
+		}
+	}
+
+	return stringValues(data)
+}
+
+// stringValues decodes the settings of a secret. Strings are kept as they are,
+// numbers and booleans in their JSON form.
+func stringValues(data map[string]json.RawMessage) (map[string]string, error) {
+	values := make(map[string]string, len(data))
+	for key, raw := range data {
+		var value string
+		if err := json.Unmarshal(raw, &value); err == nil {
+			values[key] = value
+			continue
+		}
+		if len(raw) > 0 && (raw[0] == '{' || raw[0] == '[') {
+			return nil, fmt.Errorf("secret value %s must be a string", key)
+		}
+		values[key] = string(raw)
+	}
+	return values, nil
+}
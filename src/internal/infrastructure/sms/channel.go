@@ -0,0 +1,44 @@
+package sms
+
+import (
+	"context"
+	"slices"
+)
+
+// Events sent by text message. Each deployment picks the ones it sends with
+// SMS_EVENTS; they are urgent enough to reach a phone, the rest go by email.
+const (
+	EventOrderShipped      = "order.shipped"      // The customer's order left the warehouse
+	EventPhoneVerification = "phone.verification" // Code proving the customer owns the phone
+)
+
+// Events lists every event that can be sent by text message
+var Events = []string{EventOrderShipped, EventPhoneVerification}
+
+// Channel sends the text messages of the events the deployment enabled
+type Channel struct {
+	sender Sender
+	events []string
+}
+
+// NewChannel sends the events listed through sender
+func NewChannel(sender Sender, events []string) *Channel {
+	return &Channel{sender: sender, events: events}
+}
+
+// Enabled tells whether the event is sent by text message
+func (c *Channel) Enabled(event string) bool {
+	return c != nil && slices.Contains(c.events, event)
+}
+
+// Send sends the message of the event, unless the event is disabled. It
+// returns whether the message went out.
+func (c *Channel) Send(ctx context.Context, event string, msg Message) (bool, error) {
+	if !c.Enabled(event) {
+		return false, nil
+	}
+	if err := c.sender.Send(ctx, msg); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,104 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Message is a text message to one phone number
+type Message struct {
+	To   string
+	Body string
+}
+
+//go:generate go run ../../tools/mockgen -interface Sender -out ../../testing/mocks -name SMSSender
+
+// Sender delivers text messages through an SMS provider
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// twilioBaseURL is the API of Twilio, providers with a Twilio compatible
+// API are reached through their own base URL
+const twilioBaseURL = "https://api.twilio.com"
+
+type twilioSender struct {
+	baseURL    string
+	accountSID string
+	authToken  string
+	from       string
+	client     *http.Client
+}
+
+// NewTwilioSender sends text messages through the Messages API of Twilio, or
+// of a provider with a Twilio compatible API at baseURL, from the number or
+// sender ID from. An empty baseURL is Twilio's.
+func NewTwilioSender(baseURL, accountSID, authToken, from string) Sender {
+	if baseURL == "" {
+		baseURL = twilioBaseURL
+	}
+	return &twilioSender{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		accountSID: accountSID,
+		authToken:  authToken,
+		from:       from,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type twilioError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (s *twilioSender) Send(ctx context.Context, msg Message) error {
+	form := url.Values{"To": {msg.To}, "From": {s.from}, "Body": {msg.Body}}
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(s.accountSID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(s.accountSID, s.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var apiErr twilioError
+		if json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("SMS provider responded with status %d: %s (code %d)", resp.StatusCode, apiErr.Message, apiErr.Code)
+		}
+		return fmt.Errorf("SMS provider responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+type logSender struct {
+	logger *log.Logger
+}
+
+// NewLogSender writes text messages to the application log. It is the
+// default until an SMS provider is configured.
+func NewLogSender(logger *log.Logger) Sender {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return &logSender{logger: logger}
+}
+
+func (s *logSender) Send(ctx context.Context, msg Message) error {
+	s.logger.Printf("[sms] to=%s body=%q", msg.To, msg.Body)
+	return nil
+}
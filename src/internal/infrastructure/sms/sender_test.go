@@ -0,0 +1,90 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTwilioSender_Send(t *testing.T) {
+	var gotPath, gotUser, gotPassword string
+	var gotForm map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotUser, gotPassword, _ = r.BasicAuth()
+		r.ParseForm()
+		gotForm = map[string]string{"To": r.PostForm.Get("To"), "From": r.PostForm.Get("From"), "Body": r.PostForm.Get("Body")}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"sid": "SM123", "status": "queued"}`))
+	}))
+	defer server.Close()
+
+	sender := NewTwilioSender(server.URL+"/", "AC123", "token", "+15005550006")
+	if err := sender.Send(context.Background(), Message{To: "+5511987654321", Body: "Your order has shipped"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotPath != "/2010-04-01/Accounts/AC123/Messages.json" {
+		t.Errorf("path = %q", gotPath)
+	}
+	if gotUser != "AC123" || gotPassword != "token" {
+		t.Errorf("basic auth = %q:%q, want the account SID and auth token", gotUser, gotPassword)
+	}
+	if gotForm["To"] != "+5511987654321" || gotForm["From"] != "+15005550006" || gotForm["Body"] != "Your order has shipped" {
+		t.Errorf("unexpected form %v", gotForm)
+	}
+}
+
+func TestTwilioSender_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"code": 21211, "message": "The 'To' number is not a valid phone number."}`))
+	}))
+	defer server.Close()
+
+	err := NewTwilioSender(server.URL, "AC123", "token", "+15005550006").Send(context.Background(), Message{To: "123", Body: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "21211") {
+		t.Errorf("expected the provider's error, got %v", err)
+	}
+}
+
+type failingSender struct{ sent int }
+
+func (s *failingSender) Send(ctx context.Context, msg Message) error {
+	s.sent++
+	return errors.New("provider down")
+}
+
+func TestChannel_Send(t *testing.T) {
+	var buf bytes.Buffer
+	channel := NewChannel(NewLogSender(log.New(&buf, "", 0)), []string{EventOrderShipped})
+
+	sent, err := channel.Send(context.Background(), EventOrderShipped, Message{To: "+5511987654321", Body: "shipped"})
+	if err != nil || !sent {
+		t.Fatalf("expected the message sent, got %v, %v", sent, err)
+	}
+	if !strings.Contains(buf.String(), "to=+5511987654321") {
+		t.Errorf("expected the message logged, got %q", buf.String())
+	}
+
+	buf.Reset()
+	sent, err = channel.Send(context.Background(), EventPhoneVerification, Message{To: "+5511987654321", Body: "123456"})
+	if err != nil || sent || buf.Len() != 0 {
+		t.Errorf("expected a disabled event to be dropped, got %v, %v, %q", sent, err, buf.String())
+	}
+
+	failing := &failingSender{}
+	if sent, err := NewChannel(failing, Events).Send(context.Background(), EventOrderShipped, Message{}); err == nil || sent {
+		t.Errorf("expected the sender's error, got %v, %v", sent, err)
+	}
+
+	var none *Channel
+	if none.Enabled(EventOrderShipped) {
+		t.Error("expected a nil channel to send nothing")
+	}
+}
@@ -0,0 +1,164 @@
+// Package loadtest runs load scenarios against the HTTP API and checks their
+// latency against a budget.
+//
+// A scenario sends a fixed number of requests from a number of concurrent
+// workers and fails when any response has an unexpected status or the 95th
+// percentile latency is over its budget. The same scenarios run against a
+// live deployment with cmd/loadtest and, in CI, against the API on the
+// in-memory repositories, where a regression in the handlers or use cases
+// shows up without the noise of a database.
+package loadtest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Scenario is a batch of requests with the latency they should stay within
+type Scenario struct {
+	Name        string
+	Requests    int
+	Concurrency int
+	Budget      time.Duration // 95th percentile latency
+	Status      int           // Expected status of every response
+	// Request builds the i-th request of the scenario, 0 <= i < Requests
+	Request func(i int) (*http.Request, error)
+}
+
+// Result is how a scenario went. Failures counts the requests that could not
+// be sent or got another status than expected; FirstError describes the first.
+type Result struct {
+	Scenario   string
+	Requests   int
+	Failures   int
+	FirstError string
+	Elapsed    time.Duration
+	P50        time.Duration
+	P95        time.Duration
+	P99        time.Duration
+	Max        time.Duration
+	Budget     time.Duration
+}
+
+// Err reports why the scenario failed, nil when it passed
+func (r Result) Err() error {
+	if r.Failures > 0 {
+		return fmt.Errorf("%s: %d of %d requests failed, first: %s", r.Scenario, r.Failures, r.Requests, r.FirstError)
+	}
+	if r.P95 > r.Budget {
+		return fmt.Errorf("%s: p95 latency %s is over the %s budget", r.Scenario, r.P95, r.Budget)
+	}
+	return nil
+}
+
+// Throughput is the requests per second the scenario sustained
+func (r Result) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Requests) / r.Elapsed.Seconds()
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%-14s %6d req %8.1f req/s  p50 %-10s p95 %-10s p99 %-10s max %-10s budget %-10s failures %d",
+		r.Scenario, r.Requests, r.Throughput(), r.P50, r.P95, r.P99, r.Max, r.Budget, r.Failures)
+}
+
+// Run sends the requests of the scenario with the client and measures them.
+// Cancelling the context stops the workers; requests not sent count as failures.
+func Run(ctx context.Context, client *http.Client, s Scenario) Result {
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	latencies := make([]time.Duration, s.Requests)
+	errs := make([]error, s.Requests)
+	next := make(chan int)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				latencies[i], errs[i] = send(ctx, client, s, i)
+			}
+		}()
+	}
+	for i := 0; i < s.Requests; i++ {
+		if ctx.Err() != nil {
+			errs[i] = ctx.Err()
+			continue
+		}
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	result := Result{Scenario: s.Name, Requests: s.Requests, Elapsed: time.Since(start), Budget: s.Budget}
+	measured := make([]time.Duration, 0, s.Requests)
+	for i, err := range errs {
+		if err != nil {
+			if result.Failures == 0 {
+				result.FirstError = err.Error()
+			}
+			result.Failures++
+			continue
+		}
+		measured = append(measured, latencies[i])
+	}
+
+	sort.Slice(measured, func(i, j int) bool { return measured[i] < measured[j] })
+	result.P50 = percentile(measured, 50)
+	result.P95 = percentile(measured, 95)
+	result.P99 = percentile(measured, 99)
+	if len(measured) > 0 {
+		result.Max = measured[len(measured)-1]
+	}
+	return result
+}
+
+// send makes the i-th request and times it until the body is read
+func send(ctx context.Context, client *http.Client, s Scenario, i int) (time.Duration, error) {
+	req, err := s.Request(i)
+	if err != nil {
+		return 0, err
+	}
+
+	start := time.Now()
+	res, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return 0, err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	elapsed := time.Since(start)
+	if err != nil {
+		return 0, err
+	}
+
+	if res.StatusCode != s.Status {
+		return 0, fmt.Errorf("%s %s: got %d, want %d: %.200s", req.Method, req.URL.Path, res.StatusCode, s.Status, body)
+	}
+	return elapsed, nil
+}
+
+// percentile is the nearest-rank percentile of sorted latencies, 0 when there
+// are none
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
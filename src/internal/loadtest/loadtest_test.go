@@ -0,0 +1,88 @@
+package loadtest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := make([]time.Duration, 100)
+	for i := range sorted {
+		sorted[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(sorted, 50))
+	assert.Equal(t, 95*time.Millisecond, percentile(sorted, 95))
+	assert.Equal(t, 100*time.Millisecond, percentile(sorted, 100))
+	assert.Equal(t, 7*time.Millisecond, percentile([]time.Duration{7 * time.Millisecond}, 95))
+	assert.Zero(t, percentile(nil, 95))
+}
+
+func TestRun(t *testing.T) {
+	var served atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served.Add(1)
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	scenario := func(budget time.Duration, failEvery int) Scenario {
+		return Scenario{
+			Name: "test", Requests: 40, Concurrency: 4, Budget: budget, Status: http.StatusOK,
+			Request: func(i int) (*http.Request, error) {
+				url := server.URL
+				if failEvery > 0 && i%failEvery == 0 {
+					url += "?fail=1"
+				}
+				return http.NewRequest(http.MethodGet, url, nil)
+			},
+		}
+	}
+
+	t.Run("passes within budget", func(t *testing.T) {
+		served.Store(0)
+		result := Run(context.Background(), server.Client(), scenario(time.Minute, 0))
+
+		require.NoError(t, result.Err())
+		assert.Equal(t, int64(40), served.Load())
+		assert.Equal(t, 40, result.Requests)
+		assert.Positive(t, result.P95)
+		assert.LessOrEqual(t, result.P50, result.P95)
+		assert.LessOrEqual(t, result.P95, result.Max)
+	})
+
+	t.Run("fails on unexpected status", func(t *testing.T) {
+		result := Run(context.Background(), server.Client(), scenario(time.Minute, 10))
+
+		assert.Equal(t, 4, result.Failures)
+		assert.Contains(t, result.FirstError, "got 500, want 200")
+		assert.ErrorContains(t, result.Err(), "4 of 40 requests failed")
+	})
+
+	t.Run("fails over budget", func(t *testing.T) {
+		result := Run(context.Background(), server.Client(), scenario(time.Nanosecond, 0))
+
+		assert.Zero(t, result.Failures)
+		assert.ErrorContains(t, result.Err(), "over the 1ns budget")
+	})
+
+	t.Run("stops when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		result := Run(ctx, server.Client(), scenario(time.Minute, 0))
+
+		assert.Equal(t, 40, result.Failures)
+		assert.Error(t, result.Err())
+	})
+}
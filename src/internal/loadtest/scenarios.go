@@ -0,0 +1,187 @@
+package loadtest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/adapter/http/dto"
+)
+
+// searchTerms are the words the fixture products are named after, so every
+// search has results
+var searchTerms = []string{"laptop", "phone", "headphones", "keyboard", "monitor"}
+
+// Fixture is the catalog and the customers the scenarios run on
+type Fixture struct {
+	BaseURL   string
+	Products  []string // IDs
+	Customers []string // Tokens
+}
+
+// Prepare creates the products with the admin token and signs up the
+// customers. The products have enough stock for every order of a checkout
+// burst; Cleanup deletes them.
+func Prepare(ctx context.Context, client *http.Client, baseURL, adminToken string, products, customers int) (*Fixture, error) {
+	f := &Fixture{BaseURL: strings.TrimRight(baseURL, "/")}
+	// Tells this run's accounts and products from those of earlier runs
+	run := time.Now().UTC().Format("20060102150405.000")
+
+	for i := 0; i < products; i++ {
+		term := searchTerms[i%len(searchTerms)]
+		request := dto.ProductRequest{
+			Name:        fmt.Sprintf("Load test %s %d %s", term, i, run),
+			Description: "Created by the load test, archived when it ends",
+			Price:       float64(10 + i%90),
+			Quantity:    1_000_000,
+		}
+		var product dto.ProductResponse
+		if err := call(ctx, client, http.MethodPost, f.BaseURL+"/api/products", adminToken, request, &product, http.StatusCreated); err != nil {
+			return f, err
+		}
+		f.Products = append(f.Products, product.ID)
+	}
+
+	for i := 0; i < customers; i++ {
+		email := fmt.Sprintf("loadtest+%s.%d@example.com", strings.ReplaceAll(run, ".", ""), i)
+		request := dto.RegisterRequest{Email: email, Password: "loadtest-" + run, Name: "Load Test"}
+		if err := call(ctx, client, http.MethodPost, f.BaseURL+"/api/auth/register", "", request, nil, http.StatusCreated); err != nil {
+			return f, err
+		}
+		token, err := Login(ctx, client, f.BaseURL, email, request.Password)
+		if err != nil {
+			return f, err
+		}
+		f.Customers = append(f.Customers, token)
+	}
+	return f, nil
+}
+
+// Cleanup archives the products of the fixture, which can't be deleted once
+// ordered. The customers and their orders stay.
+func (f *Fixture) Cleanup(ctx context.Context, client *http.Client, adminToken string) error {
+	for _, id := range f.Products {
+		if err := call(ctx, client, http.MethodPost, f.BaseURL+"/api/products/"+id+"/archive", adminToken, nil, nil, http.StatusOK); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Browse lists pages of the catalog and opens products, one listing for
+// every three product pages, as shoppers do
+func Browse(f *Fixture, requests, concurrency int, budget time.Duration) Scenario {
+	return Scenario{
+		Name:        "browse",
+		Requests:    requests,
+		Concurrency: concurrency,
+		Budget:      budget,
+		Status:      http.StatusOK,
+		Request: func(i int) (*http.Request, error) {
+			if i%4 == 0 {
+				page := (i/4)%((len(f.Products)+19)/20) + 1
+				return http.NewRequest(http.MethodGet, fmt.Sprintf("%s/api/products?page=%d&page_size=20", f.BaseURL, page), nil)
+			}
+			return http.NewRequest(http.MethodGet, f.BaseURL+"/api/products/"+f.Products[i%len(f.Products)], nil)
+		},
+	}
+}
+
+// Search runs the public product search for words the catalog has
+func Search(f *Fixture, requests, concurrency int, budget time.Duration) Scenario {
+	return Scenario{
+		Name:        "search",
+		Requests:    requests,
+		Concurrency: concurrency,
+		Budget:      budget,
+		Status:      http.StatusOK,
+		Request: func(i int) (*http.Request, error) {
+			return http.NewRequest(http.MethodGet, f.BaseURL+"/api/search?q="+searchTerms[i%len(searchTerms)], nil)
+		},
+	}
+}
+
+// CheckoutBurst places orders from every customer at once, each for one unit
+// of a product, so the orders contend for the same stock rows
+func CheckoutBurst(f *Fixture, requests, concurrency int, budget time.Duration) Scenario {
+	return Scenario{
+		Name:        "checkout-burst",
+		Requests:    requests,
+		Concurrency: concurrency,
+		Budget:      budget,
+		Status:      http.StatusCreated,
+		Request: func(i int) (*http.Request, error) {
+			payload, err := json.Marshal(dto.CreateOrderRequest{
+				CustomerID: i%len(f.Customers) + 1,
+				Products:   []dto.OrderItemRequest{{ProductID: f.Products[i%len(f.Products)], Quantity: 1}},
+			})
+			if err != nil {
+				return nil, err
+			}
+			req, err := http.NewRequest(http.MethodPost, f.BaseURL+"/api/orders", bytes.NewReader(payload))
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer "+f.Customers[i%len(f.Customers)])
+			return req, nil
+		},
+	}
+}
+
+// Login returns the token of the account
+func Login(ctx context.Context, client *http.Client, baseURL, email, password string) (string, error) {
+	var auth dto.AuthResponse
+	err := call(ctx, client, http.MethodPost, strings.TrimRight(baseURL, "/")+"/api/auth/login", "",
+		dto.LoginRequest{Email: email, Password: password}, &auth, http.StatusOK)
+	return auth.Token, err
+}
+
+// call sends body as JSON with the token, expects the status and decodes the
+// data of the response into out when given
+func call(ctx context.Context, client *http.Client, method, url, token string, body, out interface{}, status int) error {
+	var payload io.Reader
+	if body != nil {
+		raw, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(raw)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != status {
+		return fmt.Errorf("%s %s: got %d, want %d: %.200s", method, req.URL.Path, res.StatusCode, status, raw)
+	}
+	if out == nil {
+		return nil
+	}
+
+	var envelope dto.Response[json.RawMessage]
+	if err := json.Unmarshal(raw, &envelope); err != nil {
+		return fmt.Errorf("%s %s: %w", method, req.URL.Path, err)
+	}
+	return json.Unmarshal(envelope.Data, out)
+}
@@ -4,12 +4,35 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+	"github.com/marcofilho/go-ecommerce/src/usecase/textmessage"
+	"github.com/marcofilho/go-ecommerce/src/usecase/translation"
 )
 
 // MockServices implements the Services interface for testing
 type MockServices struct {
-	AuditService audit.AuditService
+	AuditService      audit.AuditService
+	Blocklist         blocklist.Checker
+	AccessGate        accesscode.Gate
+	FraudChecker      fraud.Checker
+	EventPublisher    events.Publisher
+	WebhookDispatcher events.Dispatcher
+	StockRecorder     stock.Recorder
+	PriceBook         pricehistory.Book
+	PriceResolver     pricing.Resolver
+	OrderWorkflow     *entity.OrderWorkflow
+	LoyaltyProgram    loyalty.Program
+	Localizer         translation.Localizer
+	TextMessenger     textmessage.Messenger
 }
 
 func (m *MockServices) GetAuditService() audit.AuditService {
@@ -19,9 +42,238 @@ func (m *MockServices) GetAuditService() audit.AuditService {
 	return &MockAuditService{}
 }
 
+func (m *MockServices) GetBlocklist() blocklist.Checker {
+	if m.Blocklist != nil {
+		return m.Blocklist
+	}
+	return &MockBlocklist{}
+}
+
+func (m *MockServices) GetAccessGate() accesscode.Gate {
+	if m.AccessGate != nil {
+		return m.AccessGate
+	}
+	return &MockAccessGate{}
+}
+
+func (m *MockServices) GetFraudChecker() fraud.Checker {
+	if m.FraudChecker != nil {
+		return m.FraudChecker
+	}
+	return fraud.NewAllowAllChecker()
+}
+
+func (m *MockServices) GetEventPublisher() events.Publisher {
+	if m.EventPublisher != nil {
+		return m.EventPublisher
+	}
+	return events.NewNoopPublisher()
+}
+
+func (m *MockServices) GetWebhookDispatcher() events.Dispatcher {
+	if m.WebhookDispatcher != nil {
+		return m.WebhookDispatcher
+	}
+	return events.NewNoopDispatcher()
+}
+
+func (m *MockServices) GetStockRecorder() stock.Recorder {
+	if m.StockRecorder != nil {
+		return m.StockRecorder
+	}
+	return &MockStockRecorder{}
+}
+
+func (m *MockServices) GetPriceBook() pricehistory.Book {
+	if m.PriceBook != nil {
+		return m.PriceBook
+	}
+	return &MockPriceBook{}
+}
+
+func (m *MockServices) GetPriceResolver() pricing.Resolver {
+	if m.PriceResolver != nil {
+		return m.PriceResolver
+	}
+	return &MockPriceResolver{}
+}
+
+func (m *MockServices) GetOrderWorkflow() *entity.OrderWorkflow {
+	if m.OrderWorkflow != nil {
+		return m.OrderWorkflow
+	}
+	return entity.NewSimpleOrderWorkflow()
+}
+
+func (m *MockServices) GetLoyaltyProgram() loyalty.Program {
+	if m.LoyaltyProgram != nil {
+		return m.LoyaltyProgram
+	}
+	return &MockLoyaltyProgram{}
+}
+
+func (m *MockServices) GetLocalizer() translation.Localizer {
+	if m.Localizer != nil {
+		return m.Localizer
+	}
+	return &MockLocalizer{}
+}
+
+func (m *MockServices) GetTextMessenger() textmessage.Messenger {
+	if m.TextMessenger != nil {
+		return m.TextMessenger
+	}
+	return &MockTextMessenger{}
+}
+
 // MockAuditService is a mock implementation of audit.AuditService
 type MockAuditService struct{}
 
 func (m *MockAuditService) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
 	return nil
 }
+
+// MockBlocklist blocks nothing
+type MockBlocklist struct{}
+
+func (m *MockBlocklist) Check(ctx context.Context, email, ip string) error {
+	return nil
+}
+
+func (m *MockBlocklist) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	return nil
+}
+
+// MockAccessGate requires no access codes
+type MockAccessGate struct{}
+
+func (m *MockAccessGate) RequiredForRegistration() bool { return false }
+func (m *MockAccessGate) RequiredForBrowsing() bool     { return false }
+
+func (m *MockAccessGate) Check(ctx context.Context, code string) error {
+	return nil
+}
+
+func (m *MockAccessGate) Redeem(ctx context.Context, code string) error {
+	return nil
+}
+
+// MockStockRecorder keeps recorded stock movements in memory
+type MockStockRecorder struct {
+	Movements []*entity.StockMovement
+}
+
+func (m *MockStockRecorder) Record(ctx context.Context, movement *entity.StockMovement) error {
+	m.Movements = append(m.Movements, movement)
+	return nil
+}
+
+// MockWebhookDispatcher keeps the types of dispatched events in memory
+type MockWebhookDispatcher struct {
+	Events []string
+}
+
+func (m *MockWebhookDispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	m.Events = append(m.Events, eventType)
+	return nil
+}
+
+// MockPriceBook keeps recorded price changes in memory and attaches the
+// scheduled ones to products
+type MockPriceBook struct {
+	Changes []*entity.PriceChange
+}
+
+func (m *MockPriceBook) Record(ctx context.Context, change *entity.PriceChange) error {
+	m.Changes = append(m.Changes, change)
+	return nil
+}
+
+func (m *MockPriceBook) Attach(ctx context.Context, products ...*entity.Product) error {
+	for _, product := range products {
+		if product == nil {
+			continue
+		}
+		product.PriceSchedule = nil
+		for _, change := range m.Changes {
+			if change.Scheduled && change.ProductID == product.ID {
+				product.PriceSchedule = append(product.PriceSchedule, *change)
+			}
+		}
+	}
+	return nil
+}
+
+// MockPriceResolver sells every line at its base price, to retail customers
+type MockPriceResolver struct{}
+
+func (m *MockPriceResolver) CustomerGroup(ctx context.Context, userID *uuid.UUID) (string, error) {
+	return entity.RetailGroup, nil
+}
+
+func (m *MockPriceResolver) UnitPrice(ctx context.Context, group string, product *entity.Product, variant *entity.ProductVariant, quantity int) (float64, error) {
+	return pricing.BasePrice(product, variant)
+}
+
+// MockLoyaltyProgram spends points from Balance, each worth 0.01, and keeps
+// the IDs of settled orders in memory
+type MockLoyaltyProgram struct {
+	Balance int
+	Settled []uuid.UUID
+}
+
+func (m *MockLoyaltyProgram) CanRedeem(ctx context.Context, userID uuid.UUID, points int) error {
+	if points > m.Balance {
+		return entity.ConflictError("Not enough loyalty points")
+	}
+	return nil
+}
+
+func (m *MockLoyaltyProgram) Redeem(ctx context.Context, userID, orderID uuid.UUID, points int, subtotal float64) (float64, error) {
+	if err := m.CanRedeem(ctx, userID, points); err != nil {
+		return 0, err
+	}
+	m.Balance -= points
+	return float64(points) / 100, nil
+}
+
+func (m *MockLoyaltyProgram) Release(ctx context.Context, orderID uuid.UUID) error {
+	return nil
+}
+
+func (m *MockLoyaltyProgram) Settle(ctx context.Context, order *entity.Order) error {
+	m.Settled = append(m.Settled, order.ID)
+	return nil
+}
+
+// MockLocalizer translates products with Translations, products are written in English
+type MockLocalizer struct {
+	Translations []entity.ProductTranslation
+}
+
+func (m *MockLocalizer) Localize(ctx context.Context, preferred []string, products ...*entity.Product) error {
+	for _, product := range products {
+		product.Localize(m.Translations, entity.LocaleFallbacks(preferred), m.DefaultLocale())
+	}
+	return nil
+}
+
+func (m *MockLocalizer) DefaultLocale() string {
+	return "en"
+}
+
+// MockTextMessenger keeps the codes and shipped orders it was asked to text
+type MockTextMessenger struct {
+	Codes   []string
+	Shipped []uuid.UUID
+}
+
+func (m *MockTextMessenger) SendPhoneCode(ctx context.Context, phone, code string) error {
+	m.Codes = append(m.Codes, code)
+	return nil
+}
+
+func (m *MockTextMessenger) OrderShipped(ctx context.Context, order *entity.Order) error {
+	m.Shipped = append(m.Shipped, order.ID)
+	return nil
+}
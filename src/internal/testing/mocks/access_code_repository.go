@@ -0,0 +1,69 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// AccessCodeRepository is a mock of repository.AccessCodeRepository
+type AccessCodeRepository struct {
+	mock.Mock
+}
+
+var _ repository.AccessCodeRepository = (*AccessCodeRepository)(nil)
+
+func (_m *AccessCodeRepository) Create(ctx context.Context, code *entity.AccessCode) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.AccessCode, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AccessCodeRepository) GetByCode(ctx context.Context, code string) (*entity.AccessCode, error) {
+	_ret := _m.Called(ctx, code)
+
+	var _r0 *entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AccessCodeRepository) Update(ctx context.Context, code *entity.AccessCode) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeRepository) Redeem(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeRepository) List(ctx context.Context) ([]*entity.AccessCode, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,38 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// AdminAlertRepository is a mock of repository.AdminAlertRepository
+type AdminAlertRepository struct {
+	mock.Mock
+}
+
+var _ repository.AdminAlertRepository = (*AdminAlertRepository)(nil)
+
+func (_m *AdminAlertRepository) Create(ctx context.Context, alert *entity.AdminAlert) error {
+	_ret := _m.Called(ctx, alert)
+	return _ret.Error(0)
+}
+
+func (_m *AdminAlertRepository) List(ctx context.Context, rule *entity.AlertRule, page int, pageSize int) ([]*entity.AdminAlert, int, error) {
+	_ret := _m.Called(ctx, rule, page, pageSize)
+
+	var _r0 []*entity.AdminAlert
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AdminAlert)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
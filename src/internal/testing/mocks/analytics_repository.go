@@ -0,0 +1,95 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// AnalyticsRepository is a mock of repository.AnalyticsRepository
+type AnalyticsRepository struct {
+	mock.Mock
+}
+
+var _ repository.AnalyticsRepository = (*AnalyticsRepository)(nil)
+
+func (_m *AnalyticsRepository) RevenueByPeriod(ctx context.Context, period entity.AnalyticsPeriod, from time.Time, until time.Time, loc *time.Location) ([]entity.RevenuePoint, error) {
+	_ret := _m.Called(ctx, period, from, until, loc)
+
+	var _r0 []entity.RevenuePoint
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.RevenuePoint)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsRepository) TopProducts(ctx context.Context, from time.Time, until time.Time, limit int) ([]entity.ProductSales, error) {
+	_ret := _m.Called(ctx, from, until, limit)
+
+	var _r0 []entity.ProductSales
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.ProductSales)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsRepository) CountOrdersByStatus(ctx context.Context, from time.Time, until time.Time) ([]entity.OrderStatusCount, error) {
+	_ret := _m.Called(ctx, from, until)
+
+	var _r0 []entity.OrderStatusCount
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.OrderStatusCount)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsRepository) SumRevenue(ctx context.Context, from time.Time, until time.Time) (int, float64, error) {
+	_ret := _m.Called(ctx, from, until)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+
+	var _r1 float64
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(float64)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *AnalyticsRepository) CountNewCustomers(ctx context.Context, from time.Time, until time.Time) (int, error) {
+	_ret := _m.Called(ctx, from, until)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsRepository) SummarizeCustomer(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *entity.CustomerSummary
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CustomerSummary)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsRepository) FavoriteCategories(ctx context.Context, userID uuid.UUID, limit int) ([]entity.CategorySales, error) {
+	_ret := _m.Called(ctx, userID, limit)
+
+	var _r0 []entity.CategorySales
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.CategorySales)
+	}
+	return _r0, _ret.Error(1)
+}
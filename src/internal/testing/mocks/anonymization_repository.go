@@ -0,0 +1,89 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// AnonymizationRepository is a mock of repository.AnonymizationRepository
+type AnonymizationRepository struct {
+	mock.Mock
+}
+
+var _ repository.AnonymizationRepository = (*AnonymizationRepository)(nil)
+
+func (_m *AnonymizationRepository) ListUsers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.User, error) {
+	_ret := _m.Called(ctx, after, limit)
+
+	var _r0 []*entity.User
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.User)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnonymizationRepository) UpdateUsers(ctx context.Context, users []*entity.User) error {
+	_ret := _m.Called(ctx, users)
+	return _ret.Error(0)
+}
+
+func (_m *AnonymizationRepository) ListCustomers(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Customer, error) {
+	_ret := _m.Called(ctx, after, limit)
+
+	var _r0 []*entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnonymizationRepository) UpdateCustomers(ctx context.Context, customers []*entity.Customer) error {
+	_ret := _m.Called(ctx, customers)
+	return _ret.Error(0)
+}
+
+func (_m *AnonymizationRepository) ListBlockedEmails(ctx context.Context, after uuid.UUID, limit int) ([]*entity.BlocklistEntry, error) {
+	_ret := _m.Called(ctx, after, limit)
+
+	var _r0 []*entity.BlocklistEntry
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.BlocklistEntry)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnonymizationRepository) UpdateBlocklistEntries(ctx context.Context, entries []*entity.BlocklistEntry) error {
+	_ret := _m.Called(ctx, entries)
+	return _ret.Error(0)
+}
+
+func (_m *AnonymizationRepository) ListInvoices(ctx context.Context, after uuid.UUID, limit int) ([]*entity.Invoice, error) {
+	_ret := _m.Called(ctx, after, limit)
+
+	var _r0 []*entity.Invoice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Invoice)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnonymizationRepository) UpdateInvoices(ctx context.Context, invoices []*entity.Invoice) error {
+	_ret := _m.Called(ctx, invoices)
+	return _ret.Error(0)
+}
+
+func (_m *AnonymizationRepository) RedactText(ctx context.Context, placeholder string) (int64, error) {
+	_ret := _m.Called(ctx, placeholder)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
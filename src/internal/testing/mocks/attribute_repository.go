@@ -0,0 +1,64 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// AttributeRepository is a mock of repository.AttributeRepository
+type AttributeRepository struct {
+	mock.Mock
+}
+
+var _ repository.AttributeRepository = (*AttributeRepository)(nil)
+
+func (_m *AttributeRepository) CreateDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	_ret := _m.Called(ctx, definition)
+	return _ret.Error(0)
+}
+
+func (_m *AttributeRepository) GetDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.AttributeDefinition
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AttributeDefinition)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeRepository) GetDefinitionByCode(ctx context.Context, code string) (*entity.AttributeDefinition, error) {
+	_ret := _m.Called(ctx, code)
+
+	var _r0 *entity.AttributeDefinition
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AttributeDefinition)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeRepository) ListDefinitions(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.AttributeDefinition
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AttributeDefinition)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeRepository) SetProductValue(ctx context.Context, value *entity.ProductAttribute) error {
+	_ret := _m.Called(ctx, value)
+	return _ret.Error(0)
+}
+
+func (_m *AttributeRepository) DeleteProductValue(ctx context.Context, productID uuid.UUID, attributeID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, attributeID)
+	return _ret.Error(0)
+}
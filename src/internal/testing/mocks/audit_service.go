@@ -0,0 +1,23 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/stretchr/testify/mock"
+)
+
+// AuditService is a mock of audit.AuditService
+type AuditService struct {
+	mock.Mock
+}
+
+var _ audit.AuditService = (*AuditService)(nil)
+
+func (_m *AuditService) LogChange(ctx context.Context, userID *uuid.UUID, action string, resourceType string, resourceID uuid.UUID, before interface{}, after interface{}) error {
+	_ret := _m.Called(ctx, userID, action, resourceType, resourceID, before, after)
+	return _ret.Error(0)
+}
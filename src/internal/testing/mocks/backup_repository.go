@@ -0,0 +1,58 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// BackupRepository is a mock of repository.BackupRepository
+type BackupRepository struct {
+	mock.Mock
+}
+
+var _ repository.BackupRepository = (*BackupRepository)(nil)
+
+func (_m *BackupRepository) SchemaVersion(ctx context.Context) (int64, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *BackupRepository) Tables() []entity.BackupTable {
+	_ret := _m.Called()
+
+	var _r0 []entity.BackupTable
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.BackupTable)
+	}
+	return _r0
+}
+
+func (_m *BackupRepository) ExportTable(ctx context.Context, table string, fn func(row entity.BackupRow) error) error {
+	_ret := _m.Called(ctx, table, fn)
+	return _ret.Error(0)
+}
+
+func (_m *BackupRepository) CountRows(ctx context.Context, table string) (int64, error) {
+	_ret := _m.Called(ctx, table)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *BackupRepository) Restore(ctx context.Context, restore func(insert func(table string, rows []entity.BackupRow) error) error) error {
+	_ret := _m.Called(ctx, restore)
+	return _ret.Error(0)
+}
@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/capture"
+	"github.com/stretchr/testify/mock"
+)
+
+// CaptureGateway is a mock of capture.Gateway
+type CaptureGateway struct {
+	mock.Mock
+}
+
+var _ capture.Gateway = (*CaptureGateway)(nil)
+
+func (_m *CaptureGateway) Capture(ctx context.Context, req capture.Request) (string, error) {
+	_ret := _m.Called(ctx, req)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// CatalogFeedRepository is a mock of repository.CatalogFeedRepository
+type CatalogFeedRepository struct {
+	mock.Mock
+}
+
+var _ repository.CatalogFeedRepository = (*CatalogFeedRepository)(nil)
+
+func (_m *CatalogFeedRepository) ScanProducts(ctx context.Context, at time.Time, batchSize int, fn func(products []*entity.Product) error) error {
+	_ret := _m.Called(ctx, at, batchSize, fn)
+	return _ret.Error(0)
+}
+
+func (_m *CatalogFeedRepository) Save(ctx context.Context, feed *entity.CatalogFeed) error {
+	_ret := _m.Called(ctx, feed)
+	return _ret.Error(0)
+}
+
+func (_m *CatalogFeedRepository) Get(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error) {
+	_ret := _m.Called(ctx, format)
+
+	var _r0 *entity.CatalogFeed
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CatalogFeed)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,59 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// CatalogReportRepository is a mock of repository.CatalogReportRepository
+type CatalogReportRepository struct {
+	mock.Mock
+}
+
+var _ repository.CatalogReportRepository = (*CatalogReportRepository)(nil)
+
+func (_m *CatalogReportRepository) ScanProducts(ctx context.Context, batchSize int, fn func(products []*entity.Product) error) error {
+	_ret := _m.Called(ctx, batchSize, fn)
+	return _ret.Error(0)
+}
+
+func (_m *CatalogReportRepository) ListOrphanVariants(ctx context.Context) ([]*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CatalogReportRepository) CountProductsByCategory(ctx context.Context) (map[uuid.UUID]int, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 map[uuid.UUID]int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(map[uuid.UUID]int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CatalogReportRepository) Create(ctx context.Context, report *entity.CatalogReport) error {
+	_ret := _m.Called(ctx, report)
+	return _ret.Error(0)
+}
+
+func (_m *CatalogReportRepository) GetLatest(ctx context.Context) (*entity.CatalogReport, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 *entity.CatalogReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CatalogReport)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,165 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// CategoryRepository is a mock of repository.CategoryRepository
+type CategoryRepository struct {
+	mock.Mock
+}
+
+var _ repository.CategoryRepository = (*CategoryRepository)(nil)
+
+func (_m *CategoryRepository) Create(ctx context.Context, category *entity.Category) error {
+	_ret := _m.Called(ctx, category)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetAll(ctx context.Context, page int, pageSize int) ([]*entity.Category, int, error) {
+	_ret := _m.Called(ctx, page, pageSize)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *CategoryRepository) Update(ctx context.Context, category *entity.Category) error {
+	_ret := _m.Called(ctx, category)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
+	_ret := _m.Called(ctx, name)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	_ret := _m.Called(ctx, slug)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) SlugExists(ctx context.Context, slug string) (bool, error) {
+	_ret := _m.Called(ctx, slug)
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetByMergedSlug(ctx context.Context, slug string) (*entity.Category, error) {
+	_ret := _m.Called(ctx, slug)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) Merge(ctx context.Context, sourceID uuid.UUID, targetID uuid.UUID) (int, error) {
+	_ret := _m.Called(ctx, sourceID, targetID)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetTree(ctx context.Context) ([]*entity.Category, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetDescendants(ctx context.Context, id uuid.UUID) ([]*entity.Category, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) AssignCategoryToProduct(ctx context.Context, productID uuid.UUID, categoryID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, categoryID)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryRepository) RemoveCategoryFromProduct(ctx context.Context, productID uuid.UUID, categoryID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, categoryID)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryRepository) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryRepository) GetProducts(ctx context.Context, categoryID uuid.UUID, at time.Time, sort repository.ProductSort, page int, pageSize int) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, categoryID, at, sort, page, pageSize)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,54 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// CustomerProfileRepository is a mock of repository.CustomerProfileRepository
+type CustomerProfileRepository struct {
+	mock.Mock
+}
+
+var _ repository.CustomerProfileRepository = (*CustomerProfileRepository)(nil)
+
+func (_m *CustomerProfileRepository) AddNote(ctx context.Context, note *entity.CustomerNote) error {
+	_ret := _m.Called(ctx, note)
+	return _ret.Error(0)
+}
+
+func (_m *CustomerProfileRepository) ListNotes(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerNote, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 []*entity.CustomerNote
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.CustomerNote)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerProfileRepository) AddRiskEvent(ctx context.Context, event *entity.CustomerRiskEvent) error {
+	_ret := _m.Called(ctx, event)
+	return _ret.Error(0)
+}
+
+func (_m *CustomerProfileRepository) ListRiskEvents(ctx context.Context, userID uuid.UUID) ([]*entity.CustomerRiskEvent, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 []*entity.CustomerRiskEvent
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.CustomerRiskEvent)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerProfileRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, userID)
+	return _ret.Error(0)
+}
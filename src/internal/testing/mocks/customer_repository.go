@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// CustomerRepository is a mock of repository.CustomerRepository
+type CustomerRepository struct {
+	mock.Mock
+}
+
+var _ repository.CustomerRepository = (*CustomerRepository)(nil)
+
+func (_m *CustomerRepository) Get(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerRepository) Save(ctx context.Context, customer *entity.Customer) error {
+	_ret := _m.Called(ctx, customer)
+	return _ret.Error(0)
+}
+
+func (_m *CustomerRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, userID)
+	return _ret.Error(0)
+}
@@ -0,0 +1,54 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// DeadLetterRepository is a mock of repository.DeadLetterRepository
+type DeadLetterRepository struct {
+	mock.Mock
+}
+
+var _ repository.DeadLetterRepository = (*DeadLetterRepository)(nil)
+
+func (_m *DeadLetterRepository) Record(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	_ret := _m.Called(ctx, webhook)
+	return _ret.Error(0)
+}
+
+func (_m *DeadLetterRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DeadLetterWebhook, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.DeadLetterWebhook
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DeadLetterWebhook)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DeadLetterRepository) Update(ctx context.Context, webhook *entity.DeadLetterWebhook) error {
+	_ret := _m.Called(ctx, webhook)
+	return _ret.Error(0)
+}
+
+func (_m *DeadLetterRepository) List(ctx context.Context, filters repository.DeadLetterFilters, page int, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []entity.DeadLetterWebhook
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.DeadLetterWebhook)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
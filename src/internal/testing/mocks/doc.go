@@ -0,0 +1,8 @@
+// Package mocks has testify mocks of the repository interfaces and the
+// infrastructure ports (audit, events, notifications, payment providers,
+// refunds, secrets, job locks), for tests of any package.
+//
+// The mocks are generated by internal/tools/mockgen from the go:generate
+// directive above each interface; regenerate them with `make mocks` after
+// changing one.
+package mocks
@@ -0,0 +1,69 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// DraftOrderRepository is a mock of repository.DraftOrderRepository
+type DraftOrderRepository struct {
+	mock.Mock
+}
+
+var _ repository.DraftOrderRepository = (*DraftOrderRepository)(nil)
+
+func (_m *DraftOrderRepository) Create(ctx context.Context, draft *entity.DraftOrder) error {
+	_ret := _m.Called(ctx, draft)
+	return _ret.Error(0)
+}
+
+func (_m *DraftOrderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderRepository) GetByToken(ctx context.Context, token string) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, token)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderRepository) Update(ctx context.Context, draft *entity.DraftOrder) error {
+	_ret := _m.Called(ctx, draft)
+	return _ret.Error(0)
+}
+
+func (_m *DraftOrderRepository) UpdateStatus(ctx context.Context, draft *entity.DraftOrder, from entity.DraftOrderStatus) error {
+	_ret := _m.Called(ctx, draft, from)
+	return _ret.Error(0)
+}
+
+func (_m *DraftOrderRepository) List(ctx context.Context, filters repository.DraftOrderFilters, page int, pageSize int) ([]*entity.DraftOrder, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []*entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.DraftOrder)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,63 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// EmailTemplateRepository is a mock of repository.EmailTemplateRepository
+type EmailTemplateRepository struct {
+	mock.Mock
+}
+
+var _ repository.EmailTemplateRepository = (*EmailTemplateRepository)(nil)
+
+func (_m *EmailTemplateRepository) CreateVersion(ctx context.Context, template *entity.EmailTemplate) error {
+	_ret := _m.Called(ctx, template)
+	return _ret.Error(0)
+}
+
+func (_m *EmailTemplateRepository) GetLatest(ctx context.Context, key string) (*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, key)
+
+	var _r0 *entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateRepository) GetVersion(ctx context.Context, key string, version int) (*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, key, version)
+
+	var _r0 *entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateRepository) ListLatest(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateRepository) ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, key)
+
+	var _r0 []*entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
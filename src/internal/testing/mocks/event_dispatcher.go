@@ -0,0 +1,22 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// EventDispatcher is a mock of events.Dispatcher
+type EventDispatcher struct {
+	mock.Mock
+}
+
+var _ events.Dispatcher = (*EventDispatcher)(nil)
+
+func (_m *EventDispatcher) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	_ret := _m.Called(ctx, eventType, data)
+	return _ret.Error(0)
+}
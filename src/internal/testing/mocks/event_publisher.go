@@ -0,0 +1,23 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// EventPublisher is a mock of events.Publisher
+type EventPublisher struct {
+	mock.Mock
+}
+
+var _ events.Publisher = (*EventPublisher)(nil)
+
+func (_m *EventPublisher) PublishProductEvent(ctx context.Context, eventType string, product *entity.Product) error {
+	_ret := _m.Called(ctx, eventType, product)
+	return _ret.Error(0)
+}
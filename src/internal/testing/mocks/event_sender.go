@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/stretchr/testify/mock"
+)
+
+// EventSender is a mock of events.Sender
+type EventSender struct {
+	mock.Mock
+}
+
+var _ events.Sender = (*EventSender)(nil)
+
+func (_m *EventSender) Send(ctx context.Context, url string, secret string, eventID string, eventType string, body []byte) (int, error) {
+	_ret := _m.Called(ctx, url, secret, eventID, eventType, body)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,35 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/feed"
+	"github.com/stretchr/testify/mock"
+)
+
+// FeedRenderer is a mock of feed.Renderer
+type FeedRenderer struct {
+	mock.Mock
+}
+
+var _ feed.Renderer = (*FeedRenderer)(nil)
+
+func (_m *FeedRenderer) Render(doc *feed.Document) ([]byte, error) {
+	_ret := _m.Called(doc)
+
+	var _r0 []byte
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]byte)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *FeedRenderer) ContentType() string {
+	_ret := _m.Called()
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
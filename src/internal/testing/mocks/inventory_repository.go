@@ -0,0 +1,33 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// InventoryRepository is a mock of repository.InventoryRepository
+type InventoryRepository struct {
+	mock.Mock
+}
+
+var _ repository.InventoryRepository = (*InventoryRepository)(nil)
+
+func (_m *InventoryRepository) SyncStock(ctx context.Context, sync *entity.InventorySync) ([]entity.StockLevelResult, []*entity.StockMovement, error) {
+	_ret := _m.Called(ctx, sync)
+
+	var _r0 []entity.StockLevelResult
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.StockLevelResult)
+	}
+
+	var _r1 []*entity.StockMovement
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.([]*entity.StockMovement)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
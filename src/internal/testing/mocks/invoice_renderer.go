@@ -0,0 +1,25 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	"github.com/stretchr/testify/mock"
+)
+
+// InvoiceRenderer is a mock of invoice.Renderer
+type InvoiceRenderer struct {
+	mock.Mock
+}
+
+var _ invoice.Renderer = (*InvoiceRenderer)(nil)
+
+func (_m *InvoiceRenderer) Render(doc *invoice.Document) ([]byte, error) {
+	_ret := _m.Called(doc)
+
+	var _r0 []byte
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]byte)
+	}
+	return _r0, _ret.Error(1)
+}
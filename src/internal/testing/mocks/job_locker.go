@@ -0,0 +1,32 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/jobs"
+	"github.com/stretchr/testify/mock"
+)
+
+// JobLocker is a mock of jobs.Locker
+type JobLocker struct {
+	mock.Mock
+}
+
+var _ jobs.Locker = (*JobLocker)(nil)
+
+func (_m *JobLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	_ret := _m.Called(ctx, name)
+
+	var _r0 func()
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(func())
+	}
+
+	var _r1 bool
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(bool)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,38 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// LogArchiveRepository is a mock of repository.LogArchiveRepository
+type LogArchiveRepository struct {
+	mock.Mock
+}
+
+var _ repository.LogArchiveRepository = (*LogArchiveRepository)(nil)
+
+func (_m *LogArchiveRepository) ArchiveAuditLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	_ret := _m.Called(ctx, cutoff, batchSize)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LogArchiveRepository) ArchiveWebhookLogs(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	_ret := _m.Called(ctx, cutoff, batchSize)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
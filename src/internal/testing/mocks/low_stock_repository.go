@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// LowStockRepository is a mock of repository.LowStockRepository
+type LowStockRepository struct {
+	mock.Mock
+}
+
+var _ repository.LowStockRepository = (*LowStockRepository)(nil)
+
+func (_m *LowStockRepository) ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error) {
+	_ret := _m.Called(ctx, threshold)
+
+	var _r0 []entity.LowStockItem
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.LowStockItem)
+	}
+	return _r0, _ret.Error(1)
+}
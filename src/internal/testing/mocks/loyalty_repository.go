@@ -0,0 +1,64 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// LoyaltyRepository is a mock of repository.LoyaltyRepository
+type LoyaltyRepository struct {
+	mock.Mock
+}
+
+var _ repository.LoyaltyRepository = (*LoyaltyRepository)(nil)
+
+func (_m *LoyaltyRepository) Create(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	_ret := _m.Called(ctx, transaction)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyRepository) Redeem(ctx context.Context, transaction *entity.LoyaltyTransaction) error {
+	_ret := _m.Called(ctx, transaction)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyRepository) Balance(ctx context.Context, userID uuid.UUID) (int, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LoyaltyRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.LoyaltyTransaction, error) {
+	_ret := _m.Called(ctx, orderID)
+
+	var _r0 []*entity.LoyaltyTransaction
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.LoyaltyTransaction)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LoyaltyRepository) ListByUser(ctx context.Context, userID uuid.UUID, page int, pageSize int) ([]*entity.LoyaltyTransaction, int, error) {
+	_ret := _m.Called(ctx, userID, page, pageSize)
+
+	var _r0 []*entity.LoyaltyTransaction
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.LoyaltyTransaction)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,60 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// OrderArchiveRepository is a mock of repository.OrderArchiveRepository
+type OrderArchiveRepository struct {
+	mock.Mock
+}
+
+var _ repository.OrderArchiveRepository = (*OrderArchiveRepository)(nil)
+
+func (_m *OrderArchiveRepository) ArchiveBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	_ret := _m.Called(ctx, cutoff, batchSize)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderArchiveRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderArchiveRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 []*entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderArchiveRepository) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
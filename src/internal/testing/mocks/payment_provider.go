@@ -0,0 +1,43 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"net/http"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/paymentprovider"
+	"github.com/stretchr/testify/mock"
+)
+
+// PaymentProvider is a mock of paymentprovider.Provider
+type PaymentProvider struct {
+	mock.Mock
+}
+
+var _ paymentprovider.Provider = (*PaymentProvider)(nil)
+
+func (_m *PaymentProvider) Name() string {
+	_ret := _m.Called()
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
+
+func (_m *PaymentProvider) Verify(r *http.Request, body []byte) error {
+	_ret := _m.Called(r, body)
+	return _ret.Error(0)
+}
+
+func (_m *PaymentProvider) Parse(r *http.Request, body []byte) (*entity.PaymentWebhookRequest, error) {
+	_ret := _m.Called(r, body)
+
+	var _r0 *entity.PaymentWebhookRequest
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.PaymentWebhookRequest)
+	}
+	return _r0, _ret.Error(1)
+}
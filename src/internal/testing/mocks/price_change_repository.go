@@ -0,0 +1,65 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceChangeRepository is a mock of repository.PriceChangeRepository
+type PriceChangeRepository struct {
+	mock.Mock
+}
+
+var _ repository.PriceChangeRepository = (*PriceChangeRepository)(nil)
+
+func (_m *PriceChangeRepository) Create(ctx context.Context, change *entity.PriceChange) error {
+	_ret := _m.Called(ctx, change)
+	return _ret.Error(0)
+}
+
+func (_m *PriceChangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.PriceChange, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.PriceChange)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PriceChangeRepository) Update(ctx context.Context, change *entity.PriceChange) error {
+	_ret := _m.Called(ctx, change)
+	return _ret.Error(0)
+}
+
+func (_m *PriceChangeRepository) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page int, pageSize int) ([]*entity.PriceChange, int, error) {
+	_ret := _m.Called(ctx, productID, filters, page, pageSize)
+
+	var _r0 []*entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceChange)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PriceChangeRepository) GetSchedules(ctx context.Context, productIDs []uuid.UUID, at time.Time) ([]*entity.PriceChange, error) {
+	_ret := _m.Called(ctx, productIDs, at)
+
+	var _r0 []*entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceChange)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,34 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceTierRepository is a mock of repository.PriceTierRepository
+type PriceTierRepository struct {
+	mock.Mock
+}
+
+var _ repository.PriceTierRepository = (*PriceTierRepository)(nil)
+
+func (_m *PriceTierRepository) GetByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.PriceTier
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceTier)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PriceTierRepository) ReplaceForProduct(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) error {
+	_ret := _m.Called(ctx, productID, tiers)
+	return _ret.Error(0)
+}
@@ -0,0 +1,64 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductOptionRepository is a mock of repository.ProductOptionRepository
+type ProductOptionRepository struct {
+	mock.Mock
+}
+
+var _ repository.ProductOptionRepository = (*ProductOptionRepository)(nil)
+
+func (_m *ProductOptionRepository) Create(ctx context.Context, option *entity.ProductOption) error {
+	_ret := _m.Called(ctx, option)
+	return _ret.Error(0)
+}
+
+func (_m *ProductOptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductOption, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.ProductOption
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductOption)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductOptionRepository) ListByProductID(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.ProductOption
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductOption)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductOptionRepository) AddValue(ctx context.Context, value *entity.ProductOptionValue) error {
+	_ret := _m.Called(ctx, value)
+	return _ret.Error(0)
+}
+
+func (_m *ProductOptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *ProductOptionRepository) InUse(ctx context.Context, id uuid.UUID) (bool, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductRepository is a mock of repository.ProductRepository
+type ProductRepository struct {
+	mock.Mock
+}
+
+var _ repository.ProductRepository = (*ProductRepository)(nil)
+
+func (_m *ProductRepository) Create(ctx context.Context, product *entity.Product) error {
+	_ret := _m.Called(ctx, product)
+	return _ret.Error(0)
+}
+
+func (_m *ProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	_ret := _m.Called(ctx, ids)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductRepository) GetAll(ctx context.Context, page int, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, page, pageSize, filters)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductRepository) ListProductsSummary(ctx context.Context, page int, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, page, pageSize, filters)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductRepository) Update(ctx context.Context, product *entity.Product) error {
+	_ret := _m.Called(ctx, product)
+	return _ret.Error(0)
+}
+
+func (_m *ProductRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
@@ -0,0 +1,59 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductReviewRepository is a mock of repository.ProductReviewRepository
+type ProductReviewRepository struct {
+	mock.Mock
+}
+
+var _ repository.ProductReviewRepository = (*ProductReviewRepository)(nil)
+
+func (_m *ProductReviewRepository) Save(ctx context.Context, review *entity.ProductReview) error {
+	_ret := _m.Called(ctx, review)
+	return _ret.Error(0)
+}
+
+func (_m *ProductReviewRepository) Get(ctx context.Context, productID uuid.UUID, userID uuid.UUID) (*entity.ProductReview, error) {
+	_ret := _m.Called(ctx, productID, userID)
+
+	var _r0 *entity.ProductReview
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductReview)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductReviewRepository) ListByProduct(ctx context.Context, productID uuid.UUID, page int, pageSize int) ([]*entity.ProductReview, int, error) {
+	_ret := _m.Called(ctx, productID, page, pageSize)
+
+	var _r0 []*entity.ProductReview
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductReview)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductReviewRepository) Delete(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, userID)
+	return _ret.Error(0)
+}
+
+func (_m *ProductReviewRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, userID)
+	return _ret.Error(0)
+}
@@ -0,0 +1,59 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductTranslationRepository is a mock of repository.ProductTranslationRepository
+type ProductTranslationRepository struct {
+	mock.Mock
+}
+
+var _ repository.ProductTranslationRepository = (*ProductTranslationRepository)(nil)
+
+func (_m *ProductTranslationRepository) Save(ctx context.Context, translation *entity.ProductTranslation) error {
+	_ret := _m.Called(ctx, translation)
+	return _ret.Error(0)
+}
+
+func (_m *ProductTranslationRepository) Get(ctx context.Context, productID uuid.UUID, locale string) (*entity.ProductTranslation, error) {
+	_ret := _m.Called(ctx, productID, locale)
+
+	var _r0 *entity.ProductTranslation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductTranslation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductTranslationRepository) ListByProduct(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.ProductTranslation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductTranslation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductTranslationRepository) ListForProducts(ctx context.Context, productIDs []uuid.UUID, locales []string) ([]entity.ProductTranslation, error) {
+	_ret := _m.Called(ctx, productIDs, locales)
+
+	var _r0 []entity.ProductTranslation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.ProductTranslation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductTranslationRepository) Delete(ctx context.Context, productID uuid.UUID, locale string) error {
+	_ret := _m.Called(ctx, productID, locale)
+	return _ret.Error(0)
+}
@@ -0,0 +1,119 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductVariantRepository is a mock of repository.ProductVariantRepository
+type ProductVariantRepository struct {
+	mock.Mock
+}
+
+var _ repository.ProductVariantRepository = (*ProductVariantRepository)(nil)
+
+func (_m *ProductVariantRepository) Create(ctx context.Context, productVariant *entity.ProductVariant) error {
+	_ret := _m.Called(ctx, productVariant)
+	return _ret.Error(0)
+}
+
+func (_m *ProductVariantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, ids)
+
+	var _r0 []*entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantRepository) GetAll(ctx context.Context, page int, pageSize int) ([]*entity.ProductVariant, int, error) {
+	_ret := _m.Called(ctx, page, pageSize)
+
+	var _r0 []*entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductVariant)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductVariantRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID, page int, pageSize int) ([]*entity.ProductVariant, int, error) {
+	_ret := _m.Called(ctx, productID, page, pageSize)
+
+	var _r0 []*entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductVariant)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductVariantRepository) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, sku)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantRepository) GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, productID, combinationKey)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantRepository) Update(ctx context.Context, productVariant *entity.ProductVariant) error {
+	_ret := _m.Called(ctx, productVariant)
+	return _ret.Error(0)
+}
+
+func (_m *ProductVariantRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *ProductVariantRepository) TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error) {
+	_ret := _m.Called(ctx, transfer)
+
+	var _r0 *entity.StockMovement
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.StockMovement)
+	}
+
+	var _r1 *entity.StockMovement
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(*entity.StockMovement)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// RecallRepository is a mock of repository.RecallRepository
+type RecallRepository struct {
+	mock.Mock
+}
+
+var _ repository.RecallRepository = (*RecallRepository)(nil)
+
+func (_m *RecallRepository) FindAffectedOrders(ctx context.Context, criteria repository.RecallCriteria) ([]entity.AffectedOrder, error) {
+	_ret := _m.Called(ctx, criteria)
+
+	var _r0 []entity.AffectedOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.AffectedOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallRepository) Create(ctx context.Context, recall *entity.Recall) error {
+	_ret := _m.Called(ctx, recall)
+	return _ret.Error(0)
+}
+
+func (_m *RecallRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Recall)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallRepository) List(ctx context.Context, page int, pageSize int) ([]*entity.Recall, int, error) {
+	_ret := _m.Called(ctx, page, pageSize)
+
+	var _r0 []*entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Recall)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *RecallRepository) GetNotice(ctx context.Context, id uuid.UUID) (*entity.RecallNotice, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.RecallNotice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RecallNotice)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallRepository) UpdateNotice(ctx context.Context, notice *entity.RecallNotice) error {
+	_ret := _m.Called(ctx, notice)
+	return _ret.Error(0)
+}
+
+func (_m *RecallRepository) ListNoticesByUser(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 []*entity.RecallNotice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.RecallNotice)
+	}
+	return _r0, _ret.Error(1)
+}
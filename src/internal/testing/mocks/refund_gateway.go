@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	"github.com/stretchr/testify/mock"
+)
+
+// RefundGateway is a mock of refund.Gateway
+type RefundGateway struct {
+	mock.Mock
+}
+
+var _ refund.Gateway = (*RefundGateway)(nil)
+
+func (_m *RefundGateway) Refund(ctx context.Context, req refund.Request) (string, error) {
+	_ret := _m.Called(ctx, req)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
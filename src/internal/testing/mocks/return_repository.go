@@ -0,0 +1,64 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReturnRepository is a mock of repository.ReturnRepository
+type ReturnRepository struct {
+	mock.Mock
+}
+
+var _ repository.ReturnRepository = (*ReturnRepository)(nil)
+
+func (_m *ReturnRepository) Create(ctx context.Context, ret *entity.Return) error {
+	_ret := _m.Called(ctx, ret)
+	return _ret.Error(0)
+}
+
+func (_m *ReturnRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Return, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnRepository) Update(ctx context.Context, ret *entity.Return) error {
+	_ret := _m.Called(ctx, ret)
+	return _ret.Error(0)
+}
+
+func (_m *ReturnRepository) List(ctx context.Context, filters repository.ReturnFilters, page int, pageSize int) ([]*entity.Return, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []*entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Return)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ReturnRepository) ListByOrder(ctx context.Context, orderID uuid.UUID) ([]*entity.Return, error) {
+	_ret := _m.Called(ctx, orderID)
+
+	var _r0 []*entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
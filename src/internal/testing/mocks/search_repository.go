@@ -0,0 +1,65 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// SearchRepository is a mock of repository.SearchRepository
+type SearchRepository struct {
+	mock.Mock
+}
+
+var _ repository.SearchRepository = (*SearchRepository)(nil)
+
+func (_m *SearchRepository) FindCandidates(ctx context.Context, terms []string, at time.Time, limit int) ([]*entity.Product, error) {
+	_ret := _m.Called(ctx, terms, at, limit)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *SearchRepository) CreateRule(ctx context.Context, rule *entity.RankingRule) error {
+	_ret := _m.Called(ctx, rule)
+	return _ret.Error(0)
+}
+
+func (_m *SearchRepository) GetRule(ctx context.Context, id uuid.UUID) (*entity.RankingRule, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.RankingRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RankingRule)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *SearchRepository) UpdateRule(ctx context.Context, rule *entity.RankingRule) error {
+	_ret := _m.Called(ctx, rule)
+	return _ret.Error(0)
+}
+
+func (_m *SearchRepository) DeleteRule(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *SearchRepository) ListRules(ctx context.Context) ([]*entity.RankingRule, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.RankingRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.RankingRule)
+	}
+	return _r0, _ret.Error(1)
+}
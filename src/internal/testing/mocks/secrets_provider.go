@@ -0,0 +1,37 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/secrets"
+	"github.com/stretchr/testify/mock"
+)
+
+// SecretsProvider is a mock of secrets.Provider
+type SecretsProvider struct {
+	mock.Mock
+}
+
+var _ secrets.Provider = (*SecretsProvider)(nil)
+
+func (_m *SecretsProvider) Name() string {
+	_ret := _m.Called()
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
+
+func (_m *SecretsProvider) Fetch(ctx context.Context) (map[string]string, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 map[string]string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(map[string]string)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,22 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/sms"
+	"github.com/stretchr/testify/mock"
+)
+
+// SMSSender is a mock of sms.Sender
+type SMSSender struct {
+	mock.Mock
+}
+
+var _ sms.Sender = (*SMSSender)(nil)
+
+func (_m *SMSSender) Send(ctx context.Context, msg sms.Message) error {
+	_ret := _m.Called(ctx, msg)
+	return _ret.Error(0)
+}
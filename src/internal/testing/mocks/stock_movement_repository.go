@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// StockMovementRepository is a mock of repository.StockMovementRepository
+type StockMovementRepository struct {
+	mock.Mock
+}
+
+var _ repository.StockMovementRepository = (*StockMovementRepository)(nil)
+
+func (_m *StockMovementRepository) Create(ctx context.Context, movement *entity.StockMovement) error {
+	_ret := _m.Called(ctx, movement)
+	return _ret.Error(0)
+}
+
+func (_m *StockMovementRepository) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page int, pageSize int) ([]*entity.StockMovement, int, error) {
+	_ret := _m.Called(ctx, productID, filters, page, pageSize)
+
+	var _r0 []*entity.StockMovement
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.StockMovement)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,38 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// StoreSettingRepository is a mock of repository.StoreSettingRepository
+type StoreSettingRepository struct {
+	mock.Mock
+}
+
+var _ repository.StoreSettingRepository = (*StoreSettingRepository)(nil)
+
+func (_m *StoreSettingRepository) List(ctx context.Context) ([]*entity.StoreSetting, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.StoreSetting
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.StoreSetting)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *StoreSettingRepository) Save(ctx context.Context, settings []*entity.StoreSetting) error {
+	_ret := _m.Called(ctx, settings)
+	return _ret.Error(0)
+}
+
+func (_m *StoreSettingRepository) Delete(ctx context.Context, keys []string) error {
+	_ret := _m.Called(ctx, keys)
+	return _ret.Error(0)
+}
@@ -0,0 +1,59 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/stretchr/testify/mock"
+)
+
+// TokenProvider is a mock of auth.TokenProvider
+type TokenProvider struct {
+	mock.Mock
+}
+
+var _ auth.TokenProvider = (*TokenProvider)(nil)
+
+func (_m *TokenProvider) GenerateToken(user *entity.User) (string, error) {
+	_ret := _m.Called(user)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TokenProvider) GenerateImpersonationToken(user *entity.User, impersonatorID uuid.UUID, lifetime time.Duration) (string, error) {
+	_ret := _m.Called(user, impersonatorID, lifetime)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TokenProvider) ValidateToken(tokenString string) (*auth.Claims, error) {
+	_ret := _m.Called(tokenString)
+
+	var _r0 *auth.Claims
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*auth.Claims)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TokenProvider) Lifetime() time.Duration {
+	_ret := _m.Called()
+
+	var _r0 time.Duration
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(time.Duration)
+	}
+	return _r0
+}
@@ -0,0 +1,45 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// TokenRevocationRepository is a mock of repository.TokenRevocationRepository
+type TokenRevocationRepository struct {
+	mock.Mock
+}
+
+var _ repository.TokenRevocationRepository = (*TokenRevocationRepository)(nil)
+
+func (_m *TokenRevocationRepository) Create(ctx context.Context, revocation *entity.TokenRevocation) error {
+	_ret := _m.Called(ctx, revocation)
+	return _ret.Error(0)
+}
+
+func (_m *TokenRevocationRepository) IsRevoked(ctx context.Context, tokenID string, userID uuid.UUID, issuedAt time.Time) (bool, error) {
+	_ret := _m.Called(ctx, tokenID, userID, issuedAt)
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TokenRevocationRepository) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	_ret := _m.Called(ctx, cutoff)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
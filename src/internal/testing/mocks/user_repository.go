@@ -0,0 +1,64 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// UserRepository is a mock of repository.UserRepository
+type UserRepository struct {
+	mock.Mock
+}
+
+var _ repository.UserRepository = (*UserRepository)(nil)
+
+func (_m *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	_ret := _m.Called(ctx, user)
+	return _ret.Error(0)
+}
+
+func (_m *UserRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.User
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.User)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *UserRepository) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	_ret := _m.Called(ctx, email)
+
+	var _r0 *entity.User
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.User)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *UserRepository) ListByRole(ctx context.Context, role entity.Role) ([]*entity.User, error) {
+	_ret := _m.Called(ctx, role)
+
+	var _r0 []*entity.User
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.User)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	_ret := _m.Called(ctx, user)
+	return _ret.Error(0)
+}
+
+func (_m *UserRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
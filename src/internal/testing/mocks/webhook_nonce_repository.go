@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookNonceRepository is a mock of repository.WebhookNonceRepository
+type WebhookNonceRepository struct {
+	mock.Mock
+}
+
+var _ repository.WebhookNonceRepository = (*WebhookNonceRepository)(nil)
+
+func (_m *WebhookNonceRepository) Claim(ctx context.Context, nonce *entity.WebhookNonce, now time.Time) (bool, error) {
+	_ret := _m.Called(ctx, nonce, now)
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookNonceRepository) DeleteExpired(ctx context.Context, cutoff time.Time) (int64, error) {
+	_ret := _m.Called(ctx, cutoff)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,80 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookRepository is a mock of repository.WebhookRepository
+type WebhookRepository struct {
+	mock.Mock
+}
+
+var _ repository.WebhookRepository = (*WebhookRepository)(nil)
+
+func (_m *WebhookRepository) Create(ctx context.Context, log *entity.WebhookLog) error {
+	_ret := _m.Called(ctx, log)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookRepository) Update(ctx context.Context, log *entity.WebhookLog) error {
+	_ret := _m.Called(ctx, log)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookLog, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookLog)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookRepository) List(ctx context.Context, filters repository.WebhookLogFilters, page int, pageSize int) ([]entity.WebhookLog, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *WebhookRepository) GetByOrderID(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page int, pageSize int) ([]entity.WebhookLog, int, error) {
+	_ret := _m.Called(ctx, orderID, filters, page, pageSize)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *WebhookRepository) ListDueRetries(ctx context.Context, now time.Time, limit int) ([]entity.WebhookLog, error) {
+	_ret := _m.Called(ctx, now, limit)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,110 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookSubscriptionRepository is a mock of repository.WebhookSubscriptionRepository
+type WebhookSubscriptionRepository struct {
+	mock.Mock
+}
+
+var _ repository.WebhookSubscriptionRepository = (*WebhookSubscriptionRepository)(nil)
+
+func (_m *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	_ret := _m.Called(ctx, subscription)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookSubscriptionRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookSubscriptionRepository) Update(ctx context.Context, subscription *entity.WebhookSubscription) error {
+	_ret := _m.Called(ctx, subscription)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookSubscriptionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookSubscriptionRepository) List(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookSubscriptionRepository) ListActive(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookSubscriptionRepository) CreateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	_ret := _m.Called(ctx, delivery)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookSubscriptionRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entity.WebhookDelivery, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.WebhookDelivery
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookDelivery)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookSubscriptionRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	_ret := _m.Called(ctx, delivery)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookSubscriptionRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page int, pageSize int) ([]*entity.WebhookDelivery, int, error) {
+	_ret := _m.Called(ctx, subscriptionID, filters, page, pageSize)
+
+	var _r0 []*entity.WebhookDelivery
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookDelivery)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *WebhookSubscriptionRepository) ListDueDeliveries(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	_ret := _m.Called(ctx, now, limit)
+
+	var _r0 []*entity.WebhookDelivery
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookDelivery)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	"github.com/stretchr/testify/mock"
+)
+
+// AccessCodeService is a mock of accesscode.AccessCodeService
+type AccessCodeService struct {
+	mock.Mock
+}
+
+var _ accesscode.AccessCodeService = (*AccessCodeService)(nil)
+
+func (_m *AccessCodeService) RequiredForRegistration() bool {
+	_ret := _m.Called()
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0
+}
+
+func (_m *AccessCodeService) RequiredForBrowsing() bool {
+	_ret := _m.Called()
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0
+}
+
+func (_m *AccessCodeService) Check(ctx context.Context, code string) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeService) Redeem(ctx context.Context, code string) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
+
+func (_m *AccessCodeService) CreateCode(ctx context.Context, code *entity.AccessCode, createdBy uuid.UUID) (*entity.AccessCode, error) {
+	_ret := _m.Called(ctx, code, createdBy)
+
+	var _r0 *entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AccessCodeService) ListCodes(ctx context.Context) ([]*entity.AccessCode, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AccessCodeService) UpdateCode(ctx context.Context, id uuid.UUID, changes accesscode.Changes, updatedBy uuid.UUID) (*entity.AccessCode, error) {
+	_ret := _m.Called(ctx, id, changes, updatedBy)
+
+	var _r0 *entity.AccessCode
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AccessCode)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AccessCodeService) DeleteCode(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	_ret := _m.Called(ctx, id, deletedBy)
+	return _ret.Error(0)
+}
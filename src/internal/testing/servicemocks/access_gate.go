@@ -0,0 +1,47 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	"github.com/stretchr/testify/mock"
+)
+
+// AccessGate is a mock of accesscode.Gate
+type AccessGate struct {
+	mock.Mock
+}
+
+var _ accesscode.Gate = (*AccessGate)(nil)
+
+func (_m *AccessGate) RequiredForRegistration() bool {
+	_ret := _m.Called()
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0
+}
+
+func (_m *AccessGate) RequiredForBrowsing() bool {
+	_ret := _m.Called()
+
+	var _r0 bool
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(bool)
+	}
+	return _r0
+}
+
+func (_m *AccessGate) Check(ctx context.Context, code string) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
+
+func (_m *AccessGate) Redeem(ctx context.Context, code string) error {
+	_ret := _m.Called(ctx, code)
+	return _ret.Error(0)
+}
@@ -0,0 +1,29 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/stretchr/testify/mock"
+)
+
+// AddressBook is a mock of fraud.AddressBook
+type AddressBook struct {
+	mock.Mock
+}
+
+var _ fraud.AddressBook = (*AddressBook)(nil)
+
+func (_m *AddressBook) GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/allocation"
+	"github.com/stretchr/testify/mock"
+)
+
+// AllocationService is a mock of allocation.AllocationService
+type AllocationService struct {
+	mock.Mock
+}
+
+var _ allocation.AllocationService = (*AllocationService)(nil)
+
+func (_m *AllocationService) PreviewAllocation(ctx context.Context, items []allocation.CartItem) (*entity.AllocationPlan, error) {
+	_ret := _m.Called(ctx, items)
+
+	var _r0 *entity.AllocationPlan
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AllocationPlan)
+	}
+	return _r0, _ret.Error(1)
+}
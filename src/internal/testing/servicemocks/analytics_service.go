@@ -0,0 +1,58 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/analytics"
+	"github.com/stretchr/testify/mock"
+)
+
+// AnalyticsService is a mock of analytics.AnalyticsService
+type AnalyticsService struct {
+	mock.Mock
+}
+
+var _ analytics.AnalyticsService = (*AnalyticsService)(nil)
+
+func (_m *AnalyticsService) Revenue(ctx context.Context, period entity.AnalyticsPeriod, dates analytics.DateRange) (*analytics.RevenueReport, error) {
+	_ret := _m.Called(ctx, period, dates)
+
+	var _r0 *analytics.RevenueReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*analytics.RevenueReport)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsService) TopProducts(ctx context.Context, dates analytics.DateRange, limit int) (*analytics.TopProductsReport, error) {
+	_ret := _m.Called(ctx, dates, limit)
+
+	var _r0 *analytics.TopProductsReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*analytics.TopProductsReport)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsService) OrdersByStatus(ctx context.Context, dates analytics.DateRange) (*analytics.OrderStatusReport, error) {
+	_ret := _m.Called(ctx, dates)
+
+	var _r0 *analytics.OrderStatusReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*analytics.OrderStatusReport)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AnalyticsService) Summary(ctx context.Context, dates analytics.DateRange) (*analytics.SummaryReport, error) {
+	_ret := _m.Called(ctx, dates)
+
+	var _r0 *analytics.SummaryReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*analytics.SummaryReport)
+	}
+	return _r0, _ret.Error(1)
+}
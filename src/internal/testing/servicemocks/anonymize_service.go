@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/anonymize"
+	"github.com/stretchr/testify/mock"
+)
+
+// AnonymizeService is a mock of anonymize.AnonymizeService
+type AnonymizeService struct {
+	mock.Mock
+}
+
+var _ anonymize.AnonymizeService = (*AnonymizeService)(nil)
+
+func (_m *AnonymizeService) Anonymize(ctx context.Context, opts anonymize.Options) (*anonymize.Summary, error) {
+	_ret := _m.Called(ctx, opts)
+
+	var _r0 *anonymize.Summary
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*anonymize.Summary)
+	}
+	return _r0, _ret.Error(1)
+}
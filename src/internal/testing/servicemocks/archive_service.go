@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/archive"
+	"github.com/stretchr/testify/mock"
+)
+
+// ArchiveService is a mock of archive.ArchiveService
+type ArchiveService struct {
+	mock.Mock
+}
+
+var _ archive.ArchiveService = (*ArchiveService)(nil)
+
+func (_m *ArchiveService) ArchiveOrders(ctx context.Context, olderThanYears int) (int, error) {
+	_ret := _m.Called(ctx, olderThanYears)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
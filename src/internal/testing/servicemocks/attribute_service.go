@@ -0,0 +1,54 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/attribute"
+	"github.com/stretchr/testify/mock"
+)
+
+// AttributeService is a mock of attribute.AttributeService
+type AttributeService struct {
+	mock.Mock
+}
+
+var _ attribute.AttributeService = (*AttributeService)(nil)
+
+func (_m *AttributeService) CreateAttribute(ctx context.Context, name string, attributeType entity.AttributeType) (*entity.AttributeDefinition, error) {
+	_ret := _m.Called(ctx, name, attributeType)
+
+	var _r0 *entity.AttributeDefinition
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.AttributeDefinition)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeService) ListAttributes(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.AttributeDefinition
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AttributeDefinition)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeService) SetProductAttribute(ctx context.Context, productID uuid.UUID, attributeID uuid.UUID, value interface{}) (*entity.ProductAttribute, error) {
+	_ret := _m.Called(ctx, productID, attributeID, value)
+
+	var _r0 *entity.ProductAttribute
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductAttribute)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AttributeService) RemoveProductAttribute(ctx context.Context, productID uuid.UUID, attributeID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, attributeID)
+	return _ret.Error(0)
+}
@@ -0,0 +1,85 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	authUseCase "github.com/marcofilho/go-ecommerce/src/usecase/auth"
+	"github.com/stretchr/testify/mock"
+)
+
+// AuthService is a mock of auth.AuthService
+type AuthService struct {
+	mock.Mock
+}
+
+var _ authUseCase.AuthService = (*AuthService)(nil)
+
+func (_m *AuthService) Register(ctx context.Context, req authUseCase.RegisterRequest) (*authUseCase.AuthResponse, error) {
+	_ret := _m.Called(ctx, req)
+
+	var _r0 *authUseCase.AuthResponse
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*authUseCase.AuthResponse)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AuthService) Login(ctx context.Context, req authUseCase.LoginRequest) (*authUseCase.AuthResponse, error) {
+	_ret := _m.Called(ctx, req)
+
+	var _r0 *authUseCase.AuthResponse
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*authUseCase.AuthResponse)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AuthService) ValidateToken(tokenString string) (*auth.Claims, error) {
+	_ret := _m.Called(tokenString)
+
+	var _r0 *auth.Claims
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*auth.Claims)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AuthService) Logout(ctx context.Context, claims *auth.Claims) error {
+	_ret := _m.Called(ctx, claims)
+	return _ret.Error(0)
+}
+
+func (_m *AuthService) RevokeToken(ctx context.Context, tokenString string, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	_ret := _m.Called(ctx, tokenString, reason, revokedBy)
+	return _ret.Error(0)
+}
+
+func (_m *AuthService) RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	_ret := _m.Called(ctx, userID, reason, revokedBy)
+	return _ret.Error(0)
+}
+
+func (_m *AuthService) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error) {
+	_ret := _m.Called(ctx, userID, active, changedBy)
+
+	var _r0 *entity.User
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.User)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *AuthService) Impersonate(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*authUseCase.AuthResponse, error) {
+	_ret := _m.Called(ctx, userID, adminID)
+
+	var _r0 *authUseCase.AuthResponse
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*authUseCase.AuthResponse)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,38 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+	"io"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/backup"
+	"github.com/stretchr/testify/mock"
+)
+
+// BackupService is a mock of backup.BackupService
+type BackupService struct {
+	mock.Mock
+}
+
+var _ backup.BackupService = (*BackupService)(nil)
+
+func (_m *BackupService) Export(ctx context.Context, w io.Writer, opts backup.ExportOptions) (*backup.Manifest, error) {
+	_ret := _m.Called(ctx, w, opts)
+
+	var _r0 *backup.Manifest
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*backup.Manifest)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *BackupService) Import(ctx context.Context, r io.ReaderAt, size int64) (*backup.Manifest, error) {
+	_ret := _m.Called(ctx, r, size)
+
+	var _r0 *backup.Manifest
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*backup.Manifest)
+	}
+	return _r0, _ret.Error(1)
+}
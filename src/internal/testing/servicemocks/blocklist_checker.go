@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	"github.com/stretchr/testify/mock"
+)
+
+// BlocklistChecker is a mock of blocklist.Checker
+type BlocklistChecker struct {
+	mock.Mock
+}
+
+var _ blocklist.Checker = (*BlocklistChecker)(nil)
+
+func (_m *BlocklistChecker) Check(ctx context.Context, email string, ip string) error {
+	_ret := _m.Called(ctx, email, ip)
+	return _ret.Error(0)
+}
+
+func (_m *BlocklistChecker) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	_ret := _m.Called(ctx, userID, ip)
+	return _ret.Error(0)
+}
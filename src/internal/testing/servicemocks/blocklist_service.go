@@ -0,0 +1,54 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	"github.com/stretchr/testify/mock"
+)
+
+// BlocklistService is a mock of blocklist.BlocklistService
+type BlocklistService struct {
+	mock.Mock
+}
+
+var _ blocklist.BlocklistService = (*BlocklistService)(nil)
+
+func (_m *BlocklistService) Check(ctx context.Context, email string, ip string) error {
+	_ret := _m.Called(ctx, email, ip)
+	return _ret.Error(0)
+}
+
+func (_m *BlocklistService) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	_ret := _m.Called(ctx, userID, ip)
+	return _ret.Error(0)
+}
+
+func (_m *BlocklistService) AddEntry(ctx context.Context, entry *entity.BlocklistEntry, createdBy uuid.UUID) (*entity.BlocklistEntry, error) {
+	_ret := _m.Called(ctx, entry, createdBy)
+
+	var _r0 *entity.BlocklistEntry
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.BlocklistEntry)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *BlocklistService) ListEntries(ctx context.Context, kind entity.BlocklistKind) ([]*entity.BlocklistEntry, error) {
+	_ret := _m.Called(ctx, kind)
+
+	var _r0 []*entity.BlocklistEntry
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.BlocklistEntry)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *BlocklistService) RemoveEntry(ctx context.Context, id uuid.UUID, removedBy uuid.UUID) error {
+	_ret := _m.Called(ctx, id, removedBy)
+	return _ret.Error(0)
+}
@@ -0,0 +1,48 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/catalogfeed"
+	"github.com/stretchr/testify/mock"
+)
+
+// CatalogFeedService is a mock of catalogfeed.CatalogFeedService
+type CatalogFeedService struct {
+	mock.Mock
+}
+
+var _ catalogfeed.CatalogFeedService = (*CatalogFeedService)(nil)
+
+func (_m *CatalogFeedService) GenerateFeeds(ctx context.Context) (int, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CatalogFeedService) GetFeed(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error) {
+	_ret := _m.Called(ctx, format)
+
+	var _r0 *entity.CatalogFeed
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CatalogFeed)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CatalogFeedService) ContentType(format entity.FeedFormat) string {
+	_ret := _m.Called(format)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/catalogreport"
+	"github.com/stretchr/testify/mock"
+)
+
+// CatalogReportService is a mock of catalogreport.CatalogReportService
+type CatalogReportService struct {
+	mock.Mock
+}
+
+var _ catalogreport.CatalogReportService = (*CatalogReportService)(nil)
+
+func (_m *CatalogReportService) RunReport(ctx context.Context, trigger entity.CatalogReportTrigger, triggeredBy *uuid.UUID) (*entity.CatalogReport, error) {
+	_ret := _m.Called(ctx, trigger, triggeredBy)
+
+	var _r0 *entity.CatalogReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CatalogReport)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CatalogReportService) GetLatestReport(ctx context.Context) (*entity.CatalogReport, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 *entity.CatalogReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CatalogReport)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,135 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/category"
+	"github.com/stretchr/testify/mock"
+)
+
+// CategoryService is a mock of category.CategoryService
+type CategoryService struct {
+	mock.Mock
+}
+
+var _ category.CategoryService = (*CategoryService)(nil)
+
+func (_m *CategoryService) CreateCategory(ctx context.Context, name string, parentID *uuid.UUID) (*entity.Category, error) {
+	_ret := _m.Called(ctx, name, parentID)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryService) ListCategories(ctx context.Context, page int, pageSize int) ([]*entity.Category, int, error) {
+	_ret := _m.Called(ctx, page, pageSize)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *CategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, name string, parentID *uuid.UUID) (*entity.Category, error) {
+	_ret := _m.Called(ctx, id, name, parentID)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID, force bool) error {
+	_ret := _m.Called(ctx, id, force)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryService) MergeCategory(ctx context.Context, id uuid.UUID, targetID uuid.UUID) (*entity.Category, int, error) {
+	_ret := _m.Called(ctx, id, targetID)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *CategoryService) GetCategoryTree(ctx context.Context) ([]*entity.Category, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CategoryService) ListProductsBySlug(ctx context.Context, slug string, sort repository.ProductSort, page int, pageSize int) (*entity.Category, []*entity.Product, int, error) {
+	_ret := _m.Called(ctx, slug, sort, page, pageSize)
+
+	var _r0 *entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Category)
+	}
+
+	var _r1 []*entity.Product
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.([]*entity.Product)
+	}
+
+	var _r2 int
+	if _v := _ret.Get(2); _v != nil {
+		_r2 = _v.(int)
+	}
+	return _r0, _r1, _r2, _ret.Error(3)
+}
+
+func (_m *CategoryService) AssignCategoryToProduct(ctx context.Context, productID uuid.UUID, categoryID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, categoryID)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryService) RemoveCategoryFromProduct(ctx context.Context, productID uuid.UUID, categoryID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, categoryID)
+	return _ret.Error(0)
+}
+
+func (_m *CategoryService) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.Category
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Category)
+	}
+	return _r0, _ret.Error(1)
+}
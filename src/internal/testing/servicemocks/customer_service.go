@@ -0,0 +1,134 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/customer"
+	"github.com/stretchr/testify/mock"
+)
+
+// CustomerService is a mock of customer.CustomerService
+type CustomerService struct {
+	mock.Mock
+}
+
+var _ customer.CustomerService = (*CustomerService)(nil)
+
+func (_m *CustomerService) GetProfile(ctx context.Context, userID uuid.UUID) (*customer.Profile, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *customer.Profile
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*customer.Profile)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) AddNote(ctx context.Context, userID uuid.UUID, authorID uuid.UUID, body string) (*entity.CustomerNote, error) {
+	_ret := _m.Called(ctx, userID, authorID, body)
+
+	var _r0 *entity.CustomerNote
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CustomerNote)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) RecordRiskEvent(ctx context.Context, userID uuid.UUID, eventType entity.RiskEventType, reference string) (*entity.CustomerRiskEvent, error) {
+	_ret := _m.Called(ctx, userID, eventType, reference)
+
+	var _r0 *entity.CustomerRiskEvent
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CustomerRiskEvent)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) RiskScore(ctx context.Context, userID uuid.UUID) (int, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) SetCustomerGroup(ctx context.Context, userID uuid.UUID, group string) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID, group)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) GetSummary(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *entity.CustomerSummary
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.CustomerSummary)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) UpdateCustomer(ctx context.Context, userID uuid.UUID, update customer.CustomerUpdate) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID, update)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) ExportData(ctx context.Context, userID uuid.UUID) (*customer.DataExport, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 *customer.DataExport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*customer.DataExport)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (int, error) {
+	_ret := _m.Called(ctx, userID, password)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *CustomerService) SendPhoneVerification(ctx context.Context, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, userID)
+	return _ret.Error(0)
+}
+
+func (_m *CustomerService) VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*entity.Customer, error) {
+	_ret := _m.Called(ctx, userID, code)
+
+	var _r0 *entity.Customer
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Customer)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,9 @@
+// Package servicemocks has testify mocks of the use case interfaces: the
+// services handlers call and the ports use cases take from one another, such
+// as fraud checkers and price resolvers.
+//
+// It imports every use case package, so the tests of a use case package can't
+// use it; they mock repositories with package mocks instead. The mocks are
+// generated by internal/tools/mockgen from the go:generate directive above each
+// interface; regenerate them with `make mocks` after changing one.
+package servicemocks
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/draftorder"
+	"github.com/stretchr/testify/mock"
+)
+
+// DraftOrderRenderer is a mock of draftorder.Renderer
+type DraftOrderRenderer struct {
+	mock.Mock
+}
+
+var _ draftorder.Renderer = (*DraftOrderRenderer)(nil)
+
+func (_m *DraftOrderRenderer) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	_ret := _m.Called(ctx, key, data)
+
+	var _r0 *entity.RenderedEmail
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RenderedEmail)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,136 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/draftorder"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// DraftOrderService is a mock of draftorder.DraftOrderService
+type DraftOrderService struct {
+	mock.Mock
+}
+
+var _ draftorder.DraftOrderService = (*DraftOrderService)(nil)
+
+func (_m *DraftOrderService) CreateDraft(ctx context.Context, actorID uuid.UUID, input draftorder.Input) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, actorID, input)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) UpdateDraft(ctx context.Context, actorID uuid.UUID, id uuid.UUID, input draftorder.Input) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, actorID, id, input)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) GetDraft(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) ListDrafts(ctx context.Context, filters repository.DraftOrderFilters, page int, pageSize int) ([]*entity.DraftOrder, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []*entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.DraftOrder)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *DraftOrderService) SendLink(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, actorID, id)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) ConvertDraft(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (*entity.Order, error) {
+	_ret := _m.Called(ctx, actorID, id)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) CancelDraft(ctx context.Context, actorID uuid.UUID, id uuid.UUID) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, actorID, id)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) GetByLink(ctx context.Context, token string) (*entity.DraftOrder, error) {
+	_ret := _m.Called(ctx, token)
+
+	var _r0 *entity.DraftOrder
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DraftOrder)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) AcceptLink(ctx context.Context, token string, clientIP string) (*entity.Order, error) {
+	_ret := _m.Called(ctx, token, clientIP)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) Quote(ctx context.Context, draft *entity.DraftOrder) (*pricing.Totals, error) {
+	_ret := _m.Called(ctx, draft)
+
+	var _r0 *pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*pricing.Totals)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *DraftOrderService) PaymentLink(draft *entity.DraftOrder) string {
+	_ret := _m.Called(draft)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
@@ -0,0 +1,88 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+	"github.com/stretchr/testify/mock"
+)
+
+// EmailTemplateService is a mock of emailtemplate.EmailTemplateService
+type EmailTemplateService struct {
+	mock.Mock
+}
+
+var _ emailtemplate.EmailTemplateService = (*EmailTemplateService)(nil)
+
+func (_m *EmailTemplateService) SaveTemplate(ctx context.Context, template *entity.EmailTemplate) (*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, template)
+
+	var _r0 *entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) GetTemplate(ctx context.Context, key string, version int) (*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, key, version)
+
+	var _r0 *entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) ListTemplates(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	_ret := _m.Called(ctx, key)
+
+	var _r0 []*entity.EmailTemplate
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.EmailTemplate)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) Preview(ctx context.Context, key string, version int, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	_ret := _m.Called(ctx, key, version, data)
+
+	var _r0 *entity.RenderedEmail
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RenderedEmail)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) TestSend(ctx context.Context, key string, version int, recipient string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	_ret := _m.Called(ctx, key, version, recipient, data)
+
+	var _r0 *entity.RenderedEmail
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RenderedEmail)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *EmailTemplateService) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	_ret := _m.Called(ctx, key, data)
+
+	var _r0 *entity.RenderedEmail
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RenderedEmail)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/stretchr/testify/mock"
+)
+
+// FraudChecker is a mock of fraud.Checker
+type FraudChecker struct {
+	mock.Mock
+}
+
+var _ fraud.Checker = (*FraudChecker)(nil)
+
+func (_m *FraudChecker) Screen(ctx context.Context, order *entity.Order) (fraud.Decision, error) {
+	_ret := _m.Called(ctx, order)
+
+	var _r0 fraud.Decision
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(fraud.Decision)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/inventory"
+	"github.com/stretchr/testify/mock"
+)
+
+// InventoryService is a mock of inventory.InventoryService
+type InventoryService struct {
+	mock.Mock
+}
+
+var _ inventory.InventoryService = (*InventoryService)(nil)
+
+func (_m *InventoryService) SyncStock(ctx context.Context, sync *entity.InventorySync) (*entity.InventorySyncReport, error) {
+	_ret := _m.Called(ctx, sync)
+
+	var _r0 *entity.InventorySyncReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.InventorySyncReport)
+	}
+	return _r0, _ret.Error(1)
+}
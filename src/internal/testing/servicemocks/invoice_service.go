@@ -0,0 +1,29 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+	"github.com/stretchr/testify/mock"
+)
+
+// InvoiceService is a mock of invoice.InvoiceService
+type InvoiceService struct {
+	mock.Mock
+}
+
+var _ invoice.InvoiceService = (*InvoiceService)(nil)
+
+func (_m *InvoiceService) GetInvoice(ctx context.Context, orderID uuid.UUID, requesterID uuid.UUID, isAdmin bool) (*entity.Invoice, error) {
+	_ret := _m.Called(ctx, orderID, requesterID, isAdmin)
+
+	var _r0 *entity.Invoice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Invoice)
+	}
+	return _r0, _ret.Error(1)
+}
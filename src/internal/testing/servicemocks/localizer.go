@@ -0,0 +1,33 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/translation"
+	"github.com/stretchr/testify/mock"
+)
+
+// Localizer is a mock of translation.Localizer
+type Localizer struct {
+	mock.Mock
+}
+
+var _ translation.Localizer = (*Localizer)(nil)
+
+func (_m *Localizer) Localize(ctx context.Context, preferred []string, products ...*entity.Product) error {
+	_ret := _m.Called(ctx, preferred, products)
+	return _ret.Error(0)
+}
+
+func (_m *Localizer) DefaultLocale() string {
+	_ret := _m.Called()
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
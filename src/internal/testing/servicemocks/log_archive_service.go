@@ -0,0 +1,37 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/logarchive"
+	"github.com/stretchr/testify/mock"
+)
+
+// LogArchiveService is a mock of logarchive.LogArchiveService
+type LogArchiveService struct {
+	mock.Mock
+}
+
+var _ logarchive.LogArchiveService = (*LogArchiveService)(nil)
+
+func (_m *LogArchiveService) ArchiveAuditLogs(ctx context.Context, olderThanDays int) (int, error) {
+	_ret := _m.Called(ctx, olderThanDays)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LogArchiveService) ArchiveWebhookLogs(ctx context.Context, olderThanDays int) (int, error) {
+	_ret := _m.Called(ctx, olderThanDays)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
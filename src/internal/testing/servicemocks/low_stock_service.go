@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/lowstock"
+	"github.com/stretchr/testify/mock"
+)
+
+// LowStockService is a mock of lowstock.LowStockService
+type LowStockService struct {
+	mock.Mock
+}
+
+var _ lowstock.LowStockService = (*LowStockService)(nil)
+
+func (_m *LowStockService) CheckLowStock(ctx context.Context) ([]entity.LowStockItem, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []entity.LowStockItem
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.LowStockItem)
+	}
+	return _r0, _ret.Error(1)
+}
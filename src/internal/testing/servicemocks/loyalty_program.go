@@ -0,0 +1,44 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+	"github.com/stretchr/testify/mock"
+)
+
+// LoyaltyProgram is a mock of loyalty.Program
+type LoyaltyProgram struct {
+	mock.Mock
+}
+
+var _ loyalty.Program = (*LoyaltyProgram)(nil)
+
+func (_m *LoyaltyProgram) CanRedeem(ctx context.Context, userID uuid.UUID, points int) error {
+	_ret := _m.Called(ctx, userID, points)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyProgram) Redeem(ctx context.Context, userID uuid.UUID, orderID uuid.UUID, points int, subtotal float64) (float64, error) {
+	_ret := _m.Called(ctx, userID, orderID, points, subtotal)
+
+	var _r0 float64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(float64)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LoyaltyProgram) Release(ctx context.Context, orderID uuid.UUID) error {
+	_ret := _m.Called(ctx, orderID)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyProgram) Settle(ctx context.Context, order *entity.Order) error {
+	_ret := _m.Called(ctx, order)
+	return _ret.Error(0)
+}
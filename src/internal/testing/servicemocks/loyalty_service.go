@@ -0,0 +1,54 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+	"github.com/stretchr/testify/mock"
+)
+
+// LoyaltyService is a mock of loyalty.LoyaltyService
+type LoyaltyService struct {
+	mock.Mock
+}
+
+var _ loyalty.LoyaltyService = (*LoyaltyService)(nil)
+
+func (_m *LoyaltyService) CanRedeem(ctx context.Context, userID uuid.UUID, points int) error {
+	_ret := _m.Called(ctx, userID, points)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyService) Redeem(ctx context.Context, userID uuid.UUID, orderID uuid.UUID, points int, subtotal float64) (float64, error) {
+	_ret := _m.Called(ctx, userID, orderID, points, subtotal)
+
+	var _r0 float64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(float64)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *LoyaltyService) Release(ctx context.Context, orderID uuid.UUID) error {
+	_ret := _m.Called(ctx, orderID)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyService) Settle(ctx context.Context, order *entity.Order) error {
+	_ret := _m.Called(ctx, order)
+	return _ret.Error(0)
+}
+
+func (_m *LoyaltyService) GetAccount(ctx context.Context, userID uuid.UUID, page int, pageSize int) (*loyalty.Account, error) {
+	_ret := _m.Called(ctx, userID, page, pageSize)
+
+	var _r0 *loyalty.Account
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*loyalty.Account)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/maintenance"
+	"github.com/stretchr/testify/mock"
+)
+
+// MaintenanceService is a mock of maintenance.MaintenanceService
+type MaintenanceService struct {
+	mock.Mock
+}
+
+var _ maintenance.MaintenanceService = (*MaintenanceService)(nil)
+
+func (_m *MaintenanceService) Status() entity.MaintenanceStatus {
+	_ret := _m.Called()
+
+	var _r0 entity.MaintenanceStatus
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.MaintenanceStatus)
+	}
+	return _r0
+}
+
+func (_m *MaintenanceService) Update(ctx context.Context, update maintenance.Update, updatedBy uuid.UUID) (entity.MaintenanceStatus, error) {
+	_ret := _m.Called(ctx, update, updatedBy)
+
+	var _r0 entity.MaintenanceStatus
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.MaintenanceStatus)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,38 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/monitoring"
+	"github.com/stretchr/testify/mock"
+)
+
+// MonitoringRule is a mock of monitoring.Rule
+type MonitoringRule struct {
+	mock.Mock
+}
+
+var _ monitoring.Rule = (*MonitoringRule)(nil)
+
+func (_m *MonitoringRule) Name() entity.AlertRule {
+	_ret := _m.Called()
+
+	var _r0 entity.AlertRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.AlertRule)
+	}
+	return _r0
+}
+
+func (_m *MonitoringRule) Evaluate(ctx context.Context, log *entity.AuditLog) (string, error) {
+	_ret := _m.Called(ctx, log)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
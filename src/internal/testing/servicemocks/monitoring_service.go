@@ -0,0 +1,49 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/monitoring"
+	"github.com/stretchr/testify/mock"
+)
+
+// MonitoringService is a mock of monitoring.MonitoringService
+type MonitoringService struct {
+	mock.Mock
+}
+
+var _ monitoring.MonitoringService = (*MonitoringService)(nil)
+
+func (_m *MonitoringService) ListActivity(ctx context.Context, filters repository.AuditLogFilters, page int, pageSize int) ([]*entity.AuditLog, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []*entity.AuditLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AuditLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *MonitoringService) ListAlerts(ctx context.Context, rule *entity.AlertRule, page int, pageSize int) ([]*entity.AdminAlert, int, error) {
+	_ret := _m.Called(ctx, rule, page, pageSize)
+
+	var _r0 []*entity.AdminAlert
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.AdminAlert)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
@@ -0,0 +1,29 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/stretchr/testify/mock"
+)
+
+// OrderCounter is a mock of fraud.OrderCounter
+type OrderCounter struct {
+	mock.Mock
+}
+
+var _ fraud.OrderCounter = (*OrderCounter)(nil)
+
+func (_m *OrderCounter) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	_ret := _m.Called(ctx, userID, since)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
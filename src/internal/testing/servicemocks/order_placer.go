@@ -0,0 +1,41 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/draftorder"
+	"github.com/marcofilho/go-ecommerce/src/usecase/order"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// OrderPlacer is a mock of draftorder.OrderPlacer
+type OrderPlacer struct {
+	mock.Mock
+}
+
+var _ draftorder.OrderPlacer = (*OrderPlacer)(nil)
+
+func (_m *OrderPlacer) CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []order.CreateOrderItem, redeemPoints int) (*entity.Order, error) {
+	_ret := _m.Called(ctx, customerID, userID, clientIP, items, redeemPoints)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderPlacer) QuoteOrder(ctx context.Context, userID *uuid.UUID, items []order.CreateOrderItem) (pricing.Totals, error) {
+	_ret := _m.Called(ctx, userID, items)
+
+	var _r0 pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(pricing.Totals)
+	}
+	return _r0, _ret.Error(1)
+}
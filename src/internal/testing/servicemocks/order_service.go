@@ -0,0 +1,116 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/order"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// OrderService is a mock of order.OrderService
+type OrderService struct {
+	mock.Mock
+}
+
+var _ order.OrderService = (*OrderService)(nil)
+
+func (_m *OrderService) CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []order.CreateOrderItem, redeemPoints int) (*entity.Order, error) {
+	_ret := _m.Called(ctx, customerID, userID, clientIP, items, redeemPoints)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderService) QuoteOrder(ctx context.Context, userID *uuid.UUID, items []order.CreateOrderItem) (pricing.Totals, error) {
+	_ret := _m.Called(ctx, userID, items)
+
+	var _r0 pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(pricing.Totals)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderService) GetOrder(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderService) GetOrderStatuses(ctx context.Context, ids []uuid.UUID, ownerID *uuid.UUID) ([]*entity.Order, []uuid.UUID, error) {
+	_ret := _m.Called(ctx, ids, ownerID)
+
+	var _r0 []*entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Order)
+	}
+
+	var _r1 []uuid.UUID
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.([]uuid.UUID)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *OrderService) ListOrders(ctx context.Context, page int, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
+	_ret := _m.Called(ctx, page, pageSize, status, paymentStatus)
+
+	var _r0 []*entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Order)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *OrderService) SearchOrders(ctx context.Context, search repository.OrderSearch, page int, pageSize int) ([]*entity.Order, int, error) {
+	_ret := _m.Called(ctx, search, page, pageSize)
+
+	var _r0 []*entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Order)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *OrderService) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatus entity.OrderStatus) (*entity.Order, error) {
+	_ret := _m.Called(ctx, id, newStatus)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *OrderService) UpdateOrderStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate) (*entity.BulkOrderStatusReport, error) {
+	_ret := _m.Called(ctx, update)
+
+	var _r0 *entity.BulkOrderStatusReport
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.BulkOrderStatusReport)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,165 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/payment"
+	"github.com/stretchr/testify/mock"
+)
+
+// PaymentService is a mock of payment.PaymentService
+type PaymentService struct {
+	mock.Mock
+}
+
+var _ payment.PaymentService = (*PaymentService)(nil)
+
+func (_m *PaymentService) ProcessWebhook(ctx context.Context, req *entity.PaymentWebhookRequest) error {
+	_ret := _m.Called(ctx, req)
+	return _ret.Error(0)
+}
+
+func (_m *PaymentService) CapturePayment(ctx context.Context, orderID uuid.UUID, userID uuid.UUID) (*entity.Order, error) {
+	_ret := _m.Called(ctx, orderID, userID)
+
+	var _r0 *entity.Order
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Order)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) GetWebhookHistory(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page int, pageSize int) ([]entity.WebhookLog, int, error) {
+	_ret := _m.Called(ctx, orderID, filters, page, pageSize)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PaymentService) GetCustomerPaymentHistory(ctx context.Context, orderID uuid.UUID, userID uuid.UUID, filters repository.WebhookLogFilters, page int, pageSize int) ([]entity.WebhookLog, int, error) {
+	_ret := _m.Called(ctx, orderID, userID, filters, page, pageSize)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PaymentService) RetryFailedWebhooks(ctx context.Context, limit int) (int, error) {
+	_ret := _m.Called(ctx, limit)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) ListWebhooks(ctx context.Context, filters repository.WebhookLogFilters, page int, pageSize int) ([]entity.WebhookLog, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.WebhookLog)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PaymentService) ReplayWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID) (*entity.WebhookLog, error) {
+	_ret := _m.Called(ctx, id, userID)
+
+	var _r0 *entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookLog)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) ResolveWebhook(ctx context.Context, id uuid.UUID, userID uuid.UUID, note string) (*entity.WebhookLog, error) {
+	_ret := _m.Called(ctx, id, userID, note)
+
+	var _r0 *entity.WebhookLog
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookLog)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) DeadLetterWebhook(ctx context.Context, source string, payload []byte, reason error) {
+	_m.Called(ctx, source, payload, reason)
+}
+
+func (_m *PaymentService) ListDeadLetters(ctx context.Context, filters repository.DeadLetterFilters, page int, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []entity.DeadLetterWebhook
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]entity.DeadLetterWebhook)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PaymentService) ReprocessDeadLetter(ctx context.Context, id uuid.UUID, userID uuid.UUID, parse payment.WebhookParser) (*entity.DeadLetterWebhook, error) {
+	_ret := _m.Called(ctx, id, userID, parse)
+
+	var _r0 *entity.DeadLetterWebhook
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DeadLetterWebhook)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) DiscardDeadLetter(ctx context.Context, id uuid.UUID, userID uuid.UUID, note string) (*entity.DeadLetterWebhook, error) {
+	_ret := _m.Called(ctx, id, userID, note)
+
+	var _r0 *entity.DeadLetterWebhook
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.DeadLetterWebhook)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PaymentService) UseWebhookNonce(ctx context.Context, nonce string, signedAt time.Time) error {
+	_ret := _m.Called(ctx, nonce, signedAt)
+	return _ret.Error(0)
+}
+
+func (_m *PaymentService) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 int64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int64)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceBook is a mock of pricehistory.Book
+type PriceBook struct {
+	mock.Mock
+}
+
+var _ pricehistory.Book = (*PriceBook)(nil)
+
+func (_m *PriceBook) Record(ctx context.Context, change *entity.PriceChange) error {
+	_ret := _m.Called(ctx, change)
+	return _ret.Error(0)
+}
+
+func (_m *PriceBook) Attach(ctx context.Context, products ...*entity.Product) error {
+	_ret := _m.Called(ctx, products)
+	return _ret.Error(0)
+}
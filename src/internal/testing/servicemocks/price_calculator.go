@@ -0,0 +1,46 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceCalculator is a mock of pricing.Calculator
+type PriceCalculator struct {
+	mock.Mock
+}
+
+var _ pricing.Calculator = (*PriceCalculator)(nil)
+
+func (_m *PriceCalculator) Calculate(in pricing.Input) pricing.Totals {
+	_ret := _m.Called(in)
+
+	var _r0 pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(pricing.Totals)
+	}
+	return _r0
+}
+
+func (_m *PriceCalculator) OrderTotals(order *entity.Order) pricing.Totals {
+	_ret := _m.Called(order)
+
+	var _r0 pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(pricing.Totals)
+	}
+	return _r0
+}
+
+func (_m *PriceCalculator) PriceOrder(order *entity.Order, in pricing.Input) pricing.Totals {
+	_ret := _m.Called(order, in)
+
+	var _r0 pricing.Totals
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(pricing.Totals)
+	}
+	return _r0
+}
@@ -0,0 +1,65 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceHistoryService is a mock of pricehistory.PriceHistoryService
+type PriceHistoryService struct {
+	mock.Mock
+}
+
+var _ pricehistory.PriceHistoryService = (*PriceHistoryService)(nil)
+
+func (_m *PriceHistoryService) Record(ctx context.Context, change *entity.PriceChange) error {
+	_ret := _m.Called(ctx, change)
+	return _ret.Error(0)
+}
+
+func (_m *PriceHistoryService) Attach(ctx context.Context, products ...*entity.Product) error {
+	_ret := _m.Called(ctx, products)
+	return _ret.Error(0)
+}
+
+func (_m *PriceHistoryService) ListHistory(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page int, pageSize int) ([]*entity.PriceChange, int, error) {
+	_ret := _m.Called(ctx, productID, filters, page, pageSize)
+
+	var _r0 []*entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceChange)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *PriceHistoryService) SchedulePrice(ctx context.Context, productID uuid.UUID, input pricehistory.ScheduleInput) (*entity.PriceChange, error) {
+	_ret := _m.Called(ctx, productID, input)
+
+	var _r0 *entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.PriceChange)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PriceHistoryService) CancelScheduledPrice(ctx context.Context, productID uuid.UUID, changeID uuid.UUID) (*entity.PriceChange, error) {
+	_ret := _m.Called(ctx, productID, changeID)
+
+	var _r0 *entity.PriceChange
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.PriceChange)
+	}
+	return _r0, _ret.Error(1)
+}
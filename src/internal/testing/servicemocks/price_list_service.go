@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceListService is a mock of pricing.PriceListService
+type PriceListService struct {
+	mock.Mock
+}
+
+var _ pricing.PriceListService = (*PriceListService)(nil)
+
+func (_m *PriceListService) GetPriceList(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.PriceTier
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceTier)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PriceListService) ReplacePriceList(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) ([]*entity.PriceTier, error) {
+	_ret := _m.Called(ctx, productID, tiers)
+
+	var _r0 []*entity.PriceTier
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.PriceTier)
+	}
+	return _r0, _ret.Error(1)
+}
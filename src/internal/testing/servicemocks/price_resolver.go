@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/stretchr/testify/mock"
+)
+
+// PriceResolver is a mock of pricing.Resolver
+type PriceResolver struct {
+	mock.Mock
+}
+
+var _ pricing.Resolver = (*PriceResolver)(nil)
+
+func (_m *PriceResolver) CustomerGroup(ctx context.Context, userID *uuid.UUID) (string, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *PriceResolver) UnitPrice(ctx context.Context, group string, product *entity.Product, variant *entity.ProductVariant, quantity int) (float64, error) {
+	_ret := _m.Called(ctx, group, product, variant, quantity)
+
+	var _r0 float64
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(float64)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,156 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/product"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductService is a mock of product.ProductService
+type ProductService struct {
+	mock.Mock
+}
+
+var _ product.ProductService = (*ProductService)(nil)
+
+func (_m *ProductService) CreateProduct(ctx context.Context, name string, description string, price float64, quantity int, measure entity.UnitMeasure, status entity.ProductStatus) (*entity.Product, error) {
+	_ret := _m.Called(ctx, name, description, price, quantity, measure, status)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) GetProduct(ctx context.Context, id uuid.UUID, locales []string, publishedOnly bool) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, locales, publishedOnly)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) ListProducts(ctx context.Context, page int, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, include []repository.ProductRelation, locales []string) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, page, pageSize, inStockOnly, status, availableOnly, attributes, sort, include, locales)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductService) ListProductSummaries(ctx context.Context, page int, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, locales []string) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, page, pageSize, inStockOnly, status, availableOnly, attributes, sort, locales)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductService) UpdateProduct(ctx context.Context, id uuid.UUID, name string, description string, price float64, quantity int, measure entity.UnitMeasure, expectedHash string) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, name, description, price, quantity, measure, expectedHash)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) DeleteProduct(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *ProductService) SetHighDemandMode(ctx context.Context, id uuid.UUID, enabled bool) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, enabled)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) SetCost(ctx context.Context, id uuid.UUID, cost *float64) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, cost)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) PublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) UnpublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) ArchiveProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) SetAvailability(ctx context.Context, id uuid.UUID, from *time.Time, until *time.Time) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, from, until)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductService) SetPurchaseLimits(ctx context.Context, id uuid.UUID, maxPerOrder int, maxPerCustomer int) (*entity.Product, error) {
+	_ret := _m.Called(ctx, id, maxPerOrder, maxPerCustomer)
+
+	var _r0 *entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Product)
+	}
+	return _r0, _ret.Error(1)
+}
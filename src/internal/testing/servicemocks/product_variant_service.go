@@ -0,0 +1,119 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	productvariant "github.com/marcofilho/go-ecommerce/src/usecase/product_variant"
+	"github.com/stretchr/testify/mock"
+)
+
+// ProductVariantService is a mock of productvariant.ProductVariantService
+type ProductVariantService struct {
+	mock.Mock
+}
+
+var _ productvariant.ProductVariantService = (*ProductVariantService)(nil)
+
+func (_m *ProductVariantService) CreateProductVariant(ctx context.Context, productID uuid.UUID, input productvariant.VariantInput) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, productID, input)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) GetProductVariant(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) ListProductVariants(ctx context.Context, productID uuid.UUID, page int, pageSize int) ([]*entity.ProductVariant, int, error) {
+	_ret := _m.Called(ctx, productID, page, pageSize)
+
+	var _r0 []*entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductVariant)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductVariantService) UpdateProductVariant(ctx context.Context, id uuid.UUID, input productvariant.VariantInput) (*entity.ProductVariant, error) {
+	_ret := _m.Called(ctx, id, input)
+
+	var _r0 *entity.ProductVariant
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductVariant)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) DeleteProductVariant(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *ProductVariantService) TransferStock(ctx context.Context, variantID uuid.UUID, input productvariant.TransferInput) (*entity.StockMovement, *entity.StockMovement, error) {
+	_ret := _m.Called(ctx, variantID, input)
+
+	var _r0 *entity.StockMovement
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.StockMovement)
+	}
+
+	var _r1 *entity.StockMovement
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(*entity.StockMovement)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ProductVariantService) CreateOption(ctx context.Context, productID uuid.UUID, name string, values []string) (*entity.ProductOption, error) {
+	_ret := _m.Called(ctx, productID, name, values)
+
+	var _r0 *entity.ProductOption
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductOption)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) ListOptions(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.ProductOption
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductOption)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) AddOptionValue(ctx context.Context, productID uuid.UUID, optionID uuid.UUID, value string) (*entity.ProductOption, error) {
+	_ret := _m.Called(ctx, productID, optionID, value)
+
+	var _r0 *entity.ProductOption
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductOption)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ProductVariantService) DeleteOption(ctx context.Context, productID uuid.UUID, optionID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, optionID)
+	return _ret.Error(0)
+}
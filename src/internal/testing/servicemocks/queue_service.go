@@ -0,0 +1,48 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/usecase/queue"
+	"github.com/stretchr/testify/mock"
+)
+
+// QueueService is a mock of queue.QueueService
+type QueueService struct {
+	mock.Mock
+}
+
+var _ queue.QueueService = (*QueueService)(nil)
+
+func (_m *QueueService) JoinQueue(ctx context.Context, productID uuid.UUID, userID uuid.UUID) (*queue.QueueStatus, error) {
+	_ret := _m.Called(ctx, productID, userID)
+
+	var _r0 *queue.QueueStatus
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*queue.QueueStatus)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *QueueService) GetQueueStatus(ctx context.Context, productID uuid.UUID, userID uuid.UUID) (*queue.QueueStatus, error) {
+	_ret := _m.Called(ctx, productID, userID)
+
+	var _r0 *queue.QueueStatus
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*queue.QueueStatus)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *QueueService) AdvanceQueues(ctx context.Context) (int, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
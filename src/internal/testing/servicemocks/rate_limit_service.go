@@ -0,0 +1,36 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/ratelimit"
+	"github.com/stretchr/testify/mock"
+)
+
+// RateLimitService is a mock of ratelimit.RateLimitService
+type RateLimitService struct {
+	mock.Mock
+}
+
+var _ ratelimit.RateLimitService = (*RateLimitService)(nil)
+
+func (_m *RateLimitService) Consume(key string) entity.RateLimitQuota {
+	_ret := _m.Called(key)
+
+	var _r0 entity.RateLimitQuota
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.RateLimitQuota)
+	}
+	return _r0
+}
+
+func (_m *RateLimitService) Quota(key string) entity.RateLimitQuota {
+	_ret := _m.Called(key)
+
+	var _r0 entity.RateLimitQuota
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.RateLimitQuota)
+	}
+	return _r0
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/recall"
+	"github.com/stretchr/testify/mock"
+)
+
+// RecallRenderer is a mock of recall.Renderer
+type RecallRenderer struct {
+	mock.Mock
+}
+
+var _ recall.Renderer = (*RecallRenderer)(nil)
+
+func (_m *RecallRenderer) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	_ret := _m.Called(ctx, key, data)
+
+	var _r0 *entity.RenderedEmail
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RenderedEmail)
+	}
+	return _r0, _ret.Error(1)
+}
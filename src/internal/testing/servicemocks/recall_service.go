@@ -0,0 +1,94 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/recall"
+	"github.com/stretchr/testify/mock"
+)
+
+// RecallService is a mock of recall.RecallService
+type RecallService struct {
+	mock.Mock
+}
+
+var _ recall.RecallService = (*RecallService)(nil)
+
+func (_m *RecallService) FindAffected(ctx context.Context, target recall.Target) (*entity.RecallImpact, error) {
+	_ret := _m.Called(ctx, target)
+
+	var _r0 *entity.RecallImpact
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RecallImpact)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallService) CreateRecall(ctx context.Context, actorID uuid.UUID, input recall.CreateInput) (*entity.Recall, error) {
+	_ret := _m.Called(ctx, actorID, input)
+
+	var _r0 *entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Recall)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallService) ResendNotices(ctx context.Context, actorID uuid.UUID, recallID uuid.UUID) (*entity.Recall, error) {
+	_ret := _m.Called(ctx, actorID, recallID)
+
+	var _r0 *entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Recall)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallService) GetRecall(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Recall)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallService) ListRecalls(ctx context.Context, page int, pageSize int) ([]*entity.Recall, int, error) {
+	_ret := _m.Called(ctx, page, pageSize)
+
+	var _r0 []*entity.Recall
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Recall)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *RecallService) ListMyNotices(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 []*entity.RecallNotice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.RecallNotice)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RecallService) Acknowledge(ctx context.Context, userID uuid.UUID, noticeID uuid.UUID) (*entity.RecallNotice, error) {
+	_ret := _m.Called(ctx, userID, noticeID)
+
+	var _r0 *entity.RecallNotice
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RecallNotice)
+	}
+	return _r0, _ret.Error(1)
+}
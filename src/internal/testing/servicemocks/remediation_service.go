@@ -0,0 +1,39 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/remediation"
+	"github.com/stretchr/testify/mock"
+)
+
+// RemediationService is a mock of remediation.RemediationService
+type RemediationService struct {
+	mock.Mock
+}
+
+var _ remediation.RemediationService = (*RemediationService)(nil)
+
+func (_m *RemediationService) Remediate(ctx context.Context, orderID uuid.UUID, actor remediation.Actor, req remediation.Request) (*entity.OrderRemediation, error) {
+	_ret := _m.Called(ctx, orderID, actor, req)
+
+	var _r0 *entity.OrderRemediation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.OrderRemediation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *RemediationService) ListRemediations(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error) {
+	_ret := _m.Called(ctx, orderID)
+
+	var _r0 []*entity.OrderRemediation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.OrderRemediation)
+	}
+	return _r0, _ret.Error(1)
+}
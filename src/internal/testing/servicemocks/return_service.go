@@ -0,0 +1,105 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/returns"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReturnService is a mock of returns.ReturnService
+type ReturnService struct {
+	mock.Mock
+}
+
+var _ returns.ReturnService = (*ReturnService)(nil)
+
+func (_m *ReturnService) RequestReturn(ctx context.Context, userID uuid.UUID, orderID uuid.UUID, req returns.Request) (*entity.Return, error) {
+	_ret := _m.Called(ctx, userID, orderID, req)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) GetCustomerReturn(ctx context.Context, userID uuid.UUID, returnID uuid.UUID) (*entity.Return, error) {
+	_ret := _m.Called(ctx, userID, returnID)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) GetReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	_ret := _m.Called(ctx, returnID)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) ListReturns(ctx context.Context, filters repository.ReturnFilters, page int, pageSize int) ([]*entity.Return, int, error) {
+	_ret := _m.Called(ctx, filters, page, pageSize)
+
+	var _r0 []*entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Return)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ReturnService) ApproveReturn(ctx context.Context, returnID uuid.UUID, reviewerID uuid.UUID, note string) (*entity.Return, error) {
+	_ret := _m.Called(ctx, returnID, reviewerID, note)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) RejectReturn(ctx context.Context, returnID uuid.UUID, reviewerID uuid.UUID, note string) (*entity.Return, error) {
+	_ret := _m.Called(ctx, returnID, reviewerID, note)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) ReceiveReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	_ret := _m.Called(ctx, returnID)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReturnService) RefundReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	_ret := _m.Called(ctx, returnID)
+
+	var _r0 *entity.Return
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.Return)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,49 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/review"
+	"github.com/stretchr/testify/mock"
+)
+
+// ReviewService is a mock of review.ReviewService
+type ReviewService struct {
+	mock.Mock
+}
+
+var _ review.ReviewService = (*ReviewService)(nil)
+
+func (_m *ReviewService) ReviewProduct(ctx context.Context, productID uuid.UUID, userID uuid.UUID, rating int, body string) (*entity.ProductReview, error) {
+	_ret := _m.Called(ctx, productID, userID, rating, body)
+
+	var _r0 *entity.ProductReview
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductReview)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *ReviewService) ListReviews(ctx context.Context, productID uuid.UUID, page int, pageSize int) ([]*entity.ProductReview, int, error) {
+	_ret := _m.Called(ctx, productID, page, pageSize)
+
+	var _r0 []*entity.ProductReview
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductReview)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *ReviewService) DeleteReview(ctx context.Context, productID uuid.UUID, userID uuid.UUID) error {
+	_ret := _m.Called(ctx, productID, userID)
+	return _ret.Error(0)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/stretchr/testify/mock"
+)
+
+// RiskScorer is a mock of fraud.RiskScorer
+type RiskScorer struct {
+	mock.Mock
+}
+
+var _ fraud.RiskScorer = (*RiskScorer)(nil)
+
+func (_m *RiskScorer) RiskScore(ctx context.Context, userID uuid.UUID) (int, error) {
+	_ret := _m.Called(ctx, userID)
+
+	var _r0 int
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(int)
+	}
+	return _r0, _ret.Error(1)
+}
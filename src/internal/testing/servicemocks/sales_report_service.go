@@ -0,0 +1,24 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/salesreport"
+	"github.com/stretchr/testify/mock"
+)
+
+// SalesReportService is a mock of salesreport.SalesReportService
+type SalesReportService struct {
+	mock.Mock
+}
+
+var _ salesreport.SalesReportService = (*SalesReportService)(nil)
+
+func (_m *SalesReportService) ExportSales(ctx context.Context, from time.Time, to time.Time, fn func(lines []entity.SalesLine) error) error {
+	_ret := _m.Called(ctx, from, to, fn)
+	return _ret.Error(0)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/search"
+	"github.com/stretchr/testify/mock"
+)
+
+// SearchService is a mock of search.SearchService
+type SearchService struct {
+	mock.Mock
+}
+
+var _ search.SearchService = (*SearchService)(nil)
+
+func (_m *SearchService) Search(ctx context.Context, query string, sortBy string, page int, pageSize int) ([]*entity.Product, int, error) {
+	_ret := _m.Called(ctx, query, sortBy, page, pageSize)
+
+	var _r0 []*entity.Product
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.Product)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *SearchService) Explain(ctx context.Context, query string, page int, pageSize int) (*entity.SearchExplanation, int, error) {
+	_ret := _m.Called(ctx, query, page, pageSize)
+
+	var _r0 *entity.SearchExplanation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.SearchExplanation)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *SearchService) ListRules(ctx context.Context) ([]*entity.RankingRule, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.RankingRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.RankingRule)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *SearchService) CreateRule(ctx context.Context, actorID uuid.UUID, input search.RuleInput) (*entity.RankingRule, error) {
+	_ret := _m.Called(ctx, actorID, input)
+
+	var _r0 *entity.RankingRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RankingRule)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *SearchService) UpdateRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID, input search.RuleInput) (*entity.RankingRule, error) {
+	_ret := _m.Called(ctx, actorID, id, input)
+
+	var _r0 *entity.RankingRule
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.RankingRule)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *SearchService) DeleteRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	_ret := _m.Called(ctx, actorID, id)
+	return _ret.Error(0)
+}
@@ -0,0 +1,27 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/usecase/seed"
+	"github.com/stretchr/testify/mock"
+)
+
+// SeedService is a mock of seed.SeedService
+type SeedService struct {
+	mock.Mock
+}
+
+var _ seed.SeedService = (*SeedService)(nil)
+
+func (_m *SeedService) Seed(ctx context.Context, opts seed.Options) (*seed.Summary, error) {
+	_ret := _m.Called(ctx, opts)
+
+	var _r0 *seed.Summary
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*seed.Summary)
+	}
+	return _r0, _ret.Error(1)
+}
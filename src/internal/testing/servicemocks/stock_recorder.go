@@ -0,0 +1,23 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+	"github.com/stretchr/testify/mock"
+)
+
+// StockRecorder is a mock of stock.Recorder
+type StockRecorder struct {
+	mock.Mock
+}
+
+var _ stock.Recorder = (*StockRecorder)(nil)
+
+func (_m *StockRecorder) Record(ctx context.Context, movement *entity.StockMovement) error {
+	_ret := _m.Called(ctx, movement)
+	return _ret.Error(0)
+}
@@ -0,0 +1,49 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/storesettings"
+	"github.com/stretchr/testify/mock"
+)
+
+// StoreSettingsService is a mock of storesettings.StoreSettingsService
+type StoreSettingsService struct {
+	mock.Mock
+}
+
+var _ storesettings.StoreSettingsService = (*StoreSettingsService)(nil)
+
+func (_m *StoreSettingsService) GetSettings(ctx context.Context) (entity.StoreSettings, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 entity.StoreSettings
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(entity.StoreSettings)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *StoreSettingsService) ListSettings(ctx context.Context) ([]*storesettings.Setting, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*storesettings.Setting
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*storesettings.Setting)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *StoreSettingsService) UpdateSettings(ctx context.Context, values map[string]*string, updatedBy uuid.UUID) ([]*storesettings.Setting, error) {
+	_ret := _m.Called(ctx, values, updatedBy)
+
+	var _r0 []*storesettings.Setting
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*storesettings.Setting)
+	}
+	return _r0, _ret.Error(1)
+}
@@ -0,0 +1,28 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/textmessage"
+	"github.com/stretchr/testify/mock"
+)
+
+// TextMessenger is a mock of textmessage.Messenger
+type TextMessenger struct {
+	mock.Mock
+}
+
+var _ textmessage.Messenger = (*TextMessenger)(nil)
+
+func (_m *TextMessenger) SendPhoneCode(ctx context.Context, phone string, code string) error {
+	_ret := _m.Called(ctx, phone, code)
+	return _ret.Error(0)
+}
+
+func (_m *TextMessenger) OrderShipped(ctx context.Context, order *entity.Order) error {
+	_ret := _m.Called(ctx, order)
+	return _ret.Error(0)
+}
@@ -0,0 +1,24 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/customer"
+	"github.com/stretchr/testify/mock"
+)
+
+// TokenRevoker is a mock of customer.TokenRevoker
+type TokenRevoker struct {
+	mock.Mock
+}
+
+var _ customer.TokenRevoker = (*TokenRevoker)(nil)
+
+func (_m *TokenRevoker) RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	_ret := _m.Called(ctx, userID, reason, revokedBy)
+	return _ret.Error(0)
+}
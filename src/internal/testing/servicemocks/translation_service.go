@@ -0,0 +1,59 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/translation"
+	"github.com/stretchr/testify/mock"
+)
+
+// TranslationService is a mock of translation.TranslationService
+type TranslationService struct {
+	mock.Mock
+}
+
+var _ translation.TranslationService = (*TranslationService)(nil)
+
+func (_m *TranslationService) Localize(ctx context.Context, preferred []string, products ...*entity.Product) error {
+	_ret := _m.Called(ctx, preferred, products)
+	return _ret.Error(0)
+}
+
+func (_m *TranslationService) DefaultLocale() string {
+	_ret := _m.Called()
+
+	var _r0 string
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(string)
+	}
+	return _r0
+}
+
+func (_m *TranslationService) SaveTranslation(ctx context.Context, productID uuid.UUID, locale string, name string, description string) (*entity.ProductTranslation, error) {
+	_ret := _m.Called(ctx, productID, locale, name, description)
+
+	var _r0 *entity.ProductTranslation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.ProductTranslation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TranslationService) ListTranslations(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error) {
+	_ret := _m.Called(ctx, productID)
+
+	var _r0 []*entity.ProductTranslation
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.ProductTranslation)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *TranslationService) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	_ret := _m.Called(ctx, productID, locale)
+	return _ret.Error(0)
+}
@@ -0,0 +1,90 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package servicemocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/webhook"
+	"github.com/stretchr/testify/mock"
+)
+
+// WebhookService is a mock of webhook.WebhookService
+type WebhookService struct {
+	mock.Mock
+}
+
+var _ webhook.WebhookService = (*WebhookService)(nil)
+
+func (_m *WebhookService) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription, createdBy uuid.UUID) (*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx, subscription, createdBy)
+
+	var _r0 *entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx, id)
+
+	var _r0 *entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookService) ListSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx)
+
+	var _r0 []*entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, update webhook.Update) (*entity.WebhookSubscription, error) {
+	_ret := _m.Called(ctx, id, update)
+
+	var _r0 *entity.WebhookSubscription
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookSubscription)
+	}
+	return _r0, _ret.Error(1)
+}
+
+func (_m *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	_ret := _m.Called(ctx, id)
+	return _ret.Error(0)
+}
+
+func (_m *WebhookService) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page int, pageSize int) ([]*entity.WebhookDelivery, int, error) {
+	_ret := _m.Called(ctx, subscriptionID, filters, page, pageSize)
+
+	var _r0 []*entity.WebhookDelivery
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.([]*entity.WebhookDelivery)
+	}
+
+	var _r1 int
+	if _v := _ret.Get(1); _v != nil {
+		_r1 = _v.(int)
+	}
+	return _r0, _r1, _ret.Error(2)
+}
+
+func (_m *WebhookService) RetryDelivery(ctx context.Context, subscriptionID uuid.UUID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	_ret := _m.Called(ctx, subscriptionID, deliveryID)
+
+	var _r0 *entity.WebhookDelivery
+	if _v := _ret.Get(0); _v != nil {
+		_r0 = _v.(*entity.WebhookDelivery)
+	}
+	return _r0, _ret.Error(1)
+}
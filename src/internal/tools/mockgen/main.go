@@ -0,0 +1,424 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Generates a testify mock of an interface. Run through the go:generate
+// directive above the interface, it reads the file go generate is running
+// for and writes the mock into -out, one file per interface:
+//
+//	//go:generate go run ../../tools/mockgen -interface OrderRepository -out ../../testing/mocks
+//
+// Every method records its call on the embedded mock.Mock and returns what
+// the expectation set with On(...).Return(...); a nil return value stands for
+// the zero value of its type, so Return(nil, err) works for any result.
+func main() {
+	name := flag.String("interface", "", "Name of the interface to mock")
+	source := flag.String("source", os.Getenv("GOFILE"), "File declaring the interface")
+	out := flag.String("out", "", "Directory of the package the mock is written into")
+	mockName := flag.String("name", "", "Name of the mock type, the interface name by default")
+	flag.Parse()
+
+	if *name == "" || *source == "" || *out == "" {
+		log.Fatal("-interface, -source (or GOFILE) and -out are required")
+	}
+	if *mockName == "" {
+		*mockName = *name
+	}
+
+	file, err := generate(*source, *name, *mockName, filepath.Base(*out))
+	if err != nil {
+		log.Fatalf("Failed to generate the mock of %s: %v", *name, err)
+	}
+
+	path := filepath.Join(*out, snakeCase(*mockName)+".go")
+	if err := os.WriteFile(path, file, 0o644); err != nil {
+		log.Fatal("Failed to write the mock: ", err)
+	}
+}
+
+// generate returns the source of the mock, in package pkg, of the interface
+// declared in the source file
+func generate(source, name, mockName, pkg string) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, source, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	iface := findInterface(file, name)
+	if iface == nil {
+		return nil, fmt.Errorf("no interface %s in %s", name, source)
+	}
+
+	// Interfaces embedded in the mocked one are looked up in the whole package
+	siblings, err := parser.ParseDir(fset, filepath.Dir(source), func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	importPath, err := packageImportPath(filepath.Dir(source))
+	if err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		source:      file.Name.Name,
+		fileImports: map[string]string{},
+		imports: map[string]string{
+			"mock": "github.com/stretchr/testify/mock",
+		},
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		alias := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			alias = spec.Name.Name
+		}
+		g.fileImports[alias] = path
+	}
+	// A package may import another of the same name, e.g. the auth use case
+	// imports the auth infrastructure; the mock then tells them apart by the
+	// directory the mocked package is in
+	if _, clash := g.fileImports[g.source]; clash {
+		g.source += sourceSuffix(filepath.Base(filepath.Dir(importPath)))
+	}
+	g.imports[g.source] = importPath
+
+	var body bytes.Buffer
+	fmt.Fprintf(&body, "// %s is a mock of %s.%s\n", mockName, file.Name.Name, name)
+	fmt.Fprintf(&body, "type %s struct {\n\tmock.Mock\n}\n\n", mockName)
+	fmt.Fprintf(&body, "var _ %s.%s = (*%s)(nil)\n", g.source, name, mockName)
+	if err := g.writeMethods(&body, mockName, iface, siblings[file.Name.Name]); err != nil {
+		return nil, err
+	}
+
+	var src bytes.Buffer
+	src.WriteString("// Code generated by mockgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&src, "package %s\n\nimport (\n", pkg)
+	aliases := make([]string, 0, len(g.imports))
+	for alias := range g.imports {
+		aliases = append(aliases, alias)
+	}
+	// The standard library first, as goimports groups them
+	sort.Slice(aliases, func(i, j int) bool {
+		a, b := g.imports[aliases[i]], g.imports[aliases[j]]
+		if isStd(a) != isStd(b) {
+			return isStd(a)
+		}
+		return a < b
+	})
+	for i, alias := range aliases {
+		path := g.imports[alias]
+		if i > 0 && isStd(g.imports[aliases[i-1]]) && !isStd(path) {
+			src.WriteString("\n")
+		}
+		if alias == path[strings.LastIndex(path, "/")+1:] {
+			fmt.Fprintf(&src, "\t%q\n", path)
+		} else {
+			fmt.Fprintf(&src, "\t%s %q\n", alias, path)
+		}
+	}
+	src.WriteString(")\n\n")
+	src.Write(body.Bytes())
+
+	return format.Source(src.Bytes())
+}
+
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			if iface, ok := typeSpec.Type.(*ast.InterfaceType); ok && typeSpec.Name.Name == name {
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+// findPackageInterface finds an interface declared in any file of the package
+func findPackageInterface(pkg *ast.Package, name string) *ast.InterfaceType {
+	for _, file := range pkg.Files {
+		if iface := findInterface(file, name); iface != nil {
+			return iface
+		}
+	}
+	return nil
+}
+
+type generator struct {
+	source      string            // Name the mock imports the package declaring the interface as
+	fileImports map[string]string // Imports of the source file, by alias
+	imports     map[string]string // Imports of the mock, by alias
+}
+
+// writeMethods writes the mocks of the methods of the interface, those of the
+// interfaces it embeds included. Embedded interfaces must be declared in the
+// same package, in a file that imports what their methods use under the same
+// names as the mocked interface's file.
+func (g *generator) writeMethods(w *bytes.Buffer, mockName string, iface *ast.InterfaceType, pkg *ast.Package) error {
+	for _, method := range iface.Methods.List {
+		if fn, ok := method.Type.(*ast.FuncType); ok {
+			for _, methodName := range method.Names {
+				if err := g.writeMethod(w, mockName, methodName.Name, fn); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		embedded, ok := method.Type.(*ast.Ident)
+		if !ok {
+			return errors.New("only interfaces of the same package can be embedded")
+		}
+		embeddedIface := findPackageInterface(pkg, embedded.Name)
+		if embeddedIface == nil {
+			return fmt.Errorf("no interface %s in package %s", embedded.Name, g.source)
+		}
+		if err := g.writeMethods(w, mockName, embeddedIface, pkg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeMethod writes the mock of one method. Its receiver and locals start
+// with an underscore so they can't clash with the parameter names.
+func (g *generator) writeMethod(w *bytes.Buffer, mockName, name string, fn *ast.FuncType) error {
+	var params, args []string
+	if fn.Params != nil {
+		for i, field := range fn.Params.List {
+			typ, err := g.typeString(field.Type)
+			if err != nil {
+				return err
+			}
+			names := field.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{{Name: "_"}}
+			}
+			for j, ident := range names {
+				paramName := ident.Name
+				if paramName == "_" {
+					paramName = fmt.Sprintf("p%d_%d", i, j)
+				}
+				params = append(params, paramName+" "+typ)
+				args = append(args, paramName)
+			}
+		}
+	}
+
+	var results []string
+	if fn.Results != nil {
+		for _, field := range fn.Results.List {
+			typ, err := g.typeString(field.Type)
+			if err != nil {
+				return err
+			}
+			count := len(field.Names)
+			if count == 0 {
+				count = 1
+			}
+			for i := 0; i < count; i++ {
+				results = append(results, typ)
+			}
+		}
+	}
+
+	resultList := strings.Join(results, ", ")
+	if len(results) > 1 {
+		resultList = "(" + resultList + ")"
+	}
+	fmt.Fprintf(w, "\nfunc (_m *%s) %s(%s) %s {\n", mockName, name, strings.Join(params, ", "), resultList)
+	if len(results) == 0 {
+		fmt.Fprintf(w, "\t_m.Called(%s)\n}\n", strings.Join(args, ", "))
+		return nil
+	}
+
+	fmt.Fprintf(w, "\t_ret := _m.Called(%s)\n", strings.Join(args, ", "))
+	var returns []string
+	for i, typ := range results {
+		if typ == "error" {
+			returns = append(returns, fmt.Sprintf("_ret.Error(%d)", i))
+			continue
+		}
+		fmt.Fprintf(w, "\n\tvar _r%d %s\n\tif _v := _ret.Get(%d); _v != nil {\n\t\t_r%d = _v.(%s)\n\t}\n", i, typ, i, i, typ)
+		returns = append(returns, fmt.Sprintf("_r%d", i))
+	}
+	fmt.Fprintf(w, "\treturn %s\n}\n", strings.Join(returns, ", "))
+	return nil
+}
+
+// typeString prints a type of the source file as the mock package refers to
+// it: types of the source package get qualified with its name, and the
+// packages used get imported
+func (g *generator) typeString(expr ast.Expr) (string, error) {
+	qualified, err := g.qualify(expr)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), qualified); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func (g *generator) qualify(expr ast.Expr) (ast.Expr, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if predeclared[e.Name] {
+			return e, nil
+		}
+		if !ast.IsExported(e.Name) {
+			return nil, fmt.Errorf("unexported type %s can't be used outside package %s", e.Name, g.source)
+		}
+		return &ast.SelectorExpr{X: ast.NewIdent(g.source), Sel: e}, nil
+	case *ast.SelectorExpr:
+		pkg, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, fmt.Errorf("unsupported type %T", e.X)
+		}
+		path, ok := g.fileImports[pkg.Name]
+		if !ok {
+			return nil, fmt.Errorf("unknown package %s", pkg.Name)
+		}
+		g.imports[pkg.Name] = path
+		return e, nil
+	case *ast.StarExpr:
+		x, err := g.qualify(e.X)
+		return &ast.StarExpr{X: x}, err
+	case *ast.ArrayType:
+		elt, err := g.qualify(e.Elt)
+		return &ast.ArrayType{Len: e.Len, Elt: elt}, err
+	case *ast.Ellipsis:
+		elt, err := g.qualify(e.Elt)
+		return &ast.Ellipsis{Elt: elt}, err
+	case *ast.MapType:
+		key, err := g.qualify(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := g.qualify(e.Value)
+		return &ast.MapType{Key: key, Value: value}, err
+	case *ast.ChanType:
+		value, err := g.qualify(e.Value)
+		return &ast.ChanType{Dir: e.Dir, Value: value}, err
+	case *ast.FuncType:
+		params, err := g.qualifyFields(e.Params)
+		if err != nil {
+			return nil, err
+		}
+		results, err := g.qualifyFields(e.Results)
+		return &ast.FuncType{Params: params, Results: results}, err
+	case *ast.InterfaceType:
+		if len(e.Methods.List) > 0 {
+			return nil, errors.New("inline interface types are not supported")
+		}
+		return e, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", expr)
+	}
+}
+
+func (g *generator) qualifyFields(fields *ast.FieldList) (*ast.FieldList, error) {
+	if fields == nil {
+		return nil, nil
+	}
+	qualified := &ast.FieldList{}
+	for _, field := range fields.List {
+		typ, err := g.qualify(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		qualified.List = append(qualified.List, &ast.Field{Names: field.Names, Type: typ})
+	}
+	return qualified, nil
+}
+
+var predeclared = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+}
+
+// packageImportPath is the import path of the package in dir, from the
+// module path in the go.mod above it
+func packageImportPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for root := dir; ; root = filepath.Dir(root) {
+		mod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(mod), "\n") {
+				if module, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+					rel, err := filepath.Rel(root, dir)
+					if err != nil {
+						return "", err
+					}
+					return strings.TrimSpace(module) + "/" + filepath.ToSlash(rel), nil
+				}
+			}
+			return "", errors.New("go.mod declares no module")
+		}
+		if root == filepath.Dir(root) {
+			return "", errors.New("no go.mod above " + dir)
+		}
+	}
+}
+
+// sourceSuffix tells a mocked package from another of the same name by its
+// parent directory, as in authUseCase
+func sourceSuffix(parent string) string {
+	if parent == "usecase" {
+		return "UseCase"
+	}
+	return strings.ToUpper(parent[:1]) + parent[1:]
+}
+
+// isStd reports whether the import path is of the standard library
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// snakeCase names the file of a mock: OrderRepository is order_repository.go
+func snakeCase(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGenerate(t *testing.T) {
+	got, err := generate(filepath.Join("testdata", "store", "store.go"), "Store", "StoreMock", "mocks")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	goldenPath := filepath.Join("testdata", "store_mock.golden")
+	if *update {
+		if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("missing golden file (run with -update): %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("mock differs from %s (run with -update to accept):\n%s", goldenPath, got)
+	}
+}
+
+func TestGenerate_Errors(t *testing.T) {
+	source := filepath.Join("testdata", "store", "store.go")
+
+	tests := []struct {
+		name      string
+		iface     string
+		wantError string
+	}{
+		{name: "unknown interface", iface: "Missing", wantError: "no interface Missing"},
+		{name: "unexported type", iface: "Broken", wantError: "unexported type filter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := generate(source, tt.iface, tt.iface, "mocks")
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("expected an error containing %q, got %v", tt.wantError, err)
+			}
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := map[string]string{
+		"OrderRepository":   "order_repository",
+		"PriceCalculator":   "price_calculator",
+		"HTTPClient":        "http_client",
+		"SMSSender":         "sms_sender",
+		"Renderer":          "renderer",
+		"DraftOrderService": "draft_order_service",
+	}
+
+	for name, want := range tests {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package store
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Item struct {
+	ID   uuid.UUID
+	Name string
+}
+
+type Reader interface {
+	Get(ctx context.Context, id uuid.UUID) (*Item, error)
+	List(ctx context.Context, ids ...uuid.UUID) ([]Item, error)
+}
+
+// Store embeds an interface of its own package and uses the types of the
+// package and of its imports
+type Store interface {
+	Reader
+	Save(ctx context.Context, item *Item) error
+	Export(w io.Writer, since time.Time) (count int, err error)
+	Tags(context.Context) map[string][]string
+	Watch(ctx context.Context, fn func(*Item) bool) <-chan error
+	Close()
+}
+
+type Broken interface {
+	Find(query filter) (*Item, error)
+}
+
+type filter struct{}
@@ -0,0 +1,195 @@
+package accesscode
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrAccessCodeRequired  = entity.ForbiddenError("An access code is required while the store is in private beta")
+	ErrInvalidAccessCode   = entity.ForbiddenError("Invalid or expired access code")
+	ErrDuplicateAccessCode = entity.ConflictError("This access code already exists")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Gate -out ../../internal/testing/servicemocks -name AccessGate
+
+// Gate keeps registration and browsing to people with an access code while
+// the store requires one
+type Gate interface {
+	// RequiredForRegistration tells whether customers need a code to register
+	RequiredForRegistration() bool
+	// RequiredForBrowsing tells whether visitors who aren't signed in need a
+	// code to see the catalog
+	RequiredForBrowsing() bool
+	// Check returns ErrAccessCodeRequired for an empty code and
+	// ErrInvalidAccessCode unless the code is active and unexpired
+	Check(ctx context.Context, code string) error
+	// Redeem checks the code and counts a registration against it, a
+	// ConflictError once it has been used up
+	Redeem(ctx context.Context, code string) error
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface AccessCodeService -out ../../internal/testing/servicemocks
+
+type AccessCodeService interface {
+	Gate
+
+	// CreateCode stores the code, generating one when it has none
+	CreateCode(ctx context.Context, code *entity.AccessCode, createdBy uuid.UUID) (*entity.AccessCode, error)
+	// ListCodes returns every code, newest first
+	ListCodes(ctx context.Context) ([]*entity.AccessCode, error)
+	UpdateCode(ctx context.Context, id uuid.UUID, changes Changes, updatedBy uuid.UUID) (*entity.AccessCode, error)
+	DeleteCode(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+// Changes replaces the note, limits and status of a code. The code itself
+// and its uses can't be changed.
+type Changes struct {
+	Note      string
+	MaxUses   int
+	ExpiresAt *time.Time
+	Active    bool
+}
+
+// Requirements tells what takes an access code
+type Requirements struct {
+	Registration bool
+	Browsing     bool
+}
+
+type UseCase struct {
+	repo     repository.AccessCodeRepository
+	services Services
+	required Requirements
+	now      func() time.Time
+}
+
+func NewUseCase(repo repository.AccessCodeRepository, services Services, required Requirements) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		services: services,
+		required: required,
+		now:      time.Now,
+	}
+}
+
+func (uc *UseCase) RequiredForRegistration() bool {
+	return uc.required.Registration
+}
+
+func (uc *UseCase) RequiredForBrowsing() bool {
+	return uc.required.Browsing
+}
+
+func (uc *UseCase) Check(ctx context.Context, code string) error {
+	_, err := uc.valid(ctx, code)
+	return err
+}
+
+func (uc *UseCase) Redeem(ctx context.Context, code string) error {
+	accessCode, err := uc.valid(ctx, code)
+	if err != nil {
+		return err
+	}
+	return uc.repo.Redeem(ctx, accessCode.ID)
+}
+
+// valid finds the code and checks it may be used
+func (uc *UseCase) valid(ctx context.Context, code string) (*entity.AccessCode, error) {
+	code = entity.NormalizeAccessCode(code)
+	if code == "" {
+		return nil, ErrAccessCodeRequired
+	}
+
+	accessCode, err := uc.repo.GetByCode(ctx, code)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil, ErrInvalidAccessCode
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !accessCode.Valid(uc.now()) {
+		return nil, ErrInvalidAccessCode
+	}
+	return accessCode, nil
+}
+
+func (uc *UseCase) CreateCode(ctx context.Context, code *entity.AccessCode, createdBy uuid.UUID) (*entity.AccessCode, error) {
+	if entity.NormalizeAccessCode(code.Code) == "" {
+		code.Code = entity.GenerateAccessCode()
+	}
+	if err := code.Validate(); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.repo.GetByCode(ctx, code.Code); err == nil {
+		return nil, ErrDuplicateAccessCode
+	} else if !errors.Is(err, entity.ErrNotFound) {
+		return nil, err
+	}
+
+	code.ID = entity.NewID()
+	code.Uses = 0
+	code.Active = true
+	code.CreatedBy = &createdBy
+	if err := uc.repo.Create(ctx, code); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &createdBy, "CREATE", "AccessCode", code.ID, nil, code)
+
+	return code, nil
+}
+
+func (uc *UseCase) ListCodes(ctx context.Context) ([]*entity.AccessCode, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *UseCase) UpdateCode(ctx context.Context, id uuid.UUID, changes Changes, updatedBy uuid.UUID) (*entity.AccessCode, error) {
+	code, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	before := *code
+	code.Note = changes.Note
+	code.MaxUses = changes.MaxUses
+	code.ExpiresAt = changes.ExpiresAt
+	code.Active = changes.Active
+	if err := code.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, code); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &updatedBy, "UPDATE", "AccessCode", code.ID, before, code)
+
+	return code, nil
+}
+
+func (uc *UseCase) DeleteCode(ctx context.Context, id uuid.UUID, deletedBy uuid.UUID) error {
+	code, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &deletedBy, "DELETE", "AccessCode", id, code, nil)
+
+	return nil
+}
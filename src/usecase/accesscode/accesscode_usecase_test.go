@@ -0,0 +1,117 @@
+package accesscode
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// services stands in for the shared test services, which depend on this package
+type services struct{}
+
+func (services) GetAuditService() audit.AuditService { return discardAudit{} }
+
+type discardAudit struct{}
+
+func (discardAudit) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
+	return nil
+}
+
+func newUseCase() *UseCase {
+	return NewUseCase(memory.NewAccessCodeRepository(memory.NewStore()), services{},
+		Requirements{Registration: true, Browsing: true})
+}
+
+func TestCreateCode(t *testing.T) {
+	uc := newUseCase()
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	code, err := uc.CreateCode(ctx, &entity.AccessCode{Code: " vip_2026 ", Note: "Press"}, adminID)
+	require.NoError(t, err)
+	assert.Equal(t, "VIP_2026", code.Code)
+	assert.True(t, code.Active)
+	assert.Equal(t, &adminID, code.CreatedBy)
+
+	generated, err := uc.CreateCode(ctx, &entity.AccessCode{}, adminID)
+	require.NoError(t, err)
+	assert.Len(t, generated.Code, 10)
+
+	_, err = uc.CreateCode(ctx, &entity.AccessCode{Code: "VIP_2026"}, adminID)
+	assert.ErrorIs(t, err, ErrDuplicateAccessCode)
+
+	for _, invalid := range []*entity.AccessCode{{Code: "ab"}, {Code: "no spaces"}, {Code: "OK-CODE", MaxUses: -1}} {
+		_, err := uc.CreateCode(ctx, invalid, adminID)
+		assert.True(t, errors.Is(err, entity.ErrValidation), "code %q", invalid.Code)
+	}
+
+	codes, err := uc.ListCodes(ctx)
+	require.NoError(t, err)
+	assert.Len(t, codes, 2)
+}
+
+func TestCheck(t *testing.T) {
+	uc := newUseCase()
+	ctx := context.Background()
+	now := time.Date(2026, time.June, 1, 12, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	expiresAt := now.Add(time.Hour)
+
+	code, err := uc.CreateCode(ctx, &entity.AccessCode{Code: "BETA", MaxUses: 1, ExpiresAt: &expiresAt}, uuid.New())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, uc.Check(ctx, ""), ErrAccessCodeRequired)
+	assert.ErrorIs(t, uc.Check(ctx, "ALPHA"), ErrInvalidAccessCode)
+	assert.NoError(t, uc.Check(ctx, "beta"))
+
+	// Used up codes still let people browse, they only stop registrations
+	require.NoError(t, uc.Redeem(ctx, "BETA"))
+	assert.True(t, errors.Is(uc.Redeem(ctx, "BETA"), entity.ErrConflict))
+	assert.NoError(t, uc.Check(ctx, "BETA"))
+
+	now = expiresAt
+	assert.ErrorIs(t, uc.Check(ctx, "BETA"), ErrInvalidAccessCode)
+
+	now = expiresAt.Add(-time.Minute)
+	_, err = uc.UpdateCode(ctx, code.ID, Changes{MaxUses: 1, ExpiresAt: &expiresAt, Active: false}, uuid.New())
+	require.NoError(t, err)
+	assert.ErrorIs(t, uc.Check(ctx, "BETA"), ErrInvalidAccessCode)
+}
+
+func TestUpdateCode_KeepsUses(t *testing.T) {
+	uc := newUseCase()
+	ctx := context.Background()
+
+	code, err := uc.CreateCode(ctx, &entity.AccessCode{Code: "BETA", MaxUses: 1}, uuid.New())
+	require.NoError(t, err)
+	require.NoError(t, uc.Redeem(ctx, "BETA"))
+
+	updated, err := uc.UpdateCode(ctx, code.ID, Changes{Note: "Raised", MaxUses: 5, Active: true}, uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated.Uses)
+	assert.Equal(t, 5, updated.MaxUses)
+	assert.NoError(t, uc.Redeem(ctx, "BETA"))
+
+	_, err = uc.UpdateCode(ctx, uuid.New(), Changes{Active: true}, uuid.New())
+	assert.True(t, errors.Is(err, entity.ErrNotFound))
+}
+
+func TestDeleteCode(t *testing.T) {
+	uc := newUseCase()
+	ctx := context.Background()
+
+	code, err := uc.CreateCode(ctx, &entity.AccessCode{Code: "BETA"}, uuid.New())
+	require.NoError(t, err)
+
+	require.NoError(t, uc.DeleteCode(ctx, code.ID, uuid.New()))
+	assert.ErrorIs(t, uc.Check(ctx, "BETA"), ErrInvalidAccessCode)
+	assert.True(t, errors.Is(uc.DeleteCode(ctx, code.ID, uuid.New()), entity.ErrNotFound))
+}
@@ -0,0 +1,123 @@
+package allocation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+var (
+	ErrEmptyCart       = entity.ValidationError("Cart must have at least one item")
+	ErrInvalidQuantity = entity.ValidationError("Quantity must be greater than 0")
+	ErrProductNotFound = entity.NotFoundError("Product not found")
+	ErrVariantNotFound = entity.NotFoundError("Product variant not found")
+	ErrVariantMismatch = entity.ValidationError("Variant does not belong to the specified product")
+)
+
+// CartItem is a line of a prospective cart
+type CartItem struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface AllocationService -out ../../internal/testing/servicemocks
+
+type AllocationService interface {
+	// PreviewAllocation works out where each cart item would ship from and when,
+	// without reserving any stock
+	PreviewAllocation(ctx context.Context, items []CartItem) (*entity.AllocationPlan, error)
+}
+
+type UseCase struct {
+	productRepo repository.ProductRepository
+	variantRepo repository.ProductVariantRepository
+	warehouse   entity.Warehouse
+	now         func() time.Time
+}
+
+// NewUseCase creates the allocation use case. Stock is kept as a single pool per
+// product or variant, so every allocation is made from warehouse.
+func NewUseCase(productRepo repository.ProductRepository, variantRepo repository.ProductVariantRepository, warehouse entity.Warehouse) *UseCase {
+	return &UseCase{
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		warehouse:   warehouse,
+		now:         time.Now,
+	}
+}
+
+func (uc *UseCase) PreviewAllocation(ctx context.Context, items []CartItem) (*entity.AllocationPlan, error) {
+	if len(items) == 0 {
+		return nil, ErrEmptyCart
+	}
+
+	shipDate := uc.warehouse.ShipDate(uc.now())
+	// The same product or variant may appear on several lines, they share its stock
+	remaining := make(map[stockKey]int)
+	plan := &entity.AllocationPlan{}
+
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return nil, ErrInvalidQuantity
+		}
+
+		key := stockKey{productID: item.ProductID}
+		if item.VariantID != nil {
+			key.variantID = *item.VariantID
+		}
+
+		if _, ok := remaining[key]; !ok {
+			available, err := uc.available(ctx, item)
+			if err != nil {
+				return nil, err
+			}
+			remaining[key] = available
+		}
+
+		allocated := min(item.Quantity, remaining[key])
+		remaining[key] -= allocated
+
+		itemAllocation := entity.ItemAllocation{
+			ProductID:   item.ProductID,
+			VariantID:   item.VariantID,
+			Quantity:    item.Quantity,
+			Unallocated: item.Quantity - allocated,
+		}
+		if allocated > 0 {
+			itemAllocation.Allocations = []entity.Allocation{{Warehouse: uc.warehouse, Quantity: allocated, ShipDate: shipDate}}
+		}
+
+		plan.Items = append(plan.Items, itemAllocation)
+	}
+
+	return plan, nil
+}
+
+type stockKey struct {
+	productID uuid.UUID
+	variantID uuid.UUID
+}
+
+// available returns the stock an item can be allocated from
+func (uc *UseCase) available(ctx context.Context, item CartItem) (int, error) {
+	if item.VariantID != nil {
+		variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
+		if err != nil {
+			return 0, ErrVariantNotFound
+		}
+		if variant.ProductID != item.ProductID {
+			return 0, ErrVariantMismatch
+		}
+		return variant.Quantity, nil
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		return 0, ErrProductNotFound
+	}
+	return product.Quantity, nil
+}
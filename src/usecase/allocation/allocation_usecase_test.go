@@ -0,0 +1,221 @@
+package allocation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type mockProductRepo struct {
+	products map[uuid.UUID]*entity.Product
+}
+
+func (m *mockProductRepo) Create(ctx context.Context, product *entity.Product) error { return nil }
+
+func (m *mockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	p, ok := m.products[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return p, nil
+}
+
+func (m *mockProductRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	var found []*entity.Product
+	for _, id := range ids {
+		if item, err := m.GetByID(ctx, id); err == nil {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockProductRepo) GetAll(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockProductRepo) ListProductsSummary(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockProductRepo) Update(ctx context.Context, product *entity.Product) error { return nil }
+
+func (m *mockProductRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+
+type mockVariantRepo struct {
+	variants map[uuid.UUID]*entity.ProductVariant
+}
+
+func (m *mockVariantRepo) Create(ctx context.Context, productVariant *entity.ProductVariant) error {
+	return nil
+}
+
+func (m *mockVariantRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
+	v, ok := m.variants[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return v, nil
+}
+
+func (m *mockVariantRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.ProductVariant, error) {
+	var found []*entity.ProductVariant
+	for _, id := range ids {
+		if item, err := m.GetByID(ctx, id); err == nil {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockVariantRepo) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockVariantRepo) GetAllByProductID(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockVariantRepo) GetBySKU(ctx context.Context, sku string) (*entity.ProductVariant, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockVariantRepo) GetByCombination(ctx context.Context, productID uuid.UUID, combinationKey string) (*entity.ProductVariant, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockVariantRepo) Update(ctx context.Context, productVariant *entity.ProductVariant) error {
+	return nil
+}
+
+func (m *mockVariantRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+
+func (m *mockVariantRepo) TransferStock(ctx context.Context, transfer *entity.VariantStockTransfer) (*entity.StockMovement, *entity.StockMovement, error) {
+	return nil, nil, nil
+}
+
+var _ repository.ProductRepository = (*mockProductRepo)(nil)
+var _ repository.ProductVariantRepository = (*mockVariantRepo)(nil)
+
+var testWarehouse = entity.Warehouse{Code: "main", Name: "Main warehouse", HandlingDays: 1, CutoffHour: 14}
+
+// Wednesday morning, before the cutoff
+var testNow = time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+
+func newTestUseCase(products []*entity.Product, variants []*entity.ProductVariant) *UseCase {
+	productRepo := &mockProductRepo{products: make(map[uuid.UUID]*entity.Product)}
+	for _, p := range products {
+		productRepo.products[p.ID] = p
+	}
+	variantRepo := &mockVariantRepo{variants: make(map[uuid.UUID]*entity.ProductVariant)}
+	for _, v := range variants {
+		variantRepo.variants[v.ID] = v
+	}
+
+	uc := NewUseCase(productRepo, variantRepo, testWarehouse)
+	uc.now = func() time.Time { return testNow }
+	return uc
+}
+
+func TestPreviewAllocation_InStock(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Quantity: 5}
+	uc := newTestUseCase([]*entity.Product{product}, nil)
+
+	plan, err := uc.PreviewAllocation(context.Background(), []CartItem{{ProductID: product.ID, Quantity: 2}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	item := plan.Items[0]
+	if len(item.Allocations) != 1 || item.Allocations[0].Quantity != 2 || item.Allocations[0].Warehouse.Code != "main" {
+		t.Fatalf("expected 2 units from main, got %+v", item.Allocations)
+	}
+	if item.IsSplit() || !plan.IsFullyAllocated() {
+		t.Error("expected a single complete shipment")
+	}
+	if product.Quantity != 5 {
+		t.Error("expected stock to be left untouched")
+	}
+}
+
+func TestPreviewAllocation_PartialStockIsSplit(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Quantity: 3}
+	uc := newTestUseCase([]*entity.Product{product}, nil)
+
+	plan, err := uc.PreviewAllocation(context.Background(), []CartItem{{ProductID: product.ID, Quantity: 5}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	item := plan.Items[0]
+	if item.Allocations[0].Quantity != 3 || item.Unallocated != 2 {
+		t.Errorf("expected 3 allocated and 2 unallocated, got %d and %d", item.Allocations[0].Quantity, item.Unallocated)
+	}
+	if !item.IsSplit() || plan.IsFullyAllocated() {
+		t.Error("expected a split, partially allocated plan")
+	}
+}
+
+func TestPreviewAllocation_LinesShareStock(t *testing.T) {
+	productID := uuid.New()
+	variant := &entity.ProductVariant{ID: uuid.New(), ProductID: productID, Quantity: 4}
+	uc := newTestUseCase(nil, []*entity.ProductVariant{variant})
+
+	plan, err := uc.PreviewAllocation(context.Background(), []CartItem{
+		{ProductID: productID, VariantID: &variant.ID, Quantity: 3},
+		{ProductID: productID, VariantID: &variant.ID, Quantity: 3},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if plan.Items[1].Allocations[0].Quantity != 1 || plan.Items[1].Unallocated != 2 {
+		t.Errorf("expected the second line to get the remaining unit, got %+v", plan.Items[1])
+	}
+}
+
+func TestPreviewAllocation_OutOfStock(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Quantity: 0}
+	uc := newTestUseCase([]*entity.Product{product}, nil)
+
+	plan, err := uc.PreviewAllocation(context.Background(), []CartItem{{ProductID: product.ID, Quantity: 1}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(plan.Items[0].Allocations) != 0 || plan.ShipDate() != nil {
+		t.Error("expected nothing to be allocated")
+	}
+}
+
+func TestPreviewAllocation_Errors(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Quantity: 5}
+	variant := &entity.ProductVariant{ID: uuid.New(), ProductID: uuid.New(), Quantity: 5}
+	unknown := uuid.New()
+	uc := newTestUseCase([]*entity.Product{product}, []*entity.ProductVariant{variant})
+
+	tests := []struct {
+		name  string
+		items []CartItem
+		want  error
+	}{
+		{"empty cart", nil, ErrEmptyCart},
+		{"zero quantity", []CartItem{{ProductID: product.ID}}, ErrInvalidQuantity},
+		{"unknown product", []CartItem{{ProductID: uuid.New(), Quantity: 1}}, ErrProductNotFound},
+		{"unknown variant", []CartItem{{ProductID: product.ID, VariantID: &unknown, Quantity: 1}}, ErrVariantNotFound},
+		{"variant of another product", []CartItem{{ProductID: product.ID, VariantID: &variant.ID, Quantity: 1}}, ErrVariantMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := uc.PreviewAllocation(context.Background(), tt.items); !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package analytics
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+var (
+	ErrInvalidPeriod    = entity.ValidationError("Invalid period. Must be 'day', 'week' or 'month'")
+	ErrInvalidDateRange = entity.ValidationError("Date range ends before it starts")
+	ErrDateRangeTooLong = entity.ValidationError("Date range must be at most 731 days")
+	ErrInvalidLimit     = entity.ValidationError("Limit must be between 1 and 100")
+)
+
+const (
+	// defaultRangeDays is the range reported when no dates are given, today included
+	defaultRangeDays = 30
+	maxRangeDays     = 731
+	defaultTopLimit  = 10
+	maxTopLimit      = 100
+)
+
+// DateRange selects the orders placed between From and To, both inclusive
+// dates in Location, UTC when nil. A zero To means today and a zero From the
+// 30 days up to To.
+type DateRange struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+}
+
+// until is the exclusive end of the range, the start of the day after To
+func (r DateRange) until() time.Time {
+	return r.To.AddDate(0, 0, 1)
+}
+
+// bounds returns the start and exclusive end of the range in UTC, the zone
+// order times are stored in
+func (r DateRange) bounds() (time.Time, time.Time) {
+	return r.From.UTC(), r.until().UTC()
+}
+
+func (r DateRange) location() *time.Location {
+	if r.Location == nil {
+		return time.UTC
+	}
+	return r.Location
+}
+
+type RevenueReport struct {
+	Range  DateRange
+	Period entity.AnalyticsPeriod
+	Points []entity.RevenuePoint // One per period, including those without sales
+}
+
+type TopProductsReport struct {
+	Range    DateRange
+	Products []entity.ProductSales
+}
+
+type OrderStatusReport struct {
+	Range  DateRange
+	Counts []entity.OrderStatusCount // Every status, in lifecycle order
+}
+
+type SummaryReport struct {
+	Range   DateRange
+	Summary *entity.SalesSummary
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface AnalyticsService -out ../../internal/testing/servicemocks
+
+type AnalyticsService interface {
+	// Revenue returns the revenue of each day, week or month in the range
+	Revenue(ctx context.Context, period entity.AnalyticsPeriod, dates DateRange) (*RevenueReport, error)
+	// TopProducts returns the best selling products by units, 10 unless limit is set
+	TopProducts(ctx context.Context, dates DateRange, limit int) (*TopProductsReport, error)
+	OrdersByStatus(ctx context.Context, dates DateRange) (*OrderStatusReport, error)
+	// Summary returns order counts, revenue, average order value and new customers
+	Summary(ctx context.Context, dates DateRange) (*SummaryReport, error)
+}
+
+type UseCase struct {
+	repo repository.AnalyticsRepository
+	now  func() time.Time
+}
+
+func NewUseCase(repo repository.AnalyticsRepository) *UseCase {
+	return &UseCase{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+func (uc *UseCase) Revenue(ctx context.Context, period entity.AnalyticsPeriod, dates DateRange) (*RevenueReport, error) {
+	if period == "" {
+		period = entity.PeriodDay
+	}
+	if !period.IsValid() {
+		return nil, ErrInvalidPeriod
+	}
+	dates, err := uc.resolve(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	from, until := dates.bounds()
+	points, err := uc.repo.RevenueByPeriod(ctx, period, from, until, dates.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RevenueReport{
+		Range:  dates,
+		Period: period,
+		Points: entity.FillRevenueSeries(points, period, dates.From, dates.until()),
+	}, nil
+}
+
+func (uc *UseCase) TopProducts(ctx context.Context, dates DateRange, limit int) (*TopProductsReport, error) {
+	if limit == 0 {
+		limit = defaultTopLimit
+	}
+	if limit < 1 || limit > maxTopLimit {
+		return nil, ErrInvalidLimit
+	}
+	dates, err := uc.resolve(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	from, until := dates.bounds()
+	products, err := uc.repo.TopProducts(ctx, from, until, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TopProductsReport{Range: dates, Products: products}, nil
+}
+
+func (uc *UseCase) OrdersByStatus(ctx context.Context, dates DateRange) (*OrderStatusReport, error) {
+	dates, err := uc.resolve(dates)
+	if err != nil {
+		return nil, err
+	}
+
+	from, until := dates.bounds()
+	found, err := uc.repo.CountOrdersByStatus(ctx, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	byStatus := make(map[entity.OrderStatus]int, len(found))
+	for _, count := range found {
+		byStatus[count.Status] = count.Count
+	}
+	counts := make([]entity.OrderStatusCount, 0, len(entity.OrderStatuses))
+	for _, status := range entity.OrderStatuses {
+		counts = append(counts, entity.OrderStatusCount{Status: status, Count: byStatus[status]})
+	}
+
+	return &OrderStatusReport{Range: dates, Counts: counts}, nil
+}
+
+func (uc *UseCase) Summary(ctx context.Context, dates DateRange) (*SummaryReport, error) {
+	dates, err := uc.resolve(dates)
+	if err != nil {
+		return nil, err
+	}
+	from, until := dates.bounds()
+
+	statuses, err := uc.repo.CountOrdersByStatus(ctx, from, until)
+	if err != nil {
+		return nil, err
+	}
+	orders := 0
+	for _, count := range statuses {
+		orders += count.Count
+	}
+
+	paidOrders, revenue, err := uc.repo.SumRevenue(ctx, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	newCustomers, err := uc.repo.CountNewCustomers(ctx, from, until)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SummaryReport{
+		Range:   dates,
+		Summary: entity.NewSalesSummary(orders, paidOrders, revenue, newCustomers),
+	}, nil
+}
+
+// resolve fills in the default dates and checks the range
+func (uc *UseCase) resolve(dates DateRange) (DateRange, error) {
+	loc := dates.location()
+	dates.Location = loc
+	if dates.To.IsZero() {
+		dates.To = uc.now()
+	}
+	dates.To = startOfDay(dates.To, loc)
+	if dates.From.IsZero() {
+		dates.From = dates.To.AddDate(0, 0, 1-defaultRangeDays)
+	}
+	dates.From = startOfDay(dates.From, loc)
+
+	if dates.To.Before(dates.From) {
+		return dates, ErrInvalidDateRange
+	}
+	if dates.until().After(dates.From.AddDate(0, 0, maxRangeDays)) {
+		return dates, ErrDateRangeTooLong
+	}
+	return dates, nil
+}
+
+// startOfDay returns the midnight in loc that starts the day of t there
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
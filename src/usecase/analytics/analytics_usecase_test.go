@@ -0,0 +1,175 @@
+package analytics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stored is what the analytics repository answers with
+type stored struct {
+	points       []entity.RevenuePoint
+	products     []entity.ProductSales
+	statuses     []entity.OrderStatusCount
+	paidOrders   int
+	revenue      float64
+	newCustomers int
+}
+
+// queried records the arguments of the last analytics query
+type queried struct {
+	from, until time.Time
+	loc         *time.Location
+	limit       int
+}
+
+func newTestUseCase(data stored) (*UseCase, *queried) {
+	repo := new(mocks.AnalyticsRepository)
+	q := &queried{}
+	repo.On("RevenueByPeriod", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(data.points, nil).
+		Run(func(args mock.Arguments) {
+			q.from, q.until, q.loc = args.Get(2).(time.Time), args.Get(3).(time.Time), args.Get(4).(*time.Location)
+		})
+	repo.On("TopProducts", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(data.products, nil).
+		Run(func(args mock.Arguments) {
+			q.from, q.until, q.limit = args.Get(1).(time.Time), args.Get(2).(time.Time), args.Get(3).(int)
+		})
+	repo.On("CountOrdersByStatus", mock.Anything, mock.Anything, mock.Anything).Return(data.statuses, nil)
+	repo.On("SumRevenue", mock.Anything, mock.Anything, mock.Anything).Return(data.paidOrders, data.revenue, nil)
+	repo.On("CountNewCustomers", mock.Anything, mock.Anything, mock.Anything).Return(data.newCustomers, nil)
+
+	uc := NewUseCase(repo)
+	uc.now = func() time.Time { return time.Date(2024, 5, 31, 15, 0, 0, 0, time.UTC) }
+	return uc, q
+}
+
+func day(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestRevenue(t *testing.T) {
+	t.Run("Defaults to daily revenue over the last 30 days", func(t *testing.T) {
+		uc, q := newTestUseCase(stored{points: []entity.RevenuePoint{
+			{PeriodStart: day("2024-05-10"), Orders: 3, Revenue: 240},
+		}})
+
+		report, err := uc.Revenue(context.Background(), "", DateRange{})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.PeriodDay, report.Period)
+		assert.Equal(t, day("2024-05-02"), report.Range.From)
+		assert.Equal(t, day("2024-05-31"), report.Range.To)
+		assert.Equal(t, day("2024-06-01"), q.until, "The last day is included")
+		assert.Len(t, report.Points, 30)
+		assert.Equal(t, 240.0, report.Points[8].Revenue)
+	})
+
+	t.Run("Weekly buckets", func(t *testing.T) {
+		uc, _ := newTestUseCase(stored{})
+
+		report, err := uc.Revenue(context.Background(), entity.PeriodWeek, DateRange{From: day("2024-05-01"), To: day("2024-05-31")})
+
+		require.NoError(t, err)
+		assert.Len(t, report.Points, 5)
+		assert.Equal(t, day("2024-04-29"), report.Points[0].PeriodStart)
+	})
+
+	t.Run("Days in the client's time zone", func(t *testing.T) {
+		saoPaulo, err := time.LoadLocation("America/Sao_Paulo")
+		require.NoError(t, err)
+		may10 := time.Date(2024, 5, 10, 0, 0, 0, 0, saoPaulo)
+		uc, q := newTestUseCase(stored{points: []entity.RevenuePoint{{PeriodStart: may10, Orders: 1, Revenue: 50}}})
+
+		report, err := uc.Revenue(context.Background(), entity.PeriodDay, DateRange{Location: saoPaulo})
+
+		require.NoError(t, err)
+		// Now is 12:00 on May 31 in São Paulo, three hours behind UTC
+		assert.Equal(t, time.Date(2024, 5, 31, 0, 0, 0, 0, saoPaulo), report.Range.To)
+		assert.Equal(t, saoPaulo, q.loc)
+		assert.Equal(t, time.Date(2024, 5, 2, 3, 0, 0, 0, time.UTC), q.from, "The repository gets the range in UTC")
+		assert.Equal(t, time.UTC, q.until.Location())
+		assert.Equal(t, 50.0, report.Points[8].Revenue)
+		assert.True(t, report.Points[8].PeriodStart.Equal(may10))
+	})
+
+	t.Run("Rejects bad input", func(t *testing.T) {
+		uc, _ := newTestUseCase(stored{})
+
+		_, err := uc.Revenue(context.Background(), "year", DateRange{})
+		assert.ErrorIs(t, err, ErrInvalidPeriod)
+
+		_, err = uc.Revenue(context.Background(), entity.PeriodDay, DateRange{From: day("2024-05-10"), To: day("2024-05-01")})
+		assert.ErrorIs(t, err, ErrInvalidDateRange)
+
+		_, err = uc.Revenue(context.Background(), entity.PeriodMonth, DateRange{From: day("2021-01-01"), To: day("2024-01-01")})
+		assert.ErrorIs(t, err, ErrDateRangeTooLong)
+	})
+}
+
+func TestTopProducts(t *testing.T) {
+	uc, q := newTestUseCase(stored{products: []entity.ProductSales{
+		{ProductID: uuid.New(), Name: "Laptop", Units: 12, Revenue: 11988},
+	}})
+
+	report, err := uc.TopProducts(context.Background(), DateRange{From: day("2024-05-01")}, 0)
+
+	require.NoError(t, err)
+	assert.Equal(t, 10, q.limit)
+	assert.Equal(t, day("2024-05-01"), q.from)
+	assert.Len(t, report.Products, 1)
+
+	_, err = uc.TopProducts(context.Background(), DateRange{}, 101)
+	assert.ErrorIs(t, err, ErrInvalidLimit)
+}
+
+func TestOrdersByStatus(t *testing.T) {
+	uc, _ := newTestUseCase(stored{statuses: []entity.OrderStatusCount{
+		{Status: entity.Completed, Count: 4},
+	}})
+
+	report, err := uc.OrdersByStatus(context.Background(), DateRange{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []entity.OrderStatusCount{
+		{Status: entity.Review, Count: 0},
+		{Status: entity.Pending, Count: 0},
+		{Status: entity.Processing, Count: 0},
+		{Status: entity.Shipped, Count: 0},
+		{Status: entity.Delivered, Count: 0},
+		{Status: entity.Completed, Count: 4},
+		{Status: entity.Cancelled, Count: 0},
+		{Status: entity.Refunded, Count: 0},
+	}, report.Counts)
+}
+
+func TestSummary(t *testing.T) {
+	uc, _ := newTestUseCase(stored{
+		statuses: []entity.OrderStatusCount{
+			{Status: entity.Pending, Count: 2},
+			{Status: entity.Completed, Count: 4},
+			{Status: entity.Cancelled, Count: 1},
+		},
+		paidOrders:   4,
+		revenue:      500,
+		newCustomers: 3,
+	})
+
+	report, err := uc.Summary(context.Background(), DateRange{})
+
+	require.NoError(t, err)
+	assert.Equal(t, 7, report.Summary.Orders)
+	assert.Equal(t, 4, report.Summary.PaidOrders)
+	assert.Equal(t, 125.0, report.Summary.AverageOrderValue)
+	assert.Equal(t, 3, report.Summary.NewCustomers)
+}
@@ -0,0 +1,193 @@
+package anonymize
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+// Redacted replaces the free text of notes and reasons
+const Redacted = "[redacted]"
+
+// InvoiceRenderer renders an issued invoice again, so its document shows the
+// anonymized customer
+type InvoiceRenderer interface {
+	RenderInvoice(ctx context.Context, invoice *entity.Invoice) ([]byte, error)
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface AnonymizeService -out ../../internal/testing/servicemocks
+
+type AnonymizeService interface {
+	// Anonymize replaces the personal data in the database with pseudonyms
+	Anonymize(ctx context.Context, opts Options) (*Summary, error)
+}
+
+type Options struct {
+	// Key derives the pseudonyms, the same key gives the same pseudonyms on
+	// every run
+	Key []byte
+	// Password replaces the password of every account, so staging can sign in
+	// as anyone
+	Password string
+}
+
+// Summary counts what was anonymized. Staff lists the admin and support
+// accounts by their new email.
+type Summary struct {
+	Users            int
+	Customers        int
+	Addresses        int
+	BlocklistEntries int
+	Invoices         int
+	Redacted         int64
+	Staff            []*entity.User
+}
+
+// UseCase scrubs the personal data of a copy of the database so it can be
+// used outside production. Emails, names, phone numbers and street addresses
+// are replaced with pseudonyms of the same shape and free text is redacted,
+// while IDs, orders, amounts, dates, cities and countries are kept, so the
+// copy still relates and adds up like production does.
+type UseCase struct {
+	repo      repository.AnonymizationRepository
+	invoices  InvoiceRenderer
+	batchSize int
+}
+
+func NewUseCase(repo repository.AnonymizationRepository, invoices InvoiceRenderer, batchSize int) *UseCase {
+	return &UseCase{
+		repo:      repo,
+		invoices:  invoices,
+		batchSize: batchSize,
+	}
+}
+
+// Anonymize scrubs the accounts, then the customer data, the blocklist and
+// the free text, and renders the invoices again last, as they show the
+// accounts. A failure partway leaves the data scrubbed so far. Running again
+// scrubs everything again, emails already pseudonymized are kept.
+func (uc *UseCase) Anonymize(ctx context.Context, opts Options) (*Summary, error) {
+	if len(opts.Key) == 0 {
+		return nil, errors.New("A key is required to derive the pseudonyms")
+	}
+	if opts.Password == "" {
+		return nil, errors.New("A password for the accounts is required")
+	}
+
+	// Hashing once is enough, every account gets the same password
+	credentials := &entity.User{}
+	if err := credentials.SetPassword(opts.Password); err != nil {
+		return nil, err
+	}
+
+	p := NewPseudonymizer(opts.Key)
+	summary := &Summary{}
+
+	err := batches(ctx, uc.batchSize, uc.repo.ListUsers, func(u *entity.User) uuid.UUID { return u.ID },
+		func(users []*entity.User) error {
+			for _, user := range users {
+				user.Email = p.Email(user.Email)
+				user.Name = p.Name(user.Name)
+				user.PasswordHash = credentials.PasswordHash
+				if user.Role != entity.RoleCustomer {
+					summary.Staff = append(summary.Staff, user)
+				}
+			}
+			summary.Users += len(users)
+			return uc.repo.UpdateUsers(ctx, users)
+		})
+	if err != nil {
+		return summary, err
+	}
+
+	err = batches(ctx, uc.batchSize, uc.repo.ListCustomers, func(c *entity.Customer) uuid.UUID { return c.UserID },
+		func(customers []*entity.Customer) error {
+			for _, customer := range customers {
+				// A pseudonym may be someone's real number: the copy
+				// must not text it, so it is left unverified
+				customer.Phone = p.Phone(customer.Phone)
+				customer.PhoneVerifiedAt = nil
+				customer.PhoneCodeHash = ""
+				customer.PhoneCodeSentAt = nil
+				for i := range customer.Addresses {
+					address := &customer.Addresses[i]
+					address.Recipient = p.Name(address.Recipient)
+					address.Line1 = p.Street(address.Line1)
+					address.Line2 = p.Unit(address.Line2)
+					address.PostalCode = p.PostalCode(address.PostalCode)
+				}
+				summary.Addresses += len(customer.Addresses)
+			}
+			summary.Customers += len(customers)
+			return uc.repo.UpdateCustomers(ctx, customers)
+		})
+	if err != nil {
+		return summary, err
+	}
+
+	err = batches(ctx, uc.batchSize, uc.repo.ListBlockedEmails, func(e *entity.BlocklistEntry) uuid.UUID { return e.ID },
+		func(entries []*entity.BlocklistEntry) error {
+			for _, entry := range entries {
+				if entry.Kind == entity.BlockDomain {
+					entry.Value = p.Domain(entry.Value)
+				} else {
+					entry.Value = p.Email(entry.Value)
+				}
+			}
+			summary.BlocklistEntries += len(entries)
+			return uc.repo.UpdateBlocklistEntries(ctx, entries)
+		})
+	if err != nil {
+		return summary, err
+	}
+
+	summary.Redacted, err = uc.repo.RedactText(ctx, Redacted)
+	if err != nil {
+		return summary, err
+	}
+
+	err = batches(ctx, uc.batchSize, uc.repo.ListInvoices, func(i *entity.Invoice) uuid.UUID { return i.ID },
+		func(invoices []*entity.Invoice) error {
+			for _, invoice := range invoices {
+				pdf, err := uc.invoices.RenderInvoice(ctx, invoice)
+				if err != nil {
+					return err
+				}
+				invoice.PDF = pdf
+			}
+			summary.Invoices += len(invoices)
+			return uc.repo.UpdateInvoices(ctx, invoices)
+		})
+	return summary, err
+}
+
+// batches lists rows batch by batch, each after the last row of the one
+// before, and hands every batch to update
+func batches[T any](ctx context.Context, size int,
+	list func(ctx context.Context, after uuid.UUID, limit int) ([]T, error),
+	id func(T) uuid.UUID,
+	update func([]T) error,
+) error {
+	after := uuid.Nil
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		rows, err := list(ctx, after, size)
+		if err != nil || len(rows) == 0 {
+			return err
+		}
+		if err := update(rows); err != nil {
+			return err
+		}
+
+		if len(rows) < size {
+			return nil
+		}
+		after = id(rows[len(rows)-1])
+	}
+}
@@ -0,0 +1,111 @@
+package anonymize
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRenderer struct {
+	rendered []uuid.UUID
+}
+
+func (r *fakeRenderer) RenderInvoice(ctx context.Context, invoice *entity.Invoice) ([]byte, error) {
+	r.rendered = append(r.rendered, invoice.ID)
+	return []byte("%PDF " + invoice.Number), nil
+}
+
+func TestAnonymize(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.AnonymizationRepository)
+	renderer := &fakeRenderer{}
+	uc := NewUseCase(repo, renderer, 2)
+
+	admin := &entity.User{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), Email: "boss@acme.com", Name: "Ana Boss", Role: entity.RoleAdmin}
+	jane := &entity.User{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), Email: "jane@gmail.com", Name: "Jane Doe", Role: entity.RoleCustomer}
+	john := &entity.User{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), Email: "john@gmail.com", Name: "John Roe", Role: entity.RoleCustomer}
+	repo.On("ListUsers", ctx, uuid.Nil, 2).Return([]*entity.User{admin, jane}, nil).Once()
+	repo.On("ListUsers", ctx, jane.ID, 2).Return([]*entity.User{john}, nil).Once()
+	repo.On("UpdateUsers", ctx, mock.Anything).Return(nil).Twice()
+
+	verifiedAt := time.Now()
+	customer := &entity.Customer{UserID: jane.ID, Phone: "+1 555 0100", PhoneVerifiedAt: &verifiedAt, Addresses: []entity.CustomerAddress{
+		{Recipient: "Jane Doe", Line1: "1 Real Street", City: "Lisbon", Country: "PT", PostalCode: "1000-001"},
+	}}
+	repo.On("ListCustomers", ctx, uuid.Nil, 2).Return([]*entity.Customer{customer}, nil).Once()
+	repo.On("UpdateCustomers", ctx, []*entity.Customer{customer}).Return(nil).Once()
+
+	blockedDomain := &entity.BlocklistEntry{ID: uuid.New(), Kind: entity.BlockDomain, Value: "acme.com"}
+	blockedEmail := &entity.BlocklistEntry{ID: uuid.New(), Kind: entity.BlockEmail, Value: "jane@gmail.com"}
+	repo.On("ListBlockedEmails", ctx, uuid.Nil, 2).Return([]*entity.BlocklistEntry{blockedDomain, blockedEmail}, nil).Once()
+	repo.On("ListBlockedEmails", ctx, blockedEmail.ID, 2).Return([]*entity.BlocklistEntry{}, nil).Once()
+	repo.On("UpdateBlocklistEntries", ctx, mock.Anything).Return(nil).Once()
+
+	repo.On("RedactText", ctx, Redacted).Return(int64(4), nil).Once()
+
+	invoice := &entity.Invoice{ID: uuid.New(), Number: "INV-1"}
+	repo.On("ListInvoices", ctx, uuid.Nil, 2).Return([]*entity.Invoice{invoice}, nil).Once()
+	repo.On("UpdateInvoices", ctx, []*entity.Invoice{invoice}).Return(nil).Once()
+
+	summary, err := uc.Anonymize(ctx, Options{Key: []byte("key"), Password: "staging1234"})
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+
+	assert.Equal(t, 3, summary.Users)
+	assert.Equal(t, 1, summary.Customers)
+	assert.Equal(t, 1, summary.Addresses)
+	assert.Equal(t, 2, summary.BlocklistEntries)
+	assert.Equal(t, 1, summary.Invoices)
+	assert.Equal(t, int64(4), summary.Redacted)
+	assert.Equal(t, []*entity.User{admin}, summary.Staff)
+
+	p := NewPseudonymizer([]byte("key"))
+	assert.Equal(t, p.Email("jane@gmail.com"), jane.Email)
+	assert.NotEqual(t, "Jane Doe", jane.Name)
+	assert.True(t, john.CheckPassword("staging1234"))
+	assert.Equal(t, jane.Email, blockedEmail.Value, "the blocklist still matches the account")
+	assert.Equal(t, p.Domain("acme.com"), blockedDomain.Value)
+
+	address := customer.Addresses[0]
+	assert.NotEqual(t, "+1 555 0100", customer.Phone)
+	assert.False(t, customer.PhoneVerified(), "a pseudonymous phone is not texted")
+	assert.Equal(t, jane.Name, address.Recipient, "the same name gets the same pseudonym")
+	assert.NotEqual(t, "1 Real Street", address.Line1)
+	assert.Equal(t, "Lisbon", address.City)
+	assert.Equal(t, "PT", address.Country)
+
+	assert.Equal(t, []uuid.UUID{invoice.ID}, renderer.rendered)
+	assert.Equal(t, []byte("%PDF INV-1"), invoice.PDF)
+}
+
+func TestAnonymize_RequiresKeyAndPassword(t *testing.T) {
+	uc := NewUseCase(new(mocks.AnonymizationRepository), &fakeRenderer{}, 10)
+
+	_, err := uc.Anonymize(context.Background(), Options{Password: "staging1234"})
+	assert.Error(t, err)
+	_, err = uc.Anonymize(context.Background(), Options{Key: []byte("key")})
+	assert.Error(t, err)
+}
+
+func TestAnonymize_StopsOnError(t *testing.T) {
+	ctx := context.Background()
+	repo := new(mocks.AnonymizationRepository)
+	uc := NewUseCase(repo, &fakeRenderer{}, 10)
+
+	user := &entity.User{ID: uuid.New(), Email: "jane@gmail.com", Role: entity.RoleCustomer}
+	repo.On("ListUsers", ctx, uuid.Nil, 10).Return([]*entity.User{user}, nil).Once()
+	repo.On("UpdateUsers", ctx, mock.Anything).Return(errors.New("database error")).Once()
+
+	summary, err := uc.Anonymize(ctx, Options{Key: []byte("key"), Password: "staging1234"})
+	assert.EqualError(t, err, "database error")
+	assert.Equal(t, 1, summary.Users)
+	repo.AssertNotCalled(t, "ListCustomers", mock.Anything, mock.Anything, mock.Anything)
+}
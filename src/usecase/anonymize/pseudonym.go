@@ -0,0 +1,148 @@
+package anonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// publicDomains are email providers shared by many people. Their addresses
+// keep the provider, so staging sees how customers spread over them.
+var publicDomains = map[string]bool{
+	"gmail.com": true, "googlemail.com": true, "yahoo.com": true, "hotmail.com": true,
+	"outlook.com": true, "live.com": true, "msn.com": true, "icloud.com": true,
+	"me.com": true, "aol.com": true, "proton.me": true, "protonmail.com": true,
+	"gmx.com": true, "gmx.de": true, "web.de": true, "yandex.ru": true,
+	"mail.ru": true, "qq.com": true, "163.com": true, "uol.com.br": true,
+	"bol.com.br": true, "terra.com.br": true,
+}
+
+var (
+	firstNames = []string{"Ada", "Ben", "Chloe", "Daniel", "Elena", "Farid", "Gabriela", "Henrik", "Ines", "Jonas",
+		"Keiko", "Liam", "Maya", "Noah", "Olga", "Pedro", "Quinn", "Rosa", "Samuel", "Tara", "Umar", "Vera", "Wen", "Yara"}
+	lastNames = []string{"Andrade", "Brooks", "Carvalho", "Dietrich", "Eriksen", "Fontaine", "Gomes", "Hansen", "Ibrahim",
+		"Jansen", "Kim", "Lindqvist", "Moreau", "Nakamura", "Oliveira", "Petrov", "Rossi", "Schmidt", "Tanaka", "Vargas"}
+	streets     = []string{"Maple", "Oak", "Cedar", "Elm", "Harbor", "Hill", "Lake", "Meadow", "Mill", "Park", "River", "Station"}
+	streetTypes = []string{"Street", "Avenue", "Road", "Lane", "Way"}
+)
+
+// Pseudonymizer replaces personal data with made-up values of the same shape.
+// The values are derived from the originals with a keyed hash: the same input
+// always gets the same pseudonym, so values repeated across tables, like an
+// email on the blocklist and on an account, still match, while nobody without
+// the key can tell what the original was.
+type Pseudonymizer struct {
+	key []byte
+}
+
+func NewPseudonymizer(key []byte) *Pseudonymizer {
+	return &Pseudonymizer{key: key}
+}
+
+// sum hashes the value, kind keeping the pseudonyms of different fields apart
+func (p *Pseudonymizer) sum(kind, value string) []byte {
+	mac := hmac.New(sha256.New, p.key)
+	mac.Write([]byte(kind + "\x00" + value))
+	return mac.Sum(nil)
+}
+
+// pick chooses one of the options for the value
+func (p *Pseudonymizer) pick(kind, value string, options []string) string {
+	n := binary.BigEndian.Uint64(p.sum(kind, value))
+	return options[n%uint64(len(options))]
+}
+
+// Email returns an address at the pseudonym of the domain. Every address is
+// under the reserved .example top level domain, so none can be delivered.
+func (p *Pseudonymizer) Email(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if strings.HasPrefix(email, "user-") && strings.HasSuffix(email, ".example") {
+		return email
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return "user-" + hex.EncodeToString(p.sum("email", email))[:16] + "@invalid.example"
+	}
+	return "user-" + hex.EncodeToString(p.sum("email", email))[:16] + "@" + p.Domain(email[at+1:])
+}
+
+// Domain returns the pseudonym of an email domain: public providers keep
+// their name, e.g. gmail.com.example, any other domain gets a made-up one,
+// the same for every address at it
+func (p *Pseudonymizer) Domain(domain string) string {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if strings.HasSuffix(domain, ".example") {
+		return domain
+	}
+	if publicDomains[domain] {
+		return domain + ".example"
+	}
+	return "org-" + hex.EncodeToString(p.sum("domain", domain))[:10] + ".example"
+}
+
+// Name returns a made-up name with as many words as the original, up to a
+// first and a last name. Empty names stay empty.
+func (p *Pseudonymizer) Name(name string) string {
+	words := strings.Fields(name)
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		return p.pick("first", name, firstNames)
+	}
+	return p.pick("first", name, firstNames) + " " + p.pick("last", name, lastNames)
+}
+
+// Phone keeps the first three characters of a number, its country or area
+// code, and its punctuation, and replaces the other digits
+func (p *Pseudonymizer) Phone(phone string) string {
+	return p.scramble("phone", phone, 3)
+}
+
+// PostalCode keeps the first two characters of a postal code, which locate a
+// region, and replaces the other letters and digits
+func (p *Pseudonymizer) PostalCode(code string) string {
+	return p.scramble("postal", code, 2)
+}
+
+// Street returns a made-up street line, e.g. 42 Maple Avenue
+func (p *Pseudonymizer) Street(line string) string {
+	if strings.TrimSpace(line) == "" {
+		return ""
+	}
+	number := binary.BigEndian.Uint16(p.sum("number", line))%999 + 1
+	return strconv.Itoa(int(number)) + " " + p.pick("street", line, streets) + " " + p.pick("street-type", line, streetTypes)
+}
+
+// Unit returns a made-up second address line, e.g. Apt 12. Empty lines stay
+// empty.
+func (p *Pseudonymizer) Unit(line string) string {
+	if strings.TrimSpace(line) == "" {
+		return ""
+	}
+	number := binary.BigEndian.Uint16(p.sum("unit", line))%99 + 1
+	return "Apt " + strconv.Itoa(int(number))
+}
+
+// scramble replaces the letters and digits of value after the first keep
+// characters with others of the same kind, keeping everything else
+func (p *Pseudonymizer) scramble(kind, value string, keep int) string {
+	sum := p.sum(kind, value)
+	out := []rune(value)
+	for i := keep; i < len(out); i++ {
+		b := sum[i%len(sum)] ^ byte(i/len(sum))
+		switch {
+		case unicode.IsDigit(out[i]):
+			out[i] = rune('0' + b%10)
+		case unicode.IsUpper(out[i]):
+			out[i] = rune('A' + b%26)
+		case unicode.IsLetter(out[i]):
+			out[i] = rune('a' + b%26)
+		}
+	}
+	return string(out)
+}
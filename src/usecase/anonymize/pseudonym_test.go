@@ -0,0 +1,48 @@
+package anonymize
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPseudonymizer_Email(t *testing.T) {
+	p := NewPseudonymizer([]byte("key"))
+
+	email := p.Email("Jane.Doe@Gmail.com")
+	assert.Regexp(t, regexp.MustCompile(`^user-[0-9a-f]{16}@gmail\.com\.example$`), email)
+	assert.Equal(t, email, p.Email("jane.doe@gmail.com "), "the same address gets the same pseudonym")
+	assert.Equal(t, email, p.Email(email), "pseudonyms are kept on a second run")
+	assert.NotEqual(t, email, p.Email("john.doe@gmail.com"))
+	assert.NotEqual(t, email, NewPseudonymizer([]byte("other")).Email("jane.doe@gmail.com"), "another key gives other pseudonyms")
+
+	jane, john := p.Email("jane@acme.com"), p.Email("john@acme.com")
+	assert.NotContains(t, jane, "acme")
+	assert.Equal(t, jane[strings.Index(jane, "@"):], john[strings.Index(john, "@"):], "addresses at one domain share its pseudonym")
+	assert.Equal(t, p.Domain("acme.com"), jane[strings.Index(jane, "@")+1:], "blocked domains match the addresses at them")
+
+	assert.True(t, strings.HasSuffix(p.Email("not-an-email"), "@invalid.example"))
+}
+
+func TestPseudonymizer_KeepsShape(t *testing.T) {
+	p := NewPseudonymizer([]byte("key"))
+
+	assert.Empty(t, p.Name(""))
+	assert.Len(t, strings.Fields(p.Name("Cher")), 1)
+	assert.Len(t, strings.Fields(p.Name("Jane Mary Doe")), 2)
+	assert.Equal(t, p.Name("Jane Doe"), p.Name("Jane Doe"))
+
+	phone := p.Phone("+55 (11) 98765-4321")
+	assert.Regexp(t, regexp.MustCompile(`^\+55 \(\d\d\) \d{5}-\d{4}$`), phone)
+	assert.NotEqual(t, "+55 (11) 98765-4321", phone)
+
+	postal := p.PostalCode("SW1A 1AA")
+	assert.Regexp(t, regexp.MustCompile(`^SW[A-Z0-9]{2} [0-9][A-Z]{2}$`), postal)
+
+	assert.Regexp(t, regexp.MustCompile(`^\d{1,3} \w+ \w+$`), p.Street("221B Baker Street"))
+	assert.Regexp(t, regexp.MustCompile(`^Apt \d{1,2}$`), p.Unit("Flat 3"))
+	assert.Empty(t, p.Street(" "))
+	assert.Empty(t, p.Unit(""))
+}
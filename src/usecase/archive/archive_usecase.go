@@ -0,0 +1,55 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface ArchiveService -out ../../internal/testing/servicemocks
+
+type ArchiveService interface {
+	// ArchiveOrders moves finalized orders older than the given number of years
+	// into cold storage and returns how many orders were archived
+	ArchiveOrders(ctx context.Context, olderThanYears int) (int, error)
+}
+
+type UseCase struct {
+	archiveRepo repository.OrderArchiveRepository
+	batchSize   int
+}
+
+func NewUseCase(archiveRepo repository.OrderArchiveRepository, batchSize int) *UseCase {
+	return &UseCase{
+		archiveRepo: archiveRepo,
+		batchSize:   batchSize,
+	}
+}
+
+func (uc *UseCase) ArchiveOrders(ctx context.Context, olderThanYears int) (int, error) {
+	if olderThanYears < 1 {
+		return 0, errors.New("Orders must be at least 1 year old to be archived")
+	}
+
+	cutoff := time.Now().AddDate(-olderThanYears, 0, 0)
+	total := 0
+
+	// Archive in small batches so each transaction stays short and hot tables are not locked for long
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		moved, err := uc.archiveRepo.ArchiveBatch(ctx, cutoff, uc.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += moved
+		if moved < uc.batchSize {
+			return total, nil
+		}
+	}
+}
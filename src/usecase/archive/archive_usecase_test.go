@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type mockArchiveRepo struct {
+	remaining int
+	calls     int
+	err       error
+}
+
+func (m *mockArchiveRepo) ArchiveBatch(ctx context.Context, cutoff time.Time, batchSize int) (int, error) {
+	m.calls++
+	if m.err != nil {
+		return 0, m.err
+	}
+	moved := batchSize
+	if m.remaining < batchSize {
+		moved = m.remaining
+	}
+	m.remaining -= moved
+	return moved, nil
+}
+
+func (m *mockArchiveRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockArchiveRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	return nil, nil
+}
+
+func (m *mockArchiveRepo) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+var _ repository.OrderArchiveRepository = (*mockArchiveRepo)(nil)
+
+func TestArchiveOrders_ProcessesAllBatches(t *testing.T) {
+	repo := &mockArchiveRepo{remaining: 25}
+	uc := NewUseCase(repo, 10)
+
+	archived, err := uc.ArchiveOrders(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if archived != 25 {
+		t.Errorf("expected 25 archived orders, got %d", archived)
+	}
+	if repo.calls != 3 {
+		t.Errorf("expected 3 batches, got %d", repo.calls)
+	}
+}
+
+func TestArchiveOrders_InvalidAge(t *testing.T) {
+	uc := NewUseCase(&mockArchiveRepo{}, 10)
+
+	if _, err := uc.ArchiveOrders(context.Background(), 0); err == nil {
+		t.Error("expected error for age below 1 year")
+	}
+}
+
+func TestArchiveOrders_StopsOnError(t *testing.T) {
+	uc := NewUseCase(&mockArchiveRepo{err: errors.New("db down")}, 10)
+
+	if _, err := uc.ArchiveOrders(context.Background(), 3); err == nil {
+		t.Error("expected repository error to be returned")
+	}
+}
@@ -0,0 +1,120 @@
+package attribute
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrAttributeNotFound    = entity.NotFoundError("Attribute not found")
+	ErrAttributeExists      = entity.ConflictError("An attribute with this code already exists")
+	ErrProductNotFound      = entity.NotFoundError("Product not found")
+	ErrProductValueNotFound = entity.NotFoundError("Product has no value for this attribute")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface AttributeService -out ../../internal/testing/servicemocks
+
+type AttributeService interface {
+	// CreateAttribute defines a new attribute. Its code, derived from the name,
+	// is the key used to filter products by it.
+	CreateAttribute(ctx context.Context, name string, attributeType entity.AttributeType) (*entity.AttributeDefinition, error)
+	ListAttributes(ctx context.Context) ([]*entity.AttributeDefinition, error)
+	// SetProductAttribute sets a product's value for an attribute, replacing any previous one.
+	// value must match the attribute type: string, float64 or bool.
+	SetProductAttribute(ctx context.Context, productID, attributeID uuid.UUID, value interface{}) (*entity.ProductAttribute, error)
+	RemoveProductAttribute(ctx context.Context, productID, attributeID uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	attributeRepo repository.AttributeRepository
+	productRepo   repository.ProductRepository
+	services      Services
+}
+
+func NewUseCase(attributeRepo repository.AttributeRepository, productRepo repository.ProductRepository, services Services) *UseCase {
+	return &UseCase{
+		attributeRepo: attributeRepo,
+		productRepo:   productRepo,
+		services:      services,
+	}
+}
+
+func (uc *UseCase) CreateAttribute(ctx context.Context, name string, attributeType entity.AttributeType) (*entity.AttributeDefinition, error) {
+	definition := &entity.AttributeDefinition{
+		ID:        entity.NewID(),
+		Name:      name,
+		Type:      attributeType,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if err := definition.Validate(); err != nil {
+		return nil, err
+	}
+
+	definition.Code = entity.Slugify(name)
+	if _, err := uc.attributeRepo.GetDefinitionByCode(ctx, definition.Code); err == nil {
+		return nil, ErrAttributeExists
+	}
+
+	if err := uc.attributeRepo.CreateDefinition(ctx, definition); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE", "Attribute", definition.ID, nil, definition)
+
+	return definition, nil
+}
+
+func (uc *UseCase) ListAttributes(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	return uc.attributeRepo.ListDefinitions(ctx)
+}
+
+func (uc *UseCase) SetProductAttribute(ctx context.Context, productID, attributeID uuid.UUID, value interface{}) (*entity.ProductAttribute, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	definition, err := uc.attributeRepo.GetDefinitionByID(ctx, attributeID)
+	if err != nil {
+		return nil, ErrAttributeNotFound
+	}
+
+	productAttribute := &entity.ProductAttribute{
+		ID:        entity.NewID(),
+		ProductID: productID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := productAttribute.SetValue(definition, value); err != nil {
+		return nil, err
+	}
+
+	if err := uc.attributeRepo.SetProductValue(ctx, productAttribute); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "SET_ATTRIBUTE", "Product", productID, nil, productAttribute)
+
+	return productAttribute, nil
+}
+
+func (uc *UseCase) RemoveProductAttribute(ctx context.Context, productID, attributeID uuid.UUID) error {
+	if err := uc.attributeRepo.DeleteProductValue(ctx, productID, attributeID); err != nil {
+		return ErrProductValueNotFound
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "REMOVE_ATTRIBUTE", "Product", productID,
+		map[string]interface{}{"attribute_id": attributeID}, nil)
+
+	return nil
+}
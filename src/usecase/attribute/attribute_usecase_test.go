@@ -0,0 +1,84 @@
+package attribute
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+)
+
+func TestCreateAttribute_DerivesCode(t *testing.T) {
+	store := memory.NewStore()
+	uc := NewUseCase(memory.NewAttributeRepository(store), memory.NewProductRepository(store), &mockServices.MockServices{})
+
+	definition, err := uc.CreateAttribute(context.Background(), "Country of Origin", entity.AttributeText)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if definition.Code != "country-of-origin" {
+		t.Errorf("expected code country-of-origin, got %s", definition.Code)
+	}
+
+	if _, err := uc.CreateAttribute(context.Background(), "Country of origin", entity.AttributeText); !errors.Is(err, ErrAttributeExists) {
+		t.Errorf("expected ErrAttributeExists, got %v", err)
+	}
+}
+
+func TestCreateAttribute_InvalidType(t *testing.T) {
+	store := memory.NewStore()
+	uc := NewUseCase(memory.NewAttributeRepository(store), memory.NewProductRepository(store), &mockServices.MockServices{})
+
+	if _, err := uc.CreateAttribute(context.Background(), "Material", "list"); err == nil {
+		t.Error("expected an error for an unknown type")
+	}
+}
+
+func TestSetProductAttribute(t *testing.T) {
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	product := &entity.Product{ID: uuid.New(), Name: "Shirt", Price: 10}
+	products.Create(context.Background(), product)
+	uc := NewUseCase(memory.NewAttributeRepository(store), products, &mockServices.MockServices{})
+
+	material, _ := uc.CreateAttribute(context.Background(), "Material", entity.AttributeText)
+
+	value, err := uc.SetProductAttribute(context.Background(), product.ID, material.ID, "Cotton")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if value.ProductID != product.ID || value.Value() != "Cotton" {
+		t.Errorf("unexpected value %+v", value)
+	}
+
+	if _, err := uc.SetProductAttribute(context.Background(), product.ID, material.ID, 42.0); !errors.Is(err, entity.ErrInvalidAttributeValue) {
+		t.Errorf("expected ErrInvalidAttributeValue, got %v", err)
+	}
+	if _, err := uc.SetProductAttribute(context.Background(), uuid.New(), material.ID, "Cotton"); !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+	if _, err := uc.SetProductAttribute(context.Background(), product.ID, uuid.New(), "Cotton"); !errors.Is(err, ErrAttributeNotFound) {
+		t.Errorf("expected ErrAttributeNotFound, got %v", err)
+	}
+}
+
+func TestRemoveProductAttribute(t *testing.T) {
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	product := &entity.Product{ID: uuid.New(), Name: "Shirt", Price: 10}
+	products.Create(context.Background(), product)
+	uc := NewUseCase(memory.NewAttributeRepository(store), products, &mockServices.MockServices{})
+
+	material, _ := uc.CreateAttribute(context.Background(), "Material", entity.AttributeText)
+	uc.SetProductAttribute(context.Background(), product.ID, material.ID, "Cotton")
+
+	if err := uc.RemoveProductAttribute(context.Background(), product.ID, material.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := uc.RemoveProductAttribute(context.Background(), product.ID, material.ID); !errors.Is(err, ErrProductValueNotFound) {
+		t.Errorf("expected ErrProductValueNotFound, got %v", err)
+	}
+}
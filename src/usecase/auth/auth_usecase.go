@@ -8,25 +8,53 @@ import (
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
 )
 
+//go:generate go run ../../internal/tools/mockgen -interface AuthService -out ../../internal/testing/servicemocks
+
 // AuthService defines the interface for authentication operations
 type AuthService interface {
 	Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error)
 	Login(ctx context.Context, req LoginRequest) (*AuthResponse, error)
 	ValidateToken(tokenString string) (*auth.Claims, error)
+	Logout(ctx context.Context, claims *auth.Claims) error
+	RevokeToken(ctx context.Context, tokenString string, reason entity.RevocationReason, revokedBy uuid.UUID) error
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error
+	SetUserActive(ctx context.Context, userID uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error)
+	Impersonate(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*AuthResponse, error)
+}
+
+// ErrTokenRevoked is returned for a token that is valid but was revoked
+var ErrTokenRevoked = errors.New("Token has been revoked")
+
+// ImpersonationLifetime is how long an impersonation token stays valid
+const ImpersonationLifetime = 15 * time.Minute
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetBlocklist() blocklist.Checker
+	GetAccessGate() accesscode.Gate
 }
 
 type UseCase struct {
-	userRepo    repository.UserRepository
-	jwtProvider auth.TokenProvider
+	userRepo       repository.UserRepository
+	revocationRepo repository.TokenRevocationRepository
+	jwtProvider    auth.TokenProvider
+	throttle       *LoginThrottle
+	services       Services
 }
 
-func NewUseCase(userRepo repository.UserRepository, jwtProvider auth.TokenProvider) *UseCase {
+func NewUseCase(userRepo repository.UserRepository, revocationRepo repository.TokenRevocationRepository, jwtProvider auth.TokenProvider, throttle *LoginThrottle, services Services) *UseCase {
 	return &UseCase{
-		userRepo:    userRepo,
-		jwtProvider: jwtProvider,
+		userRepo:       userRepo,
+		revocationRepo: revocationRepo,
+		jwtProvider:    jwtProvider,
+		throttle:       throttle,
+		services:       services,
 	}
 }
 
@@ -35,11 +63,17 @@ type RegisterRequest struct {
 	Password string
 	Name     string
 	Role     string
+	IP       string // Client address, checked against the blocklist
+	// AccessCode is redeemed when registration requires one, unless an admin
+	// creates the account
+	AccessCode string
+	ByAdmin    bool
 }
 
 type LoginRequest struct {
 	Email    string
 	Password string
+	IP       string // Client address, failed attempts are also counted per IP
 }
 
 type AuthResponse struct {
@@ -49,28 +83,38 @@ type AuthResponse struct {
 	Name      string      `json:"name"`
 	Role      entity.Role `json:"role"`
 	ExpiresAt time.Time   `json:"expires_at"`
+	// ImpersonatorID is the admin the token was issued to, for impersonation tokens
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
 }
 
-// Register creates a new user account
+// Register creates a new user account, unless the email or the client IP is
+// blocked. While the store requires access codes to register, a valid code is
+// redeemed for it.
 func (uc *UseCase) Register(ctx context.Context, req RegisterRequest) (*AuthResponse, error) {
+	if err := uc.services.GetBlocklist().Check(ctx, req.Email, req.IP); err != nil {
+		return nil, err
+	}
+
 	existingUser, _ := uc.userRepo.GetByEmail(ctx, req.Email)
 	if existingUser != nil {
-		return nil, errors.New("Email already registered")
+		return nil, entity.ConflictError("Email already registered")
 	}
 
 	role := entity.RoleCustomer
 	if req.Role != "" {
 		if req.Role == string(entity.RoleAdmin) {
 			role = entity.RoleAdmin
+		} else if req.Role == string(entity.RoleSupport) {
+			role = entity.RoleSupport
 		} else if req.Role == string(entity.RoleCustomer) {
 			role = entity.RoleCustomer
 		} else {
-			return nil, errors.New("Invalid role. Must be 'customer' or 'admin'")
+			return nil, entity.ValidationError("Invalid role. Must be 'customer', 'support' or 'admin'")
 		}
 	}
 
 	user := &entity.User{
-		ID:        uuid.New(),
+		ID:        entity.NewID(),
 		Email:     req.Email,
 		Name:      req.Name,
 		Role:      role,
@@ -87,10 +131,21 @@ func (uc *UseCase) Register(ctx context.Context, req RegisterRequest) (*AuthResp
 		return nil, err
 	}
 
+	// Redeemed last, so a registration that is turned down uses up nothing
+	if gate := uc.services.GetAccessGate(); gate.RequiredForRegistration() && !req.ByAdmin {
+		if err := gate.Redeem(ctx, req.AccessCode); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := uc.userRepo.Create(ctx, user); err != nil {
 		return nil, err
 	}
 
+	// Log account creation, including the granted role
+	uc.services.GetAuditService().LogChange(ctx, nil, "REGISTER", "User", user.ID, nil,
+		map[string]interface{}{"email": user.Email, "role": user.Role})
+
 	token, err := uc.jwtProvider.GenerateToken(user)
 	if err != nil {
 		return nil, err
@@ -102,13 +157,21 @@ func (uc *UseCase) Register(ctx context.Context, req RegisterRequest) (*AuthResp
 		Email:     user.Email,
 		Name:      user.Name,
 		Role:      user.Role,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(uc.jwtProvider.Lifetime()),
 	}, nil
 }
 
+// Login checks the credentials. Failed attempts are counted per account and
+// per client IP; past the lockout policy's thresholds Login returns a
+// LockedError without checking the password until the lockout ends.
 func (uc *UseCase) Login(ctx context.Context, req LoginRequest) (*AuthResponse, error) {
+	if err := uc.throttle.Check(req.Email, req.IP); err != nil {
+		return nil, err
+	}
+
 	user, err := uc.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
+		uc.loginFailed(ctx, nil, req)
 		return nil, errors.New("Invalid credentials")
 	}
 
@@ -117,8 +180,15 @@ func (uc *UseCase) Login(ctx context.Context, req LoginRequest) (*AuthResponse,
 	}
 
 	if !user.CheckPassword(req.Password) {
+		uc.loginFailed(ctx, user, req)
 		return nil, errors.New("Invalid credentials")
 	}
+	uc.throttle.Succeed(req.Email)
+
+	// Checked once the password is, so the blocklist can't be probed for accounts
+	if err := uc.services.GetBlocklist().Check(ctx, user.Email, req.IP); err != nil {
+		return nil, err
+	}
 
 	token, err := uc.jwtProvider.GenerateToken(user)
 	if err != nil {
@@ -131,10 +201,209 @@ func (uc *UseCase) Login(ctx context.Context, req LoginRequest) (*AuthResponse,
 		Email:     user.Email,
 		Name:      user.Name,
 		Role:      user.Role,
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		ExpiresAt: time.Now().Add(uc.jwtProvider.Lifetime()),
 	}, nil
 }
 
+// loginFailed counts a failed login and audits it. Failures for unknown
+// emails are counted too, so probing for accounts is throttled the same way;
+// they are only audited once they lock the email out.
+func (uc *UseCase) loginFailed(ctx context.Context, user *entity.User, req LoginRequest) {
+	failures, accountLock, ipLock := uc.throttle.Fail(req.Email, req.IP)
+	audit := uc.services.GetAuditService()
+
+	resourceID := uuid.Nil
+	if user != nil {
+		resourceID = user.ID
+		audit.LogChange(ctx, nil, "LOGIN_FAILED", "User", resourceID, nil,
+			map[string]interface{}{"ip": req.IP, "failures": failures})
+	}
+	if accountLock > 0 {
+		audit.LogChange(ctx, nil, "LOGIN_LOCKED", "User", resourceID, nil,
+			map[string]interface{}{"email": req.Email, "ip": req.IP, "locked_seconds": int(accountLock.Seconds())})
+	}
+	if ipLock > 0 {
+		audit.LogChange(ctx, nil, "LOGIN_IP_LOCKED", "User", uuid.Nil, nil,
+			map[string]interface{}{"ip": req.IP, "locked_seconds": int(ipLock.Seconds())})
+	}
+}
+
 func (uc *UseCase) ValidateToken(tokenString string) (*auth.Claims, error) {
 	return uc.jwtProvider.ValidateToken(tokenString)
 }
+
+// CheckRevoked returns ErrTokenRevoked when the token the claims were read
+// from was revoked, on its own or with every token of its user
+func (uc *UseCase) CheckRevoked(ctx context.Context, claims *auth.Claims) error {
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := uc.revocationRepo.IsRevoked(ctx, claims.ID, claims.UserID, issuedAt)
+	if err != nil {
+		return err
+	}
+	if revoked {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// Logout revokes the token the claims were read from
+func (uc *UseCase) Logout(ctx context.Context, claims *auth.Claims) error {
+	return uc.revokeToken(ctx, claims, entity.RevocationLogout, nil)
+}
+
+// RevokeToken revokes a token that leaked, until it expires. The token must
+// still be valid: an expired token needs no revoking.
+func (uc *UseCase) RevokeToken(ctx context.Context, tokenString string, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	if !reason.IsValid() {
+		return entity.ValidationError("Invalid revocation reason")
+	}
+
+	claims, err := uc.jwtProvider.ValidateToken(tokenString)
+	if err != nil {
+		return entity.ValidationError("Token is invalid or already expired")
+	}
+
+	if err := uc.revokeToken(ctx, claims, reason, &revokedBy); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &revokedBy, "REVOKE_TOKEN", "User", claims.UserID, nil,
+		map[string]interface{}{"token_id": claims.ID, "reason": reason})
+	return nil
+}
+
+// RevokeUserTokens revokes every token issued to the user so far, signing
+// them out everywhere; tokens issued afterwards are not affected
+func (uc *UseCase) RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	if !reason.IsValid() {
+		return entity.ValidationError("Invalid revocation reason")
+	}
+
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := uc.revokeUserTokens(ctx, userID, reason, revokedBy); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &revokedBy, "REVOKE_TOKENS", "User", userID, nil,
+		map[string]interface{}{"reason": reason})
+	return nil
+}
+
+// SetUserActive activates or deactivates an account. Inactive users cannot log
+// in, and deactivating revokes the tokens they hold so they lose access right
+// away instead of when the tokens expire.
+func (uc *UseCase) SetUserActive(ctx context.Context, userID uuid.UUID, active bool, changedBy uuid.UUID) (*entity.User, error) {
+	if !active && userID == changedBy {
+		return nil, entity.ValidationError("You cannot deactivate your own account")
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.Active == active {
+		return user, nil
+	}
+
+	user.Active = active
+	user.UpdatedAt = time.Now()
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	action := "ACTIVATE"
+	if !active {
+		action = "DEACTIVATE"
+		if err := uc.revokeUserTokens(ctx, userID, entity.RevocationDeactivated, changedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &changedBy, action, "User", userID,
+		map[string]interface{}{"active": !active}, map[string]interface{}{"active": active})
+	return user, nil
+}
+
+// Impersonate issues the admin a short-lived token of a customer, to
+// reproduce what the customer sees. The token carries the admin as the
+// impersonator, so everything done with it is audited as done by both.
+func (uc *UseCase) Impersonate(ctx context.Context, userID uuid.UUID, adminID uuid.UUID) (*AuthResponse, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// Impersonating staff would hand out their permissions
+	if user.Role != entity.RoleCustomer {
+		return nil, entity.ForbiddenError("Only customers can be impersonated")
+	}
+	if !user.IsActive() {
+		return nil, entity.ValidationError("Account is inactive")
+	}
+
+	token, err := uc.jwtProvider.GenerateImpersonationToken(user, adminID, ImpersonationLifetime)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(ImpersonationLifetime)
+
+	uc.services.GetAuditService().LogChange(ctx, &adminID, "IMPERSONATE", "User", user.ID, nil,
+		map[string]interface{}{"email": user.Email, "expires_at": expiresAt})
+
+	return &AuthResponse{
+		Token:          token,
+		UserID:         user.ID,
+		Email:          user.Email,
+		Name:           user.Name,
+		Role:           user.Role,
+		ExpiresAt:      expiresAt,
+		ImpersonatorID: &adminID,
+	}, nil
+}
+
+func (uc *UseCase) revokeToken(ctx context.Context, claims *auth.Claims, reason entity.RevocationReason, revokedBy *uuid.UUID) error {
+	// Tokens issued before tokens had IDs can only be revoked with every token of their user
+	if claims.ID == "" {
+		return entity.ValidationError("Token has no ID, revoke every token of the user instead")
+	}
+
+	expiresAt := time.Now().Add(uc.jwtProvider.Lifetime())
+	if claims.ExpiresAt != nil {
+		expiresAt = claims.ExpiresAt.Time
+	}
+
+	tokenID := claims.ID
+	return uc.revocationRepo.Create(ctx, &entity.TokenRevocation{
+		TokenID:   &tokenID,
+		UserID:    claims.UserID,
+		Reason:    reason,
+		RevokedBy: revokedBy,
+		RevokedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	})
+}
+
+// revokeUserTokens revokes the tokens of the user issued until now. They all
+// expire within a token lifetime, the revocation is kept as long.
+func (uc *UseCase) revokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	now := time.Now()
+	return uc.revocationRepo.Create(ctx, &entity.TokenRevocation{
+		UserID:    userID,
+		Reason:    reason,
+		RevokedBy: &revokedBy,
+		RevokedAt: now,
+		ExpiresAt: now.Add(uc.jwtProvider.Lifetime()),
+	})
+}
+
+// PurgeExpiredRevocations removes the revocations of tokens that have expired
+// since, which no request can present anymore
+func (uc *UseCase) PurgeExpiredRevocations(ctx context.Context) (int64, error) {
+	return uc.revocationRepo.DeleteExpired(ctx, time.Now())
+}
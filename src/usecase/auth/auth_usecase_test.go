@@ -0,0 +1,342 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/auth"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/usecase/accesscode"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+)
+
+const testSecret = "test-secret-key-for-revocation-tests"
+
+var testPolicy = LockoutPolicy{
+	MaxFailures:   3,
+	IPMaxFailures: 5,
+	Window:        15 * time.Minute,
+	Lockout:       time.Minute,
+	MaxLockout:    5 * time.Minute,
+}
+
+func newTestUseCase(t *testing.T) (*UseCase, *memory.Store) {
+	t.Helper()
+	store := memory.NewStore()
+	uc := NewUseCase(memory.NewUserRepository(store), memory.NewTokenRevocationRepository(store),
+		auth.NewJWTProvider(testSecret, 24), NewLoginThrottle(testPolicy), &mockServices.MockServices{})
+	return uc, store
+}
+
+// login registers a customer and returns the claims of their token
+func login(t *testing.T, uc *UseCase, email string) *auth.Claims {
+	t.Helper()
+	response, err := uc.Register(context.Background(), RegisterRequest{Email: email, Password: "password", Name: "Test User"})
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	claims, err := uc.ValidateToken(response.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	return claims
+}
+
+func TestLogout_RevokesOnlyThatToken(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	claims := login(t, uc, "customer@example.com")
+
+	other, _ := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password"})
+	otherClaims, _ := uc.ValidateToken(other.Token)
+
+	if err := uc.Logout(ctx, claims); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if err := uc.CheckRevoked(ctx, claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevoked() error = %v, want ErrTokenRevoked", err)
+	}
+	if err := uc.CheckRevoked(ctx, otherClaims); err != nil {
+		t.Errorf("CheckRevoked() error = %v, want the other session to stay valid", err)
+	}
+	// Logging out twice is harmless
+	if err := uc.Logout(ctx, claims); err != nil {
+		t.Errorf("Logout() error = %v on a revoked token", err)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	admin := login(t, uc, "admin@example.com")
+
+	response, _ := uc.Register(ctx, RegisterRequest{Email: "customer@example.com", Password: "password", Name: "Test User"})
+	if err := uc.RevokeToken(ctx, response.Token, entity.RevocationCompromised, admin.UserID); err != nil {
+		t.Fatalf("RevokeToken() error = %v", err)
+	}
+	claims, _ := uc.ValidateToken(response.Token)
+	if err := uc.CheckRevoked(ctx, claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevoked() error = %v, want ErrTokenRevoked", err)
+	}
+
+	if err := uc.RevokeToken(ctx, "not-a-token", entity.RevocationCompromised, admin.UserID); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("RevokeToken() error = %v, want a validation error", err)
+	}
+	if err := uc.RevokeToken(ctx, response.Token, "lost", admin.UserID); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("RevokeToken() error = %v, want a validation error for the reason", err)
+	}
+}
+
+func TestSetUserActive_DeactivatingRevokesTokens(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	admin := login(t, uc, "admin@example.com")
+	claims := login(t, uc, "customer@example.com")
+
+	user, err := uc.SetUserActive(ctx, claims.UserID, false, admin.UserID)
+	if err != nil {
+		t.Fatalf("SetUserActive() error = %v", err)
+	}
+	if user.Active {
+		t.Error("SetUserActive() left the user active")
+	}
+
+	if err := uc.CheckRevoked(ctx, claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevoked() error = %v, want ErrTokenRevoked", err)
+	}
+	if err := uc.CheckRevoked(ctx, admin); err != nil {
+		t.Errorf("CheckRevoked() error = %v, want other users unaffected", err)
+	}
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password"}); err == nil {
+		t.Error("Login() succeeded for a deactivated user")
+	}
+
+	// Tokens issued after the revocation are not covered by it
+	uc.SetUserActive(ctx, claims.UserID, true, admin.UserID)
+	later := *claims
+	later.ID = "later-token"
+	later.IssuedAt = jwt.NewNumericDate(time.Now().Add(2 * time.Second))
+	if err := uc.CheckRevoked(ctx, &later); err != nil {
+		t.Errorf("CheckRevoked() error = %v, want a later token to be valid", err)
+	}
+
+	if _, err := uc.SetUserActive(ctx, admin.UserID, false, admin.UserID); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("SetUserActive() error = %v, want admins kept from deactivating themselves", err)
+	}
+}
+
+func TestPurgeExpiredRevocations(t *testing.T) {
+	uc, store := newTestUseCase(t)
+	ctx := context.Background()
+	claims := login(t, uc, "customer@example.com")
+	uc.Logout(ctx, claims)
+
+	revocations := memory.NewTokenRevocationRepository(store)
+	revocations.Create(ctx, &entity.TokenRevocation{
+		UserID:    claims.UserID,
+		Reason:    entity.RevocationAdmin,
+		RevokedAt: time.Now().Add(-48 * time.Hour),
+		ExpiresAt: time.Now().Add(-24 * time.Hour),
+	})
+
+	removed, err := uc.PurgeExpiredRevocations(ctx)
+	if err != nil || removed != 1 {
+		t.Errorf("PurgeExpiredRevocations() = %d, %v, want 1 expired revocation removed", removed, err)
+	}
+	if err := uc.CheckRevoked(ctx, claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevoked() error = %v, want the live revocation kept", err)
+	}
+}
+
+func TestLogin_LocksAccountAfterFailures(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	login(t, uc, "customer@example.com")
+	start := time.Now()
+	uc.throttle.now = func() time.Time { return start }
+
+	wrong := LoginRequest{Email: "customer@example.com", Password: "wrong"}
+	for i := 0; i < testPolicy.MaxFailures; i++ {
+		if _, err := uc.Login(ctx, wrong); err == nil || errors.As(err, new(*LockedError)) {
+			t.Fatalf("Login() attempt %d error = %v, want invalid credentials", i+1, err)
+		}
+	}
+
+	// Even the right password is refused while locked, from any IP
+	var locked *LockedError
+	_, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password", IP: "198.51.100.7"})
+	if !errors.As(err, &locked) || locked.RetryAfter != testPolicy.Lockout {
+		t.Fatalf("Login() error = %v, want a lockout of %s", err, testPolicy.Lockout)
+	}
+
+	// The lockout doubles when the failures resume right after it
+	uc.throttle.now = func() time.Time { return start.Add(2 * time.Minute) }
+	for i := 0; i < testPolicy.MaxFailures; i++ {
+		uc.Login(ctx, wrong)
+	}
+	if _, err := uc.Login(ctx, wrong); !errors.As(err, &locked) || locked.RetryAfter != 2*testPolicy.Lockout {
+		t.Fatalf("Login() error = %v, want a lockout of %s", err, 2*testPolicy.Lockout)
+	}
+
+	// A success clears the account
+	uc.throttle.now = func() time.Time { return start.Add(5 * time.Minute) }
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password"}); err != nil {
+		t.Fatalf("Login() error = %v after the lockout", err)
+	}
+	if _, err := uc.Login(ctx, wrong); errors.As(err, &locked) {
+		t.Error("Login() kept counting failures from before the success")
+	}
+}
+
+func TestLogin_LocksIPAcrossAccounts(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	login(t, uc, "customer@example.com")
+
+	// Credential stuffing: one failure each on many accounts from the same IP
+	for i := 0; i < testPolicy.IPMaxFailures; i++ {
+		uc.Login(ctx, LoginRequest{Email: fmt.Sprintf("user%d@example.com", i), Password: "guess", IP: "203.0.113.1"})
+	}
+
+	var locked *LockedError
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password", IP: "203.0.113.1"}); !errors.As(err, &locked) {
+		t.Errorf("Login() error = %v, want the IP locked out", err)
+	}
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password", IP: "198.51.100.7"}); err != nil {
+		t.Errorf("Login() error = %v, want other IPs unaffected", err)
+	}
+}
+
+// blockEmailChecker blocks one email address
+type blockEmailChecker struct{ email string }
+
+func (c blockEmailChecker) Check(ctx context.Context, email, ip string) error {
+	if email == c.email {
+		return blocklist.ErrBlocked
+	}
+	return nil
+}
+
+func (c blockEmailChecker) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	return nil
+}
+
+func TestRegisterAndLogin_RefuseBlockedEmails(t *testing.T) {
+	uc, _ := newTestUseCase(t)
+	ctx := context.Background()
+	login(t, uc, "customer@example.com")
+	uc.services = &mockServices.MockServices{Blocklist: blockEmailChecker{email: "customer@example.com"}}
+
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "password"}); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("Login() error = %v, want the blocked account forbidden", err)
+	}
+	if _, err := uc.Login(ctx, LoginRequest{Email: "customer@example.com", Password: "wrong"}); errors.Is(err, entity.ErrForbidden) {
+		t.Error("Login() told a wrong password the account is blocked")
+	}
+
+	uc.services = &mockServices.MockServices{Blocklist: blockEmailChecker{email: "new@example.com"}}
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "new@example.com", Password: "password", Name: "New"}); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("Register() error = %v, want the blocked email forbidden", err)
+	}
+}
+
+func TestRegister_RedeemsAccessCode(t *testing.T) {
+	uc, store := newTestUseCase(t)
+	ctx := context.Background()
+	gate := accesscode.NewUseCase(memory.NewAccessCodeRepository(store), &mockServices.MockServices{}, accesscode.Requirements{Registration: true})
+	code, err := gate.CreateCode(ctx, &entity.AccessCode{Code: "beta-friends", MaxUses: 1}, uuid.New())
+	if err != nil {
+		t.Fatalf("CreateCode() error = %v", err)
+	}
+	uc.services = &mockServices.MockServices{AccessGate: gate}
+
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "a@example.com", Password: "password", Name: "Ann"}); !errors.Is(err, accesscode.ErrAccessCodeRequired) {
+		t.Errorf("Register() error = %v, want the access code required", err)
+	}
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "a@example.com", Password: "password", Name: "Ann", AccessCode: "WRONG"}); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("Register() error = %v, want an invalid code forbidden", err)
+	}
+	// A registration turned down for another reason uses nothing up
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "a@example.com", Password: "password", Name: "Ann", Role: "owner", AccessCode: "BETA-FRIENDS"}); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("Register() error = %v, want an invalid role", err)
+	}
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "a@example.com", Password: "password", Name: "Ann", AccessCode: " beta-friends "}); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "b@example.com", Password: "password", Name: "Bob", AccessCode: "BETA-FRIENDS"}); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("Register() error = %v, want the used up code refused", err)
+	}
+	if _, err := uc.Register(ctx, RegisterRequest{Email: "b@example.com", Password: "password", Name: "Bob", ByAdmin: true}); err != nil {
+		t.Errorf("Register() by an admin error = %v, want no code needed", err)
+	}
+
+	redeemed, _ := memory.NewAccessCodeRepository(store).GetByID(ctx, code.ID)
+	if redeemed.Uses != 1 {
+		t.Errorf("Uses = %d, want 1", redeemed.Uses)
+	}
+}
+
+func TestImpersonate(t *testing.T) {
+	uc, store := newTestUseCase(t)
+	ctx := context.Background()
+	adminID := uuid.New()
+	customer := login(t, uc, "customer@example.com")
+
+	response, err := uc.Impersonate(ctx, customer.UserID, adminID)
+	if err != nil {
+		t.Fatalf("Impersonate() error = %v", err)
+	}
+	if response.ImpersonatorID == nil || *response.ImpersonatorID != adminID {
+		t.Errorf("Impersonate() ImpersonatorID = %v, want %s", response.ImpersonatorID, adminID)
+	}
+	if time.Until(response.ExpiresAt) > ImpersonationLifetime {
+		t.Errorf("Impersonate() ExpiresAt = %v, want within %v", response.ExpiresAt, ImpersonationLifetime)
+	}
+
+	claims, err := uc.ValidateToken(response.Token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.UserID != customer.UserID || claims.Role != entity.RoleCustomer || !claims.Impersonated() {
+		t.Errorf("ValidateToken() claims = %+v, want the customer impersonated by %s", claims, adminID)
+	}
+
+	// Signing the customer out everywhere ends the impersonation too
+	if err := uc.RevokeUserTokens(ctx, customer.UserID, entity.RevocationAdmin, adminID); err != nil {
+		t.Fatalf("RevokeUserTokens() error = %v", err)
+	}
+	if err := uc.CheckRevoked(ctx, claims); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("CheckRevoked() error = %v, want ErrTokenRevoked", err)
+	}
+
+	t.Run("refuses staff accounts", func(t *testing.T) {
+		support, _ := uc.Register(ctx, RegisterRequest{Email: "support@example.com", Password: "password", Name: "Support", Role: "support"})
+		if _, err := uc.Impersonate(ctx, support.UserID, adminID); !errors.Is(err, entity.ErrForbidden) {
+			t.Errorf("Impersonate() error = %v, want ErrForbidden", err)
+		}
+	})
+
+	t.Run("refuses inactive accounts", func(t *testing.T) {
+		inactive := login(t, uc, "inactive@example.com")
+		user, _ := memory.NewUserRepository(store).GetByID(ctx, inactive.UserID)
+		user.Active = false
+		memory.NewUserRepository(store).Update(ctx, user)
+
+		if _, err := uc.Impersonate(ctx, inactive.UserID, adminID); !errors.Is(err, entity.ErrValidation) {
+			t.Errorf("Impersonate() error = %v, want ErrValidation", err)
+		}
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		if _, err := uc.Impersonate(ctx, uuid.New(), adminID); err == nil {
+			t.Error("Impersonate() error = nil for an unknown user")
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LockoutPolicy configures how failed logins lock an account or a client IP
+type LockoutPolicy struct {
+	MaxFailures   int           // Failures of an account before it is locked
+	IPMaxFailures int           // Failures from one IP, over any accounts, before it is locked
+	Window        time.Duration // Failures older than this are forgotten
+	Lockout       time.Duration // First lockout; each lockout after it doubles
+	MaxLockout    time.Duration // Longest lockout
+}
+
+// LockedError is returned while an account or IP is locked out
+type LockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedError) Error() string {
+	return fmt.Sprintf("Too many failed login attempts, try again in %d seconds", int(e.RetryAfter.Round(time.Second).Seconds()))
+}
+
+type loginFailures struct {
+	count       int // Failures since the last lockout
+	lastFailure time.Time
+	lockouts    int // Lockouts in a row, the exponent of the backoff
+	lockedUntil time.Time
+}
+
+// LoginThrottle tracks failed logins per account and per client IP in memory.
+// After MaxFailures an account is locked for Lockout, then twice as long after
+// each further round of failures, up to MaxLockout; a successful login clears
+// the account. IPs are tracked the same way with their own threshold and are
+// not cleared by a success, so one IP cycling through leaked credentials is
+// held back even when some of them work. Like the rate limiter, counters are
+// per process.
+type LoginThrottle struct {
+	policy    LockoutPolicy
+	now       func() time.Time
+	mu        sync.Mutex
+	failures  map[string]*loginFailures
+	nextSweep time.Time
+}
+
+func NewLoginThrottle(policy LockoutPolicy) *LoginThrottle {
+	return &LoginThrottle{
+		policy:   policy,
+		now:      time.Now,
+		failures: make(map[string]*loginFailures),
+	}
+}
+
+func accountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Check returns a LockedError when the account or the IP is locked out
+func (t *LoginThrottle) Check(email, ip string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	var retryAfter time.Duration
+	for _, key := range t.keys(email, ip) {
+		if f, ok := t.failures[key]; ok && now.Before(f.lockedUntil) {
+			retryAfter = max(retryAfter, f.lockedUntil.Sub(now))
+		}
+	}
+	if retryAfter > 0 {
+		return &LockedError{RetryAfter: retryAfter}
+	}
+	return nil
+}
+
+// Fail records a failed login. It returns the failures of the account so far
+// and the lockout it triggered, zero when it triggered none.
+func (t *LoginThrottle) Fail(email, ip string) (failures int, accountLock, ipLock time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	t.sweep(now)
+
+	if email != "" {
+		failures, accountLock = t.fail(accountKey(email), t.policy.MaxFailures, now)
+	}
+	if ip != "" {
+		_, ipLock = t.fail(ipKey(ip), t.policy.IPMaxFailures, now)
+	}
+	return failures, accountLock, ipLock
+}
+
+// Succeed clears the failures of the account
+func (t *LoginThrottle) Succeed(email string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, accountKey(email))
+}
+
+func (t *LoginThrottle) keys(email, ip string) []string {
+	var keys []string
+	if email != "" {
+		keys = append(keys, accountKey(email))
+	}
+	if ip != "" {
+		keys = append(keys, ipKey(ip))
+	}
+	return keys
+}
+
+func (t *LoginThrottle) fail(key string, limit int, now time.Time) (int, time.Duration) {
+	f, ok := t.failures[key]
+	if !ok {
+		f = &loginFailures{}
+		t.failures[key] = f
+	}
+	// Failures spread out longer than the window start over, and so does the
+	// backoff once a lockout has been followed by a quiet window
+	if now.Sub(f.lastFailure) > t.policy.Window {
+		f.count = 0
+		if now.Sub(f.lockedUntil) > t.policy.Window {
+			f.lockouts = 0
+		}
+	}
+	f.count++
+	f.lastFailure = now
+
+	if limit <= 0 || f.count < limit {
+		return f.count, 0
+	}
+
+	lockout := t.policy.Lockout
+	for i := 0; i < f.lockouts && lockout < t.policy.MaxLockout; i++ {
+		lockout *= 2
+	}
+	lockout = min(lockout, t.policy.MaxLockout)
+	failures := f.count
+	f.count = 0
+	f.lockouts++
+	f.lockedUntil = now.Add(lockout)
+	return failures, lockout
+}
+
+// sweep drops the entries that no longer lock or count anything, at most once
+// per window
+func (t *LoginThrottle) sweep(now time.Time) {
+	if now.Before(t.nextSweep) {
+		return
+	}
+	for key, f := range t.failures {
+		if now.Sub(f.lastFailure) > t.policy.Window && now.Sub(f.lockedUntil) > t.policy.Window {
+			delete(t.failures, key)
+		}
+	}
+	t.nextSweep = now.Add(t.policy.Window)
+}
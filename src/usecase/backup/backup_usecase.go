@@ -0,0 +1,413 @@
+package backup
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/anonymize"
+)
+
+const (
+	// Format names the archives of this package in their manifest
+	Format = "go-ecommerce-backup"
+	// FormatVersion is the version of the archive layout, archives of
+	// another version can't be restored
+	FormatVersion = 1
+
+	manifestFile = "manifest.json"
+)
+
+// Users tells what a backup holds of the accounts and their customer data
+type Users string
+
+const (
+	// UsersExcluded leaves the accounts and their customer data out, along
+	// with every table that names them: invoices, reviews, the blocklist and
+	// the audit and webhook logs
+	UsersExcluded Users = "excluded"
+	// UsersHashed keeps them with emails, names, phone numbers and street
+	// addresses replaced by pseudonyms derived from a keyed hash, invoices
+	// rendered again for the pseudonyms, free text and the payloads of logs
+	// redacted, and passwords dropped
+	UsersHashed Users = "hashed"
+)
+
+// InvoiceRenderer renders an issued invoice again, with the name and email of
+// its customer passed through party
+type InvoiceRenderer interface {
+	RenderInvoiceAs(ctx context.Context, invoice *entity.Invoice, party func(name, email string) (string, string)) ([]byte, error)
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface BackupService -out ../../internal/testing/servicemocks
+
+type BackupService interface {
+	// Export writes every table of the store to w as a zip archive
+	Export(ctx context.Context, w io.Writer, opts ExportOptions) (*Manifest, error)
+	// Import restores an archive into an empty database
+	Import(ctx context.Context, r io.ReaderAt, size int64) (*Manifest, error)
+}
+
+type ExportOptions struct {
+	Users Users
+	// Key derives the pseudonyms of hashed users
+	Key []byte
+}
+
+// Manifest describes an archive. It holds one file of JSON lines per table,
+// tables/<name>.jsonl, each line a row by column name, with times in RFC
+// 3339 and binary columns in base64.
+type Manifest struct {
+	Format        string         `json:"format"`
+	FormatVersion int            `json:"format_version"`
+	SchemaVersion int64          `json:"schema_version"`
+	CreatedAt     time.Time      `json:"created_at"`
+	Users         Users          `json:"users"`
+	Tables        []ManifestFile `json:"tables"`
+}
+
+// ManifestFile is a table of an archive with its number of rows
+type ManifestFile struct {
+	Name string `json:"name"`
+	Rows int64  `json:"rows"`
+}
+
+// UseCase dumps the data of a store into a versioned archive and restores
+// it, to move a store between environments. An archive restores into a
+// database migrated to the schema it was taken from.
+type UseCase struct {
+	repo      repository.BackupRepository
+	invoices  InvoiceRenderer
+	batchSize int
+}
+
+// NewUseCase takes the renderer of the invoices of hashed exports, imports
+// don't need one
+func NewUseCase(repo repository.BackupRepository, invoices InvoiceRenderer, batchSize int) *UseCase {
+	return &UseCase{
+		repo:      repo,
+		invoices:  invoices,
+		batchSize: batchSize,
+	}
+}
+
+func tableFile(table string) string {
+	return "tables/" + table + ".jsonl"
+}
+
+// Export writes the tables one after the other, the manifest last. Tables
+// are read one by one, not from one snapshot, so the store should not take
+// writes while it runs.
+func (uc *UseCase) Export(ctx context.Context, w io.Writer, opts ExportOptions) (*Manifest, error) {
+	switch opts.Users {
+	case UsersExcluded:
+	case UsersHashed:
+		if len(opts.Key) == 0 {
+			return nil, errors.New("A key is required to hash the users")
+		}
+		if uc.invoices == nil {
+			return nil, errors.New("An invoice renderer is required to hash the users")
+		}
+	default:
+		return nil, fmt.Errorf("Users must be %s or %s", UsersExcluded, UsersHashed)
+	}
+
+	version, err := uc.repo.SchemaVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{
+		Format:        Format,
+		FormatVersion: FormatVersion,
+		SchemaVersion: version,
+		CreatedAt:     time.Now().UTC(),
+		Users:         opts.Users,
+	}
+	pseudonyms := anonymize.NewPseudonymizer(opts.Key)
+
+	archive := zip.NewWriter(w)
+	for _, table := range uc.repo.Tables() {
+		if table.Personal && opts.Users == UsersExcluded {
+			continue
+		}
+
+		file, err := create(archive, tableFile(table.Name), manifest.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		encoder := json.NewEncoder(file)
+		var rows int64
+		err = uc.repo.ExportTable(ctx, table.Name, func(row entity.BackupRow) error {
+			if table.Personal {
+				if err := uc.hash(ctx, pseudonyms, table.Name, row); err != nil {
+					return err
+				}
+			}
+			rows++
+			return encoder.Encode(row)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Failed to export %s: %w", table.Name, err)
+		}
+		manifest.Tables = append(manifest.Tables, ManifestFile{Name: table.Name, Rows: rows})
+	}
+
+	file, err := create(archive, manifestFile, manifest.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	encoder := json.NewEncoder(file)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, archive.Close()
+}
+
+func create(archive *zip.Writer, name string, modified time.Time) (io.Writer, error) {
+	return archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: modified})
+}
+
+// hash replaces the personal data of a row of a personal table
+func (uc *UseCase) hash(ctx context.Context, p *anonymize.Pseudonymizer, table string, row entity.BackupRow) error {
+	replace := func(column string, pseudonym func(string) string) {
+		if value, ok := row[column].(string); ok {
+			row[column] = pseudonym(value)
+		}
+	}
+	redact := func(string) string { return anonymize.Redacted }
+
+	switch table {
+	case "users":
+		replace("email", p.Email)
+		replace("name", p.Name)
+		// Accounts of a copy sign in once their password is reset
+		replace("password_hash", func(string) string { return "" })
+	case "customers":
+		replace("phone", p.Phone)
+	case "customer_addresses":
+		replace("recipient", p.Name)
+		replace("line1", p.Street)
+		replace("line2", p.Unit)
+		replace("postal_code", p.PostalCode)
+	case "customer_notes":
+		replace("body", redact)
+	case "customer_risk_events":
+		replace("reference", redact)
+	case "invoices":
+		return uc.renderInvoice(ctx, p, row)
+	case "blocklist_entries":
+		switch row["kind"] {
+		case string(entity.BlockEmail):
+			replace("value", p.Email)
+		case string(entity.BlockDomain):
+			replace("value", p.Domain)
+		}
+		replace("reason", redact)
+	case "audit_logs", "archived_audit_logs":
+		row["payload_before"], row["payload_after"] = nil, nil
+	case "product_reviews":
+		replace("body", redact)
+	case "draft_orders":
+		replace("note", redact)
+	case "webhook_logs", "archived_webhook_logs", "dead_letter_webhooks":
+		replace("raw_payload", redact)
+	case "webhook_deliveries":
+		replace("payload", redact)
+	case "admin_alerts":
+		replace("message", redact)
+	}
+	return nil
+}
+
+// renderInvoice renders the document of an invoice row again, billed to the
+// pseudonyms its customer has in the users of the archive
+func (uc *UseCase) renderInvoice(ctx context.Context, p *anonymize.Pseudonymizer, row entity.BackupRow) error {
+	orderID, err := uuid.Parse(fmt.Sprint(row["order_id"]))
+	if err != nil {
+		return fmt.Errorf("Invoice %v has no order: %w", row["id"], err)
+	}
+	invoice := &entity.Invoice{OrderID: orderID}
+	invoice.Number, _ = row["number"].(string)
+	switch issuedAt := row["issued_at"].(type) {
+	case time.Time:
+		invoice.IssuedAt = issuedAt
+	case string:
+		if invoice.IssuedAt, err = time.Parse(time.RFC3339Nano, issuedAt); err != nil {
+			return fmt.Errorf("Invoice %v has no issue date: %w", row["id"], err)
+		}
+	}
+
+	pdf, err := uc.invoices.RenderInvoiceAs(ctx, invoice, func(name, email string) (string, string) {
+		return p.Name(name), p.Email(email)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to render invoice %s: %w", invoice.Number, err)
+	}
+	row["pdf"] = pdf
+	return nil
+}
+
+// Import checks the archive fits the database, then restores every table in
+// one transaction. The database must be migrated to the schema version of
+// the archive and hold none of the rows the archive restores.
+func (uc *UseCase) Import(ctx context.Context, r io.ReaderAt, size int64) (*Manifest, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("Not a backup archive: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	manifest, err := readManifest(files[manifestFile])
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.check(ctx, manifest, files); err != nil {
+		return nil, err
+	}
+
+	err = uc.repo.Restore(ctx, func(insert func(table string, rows []entity.BackupRow) error) error {
+		for _, table := range manifest.Tables {
+			if err := uc.restoreTable(table, files[tableFile(table.Name)], insert); err != nil {
+				return fmt.Errorf("Failed to restore %s: %w", table.Name, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func readManifest(file *zip.File) (*Manifest, error) {
+	if file == nil {
+		return nil, errors.New("Not a backup archive: it has no manifest")
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	var manifest Manifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil || manifest.Format != Format {
+		return nil, errors.New("Not a backup archive: its manifest is not readable")
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("Archive format version %d can't be restored, this build restores version %d", manifest.FormatVersion, FormatVersion)
+	}
+	return &manifest, nil
+}
+
+// check refuses archives of another schema, of unknown tables and into
+// tables that have rows
+func (uc *UseCase) check(ctx context.Context, manifest *Manifest, files map[string]*zip.File) error {
+	version, err := uc.repo.SchemaVersion(ctx)
+	if err != nil {
+		return err
+	}
+	if version != manifest.SchemaVersion {
+		return fmt.Errorf("The archive is of schema version %d and the database is at %d, migrate a database to version %d to restore it",
+			manifest.SchemaVersion, version, manifest.SchemaVersion)
+	}
+
+	known := make(map[string]bool)
+	for _, table := range uc.repo.Tables() {
+		known[table.Name] = true
+	}
+	for _, table := range manifest.Tables {
+		if !known[table.Name] {
+			return fmt.Errorf("The archive has unknown table %s", table.Name)
+		}
+		if files[tableFile(table.Name)] == nil {
+			return fmt.Errorf("The archive misses table %s", table.Name)
+		}
+		count, err := uc.repo.CountRows(ctx, table.Name)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("Table %s already has %d rows, restore into an empty database: %w", table.Name, count, entity.ErrConflict)
+		}
+	}
+	return nil
+}
+
+// restoreTable inserts the rows of a table file batch by batch
+func (uc *UseCase) restoreTable(table ManifestFile, file *zip.File, insert func(table string, rows []entity.BackupRow) error) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	decoder.UseNumber()
+	var restored int64
+	batch := make([]entity.BackupRow, 0, uc.batchSize)
+	for {
+		var row entity.BackupRow
+		err := decoder.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := numbers(row); err != nil {
+			return err
+		}
+
+		batch = append(batch, row)
+		if len(batch) == uc.batchSize {
+			if err := insert(table.Name, batch); err != nil {
+				return err
+			}
+			restored += int64(len(batch))
+			batch = batch[:0]
+		}
+	}
+	if len(batch) > 0 {
+		if err := insert(table.Name, batch); err != nil {
+			return err
+		}
+		restored += int64(len(batch))
+	}
+
+	if restored != table.Rows {
+		return fmt.Errorf("The archive holds %d rows where its manifest lists %d", restored, table.Rows)
+	}
+	return nil
+}
+
+// numbers turns the numbers of a decoded row into integers, or floats when
+// they have a fraction, so large integers keep every digit
+func numbers(row entity.BackupRow) error {
+	for column, value := range row {
+		number, ok := value.(json.Number)
+		if !ok {
+			continue
+		}
+		if integer, err := number.Int64(); err == nil {
+			row[column] = integer
+			continue
+		}
+		float, err := number.Float64()
+		if err != nil {
+			return err
+		}
+		row[column] = float
+	}
+	return nil
+}
@@ -0,0 +1,315 @@
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/usecase/anonymize"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeBackupRepository keeps tables as lists of rows
+type fakeBackupRepository struct {
+	version int64
+	list    []entity.BackupTable
+	tables  map[string][]entity.BackupRow
+	failOn  string
+}
+
+func newFakeBackupRepository() *fakeBackupRepository {
+	return &fakeBackupRepository{
+		version: 24,
+		list: []entity.BackupTable{
+			{Name: "users", Personal: true},
+			{Name: "customer_notes", Personal: true},
+			{Name: "products"},
+			{Name: "orders"},
+		},
+		tables: make(map[string][]entity.BackupRow),
+	}
+}
+
+func (r *fakeBackupRepository) SchemaVersion(ctx context.Context) (int64, error) {
+	return r.version, nil
+}
+
+func (r *fakeBackupRepository) Tables() []entity.BackupTable {
+	return r.list
+}
+
+// fakeInvoices renders every invoice as text, billed to Jane Doe
+type fakeInvoices struct{}
+
+func (fakeInvoices) RenderInvoiceAs(ctx context.Context, invoice *entity.Invoice, party func(name, email string) (string, string)) ([]byte, error) {
+	name, email := party("Jane Doe", "jane@gmail.com")
+	return []byte(invoice.Number + " billed to " + name + " <" + email + ">"), nil
+}
+
+func (r *fakeBackupRepository) ExportTable(ctx context.Context, table string, fn func(row entity.BackupRow) error) error {
+	for _, row := range r.tables[table] {
+		copied := make(entity.BackupRow, len(row))
+		for column, value := range row {
+			copied[column] = value
+		}
+		if err := fn(copied); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeBackupRepository) CountRows(ctx context.Context, table string) (int64, error) {
+	return int64(len(r.tables[table])), nil
+}
+
+func (r *fakeBackupRepository) Restore(ctx context.Context, restore func(insert func(table string, rows []entity.BackupRow) error) error) error {
+	inserted := make(map[string][]entity.BackupRow)
+	err := restore(func(table string, rows []entity.BackupRow) error {
+		if table == r.failOn {
+			return errors.New("insert failed")
+		}
+		inserted[table] = append(inserted[table], rows...)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for table, rows := range inserted {
+		r.tables[table] = rows
+	}
+	return nil
+}
+
+func seeded() *fakeBackupRepository {
+	repo := newFakeBackupRepository()
+	repo.tables["users"] = []entity.BackupRow{{"id": "u1", "email": "jane@gmail.com", "name": "Jane Doe", "active": true}}
+	repo.tables["customer_notes"] = []entity.BackupRow{{"id": "n1", "body": "Called Jane"}}
+	repo.tables["products"] = []entity.BackupRow{
+		{"id": "p1", "name": "Chess", "price": 10.5, "quantity": int64(3), "deleted_at": nil},
+		{"id": "p2", "name": "Album", "price": 15.75, "quantity": int64(9007199254740993), "deleted_at": nil},
+		{"id": "p3", "name": "Guide", "price": 20.25, "quantity": int64(0), "deleted_at": "2026-01-02T03:04:05Z"},
+	}
+	repo.tables["orders"] = []entity.BackupRow{}
+	return repo
+}
+
+func export(t *testing.T, repo *fakeBackupRepository, opts ExportOptions) (*bytes.Reader, *Manifest) {
+	t.Helper()
+	var archive bytes.Buffer
+	manifest, err := NewUseCase(repo, fakeInvoices{}, 2).Export(context.Background(), &archive, opts)
+	require.NoError(t, err)
+	return bytes.NewReader(archive.Bytes()), manifest
+}
+
+func TestExportAndImport(t *testing.T) {
+	ctx := context.Background()
+	source := seeded()
+
+	archive, manifest := export(t, source, ExportOptions{Users: UsersExcluded})
+	assert.Equal(t, int64(24), manifest.SchemaVersion)
+	assert.Equal(t, []ManifestFile{{Name: "products", Rows: 3}, {Name: "orders", Rows: 0}}, manifest.Tables)
+
+	target := newFakeBackupRepository()
+	restored, err := NewUseCase(target, fakeInvoices{}, 2).Import(ctx, archive, archive.Size())
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Tables, restored.Tables)
+	assert.Equal(t, source.tables["products"], target.tables["products"], "integers keep every digit")
+	assert.Empty(t, target.tables["users"])
+}
+
+func TestExport_HashedUsers(t *testing.T) {
+	source := seeded()
+	archive, manifest := export(t, source, ExportOptions{Users: UsersHashed, Key: []byte("key")})
+	assert.Len(t, manifest.Tables, 4)
+
+	target := newFakeBackupRepository()
+	_, err := NewUseCase(target, fakeInvoices{}, 10).Import(context.Background(), archive, archive.Size())
+	require.NoError(t, err)
+
+	user := target.tables["users"][0]
+	assert.Equal(t, anonymize.NewPseudonymizer([]byte("key")).Email("jane@gmail.com"), user["email"])
+	assert.NotEqual(t, "Jane Doe", user["name"])
+	assert.Equal(t, true, user["active"])
+	assert.Equal(t, anonymize.Redacted, target.tables["customer_notes"][0]["body"])
+	assert.Equal(t, "jane@gmail.com", source.tables["users"][0]["email"], "the source is left alone")
+
+	_, err = NewUseCase(source, fakeInvoices{}, 10).Export(context.Background(), &bytes.Buffer{}, ExportOptions{Users: UsersHashed})
+	assert.Error(t, err, "hashing needs a key")
+	_, err = NewUseCase(source, fakeInvoices{}, 10).Export(context.Background(), &bytes.Buffer{}, ExportOptions{Users: "plain"})
+	assert.Error(t, err)
+}
+
+func TestExport_LeavesNoEmails(t *testing.T) {
+	repo := seeded()
+	for _, table := range []string{"customers", "invoices", "blocklist_entries", "audit_logs", "archived_audit_logs", "product_reviews",
+		"draft_orders", "webhook_logs", "archived_webhook_logs", "dead_letter_webhooks", "webhook_deliveries", "admin_alerts"} {
+		repo.list = append(repo.list, entity.BackupTable{Name: table, Personal: true})
+	}
+	repo.tables["users"][0]["password_hash"] = "$2a$10$secret"
+	repo.tables["customers"] = []entity.BackupRow{{"user_id": "u1", "phone": "+5511999990000"}}
+	repo.tables["invoices"] = []entity.BackupRow{{"id": "i1", "order_id": "5e0b5b2a-8c2d-4a8e-9f0e-1d2c3b4a5f60", "number": "INV-2026-000001",
+		"issued_at": time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "pdf": []byte("INV-2026-000001 billed to Jane Doe <jane@gmail.com>")}}
+	repo.tables["blocklist_entries"] = []entity.BackupRow{
+		{"id": "b1", "kind": "email", "value": "jane@gmail.com", "reason": "Chargebacks from jane@gmail.com"},
+		{"id": "b2", "kind": "ip_range", "value": "203.0.113.0/24", "reason": ""},
+	}
+	repo.tables["audit_logs"] = []entity.BackupRow{{"id": "a1", "payload_before": `{"email":"jane@gmail.com"}`, "payload_after": `{"email":"jane.doe@gmail.com"}`}}
+	repo.tables["archived_audit_logs"] = []entity.BackupRow{{"id": "a2", "payload_before": nil, "payload_after": `{"email":"jane@gmail.com"}`}}
+	repo.tables["product_reviews"] = []entity.BackupRow{{"id": "r1", "rating": int64(5), "body": "Great, write me at jane@gmail.com"}}
+	repo.tables["draft_orders"] = []entity.BackupRow{{"id": "d1", "note": "For jane@gmail.com"}}
+	repo.tables["webhook_logs"] = []entity.BackupRow{{"id": "w1", "raw_payload": `{"payer":{"email":"jane@gmail.com"}}`}}
+	repo.tables["archived_webhook_logs"] = []entity.BackupRow{{"id": "w2", "raw_payload": `{"payer":{"email":"jane@gmail.com"}}`}}
+	repo.tables["dead_letter_webhooks"] = []entity.BackupRow{{"id": "w3", "raw_payload": `{"payer":{"email":"jane@gmail.com"}}`}}
+	repo.tables["webhook_deliveries"] = []entity.BackupRow{{"id": "w4", "payload": `{"customer":"jane@gmail.com"}`}}
+	repo.tables["admin_alerts"] = []entity.BackupRow{{"id": "al1", "message": "jane@gmail.com changed the role of an admin"}}
+
+	for _, opts := range []ExportOptions{{Users: UsersExcluded}, {Users: UsersHashed, Key: []byte("key")}} {
+		archive, _ := export(t, repo, opts)
+		reader, err := zip.NewReader(archive, archive.Size())
+		require.NoError(t, err)
+		for _, file := range reader.File {
+			r, err := file.Open()
+			require.NoError(t, err)
+			data, err := io.ReadAll(r)
+			require.NoError(t, err)
+			text := decodedText(t, data)
+			for _, seeded := range []string{"jane@gmail.com", "jane.doe@gmail.com", "Jane Doe", "+5511999990000", "$2a$10$secret"} {
+				assert.NotContains(t, text, seeded, "%s of a %s export", file.Name, opts.Users)
+			}
+		}
+	}
+
+	archive, _ := export(t, repo, ExportOptions{Users: UsersHashed, Key: []byte("key")})
+	target := newFakeBackupRepository()
+	target.list = repo.list
+	_, err := NewUseCase(target, nil, 10).Import(context.Background(), archive, archive.Size())
+	require.NoError(t, err)
+	p := anonymize.NewPseudonymizer([]byte("key"))
+	pdf, err := base64.StdEncoding.DecodeString(target.tables["invoices"][0]["pdf"].(string))
+	require.NoError(t, err)
+	assert.Equal(t, "INV-2026-000001 billed to "+p.Name("Jane Doe")+" <"+p.Email("jane@gmail.com")+">", string(pdf),
+		"invoices bill the pseudonyms of the users")
+	assert.Equal(t, p.Email("jane@gmail.com"), target.tables["blocklist_entries"][0]["value"])
+	assert.Equal(t, "203.0.113.0/24", target.tables["blocklist_entries"][1]["value"])
+	assert.Equal(t, "", target.tables["users"][0]["password_hash"])
+
+	_, err = NewUseCase(repo, nil, 10).Export(context.Background(), &bytes.Buffer{}, ExportOptions{Users: UsersHashed, Key: []byte("key")})
+	assert.Error(t, err, "hashing renders the invoices again")
+}
+
+// decodedText is the content of a table file along with its values decoded
+// from base64, as binary columns are written
+func decodedText(t *testing.T, data []byte) string {
+	t.Helper()
+	text := string(data)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for decoder.More() {
+		var row map[string]interface{}
+		require.NoError(t, decoder.Decode(&row))
+		for _, value := range row {
+			if encoded, ok := value.(string); ok {
+				if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+					text += "\n" + string(decoded)
+				}
+			}
+		}
+	}
+	return text
+}
+
+func TestImport_Refuses(t *testing.T) {
+	ctx := context.Background()
+	archive, _ := export(t, seeded(), ExportOptions{Users: UsersExcluded})
+
+	t.Run("Another schema version", func(t *testing.T) {
+		target := newFakeBackupRepository()
+		target.version = 25
+
+		_, err := NewUseCase(target, fakeInvoices{}, 2).Import(ctx, archive, archive.Size())
+		assert.ErrorContains(t, err, "schema version 24")
+		assert.Empty(t, target.tables)
+	})
+
+	t.Run("A database with rows", func(t *testing.T) {
+		target := newFakeBackupRepository()
+		target.tables["orders"] = []entity.BackupRow{{"id": "o1"}}
+
+		_, err := NewUseCase(target, fakeInvoices{}, 2).Import(ctx, archive, archive.Size())
+		assert.ErrorIs(t, err, entity.ErrConflict)
+		assert.Empty(t, target.tables["products"])
+	})
+
+	t.Run("A failed insert restores nothing", func(t *testing.T) {
+		target := newFakeBackupRepository()
+		target.failOn = "orders"
+		rewritten := rewrite(t, archive, "tables/orders.jsonl", `{"id":"o1"}`+"\n", 1)
+
+		_, err := NewUseCase(target, fakeInvoices{}, 2).Import(ctx, rewritten, rewritten.Size())
+		assert.ErrorContains(t, err, "Failed to restore orders")
+		assert.Empty(t, target.tables["products"])
+	})
+
+	t.Run("Rows missing from a table", func(t *testing.T) {
+		rewritten := rewrite(t, archive, "tables/products.jsonl", `{"id":"p1"}`+"\n", 0)
+
+		_, err := NewUseCase(newFakeBackupRepository(), nil, 2).Import(ctx, rewritten, rewritten.Size())
+		assert.ErrorContains(t, err, "holds 1 rows where its manifest lists 3")
+	})
+
+	t.Run("Not an archive", func(t *testing.T) {
+		_, err := NewUseCase(newFakeBackupRepository(), nil, 2).Import(ctx, bytes.NewReader([]byte("nope")), 4)
+		assert.ErrorContains(t, err, "Not a backup archive")
+	})
+}
+
+// rewrite copies an archive with the content of one file replaced; rows,
+// when not zero, is listed for the file in the manifest
+func rewrite(t *testing.T, archive *bytes.Reader, name, content string, rows int64) *bytes.Reader {
+	t.Helper()
+	reader, err := zip.NewReader(archive, archive.Size())
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	writer := zip.NewWriter(&out)
+	for _, file := range reader.File {
+		var data bytes.Buffer
+		r, err := file.Open()
+		require.NoError(t, err)
+		_, err = data.ReadFrom(r)
+		require.NoError(t, err)
+
+		switch {
+		case file.Name == name:
+			data.Reset()
+			data.WriteString(content)
+		case file.Name == manifestFile && rows > 0:
+			var manifest Manifest
+			require.NoError(t, json.Unmarshal(data.Bytes(), &manifest))
+			for i := range manifest.Tables {
+				if tableFile(manifest.Tables[i].Name) == name {
+					manifest.Tables[i].Rows = rows
+				}
+			}
+			encoded, err := json.Marshal(manifest)
+			require.NoError(t, err)
+			data.Reset()
+			data.Write(encoded)
+		}
+
+		w, err := writer.Create(file.Name)
+		require.NoError(t, err)
+		_, err = w.Write(data.Bytes())
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	return bytes.NewReader(out.Bytes())
+}
@@ -0,0 +1,211 @@
+package blocklist
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrAlreadyBlocked = entity.ConflictError("This value is already blocked")
+	ErrEntryNotFound  = entity.NotFoundError("Blocklist entry not found")
+	// ErrBlocked doesn't tell which rule matched, so it can't be used to probe the blocklist
+	ErrBlocked = entity.ForbiddenError("This email address or network is blocked")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Checker -out ../../internal/testing/servicemocks -name BlocklistChecker
+
+// Checker tells whether an email or a client IP is blocked
+type Checker interface {
+	// Check returns ErrBlocked when the email or the client IP is blocked.
+	// Either can be empty to skip it.
+	Check(ctx context.Context, email, ip string) error
+	// CheckUser checks the email of the signed-in user, if any, and the client IP
+	CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface BlocklistService -out ../../internal/testing/servicemocks
+
+type BlocklistService interface {
+	Checker
+
+	AddEntry(ctx context.Context, entry *entity.BlocklistEntry, createdBy uuid.UUID) (*entity.BlocklistEntry, error)
+	// ListEntries returns the entries newest first, only those of kind when it is set
+	ListEntries(ctx context.Context, kind entity.BlocklistKind) ([]*entity.BlocklistEntry, error)
+	RemoveEntry(ctx context.Context, id uuid.UUID, removedBy uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+// UseCase keeps the ruleset in memory and reloads it once it is older than
+// the cache TTL. Changes made through this instance apply at once; with
+// several replicas the others pick them up within the TTL.
+type UseCase struct {
+	repo     repository.BlocklistRepository
+	userRepo repository.UserRepository
+	services Services
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu         sync.RWMutex
+	ruleset    *entity.Blocklist
+	loadedAt   time.Time
+	generation int // Bumped by every change, so a reload that raced one isn't cached
+}
+
+func NewUseCase(repo repository.BlocklistRepository, userRepo repository.UserRepository, services Services, ttl time.Duration) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		userRepo: userRepo,
+		services: services,
+		ttl:      ttl,
+		now:      time.Now,
+	}
+}
+
+func (uc *UseCase) AddEntry(ctx context.Context, entry *entity.BlocklistEntry, createdBy uuid.UUID) (*entity.BlocklistEntry, error) {
+	if err := entry.Normalize(); err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if entity.NewBlocklist(existing).Contains(entry.Kind, entry.Value) {
+		return nil, ErrAlreadyBlocked
+	}
+
+	entry.ID = entity.NewID()
+	entry.CreatedBy = &createdBy
+	if err := uc.repo.Create(ctx, entry); err != nil {
+		return nil, err
+	}
+	uc.invalidate()
+
+	uc.services.GetAuditService().LogChange(ctx, &createdBy, "CREATE", "BlocklistEntry", entry.ID, nil, entry)
+
+	return entry, nil
+}
+
+func (uc *UseCase) ListEntries(ctx context.Context, kind entity.BlocklistKind) ([]*entity.BlocklistEntry, error) {
+	if kind != "" && !kind.IsValid() {
+		return nil, entity.ValidationError("Invalid kind. Must be 'email', 'domain' or 'ip_range'")
+	}
+
+	entries, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if kind == "" {
+		return entries, nil
+	}
+
+	filtered := make([]*entity.BlocklistEntry, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Kind == kind {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+func (uc *UseCase) RemoveEntry(ctx context.Context, id uuid.UUID, removedBy uuid.UUID) error {
+	entries, err := uc.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	var removed *entity.BlocklistEntry
+	for _, entry := range entries {
+		if entry.ID == id {
+			removed = entry
+			break
+		}
+	}
+	if removed == nil {
+		return ErrEntryNotFound
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.invalidate()
+
+	uc.services.GetAuditService().LogChange(ctx, &removedBy, "DELETE", "BlocklistEntry", id, removed, nil)
+
+	return nil
+}
+
+func (uc *UseCase) Check(ctx context.Context, email, ip string) error {
+	ruleset, err := uc.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	if email != "" && ruleset.MatchEmail(email) != nil {
+		return ErrBlocked
+	}
+	if ip != "" && ruleset.MatchIP(ip) != nil {
+		return ErrBlocked
+	}
+	return nil
+}
+
+func (uc *UseCase) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	email := ""
+	if userID != nil {
+		user, err := uc.userRepo.GetByID(ctx, *userID)
+		if err != nil {
+			return err
+		}
+		email = user.Email
+	}
+	return uc.Check(ctx, email, ip)
+}
+
+// load returns the cached ruleset, reloading it once it is older than the TTL.
+// When reloading fails the previous ruleset stays in use, so an unreachable
+// database doesn't lift the blocks.
+func (uc *UseCase) load(ctx context.Context) (*entity.Blocklist, error) {
+	now := uc.now()
+
+	uc.mu.RLock()
+	ruleset, loadedAt, generation := uc.ruleset, uc.loadedAt, uc.generation
+	uc.mu.RUnlock()
+	if ruleset != nil && now.Sub(loadedAt) < uc.ttl {
+		return ruleset, nil
+	}
+
+	entries, err := uc.repo.List(ctx)
+	if err != nil {
+		if ruleset != nil {
+			log.Printf("Failed to reload the blocklist, keeping the cached one: %v", err)
+			return ruleset, nil
+		}
+		return nil, err
+	}
+	ruleset = entity.NewBlocklist(entries)
+
+	uc.mu.Lock()
+	if uc.generation == generation {
+		uc.ruleset, uc.loadedAt = ruleset, now
+	}
+	uc.mu.Unlock()
+	return ruleset, nil
+}
+
+// invalidate makes the next check reload the ruleset
+func (uc *UseCase) invalidate() {
+	uc.mu.Lock()
+	uc.ruleset = nil
+	uc.generation++
+	uc.mu.Unlock()
+}
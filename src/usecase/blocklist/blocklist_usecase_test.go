@@ -0,0 +1,144 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+)
+
+// services stands in for the shared test services, which depend on this package
+type services struct{}
+
+func (services) GetAuditService() audit.AuditService { return discardAudit{} }
+
+type discardAudit struct{}
+
+func (discardAudit) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
+	return nil
+}
+
+func newUseCase() (*UseCase, repository.BlocklistRepository, repository.UserRepository) {
+	store := memory.NewStore()
+	repo := memory.NewBlocklistRepository(store)
+	users := memory.NewUserRepository(store)
+	return NewUseCase(repo, users, services{}, time.Minute), repo, users
+}
+
+func block(t *testing.T, uc *UseCase, kind entity.BlocklistKind, value string) *entity.BlocklistEntry {
+	t.Helper()
+	entry, err := uc.AddEntry(context.Background(), &entity.BlocklistEntry{Kind: kind, Value: value}, uuid.New())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return entry
+}
+
+func TestAddEntry(t *testing.T) {
+	uc, _, _ := newUseCase()
+	ctx := context.Background()
+
+	entry := block(t, uc, entity.BlockIPRange, "203.0.113.7")
+	if entry.Value != "203.0.113.7/32" || entry.CreatedBy == nil {
+		t.Errorf("expected a normalized entry with its author, got %+v", entry)
+	}
+
+	if _, err := uc.AddEntry(ctx, &entity.BlocklistEntry{Kind: entity.BlockIPRange, Value: "203.0.113.7/32"}, uuid.New()); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a conflict for a value already blocked, got %v", err)
+	}
+	if _, err := uc.AddEntry(ctx, &entity.BlocklistEntry{Kind: entity.BlockDomain, Value: "localhost"}, uuid.New()); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
+
+func TestListEntries_FiltersByKind(t *testing.T) {
+	uc, _, _ := newUseCase()
+	ctx := context.Background()
+	block(t, uc, entity.BlockEmail, "spam@example.com")
+	block(t, uc, entity.BlockDomain, "mailinator.com")
+
+	entries, err := uc.ListEntries(ctx, entity.BlockDomain)
+	if err != nil || len(entries) != 1 || entries[0].Value != "mailinator.com" {
+		t.Errorf("expected the domain entry, got %v, %v", entries, err)
+	}
+	if entries, _ := uc.ListEntries(ctx, ""); len(entries) != 2 {
+		t.Errorf("expected every entry without a kind, got %d", len(entries))
+	}
+	if _, err := uc.ListEntries(ctx, "phone"); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error for an unknown kind, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	uc, _, _ := newUseCase()
+	ctx := context.Background()
+	block(t, uc, entity.BlockDomain, "mailinator.com")
+	entry := block(t, uc, entity.BlockIPRange, "203.0.113.0/24")
+
+	if err := uc.Check(ctx, "someone@mailinator.com", ""); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("expected a blocked domain to be forbidden, got %v", err)
+	}
+	if err := uc.Check(ctx, "someone@example.com", "203.0.113.50"); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("expected a blocked range to be forbidden, got %v", err)
+	}
+	if err := uc.Check(ctx, "someone@example.com", "198.51.100.1"); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+
+	// Removing an entry applies at once on this instance
+	if err := uc.RemoveEntry(ctx, entry.ID, uuid.New()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := uc.Check(ctx, "", "203.0.113.50"); err != nil {
+		t.Errorf("expected the unblocked range to pass, got %v", err)
+	}
+	if err := uc.RemoveEntry(ctx, entry.ID, uuid.New()); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+}
+
+func TestCheck_ReloadsAfterTTL(t *testing.T) {
+	uc, repo, _ := newUseCase()
+	ctx := context.Background()
+	now := time.Now()
+	uc.now = func() time.Time { return now }
+
+	if err := uc.Check(ctx, "spam@example.com", ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Another instance blocks the address; this one sees it once its cache expires
+	if err := repo.Create(ctx, &entity.BlocklistEntry{Kind: entity.BlockEmail, Value: "spam@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := uc.Check(ctx, "spam@example.com", ""); err != nil {
+		t.Errorf("expected the cached ruleset within the TTL, got %v", err)
+	}
+	now = now.Add(time.Minute)
+	if err := uc.Check(ctx, "spam@example.com", ""); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("expected the reloaded ruleset to block, got %v", err)
+	}
+}
+
+func TestCheckUser(t *testing.T) {
+	uc, _, users := newUseCase()
+	ctx := context.Background()
+	user := &entity.User{ID: uuid.New(), Email: "spam@example.com", Name: "Spammer", Role: entity.RoleCustomer, Active: true}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatal(err)
+	}
+	block(t, uc, entity.BlockEmail, "spam@example.com")
+
+	if err := uc.CheckUser(ctx, &user.ID, ""); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("expected the blocked account to be forbidden, got %v", err)
+	}
+	if err := uc.CheckUser(ctx, nil, "198.51.100.1"); err != nil {
+		t.Errorf("expected a guest from an unblocked IP to pass, got %v", err)
+	}
+}
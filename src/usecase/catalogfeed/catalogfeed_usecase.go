@@ -0,0 +1,124 @@
+package catalogfeed
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/feed"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+)
+
+// scanBatchSize is how many products are loaded at a time while generating
+const scanBatchSize = 200
+
+//go:generate go run ../../internal/tools/mockgen -interface CatalogFeedService -out ../../internal/testing/servicemocks
+
+type CatalogFeedService interface {
+	// GenerateFeeds renders the whole catalog in every feed format, replacing
+	// the feeds served until then, and returns the number of items
+	GenerateFeeds(ctx context.Context) (int, error)
+	// GetFeed returns the latest feed of a format, generating the feeds when
+	// none was generated yet
+	GetFeed(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error)
+	ContentType(format entity.FeedFormat) string
+}
+
+type Services interface {
+	GetPriceBook() pricehistory.Book
+}
+
+// Store describes the storefront the feed items link to
+type Store struct {
+	Name     string
+	URL      string // Product pages are at URL/products/{id}
+	Currency string
+}
+
+type UseCase struct {
+	repo      repository.CatalogFeedRepository
+	services  Services
+	store     Store
+	renderers map[entity.FeedFormat]feed.Renderer
+	now       func() time.Time
+}
+
+func NewUseCase(repo repository.CatalogFeedRepository, services Services, store Store) *UseCase {
+	store.URL = strings.TrimSuffix(store.URL, "/")
+	return &UseCase{
+		repo:     repo,
+		services: services,
+		store:    store,
+		renderers: map[entity.FeedFormat]feed.Renderer{
+			entity.FeedXML: feed.NewXMLRenderer(),
+			entity.FeedCSV: feed.NewCSVRenderer(),
+		},
+		now: time.Now,
+	}
+}
+
+func (uc *UseCase) GenerateFeeds(ctx context.Context) (int, error) {
+	now := uc.now()
+	var items []entity.FeedItem
+
+	err := uc.repo.ScanProducts(ctx, now, scanBatchSize, func(products []*entity.Product) error {
+		// Items are listed at the scheduled price in effect
+		if err := uc.services.GetPriceBook().Attach(ctx, products...); err != nil {
+			return err
+		}
+		for _, product := range products {
+			items = append(items, entity.FeedItems(product, uc.store.URL+"/products/"+product.ID.String(), now)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	doc := &feed.Document{
+		Title:       uc.store.Name,
+		Link:        uc.store.URL,
+		Description: "Products of " + uc.store.Name,
+		Currency:    uc.store.Currency,
+		Items:       items,
+	}
+	for _, format := range entity.FeedFormats {
+		content, err := uc.renderers[format].Render(doc)
+		if err != nil {
+			return 0, err
+		}
+		if err := uc.repo.Save(ctx, &entity.CatalogFeed{
+			Format:      format,
+			Content:     string(content),
+			Items:       len(items),
+			GeneratedAt: now,
+		}); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(items), nil
+}
+
+func (uc *UseCase) GetFeed(ctx context.Context, format entity.FeedFormat) (*entity.CatalogFeed, error) {
+	if !format.IsValid() {
+		return nil, entity.ValidationError("Invalid feed format. Must be 'xml' or 'csv'")
+	}
+
+	catalogFeed, err := uc.repo.Get(ctx, format)
+	if errors.Is(err, entity.ErrNotFound) {
+		// Until the job first runs, e.g. right after the feeds were introduced
+		if _, err := uc.GenerateFeeds(ctx); err != nil {
+			return nil, err
+		}
+		return uc.repo.Get(ctx, format)
+	}
+	return catalogFeed, err
+}
+
+func (uc *UseCase) ContentType(format entity.FeedFormat) string {
+	return uc.renderers[format].ContentType()
+}
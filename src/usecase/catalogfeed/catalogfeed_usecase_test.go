@@ -0,0 +1,74 @@
+package catalogfeed
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+)
+
+func newUseCase(t *testing.T, products ...*entity.Product) *UseCase {
+	t.Helper()
+	store := memory.NewStore()
+	for _, product := range products {
+		if err := memory.NewProductRepository(store).Create(context.Background(), product); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	uc := NewUseCase(memory.NewCatalogFeedRepository(store), &mockServices.MockServices{}, Store{
+		Name:     "Go E-Commerce",
+		URL:      "https://shop.example.com/",
+		Currency: "EUR",
+	})
+	uc.now = func() time.Time { return time.Date(2026, time.May, 1, 3, 0, 0, 0, time.UTC) }
+	return uc
+}
+
+func TestGetFeed_GeneratesOnFirstRequest(t *testing.T) {
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Description: "Fast", Price: 999.9, Quantity: 2}
+	uc := newUseCase(t, laptop)
+
+	csvFeed, err := uc.GetFeed(context.Background(), entity.FeedCSV)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	want := laptop.ID.String() + ",Laptop,Fast,https://shop.example.com/products/" + laptop.ID.String() + ",in stock,new,999.90 EUR"
+	if csvFeed.Items != 1 || !strings.Contains(csvFeed.Content, want) {
+		t.Errorf("expected the laptop in the CSV feed, got %q", csvFeed.Content)
+	}
+
+	xmlFeed, err := uc.GetFeed(context.Background(), entity.FeedXML)
+	if err != nil || !xmlFeed.GeneratedAt.Equal(csvFeed.GeneratedAt) {
+		t.Fatalf("expected the XML feed of the same run, got %+v, %v", xmlFeed, err)
+	}
+	if !strings.Contains(xmlFeed.Content, "<g:price>999.90 EUR</g:price>") {
+		t.Errorf("expected the laptop in the XML feed, got %q", xmlFeed.Content)
+	}
+
+	if _, err := uc.GetFeed(context.Background(), "json"); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected an unknown format to be rejected, got %v", err)
+	}
+}
+
+func TestGenerateFeeds_ReplacesTheFeeds(t *testing.T) {
+	uc := newUseCase(t, &entity.Product{ID: uuid.New(), Name: "Mouse", Price: 20, Quantity: 0})
+	uc.GenerateFeeds(context.Background())
+
+	later := time.Date(2026, time.May, 1, 4, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return later }
+	items, err := uc.GenerateFeeds(context.Background())
+	if err != nil || items != 1 {
+		t.Fatalf("expected 1 item, got %d, %v", items, err)
+	}
+
+	csvFeed, _ := uc.GetFeed(context.Background(), entity.FeedCSV)
+	if !csvFeed.GeneratedAt.Equal(later) || !strings.Contains(csvFeed.Content, "out of stock") {
+		t.Errorf("expected the feed of the latest run, got %+v", csvFeed)
+	}
+}
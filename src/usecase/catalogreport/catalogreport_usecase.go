@@ -0,0 +1,111 @@
+package catalogreport
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+var ErrReportNotFound = entity.NotFoundError("Catalog report not found")
+
+// scanBatchSize is how many products are loaded at a time while scanning
+const scanBatchSize = 200
+
+//go:generate go run ../../internal/tools/mockgen -interface CatalogReportService -out ../../internal/testing/servicemocks
+
+type CatalogReportService interface {
+	// RunReport scans the whole catalog and stores the resulting report
+	RunReport(ctx context.Context, trigger entity.CatalogReportTrigger, triggeredBy *uuid.UUID) (*entity.CatalogReport, error)
+	GetLatestReport(ctx context.Context) (*entity.CatalogReport, error)
+}
+
+type UseCase struct {
+	repo         repository.CatalogReportRepository
+	categoryRepo repository.CategoryRepository
+	now          func() time.Time
+}
+
+func NewUseCase(repo repository.CatalogReportRepository, categoryRepo repository.CategoryRepository) *UseCase {
+	return &UseCase{
+		repo:         repo,
+		categoryRepo: categoryRepo,
+		now:          time.Now,
+	}
+}
+
+func (uc *UseCase) RunReport(ctx context.Context, trigger entity.CatalogReportTrigger, triggeredBy *uuid.UUID) (*entity.CatalogReport, error) {
+	var issues []entity.CatalogIssue
+
+	err := uc.repo.ScanProducts(ctx, scanBatchSize, func(products []*entity.Product) error {
+		for _, product := range products {
+			issues = append(issues, entity.CheckProduct(product)...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	orphans, err := uc.repo.ListOrphanVariants(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, variant := range orphans {
+		issues = append(issues, entity.CheckOrphanVariant(variant))
+	}
+
+	categoryIssues, err := uc.checkCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	issues = append(issues, categoryIssues...)
+
+	report := entity.NewCatalogReport(trigger, triggeredBy, issues)
+	report.CreatedAt = uc.now()
+	if err := uc.repo.Create(ctx, report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (uc *UseCase) GetLatestReport(ctx context.Context) (*entity.CatalogReport, error) {
+	report, err := uc.repo.GetLatest(ctx)
+	if err != nil {
+		return nil, ErrReportNotFound
+	}
+	return report, nil
+}
+
+// checkCategories checks every category, counting the products of its
+// subcategories towards its own
+func (uc *UseCase) checkCategories(ctx context.Context) ([]entity.CatalogIssue, error) {
+	roots, err := uc.categoryRepo.GetTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts, err := uc.repo.CountProductsByCategory(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var issues []entity.CatalogIssue
+	var walk func(category *entity.Category) int
+	walk = func(category *entity.Category) int {
+		total := counts[category.ID]
+		for _, child := range category.Children {
+			total += walk(child)
+		}
+		issues = append(issues, entity.CheckCategory(category, total)...)
+		return total
+	}
+	for _, root := range roots {
+		walk(root)
+	}
+
+	return issues, nil
+}
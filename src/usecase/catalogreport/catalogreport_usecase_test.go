@@ -0,0 +1,97 @@
+package catalogreport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestRepos returns a catalog holding products, orphan variants and
+// categories, with product counts per category
+func newTestRepos(products []*entity.Product, orphans []*entity.ProductVariant, counts map[uuid.UUID]int, categories ...*entity.Category) (*mocks.CatalogReportRepository, *mocks.CategoryRepository) {
+	reportRepo := new(mocks.CatalogReportRepository)
+	reportRepo.On("ScanProducts", mock.Anything, scanBatchSize, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) {
+			if err := args.Get(2).(func(products []*entity.Product) error)(products); err != nil {
+				panic(err)
+			}
+		})
+	reportRepo.On("ListOrphanVariants", mock.Anything).Return(orphans, nil)
+	reportRepo.On("CountProductsByCategory", mock.Anything).Return(counts, nil)
+
+	categoryRepo := new(mocks.CategoryRepository)
+	categoryRepo.On("GetTree", mock.Anything).Return(entity.BuildCategoryTree(categories), nil)
+	return reportRepo, categoryRepo
+}
+
+func TestRunReport(t *testing.T) {
+	parent := &entity.Category{ID: uuid.New(), Name: "Electronics", Slug: "electronics"}
+	child := &entity.Category{ID: uuid.New(), Name: "Gaming", Slug: "gaming", ParentID: &parent.ID}
+	empty := &entity.Category{ID: uuid.New(), Name: "Garden", Slug: "Garden!"}
+
+	reportRepo, categoryRepo := newTestRepos(
+		[]*entity.Product{
+			{ID: uuid.New(), Name: "Laptop", Description: "Fast", Price: 999},
+			{ID: uuid.New(), Name: "Mouse", Price: 0},
+		},
+		[]*entity.ProductVariant{{ID: uuid.New(), ProductID: uuid.New(), SKU: "GONE-1"}},
+		map[uuid.UUID]int{child.ID: 2},
+		parent, child, empty,
+	)
+	reportRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CatalogReport")).Return(nil)
+	uc := NewUseCase(reportRepo, categoryRepo)
+	now := time.Date(2026, time.March, 4, 3, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+
+	report, err := uc.RunReport(context.Background(), entity.ReportScheduled, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Mouse: zero price and missing description, orphan variant, Garden: broken slug and empty
+	if report.Critical != 2 || report.Warnings != 2 || report.Info != 1 {
+		t.Errorf("expected 2 critical, 2 warnings and 1 info, got %d, %d and %d", report.Critical, report.Warnings, report.Info)
+	}
+	for _, issue := range report.Issues {
+		if issue.ResourceID == parent.ID {
+			t.Error("expected a parent with products in its subcategories not to be reported as empty")
+		}
+	}
+	if report.Trigger != entity.ReportScheduled || !report.CreatedAt.Equal(now) {
+		t.Errorf("unexpected report metadata %s at %s", report.Trigger, report.CreatedAt)
+	}
+	reportRepo.AssertCalled(t, "Create", mock.Anything, report)
+}
+
+func TestGetLatestReport(t *testing.T) {
+	reportRepo, categoryRepo := newTestRepos(nil, nil, nil)
+	reportRepo.On("GetLatest", mock.Anything).Return(nil, errors.New("not found")).Once()
+	reportRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.CatalogReport")).Return(nil).
+		Run(func(args mock.Arguments) {
+			reportRepo.On("GetLatest", mock.Anything).Return(args.Get(1), nil)
+		})
+	uc := NewUseCase(reportRepo, categoryRepo)
+
+	if _, err := uc.GetLatestReport(context.Background()); !errors.Is(err, ErrReportNotFound) {
+		t.Errorf("expected ErrReportNotFound, got %v", err)
+	}
+
+	adminID := uuid.New()
+	if _, err := uc.RunReport(context.Background(), entity.ReportManual, &adminID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	report, err := uc.GetLatestReport(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.TriggeredBy == nil || *report.TriggeredBy != adminID {
+		t.Error("expected the latest report to record who ran it")
+	}
+}
@@ -7,14 +7,50 @@ import (
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
 )
 
+// ErrCategoryNotFound is returned when the requested category does not exist
+var ErrCategoryNotFound = entity.NotFoundError("Category not found")
+
+// ErrInvalidSort is returned when products are requested with an unsupported sort field
+var ErrInvalidSort = entity.ValidationError("Invalid sort field, use name, price, created_at or rating")
+
+// ErrCategoryInUse is returned when deleting a category that still has products assigned
+var ErrCategoryInUse = entity.ConflictError("Category still has products assigned, use force=true to delete it anyway")
+
+// ErrParentNotFound is returned when the requested parent category does not exist
+var ErrParentNotFound = entity.NotFoundError("Parent category not found")
+
+// ErrTargetNotFound is returned when the category to merge into does not exist
+var ErrTargetNotFound = entity.NotFoundError("Target category not found")
+
+//go:generate go run ../../internal/tools/mockgen -interface CategoryService -out ../../internal/testing/servicemocks
+
 type CategoryService interface {
-	CreateCategory(ctx context.Context, name string) (*entity.Category, error)
+	// CreateCategory creates a category, optionally nested under parentID
+	CreateCategory(ctx context.Context, name string, parentID *uuid.UUID) (*entity.Category, error)
 	GetCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error)
 	ListCategories(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error)
-	UpdateCategory(ctx context.Context, id uuid.UUID, name string) (*entity.Category, error)
-	DeleteCategory(ctx context.Context, id uuid.UUID) error
+	// UpdateCategory renames a category and moves it under parentID (nil makes it a root).
+	// The slug is kept so existing links keep working.
+	// Returns entity.ErrCategoryCycle when parentID is the category itself or one of its descendants.
+	UpdateCategory(ctx context.Context, id uuid.UUID, name string, parentID *uuid.UUID) (*entity.Category, error)
+	// DeleteCategory removes a category and moves its subcategories up to its parent.
+	// Returns ErrCategoryInUse when products are still assigned, unless force is set,
+	// in which case the products are unassigned first.
+	DeleteCategory(ctx context.Context, id uuid.UUID, force bool) error
+	// MergeCategory moves the products and subcategories of a category into
+	// the target and deletes it, for catalog cleanup. Its slug keeps leading
+	// to the target. Returns the target and the number of products moved, or
+	// entity.ErrCategoryMergeCycle when the target is the category itself or
+	// one of its descendants.
+	MergeCategory(ctx context.Context, id, targetID uuid.UUID) (*entity.Category, int, error)
+	// GetCategoryTree returns the root categories with their subcategories nested
+	GetCategoryTree(ctx context.Context) ([]*entity.Category, error)
+	// ListProductsBySlug returns the category with the given slug and a page of its products.
+	// The slug of a merged category returns the category it was merged into.
+	ListProductsBySlug(ctx context.Context, slug string, sort repository.ProductSort, page, pageSize int) (*entity.Category, []*entity.Product, int, error)
 
 	// Product-Category relationship operations
 	AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error
@@ -22,20 +58,27 @@ type CategoryService interface {
 	GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error)
 }
 
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
 type UseCase struct {
-	repo repository.CategoryRepository
+	repo     repository.CategoryRepository
+	services Services
 }
 
-func NewUseCase(repo repository.CategoryRepository) *UseCase {
+func NewUseCase(repo repository.CategoryRepository, services Services) *UseCase {
 	return &UseCase{
-		repo: repo,
+		repo:     repo,
+		services: services,
 	}
 }
 
-func (uc *UseCase) CreateCategory(ctx context.Context, name string) (*entity.Category, error) {
+func (uc *UseCase) CreateCategory(ctx context.Context, name string, parentID *uuid.UUID) (*entity.Category, error) {
 	category := &entity.Category{
-		ID:        uuid.New(),
+		ID:        entity.NewID(),
 		Name:      name,
+		ParentID:  parentID,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -44,15 +87,33 @@ func (uc *UseCase) CreateCategory(ctx context.Context, name string) (*entity.Cat
 		return nil, err
 	}
 
+	if parentID != nil {
+		if _, err := uc.repo.GetByID(ctx, *parentID); err != nil {
+			return nil, ErrParentNotFound
+		}
+	}
+
+	slug, err := uc.uniqueSlug(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	category.Slug = slug
+
 	if err := uc.repo.Create(ctx, category); err != nil {
 		return nil, err
 	}
 
+	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE", "Category", category.ID, nil, category)
+
 	return category, nil
 }
 
 func (uc *UseCase) GetCategory(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
-	return uc.repo.GetByID(ctx, id)
+	category, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+	return category, nil
 }
 
 func (uc *UseCase) ListCategories(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error) {
@@ -66,36 +127,169 @@ func (uc *UseCase) ListCategories(ctx context.Context, page, pageSize int) ([]*e
 	return uc.repo.GetAll(ctx, page, pageSize)
 }
 
-func (uc *UseCase) UpdateCategory(ctx context.Context, id uuid.UUID, name string) (*entity.Category, error) {
+func (uc *UseCase) UpdateCategory(ctx context.Context, id uuid.UUID, name string, parentID *uuid.UUID) (*entity.Category, error) {
 	category, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, ErrCategoryNotFound
 	}
 
+	original := *category
 	category.Name = name
+	category.ParentID = parentID
 	category.UpdatedAt = time.Now()
 
 	if err := category.Validate(); err != nil {
 		return nil, err
 	}
 
+	if parentID != nil {
+		if err := uc.validateParent(ctx, id, *parentID); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := uc.repo.Update(ctx, category); err != nil {
 		return nil, err
 	}
 
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE", "Category", category.ID, &original, category)
+
 	return category, nil
 }
 
-func (uc *UseCase) DeleteCategory(ctx context.Context, id uuid.UUID) error {
-	return uc.repo.Delete(ctx, id)
+func (uc *UseCase) uniqueSlug(ctx context.Context, name string) (string, error) {
+	return entity.UniqueSlug(entity.Slugify(name), func(slug string) (bool, error) {
+		return uc.repo.SlugExists(ctx, slug)
+	})
+}
+
+// validateParent ensures the new parent exists and is not below the category
+func (uc *UseCase) validateParent(ctx context.Context, id, parentID uuid.UUID) error {
+	if _, err := uc.repo.GetByID(ctx, parentID); err != nil {
+		return ErrParentNotFound
+	}
+
+	descendants, err := uc.repo.GetDescendants(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, descendant := range descendants {
+		if descendant.ID == parentID {
+			return entity.ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
+func (uc *UseCase) DeleteCategory(ctx context.Context, id uuid.UUID, force bool) error {
+	category, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+
+	if !force && len(category.Products) > 0 {
+		return ErrCategoryInUse
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE", "Category", id, category, nil)
+
+	return nil
+}
+
+func (uc *UseCase) MergeCategory(ctx context.Context, id, targetID uuid.UUID) (*entity.Category, int, error) {
+	source, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, 0, ErrCategoryNotFound
+	}
+	if _, err := uc.repo.GetByID(ctx, targetID); err != nil {
+		return nil, 0, ErrTargetNotFound
+	}
+	if id == targetID {
+		return nil, 0, entity.ErrCategoryMergeCycle
+	}
+
+	descendants, err := uc.repo.GetDescendants(ctx, id)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, descendant := range descendants {
+		if descendant.ID == targetID {
+			return nil, 0, entity.ErrCategoryMergeCycle
+		}
+	}
+
+	moved, err := uc.repo.Merge(ctx, id, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	target, err := uc.repo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "MERGE", "Category", id, source,
+		map[string]interface{}{"merged_into": targetID, "products_moved": moved})
+
+	return target, moved, nil
+}
+
+func (uc *UseCase) GetCategoryTree(ctx context.Context) ([]*entity.Category, error) {
+	return uc.repo.GetTree(ctx)
+}
+
+func (uc *UseCase) ListProductsBySlug(ctx context.Context, slug string, sort repository.ProductSort, page, pageSize int) (*entity.Category, []*entity.Product, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	if !sort.IsValid() {
+		return nil, nil, 0, ErrInvalidSort
+	}
+
+	category, err := uc.repo.GetBySlug(ctx, slug)
+	if err != nil {
+		if category, err = uc.repo.GetByMergedSlug(ctx, slug); err != nil {
+			return nil, nil, 0, ErrCategoryNotFound
+		}
+	}
+
+	products, total, err := uc.repo.GetProducts(ctx, category.ID, time.Now(), sort, page, pageSize)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	return category, products, total, nil
 }
 
 func (uc *UseCase) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	return uc.repo.AssignCategoryToProduct(ctx, productID, categoryID)
+	if err := uc.repo.AssignCategoryToProduct(ctx, productID, categoryID); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "ASSIGN_CATEGORY", "Product", productID, nil,
+		map[string]interface{}{"category_id": categoryID})
+
+	return nil
 }
 
 func (uc *UseCase) RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	return uc.repo.RemoveCategoryFromProduct(ctx, productID, categoryID)
+	if err := uc.repo.RemoveCategoryFromProduct(ctx, productID, categoryID); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "REMOVE_CATEGORY", "Product", productID,
+		map[string]interface{}{"category_id": categoryID}, nil)
+
+	return nil
 }
 
 func (uc *UseCase) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
@@ -10,76 +10,24 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
 )
 
-// MockCategoryRepository is a mock implementation of repository.CategoryRepository
-type MockCategoryRepository struct {
-	mock.Mock
-}
-
-func (m *MockCategoryRepository) Create(ctx context.Context, category *entity.Category) error {
-	args := m.Called(ctx, category)
-	return args.Error(0)
-}
-
-func (m *MockCategoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.Category, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Category), args.Error(1)
-}
-
-func (m *MockCategoryRepository) GetAll(ctx context.Context, page, pageSize int) ([]*entity.Category, int, error) {
-	args := m.Called(ctx, page, pageSize)
-	return args.Get(0).([]*entity.Category), args.Get(1).(int), args.Error(2)
-}
-
-func (m *MockCategoryRepository) GetByName(ctx context.Context, name string) (*entity.Category, error) {
-	args := m.Called(ctx, name)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.Category), args.Error(1)
-}
-
-func (m *MockCategoryRepository) Update(ctx context.Context, category *entity.Category) error {
-	args := m.Called(ctx, category)
-	return args.Error(0)
-}
-
-func (m *MockCategoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
-}
-
-func (m *MockCategoryRepository) AssignCategoryToProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	args := m.Called(ctx, productID, categoryID)
-	return args.Error(0)
-}
-
-func (m *MockCategoryRepository) RemoveCategoryFromProduct(ctx context.Context, productID, categoryID uuid.UUID) error {
-	args := m.Called(ctx, productID, categoryID)
-	return args.Error(0)
-}
-
-func (m *MockCategoryRepository) GetProductCategories(ctx context.Context, productID uuid.UUID) ([]*entity.Category, error) {
-	args := m.Called(ctx, productID)
-	return args.Get(0).([]*entity.Category), args.Error(1)
-}
-
 func TestUseCase_CreateCategory(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		name := "Electronics"
 
+		mockRepo.On("SlugExists", mock.Anything, "electronics").Return(false, nil)
 		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.Category) bool {
-			return c.Name == name
+			return c.Name == name && c.Slug == "electronics"
 		})).Return(nil)
 
-		result, err := useCase.CreateCategory(context.Background(), name)
+		result, err := useCase.CreateCategory(context.Background(), name, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -89,10 +37,10 @@ func TestUseCase_CreateCategory(t *testing.T) {
 	})
 
 	t.Run("Validation Error - Empty Name", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
-		result, err := useCase.CreateCategory(context.Background(), "")
+		result, err := useCase.CreateCategory(context.Background(), "", nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -101,16 +49,17 @@ func TestUseCase_CreateCategory(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		name := "Electronics"
 
+		mockRepo.On("SlugExists", mock.Anything, "electronics").Return(false, nil)
 		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.Category) bool {
-			return c.Name == name
+			return c.Name == name && c.Slug == "electronics"
 		})).Return(errors.New("database error"))
 
-		result, err := useCase.CreateCategory(context.Background(), name)
+		result, err := useCase.CreateCategory(context.Background(), name, nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -121,8 +70,8 @@ func TestUseCase_CreateCategory(t *testing.T) {
 
 func TestUseCase_GetCategory(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 		expectedCategory := &entity.Category{
@@ -140,8 +89,8 @@ func TestUseCase_GetCategory(t *testing.T) {
 	})
 
 	t.Run("Not Found", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 
@@ -157,8 +106,8 @@ func TestUseCase_GetCategory(t *testing.T) {
 
 func TestUseCase_ListCategories(t *testing.T) {
 	t.Run("Success - Default Pagination", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		expectedCategories := []*entity.Category{
 			{ID: uuid.New(), Name: "Electronics"},
@@ -177,8 +126,8 @@ func TestUseCase_ListCategories(t *testing.T) {
 	})
 
 	t.Run("Success - Custom Pagination", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		expectedCategories := []*entity.Category{
 			{ID: uuid.New(), Name: "Electronics"},
@@ -196,8 +145,8 @@ func TestUseCase_ListCategories(t *testing.T) {
 	})
 
 	t.Run("Success - Max Page Size Limit", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		expectedCategories := []*entity.Category{}
 		expectedTotal := 0
@@ -214,8 +163,8 @@ func TestUseCase_ListCategories(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		mockRepo.On("GetAll", mock.Anything, 1, 10).Return([]*entity.Category{}, 0, errors.New("database error"))
 
@@ -230,8 +179,8 @@ func TestUseCase_ListCategories(t *testing.T) {
 
 func TestUseCase_UpdateCategory(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 		existingCategory := &entity.Category{
@@ -245,7 +194,7 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 			return c.ID == categoryID && c.Name == newName
 		})).Return(nil)
 
-		result, err := useCase.UpdateCategory(context.Background(), categoryID, newName)
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, newName, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -254,8 +203,8 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 	})
 
 	t.Run("Validation Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 		existingCategory := &entity.Category{
@@ -265,7 +214,7 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 
 		mockRepo.On("GetByID", mock.Anything, categoryID).Return(existingCategory, nil)
 
-		result, err := useCase.UpdateCategory(context.Background(), categoryID, "")
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "", nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -274,14 +223,14 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 	})
 
 	t.Run("Category Not Found", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 
 		mockRepo.On("GetByID", mock.Anything, categoryID).Return(nil, errors.New("not found"))
 
-		result, err := useCase.UpdateCategory(context.Background(), categoryID, "New Name")
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "New Name", nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -290,8 +239,8 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 		existingCategory := &entity.Category{
@@ -302,7 +251,7 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 		mockRepo.On("GetByID", mock.Anything, categoryID).Return(existingCategory, nil)
 		mockRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("database error"))
 
-		result, err := useCase.UpdateCategory(context.Background(), categoryID, "New Name")
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "New Name", nil)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -310,40 +259,321 @@ func TestUseCase_UpdateCategory(t *testing.T) {
 	})
 }
 
+func TestUseCase_CreateCategory_SlugCollision(t *testing.T) {
+	mockRepo := new(mocks.CategoryRepository)
+	useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+	mockRepo.On("SlugExists", mock.Anything, "home-garden").Return(true, nil)
+	mockRepo.On("SlugExists", mock.Anything, "home-garden-2").Return(true, nil)
+	mockRepo.On("SlugExists", mock.Anything, "home-garden-3").Return(false, nil)
+	mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	result, err := useCase.CreateCategory(context.Background(), "Home & Garden", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "home-garden-3", result.Slug)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUseCase_ListProductsBySlug(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		category := &entity.Category{ID: uuid.New(), Name: "Laptops", Slug: "laptops"}
+		sort := repository.ProductSort{Field: repository.ProductSortPrice, Descending: true}
+		products := []*entity.Product{{ID: uuid.New(), Name: "Laptop Pro", Price: 2000}}
+
+		mockRepo.On("GetBySlug", mock.Anything, "laptops").Return(category, nil)
+		mockRepo.On("GetProducts", mock.Anything, category.ID, mock.Anything, sort, 1, 10).Return(products, 1, nil)
+
+		result, list, total, err := useCase.ListProductsBySlug(context.Background(), "laptops", sort, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, category, result)
+		assert.Equal(t, products, list)
+		assert.Equal(t, 1, total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Slug", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		mockRepo.On("GetBySlug", mock.Anything, "missing").Return(nil, errors.New("record not found"))
+		mockRepo.On("GetByMergedSlug", mock.Anything, "missing").Return(nil, errors.New("record not found"))
+
+		_, _, _, err := useCase.ListProductsBySlug(context.Background(), "missing", repository.ProductSort{Field: repository.ProductSortName}, 1, 10)
+
+		assert.ErrorIs(t, err, ErrCategoryNotFound)
+		mockRepo.AssertNotCalled(t, "GetProducts")
+	})
+
+	t.Run("Merged Slug", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		target := &entity.Category{ID: uuid.New(), Name: "Laptops", Slug: "laptops"}
+
+		mockRepo.On("GetBySlug", mock.Anything, "notebooks").Return(nil, errors.New("record not found"))
+		mockRepo.On("GetByMergedSlug", mock.Anything, "notebooks").Return(target, nil)
+		mockRepo.On("GetProducts", mock.Anything, target.ID, mock.Anything, mock.Anything, 1, 10).Return(nil, 0, nil)
+
+		result, _, _, err := useCase.ListProductsBySlug(context.Background(), "notebooks", repository.ProductSort{Field: repository.ProductSortName}, 1, 10)
+
+		assert.NoError(t, err)
+		assert.Equal(t, target, result)
+	})
+
+	t.Run("Invalid Sort", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		_, _, _, err := useCase.ListProductsBySlug(context.Background(), "laptops", repository.ProductSort{Field: "quantity; DROP TABLE products"}, 1, 10)
+
+		assert.ErrorIs(t, err, ErrInvalidSort)
+		mockRepo.AssertNotCalled(t, "GetBySlug")
+	})
+}
+
+func TestUseCase_CategoryHierarchy(t *testing.T) {
+	t.Run("Create Under Parent", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		parentID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, parentID).Return(&entity.Category{ID: parentID, Name: "Electronics"}, nil)
+		mockRepo.On("SlugExists", mock.Anything, "laptops").Return(false, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *entity.Category) bool {
+			return c.ParentID != nil && *c.ParentID == parentID
+		})).Return(nil)
+
+		result, err := useCase.CreateCategory(context.Background(), "Laptops", &parentID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, parentID, *result.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Create Under Missing Parent", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		parentID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, parentID).Return(nil, errors.New("not found"))
+
+		result, err := useCase.CreateCategory(context.Background(), "Laptops", &parentID)
+
+		assert.ErrorIs(t, err, ErrParentNotFound)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("Move Under Itself", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Name: "Electronics"}, nil)
+
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "Electronics", &categoryID)
+
+		assert.ErrorIs(t, err, entity.ErrCategoryCycle)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Move Under Descendant", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+		grandchildID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Name: "Electronics"}, nil)
+		mockRepo.On("GetByID", mock.Anything, grandchildID).Return(&entity.Category{ID: grandchildID, Name: "Laptops"}, nil)
+		mockRepo.On("GetDescendants", mock.Anything, categoryID).Return([]*entity.Category{
+			{ID: uuid.New(), Name: "Computers"},
+			{ID: grandchildID, Name: "Laptops"},
+		}, nil)
+
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "Electronics", &grandchildID)
+
+		assert.ErrorIs(t, err, entity.ErrCategoryCycle)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "Update")
+	})
+
+	t.Run("Move Under Sibling", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+		siblingID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Name: "Laptops"}, nil)
+		mockRepo.On("GetByID", mock.Anything, siblingID).Return(&entity.Category{ID: siblingID, Name: "Computers"}, nil)
+		mockRepo.On("GetDescendants", mock.Anything, categoryID).Return([]*entity.Category{}, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := useCase.UpdateCategory(context.Background(), categoryID, "Laptops", &siblingID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, siblingID, *result.ParentID)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
 func TestUseCase_DeleteCategory(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Name: "Electronics"}, nil)
 		mockRepo.On("Delete", mock.Anything, categoryID).Return(nil)
 
-		err := useCase.DeleteCategory(context.Background(), categoryID)
+		err := useCase.DeleteCategory(context.Background(), categoryID, false)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(nil, errors.New("record not found"))
+
+		err := useCase.DeleteCategory(context.Background(), categoryID, false)
+
+		assert.ErrorIs(t, err, ErrCategoryNotFound)
+		mockRepo.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("Products Assigned", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+		withProducts := &entity.Category{ID: categoryID, Name: "Electronics", Products: []entity.Product{{ID: uuid.New()}}}
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(withProducts, nil)
+
+		err := useCase.DeleteCategory(context.Background(), categoryID, false)
+
+		assert.ErrorIs(t, err, ErrCategoryInUse)
+		mockRepo.AssertNotCalled(t, "Delete")
+	})
+
+	t.Run("Force With Products Assigned", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		categoryID := uuid.New()
+		withProducts := &entity.Category{ID: categoryID, Name: "Electronics", Products: []entity.Product{{ID: uuid.New()}}}
+
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(withProducts, nil)
+		mockRepo.On("Delete", mock.Anything, categoryID).Return(nil)
+
+		err := useCase.DeleteCategory(context.Background(), categoryID, true)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		categoryID := uuid.New()
 
+		mockRepo.On("GetByID", mock.Anything, categoryID).Return(&entity.Category{ID: categoryID, Name: "Electronics"}, nil)
 		mockRepo.On("Delete", mock.Anything, categoryID).Return(errors.New("database error"))
 
-		err := useCase.DeleteCategory(context.Background(), categoryID)
+		err := useCase.DeleteCategory(context.Background(), categoryID, false)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
 	})
 }
 
+func TestUseCase_MergeCategory(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		sourceID, targetID := uuid.New(), uuid.New()
+		target := &entity.Category{ID: targetID, Name: "Phones", Slug: "phones"}
+
+		mockRepo.On("GetByID", mock.Anything, sourceID).Return(&entity.Category{ID: sourceID, Name: "Mobiles"}, nil)
+		mockRepo.On("GetByID", mock.Anything, targetID).Return(target, nil)
+		mockRepo.On("GetDescendants", mock.Anything, sourceID).Return([]*entity.Category{{ID: uuid.New()}}, nil)
+		mockRepo.On("Merge", mock.Anything, sourceID, targetID).Return(3, nil)
+
+		merged, moved, err := useCase.MergeCategory(context.Background(), sourceID, targetID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, target, merged)
+		assert.Equal(t, 3, moved)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Target Not Found", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		sourceID, targetID := uuid.New(), uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, sourceID).Return(&entity.Category{ID: sourceID}, nil)
+		mockRepo.On("GetByID", mock.Anything, targetID).Return(nil, errors.New("record not found"))
+
+		_, _, err := useCase.MergeCategory(context.Background(), sourceID, targetID)
+
+		assert.ErrorIs(t, err, ErrTargetNotFound)
+		mockRepo.AssertNotCalled(t, "Merge")
+	})
+
+	t.Run("Into Itself", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		id := uuid.New()
+		mockRepo.On("GetByID", mock.Anything, id).Return(&entity.Category{ID: id}, nil)
+
+		_, _, err := useCase.MergeCategory(context.Background(), id, id)
+
+		assert.ErrorIs(t, err, entity.ErrCategoryMergeCycle)
+		mockRepo.AssertNotCalled(t, "Merge")
+	})
+
+	t.Run("Into A Descendant", func(t *testing.T) {
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
+
+		sourceID, targetID := uuid.New(), uuid.New()
+
+		mockRepo.On("GetByID", mock.Anything, sourceID).Return(&entity.Category{ID: sourceID}, nil)
+		mockRepo.On("GetByID", mock.Anything, targetID).Return(&entity.Category{ID: targetID, ParentID: &sourceID}, nil)
+		mockRepo.On("GetDescendants", mock.Anything, sourceID).Return([]*entity.Category{{ID: targetID}}, nil)
+
+		_, _, err := useCase.MergeCategory(context.Background(), sourceID, targetID)
+
+		assert.ErrorIs(t, err, entity.ErrCategoryMergeCycle)
+		mockRepo.AssertNotCalled(t, "Merge")
+	})
+}
+
 func TestUseCase_AssignCategoryToProduct(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 		categoryID := uuid.New()
@@ -357,8 +587,8 @@ func TestUseCase_AssignCategoryToProduct(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 		categoryID := uuid.New()
@@ -374,8 +604,8 @@ func TestUseCase_AssignCategoryToProduct(t *testing.T) {
 
 func TestUseCase_RemoveCategoryFromProduct(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 		categoryID := uuid.New()
@@ -389,8 +619,8 @@ func TestUseCase_RemoveCategoryFromProduct(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 		categoryID := uuid.New()
@@ -406,8 +636,8 @@ func TestUseCase_RemoveCategoryFromProduct(t *testing.T) {
 
 func TestUseCase_GetProductCategories(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 		expectedCategories := []*entity.Category{
@@ -425,8 +655,8 @@ func TestUseCase_GetProductCategories(t *testing.T) {
 	})
 
 	t.Run("Repository Error", func(t *testing.T) {
-		mockRepo := new(MockCategoryRepository)
-		useCase := NewUseCase(mockRepo)
+		mockRepo := new(mocks.CategoryRepository)
+		useCase := NewUseCase(mockRepo, &mockServices.MockServices{})
 
 		productID := uuid.New()
 
@@ -0,0 +1,195 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/usecase/textmessage"
+)
+
+// Profile is the admin view of a customer account
+type Profile struct {
+	User       *entity.User
+	Notes      []*entity.CustomerNote
+	RiskEvents []*entity.CustomerRiskEvent
+	RiskScore  int
+	RiskLevel  entity.RiskLevel
+}
+
+// favoriteCategoryLimit is how many categories a customer summary names
+const favoriteCategoryLimit = 3
+
+//go:generate go run ../../internal/tools/mockgen -interface CustomerService -out ../../internal/testing/servicemocks
+
+type CustomerService interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*Profile, error)
+	AddNote(ctx context.Context, userID, authorID uuid.UUID, body string) (*entity.CustomerNote, error)
+	RecordRiskEvent(ctx context.Context, userID uuid.UUID, eventType entity.RiskEventType, reference string) (*entity.CustomerRiskEvent, error)
+	RiskScore(ctx context.Context, userID uuid.UUID) (int, error)
+	SetCustomerGroup(ctx context.Context, userID uuid.UUID, group string) (*entity.Customer, error)
+	// GetSummary returns the account's order count, lifetime spend, average
+	// order value, last order and favorite categories
+	GetSummary(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error)
+
+	// Self-service on the customer's own data
+	GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error)
+	UpdateCustomer(ctx context.Context, userID uuid.UUID, update CustomerUpdate) (*entity.Customer, error)
+	ExportData(ctx context.Context, userID uuid.UUID) (*DataExport, error)
+	DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (int, error)
+	SendPhoneVerification(ctx context.Context, userID uuid.UUID) error
+	VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*entity.Customer, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetTextMessenger() textmessage.Messenger
+}
+
+type UseCase struct {
+	userRepo      repository.UserRepository
+	profileRepo   repository.CustomerProfileRepository
+	customerRepo  repository.CustomerRepository
+	orderRepo     repository.OrderRepository
+	analyticsRepo repository.AnalyticsRepository
+	reviewRepo    repository.ProductReviewRepository
+	blocklistRepo repository.BlocklistRepository
+	auditRepo     repository.AuditLogRepository
+	invoices      InvoiceBiller
+	revoker       TokenRevoker
+	services      Services
+}
+
+func NewUseCase(userRepo repository.UserRepository, profileRepo repository.CustomerProfileRepository, customerRepo repository.CustomerRepository, orderRepo repository.OrderRepository, analyticsRepo repository.AnalyticsRepository, reviewRepo repository.ProductReviewRepository, blocklistRepo repository.BlocklistRepository, auditRepo repository.AuditLogRepository, invoices InvoiceBiller, revoker TokenRevoker, services Services) *UseCase {
+	return &UseCase{
+		userRepo:      userRepo,
+		profileRepo:   profileRepo,
+		customerRepo:  customerRepo,
+		orderRepo:     orderRepo,
+		analyticsRepo: analyticsRepo,
+		reviewRepo:    reviewRepo,
+		blocklistRepo: blocklistRepo,
+		auditRepo:     auditRepo,
+		invoices:      invoices,
+		revoker:       revoker,
+		services:      services,
+	}
+}
+
+func (uc *UseCase) GetProfile(ctx context.Context, userID uuid.UUID) (*Profile, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := uc.profileRepo.ListNotes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := uc.profileRepo.ListRiskEvents(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	score := calculateScore(events)
+
+	return &Profile{
+		User:       user,
+		Notes:      notes,
+		RiskEvents: events,
+		RiskScore:  score,
+		RiskLevel:  entity.RiskLevelFor(score),
+	}, nil
+}
+
+func (uc *UseCase) AddNote(ctx context.Context, userID, authorID uuid.UUID, body string) (*entity.CustomerNote, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	note := &entity.CustomerNote{
+		ID:        entity.NewID(),
+		UserID:    userID,
+		AuthorID:  authorID,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+
+	if err := note.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.profileRepo.AddNote(ctx, note); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &authorID, "ADD_NOTE", "Customer", userID, nil, note)
+
+	return note, nil
+}
+
+func (uc *UseCase) RecordRiskEvent(ctx context.Context, userID uuid.UUID, eventType entity.RiskEventType, reference string) (*entity.CustomerRiskEvent, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	event := &entity.CustomerRiskEvent{
+		ID:        entity.NewID(),
+		UserID:    userID,
+		Type:      eventType,
+		Reference: reference,
+		CreatedAt: time.Now(),
+	}
+
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.profileRepo.AddRiskEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "ADD_RISK_EVENT", "Customer", userID, nil, event)
+
+	return event, nil
+}
+
+func (uc *UseCase) RiskScore(ctx context.Context, userID uuid.UUID) (int, error) {
+	events, err := uc.profileRepo.ListRiskEvents(ctx, userID)
+	if err != nil {
+		return 0, errors.New("Failed to load risk events")
+	}
+
+	return calculateScore(events), nil
+}
+
+func (uc *UseCase) GetSummary(ctx context.Context, userID uuid.UUID) (*entity.CustomerSummary, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	summary, err := uc.analyticsRepo.SummarizeCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary.FavoriteCategories, err = uc.analyticsRepo.FavoriteCategories(ctx, userID, favoriteCategoryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func calculateScore(events []*entity.CustomerRiskEvent) int {
+	values := make([]entity.CustomerRiskEvent, len(events))
+	for i, event := range events {
+		values[i] = *event
+	}
+	return entity.CalculateRiskScore(values)
+}
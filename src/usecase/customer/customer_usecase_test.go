@@ -0,0 +1,86 @@
+package customer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSummary(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	user := f.user(t, "jane@example.com", entity.RoleCustomer)
+	other := f.user(t, "john@example.com", entity.RoleCustomer)
+
+	books := entity.Category{Name: "Books", Slug: "books"}
+	games := entity.Category{Name: "Games", Slug: "games"}
+	music := entity.Category{Name: "Music", Slug: "music"}
+	categories := memory.NewCategoryRepository(f.store)
+	for _, category := range []*entity.Category{&books, &games, &music} {
+		require.NoError(t, categories.Create(ctx, category))
+	}
+	products := memory.NewProductRepository(f.store)
+	boardGameBook := &entity.Product{Name: "Board Game Guide", Price: 20, Categories: []entity.Category{books, games}}
+	chess := &entity.Product{Name: "Chess", Price: 10, Categories: []entity.Category{games}}
+	album := &entity.Product{Name: "Album", Price: 15, Categories: []entity.Category{music}}
+	for _, product := range []*entity.Product{boardGameBook, chess, album} {
+		require.NoError(t, products.Create(ctx, product))
+	}
+
+	place := func(userID uuid.UUID, status entity.OrderStatus, createdAt time.Time, items ...entity.OrderItem) {
+		order := &entity.Order{CustomerID: 1, UserID: &userID, Status: status, PaymentStatus: entity.Paid, CreatedAt: createdAt, Products: items}
+		for _, item := range items {
+			order.TotalPrice += item.TotalPrice
+		}
+		require.NoError(t, f.orders.Create(ctx, order))
+	}
+	line := func(product *entity.Product, quantity int) entity.OrderItem {
+		total := product.Price * float64(quantity)
+		return entity.OrderItem{ProductID: product.ID, Quantity: quantity, Price: product.Price, TotalPrice: total}
+	}
+
+	// The oldest order is archived, the cancelled one counts as an order only
+	place(user.ID, entity.Completed, time.Now().AddDate(-2, 0, 0), line(album, 1))
+	_, err := f.archive.ArchiveBatch(ctx, time.Now().AddDate(-1, 0, 0), 10)
+	require.NoError(t, err)
+	lastOrderAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+	place(user.ID, entity.Completed, lastOrderAt.Add(-time.Hour), line(boardGameBook, 2), line(chess, 1))
+	place(user.ID, entity.Cancelled, lastOrderAt, line(album, 5))
+	place(other.ID, entity.Completed, lastOrderAt, line(album, 9))
+
+	summary, err := f.uc.GetSummary(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, summary.Orders)
+	assert.Equal(t, 2, summary.PaidOrders)
+	assert.Equal(t, 65.0, summary.LifetimeSpend)
+	assert.Equal(t, 32.5, summary.AverageOrderValue)
+	require.NotNil(t, summary.LastOrderAt)
+	assert.True(t, lastOrderAt.Equal(*summary.LastOrderAt), "the cancelled order is still the last one placed")
+	assert.Equal(t, []entity.CategorySales{
+		{CategoryID: games.ID, Name: "Games", Units: 3, Spend: 50},
+		{CategoryID: books.ID, Name: "Books", Units: 2, Spend: 40},
+		{CategoryID: music.ID, Name: "Music", Units: 1, Spend: 15},
+	}, summary.FavoriteCategories)
+
+	t.Run("An account without orders", func(t *testing.T) {
+		newcomer := f.user(t, "new@example.com", entity.RoleCustomer)
+
+		summary, err := f.uc.GetSummary(ctx, newcomer.ID)
+		require.NoError(t, err)
+		assert.Zero(t, summary.Orders)
+		assert.Zero(t, summary.AverageOrderValue)
+		assert.Nil(t, summary.LastOrderAt)
+		assert.Empty(t, summary.FavoriteCategories)
+	})
+
+	t.Run("An unknown account", func(t *testing.T) {
+		_, err := f.uc.GetSummary(ctx, uuid.New())
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+	})
+}
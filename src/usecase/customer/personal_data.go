@@ -0,0 +1,220 @@
+package customer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface TokenRevoker -out ../../internal/testing/servicemocks
+
+// TokenRevoker signs an account out everywhere
+type TokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error
+}
+
+// InvoiceBiller renders the invoices of orders again, billed to someone else
+type InvoiceBiller interface {
+	RebillInvoices(ctx context.Context, orderIDs []uuid.UUID, party func(name, email string) (string, string)) (int, error)
+}
+
+// deletedCustomer is who the invoices of a deleted account are billed to
+const deletedCustomer = "Deleted customer"
+
+// CustomerUpdate replaces the customer data of an account
+type CustomerUpdate struct {
+	Phone          string
+	MarketingEmail bool
+	MarketingSMS   bool
+	Addresses      []entity.CustomerAddress
+}
+
+// DataExport is everything the shop keeps about a customer that the customer
+// is entitled to see. Internal notes and risk events are left out.
+type DataExport struct {
+	User       *entity.User
+	Customer   *entity.Customer
+	Orders     []*entity.Order
+	ExportedAt time.Time
+}
+
+// GetCustomer returns the customer data of the account, empty when none was saved yet
+func (uc *UseCase) GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	if _, err := uc.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+	return uc.customer(ctx, userID)
+}
+
+func (uc *UseCase) customer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	customer, err := uc.customerRepo.Get(ctx, userID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return &entity.Customer{UserID: userID, Addresses: []entity.CustomerAddress{}}, nil
+	}
+	return customer, err
+}
+
+func (uc *UseCase) UpdateCustomer(ctx context.Context, userID uuid.UUID, update CustomerUpdate) (*entity.Customer, error) {
+	existing, err := uc.GetCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer := &entity.Customer{
+		UserID:         userID,
+		Phone:          update.Phone,
+		MarketingEmail: update.MarketingEmail,
+		MarketingSMS:   update.MarketingSMS,
+		CustomerGroup:  existing.Group(),
+		Addresses:      update.Addresses,
+		CreatedAt:      existing.CreatedAt,
+	}
+	customer.KeepPhoneVerification(existing)
+	if err := customer.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.customerRepo.Save(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	// The audit log outlives a deleted account, so it records the consents
+	// and no personal data
+	uc.services.GetAuditService().LogChange(ctx, &userID, "UPDATE", "Customer", userID, consents(existing), consents(customer))
+
+	return customer, nil
+}
+
+// SetCustomerGroup moves a customer to the group whose price list they buy
+// at, e.g. wholesale. Only admins do this, customers cannot pick their group.
+func (uc *UseCase) SetCustomerGroup(ctx context.Context, userID uuid.UUID, group string) (*entity.Customer, error) {
+	customer, err := uc.GetCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := entity.ValidateCustomerGroup(group); err != nil {
+		return nil, err
+	}
+
+	previous := customer.Group()
+	customer.CustomerGroup = group
+	if err := uc.customerRepo.Save(ctx, customer); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "SET_CUSTOMER_GROUP", "Customer", userID,
+		map[string]interface{}{"customer_group": previous}, map[string]interface{}{"customer_group": group})
+
+	return customer, nil
+}
+
+func consents(customer *entity.Customer) map[string]interface{} {
+	return map[string]interface{}{
+		"marketing_email": customer.MarketingEmail,
+		"marketing_sms":   customer.MarketingSMS,
+	}
+}
+
+// ExportData gathers the personal data of the account for the customer to download
+func (uc *UseCase) ExportData(ctx context.Context, userID uuid.UUID) (*DataExport, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	customer, err := uc.customer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := uc.orderRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "EXPORT_DATA", "User", userID, nil,
+		map[string]interface{}{"orders": len(orders)})
+
+	return &DataExport{
+		User:       user,
+		Customer:   customer,
+		Orders:     orders,
+		ExportedAt: time.Now(),
+	}, nil
+}
+
+// DeleteAccount erases a customer account after the customer confirmed with
+// their password. The tokens of the account are revoked first. Its invoices
+// are rendered again billed to a deleted customer, an email entry of the
+// blocklist is hashed, so it still blocks the address, and the payloads of
+// the audit logs about the account or naming its email are cleared. Then its
+// orders are anonymized, kept for the books but no longer linked to anyone,
+// and its reviews, customer data, notes, risk events and login are removed,
+// taking the reviews out of the ratings of the products. Staff
+// accounts are removed by an admin instead.
+//
+// It returns how many orders were anonymized. A failure partway leaves the
+// account signed out but in place, and deleting again picks up from there.
+func (uc *UseCase) DeleteAccount(ctx context.Context, userID uuid.UUID, password string) (int, error) {
+	user, err := uc.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if user.Role != entity.RoleCustomer {
+		return 0, entity.ValidationError("Only customer accounts can be deleted, ask an admin to remove staff accounts")
+	}
+	if !user.CheckPassword(password) {
+		return 0, entity.ForbiddenError("Incorrect password")
+	}
+
+	if err := uc.revoker.RevokeUserTokens(ctx, userID, entity.RevocationDeleted, userID); err != nil {
+		return 0, err
+	}
+
+	// The orders lead to the invoices until they are anonymized
+	orders, err := uc.orderRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	orderIDs := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		orderIDs[i] = order.ID
+	}
+	_, err = uc.invoices.RebillInvoices(ctx, orderIDs, func(name, email string) (string, string) {
+		return deletedCustomer, ""
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := uc.blocklistRepo.HashEmail(ctx, user.Email); err != nil {
+		return 0, err
+	}
+	if _, err := uc.auditRepo.ClearPayloads(ctx, userID, user.Email); err != nil {
+		return 0, err
+	}
+
+	anonymized, err := uc.orderRepo.AnonymizeUser(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	if err := uc.reviewRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err := uc.customerRepo.Delete(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err := uc.profileRepo.DeleteByUser(ctx, userID); err != nil {
+		return 0, err
+	}
+	if err := uc.userRepo.Delete(ctx, userID); err != nil {
+		return 0, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "DELETE_ACCOUNT", "User", userID, nil,
+		map[string]interface{}{"orders_anonymized": anonymized})
+
+	return anonymized, nil
+}
@@ -0,0 +1,296 @@
+package customer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	infraRepo "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	invoiceUseCase "github.com/marcofilho/go-ecommerce/src/usecase/invoice"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+)
+
+type mockRevoker struct {
+	revoked map[uuid.UUID]entity.RevocationReason
+}
+
+func (m *mockRevoker) RevokeUserTokens(ctx context.Context, userID uuid.UUID, reason entity.RevocationReason, revokedBy uuid.UUID) error {
+	m.revoked[userID] = reason
+	return nil
+}
+
+// textRenderer writes invoices as plain text, the PDFs are compressed
+type textRenderer struct{}
+
+func (textRenderer) Render(doc *invoice.Document) ([]byte, error) {
+	return []byte(fmt.Sprintf("%s %s <%s>", doc.Number, doc.CustomerName, doc.CustomerEmail)), nil
+}
+
+type fixture struct {
+	uc        *UseCase
+	store     *memory.Store
+	orders    repository.OrderRepository
+	archive   repository.OrderArchiveRepository
+	invoices  *invoiceUseCase.UseCase
+	blocklist repository.BlocklistRepository
+	audit     repository.AuditLogRepository
+	revoker   *mockRevoker
+	texts     *mockServices.MockTextMessenger
+}
+
+func newFixture() *fixture {
+	store := memory.NewStore()
+	archive := memory.NewOrderArchiveRepository(store)
+	orders := infraRepo.NewReadThroughOrderRepository(memory.NewOrderRepository(store), archive)
+	revoker := &mockRevoker{revoked: make(map[uuid.UUID]entity.RevocationReason)}
+	texts := &mockServices.MockTextMessenger{}
+	users := memory.NewUserRepository(store)
+	invoices := invoiceUseCase.NewUseCase(memory.NewInvoiceRepository(store), orders, memory.NewProductRepository(store), users, textRenderer{})
+	blocklist := memory.NewBlocklistRepository(store)
+	audit := memory.NewAuditLogRepository(store)
+	uc := NewUseCase(users, memory.NewCustomerProfileRepository(store),
+		memory.NewCustomerRepository(store), orders, memory.NewAnalyticsRepository(store),
+		memory.NewProductReviewRepository(store), blocklist, audit, invoices, revoker, &mockServices.MockServices{TextMessenger: texts})
+	return &fixture{uc: uc, store: store, orders: orders, archive: archive, invoices: invoices,
+		blocklist: blocklist, audit: audit, revoker: revoker, texts: texts}
+}
+
+func (f *fixture) user(t *testing.T, email string, role entity.Role) *entity.User {
+	t.Helper()
+	user := &entity.User{Email: email, Name: "Jane Doe", Role: role}
+	require.NoError(t, user.SetPassword("password"))
+	require.NoError(t, memory.NewUserRepository(f.store).Create(context.Background(), user))
+	return user
+}
+
+func (f *fixture) order(t *testing.T, userID uuid.UUID, createdAt time.Time) *entity.Order {
+	t.Helper()
+	order := &entity.Order{
+		CustomerID: 1,
+		UserID:     &userID,
+		Status:     entity.Completed,
+		TotalPrice: 10,
+		CreatedAt:  createdAt,
+		Products:   []entity.OrderItem{{ProductID: uuid.New(), Quantity: 1, Price: 10, TotalPrice: 10}},
+	}
+	require.NoError(t, f.orders.Create(context.Background(), order))
+	return order
+}
+
+var home = entity.CustomerAddress{Recipient: "Jane Doe", Line1: "221B Baker Street", City: "London", PostalCode: "NW1 6XE", Country: "GB"}
+
+func TestUpdateCustomer(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Starts empty and replaces the addresses on update", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+
+		empty, err := f.uc.GetCustomer(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, empty.Addresses)
+
+		work := home
+		work.Label, work.IsDefault = "Work", true
+		_, err = f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958", Addresses: []entity.CustomerAddress{home, work}})
+		require.NoError(t, err)
+
+		updated, err := f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958", MarketingEmail: true, Addresses: []entity.CustomerAddress{work}})
+		require.NoError(t, err)
+
+		saved, err := f.uc.GetCustomer(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, saved.MarketingEmail)
+		require.Len(t, saved.Addresses, 1)
+		assert.Equal(t, "Work", saved.Addresses[0].Label)
+		assert.Equal(t, updated.CreatedAt, saved.CreatedAt)
+	})
+
+	t.Run("Rejects invalid data", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		first, second := home, home
+		first.IsDefault, second.IsDefault = true, true
+
+		for name, update := range map[string]CustomerUpdate{
+			"phone":    {Phone: "call me"},
+			"country":  {Addresses: []entity.CustomerAddress{{Recipient: "Jane", Line1: "1 Main St", City: "Springfield", PostalCode: "12345", Country: "USA"}}},
+			"defaults": {Addresses: []entity.CustomerAddress{first, second}},
+		} {
+			_, err := f.uc.UpdateCustomer(ctx, user.ID, update)
+			assert.ErrorIs(t, err, entity.ErrValidation, name)
+		}
+	})
+}
+
+func TestSetCustomerGroup(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	user := f.user(t, "jane@example.com", entity.RoleCustomer)
+
+	customer, err := f.uc.GetCustomer(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.RetailGroup, customer.Group())
+
+	_, err = f.uc.SetCustomerGroup(ctx, user.ID, "wholesale")
+	require.NoError(t, err)
+
+	_, err = f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958"})
+	require.NoError(t, err)
+	saved, err := f.uc.GetCustomer(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "wholesale", saved.Group(), "customers cannot change their own group")
+
+	_, err = f.uc.SetCustomerGroup(ctx, user.ID, "Wholesale!")
+	assert.ErrorIs(t, err, entity.ErrValidation)
+	_, err = f.uc.SetCustomerGroup(ctx, uuid.New(), "wholesale")
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestExportData(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture()
+	user := f.user(t, "jane@example.com", entity.RoleCustomer)
+	other := f.user(t, "john@example.com", entity.RoleCustomer)
+	archived := f.order(t, user.ID, time.Now().AddDate(-2, 0, 0))
+	recent := f.order(t, user.ID, time.Now())
+	f.order(t, other.ID, time.Now())
+	_, err := f.archive.ArchiveBatch(ctx, time.Now().AddDate(-1, 0, 0), 10)
+	require.NoError(t, err)
+
+	export, err := f.uc.ExportData(ctx, user.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, user.Email, export.User.Email)
+	assert.Equal(t, user.ID, export.Customer.UserID)
+	require.Len(t, export.Orders, 2)
+	assert.Equal(t, recent.ID, export.Orders[0].ID)
+	assert.Equal(t, archived.ID, export.Orders[1].ID, "archived orders are exported too")
+}
+
+func TestDeleteAccount(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Anonymizes the orders and removes the personal data", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		other := f.user(t, "john@example.com", entity.RoleCustomer)
+		archived := f.order(t, user.ID, time.Now().AddDate(-2, 0, 0))
+		recent := f.order(t, user.ID, time.Now())
+		kept := f.order(t, other.ID, time.Now())
+		_, err := f.archive.ArchiveBatch(ctx, time.Now().AddDate(-1, 0, 0), 10)
+		require.NoError(t, err)
+		_, err = f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Addresses: []entity.CustomerAddress{home}})
+		require.NoError(t, err)
+		_, err = f.uc.AddNote(ctx, user.ID, other.ID, "Asked about a refund")
+		require.NoError(t, err)
+
+		anonymized, err := f.uc.DeleteAccount(ctx, user.ID, "password")
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, anonymized)
+		assert.Equal(t, entity.RevocationDeleted, f.revoker.revoked[user.ID])
+
+		_, err = memory.NewUserRepository(f.store).GetByID(ctx, user.ID)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		_, err = memory.NewCustomerRepository(f.store).Get(ctx, user.ID)
+		assert.ErrorIs(t, err, entity.ErrNotFound)
+		notes, err := memory.NewCustomerProfileRepository(f.store).ListNotes(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Empty(t, notes)
+
+		for _, id := range []uuid.UUID{archived.ID, recent.ID} {
+			order, err := f.orders.GetByID(ctx, id)
+			require.NoError(t, err, "orders are kept")
+			assert.Nil(t, order.UserID)
+		}
+		order, err := f.orders.GetByID(ctx, kept.ID)
+		require.NoError(t, err)
+		assert.True(t, order.IsOwnedBy(other.ID), "other accounts are untouched")
+	})
+
+	t.Run("Bills the invoices to a deleted customer", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		order := f.order(t, user.ID, time.Now())
+		issued, err := f.invoices.GetInvoice(ctx, order.ID, user.ID, false)
+		require.NoError(t, err)
+		require.Contains(t, string(issued.PDF), "jane@example.com")
+
+		_, err = f.uc.DeleteAccount(ctx, user.ID, "password")
+		require.NoError(t, err)
+
+		stored, err := memory.NewInvoiceRepository(f.store).GetByOrderID(ctx, order.ID)
+		require.NoError(t, err)
+		assert.NotContains(t, string(stored.PDF), "jane@example.com")
+		assert.NotContains(t, string(stored.PDF), "Jane Doe")
+		assert.Contains(t, string(stored.PDF), issued.Number+" "+deletedCustomer)
+	})
+
+	t.Run("Hashes the blocked email and clears the audit payloads", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		other := f.user(t, "john@example.com", entity.RoleCustomer)
+		require.NoError(t, f.blocklist.Create(ctx, &entity.BlocklistEntry{Kind: entity.BlockEmail, Value: "jane@example.com"}))
+		about := &entity.AuditLog{Action: "update", ResourceType: "user", ResourceID: user.ID, PayloadAfter: datatypes.JSON(`{"name":"Jane Doe"}`)}
+		naming := &entity.AuditLog{Action: "create", ResourceType: "order", ResourceID: uuid.New(), PayloadAfter: datatypes.JSON(`{"email":"jane@example.com"}`)}
+		kept := &entity.AuditLog{Action: "update", ResourceType: "user", ResourceID: other.ID, PayloadAfter: datatypes.JSON(`{"email":"john@example.com"}`)}
+		for _, log := range []*entity.AuditLog{about, naming, kept} {
+			require.NoError(t, f.audit.Create(ctx, log))
+		}
+
+		_, err := f.uc.DeleteAccount(ctx, user.ID, "password")
+		require.NoError(t, err)
+
+		entries, err := f.blocklist.List(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, entity.HashedEmail("jane@example.com"), entries[0].Value)
+		assert.NotNil(t, entity.NewBlocklist(entries).MatchEmail("Jane@Example.com"), "the address stays blocked")
+
+		for _, log := range []*entity.AuditLog{about, naming} {
+			logs, err := f.audit.GetByResourceID(ctx, log.ResourceType, log.ResourceID)
+			require.NoError(t, err)
+			require.Len(t, logs, 1)
+			assert.Nil(t, logs[0].PayloadAfter)
+		}
+		logs, err := f.audit.GetByResourceID(ctx, "user", other.ID)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
+		assert.JSONEq(t, `{"email":"john@example.com"}`, string(logs[0].PayloadAfter))
+	})
+
+	t.Run("Requires the password", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		order := f.order(t, user.ID, time.Now())
+
+		_, err := f.uc.DeleteAccount(ctx, user.ID, "wrong")
+
+		assert.ErrorIs(t, err, entity.ErrForbidden)
+		assert.Empty(t, f.revoker.revoked)
+		stored, err := f.orders.GetByID(ctx, order.ID)
+		require.NoError(t, err)
+		assert.True(t, stored.IsOwnedBy(user.ID))
+	})
+
+	t.Run("Refuses staff accounts", func(t *testing.T) {
+		f := newFixture()
+		admin := f.user(t, "admin@example.com", entity.RoleAdmin)
+
+		_, err := f.uc.DeleteAccount(ctx, admin.ID, "password")
+
+		assert.ErrorIs(t, err, entity.ErrValidation)
+		_, err = memory.NewUserRepository(f.store).GetByID(ctx, admin.ID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package customer
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// SendPhoneVerification texts a code to the customer's phone, to be entered
+// with VerifyPhone. Only a verified phone is sent text messages.
+func (uc *UseCase) SendPhoneVerification(ctx context.Context, userID uuid.UUID) error {
+	customer, err := uc.GetCustomer(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	code, err := entity.NewPhoneCode()
+	if err != nil {
+		return err
+	}
+	if err := customer.StartPhoneVerification(code, time.Now()); err != nil {
+		return err
+	}
+
+	// Sent before saving, so a failed text doesn't hold the next code back
+	if err := uc.services.GetTextMessenger().SendPhoneCode(ctx, customer.Phone, code); err != nil {
+		return err
+	}
+
+	return uc.customerRepo.Save(ctx, customer)
+}
+
+// VerifyPhone marks the customer's phone verified when the code is the one
+// sent. Wrong codes are counted, too many of them void the code.
+func (uc *UseCase) VerifyPhone(ctx context.Context, userID uuid.UUID, code string) (*entity.Customer, error) {
+	customer, err := uc.GetCustomer(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	attempts := customer.PhoneCodeAttempts
+	verifyErr := customer.VerifyPhone(code, time.Now())
+	if verifyErr != nil && customer.PhoneCodeAttempts == attempts {
+		return nil, verifyErr
+	}
+
+	if err := uc.customerRepo.Save(ctx, customer); err != nil {
+		return nil, err
+	}
+	if verifyErr != nil {
+		return nil, verifyErr
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "VERIFY_PHONE", "Customer", userID, nil,
+		map[string]interface{}{"phone_verified": true})
+
+	return customer, nil
+}
@@ -0,0 +1,69 @@
+package customer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPhoneVerification(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Verifies the phone with the code texted to it", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		_, err := f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958"})
+		require.NoError(t, err)
+
+		require.NoError(t, f.uc.SendPhoneVerification(ctx, user.ID))
+		require.Len(t, f.texts.Codes, 1)
+
+		customer, err := f.uc.VerifyPhone(ctx, user.ID, f.texts.Codes[0])
+		require.NoError(t, err)
+		assert.True(t, customer.PhoneVerified())
+
+		_, err = f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958", MarketingSMS: true})
+		require.NoError(t, err)
+		saved, err := f.uc.GetCustomer(ctx, user.ID)
+		require.NoError(t, err)
+		assert.True(t, saved.PhoneVerified(), "saving the same phone keeps it verified")
+
+		_, err = f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0000"})
+		require.NoError(t, err)
+		saved, err = f.uc.GetCustomer(ctx, user.ID)
+		require.NoError(t, err)
+		assert.False(t, saved.PhoneVerified(), "a new phone has to be verified again")
+	})
+
+	t.Run("Counts wrong codes", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+		_, err := f.uc.UpdateCustomer(ctx, user.ID, CustomerUpdate{Phone: "+44 20 7946 0958"})
+		require.NoError(t, err)
+		require.NoError(t, f.uc.SendPhoneVerification(ctx, user.ID))
+
+		wrong := "000000"
+		if f.texts.Codes[0] == wrong {
+			wrong = "111111"
+		}
+		for i := 0; i < entity.MaxPhoneCodeAttempts; i++ {
+			_, err = f.uc.VerifyPhone(ctx, user.ID, wrong)
+			assert.ErrorIs(t, err, entity.ErrValidation)
+		}
+
+		_, err = f.uc.VerifyPhone(ctx, user.ID, f.texts.Codes[0])
+		assert.ErrorIs(t, err, entity.ErrConflict, "too many wrong codes void the code")
+	})
+
+	t.Run("Needs a phone", func(t *testing.T) {
+		f := newFixture()
+		user := f.user(t, "jane@example.com", entity.RoleCustomer)
+
+		err := f.uc.SendPhoneVerification(ctx, user.ID)
+		assert.ErrorIs(t, err, entity.ErrValidation)
+		assert.Empty(t, f.texts.Codes)
+	})
+}
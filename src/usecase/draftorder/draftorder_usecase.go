@@ -0,0 +1,453 @@
+package draftorder
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+	"github.com/marcofilho/go-ecommerce/src/usecase/order"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+)
+
+var (
+	ErrDraftOrderNotFound = entity.NotFoundError("Draft order not found")
+	ErrProductNotFound    = entity.NotFoundError("Product not found")
+	ErrVariantNotFound    = entity.NotFoundError("Variant not found")
+	ErrVariantMismatch    = entity.ValidationError("Variant does not belong to the product")
+	ErrCustomerNotFound   = entity.NotFoundError("Customer account not found")
+)
+
+// LinkTemplateKey is the email template payment links are sent with. Until an
+// admin creates it, links are sent with a plain default text.
+const LinkTemplateKey = "draft_order.payment_link"
+
+// Item is a quantity of a product, or of one of its variants, on a draft
+type Item struct {
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Quantity  int
+}
+
+// Input is what an admin fills in for a draft order
+type Input struct {
+	CustomerID int
+	UserID     *uuid.UUID
+	Note       string
+	Items      []Item
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface DraftOrderService -out ../../internal/testing/servicemocks
+
+type DraftOrderService interface {
+	// Admins
+	CreateDraft(ctx context.Context, actorID uuid.UUID, input Input) (*entity.DraftOrder, error)
+	// UpdateDraft replaces the customer, note and items of an open or sent draft
+	UpdateDraft(ctx context.Context, actorID, id uuid.UUID, input Input) (*entity.DraftOrder, error)
+	GetDraft(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error)
+	ListDrafts(ctx context.Context, filters repository.DraftOrderFilters, page, pageSize int) ([]*entity.DraftOrder, int, error)
+	// SendLink emails the customer a payment link to accept the draft, valid
+	// for the configured number of days. Sending it again extends it.
+	SendLink(ctx context.Context, actorID, id uuid.UUID) (*entity.DraftOrder, error)
+	// ConvertDraft places the draft as an order through checkout, e.g. once the
+	// customer agreed over the phone
+	ConvertDraft(ctx context.Context, actorID, id uuid.UUID) (*entity.Order, error)
+	CancelDraft(ctx context.Context, actorID, id uuid.UUID) (*entity.DraftOrder, error)
+
+	// Customers, through the payment link
+	GetByLink(ctx context.Context, token string) (*entity.DraftOrder, error)
+	// AcceptLink places the draft as an order through checkout, to be paid like any order
+	AcceptLink(ctx context.Context, token, clientIP string) (*entity.Order, error)
+
+	// Quote prices the draft as checkout would today. It returns nil for a
+	// draft that was placed or cancelled, or whose items can't be ordered as
+	// they stand, e.g. out of stock.
+	Quote(ctx context.Context, draft *entity.DraftOrder) (*pricing.Totals, error)
+	// PaymentLink is the storefront URL of the draft's payment link
+	PaymentLink(draft *entity.DraftOrder) string
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface OrderPlacer -out ../../internal/testing/servicemocks
+
+// OrderPlacer places orders through checkout, see order.UseCase
+type OrderPlacer interface {
+	CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []order.CreateOrderItem, redeemPoints int) (*entity.Order, error)
+	QuoteOrder(ctx context.Context, userID *uuid.UUID, items []order.CreateOrderItem) (pricing.Totals, error)
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface Renderer -out ../../internal/testing/servicemocks -name DraftOrderRenderer
+
+// Renderer fills an email template, see emailtemplate.UseCase
+type Renderer interface {
+	Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	draftRepo   repository.DraftOrderRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.ProductVariantRepository
+	userRepo    repository.UserRepository
+	orders      OrderPlacer
+	templates   Renderer
+	notifier    notification.Notifier
+	services    Services
+	linkURL     string
+	linkTTL     time.Duration
+	now         func() time.Time
+}
+
+func NewUseCase(
+	draftRepo repository.DraftOrderRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.ProductVariantRepository,
+	userRepo repository.UserRepository,
+	orders OrderPlacer,
+	templates Renderer,
+	notifier notification.Notifier,
+	services Services,
+	linkURL string,
+	linkTTL time.Duration,
+) *UseCase {
+	return &UseCase{
+		draftRepo:   draftRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		userRepo:    userRepo,
+		orders:      orders,
+		templates:   templates,
+		notifier:    notifier,
+		services:    services,
+		linkURL:     strings.TrimSuffix(linkURL, "/"),
+		linkTTL:     linkTTL,
+		now:         time.Now,
+	}
+}
+
+func (uc *UseCase) CreateDraft(ctx context.Context, actorID uuid.UUID, input Input) (*entity.DraftOrder, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	draft := &entity.DraftOrder{
+		ID:        entity.NewID(),
+		Status:    entity.DraftOrderOpen,
+		Token:     token,
+		CreatedBy: &actorID,
+	}
+	if err := uc.fill(ctx, draft, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.draftRepo.Create(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "CREATE_DRAFT_ORDER", "DraftOrder", draft.ID, nil, redact(draft))
+	return draft, nil
+}
+
+func (uc *UseCase) UpdateDraft(ctx context.Context, actorID, id uuid.UUID, input Input) (*entity.DraftOrder, error) {
+	draft, err := uc.GetDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := draft.CanChange(); err != nil {
+		return nil, err
+	}
+
+	before := redact(draft)
+	if err := uc.fill(ctx, draft, input); err != nil {
+		return nil, err
+	}
+	if err := uc.draftRepo.Update(ctx, draft); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "UPDATE_DRAFT_ORDER", "DraftOrder", draft.ID, before, redact(draft))
+	return draft, nil
+}
+
+// fill puts the input on the draft once its account, products and variants
+// are known to exist. Prices and stock are left to checkout.
+func (uc *UseCase) fill(ctx context.Context, draft *entity.DraftOrder, input Input) error {
+	draft.CustomerID = input.CustomerID
+	draft.UserID = input.UserID
+	draft.Note = strings.TrimSpace(input.Note)
+	draft.Items = make([]entity.DraftOrderItem, len(input.Items))
+	for i, item := range input.Items {
+		draft.Items[i] = entity.DraftOrderItem{ProductID: item.ProductID, VariantID: item.VariantID, Quantity: item.Quantity}
+	}
+	if err := draft.Validate(); err != nil {
+		return err
+	}
+
+	if draft.UserID != nil {
+		if _, err := uc.userRepo.GetByID(ctx, *draft.UserID); err != nil {
+			return ErrCustomerNotFound
+		}
+	}
+	for _, item := range draft.Items {
+		if item.VariantID != nil {
+			variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
+			if err != nil {
+				return ErrVariantNotFound
+			}
+			if variant.ProductID != item.ProductID {
+				return ErrVariantMismatch
+			}
+			continue
+		}
+		if _, err := uc.productRepo.GetByID(ctx, item.ProductID); err != nil {
+			return ErrProductNotFound
+		}
+	}
+	return nil
+}
+
+func (uc *UseCase) GetDraft(ctx context.Context, id uuid.UUID) (*entity.DraftOrder, error) {
+	draft, err := uc.draftRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, ErrDraftOrderNotFound
+		}
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (uc *UseCase) ListDrafts(ctx context.Context, filters repository.DraftOrderFilters, page, pageSize int) ([]*entity.DraftOrder, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return uc.draftRepo.List(ctx, filters, page, pageSize)
+}
+
+func (uc *UseCase) SendLink(ctx context.Context, actorID, id uuid.UUID) (*entity.DraftOrder, error) {
+	draft, err := uc.GetDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	if err := draft.MarkSent(now, now.Add(uc.linkTTL)); err != nil {
+		return nil, err
+	}
+	user, err := uc.userRepo.GetByID(ctx, *draft.UserID)
+	if err != nil {
+		return nil, ErrCustomerNotFound
+	}
+
+	// Saved first, so the link works by the time the customer opens it
+	if err := uc.draftRepo.Update(ctx, draft); err != nil {
+		return nil, err
+	}
+	if err := uc.sendLink(ctx, draft, user); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "SEND_DRAFT_ORDER", "DraftOrder", draft.ID, nil,
+		map[string]interface{}{"recipient": user.Email, "link_expires_at": draft.LinkExpiresAt})
+	return draft, nil
+}
+
+func (uc *UseCase) sendLink(ctx context.Context, draft *entity.DraftOrder, user *entity.User) error {
+	items := make([]string, 0, len(draft.Items))
+	for _, item := range draft.Items {
+		name := item.ProductID.String()
+		if product, err := uc.productRepo.GetByID(ctx, item.ProductID); err == nil {
+			name = product.Name
+		}
+		items = append(items, fmt.Sprintf("%d x %s", item.Quantity, name))
+	}
+
+	data := map[string]interface{}{
+		"customer_name":   user.Name,
+		"payment_link":    uc.PaymentLink(draft),
+		"link_expires_at": draft.LinkExpiresAt.Format("2006-01-02 15:04 MST"),
+		"note":            draft.Note,
+		"items":           items,
+		"draft_order_id":  draft.ID.String(),
+	}
+
+	email, err := uc.templates.Render(ctx, LinkTemplateKey, data)
+	if errors.Is(err, emailtemplate.ErrTemplateNotFound) {
+		email = defaultLinkEmail(data, items)
+	} else if err != nil {
+		return err
+	}
+
+	return uc.notifier.Notify(ctx, notification.Notification{
+		Recipients: []string{user.Email},
+		Subject:    email.Subject,
+		Body:       email.Body,
+	})
+}
+
+// defaultLinkEmail is sent while no draft_order.payment_link template exists
+func defaultLinkEmail(data map[string]interface{}, items []string) *entity.RenderedEmail {
+	text := func(key string) string {
+		return html.EscapeString(fmt.Sprint(data[key]))
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>Hi %s,</p><p>We prepared an order for you:</p><ul>", text("customer_name"))
+	for _, item := range items {
+		fmt.Fprintf(&body, "<li>%s</li>", html.EscapeString(item))
+	}
+	body.WriteString("</ul>")
+	if data["note"] != "" {
+		fmt.Fprintf(&body, "<p>%s</p>", text("note"))
+	}
+	fmt.Fprintf(&body, `<p><a href="%s">Review and pay your order</a> before %s.</p>`, text("payment_link"), text("link_expires_at"))
+
+	return &entity.RenderedEmail{
+		Subject: "Your order is ready to be paid",
+		Body:    body.String(),
+	}
+}
+
+func (uc *UseCase) ConvertDraft(ctx context.Context, actorID, id uuid.UUID) (*entity.Order, error) {
+	draft, err := uc.GetDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Placed on the customer's behalf, so the admin's address isn't screened
+	return uc.convert(ctx, draft, &actorID, "")
+}
+
+func (uc *UseCase) CancelDraft(ctx context.Context, actorID, id uuid.UUID) (*entity.DraftOrder, error) {
+	draft, err := uc.GetDraft(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	from := draft.Status
+	if err := draft.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := uc.draftRepo.UpdateStatus(ctx, draft, from); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "CANCEL_DRAFT_ORDER", "DraftOrder", draft.ID,
+		map[string]interface{}{"status": from}, map[string]interface{}{"status": draft.Status})
+	return draft, nil
+}
+
+func (uc *UseCase) GetByLink(ctx context.Context, token string) (*entity.DraftOrder, error) {
+	draft, err := uc.draftRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entity.ErrNotFound) {
+			return nil, ErrDraftOrderNotFound
+		}
+		return nil, err
+	}
+	if err := draft.CheckLink(uc.now()); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+func (uc *UseCase) AcceptLink(ctx context.Context, token, clientIP string) (*entity.Order, error) {
+	draft, err := uc.GetByLink(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return uc.convert(ctx, draft, nil, clientIP)
+}
+
+// convert claims the draft and places it through checkout, so fraud screening,
+// the blocklist, purchase limits, prices and stock apply as they do to any
+// order. If checkout turns it down the draft is handed back as it was.
+func (uc *UseCase) convert(ctx context.Context, draft *entity.DraftOrder, actorID *uuid.UUID, clientIP string) (*entity.Order, error) {
+	if err := draft.CanChange(); err != nil {
+		return nil, err
+	}
+	from := draft.Status
+	draft.Status = entity.DraftOrderConverted
+	if err := uc.draftRepo.UpdateStatus(ctx, draft, from); err != nil {
+		return nil, err
+	}
+
+	placed, err := uc.orders.CreateOrder(ctx, draft.CustomerID, draft.UserID, clientIP, orderItems(draft), 0)
+	if err != nil {
+		draft.Status = from
+		if err := uc.draftRepo.UpdateStatus(ctx, draft, entity.DraftOrderConverted); err != nil {
+			fmt.Printf("Failed to reopen draft order %s: %v\n", draft.ID, err)
+		}
+		return nil, err
+	}
+
+	now := uc.now()
+	draft.OrderID, draft.ConvertedAt = &placed.ID, &now
+	if err := uc.draftRepo.UpdateStatus(ctx, draft, entity.DraftOrderConverted); err != nil {
+		fmt.Printf("Failed to link draft order %s to order %s: %v\n", draft.ID, placed.ID, err)
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, actorID, "CONVERT_DRAFT_ORDER", "DraftOrder", draft.ID,
+		map[string]interface{}{"status": from}, map[string]interface{}{"status": draft.Status, "order_id": placed.ID})
+	return placed, nil
+}
+
+func (uc *UseCase) Quote(ctx context.Context, draft *entity.DraftOrder) (*pricing.Totals, error) {
+	if draft.CanChange() != nil {
+		return nil, nil
+	}
+
+	totals, err := uc.orders.QuoteOrder(ctx, draft.UserID, orderItems(draft))
+	if err != nil {
+		// Accepting the draft tells the customer what stands in the way
+		if errors.Is(err, entity.ErrNotFound) || errors.Is(err, entity.ErrConflict) || errors.Is(err, entity.ErrInsufficientStock) ||
+			errors.Is(err, entity.ErrValidation) || errors.Is(err, entity.ErrForbidden) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &totals, nil
+}
+
+// orderItems lists the items of the draft as checkout takes them
+func orderItems(draft *entity.DraftOrder) []order.CreateOrderItem {
+	items := make([]order.CreateOrderItem, len(draft.Items))
+	for i, item := range draft.Items {
+		items[i] = order.CreateOrderItem{ProductID: item.ProductID, VariantID: item.VariantID, Quantity: item.Quantity}
+	}
+	return items
+}
+
+func (uc *UseCase) PaymentLink(draft *entity.DraftOrder) string {
+	return uc.linkURL + "/" + draft.Token
+}
+
+// redact copies a draft without the secret of its payment link, for the audit log
+func redact(draft *entity.DraftOrder) entity.DraftOrder {
+	row := *draft
+	row.Token = ""
+	return row
+}
+
+func generateToken() (string, error) {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
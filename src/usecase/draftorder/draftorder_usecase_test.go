@@ -0,0 +1,249 @@
+package draftorder
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+	"github.com/marcofilho/go-ecommerce/src/usecase/order"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+)
+
+type mockPlacer struct {
+	err    error
+	placed []order.CreateOrderItem
+	ip     string
+}
+
+func (m *mockPlacer) CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []order.CreateOrderItem, redeemPoints int) (*entity.Order, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.placed, m.ip = items, clientIP
+	return &entity.Order{ID: uuid.New(), CustomerID: customerID, UserID: userID, Status: entity.Pending}, nil
+}
+
+// QuoteOrder prices every unit at 120
+func (m *mockPlacer) QuoteOrder(ctx context.Context, userID *uuid.UUID, items []order.CreateOrderItem) (pricing.Totals, error) {
+	if m.err != nil {
+		return pricing.Totals{}, m.err
+	}
+	units := 0
+	for _, item := range items {
+		units += item.Quantity
+	}
+	return pricing.NewCalculator().Calculate(pricing.Input{Lines: []pricing.Line{{UnitPrice: 120, Quantity: units}}}), nil
+}
+
+type noTemplates struct{}
+
+func (noTemplates) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	return nil, emailtemplate.ErrTemplateNotFound
+}
+
+type mockNotifier struct {
+	sent []notification.Notification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+type fixture struct {
+	uc       *UseCase
+	placer   *mockPlacer
+	notifier *mockNotifier
+	user     *entity.User
+	product  *entity.Product
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	store := memory.NewStore()
+	users := memory.NewUserRepository(store)
+	products := memory.NewProductRepository(store)
+
+	f := &fixture{
+		placer:   &mockPlacer{},
+		notifier: &mockNotifier{},
+		user:     &entity.User{Email: "ana@example.com", Name: "Ana", PasswordHash: "x", Role: entity.RoleCustomer},
+		product:  &entity.Product{Name: "Office chair", Price: 120, Quantity: 5, Status: entity.ProductActive},
+	}
+	if err := users.Create(context.Background(), f.user); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := products.Create(context.Background(), f.product); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	f.uc = NewUseCase(memory.NewDraftOrderRepository(store), products, memory.NewProductVariantRepository(store), users,
+		f.placer, noTemplates{}, f.notifier, &mockServices.MockServices{}, "https://shop.example.com/pay/", 7*24*time.Hour)
+	return f
+}
+
+func (f *fixture) input() Input {
+	return Input{CustomerID: 7, UserID: &f.user.ID, Note: "As agreed on the phone", Items: []Item{{ProductID: f.product.ID, Quantity: 2}}}
+}
+
+func TestCreateDraft(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	draft, err := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if draft.Status != entity.DraftOrderOpen || len(draft.Token) != 64 || draft.CreatedBy == nil {
+		t.Errorf("expected an open draft with a link token and its author, got %+v", draft)
+	}
+	if link := f.uc.PaymentLink(draft); link != "https://shop.example.com/pay/"+draft.Token {
+		t.Errorf("expected the link under the storefront page, got %s", link)
+	}
+
+	input := f.input()
+	input.Items[0].ProductID = uuid.New()
+	if _, err := f.uc.CreateDraft(ctx, uuid.New(), input); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected an unknown product not found, got %v", err)
+	}
+	input = f.input()
+	missing := uuid.New()
+	input.UserID = &missing
+	if _, err := f.uc.CreateDraft(ctx, uuid.New(), input); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected an unknown account not found, got %v", err)
+	}
+}
+
+func TestSendAndAcceptLink(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	draft, _ := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+
+	if _, err := f.uc.GetByLink(ctx, draft.Token); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected the link of an unsent draft not found, got %v", err)
+	}
+
+	sent, err := f.uc.SendLink(ctx, uuid.New(), draft.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if sent.Status != entity.DraftOrderSent || sent.LinkExpiresAt == nil {
+		t.Errorf("expected a sent draft with an expiry, got %+v", sent)
+	}
+	if len(f.notifier.sent) != 1 || f.notifier.sent[0].Recipients[0] != "ana@example.com" {
+		t.Fatalf("expected the link emailed to the customer, got %+v", f.notifier.sent)
+	}
+	body := f.notifier.sent[0].Body
+	if !strings.Contains(body, f.uc.PaymentLink(draft)) || !strings.Contains(body, "2 x Office chair") {
+		t.Errorf("expected the default email with the link and items, got %s", body)
+	}
+
+	placed, err := f.uc.AcceptLink(ctx, draft.Token, "198.51.100.4")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(f.placer.placed) != 1 || f.placer.placed[0].Quantity != 2 || f.placer.ip != "198.51.100.4" {
+		t.Errorf("expected the items placed through checkout from the customer's address, got %+v", f.placer)
+	}
+
+	converted, _ := f.uc.GetDraft(ctx, draft.ID)
+	if converted.Status != entity.DraftOrderConverted || converted.OrderID == nil || *converted.OrderID != placed.ID {
+		t.Errorf("expected the draft linked to its order, got %+v", converted)
+	}
+	if _, err := f.uc.AcceptLink(ctx, draft.Token, ""); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a second acceptance to conflict, got %v", err)
+	}
+	if _, err := f.uc.UpdateDraft(ctx, uuid.New(), draft.ID, f.input()); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a converted draft closed to edits, got %v", err)
+	}
+}
+
+func TestAcceptLink_Expired(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	draft, _ := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+	f.uc.SendLink(ctx, uuid.New(), draft.ID)
+
+	f.uc.now = func() time.Time { return time.Now().Add(8 * 24 * time.Hour) }
+	if _, err := f.uc.AcceptLink(ctx, draft.Token, ""); !errors.Is(err, entity.ErrDraftLinkExpired) {
+		t.Errorf("expected the link expired, got %v", err)
+	}
+	if _, err := f.uc.ConvertDraft(ctx, uuid.New(), draft.ID); err != nil {
+		t.Errorf("expected an admin to still convert the draft, got %v", err)
+	}
+}
+
+func TestConvertDraft_CheckoutFailureReopensDraft(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	draft, _ := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+
+	f.placer.err = entity.InsufficientStockError("Insufficient stock for product: Office chair")
+	if _, err := f.uc.ConvertDraft(ctx, uuid.New(), draft.ID); err == nil {
+		t.Fatal("expected checkout's error")
+	}
+	reopened, _ := f.uc.GetDraft(ctx, draft.ID)
+	if reopened.Status != entity.DraftOrderOpen || reopened.OrderID != nil {
+		t.Errorf("expected the draft open again, got %+v", reopened)
+	}
+
+	f.placer.err = nil
+	if _, err := f.uc.ConvertDraft(ctx, uuid.New(), draft.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if f.placer.ip != "" {
+		t.Errorf("expected an admin conversion not to screen the admin's address, got %s", f.placer.ip)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	draft, _ := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+
+	totals, err := f.uc.Quote(ctx, draft)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if totals == nil || totals.Total != 240 {
+		t.Errorf("expected the draft quoted through checkout at 240, got %+v", totals)
+	}
+
+	// Items checkout would turn down leave the draft unpriced
+	f.placer.err = entity.InsufficientStockError("Insufficient stock for product: Office chair")
+	if totals, err := f.uc.Quote(ctx, draft); err != nil || totals != nil {
+		t.Errorf("expected no totals and no error, got %+v, %v", totals, err)
+	}
+	f.placer.err = errors.New("connection refused")
+	if _, err := f.uc.Quote(ctx, draft); err == nil {
+		t.Error("expected an unexpected failure returned")
+	}
+
+	f.placer.err = nil
+	cancelled, _ := f.uc.CancelDraft(ctx, uuid.New(), draft.ID)
+	if totals, err := f.uc.Quote(ctx, cancelled); err != nil || totals != nil {
+		t.Errorf("expected a cancelled draft not priced, got %+v, %v", totals, err)
+	}
+}
+
+func TestCancelDraft(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	draft, _ := f.uc.CreateDraft(ctx, uuid.New(), f.input())
+
+	cancelled, err := f.uc.CancelDraft(ctx, uuid.New(), draft.ID)
+	if err != nil || cancelled.Status != entity.DraftOrderCancelled {
+		t.Fatalf("expected the draft cancelled, got %+v, %v", cancelled, err)
+	}
+	if _, err := f.uc.ConvertDraft(ctx, uuid.New(), draft.ID); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a cancelled draft not converted, got %v", err)
+	}
+}
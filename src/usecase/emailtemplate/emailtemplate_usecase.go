@@ -0,0 +1,136 @@
+package emailtemplate
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+)
+
+var ErrTemplateNotFound = entity.NotFoundError("Email template not found")
+
+//go:generate go run ../../internal/tools/mockgen -interface EmailTemplateService -out ../../internal/testing/servicemocks
+
+type EmailTemplateService interface {
+	// SaveTemplate stores a new version of the template under key
+	SaveTemplate(ctx context.Context, template *entity.EmailTemplate) (*entity.EmailTemplate, error)
+	// GetTemplate returns a version of the template, the latest when version is 0
+	GetTemplate(ctx context.Context, key string, version int) (*entity.EmailTemplate, error)
+	ListTemplates(ctx context.Context) ([]*entity.EmailTemplate, error)
+	ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error)
+	// Preview renders a version of the template with data, or with its sample data when data is nil
+	Preview(ctx context.Context, key string, version int, data map[string]interface{}) (*entity.RenderedEmail, error)
+	// TestSend renders the template like Preview and sends it to recipient only
+	TestSend(ctx context.Context, key string, version int, recipient string, data map[string]interface{}) (*entity.RenderedEmail, error)
+	// Render fills the latest version of the template with data for a real send
+	Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	repo     repository.EmailTemplateRepository
+	notifier notification.Notifier
+	services Services
+}
+
+func NewUseCase(repo repository.EmailTemplateRepository, notifier notification.Notifier, services Services) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		notifier: notifier,
+		services: services,
+	}
+}
+
+func (uc *UseCase) SaveTemplate(ctx context.Context, template *entity.EmailTemplate) (*entity.EmailTemplate, error) {
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	template.ID = entity.NewID()
+	template.CreatedAt = time.Now()
+	if err := uc.repo.CreateVersion(ctx, template); err != nil {
+		return nil, err
+	}
+
+	// Earlier versions are kept, so the new one is all there is to record
+	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE_VERSION", "EmailTemplate", template.ID, nil, template)
+
+	return template, nil
+}
+
+func (uc *UseCase) GetTemplate(ctx context.Context, key string, version int) (*entity.EmailTemplate, error) {
+	var template *entity.EmailTemplate
+	var err error
+	if version > 0 {
+		template, err = uc.repo.GetVersion(ctx, key, version)
+	} else {
+		template, err = uc.repo.GetLatest(ctx, key)
+	}
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+	return template, nil
+}
+
+func (uc *UseCase) ListTemplates(ctx context.Context) ([]*entity.EmailTemplate, error) {
+	return uc.repo.ListLatest(ctx)
+}
+
+func (uc *UseCase) ListVersions(ctx context.Context, key string) ([]*entity.EmailTemplate, error) {
+	versions, err := uc.repo.ListVersions(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+	return versions, nil
+}
+
+func (uc *UseCase) Preview(ctx context.Context, key string, version int, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	template, err := uc.GetTemplate(ctx, key, version)
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		data, err = template.Sample()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template.Render(data)
+}
+
+func (uc *UseCase) TestSend(ctx context.Context, key string, version int, recipient string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	email, err := uc.Preview(ctx, key, version, data)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.notifier.Notify(ctx, notification.Notification{
+		Recipients: []string{recipient},
+		Subject:    "[Test] " + email.Subject,
+		Body:       email.Body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return email, nil
+}
+
+func (uc *UseCase) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	template, err := uc.GetTemplate(ctx, key, 0)
+	if err != nil {
+		return nil, err
+	}
+	return template.Render(data)
+}
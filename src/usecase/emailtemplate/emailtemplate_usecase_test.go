@@ -0,0 +1,113 @@
+package emailtemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+)
+
+type mockNotifier struct {
+	sent []notification.Notification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+func newWelcomeTemplate(subject string) *entity.EmailTemplate {
+	return &entity.EmailTemplate{
+		Key:        "welcome",
+		Subject:    subject,
+		Body:       "<p>Hi {{.name}}</p>",
+		SampleData: `{"name":"Jane"}`,
+	}
+}
+
+func TestSaveTemplate_CreatesVersions(t *testing.T) {
+	uc := NewUseCase(memory.NewEmailTemplateRepository(memory.NewStore()), &mockNotifier{}, &mockServices.MockServices{})
+
+	first, err := uc.SaveTemplate(context.Background(), newWelcomeTemplate("Welcome"))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	second, _ := uc.SaveTemplate(context.Background(), newWelcomeTemplate("Welcome aboard"))
+
+	if first.Version != 1 || second.Version != 2 {
+		t.Errorf("expected versions 1 and 2, got %d and %d", first.Version, second.Version)
+	}
+
+	latest, _ := uc.GetTemplate(context.Background(), "welcome", 0)
+	if latest.Subject != "Welcome aboard" {
+		t.Errorf("expected the latest version, got %q", latest.Subject)
+	}
+	previous, _ := uc.GetTemplate(context.Background(), "welcome", 1)
+	if previous.Subject != "Welcome" {
+		t.Errorf("expected version 1 to be kept, got %q", previous.Subject)
+	}
+}
+
+func TestSaveTemplate_InvalidTemplate(t *testing.T) {
+	repo := memory.NewEmailTemplateRepository(memory.NewStore())
+	uc := NewUseCase(repo, &mockNotifier{}, &mockServices.MockServices{})
+
+	template := newWelcomeTemplate("Welcome {{.name")
+	if _, err := uc.SaveTemplate(context.Background(), template); !errors.Is(err, entity.ErrInvalidTemplate) {
+		t.Errorf("expected ErrInvalidTemplate, got %v", err)
+	}
+	if templates, _ := repo.ListLatest(context.Background()); len(templates) != 0 {
+		t.Error("expected nothing to be stored")
+	}
+}
+
+func TestPreview_UsesSampleData(t *testing.T) {
+	uc := NewUseCase(memory.NewEmailTemplateRepository(memory.NewStore()), &mockNotifier{}, &mockServices.MockServices{})
+	uc.SaveTemplate(context.Background(), newWelcomeTemplate("Welcome {{.name}}"))
+
+	email, err := uc.Preview(context.Background(), "welcome", 0, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if email.Subject != "Welcome Jane" {
+		t.Errorf("expected sample data to be rendered, got %q", email.Subject)
+	}
+
+	email, _ = uc.Preview(context.Background(), "welcome", 0, map[string]interface{}{"name": "John"})
+	if email.Subject != "Welcome John" {
+		t.Errorf("expected given data to be rendered, got %q", email.Subject)
+	}
+}
+
+func TestPreview_UnknownTemplate(t *testing.T) {
+	uc := NewUseCase(memory.NewEmailTemplateRepository(memory.NewStore()), &mockNotifier{}, &mockServices.MockServices{})
+
+	if _, err := uc.Preview(context.Background(), "welcome", 0, nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("expected ErrTemplateNotFound, got %v", err)
+	}
+}
+
+func TestTestSend_SendsToRecipientOnly(t *testing.T) {
+	notifier := &mockNotifier{}
+	uc := NewUseCase(memory.NewEmailTemplateRepository(memory.NewStore()), notifier, &mockServices.MockServices{})
+	uc.SaveTemplate(context.Background(), newWelcomeTemplate("Welcome {{.name}}"))
+
+	if _, err := uc.TestSend(context.Background(), "welcome", 0, "marketing@example.com", nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.sent))
+	}
+	sent := notifier.sent[0]
+	if len(sent.Recipients) != 1 || sent.Recipients[0] != "marketing@example.com" {
+		t.Errorf("unexpected recipients %v", sent.Recipients)
+	}
+	if sent.Subject != "[Test] Welcome Jane" {
+		t.Errorf("expected the subject to be marked as a test, got %q", sent.Subject)
+	}
+}
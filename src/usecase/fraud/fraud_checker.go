@@ -0,0 +1,85 @@
+package fraud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// ErrOrderRejected is returned when an order fails fraud screening
+var ErrOrderRejected = entity.ForbiddenError("Order rejected by fraud screening")
+
+// Decision is what screening makes of an order it doesn't reject
+type Decision struct {
+	Review  bool     // Hold the order until an admin approves it
+	Reasons []string // Why the order is held, for the admin reviewing it
+}
+
+// Accept lets the order through
+var Accept = Decision{}
+
+// HoldForReview holds the order for reason
+func HoldForReview(reason string) Decision {
+	return Decision{Review: true, Reasons: []string{reason}}
+}
+
+// Reason returns the reasons of the decision as one line
+func (d Decision) Reason() string {
+	return strings.Join(d.Reasons, "; ")
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface Checker -out ../../internal/testing/servicemocks -name FraudChecker
+
+// Checker screens orders before they are placed. An error rejects the order,
+// a decision to review places it on hold.
+type Checker interface {
+	Screen(ctx context.Context, order *entity.Order) (Decision, error)
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface RiskScorer -out ../../internal/testing/servicemocks
+
+// RiskScorer provides the risk score of a customer account
+type RiskScorer interface {
+	RiskScore(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+type riskChecker struct {
+	scorer    RiskScorer
+	threshold int
+}
+
+// NewRiskChecker rejects orders placed by accounts whose risk score reaches threshold
+func NewRiskChecker(scorer RiskScorer, threshold int) Checker {
+	return &riskChecker{scorer: scorer, threshold: threshold}
+}
+
+func (c *riskChecker) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	if order.UserID == nil {
+		return Accept, nil
+	}
+
+	score, err := c.scorer.RiskScore(ctx, *order.UserID)
+	if err != nil {
+		// Fail open: an unavailable score must not block legitimate customers
+		return Accept, nil
+	}
+
+	if score >= c.threshold {
+		return Accept, ErrOrderRejected
+	}
+
+	return Accept, nil
+}
+
+type allowAll struct{}
+
+// NewAllowAllChecker returns a checker that accepts every order
+func NewAllowAllChecker() Checker {
+	return &allowAll{}
+}
+
+func (allowAll) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	return Accept, nil
+}
@@ -0,0 +1,45 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+type stubScorer struct {
+	score int
+	err   error
+}
+
+func (s *stubScorer) RiskScore(ctx context.Context, userID uuid.UUID) (int, error) {
+	return s.score, s.err
+}
+
+func TestRiskChecker_Screen(t *testing.T) {
+	userID := uuid.New()
+
+	tests := []struct {
+		name    string
+		scorer  *stubScorer
+		userID  *uuid.UUID
+		blocked bool
+	}{
+		{"below threshold", &stubScorer{score: 79}, &userID, false},
+		{"at threshold", &stubScorer{score: 80}, &userID, true},
+		{"anonymous order", &stubScorer{score: 100}, nil, false},
+		{"scorer unavailable", &stubScorer{err: errors.New("db down")}, &userID, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewRiskChecker(tt.scorer, 80)
+			_, err := checker.Screen(context.Background(), &entity.Order{CustomerID: 1, UserID: tt.userID})
+			if blocked := errors.Is(err, ErrOrderRejected); blocked != tt.blocked {
+				t.Errorf("Screen() blocked = %v, want %v", blocked, tt.blocked)
+			}
+		})
+	}
+}
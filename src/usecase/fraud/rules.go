@@ -0,0 +1,151 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+type rulesChecker struct {
+	rules []Checker
+}
+
+// NewRulesChecker screens orders with every rule in turn. The first rule to
+// reject an order rejects it; otherwise it is held for review when any rule
+// asks to, with the reasons of all of them.
+func NewRulesChecker(rules ...Checker) Checker {
+	return &rulesChecker{rules: rules}
+}
+
+func (c *rulesChecker) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	decision := Accept
+	for _, rule := range c.rules {
+		outcome, err := rule.Screen(ctx, order)
+		if err != nil {
+			return Accept, err
+		}
+		if outcome.Review {
+			decision.Review = true
+			decision.Reasons = append(decision.Reasons, outcome.Reasons...)
+		}
+	}
+	return decision, nil
+}
+
+type blocklistChecker struct {
+	customerIDs map[int]bool
+	userIDs     map[uuid.UUID]bool
+}
+
+// NewBlocklistChecker rejects the orders of the blocked customers, each entry
+// being a customer ID or the ID of an account. Entries that are neither are
+// ignored.
+func NewBlocklistChecker(entries []string) Checker {
+	c := &blocklistChecker{customerIDs: make(map[int]bool), userIDs: make(map[uuid.UUID]bool)}
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if customerID, err := strconv.Atoi(entry); err == nil {
+			c.customerIDs[customerID] = true
+		} else if userID, err := uuid.Parse(entry); err == nil {
+			c.userIDs[userID] = true
+		} else if entry != "" {
+			fmt.Printf("Ignoring fraud blocklist entry %q: not a customer or account ID\n", entry)
+		}
+	}
+	return c
+}
+
+func (c *blocklistChecker) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	if c.customerIDs[order.CustomerID] || (order.UserID != nil && c.userIDs[*order.UserID]) {
+		return Accept, ErrOrderRejected
+	}
+	return Accept, nil
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface OrderCounter -out ../../internal/testing/servicemocks
+
+// OrderCounter counts the orders an account placed recently
+type OrderCounter interface {
+	CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error)
+}
+
+type velocityChecker struct {
+	orders    OrderCounter
+	maxOrders int
+	window    time.Duration
+}
+
+// NewVelocityChecker holds for review the orders of accounts that already
+// placed maxOrders orders within window, as card testing does. Orders are
+// counted by account since the customer ID is whatever the client sends, so
+// guest orders are left to the other rules. A maxOrders of 0 turns the rule off.
+func NewVelocityChecker(orders OrderCounter, maxOrders int, window time.Duration) Checker {
+	return &velocityChecker{orders: orders, maxOrders: maxOrders, window: window}
+}
+
+func (c *velocityChecker) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	if c.maxOrders <= 0 || order.UserID == nil {
+		return Accept, nil
+	}
+
+	placed, err := c.orders.CountByUserSince(ctx, *order.UserID, time.Now().Add(-c.window))
+	if err != nil {
+		// Fail open like the risk score
+		return Accept, nil
+	}
+
+	if placed >= c.maxOrders {
+		return HoldForReview(fmt.Sprintf("%d orders placed in the last %s", placed, c.window)), nil
+	}
+	return Accept, nil
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface AddressBook -out ../../internal/testing/servicemocks
+
+// AddressBook looks up the customer record of an account, with its addresses
+type AddressBook interface {
+	GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error)
+}
+
+type addressChecker struct {
+	addresses AddressBook
+}
+
+// NewAddressChecker holds for review the orders of accounts whose saved
+// addresses are in different countries, as when a stolen card is billed at
+// home and shipped abroad
+func NewAddressChecker(addresses AddressBook) Checker {
+	return &addressChecker{addresses: addresses}
+}
+
+func (c *addressChecker) Screen(ctx context.Context, order *entity.Order) (Decision, error) {
+	if order.UserID == nil {
+		return Accept, nil
+	}
+
+	customer, err := c.addresses.GetCustomer(ctx, *order.UserID)
+	if err != nil {
+		return Accept, nil
+	}
+
+	seen := make(map[string]bool)
+	var countries []string
+	for _, address := range customer.Addresses {
+		if !seen[address.Country] {
+			seen[address.Country] = true
+			countries = append(countries, address.Country)
+		}
+	}
+
+	if len(countries) > 1 {
+		sort.Strings(countries)
+		return HoldForReview("Addresses in different countries: " + strings.Join(countries, ", ")), nil
+	}
+	return Accept, nil
+}
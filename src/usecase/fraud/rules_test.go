@@ -0,0 +1,140 @@
+package fraud
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+type stubOrderCounter struct {
+	count   int
+	err     error
+	counted []uuid.UUID
+}
+
+func (s *stubOrderCounter) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	s.counted = append(s.counted, userID)
+	return s.count, s.err
+}
+
+type stubAddressBook struct {
+	countries []string
+}
+
+func (s *stubAddressBook) GetCustomer(ctx context.Context, userID uuid.UUID) (*entity.Customer, error) {
+	customer := &entity.Customer{UserID: userID}
+	for _, country := range s.countries {
+		customer.Addresses = append(customer.Addresses, entity.CustomerAddress{Country: country})
+	}
+	return customer, nil
+}
+
+func TestBlocklistChecker_Screen(t *testing.T) {
+	blockedUser := uuid.New()
+	checker := NewBlocklistChecker([]string{"42", blockedUser.String(), "not-an-id"})
+
+	if _, err := checker.Screen(context.Background(), &entity.Order{CustomerID: 42}); !errors.Is(err, ErrOrderRejected) {
+		t.Errorf("expected a blocked customer to be rejected, got %v", err)
+	}
+	if _, err := checker.Screen(context.Background(), &entity.Order{CustomerID: 7, UserID: &blockedUser}); !errors.Is(err, ErrOrderRejected) {
+		t.Errorf("expected a blocked account to be rejected, got %v", err)
+	}
+	if _, err := checker.Screen(context.Background(), &entity.Order{CustomerID: 7}); err != nil {
+		t.Errorf("expected other customers to be accepted, got %v", err)
+	}
+}
+
+func TestVelocityChecker_Screen(t *testing.T) {
+	tests := []struct {
+		name      string
+		counter   *stubOrderCounter
+		maxOrders int
+		review    bool
+	}{
+		{"below the limit", &stubOrderCounter{count: 4}, 5, false},
+		{"at the limit", &stubOrderCounter{count: 5}, 5, true},
+		{"rule turned off", &stubOrderCounter{count: 50}, 0, false},
+		{"counter unavailable", &stubOrderCounter{err: errors.New("db down")}, 5, false},
+	}
+
+	userID := uuid.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := NewVelocityChecker(tt.counter, tt.maxOrders, time.Hour).Screen(context.Background(), &entity.Order{CustomerID: 1, UserID: &userID})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if decision.Review != tt.review {
+				t.Errorf("Screen() review = %v, want %v", decision.Review, tt.review)
+			}
+		})
+	}
+}
+
+func TestVelocityChecker_CountsByAccount(t *testing.T) {
+	userID := uuid.New()
+	counter := &stubOrderCounter{count: 5}
+	checker := NewVelocityChecker(counter, 5, time.Hour)
+
+	// A new customer ID on every order doesn't reset the count of the account
+	for _, customerID := range []int{1, 2} {
+		decision, _ := checker.Screen(context.Background(), &entity.Order{CustomerID: customerID, UserID: &userID})
+		if !decision.Review {
+			t.Errorf("expected the order under customer %d to be held for review", customerID)
+		}
+	}
+	if len(counter.counted) != 2 || counter.counted[0] != userID || counter.counted[1] != userID {
+		t.Errorf("expected the orders of the account to be counted, got %v", counter.counted)
+	}
+
+	if decision, _ := checker.Screen(context.Background(), &entity.Order{CustomerID: 1}); decision.Review {
+		t.Error("expected guest orders to be left to the other rules")
+	}
+	if len(counter.counted) != 2 {
+		t.Error("expected guest orders not to be counted")
+	}
+}
+
+func TestAddressChecker_Screen(t *testing.T) {
+	userID := uuid.New()
+
+	decision, _ := NewAddressChecker(&stubAddressBook{countries: []string{"US", "BR", "US"}}).Screen(context.Background(), &entity.Order{CustomerID: 1, UserID: &userID})
+	if !decision.Review || decision.Reason() != "Addresses in different countries: BR, US" {
+		t.Errorf("expected addresses in two countries to be reviewed, got %+v", decision)
+	}
+
+	decision, _ = NewAddressChecker(&stubAddressBook{countries: []string{"US", "US"}}).Screen(context.Background(), &entity.Order{CustomerID: 1, UserID: &userID})
+	if decision.Review {
+		t.Errorf("expected addresses in one country to be accepted, got %+v", decision)
+	}
+}
+
+func TestRulesChecker_Screen(t *testing.T) {
+	userID := uuid.New()
+	order := &entity.Order{CustomerID: 1, UserID: &userID}
+
+	checker := NewRulesChecker(
+		NewVelocityChecker(&stubOrderCounter{count: 10}, 5, time.Hour),
+		NewAddressChecker(&stubAddressBook{countries: []string{"US", "BR"}}),
+	)
+	decision, err := checker.Screen(context.Background(), order)
+	if err != nil || !decision.Review || len(decision.Reasons) != 2 {
+		t.Fatalf("expected both reasons to hold the order, got %+v, %v", decision, err)
+	}
+	if !strings.Contains(decision.Reason(), "10 orders placed in the last 1h0m0s") {
+		t.Errorf("unexpected reason %q", decision.Reason())
+	}
+
+	checker = NewRulesChecker(
+		NewVelocityChecker(&stubOrderCounter{count: 10}, 5, time.Hour),
+		NewBlocklistChecker([]string{"1"}),
+	)
+	if _, err := checker.Screen(context.Background(), order); !errors.Is(err, ErrOrderRejected) {
+		t.Errorf("expected a rejection to win over a review, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package inventory
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface InventoryService -out ../../internal/testing/servicemocks
+
+type InventoryService interface {
+	// SyncStock sets the stock of variants by SKU, as an ERP sends in bulk
+	SyncStock(ctx context.Context, sync *entity.InventorySync) (*entity.InventorySyncReport, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetWebhookDispatcher() events.Dispatcher
+}
+
+type UseCase struct {
+	repo     repository.InventoryRepository
+	services Services
+}
+
+func NewUseCase(repo repository.InventoryRepository, services Services) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		services: services,
+	}
+}
+
+func (uc *UseCase) SyncStock(ctx context.Context, sync *entity.InventorySync) (*entity.InventorySyncReport, error) {
+	if sync.Reference == "" {
+		sync.Reference = "sync " + uuid.New().String()
+	}
+	if err := sync.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, movements, err := uc.repo.SyncStock(ctx, sync)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.InventorySyncReport{Mode: sync.Mode, Reference: sync.Reference, Results: results}
+	for _, result := range results {
+		if result.Failed() && sync.Mode == entity.SyncAllOrNothing {
+			report.Rejected = true
+		}
+		if result.Status == entity.StockLevelUpdated {
+			uc.services.GetAuditService().LogChange(ctx, nil, "SYNC_STOCK", "ProductVariant", *result.VariantID,
+				map[string]int{"quantity": *result.QuantityBefore}, map[string]interface{}{"quantity": *result.QuantityAfter, "reference": sync.Reference})
+		}
+	}
+	for _, movement := range movements {
+		uc.services.GetWebhookDispatcher().Dispatch(ctx, events.StockChanged, events.NewStockData(movement))
+	}
+
+	return report, nil
+}
@@ -0,0 +1,85 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+)
+
+func setup(t *testing.T) (*UseCase, repository.ProductVariantRepository, *mockServices.MockWebhookDispatcher) {
+	t.Helper()
+	store := memory.NewStore()
+	product := &entity.Product{ID: uuid.New(), Name: "T-Shirt", Price: 20}
+	if err := memory.NewProductRepository(store).Create(context.Background(), product); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	variants := memory.NewProductVariantRepository(store)
+	for _, sku := range []string{"TS-S", "TS-M"} {
+		variant := &entity.ProductVariant{ID: uuid.New(), ProductID: product.ID, SKU: sku, CombinationKey: sku, Quantity: 5}
+		if err := variants.Create(context.Background(), variant); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	dispatcher := &mockServices.MockWebhookDispatcher{}
+	uc := NewUseCase(memory.NewInventoryRepository(store), &mockServices.MockServices{WebhookDispatcher: dispatcher})
+	return uc, variants, dispatcher
+}
+
+func TestSyncStock_BestEffortAppliesWhatItCan(t *testing.T) {
+	uc, variants, dispatcher := setup(t)
+
+	report, err := uc.SyncStock(context.Background(), &entity.InventorySync{
+		Mode:   entity.SyncBestEffort,
+		Levels: []entity.StockLevel{{SKU: "TS-S", Quantity: 12}, {SKU: "TS-XL", Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Rejected || report.Count(entity.StockLevelUpdated) != 1 || report.Count(entity.StockLevelNotFound) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+	if report.Reference == "" {
+		t.Error("expected a generated reference")
+	}
+
+	variant, _ := variants.GetBySKU(context.Background(), "TS-S")
+	if variant.Quantity != 12 {
+		t.Errorf("expected 12 units, got %d", variant.Quantity)
+	}
+	if len(dispatcher.Events) != 1 || dispatcher.Events[0] != entity.EventStockChanged {
+		t.Errorf("expected a stock.changed event, got %v", dispatcher.Events)
+	}
+}
+
+func TestSyncStock_AllOrNothingRejectsOnConflict(t *testing.T) {
+	uc, variants, dispatcher := setup(t)
+	stale := 9
+
+	report, err := uc.SyncStock(context.Background(), &entity.InventorySync{
+		Mode:      entity.SyncAllOrNothing,
+		Reference: "erp-2026-10-17",
+		Levels:    []entity.StockLevel{{SKU: "TS-S", Quantity: 12}, {SKU: "TS-M", Quantity: 1, ExpectedQuantity: &stale}},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !report.Rejected || report.Count(entity.StockLevelSkipped) != 1 || report.Count(entity.StockLevelConflict) != 1 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	variant, _ := variants.GetBySKU(context.Background(), "TS-S")
+	if variant.Quantity != 5 || len(dispatcher.Events) != 0 {
+		t.Errorf("expected nothing written, got %d units and events %v", variant.Quantity, dispatcher.Events)
+	}
+
+	if _, err := uc.SyncStock(context.Background(), &entity.InventorySync{Mode: "partial"}); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+}
@@ -0,0 +1,196 @@
+package invoice
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	invoiceRenderer "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+)
+
+// ErrOrderNotFound is returned when the order does not exist or is not visible to the requester
+var ErrOrderNotFound = entity.NotFoundError("Order not found")
+
+//go:generate go run ../../internal/tools/mockgen -interface InvoiceService -out ../../internal/testing/servicemocks
+
+type InvoiceService interface {
+	// GetInvoice returns the invoice of an order, issuing it on first access.
+	// Non-admin requesters can only access invoices of their own orders.
+	GetInvoice(ctx context.Context, orderID, requesterID uuid.UUID, isAdmin bool) (*entity.Invoice, error)
+}
+
+type UseCase struct {
+	invoiceRepo repository.InvoiceRepository
+	orderRepo   repository.OrderRepository
+	productRepo repository.ProductRepository
+	userRepo    repository.UserRepository
+	renderer    invoiceRenderer.Renderer
+	pricing     pricing.Calculator
+}
+
+func NewUseCase(
+	invoiceRepo repository.InvoiceRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	userRepo repository.UserRepository,
+	renderer invoiceRenderer.Renderer,
+) *UseCase {
+	return &UseCase{
+		invoiceRepo: invoiceRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		userRepo:    userRepo,
+		renderer:    renderer,
+		pricing:     pricing.NewCalculator(),
+	}
+}
+
+func (uc *UseCase) GetInvoice(ctx context.Context, orderID, requesterID uuid.UUID, isAdmin bool) (*entity.Invoice, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	if !isAdmin && !order.IsOwnedBy(requesterID) {
+		return nil, ErrOrderNotFound
+	}
+
+	if existing, err := uc.invoiceRepo.GetByOrderID(ctx, orderID); err == nil {
+		return existing, nil
+	}
+
+	invoice := &entity.Invoice{
+		ID:       entity.NewID(),
+		OrderID:  order.ID,
+		IssuedAt: time.Now(),
+	}
+
+	doc := uc.buildDocument(ctx, order, invoice.IssuedAt)
+
+	err = uc.invoiceRepo.CreateWithNextNumber(ctx, invoice, func(invoice *entity.Invoice) error {
+		doc.Number = invoice.Number
+		pdf, err := uc.renderer.Render(doc)
+		if err != nil {
+			return err
+		}
+		invoice.PDF = pdf
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return invoice, nil
+}
+
+// RenderInvoice renders an issued invoice again, keeping its number and
+// issue date, from the order and the customer account as they are now
+func (uc *UseCase) RenderInvoice(ctx context.Context, invoice *entity.Invoice) ([]byte, error) {
+	return uc.RenderInvoiceAs(ctx, invoice, nil)
+}
+
+// RenderInvoiceAs renders an issued invoice again like RenderInvoice, with
+// the name and email of the customer passed through party, so a copy of the
+// store can bill pseudonyms without the account being changed
+func (uc *UseCase) RenderInvoiceAs(ctx context.Context, invoice *entity.Invoice, party func(name, email string) (string, string)) ([]byte, error) {
+	order, err := uc.orderRepo.GetByID(ctx, invoice.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := uc.buildDocument(ctx, order, invoice.IssuedAt)
+	doc.Number = invoice.Number
+	if party != nil && (doc.CustomerName != "" || doc.CustomerEmail != "") {
+		doc.CustomerName, doc.CustomerEmail = party(doc.CustomerName, doc.CustomerEmail)
+	}
+	return uc.renderer.Render(doc)
+}
+
+// RebillInvoices renders the invoices issued for the orders again with the
+// customer passed through party, as RenderInvoiceAs does, and stores them. It
+// returns how many invoices were rendered, orders without one are skipped.
+func (uc *UseCase) RebillInvoices(ctx context.Context, orderIDs []uuid.UUID, party func(name, email string) (string, string)) (int, error) {
+	rebilled := 0
+	for _, orderID := range orderIDs {
+		invoice, err := uc.invoiceRepo.GetByOrderID(ctx, orderID)
+		if errors.Is(err, entity.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return rebilled, err
+		}
+
+		if invoice.PDF, err = uc.RenderInvoiceAs(ctx, invoice, party); err != nil {
+			return rebilled, err
+		}
+		if err := uc.invoiceRepo.UpdatePDF(ctx, invoice); err != nil {
+			return rebilled, err
+		}
+		rebilled++
+	}
+	return rebilled, nil
+}
+
+func (uc *UseCase) buildDocument(ctx context.Context, order *entity.Order, issuedAt time.Time) *invoiceRenderer.Document {
+	totals := uc.pricing.OrderTotals(order)
+
+	doc := &invoiceRenderer.Document{
+		IssuedAt:   issuedAt,
+		OrderID:    order.ID.String(),
+		CustomerID: order.CustomerID,
+		Subtotal:   totals.Subtotal,
+		Discount:   totals.Discount,
+		Tax:        totals.Tax,
+		Surcharges: totals.Surcharges,
+		Shipping:   totals.Shipping,
+		Credits:    totals.Credits,
+		Total:      totals.Total,
+	}
+
+	if order.UserID != nil {
+		if user, err := uc.userRepo.GetByID(ctx, *order.UserID); err == nil {
+			doc.CustomerName = user.Name
+			doc.CustomerEmail = user.Email
+		}
+	}
+
+	for i, item := range order.Products {
+		line := totals.Lines[i]
+		doc.Lines = append(doc.Lines, invoiceRenderer.Line{
+			Description: uc.describe(ctx, &item),
+			Quantity:    item.Quantity,
+			UnitPrice:   item.Price,
+			Discount:    line.Discount,
+			Tax:         line.Tax,
+			Total:       line.Total,
+		})
+	}
+
+	return doc
+}
+
+// describe names what an order line was for, from the snapshot taken when it
+// was ordered. Lines ordered before snapshots fall back to the catalog.
+func (uc *UseCase) describe(ctx context.Context, item *entity.OrderItem) string {
+	if item.ProductName != "" {
+		return item.Description()
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		return item.ProductID.String()
+	}
+	description := product.Name
+	if item.VariantID != nil {
+		for _, variant := range product.Variants {
+			if variant.ID == *item.VariantID {
+				description += " (" + variant.Describe() + ")"
+			}
+		}
+	}
+	return description
+}
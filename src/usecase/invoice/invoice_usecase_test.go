@@ -0,0 +1,183 @@
+package invoice
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	invoiceRenderer "github.com/marcofilho/go-ecommerce/src/internal/infrastructure/invoice"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestOrder(owner uuid.UUID) *entity.Order {
+	return &entity.Order{
+		ID:         uuid.New(),
+		CustomerID: 1,
+		UserID:     &owner,
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Quantity: 2, Price: 50, TotalPrice: 100},
+		},
+		TotalPrice: 100,
+	}
+}
+
+// newTestUseCase returns a use case whose repositories hold the orders, with
+// no invoice issued yet, a Laptop for every product and Jane Doe for every
+// customer. Invoices are numbered in sequence as they are issued, and found
+// once issued.
+func newTestUseCase(orders ...*entity.Order) (*UseCase, *mocks.InvoiceRepository) {
+	orderRepo := new(mocks.OrderRepository)
+	invoiceRepo := new(mocks.InvoiceRepository)
+	for _, o := range orders {
+		orderRepo.On("GetByID", mock.Anything, o.ID).Return(o, nil)
+		invoiceRepo.On("GetByOrderID", mock.Anything, o.ID).Return(nil, entity.NotFoundError("Invoice not found")).Once()
+	}
+	orderRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, entity.NotFoundError("Order not found"))
+
+	sequence := 0
+	invoiceRepo.On("CreateWithNextNumber", mock.Anything, mock.AnythingOfType("*entity.Invoice"), mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) {
+			invoice := args.Get(1).(*entity.Invoice)
+			sequence++
+			invoice.AssignNumber(invoice.IssuedAt.Year(), sequence)
+			if err := args.Get(2).(func(invoice *entity.Invoice) error)(invoice); err != nil {
+				panic(err)
+			}
+			invoiceRepo.On("GetByOrderID", mock.Anything, invoice.OrderID).Return(invoice, nil)
+		})
+
+	productRepo := new(mocks.ProductRepository)
+	productRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entity.Product{Name: "Laptop"}, nil)
+	userRepo := new(mocks.UserRepository)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(&entity.User{Name: "Jane Doe", Email: "jane@example.com"}, nil)
+
+	uc := NewUseCase(invoiceRepo, orderRepo, productRepo, userRepo, invoiceRenderer.NewPDFRenderer("Test Store"))
+	return uc, invoiceRepo
+}
+
+func TestGetInvoice_OwnerGetsNumberedPDF(t *testing.T) {
+	owner := uuid.New()
+	order := newTestOrder(owner)
+	uc, _ := newTestUseCase(order)
+
+	inv, err := uc.GetInvoice(context.Background(), order.ID, owner, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if inv.Sequence != 1 || inv.Number != entity.FormatInvoiceNumber(inv.Year, 1) {
+		t.Errorf("unexpected invoice number %s", inv.Number)
+	}
+	if !bytes.HasPrefix(inv.PDF, []byte("%PDF")) {
+		t.Error("expected a PDF document")
+	}
+}
+
+func TestGetInvoice_ReusesExistingInvoice(t *testing.T) {
+	owner := uuid.New()
+	order := newTestOrder(owner)
+	uc, _ := newTestUseCase(order)
+
+	first, _ := uc.GetInvoice(context.Background(), order.ID, owner, false)
+	second, err := uc.GetInvoice(context.Background(), order.ID, owner, false)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if first.Number != second.Number {
+		t.Errorf("expected the same invoice, got %s and %s", first.Number, second.Number)
+	}
+}
+
+func TestGetInvoice_SequentialNumbers(t *testing.T) {
+	owner := uuid.New()
+	orderA, orderB := newTestOrder(owner), newTestOrder(owner)
+	uc, _ := newTestUseCase(orderA, orderB)
+
+	a, _ := uc.GetInvoice(context.Background(), orderA.ID, owner, false)
+	b, _ := uc.GetInvoice(context.Background(), orderB.ID, owner, false)
+	if b.Sequence != a.Sequence+1 {
+		t.Errorf("expected sequential numbers, got %d and %d", a.Sequence, b.Sequence)
+	}
+}
+
+func TestGetInvoice_OtherCustomerIsDenied(t *testing.T) {
+	order := newTestOrder(uuid.New())
+	uc, invoiceRepo := newTestUseCase(order)
+
+	_, err := uc.GetInvoice(context.Background(), order.ID, uuid.New(), false)
+	if !errors.Is(err, ErrOrderNotFound) {
+		t.Errorf("expected ErrOrderNotFound, got %v", err)
+	}
+	invoiceRepo.AssertNotCalled(t, "CreateWithNextNumber", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetInvoice_AdminCanAccessAnyOrder(t *testing.T) {
+	order := newTestOrder(uuid.New())
+	uc, _ := newTestUseCase(order)
+
+	if _, err := uc.GetInvoice(context.Background(), order.ID, uuid.New(), true); err != nil {
+		t.Errorf("expected admin access, got %v", err)
+	}
+}
+
+func TestBuildDocument_DescribesLinesFromSnapshot(t *testing.T) {
+	order := newTestOrder(uuid.New())
+	order.Products = append(order.Products, entity.OrderItem{
+		ID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: 20, TotalPrice: 20,
+		ProductName: "T-Shirt", SKU: "TSHIRT-L", VariantDescription: "Size: L",
+	})
+	uc, _ := newTestUseCase(order)
+
+	doc := uc.buildDocument(context.Background(), order, time.Now())
+	// The line ordered before snapshots is named from the catalog
+	if doc.Lines[0].Description != "Laptop" {
+		t.Errorf("expected the catalog name, got %q", doc.Lines[0].Description)
+	}
+	// The snapshot wins over the product's current name
+	if doc.Lines[1].Description != "T-Shirt (Size: L)" {
+		t.Errorf("expected the snapshot, got %q", doc.Lines[1].Description)
+	}
+}
+
+func TestRenderInvoice_RendersIssuedInvoiceAgain(t *testing.T) {
+	owner := uuid.New()
+	order := newTestOrder(owner)
+	uc, _ := newTestUseCase(order)
+
+	issued, _ := uc.GetInvoice(context.Background(), order.ID, owner, false)
+	pdf, err := uc.RenderInvoice(context.Background(), issued)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !bytes.HasPrefix(pdf, []byte("%PDF")) {
+		t.Error("expected a PDF document")
+	}
+
+	if _, err := uc.RenderInvoice(context.Background(), &entity.Invoice{OrderID: uuid.New()}); err == nil {
+		t.Error("expected an error for a missing order")
+	}
+}
+
+func TestRenderInvoiceAs_BillsTheParty(t *testing.T) {
+	owner := uuid.New()
+	order := newTestOrder(owner)
+	uc, _ := newTestUseCase(order)
+	renderer := new(mocks.InvoiceRenderer)
+	renderer.On("Render", mock.Anything).Return([]byte("%PDF"), nil)
+	uc.renderer = renderer
+
+	_, err := uc.RenderInvoiceAs(context.Background(), &entity.Invoice{OrderID: order.ID, Number: "INV-2026-000001"},
+		func(name, email string) (string, string) { return "Alex Doe", "alex@example.net" })
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	doc := renderer.Calls[0].Arguments.Get(0).(*invoiceRenderer.Document)
+	if doc.CustomerName != "Alex Doe" || doc.CustomerEmail != "alex@example.net" || doc.Number != "INV-2026-000001" {
+		t.Errorf("expected the invoice to bill the party, got %s <%s> on %s", doc.CustomerName, doc.CustomerEmail, doc.Number)
+	}
+}
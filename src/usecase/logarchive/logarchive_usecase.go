@@ -0,0 +1,70 @@
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface LogArchiveService -out ../../internal/testing/servicemocks
+
+type LogArchiveService interface {
+	// ArchiveAuditLogs moves audit logs older than the given number of days
+	// into cold storage and returns how many logs were archived
+	ArchiveAuditLogs(ctx context.Context, olderThanDays int) (int, error)
+
+	// ArchiveWebhookLogs moves processed webhook logs older than the given
+	// number of days into cold storage and returns how many logs were archived
+	ArchiveWebhookLogs(ctx context.Context, olderThanDays int) (int, error)
+}
+
+type UseCase struct {
+	archiveRepo repository.LogArchiveRepository
+	batchSize   int
+	now         func() time.Time
+}
+
+func NewUseCase(archiveRepo repository.LogArchiveRepository, batchSize int) *UseCase {
+	return &UseCase{
+		archiveRepo: archiveRepo,
+		batchSize:   batchSize,
+		now:         time.Now,
+	}
+}
+
+func (uc *UseCase) ArchiveAuditLogs(ctx context.Context, olderThanDays int) (int, error) {
+	if olderThanDays < 1 {
+		return 0, errors.New("Audit logs must be at least 1 day old to be archived")
+	}
+	return uc.archive(ctx, uc.now().AddDate(0, 0, -olderThanDays), uc.archiveRepo.ArchiveAuditLogs)
+}
+
+func (uc *UseCase) ArchiveWebhookLogs(ctx context.Context, olderThanDays int) (int, error) {
+	if olderThanDays < 1 {
+		return 0, errors.New("Webhook logs must be at least 1 day old to be archived")
+	}
+	return uc.archive(ctx, uc.now().AddDate(0, 0, -olderThanDays), uc.archiveRepo.ArchiveWebhookLogs)
+}
+
+// archive moves logs in small batches so each transaction stays short and the
+// hot tables are not locked for long
+func (uc *UseCase) archive(ctx context.Context, cutoff time.Time, batch func(context.Context, time.Time, int) (int, error)) (int, error) {
+	total := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+
+		moved, err := batch(ctx, cutoff, uc.batchSize)
+		if err != nil {
+			return total, err
+		}
+
+		total += moved
+		if moved < uc.batchSize {
+			return total, nil
+		}
+	}
+}
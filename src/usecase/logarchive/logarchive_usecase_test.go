@@ -0,0 +1,93 @@
+package logarchive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestUseCase(repo *mocks.LogArchiveRepository) *UseCase {
+	uc := NewUseCase(repo, 10)
+	uc.now = func() time.Time { return time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC) }
+	return uc
+}
+
+// batches expects one call to method per moved count, in order
+func batches(repo *mocks.LogArchiveRepository, method string, cutoff interface{}, moved ...int) {
+	for _, n := range moved {
+		repo.On(method, mock.Anything, cutoff, 10).Return(n, nil).Once()
+	}
+}
+
+func TestArchiveAuditLogs_ProcessesAllBatches(t *testing.T) {
+	cutoff := time.Date(2024, 3, 17, 12, 0, 0, 0, time.UTC)
+	repo := new(mocks.LogArchiveRepository)
+	batches(repo, "ArchiveAuditLogs", cutoff, 10, 10, 5)
+	uc := newTestUseCase(repo)
+
+	archived, err := uc.ArchiveAuditLogs(context.Background(), 90)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if archived != 25 {
+		t.Errorf("expected 25 archived logs, got %d", archived)
+	}
+	repo.AssertNumberOfCalls(t, "ArchiveAuditLogs", 3)
+	repo.AssertNotCalled(t, "ArchiveWebhookLogs", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestArchiveWebhookLogs_ProcessesAllBatches(t *testing.T) {
+	repo := new(mocks.LogArchiveRepository)
+	batches(repo, "ArchiveWebhookLogs", mock.Anything, 10, 0)
+	uc := newTestUseCase(repo)
+
+	archived, err := uc.ArchiveWebhookLogs(context.Background(), 30)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if archived != 10 {
+		t.Errorf("expected 10 archived logs, got %d", archived)
+	}
+	if calls := len(repo.Calls); calls != 2 {
+		t.Errorf("expected a full batch to be followed by another one, got %d batches", calls)
+	}
+}
+
+func TestArchiveLogs_InvalidAge(t *testing.T) {
+	uc := newTestUseCase(new(mocks.LogArchiveRepository))
+
+	if _, err := uc.ArchiveAuditLogs(context.Background(), 0); err == nil {
+		t.Error("expected error for audit log age below 1 day")
+	}
+	if _, err := uc.ArchiveWebhookLogs(context.Background(), -1); err == nil {
+		t.Error("expected error for webhook log age below 1 day")
+	}
+}
+
+func TestArchiveLogs_StopsOnError(t *testing.T) {
+	repo := new(mocks.LogArchiveRepository)
+	repo.On("ArchiveAuditLogs", mock.Anything, mock.Anything, 10).Return(0, errors.New("db down"))
+	uc := newTestUseCase(repo)
+
+	if _, err := uc.ArchiveAuditLogs(context.Background(), 90); err == nil {
+		t.Error("expected repository error to be returned")
+	}
+}
+
+func TestArchiveLogs_StopsWhenCanceled(t *testing.T) {
+	repo := new(mocks.LogArchiveRepository)
+	uc := newTestUseCase(repo)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := uc.ArchiveAuditLogs(ctx, 90); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if calls := len(repo.Calls); calls != 0 {
+		t.Errorf("expected no batches, got %d", calls)
+	}
+}
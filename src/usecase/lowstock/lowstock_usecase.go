@@ -0,0 +1,78 @@
+package lowstock
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+)
+
+// maxListedItems caps how many items are spelled out in the notification
+const maxListedItems = 50
+
+//go:generate go run ../../internal/tools/mockgen -interface LowStockService -out ../../internal/testing/servicemocks
+
+type LowStockService interface {
+	// CheckLowStock finds the products and variants at or below the low-stock
+	// threshold and notifies the admins when there are any
+	CheckLowStock(ctx context.Context) ([]entity.LowStockItem, error)
+}
+
+type UseCase struct {
+	repo      repository.LowStockRepository
+	userRepo  repository.UserRepository
+	notifier  notification.Notifier
+	threshold int
+}
+
+func NewUseCase(repo repository.LowStockRepository, userRepo repository.UserRepository, notifier notification.Notifier, threshold int) *UseCase {
+	return &UseCase{
+		repo:      repo,
+		userRepo:  userRepo,
+		notifier:  notifier,
+		threshold: threshold,
+	}
+}
+
+func (uc *UseCase) CheckLowStock(ctx context.Context) ([]entity.LowStockItem, error) {
+	items, err := uc.repo.ListLowStock(ctx, uc.threshold)
+	if err != nil || len(items) == 0 {
+		return items, err
+	}
+
+	admins, err := uc.userRepo.ListByRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		return items, err
+	}
+
+	var recipients []string
+	for _, admin := range admins {
+		recipients = append(recipients, admin.Email)
+	}
+	if len(recipients) == 0 {
+		return items, nil
+	}
+
+	err = uc.notifier.Notify(ctx, notification.Notification{
+		Recipients: recipients,
+		Subject:    fmt.Sprintf("Low stock: %d items at or below %d units", len(items), uc.threshold),
+		Body:       summary(items),
+	})
+	return items, err
+}
+
+func summary(items []entity.LowStockItem) string {
+	var b strings.Builder
+	for i, item := range items {
+		if i == maxListedItems {
+			fmt.Fprintf(&b, "... and %d more\n", len(items)-maxListedItems)
+			break
+		}
+		b.WriteString(item.String())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package lowstock
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+)
+
+type mockLowStockRepo struct {
+	items     []entity.LowStockItem
+	threshold int
+}
+
+func (m *mockLowStockRepo) ListLowStock(ctx context.Context, threshold int) ([]entity.LowStockItem, error) {
+	m.threshold = threshold
+	return m.items, nil
+}
+
+type mockUserRepo struct {
+	admins []*entity.User
+}
+
+func (m *mockUserRepo) Create(ctx context.Context, user *entity.User) error { return nil }
+
+func (m *mockUserRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepo) GetByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepo) ListByRole(ctx context.Context, role entity.Role) ([]*entity.User, error) {
+	return m.admins, nil
+}
+
+func (m *mockUserRepo) Update(ctx context.Context, user *entity.User) error { return nil }
+
+func (m *mockUserRepo) Delete(ctx context.Context, id uuid.UUID) error { return nil }
+
+type mockNotifier struct {
+	sent []notification.Notification
+}
+
+func (m *mockNotifier) Notify(ctx context.Context, n notification.Notification) error {
+	m.sent = append(m.sent, n)
+	return nil
+}
+
+var _ repository.LowStockRepository = (*mockLowStockRepo)(nil)
+var _ repository.UserRepository = (*mockUserRepo)(nil)
+
+func TestCheckLowStock_NotifiesAdmins(t *testing.T) {
+	variantID := uuid.New()
+	repo := &mockLowStockRepo{items: []entity.LowStockItem{
+		{ProductID: uuid.New(), ProductName: "Mug", Quantity: 0},
+		{ProductID: uuid.New(), VariantID: &variantID, ProductName: "T-Shirt", SKU: "TS-RED-M", Quantity: 3},
+	}}
+	users := &mockUserRepo{admins: []*entity.User{{Email: "a@example.com"}, {Email: "b@example.com"}}}
+	notifier := &mockNotifier{}
+	uc := NewUseCase(repo, users, notifier, 5)
+
+	items, err := uc.CheckLowStock(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(items) != 2 || repo.threshold != 5 {
+		t.Errorf("expected 2 items at threshold 5, got %d at %d", len(items), repo.threshold)
+	}
+	if len(notifier.sent) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(notifier.sent))
+	}
+	sent := notifier.sent[0]
+	if len(sent.Recipients) != 2 {
+		t.Errorf("expected every admin to be notified, got %v", sent.Recipients)
+	}
+	if !strings.Contains(sent.Body, "Mug: 0 left") || !strings.Contains(sent.Body, "T-Shirt (TS-RED-M): 3 left") {
+		t.Errorf("unexpected body %q", sent.Body)
+	}
+}
+
+func TestCheckLowStock_NothingLow(t *testing.T) {
+	notifier := &mockNotifier{}
+	uc := NewUseCase(&mockLowStockRepo{}, &mockUserRepo{admins: []*entity.User{{Email: "a@example.com"}}}, notifier, 5)
+
+	if _, err := uc.CheckLowStock(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(notifier.sent) != 0 {
+		t.Error("expected no notification")
+	}
+}
+
+func TestSummary_CapsListedItems(t *testing.T) {
+	items := make([]entity.LowStockItem, maxListedItems+3)
+	for i := range items {
+		items[i] = entity.LowStockItem{ProductName: "Item", Quantity: 1}
+	}
+
+	body := summary(items)
+	if lines := strings.Count(body, "\n"); lines != maxListedItems+1 {
+		t.Errorf("expected %d lines, got %d", maxListedItems+1, lines)
+	}
+	if !strings.HasSuffix(body, "... and 3 more\n") {
+		t.Errorf("expected the remaining items to be counted, got %q", body)
+	}
+}
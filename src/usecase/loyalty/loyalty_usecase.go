@@ -0,0 +1,172 @@
+package loyalty
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Program -out ../../internal/testing/servicemocks -name LoyaltyProgram
+
+// Program is what checkout and order processing need from the loyalty program
+type Program interface {
+	// CanRedeem checks that the user has the points before an order is placed
+	CanRedeem(ctx context.Context, userID uuid.UUID, points int) error
+	// Redeem spends points of the user on an order of the given subtotal and
+	// returns the discount they give
+	Redeem(ctx context.Context, userID, orderID uuid.UUID, points int, subtotal float64) (float64, error)
+	// Release gives back the points redeemed on an order
+	Release(ctx context.Context, orderID uuid.UUID) error
+	// Settle brings the points of an order in line with its status: a
+	// completed or delivered order earns points, a cancelled or refunded one
+	// gives back the points redeemed on it and loses the points it earned.
+	// Settling an order again changes nothing.
+	Settle(ctx context.Context, order *entity.Order) error
+}
+
+// Account is the points balance of a customer with a page of its history
+type Account struct {
+	Balance      int
+	Program      entity.LoyaltyProgram
+	Transactions []*entity.LoyaltyTransaction
+	Total        int
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface LoyaltyService -out ../../internal/testing/servicemocks
+
+type LoyaltyService interface {
+	Program
+	GetAccount(ctx context.Context, userID uuid.UUID, page, pageSize int) (*Account, error)
+}
+
+type UseCase struct {
+	repo    repository.LoyaltyRepository
+	program entity.LoyaltyProgram
+}
+
+func NewUseCase(repo repository.LoyaltyRepository, program entity.LoyaltyProgram) *UseCase {
+	return &UseCase{
+		repo:    repo,
+		program: program,
+	}
+}
+
+func (uc *UseCase) GetAccount(ctx context.Context, userID uuid.UUID, page, pageSize int) (*Account, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	balance, err := uc.repo.Balance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	transactions, total, err := uc.repo.ListByUser(ctx, userID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Account{
+		Balance:      balance,
+		Program:      uc.program,
+		Transactions: transactions,
+		Total:        total,
+	}, nil
+}
+
+func (uc *UseCase) CanRedeem(ctx context.Context, userID uuid.UUID, points int) error {
+	if uc.program.PointValue <= 0 {
+		return entity.ValidationError("Loyalty points can't be redeemed")
+	}
+	if points <= 0 {
+		return entity.ValidationError("Points to redeem must be greater than 0")
+	}
+
+	balance, err := uc.repo.Balance(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return entity.NewLoyaltyTransaction(userID, uuid.Nil, entity.LoyaltyRedeemed, -points).Spend(balance)
+}
+
+func (uc *UseCase) Redeem(ctx context.Context, userID, orderID uuid.UUID, points int, subtotal float64) (float64, error) {
+	if err := uc.program.ValidateRedemption(points, subtotal); err != nil {
+		return 0, err
+	}
+
+	if err := uc.repo.Redeem(ctx, entity.NewLoyaltyTransaction(userID, orderID, entity.LoyaltyRedeemed, -points)); err != nil {
+		return 0, err
+	}
+	return uc.program.Discount(points), nil
+}
+
+func (uc *UseCase) Release(ctx context.Context, orderID uuid.UUID) error {
+	transactions, err := uc.byType(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	return uc.offset(ctx, transactions, entity.LoyaltyRedeemed, entity.LoyaltyRestored)
+}
+
+func (uc *UseCase) Settle(ctx context.Context, order *entity.Order) error {
+	// Orders placed without an account, or anonymized since, have no points
+	if order.UserID == nil {
+		return nil
+	}
+
+	transactions, err := uc.byType(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case order.IsVoid():
+		if err := uc.offset(ctx, transactions, entity.LoyaltyRedeemed, entity.LoyaltyRestored); err != nil {
+			return err
+		}
+		return uc.offset(ctx, transactions, entity.LoyaltyEarned, entity.LoyaltyReversed)
+	case order.IsDelivered():
+		if _, earned := transactions[entity.LoyaltyEarned]; earned {
+			return nil
+		}
+		// Points are earned on what was paid for the order
+		points := uc.program.PointsFor(order.AmountPaid - order.AmountRefunded)
+		if points == 0 {
+			return nil
+		}
+		return uc.repo.Create(ctx, entity.NewLoyaltyTransaction(*order.UserID, order.ID, entity.LoyaltyEarned, points))
+	}
+	return nil
+}
+
+// byType returns the transactions of an order by type, an order has at most
+// one of each
+func (uc *UseCase) byType(ctx context.Context, orderID uuid.UUID) (map[entity.LoyaltyTransactionType]*entity.LoyaltyTransaction, error) {
+	transactions, err := uc.repo.ListByOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	byType := make(map[entity.LoyaltyTransactionType]*entity.LoyaltyTransaction, len(transactions))
+	for _, transaction := range transactions {
+		byType[transaction.Type] = transaction
+	}
+	return byType, nil
+}
+
+// offset records a transaction of type offsetType cancelling out the one of
+// type original, unless there is nothing to cancel or it was cancelled already
+func (uc *UseCase) offset(ctx context.Context, transactions map[entity.LoyaltyTransactionType]*entity.LoyaltyTransaction, original, offsetType entity.LoyaltyTransactionType) error {
+	transaction, ok := transactions[original]
+	if !ok {
+		return nil
+	}
+	if _, done := transactions[offsetType]; done {
+		return nil
+	}
+	return uc.repo.Create(ctx, entity.NewLoyaltyTransaction(transaction.UserID, *transaction.OrderID, offsetType, -transaction.Points))
+}
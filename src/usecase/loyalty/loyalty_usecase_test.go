@@ -0,0 +1,83 @@
+package loyalty
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+)
+
+func newUseCase(t *testing.T) (*UseCase, uuid.UUID) {
+	t.Helper()
+	store := memory.NewStore()
+	user := &entity.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane Doe", Role: entity.RoleCustomer}
+	if err := memory.NewUserRepository(store).Create(context.Background(), user); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return NewUseCase(memory.NewLoyaltyRepository(store), entity.LoyaltyProgram{EarnRate: 1, PointValue: 0.01}), user.ID
+}
+
+func deliveredOrder(userID uuid.UUID, paid float64) *entity.Order {
+	return &entity.Order{ID: uuid.New(), UserID: &userID, Status: entity.Delivered, AmountPaid: paid}
+}
+
+func balance(t *testing.T, uc *UseCase, userID uuid.UUID) int {
+	t.Helper()
+	account, err := uc.GetAccount(context.Background(), userID, 1, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return account.Balance
+}
+
+func TestSettle_EarnsPointsOnce(t *testing.T) {
+	uc, userID := newUseCase(t)
+	order := deliveredOrder(userID, 120.75)
+
+	for i := 0; i < 2; i++ {
+		if err := uc.Settle(context.Background(), order); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+	if got := balance(t, uc, userID); got != 120 {
+		t.Errorf("expected 120 points, got %d", got)
+	}
+
+	order.Status = entity.Refunded
+	uc.Settle(context.Background(), order)
+	if got := balance(t, uc, userID); got != 0 {
+		t.Errorf("expected the refund to take the points back, got %d", got)
+	}
+}
+
+func TestRedeem_SpendsAndRestoresPoints(t *testing.T) {
+	uc, userID := newUseCase(t)
+	uc.Settle(context.Background(), deliveredOrder(userID, 500))
+
+	if err := uc.CanRedeem(context.Background(), userID, 600); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected redeeming more than the balance to conflict, got %v", err)
+	}
+
+	orderID := uuid.New()
+	if _, err := uc.Redeem(context.Background(), userID, orderID, 300, 2.50); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a discount over the subtotal to be rejected, got %v", err)
+	}
+	discount, err := uc.Redeem(context.Background(), userID, orderID, 300, 40)
+	if err != nil || discount != 3 {
+		t.Fatalf("expected a 3.00 discount, got %v, %v", discount, err)
+	}
+	if got := balance(t, uc, userID); got != 200 {
+		t.Errorf("expected 200 points left, got %d", got)
+	}
+
+	cancelled := &entity.Order{ID: orderID, UserID: &userID, Status: entity.Cancelled}
+	for i := 0; i < 2; i++ {
+		uc.Settle(context.Background(), cancelled)
+	}
+	if got := balance(t, uc, userID); got != 500 {
+		t.Errorf("expected the redeemed points back once, got %d", got)
+	}
+}
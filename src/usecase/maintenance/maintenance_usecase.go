@@ -0,0 +1,111 @@
+package maintenance
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface MaintenanceService -out ../../internal/testing/servicemocks
+
+type MaintenanceService interface {
+	// Status returns the current maintenance mode
+	Status() entity.MaintenanceStatus
+	// Update switches maintenance mode on or off. Fields of update left nil
+	// keep their current value.
+	Update(ctx context.Context, update Update, updatedBy uuid.UUID) (entity.MaintenanceStatus, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+// Update changes the maintenance mode. An empty message restores the default one.
+type Update struct {
+	Enabled    bool
+	Message    *string
+	RetryAfter *time.Duration
+}
+
+// Limits of the values admins can set
+const (
+	maxMessageLength = 500
+	maxRetryAfter    = 24 * time.Hour
+)
+
+// UseCase keeps the maintenance mode in memory. It is per process, so with
+// several replicas each instance has to be switched on its own.
+type UseCase struct {
+	services Services
+	now      func() time.Time
+	mu       sync.RWMutex
+	status   entity.MaintenanceStatus
+}
+
+// NewUseCase creates the maintenance use case starting in the configured mode
+func NewUseCase(services Services, enabled bool, message string, retryAfter time.Duration) *UseCase {
+	if strings.TrimSpace(message) == "" {
+		message = entity.DefaultMaintenanceMessage
+	}
+
+	uc := &UseCase{
+		services: services,
+		now:      time.Now,
+		status: entity.MaintenanceStatus{
+			Enabled:    enabled,
+			Message:    strings.TrimSpace(message),
+			RetryAfter: retryAfter,
+		},
+	}
+	if enabled {
+		since := uc.now()
+		uc.status.Since = &since
+	}
+	return uc
+}
+
+func (uc *UseCase) Status() entity.MaintenanceStatus {
+	uc.mu.RLock()
+	defer uc.mu.RUnlock()
+
+	return uc.status
+}
+
+func (uc *UseCase) Update(ctx context.Context, update Update, updatedBy uuid.UUID) (entity.MaintenanceStatus, error) {
+	if update.RetryAfter != nil && (*update.RetryAfter < time.Second || *update.RetryAfter > maxRetryAfter) {
+		return entity.MaintenanceStatus{}, entity.ValidationError("retry_after must be between 1 second and 24 hours")
+	}
+	if update.Message != nil && len(strings.TrimSpace(*update.Message)) > maxMessageLength {
+		return entity.MaintenanceStatus{}, entity.ValidationError("message must be at most 500 characters")
+	}
+
+	uc.mu.Lock()
+	before := uc.status
+	after := before
+	if update.Enabled != before.Enabled {
+		since := uc.now()
+		after.Enabled = update.Enabled
+		after.Since = &since
+	}
+	if update.Message != nil {
+		after.Message = strings.TrimSpace(*update.Message)
+		if after.Message == "" {
+			after.Message = entity.DefaultMaintenanceMessage
+		}
+	}
+	if update.RetryAfter != nil {
+		after.RetryAfter = *update.RetryAfter
+	}
+	after.UpdatedBy = &updatedBy
+	uc.status = after
+	uc.mu.Unlock()
+
+	uc.services.GetAuditService().LogChange(ctx, &updatedBy, "UPDATE", "Maintenance", uuid.Nil, before, after)
+
+	return after, nil
+}
@@ -0,0 +1,93 @@
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func duration(d time.Duration) *time.Duration {
+	return &d
+}
+
+func message(s string) *string {
+	return &s
+}
+
+func TestNewUseCase_ConfiguredMode(t *testing.T) {
+	status := NewUseCase(&mockServices.MockServices{}, true, "", 5*time.Minute).Status()
+
+	assert.True(t, status.Enabled)
+	assert.Equal(t, entity.DefaultMaintenanceMessage, status.Message)
+	assert.Equal(t, 300, status.RetryAfterSeconds())
+	assert.NotNil(t, status.Since)
+	assert.Nil(t, status.UpdatedBy)
+
+	status = NewUseCase(&mockServices.MockServices{}, false, "Back soon", time.Minute).Status()
+	assert.False(t, status.Enabled)
+	assert.Equal(t, "Back soon", status.Message)
+	assert.Nil(t, status.Since)
+}
+
+func TestUpdate(t *testing.T) {
+	uc := NewUseCase(&mockServices.MockServices{}, false, "", time.Minute)
+	adminID := uuid.New()
+
+	status, err := uc.Update(context.Background(), Update{
+		Enabled:    true,
+		Message:    message("  Migrating the catalog  "),
+		RetryAfter: duration(10 * time.Minute),
+	}, adminID)
+	require.NoError(t, err)
+
+	assert.True(t, status.Enabled)
+	assert.Equal(t, "Migrating the catalog", status.Message)
+	assert.Equal(t, 600, status.RetryAfterSeconds())
+	assert.Equal(t, &adminID, status.UpdatedBy)
+	require.NotNil(t, status.Since)
+	assert.Equal(t, status, uc.Status())
+
+	// Changing the message alone keeps the mode and when it started
+	since := *status.Since
+	status, err = uc.Update(context.Background(), Update{Enabled: true, Message: message("")}, adminID)
+	require.NoError(t, err)
+	assert.True(t, status.Enabled)
+	assert.Equal(t, entity.DefaultMaintenanceMessage, status.Message)
+	assert.Equal(t, 600, status.RetryAfterSeconds())
+	assert.Equal(t, since, *status.Since)
+
+	status, err = uc.Update(context.Background(), Update{Enabled: false}, adminID)
+	require.NoError(t, err)
+	assert.False(t, status.Enabled)
+	assert.False(t, uc.Status().Enabled)
+}
+
+func TestUpdate_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		update Update
+	}{
+		{"retry after too short", Update{Enabled: true, RetryAfter: duration(0)}},
+		{"retry after too long", Update{Enabled: true, RetryAfter: duration(25 * time.Hour)}},
+		{"message too long", Update{Enabled: true, Message: message(strings.Repeat("x", 501))}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewUseCase(&mockServices.MockServices{}, false, "", time.Minute)
+
+			_, err := uc.Update(context.Background(), tt.update, uuid.New())
+
+			assert.True(t, errors.Is(err, entity.ErrValidation))
+			assert.False(t, uc.Status().Enabled)
+		})
+	}
+}
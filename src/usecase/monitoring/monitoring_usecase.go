@@ -0,0 +1,126 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface MonitoringService -out ../../internal/testing/servicemocks
+
+type MonitoringService interface {
+	ListActivity(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*entity.AuditLog, int, error)
+	ListAlerts(ctx context.Context, rule *entity.AlertRule, page, pageSize int) ([]*entity.AdminAlert, int, error)
+}
+
+// UseCase evaluates alert rules against every audit log entry and notifies
+// the other admins when one fires
+type UseCase struct {
+	auditRepo repository.AuditLogRepository
+	alertRepo repository.AdminAlertRepository
+	userRepo  repository.UserRepository
+	notifier  notification.Notifier
+	rules     []Rule
+}
+
+func NewUseCase(
+	auditRepo repository.AuditLogRepository,
+	alertRepo repository.AdminAlertRepository,
+	userRepo repository.UserRepository,
+	notifier notification.Notifier,
+	rules []Rule,
+) *UseCase {
+	return &UseCase{
+		auditRepo: auditRepo,
+		alertRepo: alertRepo,
+		userRepo:  userRepo,
+		notifier:  notifier,
+		rules:     rules,
+	}
+}
+
+// Observe implements audit.Observer. Failures are logged and never block the audited action.
+func (uc *UseCase) Observe(ctx context.Context, log *entity.AuditLog) {
+	for _, rule := range uc.rules {
+		message, err := rule.Evaluate(ctx, log)
+		if err != nil {
+			fmt.Printf("Failed to evaluate alert rule %s: %v\n", rule.Name(), err)
+			continue
+		}
+		if message == "" {
+			continue
+		}
+
+		alert := &entity.AdminAlert{
+			ID:         entity.NewID(),
+			Rule:       rule.Name(),
+			ActorID:    log.UserID,
+			AuditLogID: log.ID,
+			Message:    message,
+			CreatedAt:  time.Now(),
+		}
+
+		if err := uc.alertRepo.Create(ctx, alert); err != nil {
+			fmt.Printf("Failed to store admin alert: %v\n", err)
+			continue
+		}
+
+		uc.notifyAdmins(ctx, alert)
+	}
+}
+
+// notifyAdmins alerts every active admin except the one who performed the action
+func (uc *UseCase) notifyAdmins(ctx context.Context, alert *entity.AdminAlert) {
+	admins, err := uc.userRepo.ListByRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		fmt.Printf("Failed to load admins for alert %s: %v\n", alert.ID, err)
+		return
+	}
+
+	var recipients []string
+	for _, admin := range admins {
+		if alert.ActorID != nil && admin.ID == *alert.ActorID {
+			continue
+		}
+		recipients = append(recipients, admin.Email)
+	}
+
+	if len(recipients) == 0 {
+		return
+	}
+
+	err = uc.notifier.Notify(ctx, notification.Notification{
+		Recipients: recipients,
+		Subject:    "Admin activity alert: " + string(alert.Rule),
+		Body:       alert.Message,
+	})
+	if err != nil {
+		fmt.Printf("Failed to notify admins of alert %s: %v\n", alert.ID, err)
+	}
+}
+
+func (uc *UseCase) ListActivity(ctx context.Context, filters repository.AuditLogFilters, page, pageSize int) ([]*entity.AuditLog, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return uc.auditRepo.List(ctx, filters, page, pageSize)
+}
+
+func (uc *UseCase) ListAlerts(ctx context.Context, rule *entity.AlertRule, page, pageSize int) ([]*entity.AdminAlert, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return uc.alertRepo.List(ctx, rule, page, pageSize)
+}
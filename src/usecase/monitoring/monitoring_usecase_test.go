@@ -0,0 +1,154 @@
+package monitoring
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+func newLog(action, resourceType, before, after string) *entity.AuditLog {
+	actor := uuid.New()
+	log := &entity.AuditLog{
+		ID:           uuid.New(),
+		UserID:       &actor,
+		Action:       action,
+		ResourceType: resourceType,
+		ResourceID:   uuid.New(),
+		Timestamp:    time.Now(),
+	}
+	if before != "" {
+		log.PayloadBefore = []byte(before)
+	}
+	if after != "" {
+		log.PayloadAfter = []byte(after)
+	}
+	return log
+}
+
+func TestPriceChangeRule(t *testing.T) {
+	rule := &priceChangeRule{percent: 50}
+
+	tests := []struct {
+		name      string
+		before    string
+		after     string
+		wantAlert bool
+	}{
+		{"small change", `{"Price":100}`, `{"Price":120}`, false},
+		{"large increase", `{"Price":100}`, `{"Price":200}`, true},
+		{"large decrease", `{"Price":100}`, `{"Price":10}`, true},
+		{"price unchanged", `{"Price":100,"Name":"A"}`, `{"Price":100,"Name":"B"}`, false},
+		{"created product", "", `{"Price":100}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := rule.Evaluate(context.Background(), newLog("UPDATE", "Product", tt.before, tt.after))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if (message != "") != tt.wantAlert {
+				t.Errorf("expected alert=%v, got %q", tt.wantAlert, message)
+			}
+		})
+	}
+}
+
+func TestRoleEscalationRule(t *testing.T) {
+	rule := &roleEscalationRule{}
+
+	tests := []struct {
+		name      string
+		action    string
+		before    string
+		after     string
+		wantAlert bool
+	}{
+		{"promotion to admin", "UPDATE", `{"Role":"customer"}`, `{"Role":"admin"}`, true},
+		{"admin registration", "REGISTER", "", `{"Role":"admin"}`, true},
+		{"customer registration", "REGISTER", "", `{"Role":"customer"}`, false},
+		{"admin stays admin", "UPDATE", `{"Role":"admin"}`, `{"Role":"admin"}`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := rule.Evaluate(context.Background(), newLog(tt.action, "User", tt.before, tt.after))
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if (message != "") != tt.wantAlert {
+				t.Errorf("expected alert=%v, got %q", tt.wantAlert, message)
+			}
+		})
+	}
+}
+
+func TestMassDeleteRule_AlertsOncePerThreshold(t *testing.T) {
+	auditRepo := new(mocks.AuditLogRepository)
+	rule := &massDeleteRule{auditRepo: auditRepo, threshold: 3, window: 10 * time.Minute}
+
+	var alerts int
+	for i := 1; i <= 7; i++ {
+		auditRepo.On("List", mock.Anything, mock.Anything, 1, 1).Return(nil, i, nil).Once()
+		message, err := rule.Evaluate(context.Background(), newLog("DELETE", "Product", "", ""))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if message != "" {
+			alerts++
+		}
+	}
+
+	if alerts != 2 {
+		t.Errorf("expected 2 alerts for 7 deletes with threshold 3, got %d", alerts)
+	}
+}
+
+// newTestUseCase returns a use case watching for the given rules, with admins
+// as the store's administrators
+func newTestUseCase(config RulesConfig, admins ...*entity.User) (*UseCase, *mocks.AdminAlertRepository, *mocks.Notifier) {
+	auditRepo := new(mocks.AuditLogRepository)
+	alertRepo := new(mocks.AdminAlertRepository)
+	alertRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.AdminAlert")).Return(nil)
+	userRepo := new(mocks.UserRepository)
+	userRepo.On("ListByRole", mock.Anything, entity.RoleAdmin).Return(admins, nil)
+	notifier := new(mocks.Notifier)
+	notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+	uc := NewUseCase(auditRepo, alertRepo, userRepo, notifier, NewRules(config, auditRepo))
+	return uc, alertRepo, notifier
+}
+
+func TestObserve_NotifiesOtherAdmins(t *testing.T) {
+	log := newLog("UPDATE", "Product", `{"Price":10}`, `{"Price":100}`)
+	actor := &entity.User{ID: *log.UserID, Email: "actor@example.com", Role: entity.RoleAdmin}
+	other := &entity.User{ID: uuid.New(), Email: "other@example.com", Role: entity.RoleAdmin}
+	uc, alertRepo, notifier := newTestUseCase(RulesConfig{PriceChangePercent: 50}, actor, other)
+
+	uc.Observe(context.Background(), log)
+
+	alertRepo.AssertNumberOfCalls(t, "Create", 1)
+	if alert := alertRepo.Calls[0].Arguments.Get(1).(*entity.AdminAlert); alert.Rule != entity.AlertLargePriceChange {
+		t.Fatalf("expected a price change alert, got %s", alert.Rule)
+	}
+	notifier.AssertNumberOfCalls(t, "Notify", 1)
+	sent := notifier.Calls[0].Arguments.Get(1).(notification.Notification)
+	if recipients := sent.Recipients; len(recipients) != 1 || recipients[0] != "other@example.com" {
+		t.Errorf("expected only the other admin to be notified, got %v", recipients)
+	}
+}
+
+func TestObserve_NoAlertForRoutineChange(t *testing.T) {
+	uc, alertRepo, notifier := newTestUseCase(RulesConfig{PriceChangePercent: 50, RoleEscalation: true})
+
+	uc.Observe(context.Background(), newLog("UPDATE", "Product", `{"Price":10}`, `{"Price":11}`))
+
+	alertRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	notifier.AssertNotCalled(t, "Notify", mock.Anything, mock.Anything)
+}
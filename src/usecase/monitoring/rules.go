@@ -0,0 +1,160 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Rule -out ../../internal/testing/servicemocks -name MonitoringRule
+
+// Rule inspects an audit log entry and returns an alert message when the
+// action looks suspicious, or an empty string otherwise
+type Rule interface {
+	Name() entity.AlertRule
+	Evaluate(ctx context.Context, log *entity.AuditLog) (string, error)
+}
+
+// RulesConfig configures the built-in alert rules. A zero threshold disables the rule.
+type RulesConfig struct {
+	MassDeleteThreshold int // Deletes by one user within MassDeleteWindow that raise an alert
+	MassDeleteWindow    time.Duration
+	PriceChangePercent  int // Relative price change that raises an alert
+	RoleEscalation      bool
+}
+
+// NewRules builds the rules enabled in cfg
+func NewRules(cfg RulesConfig, auditRepo repository.AuditLogRepository) []Rule {
+	var rules []Rule
+	if cfg.MassDeleteThreshold > 0 {
+		rules = append(rules, &massDeleteRule{auditRepo: auditRepo, threshold: cfg.MassDeleteThreshold, window: cfg.MassDeleteWindow})
+	}
+	if cfg.PriceChangePercent > 0 {
+		rules = append(rules, &priceChangeRule{percent: float64(cfg.PriceChangePercent)})
+	}
+	if cfg.RoleEscalation {
+		rules = append(rules, &roleEscalationRule{})
+	}
+	return rules
+}
+
+type massDeleteRule struct {
+	auditRepo repository.AuditLogRepository
+	threshold int
+	window    time.Duration
+}
+
+func (r *massDeleteRule) Name() entity.AlertRule {
+	return entity.AlertMassDelete
+}
+
+func (r *massDeleteRule) Evaluate(ctx context.Context, log *entity.AuditLog) (string, error) {
+	if log.Action != "DELETE" || log.UserID == nil {
+		return "", nil
+	}
+
+	action := "DELETE"
+	since := log.Timestamp.Add(-r.window).Format(time.RFC3339)
+	_, count, err := r.auditRepo.List(ctx, repository.AuditLogFilters{UserID: log.UserID, Action: &action, StartDate: &since}, 1, 1)
+	if err != nil {
+		return "", err
+	}
+
+	// Alert once per threshold crossed rather than on every further delete
+	if count < r.threshold || count%r.threshold != 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("User %s deleted %d records within %s (latest: %s %s)",
+		log.UserID, count, r.window, log.ResourceType, log.ResourceID), nil
+}
+
+type priceChangeRule struct {
+	percent float64
+}
+
+func (r *priceChangeRule) Name() entity.AlertRule {
+	return entity.AlertLargePriceChange
+}
+
+func (r *priceChangeRule) Evaluate(ctx context.Context, log *entity.AuditLog) (string, error) {
+	if log.ResourceType != "Product" {
+		return "", nil
+	}
+
+	before, okBefore := priceFromPayload(log.PayloadBefore)
+	after, okAfter := priceFromPayload(log.PayloadAfter)
+	if !okBefore || !okAfter || before == after {
+		return "", nil
+	}
+
+	var change float64
+	if before == 0 {
+		change = 100
+	} else {
+		change = math.Abs(after-before) / before * 100
+	}
+
+	if change < r.percent {
+		return "", nil
+	}
+
+	return fmt.Sprintf("Price of product %s changed from %.2f to %.2f (%.0f%%)",
+		log.ResourceID, before, after, change), nil
+}
+
+func priceFromPayload(payload []byte) (float64, bool) {
+	if len(payload) == 0 {
+		return 0, false
+	}
+
+	var fields struct {
+		Price *float64
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil || fields.Price == nil {
+		return 0, false
+	}
+	return *fields.Price, true
+}
+
+type roleEscalationRule struct{}
+
+func (r *roleEscalationRule) Name() entity.AlertRule {
+	return entity.AlertRoleEscalation
+}
+
+func (r *roleEscalationRule) Evaluate(ctx context.Context, log *entity.AuditLog) (string, error) {
+	if log.ResourceType != "User" {
+		return "", nil
+	}
+
+	before := roleFromPayload(log.PayloadBefore)
+	after := roleFromPayload(log.PayloadAfter)
+	if after != entity.RoleAdmin || before == entity.RoleAdmin {
+		return "", nil
+	}
+
+	if before == "" {
+		return fmt.Sprintf("Admin account %s was created", log.ResourceID), nil
+	}
+	return fmt.Sprintf("User %s was promoted from %s to admin", log.ResourceID, before), nil
+}
+
+func roleFromPayload(payload []byte) entity.Role {
+	if len(payload) == 0 {
+		return ""
+	}
+
+	var fields struct {
+		Role entity.Role
+	}
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return ""
+	}
+	return fields.Role
+}
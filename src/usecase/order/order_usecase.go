@@ -2,13 +2,23 @@ package order
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+	"github.com/marcofilho/go-ecommerce/src/usecase/textmessage"
 )
 
 type CreateOrderItem struct {
@@ -17,156 +27,552 @@ type CreateOrderItem struct {
 	Quantity  int
 }
 
+// MaxStatusBatch caps the orders of one status lookup
+const MaxStatusBatch = 100
+
+// ErrInvalidSort is returned when orders are searched with an unsupported sort field
+var ErrInvalidSort = entity.ValidationError("Invalid sort field, use created_at or total_price")
+
+//go:generate go run ../../internal/tools/mockgen -interface OrderService -out ../../internal/testing/servicemocks
+
 type OrderService interface {
-	CreateOrder(ctx context.Context, customerID int, items []CreateOrderItem) (*entity.Order, error)
+	CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []CreateOrderItem, redeemPoints int) (*entity.Order, error)
+	// QuoteOrder prices items for checkout without placing the order
+	QuoteOrder(ctx context.Context, userID *uuid.UUID, items []CreateOrderItem) (pricing.Totals, error)
 	GetOrder(ctx context.Context, id uuid.UUID) (*entity.Order, error)
+	// GetOrderStatuses looks up many orders at once for status polling
+	GetOrderStatuses(ctx context.Context, ids []uuid.UUID, ownerID *uuid.UUID) ([]*entity.Order, []uuid.UUID, error)
 	ListOrders(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error)
+	// SearchOrders looks up orders for admins by customer, date, total, SKU
+	// and status
+	SearchOrders(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error)
 	UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatus entity.OrderStatus) (*entity.Order, error)
+	// UpdateOrderStatuses moves many orders to the same status, each through
+	// the workflow on its own, as a warehouse does with a picking batch
+	UpdateOrderStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate) (*entity.BulkOrderStatusReport, error)
 }
 
 type Services interface {
 	GetAuditService() audit.AuditService
+	GetBlocklist() blocklist.Checker
+	GetFraudChecker() fraud.Checker
+	GetStockRecorder() stock.Recorder
+	GetPriceBook() pricehistory.Book
+	GetPriceResolver() pricing.Resolver
+	GetOrderWorkflow() *entity.OrderWorkflow
+	GetWebhookDispatcher() events.Dispatcher
+	GetLoyaltyProgram() loyalty.Program
+	GetTextMessenger() textmessage.Messenger
 }
 
 type UseCase struct {
 	orderRepo   repository.OrderRepository
 	productRepo repository.ProductRepository
 	variantRepo repository.ProductVariantRepository
+	queueRepo   repository.PurchaseQueueRepository
 	services    Services
+	pricing     pricing.Calculator
+	taxRate     float64
 }
 
-func NewUseCase(orderRepo repository.OrderRepository, productRepo repository.ProductRepository, variantRepo repository.ProductVariantRepository, services Services) *UseCase {
+func NewUseCase(orderRepo repository.OrderRepository, productRepo repository.ProductRepository, variantRepo repository.ProductVariantRepository, queueRepo repository.PurchaseQueueRepository, services Services, taxRate float64) *UseCase {
 	return &UseCase{
 		orderRepo:   orderRepo,
 		productRepo: productRepo,
 		variantRepo: variantRepo,
+		queueRepo:   queueRepo,
 		services:    services,
+		pricing:     pricing.NewCalculator(),
+		taxRate:     taxRate,
 	}
 }
 
-func (uc *UseCase) CreateOrder(ctx context.Context, customerID int, items []CreateOrderItem) (*entity.Order, error) {
+// CreateOrder places an order. redeemPoints loyalty points of the user are
+// taken off its subtotal as a discount; 0 redeems none. Orders from a blocked
+// account or client IP are refused.
+func (uc *UseCase) CreateOrder(ctx context.Context, customerID int, userID *uuid.UUID, clientIP string, items []CreateOrderItem, redeemPoints int) (*entity.Order, error) {
 	if customerID <= 0 {
-		return nil, errors.New("Invalid customer ID")
+		return nil, entity.ValidationError("Invalid customer ID")
+	}
+
+	if len(items) == 0 {
+		return nil, entity.ValidationError("Order must have at least one item")
+	}
+
+	if err := uc.services.GetBlocklist().CheckUser(ctx, userID, clientIP); err != nil {
+		return nil, err
+	}
+
+	// Screen the customer before any stock is reserved
+	screening, err := uc.services.GetFraudChecker().Screen(ctx, &entity.Order{CustomerID: customerID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the points balance before any stock is reserved
+	if redeemPoints > 0 {
+		if userID == nil {
+			return nil, entity.ValidationError("Sign in to redeem loyalty points")
+		}
+		if err := uc.services.GetLoyaltyProgram().CanRedeem(ctx, *userID, redeemPoints); err != nil {
+			return nil, err
+		}
+	}
+
+	// Price lists give customer groups such as wholesale their own prices
+	group, err := uc.services.GetPriceResolver().CustomerGroup(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Every item is checked and priced before any stock is taken, so an order
+	// refused for one of its items leaves the stock of the others alone
+	lines, queueEntries, err := uc.checkItems(ctx, userID, group, items)
+	if err != nil {
+		return nil, err
+	}
+
+	orderItems := make([]entity.OrderItem, 0, len(lines))
+	for _, line := range lines {
+		orderItems = append(orderItems, line.item)
+	}
+
+	order := &entity.Order{
+		ID:            entity.NewID(),
+		CustomerID:    customerID,
+		UserID:        userID,
+		Products:      orderItems,
+		Status:        entity.Pending,
+		PaymentStatus: entity.Unpaid,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	// Orders screening is unsure of keep their stock but can't be paid until
+	// an admin approves them
+	if screening.Review {
+		order.Status = entity.Review
+	}
+
+	// Redeemed points are a fixed discount on the subtotal. They are taken
+	// before the stock, which is harder to give back.
+	cart := uc.cart(lines)
+	if redeemPoints > 0 {
+		discount, err := uc.services.GetLoyaltyProgram().Redeem(ctx, *userID, order.ID, redeemPoints, cart.Totals().Subtotal)
+		if err != nil {
+			return nil, err
+		}
+		cart.SetDiscount(discount)
+	}
+
+	// Store the base price, discount and tax of every line so refunds and invoices
+	// don't have to re-derive them from the order total
+	order.TotalPrice = uc.pricing.PriceOrder(order, cart.Input()).Total
+
+	if err := order.Validate(); err != nil {
+		uc.releasePoints(ctx, order)
+		return nil, err
+	}
+
+	movements, err := uc.takeStock(ctx, lines)
+	if err != nil {
+		uc.releasePoints(ctx, order)
+		return nil, err
+	}
+
+	if err := uc.orderRepo.Create(ctx, order); err != nil {
+		uc.putBack(ctx, lines)
+		uc.releasePoints(ctx, order)
+		return nil, err
+	}
+
+	if screening.Review {
+		uc.services.GetAuditService().LogChange(ctx, nil, "HOLD_FOR_REVIEW", "Order", order.ID,
+			nil, map[string]interface{}{"status": order.Status, "reasons": screening.Reasons})
+	}
+
+	for _, movement := range movements {
+		movement.Reference = order.ID.String()
+		uc.services.GetStockRecorder().Record(ctx, movement)
 	}
 
+	// Close the purchase windows that were used so the next users in line get admitted
+	for _, entry := range queueEntries {
+		entry.Status = entity.QueueCompleted
+		entry.UpdatedAt = time.Now()
+		uc.queueRepo.Update(ctx, entry)
+	}
+
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.OrderCreated, events.NewOrderData(order))
+
+	return order, nil
+}
+
+// QuoteOrder prices items as CreateOrder would, after the same checks, for a
+// checkout preview. No stock or points are taken and nothing is saved.
+func (uc *UseCase) QuoteOrder(ctx context.Context, userID *uuid.UUID, items []CreateOrderItem) (pricing.Totals, error) {
 	if len(items) == 0 {
-		return nil, errors.New("Order must have at least one item")
+		return pricing.Totals{}, entity.ValidationError("Order must have at least one item")
 	}
 
-	var orderItems []entity.OrderItem
+	group, err := uc.services.GetPriceResolver().CustomerGroup(ctx, userID)
+	if err != nil {
+		return pricing.Totals{}, err
+	}
+
+	lines, _, err := uc.checkItems(ctx, userID, group, items)
+	if err != nil {
+		return pricing.Totals{}, err
+	}
+
+	return uc.cart(lines).Totals(), nil
+}
+
+// cart collects the checked lines at the store tax rate, to be priced when
+// its totals are read
+func (uc *UseCase) cart(lines []orderLine) *pricing.Cart {
+	cart := pricing.NewCart(uc.pricing, pricing.Input{TaxRate: uc.taxRate})
+	for _, line := range lines {
+		cart.AddLine(pricing.Line{UnitPrice: line.item.Price, Quantity: line.item.Quantity})
+	}
+	return cart
+}
+
+// orderLine is an item of an order, checked and priced, with the product or
+// variant whose stock it takes
+type orderLine struct {
+	item    entity.OrderItem
+	product *entity.Product
+	variant *entity.ProductVariant
+}
+
+// checkItems checks that the items can be ordered by the user, in the
+// quantities asked, and prices them. It returns the purchase windows of the
+// high-demand products the items order.
+func (uc *UseCase) checkItems(ctx context.Context, userID *uuid.UUID, group string, items []CreateOrderItem) ([]orderLine, []*entity.PurchaseQueueEntry, error) {
+	// Purchase limits count every unit of a product the order asks for, over all its variants
+	requested := make(map[uuid.UUID]int)
+	for _, item := range items {
+		requested[item.ProductID] += item.Quantity
+	}
+
+	products, variants, err := uc.loadItems(ctx, items)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Items ordering the same product or variant share its stock
+	reserved := make(map[uuid.UUID]int)
+	lines := make([]orderLine, 0, len(items))
+	var queueEntries []*entity.PurchaseQueueEntry
 	for _, item := range items {
-		// Check if ordering a specific variant
+		line := orderLine{item: entity.OrderItem{
+			ID:        entity.NewID(),
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		}}
+
 		if item.VariantID != nil {
-			// Order with variant: decrement variant stock
-			variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
-			if err != nil {
-				return nil, errors.New("Product variant not found: " + item.VariantID.String())
+			variant, ok := variants[*item.VariantID]
+			if !ok {
+				return nil, nil, entity.NotFoundError("Product variant not found: " + item.VariantID.String())
 			}
 
 			// Verify variant belongs to the specified product
 			if variant.ProductID != item.ProductID {
-				return nil, errors.New("Variant does not belong to the specified product")
+				return nil, nil, entity.ValidationError("Variant does not belong to the specified product")
 			}
+			line.variant = variant
+		}
+		// Variants of a deleted product can't be ordered either
+		product, ok := products[item.ProductID]
+		if !ok {
+			return nil, nil, entity.NotFoundError("Product not found: " + item.ProductID.String())
+		}
+		if line.variant != nil {
+			line.variant.Product = product
+		} else {
+			line.product = product
+		}
 
-			if !variant.IsAvailable(item.Quantity) {
-				return nil, errors.New("Insufficient stock for product variant")
-			}
+		// Drafts and archived products can't be ordered
+		if err := product.CheckPurchasable(); err != nil {
+			return nil, nil, err
+		}
+		if err := uc.checkPurchaseLimits(ctx, userID, product, requested); err != nil {
+			return nil, nil, err
+		}
 
-			// Get price from variant (uses override or base product price)
-			price, err := variant.GetPrice()
+		if product.HighDemandMode {
+			entry, err := uc.requirePurchaseWindow(ctx, item.ProductID, userID)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
+			queueEntries = append(queueEntries, entry)
+		}
 
-			orderItem := entity.OrderItem{
-				ID:        uuid.New(),
-				ProductID: item.ProductID,
-				VariantID: item.VariantID,
-				Quantity:  item.Quantity,
-				Price:     price,
+		if line.variant != nil {
+			reserved[line.variant.ID] += item.Quantity
+			if !line.variant.IsAvailable(reserved[line.variant.ID]) {
+				return nil, nil, entity.InsufficientStockError("Insufficient stock for product variant")
 			}
-
-			orderItem.CalculateTotal()
-
-			if err := orderItem.Validate(); err != nil {
-				return nil, err
+		} else {
+			reserved[product.ID] += item.Quantity
+			if !product.IsAvailable(reserved[product.ID]) {
+				return nil, nil, entity.InsufficientStockError("Insufficient stock for product: " + product.Name)
 			}
+		}
 
-			orderItems = append(orderItems, orderItem)
+		// Price the item from its scheduled price, the variant override or the
+		// base product price, then the price list
+		if err := uc.services.GetPriceBook().Attach(ctx, product); err != nil {
+			return nil, nil, err
+		}
+		price, err := uc.services.GetPriceResolver().UnitPrice(ctx, group, product, line.variant, item.Quantity)
+		if err != nil {
+			return nil, nil, err
+		}
 
-			// Decrease variant stock
-			if err := variant.DecreaseStock(item.Quantity); err != nil {
-				return nil, err
-			}
+		line.item.Price = price
+		line.item.SnapshotProduct(product, line.variant)
+		line.item.CalculateTotal()
+		if err := line.item.Validate(); err != nil {
+			return nil, nil, err
+		}
 
-			if err := uc.variantRepo.Update(ctx, variant); err != nil {
-				return nil, err
-			}
-		} else {
-			// Order without variant: decrement base product stock
-			product, err := uc.productRepo.GetByID(ctx, item.ProductID)
-			if err != nil {
-				return nil, errors.New("Product not found: " + item.ProductID.String())
-			}
+		lines = append(lines, line)
+	}
+	return lines, queueEntries, nil
+}
 
-			if !product.IsAvailable(item.Quantity) {
-				return nil, errors.New("Insufficient stock for product: " + product.Name)
-			}
+// takeStock takes the checked lines out of stock and returns the movements to
+// record once the order is placed. When a save fails, the stock the earlier
+// lines took is put back.
+func (uc *UseCase) takeStock(ctx context.Context, lines []orderLine) ([]*entity.StockMovement, error) {
+	movements := make([]*entity.StockMovement, 0, len(lines))
+	for i, line := range lines {
+		movement, err := uc.takeLine(ctx, line)
+		if err != nil {
+			uc.putBack(ctx, lines[:i])
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
 
-			orderItem := entity.OrderItem{
-				ID:        uuid.New(),
-				ProductID: product.ID,
-				VariantID: nil,
-				Quantity:  item.Quantity,
-				Price:     product.Price,
-			}
+func (uc *UseCase) takeLine(ctx context.Context, line orderLine) (*entity.StockMovement, error) {
+	if line.variant != nil {
+		before := line.variant.Quantity
+		if err := line.variant.DecreaseStock(line.item.Quantity); err != nil {
+			return nil, err
+		}
+		if err := uc.variantRepo.Update(ctx, line.variant); err != nil {
+			line.variant.Quantity = before
+			return nil, err
+		}
+		return entity.NewStockMovement(line.item.ProductID, line.item.VariantID, entity.StockOrder, before, line.variant.Quantity, ""), nil
+	}
 
-			orderItem.CalculateTotal()
+	before := line.product.Quantity
+	if err := line.product.DecreaseStock(line.item.Quantity); err != nil {
+		return nil, err
+	}
+	if err := uc.productRepo.Update(ctx, line.product); err != nil {
+		line.product.Quantity = before
+		return nil, err
+	}
+	return entity.NewStockMovement(line.product.ID, nil, entity.StockOrder, before, line.product.Quantity, ""), nil
+}
 
-			if err := orderItem.Validate(); err != nil {
-				return nil, err
+// putBack returns the stock taken by lines of an order that couldn't be placed
+func (uc *UseCase) putBack(ctx context.Context, lines []orderLine) {
+	for _, line := range lines {
+		if line.variant != nil {
+			line.variant.Quantity += line.item.Quantity
+			line.variant.UpdatedAt = time.Now()
+			if err := uc.variantRepo.Update(ctx, line.variant); err != nil {
+				fmt.Printf("Failed to put back the stock of variant %s: %v\n", line.variant.ID, err)
 			}
+			continue
+		}
 
-			orderItems = append(orderItems, orderItem)
+		if err := line.product.IncreaseStock(line.item.Quantity); err != nil {
+			continue
+		}
+		if err := uc.productRepo.Update(ctx, line.product); err != nil {
+			fmt.Printf("Failed to put back the stock of product %s: %v\n", line.product.ID, err)
+		}
+	}
+}
 
-			// Decrease base product stock
-			if err := product.DecreaseStock(item.Quantity); err != nil {
-				return nil, err
-			}
+// loadItems fetches the products the items order, the parents of the variants
+// included, and the variants, in a query each. Items ordering the same
+// product or variant share it, so each one sees the stock the previous ones
+// took.
+func (uc *UseCase) loadItems(ctx context.Context, items []CreateOrderItem) (map[uuid.UUID]*entity.Product, map[uuid.UUID]*entity.ProductVariant, error) {
+	var productIDs, variantIDs []uuid.UUID
+	for _, item := range items {
+		productIDs = append(productIDs, item.ProductID)
+		if item.VariantID != nil {
+			variantIDs = append(variantIDs, *item.VariantID)
+		}
+	}
 
-			if err := uc.productRepo.Update(ctx, product); err != nil {
-				return nil, err
-			}
+	products := make(map[uuid.UUID]*entity.Product)
+	if len(productIDs) > 0 {
+		found, err := uc.productRepo.GetByIDs(ctx, productIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, product := range found {
+			products[product.ID] = product
 		}
 	}
 
-	order := &entity.Order{
-		ID:            uuid.New(),
-		CustomerID:    customerID,
-		Products:      orderItems,
-		Status:        entity.Pending,
-		PaymentStatus: entity.Unpaid,
-		CreatedAt:     time.Now(),
-		UpdatedAt:     time.Now(),
+	variants := make(map[uuid.UUID]*entity.ProductVariant)
+	if len(variantIDs) > 0 {
+		found, err := uc.variantRepo.GetByIDs(ctx, variantIDs)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, variant := range found {
+			variants[variant.ID] = variant
+		}
 	}
+	return products, variants, nil
+}
 
-	order.CalculateTotal()
+// releasePoints gives back the points redeemed on an order that couldn't be placed
+func (uc *UseCase) releasePoints(ctx context.Context, order *entity.Order) {
+	if err := uc.services.GetLoyaltyProgram().Release(ctx, order.ID); err != nil {
+		fmt.Printf("Failed to release the loyalty points of order %s: %v\n", order.ID, err)
+	}
+}
 
-	if err := order.Validate(); err != nil {
-		return nil, err
+// checkPurchaseLimits enforces the limits of a limited-edition product on the
+// units of it requested by the order, once per product: requested loses the
+// product when it has been checked. What a customer bought before is counted by
+// account, as the customer ID of an order is whatever the client sent.
+func (uc *UseCase) checkPurchaseLimits(ctx context.Context, userID *uuid.UUID, product *entity.Product, requested map[uuid.UUID]int) error {
+	quantity, pending := requested[product.ID]
+	if !pending || !product.HasPurchaseLimits() {
+		return nil
 	}
+	delete(requested, product.ID)
 
-	if err := uc.orderRepo.Create(ctx, order); err != nil {
-		return nil, err
+	purchased := 0
+	if product.MaxPerCustomer > 0 {
+		if userID == nil {
+			return entity.ForbiddenError("Authentication required to purchase limited products")
+		}
+		var err error
+		if purchased, err = uc.orderRepo.CountPurchased(ctx, *userID, product.ID); err != nil {
+			return err
+		}
 	}
+	return product.CheckPurchaseLimits(quantity, purchased)
+}
 
-	return order, nil
+// requirePurchaseWindow ensures the user holds an open purchase window for a high-demand product
+func (uc *UseCase) requirePurchaseWindow(ctx context.Context, productID uuid.UUID, userID *uuid.UUID) (*entity.PurchaseQueueEntry, error) {
+	if userID == nil {
+		return nil, entity.ForbiddenError("Authentication required to purchase high-demand products")
+	}
+
+	entry, err := uc.queueRepo.GetActiveEntry(ctx, productID, *userID)
+	if err != nil || !entry.HasOpenWindow(time.Now()) {
+		return nil, entity.ForbiddenError("Purchase window required: join the queue for product " + productID.String())
+	}
+
+	return entry, nil
+}
+
+// restock returns the items of an order cancelled, or refunded before it
+// shipped, to stock. Items whose product
+// or variant no longer exists are skipped.
+func (uc *UseCase) restock(ctx context.Context, order *entity.Order) {
+	for _, item := range order.Products {
+		if item.VariantID != nil {
+			variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
+			if err != nil {
+				continue
+			}
+			before := variant.Quantity
+			variant.Quantity += item.Quantity
+			variant.UpdatedAt = time.Now()
+			if err := uc.variantRepo.Update(ctx, variant); err != nil {
+				fmt.Printf("Failed to restock variant %s: %v\n", variant.ID, err)
+				continue
+			}
+			uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(item.ProductID, item.VariantID, entity.StockCancellation, before, variant.Quantity, order.ID.String()))
+			continue
+		}
+
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			continue
+		}
+		before := product.Quantity
+		if err := product.IncreaseStock(item.Quantity); err != nil {
+			continue
+		}
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			fmt.Printf("Failed to restock product %s: %v\n", product.ID, err)
+			continue
+		}
+		uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(product.ID, nil, entity.StockCancellation, before, product.Quantity, order.ID.String()))
+	}
 }
 
 func (uc *UseCase) GetOrder(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
 	return uc.orderRepo.GetByID(ctx, id)
 }
 
+// GetOrderStatuses returns the orders with the IDs and their items, in
+// the order asked for, and the IDs no order was found for. With ownerID set
+// only the orders of that account are returned; those of others are reported
+// as not found so their existence is not leaked.
+func (uc *UseCase) GetOrderStatuses(ctx context.Context, ids []uuid.UUID, ownerID *uuid.UUID) ([]*entity.Order, []uuid.UUID, error) {
+	if len(ids) == 0 {
+		return nil, nil, entity.ValidationError("At least one order ID is required")
+	}
+	if len(ids) > MaxStatusBatch {
+		return nil, nil, entity.ValidationError(fmt.Sprintf("At most %d orders can be looked up at once", MaxStatusBatch))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	found, err := uc.orderRepo.GetByIDs(ctx, unique)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uuid.UUID]*entity.Order, len(found))
+	for _, order := range found {
+		if ownerID == nil || order.IsOwnedBy(*ownerID) {
+			byID[order.ID] = order
+		}
+	}
+
+	orders := make([]*entity.Order, 0, len(unique))
+	var missing []uuid.UUID
+	for _, id := range unique {
+		if order, ok := byID[id]; ok {
+			orders = append(orders, order)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return orders, missing, nil
+}
+
 func (uc *UseCase) ListOrders(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
 	if page < 1 {
 		page = 1
@@ -178,6 +584,35 @@ func (uc *UseCase) ListOrders(ctx context.Context, page, pageSize int, status *e
 	return uc.orderRepo.GetAll(ctx, page, pageSize, status, paymentStatus)
 }
 
+func (uc *UseCase) SearchOrders(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	if !search.Sort.IsValid() {
+		return nil, 0, ErrInvalidSort
+	}
+	if search.Status != nil && !slices.Contains(entity.OrderStatuses, *search.Status) {
+		return nil, 0, entity.ValidationError(fmt.Sprintf("Invalid status %q", *search.Status))
+	}
+	if search.PaymentStatus != nil && !slices.Contains(entity.PaymentStatuses, *search.PaymentStatus) {
+		return nil, 0, entity.ValidationError(fmt.Sprintf("Invalid payment status %q", *search.PaymentStatus))
+	}
+	if search.From != nil && search.To != nil && !search.From.Before(*search.To) {
+		return nil, 0, entity.ValidationError("from must be before to")
+	}
+	if search.MinTotal != nil && search.MaxTotal != nil && *search.MinTotal > *search.MaxTotal {
+		return nil, 0, entity.ValidationError("min_total cannot be greater than max_total")
+	}
+	search.CustomerEmail = strings.TrimSpace(search.CustomerEmail)
+	search.SKU = strings.TrimSpace(search.SKU)
+
+	return uc.orderRepo.Search(ctx, search, page, pageSize)
+}
+
 func (uc *UseCase) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatus entity.OrderStatus) (*entity.Order, error) {
 	order, err := uc.orderRepo.GetByID(ctx, id)
 	if err != nil {
@@ -187,7 +622,7 @@ func (uc *UseCase) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatu
 	// Store original state for audit
 	originalStatus := order.Status
 
-	if err := order.UpdateStatus(newStatus); err != nil {
+	if err := order.UpdateStatus(uc.services.GetOrderWorkflow(), newStatus); err != nil {
 		return nil, err
 	}
 
@@ -195,10 +630,57 @@ func (uc *UseCase) UpdateOrderStatus(ctx context.Context, id uuid.UUID, newStatu
 		return nil, err
 	}
 
+	uc.statusChanged(ctx, order, originalStatus)
+
+	return order, nil
+}
+
+func (uc *UseCase) UpdateOrderStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate) (*entity.BulkOrderStatusReport, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, updated, err := uc.orderRepo.UpdateStatuses(ctx, update, uc.services.GetOrderWorkflow())
+	if err != nil {
+		return nil, err
+	}
+
+	before := make(map[uuid.UUID]entity.OrderStatus, len(updated))
+	for _, result := range results {
+		if result.Outcome == entity.OrderStatusUpdated {
+			before[result.OrderID] = *result.StatusBefore
+		}
+	}
+	for _, order := range updated {
+		uc.statusChanged(ctx, order, before[order.ID])
+	}
+
+	return &entity.BulkOrderStatusReport{Status: update.Status, Results: results}, nil
+}
+
+// statusChanged restocks, settles loyalty points, logs and notifies about an
+// order saved with a new status
+func (uc *UseCase) statusChanged(ctx context.Context, order *entity.Order, originalStatus entity.OrderStatus) {
+	// Items of orders that never shipped go back in stock
+	if order.Status == entity.Cancelled || (order.Status == entity.Refunded && originalStatus == entity.Processing) {
+		uc.restock(ctx, order)
+	}
+
+	// Completed orders earn points, cancelled and refunded ones give them back
+	if err := uc.services.GetLoyaltyProgram().Settle(ctx, order); err != nil {
+		fmt.Printf("Failed to settle the loyalty points of order %s: %v\n", order.ID, err)
+	}
+
+	// Customers with a verified phone are texted when their order ships
+	if order.Status == entity.Shipped {
+		if err := uc.services.GetTextMessenger().OrderShipped(ctx, order); err != nil {
+			fmt.Printf("Failed to text the shipping of order %s: %v\n", order.ID, err)
+		}
+	}
+
 	// Log order status update
 	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE_STATUS", "Order", order.ID,
 		map[string]interface{}{"status": originalStatus},
-		map[string]interface{}{"status": newStatus})
-
-	return order, nil
+		map[string]interface{}{"status": order.Status})
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.OrderStatusChanged, events.NewOrderData(order))
 }
@@ -4,211 +4,450 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
 	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/marcofilho/go-ecommerce/src/usecase/blocklist"
+	"github.com/marcofilho/go-ecommerce/src/usecase/fraud"
+	"github.com/stretchr/testify/mock"
 )
 
-type mockOrderRepo struct {
-	orders    map[uuid.UUID]*entity.Order
-	createErr error
-	updateErr error
+var notFound = entity.NotFoundError("Not found")
+
+// stockedProducts mocks a product repository holding the products, where
+// stock updates succeed
+func stockedProducts(products ...*entity.Product) *mocks.ProductRepository {
+	repo := new(mocks.ProductRepository)
+	repo.On("GetByIDs", mock.Anything, mock.Anything).Return(products, nil)
+	for _, product := range products {
+		repo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	}
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Product")).Return(nil)
+	return repo
 }
 
-func newMockOrderRepo() *mockOrderRepo {
-	return &mockOrderRepo{orders: make(map[uuid.UUID]*entity.Order)}
+// stockedVariants mocks a variant repository holding the variants, where
+// stock updates succeed
+func stockedVariants(variants ...*entity.ProductVariant) *mocks.ProductVariantRepository {
+	repo := new(mocks.ProductVariantRepository)
+	repo.On("GetByIDs", mock.Anything, mock.Anything).Return(variants, nil)
+	for _, variant := range variants {
+		repo.On("GetByID", mock.Anything, variant.ID).Return(variant, nil)
+	}
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.ProductVariant")).Return(nil)
+	return repo
 }
 
-func (m *mockOrderRepo) Create(ctx context.Context, order *entity.Order) error {
-	if m.createErr != nil {
-		return m.createErr
+// placedOrders mocks an order repository holding the orders, where new
+// orders and updates are saved
+func placedOrders(orders ...*entity.Order) *mocks.OrderRepository {
+	repo := new(mocks.OrderRepository)
+	for _, order := range orders {
+		repo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
 	}
-	m.orders[order.ID] = order
-	return nil
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+	repo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(nil)
+	return repo
 }
 
-func (m *mockOrderRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
-	o, ok := m.orders[id]
-	if !ok {
-		return nil, errors.New("not found")
+// saved lets the order repository find an order the use case created
+func saved(repo *mocks.OrderRepository, order *entity.Order) {
+	repo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+}
+
+func TestCreateOrder_RejectsUnpublishedProducts(t *testing.T) {
+	for _, status := range []entity.ProductStatus{entity.ProductDraft, entity.ProductArchived} {
+		product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: status}
+		productRepo := stockedProducts(product)
+		uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+		_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0)
+		if !errors.Is(err, entity.ErrValidation) {
+			t.Errorf("expected a %s product to be rejected, got %v", status, err)
+		}
+		if product.Quantity != 10 {
+			t.Errorf("expected the stock of a %s product to be left alone", status)
+		}
+		productRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 	}
-	return o, nil
 }
 
-func (m *mockOrderRepo) GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
-	var result []*entity.Order
-	for _, o := range m.orders {
-		result = append(result, o)
+func TestCreateOrder_RejectsProductsOutsideTheirWindow(t *testing.T) {
+	later, earlier := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+	windows := map[string]*entity.Product{
+		"not yet available":   {AvailableFrom: &later},
+		"no longer available": {AvailableUntil: &earlier},
+	}
+	for name, product := range windows {
+		product.ID, product.Name, product.Price, product.Quantity, product.Status = uuid.New(), "Laptop", 100, 10, entity.ProductActive
+		uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+		_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0)
+		if !errors.Is(err, entity.ErrValidation) {
+			t.Errorf("expected a product %s to be rejected, got %v", name, err)
+		}
 	}
-	return result, len(result), nil
 }
 
-func (m *mockOrderRepo) Update(ctx context.Context, order *entity.Order) error {
-	if m.updateErr != nil {
-		return m.updateErr
+func TestCreateOrder_PurchaseLimits(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Sneaker Drop", Price: 100, Quantity: 10, Status: entity.ProductActive, MaxPerOrder: 2, MaxPerCustomer: 3}
+	variant := &entity.ProductVariant{ID: uuid.New(), ProductID: product.ID, Product: product, SKU: "DROP-42", Quantity: 10}
+	buyer, newcomer := uuid.New(), uuid.New()
+	orderRepo := placedOrders()
+	orderRepo.On("CountPurchased", mock.Anything, buyer, product.ID).Return(2, nil)
+	orderRepo.On("CountPurchased", mock.Anything, newcomer, product.ID).Return(0, nil)
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(variant), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	// The variant counts towards the order limit of its product
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 1}, {ProductID: product.ID, VariantID: &variant.ID, Quantity: 2}}
+	if _, err := uc.CreateOrder(context.Background(), 456, &newcomer, "", items, 0); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected 3 units in one order to be rejected, got %v", err)
 	}
-	if _, ok := m.orders[order.ID]; !ok {
-		return errors.New("not found")
+
+	if _, err := uc.CreateOrder(context.Background(), 456, &newcomer, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}, 0); err != nil {
+		t.Fatalf("expected 2 units to be ordered, got %v", err)
+	}
+	if _, err := uc.CreateOrder(context.Background(), 123, &buyer, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}, 0); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected the customer limit to be enforced over orders, got %v", err)
+	}
+	if _, err := uc.CreateOrder(context.Background(), 123, &buyer, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0); err != nil {
+		t.Errorf("expected the customer to order up to the limit, got %v", err)
+	}
+
+	// The customer ID comes from the client, so the account is what is counted
+	if _, err := uc.CreateOrder(context.Background(), 789, &buyer, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}, 0); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected the limit to hold under another customer ID, got %v", err)
+	}
+	orderRepo.AssertNotCalled(t, "CountPurchased", mock.Anything, mock.AnythingOfType("int"), mock.Anything)
+
+	if _, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0); !errors.Is(err, entity.ErrForbidden) {
+		t.Errorf("expected guests to be refused limited products, got %v", err)
 	}
-	m.orders[order.ID] = order
-	return nil
 }
 
-type mockProductRepo struct {
-	products  map[uuid.UUID]*entity.Product
-	updateErr error
+func TestCreateOrder_RefusedItemLeavesStockAlone(t *testing.T) {
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	drop := &entity.Product{ID: uuid.New(), Name: "Sneaker Drop", Price: 100, Quantity: 10, Status: entity.ProductActive, MaxPerOrder: 1}
+	productRepo := stockedProducts(laptop, drop)
+	uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{
+		{ProductID: laptop.ID, Quantity: 2},
+		{ProductID: drop.ID, Quantity: 2},
+	}, 0)
+	if !errors.Is(err, entity.ErrValidation) {
+		t.Fatalf("expected the order limit to refuse the order, got %v", err)
+	}
+	if laptop.Quantity != 10 {
+		t.Errorf("expected the laptop stock to be untouched, got %d", laptop.Quantity)
+	}
+	productRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	// Two items of the same product are checked against its stock together
+	_, err = uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{
+		{ProductID: laptop.ID, Quantity: 6},
+		{ProductID: laptop.ID, Quantity: 6},
+	}, 0)
+	if !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Errorf("expected insufficient stock, got %v", err)
+	}
+	productRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
-func newMockProductRepo() *mockProductRepo {
-	return &mockProductRepo{products: make(map[uuid.UUID]*entity.Product)}
+func TestCreateOrder_FailedSavePutsStockBack(t *testing.T) {
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	mouse := &entity.Product{ID: uuid.New(), Name: "Mouse", Price: 10, Quantity: 10, Status: entity.ProductActive}
+	productRepo := new(mocks.ProductRepository)
+	productRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*entity.Product{laptop, mouse}, nil)
+	productRepo.On("Update", mock.Anything, mouse).Return(errors.New("db down"))
+	productRepo.On("Update", mock.Anything, laptop).Return(nil)
+	uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{
+		{ProductID: laptop.ID, Quantity: 2},
+		{ProductID: mouse.ID, Quantity: 1},
+	}, 0)
+	if err == nil {
+		t.Fatal("expected the failed save to fail the order")
+	}
+	if laptop.Quantity != 10 || mouse.Quantity != 10 {
+		t.Errorf("expected the stock to be put back, got %d laptops and %d mice", laptop.Quantity, mouse.Quantity)
+	}
+	productRepo.AssertNumberOfCalls(t, "Update", 3)
 }
 
-func (m *mockProductRepo) Create(ctx context.Context, product *entity.Product) error {
-	return nil
+func TestCreateOrder_Success(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := placedOrders()
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.CustomerID != 123 {
+		t.Error("customer ID mismatch")
+	}
+	orderRepo.AssertCalled(t, "Create", mock.Anything, order)
 }
 
-func (m *mockProductRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
-	p, ok := m.products[id]
-	if !ok {
-		return nil, errors.New("not found")
+func TestCreateOrder_StoresLineComponents(t *testing.T) {
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	mouse := &entity.Product{ID: uuid.New(), Name: "Mouse", Price: 12.35, Quantity: 10, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(laptop, mouse), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0.2)
+
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{
+		{ProductID: laptop.ID, Quantity: 2},
+		{ProductID: mouse.ID, Quantity: 1},
+	}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	item := order.Products[0]
+	if item.ComponentTotal(entity.ComponentBase) != 200 || item.ComponentTotal(entity.ComponentTax) != 40 || item.TotalPrice != 240 {
+		t.Errorf("unexpected laptop line %+v", item)
+	}
+	if item.ProductName != "Laptop" || item.TaxRate != 0.2 {
+		t.Errorf("expected the laptop's name and tax rate kept on its line, got %q and %v", item.ProductName, item.TaxRate)
+	}
+	if order.Products[1].ComponentTotal(entity.ComponentTax) != 2.47 {
+		t.Errorf("expected 2.47 tax on the mouse line, got %v", order.Products[1].ComponentTotal(entity.ComponentTax))
+	}
+	if order.TotalPrice != order.Products[0].TotalPrice+order.Products[1].TotalPrice {
+		t.Errorf("expected the order total to be the sum of the lines, got %v", order.TotalPrice)
 	}
-	return p, nil
 }
 
-func (m *mockProductRepo) GetAll(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
-	return nil, 0, nil
+func TestQuoteOrder(t *testing.T) {
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	mouse := &entity.Product{ID: uuid.New(), Name: "Mouse", Price: 12.35, Quantity: 10, Status: entity.ProductActive}
+	products := stockedProducts(laptop, mouse)
+	orderRepo := placedOrders()
+	uc := NewUseCase(orderRepo, products, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0.2)
+	items := []CreateOrderItem{
+		{ProductID: laptop.ID, Quantity: 2},
+		{ProductID: mouse.ID, Quantity: 1},
+	}
+
+	totals, err := uc.QuoteOrder(context.Background(), nil, items)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if totals.Subtotal != 212.35 || totals.Tax != 42.47 || totals.Total != 254.82 {
+		t.Errorf("unexpected totals %+v", totals)
+	}
+	if laptop.Quantity != 10 || mouse.Quantity != 10 {
+		t.Error("expected a quote to leave the stock alone")
+	}
+	products.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	orderRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	// Checkout charges what it quoted
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.TotalPrice != totals.Total {
+		t.Errorf("expected the order total %v to match the quote, got %v", totals.Total, order.TotalPrice)
+	}
+
+	if _, err := uc.QuoteOrder(context.Background(), nil, []CreateOrderItem{{ProductID: laptop.ID, Quantity: 50}}); !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Errorf("expected insufficient stock, got %v", err)
+	}
 }
 
-func (m *mockProductRepo) Update(ctx context.Context, product *entity.Product) error {
-	if m.updateErr != nil {
-		return m.updateErr
+func TestCreateOrder_NoItems(t *testing.T) {
+	uc := NewUseCase(placedOrders(), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{}, 0)
+	if err == nil {
+		t.Error("expected error for empty items")
 	}
-	m.products[product.ID] = product
-	return nil
 }
 
-func (m *mockProductRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	return nil
+func TestCreateOrder_InsufficientStock(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 5, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 10}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
+
+	if err == nil {
+		t.Error("expected error for insufficient stock")
+	}
 }
 
-type mockVariantRepo struct {
-	variants  map[uuid.UUID]*entity.ProductVariant
-	updateErr error
+func TestCreateOrder_LoadsItemsAtOnce(t *testing.T) {
+	ctx := context.Background()
+	laptop := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 3, Status: entity.ProductActive}
+	mouse := &entity.Product{ID: uuid.New(), Name: "Mouse", Price: 10, Quantity: 10, Status: entity.ProductActive}
+	products := stockedProducts(laptop, mouse)
+	uc := NewUseCase(placedOrders(), products, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	items := []CreateOrderItem{{ProductID: laptop.ID, Quantity: 2}, {ProductID: mouse.ID, Quantity: 1}, {ProductID: laptop.ID, Quantity: 1}}
+	if _, err := uc.CreateOrder(ctx, 123, nil, "", items, 0); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	products.AssertNumberOfCalls(t, "GetByIDs", 1)
+	products.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	if laptop.Quantity != 0 {
+		t.Errorf("expected both laptop lines taken off its stock, got %d left", laptop.Quantity)
+	}
+
+	// Lines of one product share its stock
+	items = []CreateOrderItem{{ProductID: mouse.ID, Quantity: 5}, {ProductID: mouse.ID, Quantity: 5}}
+	if _, err := uc.CreateOrder(ctx, 123, nil, "", items, 0); !errors.Is(err, entity.ErrInsufficientStock) {
+		t.Errorf("expected the second line short of stock, got %v", err)
+	}
 }
 
-func newMockVariantRepo() *mockVariantRepo {
-	return &mockVariantRepo{variants: make(map[uuid.UUID]*entity.ProductVariant)}
+type rejectAllChecker struct{}
+
+func (rejectAllChecker) Screen(ctx context.Context, order *entity.Order) (fraud.Decision, error) {
+	return fraud.Accept, fraud.ErrOrderRejected
 }
 
-func (m *mockVariantRepo) Create(ctx context.Context, variant *entity.ProductVariant) error {
-	return nil
+type reviewAllChecker struct{}
+
+func (reviewAllChecker) Screen(ctx context.Context, order *entity.Order) (fraud.Decision, error) {
+	return fraud.HoldForReview("Too many orders"), nil
 }
 
-func (m *mockVariantRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
-	v, ok := m.variants[id]
-	if !ok {
-		return nil, errors.New("variant not found")
+func TestCreateOrder_HeldForReview(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := placedOrders()
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{FraudChecker: reviewAllChecker{}}, 0)
+
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}, 0)
+	if err != nil {
+		t.Fatalf("expected the order to be placed, got %v", err)
+	}
+	if order.Status != entity.Review {
+		t.Errorf("expected the order to be held for review, got %s", order.Status)
+	}
+	if product.Quantity != 8 {
+		t.Errorf("expected the held order to keep its stock, got %d", product.Quantity)
 	}
-	return v, nil
-}
 
-func (m *mockVariantRepo) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
-	return nil, 0, nil
+	saved(orderRepo, order)
+	approved, err := uc.UpdateOrderStatus(context.Background(), order.ID, entity.Pending)
+	if err != nil || approved.Status != entity.Pending {
+		t.Errorf("expected an admin to approve the order, got %v", err)
+	}
 }
 
-func (m *mockVariantRepo) GetAllByProductID(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error) {
-	return nil, 0, nil
+func TestCreateOrder_RejectedByFraudScreening(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	productRepo := stockedProducts(product)
+	uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{FraudChecker: rejectAllChecker{}}, 0)
+
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
+
+	if !errors.Is(err, fraud.ErrOrderRejected) {
+		t.Fatalf("expected fraud rejection, got %v", err)
+	}
+	if product.Quantity != 10 {
+		t.Error("expected stock to be left untouched")
+	}
+	productRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
 }
 
-func (m *mockVariantRepo) Update(ctx context.Context, variant *entity.ProductVariant) error {
-	if m.updateErr != nil {
-		return m.updateErr
+// blockIPChecker blocks one client IP
+type blockIPChecker struct{ ip string }
+
+func (c blockIPChecker) Check(ctx context.Context, email, ip string) error {
+	if ip == c.ip {
+		return blocklist.ErrBlocked
 	}
-	m.variants[variant.ID] = variant
 	return nil
 }
 
-func (m *mockVariantRepo) Delete(ctx context.Context, id uuid.UUID) error {
-	return nil
+func (c blockIPChecker) CheckUser(ctx context.Context, userID *uuid.UUID, ip string) error {
+	return c.Check(ctx, "", ip)
 }
 
-func TestCreateOrder_Success(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	variantRepo := newMockVariantRepo()
-	uc := NewUseCase(orderRepo, productRepo, variantRepo, &mockServices.MockServices{})
+func TestCreateOrder_RejectsBlockedClients(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{Blocklist: blockIPChecker{ip: "203.0.113.7"}}, 0)
 
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 10,
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}
+	if _, err := uc.CreateOrder(context.Background(), 123, nil, "203.0.113.7", items, 0); !errors.Is(err, entity.ErrForbidden) {
+		t.Fatalf("expected the blocked IP to be forbidden, got %v", err)
+	}
+	if product.Quantity != 10 {
+		t.Error("expected stock to be left untouched")
+	}
+	if _, err := uc.CreateOrder(context.Background(), 123, nil, "198.51.100.1", items, 0); err != nil {
+		t.Errorf("expected other clients to order, got %v", err)
 	}
+}
+
+func TestGetOrderStatuses(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	mine := &entity.Order{ID: uuid.New(), UserID: &owner, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	theirs := &entity.Order{ID: uuid.New(), UserID: &other, Status: entity.Completed, PaymentStatus: entity.Paid}
+	unknown := uuid.New()
 
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 2}}
-	order, err := uc.CreateOrder(context.Background(), 123, items)
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetByIDs", mock.Anything, []uuid.UUID{theirs.ID, mine.ID, unknown}).Return([]*entity.Order{mine, theirs}, nil)
+	orderRepo.On("GetByIDs", mock.Anything, []uuid.UUID{theirs.ID, mine.ID}).Return([]*entity.Order{mine, theirs}, nil)
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
+	orders, notFound, err := uc.GetOrderStatuses(context.Background(), []uuid.UUID{theirs.ID, mine.ID, unknown, mine.ID}, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if order.CustomerID != 123 {
-		t.Error("customer ID mismatch")
+	if len(orders) != 2 || orders[0].ID != theirs.ID || orders[1].ID != mine.ID {
+		t.Errorf("expected both orders once, in the order asked for, got %v", orders)
 	}
-}
-
-func TestCreateOrder_NoItems(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	_, err := uc.CreateOrder(context.Background(), 123, []CreateOrderItem{})
-	if err == nil {
-		t.Error("expected error for empty items")
+	if len(notFound) != 1 || notFound[0] != unknown {
+		t.Errorf("expected the unknown ID reported, got %v", notFound)
 	}
-}
 
-func TestCreateOrder_InsufficientStock(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 5,
+	// Orders of other accounts look like missing ones to a customer
+	orders, notFound, err = uc.GetOrderStatuses(context.Background(), []uuid.UUID{theirs.ID, mine.ID}, &owner)
+	if err != nil || len(orders) != 1 || orders[0].ID != mine.ID {
+		t.Errorf("expected only the owner's order, got %v, %v", orders, err)
+	}
+	if len(notFound) != 1 || notFound[0] != theirs.ID {
+		t.Errorf("expected the other account's order reported as not found, got %v", notFound)
 	}
 
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 10}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
-
-	if err == nil {
-		t.Error("expected error for insufficient stock")
+	if _, _, err := uc.GetOrderStatuses(context.Background(), nil, nil); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error without IDs, got %v", err)
+	}
+	if _, _, err := uc.GetOrderStatuses(context.Background(), make([]uuid.UUID, MaxStatusBatch+1), nil); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error over the cap, got %v", err)
 	}
 }
 
 func TestGetOrder_Success(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	oid := uuid.New()
-	orderRepo.orders[oid] = &entity.Order{ID: oid, CustomerID: 123}
+	stored := &entity.Order{ID: uuid.New(), CustomerID: 123}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
-	order, err := uc.GetOrder(context.Background(), oid)
+	order, err := uc.GetOrder(context.Background(), stored.ID)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if order.ID != oid {
+	if order.ID != stored.ID {
 		t.Error("order ID mismatch")
 	}
 }
 
 func TestListOrders_Success(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	orderRepo.orders[uuid.New()] = &entity.Order{CustomerID: 1}
-	orderRepo.orders[uuid.New()] = &entity.Order{CustomerID: 2}
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetAll", mock.Anything, 1, 10, mock.Anything, mock.Anything).
+		Return([]*entity.Order{{CustomerID: 1}, {CustomerID: 2}}, 2, nil)
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	orders, total, err := uc.ListOrders(context.Background(), 1, 10, nil, nil)
 	if err != nil {
@@ -222,17 +461,43 @@ func TestListOrders_Success(t *testing.T) {
 	}
 }
 
-func TestUpdateOrderStatus_Success(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	oid := uuid.New()
-	orderRepo.orders[oid] = &entity.Order{
-		ID: oid, Status: entity.Pending,
+func TestSearchOrders(t *testing.T) {
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("Search", mock.Anything, mock.MatchedBy(func(search repository.OrderSearch) bool {
+		return search.CustomerEmail == "ana@example.com" && search.SKU == "TSHIRT-L"
+	}), 1, 10).Return(nil, 0, nil).Once()
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+	ctx := context.Background()
+	newestFirst := repository.OrderSort{Field: repository.OrderSortCreatedAt, Descending: true}
+
+	// The email and SKU are trimmed
+	if _, _, err := uc.SearchOrders(ctx, repository.OrderSearch{CustomerEmail: " ana@example.com ", SKU: " TSHIRT-L", Sort: newestFirst}, 1, 10); err != nil {
+		t.Fatalf("expected no error, got %v", err)
 	}
+	orderRepo.AssertExpectations(t)
+
+	now := time.Now()
+	later := now.Add(time.Hour)
+	low, high := 10.0, 5.0
+	status, paymentStatus := entity.OrderStatus("lost"), entity.PaymentStatus("owed")
+	for name, search := range map[string]repository.OrderSearch{
+		"sort field":     {Sort: repository.OrderSort{Field: "customer_id"}},
+		"status":         {Status: &status, Sort: newestFirst},
+		"payment status": {PaymentStatus: &paymentStatus, Sort: newestFirst},
+		"date range":     {From: &later, To: &now, Sort: newestFirst},
+		"total range":    {MinTotal: &low, MaxTotal: &high, Sort: newestFirst},
+	} {
+		if _, _, err := uc.SearchOrders(ctx, search, 1, 10); !errors.Is(err, entity.ErrValidation) {
+			t.Errorf("%s: expected a validation error, got %v", name, err)
+		}
+	}
+}
 
-	updated, err := uc.UpdateOrderStatus(context.Background(), oid, entity.Completed)
+func TestUpdateOrderStatus_Success(t *testing.T) {
+	stored := &entity.Order{ID: uuid.New(), Status: entity.Pending}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	updated, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Completed)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -242,90 +507,351 @@ func TestUpdateOrderStatus_Success(t *testing.T) {
 }
 
 func TestUpdateOrderStatus_InvalidTransition(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	oid := uuid.New()
-	orderRepo.orders[oid] = &entity.Order{
-		ID: oid, Status: entity.Completed,
-	}
+	stored := &entity.Order{ID: uuid.New(), Status: entity.Completed}
+	orderRepo := placedOrders(stored)
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
-	_, err := uc.UpdateOrderStatus(context.Background(), oid, entity.Cancelled)
+	_, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Cancelled)
 	if err == nil {
 		t.Error("expected error for invalid transition")
 	}
+	orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_RecordsStockMovements(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	recorder := &mockServices.MockStockRecorder{}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{StockRecorder: recorder}, 0)
+
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 3}}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(recorder.Movements) != 1 {
+		t.Fatalf("expected 1 stock movement, got %d", len(recorder.Movements))
+	}
+	m := recorder.Movements[0]
+	if m.Reason != entity.StockOrder || m.QuantityBefore != 10 || m.QuantityAfter != 7 || m.Delta != -3 {
+		t.Errorf("unexpected movement %+v", m)
+	}
+	if m.Reference != order.ID.String() {
+		t.Errorf("expected reference %s, got %s", order.ID, m.Reference)
+	}
+}
+
+func TestCreateOrder_UsesScheduledPrice(t *testing.T) {
+	pid, vid := uuid.New(), uuid.New()
+	sale, variantSale := 80.0, 60.0
+	book := &mockServices.MockPriceBook{Changes: []*entity.PriceChange{
+		{ProductID: pid, Price: &sale, Scheduled: true, EffectiveFrom: time.Now().Add(-time.Hour)},
+		{ProductID: pid, VariantID: &vid, Price: &variantSale, Scheduled: true, EffectiveFrom: time.Now().Add(-time.Hour)},
+	}}
+
+	product := &entity.Product{ID: pid, Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	override := 120.0
+	variant := &entity.ProductVariant{ID: vid, ProductID: pid, Price_Override: &override, Quantity: 5,
+		Product: &entity.Product{ID: pid, Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(variant), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{PriceBook: book}, 0)
+
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{
+		{ProductID: pid, Quantity: 1},
+		{ProductID: pid, VariantID: &vid, Quantity: 1},
+	}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if order.Products[0].Price != sale || order.Products[1].Price != variantSale {
+		t.Errorf("expected scheduled prices %.2f and %.2f, got %.2f and %.2f", sale, variantSale, order.Products[0].Price, order.Products[1].Price)
+	}
+	if order.TotalPrice != sale+variantSale {
+		t.Errorf("expected total %.2f, got %.2f", sale+variantSale, order.TotalPrice)
+	}
+}
+
+// tieredResolver prices wholesale customers at 70 and lines of 10 or more units at 90
+type tieredResolver struct {
+	wholesale uuid.UUID
+}
+
+func (r tieredResolver) CustomerGroup(ctx context.Context, userID *uuid.UUID) (string, error) {
+	if userID != nil && *userID == r.wholesale {
+		return "wholesale", nil
+	}
+	return entity.RetailGroup, nil
+}
+
+func (r tieredResolver) UnitPrice(ctx context.Context, group string, product *entity.Product, variant *entity.ProductVariant, quantity int) (float64, error) {
+	switch {
+	case group == "wholesale":
+		return 70, nil
+	case quantity >= 10:
+		return 90, nil
+	}
+	return product.Price, nil
+}
+
+func TestCreateOrder_UsesPriceResolver(t *testing.T) {
+	wholesaler := uuid.New()
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 100, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository),
+		&mockServices.MockServices{PriceResolver: tieredResolver{wholesale: wholesaler}}, 0)
+
+	tests := []struct {
+		name     string
+		userID   *uuid.UUID
+		quantity int
+		want     float64
+	}{
+		{"retail", nil, 1, 100},
+		{"quantity break", nil, 10, 90},
+		{"wholesale", &wholesaler, 1, 70},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := uc.CreateOrder(context.Background(), 123, tt.userID, "", []CreateOrderItem{{ProductID: product.ID, Quantity: tt.quantity}}, 0)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if order.Products[0].Price != tt.want {
+				t.Errorf("expected unit price %.2f, got %.2f", tt.want, order.Products[0].Price)
+			}
+			if order.TotalPrice != tt.want*float64(tt.quantity) {
+				t.Errorf("expected total %.2f, got %.2f", tt.want*float64(tt.quantity), order.TotalPrice)
+			}
+		})
+	}
+}
+
+func TestUpdateOrderStatus_CancelRestocks(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 7, Status: entity.ProductActive}
+	stored := &entity.Order{
+		ID: uuid.New(), Status: entity.Pending,
+		Products: []entity.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Price: 100}},
+	}
+	recorder := &mockServices.MockStockRecorder{}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{StockRecorder: recorder}, 0)
+
+	if _, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Cancelled); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if product.Quantity != 10 {
+		t.Errorf("expected stock to be restored to 10, got %d", product.Quantity)
+	}
+	if len(recorder.Movements) != 1 || recorder.Movements[0].Reason != entity.StockCancellation {
+		t.Fatalf("expected 1 cancellation movement, got %v", recorder.Movements)
+	}
+}
+
+func TestUpdateOrderStatus_RefundProcessingOrderRestocks(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 7, Status: entity.ProductActive}
+	stored := &entity.Order{
+		ID: uuid.New(), Status: entity.Processing, PaymentStatus: entity.Paid,
+		Products: []entity.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Price: 100}},
+	}
+	recorder := &mockServices.MockStockRecorder{}
+	services := &mockServices.MockServices{StockRecorder: recorder, OrderWorkflow: entity.NewFulfillmentOrderWorkflow()}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), services, 0)
+
+	if _, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Refunded); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if product.Quantity != 10 {
+		t.Errorf("expected stock to be restored to 10, got %d", product.Quantity)
+	}
+	if len(recorder.Movements) != 1 {
+		t.Fatalf("expected 1 restock movement, got %v", recorder.Movements)
+	}
+}
+
+func TestUpdateOrderStatus_ShippedOrderIsTexted(t *testing.T) {
+	stored := &entity.Order{ID: uuid.New(), Status: entity.Processing, PaymentStatus: entity.Paid}
+	texts := &mockServices.MockTextMessenger{}
+	services := &mockServices.MockServices{TextMessenger: texts, OrderWorkflow: entity.NewFulfillmentOrderWorkflow()}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), services, 0)
+
+	if _, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Shipped); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(texts.Shipped) != 1 || texts.Shipped[0] != stored.ID {
+		t.Errorf("expected the shipping to be texted, got %v", texts.Shipped)
+	}
+
+	if _, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Delivered); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(texts.Shipped) != 1 {
+		t.Errorf("expected only the shipping to be texted, got %v", texts.Shipped)
+	}
+}
+
+func TestUpdateOrderStatus_WorkflowRejectsSkippedStatus(t *testing.T) {
+	stored := &entity.Order{ID: uuid.New(), Status: entity.Processing, PaymentStatus: entity.Paid}
+	services := &mockServices.MockServices{OrderWorkflow: entity.NewFulfillmentOrderWorkflow()}
+	uc := NewUseCase(placedOrders(stored), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), services, 0)
+
+	_, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Delivered)
+	if !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a conflict, got %v", err)
+	}
+}
+
+func TestUpdateOrderStatuses(t *testing.T) {
+	ctx := context.Background()
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 7, Status: entity.ProductActive}
+	unpaid := &entity.Order{ID: uuid.New(), Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	cancellable := &entity.Order{
+		ID: uuid.New(), Status: entity.Pending,
+		Products: []entity.OrderItem{{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Price: 100}},
+	}
+	cancelled := &entity.Order{ID: uuid.New(), Status: entity.Cancelled}
+	stored := map[uuid.UUID]*entity.Order{unpaid.ID: unpaid, cancellable.ID: cancellable, cancelled.ID: cancelled}
+
+	dispatcher := &mockServices.MockWebhookDispatcher{}
+	workflow := entity.NewFulfillmentOrderWorkflow()
+	services := &mockServices.MockServices{WebhookDispatcher: dispatcher, OrderWorkflow: workflow}
+
+	// The repository applies the update to the orders it holds, through the workflow of the use case
+	orderRepo := new(mocks.OrderRepository)
+	update := &entity.BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{cancellable.ID, cancelled.ID, uuid.New()}, Status: entity.Cancelled}
+	results, updated := update.Apply(stored, workflow)
+	orderRepo.On("UpdateStatuses", ctx, update, workflow).Return(results, updated, nil).Once()
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), services, 0)
+
+	report, err := uc.UpdateOrderStatuses(ctx, update)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if report.Count(entity.OrderStatusUpdated) != 1 || report.Count(entity.OrderStatusUnchanged) != 1 || report.Count(entity.OrderStatusNotFound) != 1 {
+		t.Errorf("expected 1 updated, 1 unchanged and 1 not found, got %+v", report.Results)
+	}
+	if product.Quantity != 10 {
+		t.Errorf("expected the cancelled order restocked to 10, got %d", product.Quantity)
+	}
+	if len(dispatcher.Events) != 1 || dispatcher.Events[0] != events.OrderStatusChanged {
+		t.Errorf("expected one order.status_changed, got %v", dispatcher.Events)
+	}
+	orderRepo.AssertExpectations(t)
+
+	update = &entity.BulkOrderStatusUpdate{OrderIDs: []uuid.UUID{unpaid.ID, unpaid.ID}, Status: entity.Cancelled}
+	if _, err := uc.UpdateOrderStatuses(ctx, update); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error for a duplicate order, got %v", err)
+	}
+	orderRepo.AssertNumberOfCalls(t, "UpdateStatuses", 1)
+}
+
+func TestOrderEvents_AreDispatched(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := placedOrders()
+	dispatcher := &mockServices.MockWebhookDispatcher{}
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{WebhookDispatcher: dispatcher}, 0)
+
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	saved(orderRepo, order)
+	if _, err := uc.UpdateOrderStatus(context.Background(), order.ID, entity.Cancelled); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(dispatcher.Events) != 2 || dispatcher.Events[0] != events.OrderCreated || dispatcher.Events[1] != events.OrderStatusChanged {
+		t.Errorf("expected order.created then order.status_changed, got %v", dispatcher.Events)
+	}
 }
 
 func TestCreateOrder_InvalidCustomerID(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
+	uc := NewUseCase(placedOrders(), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	items := []CreateOrderItem{{ProductID: uuid.New(), Quantity: 1}}
-	_, err := uc.CreateOrder(context.Background(), 0, items)
+	_, err := uc.CreateOrder(context.Background(), 0, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error for invalid customer ID")
 	}
 
-	_, err = uc.CreateOrder(context.Background(), -1, items)
+	_, err = uc.CreateOrder(context.Background(), -1, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error for negative customer ID")
 	}
 }
 
 func TestCreateOrder_ProductNotFound(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
+	uc := NewUseCase(placedOrders(), stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	items := []CreateOrderItem{{ProductID: uuid.New(), Quantity: 1}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error for product not found")
 	}
 }
 
-func TestCreateOrder_ProductUpdateError(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	productRepo.updateErr = errors.New("update failed")
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
+func TestCreateOrder_VariantsFollowTheirProduct(t *testing.T) {
+	variantOf := func(product *entity.Product) *entity.ProductVariant {
+		return &entity.ProductVariant{ID: uuid.New(), ProductID: product.ID, SKU: "LAPTOP-16GB", Quantity: 10}
+	}
 
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 10,
+	// A soft-deleted product isn't loaded, nor is it preloaded on its variants
+	deleted := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	variant := variantOf(deleted)
+	variantRepo := stockedVariants(variant)
+	uc := NewUseCase(placedOrders(), stockedProducts(), variantRepo, new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: deleted.ID, VariantID: &variant.ID, Quantity: 1}}, 0)
+	if !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected the variant of a deleted product to be not found, got %v", err)
 	}
+	variantRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	archived := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductArchived}
+	variant = variantOf(archived)
+	uc = NewUseCase(placedOrders(), stockedProducts(archived), stockedVariants(variant), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+	_, err = uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: archived.ID, VariantID: &variant.ID, Quantity: 1}}, 0)
+	if !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected the variant of an archived product to be rejected, got %v", err)
+	}
+
+	limited := &entity.Product{ID: uuid.New(), Name: "Sneaker Drop", Price: 100, Quantity: 10, Status: entity.ProductActive, MaxPerOrder: 1}
+	variant = variantOf(limited)
+	uc = NewUseCase(placedOrders(), stockedProducts(limited), stockedVariants(variant), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+	_, err = uc.CreateOrder(context.Background(), 123, nil, "", []CreateOrderItem{{ProductID: limited.ID, VariantID: &variant.ID, Quantity: 2}}, 0)
+	if !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected the order limit of the product to hold for its variants, got %v", err)
+	}
+}
 
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 2}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+func TestCreateOrder_ProductUpdateError(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	productRepo := new(mocks.ProductRepository)
+	productRepo.On("GetByIDs", mock.Anything, []uuid.UUID{product.ID}).Return([]*entity.Product{product}, nil)
+	productRepo.On("Update", mock.Anything, product).Return(errors.New("update failed"))
+	uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
+
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error from product update")
 	}
 }
 
 func TestCreateOrder_OrderCreateError(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	orderRepo.createErr = errors.New("create failed")
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 10,
-	}
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(errors.New("create failed"))
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 2}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 2}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error from order create")
 	}
 }
 
 func TestListOrders_PaginationDefaults(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetAll", mock.Anything, 1, 10, mock.Anything, mock.Anything).Return(nil, 0, nil)
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	// Test page < 1 defaults to 1
 	_, _, err := uc.ListOrders(context.Background(), 0, 10, nil, nil)
@@ -344,12 +870,13 @@ func TestListOrders_PaginationDefaults(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
+	orderRepo.AssertNumberOfCalls(t, "GetAll", 3)
 }
 
 func TestUpdateOrderStatus_NotFound(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, notFound)
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	_, err := uc.UpdateOrderStatus(context.Background(), uuid.New(), entity.Completed)
 	if err == nil {
@@ -358,53 +885,37 @@ func TestUpdateOrderStatus_NotFound(t *testing.T) {
 }
 
 func TestUpdateOrderStatus_RepositoryError(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	orderRepo.updateErr = errors.New("update failed")
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	oid := uuid.New()
-	orderRepo.orders[oid] = &entity.Order{
-		ID: oid, Status: entity.Pending,
-	}
+	stored := &entity.Order{ID: uuid.New(), Status: entity.Pending}
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetByID", mock.Anything, stored.ID).Return(stored, nil)
+	orderRepo.On("Update", mock.Anything, stored).Return(errors.New("update failed"))
+	uc := NewUseCase(orderRepo, stockedProducts(), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
-	_, err := uc.UpdateOrderStatus(context.Background(), oid, entity.Completed)
+	_, err := uc.UpdateOrderStatus(context.Background(), stored.ID, entity.Completed)
 	if err == nil {
 		t.Error("expected repository error")
 	}
 }
 
 func TestCreateOrder_InvalidOrderItem(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 10,
-	}
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	// Negative quantity should fail order item validation
-	items := []CreateOrderItem{{ProductID: pid, Quantity: -1}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: -1}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error for invalid order item")
 	}
 }
 
 func TestCreateOrder_DecreaseStockError(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 5,
-	}
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 5, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	// Request exactly available amount - should succeed
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 5}}
-	order, err := uc.CreateOrder(context.Background(), 123, items)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 5}}
+	order, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err != nil {
 		t.Fatalf("expected no error for valid order, got %v", err)
 	}
@@ -414,39 +925,156 @@ func TestCreateOrder_DecreaseStockError(t *testing.T) {
 }
 
 func TestCreateOrder_ZeroQuantityItem(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: 100, Quantity: 10,
-	}
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	// Zero quantity should fail validation
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 0}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 0}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	if err == nil {
 		t.Error("expected error for zero quantity item")
 	}
 }
 
 func TestCreateOrder_NilProductID(t *testing.T) {
-	orderRepo := newMockOrderRepo()
-	productRepo := newMockProductRepo()
-	uc := NewUseCase(orderRepo, productRepo, newMockVariantRepo(), &mockServices.MockServices{})
-
-	pid := uuid.New()
-	productRepo.products[pid] = &entity.Product{
-		ID: pid, Name: "Laptop", Price: -10, Quantity: 10,
-	}
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: -10, Quantity: 10, Status: entity.ProductActive}
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{}, 0)
 
 	// This should pass product lookup but could fail other validations
-	items := []CreateOrderItem{{ProductID: pid, Quantity: 1}}
-	_, err := uc.CreateOrder(context.Background(), 123, items)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}
+	_, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 0)
 	// May or may not error depending on validation logic
 	_ = err
 }
 
-var _ repository.OrderRepository = (*mockOrderRepo)(nil)
-var _ repository.ProductRepository = (*mockProductRepo)(nil)
+func TestCreateOrder_HighDemandRequiresPurchaseWindow(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Sneaker Drop", Price: 200, Quantity: 5, HighDemandMode: true, Status: entity.ProductActive}
+	userID := uuid.New()
+	expiresAt := time.Now().Add(time.Minute)
+	entry := &entity.PurchaseQueueEntry{ID: uuid.New(), ProductID: product.ID, UserID: userID, Status: entity.QueueAdmitted, WindowExpiresAt: &expiresAt}
+
+	queueRepo := new(mocks.PurchaseQueueRepository)
+	queueRepo.On("GetActiveEntry", mock.Anything, product.ID, userID).Return(nil, notFound).Once()
+	queueRepo.On("GetActiveEntry", mock.Anything, product.ID, userID).Return(entry, nil)
+	queueRepo.On("Update", mock.Anything, entry).Return(nil).Once()
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), queueRepo, &mockServices.MockServices{}, 0)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}
+
+	if _, err := uc.CreateOrder(context.Background(), 123, &userID, "", items, 0); err == nil {
+		t.Fatal("expected error without a purchase window")
+	}
+
+	if _, err := uc.CreateOrder(context.Background(), 123, &userID, "", items, 0); err != nil {
+		t.Fatalf("expected no error with open window, got %v", err)
+	}
+	if entry.Status != entity.QueueCompleted {
+		t.Errorf("expected queue entry to be completed, got %s", entry.Status)
+	}
+	queueRepo.AssertExpectations(t)
+}
+
+func TestCreateOrder_HighDemandExpiredWindow(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Sneaker Drop", Price: 200, Quantity: 5, HighDemandMode: true, Status: entity.ProductActive}
+	userID := uuid.New()
+	expiredAt := time.Now().Add(-time.Second)
+	entry := &entity.PurchaseQueueEntry{ID: uuid.New(), ProductID: product.ID, UserID: userID, Status: entity.QueueAdmitted, WindowExpiresAt: &expiredAt}
+
+	queueRepo := new(mocks.PurchaseQueueRepository)
+	queueRepo.On("GetActiveEntry", mock.Anything, product.ID, userID).Return(entry, nil)
+	uc := NewUseCase(placedOrders(), stockedProducts(product), stockedVariants(), queueRepo, &mockServices.MockServices{}, 0)
+
+	if _, err := uc.CreateOrder(context.Background(), 123, &userID, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 0); err == nil {
+		t.Error("expected error with expired purchase window")
+	}
+}
+
+func TestCreateOrder_RedeemsLoyaltyPoints(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := placedOrders()
+	loyaltyProgram := &mockServices.MockLoyaltyProgram{Balance: 1000}
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{LoyaltyProgram: loyaltyProgram}, 0)
+	items := []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}
+
+	if _, err := uc.CreateOrder(context.Background(), 123, nil, "", items, 500); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected guests not to redeem points, got %v", err)
+	}
+
+	userID := uuid.New()
+	if _, err := uc.CreateOrder(context.Background(), 123, &userID, "", items, 2000); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected redeeming more than the balance to conflict, got %v", err)
+	}
+	if product.Quantity != 10 {
+		t.Errorf("expected no stock reserved for a rejected redemption, got %d", product.Quantity)
+	}
+
+	order, err := uc.CreateOrder(context.Background(), 123, &userID, "", items, 500)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.TotalPrice != 95 || order.Products[0].ComponentTotal(entity.ComponentDiscount) != -5 {
+		t.Errorf("expected 5.00 off the order, got total %v with %+v", order.TotalPrice, order.Products[0].Components)
+	}
+	if loyaltyProgram.Balance != 500 {
+		t.Errorf("expected 500 points left, got %d", loyaltyProgram.Balance)
+	}
+
+	saved(orderRepo, order)
+	if _, err := uc.UpdateOrderStatus(context.Background(), order.ID, entity.Cancelled); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(loyaltyProgram.Settled) != 1 || loyaltyProgram.Settled[0] != order.ID {
+		t.Errorf("expected the cancelled order to be settled, got %v", loyaltyProgram.Settled)
+	}
+}
+
+// spentLoyalty is a loyalty program whose balance is spent by another order
+// between the check and the redemption
+type spentLoyalty struct {
+	mockServices.MockLoyaltyProgram
+	released []uuid.UUID
+}
+
+func (l *spentLoyalty) Redeem(ctx context.Context, userID, orderID uuid.UUID, points int, subtotal float64) (float64, error) {
+	return 0, entity.ConflictError("Not enough loyalty points")
+}
+
+func (l *spentLoyalty) Release(ctx context.Context, orderID uuid.UUID) error {
+	l.released = append(l.released, orderID)
+	return nil
+}
+
+func TestCreateOrder_FailedRedemptionLeavesStockAlone(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	productRepo := stockedProducts(product)
+	loyaltyProgram := &spentLoyalty{MockLoyaltyProgram: mockServices.MockLoyaltyProgram{Balance: 1000}}
+	uc := NewUseCase(placedOrders(), productRepo, stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{LoyaltyProgram: loyaltyProgram}, 0)
+	userID := uuid.New()
+
+	_, err := uc.CreateOrder(context.Background(), 123, &userID, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 1}}, 500)
+	if !errors.Is(err, entity.ErrConflict) {
+		t.Fatalf("expected the redemption to conflict, got %v", err)
+	}
+	if product.Quantity != 10 {
+		t.Errorf("expected the stock to be untouched, got %d", product.Quantity)
+	}
+	productRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateOrder_FailedCreateGivesBackStockAndPoints(t *testing.T) {
+	product := &entity.Product{ID: uuid.New(), Name: "Laptop", Price: 100, Quantity: 10, Status: entity.ProductActive}
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Order")).Return(errors.New("db down"))
+	loyaltyProgram := &spentLoyalty{}
+	uc := NewUseCase(orderRepo, stockedProducts(product), stockedVariants(), new(mocks.PurchaseQueueRepository), &mockServices.MockServices{LoyaltyProgram: loyaltyProgram}, 0)
+	userID := uuid.New()
+
+	if _, err := uc.CreateOrder(context.Background(), 123, &userID, "", []CreateOrderItem{{ProductID: product.ID, Quantity: 3}}, 0); err == nil {
+		t.Fatal("expected the failed save to fail the order")
+	}
+	if product.Quantity != 10 {
+		t.Errorf("expected the stock to be put back, got %d", product.Quantity)
+	}
+	if len(loyaltyProgram.released) != 1 {
+		t.Errorf("expected the points of the order to be released, got %v", loyaltyProgram.released)
+	}
+}
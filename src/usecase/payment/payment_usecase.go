@@ -11,72 +11,158 @@ import (
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/capture"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/usecase/loyalty"
 )
 
+//go:generate go run ../../internal/tools/mockgen -interface PaymentService -out ../../internal/testing/servicemocks
+
 type PaymentService interface {
 	ProcessWebhook(ctx context.Context, req *entity.PaymentWebhookRequest) error
-	GetWebhookHistory(ctx context.Context, orderID string) ([]entity.WebhookLog, error)
+	// CapturePayment captures the authorized payment of a pending order
+	// through the payment provider
+	CapturePayment(ctx context.Context, orderID, userID uuid.UUID) (*entity.Order, error)
+	GetWebhookHistory(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error)
+	GetCustomerPaymentHistory(ctx context.Context, orderID, userID uuid.UUID, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error)
+
+	// RetryFailedWebhooks reapplies up to limit failed webhooks whose retry is
+	// due and returns how many of them succeeded
+	RetryFailedWebhooks(ctx context.Context, limit int) (int, error)
+
+	// ListWebhooks returns the webhook logs of every order, newest first
+	ListWebhooks(ctx context.Context, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error)
+	// ReplayWebhook applies a failed or stuck webhook again right away
+	ReplayWebhook(ctx context.Context, id, userID uuid.UUID) (*entity.WebhookLog, error)
+	// ResolveWebhook marks a webhook handled without applying it
+	ResolveWebhook(ctx context.Context, id, userID uuid.UUID, note string) (*entity.WebhookLog, error)
+
+	// DeadLetterWebhook keeps a webhook that was rejected, with the body as
+	// its source sent it, for an admin to reprocess or discard
+	DeadLetterWebhook(ctx context.Context, source string, payload []byte, reason error)
+	// ListDeadLetters returns the dead-lettered webhooks, most recently failed first
+	ListDeadLetters(ctx context.Context, filters repository.DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error)
+	// ReprocessDeadLetter parses and processes a dead-lettered webhook again
+	ReprocessDeadLetter(ctx context.Context, id, userID uuid.UUID, parse WebhookParser) (*entity.DeadLetterWebhook, error)
+	// DiscardDeadLetter gives up on a dead-lettered webhook
+	DiscardDeadLetter(ctx context.Context, id, userID uuid.UUID, note string) (*entity.DeadLetterWebhook, error)
+
+	// UseWebhookNonce claims the nonce of a signed webhook, a nonce that was
+	// already used is a conflict
+	UseWebhookNonce(ctx context.Context, nonce string, signedAt time.Time) error
+	// PurgeExpiredNonces deletes the nonces whose webhooks are too old to be
+	// accepted anyway and returns how many were deleted
+	PurgeExpiredNonces(ctx context.Context) (int64, error)
+}
+
+// WebhookParser turns the body a source sent into a payment webhook, checked
+// the way it is when received. A nil webhook is an event that isn't about the
+// payment of an order.
+type WebhookParser func(ctx context.Context, source string, payload []byte) (*entity.PaymentWebhookRequest, error)
+
+// ErrWebhookRejected is matched by the errors of webhooks ProcessWebhook
+// rejected before logging them, for an order it can't find or take them, or
+// with fields it can't use. They are dead-lettered, sending them again won't
+// help until something is fixed.
+var ErrWebhookRejected = errors.New("webhook rejected")
+
+// rejectedError marks an error as a rejection, keeping its message and kind
+type rejectedError struct {
+	error
+}
+
+func (e rejectedError) Unwrap() []error {
+	return []error{e.error, ErrWebhookRejected}
 }
 
+func rejected(err error) error {
+	return rejectedError{err}
+}
+
+// maxWebhookAttempts is how many times a webhook is applied before it is left failed for good
+const maxWebhookAttempts = 6
+
+// retryBaseDelay is the wait before the first retry, doubled after each failed attempt
+const retryBaseDelay = 5 * time.Minute
+
+// WebhookTolerance is how far the signed timestamp of a webhook may be from
+// now, either way, for the webhook to be accepted
+const WebhookTolerance = 5 * time.Minute
+
+// stuckAfter is how long a webhook stays processing before it is taken as
+// stuck; a younger one may still be being applied
+const stuckAfter = 5 * time.Minute
+
 type Services interface {
 	GetAuditService() audit.AuditService
+	GetOrderWorkflow() *entity.OrderWorkflow
+	GetWebhookDispatcher() events.Dispatcher
+	GetLoyaltyProgram() loyalty.Program
 }
 
 type PaymentUseCase struct {
-	orderRepo   repository.OrderRepository
-	webhookRepo repository.WebhookRepository
-	services    Services
+	orderRepo      repository.OrderRepository
+	webhookRepo    repository.WebhookRepository
+	deadLetterRepo repository.DeadLetterRepository
+	nonceRepo      repository.WebhookNonceRepository
+	customerRepo   repository.CustomerProfileRepository
+	gateway        capture.Gateway
+	services       Services
 }
 
 func NewPaymentUseCase(
 	orderRepo repository.OrderRepository,
 	webhookRepo repository.WebhookRepository,
+	deadLetterRepo repository.DeadLetterRepository,
+	nonceRepo repository.WebhookNonceRepository,
+	customerRepo repository.CustomerProfileRepository,
+	gateway capture.Gateway,
 	services Services,
 ) *PaymentUseCase {
 	return &PaymentUseCase{
-		orderRepo:   orderRepo,
-		webhookRepo: webhookRepo,
-		services:    services,
+		orderRepo:      orderRepo,
+		webhookRepo:    webhookRepo,
+		deadLetterRepo: deadLetterRepo,
+		nonceRepo:      nonceRepo,
+		customerRepo:   customerRepo,
+		gateway:        gateway,
+		services:       services,
 	}
 }
 
 func (uc *PaymentUseCase) ProcessWebhook(ctx context.Context, req *entity.PaymentWebhookRequest) error {
 	if req.TransactionID == "" {
-		return errors.New("transaction_id is required")
+		return rejected(entity.ValidationError("transaction_id is required"))
 	}
 
-	existingLogs, err := uc.webhookRepo.GetByOrderID(ctx, req.OrderID)
-	if err == nil {
-		for _, log := range existingLogs {
-			if log.TransactionID == req.TransactionID {
-				return nil
-			}
-		}
+	_, existing, err := uc.webhookRepo.GetByOrderID(ctx, req.OrderID, repository.WebhookLogFilters{TransactionID: &req.TransactionID}, 1, 1)
+	if err == nil && existing > 0 {
+		return nil
 	}
 
 	orderID, err := uuid.Parse(req.OrderID)
 	if err != nil {
-		return errors.New("invalid order_id format")
+		return rejected(entity.ValidationError("invalid order_id format"))
 	}
 
 	order, err := uc.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
-		return errors.New("order not found")
+		return rejected(entity.NotFoundError("order not found"))
 	}
 
 	if order.Status != entity.Pending {
-		return fmt.Errorf("order status must be 'pending' to process payment, current status: %s", order.Status)
+		return rejected(entity.ConflictError(fmt.Sprintf("order status must be 'pending' to process payment, current status: %s", order.Status)))
 	}
 
-	if req.PaymentStatus != entity.Paid && req.PaymentStatus != entity.Failed {
-		return errors.New("payment_status must be either 'paid' or 'failed'")
+	if req.PaymentStatus != entity.Authorized && req.PaymentStatus != entity.Paid && req.PaymentStatus != entity.Failed {
+		return rejected(entity.ValidationError("payment_status must be 'authorized', 'paid' or 'failed'"))
 	}
 
 	// Create webhook log first with pending status
 	rawPayload, _ := json.Marshal(req)
 	now := time.Now()
 	webhookLog := &entity.WebhookLog{
-		ID:            uuid.New(),
+		ID:            entity.NewID(),
 		OrderID:       orderID,
 		TransactionID: req.TransactionID,
 		PaymentStatus: req.PaymentStatus,
@@ -90,18 +176,30 @@ func (uc *PaymentUseCase) ProcessWebhook(ctx context.Context, req *entity.Paymen
 		return fmt.Errorf("Failed to create webhook log: %w", err)
 	}
 
-	order.PaymentStatus = req.PaymentStatus
+	return uc.apply(ctx, order, req, webhookLog)
+}
+
+// apply updates the order with the webhook's payment status. When the order
+// can't be saved the webhook log is marked failed and scheduled for a retry;
+// a payment the order can't take, such as more than its balance, is failed
+// for good.
+func (uc *PaymentUseCase) apply(ctx context.Context, order *entity.Order, req *entity.PaymentWebhookRequest, webhookLog *entity.WebhookLog) error {
+	now := time.Now()
+	originalPayment, originalStatus := order.PaymentStatus, order.Status
+	before := map[string]interface{}{"payment_status": order.PaymentStatus, "status": order.Status, "amount_paid": order.AmountPaid}
 
-	if req.PaymentStatus == entity.Paid {
-		order.Status = entity.Completed
+	if err := uc.applyPayment(order, req); err != nil {
+		webhookLog.Status = entity.WebhookStatusFailed
+		webhookLog.NextRetryAt = nil
+		uc.webhookRepo.Update(ctx, webhookLog)
+		return err
 	}
 
 	if err := uc.orderRepo.Update(ctx, order); err != nil {
 		// In case something wrong happened, mark webhook as failed
 		webhookLog.Status = entity.WebhookStatusFailed
 		webhookLog.RetryCount++
-		nextRetry := time.Now().Add(5 * time.Minute)
-		webhookLog.NextRetryAt = &nextRetry
+		webhookLog.NextRetryAt = nextRetryAt(webhookLog.RetryCount, now)
 		uc.webhookRepo.Update(ctx, webhookLog)
 		return fmt.Errorf("Failed to update order: %w", err)
 	}
@@ -109,18 +207,395 @@ func (uc *PaymentUseCase) ProcessWebhook(ctx context.Context, req *entity.Paymen
 	// Mark webhook as completed
 	webhookLog.Status = entity.WebhookStatusCompleted
 	webhookLog.ProcessedAt = &now
+	webhookLog.NextRetryAt = nil
 	if err := uc.webhookRepo.Update(ctx, webhookLog); err != nil {
 		fmt.Printf("Failed to update webhook log status: %v\n", err)
 	}
 
+	// Orders completed by their payment earn loyalty points
+	if order.Status != originalStatus {
+		if err := uc.services.GetLoyaltyProgram().Settle(ctx, order); err != nil {
+			fmt.Printf("Failed to settle the loyalty points of order %s: %v\n", order.ID, err)
+		}
+	}
+
+	// Failed payments count towards the customer's risk score
+	if req.PaymentStatus == entity.Failed && order.UserID != nil {
+		riskEvent := &entity.CustomerRiskEvent{
+			ID:        entity.NewID(),
+			UserID:    *order.UserID,
+			Type:      entity.RiskFailedPayment,
+			Reference: req.TransactionID,
+			CreatedAt: now,
+		}
+		if err := uc.customerRepo.AddRiskEvent(ctx, riskEvent); err != nil {
+			fmt.Printf("Failed to record failed payment risk event: %v\n", err)
+		}
+	}
+
 	// Log payment webhook update
-	uc.services.GetAuditService().LogChange(ctx, nil, "PAYMENT_WEBHOOK", "Order", orderID,
-		map[string]interface{}{"payment_status": entity.Unpaid, "status": entity.Pending},
-		map[string]interface{}{"payment_status": req.PaymentStatus, "status": order.Status, "transaction_id": req.TransactionID})
+	uc.services.GetAuditService().LogChange(ctx, nil, "PAYMENT_WEBHOOK", "Order", order.ID, before,
+		map[string]interface{}{"payment_status": order.PaymentStatus, "status": order.Status, "amount_paid": order.AmountPaid, "transaction_id": req.TransactionID})
+
+	dispatcher := uc.services.GetWebhookDispatcher()
+	if order.PaymentStatus == entity.Paid && originalPayment != entity.Paid {
+		dispatcher.Dispatch(ctx, events.OrderPaid, events.NewOrderData(order))
+	}
+	if order.Status != originalStatus {
+		dispatcher.Dispatch(ctx, events.OrderStatusChanged, events.NewOrderData(order))
+	}
+
+	return nil
+}
+
+// applyPayment moves the payment of the order as the webhook says. A paid
+// event captures its amount, or the whole balance without one, and once
+// nothing is left to pay the order moves on as the workflow says, completed
+// or processing.
+func (uc *PaymentUseCase) applyPayment(order *entity.Order, req *entity.PaymentWebhookRequest) error {
+	switch req.PaymentStatus {
+	case entity.Authorized:
+		return order.AuthorizePayment()
+	case entity.Failed:
+		return order.FailPayment()
+	}
+
+	amount := req.Amount
+	if amount == 0 {
+		amount = order.Balance()
+	}
+	if err := order.CapturePayment(amount); err != nil {
+		return err
+	}
+	if order.PaymentStatus != entity.Paid {
+		return nil
+	}
+
+	workflow := uc.services.GetOrderWorkflow()
+	return order.UpdateStatus(workflow, workflow.PaidStatus())
+}
+
+func (uc *PaymentUseCase) RetryFailedWebhooks(ctx context.Context, limit int) (int, error) {
+	logs, err := uc.webhookRepo.ListDueRetries(ctx, time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for i := range logs {
+		if err := ctx.Err(); err != nil {
+			return succeeded, err
+		}
+		if uc.retry(ctx, &logs[i]) == nil {
+			succeeded++
+		}
+	}
+
+	return succeeded, nil
+}
+
+// retry applies a failed webhook again from its stored payload. Webhooks that
+// can no longer apply, such as for an order that is not pending anymore, are
+// not retried again.
+func (uc *PaymentUseCase) retry(ctx context.Context, webhookLog *entity.WebhookLog) error {
+	giveUp := func(err error) error {
+		webhookLog.NextRetryAt = nil
+		uc.webhookRepo.Update(ctx, webhookLog)
+		return err
+	}
+
+	var req entity.PaymentWebhookRequest
+	if err := json.Unmarshal([]byte(webhookLog.RawPayload), &req); err != nil {
+		return giveUp(fmt.Errorf("Invalid webhook payload: %w", err))
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, webhookLog.OrderID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return giveUp(err)
+	}
+	if err != nil {
+		// Left due, so the next run tries again
+		return err
+	}
+	if order.Status != entity.Pending {
+		return giveUp(entity.ConflictError(fmt.Sprintf("order status must be 'pending' to process payment, current status: %s", order.Status)))
+	}
+
+	return uc.apply(ctx, order, &req, webhookLog)
+}
+
+// nextRetryAt schedules the next attempt with exponential backoff, or returns
+// nil once the webhook ran out of attempts
+func nextRetryAt(attempts int, now time.Time) *time.Time {
+	if attempts >= maxWebhookAttempts {
+		return nil
+	}
+	next := now.Add(retryBaseDelay << (attempts - 1))
+	return &next
+}
+
+// CapturePayment captures the balance of a pending order whose payment was
+// authorized, through the payment provider. The capture is then applied as a
+// paid webhook whose transaction_id is the provider's reference, so the order
+// moves on as the workflow says, and the provider's own webhook for the
+// capture is taken as a duplicate. Capturing again after a failure is safe:
+// the provider captures an order once.
+func (uc *PaymentUseCase) CapturePayment(ctx context.Context, orderID, userID uuid.UUID) (*entity.Order, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, entity.NotFoundError("Order not found")
+	}
+	if order.Status != entity.Pending || order.PaymentStatus != entity.Authorized {
+		return nil, entity.ConflictError(fmt.Sprintf("only authorized payments of pending orders can be captured, order is %s with payment %s", order.Status, order.PaymentStatus))
+	}
+
+	authorization, err := uc.authorization(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find the authorization: %w", err)
+	}
+	amount := order.Balance()
+	reference, err := uc.gateway.Capture(ctx, capture.Request{
+		OrderID:        order.ID,
+		TransactionID:  authorization,
+		Amount:         amount,
+		IdempotencyKey: "capture-" + order.ID.String(),
+	})
+	if err != nil {
+		uc.services.GetAuditService().LogChange(ctx, &userID, "CAPTURE_PAYMENT_FAILED", "Order", order.ID, nil,
+			map[string]interface{}{"amount": amount, "error": err.Error()})
+		return nil, fmt.Errorf("Failed to capture payment: %w", err)
+	}
+
+	req := &entity.PaymentWebhookRequest{
+		OrderID:       order.ID.String(),
+		TransactionID: reference,
+		PaymentStatus: entity.Paid,
+		Amount:        amount,
+	}
+	if err := uc.ProcessWebhook(ctx, req); err != nil {
+		return nil, err
+	}
 
+	uc.services.GetAuditService().LogChange(ctx, &userID, "CAPTURE_PAYMENT", "Order", order.ID,
+		map[string]interface{}{"payment_status": entity.Authorized},
+		map[string]interface{}{"amount": amount, "transaction_id": reference})
+
+	return uc.orderRepo.GetByID(ctx, order.ID)
+}
+
+// authorization returns the transaction that authorized the payment of the
+// order, empty when its webhook is gone
+func (uc *PaymentUseCase) authorization(ctx context.Context, orderID uuid.UUID) (string, error) {
+	authorized, completed := entity.Authorized, entity.WebhookStatusCompleted
+	logs, _, err := uc.webhookRepo.GetByOrderID(ctx, orderID.String(), repository.WebhookLogFilters{
+		PaymentStatus: &authorized,
+		Status:        &completed,
+	}, 1, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(logs) == 0 {
+		return "", nil
+	}
+	return logs[0].TransactionID, nil
+}
+
+// ListWebhooks returns the webhook logs of every order (admin view)
+func (uc *PaymentUseCase) ListWebhooks(ctx context.Context, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	page, pageSize = normalizePagination(page, pageSize)
+	return uc.webhookRepo.List(ctx, filters, page, pageSize)
+}
+
+// ReplayWebhook applies a webhook from its stored payload, without waiting
+// for its next retry. The log is returned with the outcome even when applying
+// it fails.
+func (uc *PaymentUseCase) ReplayWebhook(ctx context.Context, id, userID uuid.UUID) (*entity.WebhookLog, error) {
+	webhookLog, err := uc.recoverable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": webhookLog.Status, "retry_count": webhookLog.RetryCount}
+
+	err = uc.retry(ctx, webhookLog)
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "REPLAY_WEBHOOK", "WebhookLog", webhookLog.ID, before,
+		map[string]interface{}{"status": webhookLog.Status, "retry_count": webhookLog.RetryCount})
+
+	return webhookLog, err
+}
+
+// ResolveWebhook marks a webhook an admin handled by hand, so it is not
+// retried anymore. The note goes to the audit log.
+func (uc *PaymentUseCase) ResolveWebhook(ctx context.Context, id, userID uuid.UUID, note string) (*entity.WebhookLog, error) {
+	webhookLog, err := uc.recoverable(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": webhookLog.Status}
+
+	now := time.Now()
+	webhookLog.Status = entity.WebhookStatusResolved
+	webhookLog.ProcessedAt = &now
+	webhookLog.NextRetryAt = nil
+	if err := uc.webhookRepo.Update(ctx, webhookLog); err != nil {
+		return nil, fmt.Errorf("Failed to update webhook log: %w", err)
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "RESOLVE_WEBHOOK", "WebhookLog", webhookLog.ID, before,
+		map[string]interface{}{"status": webhookLog.Status, "note": note})
+
+	return webhookLog, nil
+}
+
+// recoverable returns a webhook that can be replayed or resolved: failed,
+// pending, or processing for longer than stuckAfter
+func (uc *PaymentUseCase) recoverable(ctx context.Context, id uuid.UUID) (*entity.WebhookLog, error) {
+	webhookLog, err := uc.webhookRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, entity.NotFoundError("Webhook not found")
+	}
+
+	switch webhookLog.Status {
+	case entity.WebhookStatusCompleted, entity.WebhookStatusResolved:
+		return nil, entity.ConflictError(fmt.Sprintf("webhook is already %s", webhookLog.Status))
+	case entity.WebhookStatusProcessing:
+		if time.Since(webhookLog.CreatedAt) < stuckAfter {
+			return nil, entity.ConflictError("webhook is still being processed")
+		}
+	}
+	return webhookLog, nil
+}
+
+// DeadLetterWebhook records the webhook, or another attempt when its source
+// already sent the same body. Failing to record it is only reported, the
+// webhook is rejected either way.
+func (uc *PaymentUseCase) DeadLetterWebhook(ctx context.Context, source string, payload []byte, reason error) {
+	webhook := entity.NewDeadLetterWebhook(source, payload, reason.Error(), time.Now())
+	if err := uc.deadLetterRepo.Record(ctx, webhook); err != nil {
+		fmt.Printf("Failed to dead-letter %s webhook: %v\n", source, err)
+	}
+}
+
+// ListDeadLetters returns the dead-lettered webhooks (admin view)
+func (uc *PaymentUseCase) ListDeadLetters(ctx context.Context, filters repository.DeadLetterFilters, page, pageSize int) ([]entity.DeadLetterWebhook, int, error) {
+	page, pageSize = normalizePagination(page, pageSize)
+	return uc.deadLetterRepo.List(ctx, filters, page, pageSize)
+}
+
+// ReprocessDeadLetter runs a dead-lettered webhook through parse and
+// ProcessWebhook again, once whatever rejected it is fixed. When parse or
+// ProcessWebhook rejects it again it counts another attempt with the new
+// reason and stays pending; the error is returned with it. An event that
+// turns out not to be about a payment is reprocessed with nothing to apply.
+// A webhook that gets logged but fails to apply is left pending with the
+// error: the webhook log retries it from there, and reprocessing it again
+// finds it logged and marks it reprocessed.
+func (uc *PaymentUseCase) ReprocessDeadLetter(ctx context.Context, id, userID uuid.UUID, parse WebhookParser) (*entity.DeadLetterWebhook, error) {
+	webhook, err := uc.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := webhook.CanReprocess(); err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": webhook.Status, "attempts": webhook.Attempts}
+
+	req, err := parse(ctx, webhook.Source, []byte(webhook.RawPayload))
+	if err != nil && !errors.Is(err, entity.ErrValidation) {
+		return nil, err
+	}
+	if err == nil && req != nil {
+		err = uc.ProcessWebhook(ctx, req)
+		if err != nil && !errors.Is(err, ErrWebhookRejected) {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err != nil {
+		webhook.Fail(err.Error(), now)
+	} else if err := webhook.Reprocessed(userID, now); err != nil {
+		return nil, err
+	}
+	if updateErr := uc.deadLetterRepo.Update(ctx, webhook); updateErr != nil {
+		return nil, fmt.Errorf("Failed to update dead-lettered webhook: %w", updateErr)
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "REPROCESS_DEAD_LETTER", "DeadLetterWebhook", webhook.ID, before,
+		map[string]interface{}{"status": webhook.Status, "attempts": webhook.Attempts})
+
+	return webhook, err
+}
+
+// DiscardDeadLetter gives up on a dead-lettered webhook without processing
+// it. The note goes to the audit log.
+func (uc *PaymentUseCase) DiscardDeadLetter(ctx context.Context, id, userID uuid.UUID, note string) (*entity.DeadLetterWebhook, error) {
+	webhook, err := uc.deadLetterRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]interface{}{"status": webhook.Status}
+
+	if err := webhook.Discard(userID, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := uc.deadLetterRepo.Update(ctx, webhook); err != nil {
+		return nil, fmt.Errorf("Failed to update dead-lettered webhook: %w", err)
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "DISCARD_DEAD_LETTER", "DeadLetterWebhook", webhook.ID, before,
+		map[string]interface{}{"status": webhook.Status, "note": note})
+
+	return webhook, nil
+}
+
+// UseWebhookNonce records the nonce a webhook was signed with. It is kept
+// until the timestamp signed with it falls out of WebhookTolerance, after
+// which the signature is rejected for its timestamp anyway.
+func (uc *PaymentUseCase) UseWebhookNonce(ctx context.Context, nonce string, signedAt time.Time) error {
+	claimed, err := uc.nonceRepo.Claim(ctx, &entity.WebhookNonce{Nonce: nonce, ExpiresAt: signedAt.Add(WebhookTolerance)}, time.Now())
+	if err != nil {
+		return fmt.Errorf("Failed to record webhook nonce: %w", err)
+	}
+	if !claimed {
+		return entity.ConflictError("nonce was already used")
+	}
 	return nil
 }
 
-func (uc *PaymentUseCase) GetWebhookHistory(ctx context.Context, orderID string) ([]entity.WebhookLog, error) {
-	return uc.webhookRepo.GetByOrderID(ctx, orderID)
+// PurgeExpiredNonces deletes the nonces of webhooks past WebhookTolerance
+func (uc *PaymentUseCase) PurgeExpiredNonces(ctx context.Context) (int64, error) {
+	return uc.nonceRepo.DeleteExpired(ctx, time.Now())
+}
+
+// GetWebhookHistory returns the full webhook logs for an order (admin view)
+func (uc *PaymentUseCase) GetWebhookHistory(ctx context.Context, orderID string, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	page, pageSize = normalizePagination(page, pageSize)
+	return uc.webhookRepo.GetByOrderID(ctx, orderID, filters, page, pageSize)
+}
+
+// GetCustomerPaymentHistory returns the webhook logs for an order only if it belongs to the given user.
+// Orders owned by someone else are reported as not found so their existence is not leaked.
+func (uc *PaymentUseCase) GetCustomerPaymentHistory(ctx context.Context, orderID, userID uuid.UUID, filters repository.WebhookLogFilters, page, pageSize int) ([]entity.WebhookLog, int, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, 0, entity.NotFoundError("Order not found")
+	}
+
+	if !order.IsOwnedBy(userID) {
+		return nil, 0, entity.NotFoundError("Order not found")
+	}
+
+	page, pageSize = normalizePagination(page, pageSize)
+	return uc.webhookRepo.GetByOrderID(ctx, orderID.String(), filters, page, pageSize)
+}
+
+func normalizePagination(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return page, pageSize
 }
@@ -0,0 +1,587 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/capture"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// paymentMocks are the mocked repositories and gateway of a payment use case
+type paymentMocks struct {
+	orders      *mocks.OrderRepository
+	webhooks    *mocks.WebhookRepository
+	deadLetters *mocks.DeadLetterRepository
+	nonces      *mocks.WebhookNonceRepository
+	customers   *mocks.CustomerProfileRepository
+	gateway     *mocks.CaptureGateway
+}
+
+func newTestUseCase(services *mockServices.MockServices) (*PaymentUseCase, *paymentMocks) {
+	m := &paymentMocks{
+		orders:      new(mocks.OrderRepository),
+		webhooks:    new(mocks.WebhookRepository),
+		deadLetters: new(mocks.DeadLetterRepository),
+		nonces:      new(mocks.WebhookNonceRepository),
+		customers:   new(mocks.CustomerProfileRepository),
+		gateway:     new(mocks.CaptureGateway),
+	}
+	return NewPaymentUseCase(m.orders, m.webhooks, m.deadLetters, m.nonces, m.customers, m.gateway, services), m
+}
+
+// stored lets the order repository find the order and save it
+func (m *paymentMocks) stored(order *entity.Order) {
+	m.orders.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	m.orders.On("Update", mock.Anything, order).Return(nil)
+}
+
+// logsWebhooks takes every transaction as new and keeps the webhook logs
+// created for them, which are saved as they are updated
+func (m *paymentMocks) logsWebhooks() *[]*entity.WebhookLog {
+	var logs []*entity.WebhookLog
+	m.webhooks.On("GetByOrderID", mock.Anything, mock.Anything, transaction(), 1, 1).Return(nil, 0, nil)
+	m.webhooks.On("Create", mock.Anything, mock.AnythingOfType("*entity.WebhookLog")).Return(nil).
+		Run(func(args mock.Arguments) { logs = append(logs, args.Get(1).(*entity.WebhookLog)) })
+	m.webhooks.On("Update", mock.Anything, mock.AnythingOfType("*entity.WebhookLog")).Return(nil)
+	return &logs
+}
+
+// transaction matches the filters of a lookup by transaction, of the
+// transaction with the ID when one is given
+func transaction(id ...string) interface{} {
+	return mock.MatchedBy(func(filters repository.WebhookLogFilters) bool {
+		return filters.TransactionID != nil && (len(id) == 0 || *filters.TransactionID == id[0])
+	})
+}
+
+func paidPayload(orderID uuid.UUID) string {
+	return `{"order_id":"` + orderID.String() + `","transaction_id":"txn-1","payment_status":"paid"}`
+}
+
+func TestGetCustomerPaymentHistory_Owner(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	userID := uuid.New()
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, UserID: &userID}
+	m.stored(order)
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), repository.WebhookLogFilters{}, 1, 10).
+		Return([]entity.WebhookLog{{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-1"}}, 1, nil)
+
+	logs, total, err := uc.GetCustomerPaymentHistory(context.Background(), order.ID, userID, repository.WebhookLogFilters{}, 1, 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 1 || len(logs) != 1 {
+		t.Errorf("expected 1 log, got %d", total)
+	}
+}
+
+func TestGetCustomerPaymentHistory_NotOwner(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	ownerID := uuid.New()
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, UserID: &ownerID}
+	m.stored(order)
+
+	_, _, err := uc.GetCustomerPaymentHistory(context.Background(), order.ID, uuid.New(), repository.WebhookLogFilters{}, 1, 10)
+	if err == nil {
+		t.Error("expected error for order owned by another user")
+	}
+	m.webhooks.AssertNotCalled(t, "GetByOrderID", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetCustomerPaymentHistory_LegacyOrderWithoutOwner(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1}
+	m.stored(order)
+
+	_, _, err := uc.GetCustomerPaymentHistory(context.Background(), order.ID, uuid.New(), repository.WebhookLogFilters{}, 1, 10)
+	if err == nil {
+		t.Error("expected error for order without owner")
+	}
+}
+
+func TestProcessWebhook_DuplicateTransactionIsIgnored(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), transaction("txn-1"), 1, 1).Return(nil, 0, nil).Once()
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), transaction("txn-1"), 1, 1).
+		Return([]entity.WebhookLog{{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-1"}}, 1, nil)
+	m.webhooks.On("Create", mock.Anything, mock.AnythingOfType("*entity.WebhookLog")).Return(nil).Once()
+	m.webhooks.On("Update", mock.Anything, mock.AnythingOfType("*entity.WebhookLog")).Return(nil)
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Paid}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected duplicate to be ignored, got %v", err)
+	}
+	m.webhooks.AssertNumberOfCalls(t, "Create", 1)
+	m.orders.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestProcessWebhook_PaidOrderFollowsWorkflow(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{OrderWorkflow: entity.NewFulfillmentOrderWorkflow()})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	m.logsWebhooks()
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Paid}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.PaymentStatus != entity.Paid || order.Status != entity.Processing {
+		t.Errorf("expected the order to be paid and processing, got %s/%s", order.PaymentStatus, order.Status)
+	}
+}
+
+func TestProcessWebhook_PartialPaymentsKeepOrderPending(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, TotalPrice: 100, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	m.logsWebhooks()
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Paid, Amount: 40}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.PaymentStatus != entity.PartiallyPaid || order.Status != entity.Pending || order.Balance() != 60 {
+		t.Fatalf("expected a pending order partially paid with 60 left, got %s/%s with %.2f left", order.PaymentStatus, order.Status, order.Balance())
+	}
+
+	req = &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-2", PaymentStatus: entity.Paid}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if order.PaymentStatus != entity.Paid || order.Status != entity.Completed || order.AmountPaid != 100 {
+		t.Errorf("expected the rest of the balance to complete the order, got %s/%s with %.2f paid", order.PaymentStatus, order.Status, order.AmountPaid)
+	}
+}
+
+func TestProcessWebhook_PaymentOverBalanceIsRejected(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, TotalPrice: 100, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	logs := m.logsWebhooks()
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Paid, Amount: 150}
+	err := uc.ProcessWebhook(context.Background(), req)
+	if !errors.Is(err, entity.ErrValidation) {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	if log := (*logs)[0]; log.Status != entity.WebhookStatusFailed || log.NextRetryAt != nil {
+		t.Errorf("expected the log failed without a retry, got %+v", log)
+	}
+	m.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestProcessWebhook_FailedPaymentRecordsRiskEvent(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	userID := uuid.New()
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, UserID: &userID, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	m.logsWebhooks()
+	// The risk event is recorded for the order owner
+	m.customers.On("AddRiskEvent", mock.Anything, mock.MatchedBy(func(event *entity.CustomerRiskEvent) bool {
+		return event.Type == entity.RiskFailedPayment && event.UserID == userID && event.Reference == "txn-1"
+	})).Return(nil).Once()
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Failed}
+	if err := uc.ProcessWebhook(context.Background(), req); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	m.customers.AssertExpectations(t)
+}
+
+func TestProcessWebhook_FailedUpdateSchedulesRetry(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.orders.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	m.orders.On("Update", mock.Anything, order).Return(errors.New("db down"))
+	logs := m.logsWebhooks()
+
+	req := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "txn-1", PaymentStatus: entity.Paid}
+	if err := uc.ProcessWebhook(context.Background(), req); err == nil {
+		t.Fatal("expected the update error to be returned")
+	}
+
+	log := (*logs)[0]
+	if log.Status != entity.WebhookStatusFailed || log.RetryCount != 1 || log.NextRetryAt == nil {
+		t.Fatalf("expected a failed log scheduled for retry, got %+v", log)
+	}
+	if wait := time.Until(*log.NextRetryAt); wait < 4*time.Minute || wait > retryBaseDelay {
+		t.Errorf("expected the first retry in about %s, got %s", retryBaseDelay, wait)
+	}
+}
+
+func TestRetryFailedWebhooks_AppliesDueWebhooks(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+
+	due := time.Now().Add(-time.Minute)
+	logs := []entity.WebhookLog{
+		{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-1", PaymentStatus: entity.Paid, Status: entity.WebhookStatusFailed, RetryCount: 1, NextRetryAt: &due,
+			RawPayload: paidPayload(order.ID)},
+	}
+	m.webhooks.On("ListDueRetries", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return(logs, nil)
+	m.webhooks.On("Update", mock.Anything, &logs[0]).Return(nil)
+
+	succeeded, err := uc.RetryFailedWebhooks(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if succeeded != 1 {
+		t.Errorf("expected 1 webhook to succeed, got %d", succeeded)
+	}
+
+	if order.PaymentStatus != entity.Paid || order.Status != entity.Completed {
+		t.Errorf("expected the order to be paid and completed, got %s/%s", order.PaymentStatus, order.Status)
+	}
+	if log := logs[0]; log.Status != entity.WebhookStatusCompleted || log.NextRetryAt != nil || log.ProcessedAt == nil {
+		t.Errorf("expected the log to be completed, got %+v", log)
+	}
+}
+
+func TestRetryFailedWebhooks_GivesUpWhenOrderIsNoLongerPending(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Cancelled, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+
+	due := time.Now().Add(-time.Minute)
+	logs := []entity.WebhookLog{
+		{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-1", Status: entity.WebhookStatusFailed, RetryCount: 1, NextRetryAt: &due,
+			RawPayload: paidPayload(order.ID)},
+	}
+	m.webhooks.On("ListDueRetries", mock.Anything, mock.AnythingOfType("time.Time"), 10).Return(logs, nil)
+	m.webhooks.On("Update", mock.Anything, &logs[0]).Return(nil).Once()
+
+	succeeded, err := uc.RetryFailedWebhooks(context.Background(), 10)
+	if err != nil || succeeded != 0 {
+		t.Fatalf("expected nothing to succeed, got %d, %v", succeeded, err)
+	}
+	if log := logs[0]; log.Status != entity.WebhookStatusFailed || log.NextRetryAt != nil {
+		t.Errorf("expected the log to stay failed without another retry, got %+v", log)
+	}
+	if order.PaymentStatus != entity.Unpaid {
+		t.Error("expected the order to be left unchanged")
+	}
+	m.webhooks.AssertExpectations(t)
+	m.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestNextRetryAt_BacksOffUntilAttemptsRunOut(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	if next := nextRetryAt(1, now); next == nil || !next.Equal(now.Add(5*time.Minute)) {
+		t.Errorf("expected the first retry after 5 minutes, got %v", next)
+	}
+	if next := nextRetryAt(3, now); next == nil || !next.Equal(now.Add(20*time.Minute)) {
+		t.Errorf("expected the third retry after 20 minutes, got %v", next)
+	}
+	if next := nextRetryAt(maxWebhookAttempts, now); next != nil {
+		t.Errorf("expected no retry after %d attempts, got %v", maxWebhookAttempts, next)
+	}
+}
+
+func TestReplayWebhook_AppliesStuckWebhook(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+
+	payload := paidPayload(order.ID)
+	stuck := &entity.WebhookLog{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-1", PaymentStatus: entity.Paid,
+		Status: entity.WebhookStatusProcessing, RawPayload: payload, CreatedAt: time.Now().Add(-time.Hour)}
+	recent := &entity.WebhookLog{ID: uuid.New(), OrderID: order.ID, TransactionID: "txn-2", PaymentStatus: entity.Paid,
+		Status: entity.WebhookStatusProcessing, RawPayload: payload, CreatedAt: time.Now()}
+	m.webhooks.On("GetByID", mock.Anything, stuck.ID).Return(stuck, nil)
+	m.webhooks.On("GetByID", mock.Anything, recent.ID).Return(recent, nil)
+	m.webhooks.On("GetByID", mock.Anything, mock.Anything).Return(nil, entity.NotFoundError("Webhook log not found"))
+	m.webhooks.On("Update", mock.Anything, stuck).Return(nil)
+
+	if _, err := uc.ReplayWebhook(context.Background(), recent.ID, uuid.New()); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a webhook that may still be applying to be refused, got %v", err)
+	}
+
+	log, err := uc.ReplayWebhook(context.Background(), stuck.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if log.Status != entity.WebhookStatusCompleted {
+		t.Errorf("expected the webhook to be completed, got %s", log.Status)
+	}
+	if order.PaymentStatus != entity.Paid {
+		t.Error("expected the order to be paid")
+	}
+
+	if _, err := uc.ReplayWebhook(context.Background(), stuck.ID, uuid.New()); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a completed webhook not to be replayed, got %v", err)
+	}
+	if _, err := uc.ReplayWebhook(context.Background(), uuid.New(), uuid.New()); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected an unknown webhook not to be found, got %v", err)
+	}
+}
+
+func TestResolveWebhook_StopsRetries(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	due := time.Now().Add(-time.Minute)
+	failed := &entity.WebhookLog{ID: uuid.New(), OrderID: uuid.New(), TransactionID: "txn-1", Status: entity.WebhookStatusFailed, RetryCount: 2, NextRetryAt: &due}
+	m.webhooks.On("GetByID", mock.Anything, failed.ID).Return(failed, nil)
+	m.webhooks.On("Update", mock.Anything, failed).Return(nil).Once()
+
+	log, err := uc.ResolveWebhook(context.Background(), failed.ID, uuid.New(), "Captured in the provider dashboard")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if log.Status != entity.WebhookStatusResolved || log.NextRetryAt != nil || log.ProcessedAt == nil {
+		t.Errorf("expected the webhook to be resolved without a retry, got %+v", log)
+	}
+
+	if _, err := uc.ResolveWebhook(context.Background(), failed.ID, uuid.New(), ""); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a resolved webhook not to be resolved again, got %v", err)
+	}
+	m.webhooks.AssertExpectations(t)
+}
+
+// jsonParser parses dead letters the way the direct webhook endpoint does
+func jsonParser(ctx context.Context, source string, payload []byte) (*entity.PaymentWebhookRequest, error) {
+	var req entity.PaymentWebhookRequest
+	if err := json.Unmarshal(payload, &req); err != nil {
+		return nil, entity.ValidationError("invalid JSON")
+	}
+	return &req, nil
+}
+
+func TestProcessWebhook_RejectionsAreMarked(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+	m.webhooks.On("GetByOrderID", mock.Anything, mock.Anything, transaction(), 1, 1).Return(nil, 0, nil)
+	m.orders.On("GetByID", mock.Anything, mock.Anything).Return(nil, entity.NotFoundError("Order not found"))
+
+	err := uc.ProcessWebhook(context.Background(), &entity.PaymentWebhookRequest{OrderID: uuid.New().String(), TransactionID: "txn-1", PaymentStatus: entity.Paid})
+	if !errors.Is(err, ErrWebhookRejected) || !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected a rejected not found error, got %v", err)
+	}
+	if err.Error() != "order not found" {
+		t.Errorf("expected the message to be kept, got %q", err.Error())
+	}
+}
+
+func TestDeadLetterWebhook_RecordsEveryAttempt(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+	var recorded []*entity.DeadLetterWebhook
+	m.deadLetters.On("Record", mock.Anything, mock.AnythingOfType("*entity.DeadLetterWebhook")).Return(nil).
+		Run(func(args mock.Arguments) { recorded = append(recorded, args.Get(1).(*entity.DeadLetterWebhook)) })
+
+	uc.DeadLetterWebhook(context.Background(), entity.DirectWebhookSource, []byte(`{"order_id":"x"}`), errors.New("first"))
+	uc.DeadLetterWebhook(context.Background(), entity.DirectWebhookSource, []byte(`{"order_id":"x"}`), errors.New("second"))
+	uc.DeadLetterWebhook(context.Background(), "stripe", []byte(`{"order_id":"x"}`), errors.New("third"))
+
+	if len(recorded) != 3 {
+		t.Fatalf("expected every attempt recorded, got %d", len(recorded))
+	}
+	// The repository counts attempts of the same source and body as one dead letter
+	if first, second := recorded[0], recorded[1]; first.PayloadHash != second.PayloadHash || second.Reason != "second" || second.RawPayload != `{"order_id":"x"}` {
+		t.Errorf("expected the second attempt recorded for the same body, got %+v", second)
+	}
+	if recorded[2].Source != "stripe" || recorded[2].Attempts != 1 {
+		t.Errorf("expected the attempt of another source recorded on its own, got %+v", recorded[2])
+	}
+}
+
+func TestReprocessDeadLetter(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, TotalPrice: 10, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	deadLetter := entity.NewDeadLetterWebhook(entity.DirectWebhookSource, []byte(paidPayload(order.ID)), "order not found", time.Now())
+	m.deadLetters.On("GetByID", mock.Anything, deadLetter.ID).Return(deadLetter, nil)
+	m.deadLetters.On("Update", mock.Anything, deadLetter).Return(nil)
+	logs := m.logsWebhooks()
+	m.orders.On("GetByID", mock.Anything, order.ID).Return(nil, entity.NotFoundError("Order not found")).Once()
+
+	webhook, err := uc.ReprocessDeadLetter(context.Background(), deadLetter.ID, uuid.New(), jsonParser)
+	if !errors.Is(err, entity.ErrNotFound) {
+		t.Fatalf("expected the order still not to be found, got %v", err)
+	}
+	if webhook.Status != entity.DeadLetterPending || webhook.Attempts != 2 {
+		t.Errorf("expected another attempt to be counted, got %+v", webhook)
+	}
+
+	// The order turns up, e.g. once it is restored
+	m.stored(order)
+	adminID := uuid.New()
+	webhook, err = uc.ReprocessDeadLetter(context.Background(), deadLetter.ID, adminID, jsonParser)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if webhook.Status != entity.DeadLetterReprocessed || webhook.ResolvedBy == nil || *webhook.ResolvedBy != adminID {
+		t.Errorf("expected the dead letter to be reprocessed by the admin, got %+v", webhook)
+	}
+	if order.PaymentStatus != entity.Paid || len(*logs) != 1 {
+		t.Error("expected the payment to be applied and logged")
+	}
+
+	if _, err := uc.ReprocessDeadLetter(context.Background(), deadLetter.ID, adminID, jsonParser); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a reprocessed dead letter not to be reprocessed again, got %v", err)
+	}
+	if _, err := uc.DiscardDeadLetter(context.Background(), deadLetter.ID, adminID, ""); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a reprocessed dead letter not to be discarded, got %v", err)
+	}
+}
+
+func TestReprocessDeadLetter_LeavesItPendingWhenTheStoreFails(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+	deadLetter := entity.NewDeadLetterWebhook("mercadopago", []byte(`{"id":1}`), "unknown payment", time.Now())
+	m.deadLetters.On("GetByID", mock.Anything, deadLetter.ID).Return(deadLetter, nil)
+
+	unavailable := errors.New("provider unavailable")
+	_, err := uc.ReprocessDeadLetter(context.Background(), deadLetter.ID, uuid.New(),
+		func(ctx context.Context, source string, payload []byte) (*entity.PaymentWebhookRequest, error) {
+			return nil, unavailable
+		})
+	if !errors.Is(err, unavailable) {
+		t.Fatalf("expected the parse error, got %v", err)
+	}
+	if deadLetter.Status != entity.DeadLetterPending || deadLetter.Attempts != 1 {
+		t.Errorf("expected the dead letter to be left as it was, got %+v", deadLetter)
+	}
+	m.deadLetters.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+
+	m.deadLetters.On("Update", mock.Anything, deadLetter).Return(nil).Once()
+	webhook, err := uc.DiscardDeadLetter(context.Background(), deadLetter.ID, uuid.New(), "Test event")
+	if err != nil || webhook.Status != entity.DeadLetterDiscarded || webhook.ResolvedAt == nil {
+		t.Errorf("expected the dead letter to be discarded, got %+v, %v", webhook, err)
+	}
+}
+
+func TestUseWebhookNonce_RejectsReusedNonces(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+	now := time.Now()
+	nonce := func(value string, expiresAt time.Time) interface{} {
+		return mock.MatchedBy(func(nonce *entity.WebhookNonce) bool {
+			return nonce.Nonce == value && nonce.ExpiresAt.Equal(expiresAt)
+		})
+	}
+	// The nonce is kept until the signed timestamp falls out of the tolerance
+	m.nonces.On("Claim", mock.Anything, nonce("nonce-1", now.Add(WebhookTolerance)), mock.Anything).Return(true, nil).Once()
+	m.nonces.On("Claim", mock.Anything, nonce("nonce-1", now.Add(WebhookTolerance)), mock.Anything).Return(false, nil).Once()
+	m.nonces.On("Claim", mock.Anything, nonce("nonce-2", now.Add(-WebhookTolerance)), mock.Anything).Return(true, nil).Once()
+	m.nonces.On("DeleteExpired", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(1), nil).Once()
+
+	if err := uc.UseWebhookNonce(context.Background(), "nonce-1", now); err != nil {
+		t.Fatalf("expected a new nonce to be accepted, got %v", err)
+	}
+	if err := uc.UseWebhookNonce(context.Background(), "nonce-1", now); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a reused nonce to be a conflict, got %v", err)
+	}
+	if err := uc.UseWebhookNonce(context.Background(), "nonce-2", now.Add(-2*WebhookTolerance)); err != nil {
+		t.Fatalf("expected another nonce to be accepted, got %v", err)
+	}
+
+	removed, err := uc.PurgeExpiredNonces(context.Background())
+	if err != nil || removed != 1 {
+		t.Errorf("expected the expired nonce to be purged, got %d, %v", removed, err)
+	}
+	m.nonces.AssertExpectations(t)
+}
+
+func TestCapturePayment_CompletesAuthorizedOrder(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, TotalPrice: 80, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	m.stored(order)
+	// The provider's webhook for the capture is a duplicate
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), transaction("cap-1"), 1, 1).Return(nil, 0, nil).Once()
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), transaction("cap-1"), 1, 1).
+		Return([]entity.WebhookLog{{OrderID: order.ID, TransactionID: "cap-1"}}, 1, nil)
+	logs := m.logsWebhooks()
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), mock.MatchedBy(func(filters repository.WebhookLogFilters) bool {
+		return filters.PaymentStatus != nil && *filters.PaymentStatus == entity.Authorized &&
+			filters.Status != nil && *filters.Status == entity.WebhookStatusCompleted
+	}), 1, 1).Return([]entity.WebhookLog{{OrderID: order.ID, TransactionID: "auth-1"}}, 1, nil)
+	m.gateway.On("Capture", mock.Anything, capture.Request{
+		OrderID: order.ID, TransactionID: "auth-1", Amount: 80, IdempotencyKey: "capture-" + order.ID.String(),
+	}).Return("cap-1", nil).Once()
+
+	authorize := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "auth-1", PaymentStatus: entity.Authorized}
+	if err := uc.ProcessWebhook(context.Background(), authorize); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	captured, err := uc.CapturePayment(context.Background(), order.ID, uuid.New())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if captured.PaymentStatus != entity.Paid || captured.Status != entity.Completed || captured.AmountPaid != 80 {
+		t.Errorf("expected the order paid and completed, got %s, %s, %.2f", captured.PaymentStatus, captured.Status, captured.AmountPaid)
+	}
+	m.gateway.AssertExpectations(t)
+
+	webhook := &entity.PaymentWebhookRequest{OrderID: order.ID.String(), TransactionID: "cap-1", PaymentStatus: entity.Paid}
+	if err := uc.ProcessWebhook(context.Background(), webhook); err != nil {
+		t.Fatalf("expected the provider's webhook to be ignored, got %v", err)
+	}
+	if len(*logs) != 2 {
+		t.Errorf("expected 2 webhook logs, got %d", len(*logs))
+	}
+}
+
+func TestCapturePayment_RequiresAuthorizedPendingOrder(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	unpaid := &entity.Order{ID: uuid.New(), TotalPrice: 10, Status: entity.Pending, PaymentStatus: entity.Unpaid}
+	completed := &entity.Order{ID: uuid.New(), TotalPrice: 10, Status: entity.Completed, PaymentStatus: entity.Authorized}
+	m.stored(unpaid)
+	m.stored(completed)
+	m.orders.On("GetByID", mock.Anything, mock.Anything).Return(nil, entity.NotFoundError("Order not found"))
+
+	for _, order := range []*entity.Order{unpaid, completed} {
+		if _, err := uc.CapturePayment(context.Background(), order.ID, uuid.New()); !errors.Is(err, entity.ErrConflict) {
+			t.Errorf("expected a conflict, got %v", err)
+		}
+	}
+	if _, err := uc.CapturePayment(context.Background(), uuid.New(), uuid.New()); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected not found, got %v", err)
+	}
+	m.gateway.AssertNotCalled(t, "Capture", mock.Anything, mock.Anything)
+}
+
+func TestCapturePayment_ProviderFailureKeepsAuthorization(t *testing.T) {
+	uc, m := newTestUseCase(&mockServices.MockServices{})
+
+	order := &entity.Order{ID: uuid.New(), TotalPrice: 10, Status: entity.Pending, PaymentStatus: entity.Authorized}
+	m.stored(order)
+	m.webhooks.On("GetByOrderID", mock.Anything, order.ID.String(), mock.Anything, 1, 1).
+		Return([]entity.WebhookLog{{OrderID: order.ID, TransactionID: "auth-1"}}, 1, nil)
+	m.gateway.On("Capture", mock.Anything, mock.Anything).Return("", errors.New("payment provider responded with status 402"))
+
+	if _, err := uc.CapturePayment(context.Background(), order.ID, uuid.New()); err == nil {
+		t.Fatal("expected the provider's error")
+	}
+	if order.PaymentStatus != entity.Authorized || order.Status != entity.Pending {
+		t.Errorf("expected the order to stay authorized, got %s, %s", order.PaymentStatus, order.Status)
+	}
+	m.webhooks.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	m.orders.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,201 @@
+package pricehistory
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrProductNotFound     = entity.NotFoundError("Product not found")
+	ErrVariantNotFound     = entity.NotFoundError("Product variant not found")
+	ErrPriceChangeNotFound = entity.NotFoundError("Price change not found")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Book -out ../../internal/testing/servicemocks -name PriceBook
+
+// Book keeps the price history of the catalog. Use cases that change a price
+// record it, and use cases that sell or show products attach the scheduled
+// prices so the effective price can be resolved.
+type Book interface {
+	Record(ctx context.Context, change *entity.PriceChange) error
+	// Attach sets the PriceSchedule of the products, nil products are skipped
+	Attach(ctx context.Context, products ...*entity.Product) error
+}
+
+// ScheduleInput describes a price to apply to a product, or to one of its
+// variants, from EffectiveFrom until EffectiveTo, or for good when unset
+type ScheduleInput struct {
+	VariantID     *uuid.UUID
+	Price         float64
+	EffectiveFrom time.Time
+	EffectiveTo   *time.Time
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface PriceHistoryService -out ../../internal/testing/servicemocks
+
+type PriceHistoryService interface {
+	Book
+	ListHistory(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page, pageSize int) ([]*entity.PriceChange, int, error)
+	SchedulePrice(ctx context.Context, productID uuid.UUID, input ScheduleInput) (*entity.PriceChange, error)
+	CancelScheduledPrice(ctx context.Context, productID, changeID uuid.UUID) (*entity.PriceChange, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	repo         repository.PriceChangeRepository
+	productRepo  repository.ProductRepository
+	variantRepo  repository.ProductVariantRepository
+	resolveActor audit.ActorResolver
+	services     Services
+	now          func() time.Time
+}
+
+func NewUseCase(repo repository.PriceChangeRepository, productRepo repository.ProductRepository, variantRepo repository.ProductVariantRepository, resolveActor audit.ActorResolver, services Services) *UseCase {
+	return &UseCase{
+		repo:         repo,
+		productRepo:  productRepo,
+		variantRepo:  variantRepo,
+		resolveActor: resolveActor,
+		services:     services,
+		now:          time.Now,
+	}
+}
+
+// Record stores an edit of a price, unless the price did not change
+func (uc *UseCase) Record(ctx context.Context, change *entity.PriceChange) error {
+	if samePrice(change.PreviousPrice, change.Price) {
+		return nil
+	}
+	if change.ChangedBy == nil {
+		change.ChangedBy = uc.resolveActor(ctx).UserID
+	}
+
+	if err := change.Validate(); err != nil {
+		return err
+	}
+
+	return uc.repo.Create(ctx, change)
+}
+
+func samePrice(a, b *float64) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func (uc *UseCase) Attach(ctx context.Context, products ...*entity.Product) error {
+	var ids []uuid.UUID
+	for _, product := range products {
+		if product != nil {
+			ids = append(ids, product.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	changes, err := uc.repo.GetSchedules(ctx, ids, uc.now())
+	if err != nil {
+		return err
+	}
+
+	schedules := make(map[uuid.UUID][]entity.PriceChange)
+	for _, change := range changes {
+		schedules[change.ProductID] = append(schedules[change.ProductID], *change)
+	}
+	for _, product := range products {
+		if product != nil {
+			product.PriceSchedule = schedules[product.ID]
+		}
+	}
+	return nil
+}
+
+func (uc *UseCase) ListHistory(ctx context.Context, productID uuid.UUID, filters repository.PriceChangeFilters, page, pageSize int) ([]*entity.PriceChange, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, 0, ErrProductNotFound
+	}
+
+	return uc.repo.GetByProductID(ctx, productID, filters, page, pageSize)
+}
+
+// SchedulePrice enters a price change ahead of time. The stored price of the
+// product or variant is left alone, the scheduled price overrides it while in
+// effect.
+func (uc *UseCase) SchedulePrice(ctx context.Context, productID uuid.UUID, input ScheduleInput) (*entity.PriceChange, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+	if input.VariantID != nil {
+		variant, err := uc.variantRepo.GetByID(ctx, *input.VariantID)
+		if err != nil || variant.ProductID != productID {
+			return nil, ErrVariantNotFound
+		}
+	}
+
+	now := uc.now()
+	if !input.EffectiveFrom.After(now) {
+		return nil, entity.ValidationError("Effective from must be in the future")
+	}
+
+	price := input.Price
+	change := &entity.PriceChange{
+		ID:            entity.NewID(),
+		ProductID:     productID,
+		VariantID:     input.VariantID,
+		Price:         &price,
+		Scheduled:     true,
+		EffectiveFrom: input.EffectiveFrom,
+		EffectiveTo:   input.EffectiveTo,
+		ChangedBy:     uc.resolveActor(ctx).UserID,
+		CreatedAt:     now,
+	}
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Create(ctx, change); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "SCHEDULE_PRICE", "Product", productID, nil, change)
+
+	return change, nil
+}
+
+// CancelScheduledPrice withdraws a scheduled change that has not started yet.
+// It stays in the history as cancelled.
+func (uc *UseCase) CancelScheduledPrice(ctx context.Context, productID, changeID uuid.UUID) (*entity.PriceChange, error) {
+	change, err := uc.repo.GetByID(ctx, changeID)
+	if err != nil || change.ProductID != productID {
+		return nil, ErrPriceChangeNotFound
+	}
+
+	if err := change.Cancel(uc.now()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, change); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "CANCEL_SCHEDULED_PRICE", "Product", productID, nil, change)
+
+	return change, nil
+}
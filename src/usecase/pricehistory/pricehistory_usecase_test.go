@@ -0,0 +1,119 @@
+package pricehistory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var admin = uuid.New()
+
+// services stands in for the shared test services, which depend on this package
+type services struct{}
+
+func (services) GetAuditService() audit.AuditService { return discardAudit{} }
+
+type discardAudit struct{}
+
+func (discardAudit) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
+	return nil
+}
+
+func newUseCase(t *testing.T) (*UseCase, *entity.Product, *entity.ProductVariant) {
+	t.Helper()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	variants := memory.NewProductVariantRepository(store)
+
+	product := &entity.Product{Name: "Mug", Price: 10, Quantity: 5}
+	require.NoError(t, products.Create(context.Background(), product))
+	variant := &entity.ProductVariant{ProductID: product.ID, SKU: "MUG-RED", Quantity: 2}
+	require.NoError(t, variants.Create(context.Background(), variant))
+
+	uc := NewUseCase(memory.NewPriceChangeRepository(store), products, variants,
+		func(ctx context.Context) audit.Actor { return audit.Actor{UserID: &admin} }, services{})
+	return uc, product, variant
+}
+
+func TestRecord(t *testing.T) {
+	ctx := context.Background()
+	uc, product, _ := newUseCase(t)
+	ten, twelve := 10.0, 12.0
+
+	require.NoError(t, uc.Record(ctx, entity.NewPriceChange(product.ID, nil, &ten, &ten, nil)))
+	require.NoError(t, uc.Record(ctx, entity.NewPriceChange(product.ID, nil, &ten, &twelve, nil)))
+
+	history, total, err := uc.ListHistory(ctx, product.ID, repository.PriceChangeFilters{}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 1, total, "unchanged prices are not recorded")
+	assert.Equal(t, twelve, *history[0].Price)
+	assert.Equal(t, ten, *history[0].PreviousPrice)
+	assert.Equal(t, &admin, history[0].ChangedBy)
+}
+
+func TestSchedulePrice(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("Applies within the window", func(t *testing.T) {
+		uc, product, variant := newUseCase(t)
+		end := now.Add(2 * time.Hour)
+		_, err := uc.SchedulePrice(ctx, product.ID, ScheduleInput{Price: 8, EffectiveFrom: now.Add(time.Hour), EffectiveTo: &end})
+		require.NoError(t, err)
+		_, err = uc.SchedulePrice(ctx, product.ID, ScheduleInput{VariantID: &variant.ID, Price: 6, EffectiveFrom: now.Add(time.Hour)})
+		require.NoError(t, err)
+
+		uc.now = func() time.Time { return now.Add(90 * time.Minute) }
+		require.NoError(t, uc.Attach(ctx, product))
+		variant.Product = product
+
+		assert.Equal(t, 8.0, product.PriceAt(uc.now()))
+		price, err := variant.PriceAt(uc.now())
+		require.NoError(t, err)
+		assert.Equal(t, 6.0, price)
+		assert.Equal(t, 10.0, product.PriceAt(now.Add(3*time.Hour)), "back to the stored price once the window ends")
+	})
+
+	t.Run("Rejects invalid schedules", func(t *testing.T) {
+		uc, product, _ := newUseCase(t)
+		before := now.Add(30 * time.Minute)
+		otherVariant := uuid.New()
+
+		_, err := uc.SchedulePrice(ctx, product.ID, ScheduleInput{Price: 8, EffectiveFrom: now.Add(-time.Minute)})
+		assert.ErrorIs(t, err, entity.ErrValidation, "in the past")
+		_, err = uc.SchedulePrice(ctx, product.ID, ScheduleInput{Price: 8, EffectiveFrom: now.Add(time.Hour), EffectiveTo: &before})
+		assert.ErrorIs(t, err, entity.ErrValidation, "ends before it starts")
+		_, err = uc.SchedulePrice(ctx, product.ID, ScheduleInput{VariantID: &otherVariant, Price: 8, EffectiveFrom: now.Add(time.Hour)})
+		assert.ErrorIs(t, err, entity.ErrNotFound, "unknown variant")
+		_, err = uc.SchedulePrice(ctx, uuid.New(), ScheduleInput{Price: 8, EffectiveFrom: now.Add(time.Hour)})
+		assert.ErrorIs(t, err, entity.ErrNotFound, "unknown product")
+	})
+}
+
+func TestCancelScheduledPrice(t *testing.T) {
+	ctx := context.Background()
+	uc, product, _ := newUseCase(t)
+	change, err := uc.SchedulePrice(ctx, product.ID, ScheduleInput{Price: 8, EffectiveFrom: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = uc.CancelScheduledPrice(ctx, uuid.New(), change.ID)
+	assert.ErrorIs(t, err, entity.ErrNotFound, "belongs to another product")
+
+	cancelled, err := uc.CancelScheduledPrice(ctx, product.ID, change.ID)
+	require.NoError(t, err)
+	assert.NotNil(t, cancelled.CancelledAt)
+
+	require.NoError(t, uc.Attach(ctx, product))
+	assert.Empty(t, product.PriceSchedule)
+
+	_, err = uc.CancelScheduledPrice(ctx, product.ID, change.ID)
+	assert.ErrorIs(t, err, entity.ErrValidation, "already cancelled")
+}
@@ -0,0 +1,300 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Line is a single priced line fed into the calculator
+type Line struct {
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+	Surcharge float64 `json:"surcharge,omitempty"` // Line fee, neither discounted nor taxed
+}
+
+// Input holds everything that contributes to the monetary totals of a cart, checkout or order
+type Input struct {
+	Lines           []Line  `json:"lines"`
+	DiscountPercent float64 `json:"discount_percent"` // Applied to the subtotal, 0-100
+	DiscountAmount  float64 `json:"discount_amount"`  // Fixed discount applied after the percentage
+	TaxRate         float64 `json:"tax_rate"`         // Fraction, e.g. 0.2 for 20%
+	Shipping        float64 `json:"shipping"`
+	Credits         float64 `json:"credits"` // Store credit / gift card balance to apply
+}
+
+// Totals is the breakdown produced by the calculator
+type Totals struct {
+	Subtotal   float64      `json:"subtotal"`
+	Discount   float64      `json:"discount"`
+	Tax        float64      `json:"tax"`
+	Surcharges float64      `json:"surcharges"`
+	Shipping   float64      `json:"shipping"`
+	Credits    float64      `json:"credits"`
+	Total      float64      `json:"total"`
+	Lines      []LineTotals `json:"lines"` // One per input line, in the same order
+}
+
+// LineTotals is the share of a single line in the totals. Summed over all
+// lines, each component matches the order level component exactly.
+type LineTotals struct {
+	Base      float64 `json:"base"`
+	Discount  float64 `json:"discount"`
+	Tax       float64 `json:"tax"`
+	Surcharge float64 `json:"surcharge"`
+	Total     float64 `json:"total"`
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface Calculator -out ../../internal/testing/servicemocks -name PriceCalculator
+
+// Calculator is the single source of truth for monetary calculations.
+// Cart, checkout and order must all go through it so the numbers never drift apart.
+type Calculator interface {
+	Calculate(in Input) Totals
+	OrderTotals(order *entity.Order) Totals
+	PriceOrder(order *entity.Order, in Input) Totals
+}
+
+type calculator struct{}
+
+func NewCalculator() Calculator {
+	return &calculator{}
+}
+
+// Calculate computes totals in a fixed order: subtotal, discount, tax on the
+// discounted subtotal, surcharges, shipping and finally credits. Every component
+// is rounded to cents so the parts always add up to the total. The discount and
+// tax are split over the lines in proportion to their amounts, to the cent.
+func (c *calculator) Calculate(in Input) Totals {
+	t := Totals{Lines: make([]LineTotals, len(in.Lines))}
+
+	bases := make([]float64, len(in.Lines))
+	for i, line := range in.Lines {
+		if line.Quantity <= 0 || line.UnitPrice < 0 {
+			continue
+		}
+		bases[i] = round(line.UnitPrice * float64(line.Quantity))
+		t.Lines[i].Base = bases[i]
+		t.Lines[i].Surcharge = round(math.Max(line.Surcharge, 0))
+		t.Subtotal += bases[i]
+		t.Surcharges += t.Lines[i].Surcharge
+	}
+	t.Subtotal = round(t.Subtotal)
+	t.Surcharges = round(t.Surcharges)
+
+	percent := clamp(in.DiscountPercent, 0, 100)
+	discount := round(t.Subtotal*percent/100) + math.Max(in.DiscountAmount, 0)
+	t.Discount = round(math.Min(discount, t.Subtotal))
+
+	taxable := t.Subtotal - t.Discount
+	t.Tax = round(taxable * math.Max(in.TaxRate, 0))
+	t.Shipping = round(math.Max(in.Shipping, 0))
+
+	discounts := allocate(t.Discount, bases)
+	taxables := make([]float64, len(bases))
+	for i := range bases {
+		taxables[i] = round(bases[i] - discounts[i])
+	}
+	taxes := allocate(t.Tax, taxables)
+	for i := range t.Lines {
+		line := &t.Lines[i]
+		line.Discount = discounts[i]
+		line.Tax = taxes[i]
+		line.Total = round(line.Base - line.Discount + line.Tax + line.Surcharge)
+	}
+
+	due := round(taxable + t.Tax + t.Surcharges + t.Shipping)
+	t.Credits = round(math.Min(math.Max(in.Credits, 0), due))
+	t.Total = round(due - t.Credits)
+
+	return t
+}
+
+// OrderTotals computes totals from the components stored on the order items.
+// Items without components are priced from their unit price and quantity.
+func (c *calculator) OrderTotals(order *entity.Order) Totals {
+	t := Totals{Lines: make([]LineTotals, 0, len(order.Products))}
+
+	for i := range order.Products {
+		item := &order.Products[i]
+		line := LineTotals{
+			Base:      item.ComponentTotal(entity.ComponentBase),
+			Discount:  -item.ComponentTotal(entity.ComponentDiscount),
+			Tax:       item.ComponentTotal(entity.ComponentTax),
+			Surcharge: item.ComponentTotal(entity.ComponentSurcharge),
+		}
+		line.Total = round(line.Base - line.Discount + line.Tax + line.Surcharge)
+		t.Lines = append(t.Lines, line)
+
+		t.Subtotal += line.Base
+		t.Discount += line.Discount
+		t.Tax += line.Tax
+		t.Surcharges += line.Surcharge
+	}
+
+	t.Subtotal = round(t.Subtotal)
+	t.Discount = round(t.Discount)
+	t.Tax = round(t.Tax)
+	t.Surcharges = round(t.Surcharges)
+	t.Total = round(t.Subtotal - t.Discount + t.Tax + t.Surcharges)
+
+	return t
+}
+
+// OrderLines builds one line per order item from its unit price and quantity
+func OrderLines(order *entity.Order) []Line {
+	lines := make([]Line, 0, len(order.Products))
+	for _, item := range order.Products {
+		lines = append(lines, Line{UnitPrice: item.Price, Quantity: item.Quantity})
+	}
+	return lines
+}
+
+// PriceOrder prices the order items and stores each line's base, discount, tax
+// and surcharge on the item as components, with the tax rate. in.Lines holds one line per order
+// item, in the same order, and is built from the items when empty.
+func (c *calculator) PriceOrder(order *entity.Order, in Input) Totals {
+	if len(in.Lines) != len(order.Products) {
+		in.Lines = OrderLines(order)
+	}
+
+	totals := c.Calculate(in)
+
+	taxLabel := "Tax " + strconv.FormatFloat(in.TaxRate*100, 'f', -1, 64) + "%"
+	discountLabel := "Discount"
+	if in.DiscountPercent > 0 {
+		discountLabel = fmt.Sprintf("%s%% off", strconv.FormatFloat(clamp(in.DiscountPercent, 0, 100), 'f', -1, 64))
+	}
+
+	for i := range order.Products {
+		item := &order.Products[i]
+		line := totals.Lines[i]
+
+		item.Components = nil
+		item.TaxRate = math.Max(in.TaxRate, 0)
+		item.AddComponent(entity.ComponentBase, "", line.Base)
+		item.AddComponent(entity.ComponentDiscount, discountLabel, -line.Discount)
+		item.AddComponent(entity.ComponentTax, taxLabel, line.Tax)
+		item.AddComponent(entity.ComponentSurcharge, "Surcharge", line.Surcharge)
+		item.CalculateTotal()
+	}
+
+	return totals
+}
+
+// Lazy defers recalculation until the totals are actually read.
+// Call Invalidate whenever the underlying input changes.
+type Lazy struct {
+	calc   Calculator
+	source func() Input
+	totals Totals
+	valid  bool
+}
+
+func NewLazy(calc Calculator, source func() Input) *Lazy {
+	return &Lazy{calc: calc, source: source}
+}
+
+// Totals returns the cached totals, recalculating only if they were invalidated
+func (l *Lazy) Totals() Totals {
+	if !l.valid {
+		l.totals = l.calc.Calculate(l.source())
+		l.valid = true
+	}
+	return l.totals
+}
+
+// Invalidate marks the cached totals as stale
+func (l *Lazy) Invalidate() {
+	l.valid = false
+}
+
+// Cart collects the lines and adjustments of a cart, checkout or order as
+// they are known. Its totals are only calculated when read, and again only
+// after something changed.
+type Cart struct {
+	in     Input
+	totals *Lazy
+}
+
+func NewCart(calc Calculator, in Input) *Cart {
+	cart := &Cart{in: in}
+	cart.totals = NewLazy(calc, cart.Input)
+	return cart
+}
+
+// AddLine adds a line to the cart
+func (c *Cart) AddLine(line Line) {
+	c.in.Lines = append(c.in.Lines, line)
+	c.totals.Invalidate()
+}
+
+// SetDiscount sets the fixed discount taken off the subtotal
+func (c *Cart) SetDiscount(amount float64) {
+	c.in.DiscountAmount = amount
+	c.totals.Invalidate()
+}
+
+// Input returns a copy of what the cart is priced from
+func (c *Cart) Input() Input {
+	in := c.in
+	in.Lines = slices.Clone(c.in.Lines)
+	return in
+}
+
+// Totals returns the totals of the cart
+func (c *Cart) Totals() Totals {
+	return c.totals.Totals()
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
+
+// allocate splits amount over the weights in proportion, to the cent. Cents lost
+// to rounding go to the largest remainders so the shares add up to amount exactly.
+func allocate(amount float64, weights []float64) []float64 {
+	shares := make([]float64, len(weights))
+
+	totalWeight := 0.0
+	for _, weight := range weights {
+		totalWeight += math.Max(weight, 0)
+	}
+	cents := int64(math.Round(amount * 100))
+	if cents == 0 || totalWeight == 0 {
+		return shares
+	}
+
+	remainders := make([]float64, len(weights))
+	allocated := int64(0)
+	for i, weight := range weights {
+		exact := float64(cents) * math.Max(weight, 0) / totalWeight
+		whole := math.Floor(exact)
+		shares[i] = whole
+		remainders[i] = exact - whole
+		allocated += int64(whole)
+	}
+
+	for left := cents - allocated; left > 0; left-- {
+		largest := -1
+		for i, weight := range weights {
+			if weight > 0 && (largest == -1 || remainders[i] > remainders[largest]) {
+				largest = i
+			}
+		}
+		shares[largest]++
+		remainders[largest] = -1
+	}
+
+	for i := range shares {
+		shares[i] /= 100
+	}
+	return shares
+}
+
+func clamp(value, min, max float64) float64 {
+	return math.Max(min, math.Min(value, max))
+}
@@ -0,0 +1,238 @@
+package pricing
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestCalculator_Golden(t *testing.T) {
+	inputs, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) == 0 {
+		t.Fatal("no golden scenarios found in testdata")
+	}
+
+	calc := NewCalculator()
+
+	for _, inputPath := range inputs {
+		name := strings.TrimSuffix(filepath.Base(inputPath), ".json")
+		t.Run(name, func(t *testing.T) {
+			raw, err := os.ReadFile(inputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var in Input
+			if err := json.Unmarshal(raw, &in); err != nil {
+				t.Fatalf("invalid scenario: %v", err)
+			}
+
+			got, err := json.MarshalIndent(calc.Calculate(in), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			goldenPath := filepath.Join("testdata", name+".golden")
+			if *update {
+				if err := os.WriteFile(goldenPath, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatalf("missing golden file (run with -update): %v", err)
+			}
+
+			if !bytes.Equal(got, want) {
+				t.Errorf("totals mismatch for %s\ngot:\n%s\nwant:\n%s", name, got, want)
+			}
+		})
+	}
+}
+
+func TestCalculator_ComponentsAddUp(t *testing.T) {
+	calc := NewCalculator()
+	totals := calc.Calculate(Input{
+		Lines:           []Line{{UnitPrice: 33.33, Quantity: 3}, {UnitPrice: 0.01, Quantity: 7}},
+		DiscountPercent: 12.5,
+		TaxRate:         0.19,
+		Shipping:        4.5,
+		Credits:         10,
+	})
+
+	sum := round(totals.Subtotal - totals.Discount + totals.Tax + totals.Shipping - totals.Credits)
+	if sum != totals.Total {
+		t.Errorf("components add up to %v, total is %v", sum, totals.Total)
+	}
+}
+
+func TestCalculator_LinesAddUp(t *testing.T) {
+	totals := NewCalculator().Calculate(Input{
+		Lines:           []Line{{UnitPrice: 33.33, Quantity: 3}, {UnitPrice: 0.01, Quantity: 7}, {UnitPrice: 4.99, Quantity: 1, Surcharge: 2.5}},
+		DiscountPercent: 12.5,
+		DiscountAmount:  1,
+		TaxRate:         0.19,
+	})
+
+	var sum LineTotals
+	for _, line := range totals.Lines {
+		sum.Base += line.Base
+		sum.Discount += line.Discount
+		sum.Tax += line.Tax
+		sum.Surcharge += line.Surcharge
+		sum.Total += line.Total
+	}
+
+	if round(sum.Base) != totals.Subtotal || round(sum.Discount) != totals.Discount || round(sum.Tax) != totals.Tax || round(sum.Surcharge) != totals.Surcharges {
+		t.Errorf("lines add up to %+v, totals are %+v", sum, totals)
+	}
+	if round(sum.Total) != totals.Total {
+		t.Errorf("line totals add up to %v, total is %v", round(sum.Total), totals.Total)
+	}
+}
+
+func TestCalculator_PriceOrder(t *testing.T) {
+	order := &entity.Order{
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 19.99, Quantity: 3},
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 5.5, Quantity: 1},
+		},
+	}
+
+	calc := NewCalculator()
+	priced := calc.PriceOrder(order, Input{DiscountPercent: 10, DiscountAmount: 2, TaxRate: 0.0825})
+
+	item := order.Products[0]
+	if item.ComponentTotal(entity.ComponentBase) != 59.97 || item.ComponentTotal(entity.ComponentDiscount) != -7.83 || item.ComponentTotal(entity.ComponentTax) != 4.31 {
+		t.Errorf("unexpected components %+v", item.Components)
+	}
+	if item.TotalPrice != 56.45 {
+		t.Errorf("TotalPrice = %v, want 56.45", item.TotalPrice)
+	}
+	if item.TaxRate != 0.0825 {
+		t.Errorf("TaxRate = %v, want 0.0825", item.TaxRate)
+	}
+
+	// Totals read back from the stored components match the priced totals
+	stored := calc.OrderTotals(order)
+	if stored.Subtotal != priced.Subtotal || stored.Discount != priced.Discount || stored.Tax != priced.Tax || stored.Total != priced.Total {
+		t.Errorf("stored totals %+v differ from priced totals %+v", stored, priced)
+	}
+
+	// So does the order total summed from the priced lines
+	order.CalculateTotal()
+	if order.TotalPrice != priced.Total {
+		t.Errorf("CalculateTotal() = %v, want %v", order.TotalPrice, priced.Total)
+	}
+}
+
+func TestCalculator_OrderTotals(t *testing.T) {
+	order := &entity.Order{
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 10.5, Quantity: 2},
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 3.25, Quantity: 4},
+		},
+	}
+
+	totals := NewCalculator().OrderTotals(order)
+	if totals.Total != 34 {
+		t.Errorf("OrderTotals().Total = %v, want 34", totals.Total)
+	}
+}
+
+func TestLazy_RecalculatesOnlyWhenInvalidated(t *testing.T) {
+	calls := 0
+	in := Input{Lines: []Line{{UnitPrice: 5, Quantity: 1}}}
+	lazy := NewLazy(NewCalculator(), func() Input {
+		calls++
+		return in
+	})
+
+	lazy.Totals()
+	lazy.Totals()
+	if calls != 1 {
+		t.Fatalf("expected 1 calculation, got %d", calls)
+	}
+
+	in.Lines = append(in.Lines, Line{UnitPrice: 5, Quantity: 1})
+	if lazy.Totals().Total != 5 {
+		t.Error("expected cached totals before invalidation")
+	}
+
+	lazy.Invalidate()
+	if got := lazy.Totals().Total; got != 10 {
+		t.Errorf("Totals().Total after invalidate = %v, want 10", got)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calculations, got %d", calls)
+	}
+}
+
+func TestCart(t *testing.T) {
+	calc := &countingCalculator{Calculator: NewCalculator()}
+	cart := NewCart(calc, Input{TaxRate: 0.2})
+
+	cart.AddLine(Line{UnitPrice: 10, Quantity: 2})
+	cart.AddLine(Line{UnitPrice: 5, Quantity: 1})
+	if calc.calls != 0 {
+		t.Fatalf("expected no calculation before the totals are read, got %d", calc.calls)
+	}
+
+	if got := cart.Totals().Total; got != 30 {
+		t.Errorf("Totals().Total = %v, want 30", got)
+	}
+	cart.Totals()
+	if calc.calls != 1 {
+		t.Errorf("expected 1 calculation, got %d", calc.calls)
+	}
+
+	cart.SetDiscount(5)
+	if got := cart.Totals().Total; got != 24 {
+		t.Errorf("Totals().Total after discount = %v, want 24", got)
+	}
+	if calc.calls != 2 {
+		t.Errorf("expected 2 calculations, got %d", calc.calls)
+	}
+
+	// The input handed out can't change the cart behind its back
+	in := cart.Input()
+	in.Lines[0].Quantity = 100
+	if got := cart.Input().Lines[0].Quantity; got != 2 {
+		t.Errorf("Input().Lines[0].Quantity = %v, want 2", got)
+	}
+
+	// Pricing an order from the cart input gives the cart totals
+	order := &entity.Order{
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 10, Quantity: 2},
+			{ID: uuid.New(), ProductID: uuid.New(), Price: 5, Quantity: 1},
+		},
+	}
+	if got := NewCalculator().PriceOrder(order, cart.Input()); got.Total != cart.Totals().Total {
+		t.Errorf("PriceOrder().Total = %v, want the cart total %v", got.Total, cart.Totals().Total)
+	}
+}
+
+type countingCalculator struct {
+	Calculator
+	calls int
+}
+
+func (c *countingCalculator) Calculate(in Input) Totals {
+	c.calls++
+	return c.Calculator.Calculate(in)
+}
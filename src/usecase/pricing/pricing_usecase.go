@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrProductNotFound = entity.NotFoundError("Product not found")
+	ErrVariantNotFound = entity.NotFoundError("Product variant not found")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface PriceListService -out ../../internal/testing/servicemocks
+
+type PriceListService interface {
+	GetPriceList(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error)
+	ReplacePriceList(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) ([]*entity.PriceTier, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	tierRepo    repository.PriceTierRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.ProductVariantRepository
+	services    Services
+}
+
+func NewUseCase(tierRepo repository.PriceTierRepository, productRepo repository.ProductRepository, variantRepo repository.ProductVariantRepository, services Services) *UseCase {
+	return &UseCase{
+		tierRepo:    tierRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		services:    services,
+	}
+}
+
+func (uc *UseCase) GetPriceList(ctx context.Context, productID uuid.UUID) ([]*entity.PriceTier, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+	return uc.tierRepo.GetByProductID(ctx, productID)
+}
+
+// ReplacePriceList swaps the price list of a product for the given tiers, an
+// empty list removes it. Tiers for a variant must name a variant of the product.
+func (uc *UseCase) ReplacePriceList(ctx context.Context, productID uuid.UUID, tiers []entity.PriceTier) ([]*entity.PriceTier, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	checked := make(map[uuid.UUID]bool)
+	for i := range tiers {
+		tiers[i].ID = uuid.Nil
+		tiers[i].ProductID = productID
+		if tiers[i].CustomerGroup == "" {
+			tiers[i].CustomerGroup = entity.RetailGroup
+		}
+
+		variantID := tiers[i].VariantID
+		if variantID == nil || checked[*variantID] {
+			continue
+		}
+		variant, err := uc.variantRepo.GetByID(ctx, *variantID)
+		if err != nil || variant.ProductID != productID {
+			return nil, ErrVariantNotFound
+		}
+		checked[*variantID] = true
+	}
+	if err := entity.ValidatePriceList(tiers); err != nil {
+		return nil, err
+	}
+
+	previous, err := uc.tierRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.tierRepo.ReplaceForProduct(ctx, productID, tiers); err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.tierRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "REPLACE_PRICE_LIST", "Product", productID,
+		map[string]interface{}{"tiers": previous}, map[string]interface{}{"tiers": saved})
+
+	return saved, nil
+}
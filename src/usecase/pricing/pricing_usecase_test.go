@@ -0,0 +1,154 @@
+package pricing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// services stands in for the shared test services, which depend on this package
+type services struct{}
+
+func (services) GetAuditService() audit.AuditService { return discardAudit{} }
+
+type discardAudit struct{}
+
+func (discardAudit) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
+	return nil
+}
+
+type fixture struct {
+	uc       *UseCase
+	resolver Resolver
+	store    *memory.Store
+	product  *entity.Product
+	variant  *entity.ProductVariant
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	variants := memory.NewProductVariantRepository(store)
+
+	product := &entity.Product{Name: "Mug", Price: 10, Quantity: 100}
+	require.NoError(t, products.Create(ctx, product))
+	override := 12.0
+	variant := &entity.ProductVariant{ProductID: product.ID, SKU: "MUG-RED", Price_Override: &override, Quantity: 50}
+	require.NoError(t, variants.Create(ctx, variant))
+
+	tiers := memory.NewPriceTierRepository(store)
+	return &fixture{
+		uc:       NewUseCase(tiers, products, variants, services{}),
+		resolver: NewResolver(tiers, memory.NewCustomerRepository(store)),
+		store:    store,
+		product:  product,
+		variant:  variant,
+	}
+}
+
+func TestReplacePriceList(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Replaces the whole list", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.uc.ReplacePriceList(ctx, f.product.ID, []entity.PriceTier{{MinQuantity: 10, Price: 9}, {MinQuantity: 50, Price: 8}})
+		require.NoError(t, err)
+
+		saved, err := f.uc.ReplacePriceList(ctx, f.product.ID, []entity.PriceTier{
+			{CustomerGroup: "wholesale", MinQuantity: 1, Price: 7},
+			{VariantID: &f.variant.ID, MinQuantity: 10, Price: 11},
+		})
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.Nil(t, saved[0].VariantID, "product tiers come first")
+		assert.Equal(t, "wholesale", saved[0].CustomerGroup)
+		assert.Equal(t, entity.RetailGroup, saved[1].CustomerGroup, "the group defaults to retail")
+
+		list, err := f.uc.GetPriceList(ctx, f.product.ID)
+		require.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
+
+	t.Run("Rejects variants of other products", func(t *testing.T) {
+		f := newFixture(t)
+		other := &entity.ProductVariant{ProductID: uuid.New(), SKU: "CUP-RED"}
+		require.NoError(t, memory.NewProductVariantRepository(f.store).Create(ctx, other))
+
+		_, err := f.uc.ReplacePriceList(ctx, f.product.ID, []entity.PriceTier{{VariantID: &other.ID, MinQuantity: 1, Price: 5}})
+		assert.ErrorIs(t, err, ErrVariantNotFound)
+	})
+
+	t.Run("Rejects duplicate tiers", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.uc.ReplacePriceList(ctx, f.product.ID, []entity.PriceTier{{MinQuantity: 10, Price: 9}, {CustomerGroup: entity.RetailGroup, MinQuantity: 10, Price: 8}})
+		assert.ErrorIs(t, err, entity.ErrValidation)
+	})
+
+	t.Run("Unknown product", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.uc.GetPriceList(ctx, uuid.New())
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	_, err := f.uc.ReplacePriceList(ctx, f.product.ID, []entity.PriceTier{
+		{MinQuantity: 10, Price: 9},
+		{MinQuantity: 50, Price: 8},
+		{CustomerGroup: "wholesale", MinQuantity: 1, Price: 7.5},
+		{VariantID: &f.variant.ID, MinQuantity: 10, Price: 11},
+		{MinQuantity: 100, Price: 15},
+	})
+	require.NoError(t, err)
+
+	wholesaler := uuid.New()
+	require.NoError(t, memory.NewCustomerRepository(f.store).Save(ctx, &entity.Customer{UserID: wholesaler, CustomerGroup: "wholesale"}))
+	stranger := uuid.New()
+
+	t.Run("Customer groups", func(t *testing.T) {
+		group, err := f.resolver.CustomerGroup(ctx, &wholesaler)
+		require.NoError(t, err)
+		assert.Equal(t, "wholesale", group)
+
+		for _, userID := range []*uuid.UUID{nil, &stranger} {
+			group, err := f.resolver.CustomerGroup(ctx, userID)
+			require.NoError(t, err)
+			assert.Equal(t, entity.RetailGroup, group, "guests and accounts without customer data are retail")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		group    string
+		variant  *entity.ProductVariant
+		quantity int
+		want     float64
+	}{
+		{"base price", entity.RetailGroup, nil, 1, 10},
+		{"quantity break", entity.RetailGroup, nil, 10, 9},
+		{"deepest break", entity.RetailGroup, nil, 60, 8},
+		{"a tier never raises the price", entity.RetailGroup, nil, 100, 8},
+		{"group price", "wholesale", nil, 1, 7.5},
+		{"group gets retail breaks too", "wholesale", nil, 60, 7.5},
+		{"variant override", entity.RetailGroup, f.variant, 1, 12},
+		{"lowest applicable tier wins", entity.RetailGroup, f.variant, 10, 9},
+		{"product tiers apply to variants", entity.RetailGroup, f.variant, 50, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			price, err := f.resolver.UnitPrice(ctx, tt.group, f.product, tt.variant, tt.quantity)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, price)
+		})
+	}
+}
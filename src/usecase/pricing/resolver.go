@@ -0,0 +1,88 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Resolver -out ../../internal/testing/servicemocks -name PriceResolver
+
+// Resolver settles the unit price a line sells at from the price lists.
+// Order creation goes through it rather than reading product.Price, so
+// quantity breaks and customer group prices apply the same everywhere.
+type Resolver interface {
+	// CustomerGroup returns the group the account buys at, retail for guests
+	// and accounts without customer data
+	CustomerGroup(ctx context.Context, userID *uuid.UUID) (string, error)
+	// UnitPrice returns the unit price of quantity units of the product, or of
+	// the variant when set, for a customer of group
+	UnitPrice(ctx context.Context, group string, product *entity.Product, variant *entity.ProductVariant, quantity int) (float64, error)
+}
+
+// BasePrice is the price before price lists: the effective price of the
+// variant when set, of the product otherwise
+func BasePrice(product *entity.Product, variant *entity.ProductVariant) (float64, error) {
+	if variant != nil {
+		return variant.GetPrice()
+	}
+	if product == nil {
+		return 0, errors.New("Product not loaded: cannot determine price")
+	}
+	return product.EffectivePrice(), nil
+}
+
+type resolver struct {
+	tierRepo     repository.PriceTierRepository
+	customerRepo repository.CustomerRepository
+}
+
+func NewResolver(tierRepo repository.PriceTierRepository, customerRepo repository.CustomerRepository) Resolver {
+	return &resolver{tierRepo: tierRepo, customerRepo: customerRepo}
+}
+
+func (r *resolver) CustomerGroup(ctx context.Context, userID *uuid.UUID) (string, error) {
+	if userID == nil {
+		return entity.RetailGroup, nil
+	}
+
+	customer, err := r.customerRepo.Get(ctx, *userID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return entity.RetailGroup, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return customer.Group(), nil
+}
+
+// UnitPrice returns the lowest of the base price and the prices of the tiers
+// that apply, so a tier never makes a line dearer than a running sale
+func (r *resolver) UnitPrice(ctx context.Context, group string, product *entity.Product, variant *entity.ProductVariant, quantity int) (float64, error) {
+	price, err := BasePrice(product, variant)
+	if err != nil {
+		return 0, err
+	}
+
+	var productID uuid.UUID
+	var variantID *uuid.UUID
+	if variant != nil {
+		productID, variantID = variant.ProductID, &variant.ID
+	} else {
+		productID = product.ID
+	}
+
+	tiers, err := r.tierRepo.GetByProductID(ctx, productID)
+	if err != nil {
+		return 0, err
+	}
+	for _, tier := range tiers {
+		if tier.AppliesTo(variantID, group, quantity) && tier.Price < price {
+			price = tier.Price
+		}
+	}
+	return price, nil
+}
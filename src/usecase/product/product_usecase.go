@@ -2,45 +2,111 @@ package product
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
 	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+	"github.com/marcofilho/go-ecommerce/src/usecase/translation"
 )
 
+// ErrContentHashMismatch is returned when a conditional update targets a stale version of the product
+var ErrContentHashMismatch = errors.New("Product was modified since it was last read: content hash does not match")
+
+// ErrProductNotFound is returned when the product to change does not exist
+var ErrProductNotFound = entity.NotFoundError("Product not found")
+
+// ErrUnknownAttribute is returned when products are filtered by an attribute code that is not defined
+var ErrUnknownAttribute = entity.ValidationError("Unknown attribute")
+
+// ErrInvalidSort is returned when a listing is sorted by a field products can't be sorted by
+var ErrInvalidSort = entity.ValidationError("Invalid sort field, use name, price, created_at or rating")
+
+// ErrInvalidInclude is returned when a listing asks for a relation products don't have
+var ErrInvalidInclude = entity.ValidationError("Invalid include, use categories, variants, options or attributes")
+
+//go:generate go run ../../internal/tools/mockgen -interface ProductService -out ../../internal/testing/servicemocks
+
 type ProductService interface {
-	CreateProduct(ctx context.Context, name, description string, price float64, quantity int) (*entity.Product, error)
-	GetProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
-	ListProducts(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error)
-	UpdateProduct(ctx context.Context, id uuid.UUID, name, description string, price float64, quantity int) (*entity.Product, error)
+	// CreateProduct creates a product in status, active when empty
+	CreateProduct(ctx context.Context, name, description string, price float64, quantity int, measure entity.UnitMeasure, status entity.ProductStatus) (*entity.Product, error)
+	// GetProduct returns a product with its translation to the first of the
+	// locales it has one for, see translation.Localizer. With publishedOnly,
+	// drafts and archived products are not found.
+	GetProduct(ctx context.Context, id uuid.UUID, locales []string, publishedOnly bool) (*entity.Product, error)
+	// ListProducts returns a page of products in status, or in any status when
+	// empty, translated like GetProduct. With availableOnly, products outside
+	// their availability window are left out. attributes maps attribute codes
+	// to the value products must have, e.g. {"material": "cotton"}. sort
+	// orders the page, unsorted when its field is empty. include lists the
+	// relations loaded with the products, all of them when nil.
+	ListProducts(ctx context.Context, page, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, include []repository.ProductRelation, locales []string) ([]*entity.Product, int, error)
+	// ListProductSummaries lists products like ListProducts with only their ID,
+	// name, prices, quantity, status and rating, see ProductRepository.ListProductsSummary
+	ListProductSummaries(ctx context.Context, page, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, locales []string) ([]*entity.Product, int, error)
+	// UpdateProduct replaces the product content. When expectedHash is set the
+	// update only applies if it matches the current content hash.
+	UpdateProduct(ctx context.Context, id uuid.UUID, name, description string, price float64, quantity int, measure entity.UnitMeasure, expectedHash string) (*entity.Product, error)
 	DeleteProduct(ctx context.Context, id uuid.UUID) error
+	SetHighDemandMode(ctx context.Context, id uuid.UUID, enabled bool) (*entity.Product, error)
+	// SetCost records the unit cost search ranking computes margins from, nil clears it
+	SetCost(ctx context.Context, id uuid.UUID, cost *float64) (*entity.Product, error)
+	// PublishProduct puts a draft or archived product on sale
+	PublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+	// UnpublishProduct takes a product off sale and back to draft
+	UnpublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+	// ArchiveProduct retires a product without deleting it
+	ArchiveProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error)
+	// SetAvailability schedules when the product can be bought, nil leaves
+	// that end of the window open
+	SetAvailability(ctx context.Context, id uuid.UUID, from, until *time.Time) (*entity.Product, error)
+	// SetPurchaseLimits caps the units of the product one order and one
+	// customer can have, 0 lifts that limit
+	SetPurchaseLimits(ctx context.Context, id uuid.UUID, maxPerOrder, maxPerCustomer int) (*entity.Product, error)
 }
 
 type Services interface {
 	GetAuditService() audit.AuditService
+	GetEventPublisher() events.Publisher
+	GetWebhookDispatcher() events.Dispatcher
+	GetStockRecorder() stock.Recorder
+	GetPriceBook() pricehistory.Book
+	GetLocalizer() translation.Localizer
 }
 
 type UseCase struct {
-	repo     repository.ProductRepository
-	services Services
+	repo          repository.ProductRepository
+	attributeRepo repository.AttributeRepository
+	services      Services
 }
 
-func NewUseCase(repo repository.ProductRepository, services Services) *UseCase {
+func NewUseCase(repo repository.ProductRepository, attributeRepo repository.AttributeRepository, services Services) *UseCase {
 	return &UseCase{
-		repo:     repo,
-		services: services,
+		repo:          repo,
+		attributeRepo: attributeRepo,
+		services:      services,
 	}
 }
 
-func (uc *UseCase) CreateProduct(ctx context.Context, name, description string, price float64, quantity int) (*entity.Product, error) {
+func (uc *UseCase) CreateProduct(ctx context.Context, name, description string, price float64, quantity int, measure entity.UnitMeasure, status entity.ProductStatus) (*entity.Product, error) {
+	if status == "" {
+		status = entity.ProductActive
+	}
+
 	product := &entity.Product{
-		ID:          uuid.New(),
+		ID:          entity.NewID(),
 		Name:        name,
 		Description: description,
 		Price:       price,
 		Quantity:    quantity,
+		Measure:     measure,
+		Status:      status,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -55,15 +121,68 @@ func (uc *UseCase) CreateProduct(ctx context.Context, name, description string,
 
 	// Log product creation
 	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE", "Product", product.ID, nil, product)
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, events.ProductCreated, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.ProductCreated, events.NewProductData(product))
+	uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(product.ID, nil, entity.StockAdjustment, 0, product.Quantity, "initial stock"))
+	uc.services.GetPriceBook().Record(ctx, entity.NewPriceChange(product.ID, nil, nil, &product.Price, nil))
+
+	return product, nil
+}
 
+func (uc *UseCase) GetProduct(ctx context.Context, id uuid.UUID, locales []string, publishedOnly bool) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if publishedOnly && !product.IsPublished() {
+		return nil, ErrProductNotFound
+	}
+
+	if err := uc.services.GetPriceBook().Attach(ctx, product); err != nil {
+		return nil, err
+	}
+	if err := uc.services.GetLocalizer().Localize(ctx, locales, product); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
-func (uc *UseCase) GetProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
-	return uc.repo.GetByID(ctx, id)
+func (uc *UseCase) ListProducts(ctx context.Context, page, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, include []repository.ProductRelation, locales []string) ([]*entity.Product, int, error) {
+	for _, relation := range include {
+		if !relation.IsValid() {
+			return nil, 0, ErrInvalidInclude
+		}
+	}
+
+	page, pageSize, filters, err := uc.listFilters(ctx, page, pageSize, inStockOnly, status, availableOnly, attributes, sort)
+	if err != nil {
+		return nil, 0, err
+	}
+	filters.Include = include
+
+	products, total, err := uc.repo.GetAll(ctx, page, pageSize, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return uc.present(ctx, locales, products, total)
+}
+
+func (uc *UseCase) ListProductSummaries(ctx context.Context, page, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort, locales []string) ([]*entity.Product, int, error) {
+	page, pageSize, filters, err := uc.listFilters(ctx, page, pageSize, inStockOnly, status, availableOnly, attributes, sort)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	products, total, err := uc.repo.ListProductsSummary(ctx, page, pageSize, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+	return uc.present(ctx, locales, products, total)
 }
 
-func (uc *UseCase) ListProducts(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+// listFilters checks the criteria of a listing and turns them into the
+// repository's filters, along with the page bounded to its defaults
+func (uc *UseCase) listFilters(ctx context.Context, page, pageSize int, inStockOnly bool, status entity.ProductStatus, availableOnly bool, attributes map[string]string, sort repository.ProductSort) (int, int, repository.ProductFilters, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -71,15 +190,60 @@ func (uc *UseCase) ListProducts(ctx context.Context, page, pageSize int, inStock
 		pageSize = 10
 	}
 
-	return uc.repo.GetAll(ctx, page, pageSize, inStockOnly)
+	if status != "" && !status.IsValid() {
+		return 0, 0, repository.ProductFilters{}, entity.ValidationError("Invalid product status. Must be 'draft', 'active' or 'archived'")
+	}
+
+	if sort.Field != "" && !sort.IsValid() {
+		return 0, 0, repository.ProductFilters{}, ErrInvalidSort
+	}
+
+	filters := repository.ProductFilters{InStockOnly: inStockOnly, Status: status, Sort: sort}
+	if availableOnly {
+		now := time.Now()
+		filters.AvailableAt = &now
+	}
+	for code, raw := range attributes {
+		definition, err := uc.attributeRepo.GetDefinitionByCode(ctx, code)
+		if err != nil {
+			return 0, 0, repository.ProductFilters{}, fmt.Errorf("%w: %s", ErrUnknownAttribute, code)
+		}
+
+		value, err := definition.ParseValue(raw)
+		if err != nil {
+			return 0, 0, repository.ProductFilters{}, fmt.Errorf("%w for %s", err, code)
+		}
+
+		var filter entity.ProductAttribute
+		if err := filter.SetValue(definition, value); err != nil {
+			return 0, 0, repository.ProductFilters{}, fmt.Errorf("%w for %s", err, code)
+		}
+		filters.Attributes = append(filters.Attributes, filter)
+	}
+	return page, pageSize, filters, nil
+}
+
+// present attaches the price schedules and translations a listing shows
+func (uc *UseCase) present(ctx context.Context, locales []string, products []*entity.Product, total int) ([]*entity.Product, int, error) {
+	if err := uc.services.GetPriceBook().Attach(ctx, products...); err != nil {
+		return nil, 0, err
+	}
+	if err := uc.services.GetLocalizer().Localize(ctx, locales, products...); err != nil {
+		return nil, 0, err
+	}
+	return products, total, nil
 }
 
-func (uc *UseCase) UpdateProduct(ctx context.Context, id uuid.UUID, name, description string, price float64, quantity int) (*entity.Product, error) {
+func (uc *UseCase) UpdateProduct(ctx context.Context, id uuid.UUID, name, description string, price float64, quantity int, measure entity.UnitMeasure, expectedHash string) (*entity.Product, error) {
 	product, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
+	if expectedHash != "" && expectedHash != product.ContentHash() {
+		return nil, ErrContentHashMismatch
+	}
+
 	// Store original state for audit
 	original := *product
 
@@ -87,6 +251,7 @@ func (uc *UseCase) UpdateProduct(ctx context.Context, id uuid.UUID, name, descri
 	product.Description = description
 	product.Price = price
 	product.Quantity = quantity
+	product.Measure = measure
 	product.UpdatedAt = time.Now()
 
 	if err := product.Validate(); err != nil {
@@ -99,6 +264,10 @@ func (uc *UseCase) UpdateProduct(ctx context.Context, id uuid.UUID, name, descri
 
 	// Log product update
 	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE", "Product", product.ID, &original, product)
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, events.ProductUpdated, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.ProductUpdated, events.NewProductData(product))
+	uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(product.ID, nil, entity.StockAdjustment, original.Quantity, product.Quantity, "product update"))
+	uc.services.GetPriceBook().Record(ctx, entity.NewPriceChange(product.ID, nil, &original.Price, &product.Price, nil))
 
 	return product, nil
 }
@@ -116,6 +285,158 @@ func (uc *UseCase) DeleteProduct(ctx context.Context, id uuid.UUID) error {
 
 	// Log product deletion
 	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE", "Product", id, product, nil)
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, events.ProductArchived, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.ProductArchived, events.NewProductData(product))
 
 	return nil
 }
+
+// SetHighDemandMode turns the fair purchase queue on or off for a product
+func (uc *UseCase) SetHighDemandMode(ctx context.Context, id uuid.UUID, enabled bool) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original := product.HighDemandMode
+	product.HighDemandMode = enabled
+	product.UpdatedAt = time.Now()
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE_HIGH_DEMAND_MODE", "Product", product.ID,
+		map[string]interface{}{"high_demand_mode": original},
+		map[string]interface{}{"high_demand_mode": enabled})
+
+	return product, nil
+}
+
+// SetCost records the unit cost of a product, kept out of public responses
+func (uc *UseCase) SetCost(ctx context.Context, id uuid.UUID, cost *float64) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	original := product.Cost
+	product.Cost = cost
+	if err := product.Validate(); err != nil {
+		product.Cost = original
+		return nil, err
+	}
+	product.UpdatedAt = time.Now()
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE_COST", "Product", product.ID,
+		map[string]interface{}{"cost": original},
+		map[string]interface{}{"cost": cost})
+
+	return product, nil
+}
+
+// SetAvailability schedules the window the product can be bought in, e.g. a
+// flash sale or a seasonal item
+func (uc *UseCase) SetAvailability(ctx context.Context, id uuid.UUID, from, until *time.Time) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	originalFrom, originalUntil := product.AvailableFrom, product.AvailableUntil
+	product.AvailableFrom, product.AvailableUntil = from, until
+	if err := product.Validate(); err != nil {
+		product.AvailableFrom, product.AvailableUntil = originalFrom, originalUntil
+		return nil, err
+	}
+	product.UpdatedAt = time.Now()
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE_AVAILABILITY", "Product", product.ID,
+		map[string]interface{}{"available_from": originalFrom, "available_until": originalUntil},
+		map[string]interface{}{"available_from": from, "available_until": until})
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, events.ProductUpdated, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.ProductUpdated, events.NewProductData(product))
+
+	return product, nil
+}
+
+// SetPurchaseLimits caps how many units of the product can be bought, e.g.
+// for a limited-edition drop
+func (uc *UseCase) SetPurchaseLimits(ctx context.Context, id uuid.UUID, maxPerOrder, maxPerCustomer int) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	originalPerOrder, originalPerCustomer := product.MaxPerOrder, product.MaxPerCustomer
+	product.MaxPerOrder, product.MaxPerCustomer = maxPerOrder, maxPerCustomer
+	if err := product.Validate(); err != nil {
+		product.MaxPerOrder, product.MaxPerCustomer = originalPerOrder, originalPerCustomer
+		return nil, err
+	}
+	product.UpdatedAt = time.Now()
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE_PURCHASE_LIMITS", "Product", product.ID,
+		map[string]interface{}{"max_per_order": originalPerOrder, "max_per_customer": originalPerCustomer},
+		map[string]interface{}{"max_per_order": maxPerOrder, "max_per_customer": maxPerCustomer})
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, events.ProductUpdated, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, events.ProductUpdated, events.NewProductData(product))
+
+	return product, nil
+}
+
+func (uc *UseCase) PublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	return uc.changeStatus(ctx, id, "PUBLISH", (*entity.Product).Publish)
+}
+
+func (uc *UseCase) UnpublishProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	return uc.changeStatus(ctx, id, "UNPUBLISH", (*entity.Product).Unpublish)
+}
+
+func (uc *UseCase) ArchiveProduct(ctx context.Context, id uuid.UUID) (*entity.Product, error) {
+	return uc.changeStatus(ctx, id, "ARCHIVE", (*entity.Product).Archive)
+}
+
+// changeStatus moves a product through its publishing workflow with
+// transition and tells subscribers: archiving retires the product like a
+// deletion does, publishing and unpublishing update it.
+func (uc *UseCase) changeStatus(ctx context.Context, id uuid.UUID, action string, transition func(*entity.Product) error) (*entity.Product, error) {
+	product, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original := product.Status
+	if err := transition(product); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, product); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, action, "Product", product.ID,
+		map[string]interface{}{"status": original},
+		map[string]interface{}{"status": product.Status})
+
+	event := events.ProductUpdated
+	if product.Status == entity.ProductArchived {
+		event = events.ProductArchived
+	}
+	uc.services.GetEventPublisher().PublishProductEvent(ctx, event, product)
+	uc.services.GetWebhookDispatcher().Dispatch(ctx, event, events.NewProductData(product))
+
+	return product, nil
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
 	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
 )
 
@@ -20,6 +22,7 @@ type mockProductRepository struct {
 	getAllErr    error
 	getAllResult []*entity.Product
 	getAllTotal  int
+	lastFilters  repository.ProductFilters
 }
 
 func newMockRepo() *mockProductRepository {
@@ -47,7 +50,18 @@ func (m *mockProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent
 	return p, nil
 }
 
-func (m *mockProductRepository) GetAll(ctx context.Context, page, pageSize int, inStockOnly bool) ([]*entity.Product, int, error) {
+func (m *mockProductRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Product, error) {
+	var found []*entity.Product
+	for _, id := range ids {
+		if item, err := m.GetByID(ctx, id); err == nil {
+			found = append(found, item)
+		}
+	}
+	return found, nil
+}
+
+func (m *mockProductRepository) GetAll(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	m.lastFilters = filters
 	if m.getAllErr != nil {
 		return nil, 0, m.getAllErr
 	}
@@ -56,13 +70,18 @@ func (m *mockProductRepository) GetAll(ctx context.Context, page, pageSize int,
 	}
 	var result []*entity.Product
 	for _, p := range m.products {
-		if !inStockOnly || p.Quantity > 0 {
+		if !filters.InStockOnly || p.Quantity > 0 {
 			result = append(result, p)
 		}
 	}
 	return result, len(result), nil
 }
 
+// ListProductsSummary serves summaries from the same products as GetAll
+func (m *mockProductRepository) ListProductsSummary(ctx context.Context, page, pageSize int, filters repository.ProductFilters) ([]*entity.Product, int, error) {
+	return m.GetAll(ctx, page, pageSize, filters)
+}
+
 func (m *mockProductRepository) Update(ctx context.Context, product *entity.Product) error {
 	if m.updateErr != nil {
 		return m.updateErr
@@ -85,11 +104,45 @@ func (m *mockProductRepository) Delete(ctx context.Context, id uuid.UUID) error
 	return nil
 }
 
+type mockAttributeRepository struct {
+	definitions map[string]*entity.AttributeDefinition
+}
+
+func (m *mockAttributeRepository) CreateDefinition(ctx context.Context, definition *entity.AttributeDefinition) error {
+	return nil
+}
+
+func (m *mockAttributeRepository) GetDefinitionByID(ctx context.Context, id uuid.UUID) (*entity.AttributeDefinition, error) {
+	return nil, errors.New("not found")
+}
+
+func (m *mockAttributeRepository) GetDefinitionByCode(ctx context.Context, code string) (*entity.AttributeDefinition, error) {
+	d, ok := m.definitions[code]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return d, nil
+}
+
+func (m *mockAttributeRepository) ListDefinitions(ctx context.Context) ([]*entity.AttributeDefinition, error) {
+	return nil, nil
+}
+
+func (m *mockAttributeRepository) SetProductValue(ctx context.Context, value *entity.ProductAttribute) error {
+	return nil
+}
+
+func (m *mockAttributeRepository) DeleteProductValue(ctx context.Context, productID, attributeID uuid.UUID) error {
+	return nil
+}
+
+var _ repository.AttributeRepository = (*mockAttributeRepository)(nil)
+
 func TestCreateProduct_Success(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
-	product, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 10)
+	product, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 10, entity.UnitMeasure{}, "")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -100,9 +153,9 @@ func TestCreateProduct_Success(t *testing.T) {
 
 func TestCreateProduct_ValidationError(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
-	_, err := uc.CreateProduct(context.Background(), "", "Desc", 100, 10)
+	_, err := uc.CreateProduct(context.Background(), "", "Desc", 100, 10, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected validation error for empty name")
 	}
@@ -110,12 +163,12 @@ func TestCreateProduct_ValidationError(t *testing.T) {
 
 func TestGetProduct_Success(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
-	repo.products[id] = &entity.Product{ID: id, Name: "Test"}
+	repo.products[id] = &entity.Product{ID: id, Name: "Test", Status: entity.ProductActive}
 
-	product, err := uc.GetProduct(context.Background(), id)
+	product, err := uc.GetProduct(context.Background(), id, nil, true)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -126,7 +179,7 @@ func TestGetProduct_Success(t *testing.T) {
 
 func TestListProducts_Success(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	repo.getAllResult = []*entity.Product{
 		{ID: uuid.New(), Name: "P1", Quantity: 5},
@@ -134,7 +187,7 @@ func TestListProducts_Success(t *testing.T) {
 	}
 	repo.getAllTotal = 2
 
-	products, total, err := uc.ListProducts(context.Background(), 1, 10, false)
+	products, total, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, nil, repository.ProductSort{}, nil, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -146,14 +199,37 @@ func TestListProducts_Success(t *testing.T) {
 	}
 }
 
+func TestListProducts_AttributeFilters(t *testing.T) {
+	repo := newMockRepo()
+	material := &entity.AttributeDefinition{ID: uuid.New(), Code: "material", Name: "Material", Type: entity.AttributeText}
+	weight := &entity.AttributeDefinition{ID: uuid.New(), Code: "weight-kg", Name: "Weight (kg)", Type: entity.AttributeNumber}
+	attributes := &mockAttributeRepository{definitions: map[string]*entity.AttributeDefinition{"material": material, "weight-kg": weight}}
+	uc := NewUseCase(repo, attributes, &mockServices.MockServices{})
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, map[string]string{"material": "Cotton"}, repository.ProductSort{}, nil, nil); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(repo.lastFilters.Attributes) != 1 || repo.lastFilters.Attributes[0].AttributeID != material.ID || *repo.lastFilters.Attributes[0].TextValue != "Cotton" {
+		t.Errorf("expected a material filter, got %+v", repo.lastFilters.Attributes)
+	}
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, map[string]string{"color": "red"}, repository.ProductSort{}, nil, nil); !errors.Is(err, ErrUnknownAttribute) {
+		t.Errorf("expected ErrUnknownAttribute, got %v", err)
+	}
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, map[string]string{"weight-kg": "heavy"}, repository.ProductSort{}, nil, nil); !errors.Is(err, entity.ErrInvalidAttributeValue) {
+		t.Errorf("expected ErrInvalidAttributeValue, got %v", err)
+	}
+}
+
 func TestUpdateProduct_Success(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
 	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
 
-	updated, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10)
+	updated, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10, entity.UnitMeasure{}, "")
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -162,9 +238,80 @@ func TestUpdateProduct_Success(t *testing.T) {
 	}
 }
 
+func TestUpdateProduct_RecordsPriceChange(t *testing.T) {
+	repo := newMockRepo()
+	book := &mockServices.MockPriceBook{}
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{PriceBook: book})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
+
+	if _, err := uc.UpdateProduct(context.Background(), id, "New", "", 120, 5, entity.UnitMeasure{}, ""); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if len(book.Changes) != 1 {
+		t.Fatalf("expected 1 price change, got %d", len(book.Changes))
+	}
+	change := book.Changes[0]
+	if *change.PreviousPrice != 100 || *change.Price != 120 || change.Scheduled {
+		t.Errorf("unexpected price change %+v", change)
+	}
+}
+
+type recordingPublisher struct {
+	events []string
+}
+
+func (p *recordingPublisher) PublishProductEvent(ctx context.Context, eventType string, product *entity.Product) error {
+	p.events = append(p.events, eventType)
+	return nil
+}
+
+func TestUpdateProduct_MatchingContentHash(t *testing.T) {
+	repo := newMockRepo()
+	publisher := &recordingPublisher{}
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{EventPublisher: publisher})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
+	hash := repo.products[id].ContentHash()
+
+	updated, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10, entity.UnitMeasure{}, hash)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if updated.ContentHash() == hash {
+		t.Error("expected content hash to change after update")
+	}
+	if len(publisher.events) != 1 || publisher.events[0] != events.ProductUpdated {
+		t.Errorf("expected a product.updated event, got %v", publisher.events)
+	}
+}
+
+func TestUpdateProduct_StaleContentHash(t *testing.T) {
+	repo := newMockRepo()
+	publisher := &recordingPublisher{}
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{EventPublisher: publisher})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
+
+	_, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10, entity.UnitMeasure{}, "stale")
+	if !errors.Is(err, ErrContentHashMismatch) {
+		t.Fatalf("expected ErrContentHashMismatch, got %v", err)
+	}
+	if repo.products[id].Name != "Old" {
+		t.Error("expected product to be left untouched")
+	}
+	if len(publisher.events) != 0 {
+		t.Errorf("expected no events, got %v", publisher.events)
+	}
+}
+
 func TestDeleteProduct_Success(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
 	repo.products[id] = &entity.Product{ID: id}
@@ -181,9 +328,9 @@ func TestDeleteProduct_Success(t *testing.T) {
 func TestCreateProduct_RepositoryError(t *testing.T) {
 	repo := newMockRepo()
 	repo.createErr = errors.New("database error")
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
-	_, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 10)
+	_, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 10, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected error from repository")
 	}
@@ -191,9 +338,9 @@ func TestCreateProduct_RepositoryError(t *testing.T) {
 
 func TestCreateProduct_ZeroQuantityError(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
-	_, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 0)
+	_, err := uc.CreateProduct(context.Background(), "Laptop", "Gaming", 999.99, 0, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected validation error for zero quantity")
 	}
@@ -201,22 +348,22 @@ func TestCreateProduct_ZeroQuantityError(t *testing.T) {
 
 func TestListProducts_PaginationDefaults(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	// Test page < 1 defaults to 1
-	_, _, err := uc.ListProducts(context.Background(), 0, 10, false)
+	_, _, err := uc.ListProducts(context.Background(), 0, 10, false, "", false, nil, repository.ProductSort{}, nil, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
 	// Test page_size < 1 defaults to 10
-	_, _, err = uc.ListProducts(context.Background(), 1, 0, false)
+	_, _, err = uc.ListProducts(context.Background(), 1, 0, false, "", false, nil, repository.ProductSort{}, nil, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
 
 	// Test page_size > 100 defaults to 10
-	_, _, err = uc.ListProducts(context.Background(), 1, 150, false)
+	_, _, err = uc.ListProducts(context.Background(), 1, 150, false, "", false, nil, repository.ProductSort{}, nil, nil)
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
@@ -224,10 +371,10 @@ func TestListProducts_PaginationDefaults(t *testing.T) {
 
 func TestUpdateProduct_NotFound(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
-	_, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10)
+	_, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected not found error")
 	}
@@ -235,12 +382,12 @@ func TestUpdateProduct_NotFound(t *testing.T) {
 
 func TestUpdateProduct_ValidationError(t *testing.T) {
 	repo := newMockRepo()
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
 	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
 
-	_, err := uc.UpdateProduct(context.Background(), id, "", "Updated", 200, 10)
+	_, err := uc.UpdateProduct(context.Background(), id, "", "Updated", 200, 10, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected validation error for empty name")
 	}
@@ -249,15 +396,213 @@ func TestUpdateProduct_ValidationError(t *testing.T) {
 func TestUpdateProduct_RepositoryError(t *testing.T) {
 	repo := newMockRepo()
 	repo.updateErr = errors.New("database error")
-	uc := NewUseCase(repo, &mockServices.MockServices{})
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
 
 	id := uuid.New()
 	repo.products[id] = &entity.Product{ID: id, Name: "Old", Price: 100, Quantity: 5}
 
-	_, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10)
+	_, err := uc.UpdateProduct(context.Background(), id, "New", "Updated", 200, 10, entity.UnitMeasure{}, "")
 	if err == nil {
 		t.Error("expected repository error")
 	}
 }
 
+func TestSetCost(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{AuditService: &mockServices.MockAuditService{}})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Lamp", Price: 100, Quantity: 5}
+
+	cost := 40.0
+	product, err := uc.SetCost(context.Background(), id, &cost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.Cost == nil || *product.Cost != 40 {
+		t.Errorf("expected cost 40, got %v", product.Cost)
+	}
+
+	negative := -1.0
+	if _, err := uc.SetCost(context.Background(), id, &negative); err == nil {
+		t.Error("expected a negative cost to be rejected")
+	}
+
+	product, err = uc.SetCost(context.Background(), id, nil)
+	if err != nil || product.Cost != nil {
+		t.Errorf("expected the cost to be cleared, got %v, %v", product.Cost, err)
+	}
+}
+
 var _ repository.ProductRepository = (*mockProductRepository)(nil)
+
+func TestGetProduct_HidesDrafts(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Lamp", Status: entity.ProductDraft}
+
+	if _, err := uc.GetProduct(context.Background(), id, nil, true); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected a draft to be hidden from the public, got %v", err)
+	}
+	if _, err := uc.GetProduct(context.Background(), id, nil, false); err != nil {
+		t.Errorf("expected admins to see the draft, got %v", err)
+	}
+}
+
+func TestListProducts_FiltersByStatus(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, entity.ProductDraft, false, nil, repository.ProductSort{}, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastFilters.Status != entity.ProductDraft {
+		t.Errorf("expected the status filter to reach the repository, got %q", repo.lastFilters.Status)
+	}
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "hidden", false, nil, repository.ProductSort{}, nil, nil); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected an unknown status to be rejected, got %v", err)
+	}
+}
+
+func TestListProducts_SortsByRating(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	sort := repository.ProductSort{Field: repository.ProductSortRating, Descending: true}
+	if _, _, err := uc.ListProductSummaries(context.Background(), 1, 10, false, "", false, nil, sort, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastFilters.Sort != sort {
+		t.Errorf("expected the sort to reach the repository, got %+v", repo.lastFilters.Sort)
+	}
+
+	unknown := repository.ProductSort{Field: "popularity"}
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, nil, unknown, nil, nil); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("expected an unknown sort field to be rejected, got %v", err)
+	}
+}
+
+func TestCreateProduct_AsDraft(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	product, err := uc.CreateProduct(context.Background(), "Lamp", "", 50, 5, entity.UnitMeasure{}, entity.ProductDraft)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if product.Status != entity.ProductDraft {
+		t.Errorf("expected a draft, got %s", product.Status)
+	}
+
+	product, _ = uc.CreateProduct(context.Background(), "Desk", "", 50, 5, entity.UnitMeasure{}, "")
+	if product.Status != entity.ProductActive {
+		t.Errorf("expected products to be active by default, got %s", product.Status)
+	}
+}
+
+func TestPublishWorkflow(t *testing.T) {
+	repo := newMockRepo()
+	publisher := &recordingPublisher{}
+	dispatcher := &mockServices.MockWebhookDispatcher{}
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{EventPublisher: publisher, WebhookDispatcher: dispatcher})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Lamp", Price: 50, Quantity: 5, Status: entity.ProductDraft}
+
+	if _, err := uc.UnpublishProduct(context.Background(), id); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a draft not to be unpublished, got %v", err)
+	}
+
+	product, err := uc.PublishProduct(context.Background(), id)
+	if err != nil || product.Status != entity.ProductActive {
+		t.Fatalf("expected the product to be published, got %v, %v", product, err)
+	}
+	if _, err := uc.UnpublishProduct(context.Background(), id); err != nil || repo.products[id].Status != entity.ProductDraft {
+		t.Errorf("expected the product to be back to draft, got %v", err)
+	}
+	if _, err := uc.ArchiveProduct(context.Background(), id); err != nil || repo.products[id].Status != entity.ProductArchived {
+		t.Errorf("expected the product to be archived, got %v", err)
+	}
+
+	want := []string{events.ProductUpdated, events.ProductUpdated, events.ProductArchived}
+	if len(publisher.events) != len(want) || len(dispatcher.Events) != len(want) {
+		t.Fatalf("expected events %v, got %v and %v", want, publisher.events, dispatcher.Events)
+	}
+	for i := range want {
+		if publisher.events[i] != want[i] || dispatcher.Events[i] != want[i] {
+			t.Errorf("expected events %v, got %v and %v", want, publisher.events, dispatcher.Events)
+		}
+	}
+}
+
+func TestSetAvailability(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Lamp", Price: 50, Quantity: 5, Status: entity.ProductActive}
+
+	from := time.Date(2026, 11, 27, 0, 0, 0, 0, time.UTC)
+	until := from.Add(72 * time.Hour)
+	product, err := uc.SetAvailability(context.Background(), id, &from, &until)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !product.AvailableFrom.Equal(from) || !product.AvailableUntil.Equal(until) {
+		t.Errorf("expected the window to be set, got %v - %v", product.AvailableFrom, product.AvailableUntil)
+	}
+
+	if _, err := uc.SetAvailability(context.Background(), id, &until, &from); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a window ending before it starts to be rejected, got %v", err)
+	}
+	if !repo.products[id].AvailableFrom.Equal(from) {
+		t.Errorf("expected a rejected window to leave the product as it was, got %v", repo.products[id].AvailableFrom)
+	}
+
+	product, err = uc.SetAvailability(context.Background(), id, nil, nil)
+	if err != nil || product.AvailableFrom != nil || product.AvailableUntil != nil {
+		t.Errorf("expected the window to be cleared, got %v - %v, %v", product.AvailableFrom, product.AvailableUntil, err)
+	}
+}
+
+func TestListProducts_AvailableOnly(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", true, nil, repository.ProductSort{}, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastFilters.AvailableAt == nil {
+		t.Error("expected the availability filter to reach the repository")
+	}
+
+	if _, _, err := uc.ListProducts(context.Background(), 1, 10, false, "", false, nil, repository.ProductSort{}, nil, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.lastFilters.AvailableAt != nil {
+		t.Errorf("expected no availability filter, got %v", repo.lastFilters.AvailableAt)
+	}
+}
+
+func TestSetPurchaseLimits(t *testing.T) {
+	repo := newMockRepo()
+	uc := NewUseCase(repo, &mockAttributeRepository{}, &mockServices.MockServices{})
+
+	id := uuid.New()
+	repo.products[id] = &entity.Product{ID: id, Name: "Sneaker Drop", Price: 50, Quantity: 5, Status: entity.ProductActive}
+
+	product, err := uc.SetPurchaseLimits(context.Background(), id, 1, 2)
+	if err != nil || product.MaxPerOrder != 1 || product.MaxPerCustomer != 2 {
+		t.Fatalf("expected limits 1 and 2, got %+v, %v", product, err)
+	}
+
+	if _, err := uc.SetPurchaseLimits(context.Background(), id, -1, 0); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a negative limit to be rejected, got %v", err)
+	}
+	if repo.products[id].MaxPerOrder != 1 {
+		t.Errorf("expected a rejected limit to leave the product as it was, got %d", repo.products[id].MaxPerOrder)
+	}
+}
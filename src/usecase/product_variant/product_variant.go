@@ -2,44 +2,98 @@ package productvariant
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricehistory"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
 )
 
+var (
+	ErrProductNotFound    = entity.NotFoundError("Product not found")
+	ErrOptionNotFound     = entity.NotFoundError("Product option not found")
+	ErrOptionExists       = entity.ConflictError("Product already has an option with this name")
+	ErrOptionValueExists  = entity.ConflictError("Option already has this value")
+	ErrOptionInUse        = entity.ConflictError("Option is used by existing variants")
+	ErrProductHasVariants = entity.ConflictError("Options can't be added to a product that already has variants")
+	ErrSKUExists          = entity.ConflictError("A variant with this SKU already exists")
+	ErrCombinationExists  = entity.ConflictError("A variant with this combination of options already exists")
+	ErrVariantNotFound    = entity.NotFoundError("Product variant not found")
+	ErrVariantMismatch    = entity.ValidationError("Stock can only be transferred between variants of the same product")
+)
+
+// VariantInput holds the fields of a variant that can be set on create and update.
+// Options maps each product option name to the chosen value, e.g. {"Size": "L"}.
+// An empty SKU is generated from the option values.
+type VariantInput struct {
+	SKU           string
+	Options       map[string]string
+	PriceOverride *float64
+	Quantity      int
+}
+
+// TransferInput describes a stock transfer out of a variant. Units go to
+// ToVariantID, or to the base product when it is nil. With FromProduct set the
+// direction is reversed: units come from the base product into the variant.
+type TransferInput struct {
+	ToVariantID *uuid.UUID
+	FromProduct bool
+	Quantity    int
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface ProductVariantService -out ../../internal/testing/servicemocks
+
 type ProductVariantService interface {
-	CreateProductVariant(ctx context.Context, productID uuid.UUID, variantName, variantValue string, priceOverride *float64, quantity int) (*entity.ProductVariant, error)
+	CreateProductVariant(ctx context.Context, productID uuid.UUID, input VariantInput) (*entity.ProductVariant, error)
 	GetProductVariant(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error)
 	ListProductVariants(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error)
-	UpdateProductVariant(ctx context.Context, id uuid.UUID, variantName, variantValue string, priceOverride *float64, quantity int) (*entity.ProductVariant, error)
+	UpdateProductVariant(ctx context.Context, id uuid.UUID, input VariantInput) (*entity.ProductVariant, error)
 	DeleteProductVariant(ctx context.Context, id uuid.UUID) error
+	TransferStock(ctx context.Context, variantID uuid.UUID, input TransferInput) (*entity.StockMovement, *entity.StockMovement, error)
+
+	CreateOption(ctx context.Context, productID uuid.UUID, name string, values []string) (*entity.ProductOption, error)
+	ListOptions(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error)
+	AddOptionValue(ctx context.Context, productID, optionID uuid.UUID, value string) (*entity.ProductOption, error)
+	DeleteOption(ctx context.Context, productID, optionID uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetStockRecorder() stock.Recorder
+	GetPriceBook() pricehistory.Book
 }
 
 type UseCase struct {
-	repo repository.ProductVariantRepository
+	repo        repository.ProductVariantRepository
+	optionRepo  repository.ProductOptionRepository
+	productRepo repository.ProductRepository
+	services    Services
 }
 
-func NewUseCase(repo repository.ProductVariantRepository) *UseCase {
+func NewUseCase(repo repository.ProductVariantRepository, optionRepo repository.ProductOptionRepository, productRepo repository.ProductRepository, services Services) *UseCase {
 	return &UseCase{
-		repo: repo,
+		repo:        repo,
+		optionRepo:  optionRepo,
+		productRepo: productRepo,
+		services:    services,
 	}
 }
 
-func (uc *UseCase) CreateProductVariant(ctx context.Context, productID uuid.UUID, variantName, variantValue string, priceOverride *float64, quantity int) (*entity.ProductVariant, error) {
+func (uc *UseCase) CreateProductVariant(ctx context.Context, productID uuid.UUID, input VariantInput) (*entity.ProductVariant, error) {
 	productVariant := &entity.ProductVariant{
-		ID:             uuid.New(),
+		ID:             entity.NewID(),
 		ProductID:      productID,
-		VariantName:    variantName,
-		VariantValue:   variantValue,
-		Price_Override: priceOverride,
-		Quantity:       quantity,
+		Price_Override: input.PriceOverride,
+		Quantity:       input.Quantity,
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 	}
 
-	if err := productVariant.ValidateForCreation(); err != nil {
+	if err := uc.applyInput(ctx, productVariant, input); err != nil {
 		return nil, err
 	}
 
@@ -47,11 +101,23 @@ func (uc *UseCase) CreateProductVariant(ctx context.Context, productID uuid.UUID
 		return nil, err
 	}
 
+	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE", "ProductVariant", productVariant.ID, nil, productVariant)
+	uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(productID, &productVariant.ID, entity.StockAdjustment, 0, input.Quantity, "initial stock"))
+	uc.services.GetPriceBook().Record(ctx, entity.NewPriceChange(productID, &productVariant.ID, nil, productVariant.Price_Override, nil))
+
 	return productVariant, nil
 }
 
 func (uc *UseCase) GetProductVariant(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
-	return uc.repo.GetByID(ctx, id)
+	variant, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.services.GetPriceBook().Attach(ctx, variant.Product); err != nil {
+		return nil, err
+	}
+	return variant, nil
 }
 
 func (uc *UseCase) ListProductVariants(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error) {
@@ -62,22 +128,34 @@ func (uc *UseCase) ListProductVariants(ctx context.Context, productID uuid.UUID,
 		pageSize = 10
 	}
 
-	return uc.repo.GetAllByProductID(ctx, productID, page, pageSize)
+	variants, total, err := uc.repo.GetAllByProductID(ctx, productID, page, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	products := make([]*entity.Product, 0, len(variants))
+	for _, variant := range variants {
+		products = append(products, variant.Product)
+	}
+	if err := uc.services.GetPriceBook().Attach(ctx, products...); err != nil {
+		return nil, 0, err
+	}
+	return variants, total, nil
 }
 
-func (uc *UseCase) UpdateProductVariant(ctx context.Context, id uuid.UUID, variantName, variantValue string, priceOverride *float64, quantity int) (*entity.ProductVariant, error) {
+func (uc *UseCase) UpdateProductVariant(ctx context.Context, id uuid.UUID, input VariantInput) (*entity.ProductVariant, error) {
 	variant, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	variant.VariantName = variantName
-	variant.VariantValue = variantValue
-	variant.Price_Override = priceOverride
-	variant.Quantity = quantity
+	original := *variant
+
+	variant.Price_Override = input.PriceOverride
+	variant.Quantity = input.Quantity
 	variant.UpdatedAt = time.Now()
 
-	if err := variant.ValidateForCreation(); err != nil {
+	if err := uc.applyInput(ctx, variant, input); err != nil {
 		return nil, err
 	}
 
@@ -85,9 +163,221 @@ func (uc *UseCase) UpdateProductVariant(ctx context.Context, id uuid.UUID, varia
 		return nil, err
 	}
 
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE", "ProductVariant", variant.ID, &original, variant)
+	uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(variant.ProductID, &variant.ID, entity.StockAdjustment, original.Quantity, variant.Quantity, "variant update"))
+	uc.services.GetPriceBook().Record(ctx, entity.NewPriceChange(variant.ProductID, &variant.ID, original.Price_Override, variant.Price_Override, nil))
+
 	return variant, nil
 }
 
 func (uc *UseCase) DeleteProductVariant(ctx context.Context, id uuid.UUID) error {
-	return uc.repo.Delete(ctx, id)
+	// Get variant before deletion for audit
+	variant, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE", "ProductVariant", id, variant, nil)
+
+	return nil
+}
+
+// TransferStock atomically moves stock between a variant and another variant of
+// the same product or the base product. It returns the outgoing and incoming
+// ledger entries, which share a reference identifying the transfer.
+func (uc *UseCase) TransferStock(ctx context.Context, variantID uuid.UUID, input TransferInput) (*entity.StockMovement, *entity.StockMovement, error) {
+	variant, err := uc.repo.GetByID(ctx, variantID)
+	if err != nil {
+		return nil, nil, ErrVariantNotFound
+	}
+
+	transfer := &entity.VariantStockTransfer{
+		ProductID:     variant.ProductID,
+		FromVariantID: &variant.ID,
+		ToVariantID:   input.ToVariantID,
+		Quantity:      input.Quantity,
+		Reference:     "transfer " + uuid.New().String(),
+	}
+
+	if input.FromProduct {
+		if input.ToVariantID != nil {
+			return nil, nil, entity.ValidationError("A transfer from the base product can only go to the variant itself")
+		}
+		transfer.FromVariantID, transfer.ToVariantID = nil, &variant.ID
+	} else if input.ToVariantID != nil {
+		target, err := uc.repo.GetByID(ctx, *input.ToVariantID)
+		if err != nil {
+			return nil, nil, ErrVariantNotFound
+		}
+		if target.ProductID != variant.ProductID {
+			return nil, nil, ErrVariantMismatch
+		}
+	}
+
+	if err := transfer.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	out, in, err := uc.repo.TransferStock(ctx, transfer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "TRANSFER_STOCK", "ProductVariant", variant.ID, nil, transfer)
+
+	return out, in, nil
+}
+
+// applyInput resolves the selected options and SKU onto variant, validates it and
+// makes sure no other variant already uses the SKU or the combination
+func (uc *UseCase) applyInput(ctx context.Context, variant *entity.ProductVariant, input VariantInput) error {
+	options, err := uc.optionRepo.ListByProductID(ctx, variant.ProductID)
+	if err != nil {
+		return err
+	}
+
+	variantOptions, err := entity.BuildVariantOptions(options, input.Options)
+	if err != nil {
+		return err
+	}
+	variant.SetOptions(variantOptions)
+
+	variant.SKU = strings.ToUpper(strings.TrimSpace(input.SKU))
+	if variant.SKU == "" {
+		variant.SKU = entity.GenerateSKU(variant.ProductID, variantOptions)
+	}
+
+	if err := variant.ValidateForCreation(); err != nil {
+		return err
+	}
+
+	if existing, err := uc.repo.GetBySKU(ctx, variant.SKU); err == nil && existing.ID != variant.ID {
+		return ErrSKUExists
+	}
+	if existing, err := uc.repo.GetByCombination(ctx, variant.ProductID, variant.CombinationKey); err == nil && existing.ID != variant.ID {
+		return ErrCombinationExists
+	}
+
+	return nil
+}
+
+// CreateOption adds an option with its initial values to a product. Options are
+// fixed once the product has variants, since every variant needs a value for each.
+func (uc *UseCase) CreateOption(ctx context.Context, productID uuid.UUID, name string, values []string) (*entity.ProductOption, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	_, variantCount, err := uc.repo.GetAllByProductID(ctx, productID, 1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if variantCount > 0 {
+		return nil, ErrProductHasVariants
+	}
+
+	existing, err := uc.optionRepo.ListByProductID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	name = strings.TrimSpace(name)
+	for _, option := range existing {
+		if strings.EqualFold(option.Name, name) {
+			return nil, ErrOptionExists
+		}
+	}
+
+	option := &entity.ProductOption{
+		ID:        entity.NewID(),
+		ProductID: productID,
+		Name:      name,
+		Position:  len(existing),
+		CreatedAt: time.Now(),
+	}
+	for i, value := range values {
+		option.Values = append(option.Values, entity.ProductOptionValue{Value: strings.TrimSpace(value), Position: i})
+	}
+
+	if err := option.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.optionRepo.Create(ctx, option); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "CREATE", "ProductOption", option.ID, nil, option)
+
+	return option, nil
+}
+
+func (uc *UseCase) ListOptions(ctx context.Context, productID uuid.UUID) ([]*entity.ProductOption, error) {
+	return uc.optionRepo.ListByProductID(ctx, productID)
+}
+
+func (uc *UseCase) AddOptionValue(ctx context.Context, productID, optionID uuid.UUID, value string) (*entity.ProductOption, error) {
+	option, err := uc.getOption(ctx, productID, optionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if option.FindValue(value) != nil {
+		return nil, ErrOptionValueExists
+	}
+
+	optionValue := entity.ProductOptionValue{
+		OptionID: option.ID,
+		Value:    strings.TrimSpace(value),
+		Position: len(option.Values),
+	}
+	if err := optionValue.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.optionRepo.AddValue(ctx, &optionValue); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "ADD_VALUE", "ProductOption", option.ID, nil, optionValue)
+
+	option.Values = append(option.Values, optionValue)
+	return option, nil
+}
+
+// DeleteOption removes an option that no variant uses anymore
+func (uc *UseCase) DeleteOption(ctx context.Context, productID, optionID uuid.UUID) error {
+	option, err := uc.getOption(ctx, productID, optionID)
+	if err != nil {
+		return err
+	}
+
+	inUse, err := uc.optionRepo.InUse(ctx, optionID)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return ErrOptionInUse
+	}
+
+	if err := uc.optionRepo.Delete(ctx, optionID); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE", "ProductOption", optionID, option, nil)
+
+	return nil
+}
+
+// getOption loads an option and checks it belongs to the product
+func (uc *UseCase) getOption(ctx context.Context, productID, optionID uuid.UUID) (*entity.ProductOption, error) {
+	option, err := uc.optionRepo.GetByID(ctx, optionID)
+	if err != nil || option.ProductID != productID {
+		return nil, ErrOptionNotFound
+	}
+	return option, nil
 }
@@ -7,111 +7,111 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-// MockProductVariantRepository is a mock implementation of ProductVariantRepository
-type MockProductVariantRepository struct {
-	mock.Mock
-}
-
-func (m *MockProductVariantRepository) Create(ctx context.Context, variant *entity.ProductVariant) error {
-	args := m.Called(ctx, variant)
-	return args.Error(0)
-}
-
-func (m *MockProductVariantRepository) GetByID(ctx context.Context, id uuid.UUID) (*entity.ProductVariant, error) {
-	args := m.Called(ctx, id)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*entity.ProductVariant), args.Error(1)
-}
+var _ repository.ProductVariantRepository = (*mocks.ProductVariantRepository)(nil)
+var _ repository.ProductOptionRepository = (*mocks.ProductOptionRepository)(nil)
+var _ repository.ProductRepository = (*mocks.ProductRepository)(nil)
 
-func (m *MockProductVariantRepository) GetAll(ctx context.Context, page, pageSize int) ([]*entity.ProductVariant, int, error) {
-	args := m.Called(ctx, page, pageSize)
-	if args.Get(0) == nil {
-		return nil, args.Int(1), args.Error(2)
+// testOptions returns Size and Color options for productID
+func testOptions(productID uuid.UUID) []*entity.ProductOption {
+	return []*entity.ProductOption{
+		{ID: uuid.New(), ProductID: productID, Name: "Size", Position: 0, Values: []entity.ProductOptionValue{{Value: "S"}, {Value: "M"}, {Value: "L"}}},
+		{ID: uuid.New(), ProductID: productID, Name: "Color", Position: 1, Values: []entity.ProductOptionValue{{Value: "Red"}, {Value: "Blue"}}},
 	}
-	return args.Get(0).([]*entity.ProductVariant), args.Int(1), args.Error(2)
-}
-
-func (m *MockProductVariantRepository) GetAllByProductID(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductVariant, int, error) {
-	args := m.Called(ctx, productID, page, pageSize)
-	if args.Get(0) == nil {
-		return nil, args.Int(1), args.Error(2)
-	}
-	return args.Get(0).([]*entity.ProductVariant), args.Int(1), args.Error(2)
-}
-
-func (m *MockProductVariantRepository) Update(ctx context.Context, variant *entity.ProductVariant) error {
-	args := m.Called(ctx, variant)
-	return args.Error(0)
 }
 
-func (m *MockProductVariantRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	args := m.Called(ctx, id)
-	return args.Error(0)
+func testVariantOptions(size, color string) []entity.VariantOption {
+	return []entity.VariantOption{{Name: "Size", Value: size, Position: 0}, {Name: "Color", Value: color, Position: 1}}
 }
 
 func TestCreateProductVariant(t *testing.T) {
-	mockRepo := new(MockProductVariantRepository)
-	useCase := NewUseCase(mockRepo)
+	mockRepo := new(mocks.ProductVariantRepository)
+	mockOptionRepo := new(mocks.ProductOptionRepository)
+	useCase := NewUseCase(mockRepo, mockOptionRepo, new(mocks.ProductRepository), &mockServices.MockServices{})
 	ctx := context.Background()
 
 	productID := uuid.New()
+	options := testOptions(productID)
 	priceOverride := 39.99
+	notFound := errors.New("Product variant not found")
+
+	mockOptionRepo.On("ListByProductID", ctx, productID).Return(options, nil)
 
 	t.Run("Success - Create variant with price override", func(t *testing.T) {
+		mockRepo.On("GetBySKU", ctx, "TSHIRT-L-RED").Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=l").Return(nil, notFound).Once()
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(nil).Once()
 
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Size", "Large", &priceOverride, 50)
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			SKU:           "tshirt-l-red",
+			Options:       map[string]string{"size": "l", "Color": "Red"},
+			PriceOverride: &priceOverride,
+			Quantity:      50,
+		})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, variant)
 		assert.Equal(t, productID, variant.ProductID)
-		assert.Equal(t, "Size", variant.VariantName)
-		assert.Equal(t, "Large", variant.VariantValue)
+		assert.Equal(t, "TSHIRT-L-RED", variant.SKU)
+		assert.Equal(t, "L / Red", variant.Title())
+		assert.Equal(t, "color=red;size=l", variant.CombinationKey)
+		assert.Equal(t, options[0].ID, variant.Options[0].OptionID)
 		assert.Equal(t, &priceOverride, variant.Price_Override)
 		assert.Equal(t, 50, variant.Quantity)
 		assert.NotEqual(t, uuid.Nil, variant.ID)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Success - Create variant without price override", func(t *testing.T) {
+	t.Run("Success - Generates SKU when empty", func(t *testing.T) {
+		sku := entity.GenerateSKU(productID, testVariantOptions("M", "Blue"))
+		mockRepo.On("GetBySKU", ctx, sku).Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=blue;size=m").Return(nil, notFound).Once()
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(nil).Once()
 
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Color", "Blue", nil, 100)
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			Options:  map[string]string{"Size": "M", "Color": "Blue"},
+			Quantity: 100,
+		})
 
 		assert.NoError(t, err)
-		assert.NotNil(t, variant)
-		assert.Equal(t, productID, variant.ProductID)
-		assert.Equal(t, "Color", variant.VariantName)
-		assert.Equal(t, "Blue", variant.VariantValue)
+		assert.Equal(t, sku, variant.SKU)
 		assert.Nil(t, variant.Price_Override)
-		assert.Equal(t, 100, variant.Quantity)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure - Invalid variant name (empty)", func(t *testing.T) {
-		variant, err := useCase.CreateProductVariant(ctx, productID, "", "Medium", nil, 30)
+	t.Run("Failure - Missing option value", func(t *testing.T) {
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			Options:  map[string]string{"Size": "M"},
+			Quantity: 30,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
-		assert.Contains(t, err.Error(), "Variant name is required")
+		assert.Contains(t, err.Error(), `Missing value for option "Color"`)
 	})
 
-	t.Run("Failure - Invalid variant value (empty)", func(t *testing.T) {
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Size", "", nil, 30)
+	t.Run("Failure - Invalid option value", func(t *testing.T) {
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			Options:  map[string]string{"Size": "XXL", "Color": "Red"},
+			Quantity: 30,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
-		assert.Contains(t, err.Error(), "Variant value is required")
+		assert.Contains(t, err.Error(), `Invalid value "XXL" for option "Size"`)
 	})
 
 	t.Run("Failure - Invalid quantity (negative)", func(t *testing.T) {
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Size", "Small", nil, -10)
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: -10,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
@@ -120,28 +120,78 @@ func TestCreateProductVariant(t *testing.T) {
 
 	t.Run("Failure - Invalid price override (negative)", func(t *testing.T) {
 		negativePriceOverride := -10.00
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Size", "Medium", &negativePriceOverride, 20)
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			Options:       map[string]string{"Size": "S", "Color": "Red"},
+			PriceOverride: &negativePriceOverride,
+			Quantity:      20,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
 		assert.Contains(t, err.Error(), "Variant price override cannot be negative")
 	})
 
+	t.Run("Failure - SKU already exists", func(t *testing.T) {
+		mockRepo.On("GetBySKU", ctx, "TAKEN").Return(&entity.ProductVariant{ID: uuid.New()}, nil).Once()
+
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			SKU:      "TAKEN",
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: 20,
+		})
+
+		assert.ErrorIs(t, err, ErrSKUExists)
+		assert.Nil(t, variant)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Combination already exists", func(t *testing.T) {
+		mockRepo.On("GetBySKU", ctx, "OTHER").Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=s").Return(&entity.ProductVariant{ID: uuid.New()}, nil).Once()
+
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			SKU:      "OTHER",
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: 20,
+		})
+
+		assert.ErrorIs(t, err, ErrCombinationExists)
+		assert.Nil(t, variant)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Failure - Repository error", func(t *testing.T) {
+		mockRepo.On("GetBySKU", ctx, "DB-ERR").Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=s").Return(nil, notFound).Once()
 		mockRepo.On("Create", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(errors.New("database error")).Once()
 
-		variant, err := useCase.CreateProductVariant(ctx, productID, "Color", "Red", nil, 25)
+		variant, err := useCase.CreateProductVariant(ctx, productID, VariantInput{
+			SKU:      "DB-ERR",
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: 25,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
 		assert.Contains(t, err.Error(), "database error")
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Failure - Product has no options", func(t *testing.T) {
+		bareProductID := uuid.New()
+		mockOptionRepo.On("ListByProductID", ctx, bareProductID).Return([]*entity.ProductOption{}, nil).Once()
+
+		variant, err := useCase.CreateProductVariant(ctx, bareProductID, VariantInput{Options: map[string]string{"Size": "S"}, Quantity: 1})
+
+		assert.Error(t, err)
+		assert.Nil(t, variant)
+		assert.Contains(t, err.Error(), "Product has no options")
+	})
 }
 
 func TestGetProductVariant(t *testing.T) {
-	mockRepo := new(MockProductVariantRepository)
-	useCase := NewUseCase(mockRepo)
+	mockRepo := new(mocks.ProductVariantRepository)
+	useCase := NewUseCase(mockRepo, new(mocks.ProductOptionRepository), new(mocks.ProductRepository), &mockServices.MockServices{})
 	ctx := context.Background()
 
 	variantID := uuid.New()
@@ -152,8 +202,7 @@ func TestGetProductVariant(t *testing.T) {
 		expectedVariant := &entity.ProductVariant{
 			ID:             variantID,
 			ProductID:      productID,
-			VariantName:    "Material",
-			VariantValue:   "Cotton",
+			SKU:            "TSHIRT-COTTON",
 			Price_Override: &priceOverride,
 			Quantity:       75,
 		}
@@ -166,8 +215,7 @@ func TestGetProductVariant(t *testing.T) {
 		assert.NotNil(t, variant)
 		assert.Equal(t, variantID, variant.ID)
 		assert.Equal(t, productID, variant.ProductID)
-		assert.Equal(t, "Material", variant.VariantName)
-		assert.Equal(t, "Cotton", variant.VariantValue)
+		assert.Equal(t, "TSHIRT-COTTON", variant.SKU)
 		assert.Equal(t, 75, variant.Quantity)
 		mockRepo.AssertExpectations(t)
 	})
@@ -196,8 +244,8 @@ func TestGetProductVariant(t *testing.T) {
 }
 
 func TestListProductVariants(t *testing.T) {
-	mockRepo := new(MockProductVariantRepository)
-	useCase := NewUseCase(mockRepo)
+	mockRepo := new(mocks.ProductVariantRepository)
+	useCase := NewUseCase(mockRepo, new(mocks.ProductOptionRepository), new(mocks.ProductRepository), &mockServices.MockServices{})
 	ctx := context.Background()
 
 	productID := uuid.New()
@@ -209,16 +257,14 @@ func TestListProductVariants(t *testing.T) {
 			{
 				ID:             uuid.New(),
 				ProductID:      productID,
-				VariantName:    "Size",
-				VariantValue:   "Small",
+				SKU:            "TSHIRT-SMALL",
 				Price_Override: &priceOverride1,
 				Quantity:       20,
 			},
 			{
 				ID:             uuid.New(),
 				ProductID:      productID,
-				VariantName:    "Size",
-				VariantValue:   "Large",
+				SKU:            "TSHIRT-LARGE",
 				Price_Override: &priceOverride2,
 				Quantity:       30,
 			},
@@ -232,8 +278,8 @@ func TestListProductVariants(t *testing.T) {
 		assert.NotNil(t, variants)
 		assert.Len(t, variants, 2)
 		assert.Equal(t, 2, total)
-		assert.Equal(t, "Small", variants[0].VariantValue)
-		assert.Equal(t, "Large", variants[1].VariantValue)
+		assert.Equal(t, "TSHIRT-SMALL", variants[0].SKU)
+		assert.Equal(t, "TSHIRT-LARGE", variants[1].SKU)
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -290,56 +336,67 @@ func TestListProductVariants(t *testing.T) {
 }
 
 func TestUpdateProductVariant(t *testing.T) {
-	mockRepo := new(MockProductVariantRepository)
-	useCase := NewUseCase(mockRepo)
+	mockRepo := new(mocks.ProductVariantRepository)
+	mockOptionRepo := new(mocks.ProductOptionRepository)
+	useCase := NewUseCase(mockRepo, mockOptionRepo, new(mocks.ProductRepository), &mockServices.MockServices{})
 	ctx := context.Background()
 
 	variantID := uuid.New()
 	productID := uuid.New()
 	oldPriceOverride := 29.99
 	newPriceOverride := 34.99
+	notFound := errors.New("Product variant not found")
+
+	mockOptionRepo.On("ListByProductID", ctx, productID).Return(testOptions(productID), nil)
 
-	t.Run("Success - Update variant with price override", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
+	existing := func() *entity.ProductVariant {
+		variant := &entity.ProductVariant{
 			ID:             variantID,
 			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
+			SKU:            "TSHIRT-S-RED",
 			Price_Override: &oldPriceOverride,
 			Quantity:       20,
 		}
+		variant.SetOptions(testVariantOptions("S", "Red"))
+		return variant
+	}
 
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
+	t.Run("Success - Update variant options and price override", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, variantID).Return(existing(), nil).Once()
+		mockRepo.On("GetBySKU", ctx, "TSHIRT-M-RED").Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=m").Return(nil, notFound).Once()
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(nil).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "Medium", &newPriceOverride, 50)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			SKU:           "TSHIRT-M-RED",
+			Options:       map[string]string{"Size": "M", "Color": "Red"},
+			PriceOverride: &newPriceOverride,
+			Quantity:      50,
+		})
 
 		assert.NoError(t, err)
 		assert.NotNil(t, variant)
 		assert.Equal(t, variantID, variant.ID)
-		assert.Equal(t, "Medium", variant.VariantValue)
+		assert.Equal(t, "M / Red", variant.Title())
 		assert.Equal(t, &newPriceOverride, variant.Price_Override)
 		assert.Equal(t, 50, variant.Quantity)
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Success - Update variant removing price override", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Large",
-			Price_Override: &oldPriceOverride,
-			Quantity:       30,
-		}
+	t.Run("Success - Keeps own SKU and combination", func(t *testing.T) {
+		current := existing()
+		mockRepo.On("GetByID", ctx, variantID).Return(current, nil).Once()
+		mockRepo.On("GetBySKU", ctx, "TSHIRT-S-RED").Return(existing(), nil).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=s").Return(existing(), nil).Once()
+		mockRepo.On("Update", ctx, current).Return(nil).Once()
 
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
-		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(nil).Once()
-
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "Large", nil, 35)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			SKU:      "TSHIRT-S-RED",
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: 35,
+		})
 
 		assert.NoError(t, err)
-		assert.NotNil(t, variant)
 		assert.Nil(t, variant.Price_Override)
 		assert.Equal(t, 35, variant.Quantity)
 		mockRepo.AssertExpectations(t)
@@ -348,7 +405,7 @@ func TestUpdateProductVariant(t *testing.T) {
 	t.Run("Failure - Variant not found", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, variantID).Return(nil, errors.New("variant not found")).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "XL", nil, 10)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{Options: map[string]string{"Size": "L", "Color": "Red"}, Quantity: 10})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
@@ -356,59 +413,27 @@ func TestUpdateProductVariant(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure - Invalid variant name after update", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
-			Price_Override: nil,
-			Quantity:       20,
-		}
-
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
-
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "", "Medium", nil, 25)
-
-		assert.Error(t, err)
-		assert.Nil(t, variant)
-		assert.Contains(t, err.Error(), "Variant name is required")
-		mockRepo.AssertExpectations(t)
-	})
-
-	t.Run("Failure - Invalid variant value after update", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
-			Price_Override: nil,
-			Quantity:       20,
-		}
-
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
+	t.Run("Failure - Unknown option after update", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, variantID).Return(existing(), nil).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "", nil, 25)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			Options:  map[string]string{"Size": "M", "Color": "Red", "Fit": "Slim"},
+			Quantity: 25,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
-		assert.Contains(t, err.Error(), "Variant value is required")
+		assert.Contains(t, err.Error(), `Unknown option "fit"`)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure - Invalid quantity after update", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
-			Price_Override: nil,
-			Quantity:       20,
-		}
-
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
+		mockRepo.On("GetByID", ctx, variantID).Return(existing(), nil).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "Medium", nil, -5)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			Options:  map[string]string{"Size": "M", "Color": "Red"},
+			Quantity: -5,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
@@ -416,41 +441,33 @@ func TestUpdateProductVariant(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
-	t.Run("Failure - Invalid price override after update", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
-			Price_Override: nil,
-			Quantity:       20,
-		}
-
-		negativePriceOverride := -15.00
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
+	t.Run("Failure - Combination taken by another variant", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, variantID).Return(existing(), nil).Once()
+		mockRepo.On("GetBySKU", ctx, "TSHIRT-L-BLUE").Return(nil, notFound).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=blue;size=l").Return(&entity.ProductVariant{ID: uuid.New()}, nil).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "Medium", &negativePriceOverride, 25)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			SKU:      "TSHIRT-L-BLUE",
+			Options:  map[string]string{"Size": "L", "Color": "Blue"},
+			Quantity: 25,
+		})
 
-		assert.Error(t, err)
+		assert.ErrorIs(t, err, ErrCombinationExists)
 		assert.Nil(t, variant)
-		assert.Contains(t, err.Error(), "Variant price override cannot be negative")
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure - Repository update error", func(t *testing.T) {
-		existingVariant := &entity.ProductVariant{
-			ID:             variantID,
-			ProductID:      productID,
-			VariantName:    "Size",
-			VariantValue:   "Small",
-			Price_Override: nil,
-			Quantity:       20,
-		}
-
-		mockRepo.On("GetByID", ctx, variantID).Return(existingVariant, nil).Once()
+		mockRepo.On("GetByID", ctx, variantID).Return(existing(), nil).Once()
+		mockRepo.On("GetBySKU", ctx, "TSHIRT-S-RED").Return(existing(), nil).Once()
+		mockRepo.On("GetByCombination", ctx, productID, "color=red;size=s").Return(existing(), nil).Once()
 		mockRepo.On("Update", ctx, mock.AnythingOfType("*entity.ProductVariant")).Return(errors.New("database error")).Once()
 
-		variant, err := useCase.UpdateProductVariant(ctx, variantID, "Size", "Medium", nil, 25)
+		variant, err := useCase.UpdateProductVariant(ctx, variantID, VariantInput{
+			SKU:      "TSHIRT-S-RED",
+			Options:  map[string]string{"Size": "S", "Color": "Red"},
+			Quantity: 25,
+		})
 
 		assert.Error(t, err)
 		assert.Nil(t, variant)
@@ -460,13 +477,15 @@ func TestUpdateProductVariant(t *testing.T) {
 }
 
 func TestDeleteProductVariant(t *testing.T) {
-	mockRepo := new(MockProductVariantRepository)
-	useCase := NewUseCase(mockRepo)
+	mockRepo := new(mocks.ProductVariantRepository)
+	useCase := NewUseCase(mockRepo, new(mocks.ProductOptionRepository), new(mocks.ProductRepository), &mockServices.MockServices{})
 	ctx := context.Background()
 
 	variantID := uuid.New()
+	variant := &entity.ProductVariant{ID: variantID, ProductID: uuid.New(), SKU: "TS-L"}
 
 	t.Run("Success - Delete existing variant", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, variantID).Return(variant, nil).Once()
 		mockRepo.On("Delete", ctx, variantID).Return(nil).Once()
 
 		err := useCase.DeleteProductVariant(ctx, variantID)
@@ -476,7 +495,7 @@ func TestDeleteProductVariant(t *testing.T) {
 	})
 
 	t.Run("Failure - Variant not found", func(t *testing.T) {
-		mockRepo.On("Delete", ctx, variantID).Return(errors.New("variant not found")).Once()
+		mockRepo.On("GetByID", ctx, variantID).Return(nil, errors.New("variant not found")).Once()
 
 		err := useCase.DeleteProductVariant(ctx, variantID)
 
@@ -486,6 +505,7 @@ func TestDeleteProductVariant(t *testing.T) {
 	})
 
 	t.Run("Failure - Repository error", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, variantID).Return(variant, nil).Once()
 		mockRepo.On("Delete", ctx, variantID).Return(errors.New("database error")).Once()
 
 		err := useCase.DeleteProductVariant(ctx, variantID)
@@ -495,3 +515,218 @@ func TestDeleteProductVariant(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestTransferStock(t *testing.T) {
+	mockRepo := new(mocks.ProductVariantRepository)
+	useCase := NewUseCase(mockRepo, new(mocks.ProductOptionRepository), new(mocks.ProductRepository), &mockServices.MockServices{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	source := &entity.ProductVariant{ID: uuid.New(), ProductID: productID, SKU: "TSHIRT-L-RED", Quantity: 10}
+	target := &entity.ProductVariant{ID: uuid.New(), ProductID: productID, SKU: "TSHIRT-M-RED", Quantity: 2}
+	other := &entity.ProductVariant{ID: uuid.New(), ProductID: uuid.New(), SKU: "MUG-BLUE", Quantity: 5}
+
+	mockRepo.On("GetByID", ctx, source.ID).Return(source, nil)
+	mockRepo.On("GetByID", ctx, target.ID).Return(target, nil)
+
+	t.Run("Success - Transfer to another variant", func(t *testing.T) {
+		out := entity.NewStockMovement(productID, &source.ID, entity.StockTransfer, 10, 7, "transfer")
+		in := entity.NewStockMovement(productID, &target.ID, entity.StockTransfer, 2, 5, "transfer")
+		mockRepo.On("TransferStock", ctx, mock.MatchedBy(func(transfer *entity.VariantStockTransfer) bool {
+			return *transfer.FromVariantID == source.ID && *transfer.ToVariantID == target.ID && transfer.Quantity == 3
+		})).Return(out, in, nil).Once()
+
+		gotOut, gotIn, err := useCase.TransferStock(ctx, source.ID, TransferInput{ToVariantID: &target.ID, Quantity: 3})
+
+		assert.NoError(t, err)
+		assert.Equal(t, -3, gotOut.Delta)
+		assert.Equal(t, 3, gotIn.Delta)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success - Transfer from the base product", func(t *testing.T) {
+		mockRepo.On("TransferStock", ctx, mock.MatchedBy(func(transfer *entity.VariantStockTransfer) bool {
+			return transfer.FromVariantID == nil && *transfer.ToVariantID == source.ID && transfer.ProductID == productID
+		})).Return(&entity.StockMovement{}, &entity.StockMovement{}, nil).Once()
+
+		_, _, err := useCase.TransferStock(ctx, source.ID, TransferInput{FromProduct: true, Quantity: 1})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Variant of another product", func(t *testing.T) {
+		mockRepo.On("GetByID", ctx, other.ID).Return(other, nil).Once()
+
+		_, _, err := useCase.TransferStock(ctx, source.ID, TransferInput{ToVariantID: &other.ID, Quantity: 1})
+
+		assert.ErrorIs(t, err, ErrVariantMismatch)
+	})
+
+	t.Run("Failure - Same variant", func(t *testing.T) {
+		_, _, err := useCase.TransferStock(ctx, source.ID, TransferInput{ToVariantID: &source.ID, Quantity: 1})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("Failure - Source variant not found", func(t *testing.T) {
+		missing := uuid.New()
+		mockRepo.On("GetByID", ctx, missing).Return(nil, errors.New("Product variant not found")).Once()
+
+		_, _, err := useCase.TransferStock(ctx, missing, TransferInput{Quantity: 1})
+
+		assert.ErrorIs(t, err, ErrVariantNotFound)
+	})
+
+	t.Run("Failure - Insufficient stock", func(t *testing.T) {
+		mockRepo.On("TransferStock", ctx, mock.AnythingOfType("*entity.VariantStockTransfer")).Return(nil, nil, entity.ErrInsufficientStock).Once()
+
+		_, _, err := useCase.TransferStock(ctx, source.ID, TransferInput{Quantity: 50})
+
+		assert.ErrorIs(t, err, entity.ErrInsufficientStock)
+	})
+}
+
+func TestCreateOption(t *testing.T) {
+	ctx := context.Background()
+	productID := uuid.New()
+
+	newUseCase := func() (*UseCase, *mocks.ProductVariantRepository, *mocks.ProductOptionRepository, *mocks.ProductRepository) {
+		mockRepo := new(mocks.ProductVariantRepository)
+		mockOptionRepo := new(mocks.ProductOptionRepository)
+		mockProductRepo := new(mocks.ProductRepository)
+		return NewUseCase(mockRepo, mockOptionRepo, mockProductRepo, &mockServices.MockServices{}), mockRepo, mockOptionRepo, mockProductRepo
+	}
+
+	t.Run("Success - Option is appended after existing ones", func(t *testing.T) {
+		useCase, mockRepo, mockOptionRepo, mockProductRepo := newUseCase()
+		mockProductRepo.On("GetByID", ctx, productID).Return(&entity.Product{ID: productID}, nil).Once()
+		mockRepo.On("GetAllByProductID", ctx, productID, 1, 1).Return([]*entity.ProductVariant{}, 0, nil).Once()
+		mockOptionRepo.On("ListByProductID", ctx, productID).Return(testOptions(productID), nil).Once()
+		mockOptionRepo.On("Create", ctx, mock.AnythingOfType("*entity.ProductOption")).Return(nil).Once()
+
+		option, err := useCase.CreateOption(ctx, productID, " Material ", []string{"Cotton", "Linen"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Material", option.Name)
+		assert.Equal(t, 2, option.Position)
+		assert.Len(t, option.Values, 2)
+		assert.Equal(t, 1, option.Values[1].Position)
+	})
+
+	t.Run("Failure - Product not found", func(t *testing.T) {
+		useCase, _, _, mockProductRepo := newUseCase()
+		mockProductRepo.On("GetByID", ctx, productID).Return(nil, errors.New("Product not found")).Once()
+
+		_, err := useCase.CreateOption(ctx, productID, "Material", []string{"Cotton"})
+
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+
+	t.Run("Failure - Product already has variants", func(t *testing.T) {
+		useCase, mockRepo, _, mockProductRepo := newUseCase()
+		mockProductRepo.On("GetByID", ctx, productID).Return(&entity.Product{ID: productID}, nil).Once()
+		mockRepo.On("GetAllByProductID", ctx, productID, 1, 1).Return([]*entity.ProductVariant{{}}, 3, nil).Once()
+
+		_, err := useCase.CreateOption(ctx, productID, "Material", []string{"Cotton"})
+
+		assert.ErrorIs(t, err, ErrProductHasVariants)
+	})
+
+	t.Run("Failure - Duplicate option name", func(t *testing.T) {
+		useCase, mockRepo, mockOptionRepo, mockProductRepo := newUseCase()
+		mockProductRepo.On("GetByID", ctx, productID).Return(&entity.Product{ID: productID}, nil).Once()
+		mockRepo.On("GetAllByProductID", ctx, productID, 1, 1).Return([]*entity.ProductVariant{}, 0, nil).Once()
+		mockOptionRepo.On("ListByProductID", ctx, productID).Return(testOptions(productID), nil).Once()
+
+		_, err := useCase.CreateOption(ctx, productID, "size", []string{"XL"})
+
+		assert.ErrorIs(t, err, ErrOptionExists)
+	})
+
+	t.Run("Failure - Duplicate values", func(t *testing.T) {
+		useCase, mockRepo, mockOptionRepo, mockProductRepo := newUseCase()
+		mockProductRepo.On("GetByID", ctx, productID).Return(&entity.Product{ID: productID}, nil).Once()
+		mockRepo.On("GetAllByProductID", ctx, productID, 1, 1).Return([]*entity.ProductVariant{}, 0, nil).Once()
+		mockOptionRepo.On("ListByProductID", ctx, productID).Return([]*entity.ProductOption{}, nil).Once()
+
+		_, err := useCase.CreateOption(ctx, productID, "Material", []string{"Cotton", "cotton"})
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "Duplicate option value")
+	})
+}
+
+func TestAddOptionValue(t *testing.T) {
+	mockOptionRepo := new(mocks.ProductOptionRepository)
+	useCase := NewUseCase(new(mocks.ProductVariantRepository), mockOptionRepo, new(mocks.ProductRepository), &mockServices.MockServices{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	size := testOptions(productID)[0]
+
+	t.Run("Success - Value is appended", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, size.ID).Return(size, nil).Once()
+		mockOptionRepo.On("AddValue", ctx, mock.AnythingOfType("*entity.ProductOptionValue")).Return(nil).Once()
+
+		option, err := useCase.AddOptionValue(ctx, productID, size.ID, "XL")
+
+		assert.NoError(t, err)
+		assert.Len(t, option.Values, 4)
+		assert.Equal(t, "XL", option.Values[3].Value)
+		assert.Equal(t, 3, option.Values[3].Position)
+	})
+
+	t.Run("Failure - Value already exists", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, size.ID).Return(size, nil).Once()
+
+		_, err := useCase.AddOptionValue(ctx, productID, size.ID, "m")
+
+		assert.ErrorIs(t, err, ErrOptionValueExists)
+	})
+
+	t.Run("Failure - Option belongs to another product", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, size.ID).Return(size, nil).Once()
+
+		_, err := useCase.AddOptionValue(ctx, uuid.New(), size.ID, "XS")
+
+		assert.ErrorIs(t, err, ErrOptionNotFound)
+	})
+}
+
+func TestDeleteOption(t *testing.T) {
+	mockOptionRepo := new(mocks.ProductOptionRepository)
+	useCase := NewUseCase(new(mocks.ProductVariantRepository), mockOptionRepo, new(mocks.ProductRepository), &mockServices.MockServices{})
+	ctx := context.Background()
+
+	productID := uuid.New()
+	option := testOptions(productID)[1]
+
+	t.Run("Success - Unused option is deleted", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, option.ID).Return(option, nil).Once()
+		mockOptionRepo.On("InUse", ctx, option.ID).Return(false, nil).Once()
+		mockOptionRepo.On("Delete", ctx, option.ID).Return(nil).Once()
+
+		err := useCase.DeleteOption(ctx, productID, option.ID)
+
+		assert.NoError(t, err)
+		mockOptionRepo.AssertExpectations(t)
+	})
+
+	t.Run("Failure - Option in use", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, option.ID).Return(option, nil).Once()
+		mockOptionRepo.On("InUse", ctx, option.ID).Return(true, nil).Once()
+
+		err := useCase.DeleteOption(ctx, productID, option.ID)
+
+		assert.ErrorIs(t, err, ErrOptionInUse)
+	})
+
+	t.Run("Failure - Option not found", func(t *testing.T) {
+		mockOptionRepo.On("GetByID", ctx, option.ID).Return(nil, errors.New("Product option not found")).Once()
+
+		err := useCase.DeleteOption(ctx, productID, option.ID)
+
+		assert.ErrorIs(t, err, ErrOptionNotFound)
+	})
+}
@@ -0,0 +1,169 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+// QueueStatus is a user's current standing in a product's purchase queue
+type QueueStatus struct {
+	Entry    *entity.PurchaseQueueEntry
+	Position int // 1-based position among waiting entries, 0 once admitted
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface QueueService -out ../../internal/testing/servicemocks
+
+type QueueService interface {
+	JoinQueue(ctx context.Context, productID, userID uuid.UUID) (*QueueStatus, error)
+	GetQueueStatus(ctx context.Context, productID, userID uuid.UUID) (*QueueStatus, error)
+
+	// AdvanceQueues expires elapsed purchase windows and admits waiting users
+	// in every active queue, so queues move even when nobody polls them.
+	// It returns how many queues were advanced.
+	AdvanceQueues(ctx context.Context) (int, error)
+}
+
+type UseCase struct {
+	queueRepo   repository.PurchaseQueueRepository
+	productRepo repository.ProductRepository
+	window      time.Duration
+	maxWindows  int
+}
+
+// NewUseCase creates the queue use case. window is how long an admitted user has
+// to place the order and maxWindows caps how many purchase windows are open at once.
+func NewUseCase(queueRepo repository.PurchaseQueueRepository, productRepo repository.ProductRepository, window time.Duration, maxWindows int) *UseCase {
+	return &UseCase{
+		queueRepo:   queueRepo,
+		productRepo: productRepo,
+		window:      window,
+		maxWindows:  maxWindows,
+	}
+}
+
+// JoinQueue gives the user a place in the queue of the product, or reports
+// the one they already hold. A user holds one active place per product:
+// concurrent joins past the lookup collide on the repository's unique index
+// and are reported as a conflict.
+func (uc *UseCase) JoinQueue(ctx context.Context, productID, userID uuid.UUID) (*QueueStatus, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := product.CheckPurchasable(); err != nil {
+		return nil, err
+	}
+	if !product.HighDemandMode {
+		return nil, entity.ConflictError("Product is not in high-demand mode")
+	}
+
+	// Elapsed windows are expired first, so they don't hold the user's place
+	if err := uc.advance(ctx, product); err != nil {
+		return nil, err
+	}
+
+	if _, err := uc.queueRepo.GetActiveEntry(ctx, productID, userID); err != nil {
+		if !errors.Is(err, entity.ErrNotFound) {
+			return nil, err
+		}
+		now := time.Now()
+		entry := &entity.PurchaseQueueEntry{
+			ID:        entity.NewID(),
+			ProductID: productID,
+			UserID:    userID,
+			Status:    entity.QueueWaiting,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := uc.queueRepo.Create(ctx, entry); err != nil {
+			return nil, err
+		}
+	}
+
+	return uc.status(ctx, product, userID)
+}
+
+func (uc *UseCase) GetQueueStatus(ctx context.Context, productID, userID uuid.UUID) (*QueueStatus, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	return uc.status(ctx, product, userID)
+}
+
+// status advances the queue before reading the entry so admissions happen
+// as clients poll, between the runs of the background job
+func (uc *UseCase) status(ctx context.Context, product *entity.Product, userID uuid.UUID) (*QueueStatus, error) {
+	if err := uc.advance(ctx, product); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.queueRepo.GetActiveEntry(ctx, product.ID, userID)
+	if err != nil {
+		return nil, entity.NotFoundError("Not in queue for this product")
+	}
+
+	status := &QueueStatus{Entry: entry}
+	if entry.Status == entity.QueueWaiting {
+		ahead, err := uc.queueRepo.CountAhead(ctx, product.ID, entry.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		status.Position = ahead + 1
+	}
+
+	return status, nil
+}
+
+func (uc *UseCase) AdvanceQueues(ctx context.Context) (int, error) {
+	productIDs, err := uc.queueRepo.ListActiveProductIDs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	advanced := 0
+	for _, productID := range productIDs {
+		if err := ctx.Err(); err != nil {
+			return advanced, err
+		}
+
+		product, err := uc.productRepo.GetByID(ctx, productID)
+		if err != nil {
+			// Entries of deleted products are left for their windows to lapse
+			continue
+		}
+		if err := uc.advance(ctx, product); err != nil {
+			return advanced, err
+		}
+		advanced++
+	}
+
+	return advanced, nil
+}
+
+// advance expires the elapsed purchase windows of the product's queue and
+// admits the users waiting next
+func (uc *UseCase) advance(ctx context.Context, product *entity.Product) error {
+	return uc.queueRepo.Advance(ctx, product.ID, uc.slots(product), uc.window, time.Now())
+}
+
+// slots is the number of purchase windows that may be open at once: never more
+// than the remaining stock, so admitted users can always complete their purchase
+func (uc *UseCase) slots(product *entity.Product) int {
+	available := product.Quantity
+	if product.HasVariants() {
+		available = product.GetTotalVariantStock()
+	}
+
+	if available > uc.maxWindows {
+		return uc.maxWindows
+	}
+	return available
+}
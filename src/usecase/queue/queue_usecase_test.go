@@ -0,0 +1,171 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	uc      *UseCase
+	queue   repository.PurchaseQueueRepository
+	product *entity.Product
+}
+
+// newFixture sets up a high-demand product with 5 in stock and a single
+// purchase window open at once
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	queue := memory.NewPurchaseQueueRepository(store)
+
+	product := &entity.Product{Name: "Console", Price: 500, Quantity: 5, Status: entity.ProductActive, HighDemandMode: true}
+	require.NoError(t, products.Create(context.Background(), product))
+
+	return &fixture{uc: NewUseCase(queue, products, 5*time.Minute, 1), queue: queue, product: product}
+}
+
+// expireWindow makes the purchase window of the user's entry lapse
+func (f *fixture) expireWindow(t *testing.T, userID uuid.UUID) {
+	t.Helper()
+	entry, err := f.queue.GetActiveEntry(context.Background(), f.product.ID, userID)
+	require.NoError(t, err)
+	require.Equal(t, entity.QueueAdmitted, entry.Status)
+	elapsed := time.Now().Add(-time.Second)
+	entry.WindowExpiresAt = &elapsed
+	require.NoError(t, f.queue.Update(context.Background(), entry))
+}
+
+func TestJoinQueue(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Admits the first user and queues the others in arrival order", func(t *testing.T) {
+		f := newFixture(t)
+		first, second, third := uuid.New(), uuid.New(), uuid.New()
+
+		status, err := f.uc.JoinQueue(ctx, f.product.ID, first)
+		require.NoError(t, err)
+		assert.Equal(t, entity.QueueAdmitted, status.Entry.Status)
+		assert.Zero(t, status.Position)
+
+		status, err = f.uc.JoinQueue(ctx, f.product.ID, second)
+		require.NoError(t, err)
+		assert.Equal(t, entity.QueueWaiting, status.Entry.Status)
+		assert.Equal(t, 1, status.Position)
+
+		status, err = f.uc.JoinQueue(ctx, f.product.ID, third)
+		require.NoError(t, err)
+		assert.Equal(t, 2, status.Position)
+	})
+
+	t.Run("Joining again keeps the user's place", func(t *testing.T) {
+		f := newFixture(t)
+		first, second := uuid.New(), uuid.New()
+		_, err := f.uc.JoinQueue(ctx, f.product.ID, first)
+		require.NoError(t, err)
+		joined, err := f.uc.JoinQueue(ctx, f.product.ID, second)
+		require.NoError(t, err)
+
+		again, err := f.uc.JoinQueue(ctx, f.product.ID, second)
+		require.NoError(t, err)
+		assert.Equal(t, joined.Entry.ID, again.Entry.ID)
+		assert.Equal(t, 1, again.Position)
+	})
+
+	t.Run("Rejoins behind the queue once the window lapsed", func(t *testing.T) {
+		f := newFixture(t)
+		first, second, third := uuid.New(), uuid.New(), uuid.New()
+		admitted, err := f.uc.JoinQueue(ctx, f.product.ID, first)
+		require.NoError(t, err)
+		_, err = f.uc.JoinQueue(ctx, f.product.ID, second)
+		require.NoError(t, err)
+		_, err = f.uc.JoinQueue(ctx, f.product.ID, third)
+		require.NoError(t, err)
+		f.expireWindow(t, first)
+
+		status, err := f.uc.JoinQueue(ctx, f.product.ID, first)
+		require.NoError(t, err)
+		assert.NotEqual(t, admitted.Entry.ID, status.Entry.ID)
+		assert.Equal(t, entity.QueueWaiting, status.Entry.Status)
+		assert.Equal(t, 2, status.Position, "the next user took the window")
+
+		status, err = f.uc.GetQueueStatus(ctx, f.product.ID, second)
+		require.NoError(t, err)
+		assert.Equal(t, entity.QueueAdmitted, status.Entry.Status)
+	})
+
+	t.Run("Only high-demand products have a queue", func(t *testing.T) {
+		f := newFixture(t)
+		f.product.HighDemandMode = false
+
+		_, err := NewUseCase(f.queue, productsOf(f.product), 5*time.Minute, 1).JoinQueue(ctx, f.product.ID, uuid.New())
+		assert.ErrorIs(t, err, entity.ErrConflict)
+	})
+
+	t.Run("A concurrent join is a conflict", func(t *testing.T) {
+		queue := new(mocks.PurchaseQueueRepository)
+		product := &entity.Product{ID: uuid.New(), Quantity: 5, Status: entity.ProductActive, HighDemandMode: true}
+		userID := uuid.New()
+		queue.On("Advance", mock.Anything, product.ID, 1, 5*time.Minute, mock.Anything).Return(nil)
+		queue.On("GetActiveEntry", mock.Anything, product.ID, userID).Return(nil, entity.NotFoundError("Queue entry not found"))
+		queue.On("Create", mock.Anything, mock.Anything).Return(entity.ConflictError("Already in queue for this product"))
+
+		_, err := NewUseCase(queue, productsOf(product), 5*time.Minute, 1).JoinQueue(ctx, product.ID, userID)
+		assert.ErrorIs(t, err, entity.ErrConflict)
+		queue.AssertNumberOfCalls(t, "Create", 1)
+	})
+}
+
+func TestGetQueueStatus_NotInQueue(t *testing.T) {
+	f := newFixture(t)
+
+	_, err := f.uc.GetQueueStatus(context.Background(), f.product.ID, uuid.New())
+	assert.ErrorIs(t, err, entity.ErrNotFound)
+}
+
+func TestAdvanceQueues(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	first, second := uuid.New(), uuid.New()
+	_, err := f.uc.JoinQueue(ctx, f.product.ID, first)
+	require.NoError(t, err)
+	_, err = f.uc.JoinQueue(ctx, f.product.ID, second)
+	require.NoError(t, err)
+
+	advanced, err := f.uc.AdvanceQueues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, advanced)
+	entry, err := f.queue.GetActiveEntry(ctx, f.product.ID, second)
+	require.NoError(t, err)
+	assert.Equal(t, entity.QueueWaiting, entry.Status, "the window of the first user is still open")
+
+	f.expireWindow(t, first)
+	advanced, err = f.uc.AdvanceQueues(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, advanced)
+
+	_, err = f.queue.GetActiveEntry(ctx, f.product.ID, first)
+	assert.ErrorIs(t, err, entity.ErrNotFound, "the lapsed window expired")
+	entry, err = f.queue.GetActiveEntry(ctx, f.product.ID, second)
+	require.NoError(t, err)
+	assert.Equal(t, entity.QueueAdmitted, entry.Status)
+	require.NotNil(t, entry.WindowExpiresAt)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), *entry.WindowExpiresAt, time.Minute)
+}
+
+// productsOf serves product from a generated mock
+func productsOf(product *entity.Product) *mocks.ProductRepository {
+	products := new(mocks.ProductRepository)
+	products.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	return products
+}
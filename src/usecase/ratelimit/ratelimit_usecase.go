@@ -0,0 +1,97 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface RateLimitService -out ../../internal/testing/servicemocks
+
+type RateLimitService interface {
+	// Consume counts a request against key's budget and returns the updated quota
+	Consume(key string) entity.RateLimitQuota
+	// Quota returns key's budget without counting a request
+	Quota(key string) entity.RateLimitQuota
+}
+
+// UserKey identifies an authenticated client
+func UserKey(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// IPKey identifies an anonymous client
+func IPKey(ip string) string {
+	return "ip:" + ip
+}
+
+type window struct {
+	used    int
+	resetAt time.Time
+}
+
+// UseCase is an in-memory fixed window limiter. Counters are per process, so
+// with several replicas every instance enforces the limit on its own.
+type UseCase struct {
+	limit     int
+	period    time.Duration
+	now       func() time.Time
+	mu        sync.Mutex
+	windows   map[string]*window
+	nextSweep time.Time
+}
+
+// NewUseCase creates the rate limit use case allowing limit requests per period
+func NewUseCase(limit int, period time.Duration) *UseCase {
+	return &UseCase{
+		limit:   limit,
+		period:  period,
+		now:     time.Now,
+		windows: make(map[string]*window),
+	}
+}
+
+func (uc *UseCase) Consume(key string) entity.RateLimitQuota {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	uc.sweep(now)
+
+	w, ok := uc.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &window{resetAt: now.Add(uc.period)}
+		uc.windows[key] = w
+	}
+	w.used++
+
+	return entity.RateLimitQuota{Limit: uc.limit, Used: w.used, ResetAt: w.resetAt}
+}
+
+func (uc *UseCase) Quota(key string) entity.RateLimitQuota {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	now := uc.now()
+	w, ok := uc.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		return entity.RateLimitQuota{Limit: uc.limit, ResetAt: now.Add(uc.period)}
+	}
+
+	return entity.RateLimitQuota{Limit: uc.limit, Used: w.used, ResetAt: w.resetAt}
+}
+
+// sweep drops elapsed windows at most once per period so idle clients don't pile up
+func (uc *UseCase) sweep(now time.Time) {
+	if now.Before(uc.nextSweep) {
+		return
+	}
+	for key, w := range uc.windows {
+		if !now.Before(w.resetAt) {
+			delete(uc.windows, key)
+		}
+	}
+	uc.nextSweep = now.Add(uc.period)
+}
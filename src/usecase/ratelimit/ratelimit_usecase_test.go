@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func newTestUseCase(limit int, period time.Duration) (*UseCase, *time.Time) {
+	now := time.Date(2026, time.March, 4, 10, 0, 0, 0, time.UTC)
+	uc := NewUseCase(limit, period)
+	uc.now = func() time.Time { return now }
+	return uc, &now
+}
+
+func TestConsume_CountsWithinWindow(t *testing.T) {
+	uc, now := newTestUseCase(3, time.Minute)
+	key := UserKey(uuid.New())
+
+	for i := 0; i < 3; i++ {
+		if quota := uc.Consume(key); quota.Exceeded() {
+			t.Fatalf("request %d should be within the limit", i+1)
+		}
+	}
+
+	quota := uc.Consume(key)
+	if !quota.Exceeded() || quota.Remaining() != 0 {
+		t.Errorf("expected the fourth request to exceed the limit, got %+v", quota)
+	}
+	if !quota.ResetAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("expected reset one window after the first request, got %v", quota.ResetAt)
+	}
+}
+
+func TestConsume_WindowResets(t *testing.T) {
+	uc, now := newTestUseCase(1, time.Minute)
+	key := IPKey("10.0.0.1")
+
+	uc.Consume(key)
+	*now = now.Add(time.Minute)
+
+	if quota := uc.Consume(key); quota.Used != 1 {
+		t.Errorf("expected a fresh window, got %d used", quota.Used)
+	}
+}
+
+func TestConsume_KeysAreIndependent(t *testing.T) {
+	uc, _ := newTestUseCase(1, time.Minute)
+
+	uc.Consume(IPKey("10.0.0.1"))
+	if quota := uc.Consume(IPKey("10.0.0.2")); quota.Exceeded() {
+		t.Error("expected clients to have separate budgets")
+	}
+}
+
+func TestQuota_DoesNotCount(t *testing.T) {
+	uc, _ := newTestUseCase(5, time.Minute)
+	key := UserKey(uuid.New())
+
+	if quota := uc.Quota(key); quota.Remaining() != 5 {
+		t.Errorf("expected an unused budget, got %d remaining", quota.Remaining())
+	}
+
+	uc.Consume(key)
+	uc.Quota(key)
+	if quota := uc.Quota(key); quota.Used != 1 || quota.Remaining() != 4 {
+		t.Errorf("expected only the consumed request to count, got %+v", quota)
+	}
+}
@@ -0,0 +1,352 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+)
+
+var (
+	ErrRecallNotFound    = entity.NotFoundError("Recall not found")
+	ErrNoticeNotFound    = entity.NotFoundError("Recall notice not found")
+	ErrProductNotFound   = entity.NotFoundError("Product not found")
+	ErrVariantNotFound   = entity.NotFoundError("Variant not found")
+	ErrVariantMismatch   = entity.ValidationError("SKU does not belong to the product")
+	ErrTargetRequired    = entity.ValidationError("A SKU or product ID is required")
+	ErrDateRangeRequired = entity.ValidationError("Recall date range is required")
+	ErrInvalidDateRange  = entity.ValidationError("Recall date range ends before it starts")
+	ErrNoAffectedOrders  = entity.ConflictError("No orders are affected by the recall")
+)
+
+// NoticeTemplateKey is the email template used for recall notices. Until an
+// admin creates it, notices are sent with a plain default text.
+const NoticeTemplateKey = "recall.notice"
+
+// Target identifies the recalled goods. A SKU narrows the recall to one
+// variant, a product ID alone covers the product and all of its variants.
+// From and To are inclusive order dates.
+type Target struct {
+	SKU       string
+	ProductID *uuid.UUID
+	Lot       string
+	From      time.Time
+	To        time.Time
+}
+
+type CreateInput struct {
+	Target
+	Title       string
+	Description string
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface RecallService -out ../../internal/testing/servicemocks
+
+type RecallService interface {
+	// FindAffected lists the orders containing the target, without notifying anyone
+	FindAffected(ctx context.Context, target Target) (*entity.RecallImpact, error)
+	// CreateRecall stores the recall with a notice per affected order and notifies their customers
+	CreateRecall(ctx context.Context, actorID uuid.UUID, input CreateInput) (*entity.Recall, error)
+	// ResendNotices retries the notices that are pending or failed
+	ResendNotices(ctx context.Context, actorID uuid.UUID, recallID uuid.UUID) (*entity.Recall, error)
+	GetRecall(ctx context.Context, id uuid.UUID) (*entity.Recall, error)
+	ListRecalls(ctx context.Context, page, pageSize int) ([]*entity.Recall, int, error)
+	// ListMyNotices returns the recall notices of the user's orders
+	ListMyNotices(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error)
+	// Acknowledge records that the user read one of their notices
+	Acknowledge(ctx context.Context, userID uuid.UUID, noticeID uuid.UUID) (*entity.RecallNotice, error)
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface Renderer -out ../../internal/testing/servicemocks -name RecallRenderer
+
+// Renderer fills an email template, see emailtemplate.UseCase
+type Renderer interface {
+	Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	recallRepo  repository.RecallRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.ProductVariantRepository
+	userRepo    repository.UserRepository
+	templates   Renderer
+	notifier    notification.Notifier
+	services    Services
+	now         func() time.Time
+}
+
+func NewUseCase(
+	recallRepo repository.RecallRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.ProductVariantRepository,
+	userRepo repository.UserRepository,
+	templates Renderer,
+	notifier notification.Notifier,
+	services Services,
+) *UseCase {
+	return &UseCase{
+		recallRepo:  recallRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		userRepo:    userRepo,
+		templates:   templates,
+		notifier:    notifier,
+		services:    services,
+		now:         time.Now,
+	}
+}
+
+func (uc *UseCase) FindAffected(ctx context.Context, target Target) (*entity.RecallImpact, error) {
+	impact, _, err := uc.findAffected(ctx, target)
+	return impact, err
+}
+
+func (uc *UseCase) findAffected(ctx context.Context, target Target) (*entity.RecallImpact, *entity.Product, error) {
+	if target.From.IsZero() || target.To.IsZero() {
+		return nil, nil, ErrDateRangeRequired
+	}
+	if target.To.Before(target.From) {
+		return nil, nil, ErrInvalidDateRange
+	}
+
+	var productID uuid.UUID
+	var variantID *uuid.UUID
+	var sku string
+	switch {
+	case strings.TrimSpace(target.SKU) != "":
+		variant, err := uc.variantRepo.GetBySKU(ctx, strings.TrimSpace(target.SKU))
+		if err != nil {
+			return nil, nil, ErrVariantNotFound
+		}
+		if target.ProductID != nil && *target.ProductID != variant.ProductID {
+			return nil, nil, ErrVariantMismatch
+		}
+		productID = variant.ProductID
+		variantID = &variant.ID
+		sku = variant.SKU
+	case target.ProductID != nil:
+		productID = *target.ProductID
+	default:
+		return nil, nil, ErrTargetRequired
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil {
+		return nil, nil, ErrProductNotFound
+	}
+
+	orders, err := uc.recallRepo.FindAffectedOrders(ctx, repository.RecallCriteria{
+		ProductID: productID,
+		VariantID: variantID,
+		From:      startOfDay(target.From),
+		Until:     startOfDay(target.To).AddDate(0, 0, 1),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return entity.NewRecallImpact(productID, variantID, sku, orders), product, nil
+}
+
+func (uc *UseCase) CreateRecall(ctx context.Context, actorID uuid.UUID, input CreateInput) (*entity.Recall, error) {
+	impact, product, err := uc.findAffected(ctx, input.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	recall := &entity.Recall{
+		ID:          entity.NewID(),
+		Title:       strings.TrimSpace(input.Title),
+		Description: strings.TrimSpace(input.Description),
+		ProductID:   impact.ProductID,
+		VariantID:   impact.VariantID,
+		SKU:         impact.SKU,
+		Lot:         strings.TrimSpace(input.Lot),
+		OrderedFrom: startOfDay(input.From),
+		OrderedTo:   startOfDay(input.To),
+		CreatedBy:   actorID,
+		CreatedAt:   uc.now(),
+	}
+	if err := recall.Validate(); err != nil {
+		return nil, err
+	}
+	if len(impact.Orders) == 0 {
+		return nil, ErrNoAffectedOrders
+	}
+
+	for _, order := range impact.Orders {
+		recall.Notices = append(recall.Notices, entity.NewRecallNotice(recall.ID, order))
+	}
+
+	if err := uc.recallRepo.Create(ctx, recall); err != nil {
+		return nil, err
+	}
+
+	uc.sendNotices(ctx, recall, product)
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "CREATE_RECALL", "Recall", recall.ID, nil, recall.Summary())
+
+	return recall, nil
+}
+
+func (uc *UseCase) ResendNotices(ctx context.Context, actorID uuid.UUID, recallID uuid.UUID) (*entity.Recall, error) {
+	recall, err := uc.recallRepo.GetByID(ctx, recallID)
+	if err != nil {
+		return nil, ErrRecallNotFound
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, recall.ProductID)
+	if err != nil {
+		return nil, ErrProductNotFound
+	}
+
+	before := recall.Summary()
+	uc.sendNotices(ctx, recall, product)
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "RESEND_RECALL", "Recall", recall.ID, before, recall.Summary())
+
+	return recall, nil
+}
+
+// sendNotices notifies the customer of every pending or failed notice. Each
+// outcome is stored on its notice, so one bad address doesn't stop the campaign.
+func (uc *UseCase) sendNotices(ctx context.Context, recall *entity.Recall, product *entity.Product) {
+	for i := range recall.Notices {
+		notice := &recall.Notices[i]
+		if notice.Status != entity.NoticePending && notice.Status != entity.NoticeFailed {
+			continue
+		}
+
+		if err := uc.sendNotice(ctx, recall, notice, product); err != nil {
+			notice.MarkFailed(err)
+		} else {
+			notice.MarkSent(uc.now())
+		}
+
+		if err := uc.recallRepo.UpdateNotice(ctx, notice); err != nil {
+			fmt.Printf("Failed to update recall notice %s: %v\n", notice.ID, err)
+		}
+	}
+}
+
+func (uc *UseCase) sendNotice(ctx context.Context, recall *entity.Recall, notice *entity.RecallNotice, product *entity.Product) error {
+	user, err := uc.userRepo.GetByID(ctx, *notice.UserID)
+	if err != nil {
+		return fmt.Errorf("customer account not found: %w", err)
+	}
+
+	data := map[string]interface{}{
+		"customer_name": user.Name,
+		"recall_title":  recall.Title,
+		"description":   recall.Description,
+		"product_name":  product.Name,
+		"sku":           recall.SKU,
+		"lot":           recall.Lot,
+		"order_id":      notice.OrderID.String(),
+		"quantity":      notice.Quantity,
+		"notice_id":     notice.ID.String(),
+	}
+
+	email, err := uc.templates.Render(ctx, NoticeTemplateKey, data)
+	if errors.Is(err, emailtemplate.ErrTemplateNotFound) {
+		email = defaultNotice(data)
+	} else if err != nil {
+		return err
+	}
+
+	return uc.notifier.Notify(ctx, notification.Notification{
+		Recipients: []string{user.Email},
+		Subject:    email.Subject,
+		Body:       email.Body,
+	})
+}
+
+// defaultNotice is sent while no recall.notice template exists
+func defaultNotice(data map[string]interface{}) *entity.RenderedEmail {
+	text := func(key string) string {
+		return html.EscapeString(fmt.Sprint(data[key]))
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "<p>Hi %s,</p>", text("customer_name"))
+	fmt.Fprintf(&body, "<p>Your order %s contains %s unit(s) of %s", text("order_id"), text("quantity"), text("product_name"))
+	if data["sku"] != "" {
+		fmt.Fprintf(&body, " (SKU %s)", text("sku"))
+	}
+	body.WriteString(", which is being recalled")
+	if data["lot"] != "" {
+		fmt.Fprintf(&body, " for lot %s", text("lot"))
+	}
+	fmt.Fprintf(&body, ".</p><p>%s</p>", text("description"))
+	fmt.Fprintf(&body, "<p>Please confirm you received this notice in your account (notice %s).</p>", text("notice_id"))
+
+	return &entity.RenderedEmail{
+		Subject: fmt.Sprintf("Safety recall: %s", data["recall_title"]),
+		Body:    body.String(),
+	}
+}
+
+func (uc *UseCase) GetRecall(ctx context.Context, id uuid.UUID) (*entity.Recall, error) {
+	recall, err := uc.recallRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrRecallNotFound
+	}
+	return recall, nil
+}
+
+func (uc *UseCase) ListRecalls(ctx context.Context, page, pageSize int) ([]*entity.Recall, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return uc.recallRepo.List(ctx, page, pageSize)
+}
+
+func (uc *UseCase) ListMyNotices(ctx context.Context, userID uuid.UUID) ([]*entity.RecallNotice, error) {
+	return uc.recallRepo.ListNoticesByUser(ctx, userID)
+}
+
+func (uc *UseCase) Acknowledge(ctx context.Context, userID uuid.UUID, noticeID uuid.UUID) (*entity.RecallNotice, error) {
+	notice, err := uc.recallRepo.GetNotice(ctx, noticeID)
+	if err != nil {
+		return nil, ErrNoticeNotFound
+	}
+
+	// Other customers' notices are reported as missing
+	if notice.UserID == nil || *notice.UserID != userID {
+		return nil, ErrNoticeNotFound
+	}
+
+	if notice.Status == entity.NoticeAcknowledged {
+		return notice, nil
+	}
+
+	notice.Acknowledge(uc.now())
+	if err := uc.recallRepo.UpdateNotice(ctx, notice); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "ACKNOWLEDGE_RECALL", "RecallNotice", notice.ID, nil, notice.AcknowledgedAt)
+
+	return notice, nil
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
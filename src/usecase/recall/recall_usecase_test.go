@@ -0,0 +1,227 @@
+package recall
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/notification"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/marcofilho/go-ecommerce/src/usecase/emailtemplate"
+	"github.com/stretchr/testify/mock"
+)
+
+// missingTemplates behaves like an emailtemplate.UseCase without a recall.notice template
+type missingTemplates struct{}
+
+func (missingTemplates) Render(ctx context.Context, key string, data map[string]interface{}) (*entity.RenderedEmail, error) {
+	return nil, emailtemplate.ErrTemplateNotFound
+}
+
+type fixture struct {
+	uc       *UseCase
+	repo     *mocks.RecallRepository
+	notifier *mocks.Notifier
+	product  *entity.Product
+	variant  *entity.ProductVariant
+	customer *entity.User
+}
+
+func newFixture(affected func(customer *entity.User) []entity.AffectedOrder) *fixture {
+	product := &entity.Product{ID: uuid.New(), Name: "Space Heater", Price: 80}
+	variant := &entity.ProductVariant{ID: uuid.New(), ProductID: product.ID, SKU: "HEAT-1500"}
+	customer := &entity.User{ID: uuid.New(), Email: "jane@example.com", Name: "Jane"}
+
+	recallRepo := new(mocks.RecallRepository)
+	recallRepo.On("FindAffectedOrders", mock.Anything, mock.Anything).Return(affected(customer), nil)
+	recallRepo.On("UpdateNotice", mock.Anything, mock.AnythingOfType("*entity.RecallNotice")).Return(nil)
+	recallRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Recall")).Return(nil).
+		Run(func(args mock.Arguments) {
+			recall := args.Get(1).(*entity.Recall)
+			for i := range recall.Notices {
+				recallRepo.On("GetNotice", mock.Anything, recall.Notices[i].ID).Return(&recall.Notices[i], nil)
+			}
+		})
+
+	productRepo := new(mocks.ProductRepository)
+	productRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	productRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("Product not found"))
+	variantRepo := new(mocks.ProductVariantRepository)
+	variantRepo.On("GetBySKU", mock.Anything, variant.SKU).Return(variant, nil)
+	variantRepo.On("GetBySKU", mock.Anything, mock.Anything).Return(nil, errors.New("Product variant not found"))
+	userRepo := new(mocks.UserRepository)
+	userRepo.On("GetByID", mock.Anything, customer.ID).Return(customer, nil)
+	userRepo.On("GetByID", mock.Anything, mock.Anything).Return(nil, errors.New("User not found"))
+	notifier := new(mocks.Notifier)
+	notifier.On("Notify", mock.Anything, mock.Anything).Return(nil)
+
+	f := &fixture{
+		repo:     recallRepo,
+		notifier: notifier,
+		product:  product,
+		variant:  variant,
+		customer: customer,
+	}
+	f.uc = NewUseCase(
+		recallRepo,
+		productRepo,
+		variantRepo,
+		userRepo,
+		missingTemplates{},
+		f.notifier,
+		&mockServices.MockServices{AuditService: &mockServices.MockAuditService{}},
+	)
+	f.uc.now = func() time.Time { return time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC) }
+	return f
+}
+
+func day(value string) time.Time {
+	t, _ := time.Parse("2006-01-02", value)
+	return t
+}
+
+func TestFindAffected_BySKU(t *testing.T) {
+	f := newFixture(func(customer *entity.User) []entity.AffectedOrder {
+		return []entity.AffectedOrder{
+			{OrderID: uuid.New(), UserID: &customer.ID, CustomerID: 1, Quantity: 2},
+			{OrderID: uuid.New(), UserID: &customer.ID, CustomerID: 1, Quantity: 1},
+			{OrderID: uuid.New(), CustomerID: 2, Quantity: 3},
+		}
+	})
+
+	affected, err := f.uc.FindAffected(context.Background(), Target{SKU: "HEAT-1500", From: day("2024-01-01"), To: day("2024-03-31")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if affected.ProductID != f.product.ID || affected.VariantID == nil || *affected.VariantID != f.variant.ID {
+		t.Errorf("expected the recall to target the variant, got %+v", affected)
+	}
+	if affected.Customers != 2 || affected.Units != 6 || len(affected.Orders) != 3 {
+		t.Errorf("expected 3 orders, 2 customers and 6 units, got %d, %d and %d", len(affected.Orders), affected.Customers, affected.Units)
+	}
+
+	// The last day of the range is included
+	f.repo.AssertCalled(t, "FindAffectedOrders", mock.Anything, mock.MatchedBy(func(criteria repository.RecallCriteria) bool {
+		return criteria.Until.Equal(day("2024-04-01"))
+	}))
+}
+
+func TestFindAffected_InvalidTarget(t *testing.T) {
+	f := newFixture(func(customer *entity.User) []entity.AffectedOrder { return nil })
+	otherProduct := uuid.New()
+
+	tests := []struct {
+		name   string
+		target Target
+		want   error
+	}{
+		{"no target", Target{From: day("2024-01-01"), To: day("2024-01-31")}, ErrTargetRequired},
+		{"unknown SKU", Target{SKU: "NOPE", From: day("2024-01-01"), To: day("2024-01-31")}, ErrVariantNotFound},
+		{"SKU of another product", Target{SKU: "HEAT-1500", ProductID: &otherProduct, From: day("2024-01-01"), To: day("2024-01-31")}, ErrVariantMismatch},
+		{"unknown product", Target{ProductID: &otherProduct, From: day("2024-01-01"), To: day("2024-01-31")}, ErrProductNotFound},
+		{"missing dates", Target{SKU: "HEAT-1500"}, ErrDateRangeRequired},
+		{"reversed dates", Target{SKU: "HEAT-1500", From: day("2024-02-01"), To: day("2024-01-01")}, ErrInvalidDateRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.uc.FindAffected(context.Background(), tt.target)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
+
+func TestCreateRecall_NotifiesAffectedCustomers(t *testing.T) {
+	missingUser := uuid.New()
+	f := newFixture(func(customer *entity.User) []entity.AffectedOrder {
+		return []entity.AffectedOrder{
+			{OrderID: uuid.New(), UserID: &customer.ID, CustomerID: 1, Quantity: 2},
+			{OrderID: uuid.New(), CustomerID: 2, Quantity: 1},
+			{OrderID: uuid.New(), UserID: &missingUser, CustomerID: 3, Quantity: 1},
+		}
+	})
+
+	recall, err := f.uc.CreateRecall(context.Background(), uuid.New(), CreateInput{
+		Target:      Target{SKU: "HEAT-1500", Lot: "L-2024-07", From: day("2024-01-01"), To: day("2024-03-31")},
+		Title:       "Overheating risk",
+		Description: "Stop using the heater and return it for a full refund.",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	summary := recall.Summary()
+	if summary.Orders != 3 || summary.Sent != 1 || summary.Unreachable != 1 || summary.Failed != 1 {
+		t.Errorf("expected 1 sent, 1 unreachable and 1 failed notice, got %+v", summary)
+	}
+	f.repo.AssertNumberOfCalls(t, "UpdateNotice", 2)
+
+	f.notifier.AssertNumberOfCalls(t, "Notify", 1)
+	sent := f.notifier.Calls[0].Arguments.Get(1).(notification.Notification)
+	if sent.Recipients[0] != f.customer.Email || sent.Subject != "Safety recall: Overheating risk" {
+		t.Errorf("unexpected notification %+v", sent)
+	}
+	if !strings.Contains(sent.Body, "lot L-2024-07") || !strings.Contains(sent.Body, "SKU HEAT-1500") {
+		t.Errorf("expected the default notice to quote the SKU and lot, got %q", sent.Body)
+	}
+}
+
+func TestCreateRecall_NoAffectedOrders(t *testing.T) {
+	f := newFixture(func(customer *entity.User) []entity.AffectedOrder { return nil })
+
+	_, err := f.uc.CreateRecall(context.Background(), uuid.New(), CreateInput{
+		Target:      Target{SKU: "HEAT-1500", From: day("2024-01-01"), To: day("2024-03-31")},
+		Title:       "Overheating risk",
+		Description: "Stop using the heater.",
+	})
+	if !errors.Is(err, ErrNoAffectedOrders) {
+		t.Errorf("expected ErrNoAffectedOrders, got %v", err)
+	}
+	f.repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestAcknowledge(t *testing.T) {
+	f := newFixture(func(customer *entity.User) []entity.AffectedOrder {
+		return []entity.AffectedOrder{{OrderID: uuid.New(), UserID: &customer.ID, CustomerID: 1, Quantity: 1}}
+	})
+
+	recall, err := f.uc.CreateRecall(context.Background(), uuid.New(), CreateInput{
+		Target:      Target{SKU: "HEAT-1500", From: day("2024-01-01"), To: day("2024-03-31")},
+		Title:       "Overheating risk",
+		Description: "Stop using the heater.",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	noticeID := recall.Notices[0].ID
+
+	if _, err := f.uc.Acknowledge(context.Background(), uuid.New(), noticeID); !errors.Is(err, ErrNoticeNotFound) {
+		t.Errorf("expected another user's notice to be not found, got %v", err)
+	}
+
+	notice, err := f.uc.Acknowledge(context.Background(), f.customer.ID, noticeID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if notice.Status != entity.NoticeAcknowledged || notice.AcknowledgedAt == nil {
+		t.Errorf("expected the notice to be acknowledged, got %+v", notice)
+	}
+
+	f.uc.now = func() time.Time { return time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC) }
+	again, err := f.uc.Acknowledge(context.Background(), f.customer.ID, noticeID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.AcknowledgedAt.Month() != time.June {
+		t.Errorf("expected the first acknowledgment to be kept, got %v", again.AcknowledgedAt)
+	}
+}
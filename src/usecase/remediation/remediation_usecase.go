@@ -0,0 +1,289 @@
+package remediation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+)
+
+var (
+	ErrOrderNotFound     = entity.NotFoundError("Order not found")
+	ErrOrderNotEligible  = entity.ConflictError("Only paid orders that are not cancelled can be remediated")
+	ErrItemNotFound      = entity.NotFoundError("Order item not found")
+	ErrQuantityExceeded  = entity.ValidationError("Cannot resend more items than were ordered")
+	ErrInsufficientStock = entity.InsufficientStockError("Insufficient stock to resend the item")
+	ErrExceedsOrderTotal = entity.ConflictError("Refunds and credits cannot exceed what was paid for the order")
+	ErrBudgetExceeded    = entity.ForbiddenError("Remediation budget exceeded")
+	ErrRoleNotAllowed    = entity.ForbiddenError("Role has no remediation budget")
+)
+
+// BudgetWindow is the rolling period over which an agent's budget is spent
+const BudgetWindow = 24 * time.Hour
+
+// Budgets is the amount each role may spend on remediations per BudgetWindow.
+// Roles without an entry cannot remediate orders.
+type Budgets map[entity.Role]float64
+
+// Actor is the support agent or admin performing a remediation
+type Actor struct {
+	ID   uuid.UUID
+	Role entity.Role
+}
+
+type Request struct {
+	Action      entity.RemediationAction
+	Reason      entity.RemediationReason
+	Amount      float64    // Refund or credit amount, ignored for resends
+	OrderItemID *uuid.UUID // Item to resend
+	Quantity    int        // Number of items to resend
+	Note        string
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface RemediationService -out ../../internal/testing/servicemocks
+
+type RemediationService interface {
+	// Remediate applies a refund without return, goodwill credit or resend to an order
+	Remediate(ctx context.Context, orderID uuid.UUID, actor Actor, req Request) (*entity.OrderRemediation, error)
+	ListRemediations(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetStockRecorder() stock.Recorder
+}
+
+type UseCase struct {
+	remediationRepo repository.OrderRemediationRepository
+	orderRepo       repository.OrderRepository
+	productRepo     repository.ProductRepository
+	variantRepo     repository.ProductVariantRepository
+	webhookRepo     repository.WebhookRepository
+	gateway         refund.Gateway
+	budgets         Budgets
+	services        Services
+}
+
+func NewUseCase(
+	remediationRepo repository.OrderRemediationRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.ProductVariantRepository,
+	webhookRepo repository.WebhookRepository,
+	gateway refund.Gateway,
+	budgets Budgets,
+	services Services,
+) *UseCase {
+	return &UseCase{
+		remediationRepo: remediationRepo,
+		orderRepo:       orderRepo,
+		productRepo:     productRepo,
+		variantRepo:     variantRepo,
+		webhookRepo:     webhookRepo,
+		gateway:         gateway,
+		budgets:         budgets,
+		services:        services,
+	}
+}
+
+// Remediate applies a remediation to a paid order. Refunds and credits are
+// recorded on the payment of the order, like refunded returns, and never give
+// back more than is left of what was paid. Refunds go through the payment
+// provider.
+func (uc *UseCase) Remediate(ctx context.Context, orderID uuid.UUID, actor Actor, req Request) (*entity.OrderRemediation, error) {
+	budget, ok := uc.budgets[actor.Role]
+	if !ok {
+		return nil, ErrRoleNotAllowed
+	}
+
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	if !order.IsPaid() || order.IsVoid() {
+		return nil, ErrOrderNotEligible
+	}
+
+	remediation := &entity.OrderRemediation{
+		ID:              entity.NewID(),
+		OrderID:         order.ID,
+		Action:          req.Action,
+		Reason:          req.Reason,
+		Note:            req.Note,
+		PerformedBy:     actor.ID,
+		PerformedByRole: actor.Role,
+		CreatedAt:       time.Now(),
+	}
+
+	if req.Action == entity.RemediationResend {
+		remediation.OrderItemID = req.OrderItemID
+		remediation.Quantity = req.Quantity
+	} else {
+		remediation.Amount = round(req.Amount)
+	}
+
+	if err := remediation.Validate(); err != nil {
+		return nil, err
+	}
+
+	var item *entity.OrderItem
+	if remediation.IsPayout() {
+		if remediation.Amount > order.Refundable() {
+			return nil, fmt.Errorf("%w: %.2f remaining", ErrExceedsOrderTotal, math.Max(order.Refundable(), 0))
+		}
+	} else {
+		item = findItem(order, *req.OrderItemID)
+		if item == nil {
+			return nil, ErrItemNotFound
+		}
+		if req.Quantity > item.Quantity {
+			return nil, ErrQuantityExceeded
+		}
+		// A resend costs the store the value of the items sent, as charged on the line
+		remediation.Amount = item.AmountFor(req.Quantity)
+	}
+
+	spent, err := uc.remediationRepo.SumByActorSince(ctx, actor.ID, remediation.CreatedAt.Add(-BudgetWindow))
+	if err != nil {
+		return nil, err
+	}
+	if round(spent+remediation.Amount) > budget {
+		return nil, fmt.Errorf("%w: %.2f of %.2f left for the last 24 hours", ErrBudgetExceeded, math.Max(budget-spent, 0), budget)
+	}
+
+	switch remediation.Action {
+	case entity.RemediationResend:
+		if err := uc.shipReplacement(ctx, order, item, req.Quantity); err != nil {
+			return nil, err
+		}
+	case entity.RemediationRefund:
+		if err := uc.refund(ctx, order, remediation, actor); err != nil {
+			return nil, err
+		}
+	case entity.RemediationGoodwillCredit:
+		if err := order.CreditPayment(remediation.Amount); err != nil {
+			return nil, err
+		}
+		if err := uc.orderRepo.Update(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.remediationRepo.Create(ctx, remediation); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actor.ID, "REMEDIATE", "Order", order.ID, nil, remediation)
+
+	return remediation, nil
+}
+
+// refund pays the remediation back through the payment provider and records it
+// against the payment of the order
+func (uc *UseCase) refund(ctx context.Context, order *entity.Order, remediation *entity.OrderRemediation, actor Actor) error {
+	transactionID, err := uc.transactionID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	reference, err := uc.gateway.Refund(ctx, refund.Request{
+		OrderID:        order.ID,
+		TransactionID:  transactionID,
+		Amount:         remediation.Amount,
+		IdempotencyKey: "remediation-" + remediation.ID.String(),
+		Reason:         string(remediation.Reason),
+	})
+	if err != nil {
+		uc.services.GetAuditService().LogChange(ctx, &actor.ID, "REMEDIATE_REFUND_FAILED", "Order", order.ID, nil,
+			map[string]interface{}{"amount": remediation.Amount, "error": err.Error()})
+		return fmt.Errorf("Failed to refund the order: %w", err)
+	}
+
+	if err := order.RefundPayment(remediation.Amount); err != nil {
+		return err
+	}
+	if err := uc.orderRepo.Update(ctx, order); err != nil {
+		return err
+	}
+	uc.services.GetAuditService().LogChange(ctx, &actor.ID, "REMEDIATE_REFUND", "Order", order.ID, nil,
+		map[string]interface{}{"amount": remediation.Amount, "reference": reference})
+	return nil
+}
+
+// transactionID returns the payment transaction of the order, empty when no
+// processed payment webhook recorded one
+func (uc *UseCase) transactionID(ctx context.Context, orderID uuid.UUID) (string, error) {
+	paid, completed := entity.Paid, entity.WebhookStatusCompleted
+	logs, _, err := uc.webhookRepo.GetByOrderID(ctx, orderID.String(), repository.WebhookLogFilters{
+		PaymentStatus: &paid,
+		Status:        &completed,
+	}, 1, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(logs) == 0 {
+		return "", nil
+	}
+	return logs[0].TransactionID, nil
+}
+
+// shipReplacement takes the resent items out of stock and records the movement
+func (uc *UseCase) shipReplacement(ctx context.Context, order *entity.Order, item *entity.OrderItem, quantity int) error {
+	if item.VariantID != nil {
+		variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
+		if err != nil {
+			return ErrItemNotFound
+		}
+		before := variant.Quantity
+		if err := variant.DecreaseStock(quantity); err != nil {
+			return ErrInsufficientStock
+		}
+		if err := uc.variantRepo.Update(ctx, variant); err != nil {
+			return err
+		}
+		uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(item.ProductID, item.VariantID, entity.StockResend, before, variant.Quantity, order.ID.String()))
+		return nil
+	}
+
+	product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+	if err != nil {
+		return ErrItemNotFound
+	}
+	before := product.Quantity
+	if err := product.DecreaseStock(quantity); err != nil {
+		return ErrInsufficientStock
+	}
+	if err := uc.productRepo.Update(ctx, product); err != nil {
+		return err
+	}
+	uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(product.ID, nil, entity.StockResend, before, product.Quantity, order.ID.String()))
+	return nil
+}
+
+func (uc *UseCase) ListRemediations(ctx context.Context, orderID uuid.UUID) ([]*entity.OrderRemediation, error) {
+	if _, err := uc.orderRepo.GetByID(ctx, orderID); err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	return uc.remediationRepo.ListByOrder(ctx, orderID)
+}
+
+func findItem(order *entity.Order, itemID uuid.UUID) *entity.OrderItem {
+	for i := range order.Products {
+		if order.Products[i].ID == itemID {
+			return &order.Products[i]
+		}
+	}
+	return nil
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,313 @@
+package remediation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+type auditEntry struct {
+	userID *uuid.UUID
+	action string
+}
+
+type recordingAuditService struct {
+	entries []auditEntry
+}
+
+func (a *recordingAuditService) LogChange(ctx context.Context, userID *uuid.UUID, action, resourceType string, resourceID uuid.UUID, before, after interface{}) error {
+	a.entries = append(a.entries, auditEntry{userID: userID, action: action})
+	return nil
+}
+
+type fixture struct {
+	uc              *UseCase
+	order           *entity.Order
+	product         *entity.Product
+	remediationRepo *mocks.OrderRemediationRepository
+	orderRepo       *mocks.OrderRepository
+	gateway         *mocks.RefundGateway
+	audit           *recordingAuditService
+	stock           *mockServices.MockStockRecorder
+}
+
+func newFixture() *fixture {
+	product := &entity.Product{ID: uuid.New(), Name: "Mug", Price: 20, Quantity: 5}
+	order := &entity.Order{
+		ID:            uuid.New(),
+		CustomerID:    1,
+		Status:        entity.Completed,
+		PaymentStatus: entity.Paid,
+		Products: []entity.OrderItem{
+			{ID: uuid.New(), ProductID: product.ID, Quantity: 3, Price: 20, TotalPrice: 60},
+		},
+		TotalPrice: 60,
+		AmountPaid: 60,
+	}
+
+	remediationRepo := new(mocks.OrderRemediationRepository)
+	remediationRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.OrderRemediation")).Return(nil)
+	orderRepo := new(mocks.OrderRepository)
+	orderRepo.On("GetByID", mock.Anything, order.ID).Return(order, nil)
+	orderRepo.On("Update", mock.Anything, order).Return(nil)
+	webhookRepo := new(mocks.WebhookRepository)
+	webhookRepo.On("GetByOrderID", mock.Anything, order.ID.String(), mock.Anything, 1, 1).
+		Return([]entity.WebhookLog{{TransactionID: "txn_1"}}, 1, nil)
+	gateway := new(mocks.RefundGateway)
+	gateway.On("Refund", mock.Anything, mock.Anything).Return("re_1", nil)
+	productRepo := new(mocks.ProductRepository)
+	productRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	productRepo.On("Update", mock.Anything, product).Return(nil)
+
+	f := &fixture{
+		order:           order,
+		product:         product,
+		remediationRepo: remediationRepo,
+		orderRepo:       orderRepo,
+		gateway:         gateway,
+		audit:           &recordingAuditService{},
+		stock:           &mockServices.MockStockRecorder{},
+	}
+	f.uc = NewUseCase(
+		remediationRepo,
+		orderRepo,
+		productRepo,
+		new(mocks.ProductVariantRepository),
+		webhookRepo,
+		gateway,
+		Budgets{entity.RoleSupport: 50, entity.RoleAdmin: 500},
+		&mockServices.MockServices{AuditService: f.audit, StockRecorder: f.stock},
+	)
+	return f
+}
+
+// spent makes what the actor spent over the budget window add up to amount
+func (f *fixture) spent(actor Actor, amount float64) *mock.Call {
+	return f.remediationRepo.On("SumByActorSince", mock.Anything, actor.ID, mock.Anything).Return(amount, nil)
+}
+
+var support = Actor{ID: uuid.New(), Role: entity.RoleSupport}
+
+func TestRemediate_GoodwillCreditIsAudited(t *testing.T) {
+	f := newFixture()
+	f.spent(support, 0)
+
+	result, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationGoodwillCredit,
+		Reason: entity.ReasonLateDelivery,
+		Amount: 10,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.PerformedBy != support.ID || result.PerformedByRole != entity.RoleSupport {
+		t.Error("expected the agent and role to be recorded")
+	}
+	if len(f.audit.entries) != 1 || f.audit.entries[0].action != "REMEDIATE" || *f.audit.entries[0].userID != support.ID {
+		t.Errorf("expected a REMEDIATE audit entry by the agent, got %+v", f.audit.entries)
+	}
+	if f.order.AmountCredited != 10 || f.order.PaymentStatus != entity.Paid {
+		t.Errorf("expected 10 credited on the paid order, got %.2f on %s", f.order.AmountCredited, f.order.PaymentStatus)
+	}
+	f.gateway.AssertNotCalled(t, "Refund", mock.Anything, mock.Anything)
+}
+
+func TestRemediate_RefundGoesThroughProvider(t *testing.T) {
+	f := newFixture()
+	f.spent(support, 0)
+
+	result, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationRefund,
+		Reason: entity.ReasonDamaged,
+		Amount: 20,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	f.gateway.AssertNumberOfCalls(t, "Refund", 1)
+	req := f.gateway.Calls[0].Arguments.Get(1).(refund.Request)
+	if req.Amount != 20 || req.TransactionID != "txn_1" || req.IdempotencyKey != "remediation-"+result.ID.String() {
+		t.Errorf("unexpected refund request %+v", req)
+	}
+	if f.order.AmountRefunded != 20 || f.order.PaymentStatus != entity.PartiallyRefunded {
+		t.Errorf("expected 20 refunded on a partially refunded order, got %.2f on %s", f.order.AmountRefunded, f.order.PaymentStatus)
+	}
+	f.orderRepo.AssertCalled(t, "Update", mock.Anything, f.order)
+}
+
+func TestRemediate_FailedRefundIsNotRecorded(t *testing.T) {
+	f := newFixture()
+	f.spent(support, 0)
+	f.gateway.ExpectedCalls = nil
+	f.gateway.On("Refund", mock.Anything, mock.Anything).Return("", errors.New("provider unavailable"))
+
+	_, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationRefund,
+		Reason: entity.ReasonDamaged,
+		Amount: 20,
+	})
+	if err == nil {
+		t.Fatal("expected the provider failure to be returned")
+	}
+	if f.order.AmountRefunded != 0 || f.order.PaymentStatus != entity.Paid {
+		t.Errorf("expected the order left alone, got %.2f refunded on %s", f.order.AmountRefunded, f.order.PaymentStatus)
+	}
+	f.orderRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	f.remediationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	if len(f.audit.entries) != 1 || f.audit.entries[0].action != "REMEDIATE_REFUND_FAILED" {
+		t.Errorf("expected a REMEDIATE_REFUND_FAILED audit entry, got %+v", f.audit.entries)
+	}
+}
+
+func TestRemediate_RequiresValidReasonCode(t *testing.T) {
+	f := newFixture()
+
+	_, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationRefund,
+		Reason: "customer_was_nice",
+		Amount: 10,
+	})
+	if err == nil {
+		t.Fatal("expected an invalid reason code to be rejected")
+	}
+
+	_, err = f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationRefund,
+		Reason: entity.ReasonOther,
+		Amount: 10,
+	})
+	if err == nil {
+		t.Error("expected reason 'other' without a note to be rejected")
+	}
+}
+
+func TestRemediate_BudgetPerRole(t *testing.T) {
+	f := newFixture()
+	admin := Actor{ID: uuid.New(), Role: entity.RoleAdmin}
+	req := Request{Action: entity.RemediationRefund, Reason: entity.ReasonDamaged, Amount: 30}
+	f.spent(support, 0).Once()
+	f.spent(support, 30)
+	f.spent(admin, 0)
+
+	if _, err := f.uc.Remediate(context.Background(), f.order.ID, support, req); err != nil {
+		t.Fatalf("expected first refund within budget, got %v", err)
+	}
+
+	req.Amount = 25
+	if _, err := f.uc.Remediate(context.Background(), f.order.ID, support, req); !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded for support agent, got %v", err)
+	}
+
+	if _, err := f.uc.Remediate(context.Background(), f.order.ID, admin, req); err != nil {
+		t.Errorf("expected admin budget to allow the refund, got %v", err)
+	}
+
+	customer := Actor{ID: uuid.New(), Role: entity.RoleCustomer}
+	if _, err := f.uc.Remediate(context.Background(), f.order.ID, customer, req); !errors.Is(err, ErrRoleNotAllowed) {
+		t.Errorf("expected ErrRoleNotAllowed for customer, got %v", err)
+	}
+}
+
+func TestRemediate_PayoutsCannotExceedWhatWasPaid(t *testing.T) {
+	f := newFixture()
+	admin := Actor{ID: uuid.New(), Role: entity.RoleAdmin}
+	f.spent(admin, 0)
+
+	if _, err := f.uc.Remediate(context.Background(), f.order.ID, admin, Request{
+		Action: entity.RemediationRefund, Reason: entity.ReasonNotReceived, Amount: 50,
+	}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	_, err := f.uc.Remediate(context.Background(), f.order.ID, admin, Request{
+		Action: entity.RemediationGoodwillCredit, Reason: entity.ReasonNotReceived, Amount: 15,
+	})
+	if !errors.Is(err, ErrExceedsOrderTotal) {
+		t.Errorf("expected ErrExceedsOrderTotal, got %v", err)
+	}
+
+	// A return refunded outside remediations leaves less to give back
+	f.order.AmountRefunded = 55
+	_, err = f.uc.Remediate(context.Background(), f.order.ID, admin, Request{
+		Action: entity.RemediationGoodwillCredit, Reason: entity.ReasonNotReceived, Amount: 8,
+	})
+	if !errors.Is(err, ErrExceedsOrderTotal) {
+		t.Errorf("expected ErrExceedsOrderTotal after a refunded return, got %v", err)
+	}
+}
+
+func TestRemediate_UnpaidOrderIsNotEligible(t *testing.T) {
+	f := newFixture()
+	f.order.PaymentStatus = entity.Unpaid
+
+	_, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action: entity.RemediationGoodwillCredit, Reason: entity.ReasonLateDelivery, Amount: 5,
+	})
+	if !errors.Is(err, ErrOrderNotEligible) {
+		t.Errorf("expected ErrOrderNotEligible, got %v", err)
+	}
+}
+
+func TestRemediate_ResendTakesStockAndCountsItemValue(t *testing.T) {
+	f := newFixture()
+	itemID := f.order.Products[0].ID
+	f.spent(support, 0).Once()
+	f.spent(support, 40)
+
+	result, err := f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action:      entity.RemediationResend,
+		Reason:      entity.ReasonDamaged,
+		OrderItemID: &itemID,
+		Quantity:    2,
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if result.Amount != 40 {
+		t.Errorf("expected resend value 40, got %v", result.Amount)
+	}
+	if f.product.Quantity != 3 {
+		t.Errorf("expected stock 3 after resend, got %d", f.product.Quantity)
+	}
+	if len(f.stock.Movements) != 1 || f.stock.Movements[0].Reason != entity.StockResend {
+		t.Error("expected a resend stock movement")
+	}
+
+	// The resend used 40 of the support budget of 50
+	_, err = f.uc.Remediate(context.Background(), f.order.ID, support, Request{
+		Action:      entity.RemediationResend,
+		Reason:      entity.ReasonDamaged,
+		OrderItemID: &itemID,
+		Quantity:    1,
+	})
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Errorf("expected ErrBudgetExceeded, got %v", err)
+	}
+}
+
+func TestRemediate_ResendMoreThanOrdered(t *testing.T) {
+	f := newFixture()
+	itemID := f.order.Products[0].ID
+	admin := Actor{ID: uuid.New(), Role: entity.RoleAdmin}
+
+	_, err := f.uc.Remediate(context.Background(), f.order.ID, admin, Request{
+		Action:      entity.RemediationResend,
+		Reason:      entity.ReasonWrongItem,
+		OrderItemID: &itemID,
+		Quantity:    4,
+	})
+	if !errors.Is(err, ErrQuantityExceeded) {
+		t.Errorf("expected ErrQuantityExceeded, got %v", err)
+	}
+	if f.product.Quantity != 5 {
+		t.Error("expected stock to be untouched")
+	}
+}
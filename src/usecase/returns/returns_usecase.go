@@ -0,0 +1,380 @@
+package returns
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+)
+
+var (
+	ErrOrderNotFound      = entity.NotFoundError("Order not found")
+	ErrOrderNotReturnable = entity.ConflictError("Only delivered, paid orders can be returned")
+	ErrItemNotFound       = entity.NotFoundError("Order item not found")
+	ErrReturnNotFound     = entity.NotFoundError("Return not found")
+	ErrQuantityExceeded   = entity.ValidationError("Cannot return more items than were ordered")
+)
+
+// ItemRequest is a quantity of an order line the customer wants to send back
+type ItemRequest struct {
+	OrderItemID uuid.UUID
+	Quantity    int
+}
+
+type Request struct {
+	Reason entity.ReturnReason
+	Note   string
+	Items  []ItemRequest
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface ReturnService -out ../../internal/testing/servicemocks
+
+type ReturnService interface {
+	// Customers
+	RequestReturn(ctx context.Context, userID, orderID uuid.UUID, req Request) (*entity.Return, error)
+	GetCustomerReturn(ctx context.Context, userID, returnID uuid.UUID) (*entity.Return, error)
+
+	// Admins
+	GetReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error)
+	ListReturns(ctx context.Context, filters repository.ReturnFilters, page, pageSize int) ([]*entity.Return, int, error)
+	ApproveReturn(ctx context.Context, returnID, reviewerID uuid.UUID, note string) (*entity.Return, error)
+	RejectReturn(ctx context.Context, returnID, reviewerID uuid.UUID, note string) (*entity.Return, error)
+	ReceiveReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error)
+	RefundReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+	GetStockRecorder() stock.Recorder
+}
+
+type UseCase struct {
+	returnRepo  repository.ReturnRepository
+	orderRepo   repository.OrderRepository
+	productRepo repository.ProductRepository
+	variantRepo repository.ProductVariantRepository
+	webhookRepo repository.WebhookRepository
+	gateway     refund.Gateway
+	services    Services
+	now         func() time.Time
+}
+
+func NewUseCase(
+	returnRepo repository.ReturnRepository,
+	orderRepo repository.OrderRepository,
+	productRepo repository.ProductRepository,
+	variantRepo repository.ProductVariantRepository,
+	webhookRepo repository.WebhookRepository,
+	gateway refund.Gateway,
+	services Services,
+) *UseCase {
+	return &UseCase{
+		returnRepo:  returnRepo,
+		orderRepo:   orderRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		webhookRepo: webhookRepo,
+		gateway:     gateway,
+		services:    services,
+		now:         time.Now,
+	}
+}
+
+// RequestReturn opens a return for items of an order the customer placed. The
+// order must be completed and paid, and items can't be returned more times
+// than they were ordered across the returns of the order that weren't
+// rejected. Each item is refunded what the customer paid for it.
+func (uc *UseCase) RequestReturn(ctx context.Context, userID, orderID uuid.UUID, req Request) (*entity.Return, error) {
+	order, err := uc.orderRepo.GetByID(ctx, orderID)
+	if err != nil || !order.IsOwnedBy(userID) {
+		return nil, ErrOrderNotFound
+	}
+	if !order.IsDelivered() || !order.IsPaid() {
+		return nil, ErrOrderNotReturnable
+	}
+
+	previous, err := uc.returnRepo.ListByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	returned := make(map[uuid.UUID]int)
+	for _, ret := range previous {
+		if !ret.Counts() {
+			continue
+		}
+		for _, item := range ret.Items {
+			returned[item.OrderItemID] += item.Quantity
+		}
+	}
+
+	now := uc.now()
+	ret := &entity.Return{
+		ID:        entity.NewID(),
+		OrderID:   order.ID,
+		Status:    entity.ReturnRequested,
+		Reason:    req.Reason,
+		Note:      req.Note,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	for _, itemReq := range req.Items {
+		line := findItem(order, itemReq.OrderItemID)
+		if line == nil {
+			return nil, ErrItemNotFound
+		}
+		if itemReq.Quantity > line.Quantity-returned[line.ID] {
+			return nil, fmt.Errorf("%w: %d of the line left to return", ErrQuantityExceeded, max(line.Quantity-returned[line.ID], 0))
+		}
+		amount := line.AmountFor(itemReq.Quantity)
+		ret.Items = append(ret.Items, entity.ReturnItem{
+			ID:           entity.NewID(),
+			ReturnID:     ret.ID,
+			OrderItemID:  line.ID,
+			ProductID:    line.ProductID,
+			VariantID:    line.VariantID,
+			Quantity:     itemReq.Quantity,
+			RefundAmount: amount,
+		})
+		ret.RefundAmount = round(ret.RefundAmount + amount)
+	}
+
+	if err := ret.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.returnRepo.Create(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "REQUEST_RETURN", "Order", order.ID, nil, ret)
+
+	return ret, nil
+}
+
+// GetCustomerReturn returns a return of an order the customer placed
+func (uc *UseCase) GetCustomerReturn(ctx context.Context, userID, returnID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	order, err := uc.orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil || !order.IsOwnedBy(userID) {
+		return nil, ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+func (uc *UseCase) GetReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.returnRepo.GetByID(ctx, returnID)
+	if err != nil {
+		return nil, ErrReturnNotFound
+	}
+	return ret, nil
+}
+
+func (uc *UseCase) ListReturns(ctx context.Context, filters repository.ReturnFilters, page, pageSize int) ([]*entity.Return, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return uc.returnRepo.List(ctx, filters, page, pageSize)
+}
+
+func (uc *UseCase) ApproveReturn(ctx context.Context, returnID, reviewerID uuid.UUID, note string) (*entity.Return, error) {
+	return uc.review(ctx, returnID, entity.ReturnApproved, reviewerID, note)
+}
+
+func (uc *UseCase) RejectReturn(ctx context.Context, returnID, reviewerID uuid.UUID, note string) (*entity.Return, error) {
+	return uc.review(ctx, returnID, entity.ReturnRejected, reviewerID, note)
+}
+
+func (uc *UseCase) review(ctx context.Context, returnID uuid.UUID, status entity.ReturnStatus, reviewerID uuid.UUID, note string) (*entity.Return, error) {
+	ret, err := uc.GetReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	before := ret.Status
+
+	if err := ret.Review(status, reviewerID, note, uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &reviewerID, "REVIEW_RETURN", "Return", ret.ID,
+		map[string]interface{}{"status": before}, map[string]interface{}{"status": ret.Status, "note": note})
+
+	return ret, nil
+}
+
+// ReceiveReturn records that the items of an approved return arrived: they go
+// back in stock and the refund is issued. A refund the payment provider
+// refuses leaves the return received with the reason in RefundError, to be
+// retried with RefundReturn.
+func (uc *UseCase) ReceiveReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.GetReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The status is saved before restocking, so receiving twice can't
+	// restock twice
+	if err := ret.Receive(uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	uc.restock(ctx, ret)
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "RECEIVE_RETURN", "Return", ret.ID, nil, ret)
+
+	return uc.refund(ctx, ret)
+}
+
+// RefundReturn retries the refund of a received return
+func (uc *UseCase) RefundReturn(ctx context.Context, returnID uuid.UUID) (*entity.Return, error) {
+	ret, err := uc.GetReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ret.CanTransitionTo(entity.ReturnRefunded); err != nil {
+		return nil, err
+	}
+	return uc.refund(ctx, ret)
+}
+
+// refund pays the return back through the payment provider and records it
+// against the payment of the order. It never refunds more than is left to
+// give back of the order, once its other refunds and credits are taken off,
+// so an item refunded without return isn't refunded again.
+func (uc *UseCase) refund(ctx context.Context, ret *entity.Return) (*entity.Return, error) {
+	order, err := uc.orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil {
+		return nil, ErrOrderNotFound
+	}
+
+	ret.RefundAmount = round(math.Max(math.Min(ret.RefundAmount, order.Refundable()), 0))
+
+	var reference string
+	if ret.RefundAmount > 0 {
+		transactionID, err := uc.transactionID(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		reference, err = uc.gateway.Refund(ctx, refund.Request{
+			OrderID:        order.ID,
+			TransactionID:  transactionID,
+			Amount:         ret.RefundAmount,
+			IdempotencyKey: "return-" + ret.ID.String(),
+			Reason:         string(ret.Reason),
+		})
+		if err != nil {
+			ret.RefundError = err.Error()
+			ret.UpdatedAt = uc.now()
+			if err := uc.returnRepo.Update(ctx, ret); err != nil {
+				return nil, err
+			}
+			uc.services.GetAuditService().LogChange(ctx, nil, "REFUND_RETURN_FAILED", "Return", ret.ID, nil,
+				map[string]interface{}{"amount": ret.RefundAmount, "error": ret.RefundError})
+			return ret, nil
+		}
+	}
+
+	if err := ret.MarkRefunded(reference, uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.returnRepo.Update(ctx, ret); err != nil {
+		return nil, err
+	}
+	if ret.RefundAmount > 0 {
+		if err := order.RefundPayment(ret.RefundAmount); err != nil {
+			return nil, err
+		}
+		if err := uc.orderRepo.Update(ctx, order); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "REFUND_RETURN", "Return", ret.ID, nil,
+		map[string]interface{}{"amount": ret.RefundAmount, "reference": reference})
+
+	return ret, nil
+}
+
+// transactionID returns the payment transaction of the order, empty when no
+// processed payment webhook recorded one
+func (uc *UseCase) transactionID(ctx context.Context, orderID uuid.UUID) (string, error) {
+	paid, completed := entity.Paid, entity.WebhookStatusCompleted
+	logs, _, err := uc.webhookRepo.GetByOrderID(ctx, orderID.String(), repository.WebhookLogFilters{
+		PaymentStatus: &paid,
+		Status:        &completed,
+	}, 1, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(logs) == 0 {
+		return "", nil
+	}
+	return logs[0].TransactionID, nil
+}
+
+// restock puts the returned items back in stock. Products or variants deleted
+// since the order are skipped.
+func (uc *UseCase) restock(ctx context.Context, ret *entity.Return) {
+	reference := ret.ID.String()
+	for _, item := range ret.Items {
+		if item.VariantID != nil {
+			variant, err := uc.variantRepo.GetByID(ctx, *item.VariantID)
+			if err != nil {
+				continue
+			}
+			before := variant.Quantity
+			variant.Quantity += item.Quantity
+			variant.UpdatedAt = time.Now()
+			if err := uc.variantRepo.Update(ctx, variant); err != nil {
+				fmt.Printf("Failed to restock variant %s: %v\n", variant.ID, err)
+				continue
+			}
+			uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(item.ProductID, item.VariantID, entity.StockReturn, before, variant.Quantity, reference))
+			continue
+		}
+
+		product, err := uc.productRepo.GetByID(ctx, item.ProductID)
+		if err != nil {
+			continue
+		}
+		before := product.Quantity
+		if err := product.IncreaseStock(item.Quantity); err != nil {
+			continue
+		}
+		if err := uc.productRepo.Update(ctx, product); err != nil {
+			fmt.Printf("Failed to restock product %s: %v\n", product.ID, err)
+			continue
+		}
+		uc.services.GetStockRecorder().Record(ctx, entity.NewStockMovement(product.ID, nil, entity.StockReturn, before, product.Quantity, reference))
+	}
+}
+
+func findItem(order *entity.Order, itemID uuid.UUID) *entity.OrderItem {
+	for i := range order.Products {
+		if order.Products[i].ID == itemID {
+			return &order.Products[i]
+		}
+	}
+	return nil
+}
+
+func round(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,251 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/refund"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	customer = uuid.New()
+	admin    = uuid.New()
+)
+
+// stubGateway records refunds and fails while err is set
+type stubGateway struct {
+	requests []refund.Request
+	err      error
+}
+
+func (g *stubGateway) Refund(ctx context.Context, req refund.Request) (string, error) {
+	if g.err != nil {
+		return "", g.err
+	}
+	g.requests = append(g.requests, req)
+	return "re_" + req.IdempotencyKey, nil
+}
+
+type fixture struct {
+	uc       *UseCase
+	gateway  *stubGateway
+	recorder *mockServices.MockStockRecorder
+	products repository.ProductRepository
+	orders   repository.OrderRepository
+	order    *entity.Order
+	product  *entity.Product
+}
+
+// newFixture sets up a completed, paid order of 2 mugs at 10 with a 2 tax
+// each, and 1 plate at 5
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	orders := memory.NewOrderRepository(store)
+	webhooks := memory.NewWebhookRepository(store)
+
+	mug := &entity.Product{Name: "Mug", Price: 10, Quantity: 3}
+	require.NoError(t, products.Create(ctx, mug))
+	plate := &entity.Product{Name: "Plate", Price: 5, Quantity: 0}
+	require.NoError(t, products.Create(ctx, plate))
+
+	mugLine := entity.OrderItem{ID: uuid.New(), ProductID: mug.ID, Quantity: 2, Price: 10}
+	mugLine.AddComponent(entity.ComponentBase, "", 20)
+	mugLine.AddComponent(entity.ComponentTax, "Tax", 4)
+	mugLine.CalculateTotal()
+	plateLine := entity.OrderItem{ID: uuid.New(), ProductID: plate.ID, Quantity: 1, Price: 5}
+	plateLine.CalculateTotal()
+
+	order := &entity.Order{
+		CustomerID:    1,
+		UserID:        &customer,
+		Products:      []entity.OrderItem{mugLine, plateLine},
+		Status:        entity.Completed,
+		PaymentStatus: entity.Paid,
+	}
+	order.CalculateTotal()
+	order.AmountPaid = order.TotalPrice
+	require.NoError(t, orders.Create(ctx, order))
+	require.NoError(t, webhooks.Create(ctx, &entity.WebhookLog{
+		OrderID: order.ID, TransactionID: "txn_1", PaymentStatus: entity.Paid, Status: entity.WebhookStatusCompleted,
+	}))
+
+	gateway := &stubGateway{}
+	recorder := &mockServices.MockStockRecorder{}
+	uc := NewUseCase(memory.NewReturnRepository(store), orders, products, memory.NewProductVariantRepository(store),
+		webhooks, gateway, &mockServices.MockServices{StockRecorder: recorder})
+
+	return &fixture{uc: uc, gateway: gateway, recorder: recorder, products: products,
+		orders: orders, order: order, product: mug}
+}
+
+func (f *fixture) request(t *testing.T, quantity int) *entity.Return {
+	t.Helper()
+	ret, err := f.uc.RequestReturn(context.Background(), customer, f.order.ID, Request{
+		Reason: entity.ReturnDamaged,
+		Items:  []ItemRequest{{OrderItemID: f.order.Products[0].ID, Quantity: quantity}},
+	})
+	require.NoError(t, err)
+	return ret
+}
+
+func TestRequestReturn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Refunds what was paid for the items", func(t *testing.T) {
+		f := newFixture(t)
+		ret := f.request(t, 1)
+
+		assert.Equal(t, entity.ReturnRequested, ret.Status)
+		require.Len(t, ret.Items, 1)
+		assert.Equal(t, f.product.ID, ret.Items[0].ProductID)
+		assert.Equal(t, 12.0, ret.RefundAmount)
+	})
+
+	t.Run("Only the customer's orders", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.uc.RequestReturn(ctx, uuid.New(), f.order.ID, Request{
+			Reason: entity.ReturnDamaged,
+			Items:  []ItemRequest{{OrderItemID: f.order.Products[0].ID, Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, ErrOrderNotFound)
+	})
+
+	t.Run("Only delivered orders", func(t *testing.T) {
+		f := newFixture(t)
+		f.order.Status = entity.Pending
+		require.NoError(t, f.uc.orderRepo.Update(ctx, f.order))
+
+		_, err := f.uc.RequestReturn(ctx, customer, f.order.ID, Request{
+			Reason: entity.ReturnDamaged,
+			Items:  []ItemRequest{{OrderItemID: f.order.Products[0].ID, Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, ErrOrderNotReturnable)
+	})
+
+	t.Run("Not more than was ordered", func(t *testing.T) {
+		f := newFixture(t)
+		f.request(t, 1)
+
+		_, err := f.uc.RequestReturn(ctx, customer, f.order.ID, Request{
+			Reason: entity.ReturnDamaged,
+			Items:  []ItemRequest{{OrderItemID: f.order.Products[0].ID, Quantity: 2}},
+		})
+		assert.ErrorIs(t, err, ErrQuantityExceeded)
+	})
+
+	t.Run("Rejected returns give the items back", func(t *testing.T) {
+		f := newFixture(t)
+		ret := f.request(t, 2)
+		_, err := f.uc.RejectReturn(ctx, ret.ID, admin, "Past the return window")
+		require.NoError(t, err)
+
+		f.request(t, 2)
+	})
+
+	t.Run("Unknown order item", func(t *testing.T) {
+		f := newFixture(t)
+		_, err := f.uc.RequestReturn(ctx, customer, f.order.ID, Request{
+			Reason: entity.ReturnDamaged,
+			Items:  []ItemRequest{{OrderItemID: uuid.New(), Quantity: 1}},
+		})
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+}
+
+func TestGetCustomerReturn(t *testing.T) {
+	f := newFixture(t)
+	ret := f.request(t, 1)
+
+	got, err := f.uc.GetCustomerReturn(context.Background(), customer, ret.ID)
+	require.NoError(t, err)
+	assert.Equal(t, ret.ID, got.ID)
+
+	_, err = f.uc.GetCustomerReturn(context.Background(), uuid.New(), ret.ID)
+	assert.ErrorIs(t, err, ErrReturnNotFound)
+}
+
+func TestReceiveReturn(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Restocks and refunds", func(t *testing.T) {
+		f := newFixture(t)
+		ret := f.request(t, 2)
+		_, err := f.uc.ApproveReturn(ctx, ret.ID, admin, "")
+		require.NoError(t, err)
+
+		ret, err = f.uc.ReceiveReturn(ctx, ret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnRefunded, ret.Status)
+		assert.Equal(t, "re_return-"+ret.ID.String(), ret.RefundReference)
+
+		product, err := f.products.GetByID(ctx, f.product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, product.Quantity)
+		require.Len(t, f.recorder.Movements, 1)
+		assert.Equal(t, entity.StockReturn, f.recorder.Movements[0].Reason)
+
+		require.Len(t, f.gateway.requests, 1)
+		assert.Equal(t, "txn_1", f.gateway.requests[0].TransactionID)
+		assert.Equal(t, 24.0, f.gateway.requests[0].Amount)
+
+		order, err := f.uc.orderRepo.GetByID(ctx, f.order.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.PartiallyRefunded, order.PaymentStatus)
+		assert.Equal(t, 24.0, order.AmountRefunded)
+	})
+
+	t.Run("Requires approval", func(t *testing.T) {
+		f := newFixture(t)
+		ret := f.request(t, 1)
+
+		_, err := f.uc.ReceiveReturn(ctx, ret.ID)
+		assert.ErrorIs(t, err, entity.ErrConflict)
+		assert.Empty(t, f.recorder.Movements)
+	})
+
+	t.Run("Failed refunds can be retried", func(t *testing.T) {
+		f := newFixture(t)
+		ret := f.request(t, 1)
+		_, err := f.uc.ApproveReturn(ctx, ret.ID, admin, "")
+		require.NoError(t, err)
+
+		f.gateway.err = errors.New("provider unavailable")
+		ret, err = f.uc.ReceiveReturn(ctx, ret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnReceived, ret.Status)
+		assert.Equal(t, "provider unavailable", ret.RefundError)
+
+		f.gateway.err = nil
+		ret, err = f.uc.RefundReturn(ctx, ret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, entity.ReturnRefunded, ret.Status)
+		assert.Empty(t, ret.RefundError)
+		assert.Len(t, f.recorder.Movements, 1, "retrying doesn't restock again")
+	})
+
+	t.Run("Not more than is left of the order", func(t *testing.T) {
+		f := newFixture(t)
+		require.NoError(t, f.order.CreditPayment(20))
+		require.NoError(t, f.orders.Update(ctx, f.order))
+		ret := f.request(t, 2)
+		_, err := f.uc.ApproveReturn(ctx, ret.ID, admin, "")
+		require.NoError(t, err)
+
+		ret, err = f.uc.ReceiveReturn(ctx, ret.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 9.0, ret.RefundAmount)
+		require.Len(t, f.gateway.requests, 1)
+		assert.Equal(t, 9.0, f.gateway.requests[0].Amount)
+	})
+}
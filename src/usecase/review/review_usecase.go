@@ -0,0 +1,150 @@
+package review
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrProductNotFound = entity.NotFoundError("Product not found")
+	ErrReviewNotFound  = entity.NotFoundError("Review not found")
+	ErrNotPurchased    = entity.ForbiddenError("Only customers who received the product can review it")
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface ReviewService -out ../../internal/testing/servicemocks
+
+type ReviewService interface {
+	ReviewProduct(ctx context.Context, productID, userID uuid.UUID, rating int, body string) (*entity.ProductReview, error)
+	ListReviews(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductReview, int, error)
+	DeleteReview(ctx context.Context, productID, userID uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	reviewRepo  repository.ProductReviewRepository
+	productRepo repository.ProductRepository
+	orderRepo   repository.OrderRepository
+	services    Services
+}
+
+func NewUseCase(
+	reviewRepo repository.ProductReviewRepository,
+	productRepo repository.ProductRepository,
+	orderRepo repository.OrderRepository,
+	services Services,
+) *UseCase {
+	return &UseCase{
+		reviewRepo:  reviewRepo,
+		productRepo: productRepo,
+		orderRepo:   orderRepo,
+		services:    services,
+	}
+}
+
+// ReviewProduct leaves the review of the customer on a published product, or
+// replaces the one they left before. Only customers with a delivered order of
+// the product can review it. The rating of the product is updated with the
+// review.
+func (uc *UseCase) ReviewProduct(ctx context.Context, productID, userID uuid.UUID, rating int, body string) (*entity.ProductReview, error) {
+	if _, err := uc.publishedProduct(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	received, err := uc.hasReceived(ctx, userID, productID)
+	if err != nil {
+		return nil, err
+	}
+	if !received {
+		return nil, ErrNotPurchased
+	}
+
+	review := &entity.ProductReview{
+		ProductID: productID,
+		UserID:    userID,
+		Rating:    rating,
+		Body:      strings.TrimSpace(body),
+	}
+	if err := review.Validate(); err != nil {
+		return nil, err
+	}
+
+	before, err := uc.reviewRepo.Get(ctx, productID, userID)
+	if err != nil {
+		before = nil
+	}
+	if err := uc.reviewRepo.Save(ctx, review); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "REVIEW_PRODUCT", "Product", productID, before, review)
+
+	return review, nil
+}
+
+// ListReviews returns a page of the reviews of a published product, newest
+// first
+func (uc *UseCase) ListReviews(ctx context.Context, productID uuid.UUID, page, pageSize int) ([]*entity.ProductReview, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	if _, err := uc.publishedProduct(ctx, productID); err != nil {
+		return nil, 0, err
+	}
+	return uc.reviewRepo.ListByProduct(ctx, productID, page, pageSize)
+}
+
+// DeleteReview removes the review the customer left on the product, taking it
+// out of the rating of the product
+func (uc *UseCase) DeleteReview(ctx context.Context, productID, userID uuid.UUID) error {
+	review, err := uc.reviewRepo.Get(ctx, productID, userID)
+	if err != nil {
+		return ErrReviewNotFound
+	}
+	if err := uc.reviewRepo.Delete(ctx, productID, userID); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &userID, "DELETE_REVIEW", "Product", productID, review, nil)
+
+	return nil
+}
+
+// publishedProduct returns the product, hiding the ones the public can't see
+func (uc *UseCase) publishedProduct(ctx context.Context, productID uuid.UUID) (*entity.Product, error) {
+	product, err := uc.productRepo.GetByID(ctx, productID)
+	if err != nil || !product.IsPublished() {
+		return nil, ErrProductNotFound
+	}
+	return product, nil
+}
+
+// hasReceived reports whether the customer has a delivered order with the
+// product
+func (uc *UseCase) hasReceived(ctx context.Context, userID, productID uuid.UUID) (bool, error) {
+	orders, err := uc.orderRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	for _, order := range orders {
+		if !order.IsDelivered() {
+			continue
+		}
+		for _, item := range order.Products {
+			if item.ProductID == productID {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,140 @@
+package review
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixture struct {
+	uc       *UseCase
+	products repository.ProductRepository
+	orders   repository.OrderRepository
+	product  *entity.Product
+}
+
+// newFixture sets up a published mug with a delivered order of it for each
+// of the customers
+func newFixture(t *testing.T, customers ...uuid.UUID) *fixture {
+	t.Helper()
+	ctx := context.Background()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	orders := memory.NewOrderRepository(store)
+
+	mug := &entity.Product{Name: "Mug", Price: 10, Quantity: 3, Status: entity.ProductActive}
+	require.NoError(t, products.Create(ctx, mug))
+
+	for i := range customers {
+		require.NoError(t, orders.Create(ctx, &entity.Order{
+			CustomerID: 1,
+			UserID:     &customers[i],
+			Products:   []entity.OrderItem{{ID: uuid.New(), ProductID: mug.ID, Quantity: 1, Price: 10}},
+			Status:     entity.Delivered,
+		}))
+	}
+
+	uc := NewUseCase(memory.NewProductReviewRepository(store), products, orders, &mockServices.MockServices{})
+	return &fixture{uc: uc, products: products, orders: orders, product: mug}
+}
+
+func TestReviewProduct(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Updates the rating of the product", func(t *testing.T) {
+		alice, bob := uuid.New(), uuid.New()
+		f := newFixture(t, alice, bob)
+
+		_, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 5, "  Great mug ")
+		require.NoError(t, err)
+		review, err := f.uc.ReviewProduct(ctx, f.product.ID, bob, 2, "")
+		require.NoError(t, err)
+		assert.Equal(t, 2, review.Rating)
+
+		product, err := f.products.GetByID(ctx, f.product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 3.5, product.RatingAverage)
+		assert.Equal(t, 2, product.RatingCount)
+
+		reviews, total, err := f.uc.ListReviews(ctx, f.product.ID, 1, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, "Great mug", reviews[1].Body)
+	})
+
+	t.Run("Replaces the review the customer left before", func(t *testing.T) {
+		alice := uuid.New()
+		f := newFixture(t, alice)
+
+		first, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 1, "Broke")
+		require.NoError(t, err)
+		second, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 4, "Replaced for free")
+		require.NoError(t, err)
+		assert.Equal(t, first.ID, second.ID)
+
+		product, err := f.products.GetByID(ctx, f.product.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 4.0, product.RatingAverage)
+		assert.Equal(t, 1, product.RatingCount)
+	})
+
+	t.Run("Only customers who received the product", func(t *testing.T) {
+		alice := uuid.New()
+		f := newFixture(t)
+		require.NoError(t, f.orders.Create(ctx, &entity.Order{
+			CustomerID: 1,
+			UserID:     &alice,
+			Products:   []entity.OrderItem{{ID: uuid.New(), ProductID: f.product.ID, Quantity: 1, Price: 10}},
+			Status:     entity.Shipped,
+		}))
+
+		_, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 5, "")
+		assert.ErrorIs(t, err, ErrNotPurchased)
+		_, err = f.uc.ReviewProduct(ctx, f.product.ID, uuid.New(), 5, "")
+		assert.ErrorIs(t, err, ErrNotPurchased)
+	})
+
+	t.Run("Rejects a rating out of range", func(t *testing.T) {
+		alice := uuid.New()
+		f := newFixture(t, alice)
+
+		_, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 6, "")
+		assert.ErrorIs(t, err, entity.ErrValidation)
+	})
+
+	t.Run("Hides unpublished products", func(t *testing.T) {
+		alice := uuid.New()
+		f := newFixture(t, alice)
+		f.product.Status = entity.ProductDraft
+		require.NoError(t, f.products.Update(ctx, f.product))
+
+		_, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 5, "")
+		assert.ErrorIs(t, err, ErrProductNotFound)
+		_, _, err = f.uc.ListReviews(ctx, f.product.ID, 1, 10)
+		assert.ErrorIs(t, err, ErrProductNotFound)
+	})
+}
+
+func TestDeleteReview(t *testing.T) {
+	ctx := context.Background()
+	alice := uuid.New()
+	f := newFixture(t, alice)
+
+	_, err := f.uc.ReviewProduct(ctx, f.product.ID, alice, 5, "")
+	require.NoError(t, err)
+	require.NoError(t, f.uc.DeleteReview(ctx, f.product.ID, alice))
+
+	product, err := f.products.GetByID(ctx, f.product.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, product.RatingAverage)
+	assert.Equal(t, 0, product.RatingCount)
+
+	assert.ErrorIs(t, f.uc.DeleteReview(ctx, f.product.ID, alice), ErrReviewNotFound)
+}
@@ -0,0 +1,64 @@
+package salesreport
+
+import (
+	"context"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+var (
+	ErrDateRangeRequired = entity.ValidationError("Sales report date range is required")
+	ErrInvalidDateRange  = entity.ValidationError("Sales report date range ends before it starts")
+)
+
+// scanBatchSize is how many orders are loaded at a time while exporting
+const scanBatchSize = 500
+
+//go:generate go run ../../internal/tools/mockgen -interface SalesReportService -out ../../internal/testing/servicemocks
+
+type SalesReportService interface {
+	// ExportSales calls fn with the report lines of the orders placed from the
+	// start of from to the end of to, oldest order first, a batch of orders at
+	// a time. Days start at midnight in the location of from, and the lines
+	// carry their order times in it. An error returned by fn stops the export.
+	ExportSales(ctx context.Context, from, to time.Time, fn func(lines []entity.SalesLine) error) error
+}
+
+type UseCase struct {
+	orderRepo repository.OrderRepository
+}
+
+func NewUseCase(orderRepo repository.OrderRepository) *UseCase {
+	return &UseCase{
+		orderRepo: orderRepo,
+	}
+}
+
+func (uc *UseCase) ExportSales(ctx context.Context, from, to time.Time, fn func(lines []entity.SalesLine) error) error {
+	if from.IsZero() || to.IsZero() {
+		return ErrDateRangeRequired
+	}
+	from, to = startOfDay(from), startOfDay(to)
+	if to.Before(from) {
+		return ErrInvalidDateRange
+	}
+
+	loc := from.Location()
+	return uc.orderRepo.ScanByCreatedAt(ctx, from.UTC(), to.AddDate(0, 0, 1).UTC(), scanBatchSize, func(orders []*entity.Order) error {
+		var lines []entity.SalesLine
+		for _, order := range orders {
+			for _, line := range entity.SalesLines(order) {
+				line.OrderedAt = line.OrderedAt.In(loc)
+				lines = append(lines, line)
+			}
+		}
+		return fn(lines)
+	})
+}
+
+// startOfDay returns the midnight that starts the day of t in its location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
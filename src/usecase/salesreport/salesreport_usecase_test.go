@@ -0,0 +1,129 @@
+package salesreport
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockOrderRepo struct {
+	orders      []*entity.Order
+	from, until time.Time
+}
+
+func (m *mockOrderRepo) Create(ctx context.Context, order *entity.Order) error { return nil }
+
+func (m *mockOrderRepo) GetByID(ctx context.Context, id uuid.UUID) (*entity.Order, error) {
+	return nil, entity.NotFoundError("Order not found")
+}
+
+func (m *mockOrderRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*entity.Order, error) {
+	return nil, nil
+}
+
+func (m *mockOrderRepo) GetAll(ctx context.Context, page, pageSize int, status *entity.OrderStatus, paymentStatus *entity.PaymentStatus) ([]*entity.Order, int, error) {
+	return m.orders, len(m.orders), nil
+}
+
+func (m *mockOrderRepo) Search(ctx context.Context, search repository.OrderSearch, page, pageSize int) ([]*entity.Order, int, error) {
+	return nil, 0, nil
+}
+
+func (m *mockOrderRepo) Update(ctx context.Context, order *entity.Order) error { return nil }
+
+func (m *mockOrderRepo) UpdateStatuses(ctx context.Context, update *entity.BulkOrderStatusUpdate, workflow *entity.OrderWorkflow) ([]entity.OrderStatusResult, []*entity.Order, error) {
+	return nil, nil, nil
+}
+
+func (m *mockOrderRepo) ScanByCreatedAt(ctx context.Context, from, until time.Time, batchSize int, fn func(orders []*entity.Order) error) error {
+	m.from, m.until = from, until
+	for start := 0; start < len(m.orders); start += batchSize {
+		end := min(start+batchSize, len(m.orders))
+		if err := fn(m.orders[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockOrderRepo) ListByUser(ctx context.Context, userID uuid.UUID) ([]*entity.Order, error) {
+	return nil, nil
+}
+
+func (m *mockOrderRepo) CountByUserSince(ctx context.Context, userID uuid.UUID, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (m *mockOrderRepo) CountPurchased(ctx context.Context, userID uuid.UUID, productID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func (m *mockOrderRepo) AnonymizeUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+func day(value string) time.Time {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func newOrder(items int) *entity.Order {
+	order := &entity.Order{ID: uuid.New(), CustomerID: 1, Status: entity.Completed, PaymentStatus: entity.Paid}
+	for i := 0; i < items; i++ {
+		order.Products = append(order.Products, entity.OrderItem{ID: uuid.New(), ProductID: uuid.New(), Quantity: 1, Price: 10, TotalPrice: 10})
+	}
+	return order
+}
+
+func TestExportSales(t *testing.T) {
+	t.Run("Streams every line of every order in batches", func(t *testing.T) {
+		repo := &mockOrderRepo{}
+		for i := 0; i < scanBatchSize+1; i++ {
+			repo.orders = append(repo.orders, newOrder(2))
+		}
+		uc := NewUseCase(repo)
+
+		batches, lines := 0, 0
+		err := uc.ExportSales(context.Background(), day("2024-05-01"), day("2024-05-31"), func(batch []entity.SalesLine) error {
+			batches++
+			lines += len(batch)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, batches)
+		assert.Equal(t, 2*(scanBatchSize+1), lines)
+		assert.Equal(t, day("2024-05-01"), repo.from)
+		assert.Equal(t, day("2024-06-01"), repo.until, "The last day is included")
+	})
+
+	t.Run("Stops when the writer fails", func(t *testing.T) {
+		repo := &mockOrderRepo{orders: []*entity.Order{newOrder(1)}}
+		uc := NewUseCase(repo)
+		failure := errors.New("client went away")
+
+		err := uc.ExportSales(context.Background(), day("2024-05-01"), day("2024-05-01"), func(batch []entity.SalesLine) error {
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+	})
+
+	t.Run("Requires a valid range", func(t *testing.T) {
+		uc := NewUseCase(&mockOrderRepo{})
+		noop := func(batch []entity.SalesLine) error { return nil }
+
+		assert.ErrorIs(t, uc.ExportSales(context.Background(), time.Time{}, day("2024-05-01"), noop), ErrDateRangeRequired)
+		assert.ErrorIs(t, uc.ExportSales(context.Background(), day("2024-05-02"), day("2024-05-01"), noop), ErrInvalidDateRange)
+	})
+}
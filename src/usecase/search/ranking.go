@@ -0,0 +1,131 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+)
+
+// Text relevance points per query term, plus a bonus when the whole name is the query
+const (
+	nameMatchScore        = 2.0
+	descriptionMatchScore = 1.0
+	exactNameScore        = 3.0
+)
+
+// SourceText marks the contribution of the text match
+const SourceText = "text"
+
+// rank scores the candidates against the query and boost rules, sorts them
+// by score and places the pinned products at their positions
+func rank(query string, candidates []*entity.Product, rules []*entity.RankingRule, pins []pin) []entity.RankedProduct {
+	terms := splitTerms(query)
+	pinned := make(map[uuid.UUID]bool, len(pins))
+	for _, p := range pins {
+		pinned[p.product.ID] = true
+	}
+
+	organic := make([]entity.RankedProduct, 0, len(candidates))
+	for _, product := range candidates {
+		if pinned[product.ID] {
+			continue
+		}
+		organic = append(organic, score(query, terms, product, rules))
+	}
+
+	sort.SliceStable(organic, func(i, j int) bool {
+		if organic[i].Score != organic[j].Score {
+			return organic[i].Score > organic[j].Score
+		}
+		return organic[i].Product.Name < organic[j].Product.Name
+	})
+
+	results := organic
+	for _, p := range pins {
+		result := score(query, terms, p.product, rules)
+		result.Pinned = true
+		result.PinRuleID = &p.rule.ID
+		result.Contributions = append(result.Contributions, entity.RankingContribution{
+			Source: string(entity.RulePin),
+			RuleID: &p.rule.ID,
+			Detail: fmt.Sprintf("Pinned at position %d for %q", p.rule.Position, p.rule.Query),
+		})
+
+		at := min(p.rule.Position-1, len(results))
+		results = append(results[:at], append([]entity.RankedProduct{result}, results[at:]...)...)
+	}
+	return results
+}
+
+type pin struct {
+	rule    *entity.RankingRule
+	product *entity.Product
+}
+
+func score(query string, terms []string, product *entity.Product, rules []*entity.RankingRule) entity.RankedProduct {
+	result := entity.RankedProduct{Product: product}
+	add := func(c entity.RankingContribution) {
+		c.Score = math.Round(c.Score*100) / 100
+		result.Score += c.Score
+		result.Contributions = append(result.Contributions, c)
+	}
+
+	add(textContribution(query, terms, product))
+
+	for _, rule := range rules {
+		if !rule.Active {
+			continue
+		}
+		ruleID := rule.ID
+		switch rule.Type {
+		case entity.RuleBoostInStock:
+			if product.InStock() {
+				add(entity.RankingContribution{Source: string(rule.Type), RuleID: &ruleID, Score: rule.Weight, Detail: "In stock"})
+			} else {
+				add(entity.RankingContribution{Source: string(rule.Type), RuleID: &ruleID, Detail: "Out of stock"})
+			}
+		case entity.RuleBoostMargin:
+			if margin, ok := product.Margin(); ok {
+				add(entity.RankingContribution{Source: string(rule.Type), RuleID: &ruleID, Score: rule.Weight * margin,
+					Detail: fmt.Sprintf("Margin %.0f%%", margin*100)})
+			} else {
+				add(entity.RankingContribution{Source: string(rule.Type), RuleID: &ruleID, Detail: "No cost recorded"})
+			}
+		}
+	}
+
+	result.Score = math.Round(result.Score*100) / 100
+	return result
+}
+
+func textContribution(query string, terms []string, product *entity.Product) entity.RankingContribution {
+	name := strings.ToLower(product.Name)
+	description := strings.ToLower(product.Description)
+
+	var points float64
+	var matches []string
+	for _, term := range terms {
+		switch {
+		case strings.Contains(name, term):
+			points += nameMatchScore
+			matches = append(matches, fmt.Sprintf("%q in name", term))
+		case strings.Contains(description, term):
+			points += descriptionMatchScore
+			matches = append(matches, fmt.Sprintf("%q in description", term))
+		}
+	}
+	if entity.NormalizeSearchQuery(product.Name) == query {
+		points += exactNameScore
+		matches = append(matches, "exact name")
+	}
+
+	detail := "No text match"
+	if len(matches) > 0 {
+		detail = "Matched " + strings.Join(matches, ", ")
+	}
+	return entity.RankingContribution{Source: SourceText, Score: points, Detail: detail}
+}
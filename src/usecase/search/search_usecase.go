@@ -0,0 +1,305 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+var (
+	ErrQueryRequired   = entity.ValidationError("Search query is required")
+	ErrRuleNotFound    = entity.NotFoundError("Ranking rule not found")
+	ErrProductNotFound = entity.NotFoundError("Product not found")
+	ErrInvalidSort     = entity.ValidationError("Invalid sort, use relevance or rating")
+)
+
+// Orders of the search results
+const (
+	SortRelevance = "relevance" // Ranked by text relevance and the active rules, the default
+	SortRating    = "rating"    // Best rated first, relevance breaking ties
+)
+
+// MaxCandidates caps how many matching products are ranked per search. Rules
+// reorder these candidates, so a result past the cap can never be found.
+const MaxCandidates = 500
+
+// RuleInput is the admin-editable part of a ranking rule
+type RuleInput struct {
+	Type      entity.RankingRuleType
+	Weight    float64
+	Query     string
+	ProductID *uuid.UUID
+	Position  int
+	Active    bool
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface SearchService -out ../../internal/testing/servicemocks
+
+type SearchService interface {
+	// Search returns a page of the products matching the query, ranked by the
+	// active rules or, sorting by SortRating, best rated first
+	Search(ctx context.Context, query, sortBy string, page, pageSize int) ([]*entity.Product, int, error)
+	// Explain ranks exactly like Search and reports the score breakdown of the page
+	Explain(ctx context.Context, query string, page, pageSize int) (*entity.SearchExplanation, int, error)
+
+	ListRules(ctx context.Context) ([]*entity.RankingRule, error)
+	CreateRule(ctx context.Context, actorID uuid.UUID, input RuleInput) (*entity.RankingRule, error)
+	UpdateRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID, input RuleInput) (*entity.RankingRule, error)
+	DeleteRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	searchRepo  repository.SearchRepository
+	productRepo repository.ProductRepository
+	services    Services
+	now         func() time.Time
+}
+
+func NewUseCase(searchRepo repository.SearchRepository, productRepo repository.ProductRepository, services Services) *UseCase {
+	return &UseCase{
+		searchRepo:  searchRepo,
+		productRepo: productRepo,
+		services:    services,
+		now:         time.Now,
+	}
+}
+
+func (uc *UseCase) Search(ctx context.Context, query, sortBy string, page, pageSize int) ([]*entity.Product, int, error) {
+	if sortBy != "" && sortBy != SortRelevance && sortBy != SortRating {
+		return nil, 0, ErrInvalidSort
+	}
+	page, pageSize = normalizePage(page, pageSize)
+	results, _, err := uc.rank(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	// The rating is kept on the products, sorting by it needs no other query
+	if sortBy == SortRating {
+		sort.SliceStable(results, func(i, j int) bool {
+			a, b := results[i].Product, results[j].Product
+			if a.RatingAverage != b.RatingAverage {
+				return a.RatingAverage > b.RatingAverage
+			}
+			return a.RatingCount > b.RatingCount
+		})
+	}
+
+	pageResults := paginate(results, page, pageSize)
+	products := make([]*entity.Product, 0, len(pageResults))
+	for _, result := range pageResults {
+		products = append(products, result.Product)
+	}
+	return products, len(results), nil
+}
+
+func (uc *UseCase) Explain(ctx context.Context, query string, page, pageSize int) (*entity.SearchExplanation, int, error) {
+	page, pageSize = normalizePage(page, pageSize)
+	results, rules, err := uc.rank(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &entity.SearchExplanation{
+		Query:   entity.NormalizeSearchQuery(query),
+		Results: paginate(results, page, pageSize),
+		Rules:   rules,
+	}, len(results), nil
+}
+
+// rank returns every ranked result of the query and the rules applied
+func (uc *UseCase) rank(ctx context.Context, query string) ([]entity.RankedProduct, []*entity.RankingRule, error) {
+	query = entity.NormalizeSearchQuery(query)
+	if query == "" {
+		return nil, nil, ErrQueryRequired
+	}
+
+	candidates, err := uc.searchRepo.FindCandidates(ctx, splitTerms(query), time.Now(), MaxCandidates)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rules, err := uc.activeRules(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pins := uc.resolvePins(ctx, query, rules, candidates)
+	return rank(query, candidates, rules, pins), rules, nil
+}
+
+// activeRules returns the boosts and the pins of the query
+func (uc *UseCase) activeRules(ctx context.Context, query string) ([]*entity.RankingRule, error) {
+	rules, err := uc.searchRepo.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*entity.RankingRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Active && (rule.Type != entity.RulePin || rule.Pins(query)) {
+			active = append(active, rule)
+		}
+	}
+	return active, nil
+}
+
+// resolvePins loads the pinned products, ordered by position. Pins of deleted
+// products are skipped, and a product pinned twice keeps its first position.
+func (uc *UseCase) resolvePins(ctx context.Context, query string, rules []*entity.RankingRule, candidates []*entity.Product) []pin {
+	loaded := make(map[uuid.UUID]*entity.Product, len(candidates))
+	for _, product := range candidates {
+		loaded[product.ID] = product
+	}
+
+	var pinRules []*entity.RankingRule
+	for _, rule := range rules {
+		if rule.Pins(query) {
+			pinRules = append(pinRules, rule)
+		}
+	}
+	sort.SliceStable(pinRules, func(i, j int) bool {
+		return pinRules[i].Position < pinRules[j].Position
+	})
+
+	pins := make([]pin, 0, len(pinRules))
+	seen := make(map[uuid.UUID]bool, len(pinRules))
+	for _, rule := range pinRules {
+		if seen[*rule.ProductID] {
+			continue
+		}
+
+		product, ok := loaded[*rule.ProductID]
+		if !ok {
+			var err error
+			if product, err = uc.productRepo.GetByID(ctx, *rule.ProductID); err != nil {
+				continue
+			}
+		}
+
+		seen[product.ID] = true
+		pins = append(pins, pin{rule: rule, product: product})
+	}
+	return pins
+}
+
+func (uc *UseCase) ListRules(ctx context.Context) ([]*entity.RankingRule, error) {
+	return uc.searchRepo.ListRules(ctx)
+}
+
+func (uc *UseCase) CreateRule(ctx context.Context, actorID uuid.UUID, input RuleInput) (*entity.RankingRule, error) {
+	rule := &entity.RankingRule{}
+	if err := uc.apply(ctx, rule, input); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	rule.ID = entity.NewID()
+	rule.CreatedAt = now
+	rule.UpdatedAt = now
+
+	if err := uc.searchRepo.CreateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "CREATE", "RankingRule", rule.ID, nil, rule)
+
+	return rule, nil
+}
+
+func (uc *UseCase) UpdateRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID, input RuleInput) (*entity.RankingRule, error) {
+	rule, err := uc.searchRepo.GetRule(ctx, id)
+	if err != nil {
+		return nil, ErrRuleNotFound
+	}
+
+	before := *rule
+	if err := uc.apply(ctx, rule, input); err != nil {
+		return nil, err
+	}
+	rule.UpdatedAt = uc.now()
+
+	if err := uc.searchRepo.UpdateRule(ctx, rule); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "UPDATE", "RankingRule", rule.ID, before, rule)
+
+	return rule, nil
+}
+
+func (uc *UseCase) DeleteRule(ctx context.Context, actorID uuid.UUID, id uuid.UUID) error {
+	rule, err := uc.searchRepo.GetRule(ctx, id)
+	if err != nil {
+		return ErrRuleNotFound
+	}
+
+	if err := uc.searchRepo.DeleteRule(ctx, id); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &actorID, "DELETE", "RankingRule", id, rule, nil)
+
+	return nil
+}
+
+// apply validates the input onto the rule. Pinned products must exist.
+func (uc *UseCase) apply(ctx context.Context, rule *entity.RankingRule, input RuleInput) error {
+	rule.Type = input.Type
+	rule.Weight = input.Weight
+	rule.Query = entity.NormalizeSearchQuery(input.Query)
+	rule.ProductID = input.ProductID
+	rule.Position = input.Position
+	rule.Active = input.Active
+
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+
+	if rule.Type == entity.RulePin {
+		if _, err := uc.productRepo.GetByID(ctx, *rule.ProductID); err != nil {
+			return ErrProductNotFound
+		}
+	}
+	return nil
+}
+
+// splitTerms returns the distinct words of a normalized query
+func splitTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, term := range strings.Fields(query) {
+		if !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+func normalizePage(page, pageSize int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+	return page, pageSize
+}
+
+func paginate(results []entity.RankedProduct, page, pageSize int) []entity.RankedProduct {
+	start := (page - 1) * pageSize
+	if start >= len(results) {
+		return []entity.RankedProduct{}
+	}
+	return results[start:min(start+pageSize, len(results))]
+}
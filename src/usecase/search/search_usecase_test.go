@@ -0,0 +1,264 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+// newTestUseCase returns a use case whose search finds the candidates, which
+// are also the products in the catalog
+func newTestUseCase(candidates ...*entity.Product) (*UseCase, *mocks.SearchRepository, *mocks.ProductRepository) {
+	searchRepo := new(mocks.SearchRepository)
+	searchRepo.On("FindCandidates", mock.Anything, mock.Anything, mock.Anything, MaxCandidates).Return(candidates, nil)
+	productRepo := new(mocks.ProductRepository)
+	for _, product := range candidates {
+		productRepo.On("GetByID", mock.Anything, product.ID).Return(product, nil)
+	}
+
+	uc := NewUseCase(searchRepo, productRepo, &mockServices.MockServices{AuditService: &mockServices.MockAuditService{}})
+	return uc, searchRepo, productRepo
+}
+
+func newProduct(name string, price float64, quantity int, cost *float64) *entity.Product {
+	return &entity.Product{ID: uuid.New(), Name: name, Price: price, Quantity: quantity, Cost: cost}
+}
+
+func names(products []*entity.Product) []string {
+	result := make([]string, 0, len(products))
+	for _, product := range products {
+		result = append(result, product.Name)
+	}
+	return result
+}
+
+func assertOrder(t *testing.T, got []*entity.Product, want ...string) {
+	t.Helper()
+	if strings.Join(names(got), ", ") != strings.Join(want, ", ") {
+		t.Errorf("expected %v, got %v", want, names(got))
+	}
+}
+
+func TestSearch_RanksByTextRelevance(t *testing.T) {
+	bag := newProduct("Laptop Bag", 40, 5, nil)
+	laptop := newProduct("Laptop", 900, 5, nil)
+	stand := &entity.Product{ID: uuid.New(), Name: "Stand", Description: "Fits any laptop", Quantity: 5}
+	uc, repo, _ := newTestUseCase(bag, laptop, stand)
+	repo.On("ListRules", mock.Anything).Return(nil, nil)
+
+	products, total, err := uc.Search(context.Background(), "  LAPTOP ", "", 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 3 {
+		t.Errorf("expected 3 results, got %d", total)
+	}
+	repo.AssertCalled(t, "FindCandidates", mock.Anything, []string{"laptop"}, mock.Anything, MaxCandidates)
+	assertOrder(t, products, "Laptop", "Laptop Bag", "Stand")
+}
+
+func TestSearch_BoostsInStockAndMargin(t *testing.T) {
+	highCost, lowCost := 90.0, 20.0
+	soldOut := newProduct("Desk Lamp Classic", 100, 0, &highCost)
+	lowMargin := newProduct("Desk Lamp Basic", 100, 3, &highCost)
+	highMargin := newProduct("Desk Lamp Deluxe", 100, 3, &lowCost)
+	uc, repo, _ := newTestUseCase(soldOut, lowMargin, highMargin)
+	repo.On("ListRules", mock.Anything).Return(nil, nil).Once()
+
+	products, _, _ := uc.Search(context.Background(), "desk lamp", "", 1, 10)
+	assertOrder(t, products, "Desk Lamp Basic", "Desk Lamp Classic", "Desk Lamp Deluxe")
+
+	repo.On("ListRules", mock.Anything).Return([]*entity.RankingRule{
+		{ID: uuid.New(), Type: entity.RuleBoostInStock, Weight: 5, Active: true},
+		{ID: uuid.New(), Type: entity.RuleBoostMargin, Weight: 10, Active: true},
+	}, nil)
+
+	products, _, _ = uc.Search(context.Background(), "desk lamp", "", 1, 10)
+	assertOrder(t, products, "Desk Lamp Deluxe", "Desk Lamp Basic", "Desk Lamp Classic")
+}
+
+func TestSearch_InactiveRulesAreIgnored(t *testing.T) {
+	empty := newProduct("Chair A", 10, 0, nil)
+	stocked := newProduct("Chair B", 10, 1, nil)
+	uc, repo, _ := newTestUseCase(empty, stocked)
+	repo.On("ListRules", mock.Anything).Return([]*entity.RankingRule{{ID: uuid.New(), Type: entity.RuleBoostInStock, Weight: 5}}, nil)
+
+	products, _, _ := uc.Search(context.Background(), "chair", "", 1, 10)
+	assertOrder(t, products, "Chair A", "Chair B")
+}
+
+func TestSearch_PinsProductAtPosition(t *testing.T) {
+	a := newProduct("Phone Alpha", 10, 1, nil)
+	b := newProduct("Phone Beta", 10, 1, nil)
+	c := newProduct("Phone Gamma", 10, 1, nil)
+	rugged := newProduct("Rugged Case", 10, 1, nil) // Does not match the query
+	uc, repo, catalog := newTestUseCase(a, b, c)
+	catalog.On("GetByID", mock.Anything, rugged.ID).Return(rugged, nil)
+
+	repo.On("ListRules", mock.Anything).Return([]*entity.RankingRule{
+		{ID: uuid.New(), Type: entity.RulePin, Query: "phone", ProductID: &c.ID, Position: 1, Active: true},
+		{ID: uuid.New(), Type: entity.RulePin, Query: "phone", ProductID: &rugged.ID, Position: 3, Active: true},
+		{ID: uuid.New(), Type: entity.RulePin, Query: "tablet", ProductID: &b.ID, Position: 1, Active: true},
+	}, nil)
+
+	products, total, err := uc.Search(context.Background(), "Phone", "", 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 4 {
+		t.Errorf("expected the pinned non-matching product to be counted, got %d", total)
+	}
+	assertOrder(t, products, "Phone Gamma", "Phone Alpha", "Rugged Case", "Phone Beta")
+
+	products, _, _ = uc.Search(context.Background(), "phone", "", 2, 2)
+	assertOrder(t, products, "Rugged Case", "Phone Beta")
+}
+
+func TestSearch_PinPastTheEndIsAppended(t *testing.T) {
+	a := newProduct("Mug", 10, 1, nil)
+	b := newProduct("Mug Large", 10, 1, nil)
+	uc, repo, _ := newTestUseCase(a, b)
+	repo.On("ListRules", mock.Anything).Return([]*entity.RankingRule{
+		{ID: uuid.New(), Type: entity.RulePin, Query: "mug", ProductID: &a.ID, Position: 10, Active: true},
+	}, nil)
+
+	products, _, _ := uc.Search(context.Background(), "mug", "", 1, 10)
+	assertOrder(t, products, "Mug Large", "Mug")
+}
+
+func TestSearch_SortsByRating(t *testing.T) {
+	a := newProduct("Lamp", 10, 1, nil)
+	b := newProduct("Lamp Desk", 10, 1, nil)
+	b.RatingAverage, b.RatingCount = 4.5, 2
+	c := newProduct("Lamp Floor", 10, 1, nil)
+	c.RatingAverage, c.RatingCount = 4.5, 8
+	uc, repo, _ := newTestUseCase(a, b, c)
+	repo.On("ListRules", mock.Anything).Return(nil, nil)
+
+	products, _, err := uc.Search(context.Background(), "lamp", SortRating, 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertOrder(t, products, "Lamp Floor", "Lamp Desk", "Lamp")
+
+	if _, _, err := uc.Search(context.Background(), "lamp", "price", 1, 10); !errors.Is(err, ErrInvalidSort) {
+		t.Errorf("expected ErrInvalidSort, got %v", err)
+	}
+}
+
+func TestSearch_RequiresQuery(t *testing.T) {
+	uc, _, _ := newTestUseCase()
+
+	if _, _, err := uc.Search(context.Background(), "   ", "", 1, 10); !errors.Is(err, ErrQueryRequired) {
+		t.Errorf("expected ErrQueryRequired, got %v", err)
+	}
+}
+
+func TestExplain_ReportsContributions(t *testing.T) {
+	cost := 60.0
+	product := newProduct("Kettle", 80, 2, &cost)
+	other := newProduct("Kettle Mini", 30, 0, nil)
+	uc, repo, _ := newTestUseCase(product, other)
+	inStock := &entity.RankingRule{ID: uuid.New(), Type: entity.RuleBoostInStock, Weight: 5, Active: true}
+	margin := &entity.RankingRule{ID: uuid.New(), Type: entity.RuleBoostMargin, Weight: 10, Active: true}
+	repo.On("ListRules", mock.Anything).Return([]*entity.RankingRule{inStock, margin}, nil)
+
+	explanation, total, err := uc.Explain(context.Background(), "kettle", 1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total != 2 || len(explanation.Rules) != 2 {
+		t.Fatalf("expected 2 results and 2 rules, got %d and %d", total, len(explanation.Rules))
+	}
+
+	top := explanation.Results[0]
+	if top.Product.ID != product.ID {
+		t.Fatalf("expected Kettle first, got %s", top.Product.Name)
+	}
+	// 2 for the name, 3 for the exact name, 5 in stock, 10 * 25% margin
+	if top.Score != 12.5 {
+		t.Errorf("expected score 12.5, got %v", top.Score)
+	}
+	if len(top.Contributions) != 3 || top.Contributions[2].Detail != "Margin 25%" {
+		t.Errorf("unexpected contributions %+v", top.Contributions)
+	}
+
+	last := explanation.Results[1]
+	if last.Contributions[1].Detail != "Out of stock" || last.Contributions[2].Detail != "No cost recorded" {
+		t.Errorf("expected unmet rules to be explained, got %+v", last.Contributions)
+	}
+}
+
+func TestCreateRule(t *testing.T) {
+	product := newProduct("Headphones", 50, 1, nil)
+	uc, repo, catalog := newTestUseCase(product)
+	repo.On("CreateRule", mock.Anything, mock.AnythingOfType("*entity.RankingRule")).Return(nil)
+	actor := uuid.New()
+
+	rule, err := uc.CreateRule(context.Background(), actor, RuleInput{
+		Type: entity.RulePin, Query: "  Noise Cancelling ", ProductID: &product.ID, Position: 1, Active: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.Query != "noise cancelling" {
+		t.Errorf("expected a pin with a normalized query, got %q", rule.Query)
+	}
+	repo.AssertCalled(t, "CreateRule", mock.Anything, rule)
+
+	missing := uuid.New()
+	catalog.On("GetByID", mock.Anything, missing).Return(nil, errors.New("Product not found"))
+	_, err = uc.CreateRule(context.Background(), actor, RuleInput{
+		Type: entity.RulePin, Query: "x", ProductID: &missing, Position: 1, Active: true,
+	})
+	if !errors.Is(err, ErrProductNotFound) {
+		t.Errorf("expected ErrProductNotFound, got %v", err)
+	}
+
+	if _, err := uc.CreateRule(context.Background(), actor, RuleInput{Type: entity.RuleBoostMargin}); err == nil {
+		t.Error("expected a boost without weight to be rejected")
+	}
+}
+
+func TestUpdateAndDeleteRule(t *testing.T) {
+	uc, repo, _ := newTestUseCase()
+	repo.On("CreateRule", mock.Anything, mock.AnythingOfType("*entity.RankingRule")).Return(nil)
+	repo.On("UpdateRule", mock.Anything, mock.AnythingOfType("*entity.RankingRule")).Return(nil)
+	repo.On("DeleteRule", mock.Anything, mock.Anything).Return(nil)
+	actor := uuid.New()
+
+	rule, err := uc.CreateRule(context.Background(), actor, RuleInput{Type: entity.RuleBoostInStock, Weight: 2, Active: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Found until it is deleted
+	repo.On("GetRule", mock.Anything, rule.ID).Return(rule, nil).Twice()
+	repo.On("GetRule", mock.Anything, mock.Anything).Return(nil, errors.New("Ranking rule not found"))
+
+	updated, err := uc.UpdateRule(context.Background(), actor, rule.ID, RuleInput{Type: entity.RuleBoostInStock, Weight: 4})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Weight != 4 || updated.Active {
+		t.Errorf("expected the weight and active flag to be replaced, got %+v", updated)
+	}
+
+	if _, err := uc.UpdateRule(context.Background(), actor, uuid.New(), RuleInput{}); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("expected ErrRuleNotFound, got %v", err)
+	}
+
+	if err := uc.DeleteRule(context.Background(), actor, rule.ID); err != nil {
+		t.Errorf("expected the rule to be deleted, got %v", err)
+	}
+	repo.AssertCalled(t, "DeleteRule", mock.Anything, rule.ID)
+	if err := uc.DeleteRule(context.Background(), actor, rule.ID); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("expected ErrRuleNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,80 @@
+package seed
+
+import "github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+
+// demoCategory is a category of the demo catalog, Parent names the category
+// it is nested under
+type demoCategory struct {
+	Name   string
+	Parent string
+}
+
+var demoCategories = []demoCategory{
+	{Name: "Electronics"},
+	{Name: "Computers", Parent: "Electronics"},
+	{Name: "Audio", Parent: "Electronics"},
+	{Name: "Phones", Parent: "Electronics"},
+	{Name: "Apparel"},
+	{Name: "T-Shirts", Parent: "Apparel"},
+	{Name: "Shoes", Parent: "Apparel"},
+	{Name: "Home & Kitchen"},
+	{Name: "Grocery"},
+}
+
+// demoOption is an option of a demo product, every combination of the
+// options' values becomes a variant
+type demoOption struct {
+	Name   string
+	Values []string
+}
+
+type demoProduct struct {
+	Name        string
+	Description string
+	Price       float64
+	Categories  []string
+	Options     []demoOption
+	Measure     entity.UnitMeasure
+}
+
+var (
+	sizes  = demoOption{Name: "Size", Values: []string{"S", "M", "L", "XL"}}
+	colors = demoOption{Name: "Color", Values: []string{"Black", "White", "Navy"}}
+)
+
+var demoProducts = []demoProduct{
+	{Name: "Laptop Pro 14", Description: "14-inch laptop with 16GB RAM and a 512GB SSD.", Price: 1899, Categories: []string{"Electronics", "Computers"},
+		Options: []demoOption{{Name: "Memory", Values: []string{"16GB", "32GB"}}}},
+	{Name: "Ultrabook Air 13", Description: "Light 13-inch ultrabook with all-day battery.", Price: 1199, Categories: []string{"Electronics", "Computers"}},
+	{Name: "Mechanical Keyboard", Description: "Tenkeyless keyboard with hot-swappable switches.", Price: 129, Categories: []string{"Computers"},
+		Options: []demoOption{{Name: "Switch", Values: []string{"Red", "Brown", "Blue"}}}},
+	{Name: "Wireless Mouse", Description: "Ergonomic mouse with silent clicks.", Price: 49.9, Categories: []string{"Computers"}},
+	{Name: "27-inch 4K Monitor", Description: "IPS panel with USB-C power delivery.", Price: 449, Categories: []string{"Electronics", "Computers"}},
+	{Name: "Noise Cancelling Headphones", Description: "Over-ear headphones with 30 hours of battery.", Price: 299, Categories: []string{"Electronics", "Audio"},
+		Options: []demoOption{{Name: "Color", Values: []string{"Black", "Silver"}}}},
+	{Name: "Bluetooth Speaker", Description: "Waterproof portable speaker.", Price: 89, Categories: []string{"Audio"}},
+	{Name: "Smartphone X", Description: "6.1-inch OLED phone with a triple camera.", Price: 999, Categories: []string{"Electronics", "Phones"},
+		Options: []demoOption{{Name: "Storage", Values: []string{"128GB", "256GB"}}, {Name: "Color", Values: []string{"Graphite", "Blue"}}}},
+	{Name: "USB-C Charger 65W", Description: "Compact GaN charger for laptops and phones.", Price: 39, Categories: []string{"Phones", "Computers"}},
+	{Name: "Organic Cotton T-Shirt", Description: "Regular fit, 100% organic cotton.", Price: 24, Categories: []string{"Apparel", "T-Shirts"},
+		Options: []demoOption{sizes, colors}},
+	{Name: "Graphic T-Shirt", Description: "Soft tee with a screen-printed logo.", Price: 29, Categories: []string{"T-Shirts"},
+		Options: []demoOption{sizes}},
+	{Name: "Running Shoes", Description: "Lightweight trainers with a cushioned sole.", Price: 119, Categories: []string{"Apparel", "Shoes"},
+		Options: []demoOption{{Name: "Size", Values: []string{"40", "41", "42", "43", "44"}}}},
+	{Name: "Leather Boots", Description: "Waterproof boots in full-grain leather.", Price: 179, Categories: []string{"Shoes"},
+		Options: []demoOption{{Name: "Size", Values: []string{"41", "42", "43"}}}},
+	{Name: "Chef's Knife", Description: "8-inch stainless steel chef's knife.", Price: 69, Categories: []string{"Home & Kitchen"}},
+	{Name: "Cast Iron Skillet", Description: "Pre-seasoned 10-inch skillet.", Price: 45, Categories: []string{"Home & Kitchen"}},
+	{Name: "French Press", Description: "1 liter borosilicate glass coffee maker.", Price: 32, Categories: []string{"Home & Kitchen"}},
+	{Name: "Arabica Coffee Beans", Description: "Medium roast whole beans, 500 g.", Price: 14.5, Categories: []string{"Grocery"},
+		Measure: entity.UnitMeasure{Unit: entity.UnitKilogram, Content: 0.5}},
+	{Name: "Extra Virgin Olive Oil", Description: "Cold pressed, 750 ml bottle.", Price: 12.9, Categories: []string{"Grocery"},
+		Measure: entity.UnitMeasure{Unit: entity.UnitLiter, Content: 0.75}},
+	{Name: "Green Tea", Description: "Loose leaf sencha, 100 g.", Price: 8.5, Categories: []string{"Grocery"},
+		Measure: entity.UnitMeasure{Unit: entity.UnitKilogram, Content: 0.1}},
+}
+
+var firstNames = []string{"Alice", "Bruno", "Carla", "Diego", "Emma", "Felipe", "Grace", "Hugo", "Isabel", "João", "Kenji", "Laura", "Marco", "Nina", "Oscar", "Paula"}
+
+var lastNames = []string{"Almeida", "Becker", "Costa", "Dubois", "Evans", "Ferreira", "Garcia", "Haddad", "Ito", "Jensen", "Kowalski", "Lopez", "Martin", "Novak"}
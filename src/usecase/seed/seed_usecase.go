@@ -0,0 +1,362 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/usecase/pricing"
+)
+
+// AdminEmail is the login of the demo admin account
+const AdminEmail = "admin@demo.example.com"
+
+var ErrAlreadySeeded = entity.ConflictError("The demo data is already seeded")
+
+// Options sizes the demo data. The same Seed and Now always produce the same
+// rows, IDs included.
+type Options struct {
+	Seed      int64
+	Customers int
+	Orders    int
+	// Days is how far back the order history goes from Now
+	Days     int
+	Now      time.Time
+	Password string // Password of every demo account
+	TaxRate  float64
+}
+
+// Summary counts what was seeded
+type Summary struct {
+	Users      int
+	Categories int
+	Products   int
+	Variants   int
+	Orders     int
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface SeedService -out ../../internal/testing/servicemocks
+
+type SeedService interface {
+	// Seed fills an empty store with demo users, a catalog with variants and an
+	// order history. It fails with ErrAlreadySeeded when the demo admin exists.
+	Seed(ctx context.Context, opts Options) (*Summary, error)
+}
+
+type UseCase struct {
+	userRepo     repository.UserRepository
+	categoryRepo repository.CategoryRepository
+	productRepo  repository.ProductRepository
+	optionRepo   repository.ProductOptionRepository
+	variantRepo  repository.ProductVariantRepository
+	orderRepo    repository.OrderRepository
+	pricing      pricing.Calculator
+}
+
+func NewUseCase(userRepo repository.UserRepository, categoryRepo repository.CategoryRepository, productRepo repository.ProductRepository, optionRepo repository.ProductOptionRepository, variantRepo repository.ProductVariantRepository, orderRepo repository.OrderRepository) *UseCase {
+	return &UseCase{
+		userRepo:     userRepo,
+		categoryRepo: categoryRepo,
+		productRepo:  productRepo,
+		optionRepo:   optionRepo,
+		variantRepo:  variantRepo,
+		orderRepo:    orderRepo,
+		pricing:      pricing.NewCalculator(),
+	}
+}
+
+// seeding holds the state of one Seed run
+type seeding struct {
+	opts    Options
+	rng     *rand.Rand
+	summary Summary
+
+	customers []*entity.User
+	products  []*entity.Product
+}
+
+func (uc *UseCase) Seed(ctx context.Context, opts Options) (*Summary, error) {
+	if opts.Customers < 1 || opts.Orders < 0 || opts.Days < 1 {
+		return nil, errors.New("Seeding needs at least 1 customer and 1 day of history")
+	}
+	if len(opts.Password) < 6 {
+		return nil, errors.New("Password must be at least 6 characters")
+	}
+
+	if _, err := uc.userRepo.GetByEmail(ctx, AdminEmail); err == nil {
+		return nil, ErrAlreadySeeded
+	}
+
+	s := &seeding{opts: opts, rng: rand.New(rand.NewSource(opts.Seed))}
+
+	if err := uc.seedUsers(ctx, s); err != nil {
+		return &s.summary, fmt.Errorf("seeding users: %w", err)
+	}
+	categories, err := uc.seedCategories(ctx, s)
+	if err != nil {
+		return &s.summary, fmt.Errorf("seeding categories: %w", err)
+	}
+	if err := uc.seedProducts(ctx, s, categories); err != nil {
+		return &s.summary, fmt.Errorf("seeding products: %w", err)
+	}
+	if err := uc.seedOrders(ctx, s); err != nil {
+		return &s.summary, fmt.Errorf("seeding orders: %w", err)
+	}
+
+	return &s.summary, nil
+}
+
+func (uc *UseCase) seedUsers(ctx context.Context, s *seeding) error {
+	admin := &entity.User{ID: s.newID(), Email: AdminEmail, Name: "Demo Admin", Role: entity.RoleAdmin, Active: true}
+	// bcrypt is slow by design, every demo account shares the one hash
+	if err := admin.SetPassword(s.opts.Password); err != nil {
+		return err
+	}
+	if err := uc.userRepo.Create(ctx, admin); err != nil {
+		return err
+	}
+	s.summary.Users++
+
+	emails := map[string]bool{AdminEmail: true}
+	for len(s.customers) < s.opts.Customers {
+		first := firstNames[s.rng.Intn(len(firstNames))]
+		last := lastNames[s.rng.Intn(len(lastNames))]
+
+		local := entity.Slugify(first) + "." + entity.Slugify(last)
+		email := local + "@demo.example.com"
+		for n := 2; emails[email]; n++ {
+			email = fmt.Sprintf("%s%d@demo.example.com", local, n)
+		}
+		emails[email] = true
+
+		customer := &entity.User{
+			ID:           s.newID(),
+			Email:        email,
+			PasswordHash: admin.PasswordHash,
+			Name:         first + " " + last,
+			Role:         entity.RoleCustomer,
+			Active:       true,
+		}
+		if err := uc.userRepo.Create(ctx, customer); err != nil {
+			return err
+		}
+		s.customers = append(s.customers, customer)
+		s.summary.Users++
+	}
+	return nil
+}
+
+// seedCategories creates the category tree and returns the categories by name
+func (uc *UseCase) seedCategories(ctx context.Context, s *seeding) (map[string]*entity.Category, error) {
+	categories := make(map[string]*entity.Category, len(demoCategories))
+	for _, demo := range demoCategories {
+		category := &entity.Category{ID: s.newID(), Name: demo.Name, Slug: entity.Slugify(demo.Name)}
+		if demo.Parent != "" {
+			category.ParentID = &categories[demo.Parent].ID
+		}
+		if err := uc.categoryRepo.Create(ctx, category); err != nil {
+			return nil, err
+		}
+		categories[demo.Name] = category
+		s.summary.Categories++
+	}
+	return categories, nil
+}
+
+func (uc *UseCase) seedProducts(ctx context.Context, s *seeding, categories map[string]*entity.Category) error {
+	for _, demo := range demoProducts {
+		// Unit costs between 40% and 70% of the price, so margins vary
+		cost := roundCents(demo.Price * (0.4 + 0.3*s.rng.Float64()))
+		product := &entity.Product{
+			ID:          s.newID(),
+			Name:        demo.Name,
+			Description: demo.Description,
+			Price:       demo.Price,
+			Quantity:    20 + s.rng.Intn(180),
+			Cost:        &cost,
+			Measure:     demo.Measure,
+			CreatedAt:   s.daysAgo(s.opts.Days + 30 + s.rng.Intn(60)),
+		}
+		if err := uc.productRepo.Create(ctx, product); err != nil {
+			return err
+		}
+		s.summary.Products++
+
+		for _, name := range demo.Categories {
+			if err := uc.categoryRepo.AssignCategoryToProduct(ctx, product.ID, categories[name].ID); err != nil {
+				return err
+			}
+		}
+
+		variants, err := uc.seedVariants(ctx, s, product, demo.Options)
+		if err != nil {
+			return err
+		}
+		product.Variants = variants
+		s.products = append(s.products, product)
+	}
+	return nil
+}
+
+// seedVariants creates the product's options and one variant per combination of their values
+func (uc *UseCase) seedVariants(ctx context.Context, s *seeding, product *entity.Product, demoOptions []demoOption) ([]entity.ProductVariant, error) {
+	if len(demoOptions) == 0 {
+		return nil, nil
+	}
+
+	options := make([]*entity.ProductOption, 0, len(demoOptions))
+	for position, demo := range demoOptions {
+		option := &entity.ProductOption{ID: s.newID(), ProductID: product.ID, Name: demo.Name, Position: position}
+		for i, value := range demo.Values {
+			option.Values = append(option.Values, entity.ProductOptionValue{ID: s.newID(), OptionID: option.ID, Value: value, Position: i})
+		}
+		if err := uc.optionRepo.Create(ctx, option); err != nil {
+			return nil, err
+		}
+		options = append(options, option)
+	}
+
+	var variants []entity.ProductVariant
+	for _, selection := range combinations(demoOptions) {
+		variantOptions, err := entity.BuildVariantOptions(options, selection)
+		if err != nil {
+			return nil, err
+		}
+
+		variant := &entity.ProductVariant{ID: s.newID(), ProductID: product.ID, Quantity: 5 + s.rng.Intn(45)}
+		variant.SetOptions(variantOptions)
+		for i := range variant.Options {
+			variant.Options[i].ID = s.newID()
+			variant.Options[i].VariantID = variant.ID
+		}
+		variant.SKU = entity.GenerateSKU(product.ID, variant.Options)
+		// One variant in four costs more than the product, like a bigger size or memory
+		if s.rng.Intn(4) == 0 {
+			price := roundCents(product.Price * 1.15)
+			variant.Price_Override = &price
+		}
+
+		if err := uc.variantRepo.Create(ctx, variant); err != nil {
+			return nil, err
+		}
+		variants = append(variants, *variant)
+		s.summary.Variants++
+	}
+	return variants, nil
+}
+
+// seedOrders places orders spread over the history. Orders older than a week
+// are settled: mostly completed and paid, some cancelled. Recent ones may
+// still be pending.
+func (uc *UseCase) seedOrders(ctx context.Context, s *seeding) error {
+	for i := 0; i < s.opts.Orders; i++ {
+		customerIndex := s.rng.Intn(len(s.customers))
+		customer := s.customers[customerIndex]
+		age := s.rng.Intn(s.opts.Days)
+		createdAt := s.daysAgo(age).Add(time.Duration(s.rng.Intn(24*60)) * time.Minute)
+
+		order := &entity.Order{
+			ID:         s.newID(),
+			CustomerID: customerIndex + 1,
+			UserID:     &customer.ID,
+			CreatedAt:  createdAt,
+			UpdatedAt:  createdAt,
+		}
+		order.Status, order.PaymentStatus = s.outcome(age)
+
+		lines := 1 + s.rng.Intn(3)
+		for _, p := range s.rng.Perm(len(s.products))[:lines] {
+			product := s.products[p]
+			item := entity.OrderItem{ID: s.newID(), OrderID: order.ID, ProductID: product.ID, Quantity: 1 + s.rng.Intn(3), Price: product.Price}
+			if len(product.Variants) > 0 {
+				variant := product.Variants[s.rng.Intn(len(product.Variants))]
+				item.VariantID = &variant.ID
+				if variant.HasPriceOverride() {
+					item.Price = *variant.Price_Override
+				}
+				item.SnapshotProduct(product, &variant)
+			} else {
+				item.SnapshotProduct(product, nil)
+			}
+			order.Products = append(order.Products, item)
+		}
+
+		order.TotalPrice = uc.pricing.PriceOrder(order, pricing.Input{TaxRate: s.opts.TaxRate}).Total
+		if order.PaymentStatus == entity.Paid {
+			order.AmountPaid = order.TotalPrice
+		}
+		for j := range order.Products {
+			for k := range order.Products[j].Components {
+				order.Products[j].Components[k].ID = s.newID()
+			}
+		}
+
+		if err := order.Validate(); err != nil {
+			return err
+		}
+		if err := uc.orderRepo.Create(ctx, order); err != nil {
+			return err
+		}
+		s.summary.Orders++
+	}
+	return nil
+}
+
+// outcome picks the status of an order placed age days ago
+func (s *seeding) outcome(age int) (entity.OrderStatus, entity.PaymentStatus) {
+	roll := s.rng.Intn(100)
+	switch {
+	case age < 7 && roll < 40:
+		return entity.Pending, entity.Unpaid
+	case roll < 85:
+		return entity.Completed, entity.Paid
+	case roll < 95:
+		return entity.Cancelled, entity.Unpaid
+	default:
+		return entity.Cancelled, entity.Failed
+	}
+}
+
+// newID draws a UUID from the seeded source, so reruns produce the same IDs
+func (s *seeding) newID() uuid.UUID {
+	id, err := uuid.NewRandomFromReader(s.rng)
+	if err != nil {
+		panic(err) // rand.Rand never fails to read
+	}
+	return id
+}
+
+func (s *seeding) daysAgo(days int) time.Time {
+	return s.opts.Now.AddDate(0, 0, -days)
+}
+
+// combinations lists every selection of one value per option, e.g. Size=S +
+// Color=Black, Size=S + Color=White, ...
+func combinations(options []demoOption) []map[string]string {
+	result := []map[string]string{{}}
+	for _, option := range options {
+		var next []map[string]string
+		for _, partial := range result {
+			for _, value := range option.Values {
+				selection := make(map[string]string, len(partial)+1)
+				for name, v := range partial {
+					selection[name] = v
+				}
+				selection[option.Name] = value
+				next = append(next, selection)
+			}
+		}
+		result = next
+	}
+	return result
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
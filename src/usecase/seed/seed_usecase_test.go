@@ -0,0 +1,106 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+)
+
+var testNow = time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestUseCase() (*UseCase, *memory.Store) {
+	store := memory.NewStore()
+	return NewUseCase(
+		memory.NewUserRepository(store),
+		memory.NewCategoryRepository(store),
+		memory.NewProductRepository(store),
+		memory.NewProductOptionRepository(store),
+		memory.NewProductVariantRepository(store),
+		memory.NewOrderRepository(store),
+	), store
+}
+
+func testOptions() Options {
+	return Options{Seed: 7, Customers: 5, Orders: 40, Days: 90, Now: testNow, Password: "demo1234", TaxRate: 0.1}
+}
+
+func TestSeed_PopulatesStore(t *testing.T) {
+	uc, store := newTestUseCase()
+
+	summary, err := uc.Seed(context.Background(), testOptions())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if summary.Users != 6 || summary.Categories != len(demoCategories) || summary.Products != len(demoProducts) || summary.Orders != 40 {
+		t.Errorf("unexpected summary %+v", summary)
+	}
+
+	admin, err := memory.NewUserRepository(store).GetByEmail(context.Background(), AdminEmail)
+	if err != nil || !admin.IsAdmin() || !admin.CheckPassword("demo1234") {
+		t.Errorf("expected a demo admin signing in with the password, got %+v, %v", admin, err)
+	}
+
+	variants, total, _ := memory.NewProductVariantRepository(store).GetAll(context.Background(), 1, 1000)
+	if total != summary.Variants || total == 0 {
+		t.Errorf("expected %d variants, got %d", summary.Variants, total)
+	}
+	for _, variant := range variants {
+		if variant.SKU == "" || variant.CombinationKey == "" {
+			t.Errorf("expected variants with a SKU and combination, got %+v", variant)
+		}
+	}
+
+	orders, _, _ := memory.NewOrderRepository(store).GetAll(context.Background(), 1, 1000, nil, nil)
+	for _, order := range orders {
+		if order.CreatedAt.After(testNow.AddDate(0, 0, 1)) || order.CreatedAt.Before(testNow.AddDate(0, 0, -90)) {
+			t.Errorf("expected orders within the history, got one placed %s", order.CreatedAt)
+		}
+		if order.TotalPrice <= 0 || order.UserID == nil {
+			t.Errorf("expected priced orders placed by customers, got %+v", order)
+		}
+		if order.Status == entity.Completed && order.PaymentStatus != entity.Paid {
+			t.Errorf("expected completed orders to be paid, got %s", order.PaymentStatus)
+		}
+	}
+}
+
+func TestSeed_IsDeterministic(t *testing.T) {
+	first, firstStore := newTestUseCase()
+	second, secondStore := newTestUseCase()
+	first.Seed(context.Background(), testOptions())
+	second.Seed(context.Background(), testOptions())
+
+	firstOrders, _, _ := memory.NewOrderRepository(firstStore).GetAll(context.Background(), 1, 1000, nil, nil)
+	secondOrders, _, _ := memory.NewOrderRepository(secondStore).GetAll(context.Background(), 1, 1000, nil, nil)
+	if len(firstOrders) != len(secondOrders) {
+		t.Fatalf("expected the same number of orders, got %d and %d", len(firstOrders), len(secondOrders))
+	}
+	for i := range firstOrders {
+		if firstOrders[i].ID != secondOrders[i].ID || firstOrders[i].TotalPrice != secondOrders[i].TotalPrice {
+			t.Fatalf("expected the same orders, got %+v and %+v", firstOrders[i], secondOrders[i])
+		}
+	}
+}
+
+func TestSeed_RefusesSeededStore(t *testing.T) {
+	uc, _ := newTestUseCase()
+	uc.Seed(context.Background(), testOptions())
+
+	if _, err := uc.Seed(context.Background(), testOptions()); !errors.Is(err, ErrAlreadySeeded) {
+		t.Errorf("expected ErrAlreadySeeded, got %v", err)
+	}
+}
+
+func TestSeed_InvalidOptions(t *testing.T) {
+	uc, _ := newTestUseCase()
+
+	opts := testOptions()
+	opts.Customers = 0
+	if _, err := uc.Seed(context.Background(), opts); err == nil {
+		t.Error("expected an error without customers")
+	}
+}
@@ -0,0 +1,56 @@
+package stock
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Recorder -out ../../internal/testing/servicemocks -name StockRecorder
+
+// Recorder appends entries to the stock movement ledger
+type Recorder interface {
+	Record(ctx context.Context, movement *entity.StockMovement) error
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface StockService -out ../../internal/testing/servicemocks
+
+type StockService interface {
+	Recorder
+	ListMovements(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error)
+}
+
+type UseCase struct {
+	repo repository.StockMovementRepository
+}
+
+func NewUseCase(repo repository.StockMovementRepository) *UseCase {
+	return &UseCase{
+		repo: repo,
+	}
+}
+
+func (uc *UseCase) Record(ctx context.Context, movement *entity.StockMovement) error {
+	if movement.Delta == 0 {
+		return nil
+	}
+
+	if err := movement.Validate(); err != nil {
+		return err
+	}
+
+	return uc.repo.Create(ctx, movement)
+}
+
+func (uc *UseCase) ListMovements(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return uc.repo.GetByProductID(ctx, productID, filters, page, pageSize)
+}
@@ -0,0 +1,80 @@
+package stock
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+)
+
+type mockStockMovementRepo struct {
+	movements []*entity.StockMovement
+	lastPage  int
+	lastSize  int
+}
+
+func (m *mockStockMovementRepo) Create(ctx context.Context, movement *entity.StockMovement) error {
+	m.movements = append(m.movements, movement)
+	return nil
+}
+
+func (m *mockStockMovementRepo) GetByProductID(ctx context.Context, productID uuid.UUID, filters repository.StockMovementFilters, page, pageSize int) ([]*entity.StockMovement, int, error) {
+	m.lastPage, m.lastSize = page, pageSize
+	var result []*entity.StockMovement
+	for _, movement := range m.movements {
+		if movement.ProductID != productID {
+			continue
+		}
+		if filters.Reason != nil && movement.Reason != *filters.Reason {
+			continue
+		}
+		result = append(result, movement)
+	}
+	return result, len(result), nil
+}
+
+var _ repository.StockMovementRepository = (*mockStockMovementRepo)(nil)
+
+func TestRecord_SkipsUnchangedStock(t *testing.T) {
+	repo := &mockStockMovementRepo{}
+	uc := NewUseCase(repo)
+
+	if err := uc.Record(context.Background(), entity.NewStockMovement(uuid.New(), nil, entity.StockAdjustment, 5, 5, "")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(repo.movements) != 0 {
+		t.Errorf("expected no movement for unchanged stock, got %d", len(repo.movements))
+	}
+}
+
+func TestRecord_RejectsUnknownReason(t *testing.T) {
+	uc := NewUseCase(&mockStockMovementRepo{})
+
+	err := uc.Record(context.Background(), entity.NewStockMovement(uuid.New(), nil, "theft", 5, 4, ""))
+	if err == nil {
+		t.Error("expected error for unknown reason")
+	}
+}
+
+func TestListMovements_FiltersByReason(t *testing.T) {
+	repo := &mockStockMovementRepo{}
+	uc := NewUseCase(repo)
+
+	productID := uuid.New()
+	uc.Record(context.Background(), entity.NewStockMovement(productID, nil, entity.StockAdjustment, 0, 10, "initial stock"))
+	uc.Record(context.Background(), entity.NewStockMovement(productID, nil, entity.StockOrder, 10, 8, "order-1"))
+
+	reason := entity.StockOrder
+	movements, total, err := uc.ListMovements(context.Background(), productID, repository.StockMovementFilters{Reason: &reason}, 0, 500)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if total != 1 || movements[0].Delta != -2 {
+		t.Errorf("expected the order movement only, got %d", total)
+	}
+	if repo.lastPage != 1 || repo.lastSize != 10 {
+		t.Errorf("expected normalized pagination, got page %d size %d", repo.lastPage, repo.lastSize)
+	}
+}
@@ -0,0 +1,157 @@
+package storesettings
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface StoreSettingsService -out ../../internal/testing/servicemocks
+
+type StoreSettingsService interface {
+	// GetSettings returns the value of every setting, defaults filled in
+	GetSettings(ctx context.Context) (entity.StoreSettings, error)
+	// ListSettings describes every setting with its value, for admins
+	ListSettings(ctx context.Context) ([]*Setting, error)
+	// UpdateSettings sets the values by key; a nil value resets the setting
+	// to its default. Nothing is changed when any value is invalid.
+	UpdateSettings(ctx context.Context, values map[string]*string, updatedBy uuid.UUID) ([]*Setting, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+// Setting is a store setting with its current value. Set is false while it
+// takes its default.
+type Setting struct {
+	entity.SettingDefinition
+	Value     string
+	Set       bool
+	UpdatedBy *uuid.UUID
+	UpdatedAt *time.Time
+}
+
+type UseCase struct {
+	repo        repository.StoreSettingRepository
+	services    Services
+	definitions []entity.SettingDefinition
+}
+
+// NewUseCase creates the settings use case. defaults override the default
+// values of the settings, e.g. with the store name of the configuration.
+func NewUseCase(repo repository.StoreSettingRepository, services Services, defaults map[string]string) *UseCase {
+	definitions := make([]entity.SettingDefinition, len(entity.SettingDefinitions))
+	copy(definitions, entity.SettingDefinitions)
+	for i, definition := range definitions {
+		if value, ok := defaults[definition.Key]; ok && value != "" {
+			definitions[i].Default = value
+		}
+	}
+
+	return &UseCase{
+		repo:        repo,
+		services:    services,
+		definitions: definitions,
+	}
+}
+
+func (uc *UseCase) GetSettings(ctx context.Context) (entity.StoreSettings, error) {
+	settings, err := uc.ListSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(entity.StoreSettings, len(settings))
+	for _, setting := range settings {
+		values[setting.Key] = setting.Value
+	}
+	return values, nil
+}
+
+func (uc *UseCase) ListSettings(ctx context.Context) ([]*Setting, error) {
+	stored, err := uc.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*entity.StoreSetting, len(stored))
+	for _, setting := range stored {
+		byKey[setting.Key] = setting
+	}
+
+	settings := make([]*Setting, 0, len(uc.definitions))
+	for _, definition := range uc.definitions {
+		setting := &Setting{SettingDefinition: definition, Value: definition.Default}
+		// Rows of settings no longer defined are ignored
+		if row, ok := byKey[definition.Key]; ok {
+			updatedAt := row.UpdatedAt
+			setting.Value = row.Value
+			setting.Set = true
+			setting.UpdatedBy = row.UpdatedBy
+			setting.UpdatedAt = &updatedAt
+		}
+		settings = append(settings, setting)
+	}
+	return settings, nil
+}
+
+func (uc *UseCase) UpdateSettings(ctx context.Context, values map[string]*string, updatedBy uuid.UUID) ([]*Setting, error) {
+	if len(values) == 0 {
+		return nil, entity.ValidationError("No settings to update")
+	}
+
+	current, err := uc.GetSettings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var saved []*entity.StoreSetting
+	var reset []string
+	before := make(map[string]string)
+	after := make(map[string]string)
+	for key, value := range values {
+		definition, ok := uc.definition(key)
+		if !ok {
+			return nil, entity.ValidationError("Unknown setting " + key)
+		}
+
+		if value == nil {
+			reset = append(reset, key)
+			before[key], after[key] = current[key], definition.Default
+			continue
+		}
+
+		normalized, err := definition.Normalize(*value)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, &entity.StoreSetting{Key: key, Value: normalized, UpdatedBy: &updatedBy, UpdatedAt: now})
+		before[key], after[key] = current[key], normalized
+	}
+
+	if err := uc.repo.Save(ctx, saved); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Delete(ctx, reset); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &updatedBy, "UPDATE", "StoreSettings", uuid.Nil, before, after)
+
+	return uc.ListSettings(ctx)
+}
+
+func (uc *UseCase) definition(key string) (entity.SettingDefinition, bool) {
+	for _, definition := range uc.definitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return entity.SettingDefinition{}, false
+}
@@ -0,0 +1,95 @@
+package storesettings
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUseCase() *UseCase {
+	return NewUseCase(memory.NewStoreSettingRepository(memory.NewStore()), &mockServices.MockServices{},
+		map[string]string{entity.SettingStoreName: "Configured Shop"})
+}
+
+func value(s string) *string {
+	return &s
+}
+
+func TestGetSettings_Defaults(t *testing.T) {
+	settings, err := newUseCase().GetSettings(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, "Configured Shop", settings.StoreName())
+	assert.Equal(t, "USD", settings.Currency())
+	assert.False(t, settings.RequireTerms())
+	assert.Empty(t, settings.ContactEmail())
+}
+
+func TestUpdateSettings(t *testing.T) {
+	uc := newUseCase()
+	ctx := context.Background()
+	adminID := uuid.New()
+
+	updated, err := uc.UpdateSettings(ctx, map[string]*string{
+		entity.SettingStoreCurrency:        value("eur"),
+		entity.SettingCheckoutRequireTerms: value("true"),
+		entity.SettingStoreContactEmail:    value("help@example.com"),
+	}, adminID)
+	require.NoError(t, err)
+	require.Len(t, updated, len(entity.SettingDefinitions))
+
+	settings, err := uc.GetSettings(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", settings.Currency())
+	assert.True(t, settings.RequireTerms())
+	assert.Equal(t, "help@example.com", settings.ContactEmail())
+
+	for _, setting := range updated {
+		if setting.Key == entity.SettingStoreCurrency {
+			assert.True(t, setting.Set)
+			assert.Equal(t, "USD", setting.Default)
+			assert.Equal(t, &adminID, setting.UpdatedBy)
+			assert.NotNil(t, setting.UpdatedAt)
+		}
+		if setting.Key == entity.SettingStoreName {
+			assert.False(t, setting.Set)
+		}
+	}
+
+	t.Run("resets to the default", func(t *testing.T) {
+		_, err := uc.UpdateSettings(ctx, map[string]*string{entity.SettingStoreCurrency: nil}, adminID)
+		require.NoError(t, err)
+
+		settings, _ := uc.GetSettings(ctx)
+		assert.Equal(t, "USD", settings.Currency())
+		assert.Equal(t, "help@example.com", settings.ContactEmail())
+	})
+
+	t.Run("changes nothing when a value is invalid", func(t *testing.T) {
+		_, err := uc.UpdateSettings(ctx, map[string]*string{
+			entity.SettingStoreName:    value("Renamed"),
+			entity.SettingStoreLogoURL: value("not a url"),
+		}, adminID)
+		assert.True(t, errors.Is(err, entity.ErrValidation))
+
+		settings, _ := uc.GetSettings(ctx)
+		assert.Equal(t, "Configured Shop", settings.StoreName())
+	})
+
+	t.Run("rejects unknown settings", func(t *testing.T) {
+		_, err := uc.UpdateSettings(ctx, map[string]*string{"store.theme": value("dark")}, adminID)
+		assert.ErrorContains(t, err, "Unknown setting store.theme")
+	})
+
+	t.Run("rejects emptying a setting with a default", func(t *testing.T) {
+		_, err := uc.UpdateSettings(ctx, map[string]*string{entity.SettingStoreName: value(" ")}, adminID)
+		assert.True(t, errors.Is(err, entity.ErrValidation))
+	})
+}
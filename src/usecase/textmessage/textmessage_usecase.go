@@ -0,0 +1,71 @@
+package textmessage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/sms"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Messenger -out ../../internal/testing/servicemocks -name TextMessenger
+
+// Messenger sends customers the text messages of the events the deployment
+// enabled in SMS_EVENTS
+type Messenger interface {
+	// SendPhoneCode sends a verification code to a phone; it is a conflict
+	// when the deployment doesn't verify phones by text message
+	SendPhoneCode(ctx context.Context, phone, code string) error
+	// OrderShipped tells the customer of the order that it shipped, when the
+	// customer has a verified phone
+	OrderShipped(ctx context.Context, order *entity.Order) error
+}
+
+type UseCase struct {
+	customerRepo repository.CustomerRepository
+	channel      *sms.Channel
+}
+
+func NewUseCase(customerRepo repository.CustomerRepository, channel *sms.Channel) *UseCase {
+	return &UseCase{
+		customerRepo: customerRepo,
+		channel:      channel,
+	}
+}
+
+func (uc *UseCase) SendPhoneCode(ctx context.Context, phone, code string) error {
+	if !uc.channel.Enabled(sms.EventPhoneVerification) {
+		return entity.ConflictError("Phone verification by text message is not available")
+	}
+	_, err := uc.channel.Send(ctx, sms.EventPhoneVerification, sms.Message{
+		To:   phone,
+		Body: fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(entity.PhoneCodeTTL.Minutes())),
+	})
+	return err
+}
+
+// OrderShipped skips guest orders and customers without a verified phone,
+// they are told by email only
+func (uc *UseCase) OrderShipped(ctx context.Context, order *entity.Order) error {
+	if order.UserID == nil || !uc.channel.Enabled(sms.EventOrderShipped) {
+		return nil
+	}
+	customer, err := uc.customerRepo.Get(ctx, *order.UserID)
+	if errors.Is(err, entity.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !customer.PhoneVerified() {
+		return nil
+	}
+
+	_, err = uc.channel.Send(ctx, sms.EventOrderShipped, sms.Message{
+		To:   customer.Phone,
+		Body: fmt.Sprintf("Your order %s has shipped.", order.ID),
+	})
+	return err
+}
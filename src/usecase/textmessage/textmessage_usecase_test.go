@@ -0,0 +1,66 @@
+package textmessage
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/sms"
+	"github.com/marcofilho/go-ecommerce/src/internal/testing/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderShipped(t *testing.T) {
+	ctx := context.Background()
+	verifiedAt := time.Now()
+	verified := &entity.Customer{UserID: uuid.New(), Phone: "+5511987654321", PhoneVerifiedAt: &verifiedAt}
+	unverified := &entity.Customer{UserID: uuid.New(), Phone: "+5511900000000"}
+
+	customers := new(mocks.CustomerRepository)
+	customers.On("Get", ctx, verified.UserID).Return(verified, nil)
+	customers.On("Get", ctx, unverified.UserID).Return(unverified, nil)
+	sender := new(mocks.SMSSender)
+	sender.On("Send", ctx, mock.MatchedBy(func(msg sms.Message) bool {
+		return msg.To == verified.Phone && strings.Contains(msg.Body, "has shipped")
+	})).Return(nil).Once()
+
+	uc := NewUseCase(customers, sms.NewChannel(sender, sms.Events))
+
+	require.NoError(t, uc.OrderShipped(ctx, &entity.Order{ID: uuid.New(), UserID: &verified.UserID}))
+	require.NoError(t, uc.OrderShipped(ctx, &entity.Order{ID: uuid.New(), UserID: &unverified.UserID}))
+	require.NoError(t, uc.OrderShipped(ctx, &entity.Order{ID: uuid.New()}))
+	sender.AssertExpectations(t)
+}
+
+func TestOrderShipped_Disabled(t *testing.T) {
+	customers := new(mocks.CustomerRepository)
+	sender := new(mocks.SMSSender)
+	uc := NewUseCase(customers, sms.NewChannel(sender, []string{sms.EventPhoneVerification}))
+
+	userID := uuid.New()
+	require.NoError(t, uc.OrderShipped(context.Background(), &entity.Order{ID: uuid.New(), UserID: &userID}))
+	customers.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+}
+
+func TestSendPhoneCode(t *testing.T) {
+	ctx := context.Background()
+	sender := new(mocks.SMSSender)
+	sender.On("Send", ctx, mock.MatchedBy(func(msg sms.Message) bool {
+		return msg.To == "+5511987654321" && strings.Contains(msg.Body, "123456")
+	})).Return(nil).Once()
+
+	uc := NewUseCase(new(mocks.CustomerRepository), sms.NewChannel(sender, sms.Events))
+	require.NoError(t, uc.SendPhoneCode(ctx, "+5511987654321", "123456"))
+	sender.AssertExpectations(t)
+
+	disabled := NewUseCase(new(mocks.CustomerRepository), sms.NewChannel(sender, []string{sms.EventOrderShipped}))
+	err := disabled.SendPhoneCode(ctx, "+5511987654321", "123456")
+	assert.True(t, errors.Is(err, entity.ErrConflict), "expected a conflict, got %v", err)
+}
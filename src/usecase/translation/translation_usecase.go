@@ -0,0 +1,154 @@
+package translation
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+)
+
+//go:generate go run ../../internal/tools/mockgen -interface Localizer -out ../../internal/testing/servicemocks
+
+// Localizer puts the translations callers ask for on products
+type Localizer interface {
+	// Localize sets the translation of each product to the first of the
+	// preferred locales, or their parent languages, it has one for. Products
+	// keep their own content when none is found or the default locale comes first.
+	Localize(ctx context.Context, preferred []string, products ...*entity.Product) error
+	// DefaultLocale is the language products are written in
+	DefaultLocale() string
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface TranslationService -out ../../internal/testing/servicemocks
+
+type TranslationService interface {
+	Localizer
+	// SaveTranslation creates or replaces the translation of a product in a locale
+	SaveTranslation(ctx context.Context, productID uuid.UUID, locale, name, description string) (*entity.ProductTranslation, error)
+	ListTranslations(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error)
+	DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	repo          repository.ProductTranslationRepository
+	productRepo   repository.ProductRepository
+	services      Services
+	defaultLocale string
+}
+
+func NewUseCase(repo repository.ProductTranslationRepository, productRepo repository.ProductRepository, services Services, defaultLocale string) *UseCase {
+	return &UseCase{
+		repo:          repo,
+		productRepo:   productRepo,
+		services:      services,
+		defaultLocale: defaultLocale,
+	}
+}
+
+func (uc *UseCase) DefaultLocale() string {
+	return uc.defaultLocale
+}
+
+func (uc *UseCase) Localize(ctx context.Context, preferred []string, products ...*entity.Product) error {
+	fallbacks := entity.LocaleFallbacks(preferred)
+	if len(products) == 0 || len(fallbacks) == 0 || fallbacks[0] == uc.defaultLocale {
+		for _, product := range products {
+			product.Translation = nil
+		}
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	translations, err := uc.repo.ListForProducts(ctx, ids, fallbacks)
+	if err != nil {
+		return err
+	}
+
+	for _, product := range products {
+		product.Localize(translations, fallbacks, uc.defaultLocale)
+	}
+	return nil
+}
+
+func (uc *UseCase) SaveTranslation(ctx context.Context, productID uuid.UUID, locale, name, description string) (*entity.ProductTranslation, error) {
+	locale, err := uc.locale(locale)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	translation := &entity.ProductTranslation{
+		ProductID:   productID,
+		Locale:      locale,
+		Name:        strings.TrimSpace(name),
+		Description: description,
+		UpdatedAt:   time.Now(),
+	}
+	if err := translation.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A first translation has no previous version to audit
+	original, _ := uc.repo.Get(ctx, productID, locale)
+	if err := uc.repo.Save(ctx, translation); err != nil {
+		return nil, err
+	}
+
+	saved, err := uc.repo.Get(ctx, productID, locale)
+	if err != nil {
+		return nil, err
+	}
+	uc.services.GetAuditService().LogChange(ctx, nil, "TRANSLATE", "Product", productID, original, saved)
+	return saved, nil
+}
+
+func (uc *UseCase) ListTranslations(ctx context.Context, productID uuid.UUID) ([]*entity.ProductTranslation, error) {
+	if _, err := uc.productRepo.GetByID(ctx, productID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListByProduct(ctx, productID)
+}
+
+func (uc *UseCase) DeleteTranslation(ctx context.Context, productID uuid.UUID, locale string) error {
+	locale, err := uc.locale(locale)
+	if err != nil {
+		return err
+	}
+
+	original, err := uc.repo.Get(ctx, productID, locale)
+	if err != nil {
+		return err
+	}
+	if err := uc.repo.Delete(ctx, productID, locale); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE_TRANSLATION", "Product", productID, original, nil)
+	return nil
+}
+
+// locale normalizes a locale a translation is stored under. The default
+// locale has none, it is the product itself.
+func (uc *UseCase) locale(tag string) (string, error) {
+	locale, err := entity.NormalizeLocale(tag)
+	if err != nil {
+		return "", err
+	}
+	if locale == uc.defaultLocale {
+		return "", entity.ValidationError("Products are written in " + uc.defaultLocale + ", update the product itself instead")
+	}
+	return locale, nil
+}
@@ -0,0 +1,106 @@
+package translation
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+)
+
+// testServices stands in for the mocks package, which imports this one
+type testServices struct {
+	auditService audit.AuditService
+}
+
+func (s *testServices) GetAuditService() audit.AuditService {
+	return s.auditService
+}
+
+func newTestUseCase(t *testing.T) (*UseCase, *entity.Product) {
+	t.Helper()
+	store := memory.NewStore()
+	products := memory.NewProductRepository(store)
+	product := &entity.Product{ID: uuid.New(), Name: "Shirt", Description: "Cotton shirt", Price: 10}
+	if err := products.Create(context.Background(), product); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	services := &testServices{auditService: audit.NewAuditService(memory.NewAuditLogRepository(store), nil)}
+	return NewUseCase(memory.NewProductTranslationRepository(store), products, services, "en"), product
+}
+
+func TestSaveTranslation(t *testing.T) {
+	uc, product := newTestUseCase(t)
+	ctx := context.Background()
+
+	saved, err := uc.SaveTranslation(ctx, product.ID, "pt_br", " Camisa ", "")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if saved.Locale != "pt-BR" || saved.Name != "Camisa" {
+		t.Errorf("unexpected translation %+v", saved)
+	}
+
+	if _, err := uc.SaveTranslation(ctx, product.ID, "pt-BR", "Camiseta", "Camiseta de algodão"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	translations, _ := uc.ListTranslations(ctx, product.ID)
+	if len(translations) != 1 || translations[0].Name != "Camiseta" {
+		t.Errorf("expected the translation to be replaced, got %+v", translations)
+	}
+}
+
+func TestSaveTranslation_Invalid(t *testing.T) {
+	uc, product := newTestUseCase(t)
+	ctx := context.Background()
+
+	if _, err := uc.SaveTranslation(ctx, product.ID, "en", "Shirt", ""); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error for the default locale, got %v", err)
+	}
+	if _, err := uc.SaveTranslation(ctx, product.ID, "not a locale", "Camisa", ""); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error for an invalid locale, got %v", err)
+	}
+	if _, err := uc.SaveTranslation(ctx, product.ID, "es", " ", ""); !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a validation error for a blank name, got %v", err)
+	}
+
+	if _, err := uc.SaveTranslation(ctx, uuid.New(), "es", "Camisa", ""); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected a not found error for an unknown product, got %v", err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	uc, product := newTestUseCase(t)
+	ctx := context.Background()
+	uc.SaveTranslation(ctx, product.ID, "pt", "Camisa", "")
+
+	if err := uc.Localize(ctx, []string{"pt-BR", "en"}, product); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if product.Translation == nil || product.Translation.Locale != "pt" {
+		t.Errorf("expected the pt translation, got %+v", product.Translation)
+	}
+
+	if err := uc.Localize(ctx, []string{"en", "pt"}, product); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if product.Translation != nil {
+		t.Errorf("expected no translation when the default locale is preferred, got %+v", product.Translation)
+	}
+}
+
+func TestDeleteTranslation(t *testing.T) {
+	uc, product := newTestUseCase(t)
+	ctx := context.Background()
+	uc.SaveTranslation(ctx, product.ID, "es", "Camisa", "")
+
+	if err := uc.DeleteTranslation(ctx, product.ID, "es"); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := uc.DeleteTranslation(ctx, product.ID, "es"); !errors.Is(err, entity.ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
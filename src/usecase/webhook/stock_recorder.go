@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/usecase/stock"
+)
+
+type stockRecorder struct {
+	next       stock.Recorder
+	dispatcher events.Dispatcher
+}
+
+// NewStockRecorder records stock movements with next and dispatches a
+// stock.changed event for each one recorded. Every stock change goes through
+// the recorder, so subscribers hear of orders, returns and imports alike.
+func NewStockRecorder(next stock.Recorder, dispatcher events.Dispatcher) stock.Recorder {
+	return &stockRecorder{next: next, dispatcher: dispatcher}
+}
+
+func (r *stockRecorder) Record(ctx context.Context, movement *entity.StockMovement) error {
+	if err := r.next.Record(ctx, movement); err != nil {
+		return err
+	}
+	if movement.Delta != 0 {
+		r.dispatcher.Dispatch(ctx, events.StockChanged, events.NewStockData(movement))
+	}
+	return nil
+}
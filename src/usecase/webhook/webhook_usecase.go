@@ -0,0 +1,287 @@
+package webhook
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/audit"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+)
+
+var (
+	ErrSubscriptionNotFound = entity.NotFoundError("Webhook subscription not found")
+	ErrDeliveryNotFound     = entity.NotFoundError("Webhook delivery not found")
+)
+
+// Update changes the fields of a subscription that are set
+type Update struct {
+	URL         *string
+	Secret      *string
+	Events      []string
+	Description *string
+	Active      *bool
+}
+
+//go:generate go run ../../internal/tools/mockgen -interface WebhookService -out ../../internal/testing/servicemocks
+
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription, createdBy uuid.UUID) (*entity.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id uuid.UUID, update Update) (*entity.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page, pageSize int) ([]*entity.WebhookDelivery, int, error)
+	// RetryDelivery sends a failed delivery again on the next run of the delivery job
+	RetryDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error)
+}
+
+type Services interface {
+	GetAuditService() audit.AuditService
+}
+
+type UseCase struct {
+	repo     repository.WebhookSubscriptionRepository
+	sender   events.Sender
+	services Services
+	now      func() time.Time
+}
+
+func NewUseCase(repo repository.WebhookSubscriptionRepository, sender events.Sender, services Services) *UseCase {
+	return &UseCase{
+		repo:     repo,
+		sender:   sender,
+		services: services,
+		now:      time.Now,
+	}
+}
+
+// CreateSubscription stores an active subscription. Without a secret one is
+// generated; the caller gets it back this once, it isn't shown again.
+func (uc *UseCase) CreateSubscription(ctx context.Context, subscription *entity.WebhookSubscription, createdBy uuid.UUID) (*entity.WebhookSubscription, error) {
+	if subscription.Secret == "" {
+		secret, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		subscription.Secret = secret
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	subscription.ID = entity.NewID()
+	subscription.Active = true
+	subscription.CreatedBy = &createdBy
+	if err := uc.repo.Create(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, &createdBy, "CREATE", "WebhookSubscription", subscription.ID, nil, redact(subscription))
+
+	return subscription, nil
+}
+
+func (uc *UseCase) GetSubscription(ctx context.Context, id uuid.UUID) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (uc *UseCase) ListSubscriptions(ctx context.Context) ([]*entity.WebhookSubscription, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *UseCase) UpdateSubscription(ctx context.Context, id uuid.UUID, update Update) (*entity.WebhookSubscription, error) {
+	subscription, err := uc.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	original := redact(subscription)
+
+	if update.URL != nil {
+		subscription.URL = *update.URL
+	}
+	if update.Secret != nil {
+		subscription.Secret = *update.Secret
+	}
+	if update.Events != nil {
+		subscription.Events = update.Events
+	}
+	if update.Description != nil {
+		subscription.Description = *update.Description
+	}
+	if update.Active != nil {
+		subscription.Active = *update.Active
+	}
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "UPDATE", "WebhookSubscription", subscription.ID, original, redact(subscription))
+
+	return subscription, nil
+}
+
+// DeleteSubscription removes the subscription with its delivery log, pending
+// deliveries are dropped
+func (uc *UseCase) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	subscription, err := uc.GetSubscription(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "DELETE", "WebhookSubscription", id, redact(subscription), nil)
+
+	return nil
+}
+
+func (uc *UseCase) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, filters repository.WebhookDeliveryFilters, page, pageSize int) ([]*entity.WebhookDelivery, int, error) {
+	if _, err := uc.GetSubscription(ctx, subscriptionID); err != nil {
+		return nil, 0, err
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 10
+	}
+
+	return uc.repo.ListDeliveries(ctx, subscriptionID, filters, page, pageSize)
+}
+
+func (uc *UseCase) RetryDelivery(ctx context.Context, subscriptionID, deliveryID uuid.UUID) (*entity.WebhookDelivery, error) {
+	delivery, err := uc.repo.GetDelivery(ctx, deliveryID)
+	if err != nil || delivery.SubscriptionID != subscriptionID {
+		return nil, ErrDeliveryNotFound
+	}
+
+	if err := delivery.Retry(uc.now()); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	uc.services.GetAuditService().LogChange(ctx, nil, "RETRY_DELIVERY", "WebhookSubscription", subscriptionID, nil,
+		map[string]interface{}{"delivery_id": delivery.ID, "event_type": delivery.EventType})
+
+	return delivery, nil
+}
+
+// Dispatch queues a delivery of the event to every active subscription that
+// wants it. Every subscription gets the same payload and event ID; the
+// delivery job sends them.
+func (uc *UseCase) Dispatch(ctx context.Context, eventType string, data interface{}) error {
+	subscriptions, err := uc.repo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+
+	var matching []*entity.WebhookSubscription
+	for _, subscription := range subscriptions {
+		if subscription.Matches(eventType) {
+			matching = append(matching, subscription)
+		}
+	}
+	if len(matching) == 0 {
+		return nil
+	}
+
+	now := uc.now()
+	eventID := entity.NewID()
+	payload, err := json.Marshal(events.Envelope{
+		ID:         eventID.String(),
+		Type:       eventType,
+		OccurredAt: now.UTC().Format(time.RFC3339),
+		Data:       data,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, subscription := range matching {
+		delivery := &entity.WebhookDelivery{
+			ID:             entity.NewID(),
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      eventType,
+			Payload:        string(payload),
+			Status:         entity.DeliveryPending,
+			NextAttemptAt:  &now,
+			CreatedAt:      now,
+		}
+		if err := uc.repo.CreateDelivery(ctx, delivery); err != nil {
+			return fmt.Errorf("Failed to queue %s delivery: %w", eventType, err)
+		}
+	}
+	return nil
+}
+
+// DeliverDue sends up to limit deliveries whose attempt is due and returns
+// how many the subscribers accepted. Failed attempts are retried with a
+// backoff until the delivery runs out of attempts.
+func (uc *UseCase) DeliverDue(ctx context.Context, limit int) (int, error) {
+	deliveries, err := uc.repo.ListDueDeliveries(ctx, uc.now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	subscriptions := make(map[uuid.UUID]*entity.WebhookSubscription)
+	delivered := 0
+	for _, delivery := range deliveries {
+		subscription, ok := subscriptions[delivery.SubscriptionID]
+		if !ok {
+			subscription, err = uc.repo.GetByID(ctx, delivery.SubscriptionID)
+			if err != nil {
+				continue
+			}
+			subscriptions[delivery.SubscriptionID] = subscription
+		}
+
+		status, err := uc.sender.Send(ctx, subscription.URL, subscription.Secret, delivery.EventID.String(), delivery.EventType, []byte(delivery.Payload))
+		if err != nil {
+			delivery.Fail(status, err.Error(), uc.now())
+		} else {
+			delivery.Succeed(status, uc.now())
+			delivered++
+		}
+		if err := uc.repo.UpdateDelivery(ctx, delivery); err != nil {
+			fmt.Printf("Failed to update webhook delivery %s: %v\n", delivery.ID, err)
+		}
+	}
+	return delivered, nil
+}
+
+// redact copies a subscription without its secret, for the audit log
+func redact(subscription *entity.WebhookSubscription) entity.WebhookSubscription {
+	row := *subscription
+	row.Secret = ""
+	return row
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/entity"
+	"github.com/marcofilho/go-ecommerce/src/internal/domain/repository"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/events"
+	"github.com/marcofilho/go-ecommerce/src/internal/infrastructure/repository/memory"
+	mockServices "github.com/marcofilho/go-ecommerce/src/internal/testing"
+)
+
+type sentEvent struct {
+	url, secret, eventID, eventType string
+	body                            []byte
+}
+
+// stubSender records what it sends and answers with status
+type stubSender struct {
+	status int
+	sent   []sentEvent
+}
+
+func (s *stubSender) Send(ctx context.Context, url, secret, eventID, eventType string, body []byte) (int, error) {
+	s.sent = append(s.sent, sentEvent{url, secret, eventID, eventType, body})
+	if s.status < 200 || s.status >= 300 {
+		return s.status, fmt.Errorf("subscriber responded with status %d", s.status)
+	}
+	return s.status, nil
+}
+
+func newUseCase(sender *stubSender) (*UseCase, repository.WebhookSubscriptionRepository) {
+	repo := memory.NewWebhookSubscriptionRepository(memory.NewStore())
+	return NewUseCase(repo, sender, &mockServices.MockServices{}), repo
+}
+
+func subscribe(t *testing.T, uc *UseCase, url string, eventTypes ...string) *entity.WebhookSubscription {
+	t.Helper()
+	subscription, err := uc.CreateSubscription(context.Background(), &entity.WebhookSubscription{URL: url, Events: eventTypes}, uuid.New())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	return subscription
+}
+
+func TestCreateSubscription_GeneratesSecret(t *testing.T) {
+	uc, _ := newUseCase(&stubSender{})
+
+	subscription := subscribe(t, uc, "https://erp.example.com/hooks", "order.*")
+	if len(subscription.Secret) < entity.MinWebhookSecretLength || !subscription.Active {
+		t.Errorf("expected an active subscription with a generated secret, got %+v", subscription)
+	}
+
+	_, err := uc.CreateSubscription(context.Background(), &entity.WebhookSubscription{URL: "https://erp.example.com/hooks", Secret: "short", Events: []string{"*"}}, uuid.New())
+	if !errors.Is(err, entity.ErrValidation) {
+		t.Errorf("expected a short secret to be rejected, got %v", err)
+	}
+}
+
+func TestDispatch_QueuesMatchingSubscriptions(t *testing.T) {
+	sender := &stubSender{status: 204}
+	uc, repo := newUseCase(sender)
+	orders := subscribe(t, uc, "https://erp.example.com/orders", "order.*")
+	stock := subscribe(t, uc, "https://wms.example.com/stock", events.StockChanged)
+	paused := subscribe(t, uc, "https://old.example.com/all", "*")
+	active := false
+	uc.UpdateSubscription(context.Background(), paused.ID, Update{Active: &active})
+
+	if err := uc.Dispatch(context.Background(), events.OrderCreated, map[string]string{"id": "order-1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	delivered, err := uc.DeliverDue(context.Background(), 10)
+	if err != nil || delivered != 1 {
+		t.Fatalf("expected 1 delivery, got %d, %v", delivered, err)
+	}
+	sent := sender.sent[0]
+	if sent.url != orders.URL || sent.secret != orders.Secret || sent.eventType != events.OrderCreated {
+		t.Errorf("expected the event to go to the order subscription, got %+v", sent)
+	}
+
+	var envelope events.Envelope
+	if err := json.Unmarshal(sent.body, &envelope); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if envelope.ID != sent.eventID || envelope.Type != events.OrderCreated {
+		t.Errorf("expected the payload to carry the event ID and type, got %+v", envelope)
+	}
+
+	for _, subscription := range []*entity.WebhookSubscription{stock, paused} {
+		if _, total, _ := repo.ListDeliveries(context.Background(), subscription.ID, repository.WebhookDeliveryFilters{}, 1, 10); total != 0 {
+			t.Errorf("expected no deliveries for %s, got %d", subscription.URL, total)
+		}
+	}
+	logged, _, _ := uc.ListDeliveries(context.Background(), orders.ID, repository.WebhookDeliveryFilters{}, 1, 10)
+	if len(logged) != 1 || logged[0].Status != entity.DeliverySucceeded || logged[0].ResponseStatus != 204 {
+		t.Errorf("expected a succeeded delivery in the log, got %+v", logged)
+	}
+}
+
+func TestDeliverDue_RetriesWithBackoff(t *testing.T) {
+	sender := &stubSender{status: 503}
+	uc, _ := newUseCase(sender)
+	now := time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	subscription := subscribe(t, uc, "https://erp.example.com/hooks", "*")
+	uc.Dispatch(context.Background(), events.StockChanged, nil)
+
+	if delivered, _ := uc.DeliverDue(context.Background(), 10); delivered != 0 {
+		t.Fatalf("expected the delivery to fail, got %d delivered", delivered)
+	}
+	// Not due again until the backoff passes
+	uc.DeliverDue(context.Background(), 10)
+	if len(sender.sent) != 1 {
+		t.Fatalf("expected 1 attempt before the backoff, got %d", len(sender.sent))
+	}
+
+	pending := entity.DeliveryPending
+	deliveries, _, _ := uc.ListDeliveries(context.Background(), subscription.ID, repository.WebhookDeliveryFilters{Status: &pending}, 1, 10)
+	if len(deliveries) != 1 || deliveries[0].Attempts != 1 || deliveries[0].LastError == "" {
+		t.Fatalf("expected a pending delivery with the failed attempt, got %+v", deliveries)
+	}
+
+	sender.status = 200
+	now = now.Add(30 * time.Second)
+	if delivered, _ := uc.DeliverDue(context.Background(), 10); delivered != 1 {
+		t.Errorf("expected the retry to be delivered, got %d", delivered)
+	}
+}
+
+func TestRetryDelivery(t *testing.T) {
+	uc, repo := newUseCase(&stubSender{status: 500})
+	subscription := subscribe(t, uc, "https://erp.example.com/hooks", "*")
+	uc.Dispatch(context.Background(), events.OrderPaid, nil)
+
+	deliveries, _, _ := uc.ListDeliveries(context.Background(), subscription.ID, repository.WebhookDeliveryFilters{}, 1, 10)
+	delivery := deliveries[0]
+	if _, err := uc.RetryDelivery(context.Background(), subscription.ID, delivery.ID); !errors.Is(err, entity.ErrConflict) {
+		t.Errorf("expected a pending delivery not to be retried, got %v", err)
+	}
+
+	delivery.Status = entity.DeliveryFailed
+	delivery.Attempts = entity.MaxWebhookDeliveryAttempts
+	delivery.NextAttemptAt = nil
+	repo.UpdateDelivery(context.Background(), delivery)
+
+	if _, err := uc.RetryDelivery(context.Background(), uuid.New(), delivery.ID); !errors.Is(err, ErrDeliveryNotFound) {
+		t.Errorf("expected the delivery of another subscription not to be found, got %v", err)
+	}
+	retried, err := uc.RetryDelivery(context.Background(), subscription.ID, delivery.ID)
+	if err != nil || retried.Status != entity.DeliveryPending || retried.Attempts != 0 {
+		t.Errorf("expected the delivery to be pending again, got %+v, %v", retried, err)
+	}
+}
+
+func TestStockRecorder_DispatchesRecordedMovements(t *testing.T) {
+	recorder := &mockServices.MockStockRecorder{}
+	dispatcher := &mockServices.MockWebhookDispatcher{}
+	stockRecorder := NewStockRecorder(recorder, dispatcher)
+
+	stockRecorder.Record(context.Background(), entity.NewStockMovement(uuid.New(), nil, entity.StockOrder, 5, 3, "order-1"))
+	stockRecorder.Record(context.Background(), entity.NewStockMovement(uuid.New(), nil, entity.StockAdjustment, 5, 5, "no change"))
+
+	if len(recorder.Movements) != 2 {
+		t.Errorf("expected both movements to reach the ledger, got %d", len(recorder.Movements))
+	}
+	if len(dispatcher.Events) != 1 || dispatcher.Events[0] != events.StockChanged {
+		t.Errorf("expected one stock.changed event, got %v", dispatcher.Events)
+	}
+}
@@ -47,7 +47,7 @@ ADMIN_REGISTER_RESPONSE=$(curl -s -X POST ${API_URL}/api/auth/register \
   }")
 
 # Promote user to admin role in database
-docker exec ecommerce_postgres psql -U postgres -d ecommerce -c "UPDATE users SET role = 'admin' WHERE email = '$ADMIN_EMAIL';" > /dev/null 2>&1
+./scripts/db_exec.sh "UPDATE users SET role = 'admin' WHERE email = '$ADMIN_EMAIL';" > /dev/null 2>&1
 
 # Re-login to get fresh token with admin role
 ADMIN_LOGIN_RESPONSE=$(curl -s -X POST ${API_URL}/api/auth/login \
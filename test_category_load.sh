@@ -50,7 +50,7 @@ if [ -z "$ADMIN_TOKEN" ]; then
     
     if [ ! -z "$ADMIN_TOKEN" ]; then
         # Promote new user to admin
-        docker exec ecommerce_postgres psql -U postgres -d ecommerce -c "UPDATE users SET role = 'admin' WHERE email = 'loadtest_admin@example.com';" > /dev/null 2>&1
+        ./scripts/db_exec.sh "UPDATE users SET role = 'admin' WHERE email = 'loadtest_admin@example.com';" > /dev/null 2>&1
     fi
 fi
 
@@ -85,7 +85,7 @@ if [ -z "$ADMIN_TOKEN" ] || [ -z "$CUSTOMER_TOKEN" ]; then
 fi
 
 # If we just registered, ensure admin role is set and re-login to get fresh token
-docker exec ecommerce_postgres psql -U postgres -d ecommerce -c "UPDATE users SET role = 'admin' WHERE email = 'loadtest_admin@example.com';" > /dev/null 2>&1
+./scripts/db_exec.sh "UPDATE users SET role = 'admin' WHERE email = 'loadtest_admin@example.com';" > /dev/null 2>&1
 
 # Login again to get fresh token with admin role
 ADMIN_LOGIN=$(curl -s -X POST ${API_URL}/api/auth/login \
@@ -15,10 +15,17 @@ YELLOW='\033[1;33m'
 BLUE='\033[0;34m'
 NC='\033[0m' # No Color
 
-# Function to generate HMAC signature
+# Function to generate HMAC signature of a timestamp, nonce and payload
 generate_signature() {
-  local payload="$1"
-  echo -n "$payload" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //'
+  local timestamp="$1"
+  local nonce="$2"
+  local payload="$3"
+  printf '%s.%s.%s' "$timestamp" "$nonce" "$payload" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //'
+}
+
+# Function to generate a nonce, a new one for every request
+new_nonce() {
+  openssl rand -hex 16
 }
 
 # Function to get current timestamp
@@ -84,7 +91,7 @@ if [ -z "$ADMIN_TOKEN" ]; then
 fi
 
 # Promote to admin
-docker exec ecommerce_postgres psql -U postgres -d ecommerce -c "UPDATE users SET role = 'admin' WHERE email = 'webhook_admin@example.com';" > /dev/null 2>&1
+./scripts/db_exec.sh "UPDATE users SET role = 'admin' WHERE email = 'webhook_admin@example.com';" > /dev/null 2>&1
 
 # Re-login to get fresh token with admin role
 ADMIN_LOGIN=$(curl -s -X POST "$API_URL/api/auth/login" \
@@ -161,7 +168,7 @@ ORDER_ID=$(create_order)
 TIMESTAMP=$(get_timestamp)
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
-  -d "{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"txn_no_sig\",\"payment_status\":\"paid\"}")
+  -d "{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_no_sig\",\"payment_status\":\"paid\"}")
 assert_contains "$RESPONSE" "401" "HTTP 401 returned"
 assert_contains "$RESPONSE" "Missing payment signature" "Error message correct"
 
@@ -174,67 +181,81 @@ TIMESTAMP=$(get_timestamp)
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: invalid-signature-12345" \
-  -d "{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"txn_bad_sig\",\"payment_status\":\"paid\"}")
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $(new_nonce)" \
+  -d "{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_bad_sig\",\"payment_status\":\"paid\"}")
 assert_contains "$RESPONSE" "401" "HTTP 401 returned"
 assert_contains "$RESPONSE" "Invalid payment signature" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
 # TEST 3: Missing Transaction ID
 #═══════════════════════════════════════════════════════════════
-print_test "TEST 3: Webhook Without Transaction ID (Should Fail 400)"
+print_test "TEST 3: Webhook Without Transaction ID (Should Fail 422)"
 ORDER_ID=$(create_order)
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"payment_status\":\"paid\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
-assert_contains "$RESPONSE" "400" "HTTP 400 returned"
+assert_contains "$RESPONSE" "422" "HTTP 422 returned"
 assert_contains "$RESPONSE" "transaction_id is required" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
 # TEST 4: Invalid Order ID Format
 #═══════════════════════════════════════════════════════════════
-print_test "TEST 4: Webhook With Invalid Order ID (Should Fail 400)"
+print_test "TEST 4: Webhook With Invalid Order ID (Should Fail 422)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD='{"order_id":"not-a-valid-uuid","timestamp":'$TIMESTAMP',"transaction_id":"txn_bad_id","payment_status":"paid"}'
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD='{"order_id":"not-a-valid-uuid","transaction_id":"txn_bad_id","payment_status":"paid"}'
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
-assert_contains "$RESPONSE" "400" "HTTP 400 returned"
+assert_contains "$RESPONSE" "422" "HTTP 422 returned"
 assert_contains "$RESPONSE" "invalid order_id format" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
 # TEST 5: Non-Existent Order
 #═══════════════════════════════════════════════════════════════
-print_test "TEST 5: Webhook For Non-Existent Order (Should Fail 400)"
+print_test "TEST 5: Webhook For Non-Existent Order (Should Fail 404)"
 FAKE_ORDER_ID="00000000-0000-0000-0000-000000000000"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$FAKE_ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"txn_no_order\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$FAKE_ORDER_ID\",\"transaction_id\":\"txn_no_order\",\"payment_status\":\"paid\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
-assert_contains "$RESPONSE" "400" "HTTP 400 returned"
+assert_contains "$RESPONSE" "404" "HTTP 404 returned"
 assert_contains "$RESPONSE" "order not found" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
 # TEST 6: Invalid Payment Status
 #═══════════════════════════════════════════════════════════════
-print_test "TEST 6: Webhook With Invalid Payment Status (Should Fail 400)"
+print_test "TEST 6: Webhook With Invalid Payment Status (Should Fail 422)"
 ORDER_ID=$(create_order)
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"txn_bad_status\",\"payment_status\":\"processing\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_bad_status\",\"payment_status\":\"processing\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
-assert_contains "$RESPONSE" "400" "HTTP 400 returned"
+assert_contains "$RESPONSE" "422" "HTTP 422 returned"
 assert_contains "$RESPONSE" "payment_status must be either" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
@@ -244,11 +265,14 @@ print_test "TEST 7: Webhook With Successful Payment (Should Success 200)"
 ORDER_ID=$(create_order)
 TXN_ID="txn_success_$(date +%s)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
 assert_contains "$RESPONSE" "200" "HTTP 200 returned"
 assert_contains "$RESPONSE" "success" "Success response"
@@ -267,11 +291,14 @@ print_test "TEST 8: Webhook With Failed Payment (Should Success 200)"
 ORDER_ID=$(create_order)
 TXN_ID="txn_failed_$(date +%s)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"failed\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"failed\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
 assert_contains "$RESPONSE" "200" "HTTP 200 returned"
 
@@ -289,20 +316,36 @@ print_test "TEST 9: Idempotency - Duplicate Transaction (Should Success 200)"
 ORDER_ID=$(create_order)
 TXN_ID="txn_duplicate_$(date +%s)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 
 # First request
 RESPONSE1=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
 assert_contains "$RESPONSE1" "200" "First request succeeded"
 
-# Duplicate request with same transaction ID
+# The same request again is a replay of its nonce
+RESPONSE_REPLAY=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
+  -H "Content-Type: application/json" \
+  -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
+  -d "$PAYLOAD")
+assert_contains "$RESPONSE_REPLAY" "Nonce was already used" "Replayed request rejected"
+
+# Duplicate request with same transaction ID, signed again with a new nonce
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 RESPONSE2=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD")
 assert_contains "$RESPONSE2" "200" "Duplicate request also returns 200"
 
@@ -319,17 +362,20 @@ fi
 #═══════════════════════════════════════════════════════════════
 # TEST 10: Webhook on Already Completed Order
 #═══════════════════════════════════════════════════════════════
-print_test "TEST 10: Webhook On Already Completed Order (Should Fail 400)"
+print_test "TEST 10: Webhook On Already Completed Order (Should Fail 409)"
 ORDER_ID=$(create_order)
 
 # First, complete the order
 TXN_ID_1="txn_first_$(date +%s)"
 TIMESTAMP1=$(get_timestamp)
-PAYLOAD1="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP1,\"transaction_id\":\"$TXN_ID_1\",\"payment_status\":\"paid\"}"
-SIGNATURE1=$(generate_signature "$PAYLOAD1")
+PAYLOAD1="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID_1\",\"payment_status\":\"paid\"}"
+NONCE1=$(new_nonce)
+SIGNATURE1=$(generate_signature "$TIMESTAMP1" "$NONCE1" "$PAYLOAD1")
 curl -s -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE1" \
+  -H "X-Payment-Timestamp: $TIMESTAMP1" \
+  -H "X-Payment-Nonce: $NONCE1" \
   -d "$PAYLOAD1" > /dev/null
 
 sleep 1
@@ -337,13 +383,16 @@ sleep 1
 # Try to process another webhook on completed order
 TXN_ID_2="txn_second_$(date +%s)"
 TIMESTAMP2=$(get_timestamp)
-PAYLOAD2="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP2,\"transaction_id\":\"$TXN_ID_2\",\"payment_status\":\"paid\"}"
-SIGNATURE2=$(generate_signature "$PAYLOAD2")
+PAYLOAD2="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID_2\",\"payment_status\":\"paid\"}"
+NONCE2=$(new_nonce)
+SIGNATURE2=$(generate_signature "$TIMESTAMP2" "$NONCE2" "$PAYLOAD2")
 RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE2" \
+  -H "X-Payment-Timestamp: $TIMESTAMP2" \
+  -H "X-Payment-Nonce: $NONCE2" \
   -d "$PAYLOAD2")
-assert_contains "$RESPONSE" "400" "HTTP 400 returned"
+assert_contains "$RESPONSE" "409" "HTTP 409 returned"
 assert_contains "$RESPONSE" "order status must be 'pending'" "Error message correct"
 
 #═══════════════════════════════════════════════════════════════
@@ -356,11 +405,14 @@ ORDER_ID=$(create_order)
 for i in 1 2 3; do
   TXN_ID="txn_history_${i}_$(date +%s)"
   TIMESTAMP=$(get_timestamp)
-  PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"failed\"}"
-  SIGNATURE=$(generate_signature "$PAYLOAD")
+  PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"failed\"}"
+  NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
   curl -s -X POST "$API_URL/api/payment-webhook" \
     -H "Content-Type: application/json" \
     -H "X-Payment-Signature: $SIGNATURE" \
+    -H "X-Payment-Timestamp: $TIMESTAMP" \
+    -H "X-Payment-Nonce: $NONCE" \
     -d "$PAYLOAD" > /dev/null
   sleep 0.5
 done
@@ -368,11 +420,14 @@ done
 # Now send a successful payment
 TXN_ID_SUCCESS="txn_history_success_$(date +%s)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID_SUCCESS\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID_SUCCESS\",\"payment_status\":\"paid\"}"
+NONCE=$(new_nonce)
+SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
 curl -s -X POST "$API_URL/api/payment-webhook" \
   -H "Content-Type: application/json" \
   -H "X-Payment-Signature: $SIGNATURE" \
+  -H "X-Payment-Timestamp: $TIMESTAMP" \
+  -H "X-Payment-Nonce: $NONCE" \
   -d "$PAYLOAD" > /dev/null
 
 sleep 1
@@ -397,14 +452,17 @@ print_test "TEST 12: Concurrent Webhooks For Same Order"
 ORDER_ID=$(create_order)
 TXN_ID="txn_concurrent_$(date +%s)"
 TIMESTAMP=$(get_timestamp)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$TIMESTAMP,\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(generate_signature "$PAYLOAD")
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"$TXN_ID\",\"payment_status\":\"paid\"}"
 
-# Send 3 concurrent requests with same transaction ID
+# Send 3 concurrent requests with same transaction ID, each with its own nonce
 for i in 1 2 3; do
+  NONCE=$(new_nonce)
+  SIGNATURE=$(generate_signature "$TIMESTAMP" "$NONCE" "$PAYLOAD")
   curl -s -X POST "$API_URL/api/payment-webhook" \
     -H "Content-Type: application/json" \
     -H "X-Payment-Signature: $SIGNATURE" \
+    -H "X-Payment-Timestamp: $TIMESTAMP" \
+    -H "X-Payment-Nonce: $NONCE" \
     -d "$PAYLOAD" > /tmp/webhook_response_$i.txt &
 done
 
@@ -16,8 +16,14 @@ BLUE='\033[0;34m'
 NC='\033[0m'
 
 generate_signature() {
-  local payload="$1"
-  echo -n "$payload" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //'
+  local timestamp="$1"
+  local nonce="$2"
+  local payload="$3"
+  printf '%s.%s.%s' "$timestamp" "$nonce" "$payload" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //'
+}
+
+new_nonce() {
+  openssl rand -hex 16
 }
 
 get_timestamp() {
@@ -49,7 +55,7 @@ assert_contains() {
 
 echo -e "${BLUE}╔════════════════════════════════════════════════════════╗${NC}"
 echo -e "${BLUE}║  Payment Webhook Enhanced Security Test Suite         ║${NC}"
-echo -e "${BLUE}║  Testing Signature & Timestamp Validation             ║${NC}"
+echo -e "${BLUE}║  Testing Signature, Timestamp & Nonce Validation      ║${NC}"
 echo -e "${BLUE}╚════════════════════════════════════════════════════════╝${NC}"
 
 # Setup admin and customer users (same as original script)
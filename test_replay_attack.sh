@@ -9,30 +9,42 @@ CUSTOMER_TOKEN=$(curl -s -X POST "$API_URL/api/auth/login" \
   -d '{"email":"customer@example.com","password":"password123"}' | grep -o '"token":"[^"]*' | cut -d'"' -f4)
 
 # Get product ID (from seeded data)
-PRODUCT_ID=$(curl -s "$API_URL/api/products" | grep -o '"ID":"[^"]*' | head -1 | cut -d'"' -f4)
+PRODUCT_ID=$(curl -s "$API_URL/api/products" | grep -o '"id":"[^"]*' | head -1 | cut -d'"' -f4)
 
 # Create an order
 ORDER_ID=$(curl -s -X POST "$API_URL/api/orders" \
   -H "Authorization: Bearer $CUSTOMER_TOKEN" \
   -H "Content-Type: application/json" \
-  -d "{\"items\":[{\"product_id\":\"$PRODUCT_ID\",\"quantity\":1}]}" | grep -o '"ID":"[^"]*' | head -1 | cut -d'"' -f4)
+  -d "{\"customer_id\":1,\"products\":[{\"product_id\":\"$PRODUCT_ID\",\"quantity\":1}]}" | grep -o '"id":"[^"]*' | head -1 | cut -d'"' -f4)
 
 echo "Created order: $ORDER_ID"
 
+# Signs PAYLOAD with TIMESTAMP and NONCE: HMAC-SHA256 of "<timestamp>.<nonce>.<body>"
+sign() {
+  printf '%s.%s.%s' "$TIMESTAMP" "$NONCE" "$PAYLOAD" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //'
+}
+
+send() {
+  curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
+    -H "Content-Type: application/json" \
+    -H "X-Payment-Signature: $SIGNATURE" \
+    -H "X-Payment-Timestamp: $TIMESTAMP" \
+    -H "X-Payment-Nonce: $NONCE" \
+    -d "$PAYLOAD"
+}
+
 # Test 1: Timestamp 10 minutes in the past (should be rejected)
 echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
 echo "TEST 1: Webhook with old timestamp (10 minutes ago)"
 echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
-OLD_TIMESTAMP=$(($(date +%s) - 600)) # 10 minutes ago
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$OLD_TIMESTAMP,\"transaction_id\":\"txn_old\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(echo -n "$PAYLOAD" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //')
+TIMESTAMP=$(($(date +%s) - 600)) # 10 minutes ago
+NONCE=$(openssl rand -hex 16)
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_old\",\"payment_status\":\"paid\"}"
+SIGNATURE=$(sign)
 
-RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
-  -H "Content-Type: application/json" \
-  -H "X-Payment-Signature: $SIGNATURE" \
-  -d "$PAYLOAD")
+RESPONSE=$(send)
 
-if echo "$RESPONSE" | grep -q "401"; then
+if echo "$RESPONSE" | tail -1 | grep -q "401"; then
   echo "✓ OLD timestamp rejected with 401 (replay attack prevented)"
 else
   echo "✗ Expected 401, got: $RESPONSE"
@@ -42,40 +54,80 @@ fi
 echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
 echo "TEST 2: Webhook with future timestamp (10 minutes ahead)"
 echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
-FUTURE_TIMESTAMP=$(($(date +%s) + 600)) # 10 minutes in future
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$FUTURE_TIMESTAMP,\"transaction_id\":\"txn_future\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(echo -n "$PAYLOAD" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //')
+TIMESTAMP=$(($(date +%s) + 600)) # 10 minutes in future
+NONCE=$(openssl rand -hex 16)
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_future\",\"payment_status\":\"paid\"}"
+SIGNATURE=$(sign)
 
-RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
-  -H "Content-Type: application/json" \
-  -H "X-Payment-Signature: $SIGNATURE" \
-  -d "$PAYLOAD")
+RESPONSE=$(send)
 
-if echo "$RESPONSE" | grep -q "401"; then
+if echo "$RESPONSE" | tail -1 | grep -q "401"; then
   echo "✓ FUTURE timestamp rejected with 401 (clock skew protection)"
 else
   echo "✗ Expected 401, got: $RESPONSE"
 fi
 
-# Test 3: Current timestamp (should be accepted)
+# Test 3: Signature of a current timestamp sent with an old one (should be rejected)
 echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
-echo "TEST 3: Webhook with current timestamp (should succeed)"
+echo "TEST 3: Webhook whose timestamp was changed after signing"
 echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
-CURRENT_TIMESTAMP=$(date +%s)
-PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"timestamp\":$CURRENT_TIMESTAMP,\"transaction_id\":\"txn_current\",\"payment_status\":\"paid\"}"
-SIGNATURE=$(echo -n "$PAYLOAD" | openssl dgst -sha256 -hmac "$WEBHOOK_SECRET" | sed 's/^.* //')
+TIMESTAMP=$(date +%s)
+NONCE=$(openssl rand -hex 16)
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_tampered\",\"payment_status\":\"paid\"}"
+SIGNATURE=$(sign)
+TIMESTAMP=$((TIMESTAMP + 1))
 
-RESPONSE=$(curl -s -w "\n%{http_code}" -X POST "$API_URL/api/payment-webhook" \
-  -H "Content-Type: application/json" \
-  -H "X-Payment-Signature: $SIGNATURE" \
-  -d "$PAYLOAD")
+RESPONSE=$(send)
+
+if echo "$RESPONSE" | tail -1 | grep -q "401"; then
+  echo "✓ Changed timestamp rejected with 401 (timestamp is signed)"
+else
+  echo "✗ Expected 401, got: $RESPONSE"
+fi
 
-if echo "$RESPONSE" | grep -q "200"; then
+# Test 4: Current timestamp (should be accepted)
+echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+echo "TEST 4: Webhook with current timestamp (should succeed)"
+echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+TIMESTAMP=$(date +%s)
+NONCE=$(openssl rand -hex 16)
+PAYLOAD="{\"order_id\":\"$ORDER_ID\",\"transaction_id\":\"txn_current\",\"payment_status\":\"paid\"}"
+SIGNATURE=$(sign)
+
+RESPONSE=$(send)
+
+if echo "$RESPONSE" | tail -1 | grep -q "200"; then
   echo "✓ CURRENT timestamp accepted with 200 (within tolerance)"
 else
   echo "✗ Expected 200, got: $RESPONSE"
 fi
 
+# Test 5: The same request sent again within the window (should be rejected)
+echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+echo "TEST 5: Replay of the accepted webhook with its nonce"
+echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+RESPONSE=$(send)
+
+if echo "$RESPONSE" | tail -1 | grep -q "401" && echo "$RESPONSE" | grep -q "Nonce was already used"; then
+  echo "✓ Replayed nonce rejected with 401 (replay attack prevented)"
+else
+  echo "✗ Expected 401, got: $RESPONSE"
+fi
+
+# Test 6: The captured signature with a new nonce (should be rejected)
+echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+echo "TEST 6: Replay of the accepted webhook with a new nonce"
+echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
+NONCE=$(openssl rand -hex 16)
+
+RESPONSE=$(send)
+
+if echo "$RESPONSE" | tail -1 | grep -q "401"; then
+  echo "✓ Changed nonce rejected with 401 (nonce is signed)"
+else
+  echo "✗ Expected 401, got: $RESPONSE"
+fi
+
 echo -e "\n━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"
 echo "✓ Replay attack prevention tests complete!"
 echo "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━"